@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: journal.proto
+
+package deespecv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JournalService_ListEntries_FullMethodName = "/deespec.v1.JournalService/ListEntries"
+)
+
+// JournalServiceClient is the client API for JournalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JournalService exposes the execution journal.
+type JournalServiceClient interface {
+	// ListEntries returns journal entries, oldest first.
+	ListEntries(ctx context.Context, in *ListJournalEntriesRequest, opts ...grpc.CallOption) (*ListJournalEntriesResponse, error)
+}
+
+type journalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJournalServiceClient(cc grpc.ClientConnInterface) JournalServiceClient {
+	return &journalServiceClient{cc}
+}
+
+func (c *journalServiceClient) ListEntries(ctx context.Context, in *ListJournalEntriesRequest, opts ...grpc.CallOption) (*ListJournalEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJournalEntriesResponse)
+	err := c.cc.Invoke(ctx, JournalService_ListEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JournalServiceServer is the server API for JournalService service.
+// All implementations must embed UnimplementedJournalServiceServer
+// for forward compatibility.
+//
+// JournalService exposes the execution journal.
+type JournalServiceServer interface {
+	// ListEntries returns journal entries, oldest first.
+	ListEntries(context.Context, *ListJournalEntriesRequest) (*ListJournalEntriesResponse, error)
+	mustEmbedUnimplementedJournalServiceServer()
+}
+
+// UnimplementedJournalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJournalServiceServer struct{}
+
+func (UnimplementedJournalServiceServer) ListEntries(context.Context, *ListJournalEntriesRequest) (*ListJournalEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntries not implemented")
+}
+func (UnimplementedJournalServiceServer) mustEmbedUnimplementedJournalServiceServer() {}
+func (UnimplementedJournalServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeJournalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JournalServiceServer will
+// result in compilation errors.
+type UnsafeJournalServiceServer interface {
+	mustEmbedUnimplementedJournalServiceServer()
+}
+
+func RegisterJournalServiceServer(s grpc.ServiceRegistrar, srv JournalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJournalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JournalService_ServiceDesc, srv)
+}
+
+func _JournalService_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJournalEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JournalServiceServer).ListEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JournalService_ListEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JournalServiceServer).ListEntries(ctx, req.(*ListJournalEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JournalService_ServiceDesc is the grpc.ServiceDesc for JournalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JournalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "deespec.v1.JournalService",
+	HandlerType: (*JournalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEntries",
+			Handler:    _JournalService_ListEntries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
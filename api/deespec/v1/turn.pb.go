@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: turn.proto
+
+package deespecv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TurnProgress_Phase int32
+
+const (
+	TurnProgress_PHASE_UNSPECIFIED TurnProgress_Phase = 0
+	TurnProgress_PHASE_STARTED     TurnProgress_Phase = 1
+	TurnProgress_PHASE_COMPLETED   TurnProgress_Phase = 2
+	TurnProgress_PHASE_FAILED      TurnProgress_Phase = 3
+)
+
+// Enum value maps for TurnProgress_Phase.
+var (
+	TurnProgress_Phase_name = map[int32]string{
+		0: "PHASE_UNSPECIFIED",
+		1: "PHASE_STARTED",
+		2: "PHASE_COMPLETED",
+		3: "PHASE_FAILED",
+	}
+	TurnProgress_Phase_value = map[string]int32{
+		"PHASE_UNSPECIFIED": 0,
+		"PHASE_STARTED":     1,
+		"PHASE_COMPLETED":   2,
+		"PHASE_FAILED":      3,
+	}
+)
+
+func (x TurnProgress_Phase) Enum() *TurnProgress_Phase {
+	p := new(TurnProgress_Phase)
+	*p = x
+	return p
+}
+
+func (x TurnProgress_Phase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TurnProgress_Phase) Descriptor() protoreflect.EnumDescriptor {
+	return file_turn_proto_enumTypes[0].Descriptor()
+}
+
+func (TurnProgress_Phase) Type() protoreflect.EnumType {
+	return &file_turn_proto_enumTypes[0]
+}
+
+func (x TurnProgress_Phase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TurnProgress_Phase.Descriptor instead.
+func (TurnProgress_Phase) EnumDescriptor() ([]byte, []int) {
+	return file_turn_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type RunTurnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SbiId         string                 `protobuf:"bytes,1,opt,name=sbi_id,json=sbiId,proto3" json:"sbi_id,omitempty"`     // Empty picks the next executable SBI
+	AutoFb        bool                   `protobuf:"varint,2,opt,name=auto_fb,json=autoFb,proto3" json:"auto_fb,omitempty"` // Register FB drafts automatically
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTurnRequest) Reset() {
+	*x = RunTurnRequest{}
+	mi := &file_turn_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTurnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTurnRequest) ProtoMessage() {}
+
+func (x *RunTurnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_turn_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTurnRequest.ProtoReflect.Descriptor instead.
+func (*RunTurnRequest) Descriptor() ([]byte, []int) {
+	return file_turn_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RunTurnRequest) GetSbiId() string {
+	if x != nil {
+		return x.SbiId
+	}
+	return ""
+}
+
+func (x *RunTurnRequest) GetAutoFb() bool {
+	if x != nil {
+		return x.AutoFb
+	}
+	return false
+}
+
+// TurnProgress is a lifecycle update of a running turn.
+type TurnProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         TurnProgress_Phase     `protobuf:"varint,1,opt,name=phase,proto3,enum=deespec.v1.TurnProgress_Phase" json:"phase,omitempty"`
+	SbiId         string                 `protobuf:"bytes,2,opt,name=sbi_id,json=sbiId,proto3" json:"sbi_id,omitempty"`
+	Turn          int32                  `protobuf:"varint,3,opt,name=turn,proto3" json:"turn,omitempty"`
+	Step          string                 `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnProgress) Reset() {
+	*x = TurnProgress{}
+	mi := &file_turn_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnProgress) ProtoMessage() {}
+
+func (x *TurnProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_turn_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnProgress.ProtoReflect.Descriptor instead.
+func (*TurnProgress) Descriptor() ([]byte, []int) {
+	return file_turn_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TurnProgress) GetPhase() TurnProgress_Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return TurnProgress_PHASE_UNSPECIFIED
+}
+
+func (x *TurnProgress) GetSbiId() string {
+	if x != nil {
+		return x.SbiId
+	}
+	return ""
+}
+
+func (x *TurnProgress) GetTurn() int32 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+func (x *TurnProgress) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *TurnProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TurnProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TurnProgress) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+var File_turn_proto protoreflect.FileDescriptor
+
+const file_turn_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"turn.proto\x12\n" +
+	"deespec.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"@\n" +
+	"\x0eRunTurnRequest\x12\x15\n" +
+	"\x06sbi_id\x18\x01 \x01(\tR\x05sbiId\x12\x17\n" +
+	"\aauto_fb\x18\x02 \x01(\bR\x06autoFb\"\xc9\x02\n" +
+	"\fTurnProgress\x124\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x1e.deespec.v1.TurnProgress.PhaseR\x05phase\x12\x15\n" +
+	"\x06sbi_id\x18\x02 \x01(\tR\x05sbiId\x12\x12\n" +
+	"\x04turn\x18\x03 \x01(\x05R\x04turn\x12\x12\n" +
+	"\x04step\x18\x04 \x01(\tR\x04step\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"X\n" +
+	"\x05Phase\x12\x15\n" +
+	"\x11PHASE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rPHASE_STARTED\x10\x01\x12\x13\n" +
+	"\x0fPHASE_COMPLETED\x10\x02\x12\x10\n" +
+	"\fPHASE_FAILED\x10\x032P\n" +
+	"\vTurnService\x12A\n" +
+	"\aRunTurn\x12\x1a.deespec.v1.RunTurnRequest\x1a\x18.deespec.v1.TurnProgress0\x01B=Z;github.com/YoshitsuguKoike/deespec/api/deespec/v1;deespecv1b\x06proto3"
+
+var (
+	file_turn_proto_rawDescOnce sync.Once
+	file_turn_proto_rawDescData []byte
+)
+
+func file_turn_proto_rawDescGZIP() []byte {
+	file_turn_proto_rawDescOnce.Do(func() {
+		file_turn_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_turn_proto_rawDesc), len(file_turn_proto_rawDesc)))
+	})
+	return file_turn_proto_rawDescData
+}
+
+var file_turn_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_turn_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_turn_proto_goTypes = []any{
+	(TurnProgress_Phase)(0),       // 0: deespec.v1.TurnProgress.Phase
+	(*RunTurnRequest)(nil),        // 1: deespec.v1.RunTurnRequest
+	(*TurnProgress)(nil),          // 2: deespec.v1.TurnProgress
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_turn_proto_depIdxs = []int32{
+	0, // 0: deespec.v1.TurnProgress.phase:type_name -> deespec.v1.TurnProgress.Phase
+	3, // 1: deespec.v1.TurnProgress.timestamp:type_name -> google.protobuf.Timestamp
+	1, // 2: deespec.v1.TurnService.RunTurn:input_type -> deespec.v1.RunTurnRequest
+	2, // 3: deespec.v1.TurnService.RunTurn:output_type -> deespec.v1.TurnProgress
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_turn_proto_init() }
+func file_turn_proto_init() {
+	if File_turn_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_turn_proto_rawDesc), len(file_turn_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_turn_proto_goTypes,
+		DependencyIndexes: file_turn_proto_depIdxs,
+		EnumInfos:         file_turn_proto_enumTypes,
+		MessageInfos:      file_turn_proto_msgTypes,
+	}.Build()
+	File_turn_proto = out.File
+	file_turn_proto_goTypes = nil
+	file_turn_proto_depIdxs = nil
+}
@@ -0,0 +1,130 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: turn.proto
+
+package deespecv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TurnService_RunTurn_FullMethodName = "/deespec.v1.TurnService/RunTurn"
+)
+
+// TurnServiceClient is the client API for TurnService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TurnService drives SBI turns.
+type TurnServiceClient interface {
+	// RunTurn executes one turn and streams its progress until it finishes.
+	RunTurn(ctx context.Context, in *RunTurnRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TurnProgress], error)
+}
+
+type turnServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTurnServiceClient(cc grpc.ClientConnInterface) TurnServiceClient {
+	return &turnServiceClient{cc}
+}
+
+func (c *turnServiceClient) RunTurn(ctx context.Context, in *RunTurnRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TurnProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TurnService_ServiceDesc.Streams[0], TurnService_RunTurn_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RunTurnRequest, TurnProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TurnService_RunTurnClient = grpc.ServerStreamingClient[TurnProgress]
+
+// TurnServiceServer is the server API for TurnService service.
+// All implementations must embed UnimplementedTurnServiceServer
+// for forward compatibility.
+//
+// TurnService drives SBI turns.
+type TurnServiceServer interface {
+	// RunTurn executes one turn and streams its progress until it finishes.
+	RunTurn(*RunTurnRequest, grpc.ServerStreamingServer[TurnProgress]) error
+	mustEmbedUnimplementedTurnServiceServer()
+}
+
+// UnimplementedTurnServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTurnServiceServer struct{}
+
+func (UnimplementedTurnServiceServer) RunTurn(*RunTurnRequest, grpc.ServerStreamingServer[TurnProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method RunTurn not implemented")
+}
+func (UnimplementedTurnServiceServer) mustEmbedUnimplementedTurnServiceServer() {}
+func (UnimplementedTurnServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeTurnServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TurnServiceServer will
+// result in compilation errors.
+type UnsafeTurnServiceServer interface {
+	mustEmbedUnimplementedTurnServiceServer()
+}
+
+func RegisterTurnServiceServer(s grpc.ServiceRegistrar, srv TurnServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTurnServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TurnService_ServiceDesc, srv)
+}
+
+func _TurnService_RunTurn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunTurnRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TurnServiceServer).RunTurn(m, &grpc.GenericServerStream[RunTurnRequest, TurnProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TurnService_RunTurnServer = grpc.ServerStreamingServer[TurnProgress]
+
+// TurnService_ServiceDesc is the grpc.ServiceDesc for TurnService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TurnService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "deespec.v1.TurnService",
+	HandlerType: (*TurnServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunTurn",
+			Handler:       _TurnService_RunTurn_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "turn.proto",
+}
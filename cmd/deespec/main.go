@@ -8,6 +8,7 @@ import (
 
 func main() {
 	if err := cli.NewRoot().Execute(); err != nil {
-		os.Exit(1)
+		// The exit status tells the kind of error (see cli.ExitCode); plugins exit with their own
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -0,0 +1,156 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	deespecv1 "github.com/YoshitsuguKoike/deespec/api/deespec/v1"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// routeScopes maps each RPC to the token scope it requires; unlisted RPCs require admin
+var routeScopes = map[string]string{
+	deespecv1.TaskService_ListTasks_FullMethodName:      repository.APIScopeRead,
+	deespecv1.TaskService_GetTask_FullMethodName:        repository.APIScopeRead,
+	deespecv1.JournalService_ListEntries_FullMethodName: repository.APIScopeRead,
+	deespecv1.LockService_ListLocks_FullMethodName:      repository.APIScopeRead,
+	deespecv1.TurnService_RunTurn_FullMethodName:        repository.APIScopeRunTurn,
+	deespecv1.LockService_ReleaseLock_FullMethodName:    repository.APIScopeAdmin,
+}
+
+// stateChangingRoutes are the RPCs recorded in the audit log on every call
+var stateChangingRoutes = map[string]bool{
+	deespecv1.TurnService_RunTurn_FullMethodName:     true,
+	deespecv1.LockService_ReleaseLock_FullMethodName: true,
+}
+
+// AuthServerOptions returns the interceptors that require a bearer token with the
+// route's scope on every RPC and audit state-changing RPCs
+// Clients send the token as "authorization: Bearer <token>" metadata.
+func AuthServerOptions(authorizer *service.APIAuthorizer) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryAuthInterceptor(authorizer)),
+		grpc.ChainStreamInterceptor(streamAuthInterceptor(authorizer)),
+	}
+}
+
+func unaryAuthInterceptor(authorizer *service.APIAuthorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, err := authorizer.Authorize(ctx, bearerToken(ctx), info.FullMethod, requiredScope(info.FullMethod))
+		if err != nil {
+			return nil, authStatus(err)
+		}
+
+		resp, err := handler(service.WithAPIToken(ctx, token), req)
+		if stateChangingRoutes[info.FullMethod] {
+			auditCall(ctx, authorizer, token, info.FullMethod, requestTarget(req), err)
+		}
+		return resp, err
+	}
+}
+
+func streamAuthInterceptor(authorizer *service.APIAuthorizer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		token, err := authorizer.Authorize(ctx, bearerToken(ctx), info.FullMethod, requiredScope(info.FullMethod))
+		if err != nil {
+			return authStatus(err)
+		}
+
+		wrapped := &authServerStream{ServerStream: ss, ctx: service.WithAPIToken(ctx, token)}
+		err = handler(srv, wrapped)
+		if stateChangingRoutes[info.FullMethod] {
+			auditCall(ctx, authorizer, token, info.FullMethod, wrapped.target, err)
+		}
+		return err
+	}
+}
+
+// authServerStream carries the authenticated token and records the SBI or lock the stream acts on
+// The target is taken from the request and updated from responses, so a turn run without
+// an SBI ID is audited with the SBI that was picked
+type authServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	target string
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if target := requestTarget(m); target != "" {
+		s.target = target
+	}
+	return err
+}
+
+func (s *authServerStream) SendMsg(m interface{}) error {
+	if target := requestTarget(m); target != "" {
+		s.target = target
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// requiredScope returns the scope an RPC requires
+func requiredScope(method string) string {
+	if scope, ok := routeScopes[method]; ok {
+		return scope
+	}
+	return repository.APIScopeAdmin
+}
+
+// bearerToken returns the token of the "authorization: Bearer <token>" metadata
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return token
+		}
+	}
+	return ""
+}
+
+// requestTarget returns the SBI or lock a request or response refers to
+func requestTarget(m interface{}) string {
+	if msg, ok := m.(interface{ GetSbiId() string }); ok && msg.GetSbiId() != "" {
+		return msg.GetSbiId()
+	}
+	if msg, ok := m.(interface{ GetLockId() string }); ok {
+		return msg.GetLockId()
+	}
+	return ""
+}
+
+// auditCall records the outcome of a state-changing RPC
+func auditCall(ctx context.Context, authorizer *service.APIAuthorizer, token *repository.APIToken, route, target string, err error) {
+	outcome, detail := service.APIAuditOutcomeOK, ""
+	if err != nil {
+		outcome, detail = service.APIAuditOutcomeError, err.Error()
+	}
+	authorizer.Audit(ctx, token.Name, route, target, outcome, detail)
+}
+
+// authStatus maps authorization errors to gRPC status errors
+func authStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrAPIUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrAPIPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
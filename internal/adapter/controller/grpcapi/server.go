@@ -0,0 +1,382 @@
+// Package grpcapi exposes deespec over gRPC for programmatic orchestration.
+// The service definitions live in api/deespec/v1 so external Go programs can
+// import the generated client stubs.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	deespecv1 "github.com/YoshitsuguKoike/deespec/api/deespec/v1"
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/input"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// TurnRunner executes one turn of an SBI; an empty sbiID picks the next executable SBI
+// It returns the ID of the SBI that ran together with the turn result.
+type TurnRunner func(ctx context.Context, sbiID string, autoFB bool) (string, *dto.RunTurnOutput, error)
+
+// Dependencies holds the services backing the gRPC API
+type Dependencies struct {
+	TaskUseCase input.TaskUseCase
+	SBIRepo     repository.SBIRepository
+	JournalRepo repository.JournalRepository
+	LockService service.LockService
+	RunTurn     TurnRunner
+}
+
+// Register registers all deespec services on the gRPC server
+func Register(s *grpc.Server, deps Dependencies) {
+	deespecv1.RegisterTaskServiceServer(s, &TaskServer{taskUseCase: deps.TaskUseCase})
+	deespecv1.RegisterTurnServiceServer(s, &TurnServer{sbiRepo: deps.SBIRepo, runTurn: deps.RunTurn})
+	deespecv1.RegisterJournalServiceServer(s, &JournalServer{journalRepo: deps.JournalRepo})
+	deespecv1.RegisterLockServiceServer(s, &LockServer{lockService: deps.LockService})
+}
+
+// TaskServer implements deespec.v1.TaskService
+type TaskServer struct {
+	deespecv1.UnimplementedTaskServiceServer
+	taskUseCase input.TaskUseCase
+}
+
+// ListTasks lists tasks with optional filters
+func (s *TaskServer) ListTasks(ctx context.Context, req *deespecv1.ListTasksRequest) (*deespecv1.ListTasksResponse, error) {
+	listReq := dto.ListTasksRequest{
+		Types:    req.GetTypes(),
+		Statuses: req.GetStatuses(),
+		Limit:    int(req.GetLimit()),
+		Offset:   int(req.GetOffset()),
+	}
+	if parentID := req.GetParentId(); parentID != "" {
+		listReq.ParentID = &parentID
+	}
+	if listReq.Limit <= 0 {
+		listReq.Limit = 50
+	}
+
+	result, err := s.taskUseCase.ListTasks(ctx, listReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &deespecv1.ListTasksResponse{TotalCount: int32(result.TotalCount)}
+	for i := range result.Tasks {
+		resp.Tasks = append(resp.Tasks, taskToProto(&result.Tasks[i]))
+	}
+	return resp, nil
+}
+
+// GetTask returns a task; SBIs include their execution state
+func (s *TaskServer) GetTask(ctx context.Context, req *deespecv1.GetTaskRequest) (*deespecv1.Task, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	task, err := s.taskUseCase.GetTask(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if task.Type != "SBI" {
+		return taskToProto(task), nil
+	}
+
+	sbiDTO, err := s.taskUseCase.GetSBI(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return sbiToProto(sbiDTO), nil
+}
+
+// TurnServer implements deespec.v1.TurnService
+type TurnServer struct {
+	deespecv1.UnimplementedTurnServiceServer
+	sbiRepo repository.SBIRepository
+	runTurn TurnRunner
+}
+
+// RunTurn executes one turn and streams STARTED followed by COMPLETED or FAILED
+func (s *TurnServer) RunTurn(req *deespecv1.RunTurnRequest, stream grpc.ServerStreamingServer[deespecv1.TurnProgress]) error {
+	if s.runTurn == nil {
+		return status.Error(codes.Unimplemented, "turn execution is not available")
+	}
+	ctx := stream.Context()
+
+	started := &deespecv1.TurnProgress{
+		Phase:     deespecv1.TurnProgress_PHASE_STARTED,
+		SbiId:     req.GetSbiId(),
+		Timestamp: timestamppb.Now(),
+	}
+	if req.GetSbiId() != "" {
+		current, err := s.sbiRepo.Find(ctx, repository.SBIID(req.GetSbiId()))
+		if err != nil {
+			return toStatus(err)
+		}
+		started.Turn = int32(current.ExecutionState().CurrentTurn.Value())
+		started.Step = current.CurrentStep().String()
+		started.Status = current.Status().String()
+	}
+	if err := stream.Send(started); err != nil {
+		return err
+	}
+
+	sbiID, output, err := s.runTurn(ctx, req.GetSbiId(), req.GetAutoFb())
+	if err != nil {
+		failed := &deespecv1.TurnProgress{
+			Phase:     deespecv1.TurnProgress_PHASE_FAILED,
+			SbiId:     sbiID,
+			Message:   err.Error(),
+			Timestamp: timestamppb.Now(),
+		}
+		if sendErr := stream.Send(failed); sendErr != nil {
+			return sendErr
+		}
+		return toStatus(err)
+	}
+
+	completed := &deespecv1.TurnProgress{
+		Phase:     deespecv1.TurnProgress_PHASE_COMPLETED,
+		SbiId:     sbiID,
+		Timestamp: timestamppb.Now(),
+	}
+	if output != nil {
+		completed.Turn = int32(output.Turn)
+		completed.Step = output.NextStep
+		completed.Status = output.NextStatus
+		completed.Message = turnMessage(output)
+	}
+	return stream.Send(completed)
+}
+
+// turnMessage summarizes a turn result for progress updates
+func turnMessage(output *dto.RunTurnOutput) string {
+	switch {
+	case output.NoOp:
+		return fmt.Sprintf("no-op (%s)", output.NoOpReason)
+	case output.ErrorMsg != "":
+		return output.ErrorMsg
+	case output.Decision != "":
+		return fmt.Sprintf("%s -> %s (decision: %s)", output.PrevStatus, output.NextStatus, output.Decision)
+	default:
+		return fmt.Sprintf("%s -> %s", output.PrevStatus, output.NextStatus)
+	}
+}
+
+// JournalServer implements deespec.v1.JournalService
+type JournalServer struct {
+	deespecv1.UnimplementedJournalServiceServer
+	journalRepo repository.JournalRepository
+}
+
+// ListEntries returns journal entries, optionally filtered by SBI and limited to the last N
+func (s *JournalServer) ListEntries(ctx context.Context, req *deespecv1.ListJournalEntriesRequest) (*deespecv1.ListJournalEntriesResponse, error) {
+	var records []*repository.JournalRecord
+	var err error
+	if req.GetSbiId() != "" {
+		records, err = s.journalRepo.FindBySBI(ctx, req.GetSbiId())
+	} else {
+		records, err = s.journalRepo.Load(ctx)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if limit := int(req.GetLimit()); limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+
+	resp := &deespecv1.ListJournalEntriesResponse{}
+	for _, record := range records {
+		resp.Entries = append(resp.Entries, journalRecordToProto(record))
+	}
+	return resp, nil
+}
+
+// LockServer implements deespec.v1.LockService
+type LockServer struct {
+	deespecv1.UnimplementedLockServiceServer
+	lockService service.LockService
+}
+
+// ListLocks returns all run and state locks
+func (s *LockServer) ListLocks(ctx context.Context, _ *deespecv1.ListLocksRequest) (*deespecv1.ListLocksResponse, error) {
+	runLocks, err := s.lockService.ListRunLocks(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	stateLocks, err := s.lockService.ListStateLocks(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &deespecv1.ListLocksResponse{}
+	for _, l := range runLocks {
+		resp.Locks = append(resp.Locks, &deespecv1.Lock{
+			LockId:       l.LockID().String(),
+			Kind:         "run",
+			Pid:          int32(l.PID()),
+			Hostname:     l.Hostname(),
+			AcquiredAt:   timestamppb.New(l.AcquiredAt()),
+			ExpiresAt:    timestamppb.New(l.ExpiresAt()),
+			HeartbeatAt:  timestamppb.New(l.HeartbeatAt()),
+			FencingToken: l.FencingToken(),
+		})
+	}
+	for _, l := range stateLocks {
+		resp.Locks = append(resp.Locks, &deespecv1.Lock{
+			LockId:       l.LockID().String(),
+			Kind:         "state",
+			LockType:     string(l.LockType()),
+			Pid:          int32(l.PID()),
+			Hostname:     l.Hostname(),
+			AcquiredAt:   timestamppb.New(l.AcquiredAt()),
+			ExpiresAt:    timestamppb.New(l.ExpiresAt()),
+			HeartbeatAt:  timestamppb.New(l.HeartbeatAt()),
+			FencingToken: l.FencingToken(),
+		})
+	}
+	return resp, nil
+}
+
+// ReleaseLock force-releases a run or state lock
+func (s *LockServer) ReleaseLock(ctx context.Context, req *deespecv1.ReleaseLockRequest) (*deespecv1.ReleaseLockResponse, error) {
+	lockID, err := lock.NewLockID(req.GetLockId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch req.GetKind() {
+	case "run":
+		err = s.lockService.ReleaseRunLock(ctx, lockID)
+	case "state":
+		err = s.lockService.ReleaseStateLock(ctx, lockID)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "kind must be \"run\" or \"state\", got %q", req.GetKind())
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &deespecv1.ReleaseLockResponse{}, nil
+}
+
+// errorInfoDomain is the domain of the ErrorInfo details attached to status errors
+const errorInfoDomain = "deespec"
+
+// toStatus maps application errors to gRPC status errors
+// Errors of a known kind carry an ErrorInfo detail whose reason is the error code
+// (model.ErrorCode), the same code the CLI maps to its exit status.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var code codes.Code
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, lock.ErrStaleFencingToken):
+		code = codes.Aborted
+	case errors.Is(err, model.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, model.ErrInvalidState), errors.Is(err, model.ErrLockHeld):
+		code = codes.FailedPrecondition
+	case errors.Is(err, model.ErrAgentUnavailable):
+		code = codes.Unavailable
+	case strings.Contains(strings.ToLower(err.Error()), "not found"):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	st := status.New(code, err.Error())
+	if reason := model.ErrorCode(err); reason != model.ErrorCodeInternal {
+		if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorInfoDomain}); detailErr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// taskToProto converts a task DTO to its protobuf representation
+func taskToProto(task *dto.TaskDTO) *deespecv1.Task {
+	pb := &deespecv1.Task{
+		Id:          task.ID,
+		Type:        task.Type,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		CurrentStep: task.CurrentStep,
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		UpdatedAt:   timestamppb.New(task.UpdatedAt),
+	}
+	if task.ParentID != nil {
+		pb.ParentId = *task.ParentID
+	}
+	return pb
+}
+
+// sbiToProto converts an SBI DTO including its execution state
+func sbiToProto(sbiDTO *dto.SBIDTO) *deespecv1.Task {
+	pb := taskToProto(&sbiDTO.TaskDTO)
+	pb.Priority = int32(sbiDTO.Priority)
+	pb.CurrentTurn = int32(sbiDTO.CurrentTurn)
+	pb.MaxTurns = int32(sbiDTO.MaxTurns)
+	pb.CurrentAttempt = int32(sbiDTO.CurrentAttempt)
+	pb.MaxAttempts = int32(sbiDTO.MaxAttempts)
+	pb.Labels = sbiDTO.Labels
+	pb.AssignedAgent = sbiDTO.AssignedAgent
+	pb.FilePaths = sbiDTO.FilePaths
+	pb.LastError = sbiDTO.LastError
+	pb.StartedAt = optionalTimestamp(sbiDTO.StartedAt)
+	pb.CompletedAt = optionalTimestamp(sbiDTO.CompletedAt)
+	return pb
+}
+
+// journalRecordToProto converts a journal record to its protobuf representation
+func journalRecordToProto(record *repository.JournalRecord) *deespecv1.JournalEntry {
+	entry := &deespecv1.JournalEntry{
+		SbiId:     record.SBIID,
+		Turn:      int32(record.Turn),
+		Step:      record.Step,
+		Status:    record.Status,
+		Attempt:   int32(record.Attempt),
+		Decision:  record.Decision,
+		ElapsedMs: record.ElapsedMs,
+		Error:     record.Error,
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, record.Timestamp); err == nil {
+		entry.Timestamp = timestamppb.New(ts)
+	}
+	for _, artifact := range record.Artifacts {
+		switch a := artifact.(type) {
+		case string:
+			entry.Artifacts = append(entry.Artifacts, a)
+		case map[string]interface{}:
+			if path, ok := a["path"].(string); ok {
+				entry.Artifacts = append(entry.Artifacts, path)
+			}
+		}
+	}
+	return entry
+}
+
+// optionalTimestamp converts a nullable time
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
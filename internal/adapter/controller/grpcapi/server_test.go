@@ -0,0 +1,208 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	deespecv1 "github.com/YoshitsuguKoike/deespec/api/deespec/v1"
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/input"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// fakeTaskUseCase implements the TaskUseCase methods used by TaskServer
+type fakeTaskUseCase struct {
+	input.TaskUseCase
+	tasks []dto.TaskDTO
+}
+
+func (f *fakeTaskUseCase) ListTasks(ctx context.Context, req dto.ListTasksRequest) (*dto.ListTasksResponse, error) {
+	return &dto.ListTasksResponse{Tasks: f.tasks, TotalCount: len(f.tasks), Limit: req.Limit}, nil
+}
+
+func (f *fakeTaskUseCase) GetTask(ctx context.Context, taskID string) (*dto.TaskDTO, error) {
+	for i := range f.tasks {
+		if f.tasks[i].ID == taskID {
+			return &f.tasks[i], nil
+		}
+	}
+	return nil, errors.New("task not found")
+}
+
+func (f *fakeTaskUseCase) GetSBI(ctx context.Context, sbiID string) (*dto.SBIDTO, error) {
+	task, err := f.GetTask(ctx, sbiID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SBIDTO{TaskDTO: *task, CurrentTurn: 2, MaxTurns: 8}, nil
+}
+
+// fakeJournalRepo returns fixed records
+type fakeJournalRepo struct {
+	repository.JournalRepository
+	records []*repository.JournalRecord
+}
+
+func (f *fakeJournalRepo) Load(ctx context.Context) ([]*repository.JournalRecord, error) {
+	return f.records, nil
+}
+
+func newTestClient(t *testing.T, deps Dependencies) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	Register(server, deps)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestTaskService(t *testing.T) {
+	conn := newTestClient(t, Dependencies{TaskUseCase: &fakeTaskUseCase{tasks: []dto.TaskDTO{
+		{ID: "SBI-1", Type: "SBI", Title: "First", Status: "PENDING", CreatedAt: time.Now()},
+		{ID: "PBI-1", Type: "PBI", Title: "Feature", Status: "PENDING"},
+	}}})
+	client := deespecv1.NewTaskServiceClient(conn)
+	ctx := context.Background()
+
+	list, err := client.ListTasks(ctx, &deespecv1.ListTasksRequest{Types: []string{"SBI"}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), list.GetTotalCount())
+	assert.Equal(t, "First", list.GetTasks()[0].GetTitle())
+
+	task, err := client.GetTask(ctx, &deespecv1.GetTaskRequest{Id: "SBI-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), task.GetCurrentTurn(), "SBIs include execution state")
+
+	_, err = client.GetTask(ctx, &deespecv1.GetTaskRequest{Id: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestTurnService_StreamsProgress(t *testing.T) {
+	runErr := error(nil)
+	conn := newTestClient(t, Dependencies{
+		RunTurn: func(ctx context.Context, sbiID string, autoFB bool) (string, *dto.RunTurnOutput, error) {
+			if runErr != nil {
+				return "SBI-1", nil, runErr
+			}
+			return "SBI-1", &dto.RunTurnOutput{Turn: 3, PrevStatus: "PENDING", NextStatus: "IMPLEMENTING", NextStep: "implement"}, nil
+		},
+	})
+	client := deespecv1.NewTurnServiceClient(conn)
+
+	collect := func() ([]*deespecv1.TurnProgress, error) {
+		stream, err := client.RunTurn(context.Background(), &deespecv1.RunTurnRequest{})
+		require.NoError(t, err)
+		var updates []*deespecv1.TurnProgress
+		for {
+			update, err := stream.Recv()
+			if err == io.EOF {
+				return updates, nil
+			}
+			if err != nil {
+				return updates, err
+			}
+			updates = append(updates, update)
+		}
+	}
+
+	updates, err := collect()
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	assert.Equal(t, deespecv1.TurnProgress_PHASE_STARTED, updates[0].GetPhase())
+	assert.Equal(t, deespecv1.TurnProgress_PHASE_COMPLETED, updates[1].GetPhase())
+	assert.Equal(t, "SBI-1", updates[1].GetSbiId())
+	assert.Equal(t, int32(3), updates[1].GetTurn())
+	assert.Equal(t, "IMPLEMENTING", updates[1].GetStatus())
+
+	runErr = status.Error(codes.NotFound, "no executable SBI found")
+	updates, err = collect()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	require.Len(t, updates, 2)
+	assert.Equal(t, deespecv1.TurnProgress_PHASE_FAILED, updates[1].GetPhase())
+}
+
+func TestJournalService_ListEntries(t *testing.T) {
+	conn := newTestClient(t, Dependencies{JournalRepo: &fakeJournalRepo{records: []*repository.JournalRecord{
+		{Timestamp: "2025-01-01T00:00:00Z", SBIID: "SBI-1", Turn: 1, Step: "implement"},
+		{Timestamp: "2025-01-01T00:01:00Z", SBIID: "SBI-1", Turn: 2, Step: "review", Decision: "SUCCEEDED",
+			Artifacts: []interface{}{"a.md", map[string]interface{}{"path": "b.md"}}},
+	}}})
+	client := deespecv1.NewJournalServiceClient(conn)
+
+	resp, err := client.ListEntries(context.Background(), &deespecv1.ListJournalEntriesRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEntries(), 1)
+	entry := resp.GetEntries()[0]
+	assert.Equal(t, "review", entry.GetStep())
+	assert.Equal(t, "SUCCEEDED", entry.GetDecision())
+	assert.Equal(t, []string{"a.md", "b.md"}, entry.GetArtifacts())
+	assert.Equal(t, int64(1735689660), entry.GetTimestamp().GetSeconds())
+}
+
+func TestLockService_ReleaseLockValidatesKind(t *testing.T) {
+	conn := newTestClient(t, Dependencies{})
+	client := deespecv1.NewLockServiceClient(conn)
+
+	_, err := client.ReleaseLock(context.Background(), &deespecv1.ReleaseLockRequest{LockId: "sbi-1", Kind: "other"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTurnService_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{fmt.Errorf("SBI %w: SBI-9", model.ErrNotFound), codes.NotFound, model.ErrorCodeNotFound},
+		{fmt.Errorf("%w: SBI SBI-1 is already being executed by another worker", model.ErrLockHeld), codes.FailedPrecondition, model.ErrorCodeLockHeld},
+		{fmt.Errorf("turn: %w", fmt.Errorf("%w: claude CLI execution failed", model.ErrAgentUnavailable)), codes.Unavailable, model.ErrorCodeAgentUnavailable},
+		{errors.New("disk I/O error"), codes.Internal, ""},
+	}
+	for _, tt := range tests {
+		conn := newTestClient(t, Dependencies{
+			RunTurn: func(ctx context.Context, sbiID string, autoFB bool) (string, *dto.RunTurnOutput, error) {
+				return "SBI-1", nil, tt.err
+			},
+		})
+		stream, err := deespecv1.NewTurnServiceClient(conn).RunTurn(context.Background(), &deespecv1.RunTurnRequest{})
+		require.NoError(t, err)
+		for err == nil {
+			_, err = stream.Recv()
+		}
+
+		st := status.Convert(err)
+		assert.Equal(t, tt.wantCode, st.Code(), tt.err.Error())
+		assert.Equal(t, tt.err.Error(), st.Message())
+		var reason string
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok {
+				reason = info.GetReason()
+				assert.Equal(t, "deespec", info.GetDomain())
+			}
+		}
+		assert.Equal(t, tt.wantReason, reason, tt.err.Error())
+	}
+}
@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// RequireScope wraps next so that requests need a token granting scope
+// The token is read from "Authorization: Bearer <token>" or, for browser
+// EventSource clients that cannot set headers, the access_token query parameter.
+func RequireScope(authorizer *service.APIAuthorizer, scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if secret == "" {
+			secret = r.URL.Query().Get("access_token")
+		}
+
+		token, err := authorizer.Authorize(r.Context(), secret, r.URL.Path, scope)
+		switch {
+		case errors.Is(err, service.ErrAPIUnauthenticated):
+			w.Header().Set("WWW-Authenticate", `Bearer realm="deespec"`)
+			writeErrorResponse(w, http.StatusUnauthorized, errorCodeUnauthenticated, err.Error())
+			return
+		case errors.Is(err, service.ErrAPIPermissionDenied):
+			writeErrorResponse(w, http.StatusForbidden, errorCodePermissionDenied, err.Error())
+			return
+		case err != nil:
+			writeError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(service.WithAPIToken(r.Context(), token)))
+	})
+}
@@ -0,0 +1,53 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// Error codes of HTTP errors raised before any application code runs
+const (
+	errorCodeUnauthenticated  = "unauthenticated"
+	errorCodePermissionDenied = "permission_denied"
+	errorCodeMethodNotAllowed = "method_not_allowed"
+)
+
+// ErrorResponse is the JSON body of every HTTP error response:
+//
+//	{"error": {"code": "not_found", "message": "SBI not found: SBI-1"}}
+//
+// The codes of application errors are those of model.ErrorCode.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes an error
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorStatuses maps error codes to HTTP statuses
+var errorStatuses = map[string]int{
+	model.ErrorCodeNotFound:         http.StatusNotFound,
+	model.ErrorCodeInvalidState:     http.StatusConflict,
+	model.ErrorCodeLockHeld:         http.StatusConflict,
+	model.ErrorCodeAgentUnavailable: http.StatusServiceUnavailable,
+	model.ErrorCodeInternal:         http.StatusInternalServerError,
+}
+
+// writeError responds to an application error with the status of its kind
+func writeError(w http.ResponseWriter, err error) {
+	code := model.ErrorCode(err)
+	writeErrorResponse(w, errorStatuses[code], code, err.Error())
+}
+
+// writeErrorResponse responds with status and a JSON error body
+func writeErrorResponse(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: ErrorBody{Code: code, Message: message}})
+}
@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// decodeError reads the JSON error body of a recorded response
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) ErrorBody {
+	t.Helper()
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Error
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{fmt.Errorf("SBI %w: SBI-1", model.ErrNotFound), http.StatusNotFound, "not_found"},
+		{fmt.Errorf("%w: SBI SBI-1 is already DONE", model.ErrInvalidState), http.StatusConflict, "invalid_state"},
+		{fmt.Errorf("%w: another instance is already running", model.ErrLockHeld), http.StatusConflict, "lock_held"},
+		{fmt.Errorf("%w: ollama is not reachable", model.ErrAgentUnavailable), http.StatusServiceUnavailable, "agent_unavailable"},
+		{errors.New("disk I/O error"), http.StatusInternalServerError, "internal"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		writeError(rec, tt.err)
+		assert.Equal(t, tt.wantStatus, rec.Code, tt.err.Error())
+		assert.Equal(t, ErrorBody{Code: tt.wantCode, Message: tt.err.Error()}, decodeError(t, rec))
+	}
+}
+
+func TestMux_ErrorsAreJSON(t *testing.T) {
+	mux := NewMux(service.NewTurnEventBroker(), nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, EventsPath+"-archive", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ErrorBody{Code: "not_found", Message: "no route for " + EventsPath + "-archive"}, decodeError(t, rec))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, EventsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "method_not_allowed", decodeError(t, rec).Code)
+}
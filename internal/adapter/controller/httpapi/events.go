@@ -0,0 +1,110 @@
+// Package httpapi exposes deespec over plain HTTP for web UIs
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// EventsPath is the route of the turn event stream
+const EventsPath = "/events"
+
+// eventBuffer is the number of events buffered per client before events are dropped
+const eventBuffer = 256
+
+// keepAliveInterval is how often an idle stream sends a comment so proxies keep it open
+const keepAliveInterval = 15 * time.Second
+
+// EventSource is the subset of the turn event broker used by the events stream
+type EventSource interface {
+	Subscribe(buffer int) (<-chan output.TurnEvent, func())
+}
+
+// EventsHandler streams turn lifecycle events as Server-Sent Events
+// Each event is sent as "event: <type>" with the JSON encoded TurnEvent as data.
+// The optional sbi_id query parameter limits the stream to a single SBI.
+type EventsHandler struct {
+	source    EventSource
+	keepAlive time.Duration
+}
+
+// NewEventsHandler creates an SSE handler for the given event source
+func NewEventsHandler(source EventSource) *EventsHandler {
+	return &EventsHandler{source: source, keepAlive: keepAliveInterval}
+}
+
+// ServeHTTP streams events until the client disconnects
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, errorCodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorResponse(w, http.StatusInternalServerError, model.ErrorCodeInternal, "streaming unsupported")
+		return
+	}
+
+	sbiID := r.URL.Query().Get("sbi_id")
+	events, unsubscribe := h.source.Subscribe(eventBuffer)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if sbiID != "" && event.SBIID != sbiID {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// NewMux returns the HTTP routes served by `deespec serve`
+// With an authorizer every route requires a token with the read scope; nil serves them unauthenticated
+func NewMux(source EventSource, authorizer *service.APIAuthorizer) *http.ServeMux {
+	var events http.Handler = NewEventsHandler(source)
+	if authorizer != nil {
+		events = RequireScope(authorizer, repository.APIScopeRead, events)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(EventsPath, events)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(w, http.StatusNotFound, model.ErrorCodeNotFound, "no route for "+r.URL.Path)
+	})
+	return mux
+}
@@ -0,0 +1,56 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+func TestEventsHandler_StreamsFilteredEvents(t *testing.T) {
+	broker := service.NewTurnEventBroker()
+	server := httptest.NewServer(NewMux(broker, nil))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+EventsPath+"?sbi_id=sbi-1", nil)
+	require.NoError(t, err)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Headers are flushed after subscribing, so nothing published from here is missed
+	broker.Publish(output.TurnEvent{Type: output.TurnEventPicked, SBIID: "sbi-2", Turn: 1})
+	broker.Publish(output.TurnEvent{Type: output.TurnEventAgentOutputChunk, SBIID: "sbi-1", Turn: 3, Step: "implement",
+		Data: map[string]string{"chunk": "editing main.go"}})
+
+	reader := bufio.NewReader(resp.Body)
+	eventLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: agent-output-chunk\n", eventLine)
+
+	dataLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	var event output.TurnEvent
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(dataLine, "data: ")), &event))
+	assert.Equal(t, "sbi-1", event.SBIID)
+	assert.Equal(t, 3, event.Turn)
+	assert.Equal(t, "editing main.go", event.Data["chunk"])
+}
+
+func TestEventsHandler_RejectsNonGET(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewEventsHandler(service.NewTurnEventBroker()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, EventsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
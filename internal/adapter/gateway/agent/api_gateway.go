@@ -0,0 +1,400 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// HTTP API providers
+const (
+	APIProviderAnthropic = "anthropic"
+	APIProviderOpenAI    = "openai"
+	APIProviderGemini    = "gemini"
+)
+
+// APIProviders lists the HTTP API providers
+var APIProviders = []string{APIProviderAnthropic, APIProviderOpenAI, APIProviderGemini}
+
+const (
+	defaultAPIMaxRetries   = 3
+	defaultAPIRetryBackoff = time.Second
+	maxAPIRetryBackoff     = 30 * time.Second
+
+	// maxAPIErrorBody bounds how much of an error response is read for its message
+	maxAPIErrorBody = 64 * 1024
+	// maxAPIEventSize bounds a single server-sent event line
+	maxAPIEventSize = 4 * 1024 * 1024
+)
+
+// APIAgentType returns the agent type of an HTTP API provider (e.g. "anthropic-api")
+func APIAgentType(provider string) string {
+	return provider + "-api"
+}
+
+// APIProviderConfig configures an HTTP API provider
+// Zero values fall back to the provider defaults (see DefaultAPIProviderConfig), except
+// MaxRetries where 0 disables retries and a negative value keeps the default.
+type APIProviderConfig struct {
+	BaseURL    string        // API base URL, e.g. for a proxy or gateway
+	APIKeyEnv  string        // Environment variable holding the API key
+	Model      string        // Model used when the request does not override it
+	MaxTokens  int           // Output token limit used when the request does not set one
+	MaxRetries int           // Retries on rate limits, server errors and connection failures
+	Timeout    time.Duration // Timeout of a single API call (0: only the request timeout applies)
+}
+
+// DefaultAPIProviderConfig returns the defaults of an HTTP API provider
+func DefaultAPIProviderConfig(provider string) APIProviderConfig {
+	cfg := APIProviderConfig{MaxTokens: 8192, MaxRetries: defaultAPIMaxRetries}
+	switch provider {
+	case APIProviderAnthropic:
+		cfg.BaseURL = "https://api.anthropic.com"
+		cfg.APIKeyEnv = "ANTHROPIC_API_KEY"
+		cfg.Model = "claude-sonnet-4-5"
+	case APIProviderOpenAI:
+		cfg.BaseURL = "https://api.openai.com"
+		cfg.APIKeyEnv = "OPENAI_API_KEY"
+		cfg.Model = "gpt-4.1"
+	case APIProviderGemini:
+		cfg.BaseURL = "https://generativelanguage.googleapis.com"
+		cfg.APIKeyEnv = "GEMINI_API_KEY"
+		cfg.Model = "gemini-2.5-pro"
+	}
+	return cfg
+}
+
+// merge returns the defaults overridden by the set fields of cfg
+func (cfg APIProviderConfig) merge(defaults APIProviderConfig) APIProviderConfig {
+	merged := defaults
+	if cfg.BaseURL != "" {
+		merged.BaseURL = cfg.BaseURL
+	}
+	if cfg.APIKeyEnv != "" {
+		merged.APIKeyEnv = cfg.APIKeyEnv
+	}
+	if cfg.Model != "" {
+		merged.Model = cfg.Model
+	}
+	if cfg.MaxTokens > 0 {
+		merged.MaxTokens = cfg.MaxTokens
+	}
+	if cfg.MaxRetries >= 0 {
+		merged.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.Timeout > 0 {
+		merged.Timeout = cfg.Timeout
+	}
+	merged.BaseURL = strings.TrimRight(merged.BaseURL, "/")
+	return merged
+}
+
+// APIGateway implements AgentGateway by calling a provider's HTTP API directly
+// It needs no vendor CLI: the prompt is sent as a single user message, the reply is
+// streamed back through AgentRequest.OnOutput, and the workflow saves it as the step's
+// artifact. Rate limits (429), server errors (5xx) and connection failures are retried
+// with exponential backoff, honoring Retry-After, as long as no output was streamed yet.
+type APIGateway struct {
+	provider     string
+	api          apiProvider
+	config       APIProviderConfig
+	apiKey       string
+	httpClient   *http.Client
+	retryBackoff time.Duration
+}
+
+// NewAPIGateway creates a gateway for an HTTP API provider ("anthropic", "openai" or "gemini")
+// The API key is read from the configured environment variable, which must be set.
+func NewAPIGateway(provider string, cfg APIProviderConfig) (*APIGateway, error) {
+	api, ok := apiProviders[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown API provider: %s (supported: %s)", provider, strings.Join(APIProviders, ", "))
+	}
+	cfg = cfg.merge(DefaultAPIProviderConfig(provider))
+	apiKey := os.Getenv(cfg.APIKeyEnv)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%w: %s environment variable not set for %s", model.ErrAgentUnavailable, cfg.APIKeyEnv, APIAgentType(provider))
+	}
+	return &APIGateway{
+		provider: provider,
+		api:      api,
+		config:   cfg,
+		apiKey:   apiKey,
+		// No client timeout: responses are streamed, calls are bounded by their context
+		httpClient:   &http.Client{},
+		retryBackoff: defaultAPIRetryBackoff,
+	}, nil
+}
+
+// Execute sends the prompt to the provider's API and streams the reply
+func (g *APIGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	start := time.Now()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	call := apiCall{
+		Model:       g.config.Model,
+		Prompt:      req.Prompt,
+		MaxTokens:   g.config.MaxTokens,
+		Temperature: req.Temperature,
+	}
+	if req.Model != "" {
+		call.Model = req.Model
+	}
+	if req.MaxTokens > 0 {
+		call.MaxTokens = req.MaxTokens
+	}
+
+	var result *apiResult
+	attempts := 0
+	for {
+		attempts++
+		var retryAfter time.Duration
+		var err error
+		result, retryAfter, err = g.stream(ctx, call, req.OnOutput)
+		if err == nil {
+			break
+		}
+		if !isRetryableAPIError(err) || result.Streamed || attempts > g.config.MaxRetries || ctx.Err() != nil {
+			return nil, fmt.Errorf("%s API call failed after %d attempt(s): %w", g.provider, attempts, err)
+		}
+		if err := sleepContext(ctx, g.backoff(attempts, retryAfter)); err != nil {
+			return nil, fmt.Errorf("%s API call failed after %d attempt(s): %w", g.provider, attempts, err)
+		}
+	}
+
+	return &output.AgentResponse{
+		Output:     result.Text.String(),
+		ExitCode:   0,
+		Duration:   time.Since(start),
+		TokensUsed: result.InputTokens + result.OutputTokens,
+		AgentType:  APIAgentType(g.provider),
+		Metadata: map[string]string{
+			"provider":      g.provider,
+			"model":         call.Model,
+			"stop_reason":   result.StopReason,
+			"input_tokens":  strconv.Itoa(result.InputTokens),
+			"output_tokens": strconv.Itoa(result.OutputTokens),
+			"attempts":      strconv.Itoa(attempts),
+		},
+	}, nil
+}
+
+// GetCapability returns the capabilities of the API agent
+func (g *APIGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{
+		SupportsCodeGeneration: true,
+		SupportsReview:         true,
+		SupportsTest:           true,
+		MaxPromptSize:          g.api.maxPromptSize(),
+		ConcurrentTasks:        5,
+		AgentType:              APIAgentType(g.provider),
+	}
+}
+
+// HealthCheck verifies the API is reachable and accepts the API key
+// Lists models instead of generating, so it uses no tokens.
+func (g *APIGateway) HealthCheck(ctx context.Context) error {
+	httpReq, err := g.api.healthRequest(ctx, g.config, g.apiKey)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	httpResp, err := g.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w: %s API unreachable: %w", model.ErrAgentUnavailable, g.provider, err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s API health check failed: %w", g.provider, readAPIError(httpResp))
+	}
+	return nil
+}
+
+// stream makes one API call, passing text to onOutput as it arrives
+// The result is never nil; Streamed reports whether any text reached onOutput.
+func (g *APIGateway) stream(ctx context.Context, call apiCall, onOutput func(string)) (*apiResult, time.Duration, error) {
+	result := &apiResult{}
+	if g.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.config.Timeout)
+		defer cancel()
+	}
+
+	httpReq, err := g.api.streamRequest(ctx, g.config, g.apiKey, call)
+	if err != nil {
+		return result, 0, fmt.Errorf("create request: %w", err)
+	}
+	httpResp, err := g.httpClient.Do(httpReq)
+	if err != nil {
+		return result, 0, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		apiErr := readAPIError(httpResp)
+		return result, apiErr.RetryAfter, apiErr
+	}
+
+	err = readServerSentEvents(httpResp.Body, func(data []byte) error {
+		text, err := g.api.parseEvent(data, result)
+		if err != nil {
+			return err
+		}
+		if text != "" {
+			result.Text.WriteString(text)
+			if onOutput != nil {
+				onOutput(text)
+				result.Streamed = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, 0, fmt.Errorf("read stream: %w", err)
+	}
+	return result, 0, nil
+}
+
+// backoff returns how long to wait before the next attempt
+func (g *APIGateway) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, maxAPIRetryBackoff)
+	}
+	wait := g.retryBackoff << (attempt - 1)
+	if wait <= 0 || wait > maxAPIRetryBackoff {
+		return maxAPIRetryBackoff
+	}
+	return wait
+}
+
+// apiCall is the generation request sent to a provider
+type apiCall struct {
+	Model       string
+	Prompt      string
+	MaxTokens   int
+	Temperature float64
+}
+
+// apiResult accumulates a streamed reply
+type apiResult struct {
+	Text         strings.Builder
+	InputTokens  int
+	OutputTokens int
+	StopReason   string
+	Streamed     bool // Text was passed to AgentRequest.OnOutput, so the call cannot be retried
+}
+
+// APIError is an error response of a provider API
+type APIError struct {
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration // From the Retry-After header (0 if absent)
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API error: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed if sent again
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusRequestTimeout || e.StatusCode >= 500
+}
+
+// isRetryableAPIError reports whether a failed call should be retried
+// Provider errors retry by status; connection and stream failures always retry.
+func isRetryableAPIError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// readAPIError builds an APIError from an error response
+// Every supported provider reports errors as {"error": {"message": "..."}}.
+func readAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxAPIErrorBody))
+	var parsed struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Message != "" {
+		apiErr.Message = parsed.Error.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+// readServerSentEvents passes the data of each server-sent event to handle
+// Stops at the OpenAI "[DONE]" sentinel or the end of the stream.
+func readServerSentEvents(r io.Reader, handle func(data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxAPIEventSize)
+	var data bytes.Buffer
+	dispatch := func() error {
+		if data.Len() == 0 {
+			return nil
+		}
+		defer data.Reset()
+		return handle(data.Bytes())
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			payload := strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
+			if payload == "[DONE]" {
+				return dispatch()
+			}
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(payload)
+		}
+		// Comments, event names and ids carry nothing the providers need
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return dispatch()
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
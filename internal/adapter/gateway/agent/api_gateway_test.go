@@ -0,0 +1,224 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKeyEnv = "DEESPEC_TEST_API_KEY"
+
+// newTestAPIGateway creates a gateway for provider that talks to server
+func newTestAPIGateway(t *testing.T, provider string, server *httptest.Server, maxRetries int) *APIGateway {
+	t.Helper()
+	t.Setenv(testAPIKeyEnv, "secret")
+	gateway, err := NewAPIGateway(provider, APIProviderConfig{BaseURL: server.URL + "/", APIKeyEnv: testAPIKeyEnv, MaxRetries: maxRetries})
+	require.NoError(t, err)
+	gateway.retryBackoff = time.Millisecond
+	return gateway
+}
+
+// writeEvents writes server-sent events, one data line each
+func writeEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, event := range events {
+		fmt.Fprintf(w, "data: %s\n\n", event)
+		w.(http.Flusher).Flush()
+	}
+}
+
+func TestAPIGateway_Anthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v1/messages", strings.TrimPrefix(r.URL.Path, "/"))
+		assert.Equal(t, "secret", r.Header.Get("x-api-key"))
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "claude-opus", body["model"])
+		assert.Equal(t, true, body["stream"])
+		assert.EqualValues(t, 8192, body["max_tokens"], "provider default")
+		writeEvents(w,
+			`{"type":"message_start","message":{"usage":{"input_tokens":12}}}`,
+			`{"type":"content_block_delta","delta":{"type":"text_delta","text":"Hello, "}}`,
+			`{"type":"ping"}`,
+			`{"type":"content_block_delta","delta":{"type":"text_delta","text":"world"}}`,
+			`{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":5}}`,
+			`{"type":"message_stop"}`,
+		)
+	}))
+	defer server.Close()
+
+	var chunks []string
+	resp, err := newTestAPIGateway(t, APIProviderAnthropic, server, 0).Execute(context.Background(), output.AgentRequest{
+		Prompt:   "hi",
+		Model:    "claude-opus",
+		OnOutput: func(chunk string) { chunks = append(chunks, chunk) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, world", resp.Output)
+	assert.Equal(t, []string{"Hello, ", "world"}, chunks, "output is streamed as it arrives")
+	assert.Equal(t, 17, resp.TokensUsed)
+	assert.Equal(t, "anthropic-api", resp.AgentType)
+	assert.Equal(t, "end_turn", resp.Metadata["stop_reason"])
+	assert.Equal(t, "1", resp.Metadata["attempts"])
+}
+
+func TestAPIGateway_OpenAI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v1/chat/completions", strings.TrimPrefix(r.URL.Path, "/"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		writeEvents(w,
+			`{"choices":[{"delta":{"role":"assistant","content":""}}]}`,
+			`{"choices":[{"delta":{"content":"Done"}}]}`,
+			`{"choices":[{"delta":{},"finish_reason":"stop"}]}`,
+			`{"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":1}}`,
+			`[DONE]`,
+		)
+	}))
+	defer server.Close()
+
+	resp, err := newTestAPIGateway(t, APIProviderOpenAI, server, 0).Execute(context.Background(), output.AgentRequest{Prompt: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "Done", resp.Output)
+	assert.Equal(t, 4, resp.TokensUsed)
+	assert.Equal(t, "stop", resp.Metadata["stop_reason"])
+	assert.Equal(t, "gpt-4.1", resp.Metadata["model"])
+}
+
+func TestAPIGateway_Gemini(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v1beta/models/gemini-2.5-flash:streamGenerateContent", strings.TrimPrefix(r.URL.Path, "/"))
+		assert.Equal(t, "sse", r.URL.Query().Get("alt"))
+		assert.Equal(t, "secret", r.Header.Get("x-goog-api-key"))
+		writeEvents(w,
+			`{"candidates":[{"content":{"parts":[{"text":"Looks "}]}}],"usageMetadata":{"promptTokenCount":7,"candidatesTokenCount":1}}`,
+			`{"candidates":[{"content":{"parts":[{"text":"good"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":7,"candidatesTokenCount":2}}`,
+		)
+	}))
+	defer server.Close()
+
+	resp, err := newTestAPIGateway(t, APIProviderGemini, server, 0).Execute(context.Background(), output.AgentRequest{Prompt: "hi", Model: "gemini-2.5-flash"})
+	require.NoError(t, err)
+	assert.Equal(t, "Looks good", resp.Output)
+	assert.Equal(t, 9, resp.TokensUsed, "usage is cumulative, not summed")
+	assert.Equal(t, "STOP", resp.Metadata["stop_reason"])
+}
+
+func TestAPIGateway_RetriesRateLimitsAndServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"error":{"type":"rate_limit_error","message":"slow down"}}`)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			writeEvents(w, `{"choices":[{"delta":{"content":"ok"}}]}`, `[DONE]`)
+		}
+	}))
+	defer server.Close()
+
+	resp, err := newTestAPIGateway(t, APIProviderOpenAI, server, 3).Execute(context.Background(), output.AgentRequest{Prompt: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.Output)
+	assert.Equal(t, "3", resp.Metadata["attempts"])
+}
+
+func TestAPIGateway_GivesUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int32
+		wantErr   string
+	}{
+		{name: "client errors are not retried", status: http.StatusBadRequest, wantCalls: 1, wantErr: "API error (400): bad prompt"},
+		{name: "retries are bounded", status: http.StatusInternalServerError, wantCalls: 3, wantErr: "after 3 attempt(s)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, `{"error":{"message":"bad prompt"}}`)
+			}))
+			defer server.Close()
+
+			_, err := newTestAPIGateway(t, APIProviderAnthropic, server, 2).Execute(context.Background(), output.AgentRequest{Prompt: "hi"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestAPIGateway_DoesNotRetryAfterStreaming(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		writeEvents(w,
+			`{"type":"content_block_delta","delta":{"type":"text_delta","text":"partial"}}`,
+			`{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+		)
+	}))
+	defer server.Close()
+
+	_, err := newTestAPIGateway(t, APIProviderAnthropic, server, 3).Execute(context.Background(), output.AgentRequest{
+		Prompt:   "hi",
+		OnOutput: func(string) {},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overloaded_error - Overloaded")
+	assert.Equal(t, int32(1), calls.Load(), "output already streamed cannot be taken back")
+}
+
+func TestAPIGateway_HealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `{"data":[]}`)
+	}))
+	defer server.Close()
+
+	gateway := newTestAPIGateway(t, APIProviderOpenAI, server, 0)
+	assert.NoError(t, gateway.HealthCheck(context.Background()))
+
+	gateway.apiKey = "wrong"
+	err := gateway.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+}
+
+func TestNewAgentGatewayFactory_APIAgents(t *testing.T) {
+	t.Setenv(testAPIKeyEnv, "")
+	factory := NewAgentGatewayFactory(AgentGatewayOptions{APIProviders: map[string]APIProviderConfig{
+		APIProviderGemini: {APIKeyEnv: testAPIKeyEnv},
+	}})
+
+	_, err := factory("gemini-api")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), testAPIKeyEnv+" environment variable not set for gemini-api")
+
+	t.Setenv(testAPIKeyEnv, "secret")
+	gateway, err := factory("gemini-api")
+	require.NoError(t, err)
+	assert.Equal(t, "gemini-api", gateway.GetCapability().AgentType)
+
+	_, err = factory("mistral-api")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown agent type")
+}
@@ -0,0 +1,264 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// apiProvider speaks one provider's HTTP API
+type apiProvider interface {
+	// streamRequest builds a streaming generation request for call
+	streamRequest(ctx context.Context, cfg APIProviderConfig, apiKey string, call apiCall) (*http.Request, error)
+	// healthRequest builds a request that checks the API key without generating
+	healthRequest(ctx context.Context, cfg APIProviderConfig, apiKey string) (*http.Request, error)
+	// parseEvent records one server-sent event in result and returns the text it adds
+	parseEvent(data []byte, result *apiResult) (string, error)
+	// maxPromptSize is the largest prompt the provider's models accept, in tokens
+	maxPromptSize() int
+}
+
+// apiProviders maps provider names to their API
+var apiProviders = map[string]apiProvider{
+	APIProviderAnthropic: anthropicAPI{},
+	APIProviderOpenAI:    openAIAPI{},
+	APIProviderGemini:    geminiAPI{},
+}
+
+// newJSONRequest builds a POST request with a JSON body
+func newJSONRequest(ctx context.Context, endpoint string, body interface{}) (*http.Request, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	return req, nil
+}
+
+// anthropicAPI speaks the Anthropic Messages API
+type anthropicAPI struct{}
+
+func (anthropicAPI) streamRequest(ctx context.Context, cfg APIProviderConfig, apiKey string, call apiCall) (*http.Request, error) {
+	body := map[string]interface{}{
+		"model":      call.Model,
+		"max_tokens": call.MaxTokens,
+		"messages":   []map[string]string{{"role": "user", "content": call.Prompt}},
+		"stream":     true,
+	}
+	if call.Temperature > 0 {
+		body["temperature"] = call.Temperature
+	}
+	req, err := newJSONRequest(ctx, cfg.BaseURL+"/v1/messages", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	return req, nil
+}
+
+func (anthropicAPI) healthRequest(ctx context.Context, cfg APIProviderConfig, apiKey string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.BaseURL+"/v1/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	return req, nil
+}
+
+func (anthropicAPI) parseEvent(data []byte, result *apiResult) (string, error) {
+	var event struct {
+		Type    string `json:"type"`
+		Message struct {
+			Usage struct {
+				InputTokens int `json:"input_tokens"`
+			} `json:"usage"`
+		} `json:"message"`
+		Delta struct {
+			Type       string `json:"type"`
+			Text       string `json:"text"`
+			StopReason string `json:"stop_reason"`
+		} `json:"delta"`
+		Usage struct {
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", fmt.Errorf("decode event: %w", err)
+	}
+	switch event.Type {
+	case "message_start":
+		result.InputTokens = event.Message.Usage.InputTokens
+	case "content_block_delta":
+		if event.Delta.Type == "text_delta" {
+			return event.Delta.Text, nil
+		}
+	case "message_delta":
+		result.StopReason = event.Delta.StopReason
+		result.OutputTokens = event.Usage.OutputTokens
+	case "error":
+		// Overloaded and server errors arrive in-stream once the response has started
+		status := http.StatusInternalServerError
+		if event.Error.Type == "overloaded_error" {
+			status = 529
+		}
+		return "", &APIError{StatusCode: status, Message: fmt.Sprintf("%s - %s", event.Error.Type, event.Error.Message)}
+	}
+	return "", nil
+}
+
+func (anthropicAPI) maxPromptSize() int { return 200000 }
+
+// openAIAPI speaks the OpenAI Chat Completions API
+type openAIAPI struct{}
+
+func (openAIAPI) streamRequest(ctx context.Context, cfg APIProviderConfig, apiKey string, call apiCall) (*http.Request, error) {
+	body := map[string]interface{}{
+		"model":                 call.Model,
+		"max_completion_tokens": call.MaxTokens,
+		"messages":              []map[string]string{{"role": "user", "content": call.Prompt}},
+		"stream":                true,
+		"stream_options":        map[string]bool{"include_usage": true},
+	}
+	if call.Temperature > 0 {
+		body["temperature"] = call.Temperature
+	}
+	req, err := newJSONRequest(ctx, cfg.BaseURL+"/v1/chat/completions", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	return req, nil
+}
+
+func (openAIAPI) healthRequest(ctx context.Context, cfg APIProviderConfig, apiKey string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.BaseURL+"/v1/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	return req, nil
+}
+
+func (openAIAPI) parseEvent(data []byte, result *apiResult) (string, error) {
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+			FinishReason *string `json:"finish_reason"`
+		} `json:"choices"`
+		Usage *struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return "", fmt.Errorf("decode event: %w", err)
+	}
+	if chunk.Error != nil {
+		return "", &APIError{StatusCode: http.StatusInternalServerError, Message: chunk.Error.Message}
+	}
+	if chunk.Usage != nil {
+		result.InputTokens = chunk.Usage.PromptTokens
+		result.OutputTokens = chunk.Usage.CompletionTokens
+	}
+	if len(chunk.Choices) == 0 {
+		return "", nil
+	}
+	if reason := chunk.Choices[0].FinishReason; reason != nil {
+		result.StopReason = *reason
+	}
+	return chunk.Choices[0].Delta.Content, nil
+}
+
+func (openAIAPI) maxPromptSize() int { return 128000 }
+
+// geminiAPI speaks the Gemini generateContent API
+type geminiAPI struct{}
+
+func (geminiAPI) streamRequest(ctx context.Context, cfg APIProviderConfig, apiKey string, call apiCall) (*http.Request, error) {
+	generationConfig := map[string]interface{}{"maxOutputTokens": call.MaxTokens}
+	if call.Temperature > 0 {
+		generationConfig["temperature"] = call.Temperature
+	}
+	body := map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{"role": "user", "parts": []map[string]string{{"text": call.Prompt}}},
+		},
+		"generationConfig": generationConfig,
+	}
+	endpoint := fmt.Sprintf("%s/v1beta/models/%s:streamGenerateContent?alt=sse", cfg.BaseURL, url.PathEscape(call.Model))
+	req, err := newJSONRequest(ctx, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-goog-api-key", apiKey)
+	return req, nil
+}
+
+func (geminiAPI) healthRequest(ctx context.Context, cfg APIProviderConfig, apiKey string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.BaseURL+"/v1beta/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-goog-api-key", apiKey)
+	return req, nil
+}
+
+func (geminiAPI) parseEvent(data []byte, result *apiResult) (string, error) {
+	var chunk struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+			FinishReason string `json:"finishReason"`
+		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+	}
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return "", fmt.Errorf("decode event: %w", err)
+	}
+	// Usage is cumulative: every chunk reports the totals so far
+	if chunk.UsageMetadata.PromptTokenCount > 0 {
+		result.InputTokens = chunk.UsageMetadata.PromptTokenCount
+	}
+	if chunk.UsageMetadata.CandidatesTokenCount > 0 {
+		result.OutputTokens = chunk.UsageMetadata.CandidatesTokenCount
+	}
+	if len(chunk.Candidates) == 0 {
+		return "", nil
+	}
+	candidate := chunk.Candidates[0]
+	if candidate.FinishReason != "" {
+		result.StopReason = candidate.FinishReason
+	}
+	var text string
+	for _, part := range candidate.Content.Parts {
+		text += part.Text
+	}
+	return text, nil
+}
+
+func (geminiAPI) maxPromptSize() int { return 1000000 }
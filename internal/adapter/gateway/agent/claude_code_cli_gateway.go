@@ -2,12 +2,20 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/process"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/external/claudecli"
 )
 
@@ -16,8 +24,14 @@ import (
 type ClaudeCodeCLIGateway struct {
 	runner     *claudecli.Runner
 	workingDir string // Working directory for claude execution
+
+	versionOnce sync.Once
+	version     string // Output of `claude --version` ("" when it could not be determined)
 }
 
+// cliVersionTimeout bounds the `claude --version` call made once per gateway
+const cliVersionTimeout = 10 * time.Second
+
 // NewClaudeCodeCLIGateway creates a new Claude Code CLI gateway
 func NewClaudeCodeCLIGateway() *ClaudeCodeCLIGateway {
 	// Get current working directory
@@ -28,21 +42,61 @@ func NewClaudeCodeCLIGateway() *ClaudeCodeCLIGateway {
 
 	return &ClaudeCodeCLIGateway{
 		runner: &claudecli.Runner{
-			Bin:     "claude",
-			Timeout: 10 * time.Minute,
+			Bin:      "claude",
+			Timeout:  10 * time.Minute,
+			Registry: process.NewRegistry(AgentRegistryDir(wd)),
 		},
 		workingDir: wd,
 	}
 }
 
+// AgentRegistryDir returns the directory where the agent processes started in the project at
+// root are recorded for the reaper
+func AgentRegistryDir(root string) string {
+	return filepath.Join(root, ".deespec", "var", "agents")
+}
+
 // Execute runs Claude Code CLI with the given request
 func (g *ClaudeCodeCLIGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
 	start := time.Now()
 
-	// Execute claude CLI command
-	result, err := g.runner.Run(ctx, req.Prompt)
+	// Pass model override if the request pins one
+	var extraArgs []string
+	if req.Model != "" {
+		extraArgs = append(extraArgs, "--model", req.Model)
+	}
+
+	// Give the agent the MCP servers enabled for the task
+	if len(req.MCPServers) > 0 {
+		configPath, err := writeMCPConfig(req.MCPServers)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(configPath)
+		extraArgs = append(extraArgs, "--mcp-config", configPath)
+	}
+
+	// Propagate extra environment (e.g. the lock fence for `deespec sbi report`)
+	// and continue the previous conversation when the request carries a session
+	var sessionID string
+	opts := &claudecli.RunOptions{
+		ResumeSessionID: req.SessionID,
+		OnSessionID:     func(id string) { sessionID = id },
+	}
+	for key, value := range req.Env {
+		opts.Env = append(opts.Env, key+"="+value)
+	}
+
+	// Execute claude CLI command, streaming output when the caller wants live chunks
+	var result string
+	var err error
+	if req.OnOutput != nil {
+		result, err = g.runner.RunWithStream(ctx, req.Prompt, g.streamContext(req), opts, extraArgs...)
+	} else {
+		result, err = g.runner.RunWithOptions(ctx, req.Prompt, opts, extraArgs...)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("claude CLI execution failed: %w", err)
+		return nil, cliError(err)
 	}
 
 	// Build agent response
@@ -54,11 +108,36 @@ func (g *ClaudeCodeCLIGateway) Execute(ctx context.Context, req output.AgentRequ
 		AgentType:  "claude-code-cli",
 		Metadata: map[string]string{
 			"working_dir": g.workingDir,
-			"cli_version": "latest", // Could be enhanced to get actual version
+			"cli_version": g.cliVersion(ctx),
+			"model":       req.Model,
 		},
+		SessionID: sessionID,
 	}, nil
 }
 
+// cliVersion returns the version of the claude CLI, asking it once per gateway
+func (g *ClaudeCodeCLIGateway) cliVersion(ctx context.Context) string {
+	g.versionOnce.Do(func() {
+		versionCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cliVersionTimeout)
+		defer cancel()
+		if version, err := g.runner.Version(versionCtx); err == nil {
+			g.version = version
+		}
+	})
+	return g.version
+}
+
+// streamContext builds the stream context for a request; stream history is kept with the SBI spec
+func (g *ClaudeCodeCLIGateway) streamContext(req output.AgentRequest) *claudecli.StreamContext {
+	turn, _ := strconv.Atoi(req.Context["turn"])
+	return &claudecli.StreamContext{
+		SBIDir:    filepath.Join(".deespec", "specs", "sbi", req.Context["sbi_id"]),
+		StepName:  req.Context["step"],
+		Turn:      turn,
+		OnContent: req.OnOutput,
+	}
+}
+
 // ExecuteWithArtifact runs Claude Code CLI and instructs it to create an artifact file
 // This method builds a prompt that asks Claude to write output to a specific file
 func (g *ClaudeCodeCLIGateway) ExecuteWithArtifact(ctx context.Context, req output.AgentRequest, artifactPath string) (*output.AgentResponse, error) {
@@ -81,13 +160,13 @@ Make sure to include all relevant details, code changes, and explanations in the
 	start := time.Now()
 	result, err := g.runner.Run(ctx, enhancedPrompt)
 	if err != nil {
-		return nil, fmt.Errorf("claude CLI execution failed: %w", err)
+		return nil, cliError(err)
 	}
 
 	// Check if artifact file was created
 	if _, err := os.Stat(artifactPath); os.IsNotExist(err) {
 		// Artifact wasn't created, write the result ourselves as fallback
-		if err := os.WriteFile(artifactPath, []byte(result), 0644); err != nil {
+		if err := fs.WriteFileAtomic(artifactPath, []byte(result), 0644); err != nil {
 			return nil, fmt.Errorf("failed to write artifact file: %w", err)
 		}
 	}
@@ -116,7 +195,40 @@ func (g *ClaudeCodeCLIGateway) GetCapability() output.AgentCapability {
 		MaxPromptSize:          200000, // 200k tokens
 		ConcurrentTasks:        1,      // CLI runs one at a time
 		AgentType:              "claude-code-cli",
+		SupportsSessions:       true,
+		SupportsMCP:            true,
+	}
+}
+
+// writeMCPConfig writes the MCP servers of a request to a temporary --mcp-config file
+// A file keeps server env and headers, which may hold credentials, out of the process list.
+func writeMCPConfig(servers map[string]output.MCPServer) (string, error) {
+	data, err := json.Marshal(map[string]interface{}{"mcpServers": servers})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode MCP config: %w", err)
+	}
+	f, err := os.CreateTemp("", "deespec-mcp-*.json") // Created 0600
+	if err != nil {
+		return "", fmt.Errorf("failed to create MCP config: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write MCP config: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write MCP config: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// cliError wraps a failed claude CLI run, marking the agent unavailable when the binary is missing
+func cliError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%w: claude CLI execution failed: %w", model.ErrAgentUnavailable, err)
 	}
+	return fmt.Errorf("claude CLI execution failed: %w", err)
 }
 
 // HealthCheck verifies if claude CLI is available
@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+func TestWriteMCPConfig(t *testing.T) {
+	path, err := writeMCPConfig(map[string]output.MCPServer{
+		"db-schema": {Command: "db-mcp", Args: []string{"--read-only"}, Env: map[string]string{"DB_URL": "postgres://localhost/app"}},
+		"docs":      {Type: "http", URL: "https://mcp.example.com/docs"},
+	})
+	if err != nil {
+		t.Fatalf("writeMCPConfig() error = %v", err)
+	}
+	defer os.Remove(path)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("MCP config mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var config struct {
+		MCPServers map[string]map[string]interface{} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("MCP config is not JSON: %v", err)
+	}
+	if got := config.MCPServers["db-schema"]["command"]; got != "db-mcp" {
+		t.Errorf("db-schema command = %v, want db-mcp", got)
+	}
+	if got := config.MCPServers["docs"]["url"]; got != "https://mcp.example.com/docs" {
+		t.Errorf("docs url = %v", got)
+	}
+	if _, ok := config.MCPServers["docs"]["command"]; ok {
+		t.Error("empty fields should be omitted")
+	}
+}
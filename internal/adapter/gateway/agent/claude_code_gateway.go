@@ -35,9 +35,15 @@ func NewClaudeCodeGateway(apiKey string) *ClaudeCodeGateway {
 func (g *ClaudeCodeGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
 	start := time.Now()
 
+	// Use model override from request if specified
+	model := g.model
+	if req.Model != "" {
+		model = req.Model
+	}
+
 	// Build Claude API request
 	claudeReq := ClaudeRequest{
-		Model:       g.model,
+		Model:       model,
 		MaxTokens:   req.MaxTokens,
 		Temperature: req.Temperature,
 		Messages: []Message{
@@ -68,7 +74,7 @@ func (g *ClaudeCodeGateway) Execute(ctx context.Context, req output.AgentRequest
 		TokensUsed: resp.Usage.InputTokens + resp.Usage.OutputTokens,
 		AgentType:  "claude-code",
 		Metadata: map[string]string{
-			"model":         g.model,
+			"model":         model,
 			"stop_reason":   resp.StopReason,
 			"input_tokens":  fmt.Sprintf("%d", resp.Usage.InputTokens),
 			"output_tokens": fmt.Sprintf("%d", resp.Usage.OutputTokens),
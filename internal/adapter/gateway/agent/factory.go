@@ -5,33 +5,66 @@ import (
 	"os"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 )
 
 // NewAgentGateway creates an agent gateway based on agent type
-// Supported types: claude-code, claude-code-cli, gemini-cli, codex
+// Supported types: claude-code, claude-code-cli, gemini-cli, codex, anthropic-api, openai-api, gemini-api, ollama
 // Note: User is responsible for ensuring the agent is available (e.g., claude CLI installed)
+// HTTP API and Ollama agents use their defaults; see NewAgentGatewayFactory to configure them.
 func NewAgentGateway(agentType string) (output.AgentGateway, error) {
-	switch agentType {
-	case "claude-code":
-		// API version (requires ANTHROPIC_API_KEY)
-		apiKey := os.Getenv("ANTHROPIC_API_KEY")
-		if apiKey == "" {
-			return nil, fmt.Errorf("ANTHROPIC_API_KEY environment variable not set for claude-code")
-		}
-		return NewClaudeCodeGateway(apiKey), nil
+	return NewAgentGatewayFactory(AgentGatewayOptions{})(agentType)
+}
+
+// AgentGatewayOptions configures the agents created by NewAgentGatewayFactory
+type AgentGatewayOptions struct {
+	APIProviders map[string]APIProviderConfig // HTTP API provider name -> config (missing providers use the defaults)
+	Ollama       OllamaConfig                 // Local LLM agent
+}
+
+// NewAgentGatewayFactory returns a function that creates agent gateways by agent type
+func NewAgentGatewayFactory(options AgentGatewayOptions) func(agentType string) (output.AgentGateway, error) {
+	return func(agentType string) (output.AgentGateway, error) {
+		switch agentType {
+		case "claude-code":
+			// API version (requires ANTHROPIC_API_KEY)
+			apiKey := os.Getenv("ANTHROPIC_API_KEY")
+			if apiKey == "" {
+				return nil, fmt.Errorf("%w: ANTHROPIC_API_KEY environment variable not set for claude-code", model.ErrAgentUnavailable)
+			}
+			return NewClaudeCodeGateway(apiKey), nil
 
-	case "claude-code-cli":
-		// CLI version (assumes `claude` command is available)
-		return NewClaudeCodeCLIGateway(), nil
+		case "claude-code-cli":
+			// CLI version (assumes `claude` command is available)
+			return NewClaudeCodeCLIGateway(), nil
 
-	case "gemini-cli":
-		return NewGeminiMockGateway(), nil
+		case "gemini-cli":
+			return NewGeminiMockGateway(), nil
 
-	case "codex":
-		return NewCodexMockGateway(), nil
+		case "codex":
+			return NewCodexMockGateway(), nil
 
-	default:
-		return nil, fmt.Errorf("unknown agent type: %s (supported: claude-code, claude-code-cli, gemini-cli, codex)", agentType)
+		case "ollama":
+			// Local LLM (assumes an Ollama server is running)
+			return NewOllamaGateway(options.Ollama), nil
+		}
+
+		// HTTP API versions (no vendor CLI required)
+		for _, provider := range APIProviders {
+			if agentType != APIAgentType(provider) {
+				continue
+			}
+			cfg, ok := options.APIProviders[provider]
+			if !ok {
+				cfg = DefaultAPIProviderConfig(provider)
+			}
+			gateway, err := NewAPIGateway(provider, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return gateway, nil
+		}
+		return nil, fmt.Errorf("unknown agent type: %s (supported: claude-code, claude-code-cli, gemini-cli, codex, anthropic-api, openai-api, gemini-api, ollama)", agentType)
 	}
 }
 
@@ -44,6 +77,13 @@ func GetAvailableAgents() []string {
 		agents = append(agents, "claude-code")
 	}
 
+	// HTTP API agents are available when their API key is set
+	for _, provider := range APIProviders {
+		if os.Getenv(DefaultAPIProviderConfig(provider).APIKeyEnv) != "" {
+			agents = append(agents, APIAgentType(provider))
+		}
+	}
+
 	// Mock agents are always available
 	agents = append(agents, "gemini-cli", "codex")
 
@@ -0,0 +1,470 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	domainmodel "github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// Ollama prompt overflow strategies
+const (
+	OllamaOverflowTruncate  = "truncate"  // Drop the middle of the prompt
+	OllamaOverflowSummarize = "summarize" // Have the model summarize the middle of the prompt
+)
+
+const (
+	defaultOllamaBaseURL         = "http://localhost:11434"
+	defaultOllamaModel           = "llama3.1"
+	defaultOllamaMaxOutputTokens = 4096
+	// defaultOllamaContextWindow is Ollama's own default, used when a model reports no context length
+	defaultOllamaContextWindow = 2048
+
+	// ollamaBytesPerToken estimates token counts conservatively: code and CJK text tokenize densely
+	ollamaBytesPerToken = 3
+	// ollamaSummaryOverheadTokens reserves room for the summarization instructions
+	ollamaSummaryOverheadTokens = 256
+)
+
+// OllamaConfig configures the Ollama gateway
+type OllamaConfig struct {
+	BaseURL         string        // Ollama server URL (empty: OLLAMA_HOST or http://localhost:11434)
+	Model           string        // Model used when the request does not override it
+	ContextWindow   int           // Context length to use in tokens (0: the model's maximum)
+	MaxOutputTokens int           // Tokens reserved for the reply; the prompt must fit in the rest
+	Overflow        string        // What to do with prompts that do not fit (OllamaOverflowTruncate or OllamaOverflowSummarize)
+	Timeout         time.Duration // Timeout of a single call (0: only the request timeout applies)
+}
+
+// OllamaModelInfo describes what a local model can do, as reported by Ollama
+type OllamaModelInfo struct {
+	Name               string
+	ContextLength      int  // Maximum context length in tokens (0 if the model does not report it)
+	SupportsCompletion bool // Generates text (embedding-only models do not)
+	SupportsTools      bool // Accepts tool definitions
+}
+
+// OllamaGateway implements AgentGateway for a local LLM served by Ollama
+// Before each call it checks the model's capabilities (cached per model) and fits the prompt
+// into the model's context window, truncating or summarizing its middle when it is too long:
+// deespec prompts open with the task and close with the output instructions, so both ends are kept.
+type OllamaGateway struct {
+	config     OllamaConfig
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	models map[string]*OllamaModelInfo // Model name -> detected capabilities
+}
+
+// NewOllamaGateway creates a new Ollama gateway
+func NewOllamaGateway(cfg OllamaConfig) *OllamaGateway {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = ollamaHostURL(os.Getenv("OLLAMA_HOST"))
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.Model == "" {
+		cfg.Model = defaultOllamaModel
+	}
+	if cfg.MaxOutputTokens <= 0 {
+		cfg.MaxOutputTokens = defaultOllamaMaxOutputTokens
+	}
+	if cfg.Overflow == "" {
+		cfg.Overflow = OllamaOverflowTruncate
+	}
+	return &OllamaGateway{
+		config: cfg,
+		// No client timeout: responses are streamed, calls are bounded by their context
+		httpClient: &http.Client{},
+		models:     make(map[string]*OllamaModelInfo),
+	}
+}
+
+// ollamaHostURL turns an OLLAMA_HOST value ("host:port" or a URL) into a base URL
+func ollamaHostURL(host string) string {
+	host = strings.TrimSpace(host)
+	switch {
+	case host == "":
+		return defaultOllamaBaseURL
+	case strings.HasPrefix(host, "http://"), strings.HasPrefix(host, "https://"):
+		return host
+	default:
+		return "http://" + host
+	}
+}
+
+// Execute fits the prompt into the model's context window and streams the reply
+func (g *OllamaGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	start := time.Now()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	model := g.config.Model
+	if req.Model != "" {
+		model = req.Model
+	}
+	info, err := g.ModelInfo(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	if !info.SupportsCompletion {
+		return nil, fmt.Errorf("ollama model %s does not generate text (is it an embedding model?)", model)
+	}
+
+	window := g.contextWindow(info)
+	maxOutput := g.config.MaxOutputTokens
+	if req.MaxTokens > 0 {
+		maxOutput = req.MaxTokens
+	}
+	// Small models: never let the reply take more than half of the window
+	if maxOutput > window/2 {
+		maxOutput = window / 2
+	}
+	prompt, fit := g.fitPrompt(ctx, model, req.Prompt, window-maxOutput, window)
+
+	result, err := g.chat(ctx, model, prompt, g.options(window, maxOutput, req.Temperature), req.OnOutput)
+	if err != nil {
+		return nil, fmt.Errorf("ollama call failed: %w", err)
+	}
+
+	metadata := map[string]string{
+		"model":          model,
+		"stop_reason":    result.StopReason,
+		"input_tokens":   strconv.Itoa(result.InputTokens),
+		"output_tokens":  strconv.Itoa(result.OutputTokens),
+		"context_window": strconv.Itoa(window),
+		"supports_tools": strconv.FormatBool(info.SupportsTools),
+	}
+	if fit != "" {
+		metadata["prompt_fit"] = fit
+	}
+	return &output.AgentResponse{
+		Output:     result.Text.String(),
+		ExitCode:   0,
+		Duration:   time.Since(start),
+		TokensUsed: result.InputTokens + result.OutputTokens,
+		AgentType:  "ollama",
+		Metadata:   metadata,
+	}, nil
+}
+
+// GetCapability returns the capabilities of the Ollama agent
+// The prompt size reflects the default model once its capabilities have been detected.
+func (g *OllamaGateway) GetCapability() output.AgentCapability {
+	window := defaultOllamaContextWindow
+	g.mu.Lock()
+	if info, ok := g.models[g.config.Model]; ok {
+		window = g.contextWindow(info)
+	}
+	g.mu.Unlock()
+	return output.AgentCapability{
+		SupportsCodeGeneration: true,
+		SupportsReview:         true,
+		SupportsTest:           true,
+		MaxPromptSize:          window,
+		ConcurrentTasks:        1, // One local model serves one request at a time
+		AgentType:              "ollama",
+	}
+}
+
+// HealthCheck verifies Ollama is running and the default model is pulled
+func (g *OllamaGateway) HealthCheck(ctx context.Context) error {
+	info, err := g.ModelInfo(ctx, g.config.Model)
+	if err != nil {
+		return err
+	}
+	if !info.SupportsCompletion {
+		return fmt.Errorf("ollama model %s does not generate text", info.Name)
+	}
+	return nil
+}
+
+// ModelInfo returns the capabilities of a model, asking Ollama the first time
+func (g *OllamaGateway) ModelInfo(ctx context.Context, model string) (*OllamaModelInfo, error) {
+	g.mu.Lock()
+	info, ok := g.models[model]
+	g.mu.Unlock()
+	if ok {
+		return info, nil
+	}
+
+	body, err := json.Marshal(map[string]string{"model": model})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.config.BaseURL+"/api/show", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := g.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ollama is not reachable at %s: %w", domainmodel.ErrAgentUnavailable, g.config.BaseURL, err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: ollama model %s is not available (run `ollama pull %s`)", domainmodel.ErrAgentUnavailable, model, model)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama model check failed: %w", readOllamaError(httpResp))
+	}
+
+	var show struct {
+		Capabilities []string               `json:"capabilities"`
+		ModelInfo    map[string]interface{} `json:"model_info"`
+		Template     string                 `json:"template"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&show); err != nil {
+		return nil, fmt.Errorf("decode model info: %w", err)
+	}
+	info = parseOllamaModelInfo(model, show.Capabilities, show.ModelInfo, show.Template)
+
+	g.mu.Lock()
+	g.models[model] = info
+	g.mu.Unlock()
+	return info, nil
+}
+
+// parseOllamaModelInfo reads a model's capabilities from an /api/show response
+// Servers that predate the capabilities list are assumed to generate text, and to support
+// tools when the chat template renders them.
+func parseOllamaModelInfo(model string, capabilities []string, modelInfo map[string]interface{}, template string) *OllamaModelInfo {
+	info := &OllamaModelInfo{Name: model}
+	if capabilities == nil {
+		info.SupportsCompletion = true
+		info.SupportsTools = strings.Contains(template, ".Tools")
+	}
+	for _, capability := range capabilities {
+		switch capability {
+		case "completion":
+			info.SupportsCompletion = true
+		case "tools":
+			info.SupportsTools = true
+		}
+	}
+	// The key is prefixed by the model architecture, e.g. "llama.context_length"
+	for key, value := range modelInfo {
+		if length, ok := value.(float64); ok && strings.HasSuffix(key, ".context_length") {
+			info.ContextLength = int(length)
+		}
+	}
+	return info
+}
+
+// contextWindow returns the context length used for a model, in tokens
+func (g *OllamaGateway) contextWindow(info *OllamaModelInfo) int {
+	window := info.ContextLength
+	if window <= 0 {
+		window = defaultOllamaContextWindow
+	}
+	if g.config.ContextWindow > 0 && g.config.ContextWindow < window {
+		window = g.config.ContextWindow
+	}
+	return window
+}
+
+// options returns the generation options of a call
+// num_ctx must always be sent: Ollama otherwise runs every model with its small default context.
+func (g *OllamaGateway) options(window, maxOutput int, temperature float64) map[string]interface{} {
+	options := map[string]interface{}{"num_ctx": window, "num_predict": maxOutput}
+	if temperature > 0 {
+		options["temperature"] = temperature
+	}
+	return options
+}
+
+// fitPrompt shortens a prompt that exceeds budget tokens and reports how ("" when it fits)
+// A failed or oversized summary falls back to truncation, which always fits.
+func (g *OllamaGateway) fitPrompt(ctx context.Context, model, prompt string, budget, window int) (string, string) {
+	if estimateTokens(prompt) <= budget {
+		return prompt, ""
+	}
+	if g.config.Overflow == OllamaOverflowSummarize {
+		summarized, err := g.summarizePrompt(ctx, model, prompt, budget, window)
+		if err == nil && estimateTokens(summarized) <= budget {
+			return summarized, "summarized"
+		}
+	}
+	return truncateMiddle(prompt, budget*ollamaBytesPerToken), "truncated"
+}
+
+// summarizePrompt keeps both ends of the prompt and replaces its middle with the model's summary
+// The middle is summarized in chunks that each fit the context window.
+func (g *OllamaGateway) summarizePrompt(ctx context.Context, model, prompt string, budget, window int) (string, error) {
+	// Half of the budget keeps the prompt's ends verbatim; the summary gets the other half
+	head, middle, tail := splitMiddle(prompt, budget*ollamaBytesPerToken/2)
+	chunkBytes := (window/2 - ollamaSummaryOverheadTokens) * ollamaBytesPerToken
+	if chunkBytes <= 0 {
+		return "", fmt.Errorf("context window of %d tokens is too small to summarize", window)
+	}
+	chunks := (len(middle) + chunkBytes - 1) / chunkBytes
+	summaryTokens := budget / 2 / chunks
+	if summaryTokens < 32 {
+		return "", fmt.Errorf("prompt is too long to summarize into %d tokens", budget/2)
+	}
+
+	var summary strings.Builder
+	for len(middle) > 0 {
+		chunk := middle
+		if len(chunk) > chunkBytes {
+			chunk = middle[:runeBoundary(middle, chunkBytes)]
+		}
+		middle = middle[len(chunk):]
+		result, err := g.chat(ctx, model, ollamaSummaryPrompt+chunk, g.options(window, summaryTokens, 0), nil)
+		if err != nil {
+			return "", err
+		}
+		summary.WriteString(strings.TrimSpace(result.Text.String()))
+		summary.WriteString("\n")
+	}
+	return fmt.Sprintf("%s\n\n[Summary of a part of this prompt that did not fit the model's context window]\n%s\n%s",
+		head, strings.TrimSpace(summary.String()), tail), nil
+}
+
+// ollamaSummaryPrompt instructs the model to summarize a chunk of a prompt
+const ollamaSummaryPrompt = "Summarize the following excerpt of a coding task prompt for the agent that will do the task. " +
+	"Keep file paths, identifiers, commands, requirements and acceptance criteria verbatim; drop repetition and prose. " +
+	"Reply with the summary only.\n\n---\n"
+
+// chat sends a prompt to /api/chat and reads the streamed reply
+func (g *OllamaGateway) chat(ctx context.Context, model, prompt string, options map[string]interface{}, onOutput func(string)) (*apiResult, error) {
+	if g.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.config.Timeout)
+		defer cancel()
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"model":    model,
+		"messages": []map[string]string{{"role": "user", "content": prompt}},
+		"stream":   true,
+		"options":  options,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.config.BaseURL+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := g.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, readOllamaError(httpResp)
+	}
+
+	// The reply is newline-delimited JSON, one object per chunk
+	result := &apiResult{}
+	scanner := bufio.NewScanner(httpResp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxAPIEventSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var chunk struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+			Done            bool   `json:"done"`
+			DoneReason      string `json:"done_reason"`
+			PromptEvalCount int    `json:"prompt_eval_count"`
+			EvalCount       int    `json:"eval_count"`
+			Error           string `json:"error"`
+		}
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, fmt.Errorf("decode chunk: %w", err)
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("ollama error: %s", chunk.Error)
+		}
+		if text := chunk.Message.Content; text != "" {
+			result.Text.WriteString(text)
+			if onOutput != nil {
+				onOutput(text)
+			}
+		}
+		if chunk.Done {
+			result.StopReason = chunk.DoneReason
+			result.InputTokens = chunk.PromptEvalCount
+			result.OutputTokens = chunk.EvalCount
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read stream: %w", err)
+	}
+	return result, nil
+}
+
+// readOllamaError builds an APIError from an Ollama error response ({"error": "..."})
+func readOllamaError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxAPIErrorBody))
+	var parsed struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error != "" {
+		message = parsed.Error
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: message}
+}
+
+// estimateTokens estimates the token count of text
+func estimateTokens(text string) int {
+	return (len(text) + ollamaBytesPerToken - 1) / ollamaBytesPerToken
+}
+
+// truncateMiddle shortens s to about maxBytes by dropping its middle
+// The tail gets the larger share: it holds the output instructions the reply must follow.
+func truncateMiddle(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	head, middle, tail := splitMiddle(s, maxBytes-96) // Room for the marker
+	return fmt.Sprintf("%s\n\n[... %d bytes omitted to fit the model's context window ...]\n\n%s", head, len(middle), tail)
+}
+
+// splitMiddle splits s into a head and a tail of keepBytes in total (2:3) and the middle between them
+func splitMiddle(s string, keepBytes int) (head, middle, tail string) {
+	if keepBytes < 0 {
+		keepBytes = 0
+	}
+	if len(s) <= keepBytes {
+		return s, "", ""
+	}
+	headEnd := runeBoundary(s, keepBytes*2/5)
+	tailStart := len(s) - (keepBytes - headEnd)
+	for tailStart < len(s) && !utf8.RuneStart(s[tailStart]) {
+		tailStart++
+	}
+	return s[:headEnd], s[headEnd:tailStart], s[tailStart:]
+}
+
+// runeBoundary returns the largest index <= n that starts a rune in s
+func runeBoundary(s string, n int) int {
+	if n >= len(s) {
+		return len(s)
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return n
+}
@@ -0,0 +1,172 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOllama serves /api/show and /api/chat, recording the chat requests
+type fakeOllama struct {
+	contextLength int
+	capabilities  []string
+
+	mu        sync.Mutex
+	showCalls int
+	chats     []fakeOllamaChat
+}
+
+type fakeOllamaChat struct {
+	Prompt  string
+	Options map[string]float64
+}
+
+func (f *fakeOllama) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Model    string `json:"model"`
+		Messages []struct {
+			Content string `json:"content"`
+		} `json:"messages"`
+		Options map[string]float64 `json:"options"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch strings.TrimPrefix(r.URL.Path, "/") {
+	case "api/show":
+		f.showCalls++
+		if body.Model == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"error":"model '%s' not found"}`, body.Model)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"capabilities": f.capabilities,
+			"model_info":   map[string]interface{}{"general.architecture": "llama", "llama.context_length": f.contextLength},
+		})
+	case "api/chat":
+		prompt := body.Messages[0].Content
+		f.chats = append(f.chats, fakeOllamaChat{Prompt: prompt, Options: body.Options})
+		reply := []string{"Implemented", " the task."}
+		if strings.HasPrefix(prompt, ollamaSummaryPrompt) {
+			reply = []string{"SUMMARY"}
+		}
+		for _, text := range reply {
+			fmt.Fprintf(w, `{"message":{"role":"assistant","content":%q},"done":false}`+"\n", text)
+		}
+		fmt.Fprintln(w, `{"message":{"content":""},"done":true,"done_reason":"stop","prompt_eval_count":40,"eval_count":2}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// longPrompt builds a prompt of about size bytes with recognizable ends
+func longPrompt(size int) string {
+	return "TASK: add a handler.\n" + strings.Repeat("background detail. ", size/19) + "\nOUTPUT: write the report."
+}
+
+func TestOllamaGateway_FitsPromptIntoContextWindow(t *testing.T) {
+	fake := &fakeOllama{contextLength: 1000, capabilities: []string{"completion", "tools"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	gateway := NewOllamaGateway(OllamaConfig{BaseURL: server.URL, Model: "llama3.1", MaxOutputTokens: 200})
+
+	var chunks []string
+	resp, err := gateway.Execute(context.Background(), output.AgentRequest{
+		Prompt:   longPrompt(6000),
+		OnOutput: func(chunk string) { chunks = append(chunks, chunk) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Implemented the task.", resp.Output)
+	assert.Equal(t, []string{"Implemented", " the task."}, chunks)
+	assert.Equal(t, 42, resp.TokensUsed)
+	assert.Equal(t, "truncated", resp.Metadata["prompt_fit"])
+	assert.Equal(t, "1000", resp.Metadata["context_window"])
+	assert.Equal(t, "true", resp.Metadata["supports_tools"])
+
+	require.Len(t, fake.chats, 1)
+	chat := fake.chats[0]
+	assert.Equal(t, 1000.0, chat.Options["num_ctx"], "the context window is always sent")
+	assert.Equal(t, 200.0, chat.Options["num_predict"])
+	assert.LessOrEqual(t, estimateTokens(chat.Prompt), 800, "the prompt fits beside the reply")
+	assert.True(t, strings.HasPrefix(chat.Prompt, "TASK: add a handler."))
+	assert.True(t, strings.HasSuffix(chat.Prompt, "OUTPUT: write the report."))
+	assert.Contains(t, chat.Prompt, "bytes omitted to fit the model's context window")
+
+	// Short prompts are sent as-is, and the capabilities are not asked again
+	resp, err = gateway.Execute(context.Background(), output.AgentRequest{Prompt: "Fix the typo."})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Metadata["prompt_fit"])
+	assert.Equal(t, "Fix the typo.", fake.chats[1].Prompt)
+	assert.Equal(t, 1, fake.showCalls)
+}
+
+func TestOllamaGateway_SummarizesOverflow(t *testing.T) {
+	fake := &fakeOllama{contextLength: 2048, capabilities: []string{"completion"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	gateway := NewOllamaGateway(OllamaConfig{BaseURL: server.URL, MaxOutputTokens: 512, Overflow: OllamaOverflowSummarize, ContextWindow: 100000})
+
+	resp, err := gateway.Execute(context.Background(), output.AgentRequest{Prompt: longPrompt(12000)})
+	require.NoError(t, err)
+	assert.Equal(t, "summarized", resp.Metadata["prompt_fit"])
+	assert.Equal(t, "2048", resp.Metadata["context_window"], "a larger configured window is capped at the model's")
+
+	require.Greater(t, len(fake.chats), 1, "the middle is summarized before the task runs")
+	final := fake.chats[len(fake.chats)-1].Prompt
+	assert.True(t, strings.HasPrefix(final, "TASK: add a handler."))
+	assert.True(t, strings.HasSuffix(final, "OUTPUT: write the report."))
+	assert.Contains(t, final, "did not fit the model's context window]\nSUMMARY")
+	assert.LessOrEqual(t, estimateTokens(final), 2048-512)
+}
+
+func TestOllamaGateway_ChecksModel(t *testing.T) {
+	fake := &fakeOllama{contextLength: 8192, capabilities: []string{"embedding"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	gateway := NewOllamaGateway(OllamaConfig{BaseURL: server.URL, Model: "nomic-embed-text"})
+
+	_, err := gateway.Execute(context.Background(), output.AgentRequest{Prompt: "hi", Model: "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "run `ollama pull missing`")
+
+	err = gateway.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not generate text")
+	assert.Empty(t, fake.chats)
+}
+
+func TestParseOllamaModelInfo(t *testing.T) {
+	info := parseOllamaModelInfo("qwen", []string{"completion", "tools"}, map[string]interface{}{"qwen2.context_length": 32768.0}, "")
+	assert.Equal(t, &OllamaModelInfo{Name: "qwen", ContextLength: 32768, SupportsCompletion: true, SupportsTools: true}, info)
+
+	legacy := parseOllamaModelInfo("llama", nil, nil, "{{ if .Tools }}tools{{ end }}")
+	assert.True(t, legacy.SupportsCompletion, "servers without a capabilities list only serve generative models here")
+	assert.True(t, legacy.SupportsTools)
+	assert.Zero(t, legacy.ContextLength)
+}
+
+func TestTruncateMiddle_KeepsRunes(t *testing.T) {
+	text := strings.Repeat("実装レポートを書く。", 200)
+	truncated := truncateMiddle(text, 1000)
+	assert.True(t, utf8.ValidString(truncated))
+	assert.LessOrEqual(t, len(truncated), 1000)
+	assert.Equal(t, text, truncateMiddle(text, len(text)))
+}
+
+func TestOllamaHostURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:11434", ollamaHostURL(""))
+	assert.Equal(t, "http://gpu-box:11434", ollamaHostURL("gpu-box:11434"))
+	assert.Equal(t, "https://ollama.example.com", ollamaHostURL("https://ollama.example.com"))
+}
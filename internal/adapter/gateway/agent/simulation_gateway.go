@@ -0,0 +1,239 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// Request context keys set by the workflow for every agent call
+// The simulation gateway uses them to pick the scripted response
+const (
+	ContextKeySBIID = "sbi_id"
+	ContextKeyStep  = "step"
+	ContextKeyTurn  = "turn"
+)
+
+// SimulationReportFunc submits a report the way an agent would via `deespec sbi report`
+type SimulationReportFunc func(ctx context.Context, sbiID string, turn int, step string, decision string, content string) error
+
+// SimulationVoteFunc submits a reviewer's vote the way a review quorum reviewer would via `deespec sbi report`
+type SimulationVoteFunc func(ctx context.Context, sbiID string, turn int, reviewer string, decision string, content string) error
+
+// SimulationScript is the YAML fixture replayed by SimulationGateway
+//
+//	steps:                 # Default sequence, replayed from the start for every SBI
+//	  - step: implement
+//	    output: "Implemented feature"
+//	  - step: review
+//	    decision: NEEDS_CHANGES
+//	    output: "## Issues\n- Missing tests"
+//	  - step: implement
+//	  - step: review
+//	    decision: SUCCEEDED
+//	sbis:                  # Optional per-SBI sequences (override steps)
+//	  SBI-01H...:
+//	    - step: implement
+//	      error: "agent crashed"
+type SimulationScript struct {
+	Steps []SimulationStep            `yaml:"steps"`
+	SBIs  map[string][]SimulationStep `yaml:"sbis"`
+}
+
+// SimulationStep is a single scripted agent response
+type SimulationStep struct {
+	Step     string `yaml:"step"`     // Expected workflow step (implement, review, force_implement, postmortem)
+	Output   string `yaml:"output"`   // Agent output / report content
+	Decision string `yaml:"decision"` // Review decision (SUCCEEDED, NEEDS_CHANGES, FAILED)
+	Error    string `yaml:"error"`    // Simulated agent failure
+	DelayMs  int    `yaml:"delay_ms"` // Simulated processing time
+}
+
+// SimulationGateway implements AgentGateway by replaying a scripted sequence of responses
+// It exercises the full state machine (pick → implement → review → done) without real AI calls.
+// When a reporter is set, implement/review responses are submitted as reports, just as
+// real agents do by running `deespec sbi report`.
+type SimulationGateway struct {
+	script   SimulationScript
+	reporter SimulationReportFunc
+	voter    SimulationVoteFunc
+	cursors  map[string]int // SBI ID -> next step index
+	mu       sync.Mutex
+}
+
+// NewSimulationGateway creates a simulation gateway from a parsed script
+func NewSimulationGateway(script SimulationScript) *SimulationGateway {
+	return &SimulationGateway{
+		script:  script,
+		cursors: make(map[string]int),
+	}
+}
+
+// LoadSimulationGateway creates a simulation gateway from a YAML fixture file
+func LoadSimulationGateway(path string) (*SimulationGateway, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read simulation fixture: %w", err)
+	}
+
+	var script SimulationScript
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, fmt.Errorf("parse simulation fixture %s: %w", path, err)
+	}
+	if err := script.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid simulation fixture %s: %w", path, err)
+	}
+
+	return NewSimulationGateway(script), nil
+}
+
+// Validate checks that every scripted step is well-formed
+func (s SimulationScript) Validate() error {
+	check := func(owner string, steps []SimulationStep) error {
+		for i, st := range steps {
+			switch st.Step {
+			case "implement", "force_implement", "postmortem":
+			case "review":
+				if st.Error == "" {
+					switch st.Decision {
+					case "SUCCEEDED", "NEEDS_CHANGES", "FAILED":
+					default:
+						return fmt.Errorf("%s[%d]: review step requires decision SUCCEEDED, NEEDS_CHANGES or FAILED, got %q", owner, i, st.Decision)
+					}
+				}
+			default:
+				return fmt.Errorf("%s[%d]: unknown step %q", owner, i, st.Step)
+			}
+		}
+		return nil
+	}
+
+	if err := check("steps", s.Steps); err != nil {
+		return err
+	}
+	for sbiID, steps := range s.SBIs {
+		if err := check("sbis."+sbiID, steps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetReporter sets the function used to submit implement/review reports
+func (g *SimulationGateway) SetReporter(reporter SimulationReportFunc) {
+	g.reporter = reporter
+}
+
+// SetVoteReporter sets the function used to submit reviewer votes in review quorum mode
+// Each reviewer consumes the next scripted review step of the SBI
+func (g *SimulationGateway) SetVoteReporter(voter SimulationVoteFunc) {
+	g.voter = voter
+}
+
+// Execute replays the next scripted response for the SBI in the request context
+func (g *SimulationGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	sbiID := req.Context[ContextKeySBIID]
+	step := req.Context[ContextKeyStep]
+	turn, _ := strconv.Atoi(req.Context[ContextKeyTurn])
+
+	scripted, err := g.next(sbiID, step)
+	if err != nil {
+		return nil, err
+	}
+
+	if scripted.DelayMs > 0 {
+		select {
+		case <-time.After(time.Duration(scripted.DelayMs) * time.Millisecond):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if scripted.Error != "" {
+		return nil, fmt.Errorf("simulated agent error: %s", scripted.Error)
+	}
+
+	content := scripted.Output
+	if content == "" {
+		content = fmt.Sprintf("# Simulated %s report\n\nSBI: %s, Turn: %d\n", step, sbiID, turn)
+	}
+
+	// Submit the report like a real agent running `deespec sbi report`
+	// Review quorum reviewers vote instead of deciding the turn
+	if reviewer := req.Env[domainservice.ReviewerEnvKey]; reviewer != "" && step == "review" {
+		if g.voter != nil {
+			if err := g.voter(ctx, sbiID, turn, reviewer, scripted.Decision, content); err != nil {
+				return nil, fmt.Errorf("simulated vote submission failed: %w", err)
+			}
+		}
+	} else if g.reporter != nil && (step == "implement" || step == "review") {
+		if err := g.reporter(ctx, sbiID, turn, step, scripted.Decision, content); err != nil {
+			return nil, fmt.Errorf("simulated report submission failed: %w", err)
+		}
+	}
+
+	return &output.AgentResponse{
+		Output:    content,
+		ExitCode:  0,
+		Duration:  time.Duration(scripted.DelayMs) * time.Millisecond,
+		AgentType: "simulation",
+		Metadata: map[string]string{
+			"simulation": "true",
+			"step":       step,
+			"decision":   scripted.Decision,
+		},
+	}, nil
+}
+
+// next returns the next scripted step for an SBI
+// When the script is exhausted, implement succeeds and review is approved
+func (g *SimulationGateway) next(sbiID, step string) (SimulationStep, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	steps, ok := g.script.SBIs[sbiID]
+	if !ok {
+		steps = g.script.Steps
+	}
+
+	idx := g.cursors[sbiID]
+	if idx >= len(steps) {
+		fallback := SimulationStep{Step: step}
+		if step == "review" {
+			fallback.Decision = "SUCCEEDED"
+		}
+		return fallback, nil
+	}
+
+	scripted := steps[idx]
+	if scripted.Step != step {
+		return SimulationStep{}, fmt.Errorf("simulation script mismatch for %s: step %d expects %q, workflow requested %q", sbiID, idx+1, scripted.Step, step)
+	}
+	g.cursors[sbiID] = idx + 1
+	return scripted, nil
+}
+
+// GetCapability returns the simulation agent's capabilities
+func (g *SimulationGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{
+		SupportsCodeGeneration: true,
+		SupportsReview:         true,
+		SupportsTest:           true,
+		MaxPromptSize:          1 << 20,
+		ConcurrentTasks:        10,
+		AgentType:              "simulation",
+	}
+}
+
+// HealthCheck always succeeds for the simulation agent
+func (g *SimulationGateway) HealthCheck(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package agent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+const simulationFixture = `
+steps:
+  - step: implement
+    output: "first attempt"
+  - step: review
+    decision: NEEDS_CHANGES
+    output: "## Issues\n- Missing tests"
+sbis:
+  SBI-CRASH:
+    - step: implement
+      error: "agent crashed"
+`
+
+func simulationRequest(sbiID, step, turn string) output.AgentRequest {
+	return output.AgentRequest{
+		Prompt: "prompt",
+		Context: map[string]string{
+			agent.ContextKeySBIID: sbiID,
+			agent.ContextKeyStep:  step,
+			agent.ContextKeyTurn:  turn,
+		},
+	}
+}
+
+func loadSimulationFixture(t *testing.T, content string) *agent.SimulationGateway {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "simulation.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	gateway, err := agent.LoadSimulationGateway(path)
+	if err != nil {
+		t.Fatalf("LoadSimulationGateway() error = %v", err)
+	}
+	return gateway
+}
+
+func TestSimulationGateway_ReplaysScriptPerSBI(t *testing.T) {
+	gateway := loadSimulationFixture(t, simulationFixture)
+
+	type report struct {
+		sbiID, step, decision, content string
+		turn                           int
+	}
+	var reports []report
+	gateway.SetReporter(func(ctx context.Context, sbiID string, turn int, step, decision, content string) error {
+		reports = append(reports, report{sbiID, step, decision, content, turn})
+		return nil
+	})
+
+	ctx := context.Background()
+	resp, err := gateway.Execute(ctx, simulationRequest("SBI-A", "implement", "3"))
+	if err != nil {
+		t.Fatalf("Execute(implement) error = %v", err)
+	}
+	if resp.Output != "first attempt" || resp.AgentType != "simulation" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	if _, err := gateway.Execute(ctx, simulationRequest("SBI-A", "review", "4")); err != nil {
+		t.Fatalf("Execute(review) error = %v", err)
+	}
+
+	// Each SBI replays the default script from the start
+	if _, err := gateway.Execute(ctx, simulationRequest("SBI-B", "implement", "3")); err != nil {
+		t.Fatalf("Execute(SBI-B implement) error = %v", err)
+	}
+
+	if len(reports) != 3 {
+		t.Fatalf("reports = %d, want 3", len(reports))
+	}
+	if r := reports[1]; r.sbiID != "SBI-A" || r.step != "review" || r.decision != "NEEDS_CHANGES" || r.turn != 4 {
+		t.Errorf("unexpected review report: %+v", r)
+	}
+
+	// Exhausted script approves reviews
+	if _, err := gateway.Execute(ctx, simulationRequest("SBI-A", "implement", "5")); err != nil {
+		t.Fatalf("Execute(exhausted implement) error = %v", err)
+	}
+	if _, err := gateway.Execute(ctx, simulationRequest("SBI-A", "review", "6")); err != nil {
+		t.Fatalf("Execute(exhausted review) error = %v", err)
+	}
+	if r := reports[len(reports)-1]; r.decision != "SUCCEEDED" {
+		t.Errorf("exhausted review decision = %s, want SUCCEEDED", r.decision)
+	}
+}
+
+func TestSimulationGateway_ScriptedErrorAndMismatch(t *testing.T) {
+	gateway := loadSimulationFixture(t, simulationFixture)
+	ctx := context.Background()
+
+	_, err := gateway.Execute(ctx, simulationRequest("SBI-CRASH", "implement", "3"))
+	if err == nil || !strings.Contains(err.Error(), "agent crashed") {
+		t.Errorf("expected scripted error, got %v", err)
+	}
+
+	_, err = gateway.Execute(ctx, simulationRequest("SBI-A", "review", "3"))
+	if err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("expected step mismatch error, got %v", err)
+	}
+}
+
+func TestLoadSimulationGateway_InvalidFixture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "simulation.yaml")
+	content := "steps:\n  - step: review\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	if _, err := agent.LoadSimulationGateway(path); err == nil {
+		t.Error("expected error for review step without decision")
+	}
+}
@@ -0,0 +1,236 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// SMTP connection security
+const (
+	EmailTLSStartTLS = "starttls" // Plain connection upgraded with STARTTLS (port 587)
+	EmailTLSImplicit = "tls"      // TLS from the start (SMTPS, port 465)
+	EmailTLSNone     = "none"     // Unencrypted, e.g. a relay on localhost
+)
+
+// defaultEmailTemplate renders any notification without a template of its own
+const defaultEmailTemplate = `{{.Title}}
+{{if .TaskID}}
+Task:  {{.TaskID}}{{end}}
+Level: {{.Level}}
+Time:  {{.Time}}
+{{if .Message}}
+{{.Message}}
+{{end}}{{if .Fields}}
+{{range $key, $value := .Fields}}- {{$key}}: {{$value}}
+{{end}}{{end}}`
+
+// digestEmailTemplate renders the periodic digest of finished and at-risk tasks
+const digestEmailTemplate = `deespec digest from {{index .Fields "since"}} to {{index .Fields "until"}}
+
+Done:    {{index .Fields "done"}}
+Failed:  {{index .Fields "failed"}}
+At risk: {{index .Fields "at_risk"}}
+
+{{.Message}}
+`
+
+// builtinEmailTemplates are the templates of events with their own layout
+var builtinEmailTemplates = map[string]string{
+	"digest": digestEmailTemplate,
+}
+
+// EmailConfig holds the SMTP server and addresses of the email gateway
+type EmailConfig struct {
+	Host        string
+	Port        int
+	TLS         string // EmailTLSStartTLS (default), EmailTLSImplicit or EmailTLSNone
+	Username    string // Empty: no authentication
+	Password    string
+	From        string
+	To          []string
+	TemplateDir string // Optional: <event>.tmpl files (and default.tmpl) overriding the built-in templates
+	Timeout     time.Duration
+}
+
+// EmailNotificationGateway implements NotificationGateway by sending templated emails over SMTP
+// It serves organizations without chat webhooks; templates are text/template files rendered
+// with the notification (Title, Message, TaskID, Level, Event, Time and Fields).
+type EmailNotificationGateway struct {
+	config EmailConfig
+}
+
+// emailTemplateData is what email templates are rendered with
+type emailTemplateData struct {
+	output.Notification
+	Time string
+}
+
+// NewEmailNotificationGateway creates an email gateway
+func NewEmailNotificationGateway(config EmailConfig) *EmailNotificationGateway {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.TLS == "" {
+		config.TLS = EmailTLSStartTLS
+	}
+	return &EmailNotificationGateway{config: config}
+}
+
+// Notify renders the notification and mails it to every recipient
+func (g *EmailNotificationGateway) Notify(ctx context.Context, n output.Notification) error {
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now()
+	}
+	body, err := g.render(n)
+	if err != nil {
+		return err
+	}
+	subject := "[deespec] " + n.Title
+	if n.Level == output.NotificationLevelCritical {
+		subject = "[deespec][critical] " + n.Title
+	}
+	msg, err := buildEmailMessage(g.config.From, g.config.To, subject, body, n.Timestamp)
+	if err != nil {
+		return err
+	}
+	if err := g.send(ctx, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// render executes the template of the notification's event
+func (g *EmailNotificationGateway) render(n output.Notification) (string, error) {
+	name, text := n.Event, builtinEmailTemplates[n.Event]
+	if override, ok := g.loadTemplate(n.Event); ok {
+		text = override
+	} else if text == "" {
+		name, text = "default", defaultEmailTemplate
+		if override, ok := g.loadTemplate("default"); ok {
+			text = override
+		}
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse email template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	data := emailTemplateData{Notification: n, Time: n.Timestamp.Local().Format("2006-01-02 15:04 MST")}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render email template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// loadTemplate reads <name>.tmpl from the template directory
+func (g *EmailNotificationGateway) loadTemplate(name string) (string, bool) {
+	if g.config.TemplateDir == "" || name == "" {
+		return "", false
+	}
+	content, err := os.ReadFile(filepath.Join(g.config.TemplateDir, name+".tmpl"))
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+// send delivers the message through the SMTP server
+func (g *EmailNotificationGateway) send(ctx context.Context, msg []byte) error {
+	cfg := g.config
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	var err error
+	if cfg.TLS == EmailTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: cfg.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if cfg.TLS == EmailTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("server does not support STARTTLS (set tls to \"tls\" or \"none\")")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("authenticate: %w", err)
+		}
+	}
+	if err := client.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, to := range cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient %s: %w", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildEmailMessage builds a plain text UTF-8 message with quoted-printable body
+func buildEmailMessage(from string, to []string, subject, body string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	body = strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("encode email body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("encode email body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// LogNotificationGateway implements NotificationGateway by writing to a log stream
+// This is the default gateway when no external notification channel is configured
+type LogNotificationGateway struct {
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+// NewLogNotificationGateway creates a gateway writing to the given writer (stderr if nil)
+func NewLogNotificationGateway(writer io.Writer) *LogNotificationGateway {
+	if writer == nil {
+		writer = os.Stderr
+	}
+	return &LogNotificationGateway{writer: writer}
+}
+
+// Notify writes the notification as a single log line
+func (g *LogNotificationGateway) Notify(ctx context.Context, n output.Notification) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s [notify:%s] %s", levelIcon(n.Level), n.Level, n.Title))
+	if n.TaskID != "" {
+		sb.WriteString(fmt.Sprintf(" (task: %s)", n.TaskID))
+	}
+	if n.Message != "" {
+		sb.WriteString(" - ")
+		sb.WriteString(n.Message)
+	}
+
+	keys := make([]string, 0, len(n.Fields))
+	for k := range n.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf(" %s=%s", k, n.Fields[k]))
+	}
+	sb.WriteString("\n")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, err := io.WriteString(g.writer, sb.String()); err != nil {
+		return fmt.Errorf("write notification: %w", err)
+	}
+	return nil
+}
+
+// levelIcon returns an icon matching the notification level
+func levelIcon(level output.NotificationLevel) string {
+	switch level {
+	case output.NotificationLevelCritical:
+		return "🚨"
+	case output.NotificationLevelWarning:
+		return "⚠️ "
+	default:
+		return "ℹ️ "
+	}
+}
@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// MultiNotificationGateway implements NotificationGateway by sending to several channels
+// Every channel is tried even when one fails, so a broken webhook does not swallow emails.
+type MultiNotificationGateway struct {
+	gateways []output.NotificationGateway
+}
+
+// NewMultiNotificationGateway creates a gateway fanning out to the given gateways
+func NewMultiNotificationGateway(gateways ...output.NotificationGateway) *MultiNotificationGateway {
+	return &MultiNotificationGateway{gateways: gateways}
+}
+
+// Notify sends the notification to every gateway and joins their errors
+func (g *MultiNotificationGateway) Notify(ctx context.Context, n output.Notification) error {
+	var errs []error
+	for _, gateway := range g.gateways {
+		if err := gateway.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,237 @@
+package notification
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogNotificationGateway_Notify(t *testing.T) {
+	var buf bytes.Buffer
+	gateway := NewLogNotificationGateway(&buf)
+
+	err := gateway.Notify(context.Background(), output.Notification{
+		Level:   output.NotificationLevelWarning,
+		Event:   "task.overdue",
+		Title:   "SBI is overdue",
+		Message: "Deadline passed",
+		TaskID:  "SBI-001",
+		Fields:  map[string]string{"status": "IMPLEMENTING", "deadline": "2025-10-20"},
+	})
+	require.NoError(t, err)
+
+	line := buf.String()
+	assert.Contains(t, line, "[notify:warning] SBI is overdue (task: SBI-001) - Deadline passed")
+	assert.Contains(t, line, "deadline=2025-10-20 status=IMPLEMENTING")
+}
+
+func TestWebhookNotificationGateway_Notify(t *testing.T) {
+	var received webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	gateway := NewWebhookNotificationGateway(server.URL, time.Second)
+	err := gateway.Notify(context.Background(), output.Notification{
+		Level:  output.NotificationLevelCritical,
+		Event:  "task.overdue",
+		Title:  "SBI is overdue",
+		TaskID: "SBI-001",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "critical", received.Level)
+	assert.Equal(t, "task.overdue", received.Event)
+	assert.Equal(t, "SBI-001", received.TaskID)
+	assert.Equal(t, "[critical] SBI is overdue", received.Text)
+	assert.NotEmpty(t, received.Timestamp)
+}
+
+func TestWebhookNotificationGateway_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	gateway := NewWebhookNotificationGateway(server.URL, time.Second)
+	err := gateway.Notify(context.Background(), output.Notification{Title: "test"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+// fakeSMTPServer accepts one unencrypted SMTP session and records the envelope and message
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		defer close(s.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				s.from = strings.Trim(strings.TrimPrefix(cmd, "MAIL FROM:"), "<>")
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				s.to = append(s.to, strings.Trim(strings.TrimPrefix(cmd, "RCPT TO:"), "<>"))
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				s.data = data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) port(t *testing.T) int {
+	_, port, err := net.SplitHostPort(s.listener.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return p
+}
+
+// body decodes the quoted-printable body of the received message
+func (s *fakeSMTPServer) body(t *testing.T) string {
+	parts := strings.SplitN(s.data, "\r\n\r\n", 2)
+	require.Len(t, parts, 2)
+	var buf bytes.Buffer
+	_, err := buf.ReadFrom(quotedprintable.NewReader(strings.NewReader(parts[1])))
+	require.NoError(t, err)
+	return strings.ReplaceAll(buf.String(), "\r\n", "\n")
+}
+
+func TestEmailNotificationGateway_Notify(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	gateway := NewEmailNotificationGateway(EmailConfig{
+		Host: "127.0.0.1",
+		Port: server.port(t),
+		TLS:  EmailTLSNone,
+		From: "deespec@example.com",
+		To:   []string{"team@example.com", "lead@example.com"},
+	})
+
+	err := gateway.Notify(context.Background(), output.Notification{
+		Level:   output.NotificationLevelCritical,
+		Event:   "task.overdue",
+		Title:   "SBI is overdue",
+		Message: "Deadline passed",
+		TaskID:  "SBI-001",
+		Fields:  map[string]string{"status": "IMPLEMENTING", "deadline": "2025-10-20"},
+	})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, "deespec@example.com", server.from)
+	assert.Equal(t, []string{"team@example.com", "lead@example.com"}, server.to)
+	assert.Contains(t, server.data, "Subject: [deespec][critical] SBI is overdue\r\n")
+	assert.Contains(t, server.data, "To: team@example.com, lead@example.com\r\n")
+	body := server.body(t)
+	assert.Contains(t, body, "Task:  SBI-001\n")
+	assert.Contains(t, body, "Deadline passed\n")
+	assert.Contains(t, body, "- deadline: 2025-10-20\n- status: IMPLEMENTING\n")
+}
+
+func TestEmailNotificationGateway_Templates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "task.at_risk.tmpl"), []byte("At risk: {{.Title}} ({{index .Fields \"turn\"}})"), 0644))
+	gateway := NewEmailNotificationGateway(EmailConfig{TemplateDir: dir})
+
+	body, err := gateway.render(output.Notification{Event: "task.at_risk", Title: "Login", Fields: map[string]string{"turn": "7"}})
+	require.NoError(t, err)
+	assert.Equal(t, "At risk: Login (7)", body)
+
+	body, err = gateway.render(output.Notification{
+		Event:   "digest",
+		Title:   "Digest: 1 done",
+		Message: "Done (1):\n- Login",
+		Fields:  map[string]string{"since": "2025-10-20 09:00", "until": "2025-10-21 09:00", "done": "1", "failed": "0", "at_risk": "0"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "deespec digest from 2025-10-20 09:00 to 2025-10-21 09:00")
+	assert.Contains(t, body, "Done:    1\n")
+	assert.Contains(t, body, "- Login")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default.tmpl"), []byte("{{.Broken"), 0644))
+	_, err = gateway.render(output.Notification{Event: "task.overdue"})
+	assert.Error(t, err)
+}
+
+// failingNotifier always fails
+type failingNotifier struct{ calls int }
+
+func (n *failingNotifier) Notify(ctx context.Context, notification output.Notification) error {
+	n.calls++
+	return errors.New("unreachable")
+}
+
+func TestMultiNotificationGateway_Notify(t *testing.T) {
+	var buf bytes.Buffer
+	failing := &failingNotifier{}
+	gateway := NewMultiNotificationGateway(failing, NewLogNotificationGateway(&buf))
+
+	err := gateway.Notify(context.Background(), output.Notification{Title: "SBI is overdue"})
+	assert.EqualError(t, err, "unreachable")
+	assert.Equal(t, 1, failing.calls)
+	assert.Contains(t, buf.String(), "SBI is overdue", "later gateways are notified despite the failure")
+}
@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// WebhookNotificationGateway implements NotificationGateway by POSTing JSON to a webhook URL
+// The payload includes a "text" field so that Slack-compatible incoming webhooks render it as-is
+type WebhookNotificationGateway struct {
+	url    string
+	client *http.Client
+}
+
+// webhookPayload is the JSON body sent to the webhook
+type webhookPayload struct {
+	Text      string            `json:"text"`
+	Level     string            `json:"level"`
+	Event     string            `json:"event"`
+	Title     string            `json:"title"`
+	Message   string            `json:"message,omitempty"`
+	TaskID    string            `json:"task_id,omitempty"`
+	Timestamp string            `json:"timestamp"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// NewWebhookNotificationGateway creates a webhook gateway
+func NewWebhookNotificationGateway(url string, timeout time.Duration) *WebhookNotificationGateway {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &WebhookNotificationGateway{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts the notification to the webhook
+func (g *WebhookNotificationGateway) Notify(ctx context.Context, n output.Notification) error {
+	timestamp := n.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	text := fmt.Sprintf("[%s] %s", n.Level, n.Title)
+	if n.Message != "" {
+		text += "\n" + n.Message
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Text:      text,
+		Level:     string(n.Level),
+		Event:     n.Event,
+		Title:     n.Title,
+		Message:   n.Message,
+		TaskID:    n.TaskID,
+		Timestamp: timestamp.UTC().Format(time.RFC3339),
+		Fields:    n.Fields,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalArtifactStore_PutGet(t *testing.T) {
+	ctx := context.Background()
+	baseDir := filepath.Join(t.TempDir(), ".deespec")
+	store := NewLocalArtifactStore(baseDir)
+
+	uri, err := store.Put(ctx, "reports/sbi/SBI-001/implement_1.md", []byte("# Report"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(baseDir, "reports", "sbi", "SBI-001", "implement_1.md"), uri)
+
+	content, err := store.Get(ctx, uri)
+	require.NoError(t, err)
+	assert.Equal(t, "# Report", string(content))
+
+	content, err = store.Get(ctx, "file://"+uri)
+	require.NoError(t, err)
+	assert.Equal(t, "# Report", string(content))
+
+	_, err = store.Get(ctx, "s3://bucket/key")
+	assert.Error(t, err)
+}
+
+func TestLocalArtifactStore_RejectsEscapingKey(t *testing.T) {
+	store := NewLocalArtifactStore(t.TempDir())
+
+	_, err := store.Put(context.Background(), "../outside.md", []byte("x"))
+	assert.Error(t, err)
+}
+
+func TestS3ArtifactStore_PutGet(t *testing.T) {
+	ctx := context.Background()
+	client := NewMockS3Client()
+	store := NewS3ArtifactStoreWithClient(client, "ci-artifacts", "deespec/run-42/")
+
+	uri, err := store.Put(ctx, "reports/sbi/SBI-001/review_1.md", []byte("## Decision"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3://ci-artifacts/deespec/run-42/reports/sbi/SBI-001/review_1.md", uri)
+	assert.Equal(t, uri, store.URI("reports/sbi/SBI-001/review_1.md"))
+
+	content, err := store.Get(ctx, uri)
+	require.NoError(t, err)
+	assert.Equal(t, "## Decision", string(content))
+
+	_, err = store.Get(ctx, "s3://ci-artifacts")
+	assert.Error(t, err)
+}
+
+func TestGCSArtifactStore_PutGet(t *testing.T) {
+	ctx := context.Background()
+	objects := map[string][]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		requestPath := strings.TrimPrefix(r.URL.Path, "/")
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "upload/storage/v1/b/ci-artifacts/o", requestPath)
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Query().Get("name")] = body
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		case http.MethodGet:
+			name := strings.TrimPrefix(requestPath, "storage/v1/b/ci-artifacts/o/")
+			content, ok := objects[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(content)
+		}
+	}))
+	defer server.Close()
+
+	store := NewGCSArtifactStore(GCSConfig{
+		BucketName: "ci-artifacts",
+		Prefix:     "deespec",
+		Endpoint:   server.URL,
+		TokenFunc:  func(ctx context.Context) (string, error) { return "test-token", nil },
+	})
+
+	uri, err := store.Put(ctx, "reports/sbi/SBI-001/implement_1.md", []byte("# Report"))
+	require.NoError(t, err)
+	assert.Equal(t, "gs://ci-artifacts/deespec/reports/sbi/SBI-001/implement_1.md", uri)
+
+	content, err := store.Get(ctx, uri)
+	require.NoError(t, err)
+	assert.Equal(t, "# Report", string(content))
+
+	_, err = store.Get(ctx, "gs://ci-artifacts/missing.md")
+	assert.Error(t, err)
+}
+
+func TestWriteThroughArtifactStore(t *testing.T) {
+	ctx := context.Background()
+	baseDir := filepath.Join(t.TempDir(), ".deespec")
+	local := NewLocalArtifactStore(baseDir)
+	remote := NewS3ArtifactStoreWithClient(NewMockS3Client(), "ci-artifacts", "")
+	store := NewWriteThroughArtifactStore(local, remote)
+
+	uri, err := store.Put(ctx, "reports/sbi/SBI-001/implement_1.md", []byte("# Report"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3://ci-artifacts/reports/sbi/SBI-001/implement_1.md", uri)
+
+	// Remote URI resolves via the remote store
+	content, err := store.Get(ctx, uri)
+	require.NoError(t, err)
+	assert.Equal(t, "# Report", string(content))
+
+	// Local working copy is kept for agents
+	localPath := local.URI("reports/sbi/SBI-001/implement_1.md")
+	content, err = store.Get(ctx, localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Report", string(content))
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// gcsURIPrefix is the URI scheme for Google Cloud Storage artifacts
+const gcsURIPrefix = "gs://"
+
+// defaultGCSEndpoint is the Google Cloud Storage JSON API endpoint
+const defaultGCSEndpoint = "https://storage.googleapis.com"
+
+// GCSArtifactStore implements ArtifactStore using the Google Cloud Storage JSON API
+// Keys are stored as gs://<bucket>/<prefix>/<key>
+// Authentication uses OAuth2 access tokens provided by TokenFunc; by default they come from
+// Application Default Credentials or the GCP metadata server and are refreshed before expiry
+type GCSArtifactStore struct {
+	bucketName string
+	prefix     string
+	endpoint   string
+	client     *http.Client
+	tokenFunc  func(ctx context.Context) (string, error)
+}
+
+// GCSConfig holds GCS artifact store configuration
+type GCSConfig struct {
+	BucketName string                                    // GCS bucket name
+	Prefix     string                                    // Optional object name prefix
+	Endpoint   string                                    // API endpoint (optional, for emulators/tests)
+	TokenFunc  func(ctx context.Context) (string, error) // Access token provider (optional, defaults to ADC)
+}
+
+// NewGCSArtifactStore creates a GCS artifact store
+func NewGCSArtifactStore(cfg GCSConfig) *GCSArtifactStore {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultGCSEndpoint
+	}
+	tokenFunc := cfg.TokenFunc
+	if tokenFunc == nil {
+		tokenFunc = defaultGCSTokenFunc()
+	}
+
+	return &GCSArtifactStore{
+		bucketName: cfg.BucketName,
+		prefix:     strings.Trim(cfg.Prefix, "/"),
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		client:     &http.Client{Timeout: 60 * time.Second},
+		tokenFunc:  tokenFunc,
+	}
+}
+
+// Put uploads content to GCS and returns its gs:// URI
+func (s *GCSArtifactStore) Put(ctx context.Context, key string, content []byte) (string, error) {
+	cleanKey, err := cleanArtifactKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	objectName := joinObjectKey(s.prefix, cleanKey)
+	uploadURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		s.endpoint, url.PathEscape(s.bucketName), url.QueryEscape(objectName))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("create GCS upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentTypeForKey(cleanKey))
+
+	if _, err := s.do(ctx, req); err != nil {
+		return "", fmt.Errorf("upload artifact to GCS: %w", err)
+	}
+	return gcsURIPrefix + s.bucketName + "/" + objectName, nil
+}
+
+// Get downloads content for a gs:// URI
+// Plain local paths are read from the filesystem for journals written before offloading
+func (s *GCSArtifactStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, gcsURIPrefix) {
+		return readLocalArtifact(uri)
+	}
+
+	bucket, objectName, err := splitBucketURI(uri, gcsURIPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadURL := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		s.endpoint, url.PathEscape(bucket), url.PathEscape(objectName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create GCS download request: %w", err)
+	}
+
+	content, err := s.do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("download artifact from GCS: %w", err)
+	}
+	return content, nil
+}
+
+// URI returns the gs:// URI for a key
+func (s *GCSArtifactStore) URI(key string) string {
+	return gcsURIPrefix + s.bucketName + "/" + joinObjectKey(s.prefix, key)
+}
+
+// LocalPath returns "" since GCS keeps no local copy
+func (s *GCSArtifactStore) LocalPath(key string) string {
+	return ""
+}
+
+// do sends an authenticated request and returns the response body
+func (s *GCSArtifactStore) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	token, err := s.tokenFunc(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get access token: %w", err)
+	}
+	if token == "" {
+		return nil, errNoGCSCredentials
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("GCS returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
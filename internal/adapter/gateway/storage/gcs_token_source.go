@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// gcsScope is the OAuth2 scope needed to read and write artifacts
+	gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+	// defaultGoogleTokenURL is the OAuth2 token endpoint for user and service account credentials
+	defaultGoogleTokenURL = "https://oauth2.googleapis.com/token"
+
+	// defaultMetadataTokenURL is the GCE/GKE/Cloud Run metadata server token endpoint
+	defaultMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// tokenRefreshMargin refreshes tokens this long before they expire
+	tokenRefreshMargin = time.Minute
+)
+
+// errNoGCSCredentials is returned when no GCS credentials can be found
+var errNoGCSCredentials = errors.New("no GCS credentials found: set GOOGLE_APPLICATION_CREDENTIALS, run `gcloud auth application-default login`, run on GCP, or set DEESPEC_GCS_ACCESS_TOKEN")
+
+// fetchTokenFunc obtains a new access token and its lifetime
+type fetchTokenFunc func(ctx context.Context) (token string, expiresIn time.Duration, err error)
+
+// cachingTokenSource caches an access token and refreshes it shortly before it expires
+type cachingTokenSource struct {
+	fetch  fetchTokenFunc
+	now    func() time.Time
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// newCachingTokenSource creates a token source that refreshes through fetch
+func newCachingTokenSource(fetch fetchTokenFunc) *cachingTokenSource {
+	return &cachingTokenSource{fetch: fetch, now: time.Now}
+}
+
+// Token returns a valid access token, refreshing it when it is about to expire
+func (s *cachingTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Before(s.expiry.Add(-tokenRefreshMargin)) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("token endpoint returned an empty access token")
+	}
+	s.token = token
+	s.expiry = s.now().Add(expiresIn)
+	return token, nil
+}
+
+// defaultGCSTokenFunc resolves credentials the way Google client libraries do:
+//  1. DEESPEC_GCS_ACCESS_TOKEN / GOOGLE_OAUTH_ACCESS_TOKEN (static, not refreshed; for short commands)
+//  2. Application Default Credentials file (GOOGLE_APPLICATION_CREDENTIALS or gcloud's well-known file)
+//  3. The GCP metadata server
+//
+// Tokens from 2 and 3 are refreshed before they expire, so long `deespec run` sessions keep uploading.
+func defaultGCSTokenFunc() func(ctx context.Context) (string, error) {
+	for _, name := range []string{"DEESPEC_GCS_ACCESS_TOKEN", "GOOGLE_OAUTH_ACCESS_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return func(ctx context.Context) (string, error) { return token, nil }
+		}
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if path := adcFilePath(); path != "" {
+		fetch, err := credentialsFileFetcher(client, path)
+		if err != nil {
+			return func(ctx context.Context) (string, error) { return "", err }
+		}
+		return newCachingTokenSource(fetch).Token
+	}
+
+	metadata := newCachingTokenSource(metadataFetcher(client, defaultMetadataTokenURL))
+	return func(ctx context.Context) (string, error) {
+		token, err := metadata.Token(ctx)
+		if err != nil {
+			return "", fmt.Errorf("%w (metadata server: %v)", errNoGCSCredentials, err)
+		}
+		return token, nil
+	}
+}
+
+// adcFilePath returns the Application Default Credentials file, or "" if there is none
+func adcFilePath() string {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return path
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	path := filepath.Join(configDir, "gcloud", "application_default_credentials.json")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// credentialsFile is the subset of an ADC JSON file used for token refresh
+type credentialsFile struct {
+	Type string `json:"type"`
+
+	// authorized_user
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+
+	// service_account
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// credentialsFileFetcher builds a token fetcher for an authorized_user or service_account file
+func credentialsFileFetcher(client *http.Client, path string) (fetchTokenFunc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read GCS credentials %s: %w", path, err)
+	}
+	var creds credentialsFile
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("parse GCS credentials %s: %w", path, err)
+	}
+
+	tokenURL := creds.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultGoogleTokenURL
+	}
+
+	switch creds.Type {
+	case "authorized_user":
+		return func(ctx context.Context) (string, time.Duration, error) {
+			return postTokenRequest(ctx, client, tokenURL, url.Values{
+				"grant_type":    {"refresh_token"},
+				"client_id":     {creds.ClientID},
+				"client_secret": {creds.ClientSecret},
+				"refresh_token": {creds.RefreshToken},
+			})
+		}, nil
+
+	case "service_account":
+		key, err := parseRSAPrivateKey(creds.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse GCS credentials %s: %w", path, err)
+		}
+		return func(ctx context.Context) (string, time.Duration, error) {
+			assertion, err := signJWTAssertion(key, creds.PrivateKeyID, creds.ClientEmail, tokenURL, time.Now())
+			if err != nil {
+				return "", 0, err
+			}
+			return postTokenRequest(ctx, client, tokenURL, url.Values{
+				"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+				"assertion":  {assertion},
+			})
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported GCS credentials type %q in %s (must be authorized_user or service_account)", creds.Type, path)
+	}
+}
+
+// metadataFetcher builds a token fetcher for the GCP metadata server
+func metadataFetcher(client *http.Client, tokenURL string) fetchTokenFunc {
+	return func(ctx context.Context) (string, time.Duration, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+		if err != nil {
+			return "", 0, err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+		return doTokenRequest(client, req)
+	}
+}
+
+// postTokenRequest posts an OAuth2 token request form
+func postTokenRequest(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (string, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doTokenRequest(client, req)
+}
+
+// doTokenRequest sends a token request and decodes access_token and expires_in
+func doTokenRequest(client *http.Client, req *http.Request) (string, time.Duration, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", 0, fmt.Errorf("parse token response: %w", err)
+	}
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS#8 or PKCS#1 RSA key
+func parseRSAPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("private_key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private_key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private_key is not an RSA key")
+	}
+	return key, nil
+}
+
+// signJWTAssertion creates the RS256 signed JWT used in the service account token grant
+func signJWTAssertion(key *rsa.PrivateKey, keyID, email, audience string, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   email,
+		"scope": gcsScope,
+		"aud":   audience,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign JWT assertion: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	fetches := 0
+
+	source := newCachingTokenSource(func(ctx context.Context) (string, time.Duration, error) {
+		fetches++
+		return fmt.Sprintf("token-%d", fetches), time.Hour, nil
+	})
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Cached while valid
+	now = now.Add(30 * time.Minute)
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Refreshed within the margin before expiry
+	now = now.Add(30*time.Minute - tokenRefreshMargin)
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestCachingTokenSource_EmptyTokenIsError(t *testing.T) {
+	source := newCachingTokenSource(func(ctx context.Context) (string, time.Duration, error) {
+		return "", time.Hour, nil
+	})
+	_, err := source.Token(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCredentialsFileFetcher(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.PostForm.Get("grant_type") {
+		case "refresh_token":
+			assert.Equal(t, "user-refresh", r.PostForm.Get("refresh_token"))
+			_, _ = w.Write([]byte(`{"access_token":"user-token","expires_in":3599}`))
+		case "urn:ietf:params:oauth:grant-type:jwt-bearer":
+			assert.Len(t, strings.Split(r.PostForm.Get("assertion"), "."), 3)
+			_, _ = w.Write([]byte(`{"access_token":"sa-token","expires_in":3599}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	dir := t.TempDir()
+	writeCreds := func(name string, creds map[string]string) string {
+		data, err := json.Marshal(creds)
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+
+	userPath := writeCreds("user.json", map[string]string{
+		"type": "authorized_user", "client_id": "id", "client_secret": "secret",
+		"refresh_token": "user-refresh", "token_uri": server.URL,
+	})
+	fetch, err := credentialsFileFetcher(server.Client(), userPath)
+	require.NoError(t, err)
+	token, expiresIn, err := fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user-token", token)
+	assert.Equal(t, 3599*time.Second, expiresIn)
+
+	saPath := writeCreds("sa.json", map[string]string{
+		"type": "service_account", "client_email": "ci@example.iam.gserviceaccount.com",
+		"private_key": string(keyPEM), "token_uri": server.URL,
+	})
+	fetch, err = credentialsFileFetcher(server.Client(), saPath)
+	require.NoError(t, err)
+	token, _, err = fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "sa-token", token)
+
+	_, err = credentialsFileFetcher(server.Client(), writeCreds("ext.json", map[string]string{"type": "external_account"}))
+	assert.Error(t, err)
+}
+
+func TestGCSArtifactStore_NoTokenIsError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	store := NewGCSArtifactStore(GCSConfig{
+		BucketName: "ci-artifacts",
+		Endpoint:   server.URL,
+		TokenFunc:  func(ctx context.Context) (string, error) { return "", nil },
+	})
+
+	_, err := store.Put(context.Background(), "reports/sbi/SBI-001/implement_1.md", []byte("# Report"))
+	assert.ErrorIs(t, err, errNoGCSCredentials)
+	assert.Zero(t, requests, "unauthenticated requests must not be sent")
+}
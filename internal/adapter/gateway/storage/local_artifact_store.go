@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+// fileURIPrefix is accepted by Get for explicit local URIs
+const fileURIPrefix = "file://"
+
+// LocalArtifactStore implements ArtifactStore using the local filesystem
+// URIs are plain paths (baseDir joined with the key) to stay compatible with
+// journals written before object storage support
+type LocalArtifactStore struct {
+	baseDir string
+}
+
+// NewLocalArtifactStore creates a local artifact store rooted at baseDir (e.g., ".deespec")
+func NewLocalArtifactStore(baseDir string) *LocalArtifactStore {
+	return &LocalArtifactStore{baseDir: baseDir}
+}
+
+// Put writes content to baseDir/key and returns the file path
+func (s *LocalArtifactStore) Put(ctx context.Context, key string, content []byte) (string, error) {
+	cleanKey, err := cleanArtifactKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	filePath := s.URI(cleanKey)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("create artifact directory: %w", err)
+	}
+	if err := fs.WriteFileAtomic(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("write artifact file: %w", err)
+	}
+	return filePath, nil
+}
+
+// Get reads content from a local path or file:// URI
+func (s *LocalArtifactStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	return readLocalArtifact(uri)
+}
+
+// URI returns the local path for a key
+func (s *LocalArtifactStore) URI(key string) string {
+	return filepath.Join(s.baseDir, filepath.FromSlash(key))
+}
+
+// LocalPath returns the file path for a key (same as URI)
+func (s *LocalArtifactStore) LocalPath(key string) string {
+	return s.URI(key)
+}
+
+// readLocalArtifact reads a plain path or file:// URI
+func readLocalArtifact(uri string) ([]byte, error) {
+	if hasRemoteScheme(uri) {
+		return nil, fmt.Errorf("unsupported artifact URI for local store: %s", uri)
+	}
+	content, err := os.ReadFile(strings.TrimPrefix(uri, fileURIPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("read artifact file: %w", err)
+	}
+	return content, nil
+}
+
+// hasRemoteScheme reports whether the URI points to a non-local store
+func hasRemoteScheme(uri string) bool {
+	idx := strings.Index(uri, "://")
+	return idx > 0 && !strings.HasPrefix(uri, fileURIPrefix)
+}
+
+// cleanArtifactKey normalizes a key and rejects keys escaping the store root
+func cleanArtifactKey(key string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(filepath.ToSlash(key), "/"))
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid artifact key: %q", key)
+	}
+	return cleaned, nil
+}
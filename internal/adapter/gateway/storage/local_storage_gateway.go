@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 )
 
 // LocalStorageGateway implements StorageGateway using local filesystem
@@ -48,7 +49,7 @@ func (g *LocalStorageGateway) SaveArtifact(ctx context.Context, req output.SaveA
 
 	// Save artifact content
 	contentPath := filepath.Join(artifactDir, "content")
-	if err := os.WriteFile(contentPath, req.Content, 0644); err != nil {
+	if err := fs.WriteFileAtomic(contentPath, req.Content, 0644); err != nil {
 		return nil, fmt.Errorf("write artifact content: %w", err)
 	}
 
@@ -70,7 +71,7 @@ func (g *LocalStorageGateway) SaveArtifact(ctx context.Context, req output.SaveA
 	if err != nil {
 		return nil, fmt.Errorf("marshal metadata: %w", err)
 	}
-	if err := os.WriteFile(metadataPath, metadataJSON, 0644); err != nil {
+	if err := fs.WriteFileAtomic(metadataPath, metadataJSON, 0644); err != nil {
 		return nil, fmt.Errorf("write metadata: %w", err)
 	}
 
@@ -228,7 +229,7 @@ func (g *LocalStorageGateway) EnsureInstructionFile(instructionPath, content str
 	}
 
 	// Write content
-	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+	if err := fs.WriteFileAtomic(fullPath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("write instruction file: %w", err)
 	}
 
@@ -253,17 +254,17 @@ func (g *LocalStorageGateway) CopyFile(src, dst string) error {
 		return fmt.Errorf("create destination directory: %w", err)
 	}
 
-	// Create destination file
-	dstFile, err := os.Create(dstPath)
+	// Create destination file (replaced only once the copy is complete)
+	dstFile, err := fs.CreateAtomic(dstPath, 0644)
 	if err != nil {
 		return fmt.Errorf("create destination file: %w", err)
 	}
-	defer dstFile.Close()
+	defer dstFile.Abort()
 
 	// Copy content
 	if _, err := io.Copy(dstFile, srcFile); err != nil {
 		return fmt.Errorf("copy file content: %w", err)
 	}
 
-	return nil
+	return dstFile.Commit()
 }
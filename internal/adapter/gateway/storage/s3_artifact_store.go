@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3URIPrefix is the URI scheme for S3 artifacts
+const s3URIPrefix = "s3://"
+
+// S3ArtifactStore implements ArtifactStore using AWS S3
+// Keys are stored as s3://<bucket>/<prefix>/<key>
+type S3ArtifactStore struct {
+	client     S3API
+	bucketName string
+	prefix     string
+}
+
+// NewS3ArtifactStore creates an S3 artifact store using the default AWS credential chain
+func NewS3ArtifactStore(cfg S3Config) (*S3ArtifactStore, error) {
+	awsCfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	if cfg.Region != "" {
+		awsCfg.Region = cfg.Region
+	}
+
+	return NewS3ArtifactStoreWithClient(s3.NewFromConfig(awsCfg), cfg.BucketName, cfg.Prefix), nil
+}
+
+// NewS3ArtifactStoreWithClient creates an S3 artifact store with a custom S3 client
+// This is primarily used for testing with mock S3 clients
+func NewS3ArtifactStoreWithClient(client S3API, bucketName, prefix string) *S3ArtifactStore {
+	return &S3ArtifactStore{
+		client:     client,
+		bucketName: bucketName,
+		prefix:     strings.Trim(prefix, "/"),
+	}
+}
+
+// Put uploads content to S3 and returns its s3:// URI
+func (s *S3ArtifactStore) Put(ctx context.Context, key string, content []byte) (string, error) {
+	cleanKey, err := cleanArtifactKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	objectKey := joinObjectKey(s.prefix, cleanKey)
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(objectKey),
+		Body:        bytes.NewReader(content),
+		ContentType: aws.String(contentTypeForKey(cleanKey)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("upload artifact to S3: %w", err)
+	}
+	return s3URIPrefix + s.bucketName + "/" + objectKey, nil
+}
+
+// Get downloads content for an s3:// URI
+// Plain local paths are read from the filesystem for journals written before offloading
+func (s *S3ArtifactStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, s3URIPrefix) {
+		return readLocalArtifact(uri)
+	}
+
+	bucket, objectKey, err := splitBucketURI(uri, s3URIPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("download artifact from S3: %w", err)
+	}
+	defer obj.Body.Close()
+
+	content, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read artifact content: %w", err)
+	}
+	return content, nil
+}
+
+// URI returns the s3:// URI for a key
+func (s *S3ArtifactStore) URI(key string) string {
+	return s3URIPrefix + s.bucketName + "/" + joinObjectKey(s.prefix, key)
+}
+
+// LocalPath returns "" since S3 keeps no local copy
+func (s *S3ArtifactStore) LocalPath(key string) string {
+	return ""
+}
+
+// joinObjectKey prepends an optional prefix to an object key
+func joinObjectKey(prefix, key string) string {
+	key = strings.TrimPrefix(key, "/")
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
+
+// splitBucketURI splits "<scheme>://<bucket>/<key>" into bucket and key
+func splitBucketURI(uri, scheme string) (string, string, error) {
+	rest := strings.TrimPrefix(uri, scheme)
+	idx := strings.Index(rest, "/")
+	if idx <= 0 || idx == len(rest)-1 {
+		return "", "", fmt.Errorf("invalid artifact URI: %s", uri)
+	}
+	return rest[:idx], rest[idx+1:], nil
+}
+
+// contentTypeForKey guesses the content type from the key extension
+func contentTypeForKey(key string) string {
+	switch {
+	case strings.HasSuffix(key, ".md"):
+		return "text/markdown; charset=utf-8"
+	case strings.HasSuffix(key, ".json"), strings.HasSuffix(key, ".jsonl"):
+		return "application/json"
+	case strings.HasSuffix(key, ".log"), strings.HasSuffix(key, ".txt"):
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
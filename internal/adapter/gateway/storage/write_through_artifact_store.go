@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// WriteThroughArtifactStore keeps a local working copy and offloads to a remote store
+// Agents read previous reports from the working directory, so the local copy is
+// always written; the remote URI is returned for journals and execution logs
+type WriteThroughArtifactStore struct {
+	local  *LocalArtifactStore
+	remote output.ArtifactStore
+}
+
+// NewWriteThroughArtifactStore creates a write-through artifact store
+func NewWriteThroughArtifactStore(local *LocalArtifactStore, remote output.ArtifactStore) *WriteThroughArtifactStore {
+	return &WriteThroughArtifactStore{local: local, remote: remote}
+}
+
+// Put writes the local copy, then uploads to the remote store and returns the remote URI
+func (s *WriteThroughArtifactStore) Put(ctx context.Context, key string, content []byte) (string, error) {
+	if _, err := s.local.Put(ctx, key, content); err != nil {
+		return "", err
+	}
+	uri, err := s.remote.Put(ctx, key, content)
+	if err != nil {
+		return "", fmt.Errorf("offload artifact %s: %w", key, err)
+	}
+	return uri, nil
+}
+
+// Get resolves remote URIs via the remote store and plain paths via the local filesystem
+func (s *WriteThroughArtifactStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	if hasRemoteScheme(uri) {
+		return s.remote.Get(ctx, uri)
+	}
+	return s.local.Get(ctx, uri)
+}
+
+// URI returns the remote URI for a key
+func (s *WriteThroughArtifactStore) URI(key string) string {
+	return s.remote.URI(key)
+}
+
+// LocalPath returns the path of the local working copy
+func (s *WriteThroughArtifactStore) LocalPath(key string) string {
+	return s.local.LocalPath(key)
+}
@@ -27,6 +27,317 @@ type AgentPoolConfig struct {
 	MaxConcurrent map[string]int // Agent type -> max concurrent executions
 }
 
+// NotificationConfig holds notification and deadline escalation settings
+type NotificationConfig struct {
+	WebhookURL               string // 通知先Webhook URL (空の場合はログ出力のみ)
+	DeadlineCheckIntervalSec int    // 期限チェック間隔(秒)
+	EscalationHours          int    // 期限超過からcritical通知までの時間
+	Email                    EmailNotificationConfig
+}
+
+// EmailNotificationConfig holds SMTP settings for email notifications
+type EmailNotificationConfig struct {
+	SMTPHost            string   // SMTPサーバー (空の場合はメール通知なし)
+	SMTPPort            int      // SMTPポート
+	TLS                 string   // 接続の暗号化: "starttls", "tls" (SMTPS), "none"
+	Username            string   // SMTP認証ユーザー (空の場合は認証なし)
+	PasswordEnv         string   // SMTP認証パスワードを読む環境変数名
+	From                string   // 送信元アドレス
+	To                  []string // 宛先アドレス
+	DigestIntervalHours int      // DONE/FAILED/リスクありタスクのダイジェストの送信間隔 (時間, 0で送信しない)
+	TemplateDir         string   // メールテンプレート (<イベント名>.tmpl, default.tmpl) の上書きディレクトリ
+}
+
+// ArtifactStoreConfig holds artifact storage settings
+type ArtifactStoreConfig struct {
+	Type     string // ストレージ種別: "local", "s3", "gcs"
+	Bucket   string // バケット名 (s3/gcs)
+	Prefix   string // オブジェクトキーのプレフィックス
+	Region   string // AWSリージョン (s3のみ, 空の場合はデフォルト)
+	Endpoint string // APIエンドポイント (gcsのみ, エミュレータ用)
+}
+
+// DecompositionConfig holds SBI sizing limits applied during PBI decomposition
+type DecompositionConfig struct {
+	MinEstimatedHours     float64 // SBIの最小推定工数 (0で無効)
+	MaxEstimatedHours     float64 // SBIの最大推定工数 (0で無効)
+	MaxAcceptanceCriteria int     // 受け入れ基準の最大数 (0で無効)
+	MaxTouchedAreas       int     // 変更対象ディレクトリの最大数 (0で無効)
+	RejectOversized       bool    // 上限超過のSBIを却下する (falseの場合は警告のみ)
+}
+
+// DecisionExtractionConfig selects how review decisions are extracted from reports and agent output
+type DecisionExtractionConfig struct {
+	Strategies []string // 判定抽出戦略の適用順 ("json_tail", "front_matter", "regex", "heuristic"; 空の場合はデフォルト順)
+	Pattern    string   // regex戦略のマーカーパターン (空の場合はデフォルト)
+}
+
+// SchedulingConfig holds SBI scheduling constraints applied by the picker
+type SchedulingConfig struct {
+	PBIWIPLimit         int      // 同一PBI内で同時実行できるSBIの最大数 (0で無制限)
+	MaxTurnsPerPBI      int      // 同一PBI配下のSBIで消費できるターン数の合計上限 (0で無制限)
+	MaxAgentCallsPerDay int      // プロジェクト全体で1日 (ローカル時刻) に実行できるエージェント呼び出し数の上限 (0で無制限)
+	ImplementPoolSize   int      // 並列実行時に実装ステップ (PENDING/PICKED/IMPLEMENTING) を同時実行できる数 (0で専用プールなし)
+	ReviewPoolSize      int      // 並列実行時にレビューステップ (REVIEWING) を同時実行できる数 (0で専用プールなし)
+	Windows             []string // ターンを開始できる時間帯 ("22:00-06:00" 形式、ローカル時刻。空で常時実行)
+	AgingIntervalHours  int      // PENDINGのSBIが待ち時間に応じて優先度を1段階上げる間隔 (時間、0でエージングなし)
+	AgingMaxPriority    int      // エージングで到達できる優先度の上限 (0=normal, 1=high, 2=urgent)
+}
+
+// AgentSessionConfig controls agent conversation continuation across turns
+type AgentSessionConfig struct {
+	Enabled bool // 同一SBI・同一ステップの前回の会話を次のターンで継続する (対応エージェントのみ)
+}
+
+// TaskEnvConfig holds values that task env declarations can reference
+type TaskEnvConfig struct {
+	Vars map[string]string // SBI/PBIのenv値から${name}で参照できる値 (機密情報は置かない)
+}
+
+// ReviewerConfig is one reviewer agent in review quorum mode
+type ReviewerConfig struct {
+	Name  string // レビュアー名 (レポートファイル名に使用)
+	Agent string // エージェント種別 (空の場合はデフォルトエージェント)
+	Model string // モデル (空の場合はエージェントのデフォルト)
+}
+
+// ReviewQuorumConfig fans the review step out to several reviewer agents
+type ReviewQuorumConfig struct {
+	Reviewers []ReviewerConfig // レビュアー (2人以上で有効)
+	Rule      string           // 集約ルール ("unanimous", "majority", "any_fail_blocks"; 空の場合はmajority)
+}
+
+// Enabled reports whether review quorum mode is active
+func (c ReviewQuorumConfig) Enabled() bool {
+	return len(c.Reviewers) >= 2
+}
+
+// SelfReviewGuardConfig keeps the review step off the agent and model that implemented the turn
+type SelfReviewGuardConfig struct {
+	Enabled    bool               // 実装と同じエージェント・モデルでレビューしない
+	Alternates []AgentRouteConfig // 代替レビュアー (先頭から順に、実装者と異なるものを使用)
+}
+
+// JournalConfig controls rotation and compaction of the journal
+type JournalConfig struct {
+	RotateMaxMB       int // ジャーナルがこのサイズ (MB) に達したらセグメントへローテーション (0で無効)
+	RotateMaxAgeHours int // 最古のエントリがこの時間を超えたらローテーション (0で無効)
+	KeepFullSegments  int // 圧縮せずに残す最新セグメント数 (0で圧縮しない)
+
+	Sinks          []JournalSinkConfig // ジャーナルレコードの追加送信先 (NDJSONジャーナル自体は常に書き込む)
+	SinkBufferSize int                 // 送信先ごとに障害時に保持するレコード数 (超えると古いものから破棄)
+}
+
+// JournalSinkConfig configures one additional destination of journal records
+type JournalSinkConfig struct {
+	Type       string            // "stdout" (JSON行), "file" (NDJSONファイル), "sqlite" (journal_entriesテーブル), "http" (リモートコレクタ)
+	Path       string            // file: 書き込み先のパス
+	URL        string            // http: NDJSONをPOSTするURL
+	Headers    map[string]string // http: 追加ヘッダ (値の ${VAR} は環境変数で展開)
+	TimeoutSec int               // http: リクエストのタイムアウト秒数 (0で10秒)
+}
+
+// SpecPreflightConfig controls the spec quality check run before an SBI's first implement turn
+type SpecPreflightConfig struct {
+	Mode   string   // "off", "warn" (警告のみ), "block" (警告が解消されるまで実行しない)
+	Ignore []string // 無視するチェック ("missing_acceptance_criteria", "ambiguous_requirement", "no_file_hints")
+}
+
+// ReviewTimeoutConfig controls the review decision SLA of SBIs in REVIEWING
+type ReviewTimeoutConfig struct {
+	TimeoutSec int    // レビュー判定を待つ最大秒数 (0で無効)
+	Policy     string // タイムアウト時の処理: "retry" (差し戻して再実装), "force_implement" (レビュアーが実装して完了)
+}
+
+// OwnershipContextConfig controls the code ownership section of implement prompts
+type OwnershipContextConfig struct {
+	Enabled    bool // 対象ファイルの最近の作成者・関連PRをgit履歴から取得してプロンプトに含める
+	MaxFiles   int  // 注釈するファイル数の上限
+	MaxCommits int  // ファイルごとに参照する最近のコミット数
+}
+
+// ReviewContextConfig controls the context bundle written for review steps
+type ReviewContextConfig struct {
+	Enabled        bool   // レビュー前に差分・テスト結果・実装レポートを1つのファイルにまとめてプロンプトから参照する
+	MaxDiffKB      int    // バンドルに含める差分の上限 (KB)
+	TestCommand    string // テスト結果として実行するコマンド (空の場合は実行しない)
+	TestTimeoutSec int    // テストコマンドのタイムアウト秒数
+}
+
+// AgentCacheConfig controls reuse of agent outputs for identical prompts
+type AgentCacheConfig struct {
+	Enabled  bool     // 同一エージェント・モデル・プロンプト・作業ツリーのレビューを再利用する
+	Steps    []string // 対象ステップ ("review" のみ; ヒット時はキャッシュした判定を提出する)
+	TTLHours int      // キャッシュの有効期間 (時間, 0で無期限)
+}
+
+// FailurePolicyConfig controls how SBIs that exhaust their turn limit terminate
+type FailurePolicyConfig struct {
+	OnLimit    string // ターン上限到達時の終了状態: "done" (強制完了), "failed" (FAILEDとして終了)
+	Postmortem bool   // FAILED終了時に失敗原因を分析するポストモーテムを実行する
+}
+
+// ArtifactRetryConfig controls corrective prompts for step reports the agent did not write
+type ArtifactRetryConfig struct {
+	Attempts int // 成果物ファイルが未作成・空の場合に作成を促す追加プロンプトの回数 (0で即座にエージェント出力を保存)
+}
+
+// TrashConfig controls how long deleted PBIs and SBIs can be restored
+type TrashConfig struct {
+	RetentionDays int // 削除したPBI/SBIをゴミ箱に保持する日数 (経過後に trash purge で完全削除)
+}
+
+// PromptArchiveConfig controls the on-disk archive of the prompts sent to agents
+type PromptArchiveConfig struct {
+	Enabled       bool // 各ステップでエージェントに送ったプロンプトと応答を var/prompts に保存する
+	Compress      bool // gzip 圧縮して保存する
+	RetentionDays int  // 保存期間 (日, 0で無期限); 経過したものは次回の実行時に削除する
+}
+
+// BackupConfig controls the periodic snapshots of the task database
+type BackupConfig struct {
+	Enabled       bool // deespec run が定期的にデータベースのスナップショットを .deespec/backups に保存する
+	IntervalHours int  // スナップショットの間隔 (時間)
+	Keep          int  // 保持する定期スナップショットの数 (古いものから削除)
+	Upload        bool // スナップショットを artifact_store (s3, gcs) にもアップロードする
+}
+
+// TurnTimeBoxConfig controls the wall-clock budget of implement turns
+type TurnTimeBoxConfig struct {
+	Minutes           int // 実装ターンの実行時間の上限 (分, 0で無制限); 超過時はチェックポイントを書かせてターンを終了する
+	CheckpointMinutes int // チェックポイント作成を促す追加プロンプトのタイムアウト (分)
+}
+
+// ModelPolicyRuleConfig requires a workflow step to run on a model family at or above a version
+type ModelPolicyRuleConfig struct {
+	Step       string // 対象ステップ ("implement", "review", "done")
+	Family     string // モデル名の接頭辞 (例: "claude-opus", "gpt")
+	MinVersion string // 許可する最低バージョン (例: "4.1"; 空の場合はファミリー内の全バージョン)
+}
+
+// ModelPolicyConfig controls which models workflow steps may be dispatched to
+type ModelPolicyConfig struct {
+	Rules []ModelPolicyRuleConfig // ステップごとのモデル要件 (違反するエージェントにはディスパッチしない)
+}
+
+// AgentRoutingRuleConfig selects the agent and model of a step for tasks with matching characteristics
+type AgentRoutingRuleConfig struct {
+	Name      string   // ルール名 (ジャーナルとログに記録される)
+	Steps     []string // 対象ステップ ("implement", "review"; 空の場合は両方)
+	Labels    []string // いずれかのラベルを持つタスクに適用
+	MinHours  float64  // 見積もり時間の下限 (0で下限なし)
+	MaxHours  float64  // 見積もり時間の上限 (0で上限なし)
+	FileTypes []string // 対象ファイルがすべてこれらの拡張子のタスクに適用 (例: ".md")
+	Agent     string   // 使用するエージェント (空の場合は既定のエージェント)
+	Model     string   // 使用するモデル (空の場合はエージェントの既定値)
+}
+
+// AgentRoutingConfig controls the automatic choice of agent and model by task characteristics
+type AgentRoutingConfig struct {
+	Rules []AgentRoutingRuleConfig // 最初に一致したルールを使用 (ラベルのエージェントルートが優先)
+}
+
+// PromptExperimentConfig controls the A/B test of a prompt template variant
+type PromptExperimentConfig struct {
+	Enabled  bool   // 実験を有効にする
+	Name     string // 実験名 (ジャーナルとレポートに記録される)
+	Template string // 比較対象のプロンプトテンプレート (例: "WIP.md")
+	Variant  string // 候補テンプレート (.deespec/prompts からの相対パス, 例: "experiments/WIP.v2.md")
+}
+
+// ChangeBudgetConfig caps the code change of a single implement turn
+type ChangeBudgetConfig struct {
+	MaxLines      int    // 1ターンで変更できる行数 (追加+削除, 0で無制限)
+	MaxFiles      int    // 1ターンで変更できるファイル数 (0で無制限)
+	OnExceed      string // 超過時の動作: "split" (変更の分割を促す), "flag" (人間のレビュー対象として通知)
+	SplitAttempts int    // 分割を促す追加プロンプトの回数 (超過したままならフラグを立てる)
+}
+
+// MCPServerConfig is one MCP server agents can be given
+type MCPServerConfig struct {
+	Type    string            // "stdio" (既定), "http", "sse"
+	Command string            // stdio サーバーの実行コマンド
+	Args    []string          // stdio サーバーの引数
+	Env     map[string]string // stdio サーバーの環境変数
+	URL     string            // http/sse サーバーのURL
+	Headers map[string]string // http/sse サーバーへのリクエストヘッダー
+}
+
+// MCPConfig declares the MCP servers that labels can enable for their tasks
+type MCPConfig struct {
+	Servers map[string]MCPServerConfig // サーバー名 -> 設定 (ラベルの mcp_servers から参照)
+}
+
+// TemplateVarConfig is one custom prompt template variable
+type TemplateVarConfig struct {
+	Value   string // 固定値
+	Command string // プロジェクトルートで実行するシェルコマンド (標準出力が値になる)
+}
+
+// TemplateVarsConfig controls the custom variables prompt templates reference as {{.Custom.Name}}
+type TemplateVarsConfig struct {
+	Vars              map[string]TemplateVarConfig // 変数名 -> 値またはコマンド
+	CommandTimeoutSec int                          // コマンドのタイムアウト秒数
+}
+
+// DuplicateCheckConfig controls the near-duplicate check when registering SBIs and PBIs
+type DuplicateCheckConfig struct {
+	Mode      string  // "off", "warn" (警告のみ), "block" (--force がなければ登録しない)
+	Threshold float64 // 重複とみなす類似度 (0より大きく1以下)
+}
+
+// IDSchemeConfig controls how the IDs of new EPICs, PBIs and SBIs are generated
+type IDSchemeConfig struct {
+	Strategy string            // "ulid" (既定), "date_sequence" (例: SBI-2024-0153), "prefix" (例: SBI-0153)
+	Prefixes map[string]string // タスク種別 ("sbi", "pbi", "epic") -> 接頭辞 (既定は種別名)
+	Digits   int               // 連番の桁数 (ゼロ埋め)
+}
+
+// DatabaseEncryptionConfig controls encryption at rest of the task database with SQLCipher
+type DatabaseEncryptionConfig struct {
+	Enabled   bool   // データベースを暗号化する (SQLCipher にリンクしたビルドが必要)
+	KeySource string // 鍵の取得元: "env:NAME", "file:PATH", "cmd:COMMAND" (例: キーチェーンやシークレットマネージャーの CLI)
+}
+
+// ContextWindowConfig controls the pre-check that a prompt and the files it has the agent read
+// fit the context window of the model it is sent to
+type ContextWindowConfig struct {
+	Mode   string         // "off", "summarize" (収まらない場合はプロンプトを要約), "fail" (エージェントを呼ばずに失敗)
+	Models map[string]int // モデル名 -> コンテキストウィンドウ (トークン; 未指定のモデルはエージェントの既定値)
+}
+
+// AgentAPIProviderConfig configures one provider of the HTTP API agent backends
+type AgentAPIProviderConfig struct {
+	BaseURL    string // APIのベースURL (空の場合はプロバイダの公式エンドポイント)
+	APIKeyEnv  string // APIキーを読む環境変数名 (空の場合はプロバイダ既定: ANTHROPIC_API_KEY, OPENAI_API_KEY, GEMINI_API_KEY)
+	Model      string // 既定モデル (空の場合はプロバイダ既定)
+	MaxTokens  int    // 最大出力トークン数 (0の場合はプロバイダ既定)
+	MaxRetries int    // レート制限・サーバーエラー・接続エラー時の再試行回数 (0で再試行しない)
+	TimeoutSec int    // 1回のAPI呼び出しのタイムアウト秒数 (0の場合はステップのタイムアウトのみ)
+}
+
+// AgentAPIConfig configures agents that call provider HTTP APIs instead of vendor CLIs
+type AgentAPIConfig struct {
+	DefaultAgent string                            // 既定のエージェント種別 (空の場合はclaude-code-cli; 例: "anthropic-api")
+	Providers    map[string]AgentAPIProviderConfig // プロバイダ ("anthropic", "openai", "gemini") -> 設定
+}
+
+// OllamaConfig configures the local LLM agent served by Ollama
+type OllamaConfig struct {
+	BaseURL         string // OllamaサーバーのURL (空の場合はOLLAMA_HOSTまたはhttp://localhost:11434)
+	Model           string // 既定モデル (例: "llama3.1")
+	ContextWindow   int    // 使用するコンテキスト長 (トークン, 0の場合はモデルの最大値)
+	MaxOutputTokens int    // 出力用に確保するトークン数 (プロンプトはコンテキスト長からこれを引いた長さに収める)
+	Overflow        string // プロンプトがコンテキストに収まらない場合: "truncate" (中間を省略), "summarize" (中間をモデルで要約)
+	TimeoutSec      int    // 1回の呼び出しのタイムアウト秒数 (0の場合はステップのタイムアウトのみ)
+}
+
+// AgentRouteConfig is an agent and model pair
+type AgentRouteConfig struct {
+	Agent string // エージェント種別 (空の場合はデフォルトエージェント)
+	Model string // モデル (空の場合はエージェントのデフォルト)
+}
+
 // Config provides read-only access to application configuration.
 // This interface abstracts the configuration source (JSON, ENV, defaults)
 // and ensures the app layer doesn't depend on infrastructure details.
@@ -70,12 +381,93 @@ type Config interface {
 	PolicyPath() string  // Policy file path (DEESPEC_POLICY_PATH)
 	StderrLevel() string // Stderr log level (DEESPEC_STDERR_LEVEL)
 
+	// Localization
+	Locale() string // Locale of built-in templates and SBI section names ("ja" or "en")
+
+	// CLI
+	Aliases() map[string]string      // User-defined command aliases (alias -> expansion, e.g. "r" -> "run --parallel 2")
+	StatusLabels() map[string]string // Display labels of SBI statuses (domain status -> label, e.g. "PICKED" -> "Queued")
+
 	// Label system
 	LabelConfig() LabelConfig // Label system configuration
 
 	// Agent pool
 	AgentPoolConfig() AgentPoolConfig // Agent pool concurrency configuration
 
+	// Notification
+	NotificationConfig() NotificationConfig // Notification and deadline escalation configuration
+
+	// Artifact storage
+	ArtifactStoreConfig() ArtifactStoreConfig // Artifact storage backend configuration
+
+	// PBI decomposition
+	DecompositionConfig() DecompositionConfig // SBI sizing limits for decomposition
+
+	// Review decision extraction
+	DecisionExtractionConfig() DecisionExtractionConfig // Decision extraction strategies
+
+	// Scheduling
+	SchedulingConfig() SchedulingConfig // Per-PBI WIP limits and turn budgets for the picker
+
+	// Agent sessions
+	AgentSessionConfig() AgentSessionConfig // Conversation continuation across turns
+
+	// Task environment
+	TaskEnvConfig() TaskEnvConfig // Values referenced by SBI/PBI env declarations
+
+	// Review quorum
+	ReviewQuorumConfig() ReviewQuorumConfig // Multiple reviewer agents per review step
+
+	// Self-review guard
+	SelfReviewGuardConfig() SelfReviewGuardConfig // Reviewer must differ from the implementer
+
+	// Journal
+	JournalConfig() JournalConfig // Journal rotation and compaction
+
+	// Spec pre-flight
+	SpecPreflightConfig() SpecPreflightConfig // Spec quality check before the first turn
+
+	// Review timeout
+	ReviewTimeoutConfig() ReviewTimeoutConfig // Review decision SLA
+
+	// Ownership context
+	OwnershipContextConfig() OwnershipContextConfig // Git ownership annotations in prompts
+
+	// Review context
+	ReviewContextConfig() ReviewContextConfig // Diff, test and report bundle for reviews
+
+	// Agent API settings
+	AgentAPIConfig() AgentAPIConfig     // HTTP API agent backends (no vendor CLI required)
+	OllamaConfig() OllamaConfig         // Local LLM agent served by Ollama
+	AgentCacheConfig() AgentCacheConfig // Agent output reuse for identical prompts
+
+	// Failure handling
+	FailurePolicyConfig() FailurePolicyConfig       // Terminal status of SBIs that exhaust their turn limit
+	ArtifactRetryConfig() ArtifactRetryConfig       // Corrective prompts for missing or empty step reports
+	TrashConfig() TrashConfig                       // Retention of deleted PBIs and SBIs
+	PromptArchiveConfig() PromptArchiveConfig       // Archive of the prompts sent to agents
+	BackupConfig() BackupConfig                     // Periodic snapshots of the task database
+	TurnTimeBoxConfig() TurnTimeBoxConfig           // Wall-clock budget of implement turns
+	ModelPolicyConfig() ModelPolicyConfig           // Models workflow steps may be dispatched to
+	AgentRoutingConfig() AgentRoutingConfig         // Agent and model chosen by task characteristics
+	PromptExperimentConfig() PromptExperimentConfig // A/B test of a prompt template variant
+	MCPConfig() MCPConfig                           // MCP servers labels can enable for agents
+	ChangeBudgetConfig() ChangeBudgetConfig         // Code change cap of implement turns
+	TemplateVarsConfig() TemplateVarsConfig         // Custom prompt template variables
+
+	// Registration
+	DuplicateCheckConfig() DuplicateCheckConfig // Near-duplicate check of new SBIs and PBIs
+	IDSchemeConfig() IDSchemeConfig             // How the IDs of new tasks are generated
+
+	// Storage
+	DatabaseEncryptionConfig() DatabaseEncryptionConfig // Encryption at rest of the task database
+
+	// Prompt size
+	ContextWindowConfig() ContextWindowConfig // Pre-check that prompts fit the model's context window
+
+	// Safety
+	ReadOnly() bool // Refuse commands that mutate the database or filesystem
+
 	// Metadata
 	ConfigSource() string // Source of configuration: "json", "env", or "default"
 	SettingPath() string  // Path to setting.json if loaded from file
@@ -114,9 +506,51 @@ type AppConfig struct {
 	policyPath  string
 	stderrLevel string
 
+	locale string
+
+	aliases      map[string]string
+	statusLabels map[string]string
+
 	labelConfig     LabelConfig
 	agentPoolConfig AgentPoolConfig
 
+	notificationConfig  NotificationConfig
+	artifactStoreConfig ArtifactStoreConfig
+	decompositionConfig DecompositionConfig
+
+	decisionExtractionConfig DecisionExtractionConfig
+	schedulingConfig         SchedulingConfig
+	agentSessionConfig       AgentSessionConfig
+	taskEnvConfig            TaskEnvConfig
+	reviewQuorumConfig       ReviewQuorumConfig
+	selfReviewGuardConfig    SelfReviewGuardConfig
+	journalConfig            JournalConfig
+	specPreflightConfig      SpecPreflightConfig
+	reviewTimeoutConfig      ReviewTimeoutConfig
+	ownershipContextConfig   OwnershipContextConfig
+	reviewContextConfig      ReviewContextConfig
+	agentAPIConfig           AgentAPIConfig
+	ollamaConfig             OllamaConfig
+	agentCacheConfig         AgentCacheConfig
+	failurePolicyConfig      FailurePolicyConfig
+	artifactRetryConfig      ArtifactRetryConfig
+	trashConfig              TrashConfig
+	promptArchiveConfig      PromptArchiveConfig
+	backupConfig             BackupConfig
+	turnTimeBoxConfig        TurnTimeBoxConfig
+	modelPolicyConfig        ModelPolicyConfig
+	agentRoutingConfig       AgentRoutingConfig
+	promptExperimentConfig   PromptExperimentConfig
+	mcpConfig                MCPConfig
+	changeBudgetConfig       ChangeBudgetConfig
+	templateVarsConfig       TemplateVarsConfig
+	duplicateCheckConfig     DuplicateCheckConfig
+	contextWindowConfig      ContextWindowConfig
+	idSchemeConfig           IDSchemeConfig
+	databaseEncryptionConfig DatabaseEncryptionConfig
+
+	readOnly bool
+
 	configSource string
 	settingPath  string
 }
@@ -246,6 +680,191 @@ func (c *AppConfig) AgentPoolConfig() AgentPoolConfig {
 	return c.agentPoolConfig
 }
 
+// NotificationConfig returns the notification configuration
+func (c *AppConfig) NotificationConfig() NotificationConfig {
+	return c.notificationConfig
+}
+
+// ArtifactStoreConfig returns the artifact storage configuration
+func (c *AppConfig) ArtifactStoreConfig() ArtifactStoreConfig {
+	return c.artifactStoreConfig
+}
+
+// DecompositionConfig returns the SBI sizing limits for decomposition
+func (c *AppConfig) DecompositionConfig() DecompositionConfig {
+	return c.decompositionConfig
+}
+
+// DecisionExtractionConfig returns the review decision extraction configuration
+func (c *AppConfig) DecisionExtractionConfig() DecisionExtractionConfig {
+	return c.decisionExtractionConfig
+}
+
+// AgentSessionConfig returns the agent conversation continuation settings
+func (c *AppConfig) AgentSessionConfig() AgentSessionConfig {
+	return c.agentSessionConfig
+}
+
+// TaskEnvConfig returns the values referenced by task env declarations
+func (c *AppConfig) TaskEnvConfig() TaskEnvConfig {
+	return c.taskEnvConfig
+}
+
+// ReviewQuorumConfig returns the review quorum settings
+func (c *AppConfig) ReviewQuorumConfig() ReviewQuorumConfig {
+	return c.reviewQuorumConfig
+}
+
+// SelfReviewGuardConfig returns the self-review guard settings
+func (c *AppConfig) SelfReviewGuardConfig() SelfReviewGuardConfig {
+	return c.selfReviewGuardConfig
+}
+
+// JournalConfig returns the journal rotation settings
+func (c *AppConfig) JournalConfig() JournalConfig {
+	return c.journalConfig
+}
+
+// SpecPreflightConfig returns the spec quality pre-flight settings
+func (c *AppConfig) SpecPreflightConfig() SpecPreflightConfig {
+	return c.specPreflightConfig
+}
+
+// ReviewTimeoutConfig returns the review decision SLA settings
+func (c *AppConfig) ReviewTimeoutConfig() ReviewTimeoutConfig {
+	return c.reviewTimeoutConfig
+}
+
+// OwnershipContextConfig returns the git ownership annotation settings
+func (c *AppConfig) OwnershipContextConfig() OwnershipContextConfig {
+	return c.ownershipContextConfig
+}
+
+// ReviewContextConfig returns the review context bundle settings
+func (c *AppConfig) ReviewContextConfig() ReviewContextConfig {
+	return c.reviewContextConfig
+}
+
+// AgentAPIConfig returns the HTTP API agent backend settings
+func (c *AppConfig) AgentAPIConfig() AgentAPIConfig {
+	return c.agentAPIConfig
+}
+
+// OllamaConfig returns the local LLM agent settings
+func (c *AppConfig) OllamaConfig() OllamaConfig {
+	return c.ollamaConfig
+}
+
+// AgentCacheConfig returns the agent output cache settings
+func (c *AppConfig) AgentCacheConfig() AgentCacheConfig {
+	return c.agentCacheConfig
+}
+
+// FailurePolicyConfig returns the turn limit failure policy settings
+func (c *AppConfig) FailurePolicyConfig() FailurePolicyConfig {
+	return c.failurePolicyConfig
+}
+
+// ArtifactRetryConfig returns the missing report retry settings
+func (c *AppConfig) ArtifactRetryConfig() ArtifactRetryConfig {
+	return c.artifactRetryConfig
+}
+
+// TrashConfig returns the trash retention settings
+func (c *AppConfig) TrashConfig() TrashConfig {
+	return c.trashConfig
+}
+
+// PromptArchiveConfig returns the prompt archive settings
+func (c *AppConfig) PromptArchiveConfig() PromptArchiveConfig {
+	return c.promptArchiveConfig
+}
+
+// BackupConfig returns the database backup settings
+func (c *AppConfig) BackupConfig() BackupConfig {
+	return c.backupConfig
+}
+
+// TurnTimeBoxConfig returns the implement turn time box settings
+func (c *AppConfig) TurnTimeBoxConfig() TurnTimeBoxConfig {
+	return c.turnTimeBoxConfig
+}
+
+// ModelPolicyConfig returns the model policy settings
+func (c *AppConfig) ModelPolicyConfig() ModelPolicyConfig {
+	return c.modelPolicyConfig
+}
+
+// AgentRoutingConfig returns the agent routing settings
+func (c *AppConfig) AgentRoutingConfig() AgentRoutingConfig {
+	return c.agentRoutingConfig
+}
+
+// PromptExperimentConfig returns the prompt experiment settings
+func (c *AppConfig) PromptExperimentConfig() PromptExperimentConfig {
+	return c.promptExperimentConfig
+}
+
+// MCPConfig returns the MCP server settings
+func (c *AppConfig) MCPConfig() MCPConfig {
+	return c.mcpConfig
+}
+
+// ChangeBudgetConfig returns the per-turn change budget settings
+func (c *AppConfig) ChangeBudgetConfig() ChangeBudgetConfig {
+	return c.changeBudgetConfig
+}
+
+// TemplateVarsConfig returns the custom prompt template variable settings
+func (c *AppConfig) TemplateVarsConfig() TemplateVarsConfig {
+	return c.templateVarsConfig
+}
+
+// DuplicateCheckConfig returns the near-duplicate check settings
+func (c *AppConfig) DuplicateCheckConfig() DuplicateCheckConfig {
+	return c.duplicateCheckConfig
+}
+
+// IDSchemeConfig returns the task ID scheme settings
+func (c *AppConfig) IDSchemeConfig() IDSchemeConfig {
+	return c.idSchemeConfig
+}
+
+// DatabaseEncryptionConfig returns the database encryption settings
+func (c *AppConfig) DatabaseEncryptionConfig() DatabaseEncryptionConfig {
+	return c.databaseEncryptionConfig
+}
+
+// ContextWindowConfig returns the context window pre-check settings
+func (c *AppConfig) ContextWindowConfig() ContextWindowConfig {
+	return c.contextWindowConfig
+}
+
+// ReadOnly returns whether read-only mode is enabled
+func (c *AppConfig) ReadOnly() bool {
+	return c.readOnly
+}
+
+// SchedulingConfig returns the SBI scheduling constraints
+func (c *AppConfig) SchedulingConfig() SchedulingConfig {
+	return c.schedulingConfig
+}
+
+// Locale returns the locale of built-in templates and SBI section names
+func (c *AppConfig) Locale() string {
+	return c.locale
+}
+
+// Aliases returns the user-defined command aliases
+func (c *AppConfig) Aliases() map[string]string {
+	return c.aliases
+}
+
+// StatusLabels returns the display labels that override the workflow label of SBI statuses
+func (c *AppConfig) StatusLabels() map[string]string {
+	return c.statusLabels
+}
+
 // ConfigSource returns the source of configuration
 func (c *AppConfig) ConfigSource() string {
 	return c.configSource
@@ -267,36 +886,110 @@ func NewAppConfig(
 	disableMetricsRotation, fsyncAudit bool,
 	testMode, testQuiet bool,
 	workflow, policyPath, stderrLevel string,
+	locale string,
+	aliases map[string]string,
+	statusLabels map[string]string,
 	labelConfig LabelConfig,
 	agentPoolConfig AgentPoolConfig,
+	notificationConfig NotificationConfig,
+	artifactStoreConfig ArtifactStoreConfig,
+	decompositionConfig DecompositionConfig,
+	decisionExtractionConfig DecisionExtractionConfig,
+	schedulingConfig SchedulingConfig,
+	agentSessionConfig AgentSessionConfig,
+	taskEnvConfig TaskEnvConfig,
+	reviewQuorumConfig ReviewQuorumConfig,
+	selfReviewGuardConfig SelfReviewGuardConfig,
+	journalConfig JournalConfig,
+	specPreflightConfig SpecPreflightConfig,
+	reviewTimeoutConfig ReviewTimeoutConfig,
+	ownershipContextConfig OwnershipContextConfig,
+	reviewContextConfig ReviewContextConfig,
+	agentAPIConfig AgentAPIConfig,
+	ollamaConfig OllamaConfig,
+	agentCacheConfig AgentCacheConfig,
+	failurePolicyConfig FailurePolicyConfig,
+	artifactRetryConfig ArtifactRetryConfig,
+	trashConfig TrashConfig,
+	promptArchiveConfig PromptArchiveConfig,
+	backupConfig BackupConfig,
+	turnTimeBoxConfig TurnTimeBoxConfig,
+	modelPolicyConfig ModelPolicyConfig,
+	agentRoutingConfig AgentRoutingConfig,
+	promptExperimentConfig PromptExperimentConfig,
+	mcpConfig MCPConfig,
+	changeBudgetConfig ChangeBudgetConfig,
+	templateVarsConfig TemplateVarsConfig,
+	duplicateCheckConfig DuplicateCheckConfig,
+	contextWindowConfig ContextWindowConfig,
+	idSchemeConfig IDSchemeConfig,
+	databaseEncryptionConfig DatabaseEncryptionConfig,
+	readOnly bool,
 	configSource, settingPath string,
 ) *AppConfig {
 	return &AppConfig{
-		home:                   home,
-		agentBin:               agentBin,
-		timeoutSec:             timeoutSec,
-		editor:                 editor,
-		projectName:            projectName,
-		language:               language,
-		turn:                   turn,
-		taskID:                 taskID,
-		validate:               validate,
-		autoFB:                 autoFB,
-		strictFsync:            strictFsync,
-		maxAttempts:            maxAttempts,
-		maxTurns:               maxTurns,
-		txDestRoot:             txDestRoot,
-		disableRecovery:        disableRecovery,
-		disableMetricsRotation: disableMetricsRotation,
-		fsyncAudit:             fsyncAudit,
-		testMode:               testMode,
-		testQuiet:              testQuiet,
-		workflow:               workflow,
-		policyPath:             policyPath,
-		stderrLevel:            stderrLevel,
-		labelConfig:            labelConfig,
-		agentPoolConfig:        agentPoolConfig,
-		configSource:           configSource,
-		settingPath:            settingPath,
+		home:                     home,
+		agentBin:                 agentBin,
+		timeoutSec:               timeoutSec,
+		editor:                   editor,
+		projectName:              projectName,
+		language:                 language,
+		turn:                     turn,
+		taskID:                   taskID,
+		validate:                 validate,
+		autoFB:                   autoFB,
+		strictFsync:              strictFsync,
+		maxAttempts:              maxAttempts,
+		maxTurns:                 maxTurns,
+		txDestRoot:               txDestRoot,
+		disableRecovery:          disableRecovery,
+		disableMetricsRotation:   disableMetricsRotation,
+		fsyncAudit:               fsyncAudit,
+		testMode:                 testMode,
+		testQuiet:                testQuiet,
+		workflow:                 workflow,
+		policyPath:               policyPath,
+		stderrLevel:              stderrLevel,
+		locale:                   locale,
+		aliases:                  aliases,
+		statusLabels:             statusLabels,
+		labelConfig:              labelConfig,
+		agentPoolConfig:          agentPoolConfig,
+		notificationConfig:       notificationConfig,
+		artifactStoreConfig:      artifactStoreConfig,
+		decompositionConfig:      decompositionConfig,
+		decisionExtractionConfig: decisionExtractionConfig,
+		schedulingConfig:         schedulingConfig,
+		agentSessionConfig:       agentSessionConfig,
+		taskEnvConfig:            taskEnvConfig,
+		reviewQuorumConfig:       reviewQuorumConfig,
+		selfReviewGuardConfig:    selfReviewGuardConfig,
+		journalConfig:            journalConfig,
+		specPreflightConfig:      specPreflightConfig,
+		reviewTimeoutConfig:      reviewTimeoutConfig,
+		ownershipContextConfig:   ownershipContextConfig,
+		reviewContextConfig:      reviewContextConfig,
+		agentAPIConfig:           agentAPIConfig,
+		ollamaConfig:             ollamaConfig,
+		agentCacheConfig:         agentCacheConfig,
+		failurePolicyConfig:      failurePolicyConfig,
+		artifactRetryConfig:      artifactRetryConfig,
+		trashConfig:              trashConfig,
+		promptArchiveConfig:      promptArchiveConfig,
+		backupConfig:             backupConfig,
+		turnTimeBoxConfig:        turnTimeBoxConfig,
+		modelPolicyConfig:        modelPolicyConfig,
+		agentRoutingConfig:       agentRoutingConfig,
+		promptExperimentConfig:   promptExperimentConfig,
+		mcpConfig:                mcpConfig,
+		changeBudgetConfig:       changeBudgetConfig,
+		templateVarsConfig:       templateVarsConfig,
+		duplicateCheckConfig:     duplicateCheckConfig,
+		contextWindowConfig:      contextWindowConfig,
+		idSchemeConfig:           idSchemeConfig,
+		databaseEncryptionConfig: databaseEncryptionConfig,
+		readOnly:                 readOnly,
+		configSource:             configSource,
+		settingPath:              settingPath,
 	}
 }
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FindProjectRoot returns the nearest directory, from start up to the filesystem root,
+// that contains a .deespec directory
+// The home directory is skipped unless start is the home directory itself: ~/.deespec
+// holds the global database, not a project.
+func FindProjectRoot(start string) (string, bool) {
+	home, _ := os.UserHomeDir()
+	return findProjectRoot(start, home)
+}
+
+// findProjectRoot is FindProjectRoot with the home directory to skip given explicitly
+func findProjectRoot(start, home string) (string, bool) {
+	startAbs, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+	dir := startAbs
+	if home != "" {
+		home = filepath.Clean(home)
+	}
+	for {
+		if dir != home || dir == startAbs {
+			if stat, err := os.Stat(filepath.Join(dir, ".deespec")); err == nil && stat.IsDir() {
+				return dir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectRoot(t *testing.T) {
+	base := t.TempDir()
+	home := filepath.Join(base, "home")
+	project := filepath.Join(home, "work", "project")
+	nested := filepath.Join(project, "internal", "pkg")
+	for _, dir := range []string{filepath.Join(home, ".deespec"), filepath.Join(project, ".deespec"), nested} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		start string
+		want  string
+		found bool
+	}{
+		{"project root itself", project, project, true},
+		{"nested subdirectory", nested, project, true},
+		{"outside any project stops before the home directory", filepath.Join(home, "work"), "", false},
+		{"the home directory itself", home, home, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := findProjectRoot(tt.start, home)
+			if got != tt.want || found != tt.found {
+				t.Errorf("findProjectRoot(%s) = %q, %v; want %q, %v", tt.start, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
@@ -10,12 +10,13 @@ type RunTurnInput struct {
 // RunTurnOutput represents the result of running a single turn
 type RunTurnOutput struct {
 	// Basic info
-	Turn        int       `json:"turn"`
-	SBIID       string    `json:"sbi_id,omitempty"`       // Current SBI being processed (empty if no WIP)
-	NoOp        bool      `json:"no_op"`                  // True if no work was done
-	NoOpReason  string    `json:"no_op_reason,omitempty"` // Reason for NoOp: "lock_held", "no_tasks", or empty
-	ElapsedMs   int64     `json:"elapsed_ms"`             // Execution time
-	CompletedAt time.Time `json:"completed_at"`
+	Turn        int        `json:"turn"`
+	SBIID       string     `json:"sbi_id,omitempty"`       // Current SBI being processed (empty if no WIP)
+	NoOp        bool       `json:"no_op"`                  // True if no work was done
+	NoOpReason  string     `json:"no_op_reason,omitempty"` // Reason for NoOp: "lock_held", "no_tasks", "cancelled", "pbi_turn_budget_exhausted", "daily_agent_call_budget_exhausted", "outside_execution_window", or empty
+	ResumeAt    *time.Time `json:"resume_at,omitempty"`    // When turns may start again (outside_execution_window only)
+	ElapsedMs   int64      `json:"elapsed_ms"`             // Execution time
+	CompletedAt time.Time  `json:"completed_at"`
 
 	// State transition
 	PrevStatus string `json:"prev_status,omitempty"` // Status before this turn
@@ -68,11 +69,52 @@ type ExecuteStepInput struct {
 // ExecuteStepOutput represents the result of executing a workflow step
 type ExecuteStepOutput struct {
 	Success      bool      `json:"success"`
-	Output       string    `json:"output"`              // AI agent output
-	Decision     string    `json:"decision,omitempty"`  // Extracted decision (for review steps)
-	ArtifactPath string    `json:"artifact_path"`       // Saved artifact path
-	ErrorMsg     string    `json:"error_msg,omitempty"` // Error message if any
-	ElapsedMs    int64     `json:"elapsed_ms"`          // Execution time
+	Output       string    `json:"output"`                // AI agent output
+	Decision     string    `json:"decision,omitempty"`    // Extracted decision (for review steps)
+	ArtifactPath string    `json:"artifact_path"`         // Saved artifact path
+	ErrorMsg     string    `json:"error_msg,omitempty"`   // Error message if any
+	ErrorClass   string    `json:"error_class,omitempty"` // Error classification (see service.ClassifyError)
+	ElapsedMs    int64     `json:"elapsed_ms"`            // Execution time
 	StartedAt    time.Time `json:"started_at"`
 	CompletedAt  time.Time `json:"completed_at"`
+
+	ArtifactCheck  *ArtifactCheck `json:"artifact_check,omitempty"`  // Set when the agent did not write its report at first
+	CheckpointPath string         `json:"checkpoint_path,omitempty"` // Set when the step exceeded the turn time box and ended with a progress checkpoint
+	Routing        *AgentRouting  `json:"routing,omitempty"`         // How the agent and model of the step were chosen
+
+	ChangeBudget *ChangeBudgetCheck `json:"change_budget,omitempty"` // Set when an implement step exceeded the change budget
+}
+
+// AgentRouting records the agent and model a step ran on and why, for evaluating routing rules
+type AgentRouting struct {
+	Source string `json:"source"`         // "default", "label", "rule" or "review_alternate"
+	Rule   string `json:"rule,omitempty"` // Name of the matching routing rule
+	Agent  string `json:"agent"`
+	Model  string `json:"model,omitempty"`
+}
+
+// Outcomes of an artifact check
+const (
+	ArtifactCreatedAfterRetry = "created_after_retry" // A corrective prompt got the agent to write the report
+	ArtifactFallback          = "fallback"            // The agent output was saved as the report
+)
+
+// Outcomes of a change budget check
+const (
+	ChangeBudgetSplit   = "split"   // Split requests got the change within the budget
+	ChangeBudgetFlagged = "flagged" // The change stayed over the budget; the turn needs human review
+)
+
+// ChangeBudgetCheck records an implement step whose change exceeded the per-turn change budget
+type ChangeBudgetCheck struct {
+	LinesChanged  int    `json:"lines_changed"`  // Lines changed after the split requests
+	FilesChanged  int    `json:"files_changed"`  // Files changed after the split requests
+	SplitRequests int    `json:"split_requests"` // Prompts sent asking the agent to split the change
+	Outcome       string `json:"outcome"`        // ChangeBudgetSplit or ChangeBudgetFlagged
+}
+
+// ArtifactCheck records the corrective prompts sent for a report the agent did not write
+type ArtifactCheck struct {
+	Retries int    `json:"retries"` // Corrective prompts sent
+	Outcome string `json:"outcome"` // ArtifactCreatedAfterRetry or ArtifactFallback
 }
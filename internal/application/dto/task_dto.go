@@ -41,15 +41,17 @@ type PBIDTO struct {
 // SBIDTO represents an SBI with specific metadata and execution state
 type SBIDTO struct {
 	TaskDTO
-	EstimatedHours float64    `json:"estimated_hours"`
-	Priority       int        `json:"priority"`
-	Sequence       int        `json:"sequence"`      // Registration sequence number (auto-incremented)
-	RegisteredAt   time.Time  `json:"registered_at"` // Explicit registration timestamp
-	StartedAt      *time.Time `json:"started_at"`    // Work start time (when PENDING→PICKED)
-	CompletedAt    *time.Time `json:"completed_at"`  // Work completion time (when DONE/FAILED)
-	Labels         []string   `json:"labels"`
-	AssignedAgent  string     `json:"assigned_agent"`
-	FilePaths      []string   `json:"file_paths"`
+	EstimatedHours float64           `json:"estimated_hours"`
+	Priority       int               `json:"priority"`
+	Sequence       int               `json:"sequence"`      // Registration sequence number (auto-incremented)
+	RegisteredAt   time.Time         `json:"registered_at"` // Explicit registration timestamp
+	StartedAt      *time.Time        `json:"started_at"`    // Work start time (when PENDING→PICKED)
+	CompletedAt    *time.Time        `json:"completed_at"`  // Work completion time (when DONE/FAILED)
+	Deadline       *time.Time        `json:"deadline,omitempty"`
+	Labels         []string          `json:"labels"`
+	AssignedAgent  string            `json:"assigned_agent"`
+	FilePaths      []string          `json:"file_paths"`
+	Env            map[string]string `json:"env,omitempty"` // Environment variables exported to agents
 
 	// Execution state
 	CurrentTurn    int      `json:"current_turn"`
@@ -84,18 +86,20 @@ type CreatePBIRequest struct {
 
 // CreateSBIRequest represents a request to create an SBI
 type CreateSBIRequest struct {
-	Title          string   `json:"title" validate:"required"`
-	Description    string   `json:"description"`
-	ParentPBIID    *string  `json:"parent_pbi_id,omitempty"`
-	EstimatedHours float64  `json:"estimated_hours"`
-	Priority       int      `json:"priority"`
-	Labels         []string `json:"labels"`
-	AssignedAgent  string   `json:"assigned_agent"`
-	FilePaths      []string `json:"file_paths"`
-	DependsOn      []string `json:"depends_on,omitempty"` // SBI IDs that must be completed before this SBI
-	OnlyImplement  bool     `json:"only_implement"`       // If true, skip review cycle (implementation-only)
-	MaxTurns       *int     `json:"max_turns,omitempty"`
-	MaxAttempts    *int     `json:"max_attempts,omitempty"`
+	Title          string            `json:"title" validate:"required"`
+	Description    string            `json:"description"`
+	ParentPBIID    *string           `json:"parent_pbi_id,omitempty"`
+	EstimatedHours float64           `json:"estimated_hours"`
+	Priority       int               `json:"priority"`
+	Labels         []string          `json:"labels"`
+	AssignedAgent  string            `json:"assigned_agent"`
+	FilePaths      []string          `json:"file_paths"`
+	DependsOn      []string          `json:"depends_on,omitempty"` // SBI IDs that must be completed before this SBI
+	OnlyImplement  bool              `json:"only_implement"`       // If true, skip review cycle (implementation-only)
+	Deadline       *time.Time        `json:"deadline,omitempty"`   // Optional deadline
+	Env            map[string]string `json:"env,omitempty"`        // Non-secret environment variables for agent steps
+	MaxTurns       *int              `json:"max_turns,omitempty"`
+	MaxAttempts    *int              `json:"max_attempts,omitempty"`
 }
 
 // ListTasksRequest represents a request to list tasks
@@ -104,8 +108,20 @@ type ListTasksRequest struct {
 	Statuses  []string `json:"statuses,omitempty"`   // Filter by statuses
 	ParentID  *string  `json:"parent_id,omitempty"`  // Filter by parent
 	HasParent *bool    `json:"has_parent,omitempty"` // Filter by parent existence
+	Overdue   bool     `json:"overdue,omitempty"`    // Only unfinished tasks past their deadline
 	Limit     int      `json:"limit"`
 	Offset    int      `json:"offset"`
+
+	// SBI-only criteria
+	Labels         []string   `json:"labels,omitempty"`          // Tasks that have all of these labels
+	AssignedAgents []string   `json:"assigned_agents,omitempty"` // Filter by assigned agent
+	MinPriority    *int       `json:"min_priority,omitempty"`    // Priority range (inclusive)
+	MaxPriority    *int       `json:"max_priority,omitempty"`
+	MinTurns       *int       `json:"min_turns,omitempty"` // Turn count range (inclusive)
+	MaxTurns       *int       `json:"max_turns,omitempty"`
+	UpdatedAfter   *time.Time `json:"updated_after,omitempty"` // Last-updated window (inclusive)
+	UpdatedBefore  *time.Time `json:"updated_before,omitempty"`
+	Sort           string     `json:"sort,omitempty"` // Sort keys, e.g. "priority,-updated"
 }
 
 // ListTasksResponse represents a response with task list
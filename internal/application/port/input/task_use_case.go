@@ -29,6 +29,9 @@ type TaskUseCase interface {
 	// GetSBI retrieves an SBI by ID
 	GetSBI(ctx context.Context, sbiID string) (*dto.SBIDTO, error)
 
+	// GetSBIs retrieves the SBIs with the given IDs at once, skipping IDs without an SBI
+	GetSBIs(ctx context.Context, sbiIDs []string) ([]*dto.SBIDTO, error)
+
 	// ListTasks lists tasks with filters
 	ListTasks(ctx context.Context, req dto.ListTasksRequest) (*dto.ListTasksResponse, error)
 
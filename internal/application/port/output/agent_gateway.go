@@ -20,11 +20,27 @@ type AgentGateway interface {
 
 // AgentRequest represents a request to an AI agent
 type AgentRequest struct {
-	Prompt      string            // The prompt to send to the agent
-	Timeout     time.Duration     // Execution timeout
-	Context     map[string]string // Additional context information
-	MaxTokens   int               // Maximum tokens to generate (if applicable)
-	Temperature float64           // Temperature for generation (0.0-1.0)
+	Prompt      string               // The prompt to send to the agent
+	Timeout     time.Duration        // Execution timeout
+	Context     map[string]string    // Additional context information
+	MaxTokens   int                  // Maximum tokens to generate (if applicable)
+	Temperature float64              // Temperature for generation (0.0-1.0)
+	Model       string               // Model override (empty = agent default)
+	Env         map[string]string    // Extra environment variables for agent subprocesses
+	OnOutput    func(chunk string)   // Optional: receives output as it is produced (streaming agents only)
+	SessionID   string               // Optional: provider session to continue (agents with SupportsSessions only)
+	MCPServers  map[string]MCPServer // Optional: MCP servers the agent may use, by name (agents with SupportsMCP only)
+}
+
+// MCPServer describes an MCP (Model Context Protocol) server giving an agent extra tools
+// Local servers are started by the agent from Command; remote ones are reached at URL
+type MCPServer struct {
+	Type    string            `json:"type,omitempty"`    // "stdio" (default), "http" or "sse"
+	Command string            `json:"command,omitempty"` // Executable of a stdio server
+	Args    []string          `json:"args,omitempty"`    // Arguments of a stdio server
+	Env     map[string]string `json:"env,omitempty"`     // Environment of a stdio server
+	URL     string            `json:"url,omitempty"`     // Endpoint of an http or sse server
+	Headers map[string]string `json:"headers,omitempty"` // Request headers of an http or sse server
 }
 
 // AgentResponse represents the response from an AI agent
@@ -35,6 +51,7 @@ type AgentResponse struct {
 	TokensUsed int               // Number of tokens used (if applicable)
 	AgentType  string            // Type of agent that executed (claude/gemini/codex)
 	Metadata   map[string]string // Additional metadata
+	SessionID  string            // Provider session of this conversation (empty if unsupported)
 }
 
 // AgentCapability describes what an agent can do
@@ -42,7 +59,9 @@ type AgentCapability struct {
 	SupportsCodeGeneration bool   // Can generate code
 	SupportsReview         bool   // Can review code
 	SupportsTest           bool   // Can generate tests
-	MaxPromptSize          int    // Maximum prompt size in bytes
+	MaxPromptSize          int    // Context window of the default model in tokens (0: unknown)
 	ConcurrentTasks        int    // Number of concurrent tasks supported
 	AgentType              string // Agent type identifier
+	SupportsSessions       bool   // Can continue a previous conversation via AgentRequest.SessionID
+	SupportsMCP            bool   // Can use the MCP servers of AgentRequest.MCPServers
 }
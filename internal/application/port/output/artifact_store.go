@@ -0,0 +1,25 @@
+package output
+
+import "context"
+
+// ArtifactStore is the interface for storing workflow artifacts (reports, step outputs)
+// Artifacts are addressed by URI so that journals and execution logs stay valid
+// regardless of where the content lives:
+//   - Local filesystem: plain path (e.g., ".deespec/reports/sbi/<id>/implement_1.md")
+//   - S3:  s3://<bucket>/<prefix>/<key>
+//   - GCS: gs://<bucket>/<prefix>/<key>
+type ArtifactStore interface {
+	// Put stores content under the given key and returns its URI
+	// Key is a slash-separated path relative to the store root (e.g., "reports/sbi/<id>/implement_1.md")
+	Put(ctx context.Context, key string, content []byte) (string, error)
+
+	// Get retrieves content by URI
+	Get(ctx context.Context, uri string) ([]byte, error)
+
+	// URI returns the URI that Put would return for the given key
+	URI(key string) string
+
+	// LocalPath returns the local working-copy path of a key that agents can read,
+	// or "" when the store keeps no local copy
+	LocalPath(key string) string
+}
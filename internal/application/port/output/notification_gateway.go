@@ -0,0 +1,33 @@
+package output
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationGateway is the interface for sending notifications to humans
+// Implementations may write to a log, post to a webhook, send email, etc.
+type NotificationGateway interface {
+	// Notify sends a notification
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// Notification represents a single notification message
+type Notification struct {
+	Level     NotificationLevel // Severity of the notification
+	Event     string            // Machine-readable event name (e.g., "task.overdue")
+	Title     string            // Short human-readable title
+	Message   string            // Detailed message
+	TaskID    string            // Related task ID (optional)
+	Timestamp time.Time         // When the event occurred
+	Fields    map[string]string // Additional key/value details
+}
+
+// NotificationLevel represents the severity of a notification
+type NotificationLevel string
+
+const (
+	NotificationLevelInfo     NotificationLevel = "info"
+	NotificationLevelWarning  NotificationLevel = "warning"
+	NotificationLevelCritical NotificationLevel = "critical"
+)
@@ -0,0 +1,32 @@
+package output
+
+import "time"
+
+// TurnEventPublisher is the interface for publishing turn lifecycle events
+// Implementations must not block the turn; slow consumers may miss events
+type TurnEventPublisher interface {
+	// Publish emits a turn event to all current subscribers
+	Publish(event TurnEvent)
+}
+
+// TurnEvent represents a single step in the lifecycle of a turn
+type TurnEvent struct {
+	Type      TurnEventType     `json:"type"`
+	SBIID     string            `json:"sbi_id"`
+	Turn      int               `json:"turn"`
+	Step      string            `json:"step,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Data      map[string]string `json:"data,omitempty"` // Event-specific details
+}
+
+// TurnEventType identifies a turn lifecycle event
+type TurnEventType string
+
+const (
+	TurnEventPicked           TurnEventType = "picked"             // The SBI was selected for the turn
+	TurnEventPromptBuilt      TurnEventType = "prompt-built"       // The step prompt is ready (data: prompt_bytes)
+	TurnEventAgentStarted     TurnEventType = "agent-started"      // The agent was launched (data: agent, model)
+	TurnEventAgentOutputChunk TurnEventType = "agent-output-chunk" // Agent output as it is produced (data: chunk)
+	TurnEventDecision         TurnEventType = "decision"           // The turn outcome was decided (data: decision, next_status)
+	TurnEventSaved            TurnEventType = "saved"              // The SBI and journal were persisted (data: status)
+)
@@ -0,0 +1,15 @@
+package output
+
+import (
+	"context"
+)
+
+// UnitOfWork commits the writes of several repositories atomically
+// Repositories called with txCtx take part in the unit; when fn returns an error none of
+// their writes are kept. Journal records appended with txCtx are committed together with
+// the entities they describe, so that a crash cannot leave a status change without its record.
+type UnitOfWork interface {
+	// Do executes fn within the unit of work
+	// A Do nested in another joins the outer unit, which commits both
+	Do(ctx context.Context, fn func(txCtx context.Context) error) error
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// Sources of the agent route of a step
+const (
+	RouteSourceDefault         = "default"          // No label or rule applies; the default gateway and model
+	RouteSourceLabel           = "label"            // Pinned by the task's highest-priority routed label
+	RouteSourceRule            = "rule"             // Chosen by an agent routing rule matching the task
+	RouteSourceReviewAlternate = "review_alternate" // Replaced by the self-review guard
+)
+
+// RouteDecision records how the agent route of a step was chosen
+type RouteDecision struct {
+	Source string           // One of the RouteSource constants
+	Rule   string           // Name of the routing rule (RouteSourceRule only)
+	Route  label.AgentRoute // Agent and model pinned by the label or rule
+}
+
+// AgentGatewayFactory creates an agent gateway for the given agent type
+type AgentGatewayFactory func(agentType string) (output.AgentGateway, error)
+
+// AgentRegistry resolves which agent gateway and model handle a workflow step
+// Routing is driven by label metadata: among the task's active labels, the
+// highest-priority label that pins a route for the step wins.
+// Tasks without a routed label are routed by the first agent routing rule matching their
+// characteristics, and fall back to the default gateway.
+type AgentRegistry struct {
+	defaultGateway output.AgentGateway
+	factory        AgentGatewayFactory
+	labelRepo      repository.LabelRepository
+	router         *domainservice.AgentRouter
+	gateways       map[string]output.AgentGateway // agent type -> gateway (lazily created)
+	mu             sync.Mutex
+
+	// Self-review guard: reviews never run on the implementer's agent and model
+	selfReviewGuard  bool
+	reviewAlternates []label.AgentRoute // Tried in order when the review route matches the implementer
+}
+
+// NewAgentRegistry creates a new agent registry
+// labelRepo may be nil, in which case every request resolves to the default gateway
+func NewAgentRegistry(defaultGateway output.AgentGateway, factory AgentGatewayFactory, labelRepo repository.LabelRepository) *AgentRegistry {
+	return &AgentRegistry{
+		defaultGateway: defaultGateway,
+		factory:        factory,
+		labelRepo:      labelRepo,
+		gateways:       make(map[string]output.AgentGateway),
+	}
+}
+
+// Register adds a pre-built gateway for an agent type
+func (r *AgentRegistry) Register(agentType string, gateway output.AgentGateway) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gateways[agentType] = gateway
+}
+
+// ResolveRoute finds the agent route for a step based on task labels
+// Returns a zero route if no label pins one
+func (r *AgentRegistry) ResolveRoute(ctx context.Context, labels []string, step string) label.AgentRoute {
+	if r.labelRepo == nil || len(labels) == 0 {
+		return label.AgentRoute{}
+	}
+
+	// force_implement is executed by the implementer pool
+	if step == "force_implement" {
+		step = label.RouteStepImplement
+	}
+	if !label.IsRoutableStep(step) {
+		return label.AgentRoute{}
+	}
+
+	var best label.AgentRoute
+	bestPriority := 0
+	found := false
+	for _, name := range labels {
+		lbl, err := r.labelRepo.FindByName(ctx, name)
+		if err != nil || lbl == nil || !lbl.IsActive() {
+			continue // Unknown or inactive labels do not route
+		}
+		route, ok := lbl.AgentRoute(step)
+		if !ok {
+			continue
+		}
+		if !found || lbl.Priority() > bestPriority {
+			best = route
+			bestPriority = lbl.Priority()
+			found = true
+		}
+	}
+
+	return best
+}
+
+// SetRouter routes tasks without a routed label by their characteristics (nil disables it)
+func (r *AgentRegistry) SetRouter(router *domainservice.AgentRouter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.router = router
+}
+
+// ResolveTaskRoute finds the agent route for a step of a task
+// A label route takes precedence over the routing rules, since it was pinned by hand.
+func (r *AgentRegistry) ResolveTaskRoute(ctx context.Context, task domainservice.TaskTraits, step string) RouteDecision {
+	if route := r.ResolveRoute(ctx, task.Labels, step); !route.IsZero() {
+		return RouteDecision{Source: RouteSourceLabel, Route: route}
+	}
+
+	r.mu.Lock()
+	router := r.router
+	r.mu.Unlock()
+	if rule, ok := router.Select(step, task); ok {
+		return RouteDecision{Source: RouteSourceRule, Rule: rule.Name, Route: rule.Route()}
+	}
+	return RouteDecision{Source: RouteSourceDefault}
+}
+
+// BuildRequest resolves the gateway for a step and applies the routed model to the request
+func (r *AgentRegistry) BuildRequest(ctx context.Context, labels []string, step string, req output.AgentRequest) (output.AgentGateway, output.AgentRequest, error) {
+	gateway, req, _, err := r.BuildTaskRequest(ctx, domainservice.TaskTraits{Labels: labels}, step, req)
+	return gateway, req, err
+}
+
+// BuildTaskRequest is BuildRequest for a task's characteristics, also returning how the route was chosen
+func (r *AgentRegistry) BuildTaskRequest(ctx context.Context, task domainservice.TaskTraits, step string, req output.AgentRequest) (output.AgentGateway, output.AgentRequest, RouteDecision, error) {
+	decision := r.ResolveTaskRoute(ctx, task, step)
+	route := decision.Route
+
+	gateway := r.defaultGateway
+	if route.Agent != "" {
+		gw, err := r.gateway(route.Agent)
+		if err != nil {
+			return nil, req, decision, err
+		}
+		gateway = gw
+	}
+
+	if route.Model != "" {
+		req.Model = route.Model
+	}
+
+	return gateway, req, decision, nil
+}
+
+// Gateway returns the gateway for an agent type; an empty type selects the default gateway
+func (r *AgentRegistry) Gateway(agentType string) (output.AgentGateway, error) {
+	if agentType == "" {
+		return r.defaultGateway, nil
+	}
+	return r.gateway(agentType)
+}
+
+// Decorate wraps the default gateway and every gateway the registry creates
+// Used by chaos mode to inject faults into all agent calls.
+func (r *AgentRegistry) Decorate(wrap func(output.AgentGateway) output.AgentGateway) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defaultGateway = wrap(r.defaultGateway)
+	for agentType, gw := range r.gateways {
+		r.gateways[agentType] = wrap(gw)
+	}
+	if factory := r.factory; factory != nil {
+		r.factory = func(agentType string) (output.AgentGateway, error) {
+			gw, err := factory(agentType)
+			if err != nil {
+				return nil, err
+			}
+			return wrap(gw), nil
+		}
+	}
+}
+
+// SetSelfReviewGuard keeps the review step off the agent and model that implemented the turn
+// When the routed reviewer matches the implementer, the first alternate route that differs is used
+func (r *AgentRegistry) SetSelfReviewGuard(enabled bool, alternates []label.AgentRoute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.selfReviewGuard = enabled
+	r.reviewAlternates = alternates
+}
+
+// IsSelfReview reports whether a reviewer gateway and model would review the implementer's own work
+// Always false when the self-review guard is disabled or the implementer is unknown
+func (r *AgentRegistry) IsSelfReview(gateway output.AgentGateway, model string, implementer label.AgentRoute) bool {
+	r.mu.Lock()
+	enabled := r.selfReviewGuard
+	r.mu.Unlock()
+	if !enabled || implementer.Agent == "" {
+		return false
+	}
+	return gateway.GetCapability().AgentType == implementer.Agent && model == implementer.Model
+}
+
+// BuildReviewRequest resolves the review step like BuildRequest, but never returns the
+// implementer's agent and model while the self-review guard is enabled
+func (r *AgentRegistry) BuildReviewRequest(ctx context.Context, labels []string, implementer label.AgentRoute, req output.AgentRequest) (output.AgentGateway, output.AgentRequest, error) {
+	gateway, req, _, err := r.BuildTaskReviewRequest(ctx, domainservice.TaskTraits{Labels: labels}, implementer, req)
+	return gateway, req, err
+}
+
+// BuildTaskReviewRequest is BuildReviewRequest for a task's characteristics, also returning how
+// the route was chosen
+func (r *AgentRegistry) BuildTaskReviewRequest(ctx context.Context, task domainservice.TaskTraits, implementer label.AgentRoute, req output.AgentRequest) (output.AgentGateway, output.AgentRequest, RouteDecision, error) {
+	gateway, routedReq, decision, err := r.BuildTaskRequest(ctx, task, label.RouteStepReview, req)
+	if err != nil || !r.IsSelfReview(gateway, routedReq.Model, implementer) {
+		return gateway, routedReq, decision, err
+	}
+
+	r.mu.Lock()
+	alternates := r.reviewAlternates
+	r.mu.Unlock()
+	for _, alternate := range alternates {
+		gw, err := r.Gateway(alternate.Agent)
+		if err != nil {
+			continue // Unavailable alternates are skipped
+		}
+		if r.IsSelfReview(gw, alternate.Model, implementer) {
+			continue
+		}
+		req.Model = alternate.Model
+		return gw, req, RouteDecision{Source: RouteSourceReviewAlternate, Route: alternate}, nil
+	}
+
+	return nil, req, decision, fmt.Errorf("self-review guard: no reviewer other than the implementer (agent %s, model %q) is available",
+		implementer.Agent, implementer.Model)
+}
+
+// gateway returns the cached gateway for an agent type, creating it on first use
+func (r *AgentRegistry) gateway(agentType string) (output.AgentGateway, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if gw, ok := r.gateways[agentType]; ok {
+		return gw, nil
+	}
+
+	if r.factory == nil {
+		return nil, fmt.Errorf("no gateway registered for agent: %s", agentType)
+	}
+
+	gw, err := r.factory(agentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gateway for agent %s: %w", agentType, err)
+	}
+	r.gateways[agentType] = gw
+	return gw, nil
+}
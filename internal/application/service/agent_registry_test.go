@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLabelRepo is a minimal LabelRepository backed by a map
+type stubLabelRepo struct {
+	repository.LabelRepository
+	labels map[string]*label.Label
+}
+
+func (r *stubLabelRepo) FindByName(ctx context.Context, name string) (*label.Label, error) {
+	if lbl, ok := r.labels[name]; ok {
+		return lbl, nil
+	}
+	return nil, fmt.Errorf("label not found: %s", name)
+}
+
+// stubAgentGateway records its agent type
+type stubAgentGateway struct {
+	agentType string
+}
+
+func (g *stubAgentGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	return &output.AgentResponse{AgentType: g.agentType}, nil
+}
+
+func (g *stubAgentGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: g.agentType}
+}
+
+func (g *stubAgentGateway) HealthCheck(ctx context.Context) error { return nil }
+
+func newRoutedLabel(t *testing.T, name string, priority int, step string, route label.AgentRoute) *label.Label {
+	lbl := label.NewLabel(name, "", nil, priority)
+	require.NoError(t, lbl.SetAgentRoute(step, route))
+	return lbl
+}
+
+func TestAgentRegistry_BuildRequest_DefaultWithoutLabels(t *testing.T) {
+	defaultGW := &stubAgentGateway{agentType: "claude-code-cli"}
+	registry := NewAgentRegistry(defaultGW, nil, &stubLabelRepo{labels: map[string]*label.Label{}})
+
+	gw, req, err := registry.BuildRequest(context.Background(), nil, "implement", output.AgentRequest{Prompt: "p"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultGW, gw)
+	assert.Empty(t, req.Model)
+}
+
+func TestAgentRegistry_BuildRequest_RoutesByStep(t *testing.T) {
+	repo := &stubLabelRepo{labels: map[string]*label.Label{}}
+	frontend := newRoutedLabel(t, "frontend", 1, label.RouteStepImplement, label.AgentRoute{Agent: "claude-code-cli", Model: "claude-sonnet"})
+	require.NoError(t, frontend.SetAgentRoute(label.RouteStepReview, label.AgentRoute{Agent: "codex", Model: "gpt"}))
+	repo.labels["frontend"] = frontend
+
+	created := map[string]int{}
+	factory := func(agentType string) (output.AgentGateway, error) {
+		created[agentType]++
+		return &stubAgentGateway{agentType: agentType}, nil
+	}
+	registry := NewAgentRegistry(&stubAgentGateway{agentType: "default"}, factory, repo)
+
+	gw, req, err := registry.BuildRequest(context.Background(), []string{"frontend"}, "implement", output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+	assert.Equal(t, "claude-sonnet", req.Model)
+
+	gw, req, err = registry.BuildRequest(context.Background(), []string{"frontend"}, "review", output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "codex", gw.GetCapability().AgentType)
+	assert.Equal(t, "gpt", req.Model)
+
+	// force_implement follows the implement route
+	gw, _, err = registry.BuildRequest(context.Background(), []string{"frontend"}, "force_implement", output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+
+	// Gateways are cached per agent type
+	assert.Equal(t, 1, created["claude-code-cli"])
+}
+
+func TestAgentRegistry_ResolveRoute_HighestPriorityWins(t *testing.T) {
+	repo := &stubLabelRepo{labels: map[string]*label.Label{
+		"low":  newRoutedLabel(t, "low", 1, label.RouteStepReview, label.AgentRoute{Agent: "gemini-cli"}),
+		"high": newRoutedLabel(t, "high", 5, label.RouteStepReview, label.AgentRoute{Agent: "codex"}),
+	}}
+	registry := NewAgentRegistry(nil, nil, repo)
+
+	route := registry.ResolveRoute(context.Background(), []string{"low", "high", "unknown"}, "review")
+	assert.Equal(t, "codex", route.Agent)
+}
+
+func TestAgentRegistry_ResolveRoute_SkipsInactiveLabels(t *testing.T) {
+	inactive := newRoutedLabel(t, "legacy", 10, label.RouteStepImplement, label.AgentRoute{Agent: "codex"})
+	inactive.Deactivate()
+	registry := NewAgentRegistry(nil, nil, &stubLabelRepo{labels: map[string]*label.Label{"legacy": inactive}})
+
+	route := registry.ResolveRoute(context.Background(), []string{"legacy"}, "implement")
+	assert.True(t, route.IsZero())
+}
+
+func TestAgentRegistry_BuildRequest_FactoryError(t *testing.T) {
+	repo := &stubLabelRepo{labels: map[string]*label.Label{
+		"ml": newRoutedLabel(t, "ml", 1, label.RouteStepImplement, label.AgentRoute{Agent: "codex"}),
+	}}
+	factory := func(agentType string) (output.AgentGateway, error) {
+		return nil, fmt.Errorf("agent %s is not available", agentType)
+	}
+	registry := NewAgentRegistry(&stubAgentGateway{}, factory, repo)
+
+	_, _, err := registry.BuildRequest(context.Background(), []string{"ml"}, "implement", output.AgentRequest{})
+	assert.Error(t, err)
+}
+
+func TestAgentRegistry_BuildReviewRequest_SelfReviewGuard(t *testing.T) {
+	factory := func(agentType string) (output.AgentGateway, error) {
+		if agentType == "gemini-cli" {
+			return nil, fmt.Errorf("gemini not installed")
+		}
+		return &stubAgentGateway{agentType: agentType}, nil
+	}
+	registry := NewAgentRegistry(&stubAgentGateway{agentType: "claude-code-cli"}, factory, nil)
+	implementer := label.AgentRoute{Agent: "claude-code-cli"}
+
+	// Without the guard the implementer reviews its own work
+	gw, _, err := registry.BuildReviewRequest(context.Background(), nil, implementer, output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+
+	// Unavailable and identical alternates are skipped
+	registry.SetSelfReviewGuard(true, []label.AgentRoute{
+		{Agent: "gemini-cli"},
+		{Agent: "claude-code-cli"},
+		{Agent: "claude-code-cli", Model: "claude-opus"},
+		{Agent: "codex"},
+	})
+	gw, req, err := registry.BuildReviewRequest(context.Background(), nil, implementer, output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+	assert.Equal(t, "claude-opus", req.Model)
+
+	// A different implementer keeps the routed reviewer
+	gw, req, err = registry.BuildReviewRequest(context.Background(), nil, label.AgentRoute{Agent: "codex"}, output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+	assert.Empty(t, req.Model)
+
+	// No alternate left
+	registry.SetSelfReviewGuard(true, []label.AgentRoute{{Agent: "claude-code-cli"}})
+	_, _, err = registry.BuildReviewRequest(context.Background(), nil, implementer, output.AgentRequest{})
+	assert.ErrorContains(t, err, "self-review guard")
+}
+
+func TestAgentRegistry_BuildTaskRequest_RoutingRules(t *testing.T) {
+	repo := &stubLabelRepo{labels: map[string]*label.Label{
+		"frontend": newRoutedLabel(t, "frontend", 1, label.RouteStepImplement, label.AgentRoute{Agent: "codex", Model: "gpt-5"}),
+	}}
+	factory := func(agentType string) (output.AgentGateway, error) {
+		return &stubAgentGateway{agentType: agentType}, nil
+	}
+	registry := NewAgentRegistry(&stubAgentGateway{agentType: "claude-code-cli"}, factory, repo)
+	router, err := domainservice.NewAgentRouter([]domainservice.AgentRoutingRule{
+		{Name: "docs", FileTypes: []string{".md"}, Model: "claude-haiku"},
+		{Name: "large", MinHours: 8, Agent: "gemini-cli"},
+	})
+	require.NoError(t, err)
+	registry.SetRouter(router)
+
+	docs := domainservice.TaskTraits{Labels: []string{"frontend"}, FilePaths: []string{"README.md"}}
+
+	// A routed label takes precedence over the rules
+	gw, req, decision, err := registry.BuildTaskRequest(context.Background(), docs, "implement", output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "codex", gw.GetCapability().AgentType)
+	assert.Equal(t, "gpt-5", req.Model)
+	assert.Equal(t, RouteSourceLabel, decision.Source)
+
+	// Without a label route the first matching rule applies
+	gw, req, decision, err = registry.BuildTaskRequest(context.Background(), docs, "review", output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+	assert.Equal(t, "claude-haiku", req.Model)
+	assert.Equal(t, RouteDecision{Source: RouteSourceRule, Rule: "docs", Route: label.AgentRoute{Model: "claude-haiku"}}, decision)
+
+	gw, _, decision, err = registry.BuildTaskRequest(context.Background(), domainservice.TaskTraits{EstimatedHours: 16}, "force_implement", output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "gemini-cli", gw.GetCapability().AgentType)
+	assert.Equal(t, "large", decision.Rule)
+
+	// No rule matches
+	gw, req, decision, err = registry.BuildTaskRequest(context.Background(), domainservice.TaskTraits{EstimatedHours: 2}, "implement", output.AgentRequest{Model: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-code-cli", gw.GetCapability().AgentType)
+	assert.Equal(t, "default", req.Model)
+	assert.Equal(t, RouteSourceDefault, decision.Source)
+
+	// The self-review guard replaces a rule route that matches the implementer
+	registry.SetSelfReviewGuard(true, []label.AgentRoute{{Agent: "codex"}})
+	gw, _, decision, err = registry.BuildTaskReviewRequest(context.Background(), docs, label.AgentRoute{Agent: "claude-code-cli", Model: "claude-haiku"}, output.AgentRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "codex", gw.GetCapability().AgentType)
+	assert.Equal(t, RouteSourceReviewAlternate, decision.Source)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// CacheableSteps are the workflow steps whose agent output can be cached
+// A review's value is its report and the decision in it, which the runner submits itself on a
+// hit; the implement steps are never cached because their value is the code the agent changes,
+// and the done step is never called with a prompt.
+var CacheableSteps = []string{"review"}
+
+// AgentResponseCache reuses the agent output of a step when the same agent and model were
+// already given the same prompt for the same working tree, cutting cost when reviews are
+// re-run on unchanged code (e.g. re-validating during test reruns).
+// Entries expire after the TTL; in bypass mode the agent is always called and the
+// entry is refreshed with its new output.
+type AgentResponseCache struct {
+	repo   repository.AgentResponseCacheRepository
+	root   string
+	steps  map[string]bool
+	ttl    time.Duration
+	bypass bool
+	now    func() time.Time
+	runGit gitRunner
+}
+
+// NewAgentResponseCache creates a cache of the outputs of the given steps for the working tree
+// at root
+// Steps that are not cacheable are ignored; a ttl <= 0 keeps entries until they are replaced.
+func NewAgentResponseCache(repo repository.AgentResponseCacheRepository, root string, steps []string, ttl time.Duration) *AgentResponseCache {
+	cache := &AgentResponseCache{
+		repo:   repo,
+		root:   root,
+		steps:  make(map[string]bool),
+		ttl:    ttl,
+		now:    time.Now,
+		runGit: runGitCommand,
+	}
+	for _, step := range steps {
+		for _, cacheable := range CacheableSteps {
+			if step == cacheable {
+				cache.steps[step] = true
+			}
+		}
+	}
+	return cache
+}
+
+// SetBypass makes every lookup miss, so that fresh outputs replace the cached ones
+func (c *AgentResponseCache) SetBypass(bypass bool) {
+	c.bypass = bypass
+}
+
+// Applies reports whether the outputs of a step are cached
+func (c *AgentResponseCache) Applies(step string) bool {
+	return c != nil && c.steps[step]
+}
+
+// PromptHash returns the cache key of a prompt sent to an agent and model
+func PromptHash(agentType, model, prompt string) string {
+	sum := sha256.Sum256([]byte(agentType + "\x00" + model + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// turnNumberPattern matches the turn number where prompts mention it ("--turn 3", "**Turn**: 3")
+var turnNumberPattern = regexp.MustCompile(`(?i)(\bturn\W{0,4})\d+\b`)
+
+// turnReportPattern matches the numbered report files of turns ("implement_2.md")
+var turnReportPattern = regexp.MustCompile(`_\d+\.md\b`)
+
+// KeyPrompt returns the prompt a step's output is cached under: the prompt with the turn
+// number, the step's artifact path and the numbers of turn reports normalised out, followed by a fingerprint of the
+// working tree, so that a later turn reviewing the same code hits and changed code misses
+func (c *AgentResponseCache) KeyPrompt(ctx context.Context, prompt string, turn int, artifactPath string) (string, error) {
+	if artifactPath != "" {
+		prompt = strings.ReplaceAll(prompt, artifactPath, "<artifact>")
+	}
+	prompt = turnReportPattern.ReplaceAllString(prompt, "_<turn>.md")
+	prompt = turnNumberPattern.ReplaceAllStringFunc(prompt, func(match string) string {
+		groups := turnNumberPattern.FindStringSubmatch(match)
+		if strings.TrimPrefix(match, groups[1]) != strconv.Itoa(turn) {
+			return match
+		}
+		return groups[1] + "<turn>"
+	})
+
+	tree, err := c.workTreeFingerprint(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint working tree: %w", err)
+	}
+	return prompt + "\x00tree:" + tree, nil
+}
+
+// workTreeFingerprint hashes the tracked and untracked files of the working tree
+// .deespec is left out because the runner writes reports and state there on every turn.
+func (c *AgentResponseCache) workTreeFingerprint(ctx context.Context) (string, error) {
+	snapshot, err := snapshotWorkingTree(ctx, c.runGit, c.root)
+	if err != nil {
+		return "", err
+	}
+	tree, err := c.runGit(ctx, c.root, "ls-tree", snapshot)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, entry := range strings.Split(strings.TrimSpace(string(tree)), "\n") {
+		if !strings.HasSuffix(entry, "\t.deespec") {
+			hash.Write([]byte(entry + "\n"))
+		}
+	}
+
+	// Files the agent created are not part of the snapshot until they are added
+	others, err := c.runGit(ctx, c.root, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return "", err
+	}
+	var untracked []string
+	for _, path := range strings.Split(strings.TrimSpace(string(others)), "\n") {
+		if path != "" && !strings.HasPrefix(path, ".deespec/") {
+			untracked = append(untracked, path)
+		}
+	}
+	if len(untracked) > 0 {
+		blobs, err := c.runGit(ctx, c.root, append([]string{"hash-object", "--"}, untracked...)...)
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte(strings.Join(untracked, "\n") + "\n"))
+		hash.Write(blobs)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Lookup returns the cached output of a prompt, if a fresh one exists
+func (c *AgentResponseCache) Lookup(ctx context.Context, step, agentType, model, prompt string) (string, bool, error) {
+	if !c.Applies(step) || c.bypass {
+		return "", false, nil
+	}
+	hash := PromptHash(agentType, model, prompt)
+	cached, err := c.repo.Find(ctx, hash)
+	if err != nil {
+		return "", false, err
+	}
+	if cached == nil || c.expired(cached.CreatedAt) {
+		return "", false, nil
+	}
+	if err := c.repo.RecordHit(ctx, hash); err != nil {
+		return "", false, err
+	}
+	return cached.Output, true, nil
+}
+
+// Store caches the output of a prompt
+func (c *AgentResponseCache) Store(ctx context.Context, step, agentType, model, prompt, output string) error {
+	if !c.Applies(step) {
+		return nil
+	}
+	return c.repo.Save(ctx, &repository.CachedAgentResponse{
+		PromptHash: PromptHash(agentType, model, prompt),
+		AgentType:  agentType,
+		Model:      model,
+		Step:       step,
+		Output:     output,
+		CreatedAt:  c.now(),
+	})
+}
+
+// Prune deletes the expired entries and returns how many were deleted
+func (c *AgentResponseCache) Prune(ctx context.Context) (int, error) {
+	if c.ttl <= 0 {
+		return 0, nil
+	}
+	deleted, err := c.repo.DeleteBefore(ctx, c.now().Add(-c.ttl))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune agent response cache: %w", err)
+	}
+	return deleted, nil
+}
+
+// expired reports whether an entry created at createdAt is past the TTL
+func (c *AgentResponseCache) expired(createdAt time.Time) bool {
+	return c.ttl > 0 && c.now().Sub(createdAt) > c.ttl
+}
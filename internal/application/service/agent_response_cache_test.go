@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// memoryResponseCacheRepo keeps cached responses in memory
+type memoryResponseCacheRepo struct {
+	entries map[string]*repository.CachedAgentResponse
+}
+
+func newMemoryResponseCacheRepo() *memoryResponseCacheRepo {
+	return &memoryResponseCacheRepo{entries: make(map[string]*repository.CachedAgentResponse)}
+}
+
+func (m *memoryResponseCacheRepo) Find(ctx context.Context, promptHash string) (*repository.CachedAgentResponse, error) {
+	return m.entries[promptHash], nil
+}
+
+func (m *memoryResponseCacheRepo) Save(ctx context.Context, response *repository.CachedAgentResponse) error {
+	saved := *response
+	saved.Hits = 0
+	m.entries[response.PromptHash] = &saved
+	return nil
+}
+
+func (m *memoryResponseCacheRepo) RecordHit(ctx context.Context, promptHash string) error {
+	m.entries[promptHash].Hits++
+	return nil
+}
+
+func (m *memoryResponseCacheRepo) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	deleted := 0
+	for hash, entry := range m.entries {
+		if entry.CreatedAt.Before(before) {
+			delete(m.entries, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestAgentResponseCache_LookupAndStore(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryResponseCacheRepo()
+	cache := NewAgentResponseCache(repo, ".", []string{"review", "done", "implement"}, 0)
+
+	assert.True(t, cache.Applies("review"))
+	assert.False(t, cache.Applies("implement"), "implement outputs are never cached")
+	assert.False(t, cache.Applies("done"), "done is never called with a prompt")
+	assert.False(t, NewAgentResponseCache(repo, ".", nil, 0).Applies("review"), "only configured steps are cached")
+
+	_, hit, err := cache.Lookup(ctx, "review", "claude", "opus", "review it")
+	require.NoError(t, err)
+	assert.False(t, hit)
+
+	require.NoError(t, cache.Store(ctx, "review", "claude", "opus", "review it", "DECISION: SUCCEEDED"))
+	output, hit, err := cache.Lookup(ctx, "review", "claude", "opus", "review it")
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, "DECISION: SUCCEEDED", output)
+	assert.Equal(t, 1, repo.entries[PromptHash("claude", "opus", "review it")].Hits)
+
+	// Any difference in agent, model or prompt is a different entry
+	for _, key := range [][3]string{
+		{"gemini", "opus", "review it"},
+		{"claude", "sonnet", "review it"},
+		{"claude", "opus", "review it!"},
+	} {
+		_, hit, err := cache.Lookup(ctx, "review", key[0], key[1], key[2])
+		require.NoError(t, err)
+		assert.False(t, hit, "%v", key)
+	}
+
+	// Steps that are not cached are neither stored nor looked up
+	require.NoError(t, cache.Store(ctx, "implement", "claude", "opus", "edit", "DIFF"))
+	assert.Len(t, repo.entries, 1)
+}
+
+// fakeWorkTree answers the git commands of the working tree fingerprint
+type fakeWorkTree struct {
+	tree      string // ls-tree output of the snapshot
+	untracked string // ls-files --others output
+	blobs     string // hash-object output
+	err       error
+}
+
+func (f *fakeWorkTree) run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	switch args[0] {
+	case "stash":
+		return []byte("stash-sha\n"), nil
+	case "ls-tree":
+		return []byte(f.tree), nil
+	case "ls-files":
+		return []byte(f.untracked), nil
+	case "hash-object":
+		return []byte(f.blobs), nil
+	}
+	return nil, errors.New("unexpected git command: " + strings.Join(args, " "))
+}
+
+func TestAgentResponseCache_KeyPrompt(t *testing.T) {
+	ctx := context.Background()
+	workTree := &fakeWorkTree{tree: "040000 tree aaa\tsrc\n040000 tree ddd\t.deespec\n"}
+	cache := NewAgentResponseCache(newMemoryResponseCacheRepo(), ".", CacheableSteps, 0)
+	cache.runGit = workTree.run
+
+	reviewPrompt := func(turn string) string {
+		return "deespec sbi review SBI-1 --turn " + turn + " --decision X\n" +
+			"- **Turn**: " + turn + "\n" +
+			"Write review report to `.deespec/reports/sbi/SBI-1/review_" + turn + ".md`\n" +
+			"Read the implement report implement_" + turn + ".md (max 3 files)\n"
+	}
+	key := func(prompt string, turn int, artifactPath string) string {
+		t.Helper()
+		k, err := cache.KeyPrompt(ctx, prompt, turn, artifactPath)
+		require.NoError(t, err)
+		return k
+	}
+
+	turn2 := key(reviewPrompt("2"), 2, ".deespec/reports/sbi/SBI-1/review_2.md")
+	turn3 := key(reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md")
+	assert.Equal(t, turn2, turn3, "the turn and artifact path are not part of the key")
+	assert.Contains(t, turn3, "(max 3 files)", "other numbers are kept")
+	assert.NotEqual(t, turn3, key(reviewPrompt("2"), 3, ".deespec/reports/sbi/SBI-1/review_3.md"),
+		"a prompt about another turn is a different prompt")
+
+	// Reports and state written to .deespec do not change the key; code does
+	workTree.tree = "040000 tree aaa\tsrc\n040000 tree eee\t.deespec\n"
+	assert.Equal(t, turn3, key(reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md"))
+	workTree.untracked = ".deespec/var/state.json\n"
+	assert.Equal(t, turn3, key(reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md"))
+
+	workTree.untracked = "src/new.go\n"
+	workTree.blobs = "bbb\n"
+	withNewFile := key(reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md")
+	assert.NotEqual(t, turn3, withNewFile, "untracked files are part of the working tree")
+	workTree.blobs = "ccc\n"
+	assert.NotEqual(t, withNewFile, key(reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md"))
+
+	workTree.untracked = ""
+	workTree.tree = "040000 tree fff\tsrc\n040000 tree eee\t.deespec\n"
+	assert.NotEqual(t, turn3, key(reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md"), "changed code misses")
+
+	// Without a fingerprint nothing can be cached
+	workTree.err = errors.New("not a git repository")
+	_, err := cache.KeyPrompt(ctx, reviewPrompt("3"), 3, ".deespec/reports/sbi/SBI-1/review_3.md")
+	assert.Error(t, err)
+}
+
+func TestAgentResponseCache_TTLAndBypass(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryResponseCacheRepo()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewAgentResponseCache(repo, ".", CacheableSteps, 24*time.Hour)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Store(ctx, "review", "claude", "", "review it", "DECISION: SUCCEEDED"))
+
+	cache.SetBypass(true)
+	_, hit, err := cache.Lookup(ctx, "review", "claude", "", "review it")
+	require.NoError(t, err)
+	assert.False(t, hit, "bypass always calls the agent")
+	cache.SetBypass(false)
+
+	now = now.Add(25 * time.Hour)
+	_, hit, err = cache.Lookup(ctx, "review", "claude", "", "review it")
+	require.NoError(t, err)
+	assert.False(t, hit, "expired entries are not reused")
+
+	deleted, err := cache.Prune(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, repo.entries)
+}
+
+func TestAgentResponseCache_NilIsDisabled(t *testing.T) {
+	var cache *AgentResponseCache
+	assert.False(t, cache.Applies("review"))
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// apiTokenPrefix marks deespec API token secrets
+const apiTokenPrefix = "dsp_"
+
+// apiTokenNamePattern restricts token names to identifiers safe to print and log
+var apiTokenNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// Audit outcomes recorded by the API server
+const (
+	APIAuditOutcomeOK     = "ok"
+	APIAuditOutcomeError  = "error"
+	APIAuditOutcomeDenied = "denied"
+)
+
+var (
+	// ErrAPIUnauthenticated is returned when a request carries no valid token
+	ErrAPIUnauthenticated = errors.New("missing or invalid API token")
+
+	// ErrAPIPermissionDenied is returned when the token lacks the route's scope
+	ErrAPIPermissionDenied = errors.New("API token lacks the required scope")
+)
+
+// apiTokenContextKey keys the authenticated token in a request context
+type apiTokenContextKey struct{}
+
+// APIAuthorizer authenticates API server requests by bearer token, authorizes them
+// by scope and records state changes and refusals in the audit log
+type APIAuthorizer struct {
+	tokenRepo repository.APITokenRepository
+	auditRepo repository.APIAuditLogRepository
+	now       func() time.Time
+}
+
+// NewAPIAuthorizer creates an API authorizer
+func NewAPIAuthorizer(tokenRepo repository.APITokenRepository, auditRepo repository.APIAuditLogRepository) *APIAuthorizer {
+	return &APIAuthorizer{
+		tokenRepo: tokenRepo,
+		auditRepo: auditRepo,
+		now:       time.Now,
+	}
+}
+
+// IsAPIScope reports whether scope is a known API token scope
+func IsAPIScope(scope string) bool {
+	switch scope {
+	case repository.APIScopeRead, repository.APIScopeRunTurn, repository.APIScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// HashAPIToken returns the stored hash of a token secret
+func HashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateToken creates a token with the given scopes and returns its secret
+// The secret is only available here; the repository keeps its hash
+func (a *APIAuthorizer) CreateToken(ctx context.Context, name string, scopes []string) (string, *repository.APIToken, error) {
+	if !apiTokenNamePattern.MatchString(name) {
+		return "", nil, fmt.Errorf("invalid token name %q (letters, digits, '.', '_' and '-', up to 64 characters)", name)
+	}
+	if len(scopes) == 0 {
+		return "", nil, fmt.Errorf("at least one scope is required (read, run-turn, admin)")
+	}
+	for _, scope := range scopes {
+		if !IsAPIScope(scope) {
+			return "", nil, fmt.Errorf("unknown scope %q (read, run-turn, admin)", scope)
+		}
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	secret := apiTokenPrefix + hex.EncodeToString(random)
+
+	token := &repository.APIToken{
+		Name:      name,
+		TokenHash: HashAPIToken(secret),
+		Scopes:    scopes,
+		CreatedAt: a.now(),
+	}
+	if err := a.tokenRepo.Save(ctx, token); err != nil {
+		return "", nil, err
+	}
+	return secret, token, nil
+}
+
+// Enabled reports whether any active token exists; without one the API server runs unauthenticated
+func (a *APIAuthorizer) Enabled(ctx context.Context) (bool, error) {
+	count, err := a.tokenRepo.CountActive(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Authorize authenticates a secret and checks that its token grants scope
+// Returns the token, or ErrAPIUnauthenticated / ErrAPIPermissionDenied. Refusals are audited.
+func (a *APIAuthorizer) Authorize(ctx context.Context, secret, route, scope string) (*repository.APIToken, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		a.Audit(ctx, "", route, "", APIAuditOutcomeDenied, "no token")
+		return nil, ErrAPIUnauthenticated
+	}
+
+	token, err := a.tokenRepo.FindActiveByHash(ctx, HashAPIToken(secret))
+	if errors.Is(err, repository.ErrAPITokenNotFound) {
+		a.Audit(ctx, "", route, "", APIAuditOutcomeDenied, "invalid or revoked token")
+		return nil, ErrAPIUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.HasScope(scope) {
+		a.Audit(ctx, token.Name, route, "", APIAuditOutcomeDenied, fmt.Sprintf("requires scope %s", scope))
+		return nil, fmt.Errorf("%w: %s requires %s", ErrAPIPermissionDenied, route, scope)
+	}
+
+	if err := a.tokenRepo.TouchLastUsed(ctx, token.Name, a.now()); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to record API token use: %v\n", err)
+	}
+	return token, nil
+}
+
+// Audit records an audit entry; failures are reported but never fail the request
+func (a *APIAuthorizer) Audit(ctx context.Context, tokenName, route, target, outcome, detail string) {
+	if a.auditRepo == nil {
+		return
+	}
+	entry := &repository.APIAuditEntry{
+		TokenName: tokenName,
+		Route:     route,
+		Target:    target,
+		Outcome:   outcome,
+		Detail:    detail,
+		CreatedAt: a.now(),
+	}
+	// The request context may already be cancelled when a stream ends
+	if err := a.auditRepo.Save(context.WithoutCancel(ctx), entry); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to write API audit entry: %v\n", err)
+	}
+}
+
+// WithAPIToken returns a context carrying the authenticated token
+func WithAPIToken(ctx context.Context, token *repository.APIToken) context.Context {
+	return context.WithValue(ctx, apiTokenContextKey{}, token)
+}
+
+// APITokenFromContext returns the authenticated token of a request, or nil
+func APITokenFromContext(ctx context.Context) *repository.APIToken {
+	token, _ := ctx.Value(apiTokenContextKey{}).(*repository.APIToken)
+	return token
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// memoryAPITokenRepo keeps API tokens in memory
+type memoryAPITokenRepo struct {
+	tokens []*repository.APIToken
+}
+
+func (m *memoryAPITokenRepo) Save(ctx context.Context, token *repository.APIToken) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *memoryAPITokenRepo) FindActiveByHash(ctx context.Context, tokenHash string) (*repository.APIToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash && token.RevokedAt == nil {
+			return token, nil
+		}
+	}
+	return nil, repository.ErrAPITokenNotFound
+}
+
+func (m *memoryAPITokenRepo) List(ctx context.Context) ([]*repository.APIToken, error) {
+	return m.tokens, nil
+}
+
+func (m *memoryAPITokenRepo) CountActive(ctx context.Context) (int, error) {
+	count := 0
+	for _, token := range m.tokens {
+		if token.RevokedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *memoryAPITokenRepo) Revoke(ctx context.Context, name string) error {
+	for _, token := range m.tokens {
+		if token.Name == name && token.RevokedAt == nil {
+			now := time.Now()
+			token.RevokedAt = &now
+			return nil
+		}
+	}
+	return repository.ErrAPITokenNotFound
+}
+
+func (m *memoryAPITokenRepo) TouchLastUsed(ctx context.Context, name string, at time.Time) error {
+	for _, token := range m.tokens {
+		if token.Name == name {
+			token.LastUsedAt = &at
+		}
+	}
+	return nil
+}
+
+// memoryAPIAuditRepo keeps audit entries in memory
+type memoryAPIAuditRepo struct {
+	entries []*repository.APIAuditEntry
+}
+
+func (m *memoryAPIAuditRepo) Save(ctx context.Context, entry *repository.APIAuditEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *memoryAPIAuditRepo) ListRecent(ctx context.Context, limit int) ([]*repository.APIAuditEntry, error) {
+	return m.entries, nil
+}
+
+func TestAPIAuthorizer_CreateToken(t *testing.T) {
+	tokens := &memoryAPITokenRepo{}
+	authorizer := NewAPIAuthorizer(tokens, &memoryAPIAuditRepo{})
+	ctx := context.Background()
+
+	enabled, err := authorizer.Enabled(ctx)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	secret, token, err := authorizer.CreateToken(ctx, "ci", []string{"read", "run-turn"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "dsp_"))
+	assert.Equal(t, HashAPIToken(secret), token.TokenHash)
+	assert.NotContains(t, token.TokenHash, secret)
+
+	enabled, err = authorizer.Enabled(ctx)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	_, _, err = authorizer.CreateToken(ctx, "bad name", []string{"read"})
+	assert.Error(t, err)
+	_, _, err = authorizer.CreateToken(ctx, "ops", []string{"write"})
+	assert.Error(t, err)
+	_, _, err = authorizer.CreateToken(ctx, "ops", nil)
+	assert.Error(t, err)
+}
+
+func TestAPIAuthorizer_Authorize(t *testing.T) {
+	tokens := &memoryAPITokenRepo{}
+	audit := &memoryAPIAuditRepo{}
+	authorizer := NewAPIAuthorizer(tokens, audit)
+	ctx := context.Background()
+
+	readSecret, _, err := authorizer.CreateToken(ctx, "dashboard", []string{"read"})
+	require.NoError(t, err)
+	adminSecret, _, err := authorizer.CreateToken(ctx, "ops", []string{"admin"})
+	require.NoError(t, err)
+
+	token, err := authorizer.Authorize(ctx, readSecret, "StreamEvents", repository.APIScopeRead)
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard", token.Name)
+	assert.NotNil(t, token.LastUsedAt)
+
+	// Admin grants every scope
+	_, err = authorizer.Authorize(ctx, adminSecret, "RunTurn", repository.APIScopeRunTurn)
+	assert.NoError(t, err)
+
+	_, err = authorizer.Authorize(ctx, readSecret, "RunTurn", repository.APIScopeRunTurn)
+	assert.True(t, errors.Is(err, ErrAPIPermissionDenied))
+
+	_, err = authorizer.Authorize(ctx, "", "RunTurn", repository.APIScopeRunTurn)
+	assert.True(t, errors.Is(err, ErrAPIUnauthenticated))
+
+	require.NoError(t, tokens.Revoke(ctx, "dashboard"))
+	_, err = authorizer.Authorize(ctx, readSecret, "StreamEvents", repository.APIScopeRead)
+	assert.True(t, errors.Is(err, ErrAPIUnauthenticated))
+
+	// Only refusals are audited by Authorize
+	require.Len(t, audit.entries, 3)
+	assert.Equal(t, "dashboard", audit.entries[0].TokenName)
+	assert.Equal(t, APIAuditOutcomeDenied, audit.entries[0].Outcome)
+	assert.Equal(t, "", audit.entries[1].TokenName)
+	assert.Equal(t, "invalid or revoked token", audit.entries[2].Detail)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// Attestation is the execution record of a done SBI in a CycloneDX-like JSON format:
+// the SBI is the subject (metadata.component), every step artifact is a file component
+// with its hash, and deespec-specific facts are name/value properties
+type Attestation struct {
+	BOMFormat    string                 `json:"bomFormat"`
+	SpecVersion  string                 `json:"specVersion"`
+	SerialNumber string                 `json:"serialNumber"`
+	Version      int                    `json:"version"`
+	Metadata     AttestationMetadata    `json:"metadata"`
+	Components   []AttestationComponent `json:"components"`
+	Properties   []AttestationProperty  `json:"properties,omitempty"`
+}
+
+// AttestationMetadata describes when and by which tool an attestation was made, and its subject
+type AttestationMetadata struct {
+	Timestamp string               `json:"timestamp"`
+	Tools     AttestationTools     `json:"tools"`
+	Component AttestationComponent `json:"component"`
+}
+
+// AttestationTools lists the tools that made an attestation
+type AttestationTools struct {
+	Components []AttestationComponent `json:"components"`
+}
+
+// AttestationComponent is the SBI, a step artifact or a tool
+type AttestationComponent struct {
+	Type        string                `json:"type"`
+	BOMRef      string                `json:"bom-ref,omitempty"`
+	Name        string                `json:"name"`
+	Version     string                `json:"version,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Hashes      []AttestationHash     `json:"hashes,omitempty"`
+	Properties  []AttestationProperty `json:"properties,omitempty"`
+}
+
+// AttestationHash is a digest of a component
+type AttestationHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// AttestationProperty is a name/value fact, named with the "deespec:" prefix
+type AttestationProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AttestationBuilder builds the attestation of a done SBI from its artifact provenance and
+// the test results bundled for its reviews
+type AttestationBuilder struct {
+	root           string
+	sbiRepo        repository.SBIRepository
+	provenanceRepo repository.ArtifactProvenanceRepository
+	toolVersion    string
+	now            func() time.Time
+}
+
+// NewAttestationBuilder creates an attestation builder for the project at root
+func NewAttestationBuilder(root string, sbiRepo repository.SBIRepository, provenanceRepo repository.ArtifactProvenanceRepository, toolVersion string) *AttestationBuilder {
+	return &AttestationBuilder{
+		root:           root,
+		sbiRepo:        sbiRepo,
+		provenanceRepo: provenanceRepo,
+		toolVersion:    toolVersion,
+		now:            time.Now,
+	}
+}
+
+// Build returns the attestation of an SBI; only done SBIs can be attested
+func (b *AttestationBuilder) Build(ctx context.Context, sbiID string) (*Attestation, error) {
+	s, err := b.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find SBI: %w", err)
+	}
+	if s.Status() != model.StatusDone {
+		return nil, fmt.Errorf("%w: SBI %s is %s; only done SBIs can be attested", model.ErrInvalidState, sbiID, s.Status())
+	}
+	records, err := b.provenanceRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := AttestationComponent{
+		Type:   "data",
+		BOMRef: "sbi:" + sbiID,
+		Name:   s.Title(),
+		Properties: []AttestationProperty{
+			{Name: "deespec:sbi_id", Value: sbiID},
+			{Name: "deespec:status", Value: string(s.Status())},
+			{Name: "deespec:turns", Value: strconv.Itoa(s.ExecutionState().CurrentTurn.Value())},
+		},
+	}
+	if parent := s.ParentTaskID(); parent != nil {
+		subject.Properties = append(subject.Properties, AttestationProperty{Name: "deespec:pbi_id", Value: parent.String()})
+	}
+	completedAt := s.UpdatedAt().Value()
+	if c := s.Metadata().CompletedAt; c != nil {
+		completedAt = *c
+	}
+	subject.Properties = append(subject.Properties, AttestationProperty{Name: "deespec:completed_at", Value: completedAt.UTC().Format(time.RFC3339)})
+
+	attestation := &Attestation{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: attestationSerial(sbiID, completedAt),
+		Version:      1,
+		Metadata: AttestationMetadata{
+			Timestamp: b.now().UTC().Format(time.RFC3339),
+			Tools:     AttestationTools{Components: []AttestationComponent{{Type: "application", Name: "deespec", Version: b.toolVersion}}},
+			Component: subject,
+		},
+		Components: []AttestationComponent{},
+	}
+
+	lastReviewTurn := 0
+	for i, record := range records {
+		attestation.Components = append(attestation.Components, artifactComponent(i+1, record))
+		if record.Step == "review" && record.Turn > lastReviewTurn {
+			lastReviewTurn = record.Turn
+		}
+	}
+	var decisions []string
+	for _, record := range records {
+		if record.Step == "review" && record.Turn == lastReviewTurn && record.Decision != "" {
+			decisions = append(decisions, record.Decision)
+		}
+	}
+	if len(decisions) > 0 {
+		attestation.Properties = append(attestation.Properties, AttestationProperty{Name: "deespec:review_decision", Value: strings.Join(decisions, ", ")})
+	}
+	attestation.Properties = append(attestation.Properties, b.testRuns(sbiID, lastReviewTurn)...)
+	return attestation, nil
+}
+
+// artifactComponent describes the artifact of a provenance record
+func artifactComponent(n int, record *repository.ArtifactProvenance) AttestationComponent {
+	component := AttestationComponent{
+		Type:   "file",
+		BOMRef: fmt.Sprintf("artifact:%d", n),
+		Name:   filepath.ToSlash(record.ArtifactPath),
+	}
+	if record.ArtifactHash != "" {
+		component.Hashes = []AttestationHash{{Alg: "SHA-256", Content: record.ArtifactHash}}
+	}
+	for _, p := range []AttestationProperty{
+		{Name: "deespec:step", Value: record.Step},
+		{Name: "deespec:turn", Value: strconv.Itoa(record.Turn)},
+		{Name: "deespec:agent", Value: record.AgentType},
+		{Name: "deespec:model", Value: record.Model},
+		{Name: "deespec:agent_version", Value: record.AgentVersion},
+		{Name: "deespec:reviewer", Value: record.Reviewer},
+		{Name: "deespec:decision", Value: record.Decision},
+		{Name: "deespec:prompt_sha256", Value: record.PromptHash},
+		{Name: "deespec:created_at", Value: record.CreatedAt.UTC().Format(time.RFC3339)},
+	} {
+		if p.Value != "" {
+			component.Properties = append(component.Properties, p)
+		}
+	}
+	return component
+}
+
+// testRuns returns the test results bundled for the reviews of an SBI, up to the last review turn
+func (b *AttestationBuilder) testRuns(sbiID string, lastTurn int) []AttestationProperty {
+	var runs []AttestationProperty
+	for turn := 1; turn <= lastTurn; turn++ {
+		content, err := os.ReadFile(filepath.Join(b.root, ReviewContextPath(sbiID, turn)))
+		if err != nil {
+			continue
+		}
+		if command, result, elapsed, ok := ParseReviewTestResult(string(content)); ok {
+			runs = append(runs, AttestationProperty{
+				Name:  "deespec:test_run",
+				Value: fmt.Sprintf("turn %d: `%s` %s in %s", turn, command, result, elapsed),
+			})
+		}
+	}
+	return runs
+}
+
+// attestationSerial derives a stable urn:uuid serial number from the SBI and its completion,
+// so that exporting an attestation again yields the same serial
+func attestationSerial(sbiID string, completedAt time.Time) string {
+	sum := sha256.Sum256([]byte(sbiID + "\x00" + completedAt.UTC().Format(time.RFC3339Nano)))
+	sum[6] = (sum[6] & 0x0f) | 0x50 // Name-based UUID layout (version 5)
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// fakeProvenanceRepo serves fixed provenance records
+type fakeProvenanceRepo struct {
+	records []*repository.ArtifactProvenance
+}
+
+func (r *fakeProvenanceRepo) Save(ctx context.Context, provenance *repository.ArtifactProvenance) error {
+	r.records = append(r.records, provenance)
+	return nil
+}
+
+func (r *fakeProvenanceRepo) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.ArtifactProvenance, error) {
+	var found []*repository.ArtifactProvenance
+	for _, record := range r.records {
+		if record.SBIID == sbiID {
+			found = append(found, record)
+		}
+	}
+	return found, nil
+}
+
+func TestAttestationBuilder_Build(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+
+	s, err := sbi.NewSBI("Add login", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	sbiRepo := newMockSBIRepo()
+	sbiID := s.ID().String()
+	sbiRepo.sbis[sbiID] = s
+
+	provenanceRepo := &fakeProvenanceRepo{records: []*repository.ArtifactProvenance{
+		{SBIID: sbiID, Step: "implement", Turn: 1, ArtifactPath: "implement_1.md", ArtifactHash: "aa11", PromptHash: "p1", AgentType: "claude-code", Model: "sonnet"},
+		{SBIID: sbiID, Step: "review", Turn: 2, ArtifactPath: "review_2.md", ArtifactHash: "bb22", PromptHash: "p2", AgentType: "codex", Reviewer: "codex", Decision: "SUCCEEDED"},
+	}}
+	builder := NewAttestationBuilder(root, sbiRepo, provenanceRepo, "1.2.3")
+
+	// Only done SBIs can be attested
+	_, err = builder.Build(ctx, sbiID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only done SBIs")
+	assert.ErrorIs(t, err, model.ErrInvalidState)
+
+	for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+		require.NoError(t, s.UpdateStatus(next))
+	}
+	reviewContext := NewReviewContextBuilder(root)
+	reviewContext.SetTestCommand("echo ok", time.Minute)
+	_, err = reviewContext.Build(ctx, sbiID, 2)
+	require.NoError(t, err)
+
+	attestation, err := builder.Build(ctx, sbiID)
+	require.NoError(t, err)
+	assert.Equal(t, "CycloneDX", attestation.BOMFormat)
+	assert.Equal(t, "1.5", attestation.SpecVersion)
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, attestation.SerialNumber)
+	assert.Equal(t, "1.2.3", attestation.Metadata.Tools.Components[0].Version)
+	assert.Equal(t, "sbi:"+sbiID, attestation.Metadata.Component.BOMRef)
+	assert.Contains(t, attestation.Metadata.Component.Properties, AttestationProperty{Name: "deespec:status", Value: "DONE"})
+
+	require.Len(t, attestation.Components, 2)
+	review := attestation.Components[1]
+	assert.Equal(t, "review_2.md", review.Name)
+	assert.Equal(t, []AttestationHash{{Alg: "SHA-256", Content: "bb22"}}, review.Hashes)
+	assert.Contains(t, review.Properties, AttestationProperty{Name: "deespec:prompt_sha256", Value: "p2"})
+	assert.Contains(t, review.Properties, AttestationProperty{Name: "deespec:decision", Value: "SUCCEEDED"})
+	assert.NotContains(t, attestation.Components[0].Properties, AttestationProperty{Name: "deespec:decision", Value: ""})
+
+	assert.Contains(t, attestation.Properties, AttestationProperty{Name: "deespec:review_decision", Value: "SUCCEEDED"})
+	require.Len(t, attestation.Properties, 2)
+	assert.Equal(t, "deespec:test_run", attestation.Properties[1].Name)
+	assert.Contains(t, attestation.Properties[1].Value, "turn 2: `echo ok` passed in")
+
+	// Exporting again yields the same serial number
+	again, err := builder.Build(ctx, sbiID)
+	require.NoError(t, err)
+	assert.Equal(t, attestation.SerialNumber, again.SerialNumber)
+
+	data, err := json.Marshal(attestation)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"bom-ref":"artifact:1"`)
+}
+
+func TestParseReviewTestResult(t *testing.T) {
+	command, result, elapsed, ok := ParseReviewTestResult("## Tests\n\nCommand: `go test ./...` — failed (exit status 1) in 3s\n\n")
+	require.True(t, ok)
+	assert.Equal(t, "go test ./...", command)
+	assert.Equal(t, "failed (exit status 1)", result)
+	assert.Equal(t, "3s", elapsed)
+
+	_, _, _, ok = ParseReviewTestResult("## Tests\n\nNo test command is configured.\n")
+	assert.False(t, ok)
+}
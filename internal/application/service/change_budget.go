@@ -0,0 +1,169 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// EventTurnNeedsReview is the notification event emitted when a turn is flagged for human review
+const EventTurnNeedsReview = "turn.needs_human_review"
+
+// ChangeBudgetChecker measures the code change of implement turns against the change budget
+// A turn's change is the diff between a snapshot of the working tree taken before the agent ran
+// (`git stash create`, which leaves the tree untouched) and the tree afterwards, plus the files
+// the agent created; deespec's own files are not counted.
+type ChangeBudgetChecker struct {
+	root          string
+	budget        domainservice.ChangeBudget
+	splitAttempts int
+	notifier      output.NotificationGateway // Optional: notifications of flagged turns
+	runGit        gitRunner
+}
+
+// ChangeBase is the working tree an implement turn's change is measured from
+type ChangeBase struct {
+	commit    string
+	untracked map[string]bool // Untracked files that predate the turn
+}
+
+// NewChangeBudgetChecker creates a checker of the budget for the project at root
+// onExceed is domainservice.ChangeBudgetSplit or ChangeBudgetFlag; with ChangeBudgetSplit the
+// agent is asked up to splitAttempts times to cut its change down before the turn is flagged.
+func NewChangeBudgetChecker(root string, budget domainservice.ChangeBudget, onExceed string, splitAttempts int, notifier output.NotificationGateway) *ChangeBudgetChecker {
+	if onExceed != domainservice.ChangeBudgetSplit || splitAttempts < 0 {
+		splitAttempts = 0
+	}
+	return &ChangeBudgetChecker{
+		root:          root,
+		budget:        budget,
+		splitAttempts: splitAttempts,
+		notifier:      notifier,
+		runGit:        runGitCommand,
+	}
+}
+
+// Budget returns the limits checked
+func (c *ChangeBudgetChecker) Budget() domainservice.ChangeBudget {
+	return c.budget
+}
+
+// SplitAttempts returns how many times the agent is asked to split an oversized change
+func (c *ChangeBudgetChecker) SplitAttempts() int {
+	return c.splitAttempts
+}
+
+// Snapshot records the working tree before an implement turn
+// Returns nil when the checker is nil or the project is not a git repository.
+func (c *ChangeBudgetChecker) Snapshot(ctx context.Context) *ChangeBase {
+	if c == nil || !c.budget.Enabled() {
+		return nil
+	}
+	commit, err := snapshotWorkingTree(ctx, c.runGit, c.root)
+	if err != nil || commit == "" {
+		return nil
+	}
+	untracked, err := c.untrackedFiles(ctx)
+	if err != nil {
+		return nil
+	}
+	base := &ChangeBase{commit: commit, untracked: make(map[string]bool, len(untracked))}
+	for _, file := range untracked {
+		base.untracked[file] = true
+	}
+	return base
+}
+
+// Measure returns the change made to the working tree since base
+// Binary files count as changed files without lines; files that were already untracked when
+// base was taken are not counted.
+func (c *ChangeBudgetChecker) Measure(ctx context.Context, base *ChangeBase) (domainservice.ChangeStat, error) {
+	var stat domainservice.ChangeStat
+	out, err := c.runGit(ctx, c.root, append([]string{"diff", "--numstat", base.commit}, reviewDiffExclude...)...)
+	if err != nil {
+		return stat, fmt.Errorf("failed to diff the working tree: %w", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		stat.Files++
+		// Binary files are listed as "-\t-\t<path>"
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+		stat.Added += added
+		stat.Deleted += deleted
+	}
+
+	untracked, err := c.untrackedFiles(ctx)
+	if err != nil {
+		return stat, fmt.Errorf("failed to list new files: %w", err)
+	}
+	for _, file := range untracked {
+		if base.untracked[file] {
+			continue
+		}
+		stat.Files++
+		content, err := os.ReadFile(filepath.Join(c.root, file))
+		if err != nil || bytes.IndexByte(content, 0) >= 0 {
+			continue
+		}
+		stat.Added += bytes.Count(content, []byte("\n"))
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			stat.Added++
+		}
+	}
+	return stat, nil
+}
+
+// NotifyFlagged notifies that an implement turn was flagged for human review
+func (c *ChangeBudgetChecker) NotifyFlagged(ctx context.Context, s *sbi.SBI, turn int, stat domainservice.ChangeStat) error {
+	if c == nil || c.notifier == nil {
+		return nil
+	}
+	notification := output.Notification{
+		Level:     output.NotificationLevelWarning,
+		Event:     EventTurnNeedsReview,
+		Title:     fmt.Sprintf("Turn needs human review: %s", s.Title()),
+		Message:   fmt.Sprintf("Turn %d changed %s, exceeding the change budget of %s", turn, stat, c.budget),
+		TaskID:    s.ID().String(),
+		Timestamp: time.Now(),
+		Fields: map[string]string{
+			"type":          "SBI",
+			"turn":          strconv.Itoa(turn),
+			"lines_changed": strconv.Itoa(stat.Lines()),
+			"files_changed": strconv.Itoa(stat.Files),
+			"max_lines":     strconv.Itoa(c.budget.MaxLines),
+			"max_files":     strconv.Itoa(c.budget.MaxFiles),
+		},
+	}
+	if err := c.notifier.Notify(ctx, notification); err != nil {
+		return fmt.Errorf("failed to notify flagged turn of %s: %w", s.ID(), err)
+	}
+	return nil
+}
+
+// untrackedFiles lists the untracked, not ignored files outside .deespec
+func (c *ChangeBudgetChecker) untrackedFiles(ctx context.Context) ([]string, error) {
+	out, err := c.runGit(ctx, c.root, append([]string{"ls-files", "--others", "--exclude-standard", "-z"}, reviewDiffExclude...)...)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, file := range strings.Split(string(out), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
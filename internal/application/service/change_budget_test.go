@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+func TestChangeBudgetChecker_Measure(t *testing.T) {
+	root := initReviewRepo(t)
+	ctx := context.Background()
+	checker := NewChangeBudgetChecker(root, domainservice.ChangeBudget{MaxLines: 5}, domainservice.ChangeBudgetSplit, 2, nil)
+	assert.Equal(t, 2, checker.SplitAttempts())
+
+	// Work that predates the turn is not part of its change
+	writeContextFile(t, root, "main.go", "package main\n\n// unrelated\n")
+	writeContextFile(t, root, "notes.txt", "scratch\n")
+	base := checker.Snapshot(ctx)
+	require.NotNil(t, base)
+
+	writeContextFile(t, root, "main.go", "package main\n\nfunc handler() {}\n")
+	writeContextFile(t, root, "handler.go", "package main\n\nfunc serve() {}")
+	writeContextFile(t, root, "notes.txt", "scratch\nmore\n")
+	writeContextFile(t, root, ".deespec/reports/sbi/SBI-1/implement_1.md", "Added the handler.\n")
+
+	stat, err := checker.Measure(ctx, base)
+	require.NoError(t, err)
+	// main.go: 1 line replaced; handler.go: 3 new lines
+	assert.Equal(t, domainservice.ChangeStat{Files: 2, Added: 4, Deleted: 1}, stat)
+	assert.False(t, checker.Budget().Exceeded(stat))
+
+	writeContextFile(t, root, "router.go", "package main\n")
+	stat, err = checker.Measure(ctx, base)
+	require.NoError(t, err)
+	assert.True(t, checker.Budget().Exceeded(stat))
+}
+
+func TestChangeBudgetChecker_DisabledOrNotGit(t *testing.T) {
+	ctx := context.Background()
+	var nilChecker *ChangeBudgetChecker
+	assert.Nil(t, nilChecker.Snapshot(ctx))
+
+	root := t.TempDir()
+	assert.Nil(t, NewChangeBudgetChecker(root, domainservice.ChangeBudget{}, domainservice.ChangeBudgetSplit, 1, nil).Snapshot(ctx))
+	checker := NewChangeBudgetChecker(root, domainservice.ChangeBudget{MaxFiles: 1}, domainservice.ChangeBudgetFlag, 1, nil)
+	assert.Equal(t, 0, checker.SplitAttempts(), "flagged turns are not split")
+	assert.Nil(t, checker.Snapshot(ctx), "nothing to measure outside a git repository")
+}
+
+func TestChangeBudgetChecker_NotifyFlagged(t *testing.T) {
+	notifier := &recordingNotifier{}
+	checker := NewChangeBudgetChecker(t.TempDir(), domainservice.ChangeBudget{MaxLines: 300, MaxFiles: 10}, domainservice.ChangeBudgetFlag, 0, notifier)
+	s, err := sbi.NewSBI("Login", "Add login", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+
+	require.NoError(t, checker.NotifyFlagged(context.Background(), s, 3, domainservice.ChangeStat{Files: 12, Added: 400, Deleted: 12}))
+	require.Len(t, notifier.notifications, 1)
+	notification := notifier.notifications[0]
+	assert.Equal(t, EventTurnNeedsReview, notification.Event)
+	assert.Equal(t, s.ID().String(), notification.TaskID)
+	assert.Equal(t, "Turn 3 changed 412 lines in 12 files, exceeding the change budget of 300 lines, 10 files", notification.Message)
+	assert.Equal(t, "412", notification.Fields["lines_changed"])
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sources of the files ChangeHistory attributes to an SBI, most precise first
+const (
+	// ChangeSourceCommits are the files of commits whose message mentions the SBI ID
+	ChangeSourceCommits = "commits"
+	// ChangeSourceWorkWindow are the files committed while the SBI was being worked on
+	ChangeSourceWorkWindow = "work_window"
+	// ChangeSourceDeclared are the files the SBI declared it would modify
+	ChangeSourceDeclared = "declared"
+)
+
+// ChangeHistory reads from git history what changed for finished work: when a release ref
+// was made and which files an SBI changed, for change logs composed from DONE SBIs
+type ChangeHistory struct {
+	root     string
+	maxFiles int
+	runGit   gitRunner
+}
+
+// NewChangeHistory creates a change history reader for the git repository at root
+// maxFiles caps the files attributed to one SBI (values <= 0 keep every file).
+func NewChangeHistory(root string, maxFiles int) *ChangeHistory {
+	return &ChangeHistory{
+		root:     root,
+		maxFiles: maxFiles,
+		runGit:   runGitCommand,
+	}
+}
+
+// RefTime returns the commit time of a git ref such as a release tag ("v0.3.0")
+func (h *ChangeHistory) RefTime(ctx context.Context, ref string) (time.Time, error) {
+	out, err := h.runGit(ctx, h.root, "show", "-s", "--format=%cI", ref+"^{commit}", "--")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to resolve git ref %s: %w", ref, err)
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse commit time of %s: %w", ref, err)
+	}
+	return t, nil
+}
+
+// ChangedFiles returns the files changed for an SBI, most often changed first, and their source:
+// the commits mentioning sbiID, else the commits made between started and completed
+// (when both are known). Returns no files when neither finds a commit.
+func (h *ChangeHistory) ChangedFiles(ctx context.Context, sbiID string, started, completed *time.Time) ([]string, string, error) {
+	out, err := h.runGit(ctx, h.root, "log", "--fixed-strings", "--grep="+sbiID, "--name-only", "--format=")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read git history of %s: %w", sbiID, err)
+	}
+	if files := h.rankFiles(out); len(files) > 0 {
+		return files, ChangeSourceCommits, nil
+	}
+
+	if started == nil || completed == nil {
+		return nil, "", nil
+	}
+	out, err = h.runGit(ctx, h.root, "log", "--since="+started.Format(time.RFC3339),
+		"--until="+completed.Format(time.RFC3339), "--name-only", "--format=")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read git history of %s: %w", sbiID, err)
+	}
+	if files := h.rankFiles(out); len(files) > 0 {
+		return files, ChangeSourceWorkWindow, nil
+	}
+	return nil, "", nil
+}
+
+// rankFiles orders the files of `git log --name-only --format=` output by how many commits
+// changed them (ties alphabetically), leaving out deespec's own files
+func (h *ChangeHistory) rankFiles(log []byte) []string {
+	counts := make(map[string]int)
+	for _, line := range strings.Split(string(log), "\n") {
+		file := strings.TrimSpace(line)
+		if file == "" || file == ".deespec" || strings.HasPrefix(file, ".deespec/") {
+			continue
+		}
+		counts[file]++
+	}
+
+	files := make([]string, 0, len(counts))
+	for file := range counts {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if counts[files[i]] != counts[files[j]] {
+			return counts[files[i]] > counts[files[j]]
+		}
+		return files[i] < files[j]
+	})
+	if h.maxFiles > 0 && len(files) > h.maxFiles {
+		files = files[:h.maxFiles]
+	}
+	return files
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeHistory_ChangedFiles(t *testing.T) {
+	var calls [][]string
+	logs := map[string]string{}
+	history := NewChangeHistory(t.TempDir(), 2)
+	history.runGit = func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		for _, arg := range args {
+			if out, ok := logs[arg]; ok {
+				return []byte(out), nil
+			}
+		}
+		return nil, nil
+	}
+	ctx := context.Background()
+	started := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	completed := started.Add(2 * time.Hour)
+
+	// Commits mentioning the SBI win; files changed most often come first
+	logs["--grep=SBI-1"] = "internal/auth/login.go\n.deespec/journal.ndjson\n\ninternal/auth/login.go\ninternal/auth/token.go\nREADME.md\n"
+	files, source, err := history.ChangedFiles(ctx, "SBI-1", &started, &completed)
+	require.NoError(t, err)
+	assert.Equal(t, ChangeSourceCommits, source)
+	assert.Equal(t, []string{"internal/auth/login.go", "README.md"}, files, "capped, ties alphabetical, .deespec excluded")
+	assert.Len(t, calls, 1)
+
+	// Without such commits, the commits of the work window are used
+	logs["--since="+started.Format(time.RFC3339)] = "cmd/main.go\n"
+	files, source, err = history.ChangedFiles(ctx, "SBI-2", &started, &completed)
+	require.NoError(t, err)
+	assert.Equal(t, ChangeSourceWorkWindow, source)
+	assert.Equal(t, []string{"cmd/main.go"}, files)
+
+	// An unknown work window leaves the SBI without files
+	files, source, err = history.ChangedFiles(ctx, "SBI-3", nil, &completed)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	assert.Empty(t, source)
+}
+
+func TestChangeHistory_RefTime(t *testing.T) {
+	history := NewChangeHistory(t.TempDir(), 0)
+	history.runGit = func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+		assert.Equal(t, "v0.3.0^{commit}", args[len(args)-2], "tags are peeled to their commit")
+		return []byte("2026-09-01T12:00:00+09:00\n"), nil
+	}
+	at, err := history.RefTime(context.Background(), "v0.3.0")
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2026, 9, 1, 3, 0, 0, 0, time.UTC)))
+
+	history.runGit = func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+		return nil, assert.AnError
+	}
+	_, err = history.RefTime(context.Background(), "v9")
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "v9"))
+}
@@ -1,11 +1,117 @@
 package service
 
 import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 )
 
+// FileConflict explains why an SBI may not run concurrently with another SBI
+type FileConflict struct {
+	SBIID     string // The other SBI
+	Path      string // Declared path of the deferred SBI
+	OtherPath string // Overlapping declared path of the other SBI
+}
+
+// String describes the conflict, e.g. "shares internal/auth with SBI 01ABCDEF (internal/auth/login.go)"
+func (c FileConflict) String() string {
+	other := c.SBIID
+	if len(other) > 8 {
+		other = other[:8]
+	}
+	if c.Path == c.OtherPath {
+		return fmt.Sprintf("shares %s with SBI %s", c.Path, other)
+	}
+	return fmt.Sprintf("shares %s with SBI %s (%s)", c.Path, other, c.OtherPath)
+}
+
+// normalizeFilePath cleans a declared file path so that "./a/b.go" and "a/b.go" match
+// Returns "" for paths that declare nothing
+func normalizeFilePath(p string) string {
+	p = path.Clean(filepath.ToSlash(strings.TrimSpace(p)))
+	if p == "." || p == "/" {
+		return ""
+	}
+	return strings.TrimPrefix(p, "./")
+}
+
+// filePathsOverlap reports whether two normalized paths are the same or one is a directory of the other
+func filePathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(b, a+"/") || strings.HasPrefix(a, b+"/")
+}
+
+// findFileConflict returns the first overlap between the declared paths of an SBI and another SBI
+func findFileConflict(paths []string, otherID string, otherPaths []string) (FileConflict, bool) {
+	for _, p := range paths {
+		if p = normalizeFilePath(p); p == "" {
+			continue
+		}
+		for _, other := range otherPaths {
+			if other = normalizeFilePath(other); other != "" && filePathsOverlap(p, other) {
+				return FileConflict{SBIID: otherID, Path: p, OtherPath: other}, true
+			}
+		}
+	}
+	return FileConflict{}, false
+}
+
+// ConflictMatrix records which of a set of SBIs declare overlapping file paths
+// It is computed when SBIs are picked, so that an SBI sharing files with an SBI that is
+// already selected is deferred and its slot goes to an SBI that can run concurrently.
+type ConflictMatrix struct {
+	conflicts map[string]map[string]FileConflict // sbiID -> other sbiID -> conflict
+}
+
+// NewConflictMatrix computes the pairwise file conflicts of the SBIs
+func NewConflictMatrix(sbis []*sbi.SBI) *ConflictMatrix {
+	m := &ConflictMatrix{conflicts: make(map[string]map[string]FileConflict)}
+	for i, a := range sbis {
+		for _, b := range sbis[i+1:] {
+			aID, bID := a.ID().String(), b.ID().String()
+			if aID == bID {
+				continue
+			}
+			conflict, ok := findFileConflict(a.Metadata().FilePaths, bID, b.Metadata().FilePaths)
+			if !ok {
+				continue
+			}
+			m.add(aID, conflict)
+			m.add(bID, FileConflict{SBIID: aID, Path: conflict.OtherPath, OtherPath: conflict.Path})
+		}
+	}
+	return m
+}
+
+func (m *ConflictMatrix) add(sbiID string, conflict FileConflict) {
+	if m.conflicts[sbiID] == nil {
+		m.conflicts[sbiID] = make(map[string]FileConflict)
+	}
+	m.conflicts[sbiID][conflict.SBIID] = conflict
+}
+
+// ConflictWith returns the conflict of an SBI with the first of the selected SBIs it shares files with
+func (m *ConflictMatrix) ConflictWith(sbiID string, selected []*sbi.SBI) (FileConflict, bool) {
+	for _, s := range selected {
+		if conflict, ok := m.conflicts[sbiID][s.ID().String()]; ok {
+			return conflict, true
+		}
+	}
+	return FileConflict{}, false
+}
+
+// Pairs returns the number of conflicting SBI pairs
+func (m *ConflictMatrix) Pairs() int {
+	count := 0
+	for _, others := range m.conflicts {
+		count += len(others)
+	}
+	return count / 2
+}
+
 // ConflictDetector detects file conflicts between concurrent SBI executions
 // It tracks which files are being modified by which SBIs to prevent
 // concurrent modifications that could cause merge conflicts or data corruption
@@ -25,22 +131,27 @@ func NewConflictDetector() *ConflictDetector {
 // HasConflict checks if the specified SBI would conflict with any currently active SBIs
 // Returns true if any of the SBI's file paths are already being modified by another SBI
 func (d *ConflictDetector) HasConflict(s *sbi.SBI) bool {
+	_, ok := d.Conflict(s)
+	return ok
+}
+
+// Conflict returns the first conflict of the SBI with a currently active SBI
+// Paths conflict when they are the same or one is a directory containing the other.
+func (d *ConflictDetector) Conflict(s *sbi.SBI) (FileConflict, bool) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	filePaths := s.Metadata().FilePaths
 	sbiID := s.ID().String()
-
-	for _, filePath := range filePaths {
-		if conflictingSBIID, exists := d.activeFiles[filePath]; exists {
-			// If the file is being modified by a different SBI, there's a conflict
-			if conflictingSBIID != sbiID {
-				return true
-			}
+	for activePath, activeSBIID := range d.activeFiles {
+		// Files being modified by the same SBI are no conflict
+		if activeSBIID == sbiID {
+			continue
+		}
+		if conflict, ok := findFileConflict(s.Metadata().FilePaths, activeSBIID, []string{activePath}); ok {
+			return conflict, true
 		}
 	}
-
-	return false
+	return FileConflict{}, false
 }
 
 // Register registers an SBI's file paths as active
@@ -305,3 +305,35 @@ func TestConflictDetector_EmptyFilePaths(t *testing.T) {
 	detector.Unregister(sbiEmpty)
 	assert.Equal(t, 0, detector.GetActiveFileCount())
 }
+
+func TestConflictDetector_Conflict_DirectoryOverlap(t *testing.T) {
+	detector := NewConflictDetector()
+	detector.Register(createTestSBIForConflict("SBI-001", []string{"internal/auth"}))
+
+	conflict, ok := detector.Conflict(createTestSBIForConflict("SBI-002", []string{"./internal/auth/login.go"}))
+	assert.True(t, ok, "A file inside a registered directory should conflict")
+	assert.Equal(t, FileConflict{SBIID: "SBI-001", Path: "internal/auth/login.go", OtherPath: "internal/auth"}, conflict)
+	assert.Equal(t, "shares internal/auth/login.go with SBI SBI-001 (internal/auth)", conflict.String())
+
+	assert.False(t, detector.HasConflict(createTestSBIForConflict("SBI-003", []string{"internal/authz/token.go"})),
+		"A sibling with a common name prefix should not conflict")
+}
+
+func TestConflictMatrix(t *testing.T) {
+	sbi1 := createTestSBIForConflict("SBI-001", []string{"file1.go", "pkg/api"})
+	sbi2 := createTestSBIForConflict("SBI-002", []string{"file2.go"})
+	sbi3 := createTestSBIForConflict("SBI-003", []string{"pkg/api/handler.go"})
+	sbi4 := createTestSBIForConflict("SBI-004", []string{"file2.go", " "})
+
+	matrix := NewConflictMatrix([]*sbi.SBI{sbi1, sbi2, sbi3, sbi4})
+	assert.Equal(t, 2, matrix.Pairs())
+
+	conflict, ok := matrix.ConflictWith("SBI-003", []*sbi.SBI{sbi2, sbi1})
+	assert.True(t, ok)
+	assert.Equal(t, FileConflict{SBIID: "SBI-001", Path: "pkg/api/handler.go", OtherPath: "pkg/api"}, conflict)
+
+	_, ok = matrix.ConflictWith("SBI-004", []*sbi.SBI{sbi1, sbi3})
+	assert.False(t, ok, "SBI-004 only conflicts with SBI-002")
+	_, ok = matrix.ConflictWith("SBI-004", []*sbi.SBI{sbi2})
+	assert.True(t, ok)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ContextFile is a repository file ranked as relevant to a task
+type ContextFile struct {
+	Path     string   // Path relative to the repository root (slash separated)
+	Score    int      // Relevance score (higher is more relevant)
+	Keywords []string // Keywords that matched this file
+}
+
+// ContextSelector searches a repository for files relevant to a task description
+// so that the implement prompt can point the agent at them instead of letting it explore blindly
+type ContextSelector struct {
+	root        string
+	maxFiles    int
+	maxFileSize int64
+	skipDirs    map[string]bool
+}
+
+const (
+	defaultContextMaxFiles    = 10
+	defaultContextMaxFileSize = 512 * 1024
+
+	// pathMatchWeight ranks a keyword in the file path above scattered content matches
+	pathMatchWeight = 5
+	// maxContentHitsPerKeyword caps how much one repeated keyword can dominate a file's score
+	maxContentHitsPerKeyword = 5
+)
+
+// defaultContextSkipDirs are directories that never hold source worth reading
+var defaultContextSkipDirs = []string{
+	".git", ".deespec", ".idea", ".vscode", "node_modules", "vendor", "dist", "build", "target", "bin",
+}
+
+// contextStopWords are common words that carry no signal for code search
+var contextStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true, "from": true,
+	"into": true, "when": true, "should": true, "must": true, "will": true, "add": true, "use": true,
+	"new": true, "make": true, "are": true, "not": true, "all": true, "can": true, "has": true,
+	"have": true, "each": true, "any": true, "via": true, "than": true, "then": true, "them": true,
+	"they": true, "their": true, "its": true, "was": true, "were": true, "been": true, "only": true,
+	"also": true, "more": true, "file": true, "files": true, "task": true, "support": true,
+	"acceptance": true, "criteria": true, "returns": true, "instead": true, "given": true,
+	// Language keywords match nearly every source file
+	"return": true, "func": true, "package": true, "import": true, "class": true, "const": true,
+}
+
+// NewContextSelector creates a context selector rooted at the given repository directory
+func NewContextSelector(root string) *ContextSelector {
+	skipDirs := make(map[string]bool, len(defaultContextSkipDirs))
+	for _, dir := range defaultContextSkipDirs {
+		skipDirs[dir] = true
+	}
+	return &ContextSelector{
+		root:        root,
+		maxFiles:    defaultContextMaxFiles,
+		maxFileSize: defaultContextMaxFileSize,
+		skipDirs:    skipDirs,
+	}
+}
+
+// SetMaxFiles sets how many files Select returns (values <= 0 keep the default)
+func (s *ContextSelector) SetMaxFiles(maxFiles int) {
+	if maxFiles > 0 {
+		s.maxFiles = maxFiles
+	}
+}
+
+// Select returns the files most relevant to the description, best first.
+// Files explicitly listed by the task (hintPaths) are always ranked first when they exist.
+func (s *ContextSelector) Select(description string, hintPaths []string) ([]ContextFile, error) {
+	var selected []ContextFile
+	seen := make(map[string]bool)
+	for _, hint := range hintPaths {
+		rel := filepath.ToSlash(filepath.Clean(hint))
+		if seen[rel] || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(s.root, rel)); err != nil || info.IsDir() {
+			continue
+		}
+		seen[rel] = true
+		selected = append(selected, ContextFile{Path: rel})
+		if len(selected) >= s.maxFiles {
+			return selected, nil
+		}
+	}
+
+	keywords := extractKeywords(description)
+	if len(keywords) == 0 {
+		return selected, nil
+	}
+
+	var ranked []ContextFile
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable entries are skipped rather than failing the whole search
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if path != s.root && (s.skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if seen[rel] {
+			return nil
+		}
+
+		if file, ok := s.scoreFile(path, rel, keywords); ok {
+			ranked = append(ranked, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Path < ranked[j].Path
+	})
+
+	for _, file := range ranked {
+		if len(selected) >= s.maxFiles {
+			break
+		}
+		selected = append(selected, file)
+	}
+	return selected, nil
+}
+
+// scoreFile scores one file against the keywords; ok is false when nothing matched
+func (s *ContextSelector) scoreFile(path, rel string, keywords []string) (ContextFile, bool) {
+	file := ContextFile{Path: rel}
+	lowerPath := strings.ToLower(rel)
+	contentHits := make(map[string]int, len(keywords))
+
+	info, err := os.Stat(path)
+	if err == nil && info.Size() <= s.maxFileSize {
+		if content, err := os.ReadFile(path); err == nil && !isBinary(content) {
+			scanner := bufio.NewScanner(bytes.NewReader(content))
+			scanner.Buffer(make([]byte, 0, 64*1024), int(s.maxFileSize))
+			for scanner.Scan() {
+				line := strings.ToLower(scanner.Text())
+				for _, keyword := range keywords {
+					if contentHits[keyword] < maxContentHitsPerKeyword && strings.Contains(line, keyword) {
+						contentHits[keyword]++
+					}
+				}
+			}
+		}
+	}
+
+	// Files matching many distinct keywords beat files repeating a single one
+	for _, keyword := range keywords {
+		score := contentHits[keyword]
+		if strings.Contains(lowerPath, keyword) {
+			score += pathMatchWeight
+		}
+		if score > 0 {
+			file.Keywords = append(file.Keywords, keyword)
+			file.Score += score
+		}
+	}
+	file.Score *= len(file.Keywords)
+	return file, file.Score > 0
+}
+
+// extractKeywords splits a task description into distinct lowercase search terms.
+// Identifiers such as CamelCase or snake_case names are also split into their parts.
+func extractKeywords(description string) []string {
+	var keywords []string
+	seen := make(map[string]bool)
+	add := func(word string) {
+		word = strings.ToLower(word)
+		if len(word) < 3 || contextStopWords[word] || seen[word] || isNumeric(word) {
+			return
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+
+	tokens := strings.FieldsFunc(description, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for _, token := range tokens {
+		parts := splitIdentifier(token)
+		if len(parts) > 1 {
+			add(strings.ReplaceAll(token, "_", ""))
+		}
+		for _, part := range parts {
+			add(part)
+		}
+	}
+	return keywords
+}
+
+// splitIdentifier splits CamelCase and snake_case identifiers into words
+func splitIdentifier(token string) []string {
+	var parts []string
+	for _, segment := range strings.Split(token, "_") {
+		runes := []rune(segment)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				parts = append(parts, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < len(runes) {
+			parts = append(parts, string(runes[start:]))
+		}
+	}
+	return parts
+}
+
+// isNumeric reports whether the word consists only of digits
+func isNumeric(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBinary reports whether content looks like a binary file (NUL byte in the first 8KB)
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
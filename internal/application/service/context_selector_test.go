@@ -0,0 +1,59 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeContextFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestExtractKeywords(t *testing.T) {
+	keywords := extractKeywords("Add a RateLimiter to the login_handler. Should return 429 for the login API")
+	assert.Equal(t, []string{"ratelimiter", "rate", "limiter", "loginhandler", "login", "handler", "api"}, keywords)
+}
+
+func TestContextSelector_RanksByKeywordMatches(t *testing.T) {
+	root := t.TempDir()
+	writeContextFile(t, root, "internal/auth/login_handler.go", "package auth\n\nfunc LoginHandler() {}\n")
+	writeContextFile(t, root, "internal/auth/session.go", "package auth\n\n// session created after login\n")
+	writeContextFile(t, root, "internal/billing/invoice.go", "package billing\n")
+	writeContextFile(t, root, "node_modules/login/index.js", "login handler\n")
+	writeContextFile(t, root, ".deespec/specs/sbi/x/implement_1.md", "login handler\n")
+	writeContextFile(t, root, "assets/logo.bin", "login\x00handler")
+
+	selector := NewContextSelector(root)
+	files, err := selector.Select("Fix the login handler", nil)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"internal/auth/login_handler.go", "internal/auth/session.go"}, paths)
+	assert.Equal(t, []string{"login", "handler"}, files[0].Keywords)
+}
+
+func TestContextSelector_HintPathsFirstAndLimit(t *testing.T) {
+	root := t.TempDir()
+	writeContextFile(t, root, "cmd/main.go", "package main\n")
+	writeContextFile(t, root, "pkg/cache/cache.go", "package cache // cache store\n")
+	writeContextFile(t, root, "pkg/cache/lru.go", "package cache // lru cache\n")
+
+	selector := NewContextSelector(root)
+	selector.SetMaxFiles(2)
+	files, err := selector.Select("Improve the cache", []string{"cmd/main.go", "missing.go", "../outside.go"})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "cmd/main.go", files[0].Path)
+	assert.Empty(t, files[0].Keywords)
+	assert.Equal(t, "pkg/cache/cache.go", files[1].Path)
+}
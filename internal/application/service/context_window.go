@@ -0,0 +1,237 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Context window pre-check modes: what to do with a prompt that does not fit
+const (
+	ContextWindowOff       = "off"       // Send prompts as they are
+	ContextWindowSummarize = "summarize" // Summarize the largest prompt sections to their outline
+	ContextWindowFail      = "fail"      // Fail the step before the agent is called
+)
+
+// contextBytesPerToken estimates token counts conservatively: code and CJK text tokenize densely
+const contextBytesPerToken = 3
+
+// ErrContextWindowExceeded is returned when a prompt and the files it has the agent read do not
+// fit the model's context window
+var ErrContextWindowExceeded = errors.New("prompt exceeds the model's context window")
+
+// promptPathPattern matches the file and directory paths a prompt may instruct the agent to read
+var promptPathPattern = regexp.MustCompile(`[A-Za-z0-9_./-]*[./][A-Za-z0-9_./-]+`)
+
+// ContextWindowFile is a file a prompt has the agent read, with its estimated size
+type ContextWindowFile struct {
+	Path   string // Relative to the project root
+	Tokens int
+}
+
+// ContextEstimate is the estimated context a step needs: its prompt and the files it names
+type ContextEstimate struct {
+	Window       int // Context window of the model in tokens
+	PromptTokens int
+	FileTokens   int
+	Files        []ContextWindowFile // Largest first
+}
+
+// Tokens returns the estimated tokens of the prompt and its files together
+func (e *ContextEstimate) Tokens() int {
+	return e.PromptTokens + e.FileTokens
+}
+
+// ContextWindowCheck checks before a step is dispatched that its prompt, together with the
+// files the prompt instructs the agent to read, fits the context window of the model. Without
+// the check an oversized prompt is silently truncated by the agent. Windows are configured per
+// model name or name prefix; other models use the window their agent reports.
+type ContextWindowCheck struct {
+	root    string
+	mode    string
+	windows map[string]int
+}
+
+// NewContextWindowCheck creates a context window check for the project at root
+// An unknown mode summarizes.
+func NewContextWindowCheck(root, mode string, windows map[string]int) *ContextWindowCheck {
+	switch mode {
+	case ContextWindowOff, ContextWindowFail:
+	default:
+		mode = ContextWindowSummarize
+	}
+	return &ContextWindowCheck{root: root, mode: mode, windows: windows}
+}
+
+// Window returns the context window of a model: the configured one for the model or the
+// longest matching prefix, otherwise agentWindow
+func (c *ContextWindowCheck) Window(model string, agentWindow int) int {
+	window, matched := agentWindow, -1
+	for name, w := range c.windows {
+		if strings.HasPrefix(model, name) && len(name) > matched {
+			window, matched = w, len(name)
+		}
+	}
+	return window
+}
+
+// Estimate estimates the context a prompt needs within a window
+func (c *ContextWindowCheck) Estimate(prompt string, window int) *ContextEstimate {
+	estimate := &ContextEstimate{Window: window, PromptTokens: estimatePromptTokens(prompt)}
+	for path, size := range c.promptFiles(prompt) {
+		tokens := int((size + contextBytesPerToken - 1) / contextBytesPerToken)
+		estimate.Files = append(estimate.Files, ContextWindowFile{Path: path, Tokens: tokens})
+		estimate.FileTokens += tokens
+	}
+	sort.Slice(estimate.Files, func(i, j int) bool {
+		if estimate.Files[i].Tokens != estimate.Files[j].Tokens {
+			return estimate.Files[i].Tokens > estimate.Files[j].Tokens
+		}
+		return estimate.Files[i].Path < estimate.Files[j].Path
+	})
+	return estimate
+}
+
+// Fit returns the prompt to send to a model, with the estimate it was checked against
+// A prompt that fits is returned as is. One that does not is summarized in summarize mode;
+// when it still does not fit, or in fail mode, ErrContextWindowExceeded is returned. Without a
+// known window (0) and in off mode prompts are not checked.
+func (c *ContextWindowCheck) Fit(prompt, model string, agentWindow int) (string, *ContextEstimate, error) {
+	if c == nil || c.mode == ContextWindowOff {
+		return prompt, nil, nil
+	}
+	window := c.Window(model, agentWindow)
+	if window <= 0 {
+		return prompt, nil, nil
+	}
+	estimate := c.Estimate(prompt, window)
+	if estimate.Tokens() <= window {
+		return prompt, estimate, nil
+	}
+
+	if c.mode == ContextWindowSummarize && estimate.FileTokens < window {
+		summarized := summarizePromptSections(prompt, (window-estimate.FileTokens)*contextBytesPerToken)
+		if fitted := c.Estimate(summarized, window); fitted.Tokens() <= window {
+			return summarized, fitted, nil
+		}
+	}
+	return "", estimate, contextWindowError(model, estimate)
+}
+
+// contextWindowError describes what does not fit, naming the largest files to read
+func contextWindowError(model string, estimate *ContextEstimate) error {
+	if model == "" {
+		model = "the agent's default model"
+	}
+	msg := fmt.Sprintf("about %d tokens (prompt %d, files to read %d) for the %d-token window of %s",
+		estimate.Tokens(), estimate.PromptTokens, estimate.FileTokens, estimate.Window, model)
+	if len(estimate.Files) > 0 {
+		var largest []string
+		for i, f := range estimate.Files {
+			if i == 3 {
+				break
+			}
+			largest = append(largest, fmt.Sprintf("%s (%d)", f.Path, f.Tokens))
+		}
+		msg += "; largest files: " + strings.Join(largest, ", ")
+	}
+	return fmt.Errorf("%w: %s", ErrContextWindowExceeded, msg)
+}
+
+// promptFiles returns the sizes of the existing project files and directories a prompt names
+// Directories count their files, but not their subdirectories.
+func (c *ContextWindowCheck) promptFiles(prompt string) map[string]int64 {
+	files := make(map[string]int64)
+	for _, match := range promptPathPattern.FindAllString(prompt, -1) {
+		match = strings.TrimRight(match, ".")
+		path := match
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.root, path)
+		}
+		rel, err := filepath.Rel(c.root, path)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			files[filepath.ToSlash(rel)] = info.Size()
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entryInfo, err := entry.Info(); err == nil && entryInfo.Mode().IsRegular() {
+				files[filepath.ToSlash(filepath.Join(rel, entry.Name()))] = entryInfo.Size()
+			}
+		}
+	}
+	return files
+}
+
+// summarizePromptSections shrinks a prompt towards maxBytes by reducing its largest "## "
+// sections to their outline, largest first. The opening section (the task) and the closing one
+// (the output instructions) are kept verbatim.
+func summarizePromptSections(prompt string, maxBytes int) string {
+	sections := splitPromptSections(prompt)
+	if len(sections) < 3 {
+		return prompt
+	}
+	size := len(prompt)
+	done := make(map[int]bool)
+	for size > maxBytes {
+		largest := -1
+		for i := 1; i < len(sections)-1; i++ {
+			if !done[i] && (largest < 0 || len(sections[i]) > len(sections[largest])) {
+				largest = i
+			}
+		}
+		if largest < 0 {
+			break
+		}
+		done[largest] = true
+		original := sections[largest]
+		summary := fmt.Sprintf("%s\n\n(Summarized from %d KB to fit the model's context window: headings and the first paragraph of each section.)\n\n",
+			outlineMarkdown(original), (len(original)+1023)/1024)
+		if len(summary) < len(original) {
+			sections[largest] = summary
+			size += len(summary) - len(original)
+		}
+	}
+	return strings.Join(sections, "")
+}
+
+// splitPromptSections splits a prompt before each "## " heading outside code blocks
+func splitPromptSections(prompt string) []string {
+	var sections []string
+	var current strings.Builder
+	inCode := false
+	for _, line := range strings.SplitAfter(prompt, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+		}
+		if !inCode && strings.HasPrefix(line, "## ") && current.Len() > 0 {
+			sections = append(sections, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		sections = append(sections, current.String())
+	}
+	return sections
+}
+
+// estimatePromptTokens estimates the tokens of a text
+func estimatePromptTokens(text string) int {
+	return (len(text) + contextBytesPerToken - 1) / contextBytesPerToken
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sectionedPrompt returns a prompt with a task, a large context section and output instructions
+func sectionedPrompt(contextBytes int) string {
+	return "# Task\n\nImplement the handler.\n\n" +
+		"## Referenced SBIs\n\nFirst paragraph stays.\n\n" + strings.Repeat("detail line\n", contextBytes/12) + "\n" +
+		"## Output\n\nWrite the report to the artifact path.\n"
+}
+
+func TestContextWindowCheck_Window(t *testing.T) {
+	check := NewContextWindowCheck(t.TempDir(), ContextWindowFail, map[string]int{"claude": 100000, "claude-haiku": 50000})
+	assert.Equal(t, 50000, check.Window("claude-haiku-4-5", 200000), "the longest prefix wins")
+	assert.Equal(t, 100000, check.Window("claude-opus-4-1", 200000))
+	assert.Equal(t, 200000, check.Window("gpt-5", 200000), "other models use the agent's window")
+}
+
+func TestContextWindowCheck_CountsFilesToRead(t *testing.T) {
+	root := t.TempDir()
+	specDir := filepath.Join(root, ".deespec", "specs", "sbi", "SBI-1")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.md"), []byte(strings.Repeat("x", 3000)), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(strings.Repeat("y", 300)), 0644))
+
+	check := NewContextWindowCheck(root, ContextWindowFail, nil)
+	estimate := check.Estimate("Read .deespec/specs/sbi/SBI-1 and `main.go`. Do not read missing.go or ../outside.txt.", 2000)
+	require.Len(t, estimate.Files, 2)
+	assert.Equal(t, ContextWindowFile{Path: ".deespec/specs/sbi/SBI-1/spec.md", Tokens: 1000}, estimate.Files[0])
+	assert.Equal(t, ContextWindowFile{Path: "main.go", Tokens: 100}, estimate.Files[1])
+	assert.Equal(t, 1100, estimate.FileTokens)
+
+	// The files alone exceed the window: no summary can help
+	_, _, err := NewContextWindowCheck(root, ContextWindowSummarize, nil).Fit("Read `main.go` and .deespec/specs/sbi/SBI-1", "", 1000)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrContextWindowExceeded))
+	assert.Contains(t, err.Error(), ".deespec/specs/sbi/SBI-1/spec.md (1000)")
+	assert.Equal(t, ErrorClassPolicyViolation, ClassifyError(err))
+}
+
+func TestContextWindowCheck_Fit(t *testing.T) {
+	prompt := sectionedPrompt(30000)
+	window := 2000 // tokens, about 6 KB
+
+	fitted, estimate, err := NewContextWindowCheck(t.TempDir(), ContextWindowSummarize, nil).Fit(prompt, "", window)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, estimate.Tokens(), window)
+	assert.True(t, strings.HasPrefix(fitted, "# Task\n\nImplement the handler."), "the task is kept verbatim")
+	assert.True(t, strings.HasSuffix(fitted, "## Output\n\nWrite the report to the artifact path.\n"), "the output instructions are kept verbatim")
+	assert.Contains(t, fitted, "First paragraph stays.")
+	assert.Contains(t, fitted, "Summarized from 30 KB to fit the model's context window")
+
+	_, _, err = NewContextWindowCheck(t.TempDir(), ContextWindowFail, nil).Fit(prompt, "tiny-model", window)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2000-token window of tiny-model")
+
+	// A prompt that fits, an unknown window and off mode leave the prompt alone
+	for _, tc := range []struct {
+		check  *ContextWindowCheck
+		window int
+	}{
+		{NewContextWindowCheck(t.TempDir(), ContextWindowFail, nil), 200000},
+		{NewContextWindowCheck(t.TempDir(), ContextWindowFail, nil), 0},
+		{NewContextWindowCheck(t.TempDir(), ContextWindowOff, nil), window},
+		{nil, window},
+	} {
+		fitted, _, err := tc.check.Fit(prompt, "", tc.window)
+		require.NoError(t, err)
+		assert.Equal(t, prompt, fitted)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// DatabaseBackupResult reports one backup of the task database
+type DatabaseBackupResult struct {
+	Backup  *repository.DatabaseBackup   `json:"backup"`
+	URI     string                       `json:"uri,omitempty"` // Where the backup was uploaded
+	Rotated []*repository.DatabaseBackup `json:"rotated,omitempty"`
+}
+
+// DatabaseBackupScheduler snapshots the task database every interval, keeping the newest keep
+// scheduled snapshots, and uploads each snapshot to the artifact store when one is set
+// The newest scheduled snapshot tells when the previous one was taken, so restarting the runner
+// neither repeats nor skips a backup.
+type DatabaseBackupScheduler struct {
+	backupRepo repository.DatabaseBackupRepository
+	store      output.ArtifactStore // Optional: snapshots stay local when nil
+	interval   time.Duration
+	keep       int
+}
+
+// NewDatabaseBackupScheduler creates a scheduler taking a snapshot every interval
+func NewDatabaseBackupScheduler(backupRepo repository.DatabaseBackupRepository, store output.ArtifactStore, interval time.Duration, keep int) *DatabaseBackupScheduler {
+	return &DatabaseBackupScheduler{
+		backupRepo: backupRepo,
+		store:      store,
+		interval:   interval,
+		keep:       keep,
+	}
+}
+
+// BackupIfDue takes a scheduled snapshot when none was taken within the interval
+// Returns nil when no backup was due.
+func (s *DatabaseBackupScheduler) BackupIfDue(ctx context.Context, now time.Time) (*DatabaseBackupResult, error) {
+	backups, err := s.backupRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups {
+		if backup.Kind == repository.DatabaseBackupScheduled {
+			if now.Sub(backup.CreatedAt) < s.interval {
+				return nil, nil
+			}
+			break
+		}
+	}
+	return s.Backup(ctx, repository.DatabaseBackupScheduled, now)
+}
+
+// Backup snapshots the database now, rotates the scheduled snapshots and uploads the new one
+// A failed upload is returned with the result: the local snapshot is kept.
+func (s *DatabaseBackupScheduler) Backup(ctx context.Context, kind repository.DatabaseBackupKind, now time.Time) (*DatabaseBackupResult, error) {
+	backup, err := s.backupRepo.Create(ctx, kind, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up database: %w", err)
+	}
+	result := &DatabaseBackupResult{Backup: backup}
+	if kind == repository.DatabaseBackupScheduled && s.keep > 0 {
+		if result.Rotated, err = s.backupRepo.Rotate(ctx, kind, s.keep); err != nil {
+			return result, err
+		}
+	}
+
+	if s.store != nil {
+		content, err := os.ReadFile(backup.Path)
+		if err != nil {
+			return result, fmt.Errorf("failed to read backup: %w", err)
+		}
+		if result.URI, err = s.store.Put(ctx, BackupArtifactKey(backup.Name), content); err != nil {
+			return result, fmt.Errorf("failed to upload backup: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// Run takes the due snapshots, checking every checkInterval until ctx is cancelled
+func (s *DatabaseBackupScheduler) Run(ctx context.Context, checkInterval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.BackupIfDue(ctx, time.Now()); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// BackupArtifactKey returns the artifact store key a backup is uploaded under
+func BackupArtifactKey(name string) string {
+	return "backups/" + name
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// fakeBackupRepository keeps backups as small files in a directory
+type fakeBackupRepository struct {
+	dir     string
+	backups []*repository.DatabaseBackup
+}
+
+func (r *fakeBackupRepository) Create(ctx context.Context, kind repository.DatabaseBackupKind, at time.Time) (*repository.DatabaseBackup, error) {
+	name := string(kind) + "-" + at.UTC().Format("20060102T150405Z") + ".bak"
+	backup := &repository.DatabaseBackup{Name: name, Kind: kind, Path: filepath.Join(r.dir, name), CreatedAt: at}
+	if err := os.WriteFile(backup.Path, []byte("snapshot "+name), 0644); err != nil {
+		return nil, err
+	}
+	r.backups = append(r.backups, backup)
+	return backup, nil
+}
+
+func (r *fakeBackupRepository) List(ctx context.Context) ([]*repository.DatabaseBackup, error) {
+	backups := append([]*repository.DatabaseBackup{}, r.backups...)
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+func (r *fakeBackupRepository) Find(ctx context.Context, name string) (*repository.DatabaseBackup, error) {
+	for _, backup := range r.backups {
+		if backup.Name == name {
+			return backup, nil
+		}
+	}
+	return nil, repository.ErrDatabaseBackupNotFound
+}
+
+func (r *fakeBackupRepository) Rotate(ctx context.Context, kind repository.DatabaseBackupKind, keep int) ([]*repository.DatabaseBackup, error) {
+	backups, _ := r.List(ctx)
+	var kept, removed []*repository.DatabaseBackup
+	for _, backup := range backups {
+		if backup.Kind == kind && keep == 0 {
+			removed = append(removed, backup)
+			continue
+		}
+		if backup.Kind == kind {
+			keep--
+		}
+		kept = append(kept, backup)
+	}
+	r.backups = kept
+	return removed, nil
+}
+
+// recordingArtifactStore keeps the content put into it in memory
+type recordingArtifactStore struct {
+	content map[string][]byte
+}
+
+func (s *recordingArtifactStore) Put(ctx context.Context, key string, content []byte) (string, error) {
+	s.content[key] = content
+	return s.URI(key), nil
+}
+
+func (s *recordingArtifactStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func (s *recordingArtifactStore) URI(key string) string { return "s3://team-bucket/" + key }
+
+func (s *recordingArtifactStore) LocalPath(key string) string { return "" }
+
+func TestDatabaseBackupScheduler_BackupIfDue(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeBackupRepository{dir: t.TempDir()}
+	store := &recordingArtifactStore{content: map[string][]byte{}}
+	scheduler := NewDatabaseBackupScheduler(repo, store, 24*time.Hour, 2)
+
+	now := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	result, err := scheduler.BackupIfDue(ctx, now)
+	require.NoError(t, err)
+	require.NotNil(t, result, "the first snapshot is taken right away")
+	assert.Equal(t, "s3://team-bucket/backups/scheduled-20260301T030000Z.bak", result.URI)
+	assert.Equal(t, []byte("snapshot scheduled-20260301T030000Z.bak"), store.content["backups/scheduled-20260301T030000Z.bak"])
+
+	// Manual snapshots neither delay the schedule nor count against it
+	_, err = scheduler.Backup(ctx, repository.DatabaseBackupManual, now.Add(20*time.Hour))
+	require.NoError(t, err)
+	result, err = scheduler.BackupIfDue(ctx, now.Add(23*time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, result, "not due yet")
+
+	for day := 1; day <= 2; day++ {
+		result, err = scheduler.BackupIfDue(ctx, now.Add(time.Duration(day)*24*time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	}
+	require.Len(t, result.Rotated, 1)
+	assert.Equal(t, "scheduled-20260301T030000Z.bak", result.Rotated[0].Name)
+
+	backups, err := repo.List(ctx)
+	require.NoError(t, err)
+	var kinds []repository.DatabaseBackupKind
+	for _, backup := range backups {
+		kinds = append(kinds, backup.Kind)
+	}
+	assert.Equal(t, []repository.DatabaseBackupKind{repository.DatabaseBackupScheduled, repository.DatabaseBackupScheduled, repository.DatabaseBackupManual}, kinds)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// DefaultEscalationAfter is how long a task may stay overdue before escalating to critical
+const DefaultEscalationAfter = 24 * time.Hour
+
+// EventTaskOverdue is the notification event emitted for overdue tasks
+const EventTaskOverdue = "task.overdue"
+
+// DeadlineMonitor periodically checks for overdue SBIs and PBIs and escalates via the notification gateway
+// Each task is notified once per level: warning when first detected, critical after escalateAfter
+type DeadlineMonitor struct {
+	sbiRepo       repository.SBIRepository
+	pbiRepo       pbi.Repository // Optional: PBIs are only checked when set
+	notifier      output.NotificationGateway
+	escalateAfter time.Duration
+
+	mu       sync.Mutex
+	notified map[string]output.NotificationLevel // Task ID -> highest level already notified
+}
+
+// NewDeadlineMonitor creates a new deadline monitor
+func NewDeadlineMonitor(sbiRepo repository.SBIRepository, notifier output.NotificationGateway, escalateAfter time.Duration) *DeadlineMonitor {
+	if escalateAfter <= 0 {
+		escalateAfter = DefaultEscalationAfter
+	}
+	return &DeadlineMonitor{
+		sbiRepo:       sbiRepo,
+		notifier:      notifier,
+		escalateAfter: escalateAfter,
+		notified:      make(map[string]output.NotificationLevel),
+	}
+}
+
+// SetPBIRepository enables overdue checks for PBI deadlines
+func (m *DeadlineMonitor) SetPBIRepository(pbiRepo pbi.Repository) {
+	m.pbiRepo = pbiRepo
+}
+
+// overdueTask is an overdue SBI or PBI pending notification
+type overdueTask struct {
+	id        string
+	kind      string // "SBI" or "PBI"
+	title     string
+	status    string
+	deadline  time.Time
+	overdueBy time.Duration
+}
+
+// Check lists overdue SBIs and PBIs at the given time and sends notifications for new or escalated ones
+// Returns the number of notifications sent
+func (m *DeadlineMonitor) Check(ctx context.Context, now time.Time) (int, error) {
+	overdue, err := m.listOverdue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sent := 0
+	seen := make(map[string]bool, len(overdue))
+	for _, task := range overdue {
+		seen[task.id] = true
+
+		level := output.NotificationLevelWarning
+		if task.overdueBy >= m.escalateAfter {
+			level = output.NotificationLevelCritical
+		}
+		if prev, ok := m.notified[task.id]; ok && (prev == level || prev == output.NotificationLevelCritical) {
+			continue
+		}
+
+		if err := m.notifier.Notify(ctx, buildOverdueNotification(task, level, now)); err != nil {
+			return sent, fmt.Errorf("failed to notify overdue %s %s: %w", task.kind, task.id, err)
+		}
+		m.notified[task.id] = level
+		sent++
+	}
+
+	// Forget tasks that are no longer overdue (completed or deadline moved)
+	for id := range m.notified {
+		if !seen[id] {
+			delete(m.notified, id)
+		}
+	}
+
+	return sent, nil
+}
+
+// listOverdue collects overdue SBIs and, if a PBI repository is set, overdue PBIs
+func (m *DeadlineMonitor) listOverdue(ctx context.Context, now time.Time) ([]overdueTask, error) {
+	sbis, err := m.sbiRepo.List(ctx, repository.SBIFilter{OverdueAt: &now})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue SBIs: %w", err)
+	}
+
+	var tasks []overdueTask
+	for _, s := range sbis {
+		if s.Deadline() == nil {
+			continue
+		}
+		tasks = append(tasks, overdueTask{
+			id:        s.ID().String(),
+			kind:      "SBI",
+			title:     s.Title(),
+			status:    s.Status().String(),
+			deadline:  *s.Deadline(),
+			overdueBy: now.Sub(*s.Deadline()),
+		})
+	}
+
+	if m.pbiRepo == nil {
+		return tasks, nil
+	}
+	pbis, err := m.pbiRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PBIs: %w", err)
+	}
+	for _, p := range pbis {
+		if !p.IsOverdue(now) {
+			continue
+		}
+		tasks = append(tasks, overdueTask{
+			id:        p.ID,
+			kind:      "PBI",
+			title:     p.Title,
+			status:    string(p.Status),
+			deadline:  *p.Deadline,
+			overdueBy: now.Sub(*p.Deadline),
+		})
+	}
+	return tasks, nil
+}
+
+// Run checks for overdue tasks on every interval until the context is cancelled
+// Check errors are passed to onError (if non-nil) and do not stop the loop
+func (m *DeadlineMonitor) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx, time.Now()); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// buildOverdueNotification creates the notification for an overdue SBI or PBI
+func buildOverdueNotification(task overdueTask, level output.NotificationLevel, now time.Time) output.Notification {
+	title := fmt.Sprintf("%s overdue: %s", task.kind, task.title)
+	if level == output.NotificationLevelCritical {
+		title = fmt.Sprintf("%s overdue (escalated): %s", task.kind, task.title)
+	}
+
+	return output.Notification{
+		Level:     level,
+		Event:     EventTaskOverdue,
+		Title:     title,
+		Message:   fmt.Sprintf("Deadline %s passed %s ago", task.deadline.Local().Format("2006-01-02 15:04"), task.overdueBy.Truncate(time.Minute)),
+		TaskID:    task.id,
+		Timestamp: now,
+		Fields: map[string]string{
+			"type":     task.kind,
+			"status":   task.status,
+			"deadline": task.deadline.UTC().Format(time.RFC3339),
+		},
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// recordingNotifier records notifications for assertions
+type recordingNotifier struct {
+	notifications []output.Notification
+	err           error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification output.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func newSBIWithDeadline(t *testing.T, title string, deadline time.Time) *sbi.SBI {
+	t.Helper()
+	s, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{Deadline: &deadline})
+	require.NoError(t, err)
+	return s
+}
+
+func TestDeadlineMonitor_Check_Escalation(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSBIRepo()
+	notifier := &recordingNotifier{}
+	monitor := NewDeadlineMonitor(repo, notifier, 24*time.Hour)
+
+	now := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	overdue := newSBIWithDeadline(t, "Overdue task", now.Add(-time.Hour))
+	future := newSBIWithDeadline(t, "Future task", now.Add(time.Hour))
+	require.NoError(t, repo.Save(ctx, overdue))
+	require.NoError(t, repo.Save(ctx, future))
+
+	// First detection: warning
+	sent, err := monitor.Check(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.notifications, 1)
+	assert.Equal(t, output.NotificationLevelWarning, notifier.notifications[0].Level)
+	assert.Equal(t, EventTaskOverdue, notifier.notifications[0].Event)
+	assert.Equal(t, overdue.ID().String(), notifier.notifications[0].TaskID)
+
+	// Same level is not repeated
+	sent, err = monitor.Check(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// Escalates to critical after the escalation window
+	sent, err = monitor.Check(ctx, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent) // overdue escalated + future task now overdue
+	levels := map[string]output.NotificationLevel{}
+	for _, n := range notifier.notifications[1:] {
+		levels[n.TaskID] = n.Level
+	}
+	assert.Equal(t, output.NotificationLevelCritical, levels[overdue.ID().String()])
+	assert.Equal(t, output.NotificationLevelWarning, levels[future.ID().String()])
+}
+
+func TestDeadlineMonitor_Check_SkipsCompleted(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSBIRepo()
+	notifier := &recordingNotifier{}
+	monitor := NewDeadlineMonitor(repo, notifier, 0)
+
+	now := time.Now()
+	deadline := now.Add(-time.Hour)
+	done := sbi.ReconstructSBI(
+		model.NewTaskID(), "Done task", "",
+		model.StatusDone, model.StepDone, nil,
+		sbi.SBIMetadata{Deadline: &deadline},
+		&sbi.ExecutionState{CurrentTurn: model.NewTurn(), CurrentAttempt: model.NewAttempt()},
+		now, now,
+	)
+	require.NoError(t, repo.Save(ctx, done))
+
+	sent, err := monitor.Check(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, notifier.notifications)
+}
+
+func TestDeadlineMonitor_Check_NotifyError(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSBIRepo()
+	notifier := &recordingNotifier{err: fmt.Errorf("webhook down")}
+	monitor := NewDeadlineMonitor(repo, notifier, 0)
+
+	now := time.Now()
+	require.NoError(t, repo.Save(ctx, newSBIWithDeadline(t, "Overdue", now.Add(-time.Minute))))
+
+	_, err := monitor.Check(ctx, now)
+	assert.Error(t, err)
+
+	// A failed notification is retried on the next check
+	notifier.err = nil
+	sent, err := monitor.Check(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+// fakePBIRepo returns fixed PBIs from FindAll
+type fakePBIRepo struct {
+	pbi.Repository
+	pbis []*pbi.PBI
+}
+
+func (r *fakePBIRepo) FindAll() ([]*pbi.PBI, error) {
+	return r.pbis, nil
+}
+
+func TestDeadlineMonitor_Check_PBIs(t *testing.T) {
+	ctx := context.Background()
+	notifier := &recordingNotifier{}
+	monitor := NewDeadlineMonitor(newMockSBIRepo(), notifier, 24*time.Hour)
+
+	now := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-2 * time.Hour)
+	future := now.Add(time.Hour)
+	monitor.SetPBIRepository(&fakePBIRepo{pbis: []*pbi.PBI{
+		{ID: "PBI-001", Title: "Overdue PBI", Status: pbi.StatusInProgress, Deadline: &past},
+		{ID: "PBI-002", Title: "Done PBI", Status: pbi.StatusDone, Deadline: &past},
+		{ID: "PBI-003", Title: "Future PBI", Status: pbi.StatusPending, Deadline: &future},
+		{ID: "PBI-004", Title: "No deadline", Status: pbi.StatusPending},
+	}})
+
+	sent, err := monitor.Check(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.notifications, 1)
+	assert.Equal(t, "PBI-001", notifier.notifications[0].TaskID)
+	assert.Equal(t, "PBI overdue: Overdue PBI", notifier.notifications[0].Title)
+	assert.Equal(t, output.NotificationLevelWarning, notifier.notifications[0].Level)
+
+	// Escalates to critical after the escalation window
+	sent, err = monitor.Check(ctx, past.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent) // PBI-001 escalated + PBI-003 now overdue
+	assert.Equal(t, output.NotificationLevelCritical, notifier.notifications[1].Level)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// Duplicate check modes
+const (
+	DuplicateCheckOff   = "off"
+	DuplicateCheckWarn  = "warn"
+	DuplicateCheckBlock = "block"
+)
+
+// DefaultDuplicateThreshold is the similarity from which a task counts as a near-duplicate
+const DefaultDuplicateThreshold = 0.8
+
+// DuplicateCheck looks for near-duplicates of a task being registered among the existing
+// SBIs or PBIs, so that agents do not end up doing the same work twice
+// In block mode registration is refused unless forced; cancelled SBIs are not compared.
+type DuplicateCheck struct {
+	mode      string
+	threshold float64
+}
+
+// NewDuplicateCheck creates a duplicate check; an unknown mode falls back to warn and a
+// threshold outside (0, 1] to the default
+func NewDuplicateCheck(mode string, threshold float64) *DuplicateCheck {
+	switch mode {
+	case DuplicateCheckOff, DuplicateCheckWarn, DuplicateCheckBlock:
+	default:
+		mode = DuplicateCheckWarn
+	}
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultDuplicateThreshold
+	}
+	return &DuplicateCheck{mode: mode, threshold: threshold}
+}
+
+// Mode returns the duplicate check mode
+func (c *DuplicateCheck) Mode() string {
+	return c.mode
+}
+
+// Blocks reports whether near-duplicates refuse the registration
+func (c *DuplicateCheck) Blocks() bool {
+	return c.mode == DuplicateCheckBlock
+}
+
+// CheckSBI returns the existing SBIs similar to a new SBI's title and description
+func (c *DuplicateCheck) CheckSBI(ctx context.Context, repo repository.SBIRepository, title, description string) ([]domainservice.DuplicateMatch, error) {
+	if c.mode == DuplicateCheckOff {
+		return nil, nil
+	}
+	sbis, err := repo.List(ctx, repository.SBIFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SBIs: %w", err)
+	}
+
+	existing := make([]domainservice.DuplicateCandidate, 0, len(sbis))
+	for _, s := range sbis {
+		if s.Status() == model.StatusCancelled {
+			continue
+		}
+		existing = append(existing, domainservice.DuplicateCandidate{ID: s.ID().String(), Title: s.Title(), Description: s.Description()})
+	}
+	candidate := domainservice.DuplicateCandidate{Title: title, Description: description}
+	return domainservice.FindDuplicates(candidate, existing, c.threshold), nil
+}
+
+// CheckPBI returns the existing PBIs similar to a new PBI's title and body
+// id is the ID of the PBI when it is known, so that it is not reported as its own duplicate.
+func (c *DuplicateCheck) CheckPBI(repo pbi.Repository, id, title, body string) ([]domainservice.DuplicateMatch, error) {
+	if c.mode == DuplicateCheckOff {
+		return nil, nil
+	}
+	pbis, err := repo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PBIs: %w", err)
+	}
+
+	existing := make([]domainservice.DuplicateCandidate, 0, len(pbis))
+	for _, p := range pbis {
+		// A missing pbi.md leaves the title to compare
+		existingBody, _ := repo.GetBody(p.ID)
+		existing = append(existing, domainservice.DuplicateCandidate{ID: p.ID, Title: p.Title, Description: existingBody})
+	}
+	candidate := domainservice.DuplicateCandidate{ID: id, Title: title, Description: body}
+	return domainservice.FindDuplicates(candidate, existing, c.threshold), nil
+}
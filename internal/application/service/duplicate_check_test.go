@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+func TestDuplicateCheck_CheckSBI(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSBIRepo()
+
+	login, err := sbi.NewSBI("Add login endpoint", "POST /login returns a session token", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	cancelled, err := sbi.NewSBI("Add login endpoint", "POST /login returns a session token", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, cancelled.Cancel("superseded"))
+	other, err := sbi.NewSBI("Refactor billing reports", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	for _, s := range []*sbi.SBI{login, cancelled, other} {
+		require.NoError(t, repo.Save(ctx, s))
+	}
+
+	check := NewDuplicateCheck(DuplicateCheckBlock, 0)
+	assert.True(t, check.Blocks())
+	matches, err := check.CheckSBI(ctx, repo, "Add a login endpoint", "POST /login returns a session token")
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "cancelled SBIs are not compared")
+	assert.Equal(t, login.ID().String(), matches[0].ID)
+
+	matches, err = NewDuplicateCheck(DuplicateCheckOff, 0.5).CheckSBI(ctx, repo, "Add login endpoint", "")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+// bodyPBIRepo serves fixed PBIs and their bodies
+type bodyPBIRepo struct {
+	fakePBIRepo
+	bodies map[string]string
+}
+
+func (r *bodyPBIRepo) GetBody(id string) (string, error) {
+	return r.bodies[id], nil
+}
+
+func TestDuplicateCheck_CheckPBI(t *testing.T) {
+	repo := &bodyPBIRepo{
+		fakePBIRepo: fakePBIRepo{pbis: []*pbi.PBI{
+			{ID: "PBI-001", Title: "User authentication"},
+			{ID: "PBI-002", Title: "Billing dashboard"},
+		}},
+		bodies: map[string]string{"PBI-001": "# User authentication\n\nUsers sign in with email and password."},
+	}
+
+	check := NewDuplicateCheck("strict", 2)
+	assert.Equal(t, DuplicateCheckWarn, check.Mode(), "an unknown mode falls back to warn")
+	matches, err := check.CheckPBI(repo, "", "User authentication", "# User authentication\n\nUsers sign in with email and a password.")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "PBI-001", matches[0].ID)
+
+	// A PBI being updated is not its own duplicate
+	matches, err = check.CheckPBI(repo, "PBI-001", "User authentication", "")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
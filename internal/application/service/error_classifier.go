@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"text/template"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// Error classes recorded in the journal's "error_class" field
+// Free-form error messages are grouped into these classes so failures can be counted
+// by cause (`deespec stats errors`) instead of by message text.
+const (
+	// ErrorClassAgentTimeout: the agent did not answer in time (deadline exceeded, review timeout)
+	ErrorClassAgentTimeout = "agent_timeout"
+	// ErrorClassAgentCrash: the agent process or API call failed (non-zero exit, killed, API error)
+	ErrorClassAgentCrash = "agent_crash"
+	// ErrorClassTemplateError: a prompt template could not be read, parsed or executed
+	ErrorClassTemplateError = "template_error"
+	// ErrorClassLockLost: the runner lost its lock (stale fencing token, lease taken over, turn claimed by another runner)
+	ErrorClassLockLost = "lock_lost"
+	// ErrorClassPolicyViolation: a configured limit or rule stopped the work (max turns, budgets)
+	ErrorClassPolicyViolation = "policy_violation"
+	// ErrorClassTestFailure: the implementation failed its tests
+	ErrorClassTestFailure = "test_failure"
+	// ErrorClassUnknown: an error that matches no other class
+	ErrorClassUnknown = "unknown"
+)
+
+// ErrorClasses lists the error classes in display order
+var ErrorClasses = []string{
+	ErrorClassAgentTimeout, ErrorClassAgentCrash, ErrorClassTemplateError, ErrorClassLockLost,
+	ErrorClassPolicyViolation, ErrorClassTestFailure, ErrorClassUnknown,
+}
+
+// errorMessagePatterns classify error messages by lower-case substrings; the first match wins
+// Lock and template errors are checked before timeouts and crashes, which they often wrap.
+var errorMessagePatterns = []struct {
+	class    string
+	patterns []string
+}{
+	{ErrorClassLockLost, []string{"stale fencing token", "lock not found", "lock lost", "lost lock", "lock was taken over", "lease expired", "already claimed"}},
+	{ErrorClassTemplateError, []string{"template"}},
+	{ErrorClassPolicyViolation, []string{"exceeded max turns", "budget", "policy", "read-only", "not allowed", "context window"}},
+	{ErrorClassAgentTimeout, []string{"deadline exceeded", "timed out", "timeout"}},
+	{ErrorClassTestFailure, []string{"test failed", "tests failed", "test failure", "failing test", "--- fail"}},
+	{ErrorClassAgentCrash, []string{"execution failed", "exit status", "signal: killed", "panic", "segmentation fault", "api error", "api call failed", "agent error", "crash"}},
+}
+
+// ClassifyError returns the error class of err, or "" when err is nil
+// Known error values are classified by type; other errors by their message.
+func ClassifyError(err error) string {
+	if err == nil {
+		return ""
+	}
+	var execErr template.ExecError
+	switch {
+	case errors.Is(err, lock.ErrStaleFencingToken), errors.Is(err, lock.ErrLockNotFound), errors.Is(err, repository.ErrTurnClaimed):
+		return ErrorClassLockLost
+	case errors.As(err, &execErr):
+		return ErrorClassTemplateError
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassAgentTimeout
+	case errors.Is(err, ErrContextWindowExceeded):
+		return ErrorClassPolicyViolation
+	}
+	return ClassifyErrorMessage(err.Error())
+}
+
+// ClassifyErrorMessage returns the error class of a journaled error message, or "" when it is empty
+func ClassifyErrorMessage(msg string) string {
+	msg = strings.ToLower(strings.TrimSpace(msg))
+	if msg == "" {
+		return ""
+	}
+	for _, rule := range errorMessagePatterns {
+		for _, pattern := range rule.patterns {
+			if strings.Contains(msg, pattern) {
+				return rule.class
+			}
+		}
+	}
+	return ErrorClassUnknown
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestClassifyError(t *testing.T) {
+	tmplErr := template.Must(template.New("WIP.md.tmpl").Parse("{{.Missing}}")).Execute(&strings.Builder{}, 1)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"deadline", fmt.Errorf("claude CLI execution failed: %w", context.DeadlineExceeded), ErrorClassAgentTimeout},
+		{"stale fence", fmt.Errorf("save SBI: %w", lock.ErrStaleFencingToken), ErrorClassLockLost},
+		{"turn claimed", fmt.Errorf("claim turn 3: %w", repository.ErrTurnClaimed), ErrorClassLockLost},
+		{"template exec", fmt.Errorf("failed to execute template: %w", tmplErr), ErrorClassTemplateError},
+		{"message fallback", errors.New("Exceeded max turns (8)"), ErrorClassPolicyViolation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestClassifyErrorMessage(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"", ""},
+		{"claude CLI execution failed: context deadline exceeded", ErrorClassAgentTimeout},
+		{"review decision timed out", ErrorClassAgentTimeout},
+		{"claude CLI execution failed: exit status 1", ErrorClassAgentCrash},
+		{"API error (529): overloaded_error - Overloaded", ErrorClassAgentCrash},
+		{"failed to parse template WIP.md.tmpl: unexpected EOF", ErrorClassTemplateError},
+		{"update SBI: stale fencing token: lock run-1 is at token 3, write carries 2", ErrorClassLockLost},
+		{"Exceeded max turns (8)", ErrorClassPolicyViolation},
+		{"pbi turn budget exhausted", ErrorClassPolicyViolation},
+		{"go test ./...: 2 tests failed", ErrorClassTestFailure},
+		{"something unexpected", ErrorClassUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyErrorMessage(tt.msg))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+// Turn limit policies: the terminal status of an SBI that exhausts its turn limit
+const (
+	// FailurePolicyDone force-completes the SBI as DONE (the historical behaviour)
+	FailurePolicyDone = "done"
+	// FailurePolicyFailed terminates the SBI as FAILED so that the failure stays visible
+	FailurePolicyFailed = "failed"
+)
+
+// FailurePolicy routes SBIs that exhaust their turn limit to FAILED instead of force-completing
+// them into DONE, where unfinished work is indistinguishable from finished work.
+// With postmortem enabled, an agent step analyses why the SBI failed before it terminates.
+type FailurePolicy struct {
+	postmortem bool
+}
+
+// NewFailurePolicy creates a failure policy; it returns nil (force completion) unless
+// onLimit is FailurePolicyFailed
+func NewFailurePolicy(onLimit string, postmortem bool) *FailurePolicy {
+	if onLimit != FailurePolicyFailed {
+		return nil
+	}
+	return &FailurePolicy{postmortem: postmortem}
+}
+
+// FailsOnLimit reports whether SBIs that exhaust their turn limit terminate as FAILED
+func (p *FailurePolicy) FailsOnLimit() bool {
+	return p != nil
+}
+
+// Postmortem reports whether a postmortem step runs before an SBI terminates as FAILED
+func (p *FailurePolicy) Postmortem() bool {
+	return p != nil && p.postmortem
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailurePolicy(t *testing.T) {
+	forceDone := NewFailurePolicy(FailurePolicyDone, true)
+	assert.Nil(t, forceDone, "the done policy keeps force completion")
+	assert.False(t, forceDone.FailsOnLimit())
+	assert.False(t, forceDone.Postmortem())
+	assert.Nil(t, NewFailurePolicy("cancelled", true), "unknown policies fall back to force completion")
+
+	failed := NewFailurePolicy(FailurePolicyFailed, true)
+	assert.True(t, failed.FailsOnLimit())
+	assert.True(t, failed.Postmortem())
+	assert.False(t, NewFailurePolicy(FailurePolicyFailed, false).Postmortem())
+}
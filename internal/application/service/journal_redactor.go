@@ -0,0 +1,215 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// Journal artifact keys whose string values describe the workflow rather than the project
+var redactionKeptKeys = map[string]bool{
+	"type": true, "source": true, "agent": true, "model": true, "outcome": true,
+	"status": true, "step": true, "decision": true, "kind": true, "format": true,
+	"template": true, "variant": true,
+}
+
+// Journal artifact keys whose values are file contents or free text written by agents or users
+var redactionContentKeys = map[string]bool{
+	"content": true, "output": true, "prompt": true, "body": true, "text": true, "diff": true,
+	"summary": true, "notes": true, "note": true, "message": true, "reason": true,
+	"description": true, "title": true,
+}
+
+// Path components that are part of deespec's own layout
+var redactionKeptPathComponents = map[string]bool{
+	"": true, ".": true, "..": true, "~": true, ".deespec": true, "reports": true, "specs": true,
+	"sbi": true, "pbi": true, "epic": true, "var": true, "journal.ndjson": true,
+}
+
+const (
+	// Quoted values in error messages, e.g. label names or commands
+	redactionQuotedPattern = `"[^"\n]*"`
+	// Paths (anything with a slash) and file names with an extension
+	redactionPathPattern = `[\w.\-~@+]*/[\w.\-~@+/]*|[\w\-]+\.[A-Za-z][A-Za-z0-9]{0,4}\b`
+	// ULIDs and UUIDs, e.g. the IDs of tasks, sessions and commits
+	redactionIDPattern = `\b[0-9A-HJKMNP-TV-Z]{26}\b|\b[0-9a-fA-F]{8}(?:-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}\b`
+)
+
+var (
+	// Step reports keep their names, which tell the step and turn that wrote them
+	redactionReportName = regexp.MustCompile(`^(implement|force_implement|review|done|postmortem|checkpoint)(_\d+)?\.md$`)
+	redactionID         = regexp.MustCompile(`^(?:` + redactionIDPattern + `)$`)
+)
+
+// JournalRedactor anonymizes journal records so that they can be shared in bug reports
+// File contents and free text are dropped, while paths and identifiers are replaced with hashes
+// that are stable for a salt: the same value always maps to the same hash, so the workflow of a
+// task can still be followed, but without the salt the values cannot be guessed back.
+type JournalRedactor struct {
+	salt     []byte
+	knownIDs []string // Task IDs seen in the records, replaced wherever they occur in text
+	text     *regexp.Regexp
+}
+
+// NewJournalRedactor creates a redactor hashing with the salt
+func NewJournalRedactor(salt string) *JournalRedactor {
+	return &JournalRedactor{salt: []byte(salt)}
+}
+
+// Hash returns the stable hash of a value
+func (r *JournalRedactor) Hash(value string) string {
+	mac := hmac.New(sha256.New, r.salt)
+	mac.Write([]byte(value))
+	return "h" + hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+// Redact returns anonymized copies of the records
+func (r *JournalRedactor) Redact(records []*repository.JournalRecord) []*repository.JournalRecord {
+	for _, record := range records {
+		if record.SBIID != "" && !containsID(r.knownIDs, record.SBIID) {
+			r.knownIDs = append(r.knownIDs, record.SBIID)
+		}
+	}
+	r.text = nil
+
+	redacted := make([]*repository.JournalRecord, 0, len(records))
+	for _, record := range records {
+		copied := *record
+		if copied.SBIID != "" {
+			copied.SBIID = r.Hash(copied.SBIID)
+		}
+		copied.Error = r.Text(copied.Error)
+		copied.Artifacts = make([]interface{}, 0, len(record.Artifacts))
+		for _, artifact := range record.Artifacts {
+			copied.Artifacts = append(copied.Artifacts, r.artifact(artifact))
+		}
+		redacted = append(redacted, &copied)
+	}
+	return redacted
+}
+
+// Path hashes every component of a path except deespec's own directories and step report names
+// File extensions are kept, since they often matter to a bug.
+func (r *JournalRedactor) Path(p string) string {
+	components := strings.Split(p, "/")
+	for i, component := range components {
+		if redactionKeptPathComponents[component] || redactionReportName.MatchString(component) {
+			continue
+		}
+		ext := path.Ext(component)
+		if ext == component || len(ext) > 6 {
+			ext = ""
+		}
+		components[i] = r.Hash(strings.TrimSuffix(component, ext)) + strings.ToLower(ext)
+	}
+	return strings.Join(components, "/")
+}
+
+// Text anonymizes a message such as an error: only its first line is kept, and the task IDs,
+// paths, IDs and quoted values in it are hashed
+func (r *JournalRedactor) Text(text string) string {
+	if text == "" {
+		return ""
+	}
+	first, rest, multiline := strings.Cut(text, "\n")
+	first = r.textPattern().ReplaceAllStringFunc(first, func(match string) string {
+		switch {
+		case strings.HasPrefix(match, `"`):
+			return `"` + r.Hash(strings.Trim(match, `"`)) + `"`
+		case containsID(r.knownIDs, match) || redactionID.MatchString(match):
+			return r.Hash(match)
+		default:
+			return r.Path(match)
+		}
+	})
+	if multiline {
+		first += fmt.Sprintf(" [%d more lines redacted]", strings.Count(rest, "\n")+1)
+	}
+	return first
+}
+
+// textPattern matches what Text hashes: quoted values, paths, task IDs, ULIDs and UUIDs
+// A single pattern hashes every part of a message once, e.g. a task ID inside a path is hashed as
+// a path component, to the same hash as the ID elsewhere.
+func (r *JournalRedactor) textPattern() *regexp.Regexp {
+	if r.text == nil {
+		alternatives := []string{redactionQuotedPattern, redactionPathPattern}
+		for _, id := range r.knownIDs {
+			alternatives = append(alternatives, `\b`+regexp.QuoteMeta(id)+`\b`)
+		}
+		alternatives = append(alternatives, redactionIDPattern)
+		r.text = regexp.MustCompile(strings.Join(alternatives, "|"))
+	}
+	return r.text
+}
+
+// artifact anonymizes a journal artifact: a path or a map of metadata
+func (r *JournalRedactor) artifact(artifact interface{}) interface{} {
+	switch v := artifact.(type) {
+	case string:
+		return r.Path(v)
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			redacted[key] = r.value(key, value)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			redacted = append(redacted, r.artifact(item))
+		}
+		return redacted
+	default:
+		return v // Numbers and booleans tell nothing about the project
+	}
+}
+
+// value anonymizes an artifact field by what its key says it holds
+func (r *JournalRedactor) value(key string, value interface{}) interface{} {
+	key = strings.ToLower(key)
+	switch v := value.(type) {
+	case string:
+		switch {
+		case redactionKeptKeys[key]:
+			return v
+		case redactionContentKeys[key]:
+			return fmt.Sprintf("[redacted %d bytes]", len(v))
+		case key == "path" || key == "file" || strings.HasSuffix(key, "_path") || strings.HasSuffix(key, "_file"):
+			return r.Path(v)
+		case key == "error":
+			return r.Text(v)
+		default:
+			return r.Hash(v)
+		}
+	case map[string]interface{}, []interface{}:
+		if redactionContentKeys[key] {
+			return "[redacted]"
+		}
+		if items, ok := v.([]interface{}); ok {
+			redacted := make([]interface{}, 0, len(items))
+			for _, item := range items {
+				redacted = append(redacted, r.value(key, item))
+			}
+			return redacted
+		}
+		return r.artifact(v)
+	default:
+		return v
+	}
+}
+
+func containsID(ids []string, id string) bool {
+	for _, known := range ids {
+		if known == id {
+			return true
+		}
+	}
+	return false
+}
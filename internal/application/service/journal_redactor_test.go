@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestJournalRedactor_Redact(t *testing.T) {
+	reportPath := func(id, name string) string { return path.Join(".deespec", "reports", "sbi", id, name) }
+	const sbiID = "01K7P4N123EQAB57FA5E5ZG6A3"
+	records := []*repository.JournalRecord{
+		{
+			Timestamp:  "2026-10-01T10:00:00Z",
+			SBIID:      sbiID,
+			Turn:       2,
+			Step:       "implement",
+			Status:     "IMPLEMENTING",
+			ElapsedMs:  1200,
+			Error:      `failed to write /home/alice/acme/billing/invoice.go for SBI ` + sbiID + `: label "acme-secret" not found` + "\npanic output\nstack",
+			ErrorClass: "agent_crash",
+			Artifacts: []interface{}{
+				reportPath(sbiID, "implement_2.md"),
+				map[string]interface{}{"type": "agent_routing", "source": "rule", "rule": "acme-billing", "agent": "claude-code", "model": "claude-opus"},
+				map[string]interface{}{"type": "prompt_notes", "content": "use the Acme tax API", "retries": float64(1)},
+				map[string]interface{}{"type": "checkpoint", "path": reportPath(sbiID, "checkpoint_2.md")},
+			},
+		},
+	}
+
+	redactor := NewJournalRedactor("salt")
+	redacted := redactor.Redact(records)
+	require.Len(t, redacted, 1)
+	got := redacted[0]
+	hashedID := redactor.Hash(sbiID)
+
+	assert.Equal(t, hashedID, got.SBIID)
+	assert.Equal(t, sbiID, records[0].SBIID, "the original records are left alone")
+	assert.Equal(t, "2026-10-01T10:00:00Z", got.Timestamp)
+	assert.Equal(t, "implement", got.Step)
+	assert.Equal(t, "agent_crash", got.ErrorClass)
+	assert.Equal(t, int64(1200), got.ElapsedMs)
+
+	// Paths and IDs in the error are hashed consistently; the rest of the output is dropped
+	assert.True(t, strings.HasPrefix(got.Error, "failed to write /"), got.Error)
+	assert.Contains(t, got.Error, ".go for SBI "+hashedID+": label \""+redactor.Hash("acme-secret")+"\" not found [2 more lines redacted]")
+	assert.Equal(t, reportPath(hashedID, "implement_2.md"), got.Artifacts[0])
+	assert.Equal(t, map[string]interface{}{"type": "agent_routing", "source": "rule", "rule": redactor.Hash("acme-billing"), "agent": "claude-code", "model": "claude-opus"}, got.Artifacts[1])
+	assert.Equal(t, map[string]interface{}{"type": "prompt_notes", "content": "[redacted 20 bytes]", "retries": float64(1)}, got.Artifacts[2])
+	assert.Equal(t, reportPath(hashedID, "checkpoint_2.md"), got.Artifacts[3].(map[string]interface{})["path"])
+
+	data, err := json.Marshal(redacted)
+	require.NoError(t, err)
+	for _, secret := range []string{sbiID, "alice", "acme", "Acme", "billing", "invoice", "panic"} {
+		assert.NotContains(t, string(data), secret)
+	}
+}
+
+func TestJournalRedactor_HashIsStablePerSalt(t *testing.T) {
+	a, b := NewJournalRedactor("one"), NewJournalRedactor("two")
+	assert.Equal(t, a.Hash("src/main.go"), a.Hash("src/main.go"))
+	assert.NotEqual(t, a.Hash("src/main.go"), b.Hash("src/main.go"))
+	assert.Equal(t, a.Hash("src")+"/"+a.Hash("main")+".go", a.Path("src/main.go"))
+	assert.Equal(t, "/"+a.Hash("tmp")+"/"+a.Hash("Makefile"), a.Path("/tmp/Makefile"))
+}
@@ -26,6 +26,9 @@ type LockService interface {
 	FindStateLock(ctx context.Context, lockID lock.LockID) (*lock.StateLock, error)
 	ListStateLocks(ctx context.Context) ([]*lock.StateLock, error)
 
+	// Maintenance
+	CleanupExpiredLocks(ctx context.Context) (runCount int, stateCount int, err error)
+
 	// Lifecycle management
 	Start(ctx context.Context) error
 	Stop() error
@@ -124,7 +127,7 @@ func (s *LockServiceImpl) AcquireRunLock(ctx context.Context, lockID lock.LockID
 	}
 
 	// Start heartbeat goroutine
-	s.startRunLockHeartbeat(lockID)
+	s.startRunLockHeartbeat(runLock.Fence())
 
 	return runLock, nil
 }
@@ -172,7 +175,7 @@ func (s *LockServiceImpl) AcquireStateLock(ctx context.Context, lockID lock.Lock
 	}
 
 	// Start heartbeat goroutine
-	s.startStateLockHeartbeat(lockID)
+	s.startStateLockHeartbeat(stateLock.Fence())
 
 	return stateLock, nil
 }
@@ -208,8 +211,26 @@ func (s *LockServiceImpl) ListStateLocks(ctx context.Context) ([]*lock.StateLock
 	return s.stateLockRepo.List(ctx)
 }
 
+// CleanupExpiredLocks removes expired run and state locks immediately
+// The cleanup scheduler does the same periodically; this is for on-demand maintenance
+func (s *LockServiceImpl) CleanupExpiredLocks(ctx context.Context) (int, int, error) {
+	runCount, err := s.runLockRepo.CleanupExpired(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cleanup expired run locks: %w", err)
+	}
+
+	stateCount, err := s.stateLockRepo.CleanupExpired(ctx)
+	if err != nil {
+		return runCount, 0, fmt.Errorf("cleanup expired state locks: %w", err)
+	}
+
+	return runCount, stateCount, nil
+}
+
 // startRunLockHeartbeat starts a heartbeat goroutine for a run lock
-func (s *LockServiceImpl) startRunLockHeartbeat(lockID lock.LockID) {
+// Heartbeats are fenced, so they stop once the lease has been taken over by another holder
+func (s *LockServiceImpl) startRunLockHeartbeat(fence lock.Fence) {
+	lockID := fence.LockID
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -230,7 +251,7 @@ func (s *LockServiceImpl) startRunLockHeartbeat(lockID lock.LockID) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := s.runLockRepo.UpdateHeartbeat(context.Background(), lockID); err != nil {
+				if err := s.runLockRepo.UpdateHeartbeat(lock.WithFence(context.Background(), fence), lockID); err != nil {
 					// Lock might be released or expired, stop heartbeat
 					s.stopRunLockHeartbeat(lockID)
 					return
@@ -252,7 +273,9 @@ func (s *LockServiceImpl) stopRunLockHeartbeat(lockID lock.LockID) {
 }
 
 // startStateLockHeartbeat starts a heartbeat goroutine for a state lock
-func (s *LockServiceImpl) startStateLockHeartbeat(lockID lock.LockID) {
+// Heartbeats are fenced, so they stop once the lease has been taken over by another holder
+func (s *LockServiceImpl) startStateLockHeartbeat(fence lock.Fence) {
+	lockID := fence.LockID
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -273,7 +296,7 @@ func (s *LockServiceImpl) startStateLockHeartbeat(lockID lock.LockID) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := s.stateLockRepo.UpdateHeartbeat(context.Background(), lockID); err != nil {
+				if err := s.stateLockRepo.UpdateHeartbeat(lock.WithFence(context.Background(), fence), lockID); err != nil {
 					// Lock might be released or expired, stop heartbeat
 					s.stopStateLockHeartbeat(lockID)
 					return
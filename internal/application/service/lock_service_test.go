@@ -430,6 +430,21 @@ func TestLockService_CleanupScheduler(t *testing.T) {
 	assert.GreaterOrEqual(t, stateLockRepo.GetCleanupCallCount(), 2)
 }
 
+func TestLockService_CleanupExpiredLocks(t *testing.T) {
+	runLockRepo := NewMockRunLockRepository()
+	stateLockRepo := NewMockStateLockRepository()
+
+	service := NewLockService(runLockRepo, stateLockRepo, DefaultLockServiceConfig())
+	ctx := context.Background()
+
+	_, _, err := service.CleanupExpiredLocks(ctx)
+	require.NoError(t, err)
+
+	// On-demand cleanup does not require the scheduler to be running
+	assert.Equal(t, 1, runLockRepo.GetCleanupCallCount())
+	assert.Equal(t, 1, stateLockRepo.GetCleanupCallCount())
+}
+
 func TestLockService_Stop(t *testing.T) {
 	runLockRepo := NewMockRunLockRepository()
 	stateLockRepo := NewMockStateLockRepository()
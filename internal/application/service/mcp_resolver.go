@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// MCPResolver finds the MCP servers an agent gets for a task
+// Servers are configured once in setting.json ("mcp.servers") and enabled per task by the task's
+// active labels, e.g. a database-schema server for tasks labeled backend.
+type MCPResolver struct {
+	labelRepo repository.LabelRepository
+	servers   map[string]output.MCPServer
+}
+
+// NewMCPResolver creates an MCP resolver for the configured servers
+func NewMCPResolver(labelRepo repository.LabelRepository, servers map[string]output.MCPServer) *MCPResolver {
+	return &MCPResolver{labelRepo: labelRepo, servers: servers}
+}
+
+// Resolve returns the servers enabled by the task's active labels (nil if none), and the sorted
+// names that labels enable but setting.json does not configure
+func (r *MCPResolver) Resolve(ctx context.Context, labels []string) (map[string]output.MCPServer, []string) {
+	if r == nil || r.labelRepo == nil || len(labels) == 0 {
+		return nil, nil
+	}
+
+	var servers map[string]output.MCPServer
+	unknown := make(map[string]bool)
+	for _, name := range labels {
+		lbl, err := r.labelRepo.FindByName(ctx, name)
+		if err != nil || lbl == nil || !lbl.IsActive() {
+			continue // Unknown or inactive labels enable nothing
+		}
+		for _, serverName := range lbl.MCPServers() {
+			server, ok := r.servers[serverName]
+			if !ok {
+				unknown[serverName] = true
+				continue
+			}
+			if servers == nil {
+				servers = make(map[string]output.MCPServer)
+			}
+			servers[serverName] = server
+		}
+	}
+
+	var missing []string
+	for name := range unknown {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return servers, missing
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMCPLabel(t *testing.T, name string, servers ...string) *label.Label {
+	lbl := label.NewLabel(name, "", nil, 0)
+	require.NoError(t, lbl.SetMCPServers(servers))
+	return lbl
+}
+
+func TestMCPResolver_Resolve(t *testing.T) {
+	schema := output.MCPServer{Command: "db-mcp", Args: []string{"--read-only"}}
+	docs := output.MCPServer{Type: "http", URL: "https://mcp.example.com/docs"}
+	inactive := newMCPLabel(t, "legacy", "docs")
+	inactive.Deactivate()
+	repo := &stubLabelRepo{labels: map[string]*label.Label{
+		"backend":  newMCPLabel(t, "backend", "db-schema", "docs"),
+		"frontend": newMCPLabel(t, "frontend", "docs", "figma"),
+		"legacy":   inactive,
+		"plain":    label.NewLabel("plain", "", nil, 0),
+	}}
+	resolver := NewMCPResolver(repo, map[string]output.MCPServer{"db-schema": schema, "docs": docs})
+	ctx := context.Background()
+
+	servers, missing := resolver.Resolve(ctx, []string{"backend", "frontend", "unknown"})
+	assert.Equal(t, map[string]output.MCPServer{"db-schema": schema, "docs": docs}, servers)
+	assert.Equal(t, []string{"figma"}, missing)
+
+	servers, missing = resolver.Resolve(ctx, []string{"plain", "legacy"})
+	assert.Nil(t, servers)
+	assert.Empty(t, missing)
+
+	var disabled *MCPResolver
+	servers, _ = disabled.Resolve(ctx, []string{"backend"})
+	assert.Nil(t, servers)
+}
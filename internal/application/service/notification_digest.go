@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+// EventDigest is the notification event of the periodic digest of finished and at-risk SBIs
+const EventDigest = "digest"
+
+// digestTimeLayout formats the period of a digest
+const digestTimeLayout = "2006-01-02 15:04"
+
+// NotificationDigest periodically notifies a digest of the SBIs that finished (DONE or FAILED)
+// since the previous digest and of the SBIs at risk of exhausting their retry budget
+// The time of the previous digest is kept in a state file, so restarting the runner neither
+// resends nor skips a digest. A period without any such SBI sends nothing.
+type NotificationDigest struct {
+	sbiRepo     repository.SBIRepository
+	retryBudget *RetryBudget // Optional: at-risk SBIs are only listed when set
+	notifier    output.NotificationGateway
+	interval    time.Duration
+	statePath   string
+}
+
+// NewNotificationDigest creates a digest sent every interval; statePath records the last digest
+func NewNotificationDigest(sbiRepo repository.SBIRepository, retryBudget *RetryBudget, notifier output.NotificationGateway, interval time.Duration, statePath string) *NotificationDigest {
+	return &NotificationDigest{
+		sbiRepo:     sbiRepo,
+		retryBudget: retryBudget,
+		notifier:    notifier,
+		interval:    interval,
+		statePath:   statePath,
+	}
+}
+
+// SendIfDue sends the digest when an interval has passed since the previous one, and reports
+// whether it did; the first call only starts the schedule
+func (d *NotificationDigest) SendIfDue(ctx context.Context, now time.Time) (bool, error) {
+	last, err := d.lastSent()
+	if err != nil {
+		return false, err
+	}
+	if last.IsZero() {
+		return false, d.markSent(now)
+	}
+	if now.Sub(last) < d.interval {
+		return false, nil
+	}
+
+	notification, empty, err := d.Build(ctx, last, now)
+	if err != nil {
+		return false, err
+	}
+	if !empty {
+		if err := d.notifier.Notify(ctx, notification); err != nil {
+			return false, fmt.Errorf("failed to send digest: %w", err)
+		}
+	}
+	return !empty, d.markSent(now)
+}
+
+// Build creates the digest of the period from since to until
+// empty is true when no SBI finished in the period and none is at risk.
+func (d *NotificationDigest) Build(ctx context.Context, since, until time.Time) (notification output.Notification, empty bool, err error) {
+	done, err := d.sbiRepo.List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusDone}, UpdatedAfter: &since, UpdatedBefore: &until})
+	if err != nil {
+		return notification, false, fmt.Errorf("failed to list done SBIs: %w", err)
+	}
+	failed, err := d.sbiRepo.List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusFailed}, UpdatedAfter: &since, UpdatedBefore: &until})
+	if err != nil {
+		return notification, false, fmt.Errorf("failed to list failed SBIs: %w", err)
+	}
+	var atRisk []*sbi.SBI
+	if d.retryBudget != nil {
+		active, err := d.sbiRepo.List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusPending, model.StatusPicked, model.StatusImplementing, model.StatusReviewing}})
+		if err != nil {
+			return notification, false, fmt.Errorf("failed to list active SBIs: %w", err)
+		}
+		for _, s := range active {
+			if d.retryBudget.UsageOf(s).AtRisk {
+				atRisk = append(atRisk, s)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	d.writeSection(&sb, "Done", done)
+	d.writeSection(&sb, "Failed", failed)
+	d.writeSection(&sb, "At risk", atRisk)
+
+	notification = output.Notification{
+		Level:     output.NotificationLevelInfo,
+		Event:     EventDigest,
+		Title:     fmt.Sprintf("Digest: %d done, %d failed, %d at risk", len(done), len(failed), len(atRisk)),
+		Message:   strings.TrimRight(sb.String(), "\n"),
+		Timestamp: until,
+		Fields: map[string]string{
+			"since":   since.Local().Format(digestTimeLayout),
+			"until":   until.Local().Format(digestTimeLayout),
+			"done":    strconv.Itoa(len(done)),
+			"failed":  strconv.Itoa(len(failed)),
+			"at_risk": strconv.Itoa(len(atRisk)),
+		},
+	}
+	if len(failed) > 0 || len(atRisk) > 0 {
+		notification.Level = output.NotificationLevelWarning
+	}
+	return notification, len(done)+len(failed)+len(atRisk) == 0, nil
+}
+
+// Run sends due digests, checking every checkInterval until ctx is cancelled
+func (d *NotificationDigest) Run(ctx context.Context, checkInterval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.SendIfDue(ctx, time.Now()); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeSection lists SBIs under a heading, e.g. "Done (2):"
+func (d *NotificationDigest) writeSection(sb *strings.Builder, heading string, sbis []*sbi.SBI) {
+	if len(sbis) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "%s (%d):\n", heading, len(sbis))
+	for _, s := range sbis {
+		fmt.Fprintf(sb, "- %s (%s", s.Title(), s.ID())
+		if d.retryBudget != nil {
+			usage := d.retryBudget.UsageOf(s)
+			fmt.Fprintf(sb, ", turn %d of %d", usage.Turn, usage.MaxTurns)
+		}
+		sb.WriteString(")\n")
+	}
+	sb.WriteString("\n")
+}
+
+// lastSent returns the time of the previous digest, zero when none was sent
+func (d *NotificationDigest) lastSent() (time.Time, error) {
+	content, err := os.ReadFile(d.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read digest state: %w", err)
+	}
+	last, err := time.Parse(time.RFC3339, strings.TrimSpace(string(content)))
+	if err != nil {
+		return time.Time{}, nil // A corrupt state restarts the schedule
+	}
+	return last, nil
+}
+
+// markSent records the time of the digest just sent
+func (d *NotificationDigest) markSent(now time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(d.statePath), 0755); err != nil {
+		return fmt.Errorf("failed to create digest state directory: %w", err)
+	}
+	if err := fs.WriteFileAtomic(d.statePath, []byte(now.UTC().Format(time.RFC3339)+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to record digest state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// newSBIInStatus creates an SBI moved through the workflow to status after turns turns
+func newSBIInStatus(t *testing.T, title string, status model.Status, turns int) *sbi.SBI {
+	t.Helper()
+	s, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateStatus(model.StatusPicked))
+	require.NoError(t, s.UpdateStatus(model.StatusImplementing))
+	for i := 0; i < turns; i++ {
+		s.IncrementTurn()
+	}
+	if status != model.StatusImplementing {
+		require.NoError(t, s.UpdateStatus(status))
+	}
+	return s
+}
+
+func TestNotificationDigest_SendIfDue(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSBIRepo()
+	notifier := &recordingNotifier{}
+	statePath := filepath.Join(t.TempDir(), "var", "notification_digest")
+	digest := NewNotificationDigest(repo, NewRetryBudget(8, 3, nil), notifier, 24*time.Hour, statePath)
+
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	sent, err := digest.SendIfDue(ctx, now)
+	require.NoError(t, err)
+	assert.False(t, sent, "the first call starts the schedule")
+
+	// Nothing to report: the schedule moves on silently
+	sent, err = digest.SendIfDue(ctx, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, sent)
+	assert.Empty(t, notifier.notifications)
+
+	done := newSBIInStatus(t, "Add login", model.StatusDone, 2)
+	failed := newSBIInStatus(t, "Add logout", model.StatusFailed, 8)
+	atRisk := newSBIInStatus(t, "Add signup", model.StatusImplementing, 7)
+	fine := newSBIInStatus(t, "Add profile", model.StatusImplementing, 1)
+	for _, s := range []*sbi.SBI{done, failed, atRisk, fine} {
+		require.NoError(t, repo.Save(ctx, s))
+	}
+
+	sent, err = digest.SendIfDue(ctx, now.Add(36*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, sent, "not due until an interval after the previous digest")
+
+	sent, err = digest.SendIfDue(ctx, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, sent)
+	require.Len(t, notifier.notifications, 1)
+	n := notifier.notifications[0]
+	assert.Equal(t, EventDigest, n.Event)
+	assert.Equal(t, output.NotificationLevelWarning, n.Level)
+	assert.Equal(t, "Digest: 1 done, 1 failed, 1 at risk", n.Title)
+	assert.Equal(t, "Done (1):\n- Add login ("+done.ID().String()+", turn 2 of 8)\n\n"+
+		"Failed (1):\n- Add logout ("+failed.ID().String()+", turn 8 of 8)\n\n"+
+		"At risk (1):\n- Add signup ("+atRisk.ID().String()+", turn 7 of 8)", n.Message)
+	assert.Equal(t, "1", n.Fields["at_risk"])
+
+	// A new digest instance continues the schedule from the state file
+	digest = NewNotificationDigest(repo, nil, notifier, 24*time.Hour, statePath)
+	sent, err = digest.SendIfDue(ctx, now.Add(60*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, sent)
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileOwnership summarizes who has been changing a file, taken from git history
+type FileOwnership struct {
+	Path         string          // Path relative to the repository root
+	Authors      []AuthorCommits // Most active authors first
+	RelatedPRs   []string        // Pull requests referenced by recent commits ("#123"), newest first
+	LastModified time.Time       // Date of the newest commit (zero if the file has no history)
+}
+
+// AuthorCommits is an author and how many of the examined commits they made
+type AuthorCommits struct {
+	Name    string
+	Commits int
+}
+
+const (
+	defaultOwnershipMaxFiles   = 5
+	defaultOwnershipMaxCommits = 50
+
+	// maxOwnershipAuthors and maxOwnershipPRs keep a file's annotation to one prompt line
+	maxOwnershipAuthors = 3
+	maxOwnershipPRs     = 3
+)
+
+// pullRequestRef matches PR references in commit subjects: "Fix x (#123)", "Merge pull request #123 from ..."
+var pullRequestRef = regexp.MustCompile(`#(\d+)\b`)
+
+// gitRunner runs git in a directory and returns its standard output
+type gitRunner func(ctx context.Context, dir string, args ...string) ([]byte, error)
+
+// OwnershipProvider annotates files with ownership taken from git history (recent authors and
+// the pull requests that touched them) so that agents follow the conventions of those owners
+// Results are cached until HEAD moves, so repeated turns on the same files do not re-read history.
+type OwnershipProvider struct {
+	root       string
+	maxFiles   int
+	maxCommits int
+	runGit     gitRunner
+
+	mu    sync.Mutex
+	head  string
+	cache map[string]FileOwnership
+}
+
+// NewOwnershipProvider creates an ownership provider for the git repository at root
+func NewOwnershipProvider(root string) *OwnershipProvider {
+	return &OwnershipProvider{
+		root:       root,
+		maxFiles:   defaultOwnershipMaxFiles,
+		maxCommits: defaultOwnershipMaxCommits,
+		runGit:     runGitCommand,
+		cache:      make(map[string]FileOwnership),
+	}
+}
+
+// SetMaxFiles sets how many files Ownership annotates (values <= 0 keep the default)
+func (p *OwnershipProvider) SetMaxFiles(maxFiles int) {
+	if maxFiles > 0 {
+		p.maxFiles = maxFiles
+	}
+}
+
+// SetMaxCommits sets how many recent commits per file are examined (values <= 0 keep the default)
+func (p *OwnershipProvider) SetMaxCommits(maxCommits int) {
+	if maxCommits > 0 {
+		p.maxCommits = maxCommits
+	}
+}
+
+// Ownership returns the ownership of the first paths, in order, up to the file limit.
+// Paths without git history are skipped.
+// An error is returned when root is not a git repository or git is unavailable.
+func (p *OwnershipProvider) Ownership(ctx context.Context, paths []string) ([]FileOwnership, error) {
+	headOut, err := p.runGit(ctx, p.root, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git HEAD: %w", err)
+	}
+	head := strings.TrimSpace(string(headOut))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if head != p.head {
+		p.head = head
+		p.cache = make(map[string]FileOwnership)
+	}
+
+	var result []FileOwnership
+	for _, path := range paths {
+		if len(result) == p.maxFiles {
+			break
+		}
+		ownership, ok := p.cache[path]
+		if !ok {
+			ownership, err = p.load(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+			p.cache[path] = ownership
+		}
+		if len(ownership.Authors) > 0 {
+			result = append(result, ownership)
+		}
+	}
+	return result, nil
+}
+
+// load reads the recent history of one path
+func (p *OwnershipProvider) load(ctx context.Context, path string) (FileOwnership, error) {
+	// Fields are separated by the unit separator, which cannot appear in names or subjects
+	out, err := p.runGit(ctx, p.root, "log", "-n", fmt.Sprint(p.maxCommits),
+		"--format=%an%x1f%aI%x1f%s", "--", path)
+	if err != nil {
+		return FileOwnership{}, fmt.Errorf("failed to read git history of %s: %w", path, err)
+	}
+	return parseOwnership(path, out), nil
+}
+
+// parseOwnership aggregates `git log --format=%an%x1f%aI%x1f%s` output (newest first)
+func parseOwnership(path string, log []byte) FileOwnership {
+	ownership := FileOwnership{Path: path}
+	commits := make(map[string]int)
+	var order []string
+	seenPRs := make(map[string]bool)
+
+	for _, line := range strings.Split(string(log), "\n") {
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		author, date, subject := strings.TrimSpace(fields[0]), fields[1], fields[2]
+		if author == "" {
+			continue
+		}
+		if commits[author] == 0 {
+			order = append(order, author)
+		}
+		commits[author]++
+		if ownership.LastModified.IsZero() {
+			ownership.LastModified, _ = time.Parse(time.RFC3339, date)
+		}
+		for _, match := range pullRequestRef.FindAllStringSubmatch(subject, -1) {
+			pr := "#" + match[1]
+			if !seenPRs[pr] && len(ownership.RelatedPRs) < maxOwnershipPRs {
+				seenPRs[pr] = true
+				ownership.RelatedPRs = append(ownership.RelatedPRs, pr)
+			}
+		}
+	}
+
+	// Most commits first; ties keep the most recent author first
+	sort.SliceStable(order, func(i, j int) bool {
+		return commits[order[i]] > commits[order[j]]
+	})
+	for _, author := range order {
+		if len(ownership.Authors) == maxOwnershipAuthors {
+			break
+		}
+		ownership.Authors = append(ownership.Authors, AuthorCommits{Name: author, Commits: commits[author]})
+	}
+	return ownership
+}
+
+// runGitCommand runs git in dir
+func runGitCommand(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
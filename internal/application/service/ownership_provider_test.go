@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOwnership(t *testing.T) {
+	log := "Alice\x1f2026-09-03T10:00:00+09:00\x1fTighten token checks (#42)\n" +
+		"Bob\x1f2026-08-20T10:00:00+09:00\x1fMerge pull request #40 from bob/login\n" +
+		"Alice\x1f2026-08-01T10:00:00+09:00\x1fAdd login handler (#42) (#38)\n" +
+		"Carol\x1f2026-07-01T10:00:00+09:00\x1fInitial import\n" +
+		"Dave\x1f2026-06-01T10:00:00+09:00\x1fScaffold (#12)\n"
+
+	ownership := parseOwnership("internal/auth/login.go", []byte(log))
+	assert.Equal(t, []AuthorCommits{{"Alice", 2}, {"Bob", 1}, {"Carol", 1}}, ownership.Authors,
+		"most commits first, ties in recency order, capped")
+	assert.Equal(t, []string{"#42", "#40", "#38"}, ownership.RelatedPRs, "newest first, deduplicated, capped")
+	assert.Equal(t, "2026-09-03", ownership.LastModified.Format("2006-01-02"))
+}
+
+func TestOwnershipProvider_CachesUntilHeadMoves(t *testing.T) {
+	head := "aaa"
+	logCalls := 0
+	provider := NewOwnershipProvider(t.TempDir())
+	provider.SetMaxFiles(1)
+	provider.runGit = func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+		if args[0] == "rev-parse" {
+			return []byte(head + "\n"), nil
+		}
+		logCalls++
+		if args[len(args)-1] == "new.go" {
+			return nil, nil
+		}
+		return []byte("Alice\x1f2026-09-03T10:00:00Z\x1fFix (#1)\n"), nil
+	}
+
+	ctx := context.Background()
+	owners, err := provider.Ownership(ctx, []string{"new.go", "a.go", "b.go"})
+	require.NoError(t, err)
+	require.Len(t, owners, 1, "files without history are skipped and do not count toward the limit")
+	assert.Equal(t, "a.go", owners[0].Path)
+	assert.Equal(t, 2, logCalls)
+
+	_, err = provider.Ownership(ctx, []string{"new.go", "a.go"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, logCalls, "history is cached while HEAD is unchanged")
+
+	head = "bbb"
+	_, err = provider.Ownership(ctx, []string{"a.go"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, logCalls, "a new commit invalidates the cache")
+}
+
+func TestOwnershipProvider_ReadsGitHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	writeContextFile(t, root, "main.go", "package main\n")
+	git("add", "main.go")
+	git("-c", "user.name=Alice", "-c", "user.email=alice@example.com", "commit", "-q", "-m", "Add main (#7)")
+
+	owners, err := NewOwnershipProvider(root).Ownership(context.Background(), []string{"main.go", "missing.go"})
+	require.NoError(t, err)
+	require.Len(t, owners, 1)
+	assert.Equal(t, []AuthorCommits{{"Alice", 1}}, owners[0].Authors)
+	assert.Equal(t, []string{"#7"}, owners[0].RelatedPRs)
+	assert.WithinDuration(t, time.Now(), owners[0].LastModified, time.Hour)
+
+	_, err = NewOwnershipProvider(t.TempDir()).Ownership(context.Background(), []string{"main.go"})
+	assert.Error(t, err, "a directory outside git is reported")
+}
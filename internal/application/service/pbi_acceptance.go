@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+const (
+	// DefaultPBIAcceptanceTimeout bounds the acceptance command and the acceptance review each
+	DefaultPBIAcceptanceTimeout = 10 * time.Minute
+
+	// defaultPBIAcceptanceMaxAttempts is how many automatic acceptance runs may fail before the
+	// PBI is marked failed instead of reopening SBIs again
+	defaultPBIAcceptanceMaxAttempts = 3
+
+	// maxAcceptanceOutputBytes is how much of the command output is kept in reports and prompts
+	maxAcceptanceOutputBytes = 8 * 1024
+
+	// AcceptanceFailedDecision is the review feedback decision recorded on reopened SBIs
+	AcceptanceFailedDecision = "ACCEPTANCE_FAILED"
+)
+
+var (
+	// acceptanceReportPattern matches the acceptance reports of a PBI (acceptance_<attempt>.md)
+	acceptanceReportPattern = regexp.MustCompile(`^acceptance_(\d+)\.md$`)
+
+	acceptanceDecisionPattern = regexp.MustCompile(`(?mi)^\s*\**DECISION\**:\s*\**\s*(PASS|FAIL)`)
+	acceptanceIssuePattern    = regexp.MustCompile(`(?mi)^\s*ISSUE:\s*(.+?)\s*$`)
+	acceptanceReopenPattern   = regexp.MustCompile(`(?mi)^\s*REOPEN:\s*(\S+)`)
+)
+
+// PBIAcceptanceResult is the outcome of a PBI acceptance run
+type PBIAcceptanceResult struct {
+	PBIID          string
+	Attempt        int
+	Passed         bool
+	Issues         []string // Why the PBI was not accepted
+	ReopenedSBIIDs []string // SBIs reset to PENDING with the issues as review feedback
+	FixSBIID       string   // SBI registered when no SBI could be held responsible
+	PBIFailed      bool     // The automatic attempts are exhausted and the PBI was marked failed
+	ReportPath     string   // Relative to the project root
+}
+
+// PBIAcceptanceService runs the end-to-end check of a PBI once all of its SBIs are DONE:
+// an acceptance command, an agent-driven acceptance review, or both. A passing check
+// completes the PBI. A failing one reopens the SBIs the reviewer holds responsible, with
+// the issues as their review feedback, or registers a fix SBI under the PBI.
+type PBIAcceptanceService struct {
+	acceptanceRepo repository.PBIAcceptanceRepository
+	pbiRepo        pbi.Repository
+	sbiRepo        repository.SBIRepository
+	feedbackRepo   repository.ReviewFeedbackRepository // Optional: reopened SBIs get no feedback without it
+	agent          output.AgentGateway                 // Optional: required for agent reviews
+	root           string
+	maxAttempts    int
+	runShell       func(ctx context.Context, dir, command string) ([]byte, error)
+}
+
+// pbiAcceptanceMu keeps SBIs of one PBI that finish together from both running its check
+// It is shared by all services, since parallel workers each build their own.
+var pbiAcceptanceMu sync.Mutex
+
+// NewPBIAcceptanceService creates a PBI acceptance service for the project at root
+func NewPBIAcceptanceService(acceptanceRepo repository.PBIAcceptanceRepository, pbiRepo pbi.Repository, sbiRepo repository.SBIRepository, root string) *PBIAcceptanceService {
+	return &PBIAcceptanceService{
+		acceptanceRepo: acceptanceRepo,
+		pbiRepo:        pbiRepo,
+		sbiRepo:        sbiRepo,
+		root:           root,
+		maxAttempts:    defaultPBIAcceptanceMaxAttempts,
+		runShell:       runShellCommand,
+	}
+}
+
+// SetFeedbackRepository records the issues of a failed check as review feedback on reopened SBIs
+func (s *PBIAcceptanceService) SetFeedbackRepository(feedbackRepo repository.ReviewFeedbackRepository) {
+	s.feedbackRepo = feedbackRepo
+}
+
+// SetAgentGateway sets the agent that performs acceptance reviews
+func (s *PBIAcceptanceService) SetAgentGateway(agent output.AgentGateway) {
+	s.agent = agent
+}
+
+// AcceptanceReportDir returns the directory of a PBI's acceptance reports, relative to the project root
+func AcceptanceReportDir(pbiID string) string {
+	return filepath.Join(".deespec", "reports", "pbi", pbiID)
+}
+
+// RunIfComplete runs the PBI's acceptance test when all of its SBIs are DONE
+// Cancelled SBIs are left out. Returns nil without error when the PBI defines no test, is
+// already done or failed, or still has unfinished SBIs.
+func (s *PBIAcceptanceService) RunIfComplete(ctx context.Context, pbiID string) (*PBIAcceptanceResult, error) {
+	pbiAcceptanceMu.Lock()
+	defer pbiAcceptanceMu.Unlock()
+
+	test, err := s.acceptanceRepo.FindByPBIID(ctx, pbiID)
+	if err != nil || test == nil {
+		return nil, err
+	}
+	p, err := s.pbiRepo.FindByID(pbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PBI %s: %w", pbiID, err)
+	}
+	if p.Status == pbi.StatusDone || p.Status == pbi.StatusFailed {
+		return nil, nil
+	}
+
+	sbis, err := s.sbiRepo.FindByPBIID(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SBIs of PBI %s: %w", pbiID, err)
+	}
+	if !allSBIsDone(sbis) {
+		return nil, nil
+	}
+
+	return s.run(ctx, test, p, sbis, true)
+}
+
+// Run runs the PBI's acceptance test now, whatever the state of its SBIs
+// A manual run never marks the PBI failed, and only completes it when all SBIs are DONE.
+func (s *PBIAcceptanceService) Run(ctx context.Context, pbiID string) (*PBIAcceptanceResult, error) {
+	pbiAcceptanceMu.Lock()
+	defer pbiAcceptanceMu.Unlock()
+
+	test, err := s.acceptanceRepo.FindByPBIID(ctx, pbiID)
+	if err != nil {
+		return nil, err
+	}
+	if test == nil {
+		return nil, fmt.Errorf("%w: %s", repository.ErrPBIAcceptanceTestNotFound, pbiID)
+	}
+	p, err := s.pbiRepo.FindByID(pbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PBI %s: %w", pbiID, err)
+	}
+	sbis, err := s.sbiRepo.FindByPBIID(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SBIs of PBI %s: %w", pbiID, err)
+	}
+
+	return s.run(ctx, test, p, sbis, false)
+}
+
+// run checks the PBI, writes the report and applies the outcome
+func (s *PBIAcceptanceService) run(ctx context.Context, test *repository.PBIAcceptanceTest, p *pbi.PBI, sbis []*sbi.SBI, automatic bool) (*PBIAcceptanceResult, error) {
+	timeout := test.Timeout
+	if timeout <= 0 {
+		timeout = DefaultPBIAcceptanceTimeout
+	}
+	sort.Slice(sbis, func(i, j int) bool { return sbis[i].Sequence() < sbis[j].Sequence() })
+
+	result := &PBIAcceptanceResult{PBIID: p.ID, Attempt: s.nextAttempt(p.ID), Passed: true}
+	result.ReportPath = filepath.Join(AcceptanceReportDir(p.ID), fmt.Sprintf("acceptance_%d.md", result.Attempt))
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "# Acceptance of %s: %s (attempt %d)\n\n", p.ID, p.Title, result.Attempt)
+
+	var commandSection string
+	if test.Command != "" {
+		passed, section := s.runCommand(ctx, test.Command, timeout)
+		commandSection = section
+		report.WriteString(section)
+		if !passed {
+			result.Passed = false
+			result.Issues = append(result.Issues, fmt.Sprintf("The acceptance command `%s` failed", test.Command))
+		}
+	}
+
+	if test.AgentReview {
+		if s.agent == nil {
+			return nil, errors.New("acceptance review requires an agent")
+		}
+		body, err := s.pbiRepo.GetBody(p.ID)
+		if err != nil {
+			body = ""
+		}
+		resp, err := s.agent.Execute(ctx, output.AgentRequest{
+			Prompt:  buildAcceptancePrompt(p, body, sbis, commandSection),
+			Timeout: timeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("acceptance review failed: %w", err)
+		}
+
+		passed, issues, reopen := parseAcceptanceReview(resp.Output)
+		if !passed {
+			result.Passed = false
+		}
+		result.Issues = append(result.Issues, issues...)
+		result.ReopenedSBIIDs = reopenableSBIs(reopen, sbis)
+		fmt.Fprintf(&report, "## Acceptance Review\n\n%s\n\n", strings.TrimSpace(resp.Output))
+	}
+
+	if result.Passed {
+		result.Issues, result.ReopenedSBIIDs = nil, nil
+		if !allSBIsDone(sbis) {
+			report.WriteString("## Outcome\n\nPASSED, but the PBI has unfinished SBIs.\n")
+			return result, s.writeReport(result.ReportPath, report.String())
+		}
+		if err := s.updatePBIStatus(p, pbi.StatusDone); err != nil {
+			return nil, err
+		}
+		report.WriteString("## Outcome\n\nPASSED: the PBI is done.\n")
+		return result, s.writeReport(result.ReportPath, report.String())
+	}
+
+	if len(result.Issues) == 0 {
+		result.Issues = []string{"The acceptance review rejected the PBI without listing issues"}
+	}
+	report.WriteString("## Outcome\n\nFAILED:\n\n")
+	for _, issue := range result.Issues {
+		fmt.Fprintf(&report, "- %s\n", issue)
+	}
+	report.WriteString("\n")
+
+	switch {
+	case automatic && result.Attempt >= s.maxAttempts:
+		result.ReopenedSBIIDs = nil
+		result.PBIFailed = true
+		if err := s.updatePBIStatus(p, pbi.StatusFailed); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&report, "The acceptance test failed %d times; the PBI is marked failed.\n", result.Attempt)
+	case len(result.ReopenedSBIIDs) > 0:
+		if err := s.reopen(ctx, result, sbis); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&report, "Reopened: %s\n", strings.Join(result.ReopenedSBIIDs, ", "))
+	default:
+		fixID, err := s.registerFixSBI(ctx, p, test, result)
+		if err != nil {
+			return nil, err
+		}
+		result.FixSBIID = fixID
+		fmt.Fprintf(&report, "Registered fix SBI %s\n", fixID)
+	}
+	if !result.PBIFailed && p.Status != pbi.StatusInProgress {
+		if err := s.updatePBIStatus(p, pbi.StatusInProgress); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, s.writeReport(result.ReportPath, report.String())
+}
+
+// runCommand runs the acceptance command and returns whether it passed with its report section
+func (s *PBIAcceptanceService) runCommand(ctx context.Context, command string, timeout time.Duration) (bool, string) {
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out, err := s.runShell(cmdCtx, s.root, command)
+	status := "exit code 0"
+	var exitErr *exec.ExitError
+	switch {
+	case cmdCtx.Err() == context.DeadlineExceeded:
+		status = fmt.Sprintf("timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		status = fmt.Sprintf("exit code %d", exitErr.ExitCode())
+	case err != nil:
+		status = err.Error()
+	}
+
+	section := fmt.Sprintf("## Acceptance Command\n\n`%s`: %s\n\n```\n%s\n```\n\n",
+		command, status, strings.TrimSpace(tailBytes(string(out), maxAcceptanceOutputBytes)))
+	return err == nil, section
+}
+
+// reopen resets the SBIs held responsible to PENDING with the issues as their review feedback
+func (s *PBIAcceptanceService) reopen(ctx context.Context, result *PBIAcceptanceResult, sbis []*sbi.SBI) error {
+	turns := make(map[string]int)
+	for _, child := range sbis {
+		turns[child.ID().String()] = child.ExecutionState().CurrentTurn.Value()
+	}
+
+	for _, id := range result.ReopenedSBIIDs {
+		if err := s.sbiRepo.ResetSBIState(ctx, repository.SBIID(id), string(model.StatusPending)); err != nil {
+			return fmt.Errorf("failed to reopen SBI %s: %w", id, err)
+		}
+		if s.feedbackRepo == nil {
+			continue
+		}
+		// Recorded at the SBI's last turn, so that its next implement turn receives it
+		feedback := &repository.ReviewFeedback{
+			SBIID:      id,
+			Turn:       turns[id],
+			Decision:   AcceptanceFailedDecision,
+			Issues:     result.Issues,
+			ReportPath: result.ReportPath,
+		}
+		if err := s.feedbackRepo.Save(ctx, feedback); err != nil {
+			return fmt.Errorf("failed to save acceptance feedback for SBI %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// registerFixSBI registers an SBI under the PBI that fixes the acceptance failures
+func (s *PBIAcceptanceService) registerFixSBI(ctx context.Context, p *pbi.PBI, test *repository.PBIAcceptanceTest, result *PBIAcceptanceResult) (string, error) {
+	parentID, err := model.NewTaskIDFromString(p.ID)
+	if err != nil {
+		return "", fmt.Errorf("invalid PBI ID: %w", err)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "The acceptance test of %s (%s) failed after all of its SBIs were done.\n", p.ID, p.Title)
+	fmt.Fprintf(&body, "Read `%s` for the full output.\n\n", filepath.ToSlash(result.ReportPath))
+	body.WriteString("## Issues\n\n")
+	for _, issue := range result.Issues {
+		fmt.Fprintf(&body, "- %s\n", issue)
+	}
+	body.WriteString("\n## Acceptance Criteria\n\n")
+	if test.Command != "" {
+		fmt.Fprintf(&body, "- [ ] `%s` exits with code 0\n", test.Command)
+	}
+	for _, issue := range result.Issues {
+		if test.Command != "" && strings.Contains(issue, test.Command) {
+			continue
+		}
+		fmt.Fprintf(&body, "- [ ] Resolved: %s\n", issue)
+	}
+
+	metadata := sbi.SBIMetadata{
+		Labels:        []string{},
+		AssignedAgent: "claude-code", // Default agent
+		FilePaths:     []string{},
+		DependsOn:     []string{},
+	}
+	fix, err := sbi.NewSBI(fmt.Sprintf("Fix acceptance failures of %s", p.ID), body.String(), &parentID, metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to create fix SBI: %w", err)
+	}
+	sequence, err := s.sbiRepo.GetNextSequence(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get next sequence: %w", err)
+	}
+	fix.SetSequence(sequence)
+	fix.SetRegisteredAt(time.Now())
+
+	if err := s.sbiRepo.Save(ctx, fix); err != nil {
+		return "", fmt.Errorf("failed to save fix SBI: %w", err)
+	}
+	return fix.ID().String(), nil
+}
+
+// updatePBIStatus saves the PBI with a new status, keeping its body
+func (s *PBIAcceptanceService) updatePBIStatus(p *pbi.PBI, status pbi.Status) error {
+	if p.Status == status {
+		return nil
+	}
+	body, err := s.pbiRepo.GetBody(p.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load PBI body: %w", err)
+	}
+	if err := p.UpdateStatus(status); err != nil {
+		return err
+	}
+	if err := s.pbiRepo.Save(p, body); err != nil {
+		return fmt.Errorf("failed to save PBI %s: %w", p.ID, err)
+	}
+	return nil
+}
+
+// nextAttempt returns the number of the next acceptance run of a PBI
+func (s *PBIAcceptanceService) nextAttempt(pbiID string) int {
+	entries, err := os.ReadDir(filepath.Join(s.root, AcceptanceReportDir(pbiID)))
+	if err != nil {
+		return 1
+	}
+	last := 0
+	for _, entry := range entries {
+		if m := acceptanceReportPattern.FindStringSubmatch(entry.Name()); m != nil {
+			if attempt, _ := strconv.Atoi(m[1]); attempt > last {
+				last = attempt
+			}
+		}
+	}
+	return last + 1
+}
+
+// writeReport writes an acceptance report below the project root
+func (s *PBIAcceptanceService) writeReport(relPath, content string) error {
+	path := filepath.Join(s.root, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create acceptance report directory: %w", err)
+	}
+	if err := fs.WriteFileAtomic(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write acceptance report: %w", err)
+	}
+	return nil
+}
+
+// allSBIsDone reports whether a PBI has DONE SBIs and no unfinished ones (cancelled SBIs are left out)
+func allSBIsDone(sbis []*sbi.SBI) bool {
+	done := 0
+	for _, child := range sbis {
+		switch child.Status() {
+		case model.StatusDone:
+			done++
+		case model.StatusCancelled:
+		default:
+			return false
+		}
+	}
+	return done > 0
+}
+
+// buildAcceptancePrompt builds the prompt of the agent-driven acceptance review
+func buildAcceptancePrompt(p *pbi.PBI, body string, sbis []*sbi.SBI, commandSection string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Acceptance Review: %s %s\n\n", p.ID, p.Title)
+	sb.WriteString("All SBIs of this PBI are done. Verify end to end that the PBI as a whole meets its\n")
+	sb.WriteString("acceptance criteria: inspect the working tree and run what you need, but do not modify files.\n\n")
+	if strings.TrimSpace(body) != "" {
+		fmt.Fprintf(&sb, "## PBI\n\n%s\n\n", strings.TrimSpace(body))
+	}
+	sb.WriteString("## SBIs\n\n")
+	for _, child := range sbis {
+		fmt.Fprintf(&sb, "- %s: %s (%s)\n", child.ID().String(), child.Title(), child.Status())
+	}
+	sb.WriteString("\n")
+	sb.WriteString(commandSection)
+	sb.WriteString("## Output Format\n\n")
+	sb.WriteString("End your answer with these lines:\n\n")
+	sb.WriteString("DECISION: PASS or FAIL\n")
+	sb.WriteString("ISSUE: <one line per problem found>\n")
+	sb.WriteString("REOPEN: <ID of an SBI whose work must be redone, one line per SBI>\n\n")
+	sb.WriteString("Leave out REOPEN when no single SBI is responsible; a fix SBI is registered instead.\n")
+	return sb.String()
+}
+
+// parseAcceptanceReview extracts the decision, issues and SBIs to reopen from a review
+// A review without a decision fails.
+func parseAcceptanceReview(review string) (bool, []string, []string) {
+	var issues, reopen []string
+	for _, m := range acceptanceIssuePattern.FindAllStringSubmatch(review, -1) {
+		issues = append(issues, m[1])
+	}
+	for _, m := range acceptanceReopenPattern.FindAllStringSubmatch(review, -1) {
+		reopen = append(reopen, strings.Trim(m[1], "`*.,"))
+	}
+
+	m := acceptanceDecisionPattern.FindStringSubmatch(review)
+	if m == nil {
+		return false, append(issues, "The acceptance review gave no DECISION line"), reopen
+	}
+	return strings.EqualFold(m[1], "PASS"), issues, reopen
+}
+
+// reopenableSBIs resolves the SBIs a review asked to reopen to the DONE SBIs of the PBI
+// IDs may be given by a unique prefix; unknown IDs are ignored.
+func reopenableSBIs(ids []string, sbis []*sbi.SBI) []string {
+	var resolved []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		var match string
+		for _, child := range sbis {
+			childID := child.ID().String()
+			if child.Status() != model.StatusDone || !strings.HasPrefix(childID, id) {
+				continue
+			}
+			if match != "" {
+				match = ""
+				break
+			}
+			match = childID
+		}
+		if match != "" && !seen[match] {
+			seen[match] = true
+			resolved = append(resolved, match)
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// fakeAcceptanceRepo serves acceptance tests by PBI ID
+type fakeAcceptanceRepo struct {
+	tests map[string]*repository.PBIAcceptanceTest
+}
+
+func (r *fakeAcceptanceRepo) Save(ctx context.Context, test *repository.PBIAcceptanceTest) error {
+	r.tests[test.PBIID] = test
+	return nil
+}
+
+func (r *fakeAcceptanceRepo) FindByPBIID(ctx context.Context, pbiID string) (*repository.PBIAcceptanceTest, error) {
+	return r.tests[pbiID], nil
+}
+
+func (r *fakeAcceptanceRepo) Delete(ctx context.Context, pbiID string) error {
+	delete(r.tests, pbiID)
+	return nil
+}
+
+// statusPBIRepo serves one PBI and records its saves
+type statusPBIRepo struct {
+	pbi.Repository
+	pbi   *pbi.PBI
+	saves int
+}
+
+func (r *statusPBIRepo) FindByID(id string) (*pbi.PBI, error) {
+	if id != r.pbi.ID {
+		return nil, fmt.Errorf("PBI not found: %s", id)
+	}
+	copied := *r.pbi
+	return &copied, nil
+}
+
+func (r *statusPBIRepo) GetBody(id string) (string, error) {
+	return "## Acceptance Criteria\n- Checkout works end to end", nil
+}
+
+func (r *statusPBIRepo) Save(p *pbi.PBI, body string) error {
+	r.pbi = p
+	r.saves++
+	return nil
+}
+
+// resettingSBIRepo applies ResetSBIState to the mock SBIs
+type resettingSBIRepo struct {
+	*mockSBIRepo
+	reset []string
+}
+
+func (r *resettingSBIRepo) ResetSBIState(ctx context.Context, id repository.SBIID, toStatus string) error {
+	r.reset = append(r.reset, string(id))
+	return nil
+}
+
+// memoryFeedbackRepo keeps saved review feedback
+type memoryFeedbackRepo struct {
+	repository.ReviewFeedbackRepository
+	saved []*repository.ReviewFeedback
+}
+
+func (r *memoryFeedbackRepo) Save(ctx context.Context, feedback *repository.ReviewFeedback) error {
+	r.saved = append(r.saved, feedback)
+	return nil
+}
+
+// scriptedAgent answers every request with a fixed output
+type scriptedAgent struct {
+	output  string
+	prompts []string
+}
+
+func (g *scriptedAgent) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.prompts = append(g.prompts, req.Prompt)
+	return &output.AgentResponse{Output: g.output}, nil
+}
+
+func (g *scriptedAgent) GetCapability() output.AgentCapability { return output.AgentCapability{} }
+
+func (g *scriptedAgent) HealthCheck(ctx context.Context) error { return nil }
+
+func newAcceptanceFixture(t *testing.T, test *repository.PBIAcceptanceTest, statuses ...model.Status) (*PBIAcceptanceService, *statusPBIRepo, *resettingSBIRepo, []*sbi.SBI) {
+	t.Helper()
+	pbiID, err := model.NewTaskIDFromString("PBI-001")
+	require.NoError(t, err)
+
+	sbiRepo := &resettingSBIRepo{mockSBIRepo: newMockSBIRepo()}
+	var sbis []*sbi.SBI
+	for i, status := range statuses {
+		s := newSBIForPBI(t, fmt.Sprintf("SBI %d", i+1), &pbiID)
+		s.SetSequence(i + 1)
+		if status == model.StatusCancelled {
+			require.NoError(t, s.Cancel("not needed"))
+		}
+		for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+			if s.Status() == status {
+				break
+			}
+			require.NoError(t, s.UpdateStatus(next))
+		}
+		sbiRepo.sbis[s.ID().String()] = s
+		sbis = append(sbis, s)
+	}
+
+	pbiRepo := &statusPBIRepo{pbi: &pbi.PBI{ID: "PBI-001", Title: "Checkout", Status: pbi.StatusInProgress}}
+	test.PBIID = "PBI-001"
+	acceptanceRepo := &fakeAcceptanceRepo{tests: map[string]*repository.PBIAcceptanceTest{"PBI-001": test}}
+	return NewPBIAcceptanceService(acceptanceRepo, pbiRepo, sbiRepo, t.TempDir()), pbiRepo, sbiRepo, sbis
+}
+
+func TestPBIAcceptanceService_RunIfComplete_WaitsForAllSBIs(t *testing.T) {
+	svc, pbiRepo, _, _ := newAcceptanceFixture(t, &repository.PBIAcceptanceTest{Command: "true"}, model.StatusDone, model.StatusImplementing)
+
+	result, err := svc.RunIfComplete(context.Background(), "PBI-001")
+	require.NoError(t, err)
+	assert.Nil(t, result, "the check waits until every SBI is done")
+	assert.Zero(t, pbiRepo.saves)
+}
+
+func TestPBIAcceptanceService_RunIfComplete_Pass(t *testing.T) {
+	svc, pbiRepo, _, _ := newAcceptanceFixture(t, &repository.PBIAcceptanceTest{Command: "make e2e"}, model.StatusDone, model.StatusCancelled)
+	var commands []string
+	svc.runShell = func(ctx context.Context, dir, command string) ([]byte, error) {
+		commands = append(commands, command)
+		return []byte("ok\n"), nil
+	}
+
+	result, err := svc.RunIfComplete(context.Background(), "PBI-001")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, result.Passed)
+	assert.Equal(t, []string{"make e2e"}, commands)
+	assert.Equal(t, pbi.StatusDone, pbiRepo.pbi.Status)
+
+	report, err := os.ReadFile(filepath.Join(svc.root, result.ReportPath))
+	require.NoError(t, err)
+	assert.Contains(t, string(report), "PASSED")
+
+	// A done PBI is not checked again
+	result, err = svc.RunIfComplete(context.Background(), "PBI-001")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestPBIAcceptanceService_RunIfComplete_ReopensNamedSBIs(t *testing.T) {
+	svc, pbiRepo, sbiRepo, sbis := newAcceptanceFixture(t, &repository.PBIAcceptanceTest{AgentReview: true}, model.StatusDone, model.StatusDone)
+	feedbackRepo := &memoryFeedbackRepo{}
+	svc.SetFeedbackRepository(feedbackRepo)
+	target := sbis[1].ID().String()
+	agent := &scriptedAgent{output: fmt.Sprintf("The totals are wrong.\n\nDECISION: FAIL\nISSUE: Order totals ignore discounts\nREOPEN: %s\nREOPEN: unknown-id\n", target[:12])}
+	svc.SetAgentGateway(agent)
+
+	result, err := svc.RunIfComplete(context.Background(), "PBI-001")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{target}, result.ReopenedSBIIDs, "IDs are resolved by prefix and unknown ones ignored")
+	assert.Equal(t, []string{target}, sbiRepo.reset)
+	assert.Empty(t, result.FixSBIID)
+	assert.Equal(t, pbi.StatusInProgress, pbiRepo.pbi.Status)
+
+	require.Len(t, feedbackRepo.saved, 1)
+	assert.Equal(t, AcceptanceFailedDecision, feedbackRepo.saved[0].Decision)
+	assert.Equal(t, []string{"Order totals ignore discounts"}, feedbackRepo.saved[0].Issues)
+	assert.Equal(t, result.ReportPath, feedbackRepo.saved[0].ReportPath)
+
+	require.Len(t, agent.prompts, 1)
+	assert.Contains(t, agent.prompts[0], "Checkout works end to end", "the reviewer gets the PBI's acceptance criteria")
+	assert.Contains(t, agent.prompts[0], target)
+}
+
+func TestPBIAcceptanceService_RunIfComplete_RegistersFixSBI(t *testing.T) {
+	svc, _, sbiRepo, _ := newAcceptanceFixture(t, &repository.PBIAcceptanceTest{Command: "make e2e"}, model.StatusDone)
+	svc.runShell = func(ctx context.Context, dir, command string) ([]byte, error) {
+		return []byte("FAIL checkout_test.go:42\n"), fmt.Errorf("exit status 1")
+	}
+
+	result, err := svc.RunIfComplete(context.Background(), "PBI-001")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.False(t, result.Passed)
+	require.NotEmpty(t, result.FixSBIID)
+
+	fix := sbiRepo.sbis[result.FixSBIID]
+	require.NotNil(t, fix)
+	assert.Equal(t, "PBI-001", fix.ParentTaskID().String())
+	assert.Equal(t, model.StatusPending, fix.Status())
+	assert.Contains(t, fix.Description(), "`make e2e` exits with code 0")
+
+	report, err := os.ReadFile(filepath.Join(svc.root, result.ReportPath))
+	require.NoError(t, err)
+	assert.Contains(t, string(report), "FAIL checkout_test.go:42")
+}
+
+func TestPBIAcceptanceService_RunIfComplete_FailsPBIAfterMaxAttempts(t *testing.T) {
+	svc, pbiRepo, sbiRepo, _ := newAcceptanceFixture(t, &repository.PBIAcceptanceTest{Command: "make e2e"}, model.StatusDone)
+	svc.maxAttempts = 2
+	svc.runShell = func(ctx context.Context, dir, command string) ([]byte, error) {
+		return nil, fmt.Errorf("exit status 1")
+	}
+	ctx := context.Background()
+
+	first, err := svc.RunIfComplete(ctx, "PBI-001")
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.False(t, first.PBIFailed)
+	require.NotEmpty(t, first.FixSBIID)
+
+	// The fix SBI is done, but the check still fails
+	fix := sbiRepo.sbis[first.FixSBIID]
+	for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+		require.NoError(t, fix.UpdateStatus(next))
+	}
+
+	second, err := svc.RunIfComplete(ctx, "PBI-001")
+	require.NoError(t, err)
+	require.NotNil(t, second)
+	assert.Equal(t, 2, second.Attempt)
+	assert.True(t, second.PBIFailed)
+	assert.Empty(t, second.FixSBIID)
+	assert.Equal(t, pbi.StatusFailed, pbiRepo.pbi.Status)
+
+	// A failed PBI is only checked again on request, which never fails it
+	result, err := svc.RunIfComplete(ctx, "PBI-001")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	manual, err := svc.Run(ctx, "PBI-001")
+	require.NoError(t, err)
+	assert.False(t, manual.PBIFailed)
+	assert.NotEmpty(t, manual.FixSBIID)
+}
+
+func TestParseAcceptanceReview(t *testing.T) {
+	passed, issues, reopen := parseAcceptanceReview("All good.\n**DECISION:** PASS\n")
+	assert.True(t, passed)
+	assert.Empty(t, issues)
+	assert.Empty(t, reopen)
+
+	passed, issues, _ = parseAcceptanceReview("I could not decide.")
+	assert.False(t, passed, "a review without a decision fails")
+	assert.Len(t, issues, 1)
+}
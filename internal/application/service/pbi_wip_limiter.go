@@ -0,0 +1,144 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// PBIWIPLimiter limits how many SBIs of the same PBI may run concurrently
+// Agents working inside one feature area tend to conflict with each other,
+// so the picker only starts an SBI while its parent PBI has a free WIP slot.
+// SBIs without a parent PBI are never limited.
+type PBIWIPLimiter struct {
+	limit  int            // Maximum concurrent SBIs per PBI (0 = unlimited)
+	active map[string]int // pbiID -> number of active SBIs
+	mu     sync.Mutex
+}
+
+// NewPBIWIPLimiter creates a new limiter; limit <= 0 disables the constraint
+func NewPBIWIPLimiter(limit int) *PBIWIPLimiter {
+	if limit < 0 {
+		limit = 0
+	}
+	return &PBIWIPLimiter{
+		limit:  limit,
+		active: make(map[string]int),
+	}
+}
+
+// Limit returns the configured per-PBI limit (0 = unlimited)
+func (l *PBIWIPLimiter) Limit() int {
+	return l.limit
+}
+
+// HasCapacity checks if the SBI's parent PBI has a free WIP slot
+func (l *PBIWIPLimiter) HasCapacity(s *sbi.SBI) bool {
+	pbiID := parentPBIID(s)
+	if l.limit == 0 || pbiID == "" {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active[pbiID] < l.limit
+}
+
+// TryAcquire registers the SBI as active if its PBI has a free WIP slot
+// Returns false (without registering) when the PBI is at its limit
+func (l *PBIWIPLimiter) TryAcquire(s *sbi.SBI) bool {
+	pbiID := parentPBIID(s)
+	if pbiID == "" {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit > 0 && l.active[pbiID] >= l.limit {
+		return false
+	}
+	l.active[pbiID]++
+	return true
+}
+
+// Register counts the SBI as active regardless of the limit
+// Used for SBIs that are already in progress when the limiter is built
+func (l *PBIWIPLimiter) Register(s *sbi.SBI) {
+	pbiID := parentPBIID(s)
+	if pbiID == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active[pbiID]++
+}
+
+// Release frees the WIP slot held by the SBI
+func (l *PBIWIPLimiter) Release(s *sbi.SBI) {
+	pbiID := parentPBIID(s)
+	if pbiID == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active[pbiID] > 0 {
+		l.active[pbiID]--
+	}
+	if l.active[pbiID] == 0 {
+		delete(l.active, pbiID)
+	}
+}
+
+// PBIQueuePosition describes where a pending SBI stands in its PBI's execution queue
+type PBIQueuePosition struct {
+	PBIID    string // Parent PBI ID
+	Position int    // 1-based position among the PBI's pending SBIs in pick order
+	Waiting  bool   // True if the SBI has to wait for a WIP slot of its PBI
+}
+
+// ComputePBIQueuePositions returns the queue position of each pending SBI keyed by SBI ID
+// pending must be in pick order (priority, registration order). active are the SBIs
+// currently in progress; they occupy the WIP slots of their PBIs.
+// SBIs without a parent PBI are not queued and are omitted from the result.
+func ComputePBIQueuePositions(limit int, active, pending []*sbi.SBI) map[string]PBIQueuePosition {
+	activeCount := make(map[string]int)
+	for _, s := range active {
+		if pbiID := parentPBIID(s); pbiID != "" {
+			activeCount[pbiID]++
+		}
+	}
+
+	positions := make(map[string]PBIQueuePosition)
+	queued := make(map[string]int)
+	for _, s := range pending {
+		pbiID := parentPBIID(s)
+		if pbiID == "" {
+			continue
+		}
+		queued[pbiID]++
+		positions[s.ID().String()] = PBIQueuePosition{
+			PBIID:    pbiID,
+			Position: queued[pbiID],
+			Waiting:  limit > 0 && activeCount[pbiID]+queued[pbiID] > limit,
+		}
+	}
+	return positions
+}
+
+// InProgressStatuses are the SBI statuses that occupy a PBI WIP slot
+var InProgressStatuses = []model.Status{
+	model.StatusPicked,
+	model.StatusImplementing,
+	model.StatusReviewing,
+}
+
+// parentPBIID returns the SBI's parent PBI ID, or "" if it has none
+func parentPBIID(s *sbi.SBI) string {
+	if parent := s.ParentTaskID(); parent != nil {
+		return parent.String()
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+func newSBIForPBI(t *testing.T, title string, pbiID *model.TaskID) *sbi.SBI {
+	t.Helper()
+	s, err := sbi.NewSBI(title, "", pbiID, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	return s
+}
+
+func TestPBIWIPLimiter_TryAcquire(t *testing.T) {
+	pbiA := model.NewTaskID()
+	pbiB := model.NewTaskID()
+
+	limiter := NewPBIWIPLimiter(2)
+	a1 := newSBIForPBI(t, "A1", &pbiA)
+	a2 := newSBIForPBI(t, "A2", &pbiA)
+	a3 := newSBIForPBI(t, "A3", &pbiA)
+	b1 := newSBIForPBI(t, "B1", &pbiB)
+	orphan := newSBIForPBI(t, "No PBI", nil)
+
+	assert.True(t, limiter.TryAcquire(a1))
+	assert.True(t, limiter.TryAcquire(a2))
+	assert.False(t, limiter.TryAcquire(a3), "third SBI of the same PBI must wait")
+	assert.False(t, limiter.HasCapacity(a3))
+	assert.True(t, limiter.TryAcquire(b1), "other PBIs are not affected")
+	assert.True(t, limiter.TryAcquire(orphan), "SBIs without a PBI are never limited")
+
+	limiter.Release(a1)
+	assert.True(t, limiter.HasCapacity(a3))
+	assert.True(t, limiter.TryAcquire(a3))
+}
+
+func TestPBIWIPLimiter_Unlimited(t *testing.T) {
+	pbiA := model.NewTaskID()
+	limiter := NewPBIWIPLimiter(0)
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, limiter.TryAcquire(newSBIForPBI(t, "A", &pbiA)))
+	}
+}
+
+func TestComputePBIQueuePositions(t *testing.T) {
+	pbiA := model.NewTaskID()
+	pbiB := model.NewTaskID()
+
+	running := newSBIForPBI(t, "A running", &pbiA)
+	a1 := newSBIForPBI(t, "A1", &pbiA)
+	a2 := newSBIForPBI(t, "A2", &pbiA)
+	b1 := newSBIForPBI(t, "B1", &pbiB)
+	orphan := newSBIForPBI(t, "No PBI", nil)
+
+	positions := ComputePBIQueuePositions(2, []*sbi.SBI{running}, []*sbi.SBI{a1, b1, orphan, a2})
+
+	assert.Equal(t, PBIQueuePosition{PBIID: pbiA.String(), Position: 1, Waiting: false}, positions[a1.ID().String()])
+	assert.Equal(t, PBIQueuePosition{PBIID: pbiA.String(), Position: 2, Waiting: true}, positions[a2.ID().String()])
+	assert.Equal(t, PBIQueuePosition{PBIID: pbiB.String(), Position: 1, Waiting: false}, positions[b1.ID().String()])
+	_, queued := positions[orphan.ID().String()]
+	assert.False(t, queued)
+
+	// Without a limit nothing waits
+	positions = ComputePBIQueuePositions(0, []*sbi.SBI{running}, []*sbi.SBI{a1, a2})
+	assert.False(t, positions[a2.ID().String()].Waiting)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// defaultReferenceMaxBytes is how much of each referenced artifact goes into a prompt before
+// it is summarized
+const defaultReferenceMaxBytes = 16 * 1024
+
+// implementReportPattern matches the implement reports of an SBI (implement_<turn>.md)
+var implementReportPattern = regexp.MustCompile(`^implement_(\d+)\.md$`)
+
+// ReferenceArtifact is a final artifact of a referenced SBI
+type ReferenceArtifact struct {
+	Path    string // Relative to the project root
+	Content string
+}
+
+// ReferenceContextBuilder gives an agent the final artifacts of the SBIs its SBI references:
+// the done report and the newest implement report of each, so that work building upon an
+// earlier SBI (e.g. its design doc) does not have to rediscover it. An artifact larger than
+// the limit is summarized to its outline (headings and the first paragraph of each section),
+// with its path for the agent to read the rest.
+type ReferenceContextBuilder struct {
+	refRepo  repository.SBIReferenceRepository
+	sbiRepo  repository.SBIRepository
+	root     string
+	maxBytes int
+}
+
+// NewReferenceContextBuilder creates a reference context builder for the project at root
+func NewReferenceContextBuilder(refRepo repository.SBIReferenceRepository, sbiRepo repository.SBIRepository, root string) *ReferenceContextBuilder {
+	return &ReferenceContextBuilder{
+		refRepo:  refRepo,
+		sbiRepo:  sbiRepo,
+		root:     root,
+		maxBytes: defaultReferenceMaxBytes,
+	}
+}
+
+// SetMaxBytes sets how much of an artifact is included before it is summarized
+// (values <= 0 keep the default)
+func (b *ReferenceContextBuilder) SetMaxBytes(maxBytes int) {
+	if maxBytes > 0 {
+		b.maxBytes = maxBytes
+	}
+}
+
+// Build returns the prompt section with the artifacts of the SBIs referenced by the SBI
+// Returns an empty string when the SBI references none.
+func (b *ReferenceContextBuilder) Build(ctx context.Context, sbiID string) (string, error) {
+	refs, err := b.refRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		return "", err
+	}
+	if len(refs) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Referenced SBIs\n\n")
+	sb.WriteString("This task builds upon the following SBIs. Their final artifacts are included below;\n")
+	sb.WriteString("stay consistent with them and read the full files when a summary is not enough.\n\n")
+	for _, ref := range refs {
+		title, status := "(not found)", ""
+		if referenced, err := b.sbiRepo.Find(ctx, repository.SBIID(ref.ReferencedSBIID)); err == nil {
+			title, status = referenced.Title(), string(referenced.Status())
+		}
+		fmt.Fprintf(&sb, "### %s: %s", ref.ReferencedSBIID, title)
+		if status != "" {
+			fmt.Fprintf(&sb, " (%s)", status)
+		}
+		sb.WriteString("\n\n")
+		if ref.Note != "" {
+			fmt.Fprintf(&sb, "Relevance: %s\n\n", ref.Note)
+		}
+
+		artifacts := b.Artifacts(ref.ReferencedSBIID)
+		if len(artifacts) == 0 {
+			sb.WriteString("No artifacts yet.\n\n")
+			continue
+		}
+		for _, artifact := range artifacts {
+			fmt.Fprintf(&sb, "#### `%s`\n\n", artifact.Path)
+			sb.WriteString(b.fit(artifact.Content))
+			sb.WriteString("\n\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// Artifacts returns the final artifacts of an SBI: its done report and newest implement report
+// Reports are looked up in .deespec/reports/sbi and, for SBIs run before v0.2.13, .deespec/specs/sbi.
+func (b *ReferenceContextBuilder) Artifacts(sbiID string) []ReferenceArtifact {
+	var artifacts []ReferenceArtifact
+	for _, dir := range []string{"reports", "specs"} {
+		relDir := filepath.Join(".deespec", dir, "sbi", sbiID)
+		for _, name := range b.finalReports(relDir) {
+			relPath := filepath.Join(relDir, name)
+			content, err := os.ReadFile(filepath.Join(b.root, relPath))
+			if err != nil || strings.TrimSpace(string(content)) == "" {
+				continue
+			}
+			artifacts = append(artifacts, ReferenceArtifact{Path: filepath.ToSlash(relPath), Content: strings.TrimSpace(string(content))})
+		}
+		if len(artifacts) > 0 {
+			return artifacts
+		}
+	}
+	return artifacts
+}
+
+// finalReports returns the done report and the newest implement report found in a directory
+func (b *ReferenceContextBuilder) finalReports(relDir string) []string {
+	entries, err := os.ReadDir(filepath.Join(b.root, relDir))
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	newestImplement, newestTurn := "", 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if entry.Name() == "done.md" {
+			names = append(names, entry.Name())
+			continue
+		}
+		if m := implementReportPattern.FindStringSubmatch(entry.Name()); m != nil {
+			if turn, _ := strconv.Atoi(m[1]); turn > newestTurn {
+				newestImplement, newestTurn = entry.Name(), turn
+			}
+		}
+	}
+	if newestImplement != "" {
+		names = append(names, newestImplement)
+	}
+	sort.Strings(names) // done.md before implement_<turn>.md
+	return names
+}
+
+// fit returns an artifact as is when it is within the limit, otherwise its summary
+func (b *ReferenceContextBuilder) fit(content string) string {
+	if len(content) <= b.maxBytes {
+		return content
+	}
+	summary := truncateBytes(outlineMarkdown(content), b.maxBytes)
+	return fmt.Sprintf("%s\n\n(Summarized from %d KB: headings and the first paragraph of each section.)", summary, (len(content)+1023)/1024)
+}
+
+// outlineMarkdown keeps the headings of a Markdown document with the first paragraph of each
+// section; code blocks are left out
+func outlineMarkdown(content string) string {
+	var kept []string
+	inCode, inParagraph, sectionHasParagraph := false, false, false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			if inParagraph {
+				sectionHasParagraph = true
+			}
+			inParagraph = false
+			continue
+		}
+		switch {
+		case inCode:
+		case strings.HasPrefix(trimmed, "#"):
+			if len(kept) > 0 {
+				kept = append(kept, "")
+			}
+			kept = append(kept, trimmed)
+			inParagraph, sectionHasParagraph = false, false
+		case trimmed == "":
+			if inParagraph {
+				sectionHasParagraph = true
+			}
+			inParagraph = false
+		case inParagraph:
+			kept = append(kept, line)
+		case !sectionHasParagraph:
+			if len(kept) > 0 {
+				kept = append(kept, "")
+			}
+			kept = append(kept, line)
+			inParagraph = true
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// fakeReferenceRepo returns fixed references
+type fakeReferenceRepo struct {
+	refs []*repository.SBIReference
+}
+
+func (f *fakeReferenceRepo) Add(ctx context.Context, ref *repository.SBIReference) error {
+	f.refs = append(f.refs, ref)
+	return nil
+}
+
+func (f *fakeReferenceRepo) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.SBIReference, error) {
+	var refs []*repository.SBIReference
+	for _, ref := range f.refs {
+		if ref.SBIID == sbiID {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+func (f *fakeReferenceRepo) Delete(ctx context.Context, sbiID, referencedSBIID string) error {
+	return nil
+}
+
+func TestReferenceContextBuilder_Build(t *testing.T) {
+	root := t.TempDir()
+	sbiRepo := newMockSBIRepo()
+	design, err := sbi.NewSBI("Payment API design", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	sbiRepo.sbis[design.ID().String()] = design
+	designID := design.ID().String()
+
+	reportDir := path.Join(".deespec", "reports", "sbi", designID)
+	writeContextFile(t, root, path.Join(reportDir, "implement_1.md"), "# First draft")
+	writeContextFile(t, root, path.Join(reportDir, "implement_2.md"), "# Design\n\nUse idempotency keys.")
+	writeContextFile(t, root, path.Join(reportDir, "review_2.md"), "# Review")
+	writeContextFile(t, root, path.Join(reportDir, "done.md"), "# Done\n\nThe design is settled.")
+
+	refs := &fakeReferenceRepo{refs: []*repository.SBIReference{
+		{SBIID: "SBI-IMPL", ReferencedSBIID: designID, Note: "builds upon the design doc"},
+		{SBIID: "SBI-IMPL", ReferencedSBIID: "SBI-GONE"},
+	}}
+	builder := NewReferenceContextBuilder(refs, sbiRepo, root)
+
+	section, err := builder.Build(context.Background(), "SBI-IMPL")
+	require.NoError(t, err)
+	assert.Contains(t, section, "### "+designID+": Payment API design (PENDING)")
+	assert.Contains(t, section, "Relevance: builds upon the design doc")
+	assert.Contains(t, section, "`"+path.Join(reportDir, "done.md")+"`\n\n# Done\n\nThe design is settled.")
+	assert.Contains(t, section, "Use idempotency keys.", "the newest implement report is included")
+	assert.NotContains(t, section, "First draft")
+	assert.NotContains(t, section, "# Review")
+	assert.Contains(t, section, "### SBI-GONE: (not found)\n\nNo artifacts yet.")
+	assert.Less(t, strings.Index(section, "done.md"), strings.Index(section, "implement_2.md"))
+
+	section, err = builder.Build(context.Background(), "SBI-OTHER")
+	require.NoError(t, err)
+	assert.Empty(t, section, "an SBI without references gets no section")
+}
+
+func TestReferenceContextBuilder_SummarizesLargeArtifacts(t *testing.T) {
+	root := t.TempDir()
+	doc := "# Design\n\nUse idempotency keys.\nRetries reuse them.\n\n" + strings.Repeat("Long rationale. ", 200) +
+		"\n\n```go\nfunc Pay() {}\n```\n\n## Open Questions\n\n- Currency rounding\n\nMore detail.\n"
+	writeContextFile(t, root, ".deespec/specs/sbi/SBI-OLD/done.md", doc)
+
+	refs := &fakeReferenceRepo{refs: []*repository.SBIReference{{SBIID: "SBI-IMPL", ReferencedSBIID: "SBI-OLD"}}}
+	builder := NewReferenceContextBuilder(refs, newMockSBIRepo(), root)
+	builder.SetMaxBytes(1024)
+
+	section, err := builder.Build(context.Background(), "SBI-IMPL")
+	require.NoError(t, err)
+	assert.Contains(t, section, "`.deespec/specs/sbi/SBI-OLD/done.md`", "reports of SBIs run before v0.2.13 are found")
+	assert.Contains(t, section, "# Design\n\nUse idempotency keys.\nRetries reuse them.\n\n## Open Questions\n\n- Currency rounding")
+	assert.NotContains(t, section, "Long rationale")
+	assert.NotContains(t, section, "func Pay")
+	assert.NotContains(t, section, "More detail.")
+	assert.Contains(t, section, "(Summarized from 4 KB")
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// AtRiskRatio is the share of the turn or attempt limit after which an SBI is at risk
+const AtRiskRatio = 0.8
+
+// EventTaskAtRisk is the notification event emitted when an SBI becomes at risk
+const EventTaskAtRisk = "task.at_risk"
+
+// RetryBudget tracks how much of the turn and attempt limits an SBI has consumed
+// An unfinished SBI that has used AtRiskRatio of either limit is "at risk": it is about to be
+// force-terminated (max turns) or force-implemented (max attempts), throwing away the remaining
+// context, so a human is notified while there is still time to intervene.
+type RetryBudget struct {
+	maxTurns    int
+	maxAttempts int
+	notifier    output.NotificationGateway // Optional: at-risk notifications
+}
+
+// RetryBudgetUsage is an SBI's consumption of its retry budget
+type RetryBudgetUsage struct {
+	Turn        int  `json:"turn"`
+	MaxTurns    int  `json:"max_turns"`
+	Attempt     int  `json:"attempt"`
+	MaxAttempts int  `json:"max_attempts"`
+	AtRisk      bool `json:"at_risk"`
+}
+
+// NewRetryBudget creates a retry budget for the given limits; a limit <= 0 is never at risk
+// notifier may be nil when only usage is needed (e.g. list output).
+func NewRetryBudget(maxTurns, maxAttempts int, notifier output.NotificationGateway) *RetryBudget {
+	return &RetryBudget{
+		maxTurns:    maxTurns,
+		maxAttempts: maxAttempts,
+		notifier:    notifier,
+	}
+}
+
+// Usage returns the budget usage of an SBI in the given status; finished SBIs are never at risk
+func (b *RetryBudget) Usage(status model.Status, turn, attempt int) RetryBudgetUsage {
+	usage := RetryBudgetUsage{
+		Turn:        turn,
+		MaxTurns:    b.maxTurns,
+		Attempt:     attempt,
+		MaxAttempts: b.maxAttempts,
+	}
+	switch status {
+	case model.StatusDone, model.StatusFailed, model.StatusCancelled:
+		return usage
+	}
+	usage.AtRisk = consumed(turn, b.maxTurns) || consumed(attempt, b.maxAttempts)
+	return usage
+}
+
+// UsageOf returns the budget usage of an SBI entity
+func (b *RetryBudget) UsageOf(s *sbi.SBI) RetryBudgetUsage {
+	var turn, attempt int
+	if exec := s.ExecutionState(); exec != nil {
+		turn, attempt = exec.CurrentTurn.Value(), exec.CurrentAttempt.Value()
+	}
+	return b.Usage(s.Status(), turn, attempt)
+}
+
+// NotifyIfAtRisk notifies when s became at risk since before was taken, and reports whether it did
+// An SBI is notified once, when it crosses the threshold; it is not re-notified on later turns.
+func (b *RetryBudget) NotifyIfAtRisk(ctx context.Context, s *sbi.SBI, before RetryBudgetUsage) (bool, error) {
+	after := b.UsageOf(s)
+	if b.notifier == nil || before.AtRisk || !after.AtRisk {
+		return false, nil
+	}
+	if err := b.notifier.Notify(ctx, buildAtRiskNotification(s, after, time.Now())); err != nil {
+		return false, fmt.Errorf("failed to notify at-risk SBI %s: %w", s.ID(), err)
+	}
+	return true, nil
+}
+
+// consumed reports whether used has reached AtRiskRatio of limit
+func consumed(used, limit int) bool {
+	return limit > 0 && float64(used) >= AtRiskRatio*float64(limit)
+}
+
+// buildAtRiskNotification creates the notification for an SBI that became at risk
+func buildAtRiskNotification(s *sbi.SBI, usage RetryBudgetUsage, now time.Time) output.Notification {
+	return output.Notification{
+		Level: output.NotificationLevelWarning,
+		Event: EventTaskAtRisk,
+		Title: fmt.Sprintf("SBI at risk: %s", s.Title()),
+		Message: fmt.Sprintf("Used turn %d of %d and attempt %d of %d; the SBI is forced to finish once a limit is exceeded",
+			usage.Turn, usage.MaxTurns, usage.Attempt, usage.MaxAttempts),
+		TaskID:    s.ID().String(),
+		Timestamp: now,
+		Fields: map[string]string{
+			"type":         "SBI",
+			"status":       s.Status().String(),
+			"turn":         fmt.Sprint(usage.Turn),
+			"max_turns":    fmt.Sprint(usage.MaxTurns),
+			"attempt":      fmt.Sprint(usage.Attempt),
+			"max_attempts": fmt.Sprint(usage.MaxAttempts),
+		},
+	}
+}
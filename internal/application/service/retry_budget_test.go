@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+func TestRetryBudget_Usage(t *testing.T) {
+	budget := NewRetryBudget(8, 3, nil)
+
+	tests := []struct {
+		name    string
+		status  model.Status
+		turn    int
+		attempt int
+		want    bool
+	}{
+		{"early", model.StatusImplementing, 3, 1, false},
+		{"below 80% of turns", model.StatusImplementing, 6, 1, false},
+		{"80% of turns", model.StatusImplementing, 7, 1, true},
+		{"80% of attempts", model.StatusReviewing, 2, 3, true},
+		{"finished", model.StatusDone, 8, 3, false},
+		{"cancelled", model.StatusCancelled, 8, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := budget.Usage(tt.status, tt.turn, tt.attempt)
+			assert.Equal(t, tt.want, usage.AtRisk)
+			assert.Equal(t, 8, usage.MaxTurns)
+			assert.Equal(t, 3, usage.MaxAttempts)
+		})
+	}
+
+	assert.False(t, NewRetryBudget(0, 0, nil).Usage(model.StatusImplementing, 100, 100).AtRisk, "no limits, no risk")
+}
+
+func TestRetryBudget_NotifyIfAtRisk(t *testing.T) {
+	notifier := &recordingNotifier{}
+	budget := NewRetryBudget(5, 3, notifier)
+	ctx := context.Background()
+
+	s, err := sbi.NewSBI("Login", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateStatus(model.StatusPicked))
+	require.NoError(t, s.UpdateStatus(model.StatusImplementing))
+
+	// Turns 1..3 of 5 stay below the threshold
+	for s.ExecutionState().CurrentTurn.Value() < 3 {
+		before := budget.UsageOf(s)
+		s.IncrementTurn()
+		sent, err := budget.NotifyIfAtRisk(ctx, s, before)
+		require.NoError(t, err)
+		assert.False(t, sent)
+	}
+
+	// Turn 4 of 5 crosses 80% and is notified once
+	before := budget.UsageOf(s)
+	s.IncrementTurn()
+	sent, err := budget.NotifyIfAtRisk(ctx, s, before)
+	require.NoError(t, err)
+	assert.True(t, sent)
+	require.Len(t, notifier.notifications, 1)
+	n := notifier.notifications[0]
+	assert.Equal(t, EventTaskAtRisk, n.Event)
+	assert.Equal(t, s.ID().String(), n.TaskID)
+	assert.Equal(t, "4", n.Fields["turn"])
+	assert.Equal(t, "5", n.Fields["max_turns"])
+
+	// Later turns are not notified again
+	before = budget.UsageOf(s)
+	s.IncrementTurn()
+	sent, err = budget.NotifyIfAtRisk(ctx, s, before)
+	require.NoError(t, err)
+	assert.False(t, sent)
+	assert.Len(t, notifier.notifications, 1)
+
+	// Notification failures are returned
+	notifier.err = errors.New("webhook down")
+	_, err = budget.NotifyIfAtRisk(ctx, s, RetryBudgetUsage{})
+	assert.ErrorContains(t, err, "webhook down")
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+const (
+	defaultReviewMaxDiffBytes = 200 * 1024
+	defaultReviewTestTimeout  = 10 * time.Minute
+
+	// maxReviewReportBytes and maxReviewTestOutputBytes keep the bundle readable in one pass
+	maxReviewReportBytes     = 64 * 1024
+	maxReviewTestOutputBytes = 32 * 1024
+
+	// reviewBaseFile records the working tree snapshot the next review diffs against
+	reviewBaseFile = "review_base"
+)
+
+// reviewDiffExclude keeps deespec's own files out of the reviewed diff
+var reviewDiffExclude = []string{"--", ".", ":(exclude).deespec"}
+
+// reviewTestResultPattern matches the test result line written into a review bundle
+var reviewTestResultPattern = regexp.MustCompile("(?m)^Command: `(.*)` — (.+) in (\\S+)$")
+
+// shellRunner runs a shell command in a directory and returns its combined output
+type shellRunner func(ctx context.Context, dir, command string) ([]byte, error)
+
+// ReviewContextBuilder bundles what a reviewer needs into a single file: the code diff since
+// the last review, the test results and the implement report, so that review prompts can point
+// the agent at one file instead of having it search the reports directories.
+//
+// The diff base is a snapshot of the working tree (`git stash create`, which leaves the tree
+// untouched) taken when the SBI's implementation starts and again at every review.
+type ReviewContextBuilder struct {
+	root         string
+	maxDiffBytes int
+	testCommand  string
+	testTimeout  time.Duration
+	runGit       gitRunner
+	runShell     shellRunner
+}
+
+// NewReviewContextBuilder creates a review context builder for the project at root
+func NewReviewContextBuilder(root string) *ReviewContextBuilder {
+	return &ReviewContextBuilder{
+		root:         root,
+		maxDiffBytes: defaultReviewMaxDiffBytes,
+		testTimeout:  defaultReviewTestTimeout,
+		runGit:       runGitCommand,
+		runShell:     runShellCommand,
+	}
+}
+
+// SetMaxDiffBytes sets how much of the diff is bundled (values <= 0 keep the default)
+func (b *ReviewContextBuilder) SetMaxDiffBytes(maxDiffBytes int) {
+	if maxDiffBytes > 0 {
+		b.maxDiffBytes = maxDiffBytes
+	}
+}
+
+// SetTestCommand sets the command whose output is bundled as the test results
+// An empty command bundles no test results; a timeout <= 0 keeps the default.
+func (b *ReviewContextBuilder) SetTestCommand(command string, timeout time.Duration) {
+	b.testCommand = strings.TrimSpace(command)
+	if timeout > 0 {
+		b.testTimeout = timeout
+	}
+}
+
+// ReviewContextPath returns the bundle path of a review turn, relative to the project root
+func ReviewContextPath(sbiID string, turn int) string {
+	return filepath.Join(".deespec", "reports", "sbi", sbiID, fmt.Sprintf("review_context_%d.md", turn))
+}
+
+// ParseReviewTestResult returns the test command, its result and elapsed time from a review bundle
+func ParseReviewTestResult(bundle string) (command, result, elapsed string, ok bool) {
+	m := reviewTestResultPattern.FindStringSubmatch(bundle)
+	if m == nil {
+		return "", "", "", false
+	}
+	return m[1], m[2], m[3], true
+}
+
+// MarkImplementStart records the diff base of the SBI's first review, unless one is recorded
+func (b *ReviewContextBuilder) MarkImplementStart(ctx context.Context, sbiID string) error {
+	if _, err := os.Stat(b.basePath(sbiID)); err == nil {
+		return nil
+	}
+	return b.recordBase(ctx, sbiID, "implement")
+}
+
+// Build writes the bundle of a review turn and returns its path relative to the project root
+// The bundle is built once per turn, so every reviewer of a quorum and a re-run review see
+// the same diff; building it moves the diff base of the next review to the current tree.
+func (b *ReviewContextBuilder) Build(ctx context.Context, sbiID string, turn int) (string, error) {
+	relPath := ReviewContextPath(sbiID, turn)
+	path := filepath.Join(b.root, relPath)
+	if _, err := os.Stat(path); err == nil {
+		return relPath, nil
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# Review Context: SBI %s, turn %d\n\n", sbiID, turn)
+	fmt.Fprintf(&buf, "Bundled by deespec on %s. Read this file first: it holds the implement report,\n", time.Now().UTC().Format(time.RFC3339))
+	buf.WriteString("the code changes to review and the test results.\n\n")
+	b.writeImplementReport(&buf, sbiID, turn)
+	b.writeDiff(ctx, &buf, sbiID)
+	b.writeTestResults(ctx, &buf)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := fs.WriteFileAtomic(path, []byte(buf.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write review context: %w", err)
+	}
+	if err := b.recordBase(ctx, sbiID, "review"); err != nil {
+		return "", err
+	}
+	return relPath, nil
+}
+
+// writeImplementReport bundles the newest implement report before the review turn
+func (b *ReviewContextBuilder) writeImplementReport(buf *strings.Builder, sbiID string, turn int) {
+	buf.WriteString("## Implement Report\n\n")
+	for t := turn - 1; t >= 1; t-- {
+		relPath := filepath.Join(".deespec", "reports", "sbi", sbiID, fmt.Sprintf("implement_%d.md", t))
+		content, err := os.ReadFile(filepath.Join(b.root, relPath))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(buf, "Source: `%s`\n\n", filepath.ToSlash(relPath))
+		buf.WriteString(truncateBytes(strings.TrimSpace(string(content)), maxReviewReportBytes))
+		buf.WriteString("\n\n")
+		return
+	}
+	buf.WriteString("No implement report was found for this SBI.\n\n")
+}
+
+// writeDiff bundles the changes since the diff base, excluding .deespec
+func (b *ReviewContextBuilder) writeDiff(ctx context.Context, buf *strings.Builder, sbiID string) {
+	buf.WriteString("## Code Changes\n\n")
+
+	base, since := b.loadBase(ctx, sbiID)
+	if base == "" {
+		buf.WriteString("Not available: the project is not a git repository.\n\n")
+		return
+	}
+	stat, err := b.runGit(ctx, b.root, append([]string{"diff", "--stat", base}, reviewDiffExclude...)...)
+	if err != nil {
+		fmt.Fprintf(buf, "Not available: %v\n\n", err)
+		return
+	}
+	diff, err := b.runGit(ctx, b.root, append([]string{"diff", base}, reviewDiffExclude...)...)
+	if err != nil {
+		fmt.Fprintf(buf, "Not available: %v\n\n", err)
+		return
+	}
+	untracked, _ := b.runGit(ctx, b.root, append([]string{"ls-files", "--others", "--exclude-standard"}, reviewDiffExclude...)...)
+
+	fmt.Fprintf(buf, "Changes since %s (base `%s`), excluding `.deespec/`.\n\n", since, shortSHA(base))
+	if len(bytes.TrimSpace(diff)) == 0 && len(bytes.TrimSpace(untracked)) == 0 {
+		buf.WriteString("No changes.\n\n")
+		return
+	}
+	if len(bytes.TrimSpace(diff)) > 0 {
+		buf.WriteString("```diff\n")
+		buf.WriteString(strings.TrimRight(string(stat), "\n"))
+		buf.WriteString("\n\n")
+		buf.WriteString(truncateBytes(strings.TrimRight(string(diff), "\n"), b.maxDiffBytes))
+		buf.WriteString("\n```\n\n")
+	}
+	if files := strings.Fields(string(untracked)); len(files) > 0 {
+		buf.WriteString("New files not yet tracked by git (read them directly):\n")
+		for _, file := range files {
+			fmt.Fprintf(buf, "- `%s`\n", file)
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// writeTestResults runs the test command and bundles its outcome
+func (b *ReviewContextBuilder) writeTestResults(ctx context.Context, buf *strings.Builder) {
+	buf.WriteString("## Test Results\n\n")
+	if b.testCommand == "" {
+		buf.WriteString("No test command is configured (\"review_context.test_command\"); run the tests yourself.\n")
+		return
+	}
+
+	testCtx, cancel := context.WithTimeout(ctx, b.testTimeout)
+	defer cancel()
+	started := time.Now()
+	out, err := b.runShell(testCtx, b.root, b.testCommand)
+	elapsed := time.Since(started).Round(time.Second)
+
+	result := "passed"
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(testCtx.Err(), context.DeadlineExceeded):
+		result = fmt.Sprintf("timed out after %s", b.testTimeout)
+	case errors.As(err, &exitErr):
+		result = fmt.Sprintf("failed (exit status %d)", exitErr.ExitCode())
+	case err != nil:
+		result = fmt.Sprintf("could not run: %v", err)
+	}
+	fmt.Fprintf(buf, "Command: `%s` — %s in %s\n\n", b.testCommand, result, elapsed)
+	if output := strings.TrimSpace(string(out)); output != "" {
+		buf.WriteString("```text\n")
+		buf.WriteString(tailBytes(output, maxReviewTestOutputBytes))
+		buf.WriteString("\n```\n")
+	}
+}
+
+// loadBase returns the recorded diff base and what it marks, falling back to HEAD
+// Returns "" when the project is not a git repository.
+func (b *ReviewContextBuilder) loadBase(ctx context.Context, sbiID string) (base, since string) {
+	if content, err := os.ReadFile(b.basePath(sbiID)); err == nil {
+		fields := strings.SplitN(strings.TrimSpace(string(content)), " ", 2)
+		if fields[0] != "" {
+			// Snapshots are unreferenced and may have been garbage collected
+			if _, err := b.runGit(ctx, b.root, "cat-file", "-e", fields[0]+"^{commit}"); err == nil {
+				since = "the implementation started"
+				if len(fields) == 2 && fields[1] == "review" {
+					since = "the last review"
+				}
+				return fields[0], since
+			}
+		}
+	}
+	head, err := b.runGit(ctx, b.root, "rev-parse", "HEAD")
+	if err != nil {
+		return "", ""
+	}
+	return strings.TrimSpace(string(head)), "the last commit"
+}
+
+// recordBase snapshots the working tree as the diff base of the next review
+// The base file holds the snapshot and what took it (marker "implement" or "review").
+func (b *ReviewContextBuilder) recordBase(ctx context.Context, sbiID, marker string) error {
+	snapshot, err := b.snapshot(ctx)
+	if err != nil || snapshot == "" {
+		return nil // Not a git repository: reviews fall back to "not available"
+	}
+	if err := os.MkdirAll(filepath.Dir(b.basePath(sbiID)), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := fs.WriteFileAtomic(b.basePath(sbiID), []byte(snapshot+" "+marker+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to record review diff base: %w", err)
+	}
+	return nil
+}
+
+// snapshot returns a commit of the working tree's tracked files, or HEAD when the tree is clean
+func (b *ReviewContextBuilder) snapshot(ctx context.Context) (string, error) {
+	return snapshotWorkingTree(ctx, b.runGit, b.root)
+}
+
+// snapshotWorkingTree commits the tracked files of the working tree at root without touching it
+// (`git stash create`), falling back to HEAD when the tree is clean
+func snapshotWorkingTree(ctx context.Context, runGit gitRunner, root string) (string, error) {
+	out, err := runGit(ctx, root, "stash", "create")
+	if err != nil {
+		return "", err
+	}
+	if sha := strings.TrimSpace(string(out)); sha != "" {
+		return sha, nil
+	}
+	out, err = runGit(ctx, root, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// basePath returns the path of the SBI's diff base file
+func (b *ReviewContextBuilder) basePath(sbiID string) string {
+	return filepath.Join(b.root, ".deespec", "reports", "sbi", sbiID, reviewBaseFile)
+}
+
+// shortSHA abbreviates a commit hash for display
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// truncateBytes keeps the first max bytes of s, noting what was cut
+func truncateBytes(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + fmt.Sprintf("\n... (truncated, %d more bytes)", len(s)-max)
+}
+
+// tailBytes keeps the last max bytes of s, where test failures are usually reported
+func tailBytes(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return fmt.Sprintf("... (%d earlier bytes omitted)\n", len(s)-max) + s[len(s)-max:]
+}
+
+// runShellCommand runs command with sh in dir and returns its combined output
+func runShellCommand(ctx context.Context, dir, command string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	// Processes started by the command may keep the output open after sh is killed
+	cmd.WaitDelay = 5 * time.Second
+	return cmd.CombinedOutput()
+}
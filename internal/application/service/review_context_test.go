@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initReviewRepo creates a git repository with one commit and returns its root
+func initReviewRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(key, "Alice")
+	}
+	for _, key := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(key, "alice@example.com")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	writeContextFile(t, root, "main.go", "package main\n")
+	git("add", "main.go")
+	git("commit", "-q", "-m", "Initial import")
+	return root
+}
+
+func readReviewContext(t *testing.T, root, relPath string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(root, relPath))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestReviewContextBuilder_DiffsSinceImplementStartThenLastReview(t *testing.T) {
+	root := initReviewRepo(t)
+	ctx := context.Background()
+	builder := NewReviewContextBuilder(root)
+
+	// Uncommitted work that predates the SBI is not part of its review
+	writeContextFile(t, root, "main.go", "package main\n\n// unrelated\n")
+	require.NoError(t, builder.MarkImplementStart(ctx, "SBI-1"))
+
+	writeContextFile(t, root, "main.go", "package main\n\n// unrelated\n\nfunc handler() {}\n")
+	writeContextFile(t, root, "handler.go", "package main\n")
+	writeContextFile(t, root, ".deespec/reports/sbi/SBI-1/implement_1.md", "Added the handler.\n")
+
+	relPath, err := builder.Build(ctx, "SBI-1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(".deespec", "reports", "sbi", "SBI-1", "review_context_2.md"), relPath)
+	bundle := readReviewContext(t, root, relPath)
+	assert.Contains(t, bundle, "Added the handler.")
+	assert.Contains(t, bundle, "Changes since the implementation started")
+	assert.Contains(t, bundle, "+func handler() {}")
+	assert.NotContains(t, bundle, "+// unrelated", "changes before the implementation started are excluded")
+	assert.Contains(t, bundle, "- `handler.go`", "untracked files are listed")
+	assert.NotContains(t, bundle, "implement_1.md`\n-", ".deespec files are not part of the diff")
+	assert.Contains(t, bundle, "No test command is configured")
+
+	// Building the same turn again reuses the bundle, so every reviewer sees the same diff
+	writeContextFile(t, root, "main.go", "package main\n\n// unrelated\n\nfunc handler() { _ = 1 }\n")
+	again, err := builder.Build(ctx, "SBI-1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, bundle, readReviewContext(t, root, again))
+
+	// The next review diffs against the previous one
+	relPath, err = builder.Build(ctx, "SBI-1", 4)
+	require.NoError(t, err)
+	bundle = readReviewContext(t, root, relPath)
+	assert.Contains(t, bundle, "Changes since the last review")
+	assert.Contains(t, bundle, "+func handler() { _ = 1 }")
+	assert.NotContains(t, bundle, "+func handler() {}\n")
+
+	// A later implementation start keeps the recorded base
+	require.NoError(t, builder.MarkImplementStart(ctx, "SBI-1"))
+	relPath, err = builder.Build(ctx, "SBI-1", 6)
+	require.NoError(t, err)
+	assert.Contains(t, readReviewContext(t, root, relPath), "Changes since the last review")
+}
+
+func TestReviewContextBuilder_BundlesTestResults(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+
+	builder := NewReviewContextBuilder(root)
+	builder.SetTestCommand("echo ok; echo 'all tests passed'", time.Minute)
+	relPath, err := builder.Build(ctx, "SBI-1", 2)
+	require.NoError(t, err)
+	bundle := readReviewContext(t, root, relPath)
+	assert.Contains(t, bundle, "Not available: the project is not a git repository.")
+	assert.Contains(t, bundle, "No implement report was found")
+	assert.Contains(t, bundle, "— passed in")
+	assert.Contains(t, bundle, "all tests passed")
+
+	builder.SetTestCommand("echo '--- FAIL: TestHandler'; exit 3", time.Minute)
+	relPath, err = builder.Build(ctx, "SBI-1", 4)
+	require.NoError(t, err)
+	bundle = readReviewContext(t, root, relPath)
+	assert.Contains(t, bundle, "failed (exit status 3)")
+	assert.Contains(t, bundle, "--- FAIL: TestHandler")
+
+	builder.SetTestCommand("exec sleep 5", 50*time.Millisecond)
+	relPath, err = builder.Build(ctx, "SBI-1", 6)
+	require.NoError(t, err)
+	assert.Contains(t, readReviewContext(t, root, relPath), "timed out after 50ms")
+}
+
+func TestReviewContextBuilder_TruncatesLargeDiffs(t *testing.T) {
+	root := initReviewRepo(t)
+	ctx := context.Background()
+	builder := NewReviewContextBuilder(root)
+	builder.SetMaxDiffBytes(64)
+	require.NoError(t, builder.MarkImplementStart(ctx, "SBI-1"))
+
+	writeContextFile(t, root, "main.go", "package main\n\nvar a = \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"\n")
+	relPath, err := builder.Build(ctx, "SBI-1", 2)
+	require.NoError(t, err)
+	assert.Contains(t, readReviewContext(t, root, relPath), "... (truncated,")
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// Review timeout policies: what happens to an SBI whose review decision did not arrive in time
+const (
+	// ReviewTimeoutRetry sends the SBI back to implementation as if the review asked for changes
+	ReviewTimeoutRetry = "retry"
+	// ReviewTimeoutForceImplement has the reviewer implement the remaining work itself and finishes the SBI
+	ReviewTimeoutForceImplement = "force_implement"
+)
+
+// ReviewTimeout is the review decision SLA: an SBI left in REVIEWING longer than the timeout
+// (e.g. the agent crashed without issuing `sbi review`) is timed out instead of reviewed again
+type ReviewTimeout struct {
+	timeout time.Duration
+	policy  string
+	now     func() time.Time
+}
+
+// NewReviewTimeout creates a review timeout; it returns nil (no SLA) when timeout <= 0
+// An unknown policy falls back to ReviewTimeoutRetry.
+func NewReviewTimeout(timeout time.Duration, policy string) *ReviewTimeout {
+	if timeout <= 0 {
+		return nil
+	}
+	if policy != ReviewTimeoutForceImplement {
+		policy = ReviewTimeoutRetry
+	}
+	return &ReviewTimeout{
+		timeout: timeout,
+		policy:  policy,
+		now:     time.Now,
+	}
+}
+
+// Timeout returns how long a review decision is waited for
+func (t *ReviewTimeout) Timeout() time.Duration {
+	return t.timeout
+}
+
+// Expired reports whether s has been reviewing longer than the timeout, and for how long
+// SBIs that are not reviewing, or whose review start is unknown, never expire.
+func (t *ReviewTimeout) Expired(s *sbi.SBI) (time.Duration, bool) {
+	started := s.ReviewStartedAt()
+	if s.Status() != model.StatusReviewing || started == nil {
+		return 0, false
+	}
+	waited := t.now().Sub(*started)
+	return waited, waited > t.timeout
+}
+
+// Policy returns what happens to a timed-out review: ReviewTimeoutRetry or ReviewTimeoutForceImplement
+func (t *ReviewTimeout) Policy() string {
+	return t.policy
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+func TestReviewTimeout(t *testing.T) {
+	assert.Nil(t, NewReviewTimeout(0, ReviewTimeoutRetry), "a zero timeout disables the SLA")
+	assert.Equal(t, ReviewTimeoutRetry, NewReviewTimeout(time.Minute, "escalate").Policy())
+	assert.Equal(t, ReviewTimeoutForceImplement, NewReviewTimeout(time.Minute, ReviewTimeoutForceImplement).Policy())
+
+	task, err := sbi.NewSBI("task", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	rt := NewReviewTimeout(10*time.Minute, ReviewTimeoutRetry)
+
+	_, expired := rt.Expired(task)
+	assert.False(t, expired, "SBIs that are not reviewing never expire")
+
+	for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing} {
+		require.NoError(t, task.UpdateStatus(next))
+	}
+	require.NotNil(t, task.ReviewStartedAt())
+	started := *task.ReviewStartedAt()
+
+	rt.now = func() time.Time { return started.Add(5 * time.Minute) }
+	_, expired = rt.Expired(task)
+	assert.False(t, expired)
+
+	rt.now = func() time.Time { return started.Add(15 * time.Minute) }
+	waited, expired := rt.Expired(task)
+	assert.True(t, expired)
+	assert.Equal(t, 15*time.Minute, waited)
+
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	assert.Nil(t, task.ReviewStartedAt(), "leaving review clears the review start")
+}
@@ -13,8 +13,11 @@ import (
 
 // SBIExecutionService provides business logic for SBI execution management
 type SBIExecutionService struct {
-	sbiRepo     repository.SBIRepository
-	lockService LockService
+	sbiRepo       repository.SBIRepository
+	lockService   LockService
+	specPreflight *SpecPreflight     // Optional: pending SBIs with unresolved spec warnings are not picked
+	aging         *sbi.PriorityAging // Optional: pending SBIs are picked by their aged priority
+	now           func() time.Time
 }
 
 // NewSBIExecutionService creates a new SBI execution service
@@ -22,9 +25,20 @@ func NewSBIExecutionService(sbiRepo repository.SBIRepository, lockService LockSe
 	return &SBIExecutionService{
 		sbiRepo:     sbiRepo,
 		lockService: lockService,
+		now:         time.Now,
 	}
 }
 
+// SetSpecPreflight skips pending SBIs held back by the spec pre-flight check in block mode
+func (s *SBIExecutionService) SetSpecPreflight(preflight *SpecPreflight) {
+	s.specPreflight = preflight
+}
+
+// SetPriorityAging picks pending SBIs by their priority raised with the time they have waited
+func (s *SBIExecutionService) SetPriorityAging(aging sbi.PriorityAging) {
+	s.aging = &aging
+}
+
 // PickNextSBI selects the next SBI to execute based on priority rules
 // Priority:
 // 1. SBIs in PICKED or IMPLEMENTING status (continue implementation)
@@ -53,28 +67,27 @@ func (s *SBIExecutionService) PickNextSBI(ctx context.Context) (*sbi.SBI, error)
 	}
 
 	// No in-progress SBIs found, look for pending SBIs with met dependencies
-	// Get completed SBI IDs first to check dependencies
-	completedSet, err := s.getCompletedSBIIDs(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get completed SBIs: %w", err)
-	}
-
-	// Fetch all pending SBIs (not just 1) to filter by dependencies
+	// Fetch all pending SBIs (not just 1) with their dependency state to filter by dependencies
 	pendingFilter := repository.SBIFilter{
 		Statuses: []model.Status{model.StatusPending},
 		Limit:    100, // Get more to filter by dependencies
+		Aging:    s.aging,
+		AgedAt:   s.now(),
 	}
 
-	pendingSBIs, err := s.sbiRepo.List(ctx, pendingFilter)
+	pendingSBIs, err := s.sbiRepo.ListWithExecutionState(ctx, pendingFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pending SBIs: %w", err)
 	}
 
 	// Filter pending SBIs to only those with met dependencies
 	for _, candidate := range pendingSBIs {
-		if s.areDependenciesMet(ctx, candidate, completedSet) {
+		if s.specPreflight != nil && s.specPreflight.Holds(candidate.SBI) {
+			continue
+		}
+		if candidate.DependenciesMet() {
 			// Found a pending SBI with met dependencies, return it
-			return candidate, nil
+			return candidate.SBI, nil
 		}
 	}
 
@@ -82,45 +95,32 @@ func (s *SBIExecutionService) PickNextSBI(ctx context.Context) (*sbi.SBI, error)
 	return nil, nil
 }
 
-// getCompletedSBIIDs returns a set of completed SBI IDs
-func (s *SBIExecutionService) getCompletedSBIIDs(ctx context.Context) (map[string]bool, error) {
-	completedFilter := repository.SBIFilter{
-		Statuses: []model.Status{model.StatusDone},
-		Limit:    1000, // Get all completed SBIs
-	}
-
-	completedSBIs, err := s.sbiRepo.List(ctx, completedFilter)
-	if err != nil {
-		return nil, err
-	}
-
-	completedSet := make(map[string]bool)
-	for _, sbi := range completedSBIs {
-		completedSet[sbi.ID().String()] = true
-	}
-
-	return completedSet, nil
-}
-
-// areDependenciesMet checks if all dependencies of an SBI are completed
-func (s *SBIExecutionService) areDependenciesMet(ctx context.Context, candidate *sbi.SBI, completedSet map[string]bool) bool {
-	// Get dependencies from database
-	deps, err := s.sbiRepo.GetDependencies(ctx, repository.SBIID(candidate.ID().String()))
+// PBIQueuePositions returns the per-PBI queue position of every pending SBI keyed by SBI ID
+// SBIs marked as waiting cannot start until an SBI of the same PBI finishes (limit > 0).
+// Candidates are listed and filtered the way the parallel picker does: a single pick-ordered
+// query, with pending SBIs whose dependencies are not done left out since they never take a slot.
+func (s *SBIExecutionService) PBIQueuePositions(ctx context.Context, limit int) (map[string]PBIQueuePosition, error) {
+	candidates, err := s.sbiRepo.ListWithExecutionState(ctx, repository.SBIFilter{
+		Statuses: append([]model.Status{model.StatusPending}, InProgressStatuses...),
+		Aging:    s.aging,
+		AgedAt:   s.now(),
+	})
 	if err != nil {
-		// Log error but continue (assume no dependencies if we can't load them)
-		return true
+		return nil, fmt.Errorf("failed to list executable SBIs: %w", err)
 	}
 
-	// Check if all dependencies are in completed set
-	for _, depID := range deps {
-		if !completedSet[depID] {
-			// Dependency not completed
-			return false
+	var active, pending []*sbi.SBI
+	for _, candidate := range candidates {
+		if candidate.SBI.Status() != model.StatusPending {
+			active = append(active, candidate.SBI)
+			continue
+		}
+		if candidate.DependenciesMet() {
+			pending = append(pending, candidate.SBI)
 		}
 	}
 
-	// All dependencies met
-	return true
+	return ComputePBIQueuePositions(limit, active, pending), nil
 }
 
 // GetSBIByID retrieves an SBI by its ID
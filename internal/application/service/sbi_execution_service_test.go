@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -13,11 +15,13 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
 )
 
 // Mock SBI Repository for testing
 type mockSBIRepo struct {
 	sbis map[string]*sbi.SBI
+	deps map[string][]string
 }
 
 func newMockSBIRepo() *mockSBIRepo {
@@ -88,6 +92,10 @@ func (m *mockLockService) ListStateLocks(ctx context.Context) ([]*lock.StateLock
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (m *mockLockService) CleanupExpiredLocks(ctx context.Context) (int, int, error) {
+	return 0, 0, nil
+}
+
 func (m *mockLockService) Start(ctx context.Context) error {
 	return nil
 }
@@ -104,6 +112,34 @@ func (m *mockSBIRepo) Find(ctx context.Context, id repository.SBIID) (*sbi.SBI,
 	return s, nil
 }
 
+func (m *mockSBIRepo) FindMany(ctx context.Context, ids []repository.SBIID) ([]*sbi.SBI, error) {
+	var result []*sbi.SBI
+	for _, id := range ids {
+		if s, ok := m.sbis[string(id)]; ok {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSBIRepo) ListWithExecutionState(ctx context.Context, filter repository.SBIFilter) ([]*repository.SBIExecutionState, error) {
+	sbis, err := m.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var result []*repository.SBIExecutionState
+	for _, s := range sbis {
+		state := &repository.SBIExecutionState{SBI: s, DependsOn: m.deps[s.ID().String()]}
+		for _, depID := range state.DependsOn {
+			if dep, ok := m.sbis[depID]; !ok || dep.Status() != model.StatusDone {
+				state.UnmetDependencies = append(state.UnmetDependencies, depID)
+			}
+		}
+		result = append(result, state)
+	}
+	return result, nil
+}
+
 func (m *mockSBIRepo) Save(ctx context.Context, s *sbi.SBI) error {
 	m.sbis[s.ID().String()] = s
 	return nil
@@ -132,6 +168,11 @@ func (m *mockSBIRepo) List(ctx context.Context, filter repository.SBIFilter) ([]
 			}
 		}
 
+		// Filter by overdue deadline if specified
+		if filter.OverdueAt != nil && !s.IsOverdue(*filter.OverdueAt) {
+			continue
+		}
+
 		result = append(result, s)
 
 		// Apply limit if specified
@@ -163,8 +204,8 @@ func (m *mockSBIRepo) ResetSBIState(ctx context.Context, id repository.SBIID, to
 }
 
 func (m *mockSBIRepo) GetDependencies(ctx context.Context, sbiID repository.SBIID) ([]string, error) {
-	// Return empty dependencies for tests (no dependencies by default)
-	return []string{}, nil
+	// No dependencies unless a test sets them
+	return m.deps[string(sbiID)], nil
 }
 
 func (m *mockSBIRepo) GetDependents(ctx context.Context, sbiID repository.SBIID) ([]string, error) {
@@ -496,3 +537,72 @@ func TestSBIExecutionService_PickAndLockNextSBI_LockAlreadyHeld(t *testing.T) {
 	assert.Nil(t, pickedSBI) // Lock already held
 	assert.Nil(t, stateLock)
 }
+
+func TestSBIExecutionService_PBIQueuePositions_SkipsBlockedSBIs(t *testing.T) {
+	repo := newMockSBIRepo()
+	service := NewSBIExecutionService(repo, newMockLockService())
+	ctx := context.Background()
+
+	pbiA := model.NewTaskID()
+	blocker := newSBIForPBI(t, "Blocker", nil)
+	blocked := newSBIForPBI(t, "A blocked", &pbiA)
+	ready := newSBIForPBI(t, "A ready", &pbiA)
+	for _, s := range []*sbi.SBI{blocker, blocked, ready} {
+		require.NoError(t, repo.Save(ctx, s))
+	}
+	repo.deps = map[string][]string{blocked.ID().String(): {blocker.ID().String()}}
+
+	// The picker skips the blocked SBI, so the ready one takes the PBI's only WIP slot
+	positions, err := service.PBIQueuePositions(ctx, 1)
+	require.NoError(t, err)
+
+	_, queued := positions[blocked.ID().String()]
+	assert.False(t, queued)
+	assert.Equal(t, PBIQueuePosition{PBIID: pbiA.String(), Position: 1, Waiting: false}, positions[ready.ID().String()])
+}
+
+// TestSBIExecutionService_PickNextSBI_AgingPreventsStarvation simulates a worker finishing one
+// SBI an hour while a new urgent SBI arrives every hour: without aging the normal-priority SBI
+// registered first is never picked, with aging it is picked once it has aged to urgent
+func TestSBIExecutionService_PickNextSBI_AgingPreventsStarvation(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	const hours = 72
+
+	simulate := func(aging *sbi.PriorityAging) (pickedAt int) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		require.NoError(t, sqlite.NewMigrator(db).Migrate())
+		repo := sqlite.NewSBIRepository(db)
+		ctx := context.Background()
+
+		backlog, err := sbi.NewSBI("Backlog", "", nil, sbi.SBIMetadata{RegisteredAt: start})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, backlog))
+
+		service := NewSBIExecutionService(repo, newMockLockService())
+		if aging != nil {
+			service.SetPriorityAging(*aging)
+		}
+		for hour := 1; hour <= hours; hour++ {
+			now := start.Add(time.Duration(hour) * time.Hour)
+			service.now = func() time.Time { return now }
+			urgent, err := sbi.NewSBI(fmt.Sprintf("Urgent %d", hour), "", nil, sbi.SBIMetadata{Priority: 2, RegisteredAt: now})
+			require.NoError(t, err)
+			require.NoError(t, repo.Save(ctx, urgent))
+
+			picked, err := service.PickNextSBI(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, picked)
+			if picked.ID() == backlog.ID() {
+				return hour
+			}
+			require.NoError(t, repo.Delete(ctx, repository.SBIID(picked.ID().String())))
+		}
+		return 0
+	}
+
+	assert.Zero(t, simulate(nil), "without aging the backlog SBI starves")
+	assert.Equal(t, 48, simulate(&sbi.PriorityAging{Interval: 24 * time.Hour, MaxPriority: 2}),
+		"the backlog SBI is picked once it has waited two intervals")
+}
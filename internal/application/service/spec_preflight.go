@@ -0,0 +1,103 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// Spec pre-flight modes
+const (
+	SpecPreflightOff   = "off"
+	SpecPreflightWarn  = "warn"
+	SpecPreflightBlock = "block"
+)
+
+// NoOpReasonSpecQualityWarnings is reported when a turn is held back by unresolved spec warnings
+const NoOpReasonSpecQualityWarnings = "spec_quality_warnings"
+
+// SpecPreflight checks the quality of an SBI spec before its first implement turn
+// In block mode the SBI does not start until every warning is resolved; checks listed
+// in ignore are never reported.
+type SpecPreflight struct {
+	mode     string
+	ignore   map[string]bool
+	specsSBI string // .deespec/specs/sbi
+	specsPBI string // .deespec/specs/pbi
+}
+
+// NewSpecPreflight creates a spec pre-flight check; an unknown mode falls back to warn
+func NewSpecPreflight(mode string, ignore []string, specsSBI, specsPBI string) *SpecPreflight {
+	switch mode {
+	case SpecPreflightOff, SpecPreflightWarn, SpecPreflightBlock:
+	default:
+		mode = SpecPreflightWarn
+	}
+	ignored := make(map[string]bool)
+	for _, check := range ignore {
+		ignored[strings.TrimSpace(check)] = true
+	}
+	return &SpecPreflight{
+		mode:     mode,
+		ignore:   ignored,
+		specsSBI: specsSBI,
+		specsPBI: specsPBI,
+	}
+}
+
+// Mode returns the pre-flight mode
+func (p *SpecPreflight) Mode() string {
+	return p.mode
+}
+
+// Blocks reports whether warnings hold the SBI back
+func (p *SpecPreflight) Blocks() bool {
+	return p.mode == SpecPreflightBlock
+}
+
+// Holds reports whether the SBI may not start because of unresolved warnings
+func (p *SpecPreflight) Holds(s *sbi.SBI) bool {
+	return p.Blocks() && len(p.Check(s)) > 0
+}
+
+// Check returns the warnings of an SBI's spec (.deespec/specs/sbi/<id>/spec.md, or its
+// description when the file is missing). Acceptance criteria of the parent PBI count
+// for the SBI, as they are part of its checklist.
+func (p *SpecPreflight) Check(s *sbi.SBI) []domainservice.SpecQualityWarning {
+	if p.mode == SpecPreflightOff {
+		return nil
+	}
+
+	content := s.Description()
+	if data, err := os.ReadFile(filepath.Join(p.specsSBI, s.ID().String(), "spec.md")); err == nil {
+		content = string(data)
+	}
+
+	var warnings []domainservice.SpecQualityWarning
+	for _, warning := range domainservice.CheckSpecQuality(content, s.Metadata().FilePaths) {
+		if p.ignore[warning.Check] {
+			continue
+		}
+		if warning.Check == domainservice.SpecCheckMissingAcceptanceCriteria && p.parentHasCriteria(s) {
+			continue
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// parentHasCriteria reports whether the parent PBI body lists acceptance criteria
+func (p *SpecPreflight) parentHasCriteria(s *sbi.SBI) bool {
+	parentID := s.ParentTaskID()
+	if parentID == nil {
+		return false
+	}
+	body, err := os.ReadFile(filepath.Join(p.specsPBI, parentID.String(), "pbi.md"))
+	if err != nil {
+		return false
+	}
+	return len(domainservice.ExtractAcceptanceCriteria(string(body))) > 0
+}
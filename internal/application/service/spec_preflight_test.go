@@ -0,0 +1,63 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+func specChecks(warnings []domainservice.SpecQualityWarning) []string {
+	checks := []string{}
+	for _, w := range warnings {
+		checks = append(checks, w.Check)
+	}
+	return checks
+}
+
+func TestSpecPreflight_Check(t *testing.T) {
+	specsSBI := t.TempDir()
+	specsPBI := t.TempDir()
+
+	pbiID, err := model.NewTaskIDFromString("PBI-001")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(specsPBI, "PBI-001"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(specsPBI, "PBI-001", "pbi.md"),
+		[]byte("## Acceptance Criteria\n- Users can log in\n"), 0644))
+
+	// The description is used while spec.md is missing
+	s, err := sbi.NewSBI("Login", "Handle errors appropriately", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	preflight := NewSpecPreflight(SpecPreflightBlock, nil, specsSBI, specsPBI)
+	assert.True(t, preflight.Blocks())
+	assert.Equal(t, []string{
+		domainservice.SpecCheckMissingAcceptanceCriteria,
+		domainservice.SpecCheckAmbiguousRequirement,
+		domainservice.SpecCheckNoFileHints,
+	}, specChecks(preflight.Check(s)))
+
+	// spec.md takes precedence over the description
+	require.NoError(t, os.MkdirAll(filepath.Join(specsSBI, s.ID().String()), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(specsSBI, s.ID().String(), "spec.md"),
+		[]byte("Update internal/app/login.go.\n"), 0644))
+	assert.Equal(t, []string{domainservice.SpecCheckMissingAcceptanceCriteria}, specChecks(preflight.Check(s)))
+
+	// Criteria of the parent PBI count for the SBI
+	child, err := sbi.NewSBI("Login", "Update internal/app/login.go.", &pbiID, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	assert.Empty(t, preflight.Check(child))
+
+	// Ignored checks and the off mode
+	ignoring := NewSpecPreflight("warn", []string{domainservice.SpecCheckMissingAcceptanceCriteria}, specsSBI, specsPBI)
+	assert.False(t, ignoring.Blocks())
+	assert.Empty(t, ignoring.Check(s))
+	assert.Empty(t, NewSpecPreflight(SpecPreflightOff, nil, specsSBI, specsPBI).Check(s))
+
+	assert.Equal(t, SpecPreflightWarn, NewSpecPreflight("strict", nil, specsSBI, specsPBI).Mode())
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// DefaultStaleRetries is how many times RetryOnStale runs an operation that keeps losing to concurrent saves
+const DefaultStaleRetries = 3
+
+// RetryOnStale runs op, a load-modify-save of one entity, again while its save fails with
+// repository.ErrStaleEntity because the entity was saved by someone else in the meantime
+// op must load the entity itself, so that each run applies its change to the stored state and
+// re-checks whether the change still applies. Other errors are returned at once; after attempts
+// runs the last ErrStaleEntity is returned.
+func RetryOnStale(ctx context.Context, attempts int, op func(ctx context.Context) error) error {
+	if attempts <= 0 {
+		attempts = DefaultStaleRetries
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = op(ctx); !errors.Is(err, repository.ErrStaleEntity) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
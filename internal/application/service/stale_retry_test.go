@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestRetryOnStale(t *testing.T) {
+	ctx := context.Background()
+	stale := fmt.Errorf("save SBI x: %w", repository.ErrStaleEntity)
+
+	t.Run("retries until the save succeeds", func(t *testing.T) {
+		runs := 0
+		err := RetryOnStale(ctx, 3, func(ctx context.Context) error {
+			runs++
+			if runs < 3 {
+				return stale
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, runs)
+	})
+
+	t.Run("gives up after the attempts", func(t *testing.T) {
+		runs := 0
+		err := RetryOnStale(ctx, 2, func(ctx context.Context) error {
+			runs++
+			return stale
+		})
+		assert.ErrorIs(t, err, repository.ErrStaleEntity)
+		assert.Equal(t, 2, runs)
+	})
+
+	t.Run("returns other errors at once", func(t *testing.T) {
+		runs := 0
+		failed := errors.New("invalid status")
+		err := RetryOnStale(ctx, 0, func(ctx context.Context) error {
+			runs++
+			return failed
+		})
+		assert.Equal(t, failed, err)
+		assert.Equal(t, 1, runs)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		runs := 0
+		err := RetryOnStale(cancelled, 3, func(ctx context.Context) error {
+			runs++
+			return stale
+		})
+		assert.ErrorIs(t, err, repository.ErrStaleEntity)
+		assert.Equal(t, 1, runs)
+	})
+}
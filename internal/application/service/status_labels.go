@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// knownStatuses are the domain statuses an SBI can be in, in workflow order
+var knownStatuses = []model.Status{
+	model.StatusPending, model.StatusPicked, model.StatusImplementing, model.StatusReviewing,
+	model.StatusDone, model.StatusFailed, model.StatusCancelled,
+}
+
+// WorkflowStatusLabel returns the workflow-facing label of a domain status, as recorded in
+// run output and the journal. PICKED and IMPLEMENTING are both "WIP".
+func WorkflowStatusLabel(status model.Status) string {
+	switch status {
+	case model.StatusPending:
+		return "READY"
+	case model.StatusPicked, model.StatusImplementing:
+		return "WIP"
+	case model.StatusReviewing:
+		return "REVIEW"
+	default:
+		return string(status)
+	}
+}
+
+// StatusLabels maps domain statuses to the labels shown by list and show commands
+// Labels default to the workflow labels and can be renamed per status ("status_labels" in
+// setting.json); the domain status itself is never renamed.
+type StatusLabels struct {
+	labels map[model.Status]string
+}
+
+// NewStatusLabels creates status labels with overrides keyed by domain status name
+// (case-insensitive, e.g. {"picked": "Queued"}); unknown statuses and empty labels are errors
+func NewStatusLabels(overrides map[string]string) (*StatusLabels, error) {
+	labels := make(map[model.Status]string, len(knownStatuses))
+	for _, status := range knownStatuses {
+		labels[status] = WorkflowStatusLabel(status)
+	}
+	for name, label := range overrides {
+		status := model.Status(strings.ToUpper(strings.TrimSpace(name)))
+		if !status.IsValid() {
+			return nil, fmt.Errorf("unknown status %q (statuses: %s)", name, statusNames())
+		}
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, fmt.Errorf("label of status %s is empty", status)
+		}
+		labels[status] = label
+	}
+	return &StatusLabels{labels: labels}, nil
+}
+
+// Label returns the label of a status (the status itself when it has none)
+func (l *StatusLabels) Label(status model.Status) string {
+	if label, ok := l.labels[status]; ok {
+		return label
+	}
+	return string(status)
+}
+
+// Display returns the label followed by the domain status, e.g. "WIP (PICKED)",
+// or just the status when the label is the status itself
+func (l *StatusLabels) Display(status model.Status) string {
+	label := l.Label(status)
+	if label == string(status) {
+		return label
+	}
+	return fmt.Sprintf("%s (%s)", label, status)
+}
+
+// Statuses returns the statuses a name refers to: a domain status or a label, case-insensitive
+// (e.g. "wip" is PICKED and IMPLEMENTING unless renamed)
+func (l *StatusLabels) Statuses(name string) []model.Status {
+	name = strings.TrimSpace(name)
+	var statuses []model.Status
+	for _, status := range knownStatuses {
+		if strings.EqualFold(name, string(status)) || strings.EqualFold(name, l.Label(status)) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// statusNames lists the known statuses for error messages
+func statusNames() string {
+	names := make([]string, len(knownStatuses))
+	for i, status := range knownStatuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ", ")
+}
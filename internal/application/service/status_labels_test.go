@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+func TestStatusLabels(t *testing.T) {
+	labels, err := NewStatusLabels(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "WIP (PICKED)", labels.Display(model.StatusPicked))
+	assert.Equal(t, "WIP (IMPLEMENTING)", labels.Display(model.StatusImplementing))
+	assert.Equal(t, "READY (PENDING)", labels.Display(model.StatusPending))
+	assert.Equal(t, "DONE", labels.Display(model.StatusDone), "a label equal to the status is not repeated")
+
+	labels, err = NewStatusLabels(map[string]string{"picked": " Queued ", "IMPLEMENTING": "Coding"})
+	require.NoError(t, err)
+	assert.Equal(t, "Queued", labels.Label(model.StatusPicked))
+	assert.Equal(t, "Coding (IMPLEMENTING)", labels.Display(model.StatusImplementing))
+	assert.Equal(t, "REVIEW (REVIEWING)", labels.Display(model.StatusReviewing), "other statuses keep the workflow label")
+	assert.Equal(t, "WIP", WorkflowStatusLabel(model.StatusPicked), "overrides never change the workflow label")
+
+	_, err = NewStatusLabels(map[string]string{"BLOCKED": "Blocked"})
+	assert.ErrorContains(t, err, `unknown status "BLOCKED"`)
+	_, err = NewStatusLabels(map[string]string{"DONE": " "})
+	assert.ErrorContains(t, err, "empty")
+}
+
+func TestStatusLabels_Statuses(t *testing.T) {
+	labels, err := NewStatusLabels(map[string]string{"picked": "Queued"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.Status{model.StatusImplementing}, labels.Statuses("wip"), "PICKED was renamed")
+	assert.Equal(t, []model.Status{model.StatusPicked}, labels.Statuses("queued"))
+	assert.Equal(t, []model.Status{model.StatusPicked}, labels.Statuses("Picked"), "domain statuses always match")
+	assert.Equal(t, []model.Status{model.StatusPending}, labels.Statuses("READY"))
+	assert.Empty(t, labels.Statuses("blocked"))
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// StepKind groups the workflow steps that share a worker pool
+type StepKind string
+
+const (
+	// StepKindImplement covers picking and implementing, whose agent calls run long
+	StepKindImplement StepKind = "implement"
+	// StepKindReview covers reviewing, whose agent calls are short
+	StepKindReview StepKind = "review"
+)
+
+// StepKindOf returns the kind of step the next turn of an SBI in the given status runs
+func StepKindOf(status model.Status) StepKind {
+	if status == model.StatusReviewing {
+		return StepKindReview
+	}
+	return StepKindImplement
+}
+
+// StepPool limits concurrent turns per step kind, so that short reviews are not
+// starved behind long implementations under the parallel scheduler
+type StepPool struct {
+	sizes   map[StepKind]int // kind -> max concurrent turns
+	current map[StepKind]int // kind -> running turns
+	mu      sync.Mutex
+}
+
+// NewStepPool creates a pool with the given sizes; a size of 0 uses fallback
+func NewStepPool(implementSize, reviewSize, fallback int) *StepPool {
+	if implementSize < 1 {
+		implementSize = fallback
+	}
+	if reviewSize < 1 {
+		reviewSize = fallback
+	}
+	return &StepPool{
+		sizes: map[StepKind]int{
+			StepKindImplement: implementSize,
+			StepKindReview:    reviewSize,
+		},
+		current: make(map[StepKind]int),
+	}
+}
+
+// TryAcquire takes a slot of the kind's pool; returns false if the pool is full
+func (p *StepPool) TryAcquire(kind StepKind) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current[kind] >= p.sizes[kind] {
+		return false
+	}
+	p.current[kind]++
+	return true
+}
+
+// Release frees a slot of the kind's pool
+func (p *StepPool) Release(kind StepKind) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current[kind] > 0 {
+		p.current[kind]--
+	}
+}
+
+// Size returns the max concurrent turns of a kind
+func (p *StepPool) Size(kind StepKind) int {
+	return p.sizes[kind]
+}
+
+// Capacity returns the max concurrent turns across all kinds
+func (p *StepPool) Capacity() int {
+	return p.sizes[StepKindImplement] + p.sizes[StepKindReview]
+}
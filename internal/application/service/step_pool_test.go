@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+func TestStepKindOf(t *testing.T) {
+	assert.Equal(t, StepKindReview, StepKindOf(model.StatusReviewing))
+	for _, status := range []model.Status{model.StatusPending, model.StatusPicked, model.StatusImplementing} {
+		assert.Equal(t, StepKindImplement, StepKindOf(status), status)
+	}
+}
+
+func TestStepPool(t *testing.T) {
+	pool := NewStepPool(2, 0, 3)
+	assert.Equal(t, 2, pool.Size(StepKindImplement))
+	assert.Equal(t, 3, pool.Size(StepKindReview), "an unset size falls back to --parallel")
+	assert.Equal(t, 5, pool.Capacity())
+
+	assert.True(t, pool.TryAcquire(StepKindImplement))
+	assert.True(t, pool.TryAcquire(StepKindImplement))
+	assert.False(t, pool.TryAcquire(StepKindImplement), "implement pool is full")
+	assert.True(t, pool.TryAcquire(StepKindReview), "reviews have their own slots")
+
+	pool.Release(StepKindImplement)
+	assert.True(t, pool.TryAcquire(StepKindImplement))
+
+	pool.Release(StepKindReview)
+	pool.Release(StepKindReview)
+	assert.True(t, pool.TryAcquire(StepKindReview), "extra releases do not go negative")
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// reservedTaskEnvPrefix is used by variables deespec itself exports to agents (e.g. the lock fence)
+const reservedTaskEnvPrefix = "DEESPEC_"
+
+var (
+	taskEnvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// Task env is stored and displayed in plain text, so names that usually hold credentials are refused
+	secretEnvKeyPattern = regexp.MustCompile(`(?i)(SECRET|PASSWORD|PASSWD|TOKEN|API_?KEY|PRIVATE_?KEY|CREDENTIAL)`)
+)
+
+// ValidateTaskEnvKey checks that a task env name is a valid, non-reserved and non-secret variable name
+func ValidateTaskEnvKey(key string) error {
+	if !taskEnvKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid env name %q (letters, digits and underscores only)", key)
+	}
+	if strings.HasPrefix(strings.ToUpper(key), reservedTaskEnvPrefix) {
+		return fmt.Errorf("env name %q is reserved (%s* is set by deespec)", key, reservedTaskEnvPrefix)
+	}
+	if secretEnvKeyPattern.MatchString(key) {
+		return fmt.Errorf("env name %q looks like a secret; task env is stored in plain text", key)
+	}
+	return nil
+}
+
+// TaskEnvResolver builds the environment exported to agents working on an SBI.
+// Values declared on the parent PBI are overridden by the SBI's own, and ${name}
+// references are expanded from the task_env.vars setting.
+type TaskEnvResolver struct {
+	pbiRepo pbi.Repository // Optional: parent PBI env is only applied when set
+	vars    map[string]string
+}
+
+// NewTaskEnvResolver creates a task env resolver
+func NewTaskEnvResolver(pbiRepo pbi.Repository, vars map[string]string) *TaskEnvResolver {
+	return &TaskEnvResolver{
+		pbiRepo: pbiRepo,
+		vars:    vars,
+	}
+}
+
+// Resolve returns the expanded environment for the SBI (nil if it declares none)
+func (r *TaskEnvResolver) Resolve(s *sbi.SBI) (map[string]string, error) {
+	declared := make(map[string]string)
+	if parentID := s.ParentTaskID(); parentID != nil && r.pbiRepo != nil {
+		parent, err := r.pbiRepo.FindByID(parentID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parent PBI %s: %w", parentID.String(), err)
+		}
+		for key, value := range parent.Env {
+			declared[key] = value
+		}
+	}
+	for key, value := range s.Env() {
+		declared[key] = value
+	}
+	if len(declared) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(declared))
+	for key := range declared {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make(map[string]string, len(declared))
+	for _, key := range keys {
+		if err := ValidateTaskEnvKey(key); err != nil {
+			return nil, err
+		}
+		value, err := r.expand(declared[key])
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", key, err)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// expand replaces ${name} references with task_env.vars values
+func (r *TaskEnvResolver) expand(value string) (string, error) {
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		v, ok := r.vars[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined task_env.vars reference: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
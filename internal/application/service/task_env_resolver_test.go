@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// envPBIRepo serves PBIs by ID for env resolution
+type envPBIRepo struct {
+	pbi.Repository
+	pbis map[string]*pbi.PBI
+}
+
+func (r *envPBIRepo) FindByID(id string) (*pbi.PBI, error) {
+	p, ok := r.pbis[id]
+	if !ok {
+		return nil, fmt.Errorf("PBI not found: %s", id)
+	}
+	return p, nil
+}
+
+func newSBIWithEnv(t *testing.T, parentID string, env map[string]string) *sbi.SBI {
+	t.Helper()
+	var parent *model.TaskID
+	if parentID != "" {
+		id, err := model.NewTaskIDFromString(parentID)
+		require.NoError(t, err)
+		parent = &id
+	}
+	s, err := sbi.NewSBI("Env task", "", parent, sbi.SBIMetadata{Env: env})
+	require.NoError(t, err)
+	return s
+}
+
+func TestTaskEnvResolver_MergesPBIAndSBIEnv(t *testing.T) {
+	repo := &envPBIRepo{pbis: map[string]*pbi.PBI{
+		"PBI-001": {ID: "PBI-001", Env: map[string]string{"TARGET_SERVICE": "checkout", "FEATURE_FLAG": "old_cart"}},
+	}}
+	resolver := NewTaskEnvResolver(repo, map[string]string{"staging_url": "https://staging.example.com"})
+
+	s := newSBIWithEnv(t, "PBI-001", map[string]string{
+		"FEATURE_FLAG": "new_cart",
+		"API_BASE":     "${staging_url}/api",
+	})
+	env, err := resolver.Resolve(s)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"TARGET_SERVICE": "checkout",
+		"FEATURE_FLAG":   "new_cart",
+		"API_BASE":       "https://staging.example.com/api",
+	}, env)
+}
+
+func TestTaskEnvResolver_NoEnv(t *testing.T) {
+	resolver := NewTaskEnvResolver(nil, nil)
+	env, err := resolver.Resolve(newSBIWithEnv(t, "", nil))
+	require.NoError(t, err)
+	assert.Nil(t, env)
+}
+
+func TestTaskEnvResolver_UndefinedReference(t *testing.T) {
+	resolver := NewTaskEnvResolver(nil, map[string]string{})
+	_, err := resolver.Resolve(newSBIWithEnv(t, "", map[string]string{"API_BASE": "${missing}"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+}
+
+func TestValidateTaskEnvKey(t *testing.T) {
+	assert.NoError(t, ValidateTaskEnvKey("TARGET_SERVICE"))
+	assert.NoError(t, ValidateTaskEnvKey("_private_flag"))
+	assert.Error(t, ValidateTaskEnvKey("1ST"))
+	assert.Error(t, ValidateTaskEnvKey("WITH-DASH"))
+	assert.Error(t, ValidateTaskEnvKey("DEESPEC_FENCE_TOKEN"))
+	assert.Error(t, ValidateTaskEnvKey("GITHUB_TOKEN"))
+	assert.Error(t, ValidateTaskEnvKey("db_password"))
+	assert.Error(t, ValidateTaskEnvKey("OPENAI_API_KEY"))
+}
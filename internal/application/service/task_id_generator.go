@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// DefaultIDDigits is how many digits the number of a sequential ID is padded to
+const DefaultIDDigits = 4
+
+// TaskIDGenerator generates human-scannable task IDs from per-prefix sequences:
+// "<PREFIX>-<YEAR>-<NNNN>" with the date_sequence scheme (e.g. SBI-2024-0153) and
+// "<PREFIX>-<NNNN>" with the prefix scheme (e.g. SBI-0153). The prefix is the task type unless
+// configured otherwise. With the ulid scheme IDs are ULIDs.
+type TaskIDGenerator struct {
+	scheme   string
+	prefixes map[model.TaskType]string
+	digits   int
+	seqRepo  repository.IDSequenceRepository
+	now      func() time.Time
+}
+
+// NewTaskIDGenerator creates a task ID generator
+// prefixes maps task types ("sbi", "pbi", "epic", case-insensitive) to their prefix; digits <= 0
+// pads to DefaultIDDigits.
+func NewTaskIDGenerator(scheme string, prefixes map[string]string, digits int, seqRepo repository.IDSequenceRepository) *TaskIDGenerator {
+	if digits <= 0 {
+		digits = DefaultIDDigits
+	}
+	g := &TaskIDGenerator{
+		scheme: scheme,
+		prefixes: map[model.TaskType]string{
+			model.TaskTypeEPIC: string(model.TaskTypeEPIC),
+			model.TaskTypePBI:  string(model.TaskTypePBI),
+			model.TaskTypeSBI:  string(model.TaskTypeSBI),
+		},
+		digits:  digits,
+		seqRepo: seqRepo,
+		now:     time.Now,
+	}
+	for taskType, prefix := range prefixes {
+		if prefix != "" {
+			g.prefixes[model.TaskType(strings.ToUpper(taskType))] = prefix
+		}
+	}
+	return g
+}
+
+// GenerateID returns the ID of a new task of a type
+func (g *TaskIDGenerator) GenerateID(taskType model.TaskType) (string, error) {
+	switch g.scheme {
+	case model.IDSchemeDateSequence:
+		return g.seqRepo.NextID(context.Background(), fmt.Sprintf("%s-%d-", g.prefixes[taskType], g.now().Year()), g.digits)
+	case model.IDSchemePrefix:
+		return g.seqRepo.NextID(context.Background(), g.prefixes[taskType]+"-", g.digits)
+	default:
+		return model.NewTaskID().String(), nil
+	}
+}
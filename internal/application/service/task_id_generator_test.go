@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// fakeIDSequenceRepo counts per prefix
+type fakeIDSequenceRepo struct {
+	last map[string]int
+}
+
+func (r *fakeIDSequenceRepo) NextID(ctx context.Context, prefix string, digits int) (string, error) {
+	r.last[prefix]++
+	return fmt.Sprintf("%s%0*d", prefix, digits, r.last[prefix]), nil
+}
+
+func TestTaskIDGenerator_GenerateID(t *testing.T) {
+	seqRepo := &fakeIDSequenceRepo{last: map[string]int{"SBI-2024-": 152}}
+
+	g := NewTaskIDGenerator(model.IDSchemeDateSequence, nil, 0, seqRepo)
+	g.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+	id, err := g.GenerateID(model.TaskTypeSBI)
+	require.NoError(t, err)
+	assert.Equal(t, "SBI-2024-0153", id)
+	id, err = g.GenerateID(model.TaskTypeEPIC)
+	require.NoError(t, err)
+	assert.Equal(t, "EPIC-2024-0001", id)
+
+	g = NewTaskIDGenerator(model.IDSchemePrefix, map[string]string{"sbi": "TASK", "PBI": "STORY"}, 3, seqRepo)
+	id, err = g.GenerateID(model.TaskTypeSBI)
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-001", id)
+	id, err = g.GenerateID(model.TaskTypePBI)
+	require.NoError(t, err)
+	assert.Equal(t, "STORY-001", id)
+
+	g = NewTaskIDGenerator(model.IDSchemeULID, nil, 0, seqRepo)
+	id, err = g.GenerateID(model.TaskTypeSBI)
+	require.NoError(t, err)
+	assert.Len(t, id, 26)
+}
+
+func TestTaskIDGenerator_UsedByTaskFactories(t *testing.T) {
+	model.SetIDGenerator(NewTaskIDGenerator(model.IDSchemePrefix, map[string]string{"sbi": "TASK"}, 2, &fakeIDSequenceRepo{last: map[string]int{}}))
+	defer model.SetIDGenerator(nil)
+
+	s, err := sbi.NewSBI("Add login", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-01", s.ID().String())
+	e, err := epic.NewEPIC("Checkout", "", epic.EPICMetadata{})
+	require.NoError(t, err)
+	assert.Equal(t, "EPIC-01", e.ID().String())
+
+	model.SetIDGenerator(nil)
+	s, err = sbi.NewSBI("Add logout", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	assert.Len(t, s.ID().String(), 26, "without a generator IDs are ULIDs")
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"time"
+
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+const defaultTemplateVarTimeout = 10 * time.Second
+
+// templateVarCommandRunner runs a shell command in a directory with extra environment
+// variables and returns its standard output
+type templateVarCommandRunner func(ctx context.Context, dir, command string, env []string) ([]byte, error)
+
+// TemplateVarResolver resolves the custom variables prompt templates reference as
+// {{.Custom.Name}}, from static values or shell commands declared in setting.json
+// Commands run in the project root for every prompt, with DEESPEC_SBI_ID, DEESPEC_STEP and
+// DEESPEC_TURN set, so that they can answer for the task at hand.
+type TemplateVarResolver struct {
+	root       string
+	vars       map[string]domainservice.TemplateVar
+	timeout    time.Duration
+	runCommand templateVarCommandRunner
+}
+
+// NewTemplateVarResolver creates a resolver of the given variables for the project at root
+func NewTemplateVarResolver(root string, vars map[string]domainservice.TemplateVar) *TemplateVarResolver {
+	return &TemplateVarResolver{
+		root:       root,
+		vars:       vars,
+		timeout:    defaultTemplateVarTimeout,
+		runCommand: runTemplateVarCommand,
+	}
+}
+
+// SetCommandTimeout sets how long each command may run (values <= 0 keep the default)
+func (r *TemplateVarResolver) SetCommandTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		r.timeout = timeout
+	}
+}
+
+// Resolve returns the sanitized value of every variable for a prompt of the SBI's step
+// A command that fails or times out leaves its variable empty and is reported in the error,
+// so that the prompt can still be built. A nil resolver resolves no variables.
+func (r *TemplateVarResolver) Resolve(ctx context.Context, sbiID, step string, turn int) (map[string]string, error) {
+	values := make(map[string]string)
+	if r == nil {
+		return values, nil
+	}
+
+	names := make([]string, 0, len(r.vars))
+	for name := range r.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	env := []string{"DEESPEC_SBI_ID=" + sbiID, "DEESPEC_STEP=" + step, "DEESPEC_TURN=" + strconv.Itoa(turn)}
+	var errs []error
+	for _, name := range names {
+		v := r.vars[name]
+		if v.Command == "" {
+			values[name] = domainservice.SanitizeTemplateValue(v.Value)
+			continue
+		}
+
+		cmdCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		out, err := r.runCommand(cmdCtx, r.root, v.Command, env)
+		timedOut := errors.Is(cmdCtx.Err(), context.DeadlineExceeded)
+		cancel()
+		switch {
+		case timedOut:
+			errs = append(errs, fmt.Errorf("template variable %s: command timed out after %s", name, r.timeout))
+			values[name] = ""
+		case err != nil:
+			errs = append(errs, fmt.Errorf("template variable %s: %w", name, err))
+			values[name] = ""
+		default:
+			values[name] = domainservice.SanitizeTemplateValue(string(out))
+		}
+	}
+	return values, errors.Join(errs...)
+}
+
+// runTemplateVarCommand runs command with sh in dir and returns its standard output
+func runTemplateVarCommand(ctx context.Context, dir, command string, env []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	// Processes started by the command may keep the output open after sh is killed
+	cmd.WaitDelay = 5 * time.Second
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return out, fmt.Errorf("%w: %s", err, domainservice.SanitizeTemplateValue(string(exitErr.Stderr)))
+	}
+	return out, err
+}
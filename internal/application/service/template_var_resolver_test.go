@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+func TestTemplateVarResolver_Resolve(t *testing.T) {
+	resolver := NewTemplateVarResolver(t.TempDir(), map[string]domainservice.TemplateVar{
+		"ServiceOwner": {Value: "team-payments"},
+		"Task":         {Command: `printf '%s/%s/%s\n' "$DEESPEC_SBI_ID" "$DEESPEC_STEP" "$DEESPEC_TURN"`},
+		"Colored":      {Command: `printf '\033[32m{{.SBIID}}\033[0m'`},
+		"Broken":       {Command: "echo oops >&2; exit 3"},
+	})
+
+	values, err := resolver.Resolve(context.Background(), "SBI-1", "review", 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "template variable Broken")
+	assert.Contains(t, err.Error(), "oops")
+	assert.Equal(t, map[string]string{
+		"ServiceOwner": "team-payments",
+		"Task":         "SBI-1/review/2",
+		"Colored":      "{{.SBIID}}",
+		"Broken":       "",
+	}, values)
+}
+
+func TestTemplateVarResolver_CommandTimeout(t *testing.T) {
+	resolver := NewTemplateVarResolver(t.TempDir(), map[string]domainservice.TemplateVar{"Slow": {Command: "exec sleep 5"}})
+	resolver.SetCommandTimeout(50 * time.Millisecond)
+
+	started := time.Now()
+	values, err := resolver.Resolve(context.Background(), "SBI-1", "implement", 1)
+	assert.ErrorContains(t, err, "timed out")
+	assert.Equal(t, "", values["Slow"])
+	assert.Less(t, time.Since(started), 4*time.Second)
+}
+
+func TestTemplateVarResolver_Nil(t *testing.T) {
+	var resolver *TemplateVarResolver
+	values, err := resolver.Resolve(context.Background(), "SBI-1", "implement", 1)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
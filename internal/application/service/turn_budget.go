@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// NoOp reasons reported when a turn is held back by an exhausted budget or the schedule
+const (
+	NoOpReasonPBITurnBudgetExhausted        = "pbi_turn_budget_exhausted"
+	NoOpReasonDailyAgentCallBudgetExhausted = "daily_agent_call_budget_exhausted"
+	NoOpReasonOutsideExecutionWindow        = "outside_execution_window"
+)
+
+// TurnBudget enforces aggregate budgets on top of the per-SBI turn limit:
+// the total turns spent by the SBIs of one PBI, and the agent calls made
+// project-wide per day (local time). A limit of 0 disables that budget.
+// Agent calls are recorded even when no budget is set so that usage is
+// known as soon as one is configured.
+type TurnBudget struct {
+	maxTurnsPerPBI      int
+	maxAgentCallsPerDay int
+	sbiRepo             repository.SBIRepository
+	callRepo            repository.AgentCallRepository // Optional: daily budget is not enforced without it
+	now                 func() time.Time
+}
+
+// PBITurnUsage is the number of turns spent by the SBIs of one PBI
+type PBITurnUsage struct {
+	PBIID     string `json:"pbi_id"`
+	Turns     int    `json:"turns"`
+	Exhausted bool   `json:"exhausted"`
+}
+
+// TurnBudgetUsage is the current usage of every budget
+type TurnBudgetUsage struct {
+	Day                 string         `json:"day"` // Local date the daily budget applies to
+	AgentCalls          int            `json:"agent_calls"`
+	MaxAgentCallsPerDay int            `json:"max_agent_calls_per_day"` // 0 = unlimited
+	MaxTurnsPerPBI      int            `json:"max_turns_per_pbi"`       // 0 = unlimited
+	PBIs                []PBITurnUsage `json:"pbis"`
+}
+
+// NewTurnBudget creates a turn budget; limits <= 0 disable the corresponding budget
+func NewTurnBudget(maxTurnsPerPBI, maxAgentCallsPerDay int, sbiRepo repository.SBIRepository, callRepo repository.AgentCallRepository) *TurnBudget {
+	if maxTurnsPerPBI < 0 {
+		maxTurnsPerPBI = 0
+	}
+	if maxAgentCallsPerDay < 0 {
+		maxAgentCallsPerDay = 0
+	}
+	return &TurnBudget{
+		maxTurnsPerPBI:      maxTurnsPerPBI,
+		maxAgentCallsPerDay: maxAgentCallsPerDay,
+		sbiRepo:             sbiRepo,
+		callRepo:            callRepo,
+		now:                 time.Now,
+	}
+}
+
+// SetClock overrides the clock used to determine the current day
+func (b *TurnBudget) SetClock(now func() time.Time) {
+	if now != nil {
+		b.now = now
+	}
+}
+
+// Check returns the NoOp reason when the SBI may not start another turn, or "" when it is within budget
+func (b *TurnBudget) Check(ctx context.Context, s *sbi.SBI) (string, error) {
+	if reason, err := b.CheckDaily(ctx); err != nil || reason != "" {
+		return reason, err
+	}
+	return b.CheckPBI(ctx, s)
+}
+
+// CheckDaily returns the NoOp reason when today's agent call budget is exhausted, or ""
+func (b *TurnBudget) CheckDaily(ctx context.Context) (string, error) {
+	if b.maxAgentCallsPerDay == 0 || b.callRepo == nil {
+		return "", nil
+	}
+	calls, err := b.AgentCallsToday(ctx)
+	if err != nil {
+		return "", err
+	}
+	if calls >= b.maxAgentCallsPerDay {
+		return NoOpReasonDailyAgentCallBudgetExhausted, nil
+	}
+	return "", nil
+}
+
+// CheckPBI returns the NoOp reason when the turn budget of the SBI's parent PBI is exhausted, or ""
+// SBIs without a parent PBI are never limited
+func (b *TurnBudget) CheckPBI(ctx context.Context, s *sbi.SBI) (string, error) {
+	pbiID := parentPBIID(s)
+	if b.maxTurnsPerPBI == 0 || pbiID == "" {
+		return "", nil
+	}
+	turns, err := b.PBITurns(ctx, pbiID)
+	if err != nil {
+		return "", err
+	}
+	if turns >= b.maxTurnsPerPBI {
+		return NoOpReasonPBITurnBudgetExhausted, nil
+	}
+	return "", nil
+}
+
+// PBITurns returns the total turns spent by the SBIs of a PBI
+func (b *TurnBudget) PBITurns(ctx context.Context, pbiID string) (int, error) {
+	sbis, err := b.sbiRepo.FindByPBIID(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list SBIs of PBI %s: %w", pbiID, err)
+	}
+	return sumTurns(sbis), nil
+}
+
+// AgentCallsToday returns the number of agent calls made since local midnight
+func (b *TurnBudget) AgentCallsToday(ctx context.Context) (int, error) {
+	if b.callRepo == nil {
+		return 0, nil
+	}
+	return b.callRepo.CountSince(ctx, b.startOfDay())
+}
+
+// RecordAgentCall counts an agent execution against the daily budget
+func (b *TurnBudget) RecordAgentCall(ctx context.Context, call *repository.AgentCall) error {
+	if b.callRepo == nil {
+		return nil
+	}
+	if call.CalledAt.IsZero() {
+		call.CalledAt = b.now()
+	}
+	return b.callRepo.Save(ctx, call)
+}
+
+// Usage returns the current usage of the daily budget and of every PBI that has spent turns
+func (b *TurnBudget) Usage(ctx context.Context) (*TurnBudgetUsage, error) {
+	calls, err := b.AgentCallsToday(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sbis, err := b.sbiRepo.List(ctx, repository.SBIFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SBIs: %w", err)
+	}
+	byPBI := make(map[string][]*sbi.SBI)
+	for _, s := range sbis {
+		if pbiID := parentPBIID(s); pbiID != "" {
+			byPBI[pbiID] = append(byPBI[pbiID], s)
+		}
+	}
+
+	usage := &TurnBudgetUsage{
+		Day:                 b.startOfDay().Format("2006-01-02"),
+		AgentCalls:          calls,
+		MaxAgentCallsPerDay: b.maxAgentCallsPerDay,
+		MaxTurnsPerPBI:      b.maxTurnsPerPBI,
+		PBIs:                []PBITurnUsage{},
+	}
+	for pbiID, children := range byPBI {
+		turns := sumTurns(children)
+		if turns == 0 {
+			continue
+		}
+		usage.PBIs = append(usage.PBIs, PBITurnUsage{
+			PBIID:     pbiID,
+			Turns:     turns,
+			Exhausted: b.maxTurnsPerPBI > 0 && turns >= b.maxTurnsPerPBI,
+		})
+	}
+	sort.Slice(usage.PBIs, func(i, j int) bool { return usage.PBIs[i].PBIID < usage.PBIs[j].PBIID })
+	return usage, nil
+}
+
+// startOfDay returns local midnight of the current day
+func (b *TurnBudget) startOfDay() time.Time {
+	now := b.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// sumTurns returns the total turns spent by the SBIs
+func sumTurns(sbis []*sbi.SBI) int {
+	total := 0
+	for _, s := range sbis {
+		if state := s.ExecutionState(); state != nil {
+			total += state.CurrentTurn.Value()
+		}
+	}
+	return total
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// memoryAgentCallRepo keeps agent calls in memory
+type memoryAgentCallRepo struct {
+	calls []*repository.AgentCall
+}
+
+func (m *memoryAgentCallRepo) Save(ctx context.Context, call *repository.AgentCall) error {
+	m.calls = append(m.calls, call)
+	return nil
+}
+
+func (m *memoryAgentCallRepo) CountSince(ctx context.Context, since time.Time) (int, error) {
+	count := 0
+	for _, call := range m.calls {
+		if !call.CalledAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newSBIWithTurns(t *testing.T, repo *mockSBIRepo, pbiID *model.TaskID, turns int) *sbi.SBI {
+	t.Helper()
+	s := newSBIForPBI(t, "Budget", pbiID)
+	for i := 0; i < turns; i++ {
+		s.IncrementTurn()
+	}
+	repo.sbis[s.ID().String()] = s
+	return s
+}
+
+func TestTurnBudget_PBITurns(t *testing.T) {
+	ctx := context.Background()
+	pbiA := model.NewTaskID()
+	pbiB := model.NewTaskID()
+	repo := newMockSBIRepo()
+
+	a1 := newSBIWithTurns(t, repo, &pbiA, 3)
+	newSBIWithTurns(t, repo, &pbiA, 2)
+	b1 := newSBIWithTurns(t, repo, &pbiB, 1)
+	orphan := newSBIWithTurns(t, repo, nil, 10)
+
+	budget := NewTurnBudget(5, 0, repo, nil)
+
+	reason, err := budget.Check(ctx, a1)
+	require.NoError(t, err)
+	assert.Equal(t, NoOpReasonPBITurnBudgetExhausted, reason)
+
+	reason, err = budget.Check(ctx, b1)
+	require.NoError(t, err)
+	assert.Empty(t, reason, "other PBIs keep their own budget")
+
+	reason, err = budget.Check(ctx, orphan)
+	require.NoError(t, err)
+	assert.Empty(t, reason, "SBIs without a PBI are never limited by the PBI budget")
+
+	usage, err := budget.Usage(ctx)
+	require.NoError(t, err)
+	require.Len(t, usage.PBIs, 2)
+	for _, p := range usage.PBIs {
+		switch p.PBIID {
+		case pbiA.String():
+			assert.Equal(t, 5, p.Turns)
+			assert.True(t, p.Exhausted)
+		case pbiB.String():
+			assert.Equal(t, 1, p.Turns)
+			assert.False(t, p.Exhausted)
+		}
+	}
+}
+
+func TestTurnBudget_DailyAgentCalls(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockSBIRepo()
+	s := newSBIWithTurns(t, repo, nil, 0)
+	calls := &memoryAgentCallRepo{}
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	budget := NewTurnBudget(0, 2, repo, calls)
+	budget.SetClock(func() time.Time { return now })
+
+	// A call made yesterday does not count against today's budget
+	require.NoError(t, budget.RecordAgentCall(ctx, &repository.AgentCall{SBIID: "x", CalledAt: now.AddDate(0, 0, -1)}))
+	require.NoError(t, budget.RecordAgentCall(ctx, &repository.AgentCall{SBIID: "x"}))
+
+	reason, err := budget.Check(ctx, s)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	require.NoError(t, budget.RecordAgentCall(ctx, &repository.AgentCall{SBIID: "x"}))
+	reason, err = budget.Check(ctx, s)
+	require.NoError(t, err)
+	assert.Equal(t, NoOpReasonDailyAgentCallBudgetExhausted, reason)
+
+	// The budget resets at local midnight
+	now = now.AddDate(0, 0, 1)
+	reason, err = budget.Check(ctx, s)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	usage, err := budget.Usage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-11", usage.Day)
+	assert.Equal(t, 0, usage.AgentCalls)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// TurnEventBroker fans turn events out to in-process subscribers (e.g. the serve /events stream)
+// Publishing never blocks: a subscriber whose buffer is full misses the event
+type TurnEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan output.TurnEvent]struct{}
+}
+
+// NewTurnEventBroker creates a broker without subscribers
+func NewTurnEventBroker() *TurnEventBroker {
+	return &TurnEventBroker{subscribers: make(map[chan output.TurnEvent]struct{})}
+}
+
+// Publish sends the event to every subscriber with buffer space
+func (b *TurnEventBroker) Publish(event output.TurnEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a subscriber with the given buffer size
+// The returned function unsubscribes and closes the channel
+func (b *TurnEventBroker) Subscribe(buffer int) (<-chan output.TurnEvent, func()) {
+	ch := make(chan output.TurnEvent, buffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+func TestTurnEventBroker_FanOut(t *testing.T) {
+	broker := NewTurnEventBroker()
+
+	first, unsubscribeFirst := broker.Subscribe(4)
+	second, unsubscribeSecond := broker.Subscribe(1)
+	defer unsubscribeSecond()
+
+	broker.Publish(output.TurnEvent{Type: output.TurnEventPicked, SBIID: "sbi-1"})
+	broker.Publish(output.TurnEvent{Type: output.TurnEventSaved, SBIID: "sbi-1"})
+
+	assert.Equal(t, output.TurnEventPicked, (<-first).Type)
+	assert.Equal(t, output.TurnEventSaved, (<-first).Type)
+
+	// A full subscriber misses events instead of blocking the publisher
+	assert.Equal(t, output.TurnEventPicked, (<-second).Type)
+	assert.Len(t, second, 0)
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, open := <-first
+	assert.False(t, open)
+
+	broker.Publish(output.TurnEvent{Type: output.TurnEventDecision})
+	assert.Equal(t, output.TurnEventDecision, (<-second).Type)
+}
@@ -0,0 +1,437 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	pbiusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// analyzeTimeout bounds the agent's scan of the repository
+const analyzeTimeout = 20 * time.Minute
+
+// AnalyzeOptions configures a codebase analysis
+type AnalyzeOptions struct {
+	MaxEPICs       int  // Upper bound on the proposed EPICs
+	MaxPBIsPerEPIC int  // Upper bound on the PBIs proposed per EPIC (and without an EPIC)
+	PromptOnly     bool // Only build the prompt, without running the agent
+	Force          bool // Replace a proposal that still has undecided or unregistered drafts
+}
+
+// AnalyzeResult is the outcome of a codebase analysis
+type AnalyzeResult struct {
+	Prompt   string
+	Proposal *pbi.BacklogProposal // Nil in prompt-only mode
+}
+
+// RegisteredDraft is a draft registered as an EPIC or PBI
+type RegisteredDraft struct {
+	File   string `json:"file"`
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	EPICID string `json:"epic_id,omitempty"` // EPIC a PBI was attached to
+}
+
+// analysisProposal is the backlog the agent reports as JSON
+type analysisProposal struct {
+	EPICs []analysisEPIC `json:"epics"`
+	PBIs  []analysisPBI  `json:"pbis"` // PBIs that belong to no EPIC
+}
+
+type analysisEPIC struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Category    string        `json:"category"`
+	StoryPoints int           `json:"story_points"`
+	Priority    int           `json:"priority"`
+	PBIs        []analysisPBI `json:"pbis"`
+}
+
+type analysisPBI struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	StoryPoints int      `json:"story_points"`
+	Priority    int      `json:"priority"`
+	Evidence    []string `json:"evidence"` // Files (and lines) that motivated the PBI
+}
+
+// AnalyzeRepositoryUseCase bootstraps the backlog of an existing codebase for `deespec analyze`
+// An agent scans the repository and proposes EPICs and PBIs (architecture areas, missing tests,
+// TODOs found), which are written as drafts pending approval; approved drafts are registered later.
+type AnalyzeRepositoryUseCase struct {
+	agentGateway output.AgentGateway // Optional in prompt-only mode
+	proposalRepo repository.BacklogProposalRepository
+	epicRepo     repository.EPICRepository
+	pbiRepo      pbi.Repository
+	now          func() time.Time
+}
+
+// NewAnalyzeRepositoryUseCase creates a new AnalyzeRepositoryUseCase
+func NewAnalyzeRepositoryUseCase(
+	agentGateway output.AgentGateway,
+	proposalRepo repository.BacklogProposalRepository,
+	epicRepo repository.EPICRepository,
+	pbiRepo pbi.Repository,
+) *AnalyzeRepositoryUseCase {
+	return &AnalyzeRepositoryUseCase{
+		agentGateway: agentGateway,
+		proposalRepo: proposalRepo,
+		epicRepo:     epicRepo,
+		pbiRepo:      pbiRepo,
+		now:          time.Now,
+	}
+}
+
+// Execute asks the agent for a backlog proposal and writes it as pending drafts
+func (uc *AnalyzeRepositoryUseCase) Execute(ctx context.Context, opts AnalyzeOptions) (*AnalyzeResult, error) {
+	if opts.MaxEPICs < 1 || opts.MaxPBIsPerEPIC < 1 {
+		return nil, errors.New("at least one EPIC and one PBI per EPIC must be allowed")
+	}
+	if !opts.PromptOnly && !opts.Force {
+		current, err := uc.proposalRepo.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil && !current.Settled() {
+			return nil, fmt.Errorf("the current proposal still has %d undecided and %d unregistered draft(s); review it first or replace it with --force",
+				current.PendingCount(), len(current.Unregistered()))
+		}
+	}
+
+	prompt, err := uc.buildPrompt(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := &AnalyzeResult{Prompt: prompt}
+	if opts.PromptOnly {
+		return result, nil
+	}
+	if uc.agentGateway == nil {
+		return nil, errors.New("no agent is available")
+	}
+
+	response, err := uc.agentGateway.Execute(ctx, output.AgentRequest{Prompt: prompt, Timeout: analyzeTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("agent failed to analyze the repository: %w", err)
+	}
+	proposed, err := parseAnalysisProposal(response.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, drafts := buildBacklogDrafts(proposed, opts)
+	if len(proposal.Drafts) == 0 {
+		return nil, errors.New("the agent proposed no EPICs or PBIs")
+	}
+	proposal.GeneratedAt = uc.now()
+	if err := uc.proposalRepo.Replace(ctx, proposal, drafts); err != nil {
+		return nil, err
+	}
+	result.Proposal = proposal
+	return result, nil
+}
+
+// Proposal returns the current proposal, or nil when there is none
+func (uc *AnalyzeRepositoryUseCase) Proposal(ctx context.Context) (*pbi.BacklogProposal, error) {
+	return uc.proposalRepo.Load(ctx)
+}
+
+// Review approves or rejects drafts; no files reviews every undecided draft
+// Rejecting an EPIC draft does not reject its PBIs: approved ones are registered without an EPIC.
+func (uc *AnalyzeRepositoryUseCase) Review(ctx context.Context, files []string, status pbi.SBIApprovalStatus, reason string) ([]string, error) {
+	proposal, err := uc.proposalRepo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		return nil, errors.New("there is no backlog proposal; run 'deespec analyze' first")
+	}
+	if len(files) == 0 {
+		for _, draft := range proposal.Drafts {
+			if draft.Status == pbi.ApprovalStatusPending {
+				files = append(files, draft.File)
+			}
+		}
+	}
+	now := uc.now()
+	for _, file := range files {
+		if err := proposal.Review(file, status, reason, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.proposalRepo.Save(ctx, proposal); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Register creates the EPICs and PBIs of the approved drafts not registered yet
+// Titles and bodies are read from the draft files, so drafts edited before approval are
+// registered as edited. The manifest is saved after each draft, so a failure can be resumed.
+func (uc *AnalyzeRepositoryUseCase) Register(ctx context.Context) ([]RegisteredDraft, error) {
+	proposal, err := uc.proposalRepo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if proposal == nil {
+		return nil, errors.New("there is no backlog proposal; run 'deespec analyze' first")
+	}
+
+	registered := []RegisteredDraft{}
+	for _, draft := range proposal.Unregistered() {
+		content, err := uc.proposalRepo.ReadDraft(ctx, draft.File)
+		if err != nil {
+			return registered, err
+		}
+		title, body := splitDraft(content)
+		if title == "" {
+			title = draft.Title
+		}
+
+		entry := RegisteredDraft{File: draft.File, Kind: draft.Kind, Title: title}
+		switch draft.Kind {
+		case pbi.DraftKindEPIC:
+			entry.ID, err = uc.registerEPIC(ctx, draft, title, body)
+		case pbi.DraftKindPBI:
+			entry.ID, entry.EPICID, err = uc.registerPBI(ctx, proposal, draft, title, content)
+		default:
+			err = fmt.Errorf("unknown kind %q", draft.Kind)
+		}
+		if err != nil {
+			return registered, fmt.Errorf("failed to register draft %s: %w", draft.File, err)
+		}
+
+		draft.RegisteredID = entry.ID
+		if err := uc.proposalRepo.Save(ctx, proposal); err != nil {
+			return registered, err
+		}
+		registered = append(registered, entry)
+	}
+	return registered, nil
+}
+
+// registerEPIC creates the EPIC of a draft
+func (uc *AnalyzeRepositoryUseCase) registerEPIC(ctx context.Context, draft *pbi.BacklogDraft, title, description string) (string, error) {
+	e, err := epic.NewEPIC(title, description, epic.EPICMetadata{
+		EstimatedStoryPoints: draft.EstimatedStoryPoints,
+		Priority:             draft.Priority,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := uc.epicRepo.Save(ctx, e); err != nil {
+		return "", err
+	}
+	return e.ID().String(), nil
+}
+
+// registerPBI creates the PBI of a draft, attached to the EPIC of its parent draft when that was registered
+func (uc *AnalyzeRepositoryUseCase) registerPBI(ctx context.Context, proposal *pbi.BacklogProposal, draft *pbi.BacklogDraft, title, body string) (string, string, error) {
+	p := pbi.NewPBI(title)
+	p.EstimatedStoryPoints = draft.EstimatedStoryPoints
+	p.Priority = pbi.Priority(draft.Priority)
+
+	var parent *epic.EPIC
+	if draft.Parent != "" {
+		if parentDraft := proposal.Find(draft.Parent); parentDraft != nil && parentDraft.RegisteredID != "" {
+			e, err := uc.epicRepo.Find(ctx, repository.EPICID(parentDraft.RegisteredID))
+			if err != nil {
+				return "", "", fmt.Errorf("failed to find EPIC %s: %w", parentDraft.RegisteredID, err)
+			}
+			parent = e
+			p.ParentEpicID = parentDraft.RegisteredID
+		}
+	}
+
+	id, err := pbiusecase.NewRegisterPBIUseCase(uc.pbiRepo).Execute(p, body)
+	if err != nil {
+		return "", "", err
+	}
+	if parent == nil {
+		return id, "", nil
+	}
+	taskID, err := model.NewTaskIDFromString(id)
+	if err != nil {
+		return "", "", err
+	}
+	if err := parent.AddPBI(taskID); err != nil {
+		return "", "", err
+	}
+	if err := uc.epicRepo.Save(ctx, parent); err != nil {
+		return "", "", fmt.Errorf("failed to attach PBI %s to EPIC %s: %w", id, p.ParentEpicID, err)
+	}
+	return id, p.ParentEpicID, nil
+}
+
+// buildPrompt builds the analysis prompt, listing the existing backlog so that it is not proposed again
+func (uc *AnalyzeRepositoryUseCase) buildPrompt(ctx context.Context, opts AnalyzeOptions) (string, error) {
+	var existing []string
+	if uc.epicRepo != nil {
+		epics, err := uc.epicRepo.List(ctx, repository.EPICFilter{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list EPICs: %w", err)
+		}
+		for _, e := range epics {
+			existing = append(existing, fmt.Sprintf("- EPIC %s: %s", e.ID(), e.Title()))
+		}
+	}
+	if uc.pbiRepo != nil {
+		pbis, err := uc.pbiRepo.FindAll()
+		if err != nil {
+			return "", fmt.Errorf("failed to list PBIs: %w", err)
+		}
+		for _, p := range pbis {
+			existing = append(existing, fmt.Sprintf("- PBI %s: %s", p.ID, p.Title))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`# Codebase analysis
+
+Scan this repository and propose an initial backlog of EPICs and PBIs for adopting
+deespec on it. Do not modify any file.
+
+Look for:
+- architecture areas: the main components, their boundaries and the areas that
+  need consolidation, restructuring or documentation (category "architecture")
+- missing tests: packages and features with no or weak tests (category "tests")
+- TODO, FIXME and HACK comments worth turning into work (category "todo")
+
+`)
+	fmt.Fprintf(&sb, "Propose at most %d EPICs and at most %d PBIs per EPIC. Group related PBIs under\n", opts.MaxEPICs, opts.MaxPBIsPerEPIC)
+	fmt.Fprintf(&sb, "an EPIC; list PBIs that fit no EPIC (at most %d) under \"pbis\". Each PBI must be a\n", opts.MaxPBIsPerEPIC)
+	sb.WriteString(`self-contained increment with a description of the goal and its acceptance
+criteria, and cite the files (path or path:line) that motivated it as evidence.
+Story points are 0-13; priority is 0 (normal), 1 (high) or 2 (urgent).
+`)
+	if len(existing) > 0 {
+		sb.WriteString("\nThe backlog already contains the following; do not propose them again:\n")
+		sb.WriteString(strings.Join(existing, "\n"))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(`
+Answer with a single JSON code block of this shape, after any explanation:
+
+` + "```json" + `
+{
+  "epics": [
+    {
+      "title": "…",
+      "description": "…",
+      "category": "architecture",
+      "story_points": 13,
+      "priority": 1,
+      "pbis": [
+        {"title": "…", "description": "…", "category": "tests", "story_points": 3, "priority": 0, "evidence": ["internal/foo/bar.go"]}
+      ]
+    }
+  ],
+  "pbis": []
+}
+` + "```\n")
+	return sb.String(), nil
+}
+
+// parseAnalysisProposal extracts the JSON proposal from the agent's answer: the last
+// ```json code block, or the whole answer when it is bare JSON
+func parseAnalysisProposal(answer string) (*analysisProposal, error) {
+	text := strings.TrimSpace(answer)
+	if start := strings.LastIndex(text, "```json"); start >= 0 {
+		text = text[start+len("```json"):]
+		if end := strings.Index(text, "```"); end >= 0 {
+			text = text[:end]
+		}
+	}
+	var proposal analysisProposal
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &proposal); err != nil {
+		return nil, fmt.Errorf("the agent's answer has no valid JSON proposal: %w", err)
+	}
+	return &proposal, nil
+}
+
+// buildBacklogDrafts turns the agent's proposal into pending drafts and their Markdown files
+// Entries without a title are dropped and the limits of opts are enforced.
+func buildBacklogDrafts(proposed *analysisProposal, opts AnalyzeOptions) (*pbi.BacklogProposal, map[string]string) {
+	var drafts []pbi.BacklogDraft
+	files := make(map[string]string)
+	addPBIs := func(prefix, parent string, pbis []analysisPBI) {
+		n := 0
+		for _, p := range pbis {
+			if strings.TrimSpace(p.Title) == "" || n == opts.MaxPBIsPerEPIC {
+				continue
+			}
+			n++
+			file := fmt.Sprintf("%spbi-%02d.md", prefix, n)
+			drafts = append(drafts, newBacklogDraft(file, pbi.DraftKindPBI, parent, p.Title, p.Category, p.StoryPoints, p.Priority))
+			files[file] = renderDraft(p.Title, p.Description, p.Evidence)
+		}
+	}
+
+	n := 0
+	for _, e := range proposed.EPICs {
+		if strings.TrimSpace(e.Title) == "" || n == opts.MaxEPICs {
+			continue
+		}
+		n++
+		file := fmt.Sprintf("epic-%02d.md", n)
+		drafts = append(drafts, newBacklogDraft(file, pbi.DraftKindEPIC, "", e.Title, e.Category, e.StoryPoints, e.Priority))
+		files[file] = renderDraft(e.Title, e.Description, nil)
+		addPBIs(fmt.Sprintf("epic-%02d-", n), file, e.PBIs)
+	}
+	addPBIs("", "", proposed.PBIs)
+	return pbi.NewBacklogProposal(drafts), files
+}
+
+// newBacklogDraft creates a draft record, clamping the values the agent may get wrong
+func newBacklogDraft(file, kind, parent, title, category string, storyPoints, priority int) pbi.BacklogDraft {
+	switch category {
+	case pbi.DraftCategoryArchitecture, pbi.DraftCategoryTests, pbi.DraftCategoryTODO:
+	default:
+		category = ""
+	}
+	return pbi.BacklogDraft{
+		File:                 file,
+		Kind:                 kind,
+		Parent:               parent,
+		Title:                strings.TrimSpace(title),
+		Category:             category,
+		EstimatedStoryPoints: min(max(storyPoints, 0), 13),
+		Priority:             min(max(priority, int(pbi.PriorityNormal)), int(pbi.PriorityUrgent)),
+	}
+}
+
+// renderDraft renders the Markdown file of a draft
+func renderDraft(title, description string, evidence []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", strings.TrimSpace(title))
+	if description = strings.TrimSpace(description); description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", description)
+	}
+	if len(evidence) > 0 {
+		sb.WriteString("\n## Evidence\n\n")
+		for _, e := range evidence {
+			fmt.Fprintf(&sb, "- %s\n", e)
+		}
+	}
+	return sb.String()
+}
+
+// splitDraft splits a draft file into its H1 title and the rest
+func splitDraft(content string) (title, body string) {
+	first, rest, _ := strings.Cut(strings.TrimLeft(content, "\n"), "\n")
+	if heading, ok := strings.CutPrefix(first, "# "); ok {
+		return strings.TrimSpace(heading), strings.TrimSpace(rest)
+	}
+	return "", strings.TrimSpace(content)
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+type stubAgentGateway struct {
+	output.AgentGateway
+	answer  string
+	prompts []string
+}
+
+func (s *stubAgentGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	s.prompts = append(s.prompts, req.Prompt)
+	return &output.AgentResponse{Output: s.answer}, nil
+}
+
+type stubProposalRepository struct {
+	proposal *pbi.BacklogProposal
+	drafts   map[string]string
+}
+
+func (s *stubProposalRepository) Load(ctx context.Context) (*pbi.BacklogProposal, error) {
+	return s.proposal, nil
+}
+
+func (s *stubProposalRepository) Save(ctx context.Context, proposal *pbi.BacklogProposal) error {
+	s.proposal = proposal
+	return nil
+}
+
+func (s *stubProposalRepository) Replace(ctx context.Context, proposal *pbi.BacklogProposal, drafts map[string]string) error {
+	s.proposal, s.drafts = proposal, drafts
+	return nil
+}
+
+func (s *stubProposalRepository) ReadDraft(ctx context.Context, file string) (string, error) {
+	return s.drafts[file], nil
+}
+
+type stubEPICRepository struct {
+	repository.EPICRepository
+	epics map[string]*epic.EPIC
+}
+
+func (s *stubEPICRepository) Save(ctx context.Context, e *epic.EPIC) error {
+	s.epics[e.ID().String()] = e
+	return nil
+}
+
+func (s *stubEPICRepository) Find(ctx context.Context, id repository.EPICID) (*epic.EPIC, error) {
+	return s.epics[string(id)], nil
+}
+
+func (s *stubEPICRepository) List(ctx context.Context, filter repository.EPICFilter) ([]*epic.EPIC, error) {
+	var epics []*epic.EPIC
+	for _, e := range s.epics {
+		epics = append(epics, e)
+	}
+	return epics, nil
+}
+
+func (s *stubPBIRepository) Exists(id string) (bool, error) {
+	p, _ := s.FindByID(id)
+	return p != nil, nil
+}
+
+func (s *stubPBIRepository) Save(p *pbi.PBI, body string) error {
+	s.pbis = append(s.pbis, p)
+	return nil
+}
+
+const analysisAnswer = "I scanned the repository.\n\n```json\n" + `{
+  "epics": [
+    {
+      "title": "Persistence layer",
+      "description": "Consolidate the SQLite repositories.",
+      "category": "architecture",
+      "story_points": 40,
+      "priority": 1,
+      "pbis": [
+        {"title": "Test the SBI repository", "description": "No tests cover it.", "category": "tests", "story_points": 3, "evidence": ["internal/sbi_repository.go"]},
+        {"title": "Test the PBI repository", "category": "tests"},
+        {"title": "One too many"}
+      ]
+    },
+    {"title": "", "description": "Untitled entries are dropped"}
+  ],
+  "pbis": [
+    {"title": "Resolve the TODOs of the CLI", "category": "todo", "priority": 5}
+  ]
+}` + "\n```\n"
+
+func TestAnalyzeRepositoryUseCase(t *testing.T) {
+	ctx := context.Background()
+	agent := &stubAgentGateway{answer: analysisAnswer}
+	proposals := &stubProposalRepository{}
+	epicRepo := &stubEPICRepository{epics: map[string]*epic.EPIC{}}
+	pbiRepo := &stubPBIRepository{pbis: []*pbi.PBI{{ID: "PBI-001", Title: "Existing work"}}}
+	uc := NewAnalyzeRepositoryUseCase(agent, proposals, epicRepo, pbiRepo)
+	opts := AnalyzeOptions{MaxEPICs: 5, MaxPBIsPerEPIC: 2}
+
+	result, err := uc.Execute(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, agent.prompts, 1)
+	assert.Contains(t, agent.prompts[0], "- PBI PBI-001: Existing work", "the existing backlog is not proposed again")
+
+	var files []string
+	for _, draft := range result.Proposal.Drafts {
+		files = append(files, draft.File)
+		assert.Equal(t, pbi.ApprovalStatusPending, draft.Status)
+	}
+	assert.Equal(t, []string{"epic-01.md", "epic-01-pbi-01.md", "epic-01-pbi-02.md", "pbi-01.md"}, files)
+	epicDraft := result.Proposal.Find("epic-01.md")
+	assert.Equal(t, 13, epicDraft.EstimatedStoryPoints, "story points are clamped")
+	assert.Equal(t, 2, result.Proposal.Find("pbi-01.md").Priority, "priority is clamped")
+	assert.Equal(t, "epic-01.md", result.Proposal.Find("epic-01-pbi-01.md").Parent)
+	assert.Equal(t, "# Test the SBI repository\n\nNo tests cover it.\n\n## Evidence\n\n- internal/sbi_repository.go\n",
+		proposals.drafts["epic-01-pbi-01.md"])
+
+	// An undecided proposal is not replaced without --force
+	_, err = uc.Execute(ctx, opts)
+	assert.Error(t, err)
+
+	_, err = uc.Review(ctx, []string{"pbi-01.md"}, pbi.ApprovalStatusRejected, "not now")
+	require.NoError(t, err)
+	proposals.drafts["epic-01-pbi-02.md"] = "# Test the PBI and EPIC repositories\n\nEdited before approval.\n"
+	reviewed, err := uc.Review(ctx, nil, pbi.ApprovalStatusApproved, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"epic-01.md", "epic-01-pbi-01.md", "epic-01-pbi-02.md"}, reviewed)
+
+	registered, err := uc.Register(ctx)
+	require.NoError(t, err)
+	require.Len(t, registered, 3)
+	epicID := registered[0].ID
+	assert.Equal(t, "Persistence layer", epicRepo.epics[epicID].Title())
+	assert.Equal(t, epicID, registered[1].EPICID)
+	assert.Equal(t, "Test the PBI and EPIC repositories", registered[2].Title, "edited drafts are registered as edited")
+	assert.Len(t, epicRepo.epics[epicID].PBIIDs(), 2)
+	for _, p := range pbiRepo.pbis[1:] {
+		assert.Equal(t, epicID, p.ParentEpicID)
+	}
+
+	// Registering again creates nothing, and the settled proposal may be replaced
+	registered, err = uc.Register(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, registered)
+	assert.True(t, proposals.proposal.Settled())
+	_, err = uc.Execute(ctx, opts)
+	assert.NoError(t, err)
+}
+
+func TestAnalyzeRepositoryUseCase_PromptOnly(t *testing.T) {
+	uc := NewAnalyzeRepositoryUseCase(nil, &stubProposalRepository{}, nil, nil)
+	result, err := uc.Execute(context.Background(), AnalyzeOptions{MaxEPICs: 3, MaxPBIsPerEPIC: 4, PromptOnly: true})
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "at most 3 EPICs and at most 4 PBIs per EPIC")
+	assert.Nil(t, result.Proposal)
+}
+
+func TestParseAnalysisProposal(t *testing.T) {
+	proposal, err := parseAnalysisProposal(`{"pbis": [{"title": "Bare JSON"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Bare JSON", proposal.PBIs[0].Title)
+
+	_, err = parseAnalysisProposal("I could not finish the scan.")
+	assert.Error(t, err)
+}
@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// sbiArchiveDir is the archive store relative to the .deespec directory
+const sbiArchiveDir = "archive/sbi"
+
+// ArchiveSBIOptions selects the SBIs to archive
+type ArchiveSBIOptions struct {
+	Before   time.Time      // Archive SBIs completed (or last updated when never completed) before this time
+	Statuses []model.Status // Terminal statuses to archive (default: DONE and FAILED)
+	DryRun   bool           // If true, only report what would be archived
+}
+
+// ArchivedSBI is one SBI moved into the archive store
+type ArchivedSBI struct {
+	SBIID       string `json:"sbi_id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	ArchivePath string `json:"archive_path"` // Relative to the .deespec directory
+}
+
+// SkippedSBI is an SBI that matched the options but was left in place
+type SkippedSBI struct {
+	SBIID  string `json:"sbi_id"`
+	Reason string `json:"reason"`
+}
+
+// ArchiveSBIResult reports what an archive run did
+type ArchiveSBIResult struct {
+	Archived []ArchivedSBI `json:"archived"`
+	Skipped  []SkippedSBI  `json:"skipped"`
+	DryRun   bool          `json:"dry_run"`
+}
+
+// ArchiveSBIUseCase moves finished SBIs out of the working set into an archive store
+// Each SBI is bundled with SnapshotSBIUseCase (database rows, journal records, spec and
+// report directories) into archive/sbi/<id>.tar.gz, then removed from the database and
+// the working tree. A tombstone keeps the SBI's ID and sequence reserved and makes the
+// archive searchable and restorable.
+type ArchiveSBIUseCase struct {
+	sbiRepo       repository.SBIRepository
+	tombstoneRepo repository.SBITombstoneRepository
+	snapshot      *SnapshotSBIUseCase
+	homeDir       string // .deespec directory holding specs/, reports/ and archive/
+}
+
+// NewArchiveSBIUseCase creates a new ArchiveSBIUseCase
+func NewArchiveSBIUseCase(
+	sbiRepo repository.SBIRepository,
+	tombstoneRepo repository.SBITombstoneRepository,
+	snapshotRepo repository.SBISnapshotRepository,
+	journalRepo repository.JournalRepository,
+	homeDir string,
+) *ArchiveSBIUseCase {
+	return &ArchiveSBIUseCase{
+		sbiRepo:       sbiRepo,
+		tombstoneRepo: tombstoneRepo,
+		snapshot:      NewSnapshotSBIUseCase(snapshotRepo, journalRepo, homeDir),
+		homeDir:       homeDir,
+	}
+}
+
+// Archive moves the SBIs selected by opts into the archive store
+// SBIs that unfinished SBIs still depend on are skipped: removing them would drop the dependency
+func (uc *ArchiveSBIUseCase) Archive(ctx context.Context, opts ArchiveSBIOptions) (*ArchiveSBIResult, error) {
+	statuses := opts.Statuses
+	if len(statuses) == 0 {
+		statuses = []model.Status{model.StatusDone, model.StatusFailed}
+	}
+	for _, status := range statuses {
+		if !isArchivableStatus(status) {
+			return nil, fmt.Errorf("cannot archive SBIs in status %s (only DONE, FAILED and CANCELLED)", status)
+		}
+	}
+
+	sbis, err := uc.sbiRepo.List(ctx, repository.SBIFilter{Statuses: statuses})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SBIs: %w", err)
+	}
+
+	result := &ArchiveSBIResult{Archived: []ArchivedSBI{}, Skipped: []SkippedSBI{}, DryRun: opts.DryRun}
+	for _, s := range sbis {
+		if !opts.Before.IsZero() && !finishedAt(s).Before(opts.Before) {
+			continue
+		}
+		sbiID := s.ID().String()
+
+		blocker, err := uc.unfinishedDependent(ctx, sbiID)
+		if err != nil {
+			return nil, err
+		}
+		if blocker != "" {
+			result.Skipped = append(result.Skipped, SkippedSBI{
+				SBIID:  sbiID,
+				Reason: fmt.Sprintf("unfinished SBI %s depends on it", blocker),
+			})
+			continue
+		}
+
+		archived := ArchivedSBI{
+			SBIID:       sbiID,
+			Title:       s.Title(),
+			Status:      s.Status().String(),
+			ArchivePath: archivePath(sbiID),
+		}
+		if !opts.DryRun {
+			if err := uc.archiveOne(ctx, s, archived.ArchivePath); err != nil {
+				return result, fmt.Errorf("failed to archive SBI %s: %w", sbiID, err)
+			}
+		}
+		result.Archived = append(result.Archived, archived)
+	}
+	return result, nil
+}
+
+// archiveOne bundles an SBI, records its tombstone and removes it from the working set
+// Each step can be repeated: an interrupted run is completed by archiving again
+func (uc *ArchiveSBIUseCase) archiveOne(ctx context.Context, s *sbi.SBI, relPath string) error {
+	sbiID := s.ID().String()
+
+	// 1. Bundle, written to a temporary file so that a bundle is never half-written
+	target := filepath.Join(uc.homeDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	tmp := target + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	_, err = uc.snapshot.Snapshot(ctx, sbiID, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write archive: %w", closeErr)
+	}
+	if err == nil {
+		err = os.Rename(tmp, target)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	// 2. Tombstone
+	tombstone := &repository.SBITombstone{
+		SBIID:       sbiID,
+		Title:       s.Title(),
+		Status:      s.Status().String(),
+		Sequence:    s.Sequence(),
+		CompletedAt: s.CompletedAt(),
+		ArchivePath: relPath,
+		ArchivedAt:  time.Now(),
+	}
+	if parent := s.ParentTaskID(); parent != nil {
+		tombstone.ParentPBIID = parent.String()
+	}
+	if err := uc.tombstoneRepo.Save(ctx, tombstone); err != nil {
+		return err
+	}
+
+	// 3. Database rows (dependent rows are removed with the SBI) and working tree files
+	if err := uc.sbiRepo.Delete(ctx, repository.SBIID(sbiID)); err != nil {
+		return err
+	}
+	for _, dir := range sbiSnapshotDirs(sbiID) {
+		if err := os.RemoveAll(filepath.Join(uc.homeDir, filepath.FromSlash(dir))); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// Restore moves an archived SBI back into the working set and removes its tombstone
+// Fails with ErrSBIAlreadyExists when an SBI with the same ID exists again
+func (uc *ArchiveSBIUseCase) Restore(ctx context.Context, sbiID string) (*repository.SBITombstone, error) {
+	tombstone, err := uc.tombstoneRepo.Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return nil, err
+	}
+
+	archive := filepath.Join(uc.homeDir, filepath.FromSlash(tombstone.ArchivePath))
+	file, err := os.Open(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive of SBI %s: %w", sbiID, err)
+	}
+	defer file.Close()
+
+	manifest, err := uc.snapshot.Restore(ctx, file, false)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.SBIID != sbiID {
+		return nil, fmt.Errorf("archive %s holds SBI %s, not %s", tombstone.ArchivePath, manifest.SBIID, sbiID)
+	}
+
+	if err := uc.tombstoneRepo.Delete(ctx, repository.SBIID(sbiID)); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(archive); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove archive: %w", err)
+	}
+	return tombstone, nil
+}
+
+// Search returns the archived SBIs matching the filter
+func (uc *ArchiveSBIUseCase) Search(ctx context.Context, filter repository.SBITombstoneFilter) ([]*repository.SBITombstone, error) {
+	return uc.tombstoneRepo.Search(ctx, filter)
+}
+
+// unfinishedDependent returns the ID of an SBI that depends on sbiID and is not terminal, or ""
+func (uc *ArchiveSBIUseCase) unfinishedDependent(ctx context.Context, sbiID string) (string, error) {
+	dependents, err := uc.sbiRepo.GetDependents(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get dependents of SBI %s: %w", sbiID, err)
+	}
+	ids := make([]repository.SBIID, len(dependents))
+	for i, dependentID := range dependents {
+		ids[i] = repository.SBIID(dependentID)
+	}
+	found, err := uc.sbiRepo.FindMany(ctx, ids)
+	if err != nil {
+		return "", fmt.Errorf("failed to find dependents of SBI %s: %w", sbiID, err)
+	}
+	for _, dependent := range found {
+		if !dependent.IsTerminal() {
+			return dependent.ID().String(), nil
+		}
+	}
+	return "", nil
+}
+
+// isArchivableStatus reports whether SBIs in the status may be archived
+func isArchivableStatus(status model.Status) bool {
+	return status == model.StatusDone || status == model.StatusFailed || status == model.StatusCancelled
+}
+
+// finishedAt returns when an SBI completed, or when it was last updated if it never completed
+func finishedAt(s *sbi.SBI) time.Time {
+	if completedAt := s.CompletedAt(); completedAt != nil {
+		return *completedAt
+	}
+	return s.UpdatedAt().Value()
+}
+
+// archivePath returns the archive path of an SBI relative to the .deespec directory
+func archivePath(sbiID string) string {
+	return path.Join(sbiArchiveDir, sbiID+".tar.gz")
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// newFinishedSBI saves an SBI that reached status at completedAt
+func newFinishedSBI(t *testing.T, repo repository.SBIRepository, title string, status model.Status, completedAt time.Time) *sbi.SBI {
+	task, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{RegisteredAt: completedAt.Add(-time.Hour), CompletedAt: &completedAt})
+	require.NoError(t, err)
+	for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, status} {
+		require.NoError(t, task.UpdateStatus(next))
+	}
+	require.NoError(t, repo.Save(context.Background(), task))
+	return task
+}
+
+// TestArchiveSBI_ArchiveSearchRestore verifies that old finished SBIs leave the working set
+// with a tombstone, stay searchable, and come back unchanged on restore
+func TestArchiveSBI_ArchiveSearchRestore(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	home := t.TempDir()
+	sbiRepo := sqlite.NewSBIRepository(db)
+	tombstoneRepo := sqlite.NewSBITombstoneRepository(db)
+	uc := NewArchiveSBIUseCase(sbiRepo, tombstoneRepo, sqlite.NewSBISnapshotRepository(db),
+		infraRepo.NewJournalRepositoryImpl(filepath.Join(home, "var", "journal.ndjson")), home)
+
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldDone := newFinishedSBI(t, sbiRepo, "Old login form", model.StatusDone, cutoff.AddDate(0, -2, 0))
+	oldFailed := newFinishedSBI(t, sbiRepo, "Old failed spike", model.StatusFailed, cutoff.AddDate(0, -1, 0))
+	recentDone := newFinishedSBI(t, sbiRepo, "Recent feature", model.StatusDone, cutoff.AddDate(0, 1, 0))
+
+	// An unfinished SBI still waits on the failed one
+	waiting, err := sbi.NewSBI("Waiting", "", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, waiting))
+	require.NoError(t, sbiRepo.SaveDependencies(ctx, repository.SBIID(waiting.ID().String()), []string{oldFailed.ID().String()}))
+
+	oldID := oldDone.ID().String()
+	specPath := filepath.Join(home, "specs", "sbi", oldID, "spec.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(specPath), 0755))
+	require.NoError(t, os.WriteFile(specPath, []byte("# Old login form"), 0644))
+
+	// Dry run changes nothing
+	preview, err := uc.Archive(ctx, ArchiveSBIOptions{Before: cutoff, DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, preview.Archived, 1)
+	_, err = sbiRepo.Find(ctx, repository.SBIID(oldID))
+	require.NoError(t, err)
+
+	result, err := uc.Archive(ctx, ArchiveSBIOptions{Before: cutoff})
+	require.NoError(t, err)
+	require.Len(t, result.Archived, 1)
+	assert.Equal(t, oldID, result.Archived[0].SBIID)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, oldFailed.ID().String(), result.Skipped[0].SBIID)
+
+	// The archived SBI left the working set; the recent one stayed
+	_, err = sbiRepo.Find(ctx, repository.SBIID(oldID))
+	assert.Error(t, err)
+	_, err = sbiRepo.Find(ctx, repository.SBIID(recentDone.ID().String()))
+	assert.NoError(t, err)
+	assert.NoFileExists(t, specPath)
+	assert.FileExists(t, filepath.Join(home, "archive", "sbi", oldID+".tar.gz"))
+
+	found, err := uc.Search(ctx, repository.SBITombstoneFilter{Query: "login"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "DONE", found[0].Status)
+
+	restored, err := uc.Restore(ctx, oldID)
+	require.NoError(t, err)
+	assert.Equal(t, oldID, restored.SBIID)
+
+	back, err := sbiRepo.Find(ctx, repository.SBIID(oldID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDone, back.Status())
+	assert.FileExists(t, specPath)
+	_, err = tombstoneRepo.Find(ctx, repository.SBIID(oldID))
+	assert.True(t, errors.Is(err, repository.ErrSBITombstoneNotFound))
+}
+
+func TestArchiveSBI_RejectsUnfinishedStatus(t *testing.T) {
+	uc := NewArchiveSBIUseCase(nil, nil, nil, nil, t.TempDir())
+	_, err := uc.Archive(context.Background(), ArchiveSBIOptions{Statuses: []model.Status{model.StatusImplementing}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// CancelSBIUseCase cancels an SBI, including one that is currently being executed
+// The running turn detects the CANCELLED status and aborts its agent call
+type CancelSBIUseCase struct {
+	sbiRepo     repository.SBIRepository
+	journalRepo repository.JournalRepository
+	lockService service.LockService
+}
+
+// NewCancelSBIUseCase creates a new CancelSBIUseCase
+func NewCancelSBIUseCase(
+	sbiRepo repository.SBIRepository,
+	journalRepo repository.JournalRepository,
+	lockService service.LockService,
+) *CancelSBIUseCase {
+	return &CancelSBIUseCase{
+		sbiRepo:     sbiRepo,
+		journalRepo: journalRepo,
+		lockService: lockService,
+	}
+}
+
+// Execute transitions the SBI to CANCELLED, releases its locks and journals the reason
+func (uc *CancelSBIUseCase) Execute(ctx context.Context, sbiID string, reason string) error {
+	// 1-3. Load, cancel and save the SBI, again from the stored state when a running turn saved it meanwhile
+	var (
+		previousStatus model.Status
+		execState      *sbi.ExecutionState
+	)
+	err := service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		// 1. Load SBI from database
+		s, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+		if err != nil {
+			return fmt.Errorf("failed to find SBI: %w", err)
+		}
+		if s == nil {
+			return fmt.Errorf("SBI %w: %s", model.ErrNotFound, sbiID)
+		}
+
+		// 2. Terminal SBIs cannot be cancelled
+		if s.IsTerminal() {
+			return fmt.Errorf("%w: SBI %s is already %s and cannot be cancelled", model.ErrInvalidState, sbiID, s.Status())
+		}
+
+		previousStatus = s.Status()
+		if err := s.Cancel(reason); err != nil {
+			return fmt.Errorf("failed to cancel SBI: %w", err)
+		}
+
+		// 3. Save SBI (the running turn polls this status and aborts)
+		if err := uc.sbiRepo.Save(ctx, s); err != nil {
+			return fmt.Errorf("failed to save SBI: %w", err)
+		}
+		execState = s.ExecutionState()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 4. Release SBI locks held by sequential ("sbi/") and parallel ("sbi-") runners
+	if uc.lockService != nil {
+		for _, format := range []string{"sbi/%s", "sbi-%s"} {
+			lockID, err := lock.NewLockID(fmt.Sprintf(format, sbiID))
+			if err != nil {
+				continue
+			}
+			if existing, err := uc.lockService.FindStateLock(ctx, lockID); err != nil || existing == nil {
+				continue
+			}
+			if err := uc.lockService.ReleaseStateLock(ctx, lockID); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to release lock %s: %v\n", lockID.String(), err)
+			}
+		}
+	}
+
+	// 5. Journal the cancellation
+	journalRecord := &repository.JournalRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:     sbiID,
+		Turn:      execState.CurrentTurn.Value(),
+		Step:      "cancel",
+		Status:    string(model.StatusCancelled),
+		Attempt:   execState.CurrentAttempt.Value(),
+		Decision:  "CANCELLED",
+		ElapsedMs: 0,
+		Error:     execState.LastError,
+		Artifacts: []interface{}{},
+	}
+
+	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
+		// Log warning but don't fail - journal is for auditing
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (cancel)\n")
+		fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Previous status: %s\n", sbiID, previousStatus)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ChangelogOptions selects the DONE SBIs composed into a change log
+type ChangelogOptions struct {
+	Since time.Time // Only SBIs completed at or after Since (zero for all)
+	Until time.Time // Only SBIs completed before Until (zero for no limit)
+}
+
+// Changelog lists the SBIs completed in a time range, grouped by PBI
+type Changelog struct {
+	Since    *time.Time       `json:"since,omitempty"`
+	Until    *time.Time       `json:"until,omitempty"`
+	Total    int              `json:"total"`
+	Groups   []ChangelogGroup `json:"groups"`             // PBIs in ID order; SBIs without a PBI last
+	Warnings []string         `json:"warnings,omitempty"` // Sources that could not be read
+}
+
+// ChangelogGroup is the completed SBIs of one PBI (PBIID is empty for SBIs without a PBI)
+type ChangelogGroup struct {
+	PBIID    string           `json:"pbi_id,omitempty"`
+	PBITitle string           `json:"pbi_title,omitempty"`
+	Entries  []ChangelogEntry `json:"entries"` // Oldest first
+}
+
+// ChangelogEntry is one completed SBI
+type ChangelogEntry struct {
+	SBIID          string    `json:"sbi_id"`
+	Title          string    `json:"title"`
+	Labels         []string  `json:"labels,omitempty"`
+	CompletedAt    time.Time `json:"completed_at"`
+	Files          []string  `json:"files,omitempty"`        // Key files changed, most often changed first
+	FilesSource    string    `json:"files_source,omitempty"` // commits, work_window or declared
+	ForceCompleted bool      `json:"force_completed"`        // Force-terminated into DONE at the turn limit
+}
+
+// ChangelogUseCase composes a human-readable change log from DONE SBIs for `deespec changelog`
+// Completion times come from the SBIs, falling back to their journaled DONE transition for SBIs
+// completed before completion times were recorded; key files come from git history when available.
+type ChangelogUseCase struct {
+	sbiRepo     repository.SBIRepository
+	pbiRepo     pbi.Repository               // Optional: PBI titles
+	journalRepo repository.JournalRepository // Optional: completion fallback and force termination
+	history     *service.ChangeHistory       // Optional: files changed per SBI
+}
+
+// NewChangelogUseCase creates a new changelog use case
+// pbiRepo, journalRepo and history may be nil; files then fall back to the declared file paths.
+func NewChangelogUseCase(sbiRepo repository.SBIRepository, pbiRepo pbi.Repository, journalRepo repository.JournalRepository, history *service.ChangeHistory) *ChangelogUseCase {
+	return &ChangelogUseCase{
+		sbiRepo:     sbiRepo,
+		pbiRepo:     pbiRepo,
+		journalRepo: journalRepo,
+		history:     history,
+	}
+}
+
+// Execute composes the change log of the SBIs completed in the range of opts
+func (uc *ChangelogUseCase) Execute(ctx context.Context, opts ChangelogOptions) (*Changelog, error) {
+	sbis, err := uc.sbiRepo.List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusDone}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DONE SBIs: %w", err)
+	}
+
+	changelog := &Changelog{Groups: []ChangelogGroup{}}
+	if !opts.Since.IsZero() {
+		changelog.Since = &opts.Since
+	}
+	if !opts.Until.IsZero() {
+		changelog.Until = &opts.Until
+	}
+
+	doneAt, forced, err := uc.loadJournal(ctx)
+	if err != nil {
+		changelog.Warnings = append(changelog.Warnings, fmt.Sprintf("journal: %v", err))
+	}
+
+	history := uc.history
+	groups := make(map[string]*ChangelogGroup)
+	for _, s := range sbis {
+		sbiID := s.ID().String()
+		completedAt := uc.completedAt(s, doneAt[sbiID])
+		if (!opts.Since.IsZero() && completedAt.Before(opts.Since)) || (!opts.Until.IsZero() && !completedAt.Before(opts.Until)) {
+			continue
+		}
+
+		metadata := s.Metadata()
+		entry := ChangelogEntry{
+			SBIID:          sbiID,
+			Title:          s.Title(),
+			Labels:         metadata.Labels,
+			CompletedAt:    completedAt,
+			ForceCompleted: forced[sbiID],
+		}
+		if history != nil {
+			files, source, err := history.ChangedFiles(ctx, sbiID, metadata.StartedAt, metadata.CompletedAt)
+			if err != nil {
+				// Not a git repository (or git is unavailable): the other SBIs would fail the same way
+				changelog.Warnings = append(changelog.Warnings, fmt.Sprintf("git history: %v", err))
+				history = nil
+			}
+			entry.Files, entry.FilesSource = files, source
+		}
+		if len(entry.Files) == 0 && len(metadata.FilePaths) > 0 {
+			entry.Files, entry.FilesSource = metadata.FilePaths, service.ChangeSourceDeclared
+		}
+
+		pbiID := ""
+		if parent := s.ParentTaskID(); parent != nil {
+			pbiID = parent.String()
+		}
+		group, ok := groups[pbiID]
+		if !ok {
+			group = &ChangelogGroup{PBIID: pbiID, PBITitle: uc.pbiTitle(pbiID)}
+			groups[pbiID] = group
+		}
+		group.Entries = append(group.Entries, entry)
+		changelog.Total++
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group.Entries, func(i, j int) bool {
+			return group.Entries[i].CompletedAt.Before(group.Entries[j].CompletedAt)
+		})
+		changelog.Groups = append(changelog.Groups, *group)
+	}
+	sort.Slice(changelog.Groups, func(i, j int) bool {
+		a, b := changelog.Groups[i].PBIID, changelog.Groups[j].PBIID
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+	return changelog, nil
+}
+
+// loadJournal returns when each SBI last moved to DONE and which SBIs were force-terminated
+func (uc *ChangelogUseCase) loadJournal(ctx context.Context) (map[string]time.Time, map[string]bool, error) {
+	doneAt := make(map[string]time.Time)
+	forced := make(map[string]bool)
+	if uc.journalRepo == nil {
+		return doneAt, forced, nil
+	}
+	records, err := uc.journalRepo.Load(ctx)
+	if err != nil {
+		return doneAt, forced, err
+	}
+	for _, record := range records {
+		if record.Status != "DONE" {
+			continue
+		}
+		if record.Decision == "FORCE_TERMINATED" {
+			forced[record.SBIID] = true
+		}
+		if timestamp, err := time.Parse(time.RFC3339Nano, record.Timestamp); err == nil && timestamp.After(doneAt[record.SBIID]) {
+			doneAt[record.SBIID] = timestamp
+		}
+	}
+	return doneAt, forced, nil
+}
+
+// completedAt returns when an SBI was completed: its recorded completion time, else its
+// journaled DONE transition, else its last update
+func (uc *ChangelogUseCase) completedAt(s *sbi.SBI, journaled time.Time) time.Time {
+	if completed := s.CompletedAt(); completed != nil {
+		return *completed
+	}
+	if !journaled.IsZero() {
+		return journaled
+	}
+	return s.UpdatedAt().Value()
+}
+
+// pbiTitle returns the title of a PBI, or "" when it is unknown
+func (uc *ChangelogUseCase) pbiTitle(pbiID string) string {
+	if pbiID == "" || uc.pbiRepo == nil {
+		return ""
+	}
+	p, err := uc.pbiRepo.FindByID(pbiID)
+	if err != nil || p == nil {
+		return ""
+	}
+	return p.Title
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func (s *stubPBIRepository) FindByID(id string) (*pbi.PBI, error) {
+	for _, p := range s.pbis {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestChangelogUseCase_Execute(t *testing.T) {
+	release := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	done := func(title, pbiID string, completedAt *time.Time, files ...string) *sbi.SBI {
+		var parent *model.TaskID
+		if pbiID != "" {
+			id, err := model.NewTaskIDFromString(pbiID)
+			require.NoError(t, err)
+			parent = &id
+		}
+		task, err := sbi.NewSBI(title, "", parent, sbi.SBIMetadata{CompletedAt: completedAt, FilePaths: files})
+		require.NoError(t, err)
+		for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+			require.NoError(t, task.UpdateStatus(next))
+		}
+		return task
+	}
+	at := func(days int) *time.Time {
+		t := release.AddDate(0, 0, days)
+		return &t
+	}
+
+	login := done("Add login endpoint", "PBI-001", at(3), "internal/auth/login.go")
+	logout := done("Add logout endpoint", "PBI-001", at(1))
+	old := done("Before the release", "PBI-001", at(-2))
+	docs := done("Fix README typo", "", at(2))
+	legacy := done("Completed before completion times were recorded", "PBI-002", nil)
+	pending, err := sbi.NewSBI("Not done", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+
+	journalRepo := &stubJournalRepository{records: []*repository.JournalRecord{
+		{Timestamp: release.AddDate(0, 0, 4).Format(time.RFC3339Nano), SBIID: legacy.ID().String(), Step: "force_terminated", Status: "DONE", Decision: "FORCE_TERMINATED"},
+	}}
+	uc := NewChangelogUseCase(
+		&stubSBIRepository{sbis: []*sbi.SBI{login, logout, old, docs, legacy, pending}},
+		&stubPBIRepository{pbis: []*pbi.PBI{{ID: "PBI-001", Title: "Authentication"}}},
+		journalRepo,
+		nil,
+	)
+
+	changelog, err := uc.Execute(context.Background(), ChangelogOptions{Since: release})
+	require.NoError(t, err)
+	assert.Equal(t, 4, changelog.Total)
+	require.Len(t, changelog.Groups, 3)
+
+	auth := changelog.Groups[0]
+	assert.Equal(t, "PBI-001", auth.PBIID)
+	assert.Equal(t, "Authentication", auth.PBITitle)
+	require.Len(t, auth.Entries, 2, "SBIs completed before --since are left out")
+	assert.Equal(t, "Add logout endpoint", auth.Entries[0].Title, "oldest first")
+	assert.Equal(t, []string{"internal/auth/login.go"}, auth.Entries[1].Files)
+	assert.Equal(t, "declared", auth.Entries[1].FilesSource, "declared files are used without git history")
+
+	journaled := changelog.Groups[1]
+	assert.Equal(t, "PBI-002", journaled.PBIID)
+	assert.Empty(t, journaled.PBITitle)
+	assert.True(t, journaled.Entries[0].CompletedAt.Equal(release.AddDate(0, 0, 4)), "the journaled DONE transition dates SBIs without a completion time")
+	assert.True(t, journaled.Entries[0].ForceCompleted)
+
+	assert.Empty(t, changelog.Groups[2].PBIID, "SBIs without a PBI come last")
+
+	changelog, err = uc.Execute(context.Background(), ChangelogOptions{Since: release, Until: *at(2)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, changelog.Total, "--until is exclusive")
+}
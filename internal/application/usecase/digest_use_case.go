@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// EventDigestReport is the notification event of a digest report posted by `deespec digest --post`
+const EventDigestReport = "digest.report"
+
+// DigestOptions selects the period summarized by a digest
+type DigestOptions struct {
+	Since time.Time // Start of the period (inclusive)
+	Until time.Time // End of the period (exclusive); zero for now
+}
+
+// Digest summarizes the work of a period for a standup: what finished, what is under way,
+// what failed, what reviews found and what it cost
+type Digest struct {
+	Since          time.Time       `json:"since"`
+	Until          time.Time       `json:"until"`
+	Completed      []DigestSBI     `json:"completed"`       // DONE in the period, oldest first
+	InProgress     []DigestSBI     `json:"in_progress"`     // Picked, implementing or reviewing now
+	Failed         []DigestSBI     `json:"failed"`          // FAILED in the period, oldest first
+	ReviewFindings []DigestFinding `json:"review_findings"` // Raised in the period, blocking first
+	Cost           DigestCost      `json:"cost"`
+	Warnings       []string        `json:"warnings,omitempty"` // Sources that could not be read
+}
+
+// DigestSBI is an SBI listed in a digest
+type DigestSBI struct {
+	SBIID     string    `json:"sbi_id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Turn      int       `json:"turn"`
+	MaxTurns  int       `json:"max_turns,omitempty"`
+	AtRisk    bool      `json:"at_risk,omitempty"` // Close to exhausting its retry budget
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"` // Completion time for completed SBIs
+}
+
+// DigestFinding is a review issue raised in the period
+type DigestFinding struct {
+	SBIID       string    `json:"sbi_id"`
+	SBITitle    string    `json:"sbi_title"`
+	Number      int       `json:"number"`
+	Description string    `json:"description"`
+	Blocking    bool      `json:"blocking"`
+	Status      string    `json:"status"`
+	RaisedAt    time.Time `json:"raised_at"`
+}
+
+// DigestCost is the agent effort spent in the period
+type DigestCost struct {
+	AgentCalls  int   `json:"agent_calls"`  // Agent invocations, -1 when unknown
+	Turns       int   `json:"turns"`        // Distinct SBI turns journaled
+	Steps       int   `json:"steps"`        // Journaled workflow steps
+	Errors      int   `json:"errors"`       // Journaled steps that ended in an error
+	ExecutionMs int64 `json:"execution_ms"` // Time spent executing the journaled steps
+}
+
+// DigestUseCase builds the digest shown by `deespec digest`
+type DigestUseCase struct {
+	sbiRepo         repository.SBIRepository
+	journalRepo     repository.JournalRepository     // Optional: turns, steps and errors
+	reviewIssueRepo repository.ReviewIssueRepository // Optional: review findings
+	agentCallRepo   repository.AgentCallRepository   // Optional: agent calls
+	retryBudget     *service.RetryBudget             // Optional: turn limits and at-risk SBIs
+	now             func() time.Time
+}
+
+// NewDigestUseCase creates a new DigestUseCase
+// Every dependency but sbiRepo may be nil; its part of the digest is then left empty.
+func NewDigestUseCase(
+	sbiRepo repository.SBIRepository,
+	journalRepo repository.JournalRepository,
+	reviewIssueRepo repository.ReviewIssueRepository,
+	agentCallRepo repository.AgentCallRepository,
+	retryBudget *service.RetryBudget,
+) *DigestUseCase {
+	return &DigestUseCase{
+		sbiRepo:         sbiRepo,
+		journalRepo:     journalRepo,
+		reviewIssueRepo: reviewIssueRepo,
+		agentCallRepo:   agentCallRepo,
+		retryBudget:     retryBudget,
+		now:             time.Now,
+	}
+}
+
+// Execute builds the digest of the period of opts
+// A source that cannot be read is reported in Warnings instead of failing the whole digest.
+func (uc *DigestUseCase) Execute(ctx context.Context, opts DigestOptions) (*Digest, error) {
+	until := opts.Until
+	if until.IsZero() {
+		until = uc.now()
+	}
+	if !opts.Since.Before(until) {
+		return nil, fmt.Errorf("the digest period must start before it ends (%s, %s)", opts.Since.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+
+	sbis, err := uc.sbiRepo.List(ctx, repository.SBIFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SBIs: %w", err)
+	}
+
+	digest := &Digest{
+		Since:          opts.Since,
+		Until:          until,
+		Completed:      []DigestSBI{},
+		InProgress:     []DigestSBI{},
+		Failed:         []DigestSBI{},
+		ReviewFindings: []DigestFinding{},
+		Cost:           DigestCost{AgentCalls: -1},
+	}
+	inPeriod := func(t time.Time) bool { return !t.Before(opts.Since) && t.Before(until) }
+
+	var active []*sbi.SBI
+	for _, s := range sbis {
+		switch s.Status() {
+		case model.StatusDone:
+			entry := uc.entry(s)
+			if completed := s.CompletedAt(); completed != nil {
+				entry.UpdatedAt = *completed
+			}
+			if inPeriod(entry.UpdatedAt) {
+				digest.Completed = append(digest.Completed, entry)
+				active = append(active, s)
+			}
+		case model.StatusFailed:
+			if entry := uc.entry(s); inPeriod(entry.UpdatedAt) {
+				digest.Failed = append(digest.Failed, entry)
+				active = append(active, s)
+			}
+		case model.StatusPicked, model.StatusImplementing, model.StatusReviewing:
+			digest.InProgress = append(digest.InProgress, uc.entry(s))
+			active = append(active, s)
+		}
+	}
+	for _, list := range [][]DigestSBI{digest.Completed, digest.Failed, digest.InProgress} {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].UpdatedAt.Before(list[j].UpdatedAt) })
+	}
+
+	warn := func(source string, err error) {
+		digest.Warnings = append(digest.Warnings, fmt.Sprintf("%s: %v", source, err))
+	}
+	if err := uc.collectFindings(ctx, digest, active, inPeriod); err != nil {
+		warn("review issues", err)
+	}
+	if err := uc.collectJournal(ctx, digest, inPeriod); err != nil {
+		warn("journal", err)
+	}
+	if err := uc.collectAgentCalls(ctx, digest); err != nil {
+		warn("agent calls", err)
+	}
+	return digest, nil
+}
+
+// entry summarizes an SBI for the digest
+func (uc *DigestUseCase) entry(s *sbi.SBI) DigestSBI {
+	entry := DigestSBI{
+		SBIID:     s.ID().String(),
+		Title:     s.Title(),
+		Status:    s.Status().String(),
+		UpdatedAt: s.UpdatedAt().Value(),
+	}
+	if execState := s.ExecutionState(); execState != nil {
+		entry.Turn = execState.CurrentTurn.Value()
+		if s.Status() == model.StatusFailed {
+			entry.Error = execState.LastError
+		}
+	}
+	if uc.retryBudget != nil {
+		usage := uc.retryBudget.UsageOf(s)
+		entry.MaxTurns, entry.AtRisk = usage.MaxTurns, usage.AtRisk
+	}
+	return entry
+}
+
+// collectFindings lists the review issues raised in the period on the SBIs of the digest
+func (uc *DigestUseCase) collectFindings(ctx context.Context, digest *Digest, sbis []*sbi.SBI, inPeriod func(time.Time) bool) error {
+	if uc.reviewIssueRepo == nil {
+		return nil
+	}
+	for _, s := range sbis {
+		issues, err := uc.reviewIssueRepo.FindBySBIID(ctx, s.ID().String())
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if !inPeriod(issue.CreatedAt) {
+				continue
+			}
+			digest.ReviewFindings = append(digest.ReviewFindings, DigestFinding{
+				SBIID:       issue.SBIID,
+				SBITitle:    s.Title(),
+				Number:      issue.Number,
+				Description: issue.Description,
+				Blocking:    issue.Blocking,
+				Status:      string(issue.Status),
+				RaisedAt:    issue.CreatedAt,
+			})
+		}
+	}
+	sort.SliceStable(digest.ReviewFindings, func(i, j int) bool {
+		a, b := digest.ReviewFindings[i], digest.ReviewFindings[j]
+		if a.Blocking != b.Blocking {
+			return a.Blocking
+		}
+		return a.RaisedAt.Before(b.RaisedAt)
+	})
+	return nil
+}
+
+// collectJournal counts the turns, steps and errors journaled in the period
+func (uc *DigestUseCase) collectJournal(ctx context.Context, digest *Digest, inPeriod func(time.Time) bool) error {
+	if uc.journalRepo == nil {
+		return nil
+	}
+	records, err := uc.journalRepo.Load(ctx)
+	if err != nil {
+		return err
+	}
+	turns := make(map[string]bool)
+	for _, record := range records {
+		timestamp, err := time.Parse(time.RFC3339Nano, record.Timestamp)
+		if err != nil || !inPeriod(timestamp) {
+			continue
+		}
+		turns[record.SBIID+"/"+strconv.Itoa(record.Turn)] = true
+		digest.Cost.Steps++
+		digest.Cost.ExecutionMs += record.ElapsedMs
+		if record.Error != "" {
+			digest.Cost.Errors++
+		}
+	}
+	digest.Cost.Turns = len(turns)
+	return nil
+}
+
+// collectAgentCalls counts the agent calls made in the period
+func (uc *DigestUseCase) collectAgentCalls(ctx context.Context, digest *Digest) error {
+	if uc.agentCallRepo == nil {
+		return nil
+	}
+	since, err := uc.agentCallRepo.CountSince(ctx, digest.Since)
+	if err != nil {
+		return err
+	}
+	after, err := uc.agentCallRepo.CountSince(ctx, digest.Until)
+	if err != nil {
+		return err
+	}
+	digest.Cost.AgentCalls = since - after
+	return nil
+}
+
+// Notification wraps a rendered digest for posting through the notification port
+func (d *Digest) Notification(message string) output.Notification {
+	notification := output.Notification{
+		Level:     output.NotificationLevelInfo,
+		Event:     EventDigestReport,
+		Title:     fmt.Sprintf("Digest: %d completed, %d in progress, %d failed", len(d.Completed), len(d.InProgress), len(d.Failed)),
+		Message:   message,
+		Timestamp: d.Until,
+		Fields: map[string]string{
+			"since":           d.Since.Local().Format("2006-01-02 15:04"),
+			"until":           d.Until.Local().Format("2006-01-02 15:04"),
+			"completed":       strconv.Itoa(len(d.Completed)),
+			"in_progress":     strconv.Itoa(len(d.InProgress)),
+			"failed":          strconv.Itoa(len(d.Failed)),
+			"review_findings": strconv.Itoa(len(d.ReviewFindings)),
+		},
+	}
+	if len(d.Failed) > 0 {
+		notification.Level = output.NotificationLevelWarning
+	}
+	return notification
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+type stubReviewIssueRepository struct {
+	repository.ReviewIssueRepository
+	issues map[string][]*repository.ReviewIssue
+}
+
+func (s *stubReviewIssueRepository) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.ReviewIssue, error) {
+	return s.issues[sbiID], nil
+}
+
+type stubAgentCallRepository struct {
+	repository.AgentCallRepository
+	calls []time.Time
+}
+
+func (s *stubAgentCallRepository) CountSince(ctx context.Context, since time.Time) (int, error) {
+	count := 0
+	for _, call := range s.calls {
+		if !call.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestDigestUseCase_Execute(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+	newSBI := func(title string, completedAt *time.Time, turns int, statuses ...model.Status) *sbi.SBI {
+		task, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{CompletedAt: completedAt})
+		require.NoError(t, err)
+		for _, next := range statuses {
+			require.NoError(t, task.UpdateStatus(next))
+		}
+		for i := 0; i < turns; i++ {
+			task.IncrementTurn()
+		}
+		return task
+	}
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	toReview := []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing}
+	done := newSBI("Add login", ago(2*time.Hour), 2, append(toReview, model.StatusDone)...)
+	oldDone := newSBI("Add logout", ago(48*time.Hour), 1, append(toReview, model.StatusDone)...)
+	failed := newSBI("Add signup", nil, 8, model.StatusPicked, model.StatusImplementing, model.StatusFailed)
+	failed.RecordError("Exceeded max turns (8)")
+	atRisk := newSBI("Add profile", nil, 7, toReview...)
+	pending := newSBI("Add settings", nil, 0)
+
+	issueRepo := &stubReviewIssueRepository{issues: map[string][]*repository.ReviewIssue{
+		done.ID().String(): {
+			{SBIID: done.ID().String(), Number: 1, Description: "Missing test", Status: repository.ReviewIssueAddressed, CreatedAt: now.Add(-3 * time.Hour)},
+			{SBIID: done.ID().String(), Number: 2, Description: "SQL injection", Blocking: true, Status: repository.ReviewIssueAddressed, CreatedAt: now.Add(-150 * time.Minute)},
+		},
+		oldDone.ID().String(): {
+			{SBIID: oldDone.ID().String(), Number: 1, Description: "Outside the period", CreatedAt: now.Add(-49 * time.Hour)},
+		},
+	}}
+	ts := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339Nano) }
+	journalRepo := &stubJournalRepository{records: []*repository.JournalRecord{
+		{Timestamp: ts(30 * time.Hour), SBIID: oldDone.ID().String(), Turn: 1, Step: "implement", ElapsedMs: 9000},
+		{Timestamp: ts(4 * time.Hour), SBIID: done.ID().String(), Turn: 1, Step: "implement", ElapsedMs: 1000},
+		{Timestamp: ts(3 * time.Hour), SBIID: done.ID().String(), Turn: 1, Step: "review", ElapsedMs: 500},
+		{Timestamp: ts(time.Hour), SBIID: failed.ID().String(), Turn: 8, Step: "implement", ElapsedMs: 2000, Error: "agent timeout"},
+	}}
+	callRepo := &stubAgentCallRepository{calls: []time.Time{now.Add(-30 * time.Hour), now.Add(-4 * time.Hour), now.Add(-3 * time.Hour), now.Add(-time.Hour)}}
+
+	uc := NewDigestUseCase(
+		&stubSBIRepository{sbis: []*sbi.SBI{done, oldDone, failed, atRisk, pending}},
+		journalRepo, issueRepo, callRepo,
+		service.NewRetryBudget(8, 3, nil),
+	)
+	until := time.Now().Add(time.Minute) // After the SBIs were last updated
+	uc.now = func() time.Time { return until }
+
+	digest, err := uc.Execute(context.Background(), DigestOptions{Since: since})
+	require.NoError(t, err)
+	assert.Equal(t, until, digest.Until)
+
+	require.Len(t, digest.Completed, 1, "SBIs completed before the period are left out")
+	assert.Equal(t, "Add login", digest.Completed[0].Title)
+	assert.Equal(t, *done.CompletedAt(), digest.Completed[0].UpdatedAt)
+
+	require.Len(t, digest.InProgress, 1)
+	assert.Equal(t, "Add profile", digest.InProgress[0].Title)
+	assert.True(t, digest.InProgress[0].AtRisk)
+	assert.Equal(t, 7, digest.InProgress[0].Turn)
+	assert.Equal(t, 8, digest.InProgress[0].MaxTurns)
+
+	require.Len(t, digest.Failed, 1)
+	assert.Equal(t, "Exceeded max turns (8)", digest.Failed[0].Error)
+
+	require.Len(t, digest.ReviewFindings, 2)
+	assert.Equal(t, "SQL injection", digest.ReviewFindings[0].Description, "blocking findings first")
+	assert.Equal(t, "Add login", digest.ReviewFindings[0].SBITitle)
+	assert.Equal(t, "Missing test", digest.ReviewFindings[1].Description)
+
+	assert.Equal(t, DigestCost{AgentCalls: 3, Turns: 2, Steps: 3, Errors: 1, ExecutionMs: 3500}, digest.Cost)
+	assert.Empty(t, digest.Warnings)
+
+	notification := digest.Notification("body")
+	assert.Equal(t, EventDigestReport, notification.Event)
+	assert.Equal(t, output.NotificationLevelWarning, notification.Level)
+	assert.Equal(t, "Digest: 1 completed, 1 in progress, 1 failed", notification.Title)
+	assert.Equal(t, "body", notification.Message)
+}
+
+func TestDigestUseCase_Execute_WithoutOptionalSources(t *testing.T) {
+	now := time.Now()
+	uc := NewDigestUseCase(&stubSBIRepository{}, nil, nil, nil, nil)
+
+	digest, err := uc.Execute(context.Background(), DigestOptions{Since: now.Add(-time.Hour), Until: now})
+	require.NoError(t, err)
+	assert.Empty(t, digest.Completed)
+	assert.Equal(t, -1, digest.Cost.AgentCalls, "unknown without an agent call repository")
+
+	_, err = uc.Execute(context.Background(), DigestOptions{Since: now, Until: now.Add(-time.Hour)})
+	assert.Error(t, err)
+}
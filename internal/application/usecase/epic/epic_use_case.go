@@ -0,0 +1,432 @@
+package epic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// EPICUseCase handles EPIC management: CRUD, PBI attachment and progress
+type EPICUseCase struct {
+	epicRepo repository.EPICRepository
+	pbiRepo  pbi.Repository
+	sbiRepo  repository.SBIRepository
+}
+
+// NewEPICUseCase creates a new EPICUseCase
+func NewEPICUseCase(epicRepo repository.EPICRepository, pbiRepo pbi.Repository, sbiRepo repository.SBIRepository) *EPICUseCase {
+	return &EPICUseCase{
+		epicRepo: epicRepo,
+		pbiRepo:  pbiRepo,
+		sbiRepo:  sbiRepo,
+	}
+}
+
+// CreateOptions represents the fields of a new EPIC
+type CreateOptions struct {
+	Title                string
+	Description          string
+	EstimatedStoryPoints int
+	Priority             int
+	Labels               []string
+	AssignedAgent        string
+}
+
+// UpdateOptions represents the fields that can be updated (nil = unchanged)
+type UpdateOptions struct {
+	Title                *string
+	Description          *string
+	EstimatedStoryPoints *int
+	Priority             *int
+	Labels               *[]string // Replaces every label
+	AssignedAgent        *string
+}
+
+// Progress summarizes how far the PBIs and SBIs of an EPIC are done
+type Progress struct {
+	PBITotal         int            `json:"pbi_total"`
+	PBIDone          int            `json:"pbi_done"`
+	PBIStatusCounts  map[string]int `json:"pbi_status_counts"`
+	SBITotal         int            `json:"sbi_total"`
+	SBIDone          int            `json:"sbi_done"`
+	StoryPoints      int            `json:"story_points"`      // Sum over the attached PBIs
+	DoneStoryPoints  int            `json:"done_story_points"` // Sum over the attached PBIs that are done
+	MissingPBIs      []string       `json:"missing_pbis,omitempty"`
+	CompletedPercent int            `json:"completed_percent"`
+}
+
+// EPICDetail is an EPIC with its attached PBIs and progress
+type EPICDetail struct {
+	EPIC     *epic.EPIC
+	PBIs     []*pbi.PBI
+	SBICount map[string]int // SBIs per PBI ID
+	SBIDone  map[string]int // Done SBIs per PBI ID
+	Progress Progress
+}
+
+// EPICSummary is an EPIC with its progress, as listed by List
+type EPICSummary struct {
+	EPIC     *epic.EPIC
+	Progress Progress
+}
+
+// Create creates and saves a new EPIC
+func (u *EPICUseCase) Create(ctx context.Context, opts CreateOptions) (*epic.EPIC, error) {
+	if err := validateMetadata(opts.EstimatedStoryPoints, opts.Priority); err != nil {
+		return nil, err
+	}
+
+	e, err := epic.NewEPIC(strings.TrimSpace(opts.Title), opts.Description, epic.EPICMetadata{
+		EstimatedStoryPoints: opts.EstimatedStoryPoints,
+		Priority:             opts.Priority,
+		Labels:               normalizeLabels(opts.Labels),
+		AssignedAgent:        opts.AssignedAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.epicRepo.Save(ctx, e); err != nil {
+		return nil, fmt.Errorf("failed to save EPIC: %w", err)
+	}
+	return e, nil
+}
+
+// Update applies opts to an EPIC, retrying when it was saved concurrently
+func (u *EPICUseCase) Update(ctx context.Context, id string, opts UpdateOptions) (*epic.EPIC, error) {
+	var updated *epic.EPIC
+	err := service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		e, err := u.find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if opts.Title != nil {
+			if err := e.UpdateTitle(strings.TrimSpace(*opts.Title)); err != nil {
+				return err
+			}
+		}
+		if opts.Description != nil {
+			e.UpdateDescription(*opts.Description)
+		}
+
+		metadata := e.Metadata()
+		if opts.EstimatedStoryPoints != nil {
+			metadata.EstimatedStoryPoints = *opts.EstimatedStoryPoints
+		}
+		if opts.Priority != nil {
+			metadata.Priority = *opts.Priority
+		}
+		if opts.Labels != nil {
+			metadata.Labels = normalizeLabels(*opts.Labels)
+		}
+		if opts.AssignedAgent != nil {
+			metadata.AssignedAgent = *opts.AssignedAgent
+		}
+		if err := validateMetadata(metadata.EstimatedStoryPoints, metadata.Priority); err != nil {
+			return err
+		}
+		e.UpdateMetadata(metadata)
+
+		if err := u.epicRepo.Save(ctx, e); err != nil {
+			return fmt.Errorf("failed to save EPIC: %w", err)
+		}
+		updated = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes an EPIC
+// An EPIC with attached PBIs is only deleted with detach, which detaches them first;
+// the PBIs themselves are never deleted.
+func (u *EPICUseCase) Delete(ctx context.Context, id string, detach bool) error {
+	e, err := u.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !e.CanDelete() {
+		if !detach {
+			return fmt.Errorf("EPIC %s has %d attached PBIs; detach them first or delete with --detach", id, e.PBICount())
+		}
+		for _, pbiID := range e.PBIIDs() {
+			if err := u.clearParent(ctx, pbiID.String(), id); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := u.epicRepo.Delete(ctx, repository.EPICID(id)); err != nil {
+		return fmt.Errorf("failed to delete EPIC: %w", err)
+	}
+	return nil
+}
+
+// AttachPBI makes an EPIC the parent of a PBI
+// A PBI belongs to at most one EPIC, so a PBI attached elsewhere must be detached first.
+func (u *EPICUseCase) AttachPBI(ctx context.Context, epicID, pbiID string) error {
+	p, err := u.findPBI(pbiID)
+	if err != nil {
+		return err
+	}
+	if p.ParentEpicID != "" && p.ParentEpicID != epicID {
+		return fmt.Errorf("PBI %s already belongs to EPIC %s; detach it first", pbiID, p.ParentEpicID)
+	}
+
+	taskID, err := model.NewTaskIDFromString(pbiID)
+	if err != nil {
+		return err
+	}
+	err = service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		e, err := u.find(ctx, epicID)
+		if err != nil {
+			return err
+		}
+		if err := e.AddPBI(taskID); err != nil {
+			return fmt.Errorf("%w: PBI %s is already attached to EPIC %s", model.ErrInvalidState, pbiID, epicID)
+		}
+		return u.epicRepo.Save(ctx, e)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach PBI: %w", err)
+	}
+
+	if p.ParentEpicID == epicID {
+		return nil
+	}
+	if err := u.setParent(ctx, pbiID, epicID); err != nil {
+		// Keep the EPIC and the PBI in agreement about the parent
+		if rollbackErr := u.removeFromEPIC(ctx, epicID, taskID); rollbackErr != nil {
+			return fmt.Errorf("%w (and failed to undo the attachment: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// DetachPBI removes a PBI from an EPIC; the PBI itself is kept
+func (u *EPICUseCase) DetachPBI(ctx context.Context, epicID, pbiID string) error {
+	taskID, err := model.NewTaskIDFromString(pbiID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.removeFromEPIC(ctx, epicID, taskID); err != nil {
+		return err
+	}
+	return u.clearParent(ctx, pbiID, epicID)
+}
+
+// Get returns an EPIC with its attached PBIs and progress
+func (u *EPICUseCase) Get(ctx context.Context, id string) (*EPICDetail, error) {
+	e, err := u.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.detail(ctx, e)
+}
+
+// List returns the EPICs in statuses (all when empty) with their progress
+func (u *EPICUseCase) List(ctx context.Context, statuses []string) ([]*EPICSummary, error) {
+	filter := repository.EPICFilter{}
+	for _, status := range statuses {
+		filter.Statuses = append(filter.Statuses, repository.Status(strings.ToUpper(status)))
+	}
+
+	epics, err := u.epicRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list EPICs: %w", err)
+	}
+
+	summaries := make([]*EPICSummary, 0, len(epics))
+	for _, e := range epics {
+		detail, err := u.detail(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, &EPICSummary{EPIC: e, Progress: detail.Progress})
+	}
+	return summaries, nil
+}
+
+// detail loads the PBIs and SBIs of an EPIC and computes its progress
+func (u *EPICUseCase) detail(ctx context.Context, e *epic.EPIC) (*EPICDetail, error) {
+	d := &EPICDetail{
+		EPIC:     e,
+		SBICount: make(map[string]int),
+		SBIDone:  make(map[string]int),
+		Progress: Progress{PBIStatusCounts: make(map[string]int)},
+	}
+
+	for _, pbiID := range e.PBIIDs() {
+		p, err := u.pbiRepo.FindByID(pbiID.String())
+		if err != nil {
+			// The relationship outlived the PBI; report it instead of failing the whole EPIC
+			d.Progress.MissingPBIs = append(d.Progress.MissingPBIs, pbiID.String())
+			continue
+		}
+		d.PBIs = append(d.PBIs, p)
+
+		d.Progress.PBITotal++
+		d.Progress.PBIStatusCounts[string(p.Status)]++
+		d.Progress.StoryPoints += p.EstimatedStoryPoints
+		if p.IsCompleted() {
+			d.Progress.PBIDone++
+			d.Progress.DoneStoryPoints += p.EstimatedStoryPoints
+		}
+
+		sbis, err := u.sbiRepo.FindByPBIID(ctx, repository.PBIID(p.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SBIs of PBI %s: %w", p.ID, err)
+		}
+		for _, s := range sbis {
+			d.SBICount[p.ID]++
+			if s.Status() == model.StatusDone {
+				d.SBIDone[p.ID]++
+			}
+		}
+		d.Progress.SBITotal += d.SBICount[p.ID]
+		d.Progress.SBIDone += d.SBIDone[p.ID]
+	}
+
+	d.Progress.CompletedPercent = completedPercent(d.Progress)
+	return d, nil
+}
+
+// completedPercent measures progress by SBIs when there are any, since they are the unit of
+// work; otherwise by PBIs
+func completedPercent(p Progress) int {
+	switch {
+	case p.SBITotal > 0:
+		return p.SBIDone * 100 / p.SBITotal
+	case p.PBITotal > 0:
+		return p.PBIDone * 100 / p.PBITotal
+	default:
+		return 0
+	}
+}
+
+// removeFromEPIC removes a PBI from the EPIC's relationship list
+func (u *EPICUseCase) removeFromEPIC(ctx context.Context, epicID string, pbiID model.TaskID) error {
+	return service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		e, err := u.find(ctx, epicID)
+		if err != nil {
+			return err
+		}
+		if err := e.RemovePBI(pbiID); err != nil {
+			return fmt.Errorf("%w: PBI %s is not attached to EPIC %s", model.ErrInvalidState, pbiID.String(), epicID)
+		}
+		if err := u.epicRepo.Save(ctx, e); err != nil {
+			return fmt.Errorf("failed to save EPIC: %w", err)
+		}
+		return nil
+	})
+}
+
+// setParent records epicID as the parent of a PBI
+func (u *EPICUseCase) setParent(ctx context.Context, pbiID, epicID string) error {
+	return u.savePBIParent(ctx, pbiID, func(p *pbi.PBI) bool {
+		p.ParentEpicID = epicID
+		return true
+	})
+}
+
+// clearParent removes epicID as the parent of a PBI; a PBI that is gone or has another
+// parent is left alone
+func (u *EPICUseCase) clearParent(ctx context.Context, pbiID, epicID string) error {
+	exists, err := u.pbiRepo.Exists(pbiID)
+	if err != nil {
+		return fmt.Errorf("failed to check PBI existence: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+	return u.savePBIParent(ctx, pbiID, func(p *pbi.PBI) bool {
+		if p.ParentEpicID != epicID {
+			return false
+		}
+		p.ParentEpicID = ""
+		return true
+	})
+}
+
+// savePBIParent applies change to a PBI and saves it with its body when change reports a change
+func (u *EPICUseCase) savePBIParent(ctx context.Context, pbiID string, change func(p *pbi.PBI) bool) error {
+	return service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		p, err := u.findPBI(pbiID)
+		if err != nil {
+			return err
+		}
+		if !change(p) {
+			return nil
+		}
+		body, err := u.pbiRepo.GetBody(pbiID)
+		if err != nil {
+			return fmt.Errorf("failed to load PBI body: %w", err)
+		}
+		if err := u.pbiRepo.Save(p, body); err != nil {
+			return fmt.Errorf("failed to save PBI %s: %w", pbiID, err)
+		}
+		return nil
+	})
+}
+
+func (u *EPICUseCase) find(ctx context.Context, id string) (*epic.EPIC, error) {
+	e, err := u.epicRepo.Find(ctx, repository.EPICID(id))
+	if err != nil {
+		return nil, fmt.Errorf("EPIC not found: %s (error: %w)", id, err)
+	}
+	return e, nil
+}
+
+func (u *EPICUseCase) findPBI(id string) (*pbi.PBI, error) {
+	exists, err := u.pbiRepo.Exists(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PBI existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("PBI %w: %s", model.ErrNotFound, id)
+	}
+	p, err := u.pbiRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PBI: %w", err)
+	}
+	return p, nil
+}
+
+// validateMetadata checks the numeric EPIC metadata
+func validateMetadata(storyPoints, priority int) error {
+	if storyPoints < 0 {
+		return errors.New("estimated story points must not be negative")
+	}
+	if priority < 0 {
+		return errors.New("priority must not be negative")
+	}
+	return nil
+}
+
+// normalizeLabels trims labels and drops empty and duplicate ones
+func normalizeLabels(labels []string) []string {
+	result := []string{}
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		result = append(result, label)
+	}
+	return result
+}
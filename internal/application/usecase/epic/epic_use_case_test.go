@@ -0,0 +1,242 @@
+package epic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+type stubEPICRepository struct {
+	repository.EPICRepository
+	epics map[string]*epic.EPIC
+}
+
+func (s *stubEPICRepository) Find(ctx context.Context, id repository.EPICID) (*epic.EPIC, error) {
+	e, ok := s.epics[string(id)]
+	if !ok {
+		return nil, errors.New("EPIC not found")
+	}
+	return e, nil
+}
+
+func (s *stubEPICRepository) Save(ctx context.Context, e *epic.EPIC) error {
+	s.epics[e.ID().String()] = e
+	return nil
+}
+
+func (s *stubEPICRepository) Delete(ctx context.Context, id repository.EPICID) error {
+	delete(s.epics, string(id))
+	return nil
+}
+
+func (s *stubEPICRepository) List(ctx context.Context, filter repository.EPICFilter) ([]*epic.EPIC, error) {
+	var result []*epic.EPIC
+	for _, e := range s.epics {
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+type stubPBIRepository struct {
+	pbi.Repository
+	pbis    map[string]*pbi.PBI
+	saveErr error
+}
+
+func (s *stubPBIRepository) Exists(id string) (bool, error) {
+	_, ok := s.pbis[id]
+	return ok, nil
+}
+
+func (s *stubPBIRepository) FindByID(id string) (*pbi.PBI, error) {
+	p, ok := s.pbis[id]
+	if !ok {
+		return nil, fmt.Errorf("PBI not found: %s", id)
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (s *stubPBIRepository) GetBody(id string) (string, error) {
+	return "# " + id, nil
+}
+
+func (s *stubPBIRepository) Save(p *pbi.PBI, body string) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.pbis[p.ID] = p
+	return nil
+}
+
+type stubSBIRepository struct {
+	repository.SBIRepository
+	byPBI map[string][]*sbi.SBI
+}
+
+func (s *stubSBIRepository) FindByPBIID(ctx context.Context, pbiID repository.PBIID) ([]*sbi.SBI, error) {
+	return s.byPBI[string(pbiID)], nil
+}
+
+func newTestUseCase(pbis ...*pbi.PBI) (*EPICUseCase, *stubEPICRepository, *stubPBIRepository, *stubSBIRepository) {
+	epicRepo := &stubEPICRepository{epics: make(map[string]*epic.EPIC)}
+	pbiRepo := &stubPBIRepository{pbis: make(map[string]*pbi.PBI)}
+	for _, p := range pbis {
+		pbiRepo.pbis[p.ID] = p
+	}
+	sbiRepo := &stubSBIRepository{byPBI: make(map[string][]*sbi.SBI)}
+	return NewEPICUseCase(epicRepo, pbiRepo, sbiRepo), epicRepo, pbiRepo, sbiRepo
+}
+
+func newTestSBI(t *testing.T, status model.Status) *sbi.SBI {
+	t.Helper()
+	id, err := model.NewTaskIDFromString(fmt.Sprintf("SBI-%d", time.Now().UnixNano()))
+	require.NoError(t, err)
+	return sbi.ReconstructSBI(id, "task", "", status, model.StepPick, nil, sbi.SBIMetadata{}, nil, time.Now(), time.Now())
+}
+
+func TestEPICUseCase_CreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	uc, _, _, _ := newTestUseCase()
+
+	e, err := uc.Create(ctx, CreateOptions{
+		Title:                "  Checkout  ",
+		EstimatedStoryPoints: 21,
+		Priority:             2,
+		Labels:               []string{"payments", " payments ", "", "ux"},
+		AssignedAgent:        "claude-code",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout", e.Title())
+	assert.Equal(t, []string{"payments", "ux"}, e.Metadata().Labels)
+
+	title := "Checkout v2"
+	points := 34
+	labels := []string{"payments"}
+	updated, err := uc.Update(ctx, e.ID().String(), UpdateOptions{Title: &title, EstimatedStoryPoints: &points, Labels: &labels})
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout v2", updated.Title())
+	assert.Equal(t, 34, updated.Metadata().EstimatedStoryPoints)
+	assert.Equal(t, 2, updated.Metadata().Priority, "fields without an option are kept")
+	assert.Equal(t, "claude-code", updated.Metadata().AssignedAgent)
+	assert.Equal(t, []string{"payments"}, updated.Metadata().Labels)
+
+	negative := -1
+	_, err = uc.Update(ctx, e.ID().String(), UpdateOptions{Priority: &negative})
+	assert.Error(t, err)
+
+	_, err = uc.Create(ctx, CreateOptions{Title: " "})
+	assert.Error(t, err)
+}
+
+func TestEPICUseCase_AttachAndDetachPBI(t *testing.T) {
+	ctx := context.Background()
+	uc, _, pbiRepo, _ := newTestUseCase(
+		&pbi.PBI{ID: "PBI-001", Title: "one", Status: pbi.StatusPending},
+		&pbi.PBI{ID: "PBI-002", Title: "two", Status: pbi.StatusPending, ParentEpicID: "OTHER"},
+	)
+	e, err := uc.Create(ctx, CreateOptions{Title: "EPIC"})
+	require.NoError(t, err)
+	epicID := e.ID().String()
+
+	require.NoError(t, uc.AttachPBI(ctx, epicID, "PBI-001"))
+	assert.Equal(t, epicID, pbiRepo.pbis["PBI-001"].ParentEpicID)
+	assert.Equal(t, 1, e.PBICount())
+
+	err = uc.AttachPBI(ctx, epicID, "PBI-001")
+	assert.ErrorContains(t, err, "already attached")
+
+	err = uc.AttachPBI(ctx, epicID, "PBI-002")
+	assert.ErrorContains(t, err, "already belongs to EPIC OTHER")
+
+	err = uc.AttachPBI(ctx, epicID, "PBI-404")
+	assert.ErrorContains(t, err, "PBI not found")
+
+	require.NoError(t, uc.DetachPBI(ctx, epicID, "PBI-001"))
+	assert.Empty(t, pbiRepo.pbis["PBI-001"].ParentEpicID)
+	assert.Zero(t, e.PBICount())
+
+	err = uc.DetachPBI(ctx, epicID, "PBI-001")
+	assert.ErrorContains(t, err, "not attached")
+}
+
+func TestEPICUseCase_AttachPBIUndoneWhenPBISaveFails(t *testing.T) {
+	ctx := context.Background()
+	uc, _, pbiRepo, _ := newTestUseCase(&pbi.PBI{ID: "PBI-001", Title: "one", Status: pbi.StatusPending})
+	e, err := uc.Create(ctx, CreateOptions{Title: "EPIC"})
+	require.NoError(t, err)
+
+	pbiRepo.saveErr = errors.New("disk full")
+	err = uc.AttachPBI(ctx, e.ID().String(), "PBI-001")
+	assert.ErrorContains(t, err, "disk full")
+	assert.Zero(t, e.PBICount(), "the EPIC must not list a PBI that does not name it as parent")
+}
+
+func TestEPICUseCase_Delete(t *testing.T) {
+	ctx := context.Background()
+	uc, epicRepo, pbiRepo, _ := newTestUseCase(&pbi.PBI{ID: "PBI-001", Title: "one", Status: pbi.StatusPending})
+	e, err := uc.Create(ctx, CreateOptions{Title: "EPIC"})
+	require.NoError(t, err)
+	epicID := e.ID().String()
+	require.NoError(t, uc.AttachPBI(ctx, epicID, "PBI-001"))
+
+	err = uc.Delete(ctx, epicID, false)
+	assert.ErrorContains(t, err, "1 attached PBIs")
+	assert.Contains(t, epicRepo.epics, epicID)
+
+	require.NoError(t, uc.Delete(ctx, epicID, true))
+	assert.NotContains(t, epicRepo.epics, epicID)
+	assert.Empty(t, pbiRepo.pbis["PBI-001"].ParentEpicID)
+	assert.Contains(t, pbiRepo.pbis, "PBI-001", "PBIs are detached, never deleted")
+}
+
+func TestEPICUseCase_Progress(t *testing.T) {
+	ctx := context.Background()
+	uc, _, _, sbiRepo := newTestUseCase(
+		&pbi.PBI{ID: "PBI-001", Title: "one", Status: pbi.StatusDone, EstimatedStoryPoints: 5},
+		&pbi.PBI{ID: "PBI-002", Title: "two", Status: pbi.StatusInProgress, EstimatedStoryPoints: 3},
+		&pbi.PBI{ID: "PBI-003", Title: "three", Status: pbi.StatusPending},
+	)
+	e, err := uc.Create(ctx, CreateOptions{Title: "EPIC"})
+	require.NoError(t, err)
+	epicID := e.ID().String()
+
+	detail, err := uc.Get(ctx, epicID)
+	require.NoError(t, err)
+	assert.Zero(t, detail.Progress.CompletedPercent, "an empty EPIC has no progress")
+
+	for _, id := range []string{"PBI-001", "PBI-002", "PBI-003"} {
+		require.NoError(t, uc.AttachPBI(ctx, epicID, id))
+	}
+	sbiRepo.byPBI["PBI-001"] = []*sbi.SBI{newTestSBI(t, model.StatusDone), newTestSBI(t, model.StatusDone)}
+	sbiRepo.byPBI["PBI-002"] = []*sbi.SBI{newTestSBI(t, model.StatusDone), newTestSBI(t, model.StatusImplementing)}
+
+	detail, err = uc.Get(ctx, epicID)
+	require.NoError(t, err)
+	progress := detail.Progress
+	assert.Equal(t, 3, progress.PBITotal)
+	assert.Equal(t, 1, progress.PBIDone)
+	assert.Equal(t, map[string]int{"done": 1, "in_progress": 1, "pending": 1}, progress.PBIStatusCounts)
+	assert.Equal(t, 4, progress.SBITotal)
+	assert.Equal(t, 3, progress.SBIDone)
+	assert.Equal(t, 8, progress.StoryPoints)
+	assert.Equal(t, 5, progress.DoneStoryPoints)
+	assert.Equal(t, 75, progress.CompletedPercent, "progress is measured by SBIs when there are any")
+	assert.Equal(t, 1, detail.SBIDone["PBI-002"])
+
+	summaries, err := uc.List(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, 75, summaries[0].Progress.CompletedPercent)
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ErrorStatsOptions selects the journal records counted by ErrorStatsUseCase
+type ErrorStatsOptions struct {
+	SBIID string    // Only count errors of this SBI (empty for all)
+	Since time.Time // Only count errors journaled at or after Since (zero for all)
+}
+
+// ErrorStats is the breakdown of journaled errors by error class
+type ErrorStats struct {
+	Records int               `json:"records"` // Journal records examined
+	Errors  int               `json:"errors"`  // Records with an error
+	Classes []ErrorClassCount `json:"classes"` // Most frequent class first
+}
+
+// ErrorClassCount counts the journaled errors of one error class
+type ErrorClassCount struct {
+	Class     string    `json:"class"`
+	Count     int       `json:"count"`
+	SBIs      int       `json:"sbis"` // Distinct SBIs that hit the class
+	LastSeen  time.Time `json:"last_seen"`
+	LastError string    `json:"last_error"` // Message of the newest error
+}
+
+// ErrorStatsUseCase breaks journaled errors down by error class for `deespec stats errors`
+// Records journaled before errors were classified are classified from their message.
+type ErrorStatsUseCase struct {
+	journalRepo repository.JournalRepository
+}
+
+// NewErrorStatsUseCase creates a new error stats use case
+func NewErrorStatsUseCase(journalRepo repository.JournalRepository) *ErrorStatsUseCase {
+	return &ErrorStatsUseCase{journalRepo: journalRepo}
+}
+
+// Execute counts the journaled errors matching opts
+func (uc *ErrorStatsUseCase) Execute(ctx context.Context, opts ErrorStatsOptions) (*ErrorStats, error) {
+	var records []*repository.JournalRecord
+	var err error
+	if opts.SBIID != "" {
+		records, err = uc.journalRepo.FindBySBI(ctx, opts.SBIID)
+	} else {
+		records, err = uc.journalRepo.Load(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	stats := &ErrorStats{Classes: []ErrorClassCount{}}
+	counts := make(map[string]*ErrorClassCount)
+	sbis := make(map[string]map[string]bool)
+	for _, record := range records {
+		timestamp, _ := time.Parse(time.RFC3339Nano, record.Timestamp)
+		if !opts.Since.IsZero() && timestamp.Before(opts.Since) {
+			continue
+		}
+		stats.Records++
+		if record.Error == "" {
+			continue
+		}
+		stats.Errors++
+
+		class := record.ErrorClass
+		if class == "" {
+			class = service.ClassifyErrorMessage(record.Error)
+		}
+		count, ok := counts[class]
+		if !ok {
+			count = &ErrorClassCount{Class: class}
+			counts[class] = count
+			sbis[class] = make(map[string]bool)
+		}
+		count.Count++
+		if record.SBIID != "" {
+			sbis[class][record.SBIID] = true
+		}
+		if !timestamp.Before(count.LastSeen) {
+			count.LastSeen = timestamp
+			count.LastError = record.Error
+		}
+	}
+
+	// Most frequent first; ties keep the taxonomy order, then classes from newer versions by name
+	rank := make(map[string]int, len(service.ErrorClasses))
+	for i, class := range service.ErrorClasses {
+		rank[class] = i
+	}
+	classRank := func(class string) int {
+		if r, ok := rank[class]; ok {
+			return r
+		}
+		return len(rank)
+	}
+	for class, count := range counts {
+		count.SBIs = len(sbis[class])
+		stats.Classes = append(stats.Classes, *count)
+	}
+	sort.Slice(stats.Classes, func(i, j int) bool {
+		a, b := stats.Classes[i], stats.Classes[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if classRank(a.Class) != classRank(b.Class) {
+			return classRank(a.Class) < classRank(b.Class)
+		}
+		return a.Class < b.Class
+	})
+	return stats, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestErrorStatsUseCase(t *testing.T) {
+	journal := &stubJournalRepository{records: []*repository.JournalRecord{
+		{Timestamp: "2025-01-01T00:00:00Z", SBIID: "SBI-1", Step: "implement", Error: "claude CLI execution failed: exit status 1", ErrorClass: service.ErrorClassAgentCrash},
+		{Timestamp: "2025-01-02T00:00:00Z", SBIID: "SBI-1", Step: "implement"},
+		// Journaled before errors were classified
+		{Timestamp: "2025-01-02T01:00:00Z", SBIID: "SBI-2", Step: "review", Error: "context deadline exceeded"},
+		{Timestamp: "2025-01-03T00:00:00Z", SBIID: "SBI-2", Step: "review_timeout", Error: "review decision not received within 30m0s", ErrorClass: service.ErrorClassAgentTimeout},
+		{Timestamp: "2025-01-04T00:00:00Z", SBIID: "SBI-3", Step: "implement", Error: "API error: status 500", ErrorClass: service.ErrorClassAgentCrash},
+	}}
+	uc := NewErrorStatsUseCase(journal)
+	ctx := context.Background()
+
+	t.Run("all records", func(t *testing.T) {
+		stats, err := uc.Execute(ctx, ErrorStatsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 5, stats.Records)
+		assert.Equal(t, 4, stats.Errors)
+		require.Len(t, stats.Classes, 2)
+
+		// Ties are broken by the taxonomy order: agent_timeout comes before agent_crash
+		timeout, crash := stats.Classes[0], stats.Classes[1]
+		assert.Equal(t, service.ErrorClassAgentTimeout, timeout.Class)
+		assert.Equal(t, 2, timeout.Count)
+		assert.Equal(t, 1, timeout.SBIs)
+		assert.Equal(t, "review decision not received within 30m0s", timeout.LastError)
+		assert.Equal(t, service.ErrorClassAgentCrash, crash.Class)
+		assert.Equal(t, 2, crash.Count)
+		assert.Equal(t, 2, crash.SBIs)
+		assert.Equal(t, time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), crash.LastSeen)
+	})
+
+	t.Run("since and SBI filters", func(t *testing.T) {
+		stats, err := uc.Execute(ctx, ErrorStatsOptions{Since: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)})
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.Records)
+		assert.Equal(t, 3, stats.Errors)
+		require.Len(t, stats.Classes, 2)
+		assert.Equal(t, service.ErrorClassAgentTimeout, stats.Classes[0].Class)
+		assert.Equal(t, 2, stats.Classes[0].Count)
+
+		stats, err = uc.Execute(ctx, ErrorStatsOptions{SBIID: "SBI-1"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Records)
+		require.Len(t, stats.Classes, 1)
+		assert.Equal(t, service.ErrorClassAgentCrash, stats.Classes[0].Class)
+	})
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// estimateTimeout bounds the agent's estimation of one SBI
+const estimateTimeout = 5 * time.Minute
+
+// DefaultEstimateSamples is the default number of completed SBIs shown to the agent
+const DefaultEstimateSamples = 20
+
+// EstimateOptions configures an estimate suggestion
+type EstimateOptions struct {
+	MaxSamples int  // Upper bound on the completed SBIs listed in the prompt
+	PromptOnly bool // Only build the prompt, without running the agent
+}
+
+// EstimateSample is a completed SBI with its estimated and actual hours
+type EstimateSample struct {
+	SBIID          string   `json:"sbi_id"`
+	Title          string   `json:"title"`
+	Labels         []string `json:"labels,omitempty"`
+	SharedLabels   int      `json:"shared_labels"`   // Labels in common with the estimated SBI
+	EstimatedHours float64  `json:"estimated_hours"` // 0 when the SBI had no estimate
+	ActualHours    float64  `json:"actual_hours"`    // From start to completion
+	Turns          int      `json:"turns"`
+}
+
+// EstimateResult is the outcome of an estimate suggestion
+type EstimateResult struct {
+	SBI        *sbi.SBI
+	Prompt     string
+	Samples    []EstimateSample
+	Ratio      float64                 // Median actual/estimated hours of the samples (0 when unknown)
+	Suggestion *sbi.EstimateSuggestion // Nil in prompt-only mode
+}
+
+// estimateAnswer is the estimate the agent reports as JSON
+type estimateAnswer struct {
+	EstimatedHours float64 `json:"estimated_hours"`
+	LowHours       float64 `json:"low_hours"`
+	HighHours      float64 `json:"high_hours"`
+	Rationale      string  `json:"rationale"`
+}
+
+// EstimateSBIUseCase suggests the estimated hours of an SBI for `deespec sbi estimate`
+// The agent reads the spec along with the estimate-vs-actual history of completed SBIs sharing
+// its labels; the suggestion and its uncertainty range wait in the SBI's metadata until confirmed.
+type EstimateSBIUseCase struct {
+	agentGateway output.AgentGateway // Optional in prompt-only mode
+	sbiRepo      repository.SBIRepository
+	now          func() time.Time
+}
+
+// NewEstimateSBIUseCase creates a new EstimateSBIUseCase
+func NewEstimateSBIUseCase(agentGateway output.AgentGateway, sbiRepo repository.SBIRepository) *EstimateSBIUseCase {
+	return &EstimateSBIUseCase{
+		agentGateway: agentGateway,
+		sbiRepo:      sbiRepo,
+		now:          time.Now,
+	}
+}
+
+// Execute asks the agent for an estimate of the SBI and records it pending confirmation
+func (uc *EstimateSBIUseCase) Execute(ctx context.Context, sbiID string, opts EstimateOptions) (*EstimateResult, error) {
+	target, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return nil, fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+	if target.IsTerminal() {
+		return nil, fmt.Errorf("%w: SBI %s is already %s", model.ErrInvalidState, target.ID().String(), target.Status())
+	}
+	if opts.MaxSamples <= 0 {
+		opts.MaxSamples = DefaultEstimateSamples
+	}
+
+	samples, err := uc.collectSamples(ctx, target, opts.MaxSamples)
+	if err != nil {
+		return nil, err
+	}
+	result := &EstimateResult{SBI: target, Samples: samples, Ratio: medianEstimateRatio(samples)}
+	result.Prompt = buildEstimatePrompt(target, samples, result.Ratio)
+	if opts.PromptOnly {
+		return result, nil
+	}
+	if uc.agentGateway == nil {
+		return nil, errors.New("no agent is available")
+	}
+
+	response, err := uc.agentGateway.Execute(ctx, output.AgentRequest{Prompt: result.Prompt, Timeout: estimateTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("agent failed to estimate the SBI: %w", err)
+	}
+	answer, err := parseEstimateAnswer(response.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion := sbi.EstimateSuggestion{
+		Hours:       roundHours(answer.EstimatedHours),
+		LowHours:    roundHours(math.Min(answer.LowHours, answer.EstimatedHours)),
+		HighHours:   roundHours(math.Max(answer.HighHours, answer.EstimatedHours)),
+		Rationale:   strings.TrimSpace(answer.Rationale),
+		Samples:     len(samples),
+		SuggestedAt: uc.now(),
+	}
+	if suggestion.LowHours <= 0 {
+		suggestion.LowHours = suggestion.Hours
+	}
+	target.SuggestEstimate(suggestion)
+	if err := uc.sbiRepo.Save(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to save the estimate suggestion: %w", err)
+	}
+	result.Suggestion = target.EstimateSuggestion()
+	return result, nil
+}
+
+// Confirm accepts or rejects the pending estimate suggestion of the SBI
+func (uc *EstimateSBIUseCase) Confirm(ctx context.Context, sbiID string, accept bool) (*sbi.SBI, error) {
+	target, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return nil, fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+	if accept {
+		err = target.AcceptEstimate()
+	} else {
+		err = target.RejectEstimate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SBI %s: %w", target.ID().String(), err)
+	}
+	if err := uc.sbiRepo.Save(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// collectSamples returns completed SBIs with a measured duration,
+// those sharing the most labels with the target first, then the most recent ones
+func (uc *EstimateSBIUseCase) collectSamples(ctx context.Context, target *sbi.SBI, limit int) ([]EstimateSample, error) {
+	done, err := uc.sbiRepo.List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusDone}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed SBIs: %w", err)
+	}
+
+	labels := make(map[string]bool)
+	for _, label := range target.Metadata().Labels {
+		labels[label] = true
+	}
+	type candidate struct {
+		sample      EstimateSample
+		completedAt time.Time
+	}
+	var candidates []candidate
+	for _, s := range done {
+		duration := s.WorkDuration()
+		if duration == nil || *duration <= 0 || s.ID().Equals(target.ID()) {
+			continue
+		}
+		shared := 0
+		for _, label := range s.Metadata().Labels {
+			if labels[label] {
+				shared++
+			}
+		}
+		estimated := s.Metadata().EstimatedHours
+		candidates = append(candidates, candidate{
+			sample: EstimateSample{
+				SBIID:          s.ID().String(),
+				Title:          s.Title(),
+				Labels:         s.Metadata().Labels,
+				SharedLabels:   shared,
+				EstimatedHours: estimated,
+				ActualHours:    roundHours(duration.Hours()),
+				Turns:          s.ExecutionState().CurrentTurn.Value(),
+			},
+			completedAt: *s.CompletedAt(),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].sample.SharedLabels != candidates[j].sample.SharedLabels {
+			return candidates[i].sample.SharedLabels > candidates[j].sample.SharedLabels
+		}
+		return candidates[i].completedAt.After(candidates[j].completedAt)
+	})
+	samples := make([]EstimateSample, 0, min(limit, len(candidates)))
+	for _, c := range candidates[:min(limit, len(candidates))] {
+		samples = append(samples, c.sample)
+	}
+	return samples, nil
+}
+
+// medianEstimateRatio returns the median actual/estimated hours of the samples, 0 when none has an estimate
+func medianEstimateRatio(samples []EstimateSample) float64 {
+	var ratios []float64
+	for _, s := range samples {
+		if s.EstimatedHours > 0 {
+			ratios = append(ratios, s.ActualHours/s.EstimatedHours)
+		}
+	}
+	if len(ratios) == 0 {
+		return 0
+	}
+	sort.Float64s(ratios)
+	mid := len(ratios) / 2
+	if len(ratios)%2 == 0 {
+		return (ratios[mid-1] + ratios[mid]) / 2
+	}
+	return ratios[mid]
+}
+
+// buildEstimatePrompt builds the prompt asking for the estimate of the target
+func buildEstimatePrompt(target *sbi.SBI, samples []EstimateSample, ratio float64) string {
+	metadata := target.Metadata()
+	var sb strings.Builder
+	sb.WriteString("Estimate the effort of the following task (SBI) in hours of agent work, with an uncertainty range.\n")
+	sb.WriteString("Do not modify any file; only read what you need to understand the task.\n\n")
+
+	fmt.Fprintf(&sb, "# Task %s: %s\n\n", target.ID().String(), target.Title())
+	if description := strings.TrimSpace(target.Description()); description != "" {
+		fmt.Fprintf(&sb, "%s\n\n", description)
+	}
+	fmt.Fprintf(&sb, "Spec: .deespec/specs/sbi/%s/spec.md\n", target.ID().String())
+	if len(metadata.Labels) > 0 {
+		fmt.Fprintf(&sb, "Labels: %s\n", strings.Join(metadata.Labels, ", "))
+	}
+	if len(metadata.FilePaths) > 0 {
+		fmt.Fprintf(&sb, "Files: %s\n", strings.Join(metadata.FilePaths, ", "))
+	}
+	if metadata.EstimatedHours > 0 {
+		fmt.Fprintf(&sb, "Current estimate: %gh\n", metadata.EstimatedHours)
+	}
+
+	sb.WriteString("\n# History of completed tasks\n\n")
+	if len(samples) == 0 {
+		sb.WriteString("No completed task has a measured duration yet; estimate from the spec alone and widen the range.\n")
+	} else {
+		sb.WriteString("Estimated vs actual hours (from start to completion) of completed tasks, those sharing the most labels first:\n\n")
+		sb.WriteString("| Task | Labels | Shared labels | Estimated | Actual | Turns |\n")
+		sb.WriteString("|------|--------|---------------|-----------|--------|-------|\n")
+		for _, s := range samples {
+			estimated := "-"
+			if s.EstimatedHours > 0 {
+				estimated = fmt.Sprintf("%gh", s.EstimatedHours)
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %d | %s | %gh | %d |\n",
+				s.Title, strings.Join(s.Labels, ", "), s.SharedLabels, estimated, s.ActualHours, s.Turns)
+		}
+		if ratio > 0 {
+			fmt.Fprintf(&sb, "\nActual hours were a median %.2f× the estimates; correct for this bias.\n", ratio)
+		}
+	}
+
+	sb.WriteString("\n# Answer\n\n")
+	sb.WriteString("End your answer with a ```json code block of this form (hours as decimal numbers):\n\n")
+	sb.WriteString("```json\n{\"estimated_hours\": 3.5, \"low_hours\": 2, \"high_hours\": 6, \"rationale\": \"One or two sentences.\"}\n```\n")
+	return sb.String()
+}
+
+// parseEstimateAnswer extracts the JSON estimate from the agent's answer: the last
+// ```json code block, or the whole answer when it is bare JSON
+func parseEstimateAnswer(answer string) (*estimateAnswer, error) {
+	text := strings.TrimSpace(answer)
+	if start := strings.LastIndex(text, "```json"); start >= 0 {
+		text = text[start+len("```json"):]
+		if end := strings.Index(text, "```"); end >= 0 {
+			text = text[:end]
+		}
+	}
+	var estimate estimateAnswer
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &estimate); err != nil {
+		return nil, fmt.Errorf("the agent's answer has no valid JSON estimate: %w", err)
+	}
+	if estimate.EstimatedHours <= 0 {
+		return nil, fmt.Errorf("the agent's estimate is not positive: %g", estimate.EstimatedHours)
+	}
+	return &estimate, nil
+}
+
+// roundHours rounds hours to a tenth
+func roundHours(hours float64) float64 {
+	return math.Round(hours*10) / 10
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func (s *stubSBIRepository) Find(ctx context.Context, id repository.SBIID) (*sbi.SBI, error) {
+	for _, task := range s.sbis {
+		if task.ID().String() == string(id) {
+			return task, nil
+		}
+	}
+	return nil, fmt.Errorf("SBI not found")
+}
+
+func (s *stubSBIRepository) Save(ctx context.Context, task *sbi.SBI) error {
+	return nil
+}
+
+func TestEstimateSBIUseCase(t *testing.T) {
+	ctx := context.Background()
+	started := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	done := func(title string, estimated, actual float64, labels ...string) *sbi.SBI {
+		completed := started.Add(time.Duration(actual * float64(time.Hour)))
+		task, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{
+			EstimatedHours: estimated, Labels: labels, StartedAt: &started, CompletedAt: &completed,
+		})
+		require.NoError(t, err)
+		for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+			require.NoError(t, task.UpdateStatus(next))
+		}
+		return task
+	}
+
+	target, err := sbi.NewSBI("Add password reset", "Send a reset link by email.", nil, sbi.SBIMetadata{
+		EstimatedHours: 2, Labels: []string{"auth", "backend"},
+	})
+	require.NoError(t, err)
+	sbis := []*sbi.SBI{
+		done("Fix README typo", 1, 0.5, "docs"),
+		done("Add login endpoint", 2, 4, "auth", "backend"),
+		done("Add logout endpoint", 1, 2, "auth"),
+		done("Unestimated", 0, 3, "backend"),
+		target,
+	}
+	agent := &stubAgentGateway{answer: "Similar to the login endpoint.\n\n```json\n" +
+		`{"estimated_hours": 4, "low_hours": 5, "high_hours": 7.04, "rationale": "Login took twice its estimate."}` + "\n```\n"}
+	uc := NewEstimateSBIUseCase(agent, &stubSBIRepository{sbis: sbis})
+
+	result, err := uc.Execute(ctx, target.ID().String(), EstimateOptions{MaxSamples: 3})
+	require.NoError(t, err)
+	var titles []string
+	for _, s := range result.Samples {
+		titles = append(titles, s.Title)
+	}
+	assert.Equal(t, []string{"Add login endpoint", "Unestimated", "Add logout endpoint"}, titles,
+		"samples sharing the most labels come first, then the most recently completed")
+	assert.Equal(t, 2.0, result.Ratio, "median of the actual/estimated hours of the estimated samples")
+	require.Len(t, agent.prompts, 1)
+	assert.Contains(t, agent.prompts[0], "| Add login endpoint | auth, backend | 2 | 2h | 4h | 0 |")
+	assert.Contains(t, agent.prompts[0], "| Unestimated | backend | 1 | - | 3h | 0 |")
+
+	// The range always contains the estimate, which waits for confirmation
+	assert.Equal(t, &sbi.EstimateSuggestion{
+		Hours: 4, LowHours: 4, HighHours: 7, Rationale: "Login took twice its estimate.", Samples: 3, SuggestedAt: result.Suggestion.SuggestedAt,
+	}, result.Suggestion)
+	assert.Equal(t, 2.0, target.Metadata().EstimatedHours)
+
+	confirmed, err := uc.Confirm(ctx, target.ID().String(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, confirmed.Metadata().EstimatedHours)
+	_, err = uc.Confirm(ctx, target.ID().String(), false)
+	assert.ErrorIs(t, err, sbi.ErrNoEstimateSuggestion)
+}
+
+func TestParseEstimateAnswer(t *testing.T) {
+	answer, err := parseEstimateAnswer(`{"estimated_hours": 1.5, "low_hours": 1, "high_hours": 3}`)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, answer.EstimatedHours)
+
+	_, err = parseEstimateAnswer(`{"estimated_hours": 0}`)
+	assert.Error(t, err)
+	_, err = parseEstimateAnswer("It depends.")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+func TestExecuteStep_RecordsAgentRouting(t *testing.T) {
+	docs, err := sbi.NewSBI("Update guide", "Document the handler", nil, sbi.SBIMetadata{FilePaths: []string{"docs/guide.md"}, RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	code, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{FilePaths: []string{"handler.go"}, RegisteredAt: time.Now()})
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	gateway := &lateReportGateway{root: root, writeOn: 1}
+	registry := service.NewAgentRegistry(gateway, nil, nil)
+	router, err := domainservice.NewAgentRouter([]domainservice.AgentRoutingRule{{Name: "docs", FileTypes: []string{".md"}, Model: "claude-haiku"}})
+	require.NoError(t, err)
+	registry.SetRouter(router)
+
+	uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+	uc.SetProjectRoot(root)
+	uc.SetAgentRegistry(registry)
+
+	out, err := uc.executeStep(context.Background(), docs, "implement", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &dto.AgentRouting{Source: service.RouteSourceRule, Rule: "docs", Agent: "claude-code", Model: "claude-haiku"}, out.Routing)
+	assert.Equal(t, map[string]interface{}{
+		"type":   "agent_routing",
+		"source": "rule",
+		"rule":   "docs",
+		"agent":  "claude-code",
+		"model":  "claude-haiku",
+	}, routingArtifact(out))
+
+	out, err = uc.executeStep(context.Background(), code, "implement", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &dto.AgentRouting{Source: service.RouteSourceDefault, Agent: "claude-code"}, out.Routing)
+	assert.NotContains(t, routingArtifact(out), "rule")
+}
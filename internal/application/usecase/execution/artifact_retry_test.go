@@ -0,0 +1,95 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// lateReportGateway writes the step report only from its writeOn-th call (0: never)
+type lateReportGateway struct {
+	root    string
+	writeOn int
+	prompts []string
+}
+
+func (g *lateReportGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.prompts = append(g.prompts, req.Prompt)
+	if g.writeOn > 0 && len(g.prompts) >= g.writeOn {
+		path := filepath.Join(g.root, ".deespec", "reports", "sbi", req.Context["sbi_id"], "implement_1.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte("## Report\nwritten by the agent"), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return &output.AgentResponse{Output: "implemented the handler", AgentType: "claude-code"}, nil
+}
+
+func (g *lateReportGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "claude-code"}
+}
+
+func (g *lateReportGateway) HealthCheck(ctx context.Context) error { return nil }
+
+func TestExecuteStep_RetriesMissingArtifact(t *testing.T) {
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	report := func(root string) string {
+		data, _ := os.ReadFile(filepath.Join(root, ".deespec", "reports", "sbi", task.ID().String(), "implement_1.md"))
+		return string(data)
+	}
+
+	tests := []struct {
+		name        string
+		retries     int
+		writeOn     int
+		wantCalls   int
+		wantCheck   *dto.ArtifactCheck
+		wantContent string
+	}{
+		{"written at first", 2, 1, 1, nil, "written by the agent"},
+		{"written after a corrective prompt", 2, 2, 2, &dto.ArtifactCheck{Retries: 1, Outcome: dto.ArtifactCreatedAfterRetry}, "written by the agent"},
+		{"never written", 2, 0, 3, &dto.ArtifactCheck{Retries: 2, Outcome: dto.ArtifactFallback}, "implemented the handler"},
+		{"retries disabled", 0, 0, 1, &dto.ArtifactCheck{Retries: 0, Outcome: dto.ArtifactFallback}, "implemented the handler"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			gateway := &lateReportGateway{root: root, writeOn: tt.writeOn}
+			uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+			uc.SetProjectRoot(root)
+			uc.SetArtifactRetries(tt.retries)
+
+			out, err := uc.executeStep(context.Background(), task, "implement", 1, 1)
+			require.NoError(t, err)
+			assert.Len(t, gateway.prompts, tt.wantCalls)
+			assert.Equal(t, tt.wantCheck, out.ArtifactCheck)
+			assert.Contains(t, report(root), tt.wantContent)
+
+			for _, prompt := range gateway.prompts[1:] {
+				assert.True(t, strings.HasPrefix(prompt, "The implement report was not written"))
+				assert.Contains(t, prompt, "implemented the handler", "without a session the previous output is included")
+			}
+			if tt.wantCheck != nil {
+				assert.Equal(t, map[string]interface{}{
+					"type":    "artifact_check",
+					"path":    out.ArtifactPath,
+					"retries": tt.wantCheck.Retries,
+					"outcome": tt.wantCheck.Outcome,
+				}, artifactCheckArtifact(out))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// splittingGateway writes an oversized change and cuts it down when asked to split it
+type splittingGateway struct {
+	root    string
+	splits  bool // Whether the agent follows split requests
+	prompts []string
+}
+
+func (g *splittingGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.prompts = append(g.prompts, req.Prompt)
+	lines := 40
+	if len(g.prompts) > 1 && g.splits {
+		lines = 5
+	}
+	content := "package main\n" + strings.Repeat("var _ = 0\n", lines-1)
+	if err := os.WriteFile(filepath.Join(g.root, "handler.go"), []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	return &output.AgentResponse{Output: "implemented", AgentType: "claude-code-cli"}, nil
+}
+
+func (g *splittingGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "claude-code-cli"}
+}
+
+func (g *splittingGateway) HealthCheck(ctx context.Context) error { return nil }
+
+// initChangeBudgetRepo creates a git repository with one commit and returns its root
+func initChangeBudgetRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644))
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "main.go"},
+		{"-c", "user.name=Alice", "-c", "user.email=alice@example.com", "commit", "-q", "-m", "Initial import"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return root
+}
+
+func TestExecuteStep_ChangeBudget(t *testing.T) {
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	budget := domainservice.ChangeBudget{MaxLines: 10}
+
+	tests := []struct {
+		name     string
+		onExceed string
+		splits   bool
+		want     *dto.ChangeBudgetCheck
+		prompts  int
+	}{
+		{"split", domainservice.ChangeBudgetSplit, true, &dto.ChangeBudgetCheck{LinesChanged: 5, FilesChanged: 1, SplitRequests: 1, Outcome: dto.ChangeBudgetSplit}, 2},
+		{"split ignored", domainservice.ChangeBudgetSplit, false, &dto.ChangeBudgetCheck{LinesChanged: 40, FilesChanged: 1, SplitRequests: 1, Outcome: dto.ChangeBudgetFlagged}, 2},
+		{"flag", domainservice.ChangeBudgetFlag, true, &dto.ChangeBudgetCheck{LinesChanged: 40, FilesChanged: 1, Outcome: dto.ChangeBudgetFlagged}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := initChangeBudgetRepo(t)
+			gateway := &splittingGateway{root: root, splits: tt.splits}
+			uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+			uc.SetProjectRoot(root)
+			uc.SetArtifactRetries(0)
+			uc.SetChangeBudget(service.NewChangeBudgetChecker(root, budget, tt.onExceed, 1, nil))
+
+			stepOutput, err := uc.executeStep(context.Background(), task, "implement", 1, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, stepOutput.ChangeBudget)
+			require.Len(t, gateway.prompts, tt.prompts)
+			if tt.prompts > 1 {
+				assert.Contains(t, gateway.prompts[1], "it changes 40 lines in 1 file, over the budget of 10 lines per turn")
+			}
+			assert.Equal(t, tt.want.Outcome, uc.changeBudgetArtifact(stepOutput)["outcome"])
+
+			// Review steps are not checked
+			stepOutput, err = uc.executeStep(context.Background(), task, "review", 1, 1)
+			require.NoError(t, err)
+			assert.Nil(t, stepOutput.ChangeBudget)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+func TestExecuteStep_FailsFastWhenPromptExceedsContextWindow(t *testing.T) {
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+
+	gateway := &versionedGateway{}
+	root := t.TempDir()
+	uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+	uc.SetProjectRoot(root)
+	uc.SetContextWindowCheck(service.NewContextWindowCheck(root, service.ContextWindowFail, map[string]int{"": 1}))
+
+	_, err = uc.executeStep(context.Background(), task, "implement", 1, 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, service.ErrContextWindowExceeded))
+	assert.Equal(t, 0, gateway.calls, "the agent is not started")
+
+	// Prompts that fit the window are dispatched
+	uc.SetContextWindowCheck(service.NewContextWindowCheck(root, service.ContextWindowFail, map[string]int{"": 100000}))
+	_, err = uc.executeStep(context.Background(), task, "implement", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gateway.calls)
+}
@@ -0,0 +1,73 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// mcpLabelRepo is a LabelRepository holding labels by name
+type mcpLabelRepo struct {
+	repository.LabelRepository
+	labels map[string]*label.Label
+}
+
+func (r *mcpLabelRepo) FindByName(ctx context.Context, name string) (*label.Label, error) {
+	if lbl, ok := r.labels[name]; ok {
+		return lbl, nil
+	}
+	return nil, fmt.Errorf("label not found: %s", name)
+}
+
+// mcpGateway records the MCP servers it was given
+type mcpGateway struct {
+	supportsMCP bool
+	servers     map[string]output.MCPServer
+}
+
+func (g *mcpGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.servers = req.MCPServers
+	return &output.AgentResponse{Output: "implemented", AgentType: "claude-code-cli"}, nil
+}
+
+func (g *mcpGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "claude-code-cli", SupportsMCP: g.supportsMCP}
+}
+
+func (g *mcpGateway) HealthCheck(ctx context.Context) error { return nil }
+
+func TestExecuteStep_PassesLabelMCPServers(t *testing.T) {
+	backend := label.NewLabel("backend", "", nil, 0)
+	require.NoError(t, backend.SetMCPServers([]string{"db-schema"}))
+	schema := output.MCPServer{Command: "db-mcp"}
+	resolver := service.NewMCPResolver(&mcpLabelRepo{labels: map[string]*label.Label{"backend": backend}},
+		map[string]output.MCPServer{"db-schema": schema})
+
+	task, err := sbi.NewSBI("Add table", "Add the orders table", nil, sbi.SBIMetadata{Labels: []string{"backend"}, RegisteredAt: time.Now()})
+	require.NoError(t, err)
+
+	for _, supportsMCP := range []bool{true, false} {
+		gateway := &mcpGateway{supportsMCP: supportsMCP}
+		uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+		uc.SetProjectRoot(t.TempDir())
+		uc.SetMCPResolver(resolver)
+
+		_, err := uc.executeStep(context.Background(), task, "implement", 1, 1)
+		require.NoError(t, err)
+		if supportsMCP {
+			assert.Equal(t, map[string]output.MCPServer{"db-schema": schema}, gateway.servers)
+		} else {
+			assert.Nil(t, gateway.servers, "agents without MCP support run without the servers")
+		}
+	}
+}
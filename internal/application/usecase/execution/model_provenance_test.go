@@ -0,0 +1,104 @@
+package execution
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+// versionedGateway reports the model and CLI version it ran with
+type versionedGateway struct {
+	calls int
+}
+
+func (g *versionedGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.calls++
+	return &output.AgentResponse{
+		Output:    "DECISION: SUCCEEDED",
+		AgentType: "claude-code-cli",
+		Metadata:  map[string]string{"model": "claude-opus-4-1-20250805", "cli_version": "1.0.98 (Claude Code)"},
+	}, nil
+}
+
+func (g *versionedGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "claude-code-cli"}
+}
+
+func (g *versionedGateway) HealthCheck(ctx context.Context) error { return nil }
+
+func TestExecuteStep_RecordsArtifactProvenance(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sqlite.NewSBIRepository(db).Save(ctx, task))
+	sbiID := task.ID().String()
+
+	provenanceRepo := sqlite.NewArtifactProvenanceRepository(db)
+	uc := NewRunTurnUseCase(nil, nil, nil, &versionedGateway{}, 20, time.Minute)
+	uc.SetProjectRoot(t.TempDir())
+	uc.SetArtifactProvenanceRepository(provenanceRepo)
+
+	_, err = uc.executeStep(ctx, task, "implement", 1, 1)
+	require.NoError(t, err)
+	_, err = uc.executeStep(ctx, task, "review", 1, 1)
+	require.NoError(t, err)
+
+	records, err := provenanceRepo.FindBySBIID(ctx, sbiID)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "implement", records[0].Step)
+	assert.Equal(t, "", records[0].Decision)
+	assert.Equal(t, "review", records[1].Step)
+	assert.Equal(t, "SUCCEEDED", records[1].Decision)
+	assert.Equal(t, filepath.Join(".deespec", "reports", "sbi", sbiID, "review_1.md"), records[1].ArtifactPath)
+	// The agent wrote no report, so its output became the artifact
+	outputSum := sha256.Sum256([]byte("DECISION: SUCCEEDED"))
+	for _, record := range records {
+		assert.Equal(t, "claude-code-cli", record.AgentType)
+		assert.Equal(t, "claude-opus-4-1-20250805", record.Model, "the model the agent reports is recorded")
+		assert.Equal(t, "1.0.98 (Claude Code)", record.AgentVersion)
+		assert.Len(t, record.PromptHash, 64)
+		assert.Equal(t, hex.EncodeToString(outputSum[:]), record.ArtifactHash)
+	}
+	assert.NotEqual(t, records[0].PromptHash, records[1].PromptHash, "each step has its own prompt")
+}
+
+func TestExecuteStep_ModelPolicyBlocksDispatch(t *testing.T) {
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+
+	policy, err := domainservice.NewModelPolicy([]domainservice.ModelPolicyRule{{Step: "review", Family: "claude-opus", MinVersion: "4.1"}})
+	require.NoError(t, err)
+
+	gateway := &versionedGateway{}
+	uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+	uc.SetProjectRoot(t.TempDir())
+	uc.SetModelPolicy(policy)
+
+	// The agent default model cannot be verified against the policy
+	_, err = uc.executeStep(context.Background(), task, "review", 1, 1)
+	assert.ErrorIs(t, err, domainservice.ErrModelPolicyViolation)
+	assert.Equal(t, 0, gateway.calls, "the reviewer is not dispatched")
+
+	_, err = uc.executeStep(context.Background(), task, "implement", 1, 1)
+	require.NoError(t, err, "steps without rules run on any model")
+	assert.Equal(t, 1, gateway.calls)
+}
@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// memoryNoteRepo keeps SBI notes in memory
+type memoryNoteRepo struct {
+	repository.SBINoteRepository
+	notes []*repository.SBINote
+}
+
+func (r *memoryNoteRepo) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.SBINote, error) {
+	var result []*repository.SBINote
+	for _, note := range r.notes {
+		if note.SBIID == sbiID {
+			result = append(result, note)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryNoteRepo) FindForPrompt(ctx context.Context, sbiID string, turn int) ([]*repository.SBINote, error) {
+	var result []*repository.SBINote
+	for _, note := range r.notes {
+		if note.SBIID == sbiID && note.InjectPrompt && (note.InjectedTurn == 0 || note.InjectedTurn == turn) {
+			result = append(result, note)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryNoteRepo) MarkInjected(ctx context.Context, ids []int64, turn int) error {
+	for _, note := range r.notes {
+		for _, id := range ids {
+			if note.ID == id {
+				note.InjectedTurn = turn
+			}
+		}
+	}
+	return nil
+}
+
+// TestBuildPromptWithArtifact_Notes verifies that notes marked for the prompt reach the
+// prior context of the next turn only
+func TestBuildPromptWithArtifact_Notes(t *testing.T) {
+	tmpDir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	promptsDir := filepath.Join(".deespec", "prompts")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "WIP.md"), []byte("# Implement\n{{.PriorContext}}"), 0644))
+
+	s, err := sbi.NewSBI("Login", "Add login", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	sbiID := s.ID().String()
+
+	notes := &memoryNoteRepo{notes: []*repository.SBINote{
+		{ID: 1, SBIID: sbiID, Body: "for people only", CreatedAt: time.Now()},
+		{ID: 2, SBIID: sbiID, Body: "blocked on infra ticket INFRA-42", Links: []string{"https://tracker.example/INFRA-42"}, InjectPrompt: true, CreatedAt: time.Now()},
+	}}
+	uc := NewRunTurnUseCase(nil, nil, nil, nil, 0, 0)
+	uc.SetNoteRepository(notes)
+	ctx := context.Background()
+
+	prompt := uc.buildPromptWithArtifact(ctx, s, "implement", 2, 1, "implement_2.md")
+	assert.Contains(t, prompt, "## Notes from the Team")
+	assert.Contains(t, prompt, "blocked on infra ticket INFRA-42\n  - Link: https://tracker.example/INFRA-42\n")
+	assert.NotContains(t, prompt, "for people only")
+	assert.Equal(t, 2, notes.notes[1].InjectedTurn)
+
+	// A retry of the same turn gets the note again, the next turn does not
+	assert.Contains(t, uc.buildPromptWithArtifact(ctx, s, "implement", 2, 2, "implement_2.md"), "INFRA-42")
+	assert.NotContains(t, uc.buildPromptWithArtifact(ctx, s, "implement", 3, 1, "implement_3.md"), "Notes from the Team")
+
+	// The journal of turn 2 records the note it received
+	assert.Equal(t, map[string]interface{}{
+		"type":  "prompt_notes",
+		"turn":  2,
+		"notes": []interface{}{map[string]interface{}{"id": int64(2), "body": "blocked on infra ticket INFRA-42", "author": ""}},
+	}, uc.promptNotesArtifact(ctx, sbiID, 2))
+	assert.Nil(t, uc.promptNotesArtifact(ctx, sbiID, 3))
+}
@@ -0,0 +1,61 @@
+package execution
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// memoryPromptArchive keeps archived entries in memory
+type memoryPromptArchive struct {
+	entries []*repository.PromptArchiveEntry
+}
+
+func (a *memoryPromptArchive) Save(ctx context.Context, entry *repository.PromptArchiveEntry) error {
+	a.entries = append(a.entries, entry)
+	return nil
+}
+
+func (a *memoryPromptArchive) List(ctx context.Context, sbiID string) ([]*repository.PromptArchiveEntry, error) {
+	return a.entries, nil
+}
+
+func (a *memoryPromptArchive) Prune(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestExecuteStep_ArchivesPrompts(t *testing.T) {
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	root := t.TempDir()
+	gateway := &lateReportGateway{root: root, writeOn: 2}
+	archive := &memoryPromptArchive{}
+	uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+	uc.SetProjectRoot(root)
+	uc.SetArtifactRetries(1)
+	uc.SetPromptArchive(archive)
+
+	_, err = uc.executeStep(context.Background(), task, "implement", 1, 1)
+	require.NoError(t, err)
+
+	// The step prompt and the corrective prompt are both archived as sent
+	require.Len(t, archive.entries, 2)
+	for i, entry := range archive.entries {
+		assert.Equal(t, task.ID().String(), entry.SBIID)
+		assert.Equal(t, 1, entry.Turn)
+		assert.Equal(t, "implement", entry.Step)
+		assert.Equal(t, "claude-code", entry.AgentType)
+		assert.Equal(t, gateway.prompts[i], entry.Prompt)
+		assert.Equal(t, "implemented the handler", entry.Response)
+		assert.False(t, entry.ArchivedAt.IsZero())
+	}
+	assert.Equal(t, task.ID().String(), archive.entries[0].Context["sbi_id"])
+	assert.True(t, strings.HasPrefix(archive.entries[1].Prompt, "The implement report was not written"))
+}
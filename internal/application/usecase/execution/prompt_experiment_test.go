@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// TestBuildPromptWithArtifact_PromptExperiment verifies that SBIs of the treatment arm are prompted
+// with the variant template and that the journal artifact names the arm
+func TestBuildPromptWithArtifact_PromptExperiment(t *testing.T) {
+	root := t.TempDir()
+	promptsDir := filepath.Join(root, ".deespec", "prompts")
+	require.NoError(t, os.MkdirAll(filepath.Join(promptsDir, "experiments"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "WIP.md"), []byte("control {{.SBIID}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "experiments", "WIP.v2.md"), []byte("treatment {{.SBIID}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "REVIEW.md"), []byte("review"), 0644))
+
+	experiment := &domainservice.PromptExperiment{Name: "wip-v2", Template: "WIP.md", Variant: "experiments/WIP.v2.md"}
+	uc := NewRunTurnUseCase(nil, nil, nil, nil, 0, 0)
+	uc.SetProjectRoot(root)
+	ctx := context.Background()
+
+	s, err := sbi.NewSBI("Login", "Add login", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	sbiID := s.ID().String()
+	assert.Equal(t, "control "+sbiID, uc.buildPromptWithArtifact(ctx, s, "implement", 1, 1, "implement_1.md"))
+	assert.Nil(t, uc.promptExperimentArtifact(sbiID))
+
+	uc.SetPromptExperiment(experiment)
+	seen := map[string]bool{}
+	for i := 0; i < 64 && len(seen) < 2; i++ {
+		s, err := sbi.NewSBI("Login", "Add login", nil, sbi.SBIMetadata{})
+		require.NoError(t, err)
+		sbiID := s.ID().String()
+		arm := experiment.Assign(sbiID)
+		seen[arm] = true
+
+		assert.Equal(t, arm+" "+sbiID, uc.buildPromptWithArtifact(ctx, s, "implement", 1, 1, "implement_1.md"))
+		// Other templates are not part of the experiment
+		assert.Equal(t, "review", uc.buildPromptWithArtifact(ctx, s, "review", 2, 1, "review_2.md"))
+		assert.Equal(t, map[string]interface{}{
+			"type":       "prompt_experiment",
+			"experiment": "wip-v2",
+			"template":   "WIP.md",
+			"variant":    arm,
+		}, uc.promptExperimentArtifact(sbiID))
+	}
+	assert.Len(t, seen, 2, "both arms should be assigned")
+}
@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+// submittedReview is a review submitted through the cache's report func
+type submittedReview struct {
+	turn     int
+	step     string
+	decision string
+	content  string
+}
+
+func TestExecuteStep_CachedReviewIsSubmitted(t *testing.T) {
+	ctx := context.Background()
+	root := initChangeBudgetRepo(t)
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+
+	var submitted []submittedReview
+	var reportErr error
+	report := func(ctx context.Context, sbiID string, turn int, step, decision, content string) error {
+		if reportErr != nil {
+			return reportErr
+		}
+		submitted = append(submitted, submittedReview{turn, step, decision, content})
+		return nil
+	}
+
+	gateway := &versionedGateway{}
+	uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+	uc.SetProjectRoot(root)
+	uc.SetArtifactRetries(0)
+	cache := service.NewAgentResponseCache(sqlite.NewAgentResponseCacheRepository(db), root, service.CacheableSteps, 0)
+	uc.SetAgentResponseCache(cache, report)
+
+	stepOutput, err := uc.executeStep(ctx, task, "review", 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCEEDED", stepOutput.Decision)
+	assert.Equal(t, 1, gateway.calls)
+	assert.Empty(t, submitted, "the live reviewer submits its own review")
+
+	// A later turn reviewing the same code reuses the review and submits its decision
+	stepOutput, err = uc.executeStep(ctx, task, "review", 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gateway.calls, "the agent is not called on a hit")
+	assert.Equal(t, "SUCCEEDED", stepOutput.Decision)
+	assert.Equal(t, []submittedReview{{3, "review", "SUCCEEDED", "DECISION: SUCCEEDED"}}, submitted)
+
+	// Changed code is reviewed again
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+	_, err = uc.executeStep(ctx, task, "review", 4, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, gateway.calls)
+
+	// A hit that cannot be submitted falls back to the agent
+	reportErr = errors.New("SBI is not in review")
+	_, err = uc.executeStep(ctx, task, "review", 5, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, gateway.calls)
+	assert.Len(t, submitted, 1)
+}
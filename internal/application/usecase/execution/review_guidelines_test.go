@@ -0,0 +1,65 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// reviewGuidanceRepo serves review guidance per label
+type reviewGuidanceRepo struct {
+	repository.PromptTemplateRepository
+	guidance map[string]string
+}
+
+func (r *reviewGuidanceRepo) LoadLabelReviewContent(ctx context.Context, labelName string) string {
+	return r.guidance[labelName]
+}
+
+// TestBuildPromptWithArtifact_ReviewGuidelines verifies that the review guidance of the
+// SBI's labels reaches the REVIEW.md template data, and stays out of implement prompts
+func TestBuildPromptWithArtifact_ReviewGuidelines(t *testing.T) {
+	tmpDir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	promptsDir := filepath.Join(".deespec", "prompts")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "REVIEW.md"),
+		[]byte("# Review\n{{if .ReviewGuidelines}}\n{{.ReviewGuidelines}}{{end}}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "WIP.md"),
+		[]byte("# Implement\n{{.ReviewGuidelines}}"), 0644))
+
+	s, err := sbi.NewSBI("Login", "Add login", nil, sbi.SBIMetadata{Labels: []string{"auth", "frontend", "security/crypto"}})
+	require.NoError(t, err)
+
+	uc := NewRunTurnUseCase(nil, nil, nil, nil, 0, 0)
+	ctx := context.Background()
+
+	// Without a repository the template data stays empty
+	assert.Equal(t, "# Review\n", uc.buildPromptWithArtifact(ctx, s, "review", 2, 1, "review_2.md"))
+
+	uc.SetPromptTemplateRepository(&reviewGuidanceRepo{guidance: map[string]string{
+		"auth":            "- Tokens are never logged\n",
+		"security/crypto": "- No home-grown crypto",
+	}})
+
+	prompt := uc.buildPromptWithArtifact(ctx, s, "review", 2, 1, "review_2.md")
+	assert.Contains(t, prompt, "## Label-Specific Review Guidelines")
+	assert.Contains(t, prompt, "### Label: auth\n- Tokens are never logged\n")
+	assert.Contains(t, prompt, "### Label: security/crypto\n- No home-grown crypto\n")
+	assert.NotContains(t, prompt, "Label: frontend")
+	assert.Less(t, strings.Index(prompt, "Label: auth"), strings.Index(prompt, "Label: security/crypto"))
+
+	assert.Equal(t, "# Implement\n", uc.buildPromptWithArtifact(ctx, s, "implement", 1, 1, "implement_1.md"))
+}
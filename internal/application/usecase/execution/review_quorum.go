@@ -0,0 +1,287 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+// ReviewQuorumReviewer is one reviewer agent voting in review quorum mode
+type ReviewQuorumReviewer struct {
+	Name    string // Reviewer name, used in report file names and DEESPEC_REVIEWER
+	Gateway output.AgentGateway
+	Model   string // Optional model override
+}
+
+// ReviewReportFunc submits the turn's aggregated review the way `deespec sbi report` does
+type ReviewReportFunc func(ctx context.Context, sbiID string, turn int, step string, decision string, content string) error
+
+// reviewQuorum holds the review quorum mode settings
+type reviewQuorum struct {
+	reviewers []ReviewQuorumReviewer
+	rule      domainservice.ReviewQuorumRule
+	voteRepo  repository.ReviewVoteRepository
+	report    ReviewReportFunc
+}
+
+// reviewerResult is the outcome of one reviewer's review
+type reviewerResult struct {
+	reviewer   string
+	decision   string
+	source     string // vote, report, agent_output or none
+	reportPath string // Local report path, relative to the project root
+	reportFile string // reportPath for file access
+	output     string
+	err        error
+}
+
+// SetReviewQuorum enables review quorum mode: the review step fans out to every reviewer,
+// and their votes are aggregated by rule into the turn's decision, which is submitted via report.
+// Fewer than two reviewers leave the review step to a single agent.
+func (uc *RunTurnUseCase) SetReviewQuorum(reviewers []ReviewQuorumReviewer, rule domainservice.ReviewQuorumRule, voteRepo repository.ReviewVoteRepository, report ReviewReportFunc) {
+	if len(reviewers) < 2 || voteRepo == nil || report == nil {
+		uc.reviewQuorum = nil
+		return
+	}
+	uc.reviewQuorum = &reviewQuorum{
+		reviewers: reviewers,
+		rule:      rule,
+		voteRepo:  voteRepo,
+		report:    report,
+	}
+}
+
+// executeReviewQuorum runs every reviewer concurrently and submits the aggregated decision
+// Each reviewer's report is kept as review_<turn>_<reviewer>.md; the combined report becomes review_<turn>.md
+func (uc *RunTurnUseCase) executeReviewQuorum(ctx context.Context, sbiEntity *sbi.SBI, turn int, attempt int) (*dto.ExecuteStepOutput, error) {
+	sbiID := sbiEntity.ID().String()
+	quorum := uc.reviewQuorum
+	startTime := time.Now()
+	failed := func(err error) (*dto.ExecuteStepOutput, error) {
+		return &dto.ExecuteStepOutput{
+			Success:     false,
+			ErrorMsg:    err.Error(),
+			ElapsedMs:   time.Since(startTime).Milliseconds(),
+			StartedAt:   startTime,
+			CompletedAt: time.Now(),
+		}, err
+	}
+
+	// The self-review guard drops reviewers running on the implementer's agent and model
+	reviewers := quorum.reviewers
+	if uc.agentRegistry != nil {
+		implementer := uc.findImplementer(ctx, sbiID)
+		reviewers = nil
+		for _, reviewer := range quorum.reviewers {
+			if uc.agentRegistry.IsSelfReview(reviewer.Gateway, reviewer.Model, implementer) {
+				fmt.Fprintf(os.Stderr, "[review-quorum] SBI=%s, Reviewer=%s skipped (self-review guard)\n", sbiID, reviewer.Name)
+				continue
+			}
+			reviewers = append(reviewers, reviewer)
+		}
+		if len(reviewers) == 0 {
+			return failed(fmt.Errorf("self-review guard: every reviewer runs on the implementer's agent (%s, model %q)",
+				implementer.Agent, implementer.Model))
+		}
+	}
+
+	// Prompts are built up front: the first build may record the SBI's acceptance criteria
+	prompts := make([]string, len(reviewers))
+	for i, reviewer := range reviewers {
+		prompts[i] = uc.buildPromptWithArtifact(ctx, sbiEntity, "review", turn, attempt, reviewerReportPath(sbiID, turn, reviewer.Name))
+	}
+
+	results := make([]reviewerResult, len(reviewers))
+	var wg sync.WaitGroup
+	for i, reviewer := range reviewers {
+		wg.Add(1)
+		go func(i int, reviewer ReviewQuorumReviewer) {
+			defer wg.Done()
+			results[i] = uc.runReviewer(ctx, sbiEntity, turn, prompts[i], reviewer)
+		}(i, reviewer)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return failed(err)
+	}
+
+	// Votes submitted by reviewers via `deespec sbi report` take precedence over extracted decisions
+	voted := make(map[string]*repository.ReviewVote)
+	if votes, err := quorum.voteRepo.FindByTurn(ctx, sbiID, turn); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load review votes: %v\n", err)
+	} else {
+		for _, vote := range votes {
+			voted[vote.Reviewer] = vote
+		}
+	}
+
+	var decisions, errs []string
+	for i := range results {
+		result := &results[i]
+		if result.err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", result.reviewer, result.err))
+			continue
+		}
+		if vote, ok := voted[result.reviewer]; ok {
+			result.decision, result.source = vote.Decision, "vote"
+		} else {
+			// A review without an explicit decision does not approve the work
+			if result.decision == "" {
+				result.decision, result.source = "NEEDS_CHANGES", "none"
+			}
+			vote := &repository.ReviewVote{
+				SBIID:      sbiID,
+				Turn:       turn,
+				Reviewer:   result.reviewer,
+				Decision:   result.decision,
+				ReportPath: uc.offloadArtifact(ctx, result.reportPath),
+			}
+			if err := quorum.voteRepo.Save(ctx, vote); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to save review vote of %s: %v\n", result.reviewer, err)
+			}
+		}
+		decisions = append(decisions, result.decision)
+	}
+	if len(decisions) == 0 {
+		return failed(fmt.Errorf("all %d reviewers failed: %s", len(results), strings.Join(errs, "; ")))
+	}
+
+	decision := domainservice.AggregateReviewDecisions(quorum.rule, decisions)
+	fmt.Fprintf(os.Stderr, "[review-quorum] SBI=%s, Turn=%d, Rule=%s, Votes=%s, Decision=%s\n",
+		sbiID, turn, quorum.rule, strings.Join(decisions, ","), decision)
+
+	content := buildReviewQuorumReport(quorum.rule, decision, results)
+	if err := quorum.report(ctx, sbiID, turn, "review", decision, content); err != nil {
+		return failed(fmt.Errorf("failed to submit aggregated review: %w", err))
+	}
+
+	artifactPath := fmt.Sprintf(".deespec/reports/sbi/%s/review_%d.md", sbiID, turn)
+	if uc.artifactStore != nil {
+		artifactPath = uc.artifactStore.URI(strings.TrimPrefix(artifactPath, ".deespec/"))
+	}
+
+	return &dto.ExecuteStepOutput{
+		Success:      true,
+		Output:       content,
+		Decision:     decision,
+		ArtifactPath: artifactPath,
+		ElapsedMs:    time.Since(startTime).Milliseconds(),
+		StartedAt:    startTime,
+		CompletedAt:  time.Now(),
+	}, nil
+}
+
+// runReviewer executes one reviewer agent and extracts its decision from its own report
+func (uc *RunTurnUseCase) runReviewer(ctx context.Context, sbiEntity *sbi.SBI, turn int, prompt string, reviewer ReviewQuorumReviewer) reviewerResult {
+	sbiID := sbiEntity.ID().String()
+	artifactPath := reviewerReportPath(sbiID, turn, reviewer.Name)
+	result := reviewerResult{reviewer: reviewer.Name, reportPath: artifactPath, reportFile: uc.projectPath(artifactPath)}
+
+	agentReq, err := uc.newAgentRequest(ctx, sbiEntity, "review", turn, prompt)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	// `deespec sbi report` run by the reviewer records a vote instead of deciding the turn
+	if agentReq.Env == nil {
+		agentReq.Env = make(map[string]string)
+	}
+	agentReq.Env[domainservice.ReviewerEnvKey] = reviewer.Name
+	agentReq.Context["reviewer"] = reviewer.Name
+	agentReq.Model = reviewer.Model
+	if err := uc.modelPolicy.Check("review", agentReq.Model); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Reviewer %s of %s not dispatched: %v\n", reviewer.Name, sbiID, err)
+		result.err = err
+		return result
+	}
+	dropUnsupportedMCP(reviewer.Gateway.GetCapability(), &agentReq, sbiID, "review")
+	if err := uc.fitContextWindow(sbiID, "review", reviewer.Gateway.GetCapability(), &agentReq); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Reviewer %s of %s not dispatched: %v\n", reviewer.Name, sbiID, err)
+		result.err = err
+		return result
+	}
+
+	uc.publishEvent(output.TurnEventAgentStarted, sbiID, turn, "review", map[string]string{
+		"agent":    reviewer.Gateway.GetCapability().AgentType,
+		"model":    agentReq.Model,
+		"reviewer": reviewer.Name,
+	})
+
+	agentResult, err := reviewer.Gateway.Execute(ctx, agentReq)
+	uc.recordAgentCall(ctx, sbiID, "review", turn, reviewer.Gateway.GetCapability().AgentType)
+	uc.archivePrompt(ctx, sbiID, "review", turn, reviewer.Gateway.GetCapability().AgentType, agentReq, agentResult, err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Reviewer %s failed for %s: %v\n", reviewer.Name, sbiID, err)
+		result.err = err
+		return result
+	}
+	result.output = agentResult.Output
+	if agentResult.Output != "" {
+		uc.publishEvent(output.TurnEventAgentOutputChunk, sbiID, turn, "review",
+			map[string]string{"chunk": agentResult.Output, "reviewer": reviewer.Name})
+	}
+
+	// Keep the reviewer's output as its report when it did not write one
+	if _, err := os.Stat(result.reportFile); err != nil {
+		if err := os.MkdirAll(filepath.Dir(result.reportFile), 0755); err == nil {
+			if err := fs.WriteFileAtomic(result.reportFile, []byte(agentResult.Output), 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to write report of reviewer %s: %v\n", reviewer.Name, err)
+			}
+		}
+	}
+
+	result.decision, result.source = uc.extractDecision(artifactPath, agentResult.Output, sbiID)
+	uc.recordProvenance(ctx, &repository.ArtifactProvenance{
+		SBIID: sbiID, Step: "review", Turn: turn, ArtifactPath: artifactPath, Decision: result.decision,
+		AgentType: reviewer.Gateway.GetCapability().AgentType, Model: agentReq.Model, Reviewer: reviewer.Name,
+		ArtifactHash: uc.hashArtifact(artifactPath),
+	}, agentReq.Prompt, agentResult)
+	return result
+}
+
+// reviewerReportPath returns the local path of a reviewer's own report
+func reviewerReportPath(sbiID string, turn int, reviewer string) string {
+	return fmt.Sprintf(".deespec/reports/sbi/%s/review_%d_%s.md", sbiID, turn, reviewer)
+}
+
+// buildReviewQuorumReport combines the reviewers' reports into the turn's review report
+func buildReviewQuorumReport(rule domainservice.ReviewQuorumRule, decision string, results []reviewerResult) string {
+	var sb strings.Builder
+	sb.WriteString("## Review Quorum\n")
+	sb.WriteString(fmt.Sprintf("DECISION: %s\n\n", decision))
+	sb.WriteString(fmt.Sprintf("Rule: %s\n\n", rule))
+	sb.WriteString("| Reviewer | Decision | Source |\n")
+	sb.WriteString("|----------|----------|--------|\n")
+	for _, result := range results {
+		if result.err != nil {
+			sb.WriteString(fmt.Sprintf("| %s | ERROR | %s |\n", result.reviewer, strings.ReplaceAll(result.err.Error(), "|", "/")))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", result.reviewer, result.decision, result.source))
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		content := result.output
+		if data, err := os.ReadFile(result.reportFile); err == nil {
+			content = string(data)
+		}
+		sb.WriteString(fmt.Sprintf("\n## Reviewer: %s (%s)\n\n", result.reviewer, result.decision))
+		sb.WriteString(strings.TrimSpace(content))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,144 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/storage"
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// quorumReviewerGateway reviews with a fixed decision, either voting via `deespec sbi report`
+// or only stating the decision in its output
+type quorumReviewerGateway struct {
+	output.AgentGateway
+	decision string
+	vote     func(ctx context.Context, sbiID string, turn int, reviewer string, decision string, content string) error
+	err      error
+}
+
+func (g *quorumReviewerGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	content := fmt.Sprintf("## Review\nDECISION: %s\n", g.decision)
+	if g.vote != nil {
+		var turn int
+		fmt.Sscan(req.Context["turn"], &turn)
+		if err := g.vote(ctx, req.Context["sbi_id"], turn, req.Env[domainservice.ReviewerEnvKey], g.decision, content); err != nil {
+			return nil, err
+		}
+	}
+	return &output.AgentResponse{Output: content}, nil
+}
+
+func (g *quorumReviewerGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "quorum-test"}
+}
+
+// TestRunTurn_ReviewQuorum verifies that reviewer votes are aggregated by rule into the turn's
+// decision, and that each reviewer's report is stored separately
+func TestRunTurn_ReviewQuorum(t *testing.T) {
+	tests := []struct {
+		rule       domainservice.ReviewQuorumRule
+		wantStatus model.Status
+	}{
+		{domainservice.ReviewQuorumMajority, model.StatusDone},
+		{domainservice.ReviewQuorumAnyFailBlocks, model.StatusImplementing},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			ctx := context.Background()
+			tmpDir := t.TempDir()
+
+			wd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(tmpDir))
+			defer func() { _ = os.Chdir(wd) }()
+
+			db, err := sql.Open("sqlite3", ":memory:")
+			require.NoError(t, err)
+			defer db.Close()
+			require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+			sbiRepo := sqlite.NewSBIRepository(db)
+			voteRepo := sqlite.NewReviewVoteRepository(db)
+			journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+			artifactStore := storage.NewLocalArtifactStore(".deespec")
+			reportUseCase := usecase.NewReportSBIUseCase(
+				sbiRepo,
+				journalRepo,
+				sqlite.NewSBIExecLogRepository(db),
+				sqlite.NewReviewFeedbackRepository(db),
+				sqlite.NewAcceptanceCriteriaRepository(db),
+				artifactStore,
+			)
+			reportUseCase.SetAllowInProgressTurn(true)
+			reportUseCase.SetReviewVoteRepository(voteRepo)
+
+			task, err := sbi.NewSBI("Quorum task", "Reviewed by three agents", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+			require.NoError(t, err)
+			require.NoError(t, task.UpdateStatus(model.StatusPicked))
+			require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+			require.NoError(t, task.UpdateStatus(model.StatusReviewing))
+			task.IncrementTurn()
+			require.NoError(t, sbiRepo.Save(ctx, task))
+			sbiID := task.ID().String()
+
+			// The single-agent gateway must not be used for the review step
+			uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, &quorumReviewerGateway{err: errors.New("unexpected call")}, 20, time.Minute)
+			uc.SetArtifactStore(artifactStore)
+			uc.SetReviewQuorum([]ReviewQuorumReviewer{
+				{Name: "design", Gateway: &quorumReviewerGateway{decision: "SUCCEEDED", vote: reportUseCase.SubmitReviewVote}},
+				{Name: "flaky", Gateway: &quorumReviewerGateway{err: errors.New("agent crashed")}},
+				{Name: "security", Gateway: &quorumReviewerGateway{decision: "FAILED"}},
+				{Name: "style", Gateway: &quorumReviewerGateway{decision: "SUCCEEDED"}},
+			}, tt.rule, voteRepo, reportUseCase.Execute)
+
+			out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+			require.NoError(t, err)
+			assert.Empty(t, out.ErrorMsg)
+
+			final, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, final.Status())
+
+			// Votes are listed by reviewer; the crashed reviewer casts none
+			votes, err := voteRepo.FindByTurn(ctx, sbiID, 2)
+			require.NoError(t, err)
+			var decisions []string
+			for _, vote := range votes {
+				decisions = append(decisions, vote.Decision)
+			}
+			assert.Equal(t, []string{"SUCCEEDED", "FAILED", "SUCCEEDED"}, decisions)
+
+			for _, name := range []string{"design", "security", "style"} {
+				assert.FileExists(t, filepath.Join(".deespec", "reports", "sbi", sbiID, fmt.Sprintf("review_2_%s.md", name)))
+			}
+			combined, err := os.ReadFile(filepath.Join(".deespec", "reports", "sbi", sbiID, "review_2.md"))
+			require.NoError(t, err)
+			assert.Contains(t, string(combined), "| security | FAILED | report |")
+			assert.Contains(t, string(combined), "| design | SUCCEEDED | vote |")
+			assert.Contains(t, string(combined), "| flaky | ERROR | agent crashed |")
+		})
+	}
+}
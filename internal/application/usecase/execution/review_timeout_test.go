@@ -0,0 +1,142 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentgateway "github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// TestRunTurn_ReviewTimeout verifies that a review stalled beyond the SLA is journaled and
+// routed per policy instead of being reviewed again
+func TestRunTurn_ReviewTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		script     []agentgateway.SimulationStep
+		wantStatus model.Status
+		wantNext   string
+	}{
+		{
+			name:       "retry sends the SBI back to implementation",
+			policy:     service.ReviewTimeoutRetry,
+			wantStatus: model.StatusImplementing,
+			wantNext:   "WIP",
+		},
+		{
+			name:       "force_implement finishes the SBI",
+			policy:     service.ReviewTimeoutForceImplement,
+			script:     []agentgateway.SimulationStep{{Step: "force_implement", Output: "finished by the reviewer"}},
+			wantStatus: model.StatusDone,
+			wantNext:   "DONE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tmpDir := t.TempDir()
+
+			wd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(tmpDir))
+			defer func() { _ = os.Chdir(wd) }()
+
+			db, err := sql.Open("sqlite3", ":memory:")
+			require.NoError(t, err)
+			defer db.Close()
+			require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+			sbiRepo := sqlite.NewSBIRepository(db)
+			journalPath := filepath.Join(tmpDir, "journal.ndjson")
+			journalRepo := infrarepo.NewJournalRepositoryImpl(journalPath)
+
+			task, err := sbi.NewSBI("Stalled review", "The reviewer crashed", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+			require.NoError(t, err)
+			for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing} {
+				require.NoError(t, task.UpdateStatus(next))
+			}
+			metadata := task.Metadata()
+			stalledSince := time.Now().Add(-time.Hour)
+			metadata.ReviewStartedAt = &stalledSince
+			task.UpdateMetadata(metadata)
+			require.NoError(t, sbiRepo.Save(ctx, task))
+			sbiID := task.ID().String()
+
+			sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{Steps: tt.script})
+			uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 20, time.Minute)
+			uc.SetReviewTimeout(service.NewReviewTimeout(30*time.Minute, tt.policy))
+
+			out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+			require.NoError(t, err)
+			assert.Equal(t, "REVIEW_TIMEOUT", out.Decision)
+			assert.Equal(t, tt.wantNext, out.NextStatus)
+			assert.Contains(t, out.ErrorMsg, "review decision not received within 30m0s")
+
+			saved, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, saved.Status())
+			assert.Nil(t, saved.ReviewStartedAt())
+
+			records, err := journalRepo.FindBySBI(ctx, sbiID)
+			require.NoError(t, err)
+			require.NotEmpty(t, records)
+			last := records[len(records)-1]
+			assert.Equal(t, "review_timeout", last.Step)
+			assert.Equal(t, service.ErrorClassAgentTimeout, last.ErrorClass)
+			assert.Equal(t, "REVIEW_TIMEOUT", last.Decision)
+			assert.Equal(t, tt.wantNext, last.Status)
+		})
+	}
+}
+
+// TestRunTurn_ReviewWithinTimeout verifies that a review within the SLA runs the review step
+func TestRunTurn_ReviewWithinTimeout(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+
+	task, err := sbi.NewSBI("Fresh review", "Reviewed in time", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing} {
+		require.NoError(t, task.UpdateStatus(next))
+	}
+	require.NoError(t, sbiRepo.Save(ctx, task))
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{{Step: "review", Decision: "SUCCEEDED", Output: "LGTM"}},
+	})
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 20, time.Minute)
+	uc.SetReviewTimeout(service.NewReviewTimeout(30*time.Minute, service.ReviewTimeoutRetry))
+
+	out, err := uc.ExecuteForSBI(ctx, task.ID().String(), dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.NotEqual(t, "REVIEW_TIMEOUT", out.Decision)
+}
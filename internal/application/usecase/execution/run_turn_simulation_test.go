@@ -0,0 +1,755 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentgateway "github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/storage"
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// TestRunTurn_SimulationWorkflow drives pick → implement → review → implement → review → done
+// through the real use cases with a scripted simulation agent
+func TestRunTurn_SimulationWorkflow(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	// Step artifacts are written relative to the working directory
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+	artifactStore := storage.NewLocalArtifactStore(tmpDir)
+	reportUseCase := usecase.NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		artifactStore,
+	)
+
+	task, err := sbi.NewSBI("Simulated task", "Exercise the workflow", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{
+			{Step: "implement", Output: "first attempt"},
+			{Step: "review", Decision: "NEEDS_CHANGES", Output: "## Issues\n- Missing tests"},
+			{Step: "implement", Output: "added tests"},
+			{Step: "review", Decision: "SUCCEEDED", Output: "LGTM"},
+		},
+	})
+	reportUseCase.SetAllowInProgressTurn(true)
+	sim.SetReporter(reportUseCase.Execute)
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 20, time.Minute)
+	uc.SetArtifactStore(artifactStore)
+
+	var statuses []string
+	for i := 0; i < 10; i++ {
+		out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+		require.NoError(t, err)
+		statuses = append(statuses, out.NextStatus)
+		if out.TaskCompleted {
+			break
+		}
+	}
+
+	final, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDone, final.Status(), "status history: %v", statuses)
+	assert.Equal(t, []string{"WIP", "WIP", "REVIEW", "WIP", "REVIEW", "DONE"}, statuses)
+}
+
+// TestRunTurn_CancelDuringExecution verifies that `sbi cancel` aborts a running agent call
+func TestRunTurn_CancelDuringExecution(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", filepath.Join(tmpDir, "deespec.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+
+	task, err := sbi.NewSBI("Long task", "Cancelled mid-flight", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{{Step: "implement", DelayMs: 10000}},
+	})
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 20, time.Minute)
+	uc.SetCancelPollInterval(20 * time.Millisecond)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancelUseCase := usecase.NewCancelSBIUseCase(sbiRepo, journalRepo, nil)
+		assert.NoError(t, cancelUseCase.Execute(ctx, sbiID, "requirements changed"))
+	}()
+
+	start := time.Now()
+	out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "agent call should be aborted")
+	assert.Equal(t, "CANCELLED", out.Decision)
+	assert.True(t, out.TaskCompleted)
+
+	final, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusCancelled, final.Status())
+	assert.Equal(t, "cancelled: requirements changed", final.ExecutionState().LastError)
+
+	// Cancelled SBIs are not executed again
+	out, err = uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.True(t, out.NoOp)
+}
+
+// afterStepGateway runs a hook once the wrapped agent call has returned
+type afterStepGateway struct {
+	output.AgentGateway
+	after func()
+}
+
+func (g *afterStepGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	resp, err := g.AgentGateway.Execute(ctx, req)
+	g.after()
+	return resp, err
+}
+
+// TestRunTurn_CancelAfterStepReturns verifies that a cancel landing between the last
+// cancellation poll and the save is not overwritten by the stale in-memory SBI
+func TestRunTurn_CancelAfterStepReturns(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", filepath.Join(tmpDir, "deespec.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+
+	task, err := sbi.NewSBI("Quick task", "Cancelled right after the agent returns", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{{Step: "implement", Output: "done quickly"}},
+	})
+	gateway := &afterStepGateway{AgentGateway: sim, after: func() {
+		cancelUseCase := usecase.NewCancelSBIUseCase(sbiRepo, journalRepo, nil)
+		require.NoError(t, cancelUseCase.Execute(ctx, sbiID, "superseded"))
+	}}
+
+	// The default 5s poll never fires for a fast step
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, gateway, 20, time.Minute)
+
+	out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "CANCELLED", out.Decision)
+	assert.True(t, out.TaskCompleted)
+
+	final, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusCancelled, final.Status())
+	assert.Equal(t, "cancelled: superseded", final.ExecutionState().LastError)
+}
+
+// TestRunTurn_ConcurrentSaveDuringStep verifies that a save of the SBI by another process while
+// the step runs is kept, and the turn's decision is applied on top of it instead of overwriting it
+func TestRunTurn_ConcurrentSaveDuringStep(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", filepath.Join(tmpDir, "deespec.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+
+	task, err := sbi.NewSBI("Original title", "Edited while the agent runs", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+	startTurn := task.ExecutionState().CurrentTurn.Value()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{{Step: "implement", Output: "implemented"}},
+	})
+	gateway := &afterStepGateway{AgentGateway: sim, after: func() {
+		// Like `sbi report implement`: edit the title and move the SBI to REVIEWING
+		edited, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+		require.NoError(t, err)
+		require.NoError(t, edited.UpdateTitle("Edited title"))
+		require.NoError(t, edited.UpdateStatus(model.StatusReviewing))
+		require.NoError(t, sbiRepo.Save(ctx, edited))
+	}}
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, gateway, 20, time.Minute)
+
+	out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "REVIEW", out.NextStatus)
+
+	final, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, "Edited title", final.Title(), "the concurrent save is not overwritten")
+	assert.Equal(t, model.StatusReviewing, final.Status())
+	assert.Equal(t, startTurn+1, final.ExecutionState().CurrentTurn.Value(), "the turn's decision is applied on top")
+}
+
+// failingJournal fails every append
+type failingJournal struct {
+	repository.JournalRepository
+}
+
+func (j *failingJournal) Append(ctx context.Context, record *repository.JournalRecord) error {
+	return fmt.Errorf("journal unavailable")
+}
+
+// TestRunTurn_UnitOfWorkCommitsSaveWithJournal verifies that within a unit of work the SBI save
+// and its journal entry are kept or lost together
+func TestRunTurn_UnitOfWorkCommitsSaveWithJournal(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	path := filepath.Join(tmpDir, "deespec.db")
+	db, err := sql.Open("sqlite3", path+"?_journal_mode=WAL")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+	store, err := sqlite.OpenStore(path, sqlite.StoreOptions{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	sbiRepo := sqlite.NewSBIRepositoryWithStore(store)
+	task, err := sbi.NewSBI("Journaled task", "Picked within a unit of work", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	// A failed journal append rolls the pick back
+	uc := NewRunTurnUseCase(&failingJournal{}, sbiRepo, nil, agentgateway.NewCodexMockGateway(), 20, time.Minute)
+	uc.SetUnitOfWork(sqlite.NewUnitOfWork(store, nil))
+	_, err = uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.Error(t, err)
+
+	stored, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusPending, stored.Status())
+
+	// Through the outbox, the pick and its journal entry are committed together
+	journal := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+	outbox := sqlite.NewJournalOutboxRepository(store, journal)
+	uc = NewRunTurnUseCase(outbox, sbiRepo, nil, agentgateway.NewCodexMockGateway(), 20, time.Minute)
+	uc.SetUnitOfWork(sqlite.NewUnitOfWork(store, outbox))
+	out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "PICKED", out.Decision)
+
+	stored, err = sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusPicked, stored.Status())
+	records, err := journal.FindBySBI(ctx, sbiID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "pick", records[0].Step)
+}
+
+// TestRunTurn_AcceptanceCriteriaBlockDone verifies that a SUCCEEDED review cannot complete
+// an SBI while one of its acceptance criteria fails
+func TestRunTurn_AcceptanceCriteriaBlockDone(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	criteriaRepo := sqlite.NewAcceptanceCriteriaRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+	artifactStore := storage.NewLocalArtifactStore(tmpDir)
+	reportUseCase := usecase.NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		criteriaRepo,
+		artifactStore,
+	)
+
+	description := "Add login\n\n## Acceptance Criteria\n- Returns a token\n- Rejects wrong passwords\n"
+	task, err := sbi.NewSBI("Login", description, nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{
+			{Step: "implement", Output: "first attempt"},
+			{Step: "review", Decision: "SUCCEEDED", Output: "AC-1: PASS\nAC-2: FAIL - accepts any password"},
+			{Step: "implement", Output: "fixed password check"},
+			{Step: "review", Decision: "SUCCEEDED", Output: "AC-2: PASS"},
+		},
+	})
+	reportUseCase.SetAllowInProgressTurn(true)
+	sim.SetReporter(reportUseCase.Execute)
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 20, time.Minute)
+	uc.SetArtifactStore(artifactStore)
+	uc.SetAcceptanceCriteriaRepository(criteriaRepo)
+
+	var statuses []string
+	for i := 0; i < 10; i++ {
+		out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+		require.NoError(t, err)
+		statuses = append(statuses, out.NextStatus)
+		if out.TaskCompleted {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"WIP", "WIP", "REVIEW", "WIP", "REVIEW", "DONE"}, statuses)
+
+	criteria, err := criteriaRepo.FindBySBIID(ctx, sbiID)
+	require.NoError(t, err)
+	require.Len(t, criteria, 2)
+	for _, c := range criteria {
+		assert.Equal(t, repository.CriterionPassed, c.Status, "AC-%d", c.Position)
+	}
+}
+
+// sessionGateway hands out a new session per fresh conversation and rejects unknown sessions
+type sessionGateway struct {
+	output.AgentGateway
+	issued   map[string]bool
+	received []string
+}
+
+func (g *sessionGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.received = append(g.received, req.Context["step"]+":"+req.SessionID)
+	sessionID := req.SessionID
+	if sessionID == "" {
+		sessionID = fmt.Sprintf("session-%d", len(g.issued)+1)
+		g.issued[sessionID] = true
+	} else if !g.issued[sessionID] {
+		return nil, fmt.Errorf("unknown session %s", sessionID)
+	}
+	resp, err := g.AgentGateway.Execute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.SessionID = sessionID
+	return resp, nil
+}
+
+func (g *sessionGateway) GetCapability() output.AgentCapability {
+	capability := g.AgentGateway.GetCapability()
+	capability.SupportsSessions = true
+	return capability
+}
+
+// TestRunTurn_AgentSessionContinuation verifies that each step continues its own previous
+// conversation and that an expired session falls back to a fresh one
+func TestRunTurn_AgentSessionContinuation(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	sessionRepo := sqlite.NewAgentSessionRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+	artifactStore := storage.NewLocalArtifactStore(tmpDir)
+	reportUseCase := usecase.NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		artifactStore,
+	)
+
+	task, err := sbi.NewSBI("Session task", "Keep the conversation", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{
+			{Step: "implement", Output: "first attempt"},
+			{Step: "review", Decision: "NEEDS_CHANGES", Output: "## Issues\n- Missing tests"},
+			{Step: "implement", Output: "added tests"},
+			{Step: "review", Decision: "SUCCEEDED", Output: "LGTM"},
+		},
+	})
+	reportUseCase.SetAllowInProgressTurn(true)
+	sim.SetReporter(reportUseCase.Execute)
+	gateway := &sessionGateway{AgentGateway: sim, issued: map[string]bool{}}
+
+	// A session left over from an earlier run that the provider no longer knows
+	require.NoError(t, sessionRepo.Save(ctx, &repository.AgentSession{
+		SBIID:     sbiID,
+		Step:      "implement",
+		AgentType: gateway.GetCapability().AgentType,
+		SessionID: "expired",
+	}))
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, gateway, 20, time.Minute)
+	uc.SetArtifactStore(artifactStore)
+	uc.SetAgentSessionRepository(sessionRepo)
+
+	for i := 0; i < 10; i++ {
+		out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+		require.NoError(t, err)
+		if out.TaskCompleted {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{
+		"implement:expired",
+		"implement:",
+		"review:",
+		"implement:session-1",
+		"review:session-2",
+	}, gateway.received)
+
+	session, err := sessionRepo.Find(ctx, sbiID, "implement")
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, "session-1", session.SessionID)
+}
+
+// typedGateway presents a shared gateway as a given agent type and records the steps it executes
+type typedGateway struct {
+	output.AgentGateway
+	agentType string
+	calls     *[]string
+}
+
+func (g *typedGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	*g.calls = append(*g.calls, g.agentType+":"+req.Context["step"])
+	return g.AgentGateway.Execute(ctx, req)
+}
+
+func (g *typedGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: g.agentType}
+}
+
+// TestRunTurn_SelfReviewGuard verifies that the review step moves to another agent
+// when the routed reviewer is the agent that implemented the turn
+func TestRunTurn_SelfReviewGuard(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	stepAgentRepo := sqlite.NewStepAgentRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+	artifactStore := storage.NewLocalArtifactStore(tmpDir)
+	reportUseCase := usecase.NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		artifactStore,
+	)
+
+	task, err := sbi.NewSBI("Guarded task", "Reviewed by another agent", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{
+			{Step: "implement", Output: "implemented"},
+			{Step: "review", Decision: "SUCCEEDED", Output: "LGTM"},
+		},
+	})
+	reportUseCase.SetAllowInProgressTurn(true)
+	sim.SetReporter(reportUseCase.Execute)
+
+	var calls []string
+	implementer := &typedGateway{AgentGateway: sim, agentType: "claude-code-cli", calls: &calls}
+	registry := service.NewAgentRegistry(implementer, func(agentType string) (output.AgentGateway, error) {
+		return &typedGateway{AgentGateway: sim, agentType: agentType, calls: &calls}, nil
+	}, nil)
+	registry.SetSelfReviewGuard(true, []label.AgentRoute{{Agent: "claude-code-cli"}, {Agent: "codex", Model: "gpt-5"}})
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, implementer, 20, time.Minute)
+	uc.SetArtifactStore(artifactStore)
+	uc.SetAgentRegistry(registry)
+	uc.SetStepAgentRepository(stepAgentRepo)
+
+	for i := 0; i < 10; i++ {
+		out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+		require.NoError(t, err)
+		if out.TaskCompleted {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"claude-code-cli:implement", "codex:review"}, calls)
+	reviewer, err := stepAgentRepo.Find(ctx, sbiID, "review")
+	require.NoError(t, err)
+	require.NotNil(t, reviewer)
+	assert.Equal(t, "codex", reviewer.AgentType)
+	assert.Equal(t, "gpt-5", reviewer.Model)
+}
+
+func TestRunTurn_DailyAgentCallBudget(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+
+	task, err := sbi.NewSBI("Budgeted task", "Runs out of agent calls", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{
+			{Step: "implement", Output: "implemented"},
+		},
+	})
+
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 20, time.Minute)
+	uc.SetArtifactStore(storage.NewLocalArtifactStore(tmpDir))
+	callRepo := sqlite.NewAgentCallRepository(db)
+	uc.SetTurnBudget(service.NewTurnBudget(0, 1, sbiRepo, callRepo))
+
+	// pick → implement spends the only agent call of the day; the review turn is held back
+	var out *dto.RunTurnOutput
+	for i := 0; i < 5; i++ {
+		out, err = uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+		require.NoError(t, err)
+		if out.NoOp {
+			break
+		}
+	}
+	assert.True(t, out.NoOp)
+	assert.Equal(t, service.NoOpReasonDailyAgentCallBudgetExhausted, out.NoOpReason)
+
+	calls, err := callRepo.CountSince(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// The held-back SBI keeps its turn so that it resumes where it stopped
+	before, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusReviewing, before.Status())
+
+	out, err = uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.True(t, out.NoOp)
+	after, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, before.ExecutionState().CurrentTurn.Value(), after.ExecutionState().CurrentTurn.Value())
+}
+
+func TestRunTurn_ExecutionWindow(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	task, err := sbi.NewSBI("Night task", "Runs off-peak only", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+
+	// A window that opens in two hours
+	opens := time.Now().Add(2 * time.Hour)
+	closes := opens.Add(time.Hour)
+	schedule, err := domainservice.ParseExecutionSchedule([]string{opens.Format("15:04") + "-" + closes.Format("15:04")})
+	require.NoError(t, err)
+
+	uc := NewRunTurnUseCase(nil, sbiRepo, nil, nil, 20, time.Minute)
+	uc.SetExecutionSchedule(schedule)
+
+	out, err := uc.Execute(ctx, dto.RunTurnInput{})
+	require.NoError(t, err)
+	assert.True(t, out.NoOp)
+	assert.Equal(t, service.NoOpReasonOutsideExecutionWindow, out.NoOpReason)
+	require.NotNil(t, out.ResumeAt)
+	assert.Equal(t, opens.Truncate(time.Minute).Format("2006-01-02 15:04"), out.ResumeAt.Format("2006-01-02 15:04"))
+
+	// The task stays queued
+	queued, err := sbiRepo.Find(ctx, repository.SBIID(task.ID().String()))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusPending, queued.Status())
+}
+
+func TestRunTurn_FailurePolicyAtTurnLimit(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+	reportUseCase := usecase.NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		storage.NewLocalArtifactStore(tmpDir),
+	)
+
+	task, err := sbi.NewSBI("Stubborn task", "Never passes review", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	sim := agentgateway.NewSimulationGateway(agentgateway.SimulationScript{
+		Steps: []agentgateway.SimulationStep{
+			{Step: "implement", Output: "first attempt"},
+			{Step: "review", Decision: "NEEDS_CHANGES", Output: "## Issues\n- Wrong approach"},
+			{Step: "postmortem", Output: "Root cause: the spec does not say which approach to take"},
+		},
+	})
+	reportUseCase.SetAllowInProgressTurn(true)
+	sim.SetReporter(reportUseCase.Execute)
+
+	// pick, init, implement and review use up the four turns
+	uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, sim, 4, time.Minute)
+	uc.SetFailurePolicy(service.NewFailurePolicy(service.FailurePolicyFailed, true))
+
+	var out *dto.RunTurnOutput
+	for i := 0; i < 5; i++ {
+		out, err = uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "FAILED", out.NextStatus)
+	assert.False(t, out.TaskCompleted, "a failed SBI is not reported as completed")
+	assert.Equal(t, "Exceeded max turns (4)", out.ErrorMsg)
+
+	final, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusFailed, final.Status())
+	assert.Equal(t, "Exceeded max turns (4)", final.ExecutionState().LastError)
+	assert.NotNil(t, final.CompletedAt())
+
+	postmortemPath := fmt.Sprintf(".deespec/reports/sbi/%s/postmortem.md", sbiID)
+	content, err := os.ReadFile(postmortemPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Root cause")
+
+	records, err := journalRepo.FindBySBI(ctx, sbiID)
+	require.NoError(t, err)
+	last := records[len(records)-1]
+	assert.Equal(t, "force_terminated", last.Step)
+	assert.Equal(t, "FAILED", last.Status)
+	assert.Equal(t, []interface{}{postmortemPath}, last.Artifacts)
+}
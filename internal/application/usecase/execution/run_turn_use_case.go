@@ -3,11 +3,16 @@ package execution
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
 	"time"
 
@@ -15,22 +20,77 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
 	"github.com/YoshitsuguKoike/deespec/internal/application/service"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 )
 
 // RunTurnUseCase orchestrates a single workflow turn execution
 type RunTurnUseCase struct {
-	journalRepo     repository.JournalRepository
-	sbiRepo         repository.SBIRepository
-	lockService     service.LockService
-	agentGateway    output.AgentGateway
-	decisionService *domainservice.WorkflowDecisionService
-	maxTurns        int
-	leaseTTL        time.Duration
+	journalRepo       repository.JournalRepository
+	sbiRepo           repository.SBIRepository
+	lockService       service.LockService
+	agentGateway      output.AgentGateway
+	agentRegistry     *service.AgentRegistry                  // Optional: per-label agent/model routing
+	feedbackRepo      repository.ReviewFeedbackRepository     // Optional: review feedback carry-over
+	criteriaRepo      repository.AcceptanceCriteriaRepository // Optional: acceptance criteria checklist
+	issueRepo         repository.ReviewIssueRepository        // Optional: review issues tracked across turns
+	noteRepo          repository.SBINoteRepository            // Optional: notes marked for the prompt
+	artifactStore     output.ArtifactStore                    // Optional: offload step artifacts to object storage
+	eventPublisher    output.TurnEventPublisher               // Optional: live turn progress (serve /events)
+	unitOfWork        output.UnitOfWork                       // Optional: commit SBI saves with their journal entries
+	sessionRepo       repository.AgentSessionRepository       // Optional: continue agent conversations across turns
+	contextSelector   *service.ContextSelector                // Optional: relevant file hints in implement prompts
+	ownershipProvider *service.OwnershipProvider              // Optional: git ownership of the relevant files
+	taskEnvResolver   *service.TaskEnvResolver                // Optional: SBI/PBI env exported to agents
+	reviewQuorum      *reviewQuorum                           // Optional: several reviewer agents vote on each review
+	stepAgentRepo     repository.StepAgentRepository          // Optional: agent per step, for the self-review guard
+	turnBudget        *service.TurnBudget                     // Optional: per-PBI turn and daily agent call budgets
+	schedule          *domainservice.ExecutionSchedule        // Optional: windows in which turns may start
+	specPreflight     *service.SpecPreflight                  // Optional: spec quality check before the first turn
+	reviewTimeout     *service.ReviewTimeout                  // Optional: review decision SLA
+	failurePolicy     *service.FailurePolicy                  // Optional: FAILED instead of DONE at the turn limit
+	retryBudget       *service.RetryBudget                    // Optional: at-risk notifications before the turn/attempt limits
+	reviewContext     *service.ReviewContextBuilder           // Optional: diff, test and report bundle for reviews
+	referenceContext  *service.ReferenceContextBuilder        // Optional: artifacts of the SBIs an SBI references
+	promptRepo        repository.PromptTemplateRepository     // Optional: label-scoped review guidance
+	responseCache     *service.AgentResponseCache             // Optional: reuse outputs of identical review prompts
+	cacheReport       ReviewReportFunc                        // Submits cached reviews (required by responseCache)
+	projectRoot       string                                  // Optional: directory .deespec/... paths are relative to (default: working directory)
+	artifactRetries   int                                     // Optional: corrective prompts for missing or empty reports before saving the agent output
+	priorityAging     *sbi.PriorityAging                      // Optional: pick pending SBIs by their aged priority
+	pbiAcceptance     *service.PBIAcceptanceService           // Optional: end-to-end check of a PBI once all of its SBIs are done
+	turnTimeBox       time.Duration                           // Optional: wall-clock budget of implement steps (0: none)
+	checkpointTimeout time.Duration                           // Optional: timeout of the prompt asking a time-boxed agent for its checkpoint
+	modelPolicy       *domainservice.ModelPolicy              // Optional: models each step may be dispatched to
+	provenanceRepo    repository.ArtifactProvenanceRepository // Optional: agent, model and version of each artifact
+	promptArchive     repository.PromptArchiveRepository      // Optional: every prompt sent to agents and their answers
+	turnClaims        repository.TurnClaimRepository          // Optional: guard against executing a turn twice
+	templateVars      *service.TemplateVarResolver            // Optional: custom {{.Custom.Name}} prompt variables
+	promptExperiment  *domainservice.PromptExperiment         // Optional: A/B test of a prompt template variant
+	mcpResolver       *service.MCPResolver                    // Optional: MCP servers enabled by the SBI's labels
+	changeBudget      *service.ChangeBudgetChecker            // Optional: code change cap of implement turns
+	contextWindow     *service.ContextWindowCheck             // Optional: pre-check that prompts fit the model's context window
+	decisionService   *domainservice.WorkflowDecisionService
+	decisionExtractor *domainservice.DecisionExtractorChain // Review decision extraction strategies
+	maxTurns          int
+	leaseTTL          time.Duration
+	cancelPoll        time.Duration // How often a running step checks for `sbi cancel`
 }
 
+// defaultCancelPollInterval matches the workflow manager's execution heartbeat
+const defaultCancelPollInterval = 5 * time.Second
+
+// turnClaimStaleAfter is how long a turn claim of a runner on another host is honored
+// It outlasts the longest agent calls, so that only claims of crashed runners expire.
+const turnClaimStaleAfter = 2 * time.Hour
+
+// NoOpReasonTurnConflict reports that another runner already started the turn
+const NoOpReasonTurnConflict = "turn_conflict"
+
 // NewRunTurnUseCase creates a new RunTurnUseCase
 func NewRunTurnUseCase(
 	journalRepo repository.JournalRepository,
@@ -47,18 +107,262 @@ func NewRunTurnUseCase(
 		leaseTTL = 10 * time.Minute // Default
 	}
 
-	// Initialize workflow decision service with max attempts = 3
-	decisionService := domainservice.NewWorkflowDecisionService(3)
+	// Initialize workflow decision service with the default max attempts
+	decisionService := domainservice.NewWorkflowDecisionService(domainservice.DefaultMaxAttempts)
 
 	return &RunTurnUseCase{
-		journalRepo:     journalRepo,
-		sbiRepo:         sbiRepo,
-		lockService:     lockService,
-		agentGateway:    agentGateway,
-		decisionService: decisionService,
-		maxTurns:        maxTurns,
-		leaseTTL:        leaseTTL,
+		journalRepo:       journalRepo,
+		sbiRepo:           sbiRepo,
+		lockService:       lockService,
+		agentGateway:      agentGateway,
+		decisionService:   decisionService,
+		decisionExtractor: domainservice.NewDefaultDecisionExtractorChain(),
+		maxTurns:          maxTurns,
+		leaseTTL:          leaseTTL,
+		cancelPoll:        defaultCancelPollInterval,
+	}
+}
+
+// SetCancelPollInterval overrides how often a running step checks for cancellation
+func (uc *RunTurnUseCase) SetCancelPollInterval(interval time.Duration) {
+	if interval > 0 {
+		uc.cancelPoll = interval
+	}
+}
+
+// SetAgentRegistry enables per-label agent/model routing for workflow steps
+// Without a registry, every step is executed by the default agent gateway
+func (uc *RunTurnUseCase) SetAgentRegistry(registry *service.AgentRegistry) {
+	uc.agentRegistry = registry
+}
+
+// SetReviewFeedbackRepository enables embedding the previous review's issue list in implement prompts
+// Without a repository, implement prompts only point the agent at prior report files
+func (uc *RunTurnUseCase) SetReviewFeedbackRepository(repo repository.ReviewFeedbackRepository) {
+	uc.feedbackRepo = repo
+}
+
+// SetAcceptanceCriteriaRepository enables the acceptance criteria checklist in implement and review prompts
+// Criteria are parsed from the SBI description and its parent PBI on first use
+func (uc *RunTurnUseCase) SetAcceptanceCriteriaRepository(repo repository.AcceptanceCriteriaRepository) {
+	uc.criteriaRepo = repo
+}
+
+// SetReviewIssueRepository enables listing the open review issues in implement prompts and
+// asking reviewers to update their status
+func (uc *RunTurnUseCase) SetReviewIssueRepository(repo repository.ReviewIssueRepository) {
+	uc.issueRepo = repo
+}
+
+// SetNoteRepository enables adding the SBI notes marked for the prompt to the prior context
+// Each note goes into the prompt of the first turn built after it was added
+func (uc *RunTurnUseCase) SetNoteRepository(repo repository.SBINoteRepository) {
+	uc.noteRepo = repo
+}
+
+// SetDecisionExtractor overrides the strategies used to extract review decisions
+func (uc *RunTurnUseCase) SetDecisionExtractor(chain *domainservice.DecisionExtractorChain) {
+	if chain != nil {
+		uc.decisionExtractor = chain
+	}
+}
+
+// SetArtifactStore enables offloading step artifacts; journals record the store URI
+func (uc *RunTurnUseCase) SetArtifactStore(store output.ArtifactStore) {
+	uc.artifactStore = store
+}
+
+// SetEventPublisher enables publishing turn lifecycle events for live progress views
+func (uc *RunTurnUseCase) SetEventPublisher(publisher output.TurnEventPublisher) {
+	uc.eventPublisher = publisher
+}
+
+// SetUnitOfWork makes each SBI save and its journal entry commit atomically
+// The journal repository must take part in the unit (e.g. a SQLite journal outbox); without a
+// unit of work the entry is appended after the save, and a failed append only warns
+func (uc *RunTurnUseCase) SetUnitOfWork(unitOfWork output.UnitOfWork) {
+	uc.unitOfWork = unitOfWork
+}
+
+// SetAgentSessionRepository enables continuing each SBI step's previous agent conversation
+// on its next turn, for agents that support sessions
+func (uc *RunTurnUseCase) SetAgentSessionRepository(repo repository.AgentSessionRepository) {
+	uc.sessionRepo = repo
+}
+
+// SetContextSelector enables listing files relevant to the SBI in implement prompts
+func (uc *RunTurnUseCase) SetContextSelector(selector *service.ContextSelector) {
+	uc.contextSelector = selector
+}
+
+// SetOwnershipProvider enables annotating implement prompts with the recent authors and
+// pull requests of the files the SBI targets
+func (uc *RunTurnUseCase) SetOwnershipProvider(provider *service.OwnershipProvider) {
+	uc.ownershipProvider = provider
+}
+
+// SetTaskEnvResolver enables exporting the env declared on an SBI and its PBI to agents
+func (uc *RunTurnUseCase) SetTaskEnvResolver(resolver *service.TaskEnvResolver) {
+	uc.taskEnvResolver = resolver
+}
+
+// SetStepAgentRepository enables recording which agent executed each step
+// The agent registry's self-review guard uses it to keep the implementer off the review
+func (uc *RunTurnUseCase) SetStepAgentRepository(repo repository.StepAgentRepository) {
+	uc.stepAgentRepo = repo
+}
+
+// SetModelPolicy enables enforcing which models each step may be dispatched to
+// A step whose routed model violates the policy fails before its agent is started.
+func (uc *RunTurnUseCase) SetModelPolicy(policy *domainservice.ModelPolicy) {
+	uc.modelPolicy = policy
+}
+
+// SetContextWindowCheck enables checking that a prompt and the files it has the agent read fit
+// the model's context window before the agent is started
+func (uc *RunTurnUseCase) SetContextWindowCheck(check *service.ContextWindowCheck) {
+	uc.contextWindow = check
+}
+
+// SetPromptArchive enables keeping every prompt sent to agents and their answers
+func (uc *RunTurnUseCase) SetPromptArchive(repo repository.PromptArchiveRepository) {
+	uc.promptArchive = repo
+}
+
+// SetTurnClaimRepository enables claiming each (SBI, turn) before the agent is called
+// A runner that finds the turn claimed by another one aborts it with a journaled conflict.
+func (uc *RunTurnUseCase) SetTurnClaimRepository(repo repository.TurnClaimRepository) {
+	uc.turnClaims = repo
+}
+
+// SetArtifactProvenanceRepository enables recording the agent, model and version of each artifact
+func (uc *RunTurnUseCase) SetArtifactProvenanceRepository(repo repository.ArtifactProvenanceRepository) {
+	uc.provenanceRepo = repo
+}
+
+// SetTemplateVarResolver enables the custom variables prompt templates reference as {{.Custom.Name}}
+func (uc *RunTurnUseCase) SetTemplateVarResolver(resolver *service.TemplateVarResolver) {
+	uc.templateVars = resolver
+}
+
+// SetPromptExperiment prompts the SBIs of the treatment arm with the experiment's variant template
+// Every journal record of an SBI notes its arm, so that the arms can be compared by outcome
+func (uc *RunTurnUseCase) SetPromptExperiment(experiment *domainservice.PromptExperiment) {
+	uc.promptExperiment = experiment
+}
+
+// SetMCPResolver gives agents the MCP servers enabled by the SBI's labels
+func (uc *RunTurnUseCase) SetMCPResolver(resolver *service.MCPResolver) {
+	uc.mcpResolver = resolver
+}
+
+// SetChangeBudget sets the checker of the per-turn change budget (nil disables it)
+func (uc *RunTurnUseCase) SetChangeBudget(checker *service.ChangeBudgetChecker) {
+	uc.changeBudget = checker
+}
+
+// SetTurnBudget enables the per-PBI turn and daily agent call budgets
+// A turn whose budget is exhausted is skipped as a NoOp and every agent call is recorded
+func (uc *RunTurnUseCase) SetTurnBudget(budget *service.TurnBudget) {
+	uc.turnBudget = budget
+}
+
+// SetExecutionSchedule limits picking SBIs to the execution windows
+// Outside them a turn is a NoOp that reports when the next window opens; SBIs stay queued.
+func (uc *RunTurnUseCase) SetExecutionSchedule(schedule *domainservice.ExecutionSchedule) {
+	uc.schedule = schedule
+}
+
+// SetSpecPreflight enables the spec quality check when an SBI is picked for its first turn
+// Warnings are reported; in block mode the SBI is not picked until they are resolved
+func (uc *RunTurnUseCase) SetSpecPreflight(preflight *service.SpecPreflight) {
+	uc.specPreflight = preflight
+}
+
+// SetReviewTimeout times out reviews whose decision has not arrived within the SLA
+// A timed-out review is journaled and routed to retry or force_implement instead of being reviewed again.
+func (uc *RunTurnUseCase) SetReviewTimeout(timeout *service.ReviewTimeout) {
+	uc.reviewTimeout = timeout
+}
+
+// SetFailurePolicy terminates SBIs that exhaust their turn limit as FAILED instead of DONE
+// A nil policy keeps force-completing them.
+func (uc *RunTurnUseCase) SetFailurePolicy(policy *service.FailurePolicy) {
+	uc.failurePolicy = policy
+}
+
+// SetRetryBudget enables notifications when an SBI has used most of its turn or attempt limit
+func (uc *RunTurnUseCase) SetRetryBudget(budget *service.RetryBudget) {
+	uc.retryBudget = budget
+}
+
+// SetReviewContextBuilder enables the context bundle referenced by review prompts
+func (uc *RunTurnUseCase) SetReviewContextBuilder(builder *service.ReviewContextBuilder) {
+	uc.reviewContext = builder
+}
+
+// SetReferenceContextBuilder adds the final artifacts of the SBIs an SBI references to its
+// implement and review prompts
+func (uc *RunTurnUseCase) SetReferenceContextBuilder(builder *service.ReferenceContextBuilder) {
+	uc.referenceContext = builder
+}
+
+// SetPromptTemplateRepository enables adding the review guidance of the SBI's labels
+// (.deespec/prompts/labels/<label>.review.md) to review prompts
+func (uc *RunTurnUseCase) SetPromptTemplateRepository(repo repository.PromptTemplateRepository) {
+	uc.promptRepo = repo
+}
+
+// SetAgentResponseCache enables reusing the review of an earlier call whose prompt, agent,
+// model and working tree match; the cached review is submitted via report, like a live
+// reviewer's `deespec sbi review`
+func (uc *RunTurnUseCase) SetAgentResponseCache(cache *service.AgentResponseCache, report ReviewReportFunc) {
+	if cache == nil || report == nil {
+		uc.responseCache, uc.cacheReport = nil, nil
+		return
+	}
+	uc.responseCache = cache
+	uc.cacheReport = report
+}
+
+// SetProjectRoot makes artifact, report and template paths resolve against the project
+// root instead of the process working directory
+func (uc *RunTurnUseCase) SetProjectRoot(root string) {
+	uc.projectRoot = root
+}
+
+// SetArtifactRetries sets how many corrective prompts ask the agent to write a report it
+// left missing or empty, before its output is saved as the report instead
+func (uc *RunTurnUseCase) SetArtifactRetries(attempts int) {
+	uc.artifactRetries = attempts
+}
+
+// SetPriorityAging raises the priority of pending SBIs with the time they have waited,
+// so that low-priority SBIs are eventually picked
+func (uc *RunTurnUseCase) SetPriorityAging(aging sbi.PriorityAging) {
+	uc.priorityAging = &aging
+}
+
+// SetPBIAcceptance runs the acceptance test of a PBI when a turn completes its last SBI
+func (uc *RunTurnUseCase) SetPBIAcceptance(acceptance *service.PBIAcceptanceService) {
+	uc.pbiAcceptance = acceptance
+}
+
+// SetTurnTimeBox stops implement steps that run longer than box and asks the agent to write a
+// progress checkpoint within checkpointTimeout; the turn ends IMPLEMENTING instead of failing
+// and the next turn resumes from the checkpoint (box <= 0 disables the time box)
+func (uc *RunTurnUseCase) SetTurnTimeBox(box, checkpointTimeout time.Duration) {
+	uc.turnTimeBox = box
+	uc.checkpointTimeout = checkpointTimeout
+}
+
+// projectPath resolves a project-relative path such as .deespec/reports/... for file access
+// Prompts keep the relative form, since agents run in the project root.
+func (uc *RunTurnUseCase) projectPath(path string) string {
+	if uc.projectRoot == "" || filepath.IsAbs(path) {
+		return path
 	}
+	return filepath.Join(uc.projectRoot, path)
 }
 
 // ExecuteForSBI executes a turn for a specific SBI (for parallel execution)
@@ -72,7 +376,24 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 		return nil, fmt.Errorf("failed to find SBI %s: %w", sbiID, err)
 	}
 	if currentSBI == nil {
-		return nil, fmt.Errorf("SBI %s not found", sbiID)
+		return nil, fmt.Errorf("SBI %w: %s", model.ErrNotFound, sbiID)
+	}
+
+	// Cancelled SBIs are terminal; nothing to execute
+	if currentSBI.IsCancelled() {
+		return &dto.RunTurnOutput{
+			SBIID:       currentSBI.ID().String(),
+			NoOp:        true,
+			NoOpReason:  "cancelled",
+			PrevStatus:  uc.mapDomainStatusToString(currentSBI.Status()),
+			NextStatus:  uc.mapDomainStatusToString(currentSBI.Status()),
+			ElapsedMs:   time.Since(startTime).Milliseconds(),
+			CompletedAt: time.Now(),
+		}, nil
+	}
+
+	if held := uc.checkTurnBudget(ctx, currentSBI, startTime); held != nil {
+		return held, nil
 	}
 
 	// Get execution state from SBI entity
@@ -84,61 +405,28 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 	currentTurn := execState.CurrentTurn.Value()
 	currentAttempt := execState.CurrentAttempt.Value()
 	prevStatus := currentSBI.Status()
+	budgetBefore := uc.retryBudgetUsage(currentSBI)
 
 	// Increment turn for this execution
 	currentTurn++
+	uc.publishEvent(output.TurnEventPicked, currentSBI.ID().String(), currentTurn,
+		uc.statusToStep(uc.mapDomainStatusToString(prevStatus)),
+		map[string]string{"status": uc.mapDomainStatusToString(prevStatus)})
 
 	// Check turn limit
 	if currentTurn > uc.maxTurns {
-		// Force termination - must follow valid state transitions
-		// If currently IMPLEMENTING, transition to REVIEWING first, then to DONE
-		if prevStatus == model.StatusImplementing {
-			if err := currentSBI.UpdateStatus(model.StatusReviewing); err != nil {
-				return nil, fmt.Errorf("failed to transition to REVIEWING: %w", err)
-			}
-		}
-		// Now transition to DONE (valid from REVIEWING status)
-		if err := currentSBI.UpdateStatus(model.StatusDone); err != nil {
-			return nil, fmt.Errorf("failed to mark SBI as done: %w", err)
-		}
-		// Record work completion time for force termination
-		currentSBI.MarkAsCompleted()
-		if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-			return nil, fmt.Errorf("failed to save SBI after force termination: %w", err)
-		}
+		return uc.terminateAtTurnLimit(ctx, currentSBI, prevStatus, currentTurn, currentAttempt, startTime)
+	}
 
-		// Write journal entry for force termination
-		journalRecord := &repository.JournalRecord{
-			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
-			SBIID:     currentSBI.ID().String(),
-			Turn:      currentTurn,
-			Step:      "force_terminated",
-			Status:    "DONE",
-			Attempt:   currentAttempt,
-			Decision:  "FORCE_TERMINATED",
-			ElapsedMs: time.Since(startTime).Milliseconds(),
-			Error:     fmt.Sprintf("Exceeded max turns (%d)", uc.maxTurns),
-			Artifacts: []interface{}{},
+	// A review whose decision never arrived is timed out instead of reviewed again
+	if prevStatus == model.StatusReviewing {
+		timedOut, err := uc.handleReviewTimeout(ctx, currentSBI, currentTurn, currentAttempt, startTime)
+		if err != nil {
+			return nil, err
 		}
-
-		if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (force termination)\n")
-			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: force_terminated\n",
-				currentSBI.ID().String(), currentTurn)
+		if timedOut != nil {
+			return timedOut, nil
 		}
-
-		return &dto.RunTurnOutput{
-			Turn:          currentTurn,
-			SBIID:         currentSBI.ID().String(),
-			NoOp:          false,
-			PrevStatus:    uc.mapDomainStatusToString(prevStatus),
-			NextStatus:    "DONE",
-			Decision:      "FORCE_TERMINATED",
-			ElapsedMs:     time.Since(startTime).Milliseconds(),
-			CompletedAt:   time.Now(),
-			TaskCompleted: true,
-		}, nil
 	}
 
 	// CRITICAL FIX: Handle status-only transitions without calling AI agent
@@ -146,17 +434,16 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 
 	// Case 1: PENDING → PICKED (task selection)
 	if prevStatus == model.StatusPending {
+		if held := uc.checkSpecPreflight(currentSBI, startTime); held != nil {
+			return held, nil
+		}
 		if err := currentSBI.UpdateStatus(model.StatusPicked); err != nil {
 			return nil, fmt.Errorf("failed to update SBI status to PICKED: %w", err)
 		}
 		currentSBI.MarkAsStarted()
 		currentSBI.IncrementTurn()
 
-		if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
-		}
-
-		// Write journal entry for PICK
+		// Save the SBI with the journal entry for PICK
 		journalRecord := &repository.JournalRecord{
 			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
 			SBIID:     currentSBI.ID().String(),
@@ -169,10 +456,10 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 			Error:     "",
 			Artifacts: []interface{}{},
 		}
-
-		if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (pick)\n")
-			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		if err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+			return uc.sbiRepo.Save(ctx, currentSBI)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
 		}
 
 		return &dto.RunTurnOutput{
@@ -199,11 +486,7 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 		}
 		currentSBI.IncrementTurn()
 
-		if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
-		}
-
-		// Write journal entry for status transition
+		// Save the SBI with the journal entry for status transition
 		journalRecord := &repository.JournalRecord{
 			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
 			SBIID:     currentSBI.ID().String(),
@@ -216,10 +499,10 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 			Error:     "",
 			Artifacts: []interface{}{},
 		}
-
-		if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (status_init)\n")
-			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		if err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+			return uc.sbiRepo.Save(ctx, currentSBI)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
 		}
 
 		return &dto.RunTurnOutput{
@@ -238,14 +521,31 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 		}, nil
 	}
 
+	// Claim the turn so that a runner racing past the locks cannot call the agent for it again
+	if conflict := uc.claimTurn(ctx, currentSBI, currentTurn, currentAttempt, prevStatus, startTime); conflict != nil {
+		return conflict, nil
+	}
+	defer uc.releaseUnsavedTurn(ctx, currentSBI.ID().String(), currentTurn)
+
 	// Execute workflow step (for IMPLEMENTING, REVIEWING, etc.)
-	stepOutput, err := uc.executeStepForSBI(ctx, currentSBI, currentTurn, currentAttempt)
+	stepCtx, wasCancelled, stopWatch := uc.watchCancellation(ctx, currentSBI.ID().String())
+	stepOutput, err := uc.executeStepForSBI(stepCtx, currentSBI, currentTurn, currentAttempt)
+	stopWatch()
+	if wasCancelled() {
+		// `sbi cancel` already saved CANCELLED, released locks and journaled the reason
+		return uc.cancelledOutput(currentSBI, currentTurn, currentAttempt, prevStatus, startTime), nil
+	}
 	if err != nil {
+		errorClass := service.ClassifyError(err)
+		if stepOutput != nil && stepOutput.ErrorClass != "" {
+			errorClass = stepOutput.ErrorClass
+		}
 		stepOutput = &dto.ExecuteStepOutput{
-			Success:   false,
-			ErrorMsg:  err.Error(),
-			Decision:  "NEEDS_CHANGES",
-			ElapsedMs: time.Since(startTime).Milliseconds(),
+			Success:    false,
+			ErrorMsg:   err.Error(),
+			ErrorClass: errorClass,
+			Decision:   "NEEDS_CHANGES",
+			ElapsedMs:  time.Since(startTime).Milliseconds(),
 		}
 	}
 
@@ -254,6 +554,7 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 
 	var nextStatus model.Status
 	var shouldIncrementAttempt bool
+	var applyDecision func(s *sbi.SBI) error // nil when the stored SBI already holds the decision
 
 	// Handle reload case (for REVIEW steps where AI updates DB directly)
 	if action.NeedsReload {
@@ -276,27 +577,40 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 			currentAttempt++
 		}
 
-		// Update SBI entity with new status
-		if err := currentSBI.UpdateStatus(nextStatus); err != nil {
-			return nil, fmt.Errorf("failed to update SBI status: %w", err)
-		}
+		applyDecision = func(s *sbi.SBI) error {
+			// Update SBI entity with new status
+			// (a reloaded SBI may already be there, moved by the agent's `sbi report`)
+			if s.Status() != nextStatus {
+				if err := s.UpdateStatus(nextStatus); err != nil {
+					return fmt.Errorf("failed to update SBI status: %w", err)
+				}
+			}
 
-		// Record work start time when task is picked
-		if action.NextStatus == model.StatusPicked {
-			currentSBI.MarkAsStarted()
-		}
+			// Record work start time when task is picked
+			if action.NextStatus == model.StatusPicked {
+				s.MarkAsStarted()
+			}
 
-		// Record work completion time when task is done or failed
-		if nextStatus == model.StatusDone || nextStatus == model.StatusFailed {
-			currentSBI.MarkAsCompleted()
-		}
+			// Record work completion time when task is done or failed
+			if nextStatus == model.StatusDone || nextStatus == model.StatusFailed {
+				s.MarkAsCompleted()
+			}
 
-		// Update turn in execution state
-		if action.ShouldIncrementTurn {
-			currentSBI.IncrementTurn()
+			// Update turn in execution state
+			if action.ShouldIncrementTurn {
+				s.IncrementTurn()
+			}
+			return nil
+		}
+		if err := applyDecision(currentSBI); err != nil {
+			return nil, err
 		}
 	}
 
+	uc.publishEvent(output.TurnEventDecision, currentSBI.ID().String(), currentTurn,
+		uc.statusToStep(uc.mapDomainStatusToString(prevStatus)),
+		map[string]string{"decision": stepOutput.Decision, "next_status": uc.mapDomainStatusToString(nextStatus)})
+
 	// NOTE: done.md generation is commented out due to performance concerns
 	//
 	// BACKGROUND: Generating done.md takes 2-5 minutes per task, blocking the next task from starting.
@@ -332,39 +646,70 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 	// }
 	var doneArtifactPath string // Keep variable for journal compatibility
 
-	// Save SBI to DB
-	if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-		return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
-	}
-
-	// Write journal entry
+	// Save SBI to DB with its journal entry
+	// The entry records the status as saved, which a concurrent save may have changed
 	artifacts := []interface{}{stepOutput.ArtifactPath}
 	if doneArtifactPath != "" {
 		artifacts = append(artifacts, doneArtifactPath)
 	}
+	if notes := uc.promptNotesArtifact(ctx, currentSBI.ID().String(), currentTurn); notes != nil {
+		artifacts = append(artifacts, notes)
+	}
+	if check := artifactCheckArtifact(stepOutput); check != nil {
+		artifacts = append(artifacts, check)
+	}
+	if checkpoint := checkpointArtifact(stepOutput); checkpoint != nil {
+		artifacts = append(artifacts, checkpoint)
+	}
+	if routing := routingArtifact(stepOutput); routing != nil {
+		artifacts = append(artifacts, routing)
+	}
+	if experiment := uc.promptExperimentArtifact(currentSBI.ID().String()); experiment != nil {
+		artifacts = append(artifacts, experiment)
+	}
+	if budget := uc.changeBudgetArtifact(stepOutput); budget != nil {
+		artifacts = append(artifacts, budget)
+	}
 
 	journalRecord := &repository.JournalRecord{
-		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
-		SBIID:     currentSBI.ID().String(),
-		Turn:      currentTurn,
-		Step:      uc.statusToStep(uc.mapDomainStatusToString(nextStatus)),
-		Status:    uc.mapDomainStatusToString(nextStatus),
-		Attempt:   currentAttempt,
-		Decision:  stepOutput.Decision,
-		ElapsedMs: time.Since(startTime).Milliseconds(),
-		Error:     stepOutput.ErrorMsg,
-		Artifacts: artifacts,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:      currentSBI.ID().String(),
+		Turn:       currentTurn,
+		Attempt:    currentAttempt,
+		Decision:   stepOutput.Decision,
+		Error:      stepOutput.ErrorMsg,
+		ErrorClass: stepOutput.ErrorClass,
+		Artifacts:  artifacts,
 	}
-
-	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry\n")
-		fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
-		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: %s, Status: %s\n",
-			currentSBI.ID().String(), currentTurn,
-			uc.statusToStep(uc.mapDomainStatusToString(nextStatus)),
-			uc.mapDomainStatusToString(nextStatus))
+	priorRecords := uc.priorJournalRecords(ctx, currentSBI.ID().String())
+	err = uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+		savedSBI, err := uc.saveStepResult(ctx, currentSBI, prevStatus, nextStatus, applyDecision)
+		if err != nil {
+			return err
+		}
+		currentSBI = savedSBI
+		nextStatus = currentSBI.Status()
+		journalRecord.Step = uc.statusToStep(uc.mapDomainStatusToString(nextStatus))
+		journalRecord.Seq = repository.NextJournalSeq(priorRecords, journalRecord.SBIID, journalRecord.Turn, journalRecord.Step)
+		journalRecord.Status = uc.mapDomainStatusToString(nextStatus)
+		journalRecord.ElapsedMs = time.Since(startTime).Milliseconds()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrSBICancelled) {
+			// `sbi cancel` landed after the last cancellation poll; keep CANCELLED
+			return uc.cancelledOutput(currentSBI, currentTurn, currentAttempt, prevStatus, startTime), nil
+		}
+		return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
 	}
 
+	uc.notifyAtRisk(ctx, currentSBI, budgetBefore)
+	uc.checkPBIAcceptance(ctx, currentSBI)
+
+	uc.publishEvent(output.TurnEventSaved, currentSBI.ID().String(), currentTurn,
+		uc.statusToStep(uc.mapDomainStatusToString(prevStatus)),
+		map[string]string{"status": uc.mapDomainStatusToString(nextStatus)})
+
 	// Build output
 	taskCompleted := (nextStatus == model.StatusDone)
 
@@ -390,9 +735,25 @@ func (uc *RunTurnUseCase) ExecuteForSBI(ctx context.Context, sbiID string, input
 func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (*dto.RunTurnOutput, error) {
 	startTime := time.Now()
 
+	// Nothing is picked outside the execution windows
+	if !uc.schedule.IsOpen(startTime) {
+		resumeAt := uc.schedule.NextStart(startTime)
+		return &dto.RunTurnOutput{
+			NoOp:        true,
+			NoOpReason:  service.NoOpReasonOutsideExecutionWindow,
+			ResumeAt:    &resumeAt,
+			ElapsedMs:   time.Since(startTime).Milliseconds(),
+			CompletedAt: time.Now(),
+		}, nil
+	}
+
 	// 1. Pick or continue SBI from DB (not from state.json)
 	// Note: RunLock is managed by CLI layer, not by UseCase layer
 	sbiExecService := service.NewSBIExecutionService(uc.sbiRepo, uc.lockService)
+	sbiExecService.SetSpecPreflight(uc.specPreflight)
+	if uc.priorityAging != nil {
+		sbiExecService.SetPriorityAging(*uc.priorityAging)
+	}
 
 	// Try to pick next SBI with lock
 	var currentSBI *sbi.SBI
@@ -411,6 +772,12 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 		}, nil
 	}
 
+	// Guard every write of this turn with the lock's fencing token so that
+	// a stale holder cannot overwrite the work of a runner that took over
+	if sbiLock != nil {
+		ctx = lock.WithFence(ctx, sbiLock.Fence())
+	}
+
 	// Release lock when done
 	defer func() {
 		if sbiLock != nil {
@@ -420,6 +787,10 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 		}
 	}()
 
+	if held := uc.checkTurnBudget(ctx, currentSBI, startTime); held != nil {
+		return held, nil
+	}
+
 	// 3. Get execution state from SBI entity (not from state.json)
 	execState := currentSBI.ExecutionState()
 	if execState == nil {
@@ -429,64 +800,28 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 	currentTurn := execState.CurrentTurn.Value()
 	currentAttempt := execState.CurrentAttempt.Value()
 	prevStatus := currentSBI.Status()
+	budgetBefore := uc.retryBudgetUsage(currentSBI)
 
 	// Increment turn for this execution
 	currentTurn++
+	uc.publishEvent(output.TurnEventPicked, currentSBI.ID().String(), currentTurn,
+		uc.statusToStep(uc.mapDomainStatusToString(prevStatus)),
+		map[string]string{"status": uc.mapDomainStatusToString(prevStatus)})
 
 	// 4. Check turn limit
 	if currentTurn > uc.maxTurns {
-		// Force termination - must follow valid state transitions
-		// If currently IMPLEMENTING, transition to REVIEWING first, then to DONE
-		if prevStatus == model.StatusImplementing {
-			if err := currentSBI.UpdateStatus(model.StatusReviewing); err != nil {
-				return nil, fmt.Errorf("failed to transition to REVIEWING: %w", err)
-			}
-		}
-		// Now transition to DONE (valid from REVIEWING status)
-		if err := currentSBI.UpdateStatus(model.StatusDone); err != nil {
-			return nil, fmt.Errorf("failed to mark SBI as done: %w", err)
-		}
-		// Record work completion time for force termination
-		currentSBI.MarkAsCompleted()
-		if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-			return nil, fmt.Errorf("failed to save SBI after force termination: %w", err)
-		}
+		return uc.terminateAtTurnLimit(ctx, currentSBI, prevStatus, currentTurn, currentAttempt, startTime)
+	}
 
-		// Write journal entry for force termination
-		journalRecord := &repository.JournalRecord{
-			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
-			SBIID:     currentSBI.ID().String(),
-			Turn:      currentTurn,
-			Step:      "force_terminated",
-			Status:    "DONE",
-			Attempt:   currentAttempt,
-			Decision:  "FORCE_TERMINATED",
-			ElapsedMs: time.Since(startTime).Milliseconds(),
-			Error:     fmt.Sprintf("Exceeded max turns (%d)", uc.maxTurns),
-			Artifacts: []interface{}{},
+	// A review whose decision never arrived is timed out instead of reviewed again
+	if prevStatus == model.StatusReviewing {
+		timedOut, err := uc.handleReviewTimeout(ctx, currentSBI, currentTurn, currentAttempt, startTime)
+		if err != nil {
+			return nil, err
 		}
-
-		if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-			// Log warning to stderr but don't fail the operation
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (force termination)\n")
-			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: force_terminated\n",
-				currentSBI.ID().String(), currentTurn)
+		if timedOut != nil {
+			return timedOut, nil
 		}
-
-		// Note: State sync removed - DB is single source of truth
-
-		return &dto.RunTurnOutput{
-			Turn:          currentTurn,
-			SBIID:         currentSBI.ID().String(),
-			NoOp:          false,
-			PrevStatus:    uc.mapDomainStatusToString(prevStatus),
-			NextStatus:    "DONE",
-			Decision:      "FORCE_TERMINATED",
-			ElapsedMs:     time.Since(startTime).Milliseconds(),
-			CompletedAt:   time.Now(),
-			TaskCompleted: true,
-		}, nil
 	}
 
 	// CRITICAL FIX: Handle status-only transitions without calling AI agent
@@ -494,17 +829,16 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 
 	// Case 1: PENDING → PICKED (task selection)
 	if prevStatus == model.StatusPending {
+		if held := uc.checkSpecPreflight(currentSBI, startTime); held != nil {
+			return held, nil
+		}
 		if err := currentSBI.UpdateStatus(model.StatusPicked); err != nil {
 			return nil, fmt.Errorf("failed to update SBI status to PICKED: %w", err)
 		}
 		currentSBI.MarkAsStarted()
 		currentSBI.IncrementTurn()
 
-		if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
-		}
-
-		// Write journal entry for PICK
+		// Save the SBI with the journal entry for PICK
 		journalRecord := &repository.JournalRecord{
 			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
 			SBIID:     currentSBI.ID().String(),
@@ -517,10 +851,10 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 			Error:     "",
 			Artifacts: []interface{}{},
 		}
-
-		if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (pick)\n")
-			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		if err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+			return uc.sbiRepo.Save(ctx, currentSBI)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
 		}
 
 		return &dto.RunTurnOutput{
@@ -547,11 +881,7 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 		}
 		currentSBI.IncrementTurn()
 
-		if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
-		}
-
-		// Write journal entry for status transition
+		// Save the SBI with the journal entry for status transition
 		journalRecord := &repository.JournalRecord{
 			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
 			SBIID:     currentSBI.ID().String(),
@@ -564,10 +894,10 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 			Error:     "",
 			Artifacts: []interface{}{},
 		}
-
-		if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (status_init)\n")
-			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		if err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+			return uc.sbiRepo.Save(ctx, currentSBI)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
 		}
 
 		return &dto.RunTurnOutput{
@@ -586,14 +916,31 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 		}, nil
 	}
 
+	// Claim the turn so that a runner racing past the locks cannot call the agent for it again
+	if conflict := uc.claimTurn(ctx, currentSBI, currentTurn, currentAttempt, prevStatus, startTime); conflict != nil {
+		return conflict, nil
+	}
+	defer uc.releaseUnsavedTurn(ctx, currentSBI.ID().String(), currentTurn)
+
 	// 5. Execute workflow step (for IMPLEMENTING, REVIEWING, etc.)
-	stepOutput, err := uc.executeStepForSBI(ctx, currentSBI, currentTurn, currentAttempt)
+	stepCtx, wasCancelled, stopWatch := uc.watchCancellation(ctx, currentSBI.ID().String())
+	stepOutput, err := uc.executeStepForSBI(stepCtx, currentSBI, currentTurn, currentAttempt)
+	stopWatch()
+	if wasCancelled() {
+		// `sbi cancel` already saved CANCELLED, released locks and journaled the reason
+		return uc.cancelledOutput(currentSBI, currentTurn, currentAttempt, prevStatus, startTime), nil
+	}
 	if err != nil {
+		errorClass := service.ClassifyError(err)
+		if stepOutput != nil && stepOutput.ErrorClass != "" {
+			errorClass = stepOutput.ErrorClass
+		}
 		stepOutput = &dto.ExecuteStepOutput{
-			Success:   false,
-			ErrorMsg:  err.Error(),
-			Decision:  "NEEDS_CHANGES",
-			ElapsedMs: time.Since(startTime).Milliseconds(),
+			Success:    false,
+			ErrorMsg:   err.Error(),
+			ErrorClass: errorClass,
+			Decision:   "NEEDS_CHANGES",
+			ElapsedMs:  time.Since(startTime).Milliseconds(),
 		}
 	}
 
@@ -602,6 +949,7 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 
 	var nextStatus model.Status
 	var shouldIncrementAttempt bool
+	var applyDecision func(s *sbi.SBI) error // nil when the stored SBI already holds the decision
 
 	// Handle reload case (for REVIEW steps where AI updates DB directly)
 	if action.NeedsReload {
@@ -624,27 +972,40 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 			currentAttempt++
 		}
 
-		// Update SBI entity with new status
-		if err := currentSBI.UpdateStatus(nextStatus); err != nil {
-			return nil, fmt.Errorf("failed to update SBI status: %w", err)
-		}
+		applyDecision = func(s *sbi.SBI) error {
+			// Update SBI entity with new status
+			// (a reloaded SBI may already be there, moved by the agent's `sbi report`)
+			if s.Status() != nextStatus {
+				if err := s.UpdateStatus(nextStatus); err != nil {
+					return fmt.Errorf("failed to update SBI status: %w", err)
+				}
+			}
 
-		// Record work start time when task is picked
-		if action.NextStatus == model.StatusPicked {
-			currentSBI.MarkAsStarted()
-		}
+			// Record work start time when task is picked
+			if action.NextStatus == model.StatusPicked {
+				s.MarkAsStarted()
+			}
 
-		// Record work completion time when task is done or failed
-		if nextStatus == model.StatusDone || nextStatus == model.StatusFailed {
-			currentSBI.MarkAsCompleted()
-		}
+			// Record work completion time when task is done or failed
+			if nextStatus == model.StatusDone || nextStatus == model.StatusFailed {
+				s.MarkAsCompleted()
+			}
 
-		// Update turn in execution state
-		if action.ShouldIncrementTurn {
-			currentSBI.IncrementTurn()
+			// Update turn in execution state
+			if action.ShouldIncrementTurn {
+				s.IncrementTurn()
+			}
+			return nil
+		}
+		if err := applyDecision(currentSBI); err != nil {
+			return nil, err
 		}
 	}
 
+	uc.publishEvent(output.TurnEventDecision, currentSBI.ID().String(), currentTurn,
+		uc.statusToStep(uc.mapDomainStatusToString(prevStatus)),
+		map[string]string{"decision": stepOutput.Decision, "next_status": uc.mapDomainStatusToString(nextStatus)})
+
 	// NOTE: done.md generation is commented out due to performance concerns
 	//
 	// BACKGROUND: Generating done.md takes 2-5 minutes per task, blocking the next task from starting.
@@ -681,46 +1042,73 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 	// }
 	var doneArtifactPath string // Keep variable for journal compatibility
 
-	// 8. Save SBI to DB
-	if err := uc.sbiRepo.Save(ctx, currentSBI); err != nil {
-		return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
-	}
-
-	// 9. Write journal entry
+	// 8. Save SBI to DB with its journal entry
+	// The entry records the status as saved, which a concurrent save may have changed
 	artifacts := []interface{}{stepOutput.ArtifactPath}
 	if doneArtifactPath != "" {
 		artifacts = append(artifacts, doneArtifactPath)
 	}
+	if notes := uc.promptNotesArtifact(ctx, currentSBI.ID().String(), currentTurn); notes != nil {
+		artifacts = append(artifacts, notes)
+	}
+	if check := artifactCheckArtifact(stepOutput); check != nil {
+		artifacts = append(artifacts, check)
+	}
+	if checkpoint := checkpointArtifact(stepOutput); checkpoint != nil {
+		artifacts = append(artifacts, checkpoint)
+	}
+	if routing := routingArtifact(stepOutput); routing != nil {
+		artifacts = append(artifacts, routing)
+	}
+	if experiment := uc.promptExperimentArtifact(currentSBI.ID().String()); experiment != nil {
+		artifacts = append(artifacts, experiment)
+	}
+	if budget := uc.changeBudgetArtifact(stepOutput); budget != nil {
+		artifacts = append(artifacts, budget)
+	}
 
 	journalRecord := &repository.JournalRecord{
-		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
-		SBIID:     currentSBI.ID().String(),
-		Turn:      currentTurn,
-		Step:      uc.statusToStep(uc.mapDomainStatusToString(nextStatus)),
-		Status:    uc.mapDomainStatusToString(nextStatus),
-		Attempt:   currentAttempt,
-		Decision:  stepOutput.Decision,
-		ElapsedMs: time.Since(startTime).Milliseconds(),
-		Error:     stepOutput.ErrorMsg,
-		Artifacts: artifacts,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:      currentSBI.ID().String(),
+		Turn:       currentTurn,
+		Attempt:    currentAttempt,
+		Decision:   stepOutput.Decision,
+		Error:      stepOutput.ErrorMsg,
+		ErrorClass: stepOutput.ErrorClass,
+		Artifacts:  artifacts,
 	}
-
-	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
-		// Log warning to stderr but don't fail the operation
-		// Journal is for auditing purposes and shouldn't block execution
-		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry\n")
-		fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
-		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: %s, Status: %s\n",
-			currentSBI.ID().String(), currentTurn,
-			uc.statusToStep(uc.mapDomainStatusToString(nextStatus)),
-			uc.mapDomainStatusToString(nextStatus))
-		fmt.Fprintf(os.Stderr, "   Journal Record: Timestamp=%s, Attempt=%d, Decision=%s\n",
-			journalRecord.Timestamp, currentAttempt, stepOutput.Decision)
+	priorRecords := uc.priorJournalRecords(ctx, currentSBI.ID().String())
+	err = uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+		savedSBI, err := uc.saveStepResult(ctx, currentSBI, prevStatus, nextStatus, applyDecision)
+		if err != nil {
+			return err
+		}
+		currentSBI = savedSBI
+		nextStatus = currentSBI.Status()
+		journalRecord.Step = uc.statusToStep(uc.mapDomainStatusToString(nextStatus))
+		journalRecord.Seq = repository.NextJournalSeq(priorRecords, journalRecord.SBIID, journalRecord.Turn, journalRecord.Step)
+		journalRecord.Status = uc.mapDomainStatusToString(nextStatus)
+		journalRecord.ElapsedMs = time.Since(startTime).Milliseconds()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrSBICancelled) {
+			// `sbi cancel` landed after the last cancellation poll; keep CANCELLED
+			return uc.cancelledOutput(currentSBI, currentTurn, currentAttempt, prevStatus, startTime), nil
+		}
+		return nil, fmt.Errorf("failed to save SBI to DB: %w", err)
 	}
 
-	// 10. Note: State sync removed - DB is single source of truth
+	uc.notifyAtRisk(ctx, currentSBI, budgetBefore)
+	uc.checkPBIAcceptance(ctx, currentSBI)
 
-	// 11. Build output
+	uc.publishEvent(output.TurnEventSaved, currentSBI.ID().String(), currentTurn,
+		uc.statusToStep(uc.mapDomainStatusToString(prevStatus)),
+		map[string]string{"status": uc.mapDomainStatusToString(nextStatus)})
+
+	// 9. Note: State sync removed - DB is single source of truth
+
+	// 10. Build output
 	taskCompleted := (nextStatus == currentSBI.Status()) // Check if status is DONE
 
 	return &dto.RunTurnOutput{
@@ -741,87 +1129,1378 @@ func (uc *RunTurnUseCase) Execute(ctx context.Context, input dto.RunTurnInput) (
 
 // Helper functions
 
-// executeStepForSBI executes a workflow step for an SBI entity
-func (uc *RunTurnUseCase) executeStepForSBI(ctx context.Context, sbiEntity *sbi.SBI, turn int, attempt int) (*dto.ExecuteStepOutput, error) {
-	// Extract SBI ID and status
+// publishEvent publishes a turn lifecycle event when an event publisher is configured
+func (uc *RunTurnUseCase) publishEvent(eventType output.TurnEventType, sbiID string, turn int, step string, data map[string]string) {
+	if uc.eventPublisher == nil {
+		return
+	}
+	uc.eventPublisher.Publish(output.TurnEvent{
+		Type:      eventType,
+		SBIID:     sbiID,
+		Turn:      turn,
+		Step:      step,
+		Timestamp: time.Now().UTC(),
+		Data:      data,
+	})
+}
+
+// fitContextWindow summarizes or refuses a prompt that does not fit the model's context window
+// together with the files it has the agent read, so that the agent never truncates it silently
+func (uc *RunTurnUseCase) fitContextWindow(sbiID, step string, capability output.AgentCapability, agentReq *output.AgentRequest) error {
+	prompt, estimate, err := uc.contextWindow.Fit(agentReq.Prompt, agentReq.Model, capability.MaxPromptSize)
+	if err != nil {
+		return err
+	}
+	if prompt != agentReq.Prompt {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: %s prompt of %s did not fit the %d-token context window; its largest sections were summarized (now about %d tokens)\n",
+			step, sbiID, estimate.Window, estimate.Tokens())
+		agentReq.Prompt = prompt
+	}
+	return nil
+}
+
+// retryBudgetUsage returns the retry budget usage of an SBI (zero without a retry budget)
+func (uc *RunTurnUseCase) retryBudgetUsage(sbiEntity *sbi.SBI) service.RetryBudgetUsage {
+	if uc.retryBudget == nil {
+		return service.RetryBudgetUsage{}
+	}
+	return uc.retryBudget.UsageOf(sbiEntity)
+}
+
+// notifyAtRisk notifies when the turn made the SBI at risk of exhausting its retry budget
+// Notification failures only warn; they never fail the turn.
+func (uc *RunTurnUseCase) notifyAtRisk(ctx context.Context, sbiEntity *sbi.SBI, before service.RetryBudgetUsage) {
+	if uc.retryBudget == nil {
+		return
+	}
+	if _, err := uc.retryBudget.NotifyIfAtRisk(ctx, sbiEntity, before); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: %v\n", err)
+	}
+}
+
+// checkPBIAcceptance runs the acceptance test of the SBI's PBI when the SBI was its last one to finish
+// A failing or broken check never fails the turn: the SBI stays DONE.
+func (uc *RunTurnUseCase) checkPBIAcceptance(ctx context.Context, sbiEntity *sbi.SBI) {
+	if uc.pbiAcceptance == nil || sbiEntity.Status() != model.StatusDone || sbiEntity.ParentTaskID() == nil {
+		return
+	}
+	pbiID := sbiEntity.ParentTaskID().String()
+	result, err := uc.pbiAcceptance.RunIfComplete(ctx, pbiID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Acceptance test of %s failed to run: %v\n", pbiID, err)
+		return
+	}
+	if result == nil {
+		return
+	}
+
+	switch {
+	case result.Passed:
+		fmt.Fprintf(os.Stderr, "✅ %s passed its acceptance test and is done\n", pbiID)
+	case result.PBIFailed:
+		fmt.Fprintf(os.Stderr, "🛑 %s failed its acceptance test %d times and is marked failed (%s)\n", pbiID, result.Attempt, result.ReportPath)
+	case len(result.ReopenedSBIIDs) > 0:
+		fmt.Fprintf(os.Stderr, "🔁 %s failed its acceptance test; reopened %s (%s)\n", pbiID, strings.Join(result.ReopenedSBIIDs, ", "), result.ReportPath)
+	default:
+		fmt.Fprintf(os.Stderr, "🔁 %s failed its acceptance test; registered fix SBI %s (%s)\n", pbiID, result.FixSBIID, result.ReportPath)
+	}
+}
+
+// priorJournalRecords returns the journaled records of an SBI, to number a step journaled
+// again in the same turn (e.g. a review re-run because the agent never reported), which
+// would otherwise be skipped as a retried write. Returns nil when the journal cannot be read.
+func (uc *RunTurnUseCase) priorJournalRecords(ctx context.Context, sbiID string) []*repository.JournalRecord {
+	records, err := uc.journalRepo.FindBySBI(ctx, sbiID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to read the journal of %s: %v\n", sbiID, err)
+		return nil
+	}
+	return records
+}
+
+// saveWithJournal runs save and appends journalRecord, which save may complete
+// A journaled error without a class is classified from its message.
+// Within a unit of work a failed append rolls the save back and is returned, so that the
+// SBI and the journal never disagree; otherwise the append is best effort and only warns.
+func (uc *RunTurnUseCase) saveWithJournal(ctx context.Context, journalRecord *repository.JournalRecord, save func(ctx context.Context) error) error {
+	if journalRecord.Error != "" && journalRecord.ErrorClass == "" {
+		journalRecord.ErrorClass = service.ClassifyErrorMessage(journalRecord.Error)
+	}
+	if uc.unitOfWork != nil {
+		return uc.unitOfWork.Do(ctx, func(txCtx context.Context) error {
+			if err := save(txCtx); err != nil {
+				return err
+			}
+			if err := uc.journalRepo.Append(txCtx, journalRecord); err != nil {
+				return fmt.Errorf("failed to append journal entry: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := save(ctx); err != nil {
+		return err
+	}
+	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
+		// Journal is for auditing purposes and shouldn't block execution
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry (%s)\n", journalRecord.Step)
+		fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: %s, Status: %s\n",
+			journalRecord.SBIID, journalRecord.Turn, journalRecord.Step, journalRecord.Status)
+	}
+	return nil
+}
+
+// saveStepResult saves the SBI after a workflow step and returns it as stored
+// The agent's own `sbi report` (or another command) may have saved the SBI while the step ran,
+// failing the save with repository.ErrStaleEntity. The SBI is then reloaded: while it is still at
+// prevStatus or already at the decided nextStatus, applyDecision is repeated on it and saved;
+// otherwise, or without applyDecision, the concurrent save superseded this turn and is kept.
+func (uc *RunTurnUseCase) saveStepResult(ctx context.Context, sbiEntity *sbi.SBI, prevStatus, nextStatus model.Status, applyDecision func(s *sbi.SBI) error) (*sbi.SBI, error) {
+	saved := sbiEntity
+	reload := false
+	err := service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		if reload {
+			stored, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiEntity.ID().String()))
+			if err != nil {
+				return fmt.Errorf("failed to reload SBI after concurrent save: %w", err)
+			}
+			if stored == nil {
+				return fmt.Errorf("SBI disappeared after concurrent save: %s", sbiEntity.ID().String())
+			}
+			if stored.IsCancelled() {
+				return fmt.Errorf("save SBI %s: %w", stored.ID().String(), repository.ErrSBICancelled)
+			}
+			saved = stored
+			if applyDecision == nil || (stored.Status() != prevStatus && stored.Status() != nextStatus) {
+				return nil
+			}
+			if err := applyDecision(stored); err != nil {
+				return err
+			}
+		}
+		reload = true
+		return uc.sbiRepo.Save(ctx, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// watchCancellation derives a step context that is cancelled once the SBI is cancelled
+// via `deespec sbi cancel` (possibly from another process). The returned function
+// reports whether cancellation was observed; stop must be called when the step ends.
+func (uc *RunTurnUseCase) watchCancellation(ctx context.Context, sbiID string) (context.Context, func() bool, context.CancelFunc) {
+	stepCtx, cancel := context.WithCancel(ctx)
+	var cancelled atomic.Bool
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(uc.cancelPoll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-stepCtx.Done():
+				return
+			case <-ticker.C:
+				current, err := uc.sbiRepo.Find(stepCtx, repository.SBIID(sbiID))
+				if err == nil && current != nil && current.IsCancelled() {
+					cancelled.Store(true)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() {
+			close(done)
+			cancel()
+		})
+	}
+	return stepCtx, cancelled.Load, stop
+}
+
+// cancelledOutput builds the turn output for a step aborted by cancellation
+func (uc *RunTurnUseCase) cancelledOutput(sbiEntity *sbi.SBI, turn, attempt int, prevStatus model.Status, startTime time.Time) *dto.RunTurnOutput {
+	return &dto.RunTurnOutput{
+		Turn:          turn,
+		SBIID:         sbiEntity.ID().String(),
+		PrevStatus:    uc.mapDomainStatusToString(prevStatus),
+		NextStatus:    uc.mapDomainStatusToString(model.StatusCancelled),
+		Decision:      "CANCELLED",
+		Attempt:       attempt,
+		ErrorMsg:      "cancelled during execution",
+		ElapsedMs:     time.Since(startTime).Milliseconds(),
+		CompletedAt:   time.Now(),
+		TaskCompleted: true,
+	}
+}
+
+// checkTurnBudget returns a NoOp output when the SBI's turn budget is exhausted, or nil to run the turn
+// The SBI is left untouched so that it resumes once the budget is raised or the day rolls over
+func (uc *RunTurnUseCase) checkTurnBudget(ctx context.Context, sbiEntity *sbi.SBI, startTime time.Time) *dto.RunTurnOutput {
+	if uc.turnBudget == nil {
+		return nil
+	}
+	reason, err := uc.turnBudget.Check(ctx, sbiEntity)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to check turn budget for %s: %v\n", sbiEntity.ID().String(), err)
+		return nil
+	}
+	if reason == "" {
+		return nil
+	}
+	status := uc.mapDomainStatusToString(sbiEntity.Status())
+	return &dto.RunTurnOutput{
+		SBIID:       sbiEntity.ID().String(),
+		NoOp:        true,
+		NoOpReason:  reason,
+		PrevStatus:  status,
+		NextStatus:  status,
+		ElapsedMs:   time.Since(startTime).Milliseconds(),
+		CompletedAt: time.Now(),
+	}
+}
+
+// claimTurn claims the turn of an SBI before its step is executed
+// Returns a NoOp output, after journaling the conflict, when another runner holds the turn,
+// or nil to execute it. Failing to claim only warns: the locks still guard the turn.
+func (uc *RunTurnUseCase) claimTurn(ctx context.Context, sbiEntity *sbi.SBI, turn, attempt int, prevStatus model.Status, startTime time.Time) *dto.RunTurnOutput {
+	if uc.turnClaims == nil {
+		return nil
+	}
 	sbiID := sbiEntity.ID().String()
+	holder, err := uc.turnClaims.Claim(ctx, sbiID, turn, turnClaimStaleAfter)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, repository.ErrTurnClaimed) {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to claim turn %d of %s: %v\n", turn, sbiID, err)
+		return nil
+	}
+
+	errorMsg := fmt.Sprintf("turn %d is already claimed", turn)
+	if holder != nil {
+		errorMsg = fmt.Sprintf("turn %d is already claimed by pid %d on %s since %s",
+			turn, holder.PID, holder.Hostname, holder.ClaimedAt.Format(time.RFC3339))
+	}
+	status := uc.mapDomainStatusToString(prevStatus)
+	journalRecord := &repository.JournalRecord{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:      sbiID,
+		Turn:       turn,
+		Step:       "turn_conflict",
+		Status:     status,
+		Attempt:    attempt,
+		Decision:   "TURN_CONFLICT",
+		ElapsedMs:  time.Since(startTime).Milliseconds(),
+		Error:      errorMsg,
+		ErrorClass: service.ErrorClassLockLost,
+		Artifacts:  []interface{}{},
+	}
+	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to journal the turn conflict of %s: %v\n", sbiID, err)
+	}
+
+	return &dto.RunTurnOutput{
+		Turn:        turn,
+		SBIID:       sbiID,
+		NoOp:        true,
+		NoOpReason:  NoOpReasonTurnConflict,
+		PrevStatus:  status,
+		NextStatus:  status,
+		Attempt:     attempt,
+		ErrorMsg:    errorMsg,
+		ElapsedMs:   time.Since(startTime).Milliseconds(),
+		CompletedAt: time.Now(),
+	}
+}
+
+// releaseUnsavedTurn releases the claim of a turn the SBI did not advance to,
+// so that any runner may retry it (e.g. after the save failed)
+func (uc *RunTurnUseCase) releaseUnsavedTurn(ctx context.Context, sbiID string, turn int) {
+	if uc.turnClaims == nil {
+		return
+	}
+	// Release even when the turn was interrupted by a stop
+	ctx = context.WithoutCancel(ctx)
+	saved, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	if err == nil && saved != nil && saved.ExecutionState() != nil && saved.ExecutionState().CurrentTurn.Value() >= turn {
+		return
+	}
+	if err := uc.turnClaims.Release(ctx, sbiID, turn); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to release turn %d of %s: %v\n", turn, sbiID, err)
+	}
+}
+
+// checkSpecPreflight reports the spec warnings of an SBI about to be picked for its first turn
+// Returns a NoOp output when the warnings hold it back, or nil to pick it
+func (uc *RunTurnUseCase) checkSpecPreflight(sbiEntity *sbi.SBI, startTime time.Time) *dto.RunTurnOutput {
+	if uc.specPreflight == nil {
+		return nil
+	}
+	warnings := uc.specPreflight.Check(sbiEntity)
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	sbiID := sbiEntity.ID().String()
+	fmt.Fprintf(os.Stderr, "⚠️  WARNING: Spec of %s has %d quality warning(s):\n", sbiID, len(warnings))
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "   - [%s] %s\n", warning.Check, warning.Message)
+	}
+	if !uc.specPreflight.Blocks() {
+		return nil
+	}
+
+	status := uc.mapDomainStatusToString(sbiEntity.Status())
+	return &dto.RunTurnOutput{
+		SBIID:       sbiID,
+		NoOp:        true,
+		NoOpReason:  service.NoOpReasonSpecQualityWarnings,
+		PrevStatus:  status,
+		NextStatus:  status,
+		ElapsedMs:   time.Since(startTime).Milliseconds(),
+		CompletedAt: time.Now(),
+	}
+}
+
+// handleReviewTimeout times out the review of an SBI that has been REVIEWING longer than the
+// review decision SLA, e.g. because the agent crashed without issuing `sbi review`
+// The timeout is journaled and routed per policy: retry sends the SBI back to implementation,
+// force_implement runs the force_implement step and finishes the SBI (FAILED when the step fails).
+// Returns nil when no SLA is configured or the review is within it, so that the review step runs.
+func (uc *RunTurnUseCase) handleReviewTimeout(ctx context.Context, sbiEntity *sbi.SBI, turn, attempt int, startTime time.Time) (*dto.RunTurnOutput, error) {
+	if uc.reviewTimeout == nil {
+		return nil, nil
+	}
+	waited, expired := uc.reviewTimeout.Expired(sbiEntity)
+	if !expired {
+		return nil, nil
+	}
+
+	sbiID := sbiEntity.ID().String()
+	prevStatus := sbiEntity.Status()
+	policy := uc.reviewTimeout.Policy()
+	errorMsg := fmt.Sprintf("review decision not received within %s (waited %s)",
+		uc.reviewTimeout.Timeout(), waited.Round(time.Second))
+	fmt.Fprintf(os.Stderr, "⏰ Review of %s timed out: %s; applying policy %s\n", sbiID, errorMsg, policy)
+
+	nextStatus := model.StatusImplementing
+	var artifacts []interface{}
+	if policy == service.ReviewTimeoutForceImplement {
+		stepCtx, wasCancelled, stopWatch := uc.watchCancellation(ctx, sbiID)
+		stepOutput, err := uc.executeStep(stepCtx, sbiEntity, "force_implement", turn, attempt)
+		stopWatch()
+		if wasCancelled() {
+			return uc.cancelledOutput(sbiEntity, turn, attempt, prevStatus, startTime), nil
+		}
+		nextStatus = model.StatusDone
+		if err != nil {
+			nextStatus = model.StatusFailed
+			errorMsg += "; force_implement failed: " + err.Error()
+		} else {
+			artifacts = append(artifacts, stepOutput.ArtifactPath)
+		}
+	}
+
+	applyDecision := func(s *sbi.SBI) error {
+		if s.Status() == nextStatus {
+			return nil
+		}
+		if err := s.UpdateStatus(nextStatus); err != nil {
+			return fmt.Errorf("failed to update SBI status after review timeout: %w", err)
+		}
+		switch nextStatus {
+		case model.StatusImplementing:
+			s.IncrementTurn()
+		case model.StatusFailed:
+			s.RecordError(errorMsg)
+			s.MarkAsCompleted()
+		default:
+			s.MarkAsCompleted()
+		}
+		return nil
+	}
+	if err := applyDecision(sbiEntity); err != nil {
+		return nil, err
+	}
+
+	// The journal entry records the status as saved: a decision that arrived meanwhile is kept
+	journalRecord := &repository.JournalRecord{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:      sbiID,
+		Turn:       turn,
+		Step:       "review_timeout",
+		Attempt:    attempt,
+		Decision:   "REVIEW_TIMEOUT",
+		Error:      errorMsg,
+		ErrorClass: service.ErrorClassAgentTimeout,
+		Artifacts:  artifacts,
+	}
+	if journalRecord.Artifacts == nil {
+		journalRecord.Artifacts = []interface{}{}
+	}
+	err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+		saved, err := uc.saveStepResult(ctx, sbiEntity, prevStatus, nextStatus, applyDecision)
+		if err != nil {
+			return err
+		}
+		sbiEntity = saved
+		nextStatus = saved.Status()
+		journalRecord.Status = uc.mapDomainStatusToString(nextStatus)
+		journalRecord.ElapsedMs = time.Since(startTime).Milliseconds()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrSBICancelled) {
+			return uc.cancelledOutput(sbiEntity, turn, attempt, prevStatus, startTime), nil
+		}
+		return nil, fmt.Errorf("failed to save SBI after review timeout: %w", err)
+	}
+	uc.checkPBIAcceptance(ctx, sbiEntity)
+
+	return &dto.RunTurnOutput{
+		Turn:          turn,
+		SBIID:         sbiID,
+		PrevStatus:    uc.mapDomainStatusToString(prevStatus),
+		NextStatus:    uc.mapDomainStatusToString(nextStatus),
+		Decision:      "REVIEW_TIMEOUT",
+		Attempt:       attempt,
+		ErrorMsg:      errorMsg,
+		ElapsedMs:     time.Since(startTime).Milliseconds(),
+		CompletedAt:   time.Now(),
+		TaskCompleted: nextStatus == model.StatusDone,
+	}, nil
+}
+
+// terminateAtTurnLimit ends an SBI that has exhausted its turn limit
+// Without a failure policy the SBI is force-completed as DONE. With one it terminates as FAILED,
+// after an optional postmortem step has written an analysis of why it failed; a failed
+// postmortem does not keep the SBI from terminating.
+func (uc *RunTurnUseCase) terminateAtTurnLimit(ctx context.Context, sbiEntity *sbi.SBI, prevStatus model.Status, turn, attempt int, startTime time.Time) (*dto.RunTurnOutput, error) {
+	sbiID := sbiEntity.ID().String()
+	errorMsg := fmt.Sprintf("Exceeded max turns (%d)", uc.maxTurns)
+
+	if !uc.failurePolicy.FailsOnLimit() {
+		// Force termination - must follow valid state transitions
+		// If currently IMPLEMENTING, transition to REVIEWING first, then to DONE
+		if prevStatus == model.StatusImplementing {
+			if err := sbiEntity.UpdateStatus(model.StatusReviewing); err != nil {
+				return nil, fmt.Errorf("failed to transition to REVIEWING: %w", err)
+			}
+		}
+		// Now transition to DONE (valid from REVIEWING status)
+		if err := sbiEntity.UpdateStatus(model.StatusDone); err != nil {
+			return nil, fmt.Errorf("failed to mark SBI as done: %w", err)
+		}
+		// Record work completion time for force termination
+		sbiEntity.MarkAsCompleted()
+		// Save the SBI with the journal entry for force termination
+		journalRecord := &repository.JournalRecord{
+			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+			SBIID:     sbiID,
+			Turn:      turn,
+			Step:      "force_terminated",
+			Status:    "DONE",
+			Attempt:   attempt,
+			Decision:  "FORCE_TERMINATED",
+			ElapsedMs: time.Since(startTime).Milliseconds(),
+			Error:     errorMsg,
+			Artifacts: []interface{}{},
+		}
+		if err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+			return uc.sbiRepo.Save(ctx, sbiEntity)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save SBI after force termination: %w", err)
+		}
+		uc.checkPBIAcceptance(ctx, sbiEntity)
+
+		return &dto.RunTurnOutput{
+			Turn:          turn,
+			SBIID:         sbiID,
+			NoOp:          false,
+			PrevStatus:    uc.mapDomainStatusToString(prevStatus),
+			NextStatus:    "DONE",
+			Decision:      "FORCE_TERMINATED",
+			ElapsedMs:     time.Since(startTime).Milliseconds(),
+			CompletedAt:   time.Now(),
+			TaskCompleted: true,
+		}, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "🛑 %s exceeded max turns (%d); terminating as FAILED\n", sbiID, uc.maxTurns)
+	artifacts := []interface{}{}
+	if uc.failurePolicy.Postmortem() {
+		stepCtx, wasCancelled, stopWatch := uc.watchCancellation(ctx, sbiID)
+		stepOutput, err := uc.executeStep(stepCtx, sbiEntity, "postmortem", turn, attempt)
+		stopWatch()
+		if wasCancelled() {
+			return uc.cancelledOutput(sbiEntity, turn, attempt, prevStatus, startTime), nil
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Postmortem of %s failed: %v\n", sbiID, err)
+		} else {
+			artifacts = append(artifacts, stepOutput.ArtifactPath)
+		}
+	}
+
+	// FAILED is only reachable from IMPLEMENTING and REVIEWING: SBIs still queued are walked there first
+	applyDecision := func(s *sbi.SBI) error {
+		for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusFailed} {
+			if s.Status().CanTransitionTo(next) && (next == model.StatusFailed || !s.Status().CanTransitionTo(model.StatusFailed)) {
+				if err := s.UpdateStatus(next); err != nil {
+					return fmt.Errorf("failed to transition to %s: %w", next, err)
+				}
+			}
+		}
+		s.RecordError(errorMsg)
+		s.MarkAsCompleted()
+		return nil
+	}
+	if err := applyDecision(sbiEntity); err != nil {
+		return nil, err
+	}
+
+	journalRecord := &repository.JournalRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:     sbiID,
+		Turn:      turn,
+		Step:      "force_terminated",
+		Attempt:   attempt,
+		Decision:  "FORCE_TERMINATED",
+		Error:     errorMsg,
+		Artifacts: artifacts,
+	}
+	nextStatus := model.StatusFailed
+	err := uc.saveWithJournal(ctx, journalRecord, func(ctx context.Context) error {
+		saved, err := uc.saveStepResult(ctx, sbiEntity, prevStatus, nextStatus, applyDecision)
+		if err != nil {
+			return err
+		}
+		sbiEntity = saved
+		nextStatus = saved.Status()
+		journalRecord.Status = uc.mapDomainStatusToString(nextStatus)
+		journalRecord.ElapsedMs = time.Since(startTime).Milliseconds()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrSBICancelled) {
+			return uc.cancelledOutput(sbiEntity, turn, attempt, prevStatus, startTime), nil
+		}
+		return nil, fmt.Errorf("failed to save SBI after force termination: %w", err)
+	}
+
+	return &dto.RunTurnOutput{
+		Turn:        turn,
+		SBIID:       sbiID,
+		PrevStatus:  uc.mapDomainStatusToString(prevStatus),
+		NextStatus:  uc.mapDomainStatusToString(nextStatus),
+		Decision:    "FORCE_TERMINATED",
+		Attempt:     attempt,
+		ErrorMsg:    errorMsg,
+		ElapsedMs:   time.Since(startTime).Milliseconds(),
+		CompletedAt: time.Now(),
+	}, nil
+}
+
+// executeStepForSBI executes the workflow step of the SBI's current status
+func (uc *RunTurnUseCase) executeStepForSBI(ctx context.Context, sbiEntity *sbi.SBI, turn int, attempt int) (*dto.ExecuteStepOutput, error) {
 	currentStatus := uc.mapDomainStatusToString(sbiEntity.Status())
-	step := uc.statusToStep(currentStatus)
+	return uc.executeStep(ctx, sbiEntity, uc.statusToStep(currentStatus), turn, attempt)
+}
+
+// executeStep executes the given workflow step for an SBI entity
+func (uc *RunTurnUseCase) executeStep(ctx context.Context, sbiEntity *sbi.SBI, step string, turn int, attempt int) (*dto.ExecuteStepOutput, error) {
+	sbiID := sbiEntity.ID().String()
 
 	// Determine artifact path
 	// Since v0.2.13, reports are saved to .deespec/reports/sbi/ via commands
 	// This path is used for journal records and fallback file creation
 	var artifactPath string
-	if step == "done" {
-		artifactPath = fmt.Sprintf(".deespec/reports/sbi/%s/done.md", sbiID)
+	if step == "done" || step == "postmortem" {
+		artifactPath = fmt.Sprintf(".deespec/reports/sbi/%s/%s.md", sbiID, step)
 	} else {
 		artifactPath = fmt.Sprintf(".deespec/reports/sbi/%s/%s_%d.md", sbiID, step, turn)
 	}
 
+	// Several reviewer agents vote on the turn in review quorum mode
+	if step == "review" && uc.reviewQuorum != nil {
+		return uc.executeReviewQuorum(ctx, sbiEntity, turn, attempt)
+	}
+
 	// Build prompt with artifact generation instruction
-	prompt := uc.buildPromptWithArtifact(sbiEntity, step, turn, attempt, artifactPath)
+	prompt := uc.buildPromptWithArtifact(ctx, sbiEntity, step, turn, attempt, artifactPath)
+	uc.publishEvent(output.TurnEventPromptBuilt, sbiID, turn, step,
+		map[string]string{"prompt_bytes": strconv.Itoa(len(prompt))})
+
+	// Resolve agent gateway and model (label routing and routing rules if configured)
+	gateway := uc.agentGateway
+	agentReq, err := uc.newAgentRequest(ctx, sbiEntity, step, turn, prompt)
+	if err != nil {
+		return nil, err
+	}
+	routing := &dto.AgentRouting{Source: service.RouteSourceDefault}
+	if uc.agentRegistry != nil {
+		var routedGateway output.AgentGateway
+		var routedReq output.AgentRequest
+		var decision service.RouteDecision
+		task := taskTraits(sbiEntity)
+		if step == "review" {
+			routedGateway, routedReq, decision, err = uc.agentRegistry.BuildTaskReviewRequest(ctx, task, uc.findImplementer(ctx, sbiID), agentReq)
+		} else {
+			routedGateway, routedReq, decision, err = uc.agentRegistry.BuildTaskRequest(ctx, task, step, agentReq)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve agent for step %s: %w", step, err)
+		}
+		gateway = routedGateway
+		agentReq = routedReq
+		routing = &dto.AgentRouting{Source: decision.Source, Rule: decision.Rule}
+		if decision.Source == service.RouteSourceRule {
+			fmt.Fprintf(os.Stderr, "ℹ️  Routing %s of %s by rule %q to %s\n", step, sbiID, decision.Rule, describeRoute(gateway.GetCapability().AgentType, agentReq.Model))
+		}
+	}
+	routing.Agent = gateway.GetCapability().AgentType
+	routing.Model = agentReq.Model
+	dropUnsupportedMCP(gateway.GetCapability(), &agentReq, sbiID, step)
+	if err := uc.modelPolicy.Check(step, agentReq.Model); err != nil {
+		return nil, err
+	}
+	if err := uc.fitContextWindow(sbiID, step, gateway.GetCapability(), &agentReq); err != nil {
+		return nil, err
+	}
+
+	// Stream agent output to live progress views; agents that cannot stream
+	// publish their whole output as a single chunk once they finish
+	var streamed atomic.Bool
+	if uc.eventPublisher != nil {
+		agentReq.OnOutput = func(chunk string) {
+			streamed.Store(true)
+			uc.publishEvent(output.TurnEventAgentOutputChunk, sbiID, turn, step, map[string]string{"chunk": chunk})
+		}
+		uc.publishEvent(output.TurnEventAgentStarted, sbiID, turn, step,
+			map[string]string{"agent": gateway.GetCapability().AgentType, "model": agentReq.Model})
+	}
+
+	// Continue the step's previous conversation when the agent supports sessions
+	capability := gateway.GetCapability()
+	if uc.sessionRepo != nil && capability.SupportsSessions {
+		session, err := uc.sessionRepo.Find(ctx, sbiID, step)
+		if err == nil && session != nil && session.AgentType == capability.AgentType {
+			agentReq.SessionID = session.SessionID
+		}
+	}
+
+	// Reuse the output of an identical earlier prompt; continued sessions are never cached
+	// because the agent's answer depends on the conversation so far
+	var cachedOutput, cacheKey string
+	cacheable := agentReq.SessionID == "" && uc.responseCache.Applies(step)
+	if cacheable {
+		var keyErr error
+		if cacheKey, keyErr = uc.responseCache.KeyPrompt(ctx, agentReq.Prompt, turn, artifactPath); keyErr != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Agent response cache disabled for %s: %v\n", sbiID, keyErr)
+			cacheable = false
+		}
+	}
+	if cacheable {
+		cached, hit, cacheErr := uc.responseCache.Lookup(ctx, step, capability.AgentType, agentReq.Model, cacheKey)
+		if cacheErr != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to read agent response cache: %v\n", cacheErr)
+		} else if hit {
+			if submitErr := uc.submitCachedOutput(ctx, sbiID, turn, step, cached); submitErr != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to submit cached %s of %s, calling the agent: %v\n", step, sbiID, submitErr)
+			} else {
+				fmt.Fprintf(os.Stderr, "ℹ️  Reusing cached %s output for %s (identical prompt and working tree)\n", step, sbiID)
+				cachedOutput = cached
+			}
+		}
+	}
+
+	// Snapshot the working tree so that the turn's change can be checked against the budget
+	var changeBase *service.ChangeBase
+	if isImplementStep(step) {
+		changeBase = uc.changeBudget.Snapshot(ctx)
+	}
 
 	// Execute agent
 	startTime := time.Now()
-	agentResult, err := uc.agentGateway.Execute(ctx, output.AgentRequest{
-		Prompt:  prompt,
-		Timeout: 10 * time.Minute,
-	})
+	execCtx, stopTimeBox := uc.startTimeBox(ctx, step, &agentReq)
+	defer stopTimeBox()
+	var agentResult *output.AgentResponse
+	if cachedOutput != "" {
+		agentResult = &output.AgentResponse{
+			Output:    cachedOutput,
+			AgentType: capability.AgentType,
+			Metadata:  map[string]string{"cache": "hit"},
+		}
+		uc.archivePrompt(ctx, sbiID, step, turn, capability.AgentType, agentReq, agentResult, nil)
+	} else {
+		agentResult, err = gateway.Execute(execCtx, agentReq)
+		uc.recordAgentCall(ctx, sbiID, step, turn, capability.AgentType)
+		uc.archivePrompt(ctx, sbiID, step, turn, capability.AgentType, agentReq, agentResult, err)
+	}
+	if err != nil && agentReq.SessionID != "" && execCtx.Err() == nil {
+		// The session may have expired on the provider side; start a fresh conversation
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to continue agent session for %s (%s), starting a new one: %v\n", sbiID, step, err)
+		if delErr := uc.sessionRepo.Delete(ctx, sbiID, step); delErr != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to delete agent session: %v\n", delErr)
+		}
+		agentReq.SessionID = ""
+		agentResult, err = gateway.Execute(execCtx, agentReq)
+		uc.recordAgentCall(ctx, sbiID, step, turn, capability.AgentType)
+		uc.archivePrompt(ctx, sbiID, step, turn, capability.AgentType, agentReq, agentResult, err)
+	}
+	if err != nil && ctx.Err() == nil && errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		stepOutput, err := uc.checkpointStep(ctx, gateway, agentReq, sbiEntity, step, turn, startTime)
+		if stepOutput != nil {
+			stepOutput.Routing = routing
+		}
+		return stepOutput, err
+	}
 	if err != nil {
+		// Agent failures that are not timeouts or lock losses are crashes of the agent
+		errorClass := service.ClassifyError(err)
+		if errorClass == service.ErrorClassUnknown {
+			errorClass = service.ErrorClassAgentCrash
+		}
 		return &dto.ExecuteStepOutput{
 			Success:     false,
 			ErrorMsg:    err.Error(),
+			ErrorClass:  errorClass,
 			ElapsedMs:   time.Since(startTime).Milliseconds(),
 			StartedAt:   startTime,
 			CompletedAt: time.Now(),
 		}, err
 	}
 
-	// Note: Decision extraction for review steps is no longer needed here
-	// Since v0.2.13, AI agents execute `deespec sbi review --decision SUCCEEDED --stdin` command
-	// which updates the status directly in ReviewSBIUseCase.Execute()
-	// The decision extraction logic is only kept for backward compatibility with old workflow
-	decision := "PENDING"
+	if !streamed.Load() && agentResult.Output != "" {
+		uc.publishEvent(output.TurnEventAgentOutputChunk, sbiID, turn, step, map[string]string{"chunk": agentResult.Output})
+	}
+
+	uc.recordStepAgent(ctx, sbiID, step, turn, capability.AgentType, agentReq.Model)
+
+	if uc.sessionRepo != nil && agentResult.SessionID != "" {
+		session := &repository.AgentSession{
+			SBIID:     sbiID,
+			Step:      step,
+			AgentType: capability.AgentType,
+			SessionID: agentResult.SessionID,
+			Turn:      turn,
+		}
+		if err := uc.sessionRepo.Save(ctx, session); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to save agent session: %v\n", err)
+		}
+	}
+
+	// Note: Since v0.2.13, AI agents execute `deespec sbi review --decision SUCCEEDED --stdin` command
+	// which updates the status directly in ReviewSBIUseCase.Execute()
+	// The extracted decision is only recorded in the journal for auditability
+	decision := "PENDING"
+
+	// Ask the agent again when it did not write its report; cached reviews were already
+	// submitted, so there is no agent to ask
+	var check *dto.ArtifactCheck
+	if cachedOutput == "" {
+		check = uc.retryMissingArtifact(ctx, gateway, agentReq, agentResult, sbiID, step, turn, artifactPath)
+	}
+
+	// If the agent still didn't create the artifact, save the output ourselves as fallback
+	if !uc.artifactWritten(artifactPath) {
+		if cachedOutput == "" {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: %s report of %s was not written by the agent, saving its output instead\n", step, sbiID)
+		}
+		artifactDir := filepath.Dir(uc.projectPath(artifactPath))
+		if err := os.MkdirAll(artifactDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+		}
+
+		if err := fs.WriteFileAtomic(uc.projectPath(artifactPath), []byte(agentResult.Output), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write artifact file: %w", err)
+		}
+	}
+
+	changeCheck := uc.enforceChangeBudget(ctx, gateway, agentReq, agentResult, sbiEntity, step, turn, artifactPath, changeBase)
+
+	provenance := &repository.ArtifactProvenance{SBIID: sbiID, Step: step, Turn: turn, AgentType: capability.AgentType, Model: agentReq.Model}
+	if step == "review" {
+		if extracted, _ := uc.extractDecision(artifactPath, agentResult.Output, sbiID); extracted != "" {
+			decision = extracted
+		}
+		provenance.Decision = decision
+
+		// Cache the submitted report, which is what a hit submits again; reviews without a
+		// decision are not worth replaying
+		if cacheable && cachedOutput == "" && decision != "PENDING" {
+			if report, readErr := os.ReadFile(uc.projectPath(artifactPath)); readErr == nil {
+				if cacheErr := uc.responseCache.Store(ctx, step, capability.AgentType, agentReq.Model, cacheKey, string(report)); cacheErr != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to cache agent response: %v\n", cacheErr)
+				}
+			}
+		}
+	}
+
+	provenance.ArtifactHash = uc.hashArtifact(artifactPath)
+	provenance.ArtifactPath = uc.offloadArtifact(ctx, artifactPath)
+	uc.recordProvenance(ctx, provenance, agentReq.Prompt, agentResult)
+
+	return &dto.ExecuteStepOutput{
+		Success:      true,
+		Output:       agentResult.Output,
+		Decision:     decision,
+		ArtifactPath: provenance.ArtifactPath,
+		ElapsedMs:    time.Since(startTime).Milliseconds(),
+		StartedAt:    startTime,
+		CompletedAt:  time.Now(),
+
+		ArtifactCheck: check,
+		Routing:       routing,
+		ChangeBudget:  changeCheck,
+	}, nil
+}
+
+// submitCachedOutput submits a cached review with the decision extracted from it, so that a
+// hit decides the turn the same way the live reviewer's `deespec sbi review` did
+func (uc *RunTurnUseCase) submitCachedOutput(ctx context.Context, sbiID string, turn int, step, cached string) error {
+	decision, _ := uc.decisionExtractor.Extract(cached)
+	if decision == "" {
+		return fmt.Errorf("no decision in cached output")
+	}
+	return uc.cacheReport(ctx, sbiID, turn, step, decision, cached)
+}
+
+// artifactWritten reports whether a report file exists with content
+func (uc *RunTurnUseCase) artifactWritten(artifactPath string) bool {
+	content, err := os.ReadFile(uc.projectPath(artifactPath))
+	return err == nil && strings.TrimSpace(string(content)) != ""
+}
+
+// retryMissingArtifact sends short corrective prompts while the step's report is missing or
+// empty, up to the configured number of attempts
+// Returns nil when the agent wrote the report on its own.
+func (uc *RunTurnUseCase) retryMissingArtifact(ctx context.Context, gateway output.AgentGateway, agentReq output.AgentRequest, agentResult *output.AgentResponse, sbiID, step string, turn int, artifactPath string) *dto.ArtifactCheck {
+	if uc.artifactWritten(artifactPath) {
+		return nil
+	}
+	check := &dto.ArtifactCheck{Outcome: dto.ArtifactFallback}
+
+	// Continue the conversation when the agent has one, so the prompt can stay short
+	agentReq.SessionID = agentResult.SessionID
+	agentReq.Prompt = buildArtifactRetryPrompt(step, artifactPath, agentResult.Output, agentReq.SessionID != "")
+	agentReq.OnOutput = nil
+	agentType := gateway.GetCapability().AgentType
+
+	for check.Retries < uc.artifactRetries && ctx.Err() == nil {
+		check.Retries++
+		fmt.Fprintf(os.Stderr, "⚠️  %s report of %s is missing or empty, asking the agent to write it (%d/%d)\n",
+			step, sbiID, check.Retries, uc.artifactRetries)
+		resp, err := gateway.Execute(ctx, agentReq)
+		uc.recordAgentCall(ctx, sbiID, step, turn, agentType)
+		uc.archivePrompt(ctx, sbiID, step, turn, agentType, agentReq, resp, err)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Corrective prompt for %s failed: %v\n", sbiID, err)
+			continue
+		}
+		if uc.artifactWritten(artifactPath) {
+			check.Outcome = dto.ArtifactCreatedAfterRetry
+			break
+		}
+	}
+	return check
+}
+
+// buildArtifactRetryPrompt builds the follow-up prompt asking an agent to write the report it
+// left missing or empty; without a continued session the previous output is included
+func buildArtifactRetryPrompt(step, artifactPath, previousOutput string, continued bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("The %s report was not written: %s is missing or empty.\n\n", step, artifactPath))
+	sb.WriteString("Write your complete report for this step to that file now using the Write tool. ")
+	sb.WriteString("Do not repeat the work itself; only write the report.\n")
+	if !continued && strings.TrimSpace(previousOutput) != "" {
+		sb.WriteString("\n## Your Previous Response\n\n")
+		sb.WriteString(previousOutput)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// enforceChangeBudget checks the change an implement step made against the per-turn change budget
+// An oversized change is sent back to the agent to be split; when it stays over the budget the
+// turn is flagged for human review. Returns nil when the change is within the budget at first or
+// cannot be measured.
+func (uc *RunTurnUseCase) enforceChangeBudget(ctx context.Context, gateway output.AgentGateway, agentReq output.AgentRequest, agentResult *output.AgentResponse, sbiEntity *sbi.SBI, step string, turn int, artifactPath string, base *service.ChangeBase) *dto.ChangeBudgetCheck {
+	if base == nil {
+		return nil
+	}
+	sbiID := sbiEntity.ID().String()
+	budget := uc.changeBudget.Budget()
+	stat, err := uc.changeBudget.Measure(ctx, base)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to check the change budget of %s: %v\n", sbiID, err)
+		return nil
+	}
+	if !budget.Exceeded(stat) {
+		return nil
+	}
+	check := &dto.ChangeBudgetCheck{}
+
+	// Continue the conversation when the agent has one, so the agent knows what it changed
+	agentReq.SessionID = agentResult.SessionID
+	agentReq.OnOutput = nil
+	agentType := gateway.GetCapability().AgentType
+
+	for check.SplitRequests < uc.changeBudget.SplitAttempts() && budget.Exceeded(stat) && ctx.Err() == nil {
+		check.SplitRequests++
+		fmt.Fprintf(os.Stderr, "⚠️  Turn %d of %s changed %s, over the change budget of %s; asking the agent to split it (%d/%d)\n",
+			turn, sbiID, stat, budget, check.SplitRequests, uc.changeBudget.SplitAttempts())
+		agentReq.Prompt = buildChangeSplitPrompt(stat, budget, artifactPath, agentResult.Output, agentReq.SessionID != "")
+		resp, err := gateway.Execute(ctx, agentReq)
+		uc.recordAgentCall(ctx, sbiID, step, turn, agentType)
+		uc.archivePrompt(ctx, sbiID, step, turn, agentType, agentReq, resp, err)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Split request for %s failed: %v\n", sbiID, err)
+			continue
+		}
+		measured, err := uc.changeBudget.Measure(ctx, base)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to check the change budget of %s: %v\n", sbiID, err)
+			break
+		}
+		stat = measured
+	}
+
+	check.LinesChanged, check.FilesChanged = stat.Lines(), stat.Files
+	if !budget.Exceeded(stat) {
+		check.Outcome = dto.ChangeBudgetSplit
+		return check
+	}
+	check.Outcome = dto.ChangeBudgetFlagged
+	fmt.Fprintf(os.Stderr, "⚠️  WARNING: Turn %d of %s changed %s, over the change budget of %s; flagged for human review\n",
+		turn, sbiID, stat, budget)
+	if err := uc.changeBudget.NotifyFlagged(ctx, sbiEntity, turn, stat); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: %v\n", err)
+	}
+	return check
+}
+
+// buildChangeSplitPrompt builds the follow-up prompt asking an agent to cut an oversized change
+// down to the budget; without a continued session the previous output is included
+func buildChangeSplitPrompt(stat domainservice.ChangeStat, budget domainservice.ChangeBudget, artifactPath, previousOutput string, continued bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Your change in this turn is too large to review: it changes %s, over the budget of %s per turn.\n\n", stat, budget))
+	sb.WriteString("Split the work. Keep one coherent, working part of the change within the budget and revert the rest ")
+	sb.WriteString("from the working tree. Then update your report at " + artifactPath + ": describe what this turn implemented ")
+	sb.WriteString("and list the reverted parts under \"## Remaining Work\" so that the next turn implements them.\n")
+	if !continued && strings.TrimSpace(previousOutput) != "" {
+		sb.WriteString("\n## Your Previous Response\n\n")
+		sb.WriteString(previousOutput)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// isImplementStep reports whether a step implements the SBI, which the turn time box applies to
+func isImplementStep(step string) bool {
+	return step == "implement" || step == "force_implement"
+}
+
+// checkpointPath returns the path of the progress checkpoint a time-boxed turn leaves
+func checkpointPath(sbiID string, turn int) string {
+	return fmt.Sprintf(".deespec/reports/sbi/%s/checkpoint_%d.md", sbiID, turn)
+}
+
+// startTimeBox bounds an implement step by the turn time box; the agent request's own timeout
+// is raised so that the time box, not the agent timeout, ends long steps
+func (uc *RunTurnUseCase) startTimeBox(ctx context.Context, step string, agentReq *output.AgentRequest) (context.Context, context.CancelFunc) {
+	if uc.turnTimeBox <= 0 || !isImplementStep(step) {
+		return ctx, func() {}
+	}
+	if agentReq.Timeout < uc.turnTimeBox+time.Minute {
+		agentReq.Timeout = uc.turnTimeBox + time.Minute
+	}
+	return context.WithTimeout(ctx, uc.turnTimeBox)
+}
+
+// checkpointStep ends an implement step that exceeded the turn time box: a follow-up prompt
+// asks the agent to write a progress checkpoint, which the next turn's prompt includes
+// When the agent writes none, its answer (or a note to inspect the working tree) is saved instead.
+func (uc *RunTurnUseCase) checkpointStep(ctx context.Context, gateway output.AgentGateway, agentReq output.AgentRequest, sbiEntity *sbi.SBI, step string, turn int, startTime time.Time) (*dto.ExecuteStepOutput, error) {
+	sbiID := sbiEntity.ID().String()
+	path := checkpointPath(sbiID, turn)
+	fmt.Fprintf(os.Stderr, "⏱️  %s of %s exceeded the turn time box (%s), asking the agent for a checkpoint\n", step, sbiID, uc.turnTimeBox)
+
+	timeout := uc.checkpointTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	checkpointCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The stopped call returns no session of its own, so only a continued one can be resumed
+	agentReq.Prompt = buildCheckpointPrompt(sbiEntity, path, uc.turnTimeBox, agentReq.SessionID != "")
+	agentReq.Timeout = timeout
+	agentReq.OnOutput = nil
+	result, err := gateway.Execute(checkpointCtx, agentReq)
+	uc.recordAgentCall(ctx, sbiID, step, turn, gateway.GetCapability().AgentType)
+	uc.archivePrompt(ctx, sbiID, step, turn, gateway.GetCapability().AgentType, agentReq, result, err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Checkpoint prompt for %s failed: %v\n", sbiID, err)
+	}
+
+	if !uc.artifactWritten(path) {
+		content := fmt.Sprintf("# Checkpoint (turn %d)\n\nThe %s step was stopped after the turn time box of %s and no checkpoint was written.\n"+
+			"Inspect the working tree (e.g. git status and git diff) to see the progress made so far.\n", turn, step, uc.turnTimeBox)
+		if err == nil && strings.TrimSpace(result.Output) != "" {
+			content = result.Output
+		}
+		if err := os.MkdirAll(filepath.Dir(uc.projectPath(path)), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+		}
+		if err := fs.WriteFileAtomic(uc.projectPath(path), []byte(content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write checkpoint file: %w", err)
+		}
+	}
+
+	artifactPath := uc.offloadArtifact(ctx, path)
+	agentOutput := ""
+	if result != nil {
+		agentOutput = result.Output
+		uc.recordProvenance(ctx, &repository.ArtifactProvenance{
+			SBIID: sbiID, Step: step, Turn: turn, ArtifactPath: artifactPath, ArtifactHash: uc.hashArtifact(path),
+			AgentType: gateway.GetCapability().AgentType, Model: agentReq.Model,
+		}, agentReq.Prompt, result)
+	}
+	return &dto.ExecuteStepOutput{
+		Success:        true,
+		Output:         agentOutput,
+		Decision:       "TIME_BOXED",
+		ArtifactPath:   artifactPath,
+		CheckpointPath: path,
+		ElapsedMs:      time.Since(startTime).Milliseconds(),
+		StartedAt:      startTime,
+		CompletedAt:    time.Now(),
+	}, nil
+}
+
+// buildCheckpointPrompt builds the follow-up prompt asking a time-boxed agent to write its
+// progress checkpoint; without a continued session the task is named again
+func buildCheckpointPrompt(sbiEntity *sbi.SBI, path string, timeBox time.Duration, continued bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("The time box of this implement turn (%s) is up and your work was stopped.\n\n", timeBox))
+	if !continued {
+		sb.WriteString(fmt.Sprintf("You were working on SBI %s: %s\n\n", sbiEntity.ID().String(), sbiEntity.Title()))
+	}
+	sb.WriteString("Do not continue the implementation. Inspect the working tree (e.g. git status and git diff) ")
+	sb.WriteString(fmt.Sprintf("and write a progress checkpoint to %s using the Write tool, ", path))
+	sb.WriteString("so that the next turn can resume where you left off:\n\n")
+	sb.WriteString("## Done\nWhat is implemented so far (files changed, tests passing)\n\n")
+	sb.WriteString("## Remaining\nWhat is left to do, in order\n\n")
+	sb.WriteString("## Notes\nDecisions made, problems found and anything the next turn must know\n")
+	return sb.String()
+}
+
+// checkpointArtifact returns the journal artifact recording the checkpoint a time-boxed step
+// left, or nil when the step ran to completion
+func checkpointArtifact(stepOutput *dto.ExecuteStepOutput) map[string]interface{} {
+	if stepOutput == nil || stepOutput.CheckpointPath == "" {
+		return nil
+	}
+	return map[string]interface{}{
+		"type": "checkpoint",
+		"path": stepOutput.ArtifactPath,
+	}
+}
+
+// routingArtifact returns the journal artifact recording the agent and model a step ran on and
+// how they were chosen, so that routing rules can be evaluated against the turn's outcome
+func routingArtifact(stepOutput *dto.ExecuteStepOutput) map[string]interface{} {
+	if stepOutput == nil || stepOutput.Routing == nil {
+		return nil
+	}
+	artifact := map[string]interface{}{
+		"type":   "agent_routing",
+		"source": stepOutput.Routing.Source,
+		"agent":  stepOutput.Routing.Agent,
+		"model":  stepOutput.Routing.Model,
+	}
+	if stepOutput.Routing.Rule != "" {
+		artifact["rule"] = stepOutput.Routing.Rule
+	}
+	return artifact
+}
+
+// promptExperimentArtifact returns the journal artifact recording the arm of an SBI in the prompt
+// experiment, by which `deespec stats experiment` groups the SBI's outcome
+func (uc *RunTurnUseCase) promptExperimentArtifact(sbiID string) map[string]interface{} {
+	if uc.promptExperiment == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"type":       "prompt_experiment",
+		"experiment": uc.promptExperiment.Name,
+		"template":   uc.promptExperiment.Template,
+		"variant":    uc.promptExperiment.Assign(sbiID),
+	}
+}
+
+// taskTraits returns the characteristics of an SBI that routing rules match on
+func taskTraits(sbiEntity *sbi.SBI) domainservice.TaskTraits {
+	metadata := sbiEntity.Metadata()
+	return domainservice.TaskTraits{
+		Labels:         metadata.Labels,
+		EstimatedHours: metadata.EstimatedHours,
+		FilePaths:      metadata.FilePaths,
+	}
+}
+
+// describeRoute formats an agent and model for log lines
+func describeRoute(agent, model string) string {
+	if model == "" {
+		return agent
+	}
+	return agent + " (" + model + ")"
+}
+
+// changeBudgetArtifact returns the journal artifact recording an implement step that exceeded the
+// change budget, and whether it was split or flagged for human review
+func (uc *RunTurnUseCase) changeBudgetArtifact(stepOutput *dto.ExecuteStepOutput) map[string]interface{} {
+	if stepOutput == nil || stepOutput.ChangeBudget == nil || uc.changeBudget == nil {
+		return nil
+	}
+	budget := uc.changeBudget.Budget()
+	return map[string]interface{}{
+		"type":           "change_budget",
+		"lines_changed":  stepOutput.ChangeBudget.LinesChanged,
+		"files_changed":  stepOutput.ChangeBudget.FilesChanged,
+		"max_lines":      budget.MaxLines,
+		"max_files":      budget.MaxFiles,
+		"split_requests": stepOutput.ChangeBudget.SplitRequests,
+		"outcome":        stepOutput.ChangeBudget.Outcome,
+	}
+}
+
+// artifactCheckArtifact returns the journal artifact recording the corrective prompts sent for
+// a step's report, or nil when the agent wrote it at first
+func artifactCheckArtifact(stepOutput *dto.ExecuteStepOutput) map[string]interface{} {
+	if stepOutput == nil || stepOutput.ArtifactCheck == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"type":    "artifact_check",
+		"path":    stepOutput.ArtifactPath,
+		"retries": stepOutput.ArtifactCheck.Retries,
+		"outcome": stepOutput.ArtifactCheck.Outcome,
+	}
+}
+
+// newAgentRequest builds the agent request for a step, exporting the SBI's env and the runner's fence
+func (uc *RunTurnUseCase) newAgentRequest(ctx context.Context, sbiEntity *sbi.SBI, step string, turn int, prompt string) (output.AgentRequest, error) {
+	sbiID := sbiEntity.ID().String()
+	agentReq := output.AgentRequest{
+		Prompt:  prompt,
+		Timeout: 10 * time.Minute,
+		Context: map[string]string{
+			"sbi_id": sbiID,
+			"step":   step,
+			"turn":   strconv.Itoa(turn),
+		},
+	}
+	// Env declared on the SBI and its parent PBI
+	if uc.taskEnvResolver != nil {
+		env, err := uc.taskEnvResolver.Resolve(sbiEntity)
+		if err != nil {
+			return agentReq, fmt.Errorf("failed to resolve env for SBI %s: %w", sbiID, err)
+		}
+		agentReq.Env = env
+	}
+	// MCP servers enabled by the SBI's labels
+	servers, missing := uc.mcpResolver.Resolve(ctx, sbiEntity.Metadata().Labels)
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: MCP servers enabled by the labels of %s are not configured in setting.json: %s\n", sbiID, strings.Join(missing, ", "))
+	}
+	agentReq.MCPServers = servers
+	// Reports submitted by the agent via `deespec sbi report` carry the runner's fence
+	if fence, ok := lock.FenceFromContext(ctx); ok {
+		if agentReq.Env == nil {
+			agentReq.Env = make(map[string]string)
+		}
+		for key, value := range fence.Env() {
+			agentReq.Env[key] = value
+		}
+	}
+	return agentReq, nil
+}
+
+// dropUnsupportedMCP removes the MCP servers of a request to an agent that cannot use them
+func dropUnsupportedMCP(capability output.AgentCapability, agentReq *output.AgentRequest, sbiID, step string) {
+	if len(agentReq.MCPServers) == 0 || capability.SupportsMCP {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  WARNING: Agent %s does not support MCP servers; running %s of %s without them\n", capability.AgentType, step, sbiID)
+	agentReq.MCPServers = nil
+}
+
+// findImplementer returns the agent and model that last implemented the SBI
+// Returns a zero route when unknown, which disables the self-review guard for the review
+func (uc *RunTurnUseCase) findImplementer(ctx context.Context, sbiID string) label.AgentRoute {
+	if uc.stepAgentRepo == nil {
+		return label.AgentRoute{}
+	}
+	stepAgent, err := uc.stepAgentRepo.Find(ctx, sbiID, "implement")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load implementer of %s: %v\n", sbiID, err)
+		return label.AgentRoute{}
+	}
+	if stepAgent == nil {
+		return label.AgentRoute{}
+	}
+	return label.AgentRoute{Agent: stepAgent.AgentType, Model: stepAgent.Model}
+}
+
+// recordStepAgent records the agent and model that executed a step
+// force_implement is recorded as implement, since its output is reviewed the same way
+func (uc *RunTurnUseCase) recordStepAgent(ctx context.Context, sbiID, step string, turn int, agentType, model string) {
+	if uc.stepAgentRepo == nil {
+		return
+	}
+	if step == "force_implement" {
+		step = "implement"
+	}
+	stepAgent := &repository.StepAgent{
+		SBIID:     sbiID,
+		Step:      step,
+		AgentType: agentType,
+		Model:     model,
+		Turn:      turn,
+	}
+	if err := uc.stepAgentRepo.Save(ctx, stepAgent); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to record step agent: %v\n", err)
+	}
+}
+
+// recordProvenance records which agent, model and version produced an artifact, and the
+// hash of the prompt it was given
+// The model and CLI version the agent reports take precedence over the requested model.
+func (uc *RunTurnUseCase) recordProvenance(ctx context.Context, provenance *repository.ArtifactProvenance, prompt string, agentResult *output.AgentResponse) {
+	if uc.provenanceRepo == nil {
+		return
+	}
+	sum := sha256.Sum256([]byte(prompt))
+	provenance.PromptHash = hex.EncodeToString(sum[:])
+	if agentResult != nil {
+		if model := agentResult.Metadata["model"]; model != "" {
+			provenance.Model = model
+		}
+		provenance.AgentVersion = agentResult.Metadata["cli_version"]
+	}
+	if err := uc.provenanceRepo.Save(ctx, provenance); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to record artifact provenance: %v\n", err)
+	}
+}
 
-	// Check if artifact file was created by Claude
-	artifactCreated := false
-	if _, err := os.Stat(artifactPath); err == nil {
-		artifactCreated = true
+// hashArtifact returns the SHA-256 of a local artifact, hex-encoded ("" when it cannot be read)
+func (uc *RunTurnUseCase) hashArtifact(artifactPath string) string {
+	data, err := os.ReadFile(uc.projectPath(artifactPath))
+	if err != nil {
+		return ""
 	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
 
-	// If Claude didn't create the artifact, save the output ourselves as fallback
-	if !artifactCreated {
-		artifactDir := filepath.Dir(artifactPath)
-		if err := os.MkdirAll(artifactDir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+// archivePrompt keeps the prompt sent to an agent and its answer in the prompt archive
+func (uc *RunTurnUseCase) archivePrompt(ctx context.Context, sbiID, step string, turn int, agentType string, agentReq output.AgentRequest, agentResult *output.AgentResponse, execErr error) {
+	if uc.promptArchive == nil {
+		return
+	}
+	entry := &repository.PromptArchiveEntry{
+		SBIID:      sbiID,
+		Turn:       turn,
+		Step:       step,
+		AgentType:  agentType,
+		Model:      agentReq.Model,
+		SessionID:  agentReq.SessionID,
+		Context:    agentReq.Context,
+		Prompt:     agentReq.Prompt,
+		ArchivedAt: time.Now(),
+	}
+	if agentResult != nil {
+		entry.Response = agentResult.Output
+		entry.Cached = agentResult.Metadata["cache"] == "hit"
+		entry.DurationMs = agentResult.Duration.Milliseconds()
+		if model := agentResult.Metadata["model"]; model != "" {
+			entry.Model = model
 		}
+	}
+	if execErr != nil {
+		entry.Error = execErr.Error()
+	}
+	if err := uc.promptArchive.Save(ctx, entry); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to archive the %s prompt of %s: %v\n", step, sbiID, err)
+	}
+}
 
-		if err := os.WriteFile(artifactPath, []byte(agentResult.Output), 0644); err != nil {
-			return nil, fmt.Errorf("failed to write artifact file: %w", err)
+// recordAgentCall counts an agent execution against the daily agent call budget
+func (uc *RunTurnUseCase) recordAgentCall(ctx context.Context, sbiID, step string, turn int, agentType string) {
+	if uc.turnBudget == nil {
+		return
+	}
+	call := &repository.AgentCall{
+		SBIID:     sbiID,
+		Turn:      turn,
+		Step:      step,
+		AgentType: agentType,
+	}
+	if err := uc.turnBudget.RecordAgentCall(ctx, call); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to record agent call: %v\n", err)
+	}
+}
+
+// offloadArtifact uploads a local artifact to the artifact store and returns its URI
+// Returns the local path unchanged when no remote store is configured or the upload fails
+func (uc *RunTurnUseCase) offloadArtifact(ctx context.Context, artifactPath string) string {
+	if uc.artifactStore == nil {
+		return artifactPath
+	}
+
+	key := strings.TrimPrefix(filepath.ToSlash(artifactPath), ".deespec/")
+	if filepath.ToSlash(uc.artifactStore.URI(key)) == filepath.ToSlash(artifactPath) {
+		return artifactPath // Local store: the file is already in place
+	}
+
+	content, err := os.ReadFile(uc.projectPath(artifactPath))
+	if err == nil {
+		var uri string
+		if uri, err = uc.artifactStore.Put(ctx, key, content); err == nil {
+			return uri
 		}
 	}
 
-	return &dto.ExecuteStepOutput{
-		Success:      true,
-		Output:       agentResult.Output,
-		Decision:     decision,
-		ArtifactPath: artifactPath,
-		ElapsedMs:    time.Since(startTime).Milliseconds(),
-		StartedAt:    startTime,
-		CompletedAt:  time.Now(),
-	}, nil
+	fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to offload artifact\n")
+	fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+	fmt.Fprintf(os.Stderr, "   Path: %s\n", artifactPath)
+	return artifactPath
 }
 
 // buildPromptWithArtifact builds a prompt that instructs Claude to create an artifact file
-func (uc *RunTurnUseCase) buildPromptWithArtifact(sbiEntity *sbi.SBI, step string, turn int, attempt int, artifactPath string) string {
+func (uc *RunTurnUseCase) buildPromptWithArtifact(ctx context.Context, sbiEntity *sbi.SBI, step string, turn int, attempt int, artifactPath string) string {
 	sbiID := sbiEntity.ID().String()
 	title := sbiEntity.Title()
 	description := sbiEntity.Description()
 
-	// Get current working directory
-	workDir, err := os.Getwd()
-	if err != nil {
-		workDir = "."
+	// Agents work in the project root
+	workDir := uc.projectRoot
+	if workDir == "" {
+		var err error
+		if workDir, err = os.Getwd(); err != nil {
+			workDir = "."
+		}
 	}
 
 	// Generate prior context instructions
@@ -847,29 +2526,68 @@ func (uc *RunTurnUseCase) buildPromptWithArtifact(sbiEntity *sbi.SBI, step strin
 	switch step {
 	case "implement":
 		templatePath = ".deespec/prompts/WIP.md"
+		data.Checkpoint = uc.buildCheckpoint(sbiID, turn)
+		data.ReviewFeedback = uc.buildReviewFeedback(ctx, sbiID, turn)
+		data.ReviewIssues = uc.buildReviewIssues(ctx, sbiID, false)
+		data.AcceptanceCriteria = uc.buildAcceptanceCriteria(ctx, sbiEntity, false)
+		data.References = uc.buildReferences(ctx, sbiID)
+		files := uc.selectRelevantFiles(sbiEntity)
+		data.RelevantFiles = uc.buildRelevantFiles(files)
+		data.Ownership = uc.buildOwnership(ctx, sbiEntity, files)
+		uc.markImplementStart(ctx, sbiID)
 	case "review":
 		templatePath = ".deespec/prompts/REVIEW.md"
+		data.AcceptanceCriteria = uc.buildAcceptanceCriteria(ctx, sbiEntity, true)
+		data.ReviewIssues = uc.buildReviewIssues(ctx, sbiID, true)
+		data.References = uc.buildReferences(ctx, sbiID)
+		data.ReviewGuidelines = uc.buildReviewGuidelines(ctx, sbiEntity)
+		data.ReviewContext = uc.buildReviewContext(ctx, sbiID, turn)
 		// Since v0.2.13, reports are in .deespec/reports/sbi/
 		data.ImplementPath = fmt.Sprintf(".deespec/reports/sbi/%s/implement_%d.md", sbiID, turn-1)
 	case "force_implement":
 		templatePath = ".deespec/prompts/REVIEW_AND_WIP.md"
+		data.Checkpoint = uc.buildCheckpoint(sbiID, turn)
+		data.ReviewFeedback = uc.buildReviewFeedback(ctx, sbiID, turn)
+		data.ReviewIssues = uc.buildReviewIssues(ctx, sbiID, false)
+		data.AcceptanceCriteria = uc.buildAcceptanceCriteria(ctx, sbiEntity, false)
+		data.References = uc.buildReferences(ctx, sbiID)
+		files := uc.selectRelevantFiles(sbiEntity)
+		data.RelevantFiles = uc.buildRelevantFiles(files)
+		data.Ownership = uc.buildOwnership(ctx, sbiEntity, files)
 	case "done":
 		templatePath = ".deespec/prompts/DONE.md"
 		// Collect all implement and review paths
 		data.AllImplementPaths = uc.collectImplementPaths(sbiID, turn)
 		data.AllReviewPaths = uc.collectReviewPaths(sbiID, turn)
+	case "postmortem":
+		templatePath = ".deespec/prompts/POSTMORTEM.md"
+		data.AllImplementPaths = uc.collectImplementPaths(sbiID, turn)
+		data.AllReviewPaths = uc.collectReviewPaths(sbiID, turn)
+		data.ReviewFeedback = uc.buildReviewFeedback(ctx, sbiID, turn)
+		data.FailureReason = fmt.Sprintf("Exceeded max turns (%d)", uc.maxTurns)
 	default:
 		// Fallback to simple prompt if no template found
 		return fmt.Sprintf("Execute step %s for SBI %s (turn %d, attempt %d)", step, sbiID, turn, attempt)
 	}
 
+	// Notes are only consumed by prompts that carry the prior context
+	priorContext += uc.buildNotes(ctx, sbiID, turn)
+	data.PriorContext = priorContext
+	data.Custom = uc.buildCustomVars(ctx, sbiID, step, turn)
+
+	// SBIs in the treatment arm of a prompt experiment are prompted with the variant template
+	if uc.promptExperiment != nil {
+		dir, name := filepath.Split(templatePath)
+		templatePath = filepath.Join(dir, uc.promptExperiment.TemplateFor(sbiID, name))
+	}
+
 	// Try to expand template
 	prompt, err := uc.expandTemplate(templatePath, data)
 	if err != nil {
 		// Fallback to old-style hardcoded prompts if template fails
 		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load template %s: %v\n", templatePath, err)
 		fmt.Fprintf(os.Stderr, "   Falling back to built-in prompt\n")
-		return uc.buildFallbackPrompt(sbiEntity, step, turn, attempt, artifactPath, priorContext)
+		return uc.buildFallbackPrompt(sbiEntity, step, turn, attempt, artifactPath, priorContext+data.Checkpoint+data.ReviewFeedback+data.ReviewIssues+data.AcceptanceCriteria+data.References+data.ReviewGuidelines+data.ReviewContext+data.RelevantFiles+data.Ownership)
 	}
 
 	return prompt
@@ -877,32 +2595,53 @@ func (uc *RunTurnUseCase) buildPromptWithArtifact(sbiEntity *sbi.SBI, step strin
 
 // PromptTemplateData holds data for template expansion
 type PromptTemplateData struct {
-	WorkDir           string
-	SBIID             string
-	Title             string
-	Description       string
-	Turn              int
-	Attempt           int
-	Step              string
-	SBIDir            string
-	ArtifactPath      string
-	ImplementPath     string
-	AllImplementPaths []string
-	AllReviewPaths    []string
-	PriorContext      string
-	TaskDescription   string
+	WorkDir            string
+	SBIID              string
+	Title              string
+	Description        string
+	Turn               int
+	Attempt            int
+	Step               string
+	SBIDir             string
+	ArtifactPath       string
+	ImplementPath      string
+	AllImplementPaths  []string
+	AllReviewPaths     []string
+	PriorContext       string
+	Checkpoint         string // Progress checkpoint of the previous turn if it exceeded the time box (empty if none)
+	ReviewFeedback     string // Issues from the previous NEEDS_CHANGES/FAILED review (empty if none)
+	ReviewIssues       string // Open review issues tracked across turns (empty if none)
+	AcceptanceCriteria string // Acceptance criteria checklist (empty if the SBI has none)
+	References         string // Final artifacts of the SBIs this SBI references (empty if none)
+	RelevantFiles      string // Ranked files relevant to the task (empty if none were found)
+	Ownership          string // Recent authors and pull requests of the relevant files (empty if disabled)
+	ReviewGuidelines   string // Review guidance contributed by the SBI's labels (empty if none)
+	ReviewContext      string // Pointer to the review context bundle (empty if disabled)
+	FailureReason      string // Why the SBI is being terminated as FAILED (postmortem only)
+	TaskDescription    string
+	Custom             map[string]string // Custom variables declared in setting.json ("template_vars"); undeclared names expand to ""
+}
+
+// buildCustomVars resolves the custom template variables; variables whose command failed stay empty
+func (uc *RunTurnUseCase) buildCustomVars(ctx context.Context, sbiID, step string, turn int) map[string]string {
+	values, err := uc.templateVars.Resolve(ctx, sbiID, step, turn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to resolve template variables: %v\n", err)
+	}
+	return values
 }
 
 // expandTemplate reads a template file and expands it with given data
 func (uc *RunTurnUseCase) expandTemplate(templatePath string, data PromptTemplateData) (string, error) {
 	// Read template file
-	templateContent, err := os.ReadFile(templatePath)
+	templateContent, err := os.ReadFile(uc.projectPath(templatePath))
 	if err != nil {
 		return "", fmt.Errorf("failed to read template %s: %w", templatePath, err)
 	}
 
 	// Parse template
-	tmpl, err := template.New(filepath.Base(templatePath)).Parse(string(templateContent))
+	// Custom variables that are not declared expand to "" instead of "<no value>"
+	tmpl, err := template.New(filepath.Base(templatePath)).Option("missingkey=zero").Parse(string(templateContent))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template %s: %w", templatePath, err)
 	}
@@ -1032,11 +2771,441 @@ The report should include:
 Use the Write tool to create this file with your full completion report.
 `, priorContext, sbiID, title, description, turn, artifactPath)
 
+	case "postmortem":
+		return fmt.Sprintf(`%s# Task Postmortem Report
+
+**SBI ID**: %s
+**Title**: %s
+**Description**: %s
+**Final Turn**: %d
+**Failure Reason**: Exceeded max turns (%d)
+
+## Context
+
+This task exhausted its turn limit without an approved implementation and is being terminated as FAILED.
+Analyse why it failed. Do not modify any source code and do not run deespec sbi report or sbi review.
+
+Write your complete postmortem report to the file:
+
+**Output File**: %s
+
+The report should include:
+1. What the task tried to achieve and how far it got
+2. What each turn attempted and how it was reviewed
+3. The most likely root cause, with evidence
+4. Partial changes left in the codebase
+5. What should change before the task is retried
+
+Use the Write tool to create this file with your full postmortem report.
+`, priorContext, sbiID, title, description, turn, uc.maxTurns, artifactPath)
+
 	default:
 		return fmt.Sprintf("Execute step %s for SBI %s (turn %d, attempt %d)", step, sbiID, turn, attempt)
 	}
 }
 
+// buildReviewFeedback formats the issue list of the latest review before currentTurn
+// Returns an empty string when there is no feedback to carry over
+func (uc *RunTurnUseCase) buildReviewFeedback(ctx context.Context, sbiID string, currentTurn int) string {
+	if uc.feedbackRepo == nil || currentTurn <= 1 {
+		return ""
+	}
+
+	feedback, err := uc.feedbackRepo.FindLatestBySBIID(ctx, sbiID, currentTurn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load review feedback: %v\n", err)
+		return ""
+	}
+	if feedback == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Review Feedback from Turn %d (%s)\n\n", feedback.Turn, feedback.Decision))
+	if len(feedback.Issues) == 0 {
+		sb.WriteString("The reviewer did not list individual issues.")
+		if feedback.ReportPath != "" {
+			sb.WriteString(fmt.Sprintf(" Read `%s` for details.", feedback.ReportPath))
+		}
+		sb.WriteString("\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString("The previous review requested the following changes. Address every item:\n\n")
+	for i, issue := range feedback.Issues {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, issue))
+	}
+	if feedback.ReportPath != "" {
+		sb.WriteString(fmt.Sprintf("\nFull review report: `%s`\n", feedback.ReportPath))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// buildNotes formats the SBI notes marked for the prompt that no earlier turn received,
+// and records that this turn received them
+// Returns an empty string when there are no notes to inject
+func (uc *RunTurnUseCase) buildNotes(ctx context.Context, sbiID string, turn int) string {
+	if uc.noteRepo == nil {
+		return ""
+	}
+
+	notes, err := uc.noteRepo.FindForPrompt(ctx, sbiID, turn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load SBI notes: %v\n", err)
+		return ""
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Notes from the Team\n\n")
+	sb.WriteString("The following notes were added to this task. Take them into account:\n\n")
+	ids := make([]int64, 0, len(notes))
+	for _, note := range notes {
+		sb.WriteString(fmt.Sprintf("- (%s) %s\n", note.CreatedAt.Local().Format("2006-01-02 15:04"), note.Body))
+		for _, link := range note.Links {
+			sb.WriteString(fmt.Sprintf("  - Link: %s\n", link))
+		}
+		ids = append(ids, note.ID)
+	}
+	sb.WriteString("\n")
+
+	if err := uc.noteRepo.MarkInjected(ctx, ids, turn); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to mark SBI notes as injected: %v\n", err)
+	}
+	return sb.String()
+}
+
+// promptNotesArtifact returns the journal artifact listing the SBI notes the prompt of
+// turn received, so that a nudge given to the agent can be traced from the journal
+// Returns nil when the turn received no notes
+func (uc *RunTurnUseCase) promptNotesArtifact(ctx context.Context, sbiID string, turn int) map[string]interface{} {
+	if uc.noteRepo == nil {
+		return nil
+	}
+
+	notes, err := uc.noteRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load SBI notes for the journal: %v\n", err)
+		return nil
+	}
+	var injected []interface{}
+	for _, note := range notes {
+		if note.InjectedTurn != turn {
+			continue
+		}
+		injected = append(injected, map[string]interface{}{
+			"id":     note.ID,
+			"body":   note.Body,
+			"author": note.Author,
+		})
+	}
+	if len(injected) == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"type":  "prompt_notes",
+		"turn":  turn,
+		"notes": injected,
+	}
+}
+
+// buildAcceptanceCriteria formats the acceptance criteria checklist of an SBI
+// Review prompts additionally ask for a structured "AC-<n>: PASS|FAIL" verdict per criterion
+func (uc *RunTurnUseCase) buildAcceptanceCriteria(ctx context.Context, sbiEntity *sbi.SBI, forReview bool) string {
+	criteria := uc.ensureAcceptanceCriteria(ctx, sbiEntity)
+	if len(criteria) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Acceptance Criteria\n\n")
+	for _, c := range criteria {
+		mark := " "
+		if c.Status.IsSatisfied() {
+			mark = "x"
+		}
+		sb.WriteString(fmt.Sprintf("- [%s] AC-%d: %s", mark, c.Position, c.Description))
+		if c.Status != repository.CriterionPending {
+			sb.WriteString(fmt.Sprintf(" (%s", c.Status))
+			if c.Note != "" {
+				sb.WriteString(": " + c.Note)
+			}
+			sb.WriteString(")")
+		}
+		sb.WriteString("\n")
+	}
+
+	if forReview {
+		sb.WriteString("\nEvaluate every criterion and include one line per criterion in your review report:\n\n")
+		sb.WriteString("```\nAC-1: PASS\nAC-2: FAIL - <reason>\n```\n\n")
+		sb.WriteString("The task cannot be marked DONE until every criterion passes or is waived.\n")
+	} else {
+		sb.WriteString("\nThe task is only accepted when every unchecked criterion is satisfied.\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// buildReviewIssues formats the open review issues of the SBI
+// Implement prompts ask for the issues to be fixed; review prompts ask for a status line per issue.
+func (uc *RunTurnUseCase) buildReviewIssues(ctx context.Context, sbiID string, forReview bool) string {
+	if uc.issueRepo == nil {
+		return ""
+	}
+
+	issues, err := uc.issueRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load review issues: %v\n", err)
+		return ""
+	}
+	var open []*repository.ReviewIssue
+	for _, issue := range issues {
+		if !issue.Status.IsClosed() {
+			open = append(open, issue)
+		}
+	}
+	if len(open) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Open Review Issues\n\n")
+	for _, issue := range open {
+		kind := "blocking"
+		if !issue.Blocking {
+			kind = "non-blocking"
+		}
+		sb.WriteString(fmt.Sprintf("- ISSUE-%d [%s] %s (raised in turn %d)\n", issue.Number, kind, issue.Description, issue.RaisedTurn))
+	}
+
+	if forReview {
+		sb.WriteString("\nCheck whether each issue was resolved and include one line per issue in your review report:\n\n")
+		sb.WriteString("```\nISSUE-1: ADDRESSED - <how it was fixed>\nISSUE-2: OPEN - <what is still missing>\nISSUE-3: WONTFIX - <why it should not be fixed>\n```\n\n")
+		sb.WriteString("The task cannot be marked DONE until every blocking issue is ADDRESSED or WONTFIX. ")
+		sb.WriteString("List new issues in the Issues section as usual; do not repeat the issues above there.\n")
+	} else {
+		sb.WriteString("\nResolve every blocking issue. The task is not accepted while one is open.\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// buildReviewGuidelines concatenates the review guidance of the SBI's labels, in label order
+func (uc *RunTurnUseCase) buildReviewGuidelines(ctx context.Context, sbiEntity *sbi.SBI) string {
+	if uc.promptRepo == nil {
+		return ""
+	}
+
+	var sections []string
+	for _, labelName := range sbiEntity.Metadata().Labels {
+		content := strings.TrimSpace(uc.promptRepo.LoadLabelReviewContent(ctx, labelName))
+		if content == "" {
+			continue
+		}
+		sections = append(sections, fmt.Sprintf("### Label: %s\n%s\n", labelName, content))
+	}
+	if len(sections) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Label-Specific Review Guidelines\n\n")
+	sb.WriteString("The following checks apply based on the task labels. Treat a failed check as an issue:\n\n")
+	sb.WriteString(strings.Join(sections, "\n"))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// selectRelevantFiles ranks repository files likely relevant to the SBI, best first
+func (uc *RunTurnUseCase) selectRelevantFiles(sbiEntity *sbi.SBI) []service.ContextFile {
+	if uc.contextSelector == nil {
+		return nil
+	}
+
+	files, err := uc.contextSelector.Select(sbiEntity.Title()+"\n"+sbiEntity.Description(), sbiEntity.Metadata().FilePaths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to select relevant files: %v\n", err)
+		return nil
+	}
+	return files
+}
+
+// buildRelevantFiles lists the relevant files so the agent does not have to explore
+// the codebase blindly on its first attempt
+func (uc *RunTurnUseCase) buildRelevantFiles(files []service.ContextFile) string {
+	if len(files) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Relevant Files\n\n")
+	sb.WriteString("These files were found by searching the repository for the task's keywords. Start by reading them:\n\n")
+	for _, file := range files {
+		sb.WriteString("- " + file.Path)
+		if len(file.Keywords) > 0 {
+			sb.WriteString(" (matches: " + strings.Join(file.Keywords, ", ") + ")")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// buildOwnership annotates the files the SBI targets with their recent authors and pull requests
+// from git history, steering the agent toward the conventions already used in that code
+func (uc *RunTurnUseCase) buildOwnership(ctx context.Context, sbiEntity *sbi.SBI, files []service.ContextFile) string {
+	if uc.ownershipProvider == nil {
+		return ""
+	}
+
+	paths := sbiEntity.Metadata().FilePaths
+	if len(files) > 0 {
+		paths = make([]string, 0, len(files))
+		for _, file := range files {
+			paths = append(paths, file.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+
+	owners, err := uc.ownershipProvider.Ownership(ctx, paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to read code ownership: %v\n", err)
+		return ""
+	}
+	if len(owners) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Code Ownership\n\n")
+	sb.WriteString("Recent git history of these files. Follow the conventions their authors and the referenced pull requests established:\n\n")
+	for _, owner := range owners {
+		authors := make([]string, 0, len(owner.Authors))
+		for _, author := range owner.Authors {
+			authors = append(authors, fmt.Sprintf("%s (%d commits)", author.Name, author.Commits))
+		}
+		sb.WriteString("- " + owner.Path + ": " + strings.Join(authors, ", "))
+		if len(owner.RelatedPRs) > 0 {
+			sb.WriteString("; related PRs: " + strings.Join(owner.RelatedPRs, ", "))
+		}
+		if !owner.LastModified.IsZero() {
+			sb.WriteString("; last changed " + owner.LastModified.Format("2006-01-02"))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// buildReferences builds the prompt section with the artifacts of the SBIs the SBI references
+func (uc *RunTurnUseCase) buildReferences(ctx context.Context, sbiID string) string {
+	if uc.referenceContext == nil {
+		return ""
+	}
+	section, err := uc.referenceContext.Build(ctx, sbiID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load SBI references: %v\n", err)
+		return ""
+	}
+	return section
+}
+
+// buildCheckpoint builds the prompt section with the progress checkpoint the previous turn left
+// when it exceeded the turn time box (empty if it ran to completion)
+func (uc *RunTurnUseCase) buildCheckpoint(sbiID string, turn int) string {
+	path := checkpointPath(sbiID, turn-1)
+	content, err := os.ReadFile(uc.projectPath(path))
+	if err != nil || strings.TrimSpace(string(content)) == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Checkpoint from Turn %d\n\n", turn-1))
+	sb.WriteString(fmt.Sprintf("The previous turn ran out of its time box and left this checkpoint (%s).\n", path))
+	sb.WriteString("Resume from it: build upon the work it lists as done instead of starting over.\n\n")
+	sb.WriteString(strings.TrimSpace(string(content)))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// markImplementStart records where the SBI's code stood before implementation, for the first review's diff
+func (uc *RunTurnUseCase) markImplementStart(ctx context.Context, sbiID string) {
+	if uc.reviewContext == nil {
+		return
+	}
+	if err := uc.reviewContext.MarkImplementStart(ctx, sbiID); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to record review diff base: %v\n", err)
+	}
+}
+
+// buildReviewContext builds the review context bundle of a turn and the prompt section pointing at it
+func (uc *RunTurnUseCase) buildReviewContext(ctx context.Context, sbiID string, turn int) string {
+	if uc.reviewContext == nil {
+		return ""
+	}
+	path, err := uc.reviewContext.Build(ctx, sbiID, turn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to build review context: %v\n", err)
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Review Context\n\n")
+	sb.WriteString("Read `" + filepath.ToSlash(path) + "` first. It bundles everything under review:\n")
+	sb.WriteString("- the implement report of this turn\n")
+	sb.WriteString("- the code diff since the last review (or since implementation started)\n")
+	sb.WriteString("- the test results\n\n")
+	sb.WriteString("Start from this file instead of searching the reports directories; open source files only to verify details.\n\n")
+	return sb.String()
+}
+
+// ensureAcceptanceCriteria loads the checklist of an SBI, parsing it on first use from
+// the SBI description and the parent PBI body (.deespec/specs/pbi/<id>/pbi.md)
+func (uc *RunTurnUseCase) ensureAcceptanceCriteria(ctx context.Context, sbiEntity *sbi.SBI) []*repository.AcceptanceCriterion {
+	if uc.criteriaRepo == nil {
+		return nil
+	}
+
+	sbiID := sbiEntity.ID().String()
+	criteria, err := uc.criteriaRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load acceptance criteria: %v\n", err)
+		return nil
+	}
+	if len(criteria) > 0 {
+		return criteria
+	}
+
+	for _, text := range domainservice.ExtractAcceptanceCriteria(sbiEntity.Description()) {
+		criteria = append(criteria, &repository.AcceptanceCriterion{Description: text, Source: "sbi"})
+	}
+	if parentID := sbiEntity.ParentTaskID(); parentID != nil {
+		pbiPath := filepath.Join(".deespec", "specs", "pbi", parentID.String(), "pbi.md")
+		if body, err := os.ReadFile(uc.projectPath(pbiPath)); err == nil {
+			for _, text := range domainservice.ExtractAcceptanceCriteria(string(body)) {
+				criteria = append(criteria, &repository.AcceptanceCriterion{Description: text, Source: "pbi"})
+			}
+		}
+	}
+	if len(criteria) == 0 {
+		return nil
+	}
+
+	for i, c := range criteria {
+		c.SBIID = sbiID
+		c.Position = i + 1
+		c.Status = repository.CriterionPending
+	}
+	if err := uc.criteriaRepo.SaveAll(ctx, sbiID, criteria); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to save acceptance criteria: %v\n", err)
+	}
+	return criteria
+}
+
 // buildPriorContextInstructions generates instructions to read prior artifacts
 func (uc *RunTurnUseCase) buildPriorContextInstructions(sbiID string, currentTurn int) string {
 	var context strings.Builder
@@ -1083,24 +3252,9 @@ func (uc *RunTurnUseCase) determineNextStatusForSBI(currentStatus model.Status,
 	return nextStatus, shouldIncrement
 }
 
-// mapDomainStatusToString converts domain Status to string representation
+// mapDomainStatusToString converts domain Status to its workflow label (recorded in the journal)
 func (uc *RunTurnUseCase) mapDomainStatusToString(status model.Status) string {
-	switch status {
-	case model.StatusPending:
-		return "READY"
-	case model.StatusPicked:
-		return "WIP"
-	case model.StatusImplementing:
-		return "WIP"
-	case model.StatusReviewing:
-		return "REVIEW"
-	case model.StatusDone:
-		return "DONE"
-	case model.StatusFailed:
-		return "FAILED"
-	default:
-		return fmt.Sprintf("%v", status)
-	}
+	return service.WorkflowStatusLabel(status)
 }
 
 // mapStringToDomainStatus converts string status to domain Status
@@ -1118,6 +3272,8 @@ func (uc *RunTurnUseCase) mapStringToDomainStatus(statusStr string) model.Status
 		return model.StatusDone
 	case "FAILED":
 		return model.StatusFailed
+	case "CANCELLED":
+		return model.StatusCancelled
 	default:
 		return model.StatusPending
 	}
@@ -1138,192 +3294,41 @@ func (uc *RunTurnUseCase) statusToStep(status string) string {
 	}
 }
 
-func (uc *RunTurnUseCase) extractDecision(output string) string {
-	// Simple extraction - can be improved with regex
-	// TODO: Move to a dedicated service
-	if len(output) == 0 {
-		return "NEEDS_CHANGES"
-	}
-
-	// Check for decision keywords in output
-	// Look for explicit SUCCEEDED/FAILED/NEEDS_CHANGES markers
-	if contains(output, "DECISION: SUCCEEDED") || contains(output, "[SUCCEEDED]") {
-		return "SUCCEEDED"
-	}
-	if contains(output, "DECISION: FAILED") || contains(output, "[FAILED]") {
-		return "FAILED"
-	}
-	if contains(output, "DECISION: NEEDS_CHANGES") || contains(output, "[NEEDS_CHANGES]") {
-		return "NEEDS_CHANGES"
-	}
-
-	// For mock agents that don't provide explicit decisions, assume success
-	// This allows testing and development to proceed smoothly
-	if contains(output, "[Gemini Mock]") || contains(output, "[Codex Mock]") || contains(output, "[Mock]") {
-		return "SUCCEEDED"
-	}
-
-	// Default to NEEDS_CHANGES for real agents without explicit decision
-	return "NEEDS_CHANGES"
-}
-
-// extractDecisionWithLogging extracts decision from artifact file with metadata validation and logging
-// Returns decision string and source indicator for debugging
-func (uc *RunTurnUseCase) extractDecisionWithLogging(artifactPath string, agentOutput string, sbiID string) (decision string, source string) {
-	// First, try to determine the turn number from artifactPath (e.g., "review_4.md" -> 4)
-	// This is needed to check the new reports directory
-	var reportPath string
-	if strings.Contains(artifactPath, "review_") {
-		// Extract turn number from path like ".deespec/specs/sbi/{SBIID}/review_{turn}.md"
-		filename := filepath.Base(artifactPath)
-		// filename is like "review_4.md"
-		parts := strings.Split(filename, "_")
-		if len(parts) == 2 {
-			turnStr := strings.TrimSuffix(parts[1], ".md")
-			// Construct new reports path: .deespec/reports/sbi/{SBIID}/review_{turn}.md
-			reportPath = filepath.Join(".deespec", "reports", "sbi", sbiID, fmt.Sprintf("review_%s.md", turnStr))
-		}
-	}
-
-	// Try to read from new reports location first (priority)
-	var content []byte
-	var err error
-	var actualPath string
-
-	if reportPath != "" {
-		content, err = os.ReadFile(reportPath)
-		if err == nil {
-			actualPath = reportPath
-		}
-	}
-
-	// Fall back to old artifact path if new path doesn't exist
-	if err != nil {
-		content, err = os.ReadFile(artifactPath)
-		actualPath = artifactPath
-	}
-
-	if err != nil {
-		// Artifact doesn't exist in either location, use agent output
-		decision = uc.extractDecision(agentOutput)
-		fmt.Fprintf(os.Stderr, "[decision] SBI=%s, Source=agent_output (file not found), Decision=%s, CheckedPaths=[%s, %s]\n",
-			sbiID, decision, reportPath, artifactPath)
-		return decision, "agent_output"
-	}
-
-	fmt.Fprintf(os.Stderr, "[decision] SBI=%s, ReadFrom=%s\n", sbiID, actualPath)
-	fileContent := string(content)
-
-	// Extract decision from head (first 20 lines, ## Summary section)
-	headDecision := uc.extractDecisionFromHead(fileContent)
-
-	// Extract decision from tail JSON (last 5 lines)
-	tailDecision := uc.extractDecisionFromTailJSON(fileContent)
-
-	// Check if both metadata and JSON match
-	if headDecision != "" && tailDecision != "" && headDecision == tailDecision {
-		// Both sources agree - high confidence
-		fmt.Fprintf(os.Stderr, "[decision] SBI=%s, Source=metadata_match, HeadDecision=%s, TailDecision=%s, FinalDecision=%s\n",
-			sbiID, headDecision, tailDecision, headDecision)
-		return headDecision, "metadata_match"
+// extractDecision extracts the review decision of a step, logging which source and strategy matched
+// The report file is tried first, then the agent output; the heuristic fallback strategy
+// only applies when no source contains an explicit decision
+func (uc *RunTurnUseCase) extractDecision(artifactPath string, agentOutput string, sbiID string) (decision string, source string) {
+	type decisionSource struct {
+		name    string
+		content string
 	}
-
-	// Metadata doesn't match or is missing - use agent output as fallback
-	decision = uc.extractDecision(agentOutput)
-	fmt.Fprintf(os.Stderr, "[decision] SBI=%s, Source=agent_output (mismatch), HeadDecision=%s, TailDecision=%s, AgentDecision=%s\n",
-		sbiID, headDecision, tailDecision, decision)
-	return decision, "agent_output"
-}
-
-// extractDecisionFromHead extracts DECISION from first 20 lines in ## Summary section
-func (uc *RunTurnUseCase) extractDecisionFromHead(content string) string {
-	lines := strings.Split(content, "\n")
-	maxLines := 20
-	if len(lines) < maxLines {
-		maxLines = len(lines)
+	var sources []decisionSource
+	if content, err := os.ReadFile(uc.projectPath(artifactPath)); err == nil {
+		sources = append(sources, decisionSource{name: "report", content: string(content)})
 	}
+	sources = append(sources, decisionSource{name: "agent_output", content: agentOutput})
 
-	// Look for ## Summary section in first 20 lines
-	inSummary := false
-	for i := 0; i < maxLines; i++ {
-		line := strings.TrimSpace(lines[i])
-
-		// Check if we're entering Summary section
-		if strings.HasPrefix(line, "## Summary") {
-			inSummary = true
+	fallback, fallbackSource, fallbackStrategy := "", "", ""
+	for _, src := range sources {
+		decision, strategy := uc.decisionExtractor.Extract(src.content)
+		if decision == "" {
 			continue
 		}
-
-		// Check if we're leaving Summary section (new ## header)
-		if inSummary && strings.HasPrefix(line, "## ") && !strings.HasPrefix(line, "## Summary") {
-			break
-		}
-
-		// Look for DECISION in Summary section
-		if inSummary {
-			if contains(line, "DECISION: SUCCEEDED") {
-				return "SUCCEEDED"
-			}
-			if contains(line, "DECISION: FAILED") {
-				return "FAILED"
-			}
-			if contains(line, "DECISION: NEEDS_CHANGES") {
-				return "NEEDS_CHANGES"
-			}
-		}
-	}
-
-	return "" // Not found
-}
-
-// extractDecisionFromTailJSON extracts decision from JSON in last 5 lines
-func (uc *RunTurnUseCase) extractDecisionFromTailJSON(content string) string {
-	lines := strings.Split(content, "\n")
-
-	// Check last 5 lines for JSON
-	startIdx := len(lines) - 5
-	if startIdx < 0 {
-		startIdx = 0
-	}
-
-	for i := len(lines) - 1; i >= startIdx; i-- {
-		line := strings.TrimSpace(lines[i])
-
-		// Skip empty lines
-		if line == "" {
-			continue
+		if strategy != domainservice.DecisionStrategyHeuristic {
+			fmt.Fprintf(os.Stderr, "[decision] SBI=%s, Source=%s, Strategy=%s, Decision=%s\n", sbiID, src.name, strategy, decision)
+			return decision, src.name
 		}
-
-		// Look for JSON with decision field
-		if strings.HasPrefix(line, "{") && strings.Contains(line, "decision") {
-			// Try to parse as JSON
-			var result map[string]interface{}
-			if err := json.Unmarshal([]byte(line), &result); err == nil {
-				if decisionVal, ok := result["decision"]; ok {
-					if decisionStr, ok := decisionVal.(string); ok {
-						return strings.ToUpper(decisionStr)
-					}
-				}
-			}
+		if fallback == "" {
+			fallback, fallbackSource, fallbackStrategy = decision, src.name, strategy
 		}
 	}
 
-	return "" // Not found
-}
-
-// Helper function for case-insensitive string contains
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) &&
-		(findSubstring(s, substr) >= 0))
-}
-
-func findSubstring(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
+	if fallback == "" {
+		fmt.Fprintf(os.Stderr, "[decision] SBI=%s, Source=none, Strategy=none (no decision found)\n", sbiID)
+		return "", ""
 	}
-	return -1
+	fmt.Fprintf(os.Stderr, "[decision] SBI=%s, Source=%s, Strategy=%s, Decision=%s\n", sbiID, fallbackSource, fallbackStrategy, fallback)
+	return fallback, fallbackSource
 }
 
 // determineNextStatus determines the next status based on current state
@@ -1371,6 +3376,7 @@ func (uc *RunTurnUseCase) determineNextStatus(currentStatus string, decision str
 func (uc *RunTurnUseCase) collectImplementPaths(sbiID string, maxTurn int) []string {
 	var paths []string
 	// Since v0.2.13, reports are in .deespec/reports/sbi/ but old reports may be in .deespec/specs/sbi/
+	// until `deespec migrate reports` moves them
 	reportsDir := fmt.Sprintf(".deespec/reports/sbi/%s", sbiID)
 	specsDir := fmt.Sprintf(".deespec/specs/sbi/%s", sbiID)
 
@@ -1378,13 +3384,13 @@ func (uc *RunTurnUseCase) collectImplementPaths(sbiID string, maxTurn int) []str
 	for turn := 1; turn < maxTurn; turn++ {
 		// Try new location first
 		implementPath := filepath.Join(reportsDir, fmt.Sprintf("implement_%d.md", turn))
-		if _, err := os.Stat(implementPath); err == nil {
+		if _, err := os.Stat(uc.projectPath(implementPath)); err == nil {
 			paths = append(paths, implementPath)
 			continue
 		}
 		// Fall back to old location
 		implementPath = filepath.Join(specsDir, fmt.Sprintf("implement_%d.md", turn))
-		if _, err := os.Stat(implementPath); err == nil {
+		if _, err := os.Stat(uc.projectPath(implementPath)); err == nil {
 			paths = append(paths, implementPath)
 		}
 	}
@@ -1396,6 +3402,7 @@ func (uc *RunTurnUseCase) collectImplementPaths(sbiID string, maxTurn int) []str
 func (uc *RunTurnUseCase) collectReviewPaths(sbiID string, maxTurn int) []string {
 	var paths []string
 	// Since v0.2.13, reports are in .deespec/reports/sbi/ but old reports may be in .deespec/specs/sbi/
+	// until `deespec migrate reports` moves them
 	reportsDir := fmt.Sprintf(".deespec/reports/sbi/%s", sbiID)
 	specsDir := fmt.Sprintf(".deespec/specs/sbi/%s", sbiID)
 
@@ -1403,13 +3410,13 @@ func (uc *RunTurnUseCase) collectReviewPaths(sbiID string, maxTurn int) []string
 	for turn := 1; turn < maxTurn; turn++ {
 		// Try new location first
 		reviewPath := filepath.Join(reportsDir, fmt.Sprintf("review_%d.md", turn))
-		if _, err := os.Stat(reviewPath); err == nil {
+		if _, err := os.Stat(uc.projectPath(reviewPath)); err == nil {
 			paths = append(paths, reviewPath)
 			continue
 		}
 		// Fall back to old location
 		reviewPath = filepath.Join(specsDir, fmt.Sprintf("review_%d.md", turn))
-		if _, err := os.Stat(reviewPath); err == nil {
+		if _, err := os.Stat(uc.projectPath(reviewPath)); err == nil {
 			paths = append(paths, reviewPath)
 		}
 	}
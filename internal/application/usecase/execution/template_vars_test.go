@@ -0,0 +1,42 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// TestBuildPromptWithArtifact_CustomVars verifies that custom variables reach the templates as
+// data: template syntax in a value is printed, and undeclared variables expand to nothing
+func TestBuildPromptWithArtifact_CustomVars(t *testing.T) {
+	root := t.TempDir()
+	promptsDir := filepath.Join(root, ".deespec", "prompts")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "WIP.md"),
+		[]byte("Owner: {{.Custom.ServiceOwner}}\nStep: {{.Custom.Step}}\nMissing: [{{.Custom.Missing}}]"), 0644))
+
+	s, err := sbi.NewSBI("Login", "Add login", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+
+	uc := NewRunTurnUseCase(nil, nil, nil, nil, 0, 0)
+	uc.SetProjectRoot(root)
+	ctx := context.Background()
+
+	// Without variables the references stay empty
+	assert.Equal(t, "Owner: \nStep: \nMissing: []", uc.buildPromptWithArtifact(ctx, s, "implement", 1, 1, "implement_1.md"))
+
+	uc.SetTemplateVarResolver(service.NewTemplateVarResolver(root, map[string]domainservice.TemplateVar{
+		"ServiceOwner": {Value: "{{.SBIID}} team"},
+		"Step":         {Command: `echo "$DEESPEC_STEP"`},
+	}))
+	assert.Equal(t, "Owner: {{.SBIID}} team\nStep: implement\nMissing: []",
+		uc.buildPromptWithArtifact(ctx, s, "implement", 1, 1, "implement_1.md"))
+}
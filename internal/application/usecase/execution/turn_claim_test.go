@@ -0,0 +1,109 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// countingGateway counts the agent calls
+type countingGateway struct {
+	calls int
+}
+
+func (g *countingGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.calls++
+	return &output.AgentResponse{Output: "implemented", AgentType: "claude-code-cli"}, nil
+}
+
+func (g *countingGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "claude-code-cli"}
+}
+
+func (g *countingGateway) HealthCheck(ctx context.Context) error { return nil }
+
+// TestRunTurn_TurnClaim verifies that a turn claimed by another runner is aborted with a
+// journaled conflict instead of calling the agent again
+func TestRunTurn_TurnClaim(t *testing.T) {
+	for _, claimedElsewhere := range []bool{true, false} {
+		t.Run(map[bool]string{true: "claimed elsewhere", false: "unclaimed"}[claimedElsewhere], func(t *testing.T) {
+			ctx := context.Background()
+			tmpDir := t.TempDir()
+
+			wd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(tmpDir))
+			defer func() { _ = os.Chdir(wd) }()
+
+			db, err := sql.Open("sqlite3", ":memory:")
+			require.NoError(t, err)
+			defer db.Close()
+			require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+			sbiRepo := sqlite.NewSBIRepository(db)
+			journalRepo := infrarepo.NewJournalRepositoryImpl(filepath.Join(tmpDir, "journal.ndjson"))
+
+			task, err := sbi.NewSBI("Add login", "Add the login form", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+			require.NoError(t, err)
+			require.NoError(t, task.UpdateStatus(model.StatusPicked))
+			require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+			require.NoError(t, sbiRepo.Save(ctx, task))
+			sbiID := task.ID().String()
+			turn := task.ExecutionState().CurrentTurn.Value() + 1
+
+			if claimedElsewhere {
+				_, err = db.Exec(`INSERT INTO sbi_turn_claims (sbi_id, turn, pid, hostname, claimed_at) VALUES (?, ?, 4242, 'other-host', ?)`,
+					sbiID, turn, time.Now().UTC().Format(time.RFC3339Nano))
+				require.NoError(t, err)
+			}
+
+			gateway := &countingGateway{}
+			uc := NewRunTurnUseCase(journalRepo, sbiRepo, nil, gateway, 20, time.Minute)
+			uc.SetTurnClaimRepository(sqlite.NewTurnClaimRepository(db))
+
+			out, err := uc.ExecuteForSBI(ctx, sbiID, dto.RunTurnInput{})
+			require.NoError(t, err)
+
+			records, err := journalRepo.FindBySBI(ctx, sbiID)
+			require.NoError(t, err)
+			saved, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+			require.NoError(t, err)
+
+			if claimedElsewhere {
+				assert.True(t, out.NoOp)
+				assert.Equal(t, NoOpReasonTurnConflict, out.NoOpReason)
+				assert.Contains(t, out.ErrorMsg, "already claimed by pid 4242 on other-host")
+				assert.Equal(t, 0, gateway.calls, "the agent is not called for a claimed turn")
+				assert.Equal(t, model.StatusImplementing, saved.Status())
+
+				require.Len(t, records, 1)
+				assert.Equal(t, "TURN_CONFLICT", records[0].Decision)
+				assert.Equal(t, turn, records[0].Turn)
+				assert.Equal(t, service.ErrorClassLockLost, records[0].ErrorClass)
+			} else {
+				assert.False(t, out.NoOp)
+				assert.Equal(t, 1, gateway.calls)
+
+				var pid int
+				require.NoError(t, db.QueryRow(`SELECT pid FROM sbi_turn_claims WHERE sbi_id = ? AND turn = ?`, sbiID, turn).Scan(&pid))
+				assert.Equal(t, os.Getpid(), pid, "the claim of a saved turn is kept")
+			}
+		})
+	}
+}
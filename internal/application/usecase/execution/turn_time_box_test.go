@@ -0,0 +1,105 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// slowGateway works until it is stopped; the follow-up prompt writes the checkpoint when
+// writeCheckpoint is set and answers with a summary
+type slowGateway struct {
+	root            string
+	writeCheckpoint bool
+	prompts         []string
+}
+
+func (g *slowGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.prompts = append(g.prompts, req.Prompt)
+	if len(g.prompts) == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if g.writeCheckpoint {
+		path := filepath.Join(g.root, ".deespec", "reports", "sbi", req.Context["sbi_id"], "checkpoint_1.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte("## Done\nhandler skeleton\n## Remaining\nvalidation"), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return &output.AgentResponse{Output: "handler skeleton is in place", AgentType: "claude-code"}, nil
+}
+
+func (g *slowGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "claude-code"}
+}
+
+func (g *slowGateway) HealthCheck(ctx context.Context) error { return nil }
+
+func TestExecuteStep_TurnTimeBox(t *testing.T) {
+	task, err := sbi.NewSBI("Add handler", "Add the handler", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		writeCheckpoint bool
+		wantContent     string
+	}{
+		{"checkpoint written by the agent", true, "## Remaining\nvalidation"},
+		{"agent answer saved as checkpoint", false, "handler skeleton is in place"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			gateway := &slowGateway{root: root, writeCheckpoint: tt.writeCheckpoint}
+			uc := NewRunTurnUseCase(nil, nil, nil, gateway, 20, time.Minute)
+			uc.SetProjectRoot(root)
+			uc.SetTurnTimeBox(50*time.Millisecond, time.Minute)
+
+			out, err := uc.executeStep(context.Background(), task, "implement", 1, 1)
+			require.NoError(t, err, "a time-boxed turn ends cleanly")
+			assert.True(t, out.Success)
+			assert.Equal(t, "TIME_BOXED", out.Decision)
+			assert.Equal(t, checkpointPath(task.ID().String(), 1), out.CheckpointPath)
+			assert.Equal(t, map[string]interface{}{"type": "checkpoint", "path": out.CheckpointPath}, checkpointArtifact(out))
+
+			require.Len(t, gateway.prompts, 2)
+			assert.Contains(t, gateway.prompts[1], "checkpoint_1.md")
+			assert.Contains(t, gateway.prompts[1], "Add handler", "without a session the task is named again")
+
+			// The next turn resumes from the checkpoint
+			section := uc.buildCheckpoint(task.ID().String(), 2)
+			assert.Contains(t, section, "## Checkpoint from Turn 1")
+			assert.Contains(t, section, tt.wantContent)
+			assert.Empty(t, uc.buildCheckpoint(task.ID().String(), 3), "only the previous turn's checkpoint is resumed")
+		})
+	}
+}
+
+func TestExecuteStep_TurnTimeBoxOnlyBoxesImplementSteps(t *testing.T) {
+	uc := NewRunTurnUseCase(nil, nil, nil, nil, 20, time.Minute)
+	uc.SetTurnTimeBox(time.Minute, time.Minute)
+
+	req := output.AgentRequest{Timeout: 10 * time.Minute}
+	ctx, stop := uc.startTimeBox(context.Background(), "review", &req)
+	defer stop()
+	_, boxed := ctx.Deadline()
+	assert.False(t, boxed)
+
+	uc.SetTurnTimeBox(30*time.Minute, time.Minute)
+	ctx, stop = uc.startTimeBox(context.Background(), "implement", &req)
+	defer stop()
+	_, boxed = ctx.Deadline()
+	assert.True(t, boxed)
+	assert.Equal(t, 31*time.Minute, req.Timeout, "the agent timeout does not cut the turn before its time box")
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// journalExportFormatVersion is bumped when the bundle layout changes
+const journalExportFormatVersion = 1
+
+// Bundle entry names
+const (
+	journalExportManifestEntry = "manifest.json"
+	journalExportJournalEntry  = "journal.json"
+)
+
+// JournalExportOptions selects what a journal export contains
+type JournalExportOptions struct {
+	SBIID   string // Export only this SBI's records ("" = all)
+	Redact  bool   // Anonymize the records (see service.JournalRedactor)
+	Salt    string // Hash salt of a redacted export ("" = random, so exports cannot be linked)
+	Version string // deespec version recorded in the manifest
+}
+
+// JournalExportManifest describes the contents of a journal export bundle
+type JournalExportManifest struct {
+	FormatVersion  int            `json:"format_version"`
+	CreatedAt      string         `json:"created_at"`
+	Version        string         `json:"deespec_version"`
+	Platform       string         `json:"platform"`
+	Redacted       bool           `json:"redacted"`
+	SBIID          string         `json:"sbi_id,omitempty"` // Hashed in a redacted export
+	JournalRecords int            `json:"journal_records"`
+	SBIs           int            `json:"sbis"`
+	Steps          map[string]int `json:"steps"`         // Records per workflow step
+	ErrorClasses   map[string]int `json:"error_classes"` // Records per error class
+	FirstRecordAt  string         `json:"first_record_at,omitempty"`
+	LastRecordAt   string         `json:"last_record_at,omitempty"`
+}
+
+// ExportJournalUseCase bundles journal records into a tar.gz archive that can be attached to a
+// bug report, so that a workflow bug can be reproduced without access to the project
+type ExportJournalUseCase struct {
+	journalRepo repository.JournalRepository
+}
+
+// NewExportJournalUseCase creates a new ExportJournalUseCase
+func NewExportJournalUseCase(journalRepo repository.JournalRepository) *ExportJournalUseCase {
+	return &ExportJournalUseCase{journalRepo: journalRepo}
+}
+
+// Export writes the bundle to w
+func (uc *ExportJournalUseCase) Export(ctx context.Context, opts JournalExportOptions, w io.Writer) (*JournalExportManifest, error) {
+	var records []*repository.JournalRecord
+	var err error
+	if opts.SBIID != "" {
+		records, err = uc.journalRepo.FindBySBI(ctx, opts.SBIID)
+	} else {
+		records, err = uc.journalRepo.Load(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	sbiID := opts.SBIID
+	if opts.Redact {
+		salt := opts.Salt
+		if salt == "" {
+			if salt, err = randomSalt(); err != nil {
+				return nil, err
+			}
+		}
+		redactor := service.NewJournalRedactor(salt)
+		records = redactor.Redact(records)
+		if sbiID != "" {
+			sbiID = redactor.Hash(sbiID)
+		}
+	}
+
+	manifest := newJournalExportManifest(records, opts)
+	manifest.SBIID = sbiID
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, entry := range []struct {
+		name  string
+		value interface{}
+	}{
+		{journalExportManifestEntry, manifest},
+		{journalExportJournalEntry, records},
+	} {
+		data, err := json.MarshalIndent(entry.value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", entry.name, err)
+		}
+		if err := writeTarEntry(tw, entry.name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// newJournalExportManifest summarizes the exported records
+func newJournalExportManifest(records []*repository.JournalRecord, opts JournalExportOptions) *JournalExportManifest {
+	manifest := &JournalExportManifest{
+		FormatVersion:  journalExportFormatVersion,
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+		Version:        opts.Version,
+		Platform:       runtime.GOOS + "/" + runtime.GOARCH,
+		Redacted:       opts.Redact,
+		JournalRecords: len(records),
+		Steps:          make(map[string]int),
+		ErrorClasses:   make(map[string]int),
+	}
+
+	sbis := make(map[string]bool)
+	for _, record := range records {
+		sbis[record.SBIID] = true
+		manifest.Steps[record.Step]++
+		if record.ErrorClass != "" {
+			manifest.ErrorClasses[record.ErrorClass]++
+		}
+		if manifest.FirstRecordAt == "" || record.Timestamp < manifest.FirstRecordAt {
+			manifest.FirstRecordAt = record.Timestamp
+		}
+		if record.Timestamp > manifest.LastRecordAt {
+			manifest.LastRecordAt = record.Timestamp
+		}
+	}
+	manifest.SBIs = len(sbis)
+	return manifest
+}
+
+// randomSalt returns a salt for a one-off redacted export
+func randomSalt() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+func TestExportJournal_Redacted(t *testing.T) {
+	ctx := context.Background()
+	journalRepo := infraRepo.NewJournalRepositoryImpl(filepath.Join(t.TempDir(), "journal.ndjson"))
+	for _, record := range []*repository.JournalRecord{
+		{Timestamp: "2026-10-01T10:00:00Z", SBIID: "SBI-A", Turn: 1, Step: "implement", Status: "IMPLEMENTING", Artifacts: []interface{}{"internal/acme/tax.go"}},
+		{Timestamp: "2026-10-01T10:05:00Z", SBIID: "SBI-A", Turn: 1, Step: "review", Status: "REVIEWING", Error: "agent timed out", ErrorClass: "agent_timeout"},
+		{Timestamp: "2026-10-01T11:00:00Z", SBIID: "SBI-B", Turn: 1, Step: "implement", Status: "IMPLEMENTING"},
+	} {
+		require.NoError(t, journalRepo.Append(ctx, record))
+	}
+	uc := NewExportJournalUseCase(journalRepo)
+
+	var buf bytes.Buffer
+	manifest, err := uc.Export(ctx, JournalExportOptions{SBIID: "SBI-A", Redact: true, Salt: "bug-1", Version: "v1.2.3"}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, manifest.JournalRecords)
+	assert.Equal(t, 1, manifest.SBIs)
+	assert.Equal(t, map[string]int{"implement": 1, "review": 1}, manifest.Steps)
+	assert.Equal(t, map[string]int{"agent_timeout": 1}, manifest.ErrorClasses)
+	assert.Equal(t, "2026-10-01T10:00:00Z", manifest.FirstRecordAt)
+	assert.Equal(t, "2026-10-01T10:05:00Z", manifest.LastRecordAt)
+	assert.True(t, manifest.Redacted)
+
+	entries, err := readTarEntries(&buf)
+	require.NoError(t, err)
+	var records []*repository.JournalRecord
+	require.NoError(t, decodeSnapshotEntry(entries, journalExportJournalEntry, &records))
+	require.Len(t, records, 2)
+	assert.Equal(t, manifest.SBIID, records[0].SBIID, "the SBI filter is hashed like the records")
+	assert.NotContains(t, string(entries[journalExportJournalEntry]), "SBI-A")
+	assert.NotContains(t, string(entries[journalExportJournalEntry]), "acme")
+	assert.Contains(t, string(entries[journalExportManifestEntry]), `"deespec_version": "v1.2.3"`)
+
+	// The same salt gives the same hashes
+	var again bytes.Buffer
+	manifestAgain, err := uc.Export(ctx, JournalExportOptions{SBIID: "SBI-A", Redact: true, Salt: "bug-1"}, &again)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.SBIID, manifestAgain.SBIID)
+
+	// Without --redact the records are exported as journaled
+	var plain bytes.Buffer
+	manifest, err = uc.Export(ctx, JournalExportOptions{}, &plain)
+	require.NoError(t, err)
+	assert.Equal(t, 3, manifest.JournalRecords)
+	assert.Equal(t, 2, manifest.SBIs)
+	entries, err = readTarEntries(&plain)
+	require.NoError(t, err)
+	assert.Contains(t, string(entries[journalExportJournalEntry]), "internal/acme/tax.go")
+}
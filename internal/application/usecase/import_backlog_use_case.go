@@ -0,0 +1,424 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// Backlog import fields that CSV columns can be mapped to
+const (
+	ImportFieldTitle    = "title"    // Required
+	ImportFieldDesc     = "desc"     // PBI body / SBI description
+	ImportFieldType     = "type"     // Row kind: "pbi" or "sbi" (defaults to the import's kind)
+	ImportFieldPoints   = "points"   // PBI story points (0-13)
+	ImportFieldHours    = "hours"    // SBI estimated hours
+	ImportFieldPriority = "priority" // 0-2, or normal/high/urgent
+	ImportFieldLabels   = "labels"   // SBI labels, separated by commas or semicolons
+	ImportFieldParent   = "parent"   // SBI parent: a PBI ID, or the title of a PBI row in the same file
+)
+
+// importFields lists the mappable fields in documentation order
+var importFields = []string{
+	ImportFieldTitle, ImportFieldDesc, ImportFieldType, ImportFieldPoints,
+	ImportFieldHours, ImportFieldPriority, ImportFieldLabels, ImportFieldParent,
+}
+
+// Backlog import row actions
+const (
+	ImportActionCreated = "created" // Created, or would be created in a dry run
+	ImportActionSkipped = "skipped" // Failed validation; nothing was created
+)
+
+// ImportedRow is the outcome of one CSV data row
+type ImportedRow struct {
+	Row    int    `json:"row"`  // Line of the row in the file (the header is line 1)
+	Kind   string `json:"kind"` // "pbi" or "sbi"
+	Title  string `json:"title,omitempty"`
+	TaskID string `json:"task_id,omitempty"` // Empty in a dry run
+	Action string `json:"action"`
+	Error  string `json:"error,omitempty"` // Why a skipped row failed validation
+}
+
+// ImportBacklogResult reports what an import did, in file order
+type ImportBacklogResult struct {
+	DryRun bool          `json:"dry_run"`
+	Rows   []ImportedRow `json:"rows"`
+}
+
+// Count returns the number of rows that got the given action
+func (r *ImportBacklogResult) Count(action string) int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// ImportBacklogOptions configures a backlog import
+type ImportBacklogOptions struct {
+	Mapping     map[string]string // Import field -> CSV column header (see ParseImportMapping)
+	DefaultKind string            // Kind of rows without a type column value: "pbi" or "sbi"
+	DryRun      bool              // Validate and report without creating anything
+}
+
+// ImportBacklogUseCase bulk-creates PBIs and SBIs from a backlog exported as CSV, so an
+// existing backlog (Jira, spreadsheets, ...) can seed a new project
+// PBI rows are created before SBI rows so that SBIs can name a PBI of the same file as parent.
+// Rows that fail validation are skipped and reported; the other rows are still imported.
+type ImportBacklogUseCase struct {
+	sbiRepo  repository.SBIRepository
+	pbiRepo  pbi.Repository
+	specsSBI string // .deespec/specs/sbi
+}
+
+// NewImportBacklogUseCase creates a new ImportBacklogUseCase
+func NewImportBacklogUseCase(sbiRepo repository.SBIRepository, pbiRepo pbi.Repository, specsSBI string) *ImportBacklogUseCase {
+	return &ImportBacklogUseCase{
+		sbiRepo:  sbiRepo,
+		pbiRepo:  pbiRepo,
+		specsSBI: specsSBI,
+	}
+}
+
+// ParseImportMapping parses a column mapping such as "title=Summary,desc=Description,points=SP"
+func ParseImportMapping(spec string) (map[string]string, error) {
+	known := make(map[string]bool, len(importFields))
+	for _, field := range importFields {
+		known[field] = true
+	}
+
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q: expected field=Column", pair)
+		}
+		if !known[field] {
+			return nil, fmt.Errorf("unknown field %q in mapping (fields: %s)", field, strings.Join(importFields, ", "))
+		}
+		if _, dup := mapping[field]; dup {
+			return nil, fmt.Errorf("field %q is mapped twice", field)
+		}
+		mapping[field] = column
+	}
+	if mapping[ImportFieldTitle] == "" {
+		return nil, errors.New("mapping must include title=<column>")
+	}
+	return mapping, nil
+}
+
+// backlogRow is one validated CSV row
+type backlogRow struct {
+	line        int
+	kind        string
+	title       string
+	description string
+	points      int
+	hours       float64
+	priority    int
+	labels      []string
+	parent      string
+}
+
+// Import reads a CSV backlog (with a header row) and creates its PBIs and SBIs
+// An error is returned only for problems with the file itself, such as a mapped column
+// missing from the header; invalid rows are reported in the result.
+func (uc *ImportBacklogUseCase) Import(ctx context.Context, r io.Reader, opts ImportBacklogOptions) (*ImportBacklogResult, error) {
+	if opts.DefaultKind != "pbi" && opts.DefaultKind != "sbi" {
+		return nil, fmt.Errorf("invalid kind %q: must be pbi or sbi", opts.DefaultKind)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV file is empty")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns, err := resolveImportColumns(header, opts.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportBacklogResult{DryRun: opts.DryRun}
+	var pbiRows, sbiRows []*backlogRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		row, err := parseBacklogRow(line, record, columns, opts.DefaultKind)
+		if err != nil {
+			result.Rows = append(result.Rows, skippedRow(row, err))
+			continue
+		}
+		if row.kind == "pbi" {
+			pbiRows = append(pbiRows, row)
+		} else {
+			sbiRows = append(sbiRows, row)
+		}
+	}
+
+	// PBI titles of this file resolve SBI parents; duplicated titles are ambiguous
+	pbiByTitle := make(map[string]string)
+	for _, row := range pbiRows {
+		imported := uc.importPBI(row, opts.DryRun)
+		result.Rows = append(result.Rows, imported)
+		if imported.Action != ImportActionCreated {
+			continue
+		}
+		key := strings.ToLower(row.title)
+		if _, dup := pbiByTitle[key]; dup {
+			pbiByTitle[key] = ""
+		} else {
+			pbiByTitle[key] = imported.TaskID
+			if opts.DryRun {
+				pbiByTitle[key] = fmt.Sprintf("(PBI of row %d)", row.line)
+			}
+		}
+	}
+	for _, row := range sbiRows {
+		result.Rows = append(result.Rows, uc.importSBI(ctx, row, pbiByTitle, opts.DryRun))
+	}
+
+	sort.SliceStable(result.Rows, func(i, j int) bool {
+		return result.Rows[i].Row < result.Rows[j].Row
+	})
+	return result, nil
+}
+
+// importPBI creates the PBI of a row; its body is the title heading followed by the description
+func (uc *ImportBacklogUseCase) importPBI(row *backlogRow, dryRun bool) ImportedRow {
+	p := pbi.NewPBI(row.title)
+	p.EstimatedStoryPoints = row.points
+	p.Priority = pbi.Priority(row.priority)
+	if err := p.Validate(); err != nil {
+		return skippedRow(row, err)
+	}
+	imported := ImportedRow{Row: row.line, Kind: row.kind, Title: row.title, Action: ImportActionCreated}
+	if dryRun {
+		return imported
+	}
+
+	id, err := pbi.GenerateID(uc.pbiRepo)
+	if err != nil {
+		return skippedRow(row, fmt.Errorf("failed to generate PBI ID: %w", err))
+	}
+	p.ID = id
+	if err := uc.pbiRepo.Save(p, specMarkdown(row.title, row.description)); err != nil {
+		return skippedRow(row, fmt.Errorf("failed to save PBI: %w", err))
+	}
+	imported.TaskID = id
+	return imported
+}
+
+// importSBI creates the SBI of a row and writes its spec.md like `sbi register` does
+func (uc *ImportBacklogUseCase) importSBI(ctx context.Context, row *backlogRow, pbiByTitle map[string]string, dryRun bool) ImportedRow {
+	if row.priority < 0 || row.priority > 2 {
+		return skippedRow(row, fmt.Errorf("priority must be between 0 and 2, got %d", row.priority))
+	}
+	var parent string
+	if row.parent != "" {
+		var known bool
+		parent, known = pbiByTitle[strings.ToLower(row.parent)]
+		switch {
+		case known && parent == "":
+			return skippedRow(row, fmt.Errorf("parent %q matches several PBI rows", row.parent))
+		case !known:
+			// Not a title of this file: an existing PBI ID
+			exists, err := uc.pbiRepo.Exists(row.parent)
+			if err != nil {
+				return skippedRow(row, fmt.Errorf("failed to check parent PBI: %w", err))
+			}
+			if !exists {
+				return skippedRow(row, fmt.Errorf("parent PBI %q not found", row.parent))
+			}
+			parent = row.parent
+		}
+	}
+	imported := ImportedRow{Row: row.line, Kind: row.kind, Title: row.title, Action: ImportActionCreated}
+	if dryRun {
+		return imported
+	}
+
+	created, err := saveNewSBI(ctx, uc.sbiRepo, row.title, row.description, parent, sbi.SBIMetadata{
+		EstimatedHours: row.hours,
+		Priority:       row.priority,
+		Labels:         row.labels,
+	})
+	if err != nil {
+		return skippedRow(row, fmt.Errorf("failed to save SBI: %w", err))
+	}
+	imported.TaskID = created.ID().String()
+
+	specDir := filepath.Join(uc.specsSBI, imported.TaskID)
+	if err := os.MkdirAll(specDir, 0755); err == nil {
+		err = os.WriteFile(filepath.Join(specDir, "spec.md"), []byte(specMarkdown(row.title, row.description)), 0644)
+	}
+	if err != nil {
+		imported.Error = fmt.Sprintf("SBI created, but failed to write spec.md: %v", err)
+	}
+	return imported
+}
+
+// resolveImportColumns maps each import field to its column index (header names match case-insensitively)
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, dup := index[name]; !dup {
+			index[name] = i
+		}
+	}
+
+	columns := make(map[string]int, len(mapping))
+	var missing []string
+	for field, column := range mapping {
+		i, ok := index[strings.ToLower(column)]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%s=%s", field, column))
+			continue
+		}
+		columns[field] = i
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("mapped columns not found in the CSV header: %s", strings.Join(missing, ", "))
+	}
+	if _, ok := columns[ImportFieldTitle]; !ok {
+		return nil, errors.New("mapping must include title=<column>")
+	}
+	return columns, nil
+}
+
+// parseBacklogRow converts a record to a row; the row is returned with what was parsed even on error
+func parseBacklogRow(line int, record []string, columns map[string]int, defaultKind string) (*backlogRow, error) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := &backlogRow{
+		line:        line,
+		kind:        defaultKind,
+		title:       value(ImportFieldTitle),
+		description: value(ImportFieldDesc),
+		labels:      splitImportLabels(value(ImportFieldLabels)),
+		parent:      value(ImportFieldParent),
+	}
+	if kind := strings.ToLower(value(ImportFieldType)); kind != "" {
+		if kind != "pbi" && kind != "sbi" {
+			return row, fmt.Errorf("type must be pbi or sbi, got %q", kind)
+		}
+		row.kind = kind
+	}
+	if row.title == "" {
+		return row, errors.New("title is empty")
+	}
+	if v := value(ImportFieldPoints); v != "" {
+		points, err := strconv.ParseFloat(v, 64)
+		if err != nil || points != float64(int(points)) {
+			return row, fmt.Errorf("points must be a whole number, got %q", v)
+		}
+		row.points = int(points)
+	}
+	if v := value(ImportFieldHours); v != "" {
+		hours, err := strconv.ParseFloat(v, 64)
+		if err != nil || hours < 0 {
+			return row, fmt.Errorf("hours must be a non-negative number, got %q", v)
+		}
+		row.hours = hours
+	}
+	if v := value(ImportFieldPriority); v != "" {
+		priority, err := parseImportPriority(v)
+		if err != nil {
+			return row, err
+		}
+		row.priority = priority
+	}
+	return row, nil
+}
+
+// parseImportPriority accepts 0-2 or the priority names normal, high and urgent
+func parseImportPriority(v string) (int, error) {
+	switch strings.ToLower(v) {
+	case "normal":
+		return int(pbi.PriorityNormal), nil
+	case "high":
+		return int(pbi.PriorityHigh), nil
+	case "urgent":
+		return int(pbi.PriorityUrgent), nil
+	}
+	priority, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("priority must be 0-2 or normal/high/urgent, got %q", v)
+	}
+	return priority, nil
+}
+
+// splitImportLabels splits a labels cell on commas and semicolons
+func splitImportLabels(v string) []string {
+	var labels []string
+	for _, label := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ';' }) {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// specMarkdown renders a task spec: an H1 title followed by the description
+func specMarkdown(title, description string) string {
+	if description == "" {
+		return fmt.Sprintf("# %s\n", title)
+	}
+	return fmt.Sprintf("# %s\n\n%s\n", title, description)
+}
+
+// skippedRow reports a row that failed validation
+func skippedRow(row *backlogRow, err error) ImportedRow {
+	return ImportedRow{Row: row.line, Kind: row.kind, Title: row.title, Action: ImportActionSkipped, Error: err.Error()}
+}
+
+// isBlankRecord reports whether every cell of a record is empty
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
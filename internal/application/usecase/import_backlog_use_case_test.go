@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+const backlogCSV = "\ufeffSummary,Description,SP,Kind,Epic,Tags,Priority\n" +
+	"Checkout,\"One-page checkout,\nwith saved cards\",8,pbi,,,high\n" +
+	"Card form,Card number and expiry,,sbi,Checkout,\"frontend; payments\",\n" +
+	"Search,Full-text search,21,pbi,,,\n" +
+	",No title,3,pbi,,,\n" +
+	"Receipts,Email receipts,,sbi,PBI-404,,\n" +
+	",,,,,,\n" +
+	"Refunds,Refund flow,,story,,,\n"
+
+func newImportBacklogTest(t *testing.T) (*ImportBacklogUseCase, repository.SBIRepository, pbi.Repository, string) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	root := t.TempDir()
+	specsSBI := filepath.Join(root, ".deespec", "specs", "sbi")
+	sbiRepo := sqlite.NewSBIRepository(db)
+	pbiRepo := persistence.NewPBISQLiteRepository(db, root)
+	uc := NewImportBacklogUseCase(sbiRepo, pbiRepo, specsSBI)
+	return uc, sbiRepo, pbiRepo, specsSBI
+}
+
+func TestParseImportMapping(t *testing.T) {
+	mapping, err := ParseImportMapping("title=Summary, desc=Description,POINTS=SP")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"title": "Summary", "desc": "Description", "points": "SP"}, mapping)
+
+	for spec, want := range map[string]string{
+		"desc=Description":          "must include title",
+		"title=Summary,owner=Owner": `unknown field "owner"`,
+		"title=Summary,title=Name":  "mapped twice",
+		"title":                     "expected field=Column",
+	} {
+		_, err := ParseImportMapping(spec)
+		assert.ErrorContains(t, err, want, spec)
+	}
+}
+
+// TestImportBacklog_CreatesRowsAndReportsSkipped verifies that valid rows become PBIs and SBIs,
+// SBIs can name a PBI of the same file as parent, and invalid rows are skipped with a reason
+func TestImportBacklog_CreatesRowsAndReportsSkipped(t *testing.T) {
+	ctx := context.Background()
+	uc, sbiRepo, pbiRepo, specsSBI := newImportBacklogTest(t)
+	mapping, err := ParseImportMapping("title=summary,desc=Description,points=SP,type=Kind,parent=Epic,labels=Tags,priority=Priority")
+	require.NoError(t, err)
+
+	result, err := uc.Import(ctx, strings.NewReader(backlogCSV), ImportBacklogOptions{Mapping: mapping, DefaultKind: "pbi"})
+	require.NoError(t, err)
+
+	var lines []int
+	for _, row := range result.Rows {
+		lines = append(lines, row.Row)
+	}
+	assert.Equal(t, []int{2, 4, 5, 6, 7, 9}, lines, "rows are reported in file order by line; blank rows are ignored")
+	assert.Equal(t, 2, result.Count(ImportActionCreated))
+
+	skipped := map[int]string{}
+	for _, row := range result.Rows {
+		if row.Action == ImportActionSkipped {
+			skipped[row.Row] = row.Error
+		}
+	}
+	assert.Equal(t, map[int]string{
+		5: "story points must be between 0 and 13",
+		6: "title is empty",
+		7: `parent PBI "PBI-404" not found`,
+		9: `type must be pbi or sbi, got "story"`,
+	}, skipped)
+
+	checkout := result.Rows[0]
+	p, err := pbiRepo.FindByID(checkout.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, "Checkout", p.Title)
+	assert.Equal(t, 8, p.EstimatedStoryPoints)
+	assert.Equal(t, pbi.PriorityHigh, p.Priority)
+	body, err := pbiRepo.GetBody(checkout.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, "# Checkout\n\nOne-page checkout,\nwith saved cards\n", body)
+
+	cardForm := result.Rows[1]
+	task, err := sbiRepo.Find(ctx, repository.SBIID(cardForm.TaskID))
+	require.NoError(t, err)
+	require.NotNil(t, task.ParentTaskID())
+	assert.Equal(t, checkout.TaskID, task.ParentTaskID().String())
+	assert.Equal(t, []string{"frontend", "payments"}, task.Metadata().Labels)
+	spec, err := os.ReadFile(filepath.Join(specsSBI, cardForm.TaskID, "spec.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Card form\n\nCard number and expiry\n", string(spec))
+}
+
+// TestImportBacklog_DryRunCreatesNothing verifies that a dry run reports the same rows without saving
+func TestImportBacklog_DryRunCreatesNothing(t *testing.T) {
+	ctx := context.Background()
+	uc, sbiRepo, pbiRepo, _ := newImportBacklogTest(t)
+	mapping, err := ParseImportMapping("title=Summary,desc=Description,points=SP,type=Kind,parent=Epic")
+	require.NoError(t, err)
+
+	result, err := uc.Import(ctx, strings.NewReader(backlogCSV), ImportBacklogOptions{Mapping: mapping, DefaultKind: "pbi", DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Count(ImportActionCreated))
+	assert.Equal(t, 4, result.Count(ImportActionSkipped))
+	for _, row := range result.Rows {
+		assert.Empty(t, row.TaskID)
+	}
+
+	pbis, err := pbiRepo.FindAll()
+	require.NoError(t, err)
+	assert.Empty(t, pbis)
+	sbis, err := sbiRepo.List(ctx, repository.SBIFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, sbis)
+}
+
+func TestImportBacklog_MissingColumn(t *testing.T) {
+	uc, _, _, _ := newImportBacklogTest(t)
+	_, err := uc.Import(context.Background(), strings.NewReader(backlogCSV), ImportBacklogOptions{
+		Mapping:     map[string]string{"title": "Summary", "points": "Story Points"},
+		DefaultKind: "pbi",
+	})
+	assert.ErrorContains(t, err, "points=Story Points")
+}
@@ -0,0 +1,151 @@
+package pbi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// BacklogTreeNode is an EPIC, PBI or SBI of the backlog tree with its children
+type BacklogTreeNode struct {
+	Type            repository.TaskType
+	ID              string
+	Title           string
+	Status          string
+	StoryPoints     int // PBI: its estimate, EPIC: the sum over its PBIs
+	DoneStoryPoints int // Story points of the done PBIs
+	SBITotal        int // SBIs below the node
+	SBIDone         int
+	Children        []*BacklogTreeNode
+}
+
+// BacklogTree is the EPIC → PBI → SBI hierarchy of the backlog
+// PBIs without an EPIC and SBIs without a PBI are kept apart.
+type BacklogTree struct {
+	EPICs           []*BacklogTreeNode
+	PBIs            []*BacklogTreeNode // PBIs that belong to no EPIC
+	SBIs            []*BacklogTreeNode // SBIs that belong to no PBI
+	StoryPoints     int
+	DoneStoryPoints int
+}
+
+// BacklogTreeUseCase builds the backlog tree
+// EPICs and SBIs come from the unified task repository; PBIs, which it does not serve,
+// from the PBI repository.
+type BacklogTreeUseCase struct {
+	taskRepo repository.TaskRepository
+	pbiRepo  pbi.Repository
+}
+
+// NewBacklogTreeUseCase creates a new BacklogTreeUseCase
+func NewBacklogTreeUseCase(taskRepo repository.TaskRepository, pbiRepo pbi.Repository) *BacklogTreeUseCase {
+	return &BacklogTreeUseCase{taskRepo: taskRepo, pbiRepo: pbiRepo}
+}
+
+// Execute builds the backlog tree
+// With a status, only PBIs in that status are included, along with their EPICs and SBIs.
+func (u *BacklogTreeUseCase) Execute(ctx context.Context, status pbi.Status) (*BacklogTree, error) {
+	tasks, err := u.taskRepo.List(ctx, repository.TaskFilter{Types: []repository.TaskType{repository.TaskTypeEPIC, repository.TaskTypeSBI}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	pbis, err := u.pbiRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PBIs: %w", err)
+	}
+
+	// SBIs by parent PBI, in execution order
+	sbisByPBI := make(map[string][]*BacklogTreeNode)
+	var epics []*epic.EPIC
+	var orphanSBIs []*BacklogTreeNode
+	for _, t := range tasks {
+		switch t.Type() {
+		case model.TaskTypeEPIC:
+			if e, ok := t.(*epic.EPIC); ok {
+				epics = append(epics, e)
+			}
+		case model.TaskTypeSBI:
+			node := &BacklogTreeNode{Type: repository.TaskTypeSBI, ID: t.ID().String(), Title: t.Title(), Status: string(t.Status()), SBITotal: 1}
+			if t.Status() == model.StatusDone {
+				node.SBIDone = 1
+			}
+			if parent := t.ParentTaskID(); parent != nil {
+				sbisByPBI[parent.String()] = append(sbisByPBI[parent.String()], node)
+			} else {
+				orphanSBIs = append(orphanSBIs, node)
+			}
+		}
+	}
+
+	pbiNodes := make(map[string]*BacklogTreeNode, len(pbis))
+	var order []string
+	for _, p := range pbis {
+		if status != "" && p.Status != status {
+			continue
+		}
+		node := &BacklogTreeNode{
+			Type:        repository.TaskTypePBI,
+			ID:          p.ID,
+			Title:       p.Title,
+			Status:      string(p.Status),
+			StoryPoints: p.EstimatedStoryPoints,
+			Children:    sbisByPBI[p.ID],
+		}
+		if p.IsCompleted() {
+			node.DoneStoryPoints = p.EstimatedStoryPoints
+		}
+		for _, child := range node.Children {
+			addCounts(node, child, false)
+		}
+		pbiNodes[p.ID] = node
+		order = append(order, p.ID)
+	}
+
+	tree := &BacklogTree{}
+	attached := make(map[string]bool)
+	for _, e := range epics {
+		node := &BacklogTreeNode{Type: repository.TaskTypeEPIC, ID: e.ID().String(), Title: e.Title(), Status: string(e.Status())}
+		for _, pbiID := range e.PBIIDs() {
+			child, ok := pbiNodes[pbiID.String()]
+			if !ok || attached[child.ID] {
+				continue // Filtered out or no longer existing
+			}
+			attached[child.ID] = true
+			node.Children = append(node.Children, child)
+			addCounts(node, child, true)
+		}
+		if status != "" && len(node.Children) == 0 {
+			continue
+		}
+		tree.EPICs = append(tree.EPICs, node)
+	}
+	for _, id := range order {
+		if !attached[id] {
+			tree.PBIs = append(tree.PBIs, pbiNodes[id])
+		}
+	}
+	if status == "" {
+		tree.SBIs = orphanSBIs
+	}
+
+	for _, node := range pbiNodes {
+		tree.StoryPoints += node.StoryPoints
+		tree.DoneStoryPoints += node.DoneStoryPoints
+	}
+	return tree, nil
+}
+
+// addCounts adds the SBI counts of a child to its parent, and its story points when they
+// aggregate (PBIs into EPICs)
+func addCounts(parent, child *BacklogTreeNode, storyPoints bool) {
+	parent.SBITotal += child.SBITotal
+	parent.SBIDone += child.SBIDone
+	if storyPoints {
+		parent.StoryPoints += child.StoryPoints
+		parent.DoneStoryPoints += child.DoneStoryPoints
+	}
+}
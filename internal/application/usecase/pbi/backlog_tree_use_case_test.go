@@ -0,0 +1,109 @@
+package pbi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/task"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// fakeTaskRepository lists fixed EPICs and SBIs
+type fakeTaskRepository struct {
+	repository.TaskRepository
+	tasks []task.Task
+}
+
+func (r *fakeTaskRepository) List(ctx context.Context, filter repository.TaskFilter) ([]task.Task, error) {
+	return r.tasks, nil
+}
+
+// listingPBIRepository lists fixed PBIs
+type listingPBIRepository struct {
+	mockPBIRepository
+	pbis []*pbi.PBI
+}
+
+func (r *listingPBIRepository) FindAll() ([]*pbi.PBI, error) {
+	return r.pbis, nil
+}
+
+// newTreeSBI creates an SBI under a PBI (none when empty), done or pending
+func newTreeSBI(t *testing.T, title, pbiID string, done bool) *sbi.SBI {
+	t.Helper()
+	var parent *model.TaskID
+	if pbiID != "" {
+		id, err := model.NewTaskIDFromString(pbiID)
+		require.NoError(t, err)
+		parent = &id
+	}
+	s, err := sbi.NewSBI(title, "", parent, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	if done {
+		for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+			require.NoError(t, s.UpdateStatus(next))
+		}
+	}
+	return s
+}
+
+func TestBacklogTreeUseCase_Execute(t *testing.T) {
+	e, err := epic.NewEPIC("Checkout revamp", "", epic.EPICMetadata{})
+	require.NoError(t, err)
+	for _, id := range []string{"PBI-001", "PBI-002"} {
+		taskID, err := model.NewTaskIDFromString(id)
+		require.NoError(t, err)
+		require.NoError(t, e.AddPBI(taskID))
+	}
+
+	taskRepo := &fakeTaskRepository{tasks: []task.Task{
+		e,
+		newTreeSBI(t, "Payment endpoint", "PBI-001", true),
+		newTreeSBI(t, "Payment tests", "PBI-001", false),
+		newTreeSBI(t, "Receipt mail", "PBI-002", true),
+		newTreeSBI(t, "Stray fix", "", false),
+	}}
+	pbiRepo := &listingPBIRepository{pbis: []*pbi.PBI{
+		{ID: "PBI-001", Title: "Payment API", Status: pbi.StatusInProgress, EstimatedStoryPoints: 8},
+		{ID: "PBI-002", Title: "Receipts", Status: pbi.StatusDone, EstimatedStoryPoints: 3},
+		{ID: "PBI-003", Title: "Dark mode", Status: pbi.StatusPending, EstimatedStoryPoints: 5},
+	}}
+
+	tree, err := NewBacklogTreeUseCase(taskRepo, pbiRepo).Execute(context.Background(), "")
+	require.NoError(t, err)
+
+	require.Len(t, tree.EPICs, 1)
+	epicNode := tree.EPICs[0]
+	assert.Equal(t, repository.TaskTypeEPIC, epicNode.Type)
+	assert.Equal(t, 11, epicNode.StoryPoints)
+	assert.Equal(t, 3, epicNode.DoneStoryPoints)
+	assert.Equal(t, 3, epicNode.SBITotal)
+	assert.Equal(t, 2, epicNode.SBIDone)
+	require.Len(t, epicNode.Children, 2)
+	assert.Equal(t, "PBI-001", epicNode.Children[0].ID)
+	assert.Len(t, epicNode.Children[0].Children, 2)
+	assert.Equal(t, 1, epicNode.Children[0].SBIDone)
+
+	require.Len(t, tree.PBIs, 1, "PBIs outside any EPIC are kept apart")
+	assert.Equal(t, "PBI-003", tree.PBIs[0].ID)
+	require.Len(t, tree.SBIs, 1, "SBIs outside any PBI are kept apart")
+	assert.Equal(t, "Stray fix", tree.SBIs[0].Title)
+	assert.Equal(t, 16, tree.StoryPoints)
+	assert.Equal(t, 3, tree.DoneStoryPoints)
+
+	// A status keeps the matching PBIs and the EPICs they belong to
+	tree, err = NewBacklogTreeUseCase(taskRepo, pbiRepo).Execute(context.Background(), pbi.StatusPending)
+	require.NoError(t, err)
+	assert.Empty(t, tree.EPICs)
+	require.Len(t, tree.PBIs, 1)
+	assert.Equal(t, "PBI-003", tree.PBIs[0].ID)
+	assert.Empty(t, tree.SBIs)
+	assert.Equal(t, 5, tree.StoryPoints)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
 	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 )
@@ -23,6 +24,17 @@ type DecomposeOptions struct {
 	MaxSBIs    int  // Maximum number of SBIs to generate
 	DryRun     bool // If true, only build prompt without executing
 	OutputOnly bool // If true, only output prompt to stdout (for future use)
+
+	SizeLimits SBISizeLimits // Size bounds for generated SBIs (zero value disables sizing validation)
+
+	OutputDir    string        // Directory the agent writes SBI files to (default: the PBI directory)
+	ExistingSBIs []ExistingSBI // SBIs that already exist for the PBI (re-decomposition only)
+}
+
+// ExistingSBI summarizes an SBI that already exists for a PBI being re-decomposed
+type ExistingSBI struct {
+	Title  string // SBI title
+	Status string // Execution status (e.g. DONE) or approval status for unregistered SBI files
 }
 
 // DecomposeResult represents the result of PBI decomposition
@@ -33,6 +45,9 @@ type DecomposeResult struct {
 	PromptFilePath string   // Path to the generated prompt file
 	Message        string   // Result message
 	Prompt         string   // Generated prompt (populated in dry-run mode)
+
+	SizeReports          []*SBISizeReport // Estimated scope of each generated SBI
+	NeedsRedecomposition bool             // True if oversized SBIs were rejected and the PBI should be decomposed again
 }
 
 // DecomposePBIUseCase handles PBI decomposition into SBIs
@@ -43,6 +58,7 @@ type DecomposePBIUseCase struct {
 	labelRepo    repository.LabelRepository // Label repository for loading label instructions
 	agentGateway output.AgentGateway        // Agent gateway for AI execution (optional, can be nil for testing)
 	workingDir   string                     // Base working directory (default: ".")
+	locale       locale.Locale              // Locale of SBI section names (default: ja)
 }
 
 // NewDecomposePBIUseCase creates a new DecomposePBIUseCase instance
@@ -60,9 +76,15 @@ func NewDecomposePBIUseCase(
 		labelRepo:    labelRepo,
 		agentGateway: agentGateway,
 		workingDir:   ".", // Default to current directory
+		locale:       locale.Default,
 	}
 }
 
+// SetLocale sets the locale used to validate SBI section names and generate the integration task
+func (u *DecomposePBIUseCase) SetLocale(loc locale.Locale) {
+	u.locale = loc
+}
+
 // Execute decomposes a PBI into multiple SBIs
 // This is the first half implementation focusing on:
 // - PBI retrieval and validation
@@ -222,18 +244,44 @@ func (u *DecomposePBIUseCase) Execute(
 		}, nil
 	}
 
-	// 12. Create approval.yaml manifest
-	if err := u.createApprovalManifest(ctx, pbiID, sbiFiles); err != nil {
+	// 12. Fill in the PBI's labels, priority and agent and validate the front-matter
+	u.normalizeSBIFrontMatters(pbiEntity, sbiFiles)
+
+	// 13. Estimate SBI sizes and flag (or reject) SBIs exceeding the configured limits
+	sizeReports := u.checkSBISizes(pbiID, sbiFiles, opts.SizeLimits)
+	oversized := 0
+	for _, report := range sizeReports {
+		if report.Oversized() {
+			oversized++
+		}
+	}
+
+	// 14. Create approval.yaml manifest
+	if err := u.createApprovalManifest(ctx, pbiID, sbiFiles, sizeReports, opts.SizeLimits.RejectOversized); err != nil {
 		return nil, fmt.Errorf("failed to create approval manifest: %w", err)
 	}
 
-	// 13. Create integration task (統合テスト・修正タスク) for PBI-attached SBIs
+	// Rejected oversized SBIs require re-decomposition; the PBI stays in planning
+	if oversized > 0 && opts.SizeLimits.RejectOversized {
+		return &DecomposeResult{
+			PBIID:                pbiID,
+			SBICount:             0,
+			SBIFiles:             sbiFiles,
+			PromptFilePath:       promptFilePath,
+			Message:              fmt.Sprintf("%d/%d SBIs exceed size limits and were rejected; decompose the PBI again", oversized, len(sbiFiles)),
+			Prompt:               "",
+			SizeReports:          sizeReports,
+			NeedsRedecomposition: true,
+		}, nil
+	}
+
+	// 15. Create integration task (統合テスト・修正タスク) for PBI-attached SBIs
 	if err := u.createIntegrationTask(ctx, pbiID); err != nil {
 		log.Printf("Warning: Failed to create integration task: %v", err)
 		// Don't fail the entire process, just log warning
 	}
 
-	// 14. Return success result with validation info
+	// 16. Return success result with validation info
 	message := fmt.Sprintf("Successfully generated %d SBI files", len(sbiFiles))
 	if validationResult.MovedFiles > 0 {
 		message += fmt.Sprintf(" (%d files moved from subdirectories)", validationResult.MovedFiles)
@@ -242,6 +290,9 @@ func (u *DecomposePBIUseCase) Execute(
 		message += fmt.Sprintf(" (%d shell scripts removed)", validationResult.RemovedScripts)
 	}
 	message += " + 1 integration task for PBI-level review"
+	if oversized > 0 {
+		message += fmt.Sprintf(" (%d SBIs flagged as oversized)", oversized)
+	}
 
 	return &DecomposeResult{
 		PBIID:          pbiID,
@@ -250,6 +301,7 @@ func (u *DecomposePBIUseCase) Execute(
 		PromptFilePath: promptFilePath,
 		Message:        message,
 		Prompt:         "",
+		SizeReports:    sizeReports,
 	}, nil
 }
 
@@ -294,6 +346,9 @@ func (u *DecomposePBIUseCase) buildDecomposePrompt(
 
 	// 4. Prepare template data
 	pbiDir := filepath.Join(".deespec", "specs", "pbi", p.ID)
+	if opts.OutputDir != "" {
+		pbiDir = opts.OutputDir
+	}
 	templateData := map[string]interface{}{
 		"DeespecVersion":    buildinfo.GetVersion(),
 		"PBIID":             p.ID,
@@ -305,6 +360,8 @@ func (u *DecomposePBIUseCase) buildDecomposePrompt(
 		"MaxSBIs":           opts.MaxSBIs,
 		"PBIDir":            pbiDir,
 		"LabelInstructions": labelInstructions,
+		"SizeLimits":        opts.SizeLimits,
+		"ExistingSBIs":      opts.ExistingSBIs,
 	}
 
 	// 5. Execute template
@@ -452,15 +509,21 @@ func (u *DecomposePBIUseCase) listGeneratedSBIs(pbiID string) ([]string, error)
 
 // createApprovalManifest creates an initial approval.yaml manifest
 // for the generated SBI files
+// Oversized SBIs are annotated with their size violations, or rejected when reject is true
 func (u *DecomposePBIUseCase) createApprovalManifest(
 	ctx context.Context,
 	pbiID string,
 	sbiFiles []string,
+	sizeReports []*SBISizeReport,
+	reject bool,
 ) error {
 	// 1. Create approval manifest with all SBIs in pending status
 	manifest := pbi.NewSBIApprovalManifest(pbiID, sbiFiles)
 
-	// 2. Save manifest using repository
+	// 2. Record size violations
+	applySizeReports(manifest, sizeReports, reject)
+
+	// 3. Save manifest using repository
 	if err := u.approvalRepo.SaveManifest(ctx, manifest); err != nil {
 		return fmt.Errorf("failed to save approval manifest: %w", err)
 	}
@@ -468,6 +531,53 @@ func (u *DecomposePBIUseCase) createApprovalManifest(
 	return nil
 }
 
+// applySizeReports annotates oversized SBIs in the manifest with their size violations
+// Oversized SBIs are rejected instead when reject is true
+func applySizeReports(manifest *pbi.SBIApprovalManifest, sizeReports []*SBISizeReport, reject bool) {
+	violations := make(map[string]string, len(sizeReports))
+	for _, report := range sizeReports {
+		if report.Oversized() {
+			violations[report.File] = "exceeds size limits: " + strings.Join(report.Violations, "; ")
+		}
+	}
+	for i := range manifest.SBIs {
+		reason, ok := violations[manifest.SBIs[i].File]
+		if !ok {
+			continue
+		}
+		if reject {
+			manifest.SBIs[i].Status = pbi.ApprovalStatusRejected
+			manifest.SBIs[i].RejectionReason = reason
+		} else {
+			manifest.SBIs[i].Notes = reason
+		}
+	}
+}
+
+// checkSBISizes estimates the scope of each generated SBI file against the size limits
+// Files that cannot be read are skipped (ValidateSBIFile reports them separately)
+func (u *DecomposePBIUseCase) checkSBISizes(pbiID string, sbiFiles []string, limits SBISizeLimits) []*SBISizeReport {
+	if !limits.Enabled() {
+		return nil
+	}
+
+	pbiDir := filepath.Join(u.workingDir, ".deespec", "specs", "pbi", pbiID)
+	reports := make([]*SBISizeReport, 0, len(sbiFiles))
+	for _, file := range sbiFiles {
+		content, err := os.ReadFile(filepath.Join(pbiDir, file))
+		if err != nil {
+			log.Printf("Warning: Failed to read %s for size check: %v", file, err)
+			continue
+		}
+		report := EstimateSBISize(file, string(content), limits)
+		if report.Oversized() {
+			log.Printf("SBI %s exceeds size limits: %s", file, strings.Join(report.Violations, "; "))
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
 // ValidateSBIFile validates a single SBI file for required sections and metadata
 // Returns an error if the file doesn't meet the deespec SBI format requirements
 func (u *DecomposePBIUseCase) ValidateSBIFile(filePath string) error {
@@ -480,12 +590,7 @@ func (u *DecomposePBIUseCase) ValidateSBIFile(filePath string) error {
 	contentStr := string(content)
 
 	// 2. Validate required sections
-	requiredSections := []string{
-		"## 概要",
-		"## タスク詳細",
-		"## 受け入れ基準",
-		"## 推定工数",
-	}
+	requiredSections := u.locale.SBISections().Required()
 
 	missingSections := []string{}
 	for _, section := range requiredSections {
@@ -721,7 +826,27 @@ func (u *DecomposePBIUseCase) createIntegrationTask(ctx context.Context, pbiID s
 	integrationFilePath := filepath.Join(pbiDir, integrationFileName)
 
 	// 4. Build integration task content
-	integrationContent := fmt.Sprintf(`# PBI統合確認・修正タスク
+	integrationContent := fmt.Sprintf(integrationTaskTemplate(u.locale), pbiID, pbiID, nextSequence)
+
+	// 5. Write integration task file
+	if err := os.WriteFile(integrationFilePath, []byte(integrationContent), 0644); err != nil {
+		return fmt.Errorf("failed to write integration task file: %w", err)
+	}
+
+	log.Printf("Created integration task: %s", integrationFileName)
+	return nil
+}
+
+// integrationTaskTemplate returns the integration task content format for the locale
+// The format takes the PBI ID twice and the sequence number
+func integrationTaskTemplate(loc locale.Locale) string {
+	if loc == locale.English {
+		return integrationTaskTemplateEN
+	}
+	return integrationTaskTemplateJA
+}
+
+const integrationTaskTemplateJA = `# PBI統合確認・修正タスク
 
 ## 概要
 このタスクは、%s に属する全SBIの実装を統合的に確認し、不足している部分や不整合がある場合は修正を行います。
@@ -755,13 +880,40 @@ Parent PBI: %s
 Sequence: %d
 Labels: integration, review
 Only Implement: false
-`, pbiID, pbiID, nextSequence)
+`
 
-	// 5. Write integration task file
-	if err := os.WriteFile(integrationFilePath, []byte(integrationContent), 0644); err != nil {
-		return fmt.Errorf("failed to write integration task file: %w", err)
-	}
+const integrationTaskTemplateEN = `# PBI Integration Check and Fix Task
 
-	log.Printf("Created integration task: %s", integrationFileName)
-	return nil
-}
+## Overview
+This task verifies the implementation of all SBIs belonging to %s as a whole, and fixes anything missing or inconsistent.
+
+## Task Details
+### Checks
+1. **Completeness**: All SBIs are implemented correctly and the PBI works as a whole
+2. **Integration**: The SBIs work together correctly
+3. **Consistency**: No SBIs contradict each other's implementation
+4. **Spec conformance**: The PBI's acceptance criteria are met
+
+### Fix Policy
+- Add missing implementation
+- Unify inconsistent implementations
+- Run PBI-level integration tests and fix any problems
+
+## Acceptance Criteria
+- [ ] All SBI implementations work together
+- [ ] The PBI's acceptance criteria are met
+- [ ] Integration tests pass
+- [ ] Documentation is consistent
+
+## Estimated Hours
+- Implementation review: 0.5 hours
+- Fixes: 1.0 hours
+- Integration testing: 0.5 hours
+- Total: 2.0 hours
+
+---
+Parent PBI: %s
+Sequence: %d
+Labels: integration, review
+Only Implement: false
+`
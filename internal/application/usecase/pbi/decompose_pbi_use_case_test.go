@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/stretchr/testify/assert"
@@ -73,6 +74,10 @@ func (m *mockPromptTemplateRepository) LoadLabelContent(ctx context.Context, lab
 	return ""
 }
 
+func (m *mockPromptTemplateRepository) LoadLabelReviewContent(ctx context.Context, labelName string) string {
+	return ""
+}
+
 func (m *mockPromptTemplateRepository) LoadMetaLabels(ctx context.Context, sbiID string) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
@@ -977,7 +982,7 @@ func TestDecomposePBIUseCase_createApprovalManifest(t *testing.T) {
 
 	useCase := NewDecomposePBIUseCase(pbiRepo, promptRepo, approvalRepo, nil, nil)
 
-	err := useCase.createApprovalManifest(context.Background(), pbiID, sbiFiles)
+	err := useCase.createApprovalManifest(context.Background(), pbiID, sbiFiles, nil, false)
 
 	require.NoError(t, err)
 	require.NotNil(t, savedManifest)
@@ -995,6 +1000,40 @@ func TestDecomposePBIUseCase_createApprovalManifest(t *testing.T) {
 	}
 }
 
+// TestDecomposePBIUseCase_createApprovalManifest_Oversized tests flagging and rejecting oversized SBIs
+func TestDecomposePBIUseCase_createApprovalManifest_Oversized(t *testing.T) {
+	sbiFiles := []string{"sbi_01.md", "sbi_02.md"}
+	reports := []*SBISizeReport{
+		{File: "sbi_01.md", EstimatedHours: 3},
+		{File: "sbi_02.md", EstimatedHours: 12, Violations: []string{"estimated 12.0h exceeds 8.0h"}},
+	}
+
+	for _, reject := range []bool{false, true} {
+		var savedManifest *pbi.SBIApprovalManifest
+		approvalRepo := &mockSBIApprovalRepository{
+			saveManifestFunc: func(ctx context.Context, manifest *pbi.SBIApprovalManifest) error {
+				savedManifest = manifest
+				return nil
+			},
+		}
+		useCase := NewDecomposePBIUseCase(&mockPBIRepository{}, &mockPromptTemplateRepository{}, approvalRepo, nil, nil)
+
+		err := useCase.createApprovalManifest(context.Background(), "PBI-SIZE-001", sbiFiles, reports, reject)
+		require.NoError(t, err)
+		require.NotNil(t, savedManifest)
+
+		assert.Equal(t, pbi.ApprovalStatusPending, savedManifest.SBIs[0].Status)
+		assert.Empty(t, savedManifest.SBIs[0].Notes)
+		if reject {
+			assert.Equal(t, pbi.ApprovalStatusRejected, savedManifest.SBIs[1].Status)
+			assert.Contains(t, savedManifest.SBIs[1].RejectionReason, "exceeds 8.0h")
+		} else {
+			assert.Equal(t, pbi.ApprovalStatusPending, savedManifest.SBIs[1].Status)
+			assert.Contains(t, savedManifest.SBIs[1].Notes, "exceeds 8.0h")
+		}
+	}
+}
+
 // TestDecomposePBIUseCase_createApprovalManifest_SaveError tests error handling
 func TestDecomposePBIUseCase_createApprovalManifest_SaveError(t *testing.T) {
 	pbiID := "PBI-ERROR-001"
@@ -1010,7 +1049,7 @@ func TestDecomposePBIUseCase_createApprovalManifest_SaveError(t *testing.T) {
 
 	useCase := NewDecomposePBIUseCase(pbiRepo, promptRepo, approvalRepo, nil, nil)
 
-	err := useCase.createApprovalManifest(context.Background(), pbiID, sbiFiles)
+	err := useCase.createApprovalManifest(context.Background(), pbiID, sbiFiles, nil, false)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to save approval manifest")
@@ -1055,6 +1094,41 @@ Sequence: 1
 	assert.NoError(t, err)
 }
 
+// TestDecomposePBIUseCase_ValidateSBIFile_EnglishLocale tests validation with English section names
+func TestDecomposePBIUseCase_ValidateSBIFile_EnglishLocale(t *testing.T) {
+	content := `# Test SBI
+
+## Overview
+Test task
+
+## Task Details
+Implementation
+
+## Acceptance Criteria
+- [ ] Test 1
+
+## Estimated Hours
+2 hours
+
+---
+Parent PBI: PBI-001
+Sequence: 1
+`
+
+	tmpFile := filepath.Join(t.TempDir(), "test_sbi.md")
+	require.NoError(t, os.WriteFile(tmpFile, []byte(content), 0644))
+
+	useCase := NewDecomposePBIUseCase(&mockPBIRepository{}, &mockPromptTemplateRepository{}, &mockSBIApprovalRepository{}, nil, nil)
+
+	// Japanese (default) section names are required unless the locale is changed
+	err := useCase.ValidateSBIFile(tmpFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "## 概要")
+
+	useCase.SetLocale(locale.English)
+	assert.NoError(t, useCase.ValidateSBIFile(tmpFile))
+}
+
 // TestDecomposePBIUseCase_ValidateSBIFile_MissingSection tests missing required sections
 func TestDecomposePBIUseCase_ValidateSBIFile_MissingSection(t *testing.T) {
 	testCases := []struct {
@@ -0,0 +1,396 @@
+package pbi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+const (
+	// redecomposeStagingDir is the PBI subdirectory the agent writes proposed SBIs to
+	redecomposeStagingDir = "redecompose"
+
+	// Similarity thresholds above which a proposed SBI is treated as existing work
+	duplicateTitleSimilarity = 0.6
+	duplicateBodySimilarity  = 0.6
+)
+
+// sequenceLinePattern matches the Sequence metadata line of an SBI file
+var sequenceLinePattern = regexp.MustCompile(`(?m)^Sequence:\s*\d+\s*$`)
+
+// SBIDuplicate describes a proposed SBI that was dropped because it matches existing work
+type SBIDuplicate struct {
+	Title         string // Title of the proposed SBI
+	MatchedTitle  string // Title of the existing SBI it matches
+	MatchedStatus string // Status of the existing SBI
+}
+
+// RedecomposeResult represents the result of PBI re-decomposition
+type RedecomposeResult struct {
+	PBIID          string           // ID of the re-decomposed PBI
+	PreservedCount int              // Number of existing SBIs kept as-is
+	DoneCount      int              // Number of existing SBIs already DONE
+	AddedFiles     []string         // SBI files added to the PBI directory and approval manifest
+	Duplicates     []SBIDuplicate   // Proposed SBIs dropped as duplicates of existing work
+	Errors         []string         // Proposed SBI files that could not be parsed
+	SizeReports    []*SBISizeReport // Estimated scope of each added SBI
+	PromptFilePath string           // Path to the generated prompt file
+	Message        string           // Result message
+	Prompt         string           // Generated prompt (populated in dry-run mode)
+}
+
+// existingWork is an SBI (registered or only approved on disk) compared against proposals
+type existingWork struct {
+	title  string
+	body   string
+	status string
+}
+
+// RedecomposePBIUseCase re-runs decomposition for a partially completed PBI
+// Existing SBIs (including DONE ones) are preserved; only genuinely new SBIs are
+// added and appended to the approval manifest
+type RedecomposePBIUseCase struct {
+	decompose *DecomposePBIUseCase
+	sbiRepo   repository.SBIRepository
+}
+
+// NewRedecomposePBIUseCase creates a new RedecomposePBIUseCase instance
+func NewRedecomposePBIUseCase(
+	pbiRepo pbi.Repository,
+	sbiRepo repository.SBIRepository,
+	promptRepo repository.PromptTemplateRepository,
+	approvalRepo repository.SBIApprovalRepository,
+	labelRepo repository.LabelRepository,
+	agentGateway output.AgentGateway,
+) *RedecomposePBIUseCase {
+	return &RedecomposePBIUseCase{
+		decompose: NewDecomposePBIUseCase(pbiRepo, promptRepo, approvalRepo, labelRepo, agentGateway),
+		sbiRepo:   sbiRepo,
+	}
+}
+
+// SetWorkingDir sets the working directory (useful for testing)
+func (u *RedecomposePBIUseCase) SetWorkingDir(dir string) {
+	u.decompose.workingDir = dir
+}
+
+// SetLocale sets the locale used to validate SBI section names
+func (u *RedecomposePBIUseCase) SetLocale(loc locale.Locale) {
+	u.decompose.SetLocale(loc)
+}
+
+// Execute re-decomposes a PBI, adding only SBIs that do not duplicate existing work
+func (u *RedecomposePBIUseCase) Execute(
+	ctx context.Context,
+	pbiID string,
+	opts DecomposeOptions,
+) (*RedecomposeResult, error) {
+	// 1. Retrieve PBI and check it has been decomposed before
+	pbiEntity, err := u.decompose.pbiRepo.FindByID(pbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find PBI %s: %w", pbiID, err)
+	}
+	if pbiEntity.Status == pbi.StatusPending {
+		return nil, fmt.Errorf("PBI %s has not been decomposed yet (use 'deespec pbi decompose')", pbiID)
+	}
+
+	pbiBody, err := u.decompose.pbiRepo.GetBody(pbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PBI body for %s: %w", pbiID, err)
+	}
+
+	// 2. Collect existing work: registered SBIs and approved/pending SBI files
+	manifest, err := u.loadManifest(ctx, pbiID)
+	if err != nil {
+		return nil, err
+	}
+	existing, doneCount, err := u.collectExistingWork(ctx, pbiID, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RedecomposeResult{
+		PBIID:          pbiID,
+		PreservedCount: len(existing),
+		DoneCount:      doneCount,
+		AddedFiles:     []string{},
+	}
+
+	// 3. Build prompt listing the existing SBIs; proposals are written to a staging directory
+	pbiDir := filepath.Join(u.decompose.workingDir, ".deespec", "specs", "pbi", pbiID)
+	stagingDir := filepath.Join(pbiDir, redecomposeStagingDir)
+	opts.OutputDir = filepath.Join(".deespec", "specs", "pbi", pbiID, redecomposeStagingDir)
+	opts.ExistingSBIs = make([]ExistingSBI, 0, len(existing))
+	for _, work := range existing {
+		opts.ExistingSBIs = append(opts.ExistingSBIs, ExistingSBI{Title: work.title, Status: work.status})
+	}
+
+	prompt, err := u.decompose.buildDecomposePrompt(ctx, pbiEntity, pbiBody, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build decompose prompt: %w", err)
+	}
+	result.PromptFilePath, err = u.decompose.writePromptFile(pbiID, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write prompt file: %w", err)
+	}
+
+	if opts.DryRun {
+		result.Message = fmt.Sprintf("Prompt-only mode: prompt saved at %s", result.PromptFilePath)
+		result.Prompt = prompt
+		return result, nil
+	}
+
+	// 4. Execute AI agent
+	if u.decompose.agentGateway == nil {
+		result.Message = fmt.Sprintf("Agent gateway not available. Prompt saved at: %s", result.PromptFilePath)
+		return result, nil
+	}
+	if err := u.decompose.agentGateway.HealthCheck(ctx); err != nil {
+		log.Printf("Claude CLI not available: %v", err)
+		result.Message = fmt.Sprintf("Claude CLI not available. Prompt saved at: %s", result.PromptFilePath)
+		return result, nil
+	}
+
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return nil, fmt.Errorf("failed to clean staging directory: %w", err)
+	}
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if _, err := u.decompose.agentGateway.Execute(ctx, output.AgentRequest{
+		Prompt:  prompt,
+		Timeout: 10 * time.Minute,
+	}); err != nil {
+		log.Printf("AI execution failed: %v", err)
+		result.Message = fmt.Sprintf("AI execution failed. Prompt saved at: %s", result.PromptFilePath)
+		return result, nil
+	}
+
+	// 5. Diff proposals against existing work and move genuinely new SBIs into the PBI directory
+	proposals, err := filepath.Glob(filepath.Join(stagingDir, "sbi_*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list proposed SBI files: %w", err)
+	}
+
+	nextSequence := u.nextSequence(pbiDir)
+	for _, proposal := range proposals {
+		spec, err := ParseSBIFile(proposal)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", filepath.Base(proposal), err))
+			continue
+		}
+
+		if match := findDuplicate(spec, existing); match != nil {
+			result.Duplicates = append(result.Duplicates, SBIDuplicate{
+				Title:         spec.Title,
+				MatchedTitle:  match.title,
+				MatchedStatus: match.status,
+			})
+			continue
+		}
+
+		fileName, err := u.addSBIFile(pbiDir, proposal, nextSequence)
+		if err != nil {
+			return nil, err
+		}
+		nextSequence++
+		result.AddedFiles = append(result.AddedFiles, fileName)
+
+		// Later proposals must not duplicate an SBI added in this run either
+		existing = append(existing, existingWork{title: spec.Title, body: spec.Body, status: string(pbi.ApprovalStatusPending)})
+	}
+
+	if len(result.AddedFiles) == 0 {
+		result.Message = fmt.Sprintf("No new work found: %d proposed SBIs duplicate existing SBIs", len(result.Duplicates))
+		return result, nil
+	}
+
+	u.decompose.normalizeSBIFrontMatters(pbiEntity, result.AddedFiles)
+
+	// 6. Update the approval manifest incrementally
+	if manifest == nil {
+		manifest = pbi.NewSBIApprovalManifest(pbiID, result.AddedFiles)
+	} else {
+		manifest.AddSBIs(result.AddedFiles)
+	}
+	result.SizeReports = u.decompose.checkSBISizes(pbiID, result.AddedFiles, opts.SizeLimits)
+	applySizeReports(manifest, result.SizeReports, opts.SizeLimits.RejectOversized)
+	if err := u.decompose.approvalRepo.SaveManifest(ctx, manifest); err != nil {
+		return nil, fmt.Errorf("failed to save approval manifest: %w", err)
+	}
+
+	// 7. A completed PBI with new work is back in progress
+	if pbiEntity.Status == pbi.StatusDone {
+		if err := pbiEntity.UpdateStatus(pbi.StatusInProgress); err != nil {
+			return nil, fmt.Errorf("failed to update PBI status: %w", err)
+		}
+		if err := u.decompose.pbiRepo.Save(pbiEntity, pbiBody); err != nil {
+			return nil, fmt.Errorf("failed to save PBI: %w", err)
+		}
+	}
+
+	result.Message = fmt.Sprintf("Added %d new SBIs (%d duplicates dropped, %d existing SBIs preserved)",
+		len(result.AddedFiles), len(result.Duplicates), result.PreservedCount)
+	return result, nil
+}
+
+// loadManifest loads the approval manifest of a PBI, or returns nil if none exists
+func (u *RedecomposePBIUseCase) loadManifest(ctx context.Context, pbiID string) (*pbi.SBIApprovalManifest, error) {
+	exists, err := u.decompose.approvalRepo.ManifestExists(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check approval manifest: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	manifest, err := u.decompose.approvalRepo.LoadManifest(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approval manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// collectExistingWork gathers registered SBIs and not-yet-registered SBI files of the manifest
+// Manifests written before per-SBI registration tracking are backfilled by matching titles
+func (u *RedecomposePBIUseCase) collectExistingWork(
+	ctx context.Context,
+	pbiID string,
+	manifest *pbi.SBIApprovalManifest,
+) ([]existingWork, int, error) {
+	registered, err := u.sbiRepo.FindByPBIID(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find SBIs of PBI %s: %w", pbiID, err)
+	}
+
+	var existing []existingWork
+	doneCount := 0
+	registeredByTitle := make(map[string]string, len(registered))
+	for _, s := range registered {
+		if s.Status() == model.StatusDone {
+			doneCount++
+		}
+		registeredByTitle[s.Title()] = s.ID().String()
+		existing = append(existing, existingWork{title: s.Title(), body: s.Description(), status: string(s.Status())})
+	}
+
+	if manifest == nil {
+		return existing, doneCount, nil
+	}
+
+	pbiDir := filepath.Join(u.decompose.workingDir, ".deespec", "specs", "pbi", pbiID)
+	for i := range manifest.SBIs {
+		record := &manifest.SBIs[i]
+		if record.IsRegistered() || record.Status == pbi.ApprovalStatusRejected {
+			continue
+		}
+		spec, err := ParseSBIFile(filepath.Join(pbiDir, record.File))
+		if err != nil {
+			continue
+		}
+		if id, ok := registeredByTitle[spec.Title]; ok && manifest.Registered {
+			record.SBIID = id
+			continue
+		}
+		existing = append(existing, existingWork{title: spec.Title, body: spec.Body, status: string(record.Status)})
+	}
+
+	return existing, doneCount, nil
+}
+
+// nextSequence returns the sequence number following the highest one in the PBI directory
+func (u *RedecomposePBIUseCase) nextSequence(pbiDir string) int {
+	files, _ := filepath.Glob(filepath.Join(pbiDir, "sbi_*.md"))
+	maxSequence := len(files)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		metadata, err := extractMetadata(string(content))
+		if err != nil {
+			continue
+		}
+		var sequence int
+		if _, err := fmt.Sscanf(metadata["Sequence"], "%d", &sequence); err == nil && sequence > maxSequence {
+			maxSequence = sequence
+		}
+	}
+	return maxSequence + 1
+}
+
+// addSBIFile moves a proposed SBI file into the PBI directory under an unused name,
+// renumbering its Sequence metadata so that it follows the existing SBIs
+func (u *RedecomposePBIUseCase) addSBIFile(pbiDir, proposalPath string, sequence int) (string, error) {
+	content, err := os.ReadFile(proposalPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read proposed SBI %s: %w", proposalPath, err)
+	}
+	renumbered := sequenceLinePattern.ReplaceAllString(string(content), fmt.Sprintf("Sequence: %d", sequence))
+
+	var fileName string
+	for n := sequence; ; n++ {
+		fileName = fmt.Sprintf("sbi_%d.md", n)
+		if _, err := os.Stat(filepath.Join(pbiDir, fileName)); os.IsNotExist(err) {
+			break
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(pbiDir, fileName), []byte(renumbered), 0644); err != nil {
+		return "", fmt.Errorf("failed to write SBI file %s: %w", fileName, err)
+	}
+	return fileName, nil
+}
+
+// findDuplicate returns the existing SBI a proposal duplicates, or nil if it is new work
+func findDuplicate(spec *SBISpec, existing []existingWork) *existingWork {
+	for i := range existing {
+		if textSimilarity(spec.Title, existing[i].title) >= duplicateTitleSimilarity ||
+			textSimilarity(spec.Body, existing[i].body) >= duplicateBodySimilarity {
+			return &existing[i]
+		}
+	}
+	return nil
+}
+
+// textSimilarity computes the Jaccard similarity of character bigrams
+// Bigrams work for both space-separated English and unsegmented Japanese text
+func textSimilarity(a, b string) float64 {
+	setA := bigrams(a)
+	setB := bigrams(b)
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for gram := range setA {
+		if setB[gram] {
+			intersection++
+		}
+	}
+	union := len(setA) + len(setB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// bigrams returns the set of character bigrams of normalized text (lowercase, no whitespace)
+func bigrams(text string) map[string]bool {
+	runes := []rune(strings.Join(strings.Fields(strings.ToLower(text)), ""))
+	set := make(map[string]bool, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		set[string(runes[i:i+2])] = true
+	}
+	return set
+}
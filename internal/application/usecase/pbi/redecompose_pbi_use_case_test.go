@@ -0,0 +1,176 @@
+package pbi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// mockDecomposeAgent implements output.AgentGateway by running a callback instead of an AI agent
+type mockDecomposeAgent struct {
+	executeFunc func(req output.AgentRequest) error
+}
+
+func (m *mockDecomposeAgent) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	if err := m.executeFunc(req); err != nil {
+		return nil, err
+	}
+	return &output.AgentResponse{Output: "ok"}, nil
+}
+
+func (m *mockDecomposeAgent) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "mock"}
+}
+
+func (m *mockDecomposeAgent) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func sbiFileContent(title, overview, pbiID string, sequence int) string {
+	return fmt.Sprintf("# %s\n\n## 概要\n%s\n\n## タスク詳細\n- 実装する\n\n## 受け入れ基準\n- 動作する\n\n## 推定工数\n3時間\n\n---\nParent PBI: %s\nSequence: %d\n",
+		title, overview, pbiID, sequence)
+}
+
+func TestRedecomposePBIUseCase_Execute_AddsOnlyNewWork(t *testing.T) {
+	ctx := context.Background()
+	workDir := t.TempDir()
+	pbiID := "PBI-REDO-001"
+	pbiDir := filepath.Join(workDir, ".deespec", "specs", "pbi", pbiID)
+	require.NoError(t, os.MkdirAll(pbiDir, 0755))
+
+	// sbi_1.md is registered and DONE; sbi_2.md is approved but not registered yet
+	require.NoError(t, os.WriteFile(filepath.Join(pbiDir, "sbi_1.md"),
+		[]byte(sbiFileContent("ユーザー登録APIの実装", "ユーザー登録エンドポイントを追加する", pbiID, 1)), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pbiDir, "sbi_2.md"),
+		[]byte(sbiFileContent("ログインAPIの実装", "ログインエンドポイントを追加する", pbiID, 2)), 0644))
+
+	parentID, err := model.NewTaskIDFromString(pbiID)
+	require.NoError(t, err)
+	done, err := sbi.NewSBI("ユーザー登録APIの実装", "ユーザー登録エンドポイントを追加する", &parentID, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	for _, status := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusReviewing, model.StatusDone} {
+		require.NoError(t, done.UpdateStatus(status))
+	}
+	sbiRepo := newMockSBIRepository()
+	require.NoError(t, sbiRepo.Save(ctx, done))
+
+	// Legacy manifest: registered without per-SBI IDs
+	manifest := pbi.NewSBIApprovalManifest(pbiID, []string{"sbi_1.md", "sbi_2.md"})
+	manifest.SBIs[0].Status = pbi.ApprovalStatusApproved
+	manifest.SBIs[1].Status = pbi.ApprovalStatusApproved
+	manifest.Registered = true
+
+	var savedManifest *pbi.SBIApprovalManifest
+	approvalRepo := &mockSBIApprovalRepository{
+		manifestExistsFunc: func(ctx context.Context, id string) (bool, error) { return true, nil },
+		loadManifestFunc: func(ctx context.Context, id string) (*pbi.SBIApprovalManifest, error) {
+			return manifest, nil
+		},
+		saveManifestFunc: func(ctx context.Context, m *pbi.SBIApprovalManifest) error {
+			savedManifest = m
+			return nil
+		},
+	}
+
+	pbiEntity := &pbi.PBI{ID: pbiID, Title: "認証機能", Status: pbi.StatusDone}
+	var savedStatus pbi.Status
+	pbiRepo := &mockPBIRepository{
+		findByIDFunc: func(id string) (*pbi.PBI, error) { return pbiEntity, nil },
+		getBodyFunc:  func(id string) (string, error) { return "認証機能を実装する", nil },
+		saveFunc: func(p *pbi.PBI, body string) error {
+			savedStatus = p.Status
+			return nil
+		},
+	}
+	promptRepo := &mockPromptTemplateRepository{
+		loadPBIDecomposeTemplateFunc: func(ctx context.Context) (string, error) {
+			return "out={{.PBIDir}}\n{{range .ExistingSBIs}}[{{.Status}}] {{.Title}}\n{{end}}", nil
+		},
+	}
+
+	var prompt string
+	agent := &mockDecomposeAgent{executeFunc: func(req output.AgentRequest) error {
+		prompt = req.Prompt
+		stagingDir := filepath.Join(pbiDir, redecomposeStagingDir)
+		files := map[string]string{
+			"sbi_1.md": sbiFileContent("ユーザー登録APIの実装", "ユーザー登録エンドポイントを追加する", pbiID, 1),
+			"sbi_2.md": sbiFileContent("ログインAPIを実装", "ログインエンドポイントを追加する", pbiID, 2),
+			"sbi_3.md": sbiFileContent("パスワードリセット機能", "リセットメールを送信する", pbiID, 3),
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(stagingDir, name), []byte(content), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+
+	useCase := NewRedecomposePBIUseCase(pbiRepo, sbiRepo, promptRepo, approvalRepo, &mockLabelRepository{}, agent)
+	useCase.SetWorkingDir(workDir)
+
+	result, err := useCase.Execute(ctx, pbiID, DecomposeOptions{MinSBIs: 1, MaxSBIs: 5})
+	require.NoError(t, err)
+
+	// Prompt lists existing work and targets the staging directory
+	assert.Contains(t, prompt, "out=.deespec/specs/pbi/PBI-REDO-001/redecompose")
+	assert.Contains(t, prompt, "[DONE] ユーザー登録APIの実装")
+	assert.Contains(t, prompt, "[approved] ログインAPIの実装")
+
+	assert.Equal(t, 1, result.DoneCount)
+	assert.Equal(t, 2, result.PreservedCount)
+	assert.Equal(t, []string{"sbi_3.md"}, result.AddedFiles)
+	assert.Len(t, result.Duplicates, 2)
+
+	// The new SBI follows the existing sequence and the staging directory is removed
+	added, err := ParseSBIFile(filepath.Join(pbiDir, "sbi_3.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "パスワードリセット機能", added.Title)
+	assert.Equal(t, 3, added.Sequence)
+	assert.NoDirExists(t, filepath.Join(pbiDir, redecomposeStagingDir))
+
+	// Manifest is updated incrementally
+	require.NotNil(t, savedManifest)
+	require.Len(t, savedManifest.SBIs, 3)
+	assert.Equal(t, done.ID().String(), savedManifest.SBIs[0].SBIID)
+	assert.Equal(t, pbi.ApprovalStatusApproved, savedManifest.SBIs[1].Status)
+	assert.False(t, savedManifest.SBIs[1].IsRegistered())
+	assert.Equal(t, "sbi_3.md", savedManifest.SBIs[2].File)
+	assert.Equal(t, pbi.ApprovalStatusPending, savedManifest.SBIs[2].Status)
+	assert.False(t, savedManifest.Registered)
+	assert.Equal(t, 3, savedManifest.TotalSBIs)
+
+	// A completed PBI with new work is back in progress
+	assert.Equal(t, pbi.StatusInProgress, savedStatus)
+}
+
+func TestRedecomposePBIUseCase_Execute_PendingPBI(t *testing.T) {
+	pbiRepo := &mockPBIRepository{
+		findByIDFunc: func(id string) (*pbi.PBI, error) {
+			return &pbi.PBI{ID: id, Status: pbi.StatusPending}, nil
+		},
+	}
+
+	useCase := NewRedecomposePBIUseCase(pbiRepo, newMockSBIRepository(), &mockPromptTemplateRepository{}, &mockSBIApprovalRepository{}, nil, nil)
+	_, err := useCase.Execute(context.Background(), "PBI-NEW", DecomposeOptions{MinSBIs: 1, MaxSBIs: 5})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has not been decomposed yet")
+}
+
+func TestTextSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, textSimilarity("Login API", "login  api"))
+	assert.GreaterOrEqual(t, textSimilarity("ログインAPIの実装", "ログインAPIを実装"), duplicateTitleSimilarity)
+	assert.Less(t, textSimilarity("ログインAPIの実装", "パスワードリセット機能"), duplicateTitleSimilarity)
+	assert.Equal(t, 0.0, textSimilarity("", "anything"))
+}
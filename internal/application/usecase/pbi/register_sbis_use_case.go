@@ -63,7 +63,7 @@ func (u *RegisterSBIsUseCase) Execute(
 	opts RegisterSBIsOptions,
 ) (*RegisterSBIsResult, error) {
 	// 1. Validate PBI exists
-	_, err := u.pbiRepo.FindByID(pbiID)
+	parent, err := u.pbiRepo.FindByID(pbiID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find PBI %s: %w", pbiID, err)
 	}
@@ -80,7 +80,12 @@ func (u *RegisterSBIsUseCase) Execute(
 	}
 
 	// 4. Get approved SBI files
+	// Without Force, SBIs registered by a previous run are skipped so that
+	// re-decomposed PBIs can be registered incrementally
 	approvedFiles := manifest.GetApprovedSBIs()
+	if !opts.Force {
+		approvedFiles = manifest.GetUnregisteredApprovedSBIs()
+	}
 	if len(approvedFiles) == 0 {
 		return nil, fmt.Errorf("no approved SBIs found in approval manifest for PBI %s", pbiID)
 	}
@@ -95,6 +100,10 @@ func (u *RegisterSBIsUseCase) Execute(
 
 	var registeredSBIs []registeredSBIInfo
 	var previousSBIID string // Track previous SBI for dependency chain
+	if !opts.Force {
+		// Chain new SBIs after the ones registered by a previous run
+		previousSBIID = manifest.LastRegisteredSBIID()
+	}
 
 	for _, sbiFile := range approvedFiles {
 		sbiFilePath := u.buildSBIFilePath(pbiID, sbiFile)
@@ -108,7 +117,7 @@ func (u *RegisterSBIsUseCase) Execute(
 		}
 
 		// Register single SBI
-		sbiID, err := u.registerSingleSBI(ctx, pbiID, spec, previousSBIID, opts)
+		sbiID, err := u.registerSingleSBI(ctx, parent, spec, previousSBIID, opts)
 		if err != nil {
 			errMsg := fmt.Sprintf("failed to register %s: %v", sbiFile, err)
 			result.Errors = append(result.Errors, errMsg)
@@ -149,18 +158,21 @@ func (u *RegisterSBIsUseCase) Execute(
 		}
 
 		// Update PBI status to "planed" (decomposed and ready for execution)
-		if err := pbiEntity.UpdateStatus(pbi.StatusPlaned); err != nil {
-			return result, fmt.Errorf("failed to update PBI status: %w", err)
-		}
-
-		// Save the updated PBI (empty body string since we're only updating metadata)
-		if err := u.pbiRepo.Save(pbiEntity, ""); err != nil {
-			return result, fmt.Errorf("failed to save PBI with updated status: %w", err)
+		// A PBI already in progress (re-decomposition) keeps its status
+		if pbiEntity.Status != pbi.StatusInProgress {
+			if err := pbiEntity.UpdateStatus(pbi.StatusPlaned); err != nil {
+				return result, fmt.Errorf("failed to update PBI status: %w", err)
+			}
+
+			// Save the updated PBI (empty body string since we're only updating metadata)
+			if err := u.pbiRepo.Save(pbiEntity, ""); err != nil {
+				return result, fmt.Errorf("failed to save PBI with updated status: %w", err)
+			}
 		}
 	}
 
 	// 9. Update approval manifest with registration information
-	if err := u.updateApprovalManifest(ctx, pbiID, registeredSBIs); err != nil {
+	if err := u.updateApprovalManifest(ctx, pbiID, registeredSBIs, opts.Force); err != nil {
 		return result, fmt.Errorf("failed to update approval manifest: %w", err)
 	}
 
@@ -171,12 +183,13 @@ func (u *RegisterSBIsUseCase) Execute(
 // Returns the generated SBI ID
 func (u *RegisterSBIsUseCase) registerSingleSBI(
 	ctx context.Context,
-	pbiID string,
+	parent *pbi.PBI,
 	spec *SBISpec,
 	previousSBIID string,
 	opts RegisterSBIsOptions,
 ) (string, error) {
 	// 1. Validate parent PBI ID matches
+	pbiID := parent.ID
 	if spec.ParentPBIID != pbiID {
 		return "", fmt.Errorf(
 			"parent PBI ID mismatch: expected %s, got %s in spec",
@@ -190,13 +203,25 @@ func (u *RegisterSBIsUseCase) registerSingleSBI(
 	if err != nil {
 		return "", fmt.Errorf("invalid PBI ID: %w", err)
 	}
+	// Labels, priority and agent are inherited from the PBI unless the SBI sets its own
+	priority := int(parent.Priority)
+	if spec.Priority != nil {
+		priority = *spec.Priority
+	}
+	agent := spec.AssignedAgent
+	if agent == "" {
+		agent = parent.AssignedAgent
+	}
+	if agent == "" {
+		agent = "claude-code" // Default agent
+	}
 	metadata := sbi.SBIMetadata{
 		EstimatedHours: spec.EstimatedHours,
-		Priority:       0, // Default priority
+		Priority:       priority,
 		Sequence:       spec.Sequence,
 		RegisteredAt:   time.Now(),
-		Labels:         []string{},
-		AssignedAgent:  "claude-code", // Default agent
+		Labels:         NormalizeLabels(append(append([]string{}, parent.Labels...), spec.Labels...)),
+		AssignedAgent:  agent,
 		FilePaths:      []string{},
 		DependsOn:      []string{},
 	}
@@ -211,6 +236,16 @@ func (u *RegisterSBIsUseCase) registerSingleSBI(
 	if previousSBIID != "" {
 		sbiEntity.AddDependency(previousSBIID)
 	}
+	// Dependencies declared in the front matter must already be registered
+	for _, dependsOn := range spec.DependsOn {
+		if dependsOn == previousSBIID {
+			continue
+		}
+		if _, err := u.sbiRepo.Find(ctx, repository.SBIID(dependsOn)); err != nil {
+			return "", fmt.Errorf("depends_on %s: %w", dependsOn, err)
+		}
+		sbiEntity.AddDependency(dependsOn)
+	}
 
 	// 4. Skip database save in dry-run mode
 	if opts.DryRun {
@@ -238,6 +273,7 @@ func (u *RegisterSBIsUseCase) updateApprovalManifest(
 	ctx context.Context,
 	pbiID string,
 	registeredSBIs []registeredSBIInfo,
+	force bool,
 ) error {
 	// 1. Load current manifest
 	manifest, err := u.approvalRepo.LoadManifest(ctx, repository.PBIID(pbiID))
@@ -250,10 +286,19 @@ func (u *RegisterSBIsUseCase) updateApprovalManifest(
 	now := time.Now()
 	manifest.RegisteredAt = &now
 
-	// 3. Collect registered SBI IDs
-	manifest.RegisteredSBIs = []string{}
+	// 3. Collect registered SBI IDs (appended to the previous run's IDs unless re-registering everything)
+	if force || manifest.RegisteredSBIs == nil {
+		manifest.RegisteredSBIs = []string{}
+	}
+	registeredByFile := make(map[string]string, len(registeredSBIs))
 	for _, info := range registeredSBIs {
 		manifest.RegisteredSBIs = append(manifest.RegisteredSBIs, info.ID)
+		registeredByFile[info.FilePath] = info.ID
+	}
+	for i := range manifest.SBIs {
+		if id, ok := registeredByFile[manifest.SBIs[i].File]; ok {
+			manifest.SBIs[i].SBIID = id
+		}
 	}
 
 	// 4. Save updated manifest
@@ -37,6 +37,24 @@ func (m *mockSBIRepository) Find(ctx context.Context, id repository.SBIID) (*sbi
 	return s, nil
 }
 
+func (m *mockSBIRepository) FindMany(ctx context.Context, ids []repository.SBIID) ([]*sbi.SBI, error) {
+	var result []*sbi.SBI
+	for _, id := range ids {
+		if s, exists := m.sbis[string(id)]; exists {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSBIRepository) ListWithExecutionState(ctx context.Context, filter repository.SBIFilter) ([]*repository.SBIExecutionState, error) {
+	var result []*repository.SBIExecutionState
+	for _, s := range m.sbis {
+		result = append(result, &repository.SBIExecutionState{SBI: s, DependsOn: m.dependencies[s.ID().String()]})
+	}
+	return result, nil
+}
+
 func (m *mockSBIRepository) Save(ctx context.Context, s *sbi.SBI) error {
 	if m.saveFunc != nil {
 		return m.saveFunc(ctx, s)
@@ -251,6 +269,165 @@ func TestRegisterSBIsUseCase_Execute_Success(t *testing.T) {
 	assert.Equal(t, pbi.StatusPlaned, savedPBI.Status, "PBI status should be updated to 'planed'")
 }
 
+func TestRegisterSBIsUseCase_Execute_Incremental(t *testing.T) {
+	tmpDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	pbiID := "PBI-005"
+
+	testPBI := &pbi.PBI{ID: pbiID, Title: "Partially done PBI", Status: pbi.StatusInProgress}
+	pbiRepo := &mockPBIRepository{
+		findByIDFunc: func(id string) (*pbi.PBI, error) { return testPBI, nil },
+		saveFunc: func(p *pbi.PBI, body string) error {
+			t.Errorf("PBI in progress should keep its status, got save with %s", p.Status)
+			return nil
+		},
+	}
+	sbiRepo := newMockSBIRepository()
+
+	// sbi_01 was registered by a previous run; sbi_02 was added by re-decomposition
+	createTestSBIFile(t, tmpDir, pbiID, "sbi_01.md", "Existing work", 1, 2.0)
+	createTestSBIFile(t, tmpDir, pbiID, "sbi_02.md", "New work", 2, 2.0)
+	manifest := &pbi.SBIApprovalManifest{
+		PBIID: pbiID,
+		SBIs: []pbi.SBIApprovalRecord{
+			{File: "sbi_01.md", Status: pbi.ApprovalStatusApproved, SBIID: "SBI-EXISTING"},
+			{File: "sbi_02.md", Status: pbi.ApprovalStatusApproved},
+		},
+		RegisteredSBIs: []string{"SBI-EXISTING"},
+	}
+
+	var savedManifest *pbi.SBIApprovalManifest
+	approvalRepo := &mockSBIApprovalRepository{
+		loadManifestFunc: func(ctx context.Context, id string) (*pbi.SBIApprovalManifest, error) {
+			return manifest, nil
+		},
+		saveManifestFunc: func(ctx context.Context, m *pbi.SBIApprovalManifest) error {
+			savedManifest = m
+			return nil
+		},
+	}
+
+	useCase := NewRegisterSBIsUseCase(sbiRepo, pbiRepo, approvalRepo)
+	useCase.SetWorkingDir(tmpDir)
+
+	result, err := useCase.Execute(ctx, pbiID, RegisterSBIsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.RegisteredCount)
+
+	// The new SBI is chained after the previously registered one
+	deps, _ := sbiRepo.GetDependencies(ctx, repository.SBIID(result.SBIIDs[0]))
+	assert.Equal(t, []string{"SBI-EXISTING"}, deps)
+
+	require.NotNil(t, savedManifest)
+	assert.Equal(t, []string{"SBI-EXISTING", result.SBIIDs[0]}, savedManifest.RegisteredSBIs)
+	assert.Equal(t, result.SBIIDs[0], savedManifest.SBIs[1].SBIID)
+}
+
+func TestRegisterSBIsUseCase_Execute_InheritsPBIFields(t *testing.T) {
+	tmpDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	pbiID := "PBI-006"
+
+	testPBI := &pbi.PBI{ID: pbiID, Title: "Labeled PBI", Status: pbi.StatusPlanning, Priority: pbi.PriorityUrgent,
+		Labels: []string{"security"}, AssignedAgent: "codex"}
+	pbiRepo := &mockPBIRepository{
+		findByIDFunc: func(id string) (*pbi.PBI, error) { return testPBI, nil },
+		saveFunc:     func(p *pbi.PBI, body string) error { return nil },
+	}
+	sbiRepo := newMockSBIRepository()
+
+	// sbi_01 inherits everything; sbi_02 sets its own priority and agent
+	createTestSBIFile(t, tmpDir, pbiID, "sbi_01.md", "Inherits", 1, 2.0)
+	pbiDir := filepath.Join(tmpDir, ".deespec", "specs", "pbi", pbiID)
+	require.NoError(t, os.WriteFile(filepath.Join(pbiDir, "sbi_02.md"), []byte(
+		"# Overrides\n\n## 推定工数\n1\n\n---\nParent PBI: PBI-006\nSequence: 2\nLabels: backend\nPriority: 0\nAgent: gemini\n"), 0644))
+
+	manifest := &pbi.SBIApprovalManifest{
+		PBIID: pbiID,
+		SBIs: []pbi.SBIApprovalRecord{
+			{File: "sbi_01.md", Status: pbi.ApprovalStatusApproved},
+			{File: "sbi_02.md", Status: pbi.ApprovalStatusApproved},
+		},
+	}
+	approvalRepo := &mockSBIApprovalRepository{
+		loadManifestFunc: func(ctx context.Context, id string) (*pbi.SBIApprovalManifest, error) { return manifest, nil },
+		saveManifestFunc: func(ctx context.Context, m *pbi.SBIApprovalManifest) error { return nil },
+	}
+
+	useCase := NewRegisterSBIsUseCase(sbiRepo, pbiRepo, approvalRepo)
+	useCase.SetWorkingDir(tmpDir)
+
+	result, err := useCase.Execute(ctx, pbiID, RegisterSBIsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.RegisteredCount, result.Errors)
+
+	inherits := sbiRepo.sbis[result.SBIIDs[0]].Metadata()
+	assert.Equal(t, []string{"security"}, inherits.Labels)
+	assert.Equal(t, 2, inherits.Priority)
+	assert.Equal(t, "codex", inherits.AssignedAgent)
+
+	overrides := sbiRepo.sbis[result.SBIIDs[1]].Metadata()
+	assert.Equal(t, []string{"security", "backend"}, overrides.Labels)
+	assert.Equal(t, 0, overrides.Priority)
+	assert.Equal(t, "gemini", overrides.AssignedAgent)
+}
+
+func TestRegisterSBIsUseCase_Execute_FrontMatter(t *testing.T) {
+	tmpDir, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	pbiID := "PBI-007"
+
+	testPBI := &pbi.PBI{ID: pbiID, Title: "Front matter PBI", Status: pbi.StatusPlanning}
+	pbiRepo := &mockPBIRepository{
+		findByIDFunc: func(id string) (*pbi.PBI, error) { return testPBI, nil },
+		saveFunc:     func(p *pbi.PBI, body string) error { return nil },
+	}
+	sbiRepo := newMockSBIRepository()
+	existing, err := sbi.NewSBI("Schema", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	sbiRepo.sbis[existing.ID().String()] = existing
+
+	pbiDir := filepath.Join(tmpDir, ".deespec", "specs", "pbi", pbiID)
+	require.NoError(t, os.MkdirAll(pbiDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(pbiDir, "sbi_01.md"), []byte(fmt.Sprintf(
+		"---\nparent_pbi: %s\nsequence: 1\nlabels: [backend]\npriority: 1\nestimated_hours: 4\ndepends_on: [%s]\n---\n# Migrate\n", pbiID, existing.ID())), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pbiDir, "sbi_02.md"), []byte(fmt.Sprintf(
+		"---\nparent_pbi: %s\nsequence: 2\nestimated_hours: 1\ndepends_on: [SBI-UNKNOWN]\n---\n# Backfill\n", pbiID)), 0644))
+
+	manifest := &pbi.SBIApprovalManifest{
+		PBIID: pbiID,
+		SBIs: []pbi.SBIApprovalRecord{
+			{File: "sbi_01.md", Status: pbi.ApprovalStatusApproved},
+			{File: "sbi_02.md", Status: pbi.ApprovalStatusApproved},
+		},
+	}
+	approvalRepo := &mockSBIApprovalRepository{
+		loadManifestFunc: func(ctx context.Context, id string) (*pbi.SBIApprovalManifest, error) { return manifest, nil },
+		saveManifestFunc: func(ctx context.Context, m *pbi.SBIApprovalManifest) error { return nil },
+	}
+
+	useCase := NewRegisterSBIsUseCase(sbiRepo, pbiRepo, approvalRepo)
+	useCase.SetWorkingDir(tmpDir)
+
+	result, err := useCase.Execute(ctx, pbiID, RegisterSBIsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.RegisteredCount)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "depends_on SBI-UNKNOWN")
+
+	metadata := sbiRepo.sbis[result.SBIIDs[0]].Metadata()
+	assert.Equal(t, []string{"backend"}, metadata.Labels)
+	assert.Equal(t, 1, metadata.Priority)
+	assert.Equal(t, 4.0, metadata.EstimatedHours)
+	assert.Equal(t, []string{existing.ID().String()}, sbiRepo.dependencies[result.SBIIDs[0]])
+}
+
 func TestRegisterSBIsUseCase_Execute_DryRun(t *testing.T) {
 	// Setup
 	tmpDir, cleanup := setupTestEnvironment(t)
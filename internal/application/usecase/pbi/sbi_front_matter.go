@@ -0,0 +1,178 @@
+package pbi
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// frontMatterLinePattern matches a "Key: value" line of the SBI metadata section
+var frontMatterLinePattern = regexp.MustCompile(`^([A-Za-z][A-Za-z ]*):\s*(.*)$`)
+
+// NormalizeSBIFrontMatter fills in the cross-cutting fields of a decomposed SBI file from its
+// PBI and validates the metadata section, instead of relying on the agent to copy them:
+//   - Parent PBI is set to the PBI
+//   - Labels are the PBI's labels plus the SBI's own ones ("none" without any)
+//   - Priority and Agent are inherited from the PBI unless the SBI sets them
+//
+// It returns the normalized content and a description of each change. A file without an H1
+// title, a metadata section or a valid Sequence, or with an invalid Priority, is an error.
+// A file with YAML front matter is normalized in its front matter instead of the footer.
+func NormalizeSBIFrontMatter(content string, p *pbi.PBI) (string, []string, error) {
+	front, rest, err := domainservice.ParseSpecFrontMatter(content)
+	if err != nil {
+		return "", nil, err
+	}
+	if front != nil {
+		return normalizeYAMLFrontMatter(front, rest, p)
+	}
+	if _, err := extractTitle(content); err != nil {
+		return "", nil, err
+	}
+	delimiter := strings.Index(content, "\n---\n")
+	if delimiter < 0 {
+		return "", nil, fmt.Errorf("metadata section not found (missing '---' delimiter)")
+	}
+	head, section := content[:delimiter+len("\n---\n")], content[delimiter+len("\n---\n"):]
+
+	lines := strings.Split(strings.TrimRight(section, "\n"), "\n")
+	values := make(map[string]string)
+	index := make(map[string]int)
+	for i, line := range lines {
+		if matches := frontMatterLinePattern.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			if _, seen := index[matches[1]]; !seen {
+				values[matches[1]] = strings.TrimSpace(matches[2])
+				index[matches[1]] = i
+			}
+		}
+	}
+
+	sequence, err := strconv.Atoi(values["Sequence"])
+	if err != nil || sequence < 1 {
+		return "", nil, fmt.Errorf("invalid or missing Sequence: %q", values["Sequence"])
+	}
+	if priority, ok := values["Priority"]; ok && priority != "" {
+		if n, err := strconv.Atoi(priority); err != nil || n < int(pbi.PriorityNormal) || n > int(pbi.PriorityUrgent) {
+			return "", nil, fmt.Errorf("invalid Priority %q (valid: 0, 1, 2)", priority)
+		}
+	}
+
+	var changes []string
+	set := func(key, value string) {
+		if current, ok := values[key]; ok && current == value {
+			return
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s", key, value))
+		values[key] = value
+		if i, ok := index[key]; ok {
+			lines[i] = key + ": " + value
+			return
+		}
+		index[key] = len(lines)
+		lines = append(lines, key+": "+value)
+	}
+
+	set("Parent PBI", p.ID)
+	labels := NormalizeLabels(append(append([]string{}, p.Labels...), strings.Split(values["Labels"], ",")...))
+	if len(labels) == 0 {
+		set("Labels", "none")
+	} else {
+		set("Labels", strings.Join(labels, ", "))
+	}
+	if values["Priority"] == "" {
+		set("Priority", strconv.Itoa(int(p.Priority)))
+	}
+	if values["Agent"] == "" && p.AssignedAgent != "" {
+		set("Agent", p.AssignedAgent)
+	}
+
+	return head + strings.Join(lines, "\n") + "\n", changes, nil
+}
+
+// normalizeYAMLFrontMatter applies the same rules as NormalizeSBIFrontMatter to a YAML front matter
+// The Sequence may still come from a footer.
+func normalizeYAMLFrontMatter(front *domainservice.SpecFrontMatter, rest string, p *pbi.PBI) (string, []string, error) {
+	if _, err := extractTitle(rest); err != nil {
+		return "", nil, err
+	}
+	if front.Sequence == 0 {
+		if metadata, err := extractMetadata(rest); err != nil || metadata["Sequence"] == "" {
+			return "", nil, fmt.Errorf("invalid or missing sequence in the front matter")
+		}
+	}
+
+	var changes []string
+	if front.ParentPBI != p.ID {
+		front.ParentPBI = p.ID
+		changes = append(changes, "parent_pbi: "+p.ID)
+	}
+	labels := NormalizeLabels(append(append([]string{}, p.Labels...), front.Labels...))
+	if strings.Join(labels, ",") != strings.Join(front.Labels, ",") {
+		front.Labels = labels
+		changes = append(changes, "labels: "+strings.Join(labels, ", "))
+	}
+	if front.Priority == nil {
+		priority := int(p.Priority)
+		front.Priority = &priority
+		changes = append(changes, fmt.Sprintf("priority: %d", priority))
+	}
+	if front.Agent == "" && p.AssignedAgent != "" {
+		front.Agent = p.AssignedAgent
+		changes = append(changes, "agent: "+p.AssignedAgent)
+	}
+
+	rendered, err := front.Render()
+	if err != nil {
+		return "", nil, err
+	}
+	return rendered + rest, changes, nil
+}
+
+// NormalizeLabels trims label names and drops empty ones, "none" and duplicates, keeping the order
+func NormalizeLabels(labels []string) []string {
+	result := []string{}
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" || strings.EqualFold(label, "none") || seen[label] {
+			continue
+		}
+		seen[label] = true
+		result = append(result, label)
+	}
+	return result
+}
+
+// normalizeSBIFrontMatters normalizes the front-matter of generated SBI files in the PBI directory
+// Files that fail validation are left as they are and reported (registration rejects them).
+func (u *DecomposePBIUseCase) normalizeSBIFrontMatters(p *pbi.PBI, sbiFiles []string) {
+	pbiDir := filepath.Join(u.workingDir, ".deespec", "specs", "pbi", p.ID)
+	for _, file := range sbiFiles {
+		path := filepath.Join(pbiDir, file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Warning: Failed to read %s for front-matter normalization: %v", file, err)
+			continue
+		}
+		normalized, changes, err := NormalizeSBIFrontMatter(string(content), p)
+		if err != nil {
+			log.Printf("Warning: Invalid front-matter in %s: %v", file, err)
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(normalized), 0644); err != nil {
+			log.Printf("Warning: Failed to write normalized %s: %v", file, err)
+			continue
+		}
+		log.Printf("Normalized front-matter of %s (%s)", file, strings.Join(changes, "; "))
+	}
+}
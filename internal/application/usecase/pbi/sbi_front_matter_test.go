@@ -0,0 +1,105 @@
+package pbi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const frontMatterTestSBI = `# Add login API
+
+## 概要
+Login endpoint
+
+## 推定工数
+3
+
+---
+Parent PBI: PBI-OLD
+Sequence: 2
+Labels: backend, security
+`
+
+func TestNormalizeSBIFrontMatter(t *testing.T) {
+	parent := &pbi.PBI{ID: "PBI-001", Priority: pbi.PriorityHigh, Labels: []string{"security", "auth"}, AssignedAgent: "codex"}
+
+	normalized, changes, err := NormalizeSBIFrontMatter(frontMatterTestSBI, parent)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Parent PBI: PBI-001", "Labels: security, auth, backend", "Priority: 1", "Agent: codex"}, changes)
+	assert.Contains(t, normalized, "## 推定工数\n3\n\n---\nParent PBI: PBI-001\nSequence: 2\nLabels: security, auth, backend\nPriority: 1\nAgent: codex\n")
+
+	// The normalized file parses with the inherited fields
+	path := filepath.Join(t.TempDir(), "sbi_02.md")
+	require.NoError(t, os.WriteFile(path, []byte(normalized), 0644))
+	spec, err := ParseSBIFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "PBI-001", spec.ParentPBIID)
+	assert.Equal(t, []string{"security", "auth", "backend"}, spec.Labels)
+	require.NotNil(t, spec.Priority)
+	assert.Equal(t, 1, *spec.Priority)
+	assert.Equal(t, "codex", spec.AssignedAgent)
+
+	// Normalizing again changes nothing
+	again, changes, err := NormalizeSBIFrontMatter(normalized, parent)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, normalized, again)
+}
+
+func TestNormalizeSBIFrontMatter_KeepsSBIOverrides(t *testing.T) {
+	content := "# Task\n\n---\nParent PBI: PBI-001\nSequence: 1\nPriority: 2\nAgent: gemini\nLabels: none\n"
+	parent := &pbi.PBI{ID: "PBI-001", AssignedAgent: "codex"}
+
+	normalized, changes, err := NormalizeSBIFrontMatter(content, parent)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, content, normalized)
+}
+
+func TestNormalizeSBIFrontMatter_YAML(t *testing.T) {
+	content := "---\nsequence: 2\nlabels: [backend]\npriority: 0\nestimated_hours: 3\n---\n# Add login API\n\nLogin endpoint\n"
+	parent := &pbi.PBI{ID: "PBI-001", Priority: pbi.PriorityHigh, Labels: []string{"auth"}, AssignedAgent: "codex"}
+
+	normalized, changes, err := NormalizeSBIFrontMatter(content, parent)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"parent_pbi: PBI-001", "labels: auth, backend", "agent: codex"}, changes, "an explicit priority is kept")
+	assert.Equal(t, "---\nparent_pbi: PBI-001\nsequence: 2\nlabels:\n    - auth\n    - backend\npriority: 0\nestimated_hours: 3\nagent: codex\n---\n# Add login API\n\nLogin endpoint\n", normalized)
+
+	again, changes, err := NormalizeSBIFrontMatter(normalized, parent)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, normalized, again)
+
+	_, _, err = NormalizeSBIFrontMatter("---\nlabels: [api]\n---\n# Task\n", parent)
+	assert.ErrorContains(t, err, "missing sequence")
+}
+
+func TestNormalizeSBIFrontMatter_Invalid(t *testing.T) {
+	parent := &pbi.PBI{ID: "PBI-001"}
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no title", "Body\n\n---\nSequence: 1\n", "no H1 title"},
+		{"no metadata", "# Task\n\nBody\n", "metadata section not found"},
+		{"no sequence", "# Task\n\n---\nParent PBI: PBI-001\n", "Sequence"},
+		{"bad priority", "# Task\n\n---\nSequence: 1\nPriority: high\n", "invalid Priority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NormalizeSBIFrontMatter(tt.content, parent)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, NormalizeLabels([]string{" a", "", "none", "b", "a"}))
+	assert.Equal(t, []string{}, NormalizeLabels(nil))
+}
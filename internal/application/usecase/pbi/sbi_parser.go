@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
 )
 
 // SBISpec represents a parsed SBI specification from a Markdown file
@@ -16,9 +19,14 @@ type SBISpec struct {
 	ParentPBIID    string
 	Sequence       int
 	Labels         []string // Label names assigned to this SBI
+	Priority       *int     // Priority (0-2) set in the metadata (nil = inherit from the PBI)
+	AssignedAgent  string   // Agent set in the metadata (empty = inherit from the PBI)
+	DependsOn      []string // SBI IDs set in the front matter that must be completed first
 }
 
 // ParseSBIFile parses an SBI file and extracts all metadata
+// The metadata comes from the YAML front matter when the file has one, and otherwise from the
+// "Parent PBI:/Sequence:" footer after "---"; front matter values win over footer ones.
 func ParseSBIFile(filePath string) (*SBISpec, error) {
 	// Read file content
 	content, err := os.ReadFile(filePath)
@@ -26,7 +34,11 @@ func ParseSBIFile(filePath string) (*SBISpec, error) {
 		return nil, fmt.Errorf("failed to read SBI file %s: %w", filePath, err)
 	}
 
-	contentStr := string(content)
+	// Split off the front matter
+	front, contentStr, err := domainservice.ParseSpecFrontMatter(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
 
 	// Extract title
 	title, err := extractTitle(contentStr)
@@ -38,15 +50,25 @@ func ParseSBIFile(filePath string) (*SBISpec, error) {
 	body := extractBody(contentStr)
 
 	// Extract estimated hours
-	estimatedHours, err := extractEstimatedHours(contentStr)
-	if err != nil {
+	var estimatedHours float64
+	if front != nil && front.EstimatedHours != nil {
+		estimatedHours = *front.EstimatedHours
+	} else if estimatedHours, err = extractEstimatedHours(contentStr); err != nil {
 		return nil, fmt.Errorf("failed to extract estimated hours from %s: %w", filePath, err)
 	}
 
-	// Extract metadata
+	// Extract metadata (the footer is optional with front matter)
 	metadata, err := extractMetadata(contentStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract metadata from %s: %w", filePath, err)
+		if front == nil {
+			return nil, fmt.Errorf("failed to extract metadata from %s: %w", filePath, err)
+		}
+		metadata = make(map[string]string)
+	}
+	var dependsOn []string
+	if front != nil {
+		applyFrontMatter(metadata, front)
+		dependsOn = front.DependsOn
 	}
 
 	// Validate required metadata fields
@@ -69,6 +91,16 @@ func ParseSBIFile(filePath string) (*SBISpec, error) {
 	// Extract labels (optional)
 	labels := extractLabels(metadata)
 
+	// Extract priority (optional)
+	var priority *int
+	if priorityStr, ok := metadata["Priority"]; ok {
+		p, err := strconv.Atoi(priorityStr)
+		if err != nil || p < 0 || p > 2 {
+			return nil, fmt.Errorf("invalid priority value '%s' (valid: 0, 1, 2)", priorityStr)
+		}
+		priority = &p
+	}
+
 	return &SBISpec{
 		Title:          title,
 		Body:           body,
@@ -76,9 +108,31 @@ func ParseSBIFile(filePath string) (*SBISpec, error) {
 		ParentPBIID:    parentPBIID,
 		Sequence:       sequence,
 		Labels:         labels,
+		Priority:       priority,
+		AssignedAgent:  metadata["Agent"],
+		DependsOn:      dependsOn,
 	}, nil
 }
 
+// applyFrontMatter overrides the footer metadata with the fields set in the front matter
+func applyFrontMatter(metadata map[string]string, front *domainservice.SpecFrontMatter) {
+	if front.ParentPBI != "" {
+		metadata["Parent PBI"] = front.ParentPBI
+	}
+	if front.Sequence > 0 {
+		metadata["Sequence"] = strconv.Itoa(front.Sequence)
+	}
+	if front.Labels != nil {
+		metadata["Labels"] = strings.Join(front.Labels, ", ")
+	}
+	if front.Priority != nil {
+		metadata["Priority"] = strconv.Itoa(*front.Priority)
+	}
+	if front.Agent != "" {
+		metadata["Agent"] = front.Agent
+	}
+}
+
 // extractTitle extracts the title from the first H1 heading (# Title)
 func extractTitle(content string) (string, error) {
 	lines := strings.Split(content, "\n")
@@ -120,13 +174,21 @@ func extractBody(content string) string {
 	return strings.TrimSpace(content)
 }
 
-// extractEstimatedHours extracts estimated hours from the "## 推定工数" section
+// estimatedHoursPattern matches the estimated hours section in any supported locale
+// ("## 推定工数", "## Estimated Hours") and captures the value on the next line
+var estimatedHoursPattern = func() *regexp.Regexp {
+	var headings []string
+	for _, loc := range locale.Supported() {
+		heading := strings.TrimPrefix(loc.SBISections().EstimatedHours, "## ")
+		headings = append(headings, regexp.QuoteMeta(heading))
+	}
+	return regexp.MustCompile(`(?mi)^##\s*(?:` + strings.Join(headings, "|") + `)\s*\n\s*([0-9.]+)`)
+}()
+
+// extractEstimatedHours extracts estimated hours from the "## 推定工数" (or "## Estimated Hours") section
 // Supports formats like: "3時間", "3.5時間", "3 hours", "3.5", etc.
 func extractEstimatedHours(content string) (float64, error) {
-	// Regular expression to match "## 推定工数" section
-	// Captures the value on the next line after the heading
-	re := regexp.MustCompile(`(?m)^##\s*推定工数\s*\n\s*([0-9.]+)`)
-	matches := re.FindStringSubmatch(content)
+	matches := estimatedHoursPattern.FindStringSubmatch(content)
 
 	if len(matches) >= 2 {
 		hoursStr := strings.TrimSpace(matches[1])
@@ -158,6 +220,8 @@ func extractMetadata(content string) (map[string]string, error) {
 	parentPBIRegex := regexp.MustCompile(`(?m)^Parent PBI:\s*(.+)$`)
 	sequenceRegex := regexp.MustCompile(`(?m)^Sequence:\s*(\d+)$`)
 	labelsRegex := regexp.MustCompile(`(?m)^Labels:\s*(.+)$`)
+	priorityRegex := regexp.MustCompile(`(?m)^Priority:\s*(\S+)\s*$`)
+	agentRegex := regexp.MustCompile(`(?m)^Agent:\s*(.+)$`)
 
 	// Extract Parent PBI
 	if matches := parentPBIRegex.FindStringSubmatch(metadataSection); len(matches) >= 2 {
@@ -174,6 +238,14 @@ func extractMetadata(content string) (map[string]string, error) {
 		metadata["Labels"] = strings.TrimSpace(matches[1])
 	}
 
+	// Extract Priority and Agent (optional, filled in from the PBI by the normalizer)
+	if matches := priorityRegex.FindStringSubmatch(metadataSection); len(matches) >= 2 {
+		metadata["Priority"] = strings.TrimSpace(matches[1])
+	}
+	if matches := agentRegex.FindStringSubmatch(metadataSection); len(matches) >= 2 {
+		metadata["Agent"] = strings.TrimSpace(matches[1])
+	}
+
 	// Validate that required fields were found
 	if _, ok := metadata["Parent PBI"]; !ok {
 		return nil, fmt.Errorf("metadata section missing 'Parent PBI' field")
@@ -404,6 +404,19 @@ func TestExtractEstimatedHours_Decimal(t *testing.T) {
 	assert.Equal(t, 3.5, hours)
 }
 
+func TestExtractEstimatedHours_English(t *testing.T) {
+	content := `## Estimated Hours
+4 hours
+
+---
+`
+
+	hours, err := extractEstimatedHours(content)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, hours)
+}
+
 func TestExtractEstimatedHours_NotFound(t *testing.T) {
 	content := `## 概要
 テスト
@@ -501,3 +514,50 @@ Sequence: 13
 	assert.Equal(t, "PBI-001（PBI分解機能の実装）", spec.ParentPBIID)
 	assert.Equal(t, 13, spec.Sequence)
 }
+
+func TestParseSBIFile_FrontMatter(t *testing.T) {
+	content := `---
+parent_pbi: PBI-001
+sequence: 3
+labels: [backend, api]
+priority: 2
+estimated_hours: 1.5
+depends_on: [SBI-EARLIER]
+---
+# Payment API
+
+## 概要
+Charge the card.
+`
+	path := filepath.Join(t.TempDir(), "sbi_03.md")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	spec, err := ParseSBIFile(path)
+	require.NoError(t, err, "the footer and the estimated hours section are optional with front matter")
+	assert.Equal(t, "Payment API", spec.Title)
+	assert.Equal(t, "# Payment API\n\n## 概要\nCharge the card.", spec.Body)
+	assert.Equal(t, "PBI-001", spec.ParentPBIID)
+	assert.Equal(t, 3, spec.Sequence)
+	assert.Equal(t, []string{"backend", "api"}, spec.Labels)
+	require.NotNil(t, spec.Priority)
+	assert.Equal(t, 2, *spec.Priority)
+	assert.Equal(t, 1.5, spec.EstimatedHours)
+	assert.Equal(t, []string{"SBI-EARLIER"}, spec.DependsOn)
+}
+
+func TestParseSBIFile_FrontMatterOverridesFooter(t *testing.T) {
+	content := "---\nlabels: frontend\n---\n# Task\n\n## Estimated Hours\n2\n\n---\nParent PBI: PBI-001\nSequence: 4\nLabels: backend\n"
+	path := filepath.Join(t.TempDir(), "sbi_04.md")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	spec, err := ParseSBIFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "PBI-001", spec.ParentPBIID)
+	assert.Equal(t, 4, spec.Sequence)
+	assert.Equal(t, []string{"frontend"}, spec.Labels)
+	assert.Equal(t, 2.0, spec.EstimatedHours)
+
+	require.NoError(t, os.WriteFile(path, []byte("---\npriority: urgent\n---\n# Task\n"), 0644))
+	_, err = ParseSBIFile(path)
+	assert.ErrorContains(t, err, "invalid front matter")
+}
@@ -0,0 +1,118 @@
+package pbi
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// SBISizeLimits defines the size bounds a generated SBI must satisfy
+// A zero value disables the corresponding check
+type SBISizeLimits struct {
+	MinEstimatedHours     float64 // Smallest acceptable estimate (推定工数)
+	MaxEstimatedHours     float64 // Largest acceptable estimate (推定工数)
+	MaxAcceptanceCriteria int     // Maximum number of acceptance criteria (受け入れ基準)
+	MaxTouchedAreas       int     // Maximum number of distinct code areas referenced
+	RejectOversized       bool    // Reject violating SBIs instead of only flagging them
+}
+
+// DefaultSBISizeLimits returns the limits used when setting.json does not configure them
+func DefaultSBISizeLimits() SBISizeLimits {
+	return SBISizeLimits{
+		MinEstimatedHours:     0.5,
+		MaxEstimatedHours:     8,
+		MaxAcceptanceCriteria: 8,
+		MaxTouchedAreas:       5,
+		RejectOversized:       false,
+	}
+}
+
+// Enabled reports whether any size check is configured
+func (l SBISizeLimits) Enabled() bool {
+	return l.MinEstimatedHours > 0 || l.MaxEstimatedHours > 0 || l.MaxAcceptanceCriteria > 0 || l.MaxTouchedAreas > 0
+}
+
+// SBISizeReport holds the estimated scope of a generated SBI and any limit violations
+type SBISizeReport struct {
+	File           string   // SBI file name (e.g. sbi_1.md)
+	EstimatedHours float64  // 0 if the estimate could not be parsed
+	CriteriaCount  int      // Number of acceptance criteria
+	TouchedAreas   []string // Distinct code areas referenced in the SBI (e.g. internal/domain)
+	Violations     []string // Human-readable limit violations (empty if within limits)
+}
+
+// Oversized reports whether the SBI violates any size limit
+func (r *SBISizeReport) Oversized() bool {
+	return len(r.Violations) > 0
+}
+
+// touchedPathPattern matches backquoted file or directory paths such as `internal/domain/model/sbi.go`
+var touchedPathPattern = regexp.MustCompile("`([A-Za-z0-9_.\\-]+(?:/[A-Za-z0-9_.\\-]+)+/?)`")
+
+// EstimateSBISize estimates the scope of an SBI from its Markdown content and checks it against limits
+func EstimateSBISize(file string, content string, limits SBISizeLimits) *SBISizeReport {
+	report := &SBISizeReport{File: file}
+
+	if hours, err := extractEstimatedHours(content); err == nil {
+		report.EstimatedHours = hours
+	}
+	report.CriteriaCount = len(domainservice.ExtractAcceptanceCriteria(content))
+	report.TouchedAreas = extractTouchedAreas(content)
+
+	if limits.MaxEstimatedHours > 0 && report.EstimatedHours > limits.MaxEstimatedHours {
+		report.Violations = append(report.Violations,
+			fmt.Sprintf("estimated %.1fh exceeds %.1fh", report.EstimatedHours, limits.MaxEstimatedHours))
+	}
+	if limits.MinEstimatedHours > 0 && report.EstimatedHours > 0 && report.EstimatedHours < limits.MinEstimatedHours {
+		report.Violations = append(report.Violations,
+			fmt.Sprintf("estimated %.1fh is below %.1fh", report.EstimatedHours, limits.MinEstimatedHours))
+	}
+	if limits.MaxAcceptanceCriteria > 0 && report.CriteriaCount > limits.MaxAcceptanceCriteria {
+		report.Violations = append(report.Violations,
+			fmt.Sprintf("%d acceptance criteria exceed %d", report.CriteriaCount, limits.MaxAcceptanceCriteria))
+	}
+	if limits.MaxTouchedAreas > 0 && len(report.TouchedAreas) > limits.MaxTouchedAreas {
+		report.Violations = append(report.Violations,
+			fmt.Sprintf("touches %d areas (max %d): %s",
+				len(report.TouchedAreas), limits.MaxTouchedAreas, strings.Join(report.TouchedAreas, ", ")))
+	}
+
+	return report
+}
+
+// extractTouchedAreas collects the distinct code areas (first two directories) of referenced paths
+// Only the body before the metadata section is scanned
+func extractTouchedAreas(content string) []string {
+	seen := make(map[string]bool)
+	for _, m := range touchedPathPattern.FindAllStringSubmatch(extractBody(content), -1) {
+		p := strings.TrimSuffix(m[1], "/")
+		if strings.HasPrefix(p, ".deespec/") {
+			continue
+		}
+
+		dir := p
+		if path.Ext(p) != "" {
+			dir = path.Dir(p)
+		}
+		segments := strings.Split(dir, "/")
+		if len(segments) > 2 {
+			segments = segments[:2]
+		}
+		area := strings.Join(segments, "/")
+		if area == "." || area == "" {
+			continue
+		}
+		seen[area] = true
+	}
+
+	areas := make([]string, 0, len(seen))
+	for area := range seen {
+		areas = append(areas, area)
+	}
+	sort.Strings(areas)
+	return areas
+}
@@ -0,0 +1,55 @@
+package pbi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sizedSBIContent = "# ログインAPIの実装\n\n" +
+	"## 概要\nログインAPIを実装する\n\n" +
+	"## タスク詳細\n" +
+	"- `internal/interface/api/login.go` にハンドラを追加\n" +
+	"- `internal/application/usecase/login.go` にユースケースを追加\n" +
+	"- `internal/application/usecase/login_test.go` にテストを追加\n" +
+	"- `docs/api/` を更新\n\n" +
+	"## 受け入れ基準\n- [ ] 正しい認証情報でトークンを返す\n- [ ] 誤ったパスワードで401を返す\n- [ ] ログを出力する\n\n" +
+	"## 推定工数\n12時間\n\n" +
+	"---\n" +
+	"Parent PBI: PBI-001\n" +
+	"Sequence: 1\n"
+
+func TestEstimateSBISize(t *testing.T) {
+	report := EstimateSBISize("sbi_1.md", sizedSBIContent, SBISizeLimits{})
+
+	assert.Equal(t, 12.0, report.EstimatedHours)
+	assert.Equal(t, 3, report.CriteriaCount)
+	assert.Equal(t, []string{"docs/api", "internal/application", "internal/interface"}, report.TouchedAreas)
+	assert.False(t, report.Oversized())
+}
+
+func TestEstimateSBISize_Violations(t *testing.T) {
+	limits := SBISizeLimits{
+		MaxEstimatedHours:     8,
+		MaxAcceptanceCriteria: 2,
+		MaxTouchedAreas:       2,
+	}
+
+	report := EstimateSBISize("sbi_1.md", sizedSBIContent, limits)
+
+	assert.True(t, report.Oversized())
+	assert.Len(t, report.Violations, 3)
+	assert.Contains(t, report.Violations[0], "exceeds 8.0h")
+	assert.Contains(t, report.Violations[1], "3 acceptance criteria")
+	assert.Contains(t, report.Violations[2], "touches 3 areas")
+
+	// Default limits only flag the estimate
+	report = EstimateSBISize("sbi_1.md", sizedSBIContent, DefaultSBISizeLimits())
+	assert.Equal(t, []string{"estimated 12.0h exceeds 8.0h"}, report.Violations)
+}
+
+func TestSBISizeLimits_Enabled(t *testing.T) {
+	assert.False(t, SBISizeLimits{}.Enabled())
+	assert.False(t, SBISizeLimits{RejectOversized: true}.Enabled())
+	assert.True(t, DefaultSBISizeLimits().Enabled())
+}
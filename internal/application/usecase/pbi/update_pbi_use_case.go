@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 )
 
@@ -22,6 +23,12 @@ type UpdateOptions struct {
 	Status               *pbi.Status
 	EstimatedStoryPoints *int
 	Priority             *pbi.Priority
+	Deadline             *time.Time        // New deadline (nil = unchanged)
+	ClearDeadline        bool              // Remove the deadline
+	SetEnv               map[string]string // Env variables to add or overwrite
+	UnsetEnv             []string          // Env variables to remove
+	Labels               *[]string         // Labels inherited by decomposed SBIs (nil = unchanged)
+	AssignedAgent        *string           // Agent assigned to decomposed SBIs (nil = unchanged)
 }
 
 // Execute updates a PBI's metadata
@@ -32,7 +39,7 @@ func (u *UpdatePBIUseCase) Execute(id string, opts UpdateOptions) error {
 		return fmt.Errorf("failed to check PBI existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("PBI not found: %s", id)
+		return fmt.Errorf("PBI %w: %s", model.ErrNotFound, id)
 	}
 
 	// 2. Load existing PBI
@@ -57,6 +64,31 @@ func (u *UpdatePBIUseCase) Execute(id string, opts UpdateOptions) error {
 	if opts.Priority != nil {
 		p.Priority = *opts.Priority
 	}
+	if opts.Deadline != nil {
+		p.Deadline = opts.Deadline
+	}
+	if opts.ClearDeadline {
+		p.Deadline = nil
+	}
+	if len(opts.SetEnv) > 0 || len(opts.UnsetEnv) > 0 {
+		env := make(map[string]string, len(p.Env)+len(opts.SetEnv))
+		for key, value := range p.Env {
+			env[key] = value
+		}
+		for key, value := range opts.SetEnv {
+			env[key] = value
+		}
+		for _, key := range opts.UnsetEnv {
+			delete(env, key)
+		}
+		p.Env = env
+	}
+	if opts.Labels != nil {
+		p.Labels = *opts.Labels
+	}
+	if opts.AssignedAgent != nil {
+		p.AssignedAgent = *opts.AssignedAgent
+	}
 
 	// 5. Update timestamp
 	p.UpdatedAt = time.Now()
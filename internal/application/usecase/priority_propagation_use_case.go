@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// PriorityPropagationResult is the outcome (or, in a dry run, the plan) of a propagation
+type PriorityPropagationResult struct {
+	Type     string `json:"type"` // "EPIC" or "PBI"
+	ID       string `json:"id"`
+	Priority int    `json:"priority"`
+	DryRun   bool   `json:"dry_run"`
+	*domainservice.PriorityPropagationPlan
+}
+
+// PriorityPropagationUseCase passes the priority of an EPIC or PBI down to its PBIs and SBIs
+// Children that overrode the priority they inherited keep their own (see PlanPriorityPropagation).
+type PriorityPropagationUseCase struct {
+	epicRepo repository.EPICRepository
+	pbiRepo  pbi.Repository
+	sbiRepo  repository.SBIRepository
+}
+
+// NewPriorityPropagationUseCase creates a new PriorityPropagationUseCase
+func NewPriorityPropagationUseCase(epicRepo repository.EPICRepository, pbiRepo pbi.Repository, sbiRepo repository.SBIRepository) *PriorityPropagationUseCase {
+	return &PriorityPropagationUseCase{
+		epicRepo: epicRepo,
+		pbiRepo:  pbiRepo,
+		sbiRepo:  sbiRepo,
+	}
+}
+
+// Propagate passes the current priority of the EPIC or PBI id down to its children
+// previous is the parent's priority before its last change (the one its children inherited),
+// if known. With dryRun nothing is saved.
+func (uc *PriorityPropagationUseCase) Propagate(ctx context.Context, id string, previous []int, dryRun bool) (*PriorityPropagationResult, error) {
+	result := &PriorityPropagationResult{ID: id, DryRun: dryRun}
+	var children []*domainservice.PriorityNode
+
+	e, err := uc.epicRepo.Find(ctx, repository.EPICID(id))
+	if err == nil {
+		result.Type = "EPIC"
+		result.Priority = e.Metadata().Priority
+		for _, pbiID := range e.PBIIDs() {
+			node, err := uc.pbiNode(ctx, pbiID.String())
+			if err != nil {
+				return nil, err
+			}
+			if node != nil {
+				children = append(children, node)
+			}
+		}
+	} else {
+		p, pbiErr := uc.findPBI(id)
+		if pbiErr != nil {
+			return nil, fmt.Errorf("no EPIC or PBI found with ID %s", id)
+		}
+		result.Type = "PBI"
+		result.Priority = int(p.Priority)
+		if children, err = uc.sbiNodes(ctx, p.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	result.PriorityPropagationPlan = domainservice.PlanPriorityPropagation(result.Priority, previous, children)
+	if dryRun {
+		return result, nil
+	}
+	for _, change := range result.Changes {
+		if err := uc.apply(ctx, change); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// pbiNode loads a PBI with its SBIs; a PBI that no longer exists is skipped
+func (uc *PriorityPropagationUseCase) pbiNode(ctx context.Context, id string) (*domainservice.PriorityNode, error) {
+	exists, err := uc.pbiRepo.Exists(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PBI existence: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	p, err := uc.pbiRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PBI %s: %w", id, err)
+	}
+	children, err := uc.sbiNodes(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domainservice.PriorityNode{
+		Type:      "PBI",
+		ID:        p.ID,
+		Title:     p.Title,
+		Priority:  int(p.Priority),
+		Completed: p.IsCompleted(),
+		Children:  children,
+	}, nil
+}
+
+// sbiNodes loads the SBIs of a PBI
+func (uc *PriorityPropagationUseCase) sbiNodes(ctx context.Context, pbiID string) ([]*domainservice.PriorityNode, error) {
+	sbis, err := uc.sbiRepo.FindByPBIID(ctx, repository.PBIID(pbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SBIs of PBI %s: %w", pbiID, err)
+	}
+	nodes := make([]*domainservice.PriorityNode, 0, len(sbis))
+	for _, s := range sbis {
+		nodes = append(nodes, &domainservice.PriorityNode{
+			Type:      "SBI",
+			ID:        s.ID().String(),
+			Title:     s.Title(),
+			Priority:  s.Priority(),
+			Completed: s.Status() == model.StatusDone || s.Status() == model.StatusFailed,
+		})
+	}
+	return nodes, nil
+}
+
+// apply saves a planned change, unless the task's priority was changed meanwhile
+func (uc *PriorityPropagationUseCase) apply(ctx context.Context, change domainservice.PriorityChange) error {
+	return service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		switch change.Type {
+		case "PBI":
+			p, err := uc.findPBI(change.ID)
+			if err != nil {
+				return err
+			}
+			if int(p.Priority) != change.From {
+				return nil
+			}
+			body, err := uc.pbiRepo.GetBody(p.ID)
+			if err != nil {
+				return fmt.Errorf("failed to load PBI body: %w", err)
+			}
+			p.Priority = pbi.Priority(change.To)
+			if err := uc.pbiRepo.Save(p, body); err != nil {
+				return fmt.Errorf("failed to save PBI %s: %w", p.ID, err)
+			}
+		case "SBI":
+			s, err := uc.sbiRepo.Find(ctx, repository.SBIID(change.ID))
+			if err != nil {
+				return fmt.Errorf("failed to find SBI %s: %w", change.ID, err)
+			}
+			if s.Priority() != change.From {
+				return nil
+			}
+			s.SetPriority(change.To)
+			if err := uc.sbiRepo.Save(ctx, s); err != nil {
+				return fmt.Errorf("failed to save SBI %s: %w", change.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (uc *PriorityPropagationUseCase) findPBI(id string) (*pbi.PBI, error) {
+	exists, err := uc.pbiRepo.Exists(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PBI existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("PBI %w: %s", model.ErrNotFound, id)
+	}
+	return uc.pbiRepo.FindByID(id)
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+func TestPriorityPropagation_EPIC(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	epicRepo := sqlite.NewEPICRepository(db)
+	pbiRepo := persistence.NewPBISQLiteRepository(db, t.TempDir())
+	sbiRepo := sqlite.NewSBIRepository(db)
+	uc := NewPriorityPropagationUseCase(epicRepo, pbiRepo, sbiRepo)
+
+	e, err := epic.NewEPIC("Checkout", "", epic.EPICMetadata{Priority: 2})
+	require.NoError(t, err)
+	addPBI := func(id string, priority pbi.Priority) {
+		p := pbi.NewPBI(id)
+		p.ID = id
+		p.Priority = priority
+		p.ParentEpicID = e.ID().String()
+		require.NoError(t, pbiRepo.Save(p, "# "+id+"\n"))
+		taskID, err := model.NewTaskIDFromString(id)
+		require.NoError(t, err)
+		require.NoError(t, e.AddPBI(taskID))
+	}
+	addPBI("PBI-001", pbi.PriorityNormal)
+	addPBI("PBI-002", pbi.PriorityHigh)
+	require.NoError(t, epicRepo.Save(ctx, e))
+
+	addSBI := func(priority int) string {
+		parent, err := model.NewTaskIDFromString("PBI-001")
+		require.NoError(t, err)
+		s, err := sbi.NewSBI("Task", "", &parent, sbi.SBIMetadata{Priority: priority, RegisteredAt: time.Now()})
+		require.NoError(t, err)
+		require.NoError(t, sbiRepo.Save(ctx, s))
+		return s.ID().String()
+	}
+	inherited := addSBI(0)
+	overridden := addSBI(1)
+
+	// The dry run only plans
+	result, err := uc.Propagate(ctx, e.ID().String(), nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, "EPIC", result.Type)
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, "PBI-001", result.Changes[0].ID)
+	assert.Equal(t, inherited, result.Changes[1].ID)
+	require.Len(t, result.Overridden, 2)
+	p, err := pbiRepo.FindByID("PBI-001")
+	require.NoError(t, err)
+	assert.Equal(t, pbi.PriorityNormal, p.Priority)
+
+	// PBI-002 inherited the EPIC's previous priority, so it follows once that is known
+	result, err = uc.Propagate(ctx, e.ID().String(), []int{1}, false)
+	require.NoError(t, err)
+	assert.Len(t, result.Changes, 3)
+	assert.Len(t, result.Overridden, 1)
+	for _, id := range []string{"PBI-001", "PBI-002"} {
+		p, err := pbiRepo.FindByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, pbi.PriorityUrgent, p.Priority, id)
+	}
+	s, err := sbiRepo.Find(ctx, repository.SBIID(inherited))
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Priority())
+	s, err = sbiRepo.Find(ctx, repository.SBIID(overridden))
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Priority())
+
+	_, err = uc.Propagate(ctx, "PBI-404", nil, true)
+	assert.Error(t, err)
+}
@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// journalIssueWindow is how far back journal errors are reported
+const journalIssueWindow = 24 * time.Hour
+
+// maxRecentJournalIssues caps the journal errors listed in the overview
+const maxRecentJournalIssues = 5
+
+// ProjectStatus is a one-shot overview of the whole project
+type ProjectStatus struct {
+	GeneratedAt      time.Time         `json:"generated_at"`
+	EPICCounts       map[string]int    `json:"epic_counts"`
+	PBICounts        map[string]int    `json:"pbi_counts"`
+	SBICounts        map[string]int    `json:"sbi_counts"`
+	InFlight         []InFlightSBI     `json:"in_flight"`
+	Failed           []FailedSBI       `json:"failed"` // Newest first
+	Locks            []LockSummary     `json:"locks"`
+	LastTurn         *JournalTurn      `json:"last_turn,omitempty"`
+	JournalIssues    int               `json:"journal_issues_24h"`
+	RecentIssues     []JournalTurn     `json:"recent_issues,omitempty"`
+	PendingApprovals []PendingApproval `json:"pending_approvals"`
+	Warnings         []string          `json:"warnings,omitempty"` // Sections that could not be collected
+}
+
+// InFlightSBI is an SBI that is currently being picked, implemented or reviewed
+type InFlightSBI struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Turn      int       `json:"turn"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FailedSBI is an SBI that terminated as FAILED
+type FailedSBI struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Turn      int       `json:"turn"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LockSummary describes an active run or state lock
+type LockSummary struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"` // "run" or "state"
+	PID       int       `json:"pid"`
+	Hostname  string    `json:"hostname"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Expired   bool      `json:"expired"`
+}
+
+// JournalTurn is a journal entry summarized for the overview
+type JournalTurn struct {
+	Timestamp time.Time `json:"timestamp"`
+	SBIID     string    `json:"sbi_id"`
+	Turn      int       `json:"turn"`
+	Step      string    `json:"step"`
+	Decision  string    `json:"decision,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// PendingApproval counts generated SBIs of a PBI that still need a human decision
+type PendingApproval struct {
+	PBIID        string `json:"pbi_id"`
+	Pending      int    `json:"pending"`
+	Unregistered int    `json:"approved_unregistered"` // Approved but not yet registered
+}
+
+// ProjectStatusUseCase collects the project overview shown by `deespec status`
+type ProjectStatusUseCase struct {
+	epicRepo     repository.EPICRepository
+	pbiRepo      pbi.Repository
+	sbiRepo      repository.SBIRepository
+	journalRepo  repository.JournalRepository
+	approvalRepo repository.SBIApprovalRepository
+	lockService  service.LockService
+	now          func() time.Time
+}
+
+// NewProjectStatusUseCase creates a new ProjectStatusUseCase
+// Any dependency may be nil; its section is then left empty
+func NewProjectStatusUseCase(
+	epicRepo repository.EPICRepository,
+	pbiRepo pbi.Repository,
+	sbiRepo repository.SBIRepository,
+	journalRepo repository.JournalRepository,
+	approvalRepo repository.SBIApprovalRepository,
+	lockService service.LockService,
+) *ProjectStatusUseCase {
+	return &ProjectStatusUseCase{
+		epicRepo:     epicRepo,
+		pbiRepo:      pbiRepo,
+		sbiRepo:      sbiRepo,
+		journalRepo:  journalRepo,
+		approvalRepo: approvalRepo,
+		lockService:  lockService,
+		now:          time.Now,
+	}
+}
+
+// Execute builds the overview
+// A failing section is reported in Warnings instead of failing the whole overview
+func (uc *ProjectStatusUseCase) Execute(ctx context.Context) (*ProjectStatus, error) {
+	status := &ProjectStatus{
+		GeneratedAt:      uc.now().UTC(),
+		EPICCounts:       map[string]int{},
+		PBICounts:        map[string]int{},
+		SBICounts:        map[string]int{},
+		InFlight:         []InFlightSBI{},
+		Failed:           []FailedSBI{},
+		Locks:            []LockSummary{},
+		PendingApprovals: []PendingApproval{},
+	}
+
+	warn := func(section string, err error) {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("%s: %v", section, err))
+	}
+
+	if err := uc.collectEPICs(ctx, status); err != nil {
+		warn("epics", err)
+	}
+	pbiIDs, err := uc.collectPBIs(status)
+	if err != nil {
+		warn("pbis", err)
+	}
+	if err := uc.collectSBIs(ctx, status); err != nil {
+		warn("sbis", err)
+	}
+	if err := uc.collectLocks(ctx, status); err != nil {
+		warn("locks", err)
+	}
+	if err := uc.collectJournal(ctx, status); err != nil {
+		warn("journal", err)
+	}
+	if err := uc.collectApprovals(ctx, status, pbiIDs); err != nil {
+		warn("approvals", err)
+	}
+
+	return status, nil
+}
+
+func (uc *ProjectStatusUseCase) collectEPICs(ctx context.Context, status *ProjectStatus) error {
+	if uc.epicRepo == nil {
+		return nil
+	}
+	epics, err := uc.epicRepo.List(ctx, repository.EPICFilter{})
+	if err != nil {
+		return err
+	}
+	for _, e := range epics {
+		status.EPICCounts[e.Status().String()]++
+	}
+	return nil
+}
+
+func (uc *ProjectStatusUseCase) collectPBIs(status *ProjectStatus) ([]string, error) {
+	if uc.pbiRepo == nil {
+		return nil, nil
+	}
+	pbis, err := uc.pbiRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(pbis))
+	for _, p := range pbis {
+		status.PBICounts[string(p.Status)]++
+		ids = append(ids, p.ID)
+	}
+	return ids, nil
+}
+
+func (uc *ProjectStatusUseCase) collectSBIs(ctx context.Context, status *ProjectStatus) error {
+	if uc.sbiRepo == nil {
+		return nil
+	}
+	sbis, err := uc.sbiRepo.List(ctx, repository.SBIFilter{})
+	if err != nil {
+		return err
+	}
+	for _, s := range sbis {
+		status.SBICounts[s.Status().String()]++
+
+		switch s.Status() {
+		case model.StatusPicked, model.StatusImplementing, model.StatusReviewing:
+			turn := 0
+			if execState := s.ExecutionState(); execState != nil {
+				turn = execState.CurrentTurn.Value()
+			}
+			status.InFlight = append(status.InFlight, InFlightSBI{
+				ID:        s.ID().String(),
+				Title:     s.Title(),
+				Status:    s.Status().String(),
+				Turn:      turn,
+				UpdatedAt: s.UpdatedAt().Value(),
+			})
+		case model.StatusFailed:
+			failed := FailedSBI{
+				ID:        s.ID().String(),
+				Title:     s.Title(),
+				UpdatedAt: s.UpdatedAt().Value(),
+			}
+			if execState := s.ExecutionState(); execState != nil {
+				failed.Turn = execState.CurrentTurn.Value()
+				failed.Error = execState.LastError
+			}
+			status.Failed = append(status.Failed, failed)
+		}
+	}
+	sort.SliceStable(status.Failed, func(i, j int) bool {
+		return status.Failed[i].UpdatedAt.After(status.Failed[j].UpdatedAt)
+	})
+	return nil
+}
+
+func (uc *ProjectStatusUseCase) collectLocks(ctx context.Context, status *ProjectStatus) error {
+	if uc.lockService == nil {
+		return nil
+	}
+	runLocks, err := uc.lockService.ListRunLocks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, l := range runLocks {
+		status.Locks = append(status.Locks, LockSummary{
+			ID:        l.LockID().String(),
+			Kind:      "run",
+			PID:       l.PID(),
+			Hostname:  l.Hostname(),
+			ExpiresAt: l.ExpiresAt(),
+			Expired:   l.IsExpired(),
+		})
+	}
+
+	stateLocks, err := uc.lockService.ListStateLocks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, l := range stateLocks {
+		status.Locks = append(status.Locks, LockSummary{
+			ID:        l.LockID().String(),
+			Kind:      "state",
+			PID:       l.PID(),
+			Hostname:  l.Hostname(),
+			ExpiresAt: l.ExpiresAt(),
+			Expired:   l.IsExpired(),
+		})
+	}
+	return nil
+}
+
+func (uc *ProjectStatusUseCase) collectJournal(ctx context.Context, status *ProjectStatus) error {
+	if uc.journalRepo == nil {
+		return nil
+	}
+	records, err := uc.journalRepo.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	since := uc.now().Add(-journalIssueWindow)
+	var issues []JournalTurn
+	for _, record := range records {
+		ts, err := time.Parse(time.RFC3339Nano, record.Timestamp)
+		if err != nil {
+			continue
+		}
+		entry := JournalTurn{
+			Timestamp: ts,
+			SBIID:     record.SBIID,
+			Turn:      record.Turn,
+			Step:      record.Step,
+			Decision:  record.Decision,
+			Error:     record.Error,
+		}
+		if status.LastTurn == nil || ts.After(status.LastTurn.Timestamp) {
+			last := entry
+			status.LastTurn = &last
+		}
+		if record.Error != "" && ts.After(since) {
+			issues = append(issues, entry)
+		}
+	}
+
+	// Newest issues first
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Timestamp.After(issues[j].Timestamp) })
+	status.JournalIssues = len(issues)
+	if len(issues) > maxRecentJournalIssues {
+		issues = issues[:maxRecentJournalIssues]
+	}
+	status.RecentIssues = issues
+	return nil
+}
+
+func (uc *ProjectStatusUseCase) collectApprovals(ctx context.Context, status *ProjectStatus, pbiIDs []string) error {
+	if uc.approvalRepo == nil {
+		return nil
+	}
+	for _, pbiID := range pbiIDs {
+		exists, err := uc.approvalRepo.ManifestExists(ctx, repository.PBIID(pbiID))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		manifest, err := uc.approvalRepo.LoadManifest(ctx, repository.PBIID(pbiID))
+		if err != nil {
+			return fmt.Errorf("%s: %w", pbiID, err)
+		}
+		pending := manifest.PendingCount()
+		unregistered := len(manifest.GetUnregisteredApprovedSBIs())
+		if pending == 0 && unregistered == 0 {
+			continue
+		}
+		status.PendingApprovals = append(status.PendingApprovals, PendingApproval{
+			PBIID:        pbiID,
+			Pending:      pending,
+			Unregistered: unregistered,
+		})
+	}
+	sort.Slice(status.PendingApprovals, func(i, j int) bool {
+		return status.PendingApprovals[i].PBIID < status.PendingApprovals[j].PBIID
+	})
+	return nil
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+type stubJournalRepository struct {
+	repository.JournalRepository
+	records []*repository.JournalRecord
+}
+
+func (s *stubJournalRepository) Load(ctx context.Context) ([]*repository.JournalRecord, error) {
+	return s.records, nil
+}
+
+func (s *stubJournalRepository) FindBySBI(ctx context.Context, sbiID string) ([]*repository.JournalRecord, error) {
+	var records []*repository.JournalRecord
+	for _, record := range s.records {
+		if record.SBIID == sbiID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+type stubPBIRepository struct {
+	pbi.Repository
+	pbis []*pbi.PBI
+}
+
+func (s *stubPBIRepository) FindAll() ([]*pbi.PBI, error) {
+	return s.pbis, nil
+}
+
+type stubApprovalRepository struct {
+	repository.SBIApprovalRepository
+	manifests map[string]*pbi.SBIApprovalManifest
+}
+
+func (s *stubApprovalRepository) ManifestExists(ctx context.Context, pbiID repository.PBIID) (bool, error) {
+	_, ok := s.manifests[string(pbiID)]
+	return ok, nil
+}
+
+func (s *stubApprovalRepository) LoadManifest(ctx context.Context, pbiID repository.PBIID) (*pbi.SBIApprovalManifest, error) {
+	return s.manifests[string(pbiID)], nil
+}
+
+func TestProjectStatusUseCase_Execute(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339Nano) }
+
+	journalRepo := &stubJournalRepository{records: []*repository.JournalRecord{
+		{Timestamp: ts(48 * time.Hour), SBIID: "SBI-OLD", Turn: 1, Step: "implement", Error: "old failure"},
+		{Timestamp: ts(3 * time.Hour), SBIID: "SBI-A", Turn: 2, Step: "review", Error: "agent timeout"},
+		{Timestamp: ts(time.Hour), SBIID: "SBI-B", Turn: 3, Step: "review", Decision: "SUCCEEDED"},
+		{Timestamp: ts(2 * time.Hour), SBIID: "SBI-A", Turn: 3, Step: "implement", Error: "build failed"},
+	}}
+
+	pending := pbi.NewSBIApprovalManifest("PBI-002", []string{"sbi_1.md", "sbi_2.md"})
+	pending.SBIs[0].Status = pbi.ApprovalStatusApproved
+	done := pbi.NewSBIApprovalManifest("PBI-001", []string{"sbi_1.md"})
+	done.SBIs[0].Status = pbi.ApprovalStatusApproved
+	done.SBIs[0].SBIID = "SBI-1"
+
+	uc := NewProjectStatusUseCase(
+		nil,
+		&stubPBIRepository{pbis: []*pbi.PBI{
+			{ID: "PBI-002", Status: pbi.StatusPlaned},
+			{ID: "PBI-001", Status: pbi.StatusDone},
+			{ID: "PBI-003", Status: pbi.StatusPending},
+		}},
+		nil,
+		journalRepo,
+		&stubApprovalRepository{manifests: map[string]*pbi.SBIApprovalManifest{"PBI-001": done, "PBI-002": pending}},
+		nil,
+	)
+	uc.now = func() time.Time { return now }
+
+	status, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"planed": 1, "done": 1, "pending": 1}, status.PBICounts)
+
+	// Last turn is the newest record regardless of journal order
+	require.NotNil(t, status.LastTurn)
+	assert.Equal(t, "SBI-B", status.LastTurn.SBIID)
+
+	// Only errors within 24h are counted, newest first
+	assert.Equal(t, 2, status.JournalIssues)
+	require.Len(t, status.RecentIssues, 2)
+	assert.Equal(t, "build failed", status.RecentIssues[0].Error)
+	assert.Equal(t, "agent timeout", status.RecentIssues[1].Error)
+
+	// Fully registered manifests are not listed
+	assert.Equal(t, []PendingApproval{{PBIID: "PBI-002", Pending: 1, Unregistered: 1}}, status.PendingApprovals)
+	assert.Empty(t, status.Warnings)
+}
+
+type stubSBIRepository struct {
+	repository.SBIRepository
+	sbis []*sbi.SBI
+}
+
+func (s *stubSBIRepository) List(ctx context.Context, filter repository.SBIFilter) ([]*sbi.SBI, error) {
+	if len(filter.Statuses) == 0 {
+		return s.sbis, nil
+	}
+	var sbis []*sbi.SBI
+	for _, task := range s.sbis {
+		for _, status := range filter.Statuses {
+			if task.Status() == status {
+				sbis = append(sbis, task)
+			}
+		}
+	}
+	return sbis, nil
+}
+
+func TestProjectStatusUseCase_ListsFailedSBIs(t *testing.T) {
+	newSBI := func(title string, statuses ...model.Status) *sbi.SBI {
+		task, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{})
+		require.NoError(t, err)
+		for _, next := range statuses {
+			require.NoError(t, task.UpdateStatus(next))
+		}
+		return task
+	}
+	implementing := newSBI("in progress", model.StatusPicked, model.StatusImplementing)
+	failed := newSBI("gave up", model.StatusPicked, model.StatusImplementing, model.StatusFailed)
+	failed.RecordError("Exceeded max turns (8)")
+
+	uc := NewProjectStatusUseCase(nil, nil, &stubSBIRepository{sbis: []*sbi.SBI{implementing, failed, newSBI("queued")}}, nil, nil, nil)
+	status, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"PENDING": 1, "IMPLEMENTING": 1, "FAILED": 1}, status.SBICounts)
+	require.Len(t, status.InFlight, 1)
+	require.Len(t, status.Failed, 1)
+	assert.Equal(t, failed.ID().String(), status.Failed[0].ID)
+	assert.Equal(t, "Exceeded max turns (8)", status.Failed[0].Error)
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// PromptExperimentReportOptions selects the experiments compared by PromptExperimentReportUseCase
+type PromptExperimentReportOptions struct {
+	Experiment string // Only report this experiment (empty for all)
+}
+
+// PromptExperimentReport compares the arms of the prompt experiments found in the journal
+type PromptExperimentReport struct {
+	Experiments []PromptExperimentResult `json:"experiments"` // By name
+}
+
+// PromptExperimentResult is the outcome of one prompt experiment
+type PromptExperimentResult struct {
+	Name     string                `json:"name"`
+	Template string                `json:"template"`
+	Excluded int                   `json:"excluded"` // SBIs that had started before the experiment
+	Arms     []PromptExperimentArm `json:"arms"`     // Control first
+}
+
+// PromptExperimentArm is the outcome of the SBIs assigned to one arm of a prompt experiment
+type PromptExperimentArm struct {
+	Variant        string  `json:"variant"`
+	SBIs           int     `json:"sbis"`
+	Done           int     `json:"done"`
+	Failed         int     `json:"failed"`           // Including force-terminated SBIs
+	TurnsToDone    float64 `json:"turns_to_done"`    // Mean turn at which done SBIs reached DONE
+	Reviews        int     `json:"reviews"`          // Review decisions
+	ReviewsPassed  int     `json:"reviews_passed"`   // SUCCEEDED review decisions
+	ReviewPassRate float64 `json:"review_pass_rate"` // ReviewsPassed / Reviews (0 without reviews)
+}
+
+// PromptExperimentReportUseCase compares the arms of prompt experiments by the outcome of their
+// SBIs for `deespec stats experiment`
+// An SBI takes part in an experiment when its first journal record notes its arm; SBIs that had
+// started before the experiment are excluded, as their early turns ran on the regular template.
+type PromptExperimentReportUseCase struct {
+	journalRepo repository.JournalRepository
+}
+
+// NewPromptExperimentReportUseCase creates a new prompt experiment report use case
+func NewPromptExperimentReportUseCase(journalRepo repository.JournalRepository) *PromptExperimentReportUseCase {
+	return &PromptExperimentReportUseCase{journalRepo: journalRepo}
+}
+
+// experimentSBI is the journaled outcome of one SBI in an experiment
+type experimentSBI struct {
+	variant     string
+	excluded    bool
+	doneTurn    int
+	failed      bool
+	reviews     int
+	reviewsPass int
+}
+
+// Execute compares the arms of the experiments matching opts
+func (uc *PromptExperimentReportUseCase) Execute(ctx context.Context, opts PromptExperimentReportOptions) (*PromptExperimentReport, error) {
+	records, err := uc.journalRepo.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	results := make(map[string]*PromptExperimentResult)
+	sbis := make(map[string]map[string]*experimentSBI) // experiment -> SBI ID -> outcome
+	seen := make(map[string]bool)                      // SBIs with an earlier record
+	for _, record := range records {
+		if record.SBIID == "" {
+			continue
+		}
+		first := !seen[record.SBIID]
+		seen[record.SBIID] = true
+
+		for _, artifact := range record.Artifacts {
+			name, template, variant, ok := experimentArtifact(artifact)
+			if !ok || (opts.Experiment != "" && name != opts.Experiment) {
+				continue
+			}
+			if results[name] == nil {
+				results[name] = &PromptExperimentResult{Name: name, Template: template}
+				sbis[name] = make(map[string]*experimentSBI)
+			}
+			if sbis[name][record.SBIID] == nil {
+				sbis[name][record.SBIID] = &experimentSBI{variant: variant, excluded: !first}
+			}
+		}
+
+		for name := range sbis {
+			outcome := sbis[name][record.SBIID]
+			if outcome == nil {
+				continue
+			}
+			switch record.Decision {
+			case "SUCCEEDED":
+				outcome.reviews++
+				outcome.reviewsPass++
+			case "NEEDS_CHANGES", "FAILED":
+				outcome.reviews++
+			}
+			switch {
+			case record.Status == "DONE" && record.Decision == "FORCE_TERMINATED", record.Status == "FAILED":
+				outcome.failed = true
+			case record.Status == "DONE" && outcome.doneTurn == 0:
+				outcome.doneTurn = record.Turn
+			}
+		}
+	}
+
+	report := &PromptExperimentReport{Experiments: []PromptExperimentResult{}}
+	for name, result := range results {
+		arms := map[string]*PromptExperimentArm{
+			domainservice.PromptVariantControl:   {Variant: domainservice.PromptVariantControl},
+			domainservice.PromptVariantTreatment: {Variant: domainservice.PromptVariantTreatment},
+		}
+		turns := make(map[string]int)
+		for _, outcome := range sbis[name] {
+			if outcome.excluded {
+				result.Excluded++
+				continue
+			}
+			arm, ok := arms[outcome.variant]
+			if !ok {
+				arm = &PromptExperimentArm{Variant: outcome.variant}
+				arms[outcome.variant] = arm
+			}
+			arm.SBIs++
+			arm.Reviews += outcome.reviews
+			arm.ReviewsPassed += outcome.reviewsPass
+			if outcome.failed {
+				arm.Failed++
+			} else if outcome.doneTurn > 0 {
+				arm.Done++
+				turns[outcome.variant] += outcome.doneTurn
+			}
+		}
+		for variant, arm := range arms {
+			if arm.Done > 0 {
+				arm.TurnsToDone = float64(turns[variant]) / float64(arm.Done)
+			}
+			if arm.Reviews > 0 {
+				arm.ReviewPassRate = float64(arm.ReviewsPassed) / float64(arm.Reviews)
+			}
+			result.Arms = append(result.Arms, *arm)
+		}
+		sort.Slice(result.Arms, func(i, j int) bool {
+			a, b := result.Arms[i].Variant, result.Arms[j].Variant
+			if armRank(a) != armRank(b) {
+				return armRank(a) < armRank(b)
+			}
+			return a < b
+		})
+		report.Experiments = append(report.Experiments, *result)
+	}
+	sort.Slice(report.Experiments, func(i, j int) bool {
+		return report.Experiments[i].Name < report.Experiments[j].Name
+	})
+	return report, nil
+}
+
+// experimentArtifact reads the prompt experiment artifact of a journal record
+func experimentArtifact(artifact interface{}) (name, template, variant string, ok bool) {
+	fields, isMap := artifact.(map[string]interface{})
+	if !isMap || fields["type"] != "prompt_experiment" {
+		return "", "", "", false
+	}
+	name, _ = fields["experiment"].(string)
+	template, _ = fields["template"].(string)
+	variant, _ = fields["variant"].(string)
+	return name, template, variant, name != "" && variant != ""
+}
+
+// armRank orders the control arm before the treatment arm
+func armRank(variant string) int {
+	switch variant {
+	case domainservice.PromptVariantControl:
+		return 0
+	case domainservice.PromptVariantTreatment:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func experimentTag(name, variant string) map[string]interface{} {
+	return map[string]interface{}{"type": "prompt_experiment", "experiment": name, "template": "WIP.md", "variant": variant}
+}
+
+func TestPromptExperimentReportUseCase(t *testing.T) {
+	control, treatment := experimentTag("wip-v2", "control"), experimentTag("wip-v2", "treatment")
+	journal := &stubJournalRepository{records: []*repository.JournalRecord{
+		// Control: one SBI done after a rejected review, one force-terminated
+		{SBIID: "SBI-1", Turn: 1, Status: "REVIEW", Decision: "PENDING", Artifacts: []interface{}{"implement_1.md", control}},
+		{SBIID: "SBI-1", Turn: 1, Status: "WIP", Decision: "NEEDS_CHANGES", Artifacts: []interface{}{control}},
+		{SBIID: "SBI-1", Turn: 2, Status: "REVIEW", Decision: "PENDING", Artifacts: []interface{}{control}},
+		{SBIID: "SBI-1", Turn: 2, Status: "DONE", Decision: "SUCCEEDED", Artifacts: []interface{}{control}},
+		{SBIID: "SBI-2", Turn: 1, Status: "REVIEW", Decision: "PENDING", Artifacts: []interface{}{control}},
+		{SBIID: "SBI-2", Turn: 8, Status: "DONE", Decision: "FORCE_TERMINATED", Artifacts: []interface{}{control}},
+		// Treatment: done at the first review
+		{SBIID: "SBI-3", Turn: 1, Status: "REVIEW", Decision: "PENDING", Artifacts: []interface{}{treatment}},
+		{SBIID: "SBI-3", Turn: 1, Status: "DONE", Decision: "SUCCEEDED", Artifacts: []interface{}{treatment}},
+		// Started before the experiment
+		{SBIID: "SBI-4", Turn: 1, Status: "REVIEW", Decision: "PENDING"},
+		{SBIID: "SBI-4", Turn: 1, Status: "DONE", Decision: "SUCCEEDED", Artifacts: []interface{}{treatment}},
+		// Another experiment
+		{SBIID: "SBI-5", Turn: 1, Status: "REVIEW", Decision: "PENDING", Artifacts: []interface{}{experimentTag("review-v1", "control")}},
+	}}
+	uc := NewPromptExperimentReportUseCase(journal)
+
+	report, err := uc.Execute(context.Background(), PromptExperimentReportOptions{Experiment: "wip-v2"})
+	require.NoError(t, err)
+	require.Len(t, report.Experiments, 1)
+	result := report.Experiments[0]
+	assert.Equal(t, "wip-v2", result.Name)
+	assert.Equal(t, "WIP.md", result.Template)
+	assert.Equal(t, 1, result.Excluded)
+	assert.Equal(t, []PromptExperimentArm{
+		{Variant: "control", SBIs: 2, Done: 1, Failed: 1, TurnsToDone: 2, Reviews: 2, ReviewsPassed: 1, ReviewPassRate: 0.5},
+		{Variant: "treatment", SBIs: 1, Done: 1, TurnsToDone: 1, Reviews: 1, ReviewsPassed: 1, ReviewPassRate: 1},
+	}, result.Arms)
+
+	report, err = uc.Execute(context.Background(), PromptExperimentReportOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Experiments, 2)
+	assert.Equal(t, "review-v1", report.Experiments[0].Name)
+	assert.Equal(t, 1, report.Experiments[0].Arms[0].SBIs)
+	assert.Equal(t, 0, report.Experiments[0].Arms[1].SBIs)
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// RebuiltTask is one PBI or SBI restored from its spec file, or a spec that could not be restored
+type RebuiltTask struct {
+	Kind   string `json:"kind"` // "sbi" or "pbi"
+	TaskID string `json:"task_id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	Turn   int    `json:"turn,omitempty"`  // Last journaled turn (SBIs)
+	Error  string `json:"error,omitempty"` // Why the spec could not be restored
+}
+
+// RebuildTasksResult reports what one rebuild restored
+type RebuildTasksResult struct {
+	Tasks []RebuiltTask `json:"tasks"`
+}
+
+// Restored returns the number of tasks restored
+func (r *RebuildTasksResult) Restored() int {
+	count := 0
+	for _, task := range r.Tasks {
+		if task.Error == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// RebuildTasksUseCase restores the PBIs and SBIs missing from the database from .deespec/specs
+// for `deespec db repair`. Tasks still in the database are left untouched:
+//   - specs/pbi/<id>/pbi.md gives a PBI its title and body; it comes back pending
+//   - specs/sbi/<id>/spec.md gives an SBI its title, description, parent PBI and labels, and the
+//     journal its last turn and outcome: DONE, FAILED and CANCELLED SBIs keep their status, the
+//     others come back pending so that the next run picks them up again
+//
+// SBIs are numbered in the order they were first journaled (or their spec written).
+type RebuildTasksUseCase struct {
+	sbiRepo     repository.SBIRepository
+	pbiRepo     pbi.Repository
+	journalRepo repository.JournalRepository // Optional
+	specsSBI    string                       // .deespec/specs/sbi
+	specsPBI    string                       // .deespec/specs/pbi
+}
+
+// NewRebuildTasksUseCase creates a new RebuildTasksUseCase
+func NewRebuildTasksUseCase(
+	sbiRepo repository.SBIRepository,
+	pbiRepo pbi.Repository,
+	journalRepo repository.JournalRepository,
+	specsSBI, specsPBI string,
+) *RebuildTasksUseCase {
+	return &RebuildTasksUseCase{
+		sbiRepo:     sbiRepo,
+		pbiRepo:     pbiRepo,
+		journalRepo: journalRepo,
+		specsSBI:    specsSBI,
+		specsPBI:    specsPBI,
+	}
+}
+
+// sbiSpec is an SBI spec to restore with what the journal knows about it
+type sbiSpec struct {
+	id       string
+	path     string
+	since    time.Time // First journal record, or the spec's modification time
+	journals []*repository.JournalRecord
+}
+
+// Execute restores the missing PBIs first, so that restored SBIs find their parents, then the SBIs
+func (uc *RebuildTasksUseCase) Execute(ctx context.Context) (*RebuildTasksResult, error) {
+	result := &RebuildTasksResult{Tasks: []RebuiltTask{}}
+	if err := uc.rebuildPBIs(result); err != nil {
+		return result, err
+	}
+	if err := uc.rebuildSBIs(ctx, result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// rebuildPBIs restores the PBIs whose pbi.md has no database record
+func (uc *RebuildTasksUseCase) rebuildPBIs(result *RebuildTasksResult) error {
+	dirs, err := specDirs(uc.specsPBI, "pbi.md")
+	if err != nil {
+		return err
+	}
+	for _, id := range dirs {
+		exists, err := uc.pbiRepo.Exists(id)
+		if err != nil {
+			return fmt.Errorf("failed to check PBI %s: %w", id, err)
+		}
+		if exists {
+			continue
+		}
+
+		task := RebuiltTask{Kind: "pbi", TaskID: id}
+		path := filepath.Join(uc.specsPBI, id, "pbi.md")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		title, _, _, err := parseSpecMarkdown(string(data))
+		if err != nil {
+			task.Error = err.Error()
+			result.Tasks = append(result.Tasks, task)
+			continue
+		}
+
+		p := pbi.NewPBI(title)
+		p.ID = id
+		if info, err := os.Stat(path); err == nil {
+			p.CreatedAt = info.ModTime()
+		}
+		if err := uc.pbiRepo.Save(p, string(data)); err != nil {
+			return fmt.Errorf("failed to restore PBI %s: %w", id, err)
+		}
+		task.Title = title
+		task.Status = string(p.Status)
+		result.Tasks = append(result.Tasks, task)
+	}
+	return nil
+}
+
+// rebuildSBIs restores the SBIs whose spec.md has no database record
+func (uc *RebuildTasksUseCase) rebuildSBIs(ctx context.Context, result *RebuildTasksResult) error {
+	dirs, err := specDirs(uc.specsSBI, "spec.md")
+	if err != nil {
+		return err
+	}
+	existing, err := uc.sbiRepo.List(ctx, repository.SBIFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list SBIs: %w", err)
+	}
+	known := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		known[s.ID().String()] = true
+	}
+	journals, err := uc.journalBySBI(ctx)
+	if err != nil {
+		return err
+	}
+
+	var specs []sbiSpec
+	for _, id := range dirs {
+		if known[id] {
+			continue
+		}
+		spec := sbiSpec{id: id, path: filepath.Join(uc.specsSBI, id, "spec.md"), journals: journals[id]}
+		if info, err := os.Stat(spec.path); err == nil {
+			spec.since = info.ModTime()
+		}
+		if len(spec.journals) > 0 {
+			if t, err := time.Parse(time.RFC3339Nano, spec.journals[0].Timestamp); err == nil {
+				spec.since = t
+			}
+		}
+		specs = append(specs, spec)
+	}
+	sort.SliceStable(specs, func(i, j int) bool { return specs[i].since.Before(specs[j].since) })
+
+	for _, spec := range specs {
+		task, err := uc.rebuildSBI(ctx, spec)
+		if err != nil {
+			return err
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+	return nil
+}
+
+// rebuildSBI restores one SBI from its spec and journal records
+func (uc *RebuildTasksUseCase) rebuildSBI(ctx context.Context, spec sbiSpec) (RebuiltTask, error) {
+	task := RebuiltTask{Kind: "sbi", TaskID: spec.id}
+	data, err := os.ReadFile(spec.path)
+	if err != nil {
+		return task, fmt.Errorf("failed to read %s: %w", spec.path, err)
+	}
+	title, body, metadata, err := parseSpecMarkdown(string(data))
+	if err != nil {
+		task.Error = err.Error()
+		return task, nil
+	}
+	task.Title = title
+	id, err := model.NewTaskIDFromString(spec.id)
+	if err != nil {
+		task.Error = err.Error()
+		return task, nil
+	}
+
+	var parent *model.TaskID
+	if parentID := metadata["Parent PBI"]; parentID != "" {
+		if exists, err := uc.pbiRepo.Exists(parentID); err == nil && exists {
+			if pid, err := model.NewTaskIDFromString(parentID); err == nil {
+				parent = &pid
+			}
+		}
+	}
+
+	status, step := model.StatusPending, model.StepPick
+	execution := &sbi.ExecutionState{
+		CurrentTurn:    model.NewTurn(),
+		CurrentAttempt: model.NewAttempt(),
+		MaxTurns:       10,
+		MaxAttempts:    3,
+		ArtifactPaths:  []string{},
+	}
+	sequence, err := uc.sbiRepo.GetNextSequence(ctx)
+	if err != nil {
+		return task, fmt.Errorf("failed to get next sequence: %w", err)
+	}
+	// Dependencies come back with the salvaged rows, not from the spec: their SBIs may not be restored yet
+	sbiMetadata := specSBIMetadata(metadata)
+	sbiMetadata.DependsOn = nil
+	sbiMetadata.Sequence = sequence
+	sbiMetadata.RegisteredAt = spec.since
+
+	if n := len(spec.journals); n > 0 {
+		last := spec.journals[n-1]
+		turn := 0
+		for _, record := range spec.journals {
+			turn = max(turn, record.Turn)
+		}
+		if t, err := model.NewTurnFromInt(turn); err == nil {
+			execution.CurrentTurn = t
+		}
+		started := spec.since
+		sbiMetadata.StartedAt = &started
+		switch outcome := model.Status(last.Status); outcome {
+		case model.StatusDone, model.StatusFailed, model.StatusCancelled:
+			status, step = outcome, model.StepDone
+			if completed, err := time.Parse(time.RFC3339Nano, last.Timestamp); err == nil {
+				sbiMetadata.CompletedAt = &completed
+			}
+			execution.LastError = last.Error
+		}
+		task.Turn = turn
+	}
+
+	restored := sbi.ReconstructSBI(id, title, body, status, step, parent, sbiMetadata, execution, spec.since, time.Now())
+	if err := uc.sbiRepo.Save(ctx, restored); err != nil {
+		return task, fmt.Errorf("failed to restore SBI %s: %w", spec.id, err)
+	}
+	task.Status = string(status)
+	return task, nil
+}
+
+// journalBySBI returns the journal records of each SBI, oldest first
+func (uc *RebuildTasksUseCase) journalBySBI(ctx context.Context) (map[string][]*repository.JournalRecord, error) {
+	bySBI := make(map[string][]*repository.JournalRecord)
+	if uc.journalRepo == nil {
+		return bySBI, nil
+	}
+	records, err := uc.journalRepo.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load journal: %w", err)
+	}
+	for _, record := range records {
+		if record.SBIID != "" {
+			bySBI[record.SBIID] = append(bySBI[record.SBIID], record)
+		}
+	}
+	return bySBI, nil
+}
+
+// specDirs returns the sorted names of the task directories below dir holding the file name
+func specDirs(dir, name string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), name)); err == nil {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
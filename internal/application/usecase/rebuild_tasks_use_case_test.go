@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// recordingSBIRepository keeps the SBIs saved through it
+type recordingSBIRepository struct {
+	stubSBIRepository
+	saved []*sbi.SBI
+}
+
+func (r *recordingSBIRepository) Save(ctx context.Context, task *sbi.SBI) error {
+	r.saved = append(r.saved, task)
+	return nil
+}
+
+func (r *recordingSBIRepository) GetNextSequence(ctx context.Context) (int, error) {
+	return len(r.sbis) + len(r.saved) + 1, nil
+}
+
+func TestRebuildTasksUseCase(t *testing.T) {
+	specs := t.TempDir()
+	specsSBI, specsPBI := filepath.Join(specs, "sbi"), filepath.Join(specs, "pbi")
+	writeSpec(t, filepath.Join(specsPBI, "PBI-001", "pbi.md"), "# Checkout\n\nPay for the cart.\n")
+	writeSpec(t, filepath.Join(specsSBI, "SBI-KEPT", "spec.md"), "# Still in the database\n")
+	writeSpec(t, filepath.Join(specsSBI, "SBI-DONE", "spec.md"), "## Guidelines\n\n# Payment API\n\nCharge the card.\n\n---\nParent PBI: PBI-001\nLabels: backend, api\n")
+	writeSpec(t, filepath.Join(specsSBI, "SBI-WIP", "spec.md"), "# Receipt email\n\nSend a receipt.\n")
+	writeSpec(t, filepath.Join(specsSBI, "SBI-BAD", "spec.md"), "No title here\n")
+
+	kept, err := sbi.NewSBI("Still in the database", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	keptID, err := model.NewTaskIDFromString("SBI-KEPT")
+	require.NoError(t, err)
+	kept = sbi.ReconstructSBI(keptID, kept.Title(), "", kept.Status(), kept.CurrentStep(), nil, kept.Metadata(), kept.ExecutionState(), kept.CreatedAt().Value(), kept.UpdatedAt().Value())
+	sbiRepo := &recordingSBIRepository{stubSBIRepository: stubSBIRepository{sbis: []*sbi.SBI{kept}}}
+	pbiRepo := &stubPBIRepository{}
+	journal := &stubJournalRepository{records: []*repository.JournalRecord{
+		{Timestamp: "2026-01-01T09:00:00Z", SBIID: "SBI-WIP", Turn: 1, Step: "pick", Status: "WIP"},
+		{Timestamp: "2026-01-02T09:00:00Z", SBIID: "SBI-DONE", Turn: 1, Step: "pick", Status: "WIP"},
+		{Timestamp: "2026-01-02T10:00:00Z", SBIID: "SBI-WIP", Turn: 2, Step: "review", Status: "REVIEW"},
+		{Timestamp: "2026-01-02T11:00:00Z", SBIID: "SBI-DONE", Turn: 3, Step: "done", Status: "DONE"},
+	}}
+
+	uc := NewRebuildTasksUseCase(sbiRepo, pbiRepo, journal, specsSBI, specsPBI)
+	result, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, pbiRepo.pbis, 1)
+	assert.Equal(t, "PBI-001", pbiRepo.pbis[0].ID)
+	assert.Equal(t, "Checkout", pbiRepo.pbis[0].Title)
+	assert.Equal(t, pbi.StatusPending, pbiRepo.pbis[0].Status)
+
+	require.Len(t, sbiRepo.saved, 2, "only the SBIs missing from the database are restored")
+	wip, done := sbiRepo.saved[0], sbiRepo.saved[1]
+	assert.Equal(t, "SBI-WIP", wip.ID().String(), "SBIs are restored in the order they were first journaled")
+	assert.Equal(t, model.StatusPending, wip.Status(), "unfinished SBIs are picked up again")
+	assert.Equal(t, 2, wip.ExecutionState().CurrentTurn.Value())
+
+	assert.Equal(t, "Payment API", done.Title())
+	assert.Equal(t, "Charge the card.", done.Description())
+	assert.Equal(t, model.StatusDone, done.Status())
+	assert.Equal(t, 3, done.ExecutionState().CurrentTurn.Value())
+	assert.Equal(t, []string{"backend", "api"}, done.Metadata().Labels)
+	require.NotNil(t, done.ParentTaskID())
+	assert.Equal(t, "PBI-001", done.ParentTaskID().String())
+	require.NotNil(t, done.CompletedAt())
+	assert.Greater(t, done.Sequence(), wip.Sequence())
+
+	assert.Equal(t, 3, result.Restored())
+	var bad *RebuiltTask
+	for i := range result.Tasks {
+		if result.Tasks[i].TaskID == "SBI-BAD" {
+			bad = &result.Tasks[i]
+		}
+	}
+	require.NotNil(t, bad)
+	assert.Equal(t, "no H1 title found", bad.Error)
+}
@@ -4,20 +4,31 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"path"
 	"strings"
 	"time"
 
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
 	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
 )
 
 // ReportSBIUseCase handles implementation and review report submission from AI agents
 type ReportSBIUseCase struct {
-	sbiRepo     repository.SBIRepository
-	journalRepo repository.JournalRepository
-	execLogRepo repository.SBIExecLogRepository
+	sbiRepo       repository.SBIRepository
+	journalRepo   repository.JournalRepository
+	execLogRepo   repository.SBIExecLogRepository
+	feedbackRepo  repository.ReviewFeedbackRepository
+	criteriaRepo  repository.AcceptanceCriteriaRepository
+	artifactStore output.ArtifactStore
+	voteRepo      repository.ReviewVoteRepository  // Optional: reviewer votes in review quorum mode
+	issueRepo     repository.ReviewIssueRepository // Optional: review issues tracked across turns
+
+	// allowInProgressTurn also accepts reports for the stored turn + 1 (simulation mode)
+	allowInProgressTurn bool
 }
 
 // NewReportSBIUseCase creates a new ReportSBIUseCase
@@ -25,136 +36,189 @@ func NewReportSBIUseCase(
 	sbiRepo repository.SBIRepository,
 	journalRepo repository.JournalRepository,
 	execLogRepo repository.SBIExecLogRepository,
+	feedbackRepo repository.ReviewFeedbackRepository,
+	criteriaRepo repository.AcceptanceCriteriaRepository,
+	artifactStore output.ArtifactStore,
 ) *ReportSBIUseCase {
 	return &ReportSBIUseCase{
-		sbiRepo:     sbiRepo,
-		journalRepo: journalRepo,
-		execLogRepo: execLogRepo,
+		sbiRepo:       sbiRepo,
+		journalRepo:   journalRepo,
+		execLogRepo:   execLogRepo,
+		feedbackRepo:  feedbackRepo,
+		criteriaRepo:  criteriaRepo,
+		artifactStore: artifactStore,
 	}
 }
 
-// Execute processes a report (implement or review) and updates SBI status accordingly
-func (uc *ReportSBIUseCase) Execute(ctx context.Context, sbiID string, turn int, step string, decision string, content string) error {
-	// 1. Load SBI from database
-	sbi, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
-	if err != nil {
-		return fmt.Errorf("failed to find SBI: %w", err)
-	}
-	if sbi == nil {
-		return fmt.Errorf("SBI not found: %s", sbiID)
-	}
+// SetAllowInProgressTurn accepts reports numbered as the in-progress step (stored turn + 1)
+// in addition to the stored turn. Used by simulation mode, whose scripted agent reports
+// from inside the running step, before the workflow advances the stored turn.
+func (uc *ReportSBIUseCase) SetAllowInProgressTurn(allow bool) {
+	uc.allowInProgressTurn = allow
+}
 
-	// 2. Validate turn number
-	execState := sbi.ExecutionState()
-	if execState == nil {
-		return fmt.Errorf("SBI %s has no execution state", sbiID)
-	}
+// SetReviewVoteRepository enables recording reviewer votes in review quorum mode
+func (uc *ReportSBIUseCase) SetReviewVoteRepository(repo repository.ReviewVoteRepository) {
+	uc.voteRepo = repo
+}
 
-	currentTurn := execState.CurrentTurn.Value()
-	if turn != currentTurn {
-		return fmt.Errorf(
-			"turn mismatch: SBI is at turn %d, but report is for turn %d",
-			currentTurn, turn,
-		)
-	}
+// SetReviewIssueRepository enables tracking review issues across turns
+// Reviews then update the status of the open issues, and DONE is blocked while blocking issues are open.
+func (uc *ReportSBIUseCase) SetReviewIssueRepository(repo repository.ReviewIssueRepository) {
+	uc.issueRepo = repo
+}
 
-	// 3. Determine report directory and filename
-	reportDir := filepath.Join(".deespec", "reports", "sbi", sbiID)
-	var filename string
+// Execute processes a report (implement or review) and updates SBI status accordingly
+func (uc *ReportSBIUseCase) Execute(ctx context.Context, sbiID string, turn int, step string, decision string, content string) error {
+	// 1-7. Load, update and save the SBI, again from the stored state when it was saved meanwhile
+	// (e.g. by the running turn); the turn and status checks are repeated against that state
+	var (
+		attempt          int
+		reportPath       string
+		promptReportPath string
+		previousStatus   model.Status
+		nextStatus       model.Status
+		unmetCriteria    []*repository.AcceptanceCriterion
+		trackedIssues    []*repository.ReviewIssue
+	)
+	err := service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+		// 1. Load SBI from database
+		sbi, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+		if err != nil {
+			return fmt.Errorf("failed to find SBI: %w", err)
+		}
+		if sbi == nil {
+			return fmt.Errorf("SBI %w: %s", model.ErrNotFound, sbiID)
+		}
 
-	switch step {
-	case "implement":
-		filename = fmt.Sprintf("implement_%d.md", turn)
-	case "review":
-		filename = fmt.Sprintf("review_%d.md", turn)
-	default:
-		return fmt.Errorf("unsupported step type: %s (must be 'implement' or 'review')", step)
-	}
+		// 2. Validate turn number
+		execState := sbi.ExecutionState()
+		if execState == nil {
+			return fmt.Errorf("SBI %s has no execution state", sbiID)
+		}
+		attempt = execState.CurrentAttempt.Value()
 
-	// 4. Create report directory if it doesn't exist
-	if err := os.MkdirAll(reportDir, 0755); err != nil {
-		return fmt.Errorf("failed to create report directory: %w", err)
-	}
+		if err := uc.validateTurn(execState.CurrentTurn.Value(), turn); err != nil {
+			return err
+		}
 
-	// 5. Write report content to file
-	reportPath := filepath.Join(reportDir, filename)
-	if err := os.WriteFile(reportPath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write report file: %w", err)
-	}
+		// 3. Determine report key and filename
+		var filename string
 
-	// 6. Update SBI status based on step and decision
-	previousStatus := sbi.Status()
-	var nextStatus model.Status
+		switch step {
+		case "implement":
+			filename = fmt.Sprintf("implement_%d.md", turn)
+		case "review":
+			filename = fmt.Sprintf("review_%d.md", turn)
+		default:
+			return fmt.Errorf("unsupported step type: %s (must be 'implement' or 'review')", step)
+		}
 
-	switch step {
-	case "implement":
-		// IMPLEMENTING → REVIEWING or DONE (depending on only_implement flag)
-		if previousStatus != model.StatusImplementing {
-			return fmt.Errorf("invalid status for implement report: expected IMPLEMENTING, got %s", previousStatus)
+		// 4-5. Store report content
+		// The URI (s3://, gs:// when offloaded) is recorded in the exec log and journal;
+		// prompts reference the local working copy, which agents can actually read
+		reportKey := path.Join("reports", "sbi", sbiID, filename)
+		reportPath, err = uc.artifactStore.Put(ctx, reportKey, []byte(content))
+		if err != nil {
+			return fmt.Errorf("failed to store report: %w", err)
 		}
+		promptReportPath = uc.artifactStore.LocalPath(reportKey)
+
+		// 6. Update SBI status based on step and decision
+		previousStatus = sbi.Status()
 
-		// Check only_implement flag to determine next status
-		if sbi.OnlyImplement() {
-			// Skip review phase for SBIs with parent PBI (only_implement=true)
-			nextStatus = model.StatusDone
-			if err := sbi.UpdateStatus(model.StatusDone); err != nil {
-				return fmt.Errorf("failed to update status to DONE: %w", err)
+		switch step {
+		case "implement":
+			// IMPLEMENTING → REVIEWING or DONE (depending on only_implement flag)
+			if previousStatus != model.StatusImplementing {
+				return fmt.Errorf("%w: implement report expects IMPLEMENTING, got %s", model.ErrInvalidState, previousStatus)
 			}
-			sbi.MarkAsCompleted()
-			fmt.Printf("✅ Implementation completed, marked as DONE (only_implement=true) (SBI: %s, Turn: %d)\n", sbiID, turn)
-		} else {
-			// Normal workflow: move to review phase
-			nextStatus = model.StatusReviewing
-			if err := sbi.UpdateStatus(model.StatusReviewing); err != nil {
-				return fmt.Errorf("failed to update status to REVIEWING: %w", err)
+
+			// Check only_implement flag to determine next status
+			if sbi.OnlyImplement() {
+				// Skip review phase for SBIs with parent PBI (only_implement=true)
+				nextStatus = model.StatusDone
+				if err := sbi.UpdateStatus(model.StatusDone); err != nil {
+					return fmt.Errorf("failed to update status to DONE: %w", err)
+				}
+				sbi.MarkAsCompleted()
+				fmt.Printf("✅ Implementation completed, marked as DONE (only_implement=true) (SBI: %s, Turn: %d)\n", sbiID, turn)
+			} else {
+				// Normal workflow: move to review phase
+				nextStatus = model.StatusReviewing
+				if err := sbi.UpdateStatus(model.StatusReviewing); err != nil {
+					return fmt.Errorf("failed to update status to REVIEWING: %w", err)
+				}
+				fmt.Printf("✅ Implementation completed, moving to review (SBI: %s, Turn: %d)\n", sbiID, turn)
 			}
-			fmt.Printf("✅ Implementation completed, moving to review (SBI: %s, Turn: %d)\n", sbiID, turn)
-		}
 
-	case "review":
-		// Validate decision for review step
-		if decision == "" {
-			return fmt.Errorf("decision is required for review step")
-		}
+		case "review":
+			// Validate decision for review step
+			if decision == "" {
+				return fmt.Errorf("decision is required for review step")
+			}
 
-		// Validate status
-		if previousStatus != model.StatusReviewing {
-			return fmt.Errorf("invalid status for review report: expected REVIEWING, got %s", previousStatus)
-		}
+			// Validate status
+			if previousStatus != model.StatusReviewing {
+				return fmt.Errorf("%w: review report expects REVIEWING, got %s", model.ErrInvalidState, previousStatus)
+			}
 
-		switch decision {
-		case "SUCCEEDED":
-			// REVIEWING → DONE (review passed)
-			nextStatus = model.StatusDone
-			if err := sbi.UpdateStatus(model.StatusDone); err != nil {
-				return fmt.Errorf("failed to update status to DONE: %w", err)
+			// DONE is blocked until every acceptance criterion passes or is waived
+			unmetCriteria = uc.evaluateAcceptanceCriteria(ctx, sbiID, turn, content)
+			if decision == "SUCCEEDED" && len(unmetCriteria) > 0 {
+				fmt.Printf("⚠️  %d acceptance criteria not met, downgrading review to NEEDS_CHANGES (SBI: %s)\n",
+					len(unmetCriteria), sbiID)
+				decision = "NEEDS_CHANGES"
 			}
-			// Record work completion time
-			sbi.MarkAsCompleted()
-			fmt.Printf("✅ SBI %s marked as DONE (turn %d review: SUCCEEDED)\n", sbiID, turn)
-
-		case "NEEDS_CHANGES", "FAILED":
-			// REVIEWING → IMPLEMENTING (needs another turn)
-			nextStatus = model.StatusImplementing
-			if err := sbi.UpdateStatus(model.StatusImplementing); err != nil {
-				return fmt.Errorf("failed to update status to IMPLEMENTING: %w", err)
+
+			// ... and until every blocking review issue is addressed or won't be fixed
+			trackedIssues = uc.updateReviewIssues(ctx, sbiID, turn, content)
+			if openBlocking := countOpenBlocking(trackedIssues); decision == "SUCCEEDED" && openBlocking > 0 {
+				fmt.Printf("⚠️  %d blocking review issues still open, downgrading review to NEEDS_CHANGES (SBI: %s)\n",
+					openBlocking, sbiID)
+				decision = "NEEDS_CHANGES"
+			}
+
+			switch decision {
+			case "SUCCEEDED":
+				// REVIEWING → DONE (review passed)
+				nextStatus = model.StatusDone
+				if err := sbi.UpdateStatus(model.StatusDone); err != nil {
+					return fmt.Errorf("failed to update status to DONE: %w", err)
+				}
+				// Record work completion time
+				sbi.MarkAsCompleted()
+				fmt.Printf("✅ SBI %s marked as DONE (turn %d review: SUCCEEDED)\n", sbiID, turn)
+
+			case "NEEDS_CHANGES", "FAILED":
+				// REVIEWING → IMPLEMENTING (needs another turn)
+				nextStatus = model.StatusImplementing
+				if err := sbi.UpdateStatus(model.StatusImplementing); err != nil {
+					return fmt.Errorf("failed to update status to IMPLEMENTING: %w", err)
+				}
+				// Increment turn for next implementation cycle
+				sbi.IncrementTurn()
+				fmt.Printf("🔄 SBI %s moved to next turn (turn %d → %d, review: %s)\n",
+					sbiID, turn, turn+1, decision)
+
+			default:
+				return fmt.Errorf("invalid decision: %s (must be SUCCEEDED, NEEDS_CHANGES, or FAILED)", decision)
 			}
-			// Increment turn for next implementation cycle
-			sbi.IncrementTurn()
-			fmt.Printf("🔄 SBI %s moved to next turn (turn %d → %d, review: %s)\n",
-				sbiID, turn, turn+1, decision)
 
 		default:
-			return fmt.Errorf("invalid decision: %s (must be SUCCEEDED, NEEDS_CHANGES, or FAILED)", decision)
+			return fmt.Errorf("unsupported step: %s", step)
 		}
 
-	default:
-		return fmt.Errorf("unsupported step: %s", step)
-	}
-
-	// 7. Save SBI to database
-	if err := uc.sbiRepo.Save(ctx, sbi); err != nil {
-		return fmt.Errorf("failed to save SBI: %w", err)
+		// 7. Save SBI to database
+		// With a lock fence in ctx (inherited by `sbi report` from the runner), a stale holder's
+		// save is rejected here, so the exec log, feedback and journal writes below are skipped too
+		if err := uc.sbiRepo.Save(ctx, sbi); err != nil {
+			return fmt.Errorf("failed to save SBI: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// 8. Record execution log
@@ -178,18 +242,44 @@ func (uc *ReportSBIUseCase) Execute(ctx context.Context, sbiID string, turn int,
 		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: %s\n", sbiID, turn, step)
 	}
 
-	// 9. Write journal entry for audit trail
+	// 9. Carry over review issues to the next implement turn
+	if step == "review" && (decision == "NEEDS_CHANGES" || decision == "FAILED") && uc.feedbackRepo != nil {
+		issues := domainservice.ExtractNewReviewIssues(content)
+		for _, c := range unmetCriteria {
+			issues = append(issues, fmt.Sprintf("AC-%d not met: %s", c.Position, c.Description))
+		}
+		feedback := &repository.ReviewFeedback{
+			SBIID:      sbiID,
+			Turn:       turn,
+			Decision:   decision,
+			Issues:     issues,
+			ReportPath: promptReportPath,
+		}
+		if err := uc.feedbackRepo.Save(ctx, feedback); err != nil {
+			// Log warning but don't fail - the next turn can still read the report file
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to save review feedback\n")
+			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d\n", sbiID, turn)
+		}
+	}
+
+	// 9b. Track the issues the review raised until a later review closes them
+	if step == "review" {
+		uc.recordReviewIssues(ctx, sbiID, turn, decision, content, trackedIssues)
+	}
+
+	// 10. Write journal entry for audit trail
 	journalRecord := &repository.JournalRecord{
 		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
 		SBIID:     sbiID,
 		Turn:      turn,
 		Step:      fmt.Sprintf("report_%s", step),
 		Status:    string(nextStatus),
-		Attempt:   execState.CurrentAttempt.Value(),
+		Attempt:   attempt,
 		Decision:  decision,
 		ElapsedMs: 0, // Command execution, not agent execution
 		Error:     "",
-		Artifacts: []interface{}{filename},
+		Artifacts: []interface{}{reportPath},
 	}
 
 	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
@@ -199,7 +289,7 @@ func (uc *ReportSBIUseCase) Execute(ctx context.Context, sbiID string, turn int,
 		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Step: %s\n", sbiID, turn, step)
 	}
 
-	// 10. Log report submission with version info
+	// 11. Log report submission with version info
 	version := buildinfo.GetVersion()
 	currentTime := time.Now().Format("2006-01-02 15:04:05")
 	fmt.Fprintf(os.Stderr, "[report] SBI=%s, Step=%s, Decision=%s, Turn=%d, Time=%s, Version=%s, Transition=%s→%s\n",
@@ -210,3 +300,214 @@ func (uc *ReportSBIUseCase) Execute(ctx context.Context, sbiID string, turn int,
 
 	return nil
 }
+
+// SubmitReviewVote records one reviewer's review in review quorum mode
+// The report is stored as review_<turn>_<reviewer>.md and the SBI status is left unchanged;
+// the workflow aggregates the votes of all reviewers into the turn's review decision
+func (uc *ReportSBIUseCase) SubmitReviewVote(ctx context.Context, sbiID string, turn int, reviewer string, decision string, content string) error {
+	if uc.voteRepo == nil {
+		return fmt.Errorf("review votes are not supported without a vote repository")
+	}
+	if err := domainservice.ValidateReviewerName(reviewer); err != nil {
+		return err
+	}
+	switch decision {
+	case "SUCCEEDED", "NEEDS_CHANGES", "FAILED":
+	default:
+		return fmt.Errorf("invalid decision: %s (must be SUCCEEDED, NEEDS_CHANGES, or FAILED)", decision)
+	}
+
+	sbi, err := uc.sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return fmt.Errorf("failed to find SBI: %w", err)
+	}
+	if sbi == nil {
+		return fmt.Errorf("SBI %w: %s", model.ErrNotFound, sbiID)
+	}
+	execState := sbi.ExecutionState()
+	if execState == nil {
+		return fmt.Errorf("SBI %s has no execution state", sbiID)
+	}
+	if err := uc.validateTurn(execState.CurrentTurn.Value(), turn); err != nil {
+		return err
+	}
+	if sbi.Status() != model.StatusReviewing {
+		return fmt.Errorf("%w: review vote expects REVIEWING, got %s", model.ErrInvalidState, sbi.Status())
+	}
+
+	reportKey := path.Join("reports", "sbi", sbiID, fmt.Sprintf("review_%d_%s.md", turn, reviewer))
+	reportPath, err := uc.artifactStore.Put(ctx, reportKey, []byte(content))
+	if err != nil {
+		return fmt.Errorf("failed to store report: %w", err)
+	}
+
+	vote := &repository.ReviewVote{
+		SBIID:      sbiID,
+		Turn:       turn,
+		Reviewer:   reviewer,
+		Decision:   decision,
+		ReportPath: reportPath,
+	}
+	if err := uc.voteRepo.Save(ctx, vote); err != nil {
+		return fmt.Errorf("failed to save review vote: %w", err)
+	}
+
+	journalRecord := &repository.JournalRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		SBIID:     sbiID,
+		Turn:      turn,
+		Step:      fmt.Sprintf("report_review_vote_%s", reviewer), // One entry per reviewer and turn
+		Status:    string(sbi.Status()),
+		Attempt:   execState.CurrentAttempt.Value(),
+		Decision:  decision,
+		ElapsedMs: 0,
+		Error:     "",
+		Artifacts: []interface{}{reportPath},
+	}
+	if err := uc.journalRepo.Append(ctx, journalRecord); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to append journal entry\n")
+		fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d, Reviewer: %s\n", sbiID, turn, reviewer)
+	}
+
+	fmt.Printf("✅ Review vote submitted: %s (SBI: %s, Turn: %d, Reviewer: %s, Decision: %s)\n",
+		reportPath, sbiID, turn, reviewer, decision)
+	return nil
+}
+
+// validateTurn checks that a report is numbered as the SBI's stored turn
+// (or the in-progress turn when allowed)
+func (uc *ReportSBIUseCase) validateTurn(currentTurn, turn int) error {
+	inProgressTurn := uc.allowInProgressTurn && turn == currentTurn+1
+	if turn != currentTurn && !inProgressTurn {
+		return fmt.Errorf(
+			"turn mismatch: SBI is at turn %d, but report is for turn %d",
+			currentTurn, turn,
+		)
+	}
+	return nil
+}
+
+// evaluateAcceptanceCriteria records the reviewer's "AC-<n>: PASS|FAIL" verdicts and returns
+// the criteria that are still neither passed nor waived
+// Waived criteria are never overwritten by a review
+func (uc *ReportSBIUseCase) evaluateAcceptanceCriteria(ctx context.Context, sbiID string, turn int, content string) []*repository.AcceptanceCriterion {
+	if uc.criteriaRepo == nil {
+		return nil
+	}
+
+	criteria, err := uc.criteriaRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		// Log warning but don't block the review - the checklist is advisory when unavailable
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load acceptance criteria: %v\n", err)
+		return nil
+	}
+
+	results := domainservice.ParseCriteriaResults(content)
+	var unmet []*repository.AcceptanceCriterion
+	for _, c := range criteria {
+		if result, ok := results[c.Position]; ok && c.Status != repository.CriterionWaived {
+			status := repository.CriterionFailed
+			if result.Passed {
+				status = repository.CriterionPassed
+			}
+			if err := uc.criteriaRepo.UpdateStatus(ctx, sbiID, c.Position, status, result.Note, turn); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to update AC-%d: %v\n", c.Position, err)
+			}
+			c.Status = status
+			c.Note = result.Note
+		}
+		if !c.Status.IsSatisfied() {
+			unmet = append(unmet, c)
+		}
+	}
+
+	return unmet
+}
+
+// updateReviewIssues records the reviewer's "ISSUE-<n>: ADDRESSED|OPEN|WONTFIX" updates and
+// returns the SBI's tracked issues with their new status
+func (uc *ReportSBIUseCase) updateReviewIssues(ctx context.Context, sbiID string, turn int, content string) []*repository.ReviewIssue {
+	if uc.issueRepo == nil {
+		return nil
+	}
+
+	issues, err := uc.issueRepo.FindBySBIID(ctx, sbiID)
+	if err != nil {
+		// Log warning but don't block the review - issue tracking is advisory when unavailable
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to load review issues: %v\n", err)
+		return nil
+	}
+
+	updates := domainservice.ParseIssueUpdates(content)
+	for _, issue := range issues {
+		update, ok := updates[issue.Number]
+		if !ok {
+			continue
+		}
+		status := repository.ReviewIssueStatus(update.Status)
+		if status == issue.Status && update.Note == issue.Note {
+			continue
+		}
+		if err := uc.issueRepo.UpdateStatus(ctx, sbiID, issue.Number, status, update.Note, turn); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to update ISSUE-%d: %v\n", issue.Number, err)
+			continue
+		}
+		issue.Status = status
+		issue.Note = update.Note
+		issue.Turn = turn
+	}
+
+	return issues
+}
+
+// recordReviewIssues adds the issues a review raised that are not open already
+// Issues raised by a passing review, and those tagged as nits or suggestions, are not blocking.
+func (uc *ReportSBIUseCase) recordReviewIssues(ctx context.Context, sbiID string, turn int, decision, content string, tracked []*repository.ReviewIssue) {
+	if uc.issueRepo == nil {
+		return
+	}
+
+	known := make(map[string]bool, len(tracked))
+	for _, issue := range tracked {
+		if !issue.Status.IsClosed() {
+			known[issue.Description] = true
+		}
+	}
+	var issues []*repository.ReviewIssue
+	for _, description := range domainservice.ExtractNewReviewIssues(content) {
+		if known[description] {
+			continue
+		}
+		known[description] = true
+		issues = append(issues, &repository.ReviewIssue{
+			SBIID:       sbiID,
+			Description: description,
+			Blocking:    decision != "SUCCEEDED" && domainservice.IsBlockingIssue(description),
+			Status:      repository.ReviewIssueOpen,
+			RaisedTurn:  turn,
+			Turn:        turn,
+		})
+	}
+	if len(issues) == 0 {
+		return
+	}
+
+	if err := uc.issueRepo.Add(ctx, sbiID, issues); err != nil {
+		// Log warning but don't fail - the next turn still receives the review feedback
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to record review issues\n")
+		fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "   SBI ID: %s, Turn: %d\n", sbiID, turn)
+	}
+}
+
+// countOpenBlocking returns the number of blocking issues that are not closed
+func countOpenBlocking(issues []*repository.ReviewIssue) int {
+	count := 0
+	for _, issue := range issues {
+		if issue.Blocking && !issue.Status.IsClosed() {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"path"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+// memoryArtifactStore keeps artifacts in memory, addressed like an offloaded store
+// with a local working copy
+type memoryArtifactStore struct {
+	contents map[string][]byte
+}
+
+func (s *memoryArtifactStore) Put(ctx context.Context, key string, content []byte) (string, error) {
+	s.contents[s.URI(key)] = content
+	return s.URI(key), nil
+}
+
+func (s *memoryArtifactStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	return s.contents[uri], nil
+}
+
+func (s *memoryArtifactStore) URI(key string) string {
+	return "s3://ci-artifacts/" + key
+}
+
+func (s *memoryArtifactStore) LocalPath(key string) string {
+	return ".deespec/" + key
+}
+
+// recordingJournalRepository records appended journal records
+type recordingJournalRepository struct {
+	repository.JournalRepository
+	records []*repository.JournalRecord
+}
+
+func (r *recordingJournalRepository) Append(ctx context.Context, record *repository.JournalRecord) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+// TestReportSBI_StaleFenceRejected verifies that a report submitted by the agent of a runner
+// whose lease was taken over is rejected together with its journal entry
+func TestReportSBI_StaleFenceRejected(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	lockRepo := sqlite.NewStateLockRepository(db)
+	journalRepo := &recordingJournalRepository{}
+	uc := NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		&memoryArtifactStore{contents: map[string][]byte{}},
+	)
+
+	task, err := sbi.NewSBI("Fenced report", "", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	task.IncrementTurn()
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+	lockID, _ := lock.NewLockID("sbi-" + sbiID)
+
+	// Runner A's lease expires and runner B takes over
+	stale, err := lockRepo.Acquire(ctx, lockID, lock.LockTypeWrite, -time.Second)
+	require.NoError(t, err)
+	current, err := lockRepo.Acquire(ctx, lockID, lock.LockTypeWrite, 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, current)
+
+	// `sbi report` from runner A's agent inherits A's fence through the environment
+	env := stale.Fence().Env()
+	fence, fenced, err := lock.FenceFromEnv(func(key string) string { return env[key] })
+	require.NoError(t, err)
+	require.True(t, fenced)
+
+	err = uc.Execute(lock.WithFence(ctx, fence), sbiID, 1, "implement", "", "late report")
+	assert.ErrorIs(t, err, lock.ErrStaleFencingToken)
+	assert.Empty(t, journalRepo.records)
+
+	saved, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusImplementing, saved.Status())
+
+	// The current holder's report goes through
+	require.NoError(t, uc.Execute(lock.WithFence(ctx, current.Fence()), sbiID, 1, "implement", "", "report"))
+	assert.Len(t, journalRepo.records, 1)
+}
+
+// TestReportSBI_OffloadedReportPaths verifies that journals record the remote URI while
+// review feedback carried into prompts keeps the local path agents can read
+func TestReportSBI_OffloadedReportPaths(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	feedbackRepo := sqlite.NewReviewFeedbackRepository(db)
+	journalRepo := &recordingJournalRepository{}
+	uc := NewReportSBIUseCase(
+		sbiRepo,
+		journalRepo,
+		sqlite.NewSBIExecLogRepository(db),
+		feedbackRepo,
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		&memoryArtifactStore{contents: map[string][]byte{}},
+	)
+
+	task, err := sbi.NewSBI("Offloaded review", "", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	require.NoError(t, task.UpdateStatus(model.StatusReviewing))
+	task.IncrementTurn()
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	require.NoError(t, uc.Execute(ctx, sbiID, 1, "review", "NEEDS_CHANGES", "## Issues\n- Missing tests"))
+
+	reportKey := path.Join("reports/sbi", sbiID, "review_1.md")
+	require.Len(t, journalRepo.records, 1)
+	assert.Equal(t, []interface{}{"s3://ci-artifacts/" + reportKey}, journalRepo.records[0].Artifacts)
+
+	feedback, err := feedbackRepo.FindBySBIIDAndTurn(ctx, sbiID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, feedback)
+	assert.Equal(t, ".deespec/"+reportKey, feedback.ReportPath)
+}
+
+// TestReportSBI_ReviewIssuesBlockDone verifies that review issues are tracked across turns
+// and that a passing review is downgraded while a blocking issue is still open
+func TestReportSBI_ReviewIssuesBlockDone(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	issueRepo := sqlite.NewReviewIssueRepository(db)
+	uc := NewReportSBIUseCase(
+		sbiRepo,
+		&recordingJournalRepository{},
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		&memoryArtifactStore{contents: map[string][]byte{}},
+	)
+	uc.SetReviewIssueRepository(issueRepo)
+
+	task, err := sbi.NewSBI("Tracked issues", "", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	require.NoError(t, task.UpdateStatus(model.StatusReviewing))
+	task.IncrementTurn()
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	require.NoError(t, uc.Execute(ctx, sbiID, 1, "review", "NEEDS_CHANGES",
+		"## Issues\n- Handle expired tokens\n- nit: rename cfg\n- Missing tests"))
+	issues, err := issueRepo.FindBySBIID(ctx, sbiID)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	assert.True(t, issues[0].Blocking)
+	assert.False(t, issues[1].Blocking)
+	assert.Equal(t, 1, issues[2].RaisedTurn)
+
+	// ISSUE-3 is left open, so the passing review must not complete the SBI
+	require.NoError(t, uc.Execute(ctx, sbiID, 2, "implement", "", "implemented"))
+	require.NoError(t, uc.Execute(ctx, sbiID, 2, "review", "SUCCEEDED",
+		"## Tracked Issues\n- ISSUE-1: ADDRESSED - guard added\n- ISSUE-2: WONTFIX - naming is fine\n- ISSUE-3: OPEN"))
+	saved, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusImplementing, saved.Status())
+
+	issues, err = issueRepo.FindBySBIID(ctx, sbiID)
+	require.NoError(t, err)
+	require.Len(t, issues, 3, "status updates are not new issues")
+	assert.Equal(t, repository.ReviewIssueAddressed, issues[0].Status)
+	assert.Equal(t, "guard added", issues[0].Note)
+	assert.Equal(t, 2, issues[0].Turn)
+	assert.Equal(t, repository.ReviewIssueWontFix, issues[1].Status)
+	assert.Equal(t, repository.ReviewIssueOpen, issues[2].Status)
+
+	require.NoError(t, uc.Execute(ctx, sbiID, 3, "implement", "", "tests added"))
+	require.NoError(t, uc.Execute(ctx, sbiID, 3, "review", "SUCCEEDED", "ISSUE-3: FIXED"))
+	saved, err = sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDone, saved.Status())
+}
+
+// TestReportSBI_ReviewVoteKeepsStatus verifies that a reviewer vote in review quorum mode
+// stores the reviewer's own report without deciding the turn
+func TestReportSBI_ReviewVoteKeepsStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	voteRepo := sqlite.NewReviewVoteRepository(db)
+	store := &memoryArtifactStore{contents: map[string][]byte{}}
+	uc := NewReportSBIUseCase(
+		sbiRepo,
+		&recordingJournalRepository{},
+		sqlite.NewSBIExecLogRepository(db),
+		sqlite.NewReviewFeedbackRepository(db),
+		sqlite.NewAcceptanceCriteriaRepository(db),
+		store,
+	)
+
+	task, err := sbi.NewSBI("Quorum review", "", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	require.NoError(t, task.UpdateStatus(model.StatusReviewing))
+	task.IncrementTurn()
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	// Votes need a vote repository
+	assert.Error(t, uc.SubmitReviewVote(ctx, sbiID, 1, "security", "FAILED", "## Issues\n- SQL injection"))
+
+	uc.SetReviewVoteRepository(voteRepo)
+	assert.Error(t, uc.SubmitReviewVote(ctx, sbiID, 1, "../escape", "FAILED", "report"))
+	require.NoError(t, uc.SubmitReviewVote(ctx, sbiID, 1, "security", "FAILED", "## Issues\n- SQL injection"))
+
+	votes, err := voteRepo.FindByTurn(ctx, sbiID, 1)
+	require.NoError(t, err)
+	require.Len(t, votes, 1)
+	assert.Equal(t, "FAILED", votes[0].Decision)
+	reportURI := "s3://ci-artifacts/" + path.Join("reports/sbi", sbiID, "review_1_security.md")
+	assert.Equal(t, reportURI, votes[0].ReportPath)
+	assert.Equal(t, "## Issues\n- SQL injection", string(store.contents[reportURI]))
+
+	saved, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusReviewing, saved.Status())
+	assert.Equal(t, 1, saved.ExecutionState().CurrentTurn.Value())
+}
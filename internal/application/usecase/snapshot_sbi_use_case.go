@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// sbiSnapshotFormatVersion is bumped when the archive layout changes
+const sbiSnapshotFormatVersion = 1
+
+// Archive entry names
+const (
+	snapshotManifestEntry = "manifest.json"
+	snapshotRowsEntry     = "rows.json"
+	snapshotJournalEntry  = "journal.json"
+	snapshotFilesPrefix   = "files/"
+)
+
+// ErrSBIAlreadyExists is returned by Restore when the SBI exists and overwriting was not requested
+var ErrSBIAlreadyExists = errors.New("sbi already exists")
+
+// SBISnapshotManifest describes the contents of an SBI snapshot archive
+type SBISnapshotManifest struct {
+	FormatVersion  int      `json:"format_version"`
+	SBIID          string   `json:"sbi_id"`
+	CreatedAt      string   `json:"created_at"`
+	JournalRecords int      `json:"journal_records"`
+	Files          []string `json:"files"` // Paths relative to the .deespec directory
+}
+
+// SnapshotSBIUseCase bundles the full state of a single SBI into a tar.gz archive and restores it,
+// so that agent failures can be reproduced on another machine
+// The archive holds the SBI's database rows, its journal records, and its spec and report directories
+type SnapshotSBIUseCase struct {
+	snapshotRepo repository.SBISnapshotRepository
+	journalRepo  repository.JournalRepository
+	homeDir      string // .deespec directory holding specs/ and reports/
+}
+
+// NewSnapshotSBIUseCase creates a new SnapshotSBIUseCase
+func NewSnapshotSBIUseCase(
+	snapshotRepo repository.SBISnapshotRepository,
+	journalRepo repository.JournalRepository,
+	homeDir string,
+) *SnapshotSBIUseCase {
+	return &SnapshotSBIUseCase{
+		snapshotRepo: snapshotRepo,
+		journalRepo:  journalRepo,
+		homeDir:      homeDir,
+	}
+}
+
+// Snapshot writes the archive of an SBI to w
+func (uc *SnapshotSBIUseCase) Snapshot(ctx context.Context, sbiID string, w io.Writer) (*SBISnapshotManifest, error) {
+	// 1. Database rows
+	rows, err := uc.snapshotRepo.ExportRows(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export SBI rows: %w", err)
+	}
+
+	// 2. Journal records
+	records, err := uc.journalRepo.FindBySBI(ctx, sbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	// 3. Spec and report files
+	files := make(map[string][]byte)
+	for _, dir := range sbiSnapshotDirs(sbiID) {
+		if err := uc.collectFiles(dir, files); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest := &SBISnapshotManifest{
+		FormatVersion:  sbiSnapshotFormatVersion,
+		SBIID:          sbiID,
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+		JournalRecords: len(records),
+		Files:          sortedKeys(files),
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, entry := range []struct {
+		name  string
+		value interface{}
+	}{
+		{snapshotManifestEntry, manifest},
+		{snapshotRowsEntry, rows},
+		{snapshotJournalEntry, records},
+	} {
+		data, err := json.MarshalIndent(entry.value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", entry.name, err)
+		}
+		if err := writeTarEntry(tw, entry.name, data); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range manifest.Files {
+		if err := writeTarEntry(tw, snapshotFilesPrefix+name, files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// Restore reads an archive written by Snapshot and restores the SBI
+// An existing SBI is only replaced when overwrite is set
+func (uc *SnapshotSBIUseCase) Restore(ctx context.Context, r io.Reader, overwrite bool) (*SBISnapshotManifest, error) {
+	entries, err := readTarEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Validate the manifest
+	var manifest SBISnapshotManifest
+	if err := decodeSnapshotEntry(entries, snapshotManifestEntry, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion != sbiSnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d (expected %d)", manifest.FormatVersion, sbiSnapshotFormatVersion)
+	}
+	if manifest.SBIID == "" || strings.ContainsAny(manifest.SBIID, `/\`) || strings.Contains(manifest.SBIID, "..") {
+		return nil, fmt.Errorf("invalid SBI ID in snapshot: %q", manifest.SBIID)
+	}
+
+	var rows repository.SBIRows
+	if err := decodeSnapshotEntry(entries, snapshotRowsEntry, &rows); err != nil {
+		return nil, err
+	}
+	var records []*repository.JournalRecord
+	if err := decodeSnapshotEntry(entries, snapshotJournalEntry, &records); err != nil {
+		return nil, err
+	}
+
+	// 2. Refuse to clobber an existing SBI unless asked to
+	if !overwrite {
+		_, err := uc.snapshotRepo.ExportRows(ctx, repository.SBIID(manifest.SBIID))
+		if err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrSBIAlreadyExists, manifest.SBIID)
+		}
+		if !errors.Is(err, repository.ErrSBIRowsNotFound) {
+			return nil, fmt.Errorf("failed to check existing SBI: %w", err)
+		}
+	}
+
+	// 3. Database rows
+	if err := uc.snapshotRepo.ImportRows(ctx, repository.SBIID(manifest.SBIID), rows); err != nil {
+		return nil, fmt.Errorf("failed to import SBI rows: %w", err)
+	}
+
+	// 4. Journal records (Append skips records that are already journaled)
+	for _, record := range records {
+		if record.SBIID != manifest.SBIID {
+			continue
+		}
+		if err := uc.journalRepo.Append(ctx, record); err != nil {
+			return nil, fmt.Errorf("failed to restore journal record: %w", err)
+		}
+	}
+
+	// 5. Spec and report files, confined to the SBI's own directories
+	allowed := sbiSnapshotDirs(manifest.SBIID)
+	for _, name := range manifest.Files {
+		content, ok := entries[snapshotFilesPrefix+name]
+		if !ok {
+			return nil, fmt.Errorf("snapshot is missing file %s", name)
+		}
+		if !isWithinSnapshotDirs(name, allowed) {
+			return nil, fmt.Errorf("snapshot file outside the SBI directories: %s", name)
+		}
+
+		target := filepath.Join(uc.homeDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+	}
+
+	return &manifest, nil
+}
+
+// sbiSnapshotDirs returns the directories of an SBI relative to the .deespec directory
+func sbiSnapshotDirs(sbiID string) []string {
+	return []string{
+		path.Join("specs", "sbi", sbiID),
+		path.Join("reports", "sbi", sbiID),
+	}
+}
+
+// isWithinSnapshotDirs reports whether a slash-separated relative path lies inside one of dirs
+func isWithinSnapshotDirs(name string, dirs []string) bool {
+	clean := path.Clean(name)
+	if clean != name || path.IsAbs(clean) {
+		return false
+	}
+	for _, dir := range dirs {
+		if strings.HasPrefix(clean, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// collectFiles adds the regular files under dir (relative to the home directory) to files
+func (uc *SnapshotSBIUseCase) collectFiles(dir string, files map[string][]byte) error {
+	root := filepath.Join(uc.homeDir, filepath.FromSlash(dir))
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(uc.homeDir, p)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to collect files from %s: %w", dir, err)
+	}
+	return nil
+}
+
+// writeTarEntry writes a regular file entry to the archive
+func writeTarEntry(tw *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// readTarEntries reads all regular file entries of a tar.gz archive
+func readTarEntries(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot archive: %w", err)
+	}
+	defer gz.Close()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		entries[header.Name] = buf.Bytes()
+	}
+	return entries, nil
+}
+
+// decodeSnapshotEntry decodes a JSON archive entry
+func decodeSnapshotEntry(entries map[string][]byte, name string, v interface{}) error {
+	data, ok := entries[name]
+	if !ok {
+		return fmt.Errorf("snapshot is missing %s", name)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of files in lexical order
+func sortedKeys(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// snapshotEnv is one machine: a database, a journal and a .deespec directory
+type snapshotEnv struct {
+	db   *sql.DB
+	home string
+	uc   *SnapshotSBIUseCase
+}
+
+func newSnapshotEnv(t *testing.T) *snapshotEnv {
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	home := t.TempDir()
+	journalRepo := infraRepo.NewJournalRepositoryImpl(filepath.Join(home, "var", "journal.ndjson"))
+	return &snapshotEnv{
+		db:   db,
+		home: home,
+		uc:   NewSnapshotSBIUseCase(sqlite.NewSBISnapshotRepository(db), journalRepo, home),
+	}
+}
+
+// TestSnapshotSBI_RestoresOnAnotherMachine verifies that an SBI's DB rows, journal and
+// files survive a snapshot/restore round trip into an empty environment
+func TestSnapshotSBI_RestoresOnAnotherMachine(t *testing.T) {
+	ctx := context.Background()
+	source := newSnapshotEnv(t)
+
+	sbiRepo := sqlite.NewSBIRepository(source.db)
+	task, err := sbi.NewSBI("Flaky login", "Fails on the second review", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateStatus(model.StatusPicked))
+	require.NoError(t, task.UpdateStatus(model.StatusImplementing))
+	task.IncrementTurn()
+	task.RecordError("agent timed out")
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	sbiID := task.ID().String()
+
+	require.NoError(t, sqlite.NewSBIExecLogRepository(source.db).Save(ctx, &repository.SBIExecLog{
+		SBIID: sbiID, Turn: 1, Step: "IMPLEMENT", ReportPath: "reports/implement_1.md", ExecutedAt: time.Now(),
+	}))
+	require.NoError(t, sqlite.NewReviewFeedbackRepository(source.db).Save(ctx, &repository.ReviewFeedback{
+		SBIID: sbiID, Turn: 1, Decision: "NEEDS_CHANGES", Issues: []string{"Missing tests"},
+	}))
+	require.NoError(t, source.uc.journalRepo.Append(ctx, &repository.JournalRecord{
+		Timestamp: "2025-10-20T12:00:00Z", SBIID: sbiID, Turn: 1, Step: "implement", Status: "IMPLEMENTING",
+	}))
+
+	specPath := filepath.Join(source.home, "specs", "sbi", sbiID, "spec.md")
+	reportPath := filepath.Join(source.home, "reports", "sbi", sbiID, "implement_1.md")
+	for path, content := range map[string]string{specPath: "# Flaky login", reportPath: "# Report"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	var archive bytes.Buffer
+	manifest, err := source.uc.Snapshot(ctx, sbiID, &archive)
+	require.NoError(t, err)
+	assert.Equal(t, 1, manifest.JournalRecords)
+	assert.Len(t, manifest.Files, 2)
+
+	// Restore into an empty environment
+	target := newSnapshotEnv(t)
+	restored, err := target.uc.Restore(ctx, bytes.NewReader(archive.Bytes()), false)
+	require.NoError(t, err)
+	assert.Equal(t, sbiID, restored.SBIID)
+
+	saved, err := sqlite.NewSBIRepository(target.db).Find(ctx, repository.SBIID(sbiID))
+	require.NoError(t, err)
+	assert.Equal(t, "Flaky login", saved.Title())
+	assert.Equal(t, model.StatusImplementing, saved.Status())
+	assert.Equal(t, task.ExecutionState().CurrentTurn.Value(), saved.ExecutionState().CurrentTurn.Value())
+	assert.Equal(t, "agent timed out", saved.ExecutionState().LastError)
+
+	logs, err := sqlite.NewSBIExecLogRepository(target.db).FindBySBIID(ctx, sbiID)
+	require.NoError(t, err)
+	assert.Len(t, logs, 1)
+	feedback, err := sqlite.NewReviewFeedbackRepository(target.db).FindBySBIIDAndTurn(ctx, sbiID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, feedback)
+	assert.Equal(t, []string{"Missing tests"}, feedback.Issues)
+
+	records, err := target.uc.journalRepo.FindBySBI(ctx, sbiID)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	content, err := os.ReadFile(filepath.Join(target.home, "specs", "sbi", sbiID, "spec.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Flaky login", string(content))
+
+	// An existing SBI is only replaced on request, and replacing does not duplicate rows
+	_, err = target.uc.Restore(ctx, bytes.NewReader(archive.Bytes()), false)
+	assert.ErrorIs(t, err, ErrSBIAlreadyExists)
+	_, err = target.uc.Restore(ctx, bytes.NewReader(archive.Bytes()), true)
+	require.NoError(t, err)
+	logs, err = sqlite.NewSBIExecLogRepository(target.db).FindBySBIID(ctx, sbiID)
+	require.NoError(t, err)
+	assert.Len(t, logs, 1)
+	records, err = target.uc.journalRepo.FindBySBI(ctx, sbiID)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestSnapshotSBI_UnknownSBI(t *testing.T) {
+	env := newSnapshotEnv(t)
+	_, err := env.uc.Snapshot(context.Background(), "missing", &bytes.Buffer{})
+	assert.ErrorIs(t, err, repository.ErrSBIRowsNotFound)
+}
+
+func TestIsWithinSnapshotDirs(t *testing.T) {
+	dirs := sbiSnapshotDirs("SBI-1")
+	assert.True(t, isWithinSnapshotDirs("specs/sbi/SBI-1/spec.md", dirs))
+	assert.True(t, isWithinSnapshotDirs("reports/sbi/SBI-1/review_2.md", dirs))
+	assert.False(t, isWithinSnapshotDirs("specs/sbi/SBI-1/../SBI-2/spec.md", dirs))
+	assert.False(t, isWithinSnapshotDirs("specs/sbi/SBI-10/spec.md", dirs))
+	assert.False(t, isWithinSnapshotDirs("etc/workflow.yaml", dirs))
+}
@@ -0,0 +1,469 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// Spec sync actions
+const (
+	SpecActionRegistered = "registered"
+	SpecActionUpdated    = "updated"
+	SpecActionInvalid    = "invalid"
+)
+
+// specMetadataPattern matches one line of the optional metadata section closing a dropped-in SBI spec
+var specMetadataPattern = regexp.MustCompile(`^(Parent PBI|Labels):\s*(.*)$`)
+
+// SyncedSpec is one spec file a sync registered, updated or rejected
+type SyncedSpec struct {
+	Path   string `json:"path"`              // Path of the spec file when it was found
+	Kind   string `json:"kind"`              // "sbi" or "pbi"
+	TaskID string `json:"task_id,omitempty"` // Registered or updated task
+	Title  string `json:"title,omitempty"`
+	Action string `json:"action"`
+	Error  string `json:"error,omitempty"` // Why an invalid spec was rejected
+}
+
+// SyncSpecsResult reports what one sync did
+type SyncSpecsResult struct {
+	Specs []SyncedSpec `json:"specs"`
+}
+
+// Count returns the number of specs that got the given action
+func (r *SyncSpecsResult) Count(action string) int {
+	count := 0
+	for _, s := range r.Specs {
+		if s.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// SyncSpecsUseCase registers and updates tasks from the markdown files under .deespec/specs
+// so specs can be added through version control instead of CLI commands:
+//   - specs/sbi/*.md and specs/pbi/*.md are dropped-in specs. Each is validated, registered as
+//     a new SBI or PBI, and moved to the task's own directory (specs/sbi/<id>/spec.md,
+//     specs/pbi/<id>/pbi.md) so it is registered exactly once
+//   - specs/sbi/<id>/spec.md edited after the SBI was last saved updates the SBI's title and
+//     description. PBI bodies are always read from pbi.md, so PBI edits need no sync
+//
+// Invalid files are reported once per modification and left in place until they are fixed.
+type SyncSpecsUseCase struct {
+	sbiRepo  repository.SBIRepository
+	pbiRepo  pbi.Repository
+	specsSBI string // .deespec/specs/sbi
+	specsPBI string // .deespec/specs/pbi
+
+	rejected map[string]time.Time // Invalid spec path -> modification time already reported
+}
+
+// NewSyncSpecsUseCase creates a new SyncSpecsUseCase
+func NewSyncSpecsUseCase(
+	sbiRepo repository.SBIRepository,
+	pbiRepo pbi.Repository,
+	specsSBI, specsPBI string,
+) *SyncSpecsUseCase {
+	return &SyncSpecsUseCase{
+		sbiRepo:  sbiRepo,
+		pbiRepo:  pbiRepo,
+		specsSBI: specsSBI,
+		specsPBI: specsPBI,
+		rejected: make(map[string]time.Time),
+	}
+}
+
+// Sync scans the specs directories once and registers or updates the tasks of new and edited files
+// Errors that would leave a registered spec behind as a drop-in (and register it again on the
+// next sync) stop the sync; problems with the content of a single file are reported in the result.
+func (uc *SyncSpecsUseCase) Sync(ctx context.Context) (*SyncSpecsResult, error) {
+	result := &SyncSpecsResult{Specs: []SyncedSpec{}}
+
+	sbiDropIns, err := dropInFiles(uc.specsSBI)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range sbiDropIns {
+		spec, err := uc.registerSBI(ctx, path)
+		if err != nil {
+			return result, err
+		}
+		uc.record(result, spec, path)
+	}
+
+	pbiDropIns, err := dropInFiles(uc.specsPBI)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range pbiDropIns {
+		spec, err := uc.registerPBI(path)
+		if err != nil {
+			return result, err
+		}
+		uc.record(result, spec, path)
+	}
+
+	edited, err := uc.editedSBISpecs(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, spec := range edited {
+		uc.record(result, spec, spec.Path)
+	}
+
+	return result, nil
+}
+
+// record adds spec to the result, dropping invalid specs that were already reported unchanged
+func (uc *SyncSpecsUseCase) record(result *SyncSpecsResult, spec *SyncedSpec, path string) {
+	if spec == nil {
+		return
+	}
+	if spec.Action != SpecActionInvalid {
+		delete(uc.rejected, path)
+		result.Specs = append(result.Specs, *spec)
+		return
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if reported, ok := uc.rejected[path]; ok && reported.Equal(info.ModTime()) {
+		return
+	}
+	uc.rejected[path] = info.ModTime()
+	result.Specs = append(result.Specs, *spec)
+}
+
+// registerSBI registers a dropped-in SBI spec and moves it to specs/sbi/<id>/spec.md
+func (uc *SyncSpecsUseCase) registerSBI(ctx context.Context, path string) (*SyncedSpec, error) {
+	spec := &SyncedSpec{Path: path, Kind: "sbi"}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	title, body, metadata, err := parseSpecMarkdown(string(data))
+	if err == nil && body == "" {
+		err = errors.New("spec body is empty")
+	}
+	if err != nil {
+		return invalidSpec(spec, err), nil
+	}
+	spec.Title = title
+
+	parent := metadata["Parent PBI"]
+	if parent != "" {
+		exists, err := uc.pbiRepo.Exists(parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check parent PBI of %s: %w", path, err)
+		}
+		if !exists {
+			return invalidSpec(spec, fmt.Errorf("parent PBI %q not found", parent)), nil
+		}
+	}
+	specMetadata := specSBIMetadata(metadata)
+	for _, dependsOn := range specMetadata.DependsOn {
+		if _, err := uc.sbiRepo.Find(ctx, repository.SBIID(dependsOn)); err != nil {
+			return invalidSpec(spec, fmt.Errorf("depends_on %s: %w", dependsOn, err)), nil
+		}
+	}
+	created, err := saveNewSBI(ctx, uc.sbiRepo, title, body, parent, specMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register SBI from %s: %w", path, err)
+	}
+	id := created.ID().String()
+	spec.TaskID = id
+	spec.Action = SpecActionRegistered
+
+	specDir := filepath.Join(uc.specsSBI, id)
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		return nil, fmt.Errorf("SBI %s registered from %s, but failed to create %s: %w", id, path, specDir, err)
+	}
+	if err := os.Rename(path, filepath.Join(specDir, "spec.md")); err != nil {
+		return nil, fmt.Errorf("SBI %s registered from %s, but failed to move the spec: %w", id, path, err)
+	}
+	return spec, nil
+}
+
+// saveNewSBI saves a new SBI under an optional parent PBI, numbered after the existing SBIs
+// TaskUseCase.CreateSBI looks parents up in the deprecated PBI repository, which knows no PBIs,
+// so SBIs are saved directly like `pbi register` does. The parent must already exist.
+func saveNewSBI(ctx context.Context, sbiRepo repository.SBIRepository, title, description, parentPBIID string, metadata sbi.SBIMetadata) (*sbi.SBI, error) {
+	var parent *model.TaskID
+	if parentPBIID != "" {
+		id, err := model.NewTaskIDFromString(parentPBIID)
+		if err != nil {
+			return nil, err
+		}
+		parent = &id
+	}
+	sequence, err := sbiRepo.GetNextSequence(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next sequence: %w", err)
+	}
+	metadata.Sequence = sequence
+	metadata.RegisteredAt = time.Now()
+
+	task, err := sbi.NewSBI(title, description, parent, metadata)
+	if err != nil {
+		return nil, err
+	}
+	if err := sbiRepo.Save(ctx, task); err != nil {
+		return nil, err
+	}
+	if len(metadata.DependsOn) > 0 {
+		if err := sbiRepo.SaveDependencies(ctx, repository.SBIID(task.ID().String()), metadata.DependsOn); err != nil {
+			return nil, fmt.Errorf("failed to save dependencies: %w", err)
+		}
+	}
+	return task, nil
+}
+
+// registerPBI registers a dropped-in PBI spec; the repository writes it to specs/pbi/<id>/pbi.md
+func (uc *SyncSpecsUseCase) registerPBI(path string) (*SyncedSpec, error) {
+	spec := &SyncedSpec{Path: path, Kind: "pbi"}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	body := string(data)
+	title, _, _, err := parseSpecMarkdown(body)
+	if err != nil {
+		return invalidSpec(spec, err), nil
+	}
+	spec.Title = title
+
+	p := pbi.NewPBI(title)
+	if err := p.Validate(); err != nil {
+		return invalidSpec(spec, err), nil
+	}
+	id, err := pbi.GenerateID(uc.pbiRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PBI ID: %w", err)
+	}
+	p.ID = id
+	if err := uc.pbiRepo.Save(p, body); err != nil {
+		return nil, fmt.Errorf("failed to register PBI from %s: %w", path, err)
+	}
+	spec.TaskID = id
+	spec.Action = SpecActionRegistered
+
+	if err := os.Remove(path); err != nil {
+		return nil, fmt.Errorf("PBI %s registered from %s, but failed to remove the spec: %w", id, path, err)
+	}
+	return spec, nil
+}
+
+// editedSBISpecs updates the SBIs whose spec.md changed after the SBI was last saved
+// Directories without a known SBI (archived SBIs, stray directories) are ignored.
+func (uc *SyncSpecsUseCase) editedSBISpecs(ctx context.Context) ([]*SyncedSpec, error) {
+	entries, err := os.ReadDir(uc.specsSBI)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", uc.specsSBI, err)
+	}
+
+	var specs []*SyncedSpec
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(uc.specsSBI, entry.Name(), "spec.md")
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		sbiID := repository.SBIID(entry.Name())
+		current, err := uc.sbiRepo.Find(ctx, sbiID)
+		if err != nil || current.IsTerminal() || !info.ModTime().After(current.UpdatedAt().Value()) {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return specs, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		spec := &SyncedSpec{Path: path, Kind: "sbi", TaskID: entry.Name()}
+		title, body, _, err := parseSpecMarkdown(string(data))
+		if err != nil {
+			specs = append(specs, invalidSpec(spec, err))
+			continue
+		}
+		spec.Title = title
+		if title == current.Title() && body == strings.TrimSpace(current.Description()) {
+			continue
+		}
+
+		err = service.RetryOnStale(ctx, service.DefaultStaleRetries, func(ctx context.Context) error {
+			s, err := uc.sbiRepo.Find(ctx, sbiID)
+			if err != nil {
+				return err
+			}
+			if err := s.UpdateTitle(title); err != nil {
+				return err
+			}
+			s.UpdateDescription(body)
+			return uc.sbiRepo.Save(ctx, s)
+		})
+		if err != nil {
+			return specs, fmt.Errorf("failed to update SBI %s from %s: %w", sbiID, path, err)
+		}
+		spec.Action = SpecActionUpdated
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// invalidSpec marks spec as rejected for err
+func invalidSpec(spec *SyncedSpec, err error) *SyncedSpec {
+	spec.Action = SpecActionInvalid
+	spec.Error = err.Error()
+	return spec
+}
+
+// dropInFiles returns the markdown files directly inside dir, sorted by name
+// Hidden files are skipped so editors' swap and temporary files are not registered half-written.
+func dropInFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || !strings.EqualFold(filepath.Ext(name), ".md") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// parseSpecMarkdown splits a spec into its H1 title, the body below the title and the optional
+// metadata section ("---" followed only by "Parent PBI:" and "Labels:" lines) closing it
+// Anything above the title, such as the guideline preamble of 'sbi register', is not part of the body.
+// The fields of a YAML front matter are added to the metadata under the same keys ("Parent PBI",
+// "Labels", "Priority", "Estimated Hours", "Depends On", "Agent") and win over the section's.
+func parseSpecMarkdown(content string) (title, body string, metadata map[string]string, err error) {
+	front, content, err := domainservice.ParseSpecFrontMatter(content)
+	if err != nil {
+		return "", "", nil, err
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+
+	titleLine := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "# ") {
+			title = strings.TrimSpace(strings.TrimPrefix(trimmed, "# "))
+			titleLine = i
+			break
+		}
+	}
+	if titleLine < 0 || title == "" {
+		return "", "", nil, errors.New("no H1 title found")
+	}
+
+	body = strings.Join(lines[titleLine+1:], "\n")
+	metadata = map[string]string{}
+	if idx := strings.LastIndex(body, "\n---\n"); idx >= 0 {
+		section := map[string]string{}
+		isMetadata := true
+		for _, line := range strings.Split(body[idx+len("\n---\n"):], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			matches := specMetadataPattern.FindStringSubmatch(line)
+			if matches == nil {
+				isMetadata = false
+				break
+			}
+			section[matches[1]] = strings.TrimSpace(matches[2])
+		}
+		if isMetadata && len(section) > 0 {
+			metadata = section
+			body = body[:idx]
+		}
+	}
+	if front != nil {
+		addFrontMatter(metadata, front)
+	}
+	return title, strings.TrimSpace(body), metadata, nil
+}
+
+// addFrontMatter adds the fields set in a spec's front matter to its metadata
+func addFrontMatter(metadata map[string]string, front *domainservice.SpecFrontMatter) {
+	if front.ParentPBI != "" {
+		metadata["Parent PBI"] = front.ParentPBI
+	}
+	if front.Labels != nil {
+		metadata["Labels"] = strings.Join(front.Labels, ", ")
+	}
+	if front.Priority != nil {
+		metadata["Priority"] = strconv.Itoa(*front.Priority)
+	}
+	if front.EstimatedHours != nil {
+		metadata["Estimated Hours"] = strconv.FormatFloat(*front.EstimatedHours, 'f', -1, 64)
+	}
+	if len(front.DependsOn) > 0 {
+		metadata["Depends On"] = strings.Join(front.DependsOn, ", ")
+	}
+	if front.Agent != "" {
+		metadata["Agent"] = front.Agent
+	}
+}
+
+// specSBIMetadata returns the SBI metadata set by a spec's metadata (already validated)
+func specSBIMetadata(metadata map[string]string) sbi.SBIMetadata {
+	result := sbi.SBIMetadata{
+		Labels:        splitSpecLabels(metadata["Labels"]),
+		DependsOn:     splitSpecLabels(metadata["Depends On"]),
+		AssignedAgent: metadata["Agent"],
+	}
+	result.Priority, _ = strconv.Atoi(metadata["Priority"])
+	result.EstimatedHours, _ = strconv.ParseFloat(metadata["Estimated Hours"], 64)
+	return result
+}
+
+// splitSpecLabels parses a comma-separated "Labels:" value ("none" means no labels)
+func splitSpecLabels(value string) []string {
+	if strings.EqualFold(strings.TrimSpace(value), "none") {
+		return nil
+	}
+	var labels []string
+	for _, label := range strings.Split(value, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
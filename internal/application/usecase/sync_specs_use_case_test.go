@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+func newSyncSpecsTest(t *testing.T) (*SyncSpecsUseCase, repository.SBIRepository, pbi.Repository, string) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	root := t.TempDir()
+	specs := filepath.Join(root, ".deespec", "specs")
+	require.NoError(t, os.MkdirAll(filepath.Join(specs, "sbi"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(specs, "pbi"), 0755))
+
+	sbiRepo := sqlite.NewSBIRepository(db)
+	pbiRepo := persistence.NewPBISQLiteRepository(db, root)
+	uc := NewSyncSpecsUseCase(sbiRepo, pbiRepo, filepath.Join(specs, "sbi"), filepath.Join(specs, "pbi"))
+	return uc, sbiRepo, pbiRepo, specs
+}
+
+func writeSpec(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// TestSyncSpecs_RegistersDropIns verifies that dropped-in SBI and PBI specs are registered
+// once and moved into the task's own directory
+func TestSyncSpecs_RegistersDropIns(t *testing.T) {
+	ctx := context.Background()
+	uc, sbiRepo, pbiRepo, specs := newSyncSpecsTest(t)
+	parent := pbi.NewPBI("Authentication")
+	parent.ID = "PBI-001"
+	require.NoError(t, pbiRepo.Save(parent, "# Authentication\n"))
+
+	dropIn := filepath.Join(specs, "sbi", "login.md")
+	writeSpec(t, dropIn, "# Login form\n\nEmail and password fields.\n\n---\nParent PBI: PBI-001\nLabels: frontend, auth\n")
+	writeSpec(t, filepath.Join(specs, "pbi", "checkout.md"), "# Checkout\n\nOne-page checkout.\n")
+	writeSpec(t, filepath.Join(specs, "sbi", ".login.md.swp"), "partial")
+
+	result, err := uc.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Specs, 2)
+	assert.Equal(t, 2, result.Count(SpecActionRegistered))
+
+	sbiSpec := result.Specs[0]
+	assert.Equal(t, "sbi", sbiSpec.Kind)
+	assert.NoFileExists(t, dropIn)
+	assert.FileExists(t, filepath.Join(specs, "sbi", sbiSpec.TaskID, "spec.md"))
+	registered, err := sbiRepo.Find(ctx, repository.SBIID(sbiSpec.TaskID))
+	require.NoError(t, err)
+	assert.Equal(t, "Login form", registered.Title())
+	assert.Equal(t, "Email and password fields.", registered.Description())
+	assert.Equal(t, []string{"frontend", "auth"}, registered.Metadata().Labels)
+	require.NotNil(t, registered.ParentTaskID())
+	assert.Equal(t, "PBI-001", registered.ParentTaskID().String())
+
+	pbiSpec := result.Specs[1]
+	assert.Equal(t, "pbi", pbiSpec.Kind)
+	assert.Equal(t, "Checkout", pbiSpec.Title)
+	assert.NoFileExists(t, filepath.Join(specs, "pbi", "checkout.md"))
+	assert.FileExists(t, filepath.Join(specs, "pbi", pbiSpec.TaskID, "pbi.md"))
+
+	result, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Specs, "registered specs are not registered again")
+}
+
+// TestSyncSpecs_FrontMatter verifies that the YAML front matter of a dropped-in spec sets the
+// SBI's metadata, and that an invalid one rejects the spec
+func TestSyncSpecs_FrontMatter(t *testing.T) {
+	ctx := context.Background()
+	uc, sbiRepo, _, specs := newSyncSpecsTest(t)
+	first, err := saveNewSBI(ctx, sbiRepo, "Schema", "Tables", "", sbi.SBIMetadata{})
+	require.NoError(t, err)
+
+	writeSpec(t, filepath.Join(specs, "sbi", "backfill.md"),
+		"---\nlabels: [backend, data]\npriority: 2\nestimated_hours: 1.5\ndepends_on: ["+first.ID().String()+"]\nagent: codex\n---\n# Backfill\n\nCopy the rows.\n")
+	writeSpec(t, filepath.Join(specs, "sbi", "typo.md"), "---\nlabel: backend\n---\n# Typo\n\nBody.\n")
+
+	result, err := uc.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Specs, 2)
+	assert.Equal(t, SpecActionRegistered, result.Specs[0].Action)
+	assert.Equal(t, SpecActionInvalid, result.Specs[1].Action)
+	assert.Contains(t, result.Specs[1].Error, "field label not found")
+
+	registered, err := sbiRepo.Find(ctx, repository.SBIID(result.Specs[0].TaskID))
+	require.NoError(t, err)
+	assert.Equal(t, "Copy the rows.", registered.Description())
+	metadata := registered.Metadata()
+	assert.Equal(t, []string{"backend", "data"}, metadata.Labels)
+	assert.Equal(t, 2, metadata.Priority)
+	assert.Equal(t, 1.5, metadata.EstimatedHours)
+	assert.Equal(t, "codex", metadata.AssignedAgent)
+	dependencies, err := sbiRepo.GetDependencies(ctx, repository.SBIID(result.Specs[0].TaskID))
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.ID().String()}, dependencies)
+}
+
+// TestSyncSpecs_InvalidSpecsReportedOnce verifies that invalid drop-ins stay in place and are
+// reported again only after they change
+func TestSyncSpecs_InvalidSpecsReportedOnce(t *testing.T) {
+	ctx := context.Background()
+	uc, _, _, specs := newSyncSpecsTest(t)
+
+	noTitle := filepath.Join(specs, "sbi", "notes.md")
+	writeSpec(t, noTitle, "just some text\n")
+	unknownParent := filepath.Join(specs, "sbi", "orphan.md")
+	writeSpec(t, unknownParent, "# Orphan\n\nBody.\n\n---\nParent PBI: PBI-404\n")
+
+	result, err := uc.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Specs, 2)
+	assert.Equal(t, 2, result.Count(SpecActionInvalid))
+	assert.Equal(t, "no H1 title found", result.Specs[0].Error)
+	assert.FileExists(t, noTitle)
+	assert.FileExists(t, unknownParent)
+
+	result, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Specs, "unchanged invalid specs are reported once")
+
+	writeSpec(t, noTitle, "# Notes\n\nNow with a title.\n")
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(noTitle, later, later))
+	result, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Specs, 1)
+	assert.Equal(t, SpecActionRegistered, result.Specs[0].Action)
+}
+
+// TestSyncSpecs_UpdatesEditedSpecs verifies that an edited spec.md updates its SBI, while
+// untouched specs and finished SBIs are left alone
+func TestSyncSpecs_UpdatesEditedSpecs(t *testing.T) {
+	ctx := context.Background()
+	uc, sbiRepo, _, specs := newSyncSpecsTest(t)
+
+	task, err := sbi.NewSBI("Search", "Full-text search.", nil, sbi.SBIMetadata{RegisteredAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	specPath := filepath.Join(specs, "sbi", task.ID().String(), "spec.md")
+	writeSpec(t, specPath, "## ガイドライン\n\n---\n\n# Search\n\nFull-text search.\n")
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(specPath, past, past))
+
+	result, err := uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Specs, "specs older than the SBI are not synced")
+
+	writeSpec(t, specPath, "## ガイドライン\n\n---\n\n# Search v2\n\nFull-text search with filters.\n\n---\n\nSee the design doc.\n")
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(specPath, later, later))
+
+	result, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Specs, 1)
+	assert.Equal(t, SpecActionUpdated, result.Specs[0].Action)
+	updated, err := sbiRepo.Find(ctx, repository.SBIID(task.ID().String()))
+	require.NoError(t, err)
+	assert.Equal(t, "Search v2", updated.Title())
+	assert.Equal(t, "Full-text search with filters.\n\n---\n\nSee the design doc.", updated.Description(),
+		"a horizontal rule is only metadata when followed by metadata lines")
+
+	for _, next := range []model.Status{model.StatusPicked, model.StatusImplementing, model.StatusDone} {
+		require.NoError(t, updated.UpdateStatus(next))
+	}
+	require.NoError(t, sbiRepo.Save(ctx, updated))
+	writeSpec(t, specPath, "# Search v3\n\nToo late.\n")
+	later = time.Now().Add(2 * time.Hour)
+	require.NoError(t, os.Chtimes(specPath, later, later))
+
+	result, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Specs, "finished SBIs are not updated")
+}
@@ -105,7 +105,7 @@ func (uc *TaskUseCaseImpl) CreateSBI(ctx context.Context, req dto.CreateSBIReque
 		// Verify parent PBI exists
 		_, err = uc.pbiRepo.Find(ctx, repository.PBIID(*req.ParentPBIID))
 		if err != nil {
-			return nil, errors.New("parent PBI not found")
+			return nil, fmt.Errorf("parent PBI %w: %s", model.ErrNotFound, *req.ParentPBIID)
 		}
 	}
 
@@ -122,6 +122,8 @@ func (uc *TaskUseCaseImpl) CreateSBI(ctx context.Context, req dto.CreateSBIReque
 			FilePaths:      req.FilePaths,
 			DependsOn:      req.DependsOn,
 			OnlyImplement:  req.OnlyImplement,
+			Deadline:       req.Deadline,
+			Env:            req.Env,
 		},
 	)
 	if err != nil {
@@ -212,6 +214,25 @@ func (uc *TaskUseCaseImpl) GetSBI(ctx context.Context, sbiID string) (*dto.SBIDT
 	return uc.sbiToDTO(sbiTask), nil
 }
 
+// GetSBIs retrieves SBIs by ID in a single repository call
+func (uc *TaskUseCaseImpl) GetSBIs(ctx context.Context, sbiIDs []string) ([]*dto.SBIDTO, error) {
+	ids := make([]repository.SBIID, len(sbiIDs))
+	for i, id := range sbiIDs {
+		ids[i] = repository.SBIID(id)
+	}
+
+	sbiTasks, err := uc.sbiRepo.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.SBIDTO, len(sbiTasks))
+	for i, sbiTask := range sbiTasks {
+		result[i] = uc.sbiToDTO(sbiTask)
+	}
+	return result, nil
+}
+
 // ListTasks lists tasks with filters
 func (uc *TaskUseCaseImpl) ListTasks(ctx context.Context, req dto.ListTasksRequest) (*dto.ListTasksResponse, error) {
 	// Convert request to repository filter
@@ -247,6 +268,27 @@ func (uc *TaskUseCaseImpl) ListTasks(ctx context.Context, req dto.ListTasksReque
 	}
 
 	filter.HasParent = req.HasParent
+	filter.Labels = req.Labels
+	filter.AssignedAgents = req.AssignedAgents
+	filter.MinPriority = req.MinPriority
+	filter.MaxPriority = req.MaxPriority
+	filter.MinTurns = req.MinTurns
+	filter.MaxTurns = req.MaxTurns
+	filter.UpdatedAfter = req.UpdatedAfter
+	filter.UpdatedBefore = req.UpdatedBefore
+
+	if req.Sort != "" {
+		sort, err := repository.ParseSortKeys(req.Sort, repository.SBISortFields)
+		if err != nil {
+			return nil, err
+		}
+		filter.Sort = sort
+	}
+
+	if req.Overdue {
+		now := time.Now()
+		filter.OverdueAt = &now
+	}
 
 	// Fetch tasks
 	tasks, err := uc.taskRepo.List(ctx, filter)
@@ -391,9 +433,11 @@ func (uc *TaskUseCaseImpl) sbiToDTO(sbiTask *sbi.SBI) *dto.SBIDTO {
 		RegisteredAt:   sbiTask.RegisteredAt(),
 		StartedAt:      sbiTask.StartedAt(),
 		CompletedAt:    sbiTask.CompletedAt(),
+		Deadline:       sbiTask.Deadline(),
 		Labels:         metadata.Labels,
 		AssignedAgent:  metadata.AssignedAgent,
 		FilePaths:      metadata.FilePaths,
+		Env:            metadata.Env,
 		CurrentTurn:    execState.CurrentTurn.Value(),
 		CurrentAttempt: execState.CurrentAttempt.Value(),
 		MaxTurns:       execState.MaxTurns,
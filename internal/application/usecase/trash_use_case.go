@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// trashDir is the trash store relative to the .deespec directory
+// The files of a trashed item keep their relative path below it (trash/specs/pbi/<id>).
+const trashDir = "trash"
+
+// TrashedItem is an item in the trash with the time it is purged after
+type TrashedItem struct {
+	*repository.TrashItem
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TrashPurgeOptions selects the items to purge
+type TrashPurgeOptions struct {
+	IDs    []string // Purge these items regardless of their age
+	All    bool     // Purge everything in the trash
+	DryRun bool     // If true, only report what would be purged
+}
+
+// TrashUseCase moves deleted PBIs and SBIs to the trash and back
+// A deleted item keeps its database rows (marked deleted and hidden from every lookup),
+// and its spec and report directories move below .deespec/trash. It can be restored until
+// it is purged, which happens once the retention window has passed.
+type TrashUseCase struct {
+	trashRepo repository.TrashRepository
+	homeDir   string // .deespec directory holding specs/, reports/ and trash/
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewTrashUseCase creates a new TrashUseCase keeping deleted items for retention
+func NewTrashUseCase(trashRepo repository.TrashRepository, homeDir string, retention time.Duration) *TrashUseCase {
+	return &TrashUseCase{
+		trashRepo: trashRepo,
+		homeDir:   homeDir,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Delete moves a PBI or SBI to the trash
+func (uc *TrashUseCase) Delete(ctx context.Context, kind repository.TrashKind, id string) error {
+	if err := uc.trashRepo.Trash(ctx, kind, id, uc.now()); err != nil {
+		return err
+	}
+	for _, dir := range trashedDirs(kind, id) {
+		target := uc.trashPath(dir)
+		// A leftover of an earlier purge is replaced
+		if err := os.RemoveAll(target); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", target, err)
+		}
+		if err := moveDir(uc.livePath(dir), target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the items in the trash, most recently deleted first
+func (uc *TrashUseCase) List(ctx context.Context) ([]*TrashedItem, error) {
+	items, err := uc.trashRepo.List(ctx, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return uc.withExpiry(items), nil
+}
+
+// Restore moves an item out of the trash, back into the working set
+// Each step can be repeated: an interrupted restore is completed by restoring again
+func (uc *TrashUseCase) Restore(ctx context.Context, id string) (*TrashedItem, error) {
+	item, err := uc.trashRepo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range trashedDirs(item.Kind, item.ID) {
+		if err := moveDir(uc.trashPath(dir), uc.livePath(dir)); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.trashRepo.Restore(ctx, item.Kind, item.ID); err != nil {
+		return nil, err
+	}
+	return uc.withExpiry([]*repository.TrashItem{item})[0], nil
+}
+
+// Purge permanently removes the selected items: by default those past the retention window
+func (uc *TrashUseCase) Purge(ctx context.Context, opts TrashPurgeOptions) ([]*TrashedItem, error) {
+	var items []*repository.TrashItem
+	switch {
+	case len(opts.IDs) > 0:
+		for _, id := range opts.IDs {
+			item, err := uc.trashRepo.Find(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	case opts.All:
+		all, err := uc.trashRepo.List(ctx, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		items = all
+	default:
+		expired, err := uc.trashRepo.List(ctx, uc.now().Add(-uc.retention))
+		if err != nil {
+			return nil, err
+		}
+		items = expired
+	}
+
+	purged := []*TrashedItem{}
+	for _, item := range uc.withExpiry(items) {
+		if !opts.DryRun {
+			if err := uc.purgeOne(ctx, item.TrashItem); err != nil {
+				return purged, fmt.Errorf("failed to purge %s %s: %w", item.Kind, item.ID, err)
+			}
+		}
+		purged = append(purged, item)
+	}
+	return purged, nil
+}
+
+// purgeOne removes the rows and the trashed files of an item
+func (uc *TrashUseCase) purgeOne(ctx context.Context, item *repository.TrashItem) error {
+	if err := uc.trashRepo.Purge(ctx, item.Kind, item.ID); err != nil {
+		return err
+	}
+	for _, dir := range trashedDirs(item.Kind, item.ID) {
+		if err := os.RemoveAll(uc.trashPath(dir)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// withExpiry adds the purge time to items
+func (uc *TrashUseCase) withExpiry(items []*repository.TrashItem) []*TrashedItem {
+	result := make([]*TrashedItem, len(items))
+	for i, item := range items {
+		result[i] = &TrashedItem{TrashItem: item, ExpiresAt: item.DeletedAt.Add(uc.retention)}
+	}
+	return result
+}
+
+// livePath returns the working tree path of a directory relative to the .deespec directory
+func (uc *TrashUseCase) livePath(dir string) string {
+	return filepath.Join(uc.homeDir, filepath.FromSlash(dir))
+}
+
+// trashPath returns the path a directory is kept at while its item is in the trash
+func (uc *TrashUseCase) trashPath(dir string) string {
+	return filepath.Join(uc.homeDir, trashDir, filepath.FromSlash(dir))
+}
+
+// trashedDirs returns the directories of an item, relative to the .deespec directory
+func trashedDirs(kind repository.TrashKind, id string) []string {
+	if kind == repository.TrashKindSBI {
+		return sbiSnapshotDirs(id)
+	}
+	return []string{path.Join("specs", "pbi", id)}
+}
+
+// moveDir moves a directory, doing nothing when it was already moved (or never existed)
+// Fails when both the source and the target exist, rather than overwriting the target
+func moveDir(from, to string) error {
+	if _, err := os.Stat(from); errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", from, err)
+	}
+	if _, err := os.Stat(to); err == nil {
+		return fmt.Errorf("cannot move %s: %s already exists", from, to)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", to, err)
+	}
+	if err := os.Rename(from, to); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", from, to, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+// TestTrash_DeleteRestorePurge verifies that a deleted SBI's rows and files survive in the
+// trash until it is restored, and are removed once purged after the retention window
+func TestTrash_DeleteRestorePurge(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	home := t.TempDir()
+	sbiRepo := sqlite.NewSBIRepository(db)
+	uc := NewTrashUseCase(sqlite.NewTrashRepository(db), home, 30*24*time.Hour)
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	task, err := sbi.NewSBI("Login form", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+	id := task.ID().String()
+	specPath := filepath.Join(home, "specs", "sbi", id, "spec.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(specPath), 0755))
+	require.NoError(t, os.WriteFile(specPath, []byte("# Login form"), 0644))
+
+	require.NoError(t, uc.Delete(ctx, repository.TrashKindSBI, id))
+	_, err = sbiRepo.Find(ctx, repository.SBIID(id))
+	assert.Error(t, err, "a deleted SBI leaves the working set")
+	assert.NoFileExists(t, specPath)
+	assert.FileExists(t, filepath.Join(home, "trash", "specs", "sbi", id, "spec.md"))
+
+	items, err := uc.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, id, items[0].ID)
+	assert.True(t, now.AddDate(0, 0, 30).Equal(items[0].ExpiresAt))
+
+	restored, err := uc.Restore(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Login form", restored.Title)
+	_, err = sbiRepo.Find(ctx, repository.SBIID(id))
+	require.NoError(t, err)
+	assert.FileExists(t, specPath)
+
+	// Within the retention window nothing is purged
+	require.NoError(t, uc.Delete(ctx, repository.TrashKindSBI, id))
+	now = now.AddDate(0, 0, 29)
+	purged, err := uc.Purge(ctx, TrashPurgeOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	now = now.AddDate(0, 0, 2)
+	purged, err = uc.Purge(ctx, TrashPurgeOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	_, err = uc.Restore(ctx, "unknown")
+	assert.True(t, errors.Is(err, repository.ErrTrashItemNotFound))
+
+	purged, err = uc.Purge(ctx, TrashPurgeOptions{})
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.NoDirExists(t, filepath.Join(home, "trash", "specs", "sbi", id))
+	_, err = uc.Restore(ctx, id)
+	assert.True(t, errors.Is(err, repository.ErrTrashItemNotFound), "purged items cannot be restored")
+}
+
+// TestTrash_RestoreKeepsExistingFiles verifies that a restore never overwrites files
+// created in the working tree since the deletion
+func TestTrash_RestoreKeepsExistingFiles(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, sqlite.NewMigrator(db).Migrate())
+
+	home := t.TempDir()
+	uc := NewTrashUseCase(sqlite.NewTrashRepository(db), home, time.Hour)
+	_, err = db.Exec(`INSERT INTO pbis (id, title, status, current_step, created_at, updated_at) VALUES ('PBI-001', 'Auth', 'pending', 'planning', ?, ?)`,
+		time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339))
+	require.NoError(t, err)
+	pbiPath := filepath.Join(home, "specs", "pbi", "PBI-001", "pbi.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(pbiPath), 0755))
+	require.NoError(t, os.WriteFile(pbiPath, []byte("# Auth"), 0644))
+
+	require.NoError(t, uc.Delete(ctx, repository.TrashKindPBI, "PBI-001"))
+	require.NoError(t, os.MkdirAll(filepath.Dir(pbiPath), 0755))
+	require.NoError(t, os.WriteFile(pbiPath, []byte("# Rewritten"), 0644))
+
+	_, err = uc.Restore(ctx, "PBI-001")
+	require.Error(t, err)
+	data, err := os.ReadFile(pbiPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Rewritten", string(data))
+	item, err := uc.trashRepo.Find(ctx, "PBI-001")
+	require.NoError(t, err, "the PBI stays in the trash")
+	assert.Equal(t, repository.TrashKindPBI, item.Kind)
+}
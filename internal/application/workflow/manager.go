@@ -2,12 +2,14 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 )
 
@@ -314,7 +316,8 @@ func (wm *WorkflowManager) runWorkflowLoop(runner WorkflowRunner, config Workflo
 			stats.LastError = err
 			consecutiveErrors++
 			// Check if it's a lock contention error
-			isLockError := strings.Contains(err.Error(), "another instance") ||
+			isLockError := errors.Is(err, model.ErrLockHeld) ||
+				strings.Contains(err.Error(), "another instance") ||
 				strings.Contains(err.Error(), "another process is running") ||
 				strings.Contains(err.Error(), "state.lock: file exists")
 
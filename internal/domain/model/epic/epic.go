@@ -111,6 +111,14 @@ func (e *EPIC) UpdatedAt() model.Timestamp {
 	return e.base.UpdatedAt()
 }
 
+func (e *EPIC) Version() int {
+	return e.base.Version()
+}
+
+func (e *EPIC) SetVersion(version int) {
+	e.base.SetVersion(version)
+}
+
 func (e *EPIC) UpdateStatus(newStatus model.Status) error {
 	return e.base.UpdateStatus(newStatus)
 }
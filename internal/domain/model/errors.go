@@ -0,0 +1,45 @@
+package model
+
+import "errors"
+
+// Error kinds shared by the use cases
+// Use cases wrap these with fmt.Errorf("SBI %w: %s", model.ErrNotFound, id) so that the CLI and
+// the API server can tell user errors from system errors with errors.Is, whatever the message.
+var (
+	// ErrNotFound: the requested task, lock or other record does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidState: the record exists but its status does not allow the operation
+	ErrInvalidState = errors.New("invalid state")
+	// ErrLockHeld: another process holds the lock the operation needs
+	ErrLockHeld = errors.New("lock held")
+	// ErrAgentUnavailable: the agent cannot be reached (binary missing, API key unset, server down)
+	ErrAgentUnavailable = errors.New("agent unavailable")
+)
+
+// Error codes reported in JSON error payloads
+const (
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeInvalidState     = "invalid_state"
+	ErrorCodeLockHeld         = "lock_held"
+	ErrorCodeAgentUnavailable = "agent_unavailable"
+	ErrorCodeInternal         = "internal"
+)
+
+// ErrorCode returns the error code of err, or "" when err is nil
+// Errors that wrap none of the error kinds above are system errors ("internal").
+func ErrorCode(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotFound):
+		return ErrorCodeNotFound
+	case errors.Is(err, ErrInvalidState):
+		return ErrorCodeInvalidState
+	case errors.Is(err, ErrLockHeld):
+		return ErrorCodeLockHeld
+	case errors.Is(err, ErrAgentUnavailable):
+		return ErrorCodeAgentUnavailable
+	default:
+		return ErrorCodeInternal
+	}
+}
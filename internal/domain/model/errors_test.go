@@ -0,0 +1,31 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{fmt.Errorf("SBI %w: SBI-1", ErrNotFound), ErrorCodeNotFound},
+		{fmt.Errorf("cancel: %w", fmt.Errorf("%w: SBI-1 is DONE", ErrInvalidState)), ErrorCodeInvalidState},
+		{fmt.Errorf("%w: another instance is already running", ErrLockHeld), ErrorCodeLockHeld},
+		{fmt.Errorf("%w: claude not in PATH", ErrAgentUnavailable), ErrorCodeAgentUnavailable},
+		{errors.New("disk full"), ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("ErrorCode(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+
+	if msg := fmt.Errorf("SBI %w: SBI-1", ErrNotFound).Error(); msg != "SBI not found: SBI-1" {
+		t.Errorf("wrapped message = %q", msg)
+	}
+}
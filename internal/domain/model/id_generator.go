@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// ID schemes of new tasks
+const (
+	IDSchemeULID         = "ulid"          // 01JB6X8Y2K9FQR4T3VWHGP5M2C (default)
+	IDSchemeDateSequence = "date_sequence" // SBI-2024-0153: type prefix, year and a sequence per year
+	IDSchemePrefix       = "prefix"        // SBI-0153: type prefix and a sequence
+)
+
+// idPrefixPattern restricts ID prefixes to what is safe in file paths and branch names
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,15}$`)
+
+// ValidateIDPrefix checks that an ID prefix is safe to use in file paths and branch names
+func ValidateIDPrefix(prefix string) error {
+	if !idPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("must start with a letter and contain only letters, digits and '_' (at most 16), got %q", prefix)
+	}
+	return nil
+}
+
+// IDGenerator generates the IDs of new EPICs, PBIs and SBIs
+type IDGenerator interface {
+	GenerateID(taskType TaskType) (string, error)
+}
+
+var (
+	idGeneratorMu sync.RWMutex
+	idGenerator   IDGenerator
+)
+
+// SetIDGenerator sets how the IDs of new tasks are generated; nil restores ULIDs
+func SetIDGenerator(g IDGenerator) {
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	idGenerator = g
+}
+
+// NewTaskIDFor creates the ID of a new task of a type with the configured generator
+// Without a generator the ID is a ULID.
+func NewTaskIDFor(taskType TaskType) (TaskID, error) {
+	idGeneratorMu.RLock()
+	g := idGenerator
+	idGeneratorMu.RUnlock()
+	if g == nil {
+		return NewTaskID(), nil
+	}
+	id, err := g.GenerateID(taskType)
+	if err != nil {
+		return TaskID{}, fmt.Errorf("failed to generate %s ID: %w", taskType, err)
+	}
+	return NewTaskIDFromString(id)
+}
@@ -0,0 +1,140 @@
+package label
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Routable workflow steps
+const (
+	RouteStepImplement = "implement"
+	RouteStepReview    = "review"
+)
+
+// RoutableAgents lists the agent types a route can pin
+var RoutableAgents = []string{"claude-code", "claude-code-cli", "gemini-cli", "codex", "anthropic-api", "openai-api", "gemini-api", "ollama"}
+
+// metadataKeyAgentRouting is the key under which agent routing is stored in label metadata
+const metadataKeyAgentRouting = "agent_routing"
+
+// AgentRoute pins which agent and model handle a workflow step for a label
+// Empty fields mean "use the default" for that part of the route
+type AgentRoute struct {
+	Agent string `json:"agent,omitempty"` // Agent type (e.g., "claude-code-cli", "gemini-cli", "codex")
+	Model string `json:"model,omitempty"` // Model identifier passed to the agent (e.g., "claude-sonnet-4-5")
+}
+
+// IsZero reports whether the route pins nothing
+func (r AgentRoute) IsZero() bool {
+	return r.Agent == "" && r.Model == ""
+}
+
+// IsRoutableStep checks if the step can carry an agent route
+func IsRoutableStep(step string) bool {
+	return step == RouteStepImplement || step == RouteStepReview
+}
+
+// IsRoutableAgent checks if the agent type can be pinned by a route
+func IsRoutableAgent(agent string) bool {
+	for _, known := range RoutableAgents {
+		if agent == known {
+			return true
+		}
+	}
+	return false
+}
+
+// AgentRoutes returns all agent routes pinned by this label (step -> route)
+// Returns an empty map if metadata is empty or does not contain routing
+func (l *Label) AgentRoutes() map[string]AgentRoute {
+	routes := make(map[string]AgentRoute)
+
+	meta, err := l.parseMetadata()
+	if err != nil {
+		return routes
+	}
+
+	raw, ok := meta[metadataKeyAgentRouting]
+	if !ok {
+		return routes
+	}
+
+	if err := json.Unmarshal(raw, &routes); err != nil {
+		return make(map[string]AgentRoute)
+	}
+	return routes
+}
+
+// AgentRoute returns the agent route pinned for a step
+func (l *Label) AgentRoute(step string) (AgentRoute, bool) {
+	route, ok := l.AgentRoutes()[step]
+	if !ok || route.IsZero() {
+		return AgentRoute{}, false
+	}
+	return route, true
+}
+
+// SetAgentRoute pins an agent route for a step
+// Passing a zero route removes the pin for that step
+// Other metadata keys are preserved as-is
+func (l *Label) SetAgentRoute(step string, route AgentRoute) error {
+	if !IsRoutableStep(step) {
+		return fmt.Errorf("invalid step for agent route: %s (must be %s or %s)", step, RouteStepImplement, RouteStepReview)
+	}
+	if route.Agent != "" && !IsRoutableAgent(route.Agent) {
+		return fmt.Errorf("unknown agent for agent route: %s (must be one of %s)", route.Agent, strings.Join(RoutableAgents, ", "))
+	}
+
+	meta, err := l.parseMetadata()
+	if err != nil {
+		return fmt.Errorf("invalid label metadata: %w", err)
+	}
+
+	routes := l.AgentRoutes()
+	if route.IsZero() {
+		delete(routes, step)
+	} else {
+		routes[step] = route
+	}
+
+	if len(routes) == 0 {
+		delete(meta, metadataKeyAgentRouting)
+	} else {
+		data, err := json.Marshal(routes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal agent routing: %w", err)
+		}
+		meta[metadataKeyAgentRouting] = data
+	}
+
+	return l.saveMetadata(meta)
+}
+
+// parseMetadata decodes the metadata JSON object, keeping unknown keys raw
+func (l *Label) parseMetadata() (map[string]json.RawMessage, error) {
+	meta := make(map[string]json.RawMessage)
+	if l.metadata == "" {
+		return meta, nil
+	}
+	if err := json.Unmarshal([]byte(l.metadata), &meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// saveMetadata encodes the metadata JSON object, clearing the metadata when no key is left
+func (l *Label) saveMetadata(meta map[string]json.RawMessage) error {
+	if len(meta) == 0 {
+		l.metadata = ""
+	} else {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("failed to marshal label metadata: %w", err)
+		}
+		l.metadata = string(data)
+	}
+	l.updatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,110 @@
+package label
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLabel_SetAgentRoute(t *testing.T) {
+	lbl := NewLabel("frontend", "Frontend work", nil, 1)
+
+	if err := lbl.SetAgentRoute(RouteStepImplement, AgentRoute{Agent: "claude-code-cli", Model: "claude-sonnet"}); err != nil {
+		t.Fatalf("SetAgentRoute() error = %v", err)
+	}
+	if err := lbl.SetAgentRoute(RouteStepReview, AgentRoute{Agent: "codex", Model: "gpt"}); err != nil {
+		t.Fatalf("SetAgentRoute() error = %v", err)
+	}
+
+	route, ok := lbl.AgentRoute(RouteStepImplement)
+	if !ok {
+		t.Fatal("AgentRoute(implement) not found")
+	}
+	if route.Agent != "claude-code-cli" || route.Model != "claude-sonnet" {
+		t.Errorf("AgentRoute(implement) = %+v", route)
+	}
+
+	route, ok = lbl.AgentRoute(RouteStepReview)
+	if !ok {
+		t.Fatal("AgentRoute(review) not found")
+	}
+	if route.Agent != "codex" || route.Model != "gpt" {
+		t.Errorf("AgentRoute(review) = %+v", route)
+	}
+}
+
+func TestLabel_SetAgentRoute_InvalidStep(t *testing.T) {
+	lbl := NewLabel("frontend", "", nil, 0)
+
+	if err := lbl.SetAgentRoute("done", AgentRoute{Agent: "codex"}); err == nil {
+		t.Error("SetAgentRoute() should reject non-routable step")
+	}
+}
+
+func TestLabel_SetAgentRoute_UnknownAgent(t *testing.T) {
+	lbl := NewLabel("frontend", "", nil, 0)
+
+	if err := lbl.SetAgentRoute(RouteStepImplement, AgentRoute{Agent: "claude"}); err == nil {
+		t.Error("SetAgentRoute() should reject unknown agent")
+	}
+	if _, ok := lbl.AgentRoute(RouteStepImplement); ok {
+		t.Error("AgentRoute(implement) should not be set")
+	}
+
+	// A model-only route keeps the default agent
+	if err := lbl.SetAgentRoute(RouteStepImplement, AgentRoute{Model: "claude-sonnet"}); err != nil {
+		t.Errorf("SetAgentRoute() error = %v", err)
+	}
+}
+
+func TestLabel_SetAgentRoute_RemoveWithZeroRoute(t *testing.T) {
+	lbl := NewLabel("frontend", "", nil, 0)
+
+	_ = lbl.SetAgentRoute(RouteStepImplement, AgentRoute{Agent: "codex"})
+	if err := lbl.SetAgentRoute(RouteStepImplement, AgentRoute{}); err != nil {
+		t.Fatalf("SetAgentRoute() error = %v", err)
+	}
+
+	if _, ok := lbl.AgentRoute(RouteStepImplement); ok {
+		t.Error("AgentRoute(implement) should be removed")
+	}
+	if lbl.Metadata() != "" {
+		t.Errorf("Metadata() = %q, want empty", lbl.Metadata())
+	}
+}
+
+func TestLabel_SetAgentRoute_PreservesOtherMetadata(t *testing.T) {
+	lbl := ReconstructLabel(
+		1, "frontend", "", nil, nil, nil, "", 0, true,
+		0, time.Now(), `{"owner":"web-team"}`, time.Now(), time.Now(),
+	)
+
+	if err := lbl.SetAgentRoute(RouteStepReview, AgentRoute{Model: "gpt"}); err != nil {
+		t.Fatalf("SetAgentRoute() error = %v", err)
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal([]byte(lbl.Metadata()), &meta); err != nil {
+		t.Fatalf("metadata is not valid JSON: %v", err)
+	}
+	if meta["owner"] != "web-team" {
+		t.Errorf("owner metadata lost: %v", meta)
+	}
+	if _, ok := meta["agent_routing"]; !ok {
+		t.Errorf("agent_routing missing: %v", meta)
+	}
+}
+
+func TestLabel_AgentRoutes_InvalidMetadata(t *testing.T) {
+	lbl := ReconstructLabel(
+		1, "broken", "", nil, nil, nil, "", 0, true,
+		0, time.Now(), "not-json", time.Now(), time.Now(),
+	)
+
+	if routes := lbl.AgentRoutes(); len(routes) != 0 {
+		t.Errorf("AgentRoutes() = %v, want empty", routes)
+	}
+	if err := lbl.SetAgentRoute(RouteStepImplement, AgentRoute{Agent: "codex"}); err == nil {
+		t.Error("SetAgentRoute() should fail on invalid metadata")
+	}
+}
@@ -0,0 +1,70 @@
+package label
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// metadataKeyMCPServers is the key under which the MCP servers of a label are stored in label metadata
+const metadataKeyMCPServers = "mcp_servers"
+
+// mcpServerNamePattern matches the names of MCP servers configured in setting.json ("mcp.servers")
+var mcpServerNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// IsValidMCPServerName checks if a name can refer to an MCP server
+func IsValidMCPServerName(name string) bool {
+	return mcpServerNamePattern.MatchString(name)
+}
+
+// MCPServers returns the names of the MCP servers enabled for tasks carrying this label, sorted
+// Returns nil if metadata is empty or does not enable any server
+func (l *Label) MCPServers() []string {
+	meta, err := l.parseMetadata()
+	if err != nil {
+		return nil
+	}
+	raw, ok := meta[metadataKeyMCPServers]
+	if !ok {
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(raw, &names); err != nil {
+		return nil
+	}
+	return names
+}
+
+// SetMCPServers enables MCP servers, by their names in setting.json, for tasks carrying this label
+// Passing no names removes them; other metadata keys are preserved as-is
+func (l *Label) SetMCPServers(names []string) error {
+	unique := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !IsValidMCPServerName(name) {
+			return fmt.Errorf("invalid MCP server name: %q (letters, digits, '_', '.' and '-' only)", name)
+		}
+		unique[name] = true
+	}
+
+	meta, err := l.parseMetadata()
+	if err != nil {
+		return fmt.Errorf("invalid label metadata: %w", err)
+	}
+
+	if len(unique) == 0 {
+		delete(meta, metadataKeyMCPServers)
+	} else {
+		sorted := make([]string, 0, len(unique))
+		for name := range unique {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		data, err := json.Marshal(sorted)
+		if err != nil {
+			return fmt.Errorf("failed to marshal MCP servers: %w", err)
+		}
+		meta[metadataKeyMCPServers] = data
+	}
+	return l.saveMetadata(meta)
+}
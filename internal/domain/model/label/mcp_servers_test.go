@@ -0,0 +1,38 @@
+package label
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabel_SetMCPServers(t *testing.T) {
+	lbl := NewLabel("backend", "", nil, 0)
+	if err := lbl.SetAgentRoute(RouteStepImplement, AgentRoute{Model: "claude-sonnet"}); err != nil {
+		t.Fatalf("SetAgentRoute() error = %v", err)
+	}
+
+	if err := lbl.SetMCPServers([]string{"db-schema", "context7", "db-schema"}); err != nil {
+		t.Fatalf("SetMCPServers() error = %v", err)
+	}
+	if got, want := lbl.MCPServers(), []string{"context7", "db-schema"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MCPServers() = %v, want %v", got, want)
+	}
+	if _, ok := lbl.AgentRoute(RouteStepImplement); !ok {
+		t.Error("SetMCPServers() should preserve the agent route")
+	}
+
+	if err := lbl.SetMCPServers([]string{"bad name"}); err == nil {
+		t.Error("SetMCPServers() should reject invalid names")
+	}
+
+	if err := lbl.SetMCPServers(nil); err != nil {
+		t.Fatalf("SetMCPServers(nil) error = %v", err)
+	}
+	if got := lbl.MCPServers(); got != nil {
+		t.Errorf("MCPServers() = %v, want nil", got)
+	}
+	_ = lbl.SetAgentRoute(RouteStepImplement, AgentRoute{})
+	if lbl.Metadata() != "" {
+		t.Errorf("Metadata() = %q, want empty", lbl.Metadata())
+	}
+}
@@ -0,0 +1,84 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale selects the language of built-in templates and SBI spec section names
+type Locale string
+
+// Supported locales
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+)
+
+// Default is the locale used when none is configured
+const Default = Japanese
+
+// Supported returns all supported locales
+func Supported() []Locale {
+	return []Locale{Japanese, English}
+}
+
+// Parse converts a locale string (e.g. "en", "EN", "en_US") to a Locale
+// An empty string resolves to Default
+func Parse(s string) (Locale, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return Default, nil
+	}
+	// Accept region-qualified forms such as "en-US" or "ja_JP"
+	if i := strings.IndexAny(s, "-_"); i > 0 {
+		s = s[:i]
+	}
+	for _, l := range Supported() {
+		if string(l) == s {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported locale %q (supported: ja, en)", s)
+}
+
+// String returns the locale code
+func (l Locale) String() string {
+	return string(l)
+}
+
+// SBISections holds the Markdown headings an SBI spec file must contain
+type SBISections struct {
+	Overview           string // e.g. "## 概要"
+	Details            string // e.g. "## タスク詳細"
+	AcceptanceCriteria string // e.g. "## 受け入れ基準"
+	EstimatedHours     string // e.g. "## 推定工数"
+}
+
+// Required returns the headings in the order they appear in an SBI spec
+func (s SBISections) Required() []string {
+	return []string{s.Overview, s.Details, s.AcceptanceCriteria, s.EstimatedHours}
+}
+
+var sbiSections = map[Locale]SBISections{
+	Japanese: {
+		Overview:           "## 概要",
+		Details:            "## タスク詳細",
+		AcceptanceCriteria: "## 受け入れ基準",
+		EstimatedHours:     "## 推定工数",
+	},
+	English: {
+		Overview:           "## Overview",
+		Details:            "## Task Details",
+		AcceptanceCriteria: "## Acceptance Criteria",
+		EstimatedHours:     "## Estimated Hours",
+	},
+}
+
+// SBISections returns the SBI spec section headings for this locale
+// Unknown locales fall back to Default
+func (l Locale) SBISections() SBISections {
+	if s, ok := sbiSections[l]; ok {
+		return s
+	}
+	return sbiSections[Default]
+}
@@ -0,0 +1,37 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Locale
+	}{
+		{"", Japanese},
+		{"ja", Japanese},
+		{"en", English},
+		{" EN ", English},
+		{"en_US", English},
+		{"ja-JP", Japanese},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	_, err := Parse("fr")
+	assert.Error(t, err)
+}
+
+func TestLocale_SBISections(t *testing.T) {
+	assert.Equal(t, "## 概要", Japanese.SBISections().Overview)
+	assert.Equal(t, "## Overview", English.SBISections().Overview)
+	assert.Equal(t, Japanese.SBISections(), Locale("xx").SBISections())
+	assert.Len(t, English.SBISections().Required(), 4)
+}
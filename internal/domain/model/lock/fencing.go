@@ -0,0 +1,69 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrStaleFencingToken is returned when a write carries a fencing token older than
+// the newest token issued for its lock, i.e. the lease expired and another runner took over
+var ErrStaleFencingToken = errors.New("stale fencing token")
+
+// Fence identifies the lock holder on whose behalf a write is made
+// Tokens increase monotonically per lock ID, so a higher token always means a newer holder
+type Fence struct {
+	LockID LockID
+	Token  int64
+}
+
+type fenceContextKey struct{}
+
+// WithFence returns a context whose repository writes are guarded by the given fence
+func WithFence(ctx context.Context, fence Fence) context.Context {
+	return context.WithValue(ctx, fenceContextKey{}, fence)
+}
+
+// FenceFromContext returns the fence attached by WithFence, if any
+func FenceFromContext(ctx context.Context) (Fence, bool) {
+	fence, ok := ctx.Value(fenceContextKey{}).(Fence)
+	if !ok || fence.Token <= 0 {
+		return Fence{}, false
+	}
+	return fence, true
+}
+
+// Environment variables carrying a fence to agent subprocesses, so that writes made by
+// `deespec sbi report` from inside a step are fenced like the runner's own writes
+const (
+	EnvFenceLockID = "DEESPEC_FENCE_LOCK_ID"
+	EnvFenceToken  = "DEESPEC_FENCE_TOKEN"
+)
+
+// Env returns the environment variables that propagate the fence to a subprocess
+func (f Fence) Env() map[string]string {
+	return map[string]string{
+		EnvFenceLockID: f.LockID.String(),
+		EnvFenceToken:  strconv.FormatInt(f.Token, 10),
+	}
+}
+
+// FenceFromEnv reads a fence propagated by Fence.Env using getenv (e.g. os.Getenv)
+// Returns false when no fence is set
+func FenceFromEnv(getenv func(string) string) (Fence, bool, error) {
+	rawID, rawToken := getenv(EnvFenceLockID), getenv(EnvFenceToken)
+	if rawID == "" && rawToken == "" {
+		return Fence{}, false, nil
+	}
+
+	lockID, err := NewLockID(rawID)
+	if err != nil {
+		return Fence{}, false, fmt.Errorf("invalid %s: %w", EnvFenceLockID, err)
+	}
+	token, err := strconv.ParseInt(rawToken, 10, 64)
+	if err != nil || token <= 0 {
+		return Fence{}, false, fmt.Errorf("invalid %s: %q", EnvFenceToken, rawToken)
+	}
+	return Fence{LockID: lockID, Token: token}, true, nil
+}
@@ -1,13 +1,14 @@
 package lock
 
 import (
-	"errors"
 	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 )
 
 // Common lock errors
 var (
-	ErrLockNotFound = errors.New("lock not found")
+	ErrLockNotFound = fmt.Errorf("lock %w", model.ErrNotFound)
 )
 
 // LockID is a value object representing a unique lock identifier
@@ -9,13 +9,14 @@ import (
 // RunLock represents an execution lock for SBI tasks
 // It ensures only one process can execute a specific SBI at a time
 type RunLock struct {
-	lockID      LockID
-	pid         int
-	hostname    string
-	acquiredAt  time.Time
-	expiresAt   time.Time
-	heartbeatAt time.Time
-	metadata    map[string]string
+	lockID       LockID
+	pid          int
+	hostname     string
+	acquiredAt   time.Time
+	expiresAt    time.Time
+	heartbeatAt  time.Time
+	metadata     map[string]string
+	fencingToken int64 // Monotonically increasing per lock ID; 0 until persisted
 }
 
 // NewRunLock creates a new run lock
@@ -92,6 +93,16 @@ func (l *RunLock) GetMetadata(key string) (string, bool) {
 	return value, exists
 }
 
+// SetFencingToken records the fencing token issued by the repository on acquisition
+func (l *RunLock) SetFencingToken(token int64) {
+	l.fencingToken = token
+}
+
+// Fence returns the fence that guards writes made while holding this lock
+func (l *RunLock) Fence() Fence {
+	return Fence{LockID: l.lockID, Token: l.fencingToken}
+}
+
 // Getters
 func (l *RunLock) LockID() LockID               { return l.lockID }
 func (l *RunLock) PID() int                     { return l.pid }
@@ -101,3 +112,4 @@ func (l *RunLock) ExpiresAt() time.Time         { return l.expiresAt }
 func (l *RunLock) HeartbeatAt() time.Time       { return l.heartbeatAt }
 func (l *RunLock) Metadata() map[string]string  { return l.metadata }
 func (l *RunLock) RemainingTime() time.Duration { return time.Until(l.expiresAt) }
+func (l *RunLock) FencingToken() int64          { return l.fencingToken }
@@ -17,13 +17,14 @@ const (
 // StateLock represents a lock for state file access
 // It supports both read and write locks for state file operations
 type StateLock struct {
-	lockID      LockID
-	pid         int
-	hostname    string
-	acquiredAt  time.Time
-	expiresAt   time.Time
-	heartbeatAt time.Time
-	lockType    LockType
+	lockID       LockID
+	pid          int
+	hostname     string
+	acquiredAt   time.Time
+	expiresAt    time.Time
+	heartbeatAt  time.Time
+	lockType     LockType
+	fencingToken int64 // Monotonically increasing per lock ID; 0 until persisted
 }
 
 // NewStateLock creates a new state lock
@@ -90,6 +91,16 @@ func (l *StateLock) Extend(duration time.Duration) {
 	l.expiresAt = l.expiresAt.Add(duration)
 }
 
+// SetFencingToken records the fencing token issued by the repository on acquisition
+func (l *StateLock) SetFencingToken(token int64) {
+	l.fencingToken = token
+}
+
+// Fence returns the fence that guards writes made while holding this lock
+func (l *StateLock) Fence() Fence {
+	return Fence{LockID: l.lockID, Token: l.fencingToken}
+}
+
 // Getters
 func (l *StateLock) LockID() LockID               { return l.lockID }
 func (l *StateLock) PID() int                     { return l.pid }
@@ -99,3 +110,4 @@ func (l *StateLock) ExpiresAt() time.Time         { return l.expiresAt }
 func (l *StateLock) HeartbeatAt() time.Time       { return l.heartbeatAt }
 func (l *StateLock) LockType() LockType           { return l.lockType }
 func (l *StateLock) RemainingTime() time.Duration { return time.Until(l.expiresAt) }
+func (l *StateLock) FencingToken() int64          { return l.fencingToken }
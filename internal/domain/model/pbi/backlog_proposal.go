@@ -0,0 +1,119 @@
+package pbi
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of backlog drafts
+const (
+	DraftKindEPIC = "epic"
+	DraftKindPBI  = "pbi"
+)
+
+// Categories of backlog drafts proposed by a codebase analysis
+const (
+	DraftCategoryArchitecture = "architecture" // An architecture area to consolidate or restructure
+	DraftCategoryTests        = "tests"        // Missing or weak tests
+	DraftCategoryTODO         = "todo"         // TODO/FIXME comments found in the code
+)
+
+// BacklogDraft is one proposed EPIC or PBI awaiting a human decision
+// The draft's Markdown file holds its title (H1) and body; the record holds its metadata.
+type BacklogDraft struct {
+	File                 string            `yaml:"file"`
+	Kind                 string            `yaml:"kind"`             // DraftKindEPIC or DraftKindPBI
+	Parent               string            `yaml:"parent,omitempty"` // File of the parent EPIC draft (PBIs only)
+	Title                string            `yaml:"title"`
+	Category             string            `yaml:"category,omitempty"`
+	EstimatedStoryPoints int               `yaml:"story_points,omitempty"`
+	Priority             int               `yaml:"priority,omitempty"`
+	Status               SBIApprovalStatus `yaml:"status"`
+	ReviewedAt           *time.Time        `yaml:"reviewed_at,omitempty"`
+	RejectionReason      string            `yaml:"rejection_reason,omitempty"`
+	RegisteredID         string            `yaml:"registered_id,omitempty"` // ID of the created EPIC or PBI (empty until registered)
+}
+
+// IsApproved reports whether the draft was approved (possibly after editing)
+func (d *BacklogDraft) IsApproved() bool {
+	return d.Status == ApprovalStatusApproved || d.Status == ApprovalStatusEdited
+}
+
+// BacklogProposal is the initial EPIC/PBI backlog proposed by `deespec analyze`
+// Drafts start pending; approved drafts are registered as EPICs and PBIs.
+type BacklogProposal struct {
+	GeneratedAt time.Time      `yaml:"generated_at"`
+	Drafts      []BacklogDraft `yaml:"drafts"` // EPICs each followed by their PBIs; PBIs without an EPIC last
+}
+
+// NewBacklogProposal creates a proposal of pending drafts
+func NewBacklogProposal(drafts []BacklogDraft) *BacklogProposal {
+	for i := range drafts {
+		drafts[i].Status = ApprovalStatusPending
+	}
+	return &BacklogProposal{
+		GeneratedAt: time.Now(),
+		Drafts:      drafts,
+	}
+}
+
+// Find returns the draft of a file, or nil when there is none
+func (p *BacklogProposal) Find(file string) *BacklogDraft {
+	for i := range p.Drafts {
+		if p.Drafts[i].File == file {
+			return &p.Drafts[i]
+		}
+	}
+	return nil
+}
+
+// Review records a decision on a draft; reason is kept for rejections
+// Registered drafts can no longer be reviewed.
+func (p *BacklogProposal) Review(file string, status SBIApprovalStatus, reason string, at time.Time) error {
+	draft := p.Find(file)
+	if draft == nil {
+		return fmt.Errorf("no draft %s in the proposal", file)
+	}
+	if draft.RegisteredID != "" {
+		return fmt.Errorf("draft %s is already registered as %s", file, draft.RegisteredID)
+	}
+	draft.Status = status
+	draft.ReviewedAt = &at
+	draft.RejectionReason = ""
+	if status == ApprovalStatusRejected {
+		draft.RejectionReason = reason
+	}
+	return nil
+}
+
+// PendingCount returns the number of drafts awaiting a decision
+func (p *BacklogProposal) PendingCount() int {
+	count := 0
+	for _, draft := range p.Drafts {
+		if draft.Status == ApprovalStatusPending {
+			count++
+		}
+	}
+	return count
+}
+
+// Unregistered returns the approved drafts not registered yet, in proposal order
+func (p *BacklogProposal) Unregistered() []*BacklogDraft {
+	var drafts []*BacklogDraft
+	for i := range p.Drafts {
+		if p.Drafts[i].IsApproved() && p.Drafts[i].RegisteredID == "" {
+			drafts = append(drafts, &p.Drafts[i])
+		}
+	}
+	return drafts
+}
+
+// Settled reports whether every draft was rejected or registered, so a new proposal may replace it
+func (p *BacklogProposal) Settled() bool {
+	for _, draft := range p.Drafts {
+		if draft.Status != ApprovalStatusRejected && draft.RegisteredID == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package pbi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBacklogProposal_Review(t *testing.T) {
+	proposal := NewBacklogProposal([]BacklogDraft{
+		{File: "epic-01.md", Kind: DraftKindEPIC, Title: "Persistence layer"},
+		{File: "epic-01-pbi-01.md", Kind: DraftKindPBI, Parent: "epic-01.md", Title: "Test the SQLite repositories"},
+		{File: "pbi-01.md", Kind: DraftKindPBI, Title: "Resolve TODOs in the CLI"},
+	})
+	if proposal.PendingCount() != 3 {
+		t.Fatalf("PendingCount() = %d, want 3", proposal.PendingCount())
+	}
+
+	now := time.Now()
+	if err := proposal.Review("epic-01.md", ApprovalStatusApproved, "", now); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if err := proposal.Review("pbi-01.md", ApprovalStatusRejected, "out of scope", now); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if err := proposal.Review("missing.md", ApprovalStatusApproved, "", now); err == nil {
+		t.Error("Review() of an unknown draft should fail")
+	}
+
+	if got := proposal.Find("pbi-01.md").RejectionReason; got != "out of scope" {
+		t.Errorf("RejectionReason = %q, want %q", got, "out of scope")
+	}
+	unregistered := proposal.Unregistered()
+	if len(unregistered) != 1 || unregistered[0].File != "epic-01.md" {
+		t.Fatalf("Unregistered() = %v, want only epic-01.md", unregistered)
+	}
+	if proposal.Settled() {
+		t.Error("Settled() = true with a pending and an unregistered draft")
+	}
+
+	unregistered[0].RegisteredID = "EPIC-001"
+	if err := proposal.Review("epic-01.md", ApprovalStatusRejected, "", now); err == nil {
+		t.Error("Review() of a registered draft should fail")
+	}
+	if err := proposal.Review("epic-01-pbi-01.md", ApprovalStatusRejected, "", now); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if !proposal.Settled() {
+		t.Error("Settled() = false with every draft rejected or registered")
+	}
+}
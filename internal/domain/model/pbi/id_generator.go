@@ -1,16 +1,33 @@
 package pbi
 
 import (
-	"crypto/rand"
-	"time"
+	"fmt"
 
-	"github.com/oklog/ulid/v2"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 )
 
-// GenerateID generates a new PBI ID using ULID
-// Format: ULID (e.g., 01JB6X8Y2K9FQR4T3VWHGP5M2C)
+// maxIDAttempts bounds how many generated IDs are tried when they collide with existing PBIs
+const maxIDAttempts = 5
+
+// GenerateID generates a new PBI ID with the configured ID scheme (ULID by default)
+// Format: ULID (e.g., 01JB6X8Y2K9FQR4T3VWHGP5M2C) or, with a sequential scheme, e.g. PBI-2024-0153
+// An ID already used by a PBI is never returned.
 func GenerateID(repo Repository) (string, error) {
-	entropy := ulid.Monotonic(rand.Reader, 0)
-	id := ulid.MustNew(ulid.Timestamp(time.Now()), entropy)
-	return id.String(), nil
+	for attempt := 0; attempt < maxIDAttempts; attempt++ {
+		id, err := model.NewTaskIDFor(model.TaskTypePBI)
+		if err != nil {
+			return "", err
+		}
+		if repo == nil {
+			return id.String(), nil
+		}
+		exists, err := repo.Exists(id.String())
+		if err != nil {
+			return "", fmt.Errorf("failed to check PBI ID: %w", err)
+		}
+		if !exists {
+			return id.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no unused PBI ID after %d attempts", maxIDAttempts)
 }
@@ -15,9 +15,14 @@ type PBI struct {
 	Status               Status
 	EstimatedStoryPoints int
 	Priority             Priority
-	ParentEpicID         string // Optional parent EPIC ID
+	ParentEpicID         string            // Optional parent EPIC ID
+	Deadline             *time.Time        // Optional deadline (nil = no deadline)
+	Env                  map[string]string // Non-secret environment variables exported to agents working on its SBIs
+	Labels               []string          // Labels inherited by the SBIs decomposed from it
+	AssignedAgent        string            // Agent assigned to the SBIs decomposed from it (empty = default agent)
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
+	Version              int // Stored version the PBI was loaded or last saved at (0 = never saved)
 }
 
 // Status represents the PBI status (5 stages)
@@ -142,3 +147,11 @@ func (p *PBI) IsPending() bool {
 func (p *PBI) IsInProgress() bool {
 	return p.Status == StatusInProgress
 }
+
+// IsOverdue checks if the deadline has passed at the given time while the PBI is still unfinished
+func (p *PBI) IsOverdue(now time.Time) bool {
+	if p.Deadline == nil || p.Status == StatusDone || p.Status == StatusFailed {
+		return false
+	}
+	return now.After(*p.Deadline)
+}
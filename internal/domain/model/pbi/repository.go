@@ -6,6 +6,7 @@ type Repository interface {
 	// Save saves a PBI with its Markdown body
 	// - Saves metadata to database
 	// - Saves body to .deespec/specs/pbi/{id}/pbi.md
+	// Fails with repository.ErrStaleEntity when the stored PBI changed since it was loaded
 	Save(pbi *PBI, body string) error
 
 	// FindByID retrieves a PBI by ID (metadata only)
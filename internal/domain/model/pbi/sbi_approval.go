@@ -20,6 +20,12 @@ type SBIApprovalRecord struct {
 	ReviewedAt      *time.Time        `yaml:"reviewed_at,omitempty"`
 	Notes           string            `yaml:"notes,omitempty"`
 	RejectionReason string            `yaml:"rejection_reason,omitempty"`
+	SBIID           string            `yaml:"sbi_id,omitempty"` // ID of the registered SBI (empty until registered)
+}
+
+// IsRegistered checks if the SBI file has been registered to the database
+func (r SBIApprovalRecord) IsRegistered() bool {
+	return r.SBIID != ""
 }
 
 // SBIApprovalManifest represents the approval manifest for all generated SBIs
@@ -65,6 +71,42 @@ func (m *SBIApprovalManifest) GetApprovedSBIs() []string {
 	return approved
 }
 
+// GetUnregisteredApprovedSBIs returns approved SBI files that have not been registered yet
+func (m *SBIApprovalManifest) GetUnregisteredApprovedSBIs() []string {
+	var approved []string
+	for _, sbi := range m.SBIs {
+		if (sbi.Status == ApprovalStatusApproved || sbi.Status == ApprovalStatusEdited) && !sbi.IsRegistered() {
+			approved = append(approved, sbi.File)
+		}
+	}
+	return approved
+}
+
+// LastRegisteredSBIID returns the ID of the last registered SBI in manifest order (empty if none)
+func (m *SBIApprovalManifest) LastRegisteredSBIID() string {
+	for i := len(m.SBIs) - 1; i >= 0; i-- {
+		if m.SBIs[i].IsRegistered() {
+			return m.SBIs[i].SBIID
+		}
+	}
+	return ""
+}
+
+// AddSBIs appends newly generated SBI files in pending status
+// The manifest is marked as not registered so that the new SBIs can be registered incrementally
+func (m *SBIApprovalManifest) AddSBIs(sbiFiles []string) {
+	for _, file := range sbiFiles {
+		m.SBIs = append(m.SBIs, SBIApprovalRecord{
+			File:   file,
+			Status: ApprovalStatusPending,
+		})
+	}
+	m.TotalSBIs = len(m.SBIs)
+	if len(sbiFiles) > 0 {
+		m.Registered = false
+	}
+}
+
 // GetPendingSBIs returns list of pending SBI files
 func (m *SBIApprovalManifest) GetPendingSBIs() []string {
 	var pending []string
@@ -460,3 +460,33 @@ func TestSBIApprovalStatus_Constants(t *testing.T) {
 		t.Errorf("ApprovalStatusEdited = %v, want edited", ApprovalStatusEdited)
 	}
 }
+
+func TestSBIApprovalManifest_AddSBIsIncrementally(t *testing.T) {
+	manifest := NewSBIApprovalManifest("PBI-001", []string{"sbi_1.md", "sbi_2.md"})
+	manifest.SBIs[0].Status = ApprovalStatusApproved
+	manifest.SBIs[0].SBIID = "SBI-AAA"
+	manifest.SBIs[1].Status = ApprovalStatusApproved
+	manifest.Registered = true
+
+	if got := manifest.LastRegisteredSBIID(); got != "SBI-AAA" {
+		t.Errorf("LastRegisteredSBIID() = %v, want SBI-AAA", got)
+	}
+
+	manifest.AddSBIs([]string{"sbi_3.md"})
+
+	if manifest.TotalSBIs != 3 {
+		t.Errorf("TotalSBIs = %v, want 3", manifest.TotalSBIs)
+	}
+	if manifest.Registered {
+		t.Error("Registered should be reset after adding SBIs")
+	}
+	if manifest.SBIs[2].Status != ApprovalStatusPending {
+		t.Errorf("SBIs[2].Status = %v, want pending", manifest.SBIs[2].Status)
+	}
+
+	manifest.SBIs[2].Status = ApprovalStatusEdited
+	got := manifest.GetUnregisteredApprovedSBIs()
+	if len(got) != 2 || got[0] != "sbi_2.md" || got[1] != "sbi_3.md" {
+		t.Errorf("GetUnregisteredApprovedSBIs() = %v, want [sbi_2.md sbi_3.md]", got)
+	}
+}
@@ -0,0 +1,50 @@
+package sbi
+
+import (
+	"errors"
+	"time"
+)
+
+// EstimateSuggestion is an estimate of the SBI's effort awaiting confirmation
+// It is suggested by an agent from the estimate-vs-actual history of similar SBIs and only
+// replaces EstimatedHours once accepted.
+type EstimateSuggestion struct {
+	Hours       float64   `json:"hours"`
+	LowHours    float64   `json:"low_hours"`  // Lower bound of the uncertainty range
+	HighHours   float64   `json:"high_hours"` // Upper bound of the uncertainty range
+	Rationale   string    `json:"rationale,omitempty"`
+	Samples     int       `json:"samples"` // Completed SBIs the suggestion was based on
+	SuggestedAt time.Time `json:"suggested_at"`
+}
+
+// ErrNoEstimateSuggestion is returned when confirming an estimate that was never suggested
+var ErrNoEstimateSuggestion = errors.New("no estimate suggestion is pending")
+
+// EstimateSuggestion returns the estimate awaiting confirmation, or nil when there is none
+func (s *SBI) EstimateSuggestion() *EstimateSuggestion {
+	return s.metadata.EstimateSuggestion
+}
+
+// SuggestEstimate records an estimate awaiting confirmation, replacing any pending one
+func (s *SBI) SuggestEstimate(suggestion EstimateSuggestion) {
+	s.metadata.EstimateSuggestion = &suggestion
+}
+
+// AcceptEstimate confirms the pending suggestion as the SBI's estimated hours
+func (s *SBI) AcceptEstimate() error {
+	if s.metadata.EstimateSuggestion == nil {
+		return ErrNoEstimateSuggestion
+	}
+	s.metadata.EstimatedHours = s.metadata.EstimateSuggestion.Hours
+	s.metadata.EstimateSuggestion = nil
+	return nil
+}
+
+// RejectEstimate discards the pending suggestion, keeping the estimated hours
+func (s *SBI) RejectEstimate() error {
+	if s.metadata.EstimateSuggestion == nil {
+		return ErrNoEstimateSuggestion
+	}
+	s.metadata.EstimateSuggestion = nil
+	return nil
+}
@@ -0,0 +1,42 @@
+package sbi
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// PriorityAging raises the priority of pending SBIs with the time they have waited since
+// registration, so that a steady stream of higher-priority SBIs cannot starve lower ones:
+// an SBI gains one level per interval up to MaxPriority, where it competes with the SBIs
+// already at that level in registration order (oldest first)
+type PriorityAging struct {
+	Interval    time.Duration // Wait that gains one priority level (0 disables aging)
+	MaxPriority int           // Level aging stops at; priorities above it are left unchanged
+}
+
+// Enabled reports whether waiting raises priorities
+func (a PriorityAging) Enabled() bool {
+	return a.Interval > 0
+}
+
+// Effective returns the priority an SBI is picked by at now
+// Only pending SBIs age; SBIs without a registration time keep their priority.
+func (a PriorityAging) Effective(priority int, status model.Status, registeredAt, now time.Time) int {
+	if !a.Enabled() || status != model.StatusPending || registeredAt.IsZero() || priority >= a.MaxPriority {
+		return priority
+	}
+	waited := now.Sub(registeredAt)
+	if waited <= 0 {
+		return priority
+	}
+	if levels := int64(waited / a.Interval); levels < int64(a.MaxPriority-priority) {
+		return priority + int(levels)
+	}
+	return a.MaxPriority
+}
+
+// EffectivePriority returns the priority the SBI is picked by at now under aging
+func (s *SBI) EffectivePriority(aging PriorityAging, now time.Time) int {
+	return aging.Effective(s.Priority(), s.Status(), s.RegisteredAt(), now)
+}
@@ -0,0 +1,44 @@
+package sbi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+func TestPriorityAging_Effective(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	aging := PriorityAging{Interval: 24 * time.Hour, MaxPriority: 2}
+
+	tests := []struct {
+		name     string
+		aging    PriorityAging
+		priority int
+		status   model.Status
+		waited   time.Duration
+		want     int
+	}{
+		{"just registered", aging, 0, model.StatusPending, time.Hour, 0},
+		{"one interval", aging, 0, model.StatusPending, 24 * time.Hour, 1},
+		{"capped at the maximum", aging, 0, model.StatusPending, 30 * 24 * time.Hour, 2},
+		{"high priority reaches the maximum sooner", aging, 1, model.StatusPending, 25 * time.Hour, 2},
+		{"above the maximum is unchanged", aging, 3, model.StatusPending, 30 * 24 * time.Hour, 3},
+		{"only pending SBIs age", aging, 0, model.StatusImplementing, 30 * 24 * time.Hour, 0},
+		{"registered in the future", aging, 0, model.StatusPending, -time.Hour, 0},
+		{"disabled", PriorityAging{MaxPriority: 2}, 0, model.StatusPending, 30 * 24 * time.Hour, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.aging.Effective(tt.priority, tt.status, now.Add(-tt.waited), now)
+			if got != tt.want {
+				t.Errorf("Effective() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := aging.Effective(0, model.StatusPending, time.Time{}, now); got != 0 {
+		t.Errorf("Effective() without a registration time = %d, want the stored priority", got)
+	}
+}
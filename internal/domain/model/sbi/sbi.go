@@ -18,17 +18,21 @@ type SBI struct {
 
 // SBIMetadata contains SBI-specific metadata
 type SBIMetadata struct {
-	EstimatedHours float64
-	Priority       int        // 0=通常, 1=高, 2=緊急
-	Sequence       int        // 登録順序番号 (自動採番)
-	RegisteredAt   time.Time  // 明示的な登録タイムスタンプ
-	StartedAt      *time.Time // 作業開始時刻 (PENDING→PICKED時に記録)
-	CompletedAt    *time.Time // 作業完了時刻 (DONE/FAILED時に記録)
-	Labels         []string
-	AssignedAgent  string   // e.g., "claude-code", "gemini-cli", "codex"
-	FilePaths      []string // Files to be modified/created
-	DependsOn      []string // IDs of SBIs that must be completed before this SBI
-	OnlyImplement  bool     // false=実装→レビュー（デフォルト）, true=実装のみ
+	EstimatedHours     float64
+	Priority           int        // 0=通常, 1=高, 2=緊急
+	Sequence           int        // 登録順序番号 (自動採番)
+	RegisteredAt       time.Time  // 明示的な登録タイムスタンプ
+	StartedAt          *time.Time // 作業開始時刻 (PENDING→PICKED時に記録)
+	CompletedAt        *time.Time // 作業完了時刻 (DONE/FAILED時に記録)
+	Deadline           *time.Time // 期限 (任意, nil=期限なし)
+	ReviewStartedAt    *time.Time // 現在のレビュー開始時刻 (REVIEWINGに入った時に記録, それ以外はnil)
+	Labels             []string
+	AssignedAgent      string              // e.g., "claude-code", "gemini-cli", "codex"
+	FilePaths          []string            // Files to be modified/created
+	DependsOn          []string            // IDs of SBIs that must be completed before this SBI
+	OnlyImplement      bool                // false=実装→レビュー（デフォルト）, true=実装のみ
+	Env                map[string]string   // エージェント実行時に渡す環境変数 (非機密のみ)
+	EstimateSuggestion *EstimateSuggestion // 確認待ちの見積もり提案 (nil=なし)
 }
 
 // ExecutionState tracks the execution state of an SBI
@@ -139,8 +143,27 @@ func (s *SBI) UpdatedAt() model.Timestamp {
 	return s.base.UpdatedAt()
 }
 
+func (s *SBI) Version() int {
+	return s.base.Version()
+}
+
+func (s *SBI) SetVersion(version int) {
+	s.base.SetVersion(version)
+}
+
+// UpdateStatus transitions the SBI to newStatus, tracking when its current review started
 func (s *SBI) UpdateStatus(newStatus model.Status) error {
-	return s.base.UpdateStatus(newStatus)
+	previous := s.base.Status()
+	if err := s.base.UpdateStatus(newStatus); err != nil {
+		return err
+	}
+	if newStatus != model.StatusReviewing {
+		s.metadata.ReviewStartedAt = nil
+	} else if previous != model.StatusReviewing {
+		now := time.Now()
+		s.metadata.ReviewStartedAt = &now
+	}
+	return nil
 }
 
 func (s *SBI) UpdateStep(newStep model.Step) error {
@@ -231,6 +254,29 @@ func (s *SBI) IsFailed() bool {
 	return s.base.Status() == model.StatusFailed
 }
 
+// IsCancelled checks if the SBI has been cancelled
+func (s *SBI) IsCancelled() bool {
+	return s.base.Status() == model.StatusCancelled
+}
+
+// IsTerminal checks if the SBI has reached a terminal status (DONE, FAILED or CANCELLED)
+func (s *SBI) IsTerminal() bool {
+	return s.IsCompleted() || s.IsFailed() || s.IsCancelled()
+}
+
+// Cancel transitions the SBI to CANCELLED and records the reason as the last error
+func (s *SBI) Cancel(reason string) error {
+	if err := s.base.UpdateStatus(model.StatusCancelled); err != nil {
+		return err
+	}
+	if reason == "" {
+		reason = "cancelled by user"
+	}
+	s.execution.LastError = "cancelled: " + reason
+	s.MarkAsCompleted()
+	return nil
+}
+
 // SetMaxTurns sets the maximum number of turns
 func (s *SBI) SetMaxTurns(maxTurns int) {
 	s.execution.MaxTurns = maxTurns
@@ -308,6 +354,11 @@ func (s *SBI) StartedAt() *time.Time {
 	return s.metadata.StartedAt
 }
 
+// ReviewStartedAt returns when the SBI entered its current review, or nil when it is not reviewing
+func (s *SBI) ReviewStartedAt() *time.Time {
+	return s.metadata.ReviewStartedAt
+}
+
 // CompletedAt returns the work completion time
 func (s *SBI) CompletedAt() *time.Time {
 	return s.metadata.CompletedAt
@@ -323,6 +374,29 @@ func (s *SBI) WorkDuration() *time.Duration {
 	return &duration
 }
 
+// Deadline returns the deadline of the SBI (nil if not set)
+func (s *SBI) Deadline() *time.Time {
+	return s.metadata.Deadline
+}
+
+// SetDeadline sets or clears (nil) the deadline of the SBI
+func (s *SBI) SetDeadline(deadline *time.Time) {
+	s.metadata.Deadline = deadline
+}
+
+// Env returns the environment variables exported to agents working on the SBI
+func (s *SBI) Env() map[string]string {
+	return s.metadata.Env
+}
+
+// IsOverdue checks if the deadline has passed at the given time while the SBI is still unfinished
+func (s *SBI) IsOverdue(now time.Time) bool {
+	if s.metadata.Deadline == nil || s.IsTerminal() {
+		return false
+	}
+	return now.After(*s.metadata.Deadline)
+}
+
 // === Workflow Control Methods ===
 
 // OnlyImplement checks if this SBI should only do implementation (no review)
@@ -352,6 +352,30 @@ func TestSBI_IsFailed(t *testing.T) {
 	}
 }
 
+func TestSBI_Cancel(t *testing.T) {
+	sbi, _ := NewSBI("Test", "Description", nil, SBIMetadata{})
+	sbi.UpdateStatus(model.StatusPicked)
+	sbi.UpdateStatus(model.StatusImplementing)
+
+	if err := sbi.Cancel("requirements changed"); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if !sbi.IsCancelled() || !sbi.IsTerminal() {
+		t.Error("Cancelled SBI should be cancelled and terminal")
+	}
+	if sbi.ExecutionState().LastError != "cancelled: requirements changed" {
+		t.Errorf("LastError = %q", sbi.ExecutionState().LastError)
+	}
+	if sbi.CompletedAt() == nil {
+		t.Error("CompletedAt should be recorded on cancel")
+	}
+
+	// Terminal: cannot cancel again
+	if err := sbi.Cancel("again"); err == nil {
+		t.Error("Cancelling a cancelled SBI should fail")
+	}
+}
+
 func TestSBI_SetAndGetSequence(t *testing.T) {
 	metadata := SBIMetadata{}
 	sbi, _ := NewSBI("Test", "Description", nil, metadata)
@@ -515,3 +539,57 @@ func TestSBI_Timestamps(t *testing.T) {
 		t.Error("UpdatedAt should not be before CreatedAt")
 	}
 }
+
+func TestSBI_IsOverdue(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	sbi, _ := NewSBI("Test", "Description", nil, SBIMetadata{})
+	if sbi.IsOverdue(now) {
+		t.Error("SBI without deadline should not be overdue")
+	}
+
+	sbi.SetDeadline(&future)
+	if sbi.IsOverdue(now) {
+		t.Error("SBI with future deadline should not be overdue")
+	}
+
+	sbi.SetDeadline(&past)
+	if !sbi.IsOverdue(now) {
+		t.Error("SBI with past deadline should be overdue")
+	}
+
+	done := ReconstructSBI(
+		model.NewTaskID(), "Done", "", model.StatusDone, model.StepDone, nil,
+		SBIMetadata{Deadline: &past},
+		&ExecutionState{CurrentTurn: model.NewTurn(), CurrentAttempt: model.NewAttempt()},
+		now, now,
+	)
+	if done.IsOverdue(now) {
+		t.Error("Completed SBI should not be overdue")
+	}
+}
+
+func TestSBI_EstimateSuggestion(t *testing.T) {
+	sbi, _ := NewSBI("Test", "Description", nil, SBIMetadata{EstimatedHours: 2})
+	if err := sbi.AcceptEstimate(); err != ErrNoEstimateSuggestion {
+		t.Errorf("AcceptEstimate() without a suggestion = %v, want ErrNoEstimateSuggestion", err)
+	}
+
+	sbi.SuggestEstimate(EstimateSuggestion{Hours: 5, LowHours: 3, HighHours: 8})
+	if err := sbi.RejectEstimate(); err != nil {
+		t.Fatalf("RejectEstimate() error = %v", err)
+	}
+	if sbi.Metadata().EstimatedHours != 2 || sbi.EstimateSuggestion() != nil {
+		t.Error("A rejected suggestion should be discarded without changing the estimate")
+	}
+
+	sbi.SuggestEstimate(EstimateSuggestion{Hours: 5, LowHours: 3, HighHours: 8})
+	if err := sbi.AcceptEstimate(); err != nil {
+		t.Fatalf("AcceptEstimate() error = %v", err)
+	}
+	if sbi.Metadata().EstimatedHours != 5 || sbi.EstimateSuggestion() != nil {
+		t.Errorf("EstimatedHours = %v, want the accepted 5 and no pending suggestion", sbi.Metadata().EstimatedHours)
+	}
+}
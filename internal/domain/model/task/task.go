@@ -2,6 +2,7 @@ package task
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
@@ -54,6 +55,7 @@ type BaseTask struct {
 	parentID    *model.TaskID
 	createdAt   model.Timestamp
 	updatedAt   model.Timestamp
+	version     int // Stored version the task was loaded at (0 = never saved)
 }
 
 // NewBaseTask creates a new base task
@@ -70,9 +72,14 @@ func NewBaseTask(
 		return nil, errors.New("title cannot be empty")
 	}
 
+	id, err := model.NewTaskIDFor(taskType)
+	if err != nil {
+		return nil, err
+	}
+
 	now := model.NewTimestamp()
 	return &BaseTask{
-		id:          model.NewTaskID(),
+		id:          id,
 		taskType:    taskType,
 		title:       title,
 		description: description,
@@ -154,6 +161,17 @@ func (b *BaseTask) UpdatedAt() model.Timestamp {
 	return b.updatedAt
 }
 
+// Version returns the stored version the task was loaded or last saved at (0 = never saved)
+func (b *BaseTask) Version() int {
+	return b.version
+}
+
+// SetVersion records the stored version of the task
+// Called by repositories after loading or saving the task; Save checks it against the stored row
+func (b *BaseTask) SetVersion(version int) {
+	b.version = version
+}
+
 // UpdateStatus transitions to a new status
 func (b *BaseTask) UpdateStatus(newStatus model.Status) error {
 	if !newStatus.IsValid() {
@@ -161,7 +179,7 @@ func (b *BaseTask) UpdateStatus(newStatus model.Status) error {
 	}
 
 	if !b.status.CanTransitionTo(newStatus) {
-		return errors.New("invalid status transition from " + b.status.String() + " to " + newStatus.String())
+		return fmt.Errorf("%w: status cannot change from %s to %s", model.ErrInvalidState, b.status, newStatus)
 	}
 
 	b.status = newStatus
@@ -186,6 +204,8 @@ func (b *BaseTask) deriveStepFromStatus(status model.Status) model.Step {
 		return model.StepDone
 	case model.StatusFailed:
 		return model.StepDone
+	case model.StatusCancelled:
+		return model.StepDone
 	default:
 		return model.StepPick
 	}
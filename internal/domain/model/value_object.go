@@ -73,6 +73,7 @@ const (
 	StatusReviewing    Status = "REVIEWING"
 	StatusDone         Status = "DONE"
 	StatusFailed       Status = "FAILED"
+	StatusCancelled    Status = "CANCELLED"
 )
 
 // String returns the string representation
@@ -83,7 +84,7 @@ func (s Status) String() string {
 // IsValid validates the status
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusPending, StatusPicked, StatusImplementing, StatusReviewing, StatusDone, StatusFailed:
+	case StatusPending, StatusPicked, StatusImplementing, StatusReviewing, StatusDone, StatusFailed, StatusCancelled:
 		return true
 	default:
 		return false
@@ -93,12 +94,13 @@ func (s Status) IsValid() bool {
 // CanTransitionTo checks if a status transition is valid
 func (s Status) CanTransitionTo(next Status) bool {
 	validTransitions := map[Status][]Status{
-		StatusPending:      {StatusPicked},
-		StatusPicked:       {StatusImplementing, StatusPending},
-		StatusImplementing: {StatusReviewing, StatusDone, StatusFailed, StatusPending}, // Added StatusDone for only_implement=true workflow
-		StatusReviewing:    {StatusDone, StatusImplementing, StatusFailed},
+		StatusPending:      {StatusPicked, StatusCancelled},
+		StatusPicked:       {StatusImplementing, StatusPending, StatusCancelled},
+		StatusImplementing: {StatusReviewing, StatusDone, StatusFailed, StatusPending, StatusCancelled}, // Added StatusDone for only_implement=true workflow
+		StatusReviewing:    {StatusDone, StatusImplementing, StatusFailed, StatusCancelled},
 		StatusDone:         {},
 		StatusFailed:       {StatusPending},
+		StatusCancelled:    {}, // Terminal: use `sbi reset` to re-run
 	}
 
 	allowed, exists := validTransitions[s]
@@ -143,6 +143,7 @@ func TestStatus_IsValid(t *testing.T) {
 		{"Reviewing is valid", StatusReviewing, true},
 		{"Done is valid", StatusDone, true},
 		{"Failed is valid", StatusFailed, true},
+		{"Cancelled is valid", StatusCancelled, true},
 		{"Invalid status", Status("INVALID"), false},
 		{"Empty status", Status(""), false},
 	}
@@ -174,6 +175,8 @@ func TestStatus_CanTransitionTo(t *testing.T) {
 		{"Reviewing to Implementing", StatusReviewing, StatusImplementing, true},
 		{"Reviewing to Failed", StatusReviewing, StatusFailed, true},
 		{"Failed to Pending", StatusFailed, StatusPending, true},
+		{"Implementing to Cancelled", StatusImplementing, StatusCancelled, true},
+		{"Reviewing to Cancelled", StatusReviewing, StatusCancelled, true},
 
 		// Invalid transitions
 		{"Pending to Implementing", StatusPending, StatusImplementing, false},
@@ -182,6 +185,8 @@ func TestStatus_CanTransitionTo(t *testing.T) {
 		{"Done to Picked", StatusDone, StatusPicked, false},
 		{"Picked to Done", StatusPicked, StatusDone, false},
 		{"Failed to Done", StatusFailed, StatusDone, false},
+		{"Done to Cancelled", StatusDone, StatusCancelled, false},
+		{"Cancelled to Pending", StatusCancelled, StatusPending, false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// CriterionStatus represents the evaluation state of an acceptance criterion
+type CriterionStatus string
+
+const (
+	CriterionPending CriterionStatus = "PENDING" // Not yet evaluated by a review
+	CriterionPassed  CriterionStatus = "PASSED"  // Review confirmed the criterion is met
+	CriterionFailed  CriterionStatus = "FAILED"  // Review found the criterion is not met
+	CriterionWaived  CriterionStatus = "WAIVED"  // Explicitly waived by a user
+)
+
+// IsSatisfied reports whether the criterion no longer blocks DONE
+func (s CriterionStatus) IsSatisfied() bool {
+	return s == CriterionPassed || s == CriterionWaived
+}
+
+// AcceptanceCriterion is a single checklist item of an SBI's acceptance criteria
+type AcceptanceCriterion struct {
+	ID          int64
+	SBIID       string
+	Position    int    // 1-based, referenced as AC-<position> in review reports
+	Description string // Criterion text
+	Source      string // "sbi" or "pbi"
+	Status      CriterionStatus
+	Note        string // Reviewer note or waiver reason
+	Turn        int    // Turn of the last evaluation (0 if never evaluated)
+	UpdatedAt   time.Time
+}
+
+// AcceptanceCriteriaRepository defines the interface for acceptance criteria persistence
+type AcceptanceCriteriaRepository interface {
+	// SaveAll replaces the checklist of an SBI
+	SaveAll(ctx context.Context, sbiID string, criteria []*AcceptanceCriterion) error
+
+	// FindBySBIID retrieves the checklist of an SBI ordered by position
+	// Returns an empty slice without error when the SBI has no criteria
+	FindBySBIID(ctx context.Context, sbiID string) ([]*AcceptanceCriterion, error)
+
+	// UpdateStatus sets the status and note of a single criterion
+	UpdateStatus(ctx context.Context, sbiID string, position int, status CriterionStatus, note string, turn int) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// AgentCall is a single agent execution, counted against the daily agent call budget
+type AgentCall struct {
+	SBIID     string
+	Turn      int
+	Step      string // 'implement' or 'review'
+	AgentType string // Agent type reported by the gateway capability
+	CalledAt  time.Time
+}
+
+// AgentCallRepository records agent executions for budget accounting
+type AgentCallRepository interface {
+	// Save records an agent call; a zero CalledAt is recorded as now
+	Save(ctx context.Context, call *AgentCall) error
+
+	// CountSince returns the number of agent calls made at or after since
+	CountSince(ctx context.Context, since time.Time) (int, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// CachedAgentResponse is an agent output stored for an identical prompt
+type CachedAgentResponse struct {
+	PromptHash string // SHA-256 of the agent type, model and prompt
+	AgentType  string
+	Model      string // Empty when the agent's default model was used
+	Step       string // Workflow step that produced the output (e.g. 'done')
+	Output     string
+	Hits       int // Times the output was reused
+	CreatedAt  time.Time
+}
+
+// AgentResponseCacheRepository stores agent outputs by prompt hash
+type AgentResponseCacheRepository interface {
+	// Find retrieves the cached response of a prompt hash
+	// Returns nil without error when none is cached
+	Find(ctx context.Context, promptHash string) (*CachedAgentResponse, error)
+
+	// Save stores a response, replacing any previous one for the prompt hash
+	Save(ctx context.Context, response *CachedAgentResponse) error
+
+	// RecordHit counts a reuse of the cached response of a prompt hash
+	RecordHit(ctx context.Context, promptHash string) error
+
+	// DeleteBefore removes the responses cached before the given time and returns how many
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
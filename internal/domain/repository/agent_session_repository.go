@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// AgentSession is the provider conversation an SBI step continues on its next turn
+type AgentSession struct {
+	SBIID     string
+	Step      string // 'implement' or 'review'
+	AgentType string // Agent that owns the session; other agents cannot resume it
+	SessionID string // Provider session ID
+	Turn      int    // Turn that last used the session
+	UpdatedAt time.Time
+}
+
+// AgentSessionRepository persists agent sessions per SBI step
+type AgentSessionRepository interface {
+	// Find retrieves the session of an SBI step
+	// Returns nil without error when no session exists
+	Find(ctx context.Context, sbiID, step string) (*AgentSession, error)
+
+	// Save stores the session of an SBI step, replacing any previous one
+	Save(ctx context.Context, session *AgentSession) error
+
+	// Delete removes the session of an SBI step (e.g. when it can no longer be resumed)
+	Delete(ctx context.Context, sbiID, step string) error
+}
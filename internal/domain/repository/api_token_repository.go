@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrAPITokenNotFound is returned when no active token matches
+var ErrAPITokenNotFound = fmt.Errorf("api token %w", model.ErrNotFound)
+
+// API token scopes checked per route by the API server
+const (
+	APIScopeRead    = "read"     // List and inspect tasks, journal, locks and events
+	APIScopeRunTurn = "run-turn" // Run SBI turns
+	APIScopeAdmin   = "admin"    // Everything, including releasing locks
+)
+
+// APIToken is a credential for the API server; only the hash of its secret is stored
+type APIToken struct {
+	Name       string
+	TokenHash  string // SHA-256 (hex) of the secret
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// HasScope reports whether the token grants scope; admin grants every scope
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == APIScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// APITokenRepository stores API tokens
+type APITokenRepository interface {
+	// Save creates a token; the name must be unused
+	Save(ctx context.Context, token *APIToken) error
+
+	// FindActiveByHash returns the unrevoked token with the secret hash
+	// Returns ErrAPITokenNotFound when there is none
+	FindActiveByHash(ctx context.Context, tokenHash string) (*APIToken, error)
+
+	// List returns all tokens, including revoked ones, by name
+	List(ctx context.Context) ([]*APIToken, error)
+
+	// CountActive returns the number of unrevoked tokens
+	CountActive(ctx context.Context) (int, error)
+
+	// Revoke revokes a token by name
+	// Returns ErrAPITokenNotFound when no active token has the name
+	Revoke(ctx context.Context, name string) error
+
+	// TouchLastUsed records when a token was last used
+	TouchLastUsed(ctx context.Context, name string, at time.Time) error
+}
+
+// APIAuditEntry records a state change made through the API server, or a refused request
+type APIAuditEntry struct {
+	ID        int64
+	TokenName string // '' when no valid token was presented
+	Route     string // gRPC method or HTTP path
+	Target    string // SBI or lock the request acted on
+	Outcome   string // 'ok', 'error' or 'denied'
+	Detail    string
+	CreatedAt time.Time
+}
+
+// APIAuditLogRepository stores the API audit log
+type APIAuditLogRepository interface {
+	// Save appends an entry; a zero CreatedAt is recorded as now
+	Save(ctx context.Context, entry *APIAuditEntry) error
+
+	// ListRecent returns the newest entries first; limit <= 0 returns all
+	ListRecent(ctx context.Context, limit int) ([]*APIAuditEntry, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ArtifactProvenance records which agent, model and agent version produced a step artifact
+type ArtifactProvenance struct {
+	SBIID        string
+	Step         string // Step that produced the artifact (e.g. 'implement', 'review', 'done')
+	Turn         int    // Turn the step was executed in
+	ArtifactPath string // Report written by the step
+	Decision     string // Review decision ("" for other steps)
+	AgentType    string // Agent type reported by the gateway capability
+	Model        string // Model reported by the agent ("" = unknown)
+	AgentVersion string // Version of the agent CLI ("" = unknown)
+	Reviewer     string // Reviewer name within a review quorum ("" = single reviewer)
+	PromptHash   string // SHA-256 of the prompt sent to the agent, hex-encoded ("" = unknown)
+	ArtifactHash string // SHA-256 of the artifact when it was written, hex-encoded ("" = unknown)
+	CreatedAt    time.Time
+}
+
+// ArtifactProvenanceRepository persists the provenance of SBI step artifacts
+type ArtifactProvenanceRepository interface {
+	// Save records the provenance of an artifact
+	Save(ctx context.Context, provenance *ArtifactProvenance) error
+
+	// FindBySBIID retrieves the provenance records of an SBI, oldest first
+	FindBySBIID(ctx context.Context, sbiID string) ([]*ArtifactProvenance, error)
+}
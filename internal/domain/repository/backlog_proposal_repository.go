@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+)
+
+// BacklogProposalRepository manages the backlog proposed by `deespec analyze`: a
+// proposal.yaml manifest and one Markdown file per draft EPIC or PBI
+type BacklogProposalRepository interface {
+	// Load loads the proposal manifest
+	// Returns nil without error when there is no proposal
+	Load(ctx context.Context) (*pbi.BacklogProposal, error)
+
+	// Save persists the proposal manifest
+	Save(ctx context.Context, proposal *pbi.BacklogProposal) error
+
+	// Replace removes the current proposal and its drafts, then writes the draft files
+	// (file name to Markdown content) and the manifest of the new proposal
+	Replace(ctx context.Context, proposal *pbi.BacklogProposal, drafts map[string]string) error
+
+	// ReadDraft returns the Markdown content of a draft file
+	ReadDraft(ctx context.Context, file string) (string, error)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrDatabaseBackupNotFound is returned when no backup of the database has the name
+var ErrDatabaseBackupNotFound = fmt.Errorf("database backup %w", model.ErrNotFound)
+
+// DatabaseBackupKind tells why a backup of the database was taken
+type DatabaseBackupKind string
+
+const (
+	DatabaseBackupScheduled  DatabaseBackupKind = "scheduled"   // Periodic snapshot taken by the run daemon
+	DatabaseBackupManual     DatabaseBackupKind = "manual"      // Taken by deespec backup now
+	DatabaseBackupPreRestore DatabaseBackupKind = "pre-restore" // The database replaced by deespec backup restore
+	DatabaseBackupMigration  DatabaseBackupKind = "migration"   // Taken before a schema migration
+)
+
+// DatabaseBackup is a snapshot of the task database
+type DatabaseBackup struct {
+	Name      string             `json:"name"` // File name, e.g. deespec.db.scheduled-20260101T030000Z.bak
+	Kind      DatabaseBackupKind `json:"kind"`
+	Path      string             `json:"path"`
+	Size      int64              `json:"size"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// DatabaseBackupRepository takes, lists and rotates the snapshots of the task database
+type DatabaseBackupRepository interface {
+	// Create snapshots the database as a backup of the kind taken at the given time
+	// The snapshot is consistent even while other commands write to the database.
+	Create(ctx context.Context, kind DatabaseBackupKind, at time.Time) (*DatabaseBackup, error)
+
+	// List returns the backups of the database, newest first
+	List(ctx context.Context) ([]*DatabaseBackup, error)
+
+	// Find returns the backup with the name
+	// Returns ErrDatabaseBackupNotFound when there is none
+	Find(ctx context.Context, name string) (*DatabaseBackup, error)
+
+	// Rotate removes all but the newest keep backups of the kind and returns the removed ones
+	Rotate(ctx context.Context, kind DatabaseBackupKind, keep int) ([]*DatabaseBackup, error)
+}
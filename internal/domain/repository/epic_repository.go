@@ -12,6 +12,7 @@ type EPICRepository interface {
 	Find(ctx context.Context, id EPICID) (*epic.EPIC, error)
 
 	// Save persists an EPIC entity
+	// Returns ErrStaleEntity when the stored entity changed since it was loaded
 	Save(ctx context.Context, e *epic.EPIC) error
 
 	// Delete removes an EPIC
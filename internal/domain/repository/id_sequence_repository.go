@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// IDSequenceRepository hands out sequential task IDs (e.g. SBI-2024-0153)
+type IDSequenceRepository interface {
+	// NextID returns the next ID of a prefix: the prefix followed by the next number of its
+	// sequence, zero-padded to digits. Numbers whose ID is already used by an EPIC, PBI or SBI
+	// (including deleted and archived ones, and IDs imported from elsewhere) are skipped.
+	NextID(ctx context.Context, prefix string, digits int) (string, error)
+}
@@ -1,24 +1,83 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sort"
+)
 
 // JournalRecord represents a single journal entry
 type JournalRecord struct {
-	Timestamp string        // UTC RFC3339Nano
-	SBIID     string        // SBI ID for filtering
-	Turn      int           // Turn number
-	Step      string        // Workflow step
-	Status    string        // Execution status
-	Attempt   int           // Attempt number
-	Decision  string        // Review decision
-	ElapsedMs int64         // Execution time in milliseconds
-	Error     string        // Error message if any
-	Artifacts []interface{} // Artifact paths and metadata
+	Timestamp  string        // UTC RFC3339Nano
+	SBIID      string        // SBI ID for filtering
+	Turn       int           // Turn number
+	Step       string        // Workflow step
+	Status     string        // Execution status
+	Attempt    int           // Attempt number
+	Decision   string        // Review decision
+	ElapsedMs  int64         // Execution time in milliseconds
+	Error      string        // Error message if any
+	ErrorClass string        // Error classification, e.g. "agent_timeout" (empty without an error)
+	Artifacts  []interface{} // Artifact paths and metadata
+	Seq        int           // Write of the step in the turn, 0 for the first (see IdempotencyKey)
+}
+
+// IdempotencyKey identifies the turn step a record belongs to (sbi_id/turn/step, then #seq when Seq > 0)
+// A step journaled again in the same turn is a retry, unless its writer set a new Seq before
+// the first write (e.g. a review re-run because the agent never reported; see NextJournalSeq).
+// Records without an SBI ID or step have no key and are never deduplicated
+func (r *JournalRecord) IdempotencyKey() string {
+	if r.SBIID == "" || r.Step == "" {
+		return ""
+	}
+	if r.Seq > 0 {
+		return fmt.Sprintf("%s/%d/%s#%d", r.SBIID, r.Turn, r.Step, r.Seq)
+	}
+	return fmt.Sprintf("%s/%d/%s", r.SBIID, r.Turn, r.Step)
+}
+
+// NextJournalSeq returns the Seq of a new write of a turn step, given the SBI's journaled records:
+// 0 for the first write, one more than the highest Seq journaled for the step otherwise
+func NextJournalSeq(records []*JournalRecord, sbiID string, turn int, step string) int {
+	next := 0
+	for _, record := range records {
+		if record.SBIID == sbiID && record.Turn == turn && record.Step == step && record.Seq >= next {
+			next = record.Seq + 1
+		}
+	}
+	return next
+}
+
+// JournalDuplicate reports an idempotency key that occurs more than once in the journal
+type JournalDuplicate struct {
+	Key   string
+	Count int
+}
+
+// FindJournalDuplicates returns the idempotency keys that occur more than once, sorted by key
+func FindJournalDuplicates(records []*JournalRecord) []JournalDuplicate {
+	counts := make(map[string]int)
+	for _, record := range records {
+		if key := record.IdempotencyKey(); key != "" {
+			counts[key]++
+		}
+	}
+
+	var duplicates []JournalDuplicate
+	for key, count := range counts {
+		if count > 1 {
+			duplicates = append(duplicates, JournalDuplicate{Key: key, Count: count})
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i].Key < duplicates[j].Key })
+	return duplicates
 }
 
 // JournalRepository manages execution journal persistence
 type JournalRepository interface {
 	// Append adds a new record to the journal
+	// Append is idempotent: a record whose IdempotencyKey is already journaled is
+	// skipped without error, so replays and crash recovery do not duplicate entries
 	Append(ctx context.Context, record *JournalRecord) error
 
 	// Load retrieves all journal records
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrPBIAcceptanceTestNotFound is returned when a PBI defines no acceptance test
+var ErrPBIAcceptanceTestNotFound = fmt.Errorf("PBI acceptance test %w", model.ErrNotFound)
+
+// PBIAcceptanceTest is the end-to-end check of a PBI, run once all of its SBIs are DONE
+// At least one of Command and AgentReview is set.
+type PBIAcceptanceTest struct {
+	PBIID       string
+	Command     string        // Shell command run in the project root; exit code 0 passes
+	AgentReview bool          // An agent reviews the PBI against its acceptance criteria
+	Timeout     time.Duration // 0 uses the default timeout
+	UpdatedAt   time.Time
+}
+
+// PBIAcceptanceRepository defines the interface for PBI acceptance test persistence
+type PBIAcceptanceRepository interface {
+	// Save saves the acceptance test of a PBI, replacing an existing one
+	Save(ctx context.Context, test *PBIAcceptanceTest) error
+
+	// FindByPBIID retrieves the acceptance test of a PBI
+	// Returns nil without error when the PBI defines none
+	FindByPBIID(ctx context.Context, pbiID string) (*PBIAcceptanceTest, error)
+
+	// Delete removes the acceptance test of a PBI
+	// Returns ErrPBIAcceptanceTestNotFound when the PBI defines none
+	Delete(ctx context.Context, pbiID string) error
+}
@@ -12,6 +12,7 @@ type PBIRepository interface {
 	Find(ctx context.Context, id PBIID) (*pbi.PBI, error)
 
 	// Save persists a PBI entity
+	// Returns ErrStaleEntity when the stored entity changed since it was loaded
 	Save(ctx context.Context, p *pbi.PBI) error
 
 	// Delete removes a PBI
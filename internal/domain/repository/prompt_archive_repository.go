@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PromptArchiveEntry is one prompt sent to an agent during a turn, with the agent's answer
+type PromptArchiveEntry struct {
+	SBIID      string            `json:"sbi_id"`
+	Turn       int               `json:"turn"`
+	Step       string            `json:"step"`
+	AgentType  string            `json:"agent_type"`
+	Model      string            `json:"model,omitempty"`
+	SessionID  string            `json:"session_id,omitempty"` // Continued agent conversation, if any
+	Context    map[string]string `json:"context,omitempty"`    // Context of the agent request (e.g. the reviewer)
+	Cached     bool              `json:"cached,omitempty"`     // The response was reused from the agent response cache
+	Prompt     string            `json:"prompt"`
+	Response   string            `json:"response"`
+	Error      string            `json:"error,omitempty"` // Why the agent failed, if it did
+	DurationMs int64             `json:"duration_ms,omitempty"`
+	ArchivedAt time.Time         `json:"archived_at"`
+	File       string            `json:"-"` // Archive file the entry was read from
+}
+
+// PromptArchiveRepository keeps the exact prompts sent to agents and their answers, so that
+// an agent's behavior can be explained long after the turn
+type PromptArchiveRepository interface {
+	// Save archives an entry
+	Save(ctx context.Context, entry *PromptArchiveEntry) error
+
+	// List returns the archived entries of an SBI, oldest first
+	List(ctx context.Context, sbiID string) ([]*PromptArchiveEntry, error)
+
+	// Prune removes the entries archived before the cutoff and returns how many were removed
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
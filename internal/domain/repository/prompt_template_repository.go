@@ -12,6 +12,11 @@ type PromptTemplateRepository interface {
 	// Returns empty string if label file doesn't exist
 	LoadLabelContent(ctx context.Context, labelName string) string
 
+	// LoadLabelReviewContent loads the review-specific guidance of a label
+	// (e.g. a security checklist for label "auth"), which is added to review prompts
+	// Returns empty string if the label has no review guidance
+	LoadLabelReviewContent(ctx context.Context, labelName string) string
+
 	// LoadMetaLabels loads labels from a task's meta.yaml file
 	// Returns empty slice if meta file doesn't exist or has no labels
 	LoadMetaLabels(ctx context.Context, sbiID string) ([]string, error)
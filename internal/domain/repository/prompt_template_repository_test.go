@@ -14,6 +14,7 @@ type MockPromptTemplateRepository struct {
 	mu                      sync.RWMutex
 	templates               map[string]string
 	labels                  map[string]string
+	reviewLabels            map[string]string
 	metaFiles               map[string][]string
 	pbiDecomposeTemplate    string
 	pbiDecomposeTemplateSet bool
@@ -22,9 +23,10 @@ type MockPromptTemplateRepository struct {
 // NewMockPromptTemplateRepository creates a new mock prompt template repository
 func NewMockPromptTemplateRepository() *MockPromptTemplateRepository {
 	return &MockPromptTemplateRepository{
-		templates: make(map[string]string),
-		labels:    make(map[string]string),
-		metaFiles: make(map[string][]string),
+		templates:    make(map[string]string),
+		labels:       make(map[string]string),
+		reviewLabels: make(map[string]string),
+		metaFiles:    make(map[string][]string),
 	}
 }
 
@@ -66,6 +68,14 @@ func (m *MockPromptTemplateRepository) LoadLabelContent(ctx context.Context, lab
 	return ""
 }
 
+// LoadLabelReviewContent loads the review-specific guidance of a label
+func (m *MockPromptTemplateRepository) LoadLabelReviewContent(ctx context.Context, labelName string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.reviewLabels[labelName]
+}
+
 // LoadMetaLabels loads labels from a task's meta.yaml file
 func (m *MockPromptTemplateRepository) LoadMetaLabels(ctx context.Context, sbiID string) ([]string, error) {
 	m.mu.RLock()
@@ -105,6 +115,12 @@ func (m *MockPromptTemplateRepository) SetLabel(labelName, content string) {
 	m.labels[labelName] = content
 }
 
+func (m *MockPromptTemplateRepository) SetLabelReview(labelName, content string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reviewLabels[labelName] = content
+}
+
 func (m *MockPromptTemplateRepository) SetMetaLabels(sbiID string, labels []string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -236,6 +252,25 @@ func TestPromptTemplateRepository_LoadLabelContentNotFound(t *testing.T) {
 	}
 }
 
+func TestPromptTemplateRepository_LoadLabelReviewContent(t *testing.T) {
+	repo := NewMockPromptTemplateRepository()
+	ctx := context.Background()
+
+	repo.SetLabel("auth", "# Auth Guidelines")
+	repo.SetLabelReview("auth", "# Auth Review Checklist\n- Tokens are never logged")
+
+	// Review guidance is kept apart from the implement guidance
+	if content := repo.LoadLabelReviewContent(ctx, "auth"); content != "# Auth Review Checklist\n- Tokens are never logged" {
+		t.Errorf("Expected auth review content, got: %s", content)
+	}
+	if content := repo.LoadLabelContent(ctx, "auth"); content != "# Auth Guidelines" {
+		t.Errorf("Expected auth label content, got: %s", content)
+	}
+	if content := repo.LoadLabelReviewContent(ctx, "frontend"); content != "" {
+		t.Errorf("Expected empty string for label without review guidance, got: %s", content)
+	}
+}
+
 func TestPromptTemplateRepository_LoadLabelContentHierarchical(t *testing.T) {
 	repo := NewMockPromptTemplateRepository()
 	ctx := context.Background()
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ReviewFeedback represents the structured issue list extracted from a review report
+// It is carried over into the next implement prompt when a review returns NEEDS_CHANGES or FAILED
+type ReviewFeedback struct {
+	ID         int64
+	SBIID      string
+	Turn       int      // Turn of the review that produced the feedback
+	Decision   string   // NEEDS_CHANGES or FAILED
+	Issues     []string // Issues extracted from the review report, in report order
+	ReportPath string   // Local path of the review report, readable by the next implement agent
+	CreatedAt  time.Time
+}
+
+// ReviewFeedbackRepository defines the interface for review feedback persistence
+type ReviewFeedbackRepository interface {
+	// Save saves feedback for a review turn, replacing any existing feedback for the same turn
+	Save(ctx context.Context, feedback *ReviewFeedback) error
+
+	// FindBySBIIDAndTurn retrieves feedback produced by the review at a specific turn
+	// Returns nil without error when no feedback exists
+	FindBySBIIDAndTurn(ctx context.Context, sbiID string, turn int) (*ReviewFeedback, error)
+
+	// FindLatestBySBIID retrieves the most recent feedback before the given turn
+	// Returns nil without error when no feedback exists
+	FindLatestBySBIID(ctx context.Context, sbiID string, beforeTurn int) (*ReviewFeedback, error)
+}
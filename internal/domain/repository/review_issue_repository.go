@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ReviewIssueStatus represents the resolution state of a review issue
+type ReviewIssueStatus string
+
+const (
+	ReviewIssueOpen      ReviewIssueStatus = "OPEN"      // Raised and not yet resolved
+	ReviewIssueAddressed ReviewIssueStatus = "ADDRESSED" // A review confirmed the issue is fixed
+	ReviewIssueWontFix   ReviewIssueStatus = "WONTFIX"   // Deliberately not fixed, with a reason
+)
+
+// IsClosed reports whether the issue no longer blocks DONE
+func (s ReviewIssueStatus) IsClosed() bool {
+	return s == ReviewIssueAddressed || s == ReviewIssueWontFix
+}
+
+// ReviewIssue is a single issue raised by a review, tracked across turns until it is closed
+type ReviewIssue struct {
+	ID          int64
+	SBIID       string
+	Number      int    // 1-based per SBI, referenced as ISSUE-<number> in review reports
+	Description string // Issue text from the review report
+	Blocking    bool   // Blocking issues must be closed before the SBI can be DONE
+	Status      ReviewIssueStatus
+	Note        string // Note of the last status change
+	RaisedTurn  int    // Turn of the review that raised the issue
+	Turn        int    // Turn of the last status change (the raising turn until then)
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReviewIssueRepository defines the interface for review issue persistence
+type ReviewIssueRepository interface {
+	// Add records new issues of an SBI, numbering them after the existing ones
+	// The ID and Number of each issue are set
+	Add(ctx context.Context, sbiID string, issues []*ReviewIssue) error
+
+	// FindBySBIID retrieves the issues of an SBI ordered by number
+	// Returns an empty slice without error when the SBI has no issues
+	FindBySBIID(ctx context.Context, sbiID string) ([]*ReviewIssue, error)
+
+	// UpdateStatus sets the status and note of a single issue
+	UpdateStatus(ctx context.Context, sbiID string, number int, status ReviewIssueStatus, note string, turn int) error
+}
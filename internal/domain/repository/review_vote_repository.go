@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ReviewVote is one reviewer's decision for an SBI turn in review quorum mode
+type ReviewVote struct {
+	SBIID      string
+	Turn       int
+	Reviewer   string // Reviewer name from the review_quorum setting
+	Decision   string // SUCCEEDED, NEEDS_CHANGES or FAILED
+	ReportPath string // The reviewer's own report
+	CreatedAt  time.Time
+}
+
+// ReviewVoteRepository persists reviewer votes per SBI turn
+type ReviewVoteRepository interface {
+	// Save stores a reviewer's vote, replacing the reviewer's previous vote for the turn
+	Save(ctx context.Context, vote *ReviewVote) error
+
+	// FindByTurn retrieves all votes cast for an SBI turn, ordered by reviewer
+	FindByTurn(ctx context.Context, sbiID string, turn int) ([]*ReviewVote, error)
+}
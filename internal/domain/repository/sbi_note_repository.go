@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrSBINoteNotFound is returned when a note does not exist for the SBI
+var ErrSBINoteNotFound = fmt.Errorf("SBI note %w", model.ErrNotFound)
+
+// SBINote is a free-form note or link attached to an SBI by a person
+// A note marked InjectPrompt is added to the prior context of the next agent step
+type SBINote struct {
+	ID           int64
+	SBIID        string
+	Body         string
+	Links        []string // URLs or ticket references
+	Author       string
+	InjectPrompt bool
+	InjectedTurn int // Turn whose prompt received the note; 0 until then
+	CreatedAt    time.Time
+}
+
+// SBINoteRepository defines the interface for SBI note persistence
+type SBINoteRepository interface {
+	// Add saves a new note and sets its ID
+	Add(ctx context.Context, note *SBINote) error
+
+	// FindBySBIID retrieves the notes of an SBI, oldest first
+	FindBySBIID(ctx context.Context, sbiID string) ([]*SBINote, error)
+
+	// FindForPrompt retrieves the notes to inject into the prompt of a turn: notes marked for
+	// the prompt that no earlier turn received. A retried turn gets the same notes again.
+	FindForPrompt(ctx context.Context, sbiID string, turn int) ([]*SBINote, error)
+
+	// MarkInjected records that the prompt of turn received the notes
+	MarkInjected(ctx context.Context, ids []int64, turn int) error
+
+	// SetInjectPrompt marks a note of an SBI for the prompt of the next turn, or unmarks it
+	// A note marked again reaches the next turn even if an earlier turn received it
+	// Returns ErrSBINoteNotFound when the SBI has no note with that ID
+	SetInjectPrompt(ctx context.Context, sbiID string, id int64, inject bool) error
+
+	// Delete removes a note of an SBI
+	// Returns ErrSBINoteNotFound when the SBI has no note with that ID
+	Delete(ctx context.Context, sbiID string, id int64) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrSBIReferenceNotFound is returned when an SBI does not reference the other SBI
+var ErrSBIReferenceNotFound = fmt.Errorf("SBI reference %w", model.ErrNotFound)
+
+// SBIReference declares that the final artifacts of another SBI are context for an SBI
+// (e.g. an implementation that builds upon the design doc written by an earlier SBI)
+// Unlike a dependency, a reference does not hold the SBI back.
+type SBIReference struct {
+	SBIID           string
+	ReferencedSBIID string
+	Note            string // Why the artifacts are relevant; shown to the agent
+	CreatedAt       time.Time
+}
+
+// SBIReferenceRepository defines the interface for SBI reference persistence
+type SBIReferenceRepository interface {
+	// Add saves a reference, replacing the note of an existing one
+	Add(ctx context.Context, ref *SBIReference) error
+
+	// FindBySBIID retrieves the references of an SBI, oldest first
+	FindBySBIID(ctx context.Context, sbiID string) ([]*SBIReference, error)
+
+	// Delete removes a reference
+	// Returns ErrSBIReferenceNotFound when the SBI does not reference the other SBI
+	Delete(ctx context.Context, sbiID, referencedSBIID string) error
+}
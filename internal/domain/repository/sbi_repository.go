@@ -2,17 +2,31 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 )
 
+// ErrSBICancelled is returned by Save when the stored SBI was cancelled in the meantime.
+// CANCELLED is terminal, so a stale in-memory entity must not overwrite it (use ResetSBIState to re-run).
+var ErrSBICancelled = errors.New("sbi was cancelled")
+
 // SBIRepository manages SBI entities
 type SBIRepository interface {
 	// Find retrieves an SBI by its ID
 	Find(ctx context.Context, id SBIID) (*sbi.SBI, error)
 
+	// FindMany retrieves the SBIs with the given IDs at once
+	// SBIs are returned in the order of ids; IDs without a stored SBI are skipped
+	FindMany(ctx context.Context, ids []SBIID) ([]*sbi.SBI, error)
+
 	// Save persists an SBI entity
+	// Returns ErrSBICancelled instead of overwriting a stored CANCELLED SBI with another status
+	// Returns ErrStaleEntity when the stored entity changed since it was loaded
 	Save(ctx context.Context, s *sbi.SBI) error
 
 	// Delete removes an SBI
@@ -21,6 +35,10 @@ type SBIRepository interface {
 	// List retrieves SBIs by filter
 	List(ctx context.Context, filter SBIFilter) ([]*sbi.SBI, error)
 
+	// ListWithExecutionState retrieves SBIs by filter together with the state of their dependencies,
+	// so that schedulers can decide which SBIs may run without loading dependencies one SBI at a time
+	ListWithExecutionState(ctx context.Context, filter SBIFilter) ([]*SBIExecutionState, error)
+
 	// FindByPBIID retrieves all SBIs belonging to a PBI
 	FindByPBIID(ctx context.Context, pbiID PBIID) ([]*sbi.SBI, error)
 
@@ -41,11 +59,65 @@ type SBIRepository interface {
 	SaveDependencies(ctx context.Context, sbiID SBIID, dependsOn []string) error
 }
 
+// SBIExecutionState is an SBI together with the state of the SBIs it depends on
+type SBIExecutionState struct {
+	SBI               *sbi.SBI
+	DependsOn         []string // IDs of the SBIs it depends on
+	UnmetDependencies []string // Dependencies that are not DONE, including ones no longer stored
+}
+
+// DependenciesMet reports whether every dependency of the SBI is DONE
+func (s *SBIExecutionState) DependenciesMet() bool {
+	return len(s.UnmetDependencies) == 0
+}
+
 // SBIFilter defines criteria for filtering SBIs
 type SBIFilter struct {
-	PBIID    *PBIID         // Filter by parent PBI
-	Labels   []string       // Filter by labels
-	Statuses []model.Status // Filter by status (uses domain model Status)
-	Limit    int
-	Offset   int
+	PBIID          *PBIID         // Filter by parent PBI
+	Labels         []string       // Filter SBIs that have all of these labels
+	Statuses       []model.Status // Filter by status (uses domain model Status)
+	AssignedAgents []string       // Filter by assigned agent
+	MinPriority    *int           // Filter by priority (inclusive)
+	MaxPriority    *int
+	MinTurns       *int // Filter by current turn (inclusive)
+	MaxTurns       *int
+	UpdatedAfter   *time.Time // Filter by last update (inclusive)
+	UpdatedBefore  *time.Time
+	OverdueAt      *time.Time         // Filter unfinished SBIs whose deadline is before this time
+	Sort           []SortKey          // Order before the execution order (priority, registration, sequence)
+	Aging          *sbi.PriorityAging // When enabled, the execution order uses the aged priority of pending SBIs
+	AgedAt         time.Time          // Time waits are measured to under Aging (now when zero)
+	Limit          int
+	Offset         int
+}
+
+// SortKey orders listed tasks by one field
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// SBISortFields are the fields SBIs can be sorted by
+var SBISortFields = []string{"priority", "turns", "updated", "created", "title", "status", "agent"}
+
+// ParseSortKeys parses comma-separated sort keys such as "priority,-updated"
+// ("-" sorts descending); every field must be one of fields
+func ParseSortKeys(spec string, fields []string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key := SortKey{Field: strings.ToLower(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+		known := false
+		for _, field := range fields {
+			known = known || key.Field == field
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown sort key %q (keys: %s)", key.Field, strings.Join(fields, ", "))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
 }
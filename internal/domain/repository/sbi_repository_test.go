@@ -41,6 +41,20 @@ func (m *MockSBIRepository) Find(ctx context.Context, id repository.SBIID) (*sbi
 	return s, nil
 }
 
+// FindMany retrieves the SBIs with the given IDs, skipping unknown IDs
+func (m *MockSBIRepository) FindMany(ctx context.Context, ids []repository.SBIID) ([]*sbi.SBI, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*sbi.SBI
+	for _, id := range ids {
+		if s, exists := m.sbis[id]; exists {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
 // Save persists an SBI entity
 func (m *MockSBIRepository) Save(ctx context.Context, s *sbi.SBI) error {
 	m.mu.Lock()
@@ -272,6 +286,69 @@ func TestSBIRepository_FindNotFound(t *testing.T) {
 	}
 }
 
+func TestSBIRepository_FindMany(t *testing.T) {
+	repo := NewMockSBIRepository()
+	ctx := context.Background()
+
+	var ids []repository.SBIID
+	for _, title := range []string{"First", "Second", "Third"} {
+		s, err := sbi.NewSBI(title, "Description", nil, sbi.SBIMetadata{})
+		if err != nil {
+			t.Fatalf("Failed to create SBI: %v", err)
+		}
+		if err := repo.Save(ctx, s); err != nil {
+			t.Fatalf("Failed to save SBI: %v", err)
+		}
+		ids = append(ids, repository.SBIID(s.ID().String()))
+	}
+
+	// Results follow the requested order and skip unknown IDs
+	found, err := repo.FindMany(ctx, []repository.SBIID{ids[2], "unknown", ids[0]})
+	if err != nil {
+		t.Fatalf("Failed to find SBIs: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("Expected 2 SBIs, got %d", len(found))
+	}
+	if found[0].Title() != "Third" || found[1].Title() != "First" {
+		t.Errorf("Expected [Third First], got [%s %s]", found[0].Title(), found[1].Title())
+	}
+}
+
+func TestSBIExecutionState_DependenciesMet(t *testing.T) {
+	tests := []struct {
+		name  string
+		state repository.SBIExecutionState
+		want  bool
+	}{
+		{"no dependencies", repository.SBIExecutionState{}, true},
+		{"all done", repository.SBIExecutionState{DependsOn: []string{"A", "B"}, UnmetDependencies: []string{}}, true},
+		{"one unmet", repository.SBIExecutionState{DependsOn: []string{"A", "B"}, UnmetDependencies: []string{"B"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.DependenciesMet(); got != tt.want {
+				t.Errorf("DependenciesMet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortKeys(t *testing.T) {
+	keys, err := repository.ParseSortKeys(" priority, -Updated ,", repository.SBISortFields)
+	if err != nil {
+		t.Fatalf("ParseSortKeys() error = %v", err)
+	}
+	want := []repository.SortKey{{Field: "priority"}, {Field: "updated", Desc: true}}
+	if len(keys) != len(want) || keys[0] != want[0] || keys[1] != want[1] {
+		t.Errorf("ParseSortKeys() = %v, want %v", keys, want)
+	}
+
+	if _, err := repository.ParseSortKeys("size", repository.SBISortFields); err == nil {
+		t.Error("ParseSortKeys() accepted an unknown key")
+	}
+}
+
 func TestSBIRepository_Save(t *testing.T) {
 	repo := NewMockSBIRepository()
 	ctx := context.Background()
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrSBIRowsNotFound is returned by ExportRows when the SBI has no row
+var ErrSBIRowsNotFound = fmt.Errorf("sbi rows %w", model.ErrNotFound)
+
+// SBIRows holds the database rows of a single SBI keyed by table name
+// Column values are kept as read from the database so rows round-trip unchanged
+type SBIRows map[string][]map[string]interface{}
+
+// SBISnapshotRepository copies the stored state of a single SBI in and out of the database
+// Used to reproduce an SBI on another machine (deespec sbi snapshot / sbi restore)
+// and to archive finished SBIs (deespec archive)
+type SBISnapshotRepository interface {
+	// ExportRows returns the SBI row together with its dependencies, execution logs,
+	// review feedback, acceptance criteria, review votes and step agents
+	// Returns ErrSBIRowsNotFound when the SBI does not exist
+	ExportRows(ctx context.Context, id SBIID) (SBIRows, error)
+
+	// ImportRows replaces the stored rows of the SBI with the given rows
+	// References that cannot be satisfied in this database (a missing parent PBI or
+	// dependency) are dropped rather than failing the import
+	ImportRows(ctx context.Context, id SBIID, rows SBIRows) error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrSBITombstoneNotFound is returned by Find when the SBI was never archived
+var ErrSBITombstoneNotFound = fmt.Errorf("sbi tombstone %w", model.ErrNotFound)
+
+// SBITombstone is the record left in the database for an archived SBI
+// It keeps the SBI's ID and sequence reserved and points to the archive holding its full state
+type SBITombstone struct {
+	SBIID       string
+	ParentPBIID string
+	Title       string
+	Status      string // Status at archive time
+	Sequence    int
+	CompletedAt *time.Time
+	ArchivePath string // Archive path relative to the .deespec directory
+	ArchivedAt  time.Time
+}
+
+// SBITombstoneFilter narrows a tombstone search
+type SBITombstoneFilter struct {
+	Query    string   // Case-insensitive match against the ID, title and parent PBI ID
+	Statuses []string // Status at archive time
+	Limit    int
+}
+
+// SBITombstoneRepository stores the tombstones of archived SBIs
+type SBITombstoneRepository interface {
+	// Save creates or replaces the tombstone of an SBI
+	Save(ctx context.Context, tombstone *SBITombstone) error
+
+	// Find returns the tombstone of an SBI
+	// Returns ErrSBITombstoneNotFound when the SBI was never archived
+	Find(ctx context.Context, id SBIID) (*SBITombstone, error)
+
+	// Search returns the tombstones matching the filter, most recently archived first
+	Search(ctx context.Context, filter SBITombstoneFilter) ([]*SBITombstone, error)
+
+	// Delete removes the tombstone of a restored SBI
+	Delete(ctx context.Context, id SBIID) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// StepAgent is the agent and model that last executed an SBI step
+type StepAgent struct {
+	SBIID     string
+	Step      string // 'implement' or 'review'
+	AgentType string // Agent type reported by the gateway capability
+	Model     string // Model passed to the agent ("" = agent default)
+	Turn      int    // Turn the step was executed in
+	UpdatedAt time.Time
+}
+
+// StepAgentRepository persists which agent executed each SBI step
+type StepAgentRepository interface {
+	// Find retrieves the agent that last executed an SBI step
+	// Returns nil without error when the step has not been executed
+	Find(ctx context.Context, sbiID, step string) (*StepAgent, error)
+
+	// Save records the agent of an SBI step, replacing any previous one
+	Save(ctx context.Context, stepAgent *StepAgent) error
+}
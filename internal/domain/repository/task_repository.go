@@ -2,10 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/task"
 )
 
+// ErrStaleEntity is returned by Save when the stored entity was saved by someone else after it was loaded.
+// Stored tasks carry a version that every save increments; a save succeeds only at the version the
+// entity was loaded at. Callers reload the entity, reapply their change and save again (see
+// service.RetryOnStale), or keep the stored state when the concurrent change supersedes theirs.
+var ErrStaleEntity = errors.New("entity was modified concurrently")
+
 // TaskRepository is a unified repository interface for Task entities (EPIC/PBI/SBI)
 // This interface allows polymorphic handling of different task types
 type TaskRepository interface {
@@ -13,6 +21,7 @@ type TaskRepository interface {
 	FindByID(ctx context.Context, id TaskID) (task.Task, error)
 
 	// Save persists a task entity
+	// Returns ErrStaleEntity when the stored task changed since it was loaded
 	Save(ctx context.Context, t task.Task) error
 
 	// Delete removes a task
@@ -61,6 +70,18 @@ type TaskFilter struct {
 	Steps     []Step     // Filter by current steps
 	ParentID  *TaskID    // Filter by parent task
 	HasParent *bool      // Filter tasks with/without parent
+	OverdueAt *time.Time // Filter tasks whose deadline passed before this time
 	Limit     int        // Limit number of results
 	Offset    int        // Offset for pagination
+
+	// SBI-only criteria (see SBIFilter)
+	Labels         []string
+	AssignedAgents []string
+	MinPriority    *int
+	MaxPriority    *int
+	MinTurns       *int
+	MaxTurns       *int
+	UpdatedAfter   *time.Time
+	UpdatedBefore  *time.Time
+	Sort           []SortKey
 }
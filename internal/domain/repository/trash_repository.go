@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrTrashItemNotFound is returned when no trashed PBI or SBI has the ID
+var ErrTrashItemNotFound = fmt.Errorf("trash item %w", model.ErrNotFound)
+
+// TrashKind is the kind of a trashed item
+type TrashKind string
+
+const (
+	TrashKindPBI TrashKind = "pbi"
+	TrashKindSBI TrashKind = "sbi"
+)
+
+// TrashItem is a deleted PBI or SBI kept in the trash
+// Its rows stay in the database, hidden from every lookup, until it is restored or purged
+type TrashItem struct {
+	Kind      TrashKind `json:"kind"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"` // Status at deletion time
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashRepository soft-deletes PBIs and SBIs and manages the trashed ones
+type TrashRepository interface {
+	// Trash marks a PBI or SBI as deleted at deletedAt
+	// Returns ErrTrashItemNotFound when no live item of the kind has the ID
+	Trash(ctx context.Context, kind TrashKind, id string, deletedAt time.Time) error
+
+	// Find returns the trashed PBI or SBI with the ID
+	// Returns ErrTrashItemNotFound when the ID is not in the trash
+	Find(ctx context.Context, id string) (*TrashItem, error)
+
+	// List returns the trashed items deleted before the given time (all when zero),
+	// most recently deleted first
+	List(ctx context.Context, deletedBefore time.Time) ([]*TrashItem, error)
+
+	// Restore clears the deletion mark of a trashed item
+	Restore(ctx context.Context, kind TrashKind, id string) error
+
+	// Purge permanently removes the rows of a trashed item (dependent rows are removed with an SBI)
+	Purge(ctx context.Context, kind TrashKind, id string) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// ErrTurnClaimed is returned by Claim when another runner already started the turn
+var ErrTurnClaimed = fmt.Errorf("%w: turn already claimed", model.ErrLockHeld)
+
+// TurnClaim records the runner that started a turn of an SBI
+type TurnClaim struct {
+	SBIID     string
+	Turn      int
+	PID       int
+	Hostname  string
+	ClaimedAt time.Time
+}
+
+// TurnClaimRepository guards turns against being executed twice
+// Runners claim (SBI, turn) right before calling the agent, so that a runner racing
+// past the locks (e.g. because of clock skew between hosts) cannot execute it again.
+type TurnClaimRepository interface {
+	// Claim records the current process as the runner of the turn
+	// Claims of the current process are claimed again, and claims older than staleAfter
+	// (0: never) or of a process of this host that exited are taken over.
+	// Returns the holding claim and ErrTurnClaimed when another runner holds the turn.
+	Claim(ctx context.Context, sbiID string, turn int, staleAfter time.Duration) (*TurnClaim, error)
+
+	// Release removes the claim of the current process, so that the turn can be retried
+	// by any runner (e.g. when it ended without being saved)
+	Release(ctx context.Context, sbiID string, turn int) error
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// criteriaHeadingKeywords are heading fragments that mark a section listing acceptance criteria
+var criteriaHeadingKeywords = []string{
+	"acceptance criteria", "acceptance criterion", "definition of done",
+	"受け入れ基準", "受入基準", "受け入れ条件", "完了条件", "完了基準",
+}
+
+// criterionResultPattern matches review lines such as "AC-2: FAIL - returns 500"
+var criterionResultPattern = regexp.MustCompile(`(?i)\bAC-(\d+)\s*[:：]\s*(PASS(?:ED)?|FAIL(?:ED)?)\b(.*)$`)
+
+// CriterionResult is the reviewer's verdict on a single acceptance criterion
+type CriterionResult struct {
+	Passed bool
+	Note   string
+}
+
+// ExtractAcceptanceCriteria extracts acceptance criteria from PBI/SBI markdown
+// List items under headings such as "Acceptance Criteria" or "受け入れ基準" are collected in order
+func ExtractAcceptanceCriteria(content string) []string {
+	var criteria []string
+	inSection := false
+	sectionLevel := 0
+	lastWasItem := false
+
+	for _, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(raw, " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if m := headingPattern.FindStringSubmatch(trimmed); m != nil {
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if isCriteriaHeading(m[1]) {
+				inSection = true
+				sectionLevel = level
+			} else if inSection && level <= sectionLevel {
+				// A sibling or parent heading ends the section; sub-headings do not
+				inSection = false
+			}
+			lastWasItem = false
+			continue
+		}
+
+		if trimmed == "" {
+			lastWasItem = false
+			continue
+		}
+
+		m := listItemPattern.FindStringSubmatch(line)
+		if m == nil {
+			// Indented continuation of the previous list item
+			if lastWasItem && inSection && strings.HasPrefix(raw, " ") && len(criteria) > 0 {
+				criteria[len(criteria)-1] += " " + trimmed
+				continue
+			}
+			lastWasItem = false
+			continue
+		}
+
+		if !inSection {
+			continue
+		}
+
+		text := strings.TrimSpace(m[2])
+		if cb := checkboxPattern.FindStringSubmatch(text); cb != nil {
+			text = strings.TrimSpace(cb[2])
+		}
+		if text == "" || isEmptyIssue(text) {
+			lastWasItem = false
+			continue
+		}
+
+		criteria = append(criteria, text)
+		lastWasItem = true
+	}
+
+	return dedupe(criteria)
+}
+
+// ParseCriteriaResults extracts per-criterion verdicts ("AC-<n>: PASS|FAIL - note") from a review report
+// When a criterion is reported more than once, the last verdict wins
+func ParseCriteriaResults(content string) map[int]CriterionResult {
+	results := make(map[int]CriterionResult)
+
+	for _, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		m := criterionResultPattern.FindStringSubmatch(raw)
+		if m == nil {
+			continue
+		}
+		position, err := strconv.Atoi(m[1])
+		if err != nil || position <= 0 {
+			continue
+		}
+		results[position] = CriterionResult{
+			Passed: strings.HasPrefix(strings.ToUpper(m[2]), "PASS"),
+			Note:   strings.TrimSpace(strings.TrimLeft(m[3], "*_` -–—:：")),
+		}
+	}
+
+	return results
+}
+
+// isCriteriaHeading reports whether a heading introduces a list of acceptance criteria
+func isCriteriaHeading(heading string) bool {
+	lower := strings.ToLower(heading)
+	for _, keyword := range criteriaHeadingKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractAcceptanceCriteria(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "english section with checkboxes and continuation",
+			content: `# Login API
+
+## Acceptance Criteria
+- [ ] Returns 200 with a token on success
+- [x] Returns 401 on wrong password
+  without revealing which field was wrong
+
+## Notes
+- Not a criterion
+`,
+			want: []string{
+				"Returns 200 with a token on success",
+				"Returns 401 on wrong password without revealing which field was wrong",
+			},
+		},
+		{
+			name: "japanese heading with sub-heading",
+			content: `## 受け入れ基準
+### 機能
+1. ログインできる
+### 非機能
+2. 応答が1秒以内
+## 備考
+- 対象外
+`,
+			want: []string{"ログインできる", "応答が1秒以内"},
+		},
+		{
+			name:    "no criteria section",
+			content: "## Description\n- just a list\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractAcceptanceCriteria(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractAcceptanceCriteria() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCriteriaResults(t *testing.T) {
+	content := `## Summary
+DECISION: NEEDS_CHANGES
+
+## Acceptance Criteria
+- AC-1: PASS
+- **AC-2: FAIL** - returns 500 on wrong password
+- AC-3：passed — verified manually
+AC-4: FAIL
+`
+	got := ParseCriteriaResults(content)
+	want := map[int]CriterionResult{
+		1: {Passed: true, Note: ""},
+		2: {Passed: false, Note: "returns 500 on wrong password"},
+		3: {Passed: true, Note: "verified manually"},
+		4: {Passed: false, Note: ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCriteriaResults() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+)
+
+// AgentRoutingRule selects the agent and model of a step for tasks with matching characteristics,
+// e.g. large refactors on a model with a big context window, documentation on a cheaper one
+// Every condition that is set must match; a rule without conditions matches every task.
+type AgentRoutingRule struct {
+	Name      string   // Identifies the rule in logs and journals
+	Steps     []string // "implement" and/or "review" (empty = both)
+	Labels    []string // The task has at least one of these labels
+	MinHours  float64  // Estimated hours at least (0 = no lower bound)
+	MaxHours  float64  // Estimated hours at most (0 = no upper bound)
+	FileTypes []string // Every file the task touches has one of these extensions, e.g. ".md" (the task must list files)
+	Agent     string   // Agent type to use ("" = default agent)
+	Model     string   // Model to use ("" = agent default)
+}
+
+// TaskTraits are the characteristics of a task that routing rules match on
+type TaskTraits struct {
+	Labels         []string
+	EstimatedHours float64
+	FilePaths      []string
+}
+
+// AgentRouter picks the agent and model of a step by the first matching routing rule
+// A nil router matches nothing.
+type AgentRouter struct {
+	rules []AgentRoutingRule
+}
+
+// NewAgentRouter validates the rules and builds a router from them
+func NewAgentRouter(rules []AgentRoutingRule) (*AgentRouter, error) {
+	router := &AgentRouter{}
+	for i, rule := range rules {
+		if err := ValidateAgentRoutingRule(rule); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		router.rules = append(router.rules, normalizeRoutingRule(rule))
+	}
+	return router, nil
+}
+
+// ValidateAgentRoutingRule checks the steps, bounds and route of a rule
+func ValidateAgentRoutingRule(rule AgentRoutingRule) error {
+	for _, step := range rule.Steps {
+		if !label.IsRoutableStep(step) {
+			return fmt.Errorf("unknown step %q (must be implement or review)", step)
+		}
+	}
+	if rule.MinHours < 0 || rule.MaxHours < 0 {
+		return errors.New("min_hours and max_hours must not be negative")
+	}
+	if rule.MaxHours > 0 && rule.MinHours > rule.MaxHours {
+		return fmt.Errorf("min_hours %g is greater than max_hours %g", rule.MinHours, rule.MaxHours)
+	}
+	for _, ext := range rule.FileTypes {
+		if strings.Trim(ext, ". ") == "" {
+			return fmt.Errorf("invalid file type %q (use an extension such as .md)", ext)
+		}
+	}
+	if rule.Agent == "" && rule.Model == "" {
+		return errors.New("agent or model is required")
+	}
+	if rule.Agent != "" && !label.IsRoutableAgent(rule.Agent) {
+		return fmt.Errorf("unknown agent %q (must be one of %s)", rule.Agent, strings.Join(label.RoutableAgents, ", "))
+	}
+	return nil
+}
+
+// normalizeRoutingRule names unnamed rules and lower-cases file extensions with a leading dot
+func normalizeRoutingRule(rule AgentRoutingRule) AgentRoutingRule {
+	if rule.Name == "" {
+		rule.Name = strings.Trim(rule.Agent+"/"+rule.Model, "/")
+	}
+	fileTypes := make([]string, 0, len(rule.FileTypes))
+	for _, ext := range rule.FileTypes {
+		fileTypes = append(fileTypes, "."+strings.ToLower(strings.Trim(ext, ". ")))
+	}
+	rule.FileTypes = fileTypes
+	return rule
+}
+
+// Select returns the first rule matching the step and the task
+// force_implement is routed as implement.
+func (r *AgentRouter) Select(step string, traits TaskTraits) (AgentRoutingRule, bool) {
+	if r == nil {
+		return AgentRoutingRule{}, false
+	}
+	if step == "force_implement" {
+		step = label.RouteStepImplement
+	}
+	if !label.IsRoutableStep(step) {
+		return AgentRoutingRule{}, false
+	}
+	for _, rule := range r.rules {
+		if rule.matches(step, traits) {
+			return rule, true
+		}
+	}
+	return AgentRoutingRule{}, false
+}
+
+// Route returns the agent route of the rule
+func (rule AgentRoutingRule) Route() label.AgentRoute {
+	return label.AgentRoute{Agent: rule.Agent, Model: rule.Model}
+}
+
+func (rule AgentRoutingRule) matches(step string, traits TaskTraits) bool {
+	if len(rule.Steps) > 0 && !containsString(rule.Steps, step) {
+		return false
+	}
+	if len(rule.Labels) > 0 && !sharesLabel(rule.Labels, traits.Labels) {
+		return false
+	}
+	if rule.MinHours > 0 && traits.EstimatedHours < rule.MinHours {
+		return false
+	}
+	if rule.MaxHours > 0 && traits.EstimatedHours > rule.MaxHours {
+		return false
+	}
+	if len(rule.FileTypes) > 0 {
+		if len(traits.FilePaths) == 0 {
+			return false
+		}
+		for _, path := range traits.FilePaths {
+			if !containsString(rule.FileTypes, strings.ToLower(filepath.Ext(path))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sharesLabel reports whether the task has one of the labels (case-insensitive)
+func sharesLabel(ruleLabels, taskLabels []string) bool {
+	for _, want := range ruleLabels {
+		for _, have := range taskLabels {
+			if strings.EqualFold(want, have) {
+				return true
+			}
+		}
+	}
+	return false
+}
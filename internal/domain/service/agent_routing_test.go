@@ -0,0 +1,101 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAgentRouter_Select(t *testing.T) {
+	router, err := NewAgentRouter([]AgentRoutingRule{
+		{Name: "docs", Labels: []string{"docs"}, Agent: "claude-code-cli", Model: "claude-haiku"},
+		{Name: "markdown", FileTypes: []string{"md", ".MDX"}, Model: "claude-haiku"},
+		{Name: "large-refactor", Steps: []string{"implement"}, Labels: []string{"refactor"}, MinHours: 8, Model: "claude-opus"},
+		{Name: "quick-review", Steps: []string{"review"}, MaxHours: 1, Agent: "codex"},
+	})
+	if err != nil {
+		t.Fatalf("NewAgentRouter failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		step   string
+		traits TaskTraits
+		want   string // "" = no rule
+	}{
+		{"label matches case-insensitively", "implement", TaskTraits{Labels: []string{"Docs"}}, "docs"},
+		{"first matching rule wins", "review", TaskTraits{Labels: []string{"docs"}, FilePaths: []string{"README.md"}}, "docs"},
+		{"all files of the types", "implement", TaskTraits{FilePaths: []string{"README.md", "docs/guide.mdx"}}, "markdown"},
+		{"not all files of the types", "implement", TaskTraits{FilePaths: []string{"README.md", "main.go"}}, ""},
+		{"file types need files", "implement", TaskTraits{}, ""},
+		{"large refactor", "implement", TaskTraits{Labels: []string{"refactor"}, EstimatedHours: 12}, "large-refactor"},
+		{"forced implementation", "force_implement", TaskTraits{Labels: []string{"refactor"}, EstimatedHours: 8}, "large-refactor"},
+		{"small refactor", "implement", TaskTraits{Labels: []string{"refactor"}, EstimatedHours: 2}, ""},
+		{"refactor review", "review", TaskTraits{Labels: []string{"refactor"}, EstimatedHours: 12}, ""},
+		{"quick review", "review", TaskTraits{EstimatedHours: 0.5}, "quick-review"},
+		{"unroutable step", "done", TaskTraits{Labels: []string{"docs"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := router.Select(tt.step, tt.traits)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Select() = %q; want no rule", rule.Name)
+				}
+				return
+			}
+			if !ok || rule.Name != tt.want {
+				t.Errorf("Select() = %q, %v; want %q", rule.Name, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentRouter_NilAndDefaultName(t *testing.T) {
+	var router *AgentRouter
+	if _, ok := router.Select("implement", TaskTraits{}); ok {
+		t.Error("nil router should not select a rule")
+	}
+
+	router, err := NewAgentRouter([]AgentRoutingRule{{Agent: "codex", Model: "gpt-5"}})
+	if err != nil {
+		t.Fatalf("NewAgentRouter failed: %v", err)
+	}
+	rule, ok := router.Select("review", TaskTraits{})
+	if !ok || rule.Name != "codex/gpt-5" {
+		t.Errorf("Select() = %q, %v; want codex/gpt-5", rule.Name, ok)
+	}
+	if route := rule.Route(); route.Agent != "codex" || route.Model != "gpt-5" {
+		t.Errorf("Route() = %+v", route)
+	}
+}
+
+func TestValidateAgentRoutingRule(t *testing.T) {
+	tests := []struct {
+		rule    AgentRoutingRule
+		wantErr string
+	}{
+		{AgentRoutingRule{Model: "claude-haiku"}, ""},
+		{AgentRoutingRule{Steps: []string{"done"}, Model: "m"}, "unknown step"},
+		{AgentRoutingRule{MinHours: -1, Model: "m"}, "must not be negative"},
+		{AgentRoutingRule{MinHours: 8, MaxHours: 4, Model: "m"}, "greater than max_hours"},
+		{AgentRoutingRule{FileTypes: []string{"."}, Model: "m"}, "invalid file type"},
+		{AgentRoutingRule{Labels: []string{"docs"}}, "agent or model is required"},
+		{AgentRoutingRule{Agent: "claude"}, "unknown agent"},
+	}
+	for _, tt := range tests {
+		err := ValidateAgentRoutingRule(tt.rule)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateAgentRoutingRule(%+v) = %v; want nil", tt.rule, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateAgentRoutingRule(%+v) = %v; want %q", tt.rule, err, tt.wantErr)
+		}
+	}
+
+	if _, err := NewAgentRouter([]AgentRoutingRule{{Model: "m"}, {Agent: "claude"}}); err == nil || !strings.Contains(err.Error(), "rule 2") {
+		t.Errorf("NewAgentRouter should report the invalid rule, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// What happens when an implement turn exceeds its change budget
+const (
+	ChangeBudgetSplit = "split" // Ask the agent to cut the change down, flagging the turn if it does not
+	ChangeBudgetFlag  = "flag"  // Flag the turn for human review right away
+)
+
+// ChangeStat is the size of the code change an implement turn made
+type ChangeStat struct {
+	Files   int // Files added, modified or deleted
+	Added   int // Lines added
+	Deleted int // Lines deleted
+}
+
+// Lines returns the number of lines changed (added plus deleted)
+func (s ChangeStat) Lines() int {
+	return s.Added + s.Deleted
+}
+
+// String describes the change, e.g. "412 lines in 9 files"
+func (s ChangeStat) String() string {
+	return fmt.Sprintf("%s in %s", plural(s.Lines(), "line"), plural(s.Files, "file"))
+}
+
+// ChangeBudget caps how much code a single implement turn may change, keeping agent diffs small
+// enough for a human to review; a limit <= 0 is not checked
+type ChangeBudget struct {
+	MaxLines int // Lines changed (added plus deleted)
+	MaxFiles int // Files changed
+}
+
+// Enabled reports whether any limit is set
+func (b ChangeBudget) Enabled() bool {
+	return b.MaxLines > 0 || b.MaxFiles > 0
+}
+
+// Exceeded reports whether a change is over any of the limits
+func (b ChangeBudget) Exceeded(stat ChangeStat) bool {
+	return (b.MaxLines > 0 && stat.Lines() > b.MaxLines) || (b.MaxFiles > 0 && stat.Files > b.MaxFiles)
+}
+
+// String describes the limits, e.g. "300 lines, 10 files"
+func (b ChangeBudget) String() string {
+	var limits []string
+	if b.MaxLines > 0 {
+		limits = append(limits, plural(b.MaxLines, "line"))
+	}
+	if b.MaxFiles > 0 {
+		limits = append(limits, plural(b.MaxFiles, "file"))
+	}
+	return strings.Join(limits, ", ")
+}
+
+// plural formats a count with its noun, e.g. "1 file", "2 files"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
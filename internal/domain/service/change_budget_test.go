@@ -0,0 +1,34 @@
+package service
+
+import "testing"
+
+func TestChangeBudget_Exceeded(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget ChangeBudget
+		stat   ChangeStat
+		want   bool
+	}{
+		{"no limits", ChangeBudget{}, ChangeStat{Files: 100, Added: 5000}, false},
+		{"within both", ChangeBudget{MaxLines: 300, MaxFiles: 10}, ChangeStat{Files: 10, Added: 200, Deleted: 100}, false},
+		{"too many lines", ChangeBudget{MaxLines: 300, MaxFiles: 10}, ChangeStat{Files: 2, Added: 250, Deleted: 51}, true},
+		{"too many files", ChangeBudget{MaxLines: 300, MaxFiles: 10}, ChangeStat{Files: 11, Added: 20}, true},
+		{"files only", ChangeBudget{MaxFiles: 3}, ChangeStat{Files: 3, Added: 5000}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.budget.Exceeded(tt.stat); got != tt.want {
+				t.Errorf("Exceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeBudget_String(t *testing.T) {
+	if got := (ChangeBudget{MaxLines: 300, MaxFiles: 1}).String(); got != "300 lines, 1 file" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (ChangeStat{Files: 1, Added: 1}).String(); got != "1 line in 1 file" {
+		t.Errorf("String() = %q", got)
+	}
+}
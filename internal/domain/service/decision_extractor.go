@@ -0,0 +1,228 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Review decisions recognized by the decision extractors
+const (
+	DecisionSucceeded    = "SUCCEEDED"
+	DecisionNeedsChanges = "NEEDS_CHANGES"
+	DecisionFailed       = "FAILED"
+)
+
+// Decision extraction strategy names (setting.json "decision_extraction.strategies")
+const (
+	DecisionStrategyJSONTail    = "json_tail"
+	DecisionStrategyFrontMatter = "front_matter"
+	DecisionStrategyRegex       = "regex"
+	DecisionStrategyHeuristic   = "heuristic"
+)
+
+// DefaultDecisionStrategies is the strategy order used when none is configured
+var DefaultDecisionStrategies = []string{
+	DecisionStrategyJSONTail,
+	DecisionStrategyFrontMatter,
+	DecisionStrategyRegex,
+	DecisionStrategyHeuristic,
+}
+
+// DecisionExtractor extracts a review decision from a review report or agent output
+type DecisionExtractor interface {
+	// Name returns the strategy name recorded in logs for auditability
+	Name() string
+	// Extract returns the decision and true if this strategy found one
+	Extract(content string) (string, bool)
+}
+
+// normalizeDecision maps a raw decision value to a known decision
+func normalizeDecision(raw string) (string, bool) {
+	decision := strings.ToUpper(strings.TrimSpace(raw))
+	decision = strings.ReplaceAll(decision, " ", "_")
+	switch decision {
+	case DecisionSucceeded, DecisionNeedsChanges, DecisionFailed:
+		return decision, true
+	}
+	return "", false
+}
+
+// JSONTailDecisionExtractor reads {"decision": "..."} from one of the last lines
+type JSONTailDecisionExtractor struct {
+	MaxLines int // Number of trailing lines to scan
+}
+
+// Name returns the strategy name
+func (e *JSONTailDecisionExtractor) Name() string { return DecisionStrategyJSONTail }
+
+// Extract scans the trailing lines for a JSON object with a decision field
+func (e *JSONTailDecisionExtractor) Extract(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	maxLines := e.MaxLines
+	if maxLines <= 0 {
+		maxLines = 5
+	}
+	start := len(lines) - maxLines
+	if start < 0 {
+		start = 0
+	}
+
+	for i := len(lines) - 1; i >= start; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "{") || !strings.Contains(line, "decision") {
+			continue
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			continue
+		}
+		if raw, ok := result["decision"].(string); ok {
+			if decision, ok := normalizeDecision(raw); ok {
+				return decision, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FrontMatterDecisionExtractor reads "decision:" from a leading YAML front matter block
+type FrontMatterDecisionExtractor struct{}
+
+// Name returns the strategy name
+func (e *FrontMatterDecisionExtractor) Name() string { return DecisionStrategyFrontMatter }
+
+// Extract parses the front matter delimited by "---" lines at the top of content
+func (e *FrontMatterDecisionExtractor) Extract(content string) (string, bool) {
+	content = strings.TrimLeft(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return "", false
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return "", false
+	}
+
+	var frontMatter map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content[4:4+end]), &frontMatter); err != nil {
+		return "", false
+	}
+	raw, ok := frontMatter["decision"].(string)
+	if !ok {
+		return "", false
+	}
+	return normalizeDecision(raw)
+}
+
+// defaultDecisionPattern matches "DECISION: SUCCEEDED" style lines and "[SUCCEEDED]" markers
+var defaultDecisionPattern = regexp.MustCompile(`(?i)(?:DECISION\s*[:：]\s*\**\s*(SUCCEEDED|NEEDS[_ ]CHANGES|FAILED)\b|\[(SUCCEEDED|NEEDS_CHANGES|FAILED)\])`)
+
+// RegexDecisionExtractor finds the first decision marker matching Pattern
+// The decision is taken from the first non-empty capture group
+type RegexDecisionExtractor struct {
+	Pattern *regexp.Regexp
+}
+
+// Name returns the strategy name
+func (e *RegexDecisionExtractor) Name() string { return DecisionStrategyRegex }
+
+// Extract returns the decision of the first marker in content
+func (e *RegexDecisionExtractor) Extract(content string) (string, bool) {
+	pattern := e.Pattern
+	if pattern == nil {
+		pattern = defaultDecisionPattern
+	}
+	m := pattern.FindStringSubmatch(content)
+	if m == nil {
+		return "", false
+	}
+	if len(m) == 1 {
+		return normalizeDecision(m[0])
+	}
+	for _, group := range m[1:] {
+		if group != "" {
+			return normalizeDecision(group)
+		}
+	}
+	return "", false
+}
+
+// mockAgentMarkers identify output of mock agents, which never state a decision
+var mockAgentMarkers = []string{"[Gemini Mock]", "[Codex Mock]", "[Mock]"}
+
+// HeuristicDecisionExtractor is the last-resort fallback and always yields a decision:
+// SUCCEEDED for mock agents (so development flows proceed), NEEDS_CHANGES otherwise
+type HeuristicDecisionExtractor struct{}
+
+// Name returns the strategy name
+func (e *HeuristicDecisionExtractor) Name() string { return DecisionStrategyHeuristic }
+
+// Extract always returns a decision
+func (e *HeuristicDecisionExtractor) Extract(content string) (string, bool) {
+	for _, marker := range mockAgentMarkers {
+		if strings.Contains(content, marker) {
+			return DecisionSucceeded, true
+		}
+	}
+	return DecisionNeedsChanges, true
+}
+
+// DecisionExtractorChain tries its extractors in order and reports which one matched
+type DecisionExtractorChain struct {
+	extractors []DecisionExtractor
+}
+
+// NewDecisionExtractorChain creates a chain from strategy names
+// An empty list uses DefaultDecisionStrategies; an unknown name is an error.
+// pattern overrides the marker pattern of the regex strategy (empty keeps the default).
+func NewDecisionExtractorChain(strategies []string, pattern string) (*DecisionExtractorChain, error) {
+	if len(strategies) == 0 {
+		strategies = DefaultDecisionStrategies
+	}
+
+	var markerPattern *regexp.Regexp
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decision pattern: %w", err)
+		}
+		markerPattern = compiled
+	}
+
+	chain := &DecisionExtractorChain{}
+	for _, name := range strategies {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case DecisionStrategyJSONTail:
+			chain.extractors = append(chain.extractors, &JSONTailDecisionExtractor{MaxLines: 5})
+		case DecisionStrategyFrontMatter:
+			chain.extractors = append(chain.extractors, &FrontMatterDecisionExtractor{})
+		case DecisionStrategyRegex:
+			chain.extractors = append(chain.extractors, &RegexDecisionExtractor{Pattern: markerPattern})
+		case DecisionStrategyHeuristic:
+			chain.extractors = append(chain.extractors, &HeuristicDecisionExtractor{})
+		default:
+			return nil, fmt.Errorf("unknown decision extractor %q (valid: %s)", name, strings.Join(DefaultDecisionStrategies, ", "))
+		}
+	}
+	return chain, nil
+}
+
+// NewDefaultDecisionExtractorChain creates a chain with DefaultDecisionStrategies
+func NewDefaultDecisionExtractorChain() *DecisionExtractorChain {
+	chain, _ := NewDecisionExtractorChain(nil, "")
+	return chain
+}
+
+// Extract returns the decision of the first matching extractor and its strategy name
+// Both are empty when no extractor matched (only possible without the heuristic fallback)
+func (c *DecisionExtractorChain) Extract(content string) (decision string, strategy string) {
+	for _, extractor := range c.extractors {
+		if decision, ok := extractor.Extract(content); ok {
+			return decision, extractor.Name()
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestDecisionExtractorChain_Extract(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantDecision string
+		wantStrategy string
+	}{
+		{
+			name: "structured JSON tail",
+			content: `## Summary
+DECISION: NEEDS_CHANGES
+
+Looks good overall.
+
+{"decision": "succeeded", "turn": 3}
+`,
+			wantDecision: DecisionSucceeded,
+			wantStrategy: DecisionStrategyJSONTail,
+		},
+		{
+			name: "YAML front matter",
+			content: `---
+decision: needs changes
+reviewer: claude
+---
+# Review
+`,
+			wantDecision: DecisionNeedsChanges,
+			wantStrategy: DecisionStrategyFrontMatter,
+		},
+		{
+			name:         "regex marker",
+			content:      "## Summary\n**DECISION: FAILED**\n\nThe build is broken.",
+			wantDecision: DecisionFailed,
+			wantStrategy: DecisionStrategyRegex,
+		},
+		{
+			name:         "bracket marker",
+			content:      "Review complete [SUCCEEDED]",
+			wantDecision: DecisionSucceeded,
+			wantStrategy: DecisionStrategyRegex,
+		},
+		{
+			name:         "mock agent heuristic",
+			content:      "[Codex Mock] implemented the task",
+			wantDecision: DecisionSucceeded,
+			wantStrategy: DecisionStrategyHeuristic,
+		},
+		{
+			name:         "no marker heuristic",
+			content:      "I reviewed the code.",
+			wantDecision: DecisionNeedsChanges,
+			wantStrategy: DecisionStrategyHeuristic,
+		},
+		{
+			name:         "invalid JSON decision falls through",
+			content:      "DECISION: SUCCEEDED\n{\"decision\": \"maybe\"}",
+			wantDecision: DecisionSucceeded,
+			wantStrategy: DecisionStrategyRegex,
+		},
+	}
+
+	chain := NewDefaultDecisionExtractorChain()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, strategy := chain.Extract(tt.content)
+			if decision != tt.wantDecision || strategy != tt.wantStrategy {
+				t.Errorf("Extract() = (%q, %q), want (%q, %q)", decision, strategy, tt.wantDecision, tt.wantStrategy)
+			}
+		})
+	}
+}
+
+func TestNewDecisionExtractorChain_Config(t *testing.T) {
+	// Order and custom pattern come from configuration
+	chain, err := NewDecisionExtractorChain([]string{"regex", "json_tail"}, `(?m)^Verdict:\s*(\w+)`)
+	if err != nil {
+		t.Fatalf("NewDecisionExtractorChain() error = %v", err)
+	}
+
+	decision, strategy := chain.Extract("Verdict: failed\n{\"decision\": \"SUCCEEDED\"}")
+	if decision != DecisionFailed || strategy != DecisionStrategyRegex {
+		t.Errorf("Extract() = (%q, %q), want (FAILED, regex)", decision, strategy)
+	}
+
+	// Without the heuristic fallback nothing may match
+	decision, strategy = chain.Extract("no decision here")
+	if decision != "" || strategy != "" {
+		t.Errorf("Extract() = (%q, %q), want no match", decision, strategy)
+	}
+
+	if _, err := NewDecisionExtractorChain([]string{"llm"}, ""); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+	if _, err := NewDecisionExtractorChain(nil, "("); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
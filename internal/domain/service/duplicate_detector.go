@@ -0,0 +1,96 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weight of the title in the similarity of tasks that both have a description
+const duplicateTitleWeight = 0.6
+
+// DuplicateCandidate is a task compared for near-duplicates: a new task or an existing one
+type DuplicateCandidate struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+// DuplicateMatch is an existing task similar to a new one
+type DuplicateMatch struct {
+	ID    string
+	Title string
+	Score float64 // Similarity from 0 (unrelated) to 1 (identical)
+}
+
+// FindDuplicates returns the existing tasks whose similarity to the candidate is at least
+// the threshold, most similar first
+// Similarity is the Jaccard index of the character trigrams of the normalized texts. The
+// title counts for 60% when both tasks have a description; otherwise the title alone decides.
+func FindDuplicates(candidate DuplicateCandidate, existing []DuplicateCandidate, threshold float64) []DuplicateMatch {
+	title := trigrams(candidate.Title)
+	description := trigrams(candidate.Description)
+
+	var matches []DuplicateMatch
+	for _, other := range existing {
+		if other.ID != "" && other.ID == candidate.ID {
+			continue
+		}
+		score := jaccard(title, trigrams(other.Title))
+		if otherDescription := trigrams(other.Description); len(description) > 0 && len(otherDescription) > 0 {
+			score = duplicateTitleWeight*score + (1-duplicateTitleWeight)*jaccard(description, otherDescription)
+		}
+		if score >= threshold {
+			matches = append(matches, DuplicateMatch{ID: other.ID, Title: other.Title, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// trigrams returns the set of character trigrams of a text, lower-cased with runs of
+// punctuation and spaces collapsed into one space (Japanese text works as is)
+func trigrams(text string) map[string]bool {
+	var b strings.Builder
+	space := true // Drops leading separators
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+		} else if !space {
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+	runes := []rune(strings.TrimSpace(b.String()))
+	if len(runes) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool)
+	if len(runes) < 3 {
+		set[string(runes)] = true
+		return set
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = true
+	}
+	return set
+}
+
+// jaccard returns the Jaccard index of two sets (0 when either is empty)
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for gram := range a {
+		if b[gram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
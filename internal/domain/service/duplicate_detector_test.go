@@ -0,0 +1,68 @@
+package service
+
+import "testing"
+
+func TestFindDuplicates(t *testing.T) {
+	existing := []DuplicateCandidate{
+		{ID: "SBI-1", Title: "Add login endpoint"},
+		{ID: "SBI-2", Title: "Add logout endpoint"},
+		{ID: "SBI-3", Title: "Refactor billing reports"},
+		{ID: "SBI-4", Title: "add LOGIN endpoint!"},
+	}
+
+	matches := FindDuplicates(DuplicateCandidate{Title: "Add login endpoint"}, existing, 0.8)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+	for _, m := range matches {
+		if m.ID != "SBI-1" && m.ID != "SBI-4" {
+			t.Errorf("unexpected match %+v", m)
+		}
+		if m.Score != 1 {
+			t.Errorf("expected normalized titles to match exactly, got %v for %s", m.Score, m.ID)
+		}
+	}
+
+	matches = FindDuplicates(DuplicateCandidate{Title: "Add login endpoint"}, existing, 0.3)
+	if len(matches) < 3 || matches[2].ID != "SBI-2" {
+		t.Fatalf("expected the similar title ranked after the exact ones, got %+v", matches)
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Score > matches[i-1].Score {
+			t.Errorf("matches are not sorted by score: %+v", matches)
+		}
+	}
+}
+
+func TestFindDuplicates_Descriptions(t *testing.T) {
+	existing := []DuplicateCandidate{
+		{ID: "SBI-1", Title: "Fix flaky test", Description: "The payment webhook test times out on CI"},
+		{ID: "SBI-2", Title: "Fix flaky test", Description: "Search indexer test depends on map iteration order"},
+	}
+
+	candidate := DuplicateCandidate{Title: "Fix flaky test", Description: "The payment webhook test times out on CI runners"}
+	matches := FindDuplicates(candidate, existing, 0.8)
+	if len(matches) != 1 || matches[0].ID != "SBI-1" {
+		t.Errorf("expected the description to tell same-titled tasks apart, got %+v", matches)
+	}
+
+	// Without a description the title alone decides
+	matches = FindDuplicates(DuplicateCandidate{Title: "Fix flaky test"}, existing, 0.8)
+	if len(matches) != 2 {
+		t.Errorf("expected both same-titled tasks, got %+v", matches)
+	}
+}
+
+func TestFindDuplicates_SkipsItself(t *testing.T) {
+	existing := []DuplicateCandidate{
+		{ID: "PBI-001", Title: "ユーザー認証の実装"},
+		{ID: "PBI-002", Title: ""},
+	}
+	if matches := FindDuplicates(DuplicateCandidate{ID: "PBI-001", Title: "ユーザー認証の実装"}, existing, 0.5); len(matches) != 0 {
+		t.Errorf("a task is not a duplicate of itself, got %+v", matches)
+	}
+	matches := FindDuplicates(DuplicateCandidate{Title: "ユーザー認証を実装"}, existing, 0.3)
+	if len(matches) != 1 || matches[0].ID != "PBI-001" {
+		t.Errorf("expected the Japanese title to match, got %+v", matches)
+	}
+}
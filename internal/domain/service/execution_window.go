@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var executionWindowPattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})$`)
+
+// ExecutionWindow is a daily period of local time in which turns may start
+// A window whose end is before its start wraps past midnight (e.g. 22:00-06:00).
+type ExecutionWindow struct {
+	Start int // Minutes after local midnight
+	End   int // Minutes after local midnight; 1440 for "24:00"
+}
+
+// ParseExecutionWindow parses a window written as "HH:MM-HH:MM"
+func ParseExecutionWindow(spec string) (ExecutionWindow, error) {
+	m := executionWindowPattern.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return ExecutionWindow{}, fmt.Errorf("invalid execution window %q (expected HH:MM-HH:MM, e.g. 22:00-06:00)", spec)
+	}
+	start, okStart := clockMinutes(m[1], m[2])
+	end, okEnd := clockMinutes(m[3], m[4])
+	if !okStart || !okEnd || start == 24*60 {
+		return ExecutionWindow{}, fmt.Errorf("invalid execution window %q (times must be between 00:00 and 24:00)", spec)
+	}
+	if start == end {
+		return ExecutionWindow{}, fmt.Errorf("invalid execution window %q (start and end must differ; use 00:00-24:00 for the whole day)", spec)
+	}
+	return ExecutionWindow{Start: start, End: end}, nil
+}
+
+// clockMinutes converts an hour and minute to minutes after midnight, accepting 24:00
+func clockMinutes(hour, minute string) (int, bool) {
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minute)
+	if m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+// Contains reports whether a time of day, in minutes after midnight, falls in the window
+func (w ExecutionWindow) Contains(minute int) bool {
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	return minute >= w.Start || minute < w.End
+}
+
+// String returns the window as "HH:MM-HH:MM"
+func (w ExecutionWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
+
+// ExecutionSchedule is the set of windows in which turns may start
+// A schedule without windows is always open.
+type ExecutionSchedule struct {
+	windows []ExecutionWindow
+}
+
+// ParseExecutionSchedule parses the windows of a schedule
+func ParseExecutionSchedule(specs []string) (*ExecutionSchedule, error) {
+	schedule := &ExecutionSchedule{}
+	for _, spec := range specs {
+		window, err := ParseExecutionWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		schedule.windows = append(schedule.windows, window)
+	}
+	return schedule, nil
+}
+
+// Restricted reports whether the schedule limits when turns may start
+func (s *ExecutionSchedule) Restricted() bool {
+	return s != nil && len(s.windows) > 0
+}
+
+// IsOpen reports whether turns may start at t (in t's location)
+func (s *ExecutionSchedule) IsOpen(t time.Time) bool {
+	if !s.Restricted() {
+		return true
+	}
+	minute := t.Hour()*60 + t.Minute()
+	for _, w := range s.windows {
+		if w.Contains(minute) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns when turns may next start: t itself while a window is open,
+// otherwise the start of the next window
+func (s *ExecutionSchedule) NextStart(t time.Time) time.Time {
+	if s.IsOpen(t) {
+		return t
+	}
+	var next time.Time
+	for day := 0; day <= 1; day++ {
+		for _, w := range s.windows {
+			start := time.Date(t.Year(), t.Month(), t.Day()+day, w.Start/60, w.Start%60, 0, 0, t.Location())
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+	return next
+}
+
+// String returns the windows separated by commas
+func (s *ExecutionSchedule) String() string {
+	if !s.Restricted() {
+		return "always"
+	}
+	specs := make([]string, 0, len(s.windows))
+	for _, w := range s.windows {
+		specs = append(specs, w.String())
+	}
+	return strings.Join(specs, ", ")
+}
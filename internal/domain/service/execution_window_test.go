@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExecutionWindow(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"22:00-06:00", "22:00-06:00", false},
+		{" 9:30 - 17:00 ", "09:30-17:00", false},
+		{"00:00-24:00", "00:00-24:00", false},
+		{"22:00", "", true},
+		{"22:00-6", "", true},
+		{"25:00-06:00", "", true},
+		{"24:00-06:00", "", true},
+		{"08:60-09:00", "", true},
+		{"08:00-08:00", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseExecutionWindow(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExecutionWindow(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseExecutionWindow(%q) = %s, want %s", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutionSchedule_NextStart(t *testing.T) {
+	schedule, err := ParseExecutionSchedule([]string{"22:00-06:00", "12:00-13:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantOpen bool
+		want     time.Time
+	}{
+		{"inside a window wrapping midnight, before midnight", at(16, 23, 0), true, at(16, 23, 0)},
+		{"inside a window wrapping midnight, after midnight", at(16, 5, 59), true, at(16, 5, 59)},
+		{"end is exclusive", at(16, 6, 0), false, at(16, 12, 0)},
+		{"between windows", at(16, 13, 30), false, at(16, 22, 0)},
+		{"inside the noon window", at(16, 12, 30), true, at(16, 12, 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.IsOpen(tt.now); got != tt.wantOpen {
+				t.Errorf("IsOpen(%s) = %v, want %v", tt.now.Format("15:04"), got, tt.wantOpen)
+			}
+			if got := schedule.NextStart(tt.now); !got.Equal(tt.want) {
+				t.Errorf("NextStart(%s) = %s, want %s", tt.now.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+
+	daytime, _ := ParseExecutionSchedule([]string{"09:00-17:00"})
+	if got := daytime.NextStart(at(16, 18, 0)); !got.Equal(at(17, 9, 0)) {
+		t.Errorf("NextStart after the last window = %s, want the next morning", got)
+	}
+
+	var always *ExecutionSchedule
+	if !always.IsOpen(at(16, 3, 0)) || always.String() != "always" {
+		t.Error("a schedule without windows is always open")
+	}
+}
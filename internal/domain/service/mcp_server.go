@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// MCP server transports
+const (
+	MCPTransportStdio = "stdio" // Started by the agent as a subprocess
+	MCPTransportHTTP  = "http"  // Streamable HTTP endpoint
+	MCPTransportSSE   = "sse"   // Server-sent events endpoint
+)
+
+// ValidateMCPServer checks that an MCP server has what its transport needs: a command for a stdio
+// server ("" is stdio), an http(s) URL for a remote one
+func ValidateMCPServer(transport, command, endpoint string) error {
+	switch transport {
+	case "", MCPTransportStdio:
+		if command == "" {
+			return errors.New("command is required for a stdio server")
+		}
+		if endpoint != "" {
+			return errors.New("url is only used by http and sse servers")
+		}
+	case MCPTransportHTTP, MCPTransportSSE:
+		if command != "" {
+			return errors.New("command is only used by stdio servers")
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid url %q (must be an http or https URL)", endpoint)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (must be %s, %s or %s)", transport, MCPTransportStdio, MCPTransportHTTP, MCPTransportSSE)
+	}
+	return nil
+}
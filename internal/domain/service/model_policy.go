@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrModelPolicyViolation is returned when a step would run on a model its policy does not allow
+var ErrModelPolicyViolation = errors.New("model policy violation")
+
+// ModelPolicyRule requires a workflow step to run on a model family at or above a version,
+// e.g. reviews on claude-opus 4.1 or newer
+type ModelPolicyRule struct {
+	Step       string // "implement" (including forced implementation), "review" or "done"
+	Family     string // Model name prefix, e.g. "claude-opus" or "gpt"
+	MinVersion string // Lowest allowed version, e.g. "4.1" ("" = any version of the family)
+}
+
+// ModelPolicy enforces model rules per step before an agent is dispatched
+// A nil policy allows every model.
+type ModelPolicy struct {
+	rules []modelPolicyRule
+}
+
+type modelPolicyRule struct {
+	ModelPolicyRule
+	minVersion []int
+}
+
+// NewModelPolicy validates the rules and builds a policy from them
+func NewModelPolicy(rules []ModelPolicyRule) (*ModelPolicy, error) {
+	policy := &ModelPolicy{}
+	for i, rule := range rules {
+		if err := ValidateModelPolicyRule(rule); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		minVersion, _ := parseVersion(rule.MinVersion)
+		policy.rules = append(policy.rules, modelPolicyRule{ModelPolicyRule: rule, minVersion: minVersion})
+	}
+	return policy, nil
+}
+
+// ValidateModelPolicyRule checks the step, family and minimum version of a rule
+func ValidateModelPolicyRule(rule ModelPolicyRule) error {
+	switch rule.Step {
+	case "implement", "review", "done":
+	default:
+		return fmt.Errorf("unknown step %q (must be implement, review or done)", rule.Step)
+	}
+	if strings.TrimSpace(rule.Family) == "" {
+		return errors.New("family is required")
+	}
+	if rule.MinVersion != "" {
+		if _, ok := parseVersion(rule.MinVersion); !ok {
+			return fmt.Errorf("invalid min_version %q (use numbers separated by dots, e.g. 4.1)", rule.MinVersion)
+		}
+	}
+	return nil
+}
+
+// Check returns an error wrapping ErrModelPolicyViolation when a rule for the step does not
+// allow the model; an empty model (the agent default) cannot be verified and is not allowed
+// by any rule
+func (p *ModelPolicy) Check(step, model string) error {
+	if p == nil {
+		return nil
+	}
+	if step == "force_implement" {
+		step = "implement"
+	}
+	for _, rule := range p.rules {
+		if rule.Step != step {
+			continue
+		}
+		required := rule.Family
+		if rule.MinVersion != "" {
+			required += " " + rule.MinVersion + " or newer"
+		}
+		if model == "" {
+			return fmt.Errorf("%w: %s must run on %s, but no model is pinned for it", ErrModelPolicyViolation, step, required)
+		}
+		version, ok := ModelVersion(rule.Family, model)
+		if !ok || compareVersions(version, rule.minVersion) < 0 {
+			return fmt.Errorf("%w: %s must run on %s, got %q", ErrModelPolicyViolation, step, required, model)
+		}
+	}
+	return nil
+}
+
+// ModelVersion returns the version of a model of the given family: the numbers following the
+// family in its name, up to a date suffix or a non-numeric part
+// e.g. "claude-opus-4-1-20250805" is version 4.1 of "claude-opus", "gpt-4.1-mini" version 4.1 of "gpt".
+// Reports false when the model does not belong to the family.
+func ModelVersion(family, model string) ([]int, bool) {
+	family = strings.ToLower(strings.TrimSpace(family))
+	model = strings.ToLower(strings.TrimSpace(model))
+	if !strings.HasPrefix(model, family) {
+		return nil, false
+	}
+	rest := model[len(family):]
+	if rest != "" && rest[0] != '-' && rest[0] != '.' && (rest[0] < '0' || rest[0] > '9') {
+		return nil, false // e.g. "gpt" does not cover "gptx-1"
+	}
+
+	var version []int
+	for _, part := range strings.FieldsFunc(rest, func(r rune) bool { return r == '-' || r == '.' }) {
+		digits := part
+		if i := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			digits = part[:i]
+		}
+		if digits == "" || len(digits) >= 6 {
+			break // A name part or a date snapshot suffix
+		}
+		n, _ := strconv.Atoi(digits)
+		version = append(version, n)
+		if len(digits) < len(part) {
+			break // e.g. "4o"
+		}
+	}
+	return version, true
+}
+
+// parseVersion parses a dotted version like "4.1"
+func parseVersion(s string) ([]int, bool) {
+	var version []int
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		version = append(version, n)
+	}
+	return version, true
+}
+
+// compareVersions compares versions component by component, missing components counting as 0
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestModelVersion(t *testing.T) {
+	tests := []struct {
+		family string
+		model  string
+		want   []int
+		ok     bool
+	}{
+		{"claude-opus", "claude-opus-4-1-20250805", []int{4, 1}, true},
+		{"claude-opus", "claude-opus-4", []int{4}, true},
+		{"claude-sonnet", "claude-opus-4", nil, false},
+		{"claude", "claude-3-5-sonnet-20241022", []int{3, 5}, true},
+		{"gpt", "gpt-4.1-mini", []int{4, 1}, true},
+		{"gpt", "gpt-4o", []int{4}, true},
+		{"gpt", "gptx-1", nil, false},
+		{"claude-opus", "opus", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := ModelVersion(tt.family, tt.model)
+		if ok != tt.ok || len(got) != len(tt.want) {
+			t.Errorf("ModelVersion(%q, %q) = %v, %v; want %v, %v", tt.family, tt.model, got, ok, tt.want, tt.ok)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ModelVersion(%q, %q) = %v; want %v", tt.family, tt.model, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestModelPolicy_Check(t *testing.T) {
+	policy, err := NewModelPolicy([]ModelPolicyRule{{Step: "review", Family: "claude-opus", MinVersion: "4.1"}})
+	if err != nil {
+		t.Fatalf("NewModelPolicy failed: %v", err)
+	}
+
+	tests := []struct {
+		step    string
+		model   string
+		allowed bool
+	}{
+		{"review", "claude-opus-4-1-20250805", true},
+		{"review", "claude-opus-4-5", true},
+		{"review", "claude-opus-4-20250514", false},
+		{"review", "claude-sonnet-4-5", false},
+		{"review", "", false},
+		{"implement", "claude-sonnet-4-5", true},
+		{"implement", "", true},
+	}
+	for _, tt := range tests {
+		err := policy.Check(tt.step, tt.model)
+		if tt.allowed && err != nil {
+			t.Errorf("Check(%q, %q) = %v; want allowed", tt.step, tt.model, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrModelPolicyViolation) {
+			t.Errorf("Check(%q, %q) = %v; want a policy violation", tt.step, tt.model, err)
+		}
+	}
+
+	var none *ModelPolicy
+	if err := none.Check("review", ""); err != nil {
+		t.Errorf("a nil policy allows every model, got %v", err)
+	}
+}
+
+func TestModelPolicy_ForceImplementFollowsImplementRules(t *testing.T) {
+	policy, err := NewModelPolicy([]ModelPolicyRule{{Step: "implement", Family: "gpt", MinVersion: "5"}})
+	if err != nil {
+		t.Fatalf("NewModelPolicy failed: %v", err)
+	}
+	if err := policy.Check("force_implement", "gpt-4.1"); !errors.Is(err, ErrModelPolicyViolation) {
+		t.Errorf("expected a policy violation, got %v", err)
+	}
+}
+
+func TestValidateModelPolicyRule(t *testing.T) {
+	invalid := []ModelPolicyRule{
+		{Step: "deploy", Family: "gpt"},
+		{Step: "review", Family: " "},
+		{Step: "review", Family: "gpt", MinVersion: "4.x"},
+	}
+	for _, rule := range invalid {
+		if err := ValidateModelPolicyRule(rule); err == nil {
+			t.Errorf("ValidateModelPolicyRule(%+v) accepted an invalid rule", rule)
+		}
+	}
+	if err := ValidateModelPolicyRule(ModelPolicyRule{Step: "done", Family: "claude"}); err != nil {
+		t.Errorf("ValidateModelPolicyRule rejected a valid rule: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+// Task priorities of PBIs and SBIs (EPIC priorities are unbounded and clamped when propagated)
+const (
+	PriorityNormal = 0 // 通常
+	PriorityUrgent = 2 // 緊急
+)
+
+// PriorityNode is a PBI or SBI below the EPIC or PBI whose priority is propagated
+type PriorityNode struct {
+	Type      string // "PBI" or "SBI"
+	ID        string
+	Title     string
+	Priority  int
+	Completed bool            // Done or failed tasks keep their priority
+	Children  []*PriorityNode // SBIs of a PBI
+}
+
+// PriorityChange is a task whose priority follows its parent's
+type PriorityChange struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+}
+
+// PriorityPropagationPlan lists the tasks a priority change reaches and those that keep their own
+type PriorityPropagationPlan struct {
+	Changes    []PriorityChange `json:"changes"`
+	Overridden []PriorityChange `json:"overridden"` // From and To are the task's own and the parent's priority
+	Completed  int              `json:"completed"`  // Done or failed tasks left alone
+}
+
+// PlanPriorityPropagation decides which children follow a parent's new priority
+// A child follows unless it overrode the priority it inherited: its priority must be the parent's
+// previous priority or the default (normal) priority, which children start with. A child that
+// follows passes the change on to its own children; an overriding child keeps its whole subtree.
+func PlanPriorityPropagation(priority int, previous []int, children []*PriorityNode) *PriorityPropagationPlan {
+	plan := &PriorityPropagationPlan{Changes: []PriorityChange{}, Overridden: []PriorityChange{}}
+	planPriorityPropagation(plan, ClampTaskPriority(priority), previous, children)
+	return plan
+}
+
+func planPriorityPropagation(plan *PriorityPropagationPlan, priority int, previous []int, children []*PriorityNode) {
+	inherited := map[int]bool{PriorityNormal: true}
+	for _, p := range previous {
+		inherited[ClampTaskPriority(p)] = true
+	}
+
+	for _, child := range children {
+		if child.Completed {
+			plan.Completed++
+			continue
+		}
+		if child.Priority == priority {
+			continue
+		}
+		change := PriorityChange{Type: child.Type, ID: child.ID, Title: child.Title, From: child.Priority, To: priority}
+		if !inherited[child.Priority] {
+			plan.Overridden = append(plan.Overridden, change)
+			continue
+		}
+		plan.Changes = append(plan.Changes, change)
+		planPriorityPropagation(plan, priority, []int{child.Priority}, child.Children)
+	}
+}
+
+// ClampTaskPriority maps a priority onto the range of PBI and SBI priorities
+func ClampTaskPriority(priority int) int {
+	switch {
+	case priority < PriorityNormal:
+		return PriorityNormal
+	case priority > PriorityUrgent:
+		return PriorityUrgent
+	default:
+		return priority
+	}
+}
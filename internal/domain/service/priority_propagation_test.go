@@ -0,0 +1,59 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanPriorityPropagation(t *testing.T) {
+	children := []*PriorityNode{
+		{Type: "PBI", ID: "PBI-001", Title: "Follows", Priority: 1, Children: []*PriorityNode{
+			{Type: "SBI", ID: "S1", Title: "Inherited", Priority: 1},
+			{Type: "SBI", ID: "S2", Title: "Never prioritized", Priority: 0},
+			{Type: "SBI", ID: "S3", Title: "Lowered", Priority: 0, Completed: true},
+		}},
+		{Type: "PBI", ID: "PBI-002", Title: "Default", Priority: 0, Children: []*PriorityNode{
+			{Type: "SBI", ID: "S4", Title: "Own priority", Priority: 1},
+		}},
+		{Type: "PBI", ID: "PBI-003", Title: "Already urgent", Priority: 2, Children: []*PriorityNode{
+			{Type: "SBI", ID: "S5", Title: "Untouched", Priority: 0},
+		}},
+		{Type: "PBI", ID: "PBI-004", Title: "Done", Priority: 1, Completed: true},
+	}
+
+	// An EPIC raised from 1 to 5; PBI-002 overrode nothing, so it follows too
+	plan := PlanPriorityPropagation(5, []int{1}, children)
+
+	wantChanges := []PriorityChange{
+		{Type: "PBI", ID: "PBI-001", Title: "Follows", From: 1, To: 2},
+		{Type: "SBI", ID: "S1", Title: "Inherited", From: 1, To: 2},
+		{Type: "SBI", ID: "S2", Title: "Never prioritized", From: 0, To: 2},
+		{Type: "PBI", ID: "PBI-002", Title: "Default", From: 0, To: 2},
+	}
+	wantOverridden := []PriorityChange{
+		{Type: "SBI", ID: "S4", Title: "Own priority", From: 1, To: 2},
+	}
+	if !reflect.DeepEqual(plan.Changes, wantChanges) {
+		t.Errorf("Changes = %+v, want %+v", plan.Changes, wantChanges)
+	}
+	if !reflect.DeepEqual(plan.Overridden, wantOverridden) {
+		t.Errorf("Overridden = %+v, want %+v", plan.Overridden, wantOverridden)
+	}
+	if plan.Completed != 2 {
+		t.Errorf("Completed = %d, want 2", plan.Completed)
+	}
+}
+
+func TestPlanPriorityPropagation_KeepsOverrides(t *testing.T) {
+	children := []*PriorityNode{
+		{Type: "PBI", ID: "PBI-001", Priority: 2, Children: []*PriorityNode{
+			{Type: "SBI", ID: "S1", Priority: 2},
+		}},
+	}
+
+	// Without the previous priority only children at the default priority follow
+	plan := PlanPriorityPropagation(1, nil, children)
+	if len(plan.Changes) != 0 || len(plan.Overridden) != 1 || plan.Overridden[0].ID != "PBI-001" {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Arms of a prompt experiment
+const (
+	PromptVariantControl   = "control"   // The regular prompt template
+	PromptVariantTreatment = "treatment" // The experimental variant of the template
+)
+
+// promptExperimentTemplates are the prompt templates an experiment can replace
+var promptExperimentTemplates = []string{"WIP.md", "REVIEW.md", "REVIEW_AND_WIP.md", "DONE.md", "POSTMORTEM.md"}
+
+// PromptExperiment compares a variant of a prompt template against the template itself
+// Every SBI is assigned to one arm for good: the assignment is a hash of the experiment name and
+// the SBI ID, so both arms receive a random, and thus comparable, half of the SBIs, and an SBI
+// keeps its arm across turns and runs.
+type PromptExperiment struct {
+	Name     string // Identifies the experiment in journals and reports
+	Template string // Prompt template under test, e.g. "WIP.md"
+	Variant  string // Variant template, relative to .deespec/prompts (e.g. "experiments/WIP.v2.md")
+}
+
+// ValidatePromptExperiment checks the name, template and variant of an experiment
+func ValidatePromptExperiment(e PromptExperiment) error {
+	if strings.TrimSpace(e.Name) == "" {
+		return errors.New("name is required")
+	}
+	if !IsPromptExperimentTemplate(e.Template) {
+		return fmt.Errorf("unknown template %q (must be one of %s)", e.Template, strings.Join(promptExperimentTemplates, ", "))
+	}
+	if e.Variant == "" {
+		return errors.New("variant is required")
+	}
+	variant := path.Clean(strings.ReplaceAll(e.Variant, "\\", "/"))
+	if path.IsAbs(variant) || variant == ".." || strings.HasPrefix(variant, "../") {
+		return fmt.Errorf("variant %q must be relative to .deespec/prompts", e.Variant)
+	}
+	if variant == e.Template {
+		return errors.New("variant must differ from the template")
+	}
+	return nil
+}
+
+// IsPromptExperimentTemplate reports whether a prompt template can be experimented on
+func IsPromptExperimentTemplate(name string) bool {
+	for _, template := range promptExperimentTemplates {
+		if template == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Assign returns the arm of an SBI; a nil experiment assigns nothing
+func (e *PromptExperiment) Assign(sbiID string) string {
+	if e == nil || sbiID == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(e.Name + "/" + sbiID))
+	if sum[0]&1 == 1 {
+		return PromptVariantTreatment
+	}
+	return PromptVariantControl
+}
+
+// TemplateFor returns the template an SBI's prompt is built from: the variant for SBIs in the
+// treatment arm, else the template itself
+// name is the template's file name, e.g. "WIP.md"; the variant is returned relative to the prompts
+// directory.
+func (e *PromptExperiment) TemplateFor(sbiID, name string) string {
+	if e == nil || name != e.Template || e.Assign(sbiID) != PromptVariantTreatment {
+		return name
+	}
+	return path.Clean(strings.ReplaceAll(e.Variant, "\\", "/"))
+}
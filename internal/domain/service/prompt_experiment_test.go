@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidatePromptExperiment(t *testing.T) {
+	tests := []struct {
+		name       string
+		experiment PromptExperiment
+		wantErr    bool
+	}{
+		{"valid", PromptExperiment{Name: "wip-v2", Template: "WIP.md", Variant: "experiments/WIP.v2.md"}, false},
+		{"missing name", PromptExperiment{Template: "WIP.md", Variant: "WIP.v2.md"}, true},
+		{"unknown template", PromptExperiment{Name: "x", Template: "PLAN.md", Variant: "PLAN.v2.md"}, true},
+		{"missing variant", PromptExperiment{Name: "x", Template: "WIP.md"}, true},
+		{"variant outside prompts", PromptExperiment{Name: "x", Template: "WIP.md", Variant: "../WIP.v2.md"}, true},
+		{"variant is the template", PromptExperiment{Name: "x", Template: "WIP.md", Variant: "./WIP.md"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePromptExperiment(tt.experiment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePromptExperiment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromptExperiment_Assign(t *testing.T) {
+	experiment := &PromptExperiment{Name: "wip-v2", Template: "WIP.md", Variant: "experiments/WIP.v2.md"}
+
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		sbiID := fmt.Sprintf("SBI-%04d", i)
+		arm := experiment.Assign(sbiID)
+		if arm != experiment.Assign(sbiID) {
+			t.Fatalf("Assign(%s) is not stable", sbiID)
+		}
+		counts[arm]++
+
+		want := "WIP.md"
+		if arm == PromptVariantTreatment {
+			want = "experiments/WIP.v2.md"
+		}
+		if got := experiment.TemplateFor(sbiID, "WIP.md"); got != want {
+			t.Errorf("TemplateFor(%s, WIP.md) = %q, want %q", sbiID, got, want)
+		}
+		if got := experiment.TemplateFor(sbiID, "REVIEW.md"); got != "REVIEW.md" {
+			t.Errorf("TemplateFor(%s, REVIEW.md) = %q, want the template itself", sbiID, got)
+		}
+	}
+	// Both arms receive about half of the SBIs
+	if counts[PromptVariantControl] < 400 || counts[PromptVariantTreatment] < 400 {
+		t.Errorf("unbalanced assignment: %v", counts)
+	}
+
+	var disabled *PromptExperiment
+	if disabled.Assign("SBI-1") != "" || disabled.TemplateFor("SBI-1", "WIP.md") != "WIP.md" {
+		t.Error("a nil experiment should assign nothing")
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// issueHeadingKeywords are heading fragments that mark a section listing review issues
+var issueHeadingKeywords = []string{
+	"issue", "problem", "recommendation", "required change", "requested change",
+	"fix", "todo", "action item",
+	"指摘", "問題", "修正", "改善", "推奨", "課題",
+}
+
+// emptyIssueMarkers are list items that mean "nothing to report"
+var emptyIssueMarkers = []string{
+	"none", "n/a", "na", "nothing", "なし", "特になし", "ありません", "特にありません",
+}
+
+// nonBlockingIssueMarkers are issue prefixes or tags that mark a suggestion rather than a required change
+var nonBlockingIssueMarkers = []string{
+	"nit", "minor", "optional", "suggestion", "nice to have", "non-blocking", "nonblocking",
+	"軽微", "任意", "提案",
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	listItemPattern = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(.*)$`)
+	checkboxPattern = regexp.MustCompile(`^\[([ xX])\]\s*(.*)$`)
+
+	// issueUpdatePattern matches review lines such as "ISSUE-2: ADDRESSED - guard added"
+	issueUpdatePattern = regexp.MustCompile(`(?i)\bISSUE-(\d+)[*_\x60]*\s*[:：]\s*(OPEN|ADDRESSED|FIXED|RESOLVED|WONT_?FIX|WON'T FIX)\b(.*)$`)
+
+	// issueReferencePattern matches list items that only refer to a tracked issue
+	issueReferencePattern = regexp.MustCompile(`(?i)^[*_\x60]*ISSUE-\d+\b`)
+)
+
+// Review issue statuses reported as "ISSUE-<n>: <STATUS>"
+const (
+	IssueStatusOpen      = "OPEN"
+	IssueStatusAddressed = "ADDRESSED"
+	IssueStatusWontFix   = "WONTFIX"
+)
+
+// IssueUpdate is the reviewer's status update of a tracked review issue
+type IssueUpdate struct {
+	Status string // IssueStatusOpen, IssueStatusAddressed or IssueStatusWontFix
+	Note   string
+}
+
+// ExtractReviewIssues extracts the list of issues from a review report
+// List items under headings such as "Issues", "Recommendations" or "指摘事項" are collected;
+// when no such section exists, unchecked checklist items anywhere in the report are used instead
+func ExtractReviewIssues(content string) []string {
+	var sectionIssues, uncheckedItems []string
+	inIssueSection := false
+	lastWasItem := false
+
+	for _, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(raw, " \t")
+
+		if m := headingPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			inIssueSection = isIssueHeading(m[1])
+			lastWasItem = false
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			lastWasItem = false
+			continue
+		}
+
+		m := listItemPattern.FindStringSubmatch(line)
+		if m == nil {
+			// Indented continuation of the previous list item
+			if lastWasItem && inIssueSection && strings.HasPrefix(raw, " ") && len(sectionIssues) > 0 {
+				sectionIssues[len(sectionIssues)-1] += " " + strings.TrimSpace(line)
+				continue
+			}
+			lastWasItem = false
+			continue
+		}
+
+		text := strings.TrimSpace(m[2])
+		checked := false
+		isCheckbox := false
+		if cb := checkboxPattern.FindStringSubmatch(text); cb != nil {
+			isCheckbox = true
+			checked = cb[1] != " "
+			text = strings.TrimSpace(cb[2])
+		}
+		if text == "" || checked || isEmptyIssue(text) {
+			lastWasItem = false
+			continue
+		}
+
+		if inIssueSection {
+			sectionIssues = append(sectionIssues, text)
+			lastWasItem = true
+		} else if isCheckbox {
+			uncheckedItems = append(uncheckedItems, text)
+			lastWasItem = false
+		}
+	}
+
+	if len(sectionIssues) > 0 {
+		return dedupe(sectionIssues)
+	}
+	return dedupe(uncheckedItems)
+}
+
+// ParseIssueUpdates extracts status updates of tracked issues ("ISSUE-<n>: ADDRESSED|OPEN|WONTFIX - note")
+// from a review report. FIXED and RESOLVED count as ADDRESSED. When an issue is updated more
+// than once, the last update wins.
+func ParseIssueUpdates(content string) map[int]IssueUpdate {
+	updates := make(map[int]IssueUpdate)
+
+	for _, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		m := issueUpdatePattern.FindStringSubmatch(raw)
+		if m == nil {
+			continue
+		}
+		number, err := strconv.Atoi(m[1])
+		if err != nil || number <= 0 {
+			continue
+		}
+		status := IssueStatusAddressed
+		switch strings.ToUpper(m[2]) {
+		case "OPEN":
+			status = IssueStatusOpen
+		case "WONTFIX", "WONT_FIX", "WON'T FIX":
+			status = IssueStatusWontFix
+		}
+		updates[number] = IssueUpdate{
+			Status: status,
+			Note:   strings.TrimSpace(strings.TrimLeft(m[3], "*_` -–—:：")),
+		}
+	}
+
+	return updates
+}
+
+// ExtractNewReviewIssues extracts the issues a review report raises, leaving out the status
+// updates of already tracked issues ("ISSUE-<n>: ...")
+func ExtractNewReviewIssues(content string) []string {
+	var issues []string
+	for _, issue := range ExtractReviewIssues(content) {
+		if !issueReferencePattern.MatchString(issue) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// IsBlockingIssue reports whether an issue is a required change
+// Issues tagged as nits, minor, optional or suggestions (e.g. "nit: rename", "[optional] ...") are not.
+func IsBlockingIssue(issue string) bool {
+	lower := strings.ToLower(strings.TrimLeft(issue, "*_`[( "))
+	for _, marker := range nonBlockingIssueMarkers {
+		if strings.Contains(lower, "("+marker+")") || strings.Contains(lower, "["+marker+"]") {
+			return false
+		}
+		if rest, ok := strings.CutPrefix(lower, marker); ok {
+			// "minor: ..." but not "minority ..."
+			if r, _ := utf8.DecodeRuneInString(rest); rest == "" || !unicode.IsLetter(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isIssueHeading reports whether a heading introduces a list of issues
+func isIssueHeading(heading string) bool {
+	lower := strings.ToLower(heading)
+	for _, keyword := range issueHeadingKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyIssue reports whether a list item only states that there are no issues
+func isEmptyIssue(text string) bool {
+	normalized := strings.ToLower(strings.Trim(text, " 。.!！"))
+	for _, marker := range emptyIssueMarkers {
+		if normalized == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupe removes duplicate issues while keeping their original order
+func dedupe(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(items))
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractReviewIssues(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "recommendations section",
+			content: `## Summary
+DECISION: NEEDS_CHANGES
+
+実装は概ね良好ですが修正が必要です。
+
+## Review Details
+- 認証ミドルウェアが正しく実装されている
+
+## Recommendations
+- Add error handling for expired tokens
+- Missing tests for the refresh flow
+`,
+			want: []string{"Add error handling for expired tokens", "Missing tests for the refresh flow"},
+		},
+		{
+			name: "japanese heading with numbered list and continuation",
+			content: `## 指摘事項
+1. エラーハンドリングが不足している
+   特に DB 接続失敗時
+2) テストが無い
+`,
+			want: []string{"エラーハンドリングが不足している 特に DB 接続失敗時", "テストが無い"},
+		},
+		{
+			name: "empty markers are ignored",
+			content: `## Recommendations
+- 特になし
+`,
+			want: nil,
+		},
+		{
+			name: "unchecked checklist items as fallback",
+			content: `## Checklist
+- [x] Builds successfully
+- [ ] Handle nil input
+- [ ] Update README
+`,
+			want: []string{"Handle nil input", "Update README"},
+		},
+		{
+			name: "duplicates are removed",
+			content: `## Issues
+- Fix lint errors
+- Fix lint errors
+`,
+			want: []string{"Fix lint errors"},
+		},
+		{
+			name:    "no issues",
+			content: "## Summary\nDECISION: SUCCEEDED\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractReviewIssues(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractReviewIssues() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIssueUpdates(t *testing.T) {
+	content := `## Tracked Issues
+- ISSUE-1: ADDRESSED - nil check added
+- **ISSUE-2**: wont_fix – out of scope for this task
+ISSUE-3: OPEN
+ISSUE-4：FIXED
+- ISSUE-1: RESOLVED
+ISSUE-0: ADDRESSED
+Mentions ISSUE-5 without a status
+`
+	got := ParseIssueUpdates(content)
+	want := map[int]IssueUpdate{
+		1: {Status: IssueStatusAddressed},
+		2: {Status: IssueStatusWontFix, Note: "out of scope for this task"},
+		3: {Status: IssueStatusOpen},
+		4: {Status: IssueStatusAddressed},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseIssueUpdates() = %#v, want %#v", got, want)
+	}
+}
+
+func TestExtractNewReviewIssues(t *testing.T) {
+	content := `## Issues
+- ISSUE-1: ADDRESSED - nil check added
+- Missing tests for the refresh flow
+`
+	got := ExtractNewReviewIssues(content)
+	want := []string{"Missing tests for the refresh flow"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractNewReviewIssues() = %#v, want %#v", got, want)
+	}
+}
+
+func TestIsBlockingIssue(t *testing.T) {
+	tests := map[string]bool{
+		"Handle expired tokens":                 true,
+		"nit: rename the variable":              false,
+		"Minor: typo in the comment":            false,
+		"[optional] extract a helper":           false,
+		"Consider caching (nice to have)":       false,
+		"**Suggestion**: use a table test":      false,
+		"軽微: コメントの誤字":                           false,
+		"Minority report handling is incorrect": true,
+	}
+	for issue, want := range tests {
+		if got := IsBlockingIssue(issue); got != want {
+			t.Errorf("IsBlockingIssue(%q) = %v, want %v", issue, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReviewQuorumRule decides how the votes of several reviewers become the turn's review decision
+type ReviewQuorumRule string
+
+const (
+	// ReviewQuorumUnanimous approves only when every reviewer approves
+	ReviewQuorumUnanimous ReviewQuorumRule = "unanimous"
+	// ReviewQuorumMajority approves when more than half of the reviewers approve
+	ReviewQuorumMajority ReviewQuorumRule = "majority"
+	// ReviewQuorumAnyFailBlocks fails when any reviewer fails, otherwise applies the majority rule
+	ReviewQuorumAnyFailBlocks ReviewQuorumRule = "any_fail_blocks"
+)
+
+// ReviewerEnvKey names the reviewer an agent reviews as in review quorum mode
+// `deespec sbi report --step review` records a vote instead of the turn's decision when it is set
+const ReviewerEnvKey = "DEESPEC_REVIEWER"
+
+var reviewerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ParseReviewQuorumRule parses a rule name; an empty name selects the majority rule
+// "any-fail-blocks" is accepted as a spelling of any_fail_blocks
+func ParseReviewQuorumRule(name string) (ReviewQuorumRule, error) {
+	switch ReviewQuorumRule(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")) {
+	case "", ReviewQuorumMajority:
+		return ReviewQuorumMajority, nil
+	case ReviewQuorumUnanimous:
+		return ReviewQuorumUnanimous, nil
+	case ReviewQuorumAnyFailBlocks:
+		return ReviewQuorumAnyFailBlocks, nil
+	default:
+		return "", fmt.Errorf("unknown review quorum rule %q (must be unanimous, majority or any_fail_blocks)", name)
+	}
+}
+
+// ValidateReviewerName checks that a reviewer name can be used in report file names
+func ValidateReviewerName(name string) error {
+	if !reviewerNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid reviewer name %q (lowercase letters, digits, '-' and '_' only)", name)
+	}
+	return nil
+}
+
+// AggregateReviewDecisions combines reviewer decisions (SUCCEEDED, NEEDS_CHANGES, FAILED) by rule
+// When the votes do not approve, the result is FAILED if more reviewers failed the work
+// than asked for changes, and NEEDS_CHANGES otherwise. No votes never approve.
+func AggregateReviewDecisions(rule ReviewQuorumRule, decisions []string) string {
+	succeeded, needsChanges, failed := 0, 0, 0
+	for _, decision := range decisions {
+		switch decision {
+		case "SUCCEEDED":
+			succeeded++
+		case "FAILED":
+			failed++
+		default:
+			needsChanges++
+		}
+	}
+
+	rejected := "NEEDS_CHANGES"
+	if failed > needsChanges {
+		rejected = "FAILED"
+	}
+
+	total := len(decisions)
+	switch rule {
+	case ReviewQuorumUnanimous:
+		if total > 0 && succeeded == total {
+			return "SUCCEEDED"
+		}
+	case ReviewQuorumAnyFailBlocks:
+		if failed > 0 {
+			return "FAILED"
+		}
+		if succeeded*2 > total {
+			return "SUCCEEDED"
+		}
+	default:
+		if succeeded*2 > total {
+			return "SUCCEEDED"
+		}
+	}
+	return rejected
+}
@@ -0,0 +1,55 @@
+package service
+
+import "testing"
+
+func TestAggregateReviewDecisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      ReviewQuorumRule
+		decisions []string
+		want      string
+	}{
+		{"unanimous approves", ReviewQuorumUnanimous, []string{"SUCCEEDED", "SUCCEEDED", "SUCCEEDED"}, "SUCCEEDED"},
+		{"unanimous blocked by one", ReviewQuorumUnanimous, []string{"SUCCEEDED", "NEEDS_CHANGES", "SUCCEEDED"}, "NEEDS_CHANGES"},
+		{"majority approves", ReviewQuorumMajority, []string{"SUCCEEDED", "NEEDS_CHANGES", "SUCCEEDED"}, "SUCCEEDED"},
+		{"majority tie rejects", ReviewQuorumMajority, []string{"SUCCEEDED", "NEEDS_CHANGES"}, "NEEDS_CHANGES"},
+		{"majority rejection by failures", ReviewQuorumMajority, []string{"FAILED", "FAILED", "SUCCEEDED"}, "FAILED"},
+		{"rejection tie asks for changes", ReviewQuorumMajority, []string{"FAILED", "NEEDS_CHANGES", "SUCCEEDED"}, "NEEDS_CHANGES"},
+		{"any fail blocks", ReviewQuorumAnyFailBlocks, []string{"SUCCEEDED", "SUCCEEDED", "FAILED"}, "FAILED"},
+		{"any fail blocks without failures", ReviewQuorumAnyFailBlocks, []string{"SUCCEEDED", "SUCCEEDED", "NEEDS_CHANGES"}, "SUCCEEDED"},
+		{"no votes", ReviewQuorumMajority, nil, "NEEDS_CHANGES"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AggregateReviewDecisions(tt.rule, tt.decisions); got != tt.want {
+				t.Errorf("AggregateReviewDecisions(%s, %v) = %s, want %s", tt.rule, tt.decisions, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReviewQuorumRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    ReviewQuorumRule
+		wantErr bool
+	}{
+		{"", ReviewQuorumMajority, false},
+		{"unanimous", ReviewQuorumUnanimous, false},
+		{"any-fail-blocks", ReviewQuorumAnyFailBlocks, false},
+		{"ANY_FAIL_BLOCKS", ReviewQuorumAnyFailBlocks, false},
+		{"plurality", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseReviewQuorumRule(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseReviewQuorumRule(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseReviewQuorumRule(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
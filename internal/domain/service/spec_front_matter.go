@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFrontMatter is the optional YAML front matter at the top of an SBI spec.md:
+//
+//	---
+//	parent_pbi: PBI-001
+//	sequence: 2
+//	labels: [backend, api]
+//	priority: 1
+//	estimated_hours: 3.5
+//	depends_on: [SBI-01HX0000000000000000000000]
+//	agent: codex
+//	---
+//	# Payment API
+//
+// Every field is optional. Lists may also be written as comma-separated strings
+// ("labels: backend, api"). Unknown fields are rejected, so that a typo does not
+// silently drop metadata.
+type SpecFrontMatter struct {
+	ParentPBI      string   `yaml:"parent_pbi,omitempty"`
+	Sequence       int      `yaml:"sequence,omitempty"`
+	Labels         specList `yaml:"labels,omitempty"`
+	Priority       *int     `yaml:"priority,omitempty"`        // 0=normal, 1=high, 2=urgent
+	EstimatedHours *float64 `yaml:"estimated_hours,omitempty"` // nil when not set
+	DependsOn      specList `yaml:"depends_on,omitempty"`      // IDs of SBIs that must be done first
+	Agent          string   `yaml:"agent,omitempty"`
+}
+
+// specList is a YAML sequence of strings, or a comma-separated string
+type specList []string
+
+// UnmarshalYAML accepts both "[a, b]" and "a, b"
+func (l *specList) UnmarshalYAML(value *yaml.Node) error {
+	var items []string
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.Tag == "!!null" {
+			break
+		}
+		items = strings.Split(value.Value, ",")
+	case yaml.SequenceNode:
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("line %d: expected a list of strings", value.Line)
+	}
+	result := specList{}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" && !strings.EqualFold(item, "none") {
+			result = append(result, item)
+		}
+	}
+	*l = result
+	return nil
+}
+
+// frontMatterDelimiter opens and closes the front matter
+const frontMatterDelimiter = "---"
+
+// ParseSpecFrontMatter splits the YAML front matter off the top of a spec and validates it
+// A spec without front matter (its first line is not "---", or no "---" line closes it) is
+// returned unchanged with nil, as are the specs still using the "Parent PBI:/Sequence:" footer.
+func ParseSpecFrontMatter(content string) (*SpecFrontMatter, string, error) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if strings.TrimRight(lines[0], " \t") != frontMatterDelimiter {
+		return nil, content, nil
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t") == frontMatterDelimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, content, nil
+	}
+	block, rest := strings.Join(lines[1:end], "\n"), strings.Join(lines[end+1:], "\n")
+
+	front := &SpecFrontMatter{}
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(block)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(front); err != nil && !errors.Is(err, io.EOF) {
+		return nil, content, fmt.Errorf("invalid front matter: %w", err)
+	}
+	if err := front.Validate(); err != nil {
+		return nil, content, err
+	}
+	return front, rest, nil
+}
+
+// Render returns the front matter as the "---" delimited block that starts a spec
+func (f *SpecFrontMatter) Render() (string, error) {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to render front matter: %w", err)
+	}
+	if string(data) == "{}\n" {
+		data = nil
+	}
+	return frontMatterDelimiter + "\n" + string(data) + frontMatterDelimiter + "\n", nil
+}
+
+// Validate checks the values of the front matter
+func (f *SpecFrontMatter) Validate() error {
+	if f.Sequence < 0 {
+		return fmt.Errorf("invalid front matter: sequence must be at least 1, got %d", f.Sequence)
+	}
+	if f.Priority != nil && (*f.Priority < 0 || *f.Priority > 2) {
+		return fmt.Errorf("invalid front matter: priority must be 0, 1 or 2, got %d", *f.Priority)
+	}
+	if f.EstimatedHours != nil && *f.EstimatedHours < 0 {
+		return fmt.Errorf("invalid front matter: estimated_hours must not be negative, got %g", *f.EstimatedHours)
+	}
+	seen := make(map[string]bool, len(f.DependsOn))
+	for _, id := range f.DependsOn {
+		if strings.ContainsAny(id, " \t") {
+			return fmt.Errorf("invalid front matter: depends_on has an invalid SBI ID %q", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("invalid front matter: depends_on lists %s twice", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpecFrontMatter(t *testing.T) {
+	content := "---\nparent_pbi: PBI-001\nsequence: 2\nlabels: [backend, api]\npriority: 1\nestimated_hours: 3.5\ndepends_on: SBI-A, SBI-B\nagent: codex\n---\n# Payment API\n\nCharge the card.\n"
+
+	front, rest, err := ParseSpecFrontMatter(content)
+	require.NoError(t, err)
+	require.NotNil(t, front)
+	assert.Equal(t, "PBI-001", front.ParentPBI)
+	assert.Equal(t, 2, front.Sequence)
+	assert.Equal(t, []string{"backend", "api"}, []string(front.Labels))
+	require.NotNil(t, front.Priority)
+	assert.Equal(t, 1, *front.Priority)
+	require.NotNil(t, front.EstimatedHours)
+	assert.Equal(t, 3.5, *front.EstimatedHours)
+	assert.Equal(t, []string{"SBI-A", "SBI-B"}, []string(front.DependsOn), "comma-separated lists are accepted")
+	assert.Equal(t, "codex", front.Agent)
+	assert.Equal(t, "# Payment API\n\nCharge the card.\n", rest)
+
+	rendered, err := front.Render()
+	require.NoError(t, err)
+	again, _, err := ParseSpecFrontMatter(rendered + rest)
+	require.NoError(t, err)
+	assert.Equal(t, front, again, "rendered front matter parses back")
+}
+
+func TestParseSpecFrontMatter_None(t *testing.T) {
+	for _, content := range []string{
+		"# Task\n\nBody\n\n---\nParent PBI: PBI-001\nSequence: 1\n",
+		"---\n# Task without a closing line\n",
+		"",
+	} {
+		front, rest, err := ParseSpecFrontMatter(content)
+		require.NoError(t, err)
+		assert.Nil(t, front)
+		assert.Equal(t, content, rest)
+	}
+
+	front, rest, err := ParseSpecFrontMatter("---\n---\n# Task\n")
+	require.NoError(t, err)
+	require.NotNil(t, front, "an empty front matter is still split off")
+	assert.Equal(t, "# Task\n", rest)
+}
+
+func TestParseSpecFrontMatter_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   string
+		wantErr string
+	}{
+		{"unknown field", "labels: [api]\ndepend_on: [SBI-A]", "field depend_on not found"},
+		{"priority out of range", "priority: 3", "priority must be 0, 1 or 2, got 3"},
+		{"negative hours", "estimated_hours: -1", "estimated_hours must not be negative"},
+		{"negative sequence", "sequence: -2", "sequence must be at least 1"},
+		{"duplicate dependency", "depends_on: [SBI-A, SBI-A]", "depends_on lists SBI-A twice"},
+		{"map as list", "labels: {a: b}", "expected a list of strings"},
+		{"not a mapping", "just some text", "invalid front matter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseSpecFrontMatter("---\n" + tt.block + "\n---\n# Task\n")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
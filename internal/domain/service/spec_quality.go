@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Spec quality checks run before an SBI's first implement turn
+const (
+	SpecCheckMissingAcceptanceCriteria = "missing_acceptance_criteria"
+	SpecCheckAmbiguousRequirement      = "ambiguous_requirement"
+	SpecCheckNoFileHints               = "no_file_hints"
+)
+
+// SpecChecks lists every spec quality check
+var SpecChecks = []string{
+	SpecCheckMissingAcceptanceCriteria,
+	SpecCheckAmbiguousRequirement,
+	SpecCheckNoFileHints,
+}
+
+// vagueTerms are phrases that leave a requirement open to interpretation
+var vagueTerms = []string{
+	"etc", "and so on", "as needed", "as appropriate", "appropriately", "if necessary",
+	"if possible", "somehow", "user-friendly", "intuitive", "properly", "and/or", "tbd", "tbc",
+	"など", "適切に", "適宜", "必要に応じて", "いい感じに", "なるべく", "できれば", "未定",
+}
+
+var (
+	// urlPattern matches URLs, which are not file hints
+	urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+
+	// fileHintPattern matches paths such as internal/app/run.go, cmd/ or main.go
+	fileHintPattern = regexp.MustCompile(`(?:^|[\s` + "`" + `'"(])(?:\.{0,2}/)?(?:[\w.-]+/)+[\w.-]*|\b[\w-]+\.(?:go|ts|tsx|js|jsx|py|rb|java|kt|rs|c|h|cpp|cs|swift|php|sql|proto|sh|ya?ml|json|toml|md|html|css)\b`)
+
+	// asciiTermPattern matches a vague ASCII term on word boundaries
+	asciiTermPattern = func() *regexp.Regexp {
+		var ascii []string
+		for _, term := range vagueTerms {
+			if isASCII(term) {
+				ascii = append(ascii, regexp.QuoteMeta(term))
+			}
+		}
+		return regexp.MustCompile(`(?i)\b(?:` + strings.Join(ascii, "|") + `)\b`)
+	}()
+)
+
+// SpecQualityWarning is one finding of the spec quality pre-flight check
+type SpecQualityWarning struct {
+	Check   string `json:"check"`
+	Line    int    `json:"line,omitempty"` // 1-based line in the spec (0 = whole spec)
+	Message string `json:"message"`
+}
+
+// CheckSpecQuality runs cheap heuristics over an SBI spec and returns its warnings:
+// no acceptance criteria, requirements phrased with vague terms, and no hint of
+// which files to touch. filePaths are file hints recorded outside the spec.
+func CheckSpecQuality(content string, filePaths []string) []SpecQualityWarning {
+	var warnings []SpecQualityWarning
+
+	if len(ExtractAcceptanceCriteria(content)) == 0 {
+		warnings = append(warnings, SpecQualityWarning{
+			Check:   SpecCheckMissingAcceptanceCriteria,
+			Message: "No acceptance criteria: add an \"Acceptance Criteria\" section listing verifiable outcomes",
+		})
+	}
+
+	hasFileHint := len(filePaths) > 0
+	inCodeBlock := false
+	for i, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if !hasFileHint && fileHintPattern.MatchString(urlPattern.ReplaceAllString(line, "")) {
+			hasFileHint = true
+		}
+		if inCodeBlock || line == "" || headingPattern.MatchString(line) {
+			continue
+		}
+		if terms := findVagueTerms(line); len(terms) > 0 {
+			warnings = append(warnings, SpecQualityWarning{
+				Check:   SpecCheckAmbiguousRequirement,
+				Line:    i + 1,
+				Message: fmt.Sprintf("Ambiguous wording (%s): %s", strings.Join(terms, ", "), truncateRunes(line, 80)),
+			})
+		}
+	}
+
+	if !hasFileHint {
+		warnings = append(warnings, SpecQualityWarning{
+			Check:   SpecCheckNoFileHints,
+			Message: "No file hints: name the files or directories the change is expected to touch",
+		})
+	}
+
+	return warnings
+}
+
+// findVagueTerms returns the vague terms used in a line, in list order
+func findVagueTerms(line string) []string {
+	var found []string
+	lower := strings.ToLower(line)
+	asciiMatches := make(map[string]bool)
+	for _, match := range asciiTermPattern.FindAllString(lower, -1) {
+		asciiMatches[match] = true
+	}
+	for _, term := range vagueTerms {
+		if isASCII(term) {
+			if asciiMatches[term] {
+				found = append(found, term)
+			}
+		} else if strings.Contains(lower, term) {
+			found = append(found, term)
+		}
+	}
+	return found
+}
+
+// isASCII reports whether s only contains ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// truncateRunes shortens s to max runes, marking the cut with "..."
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "..."
+}
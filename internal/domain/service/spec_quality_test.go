@@ -0,0 +1,82 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckSpecQuality(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		filePaths []string
+		want      []string // check of each warning, in order
+		wantLines []int
+	}{
+		{
+			name: "well specified",
+			content: `# Login API
+
+Add POST /login to internal/adapter/controller/httpapi/login.go.
+
+## Acceptance Criteria
+- Returns 200 with a token on success
+- Returns 401 on wrong password
+`,
+			want:      nil,
+			wantLines: nil,
+		},
+		{
+			name: "nothing but a vague sentence",
+			content: `# Improve login
+
+Make the login page user-friendly and handle errors properly, etc.
+`,
+			want:      []string{SpecCheckMissingAcceptanceCriteria, SpecCheckAmbiguousRequirement, SpecCheckNoFileHints},
+			wantLines: []int{0, 3, 0},
+		},
+		{
+			name: "file hints recorded outside the spec; URLs and code blocks ignored",
+			content: `See https://example.com/docs/login for the flow.
+
+` + "```" + `
+retry as needed
+` + "```" + `
+
+## 受け入れ基準
+- 必要に応じてリトライする
+`,
+			filePaths: []string{"internal/app/login.go"},
+			want:      []string{SpecCheckAmbiguousRequirement},
+			wantLines: []int{8},
+		},
+		{
+			name: "word boundaries",
+			content: `Store the fetch etcd key in config.yaml.
+
+## Acceptance Criteria
+- The key is written
+`,
+			want:      nil,
+			wantLines: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := CheckSpecQuality(tt.content, tt.filePaths)
+			var checks []string
+			var lines []int
+			for _, w := range warnings {
+				checks = append(checks, w.Check)
+				lines = append(lines, w.Line)
+			}
+			if !reflect.DeepEqual(checks, tt.want) {
+				t.Errorf("checks = %v, want %v (%+v)", checks, tt.want, warnings)
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxTemplateVarBytes caps a custom template variable so that one command cannot flood the prompt
+const MaxTemplateVarBytes = 4 * 1024
+
+// TemplateVar is a custom prompt template variable, referenced as {{.Custom.Name}}:
+// a static value or the output of a shell command
+type TemplateVar struct {
+	Value   string // Static value
+	Command string // Shell command run in the project root; its trimmed standard output is the value
+}
+
+var (
+	// Names are referenced as template fields, so they must be Go identifiers
+	templateVarNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+	// ansiEscapePattern matches terminal color and cursor sequences in command output
+	ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+)
+
+// ValidateTemplateVar checks that a variable has a usable name and exactly one source
+func ValidateTemplateVar(name string, v TemplateVar) error {
+	if !templateVarNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid variable name %q (a letter followed by letters, digits and underscores)", name)
+	}
+	hasValue, hasCommand := v.Value != "", strings.TrimSpace(v.Command) != ""
+	if hasValue == hasCommand {
+		return errors.New("set either value or command")
+	}
+	return nil
+}
+
+// SanitizeTemplateValue makes a variable value safe to place in a prompt: terminal escape
+// sequences and control characters other than newlines and tabs are removed, surrounding
+// whitespace is trimmed and the value is cut to MaxTemplateVarBytes
+// Values reach templates as data, so template syntax in them is printed, never evaluated.
+func SanitizeTemplateValue(value string) string {
+	value = ansiEscapePattern.ReplaceAllString(value, "")
+	value = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			return r
+		}
+		return -1
+	}, strings.ToValidUTF8(value, ""))
+	value = strings.TrimSpace(value)
+	if len(value) <= MaxTemplateVarBytes {
+		return value
+	}
+	cut := MaxTemplateVarBytes
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "… (truncated)"
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplateVar(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       TemplateVar
+		wantErr bool
+	}{
+		{"ServiceOwner", TemplateVar{Value: "team-payments"}, false},
+		{"on_call", TemplateVar{Command: "./scripts/oncall.sh"}, false},
+		{"1st", TemplateVar{Value: "x"}, true},
+		{"service-owner", TemplateVar{Value: "x"}, true},
+		{"Owner", TemplateVar{}, true},
+		{"Owner", TemplateVar{Value: "x", Command: "echo y"}, true},
+	}
+	for _, tt := range tests {
+		if err := ValidateTemplateVar(tt.name, tt.v); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateTemplateVar(%q, %+v) = %v, wantErr %v", tt.name, tt.v, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSanitizeTemplateValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"  team-payments\n", "team-payments"},
+		{"\x1b[31malice\x1b[0m", "alice"},
+		{"a\x00b\rc\n\td", "abc\n\td"},
+		{"{{.SBIID}}", "{{.SBIID}}"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeTemplateValue(tt.in); got != tt.want {
+			t.Errorf("SanitizeTemplateValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	long := SanitizeTemplateValue(strings.Repeat("é", MaxTemplateVarBytes))
+	if !strings.HasSuffix(long, "… (truncated)") || len(long) > MaxTemplateVarBytes+len("… (truncated)") {
+		t.Errorf("long values must be truncated, got %d bytes", len(long))
+	}
+	if !strings.HasPrefix(long, "é") || strings.ContainsRune(strings.TrimSuffix(long, "… (truncated)"), '�') {
+		t.Error("truncation must keep whole characters")
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 )
 
+// DefaultMaxAttempts is how many review rounds an SBI gets before implementation is forced
+const DefaultMaxAttempts = 3
+
 // WorkflowDecisionService manages all workflow decision logic for SBI execution
 // This service centralizes the decision-making process for determining next actions
 type WorkflowDecisionService struct {
@@ -15,7 +18,7 @@ type WorkflowDecisionService struct {
 // NewWorkflowDecisionService creates a new workflow decision service
 func NewWorkflowDecisionService(maxAttempts int) *WorkflowDecisionService {
 	if maxAttempts <= 0 {
-		maxAttempts = 3 // Default
+		maxAttempts = DefaultMaxAttempts
 	}
 	return &WorkflowDecisionService{
 		maxAttempts: maxAttempts,
@@ -92,6 +95,16 @@ func (s *WorkflowDecisionService) decideForImplementOnly(
 			}
 		}
 
+		if stepResult.CheckpointPath != "" {
+			// IMPLEMENTING (time-boxed) → IMPLEMENTING, resumed from the checkpoint next turn
+			return &WorkflowAction{
+				NextStatus:          model.StatusImplementing,
+				NextStep:            model.StepImplement,
+				ShouldIncrementTurn: true,
+				Reason:              "only_implement: IMPLEMENTING (time-boxed, resume from checkpoint)",
+			}
+		}
+
 		if stepResult.Success {
 			// IMPLEMENTING (success) → DONE (skip REVIEW)
 			return &WorkflowAction{
@@ -192,6 +205,16 @@ func (s *WorkflowDecisionService) decideForFullWorkflow(
 			}
 		}
 
+		if stepResult.CheckpointPath != "" {
+			// IMPLEMENTING (time-boxed) → IMPLEMENTING, resumed from the checkpoint next turn
+			return &WorkflowAction{
+				NextStatus:          model.StatusImplementing,
+				NextStep:            model.StepImplement,
+				ShouldIncrementTurn: true,
+				Reason:              "full_workflow: IMPLEMENTING (time-boxed, resume from checkpoint)",
+			}
+		}
+
 		if stepResult.Success {
 			// IMPLEMENTING (success) → REVIEWING
 			return &WorkflowAction{
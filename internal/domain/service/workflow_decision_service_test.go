@@ -147,3 +147,28 @@ func TestWorkflowDecisionService_OnlyImplement_IMPLEMENTING_Failure_to_FAILED(t
 		t.Errorf("Expected FAILED, got %v", action.NextStatus)
 	}
 }
+
+func TestWorkflowDecisionService_IMPLEMENTING_Checkpointed_stays_IMPLEMENTING(t *testing.T) {
+	service := NewWorkflowDecisionService(3)
+
+	for _, onlyImplement := range []bool{false, true} {
+		testSBI := createTestSBI(model.StatusImplementing, onlyImplement, 1)
+
+		stepResult := &dto.ExecuteStepOutput{
+			Success:        true,
+			CheckpointPath: ".deespec/reports/sbi/test/checkpoint_2.md",
+		}
+
+		action := service.DecideNextAction(testSBI, stepResult)
+
+		if action.NextStatus != model.StatusImplementing {
+			t.Errorf("only_implement=%v: expected IMPLEMENTING, got %v", onlyImplement, action.NextStatus)
+		}
+		if !action.ShouldIncrementTurn {
+			t.Errorf("only_implement=%v: expected turn increment", onlyImplement)
+		}
+		if action.ShouldIncrementAttempt {
+			t.Errorf("only_implement=%v: a time-boxed turn must not use up an attempt", onlyImplement)
+		}
+	}
+}
@@ -12,6 +12,12 @@ import (
 //go:embed templates/etc/* templates/etc/policies/* templates/prompts/* templates/templates/*
 var templatesFS embed.FS
 
+// localesFS holds localized overrides of the default (Japanese) templates.
+// Files under locales/<locale>/ replace the template with the same path.
+//
+//go:embed locales
+var localesFS embed.FS
+
 // Template represents a template file to be written
 type Template struct {
 	Path    string
@@ -65,6 +71,34 @@ func GetTemplates() ([]Template, error) {
 	return templates, nil
 }
 
+// GetTemplatesForLocale returns all templates with localized overrides applied
+// Templates without a localized version, and unknown locales, fall back to the defaults
+func GetTemplatesForLocale(locale string) ([]Template, error) {
+	templates, err := GetTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	root := "locales/" + locale
+	if _, err := fs.Stat(localesFS, root); err != nil {
+		return templates, nil
+	}
+
+	for i, tmpl := range templates {
+		content, err := localesFS.ReadFile(root + "/" + tmpl.Path + ".tmpl")
+		if err != nil {
+			// Fall back to the non-.tmpl name for templates stored without the suffix
+			content, err = localesFS.ReadFile(root + "/" + tmpl.Path)
+			if err != nil {
+				continue
+			}
+		}
+		templates[i].Content = content
+	}
+
+	return templates, nil
+}
+
 // WriteTemplateResult represents the result of writing a template
 type WriteTemplateResult struct {
 	Path   string
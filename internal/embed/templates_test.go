@@ -0,0 +1,54 @@
+package embed
+
+import (
+	"strings"
+	"testing"
+)
+
+func findTemplate(t *testing.T, templates []Template, path string) Template {
+	t.Helper()
+	for _, tmpl := range templates {
+		if tmpl.Path == path {
+			return tmpl
+		}
+	}
+	t.Fatalf("template %s not found", path)
+	return Template{}
+}
+
+func TestGetTemplatesForLocale(t *testing.T) {
+	defaults, err := GetTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	en, err := GetTemplatesForLocale("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(en) != len(defaults) {
+		t.Fatalf("localized template count = %d, want %d", len(en), len(defaults))
+	}
+	if decompose := findTemplate(t, en, "prompts/PBI_DECOMPOSE.md"); !strings.Contains(string(decompose.Content), "## Overview") {
+		t.Error("English PBI_DECOMPOSE.md should use English SBI section names")
+	}
+	if wip := findTemplate(t, en, "prompts/WIP.md"); strings.Contains(string(wip.Content), "Japanese") {
+		t.Error("English WIP.md should not ask for Japanese reports")
+	}
+
+	// Templates without a localized version are unchanged
+	if string(findTemplate(t, en, "etc/workflow.yaml").Content) != string(findTemplate(t, defaults, "etc/workflow.yaml").Content) {
+		t.Error("workflow.yaml should fall back to the default template")
+	}
+
+	// Japanese (default) and unknown locales return the defaults
+	for _, locale := range []string{"ja", "xx"} {
+		got, err := GetTemplatesForLocale(locale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decompose := findTemplate(t, got, "prompts/PBI_DECOMPOSE.md"); !strings.Contains(string(decompose.Content), "## 概要") {
+			t.Errorf("%s: PBI_DECOMPOSE.md should use Japanese SBI section names", locale)
+		}
+	}
+}
@@ -0,0 +1,538 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// SettingIssue is a problem found in setting.json
+type SettingIssue struct {
+	Path    string `json:"path"` // Dotted key path (e.g. "scheduling.pbi_wip_limit"); empty for the whole file
+	Message string `json:"message"`
+}
+
+func (i SettingIssue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// SettingsError reports every problem found in a setting.json file
+type SettingsError struct {
+	File   string
+	Issues []SettingIssue
+}
+
+func (e *SettingsError) Error() string {
+	var b strings.Builder
+	if len(e.Issues) == 1 {
+		fmt.Fprintf(&b, "invalid %s: %s", e.File, e.Issues[0])
+		return b.String()
+	}
+	fmt.Fprintf(&b, "invalid %s (%d problems):", e.File, len(e.Issues))
+	for _, issue := range e.Issues {
+		fmt.Fprintf(&b, "\n  - %s", issue)
+	}
+	return b.String()
+}
+
+// ValidateSettings checks setting.json content against the RawSettings schema
+// Every unknown key, value of the wrong type and value out of range is reported, so that a
+// typo no longer falls back to a default silently. Empty content is valid.
+func ValidateSettings(data []byte) []SettingIssue {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return []SettingIssue{{Message: fmt.Sprintf("not valid JSON: %v", err)}}
+	}
+
+	issues := checkSchema("", document, reflect.TypeOf(RawSettings{}))
+	if len(issues) > 0 {
+		// Ranges are only meaningful once every value has its type
+		return issues
+	}
+
+	var settings RawSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return []SettingIssue{{Message: err.Error()}}
+	}
+	// Defaults take part in cross-field checks (e.g. max_estimated_hours against the default minimum)
+	applyDefaults(&settings)
+	return checkRanges(&settings)
+}
+
+// checkSchema reports unknown keys and type mismatches of value against the Go type t
+// JSON null is accepted everywhere and means "use the default".
+func checkSchema(path string, value interface{}, t reflect.Type) []SettingIssue {
+	if value == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []SettingIssue{typeIssue(path, "an object", value)}
+		}
+		fields := jsonFields(t)
+		var issues []SettingIssue
+		for _, key := range sortedKeys(object) {
+			field, known := fields[key]
+			if !known {
+				message := "unknown key"
+				if suggestion := closestKey(key, fields); suggestion != "" {
+					message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+				}
+				issues = append(issues, SettingIssue{Path: joinPath(path, key), Message: message})
+				continue
+			}
+			issues = append(issues, checkSchema(joinPath(path, key), object[key], field.Type)...)
+		}
+		return issues
+
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []SettingIssue{typeIssue(path, "an object", value)}
+		}
+		var issues []SettingIssue
+		for _, key := range sortedKeys(object) {
+			issues = append(issues, checkSchema(joinPath(path, key), object[key], t.Elem())...)
+		}
+		return issues
+
+	case reflect.Slice:
+		array, ok := value.([]interface{})
+		if !ok {
+			return []SettingIssue{typeIssue(path, "an array", value)}
+		}
+		var issues []SettingIssue
+		for i, element := range array {
+			issues = append(issues, checkSchema(fmt.Sprintf("%s[%d]", path, i), element, t.Elem())...)
+		}
+		return issues
+
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return []SettingIssue{typeIssue(path, "a string", value)}
+		}
+
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return []SettingIssue{typeIssue(path, "true or false", value)}
+		}
+
+	case reflect.Int:
+		number, ok := value.(json.Number)
+		if !ok {
+			return []SettingIssue{typeIssue(path, "an integer", value)}
+		}
+		if _, err := number.Int64(); err != nil {
+			return []SettingIssue{typeIssue(path, "an integer", value)}
+		}
+
+	case reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			return []SettingIssue{typeIssue(path, "a number", value)}
+		}
+	}
+	return nil
+}
+
+// checkRanges reports values that have the right type but are out of range
+func checkRanges(s *RawSettings) []SettingIssue {
+	var issues []SettingIssue
+	atLeast := func(path string, v *int, min int) {
+		if v != nil && *v < min {
+			issues = append(issues, SettingIssue{Path: path, Message: fmt.Sprintf("must be at least %d, got %d", min, *v)})
+		}
+	}
+	oneOf := func(path string, v *string, allowed ...string) {
+		if v == nil {
+			return
+		}
+		for _, a := range allowed {
+			if *v == a {
+				return
+			}
+		}
+		issues = append(issues, SettingIssue{Path: path, Message: fmt.Sprintf("must be one of %s, got %q", strings.Join(allowed, ", "), *v)})
+	}
+
+	atLeast("timeout_sec", s.TimeoutSec, 1)
+	atLeast("max_attempts", s.MaxAttempts, 1)
+	atLeast("max_turns", s.MaxTurns, 1)
+	oneOf("stderr_level", s.StderrLevel, "debug", "info", "warn", "warning", "error", "fatal")
+	if s.Locale != nil {
+		if _, err := locale.Parse(*s.Locale); err != nil {
+			issues = append(issues, SettingIssue{Path: "locale", Message: err.Error()})
+		}
+	}
+
+	if s.StatusLabels != nil {
+		for _, name := range sortedKeys(*s.StatusLabels) {
+			if !model.Status(strings.ToUpper(name)).IsValid() {
+				issues = append(issues, SettingIssue{Path: "status_labels." + name, Message: "unknown SBI status (use PENDING, PICKED, IMPLEMENTING, REVIEWING, DONE, FAILED or CANCELLED)"})
+			} else if strings.TrimSpace((*s.StatusLabels)[name]) == "" {
+				issues = append(issues, SettingIssue{Path: "status_labels." + name, Message: "must not be empty"})
+			}
+		}
+	}
+
+	if c := s.LabelConfig; c != nil && c.Import != nil {
+		atLeast("label_config.import.max_line_count", c.Import.MaxLineCount, 1)
+	}
+	if c := s.AgentPoolConfig; c != nil && c.MaxConcurrent != nil {
+		for _, agent := range sortedKeys(*c.MaxConcurrent) {
+			limit := (*c.MaxConcurrent)[agent]
+			atLeast("agent_pool_config.max_concurrent."+agent, &limit, 1)
+		}
+	}
+	if c := s.NotificationConfig; c != nil {
+		atLeast("notification_config.deadline_check_interval_sec", c.DeadlineCheckIntervalSec, 1)
+		atLeast("notification_config.escalation_hours", c.EscalationHours, 0)
+		if e := c.Email; e != nil && e.SMTPHost != "" {
+			if e.SMTPPort != nil && (*e.SMTPPort < 1 || *e.SMTPPort > 65535) {
+				issues = append(issues, SettingIssue{Path: "notification_config.email.smtp_port", Message: fmt.Sprintf("must be between 1 and 65535, got %d", *e.SMTPPort)})
+			}
+			oneOf("notification_config.email.tls", e.TLS, "starttls", "tls", "none")
+			if _, err := mail.ParseAddress(e.From); err != nil {
+				issues = append(issues, SettingIssue{Path: "notification_config.email.from", Message: fmt.Sprintf("invalid address %q", e.From)})
+			}
+			if len(e.To) == 0 {
+				issues = append(issues, SettingIssue{Path: "notification_config.email.to", Message: "at least one recipient is required"})
+			}
+			for i, to := range e.To {
+				if _, err := mail.ParseAddress(to); err != nil {
+					issues = append(issues, SettingIssue{Path: fmt.Sprintf("notification_config.email.to[%d]", i), Message: fmt.Sprintf("invalid address %q", to)})
+				}
+			}
+			atLeast("notification_config.email.digest_interval_hours", e.DigestIntervalHours, 0)
+		}
+	}
+	if c := s.ArtifactStore; c != nil {
+		oneOf("artifact_store.type", c.Type, "local", "s3", "gcs")
+		if c.Type != nil && (*c.Type == "s3" || *c.Type == "gcs") && (c.Bucket == nil || *c.Bucket == "") {
+			issues = append(issues, SettingIssue{Path: "artifact_store.bucket", Message: fmt.Sprintf("is required for %s", *c.Type)})
+		}
+	}
+	if c := s.Decomposition; c != nil {
+		if c.MinEstimatedHours != nil && *c.MinEstimatedHours < 0 {
+			issues = append(issues, SettingIssue{Path: "decomposition.min_estimated_hours", Message: fmt.Sprintf("must not be negative, got %g", *c.MinEstimatedHours)})
+		}
+		if c.MinEstimatedHours != nil && c.MaxEstimatedHours != nil && *c.MaxEstimatedHours < *c.MinEstimatedHours {
+			issues = append(issues, SettingIssue{Path: "decomposition.max_estimated_hours", Message: fmt.Sprintf("must not be less than min_estimated_hours (%g), got %g", *c.MinEstimatedHours, *c.MaxEstimatedHours)})
+		}
+		atLeast("decomposition.max_acceptance_criteria", c.MaxAcceptanceCriteria, 1)
+		atLeast("decomposition.max_touched_areas", c.MaxTouchedAreas, 1)
+	}
+	if c := s.DecisionExtraction; c != nil {
+		var strategies []string
+		if c.Strategies != nil {
+			strategies = *c.Strategies
+		}
+		pattern := ""
+		if c.Pattern != nil {
+			pattern = *c.Pattern
+		}
+		if _, err := domainservice.NewDecisionExtractorChain(strategies, pattern); err != nil {
+			issues = append(issues, SettingIssue{Path: "decision_extraction", Message: err.Error()})
+		}
+	}
+	if c := s.Scheduling; c != nil {
+		atLeast("scheduling.pbi_wip_limit", c.PBIWIPLimit, 0)
+		atLeast("scheduling.max_turns_per_pbi", c.MaxTurnsPerPBI, 0)
+		atLeast("scheduling.max_agent_calls_per_day", c.MaxAgentCallsPerDay, 0)
+		atLeast("scheduling.implement_pool_size", c.ImplementPoolSize, 0)
+		atLeast("scheduling.review_pool_size", c.ReviewPoolSize, 0)
+		atLeast("scheduling.aging_interval_hours", c.AgingIntervalHours, 0)
+		atLeast("scheduling.aging_max_priority", c.AgingMaxPriority, 0)
+		for i, spec := range c.Windows {
+			if _, err := domainservice.ParseExecutionWindow(spec); err != nil {
+				issues = append(issues, SettingIssue{Path: fmt.Sprintf("scheduling.windows[%d]", i), Message: err.Error()})
+			}
+		}
+	}
+	if c := s.ReviewQuorum; c != nil && c.Rule != nil {
+		if _, err := domainservice.ParseReviewQuorumRule(*c.Rule); err != nil {
+			issues = append(issues, SettingIssue{Path: "review_quorum.rule", Message: err.Error()})
+		}
+	}
+	if c := s.Journal; c != nil {
+		atLeast("journal.rotate_max_mb", c.RotateMaxMB, 0)
+		atLeast("journal.rotate_max_age_hours", c.RotateMaxAgeHours, 0)
+		atLeast("journal.keep_full_segments", c.KeepFullSegments, 0)
+		atLeast("journal.sink_buffer_size", c.SinkBufferSize, 1)
+		for i := range c.Sinks {
+			sink := &c.Sinks[i]
+			path := fmt.Sprintf("journal.sinks[%d]", i)
+			oneOf(path+".type", &sink.Type, "stdout", "file", "sqlite", "http")
+			if sink.Type == "file" && sink.Path == "" {
+				issues = append(issues, SettingIssue{Path: path + ".path", Message: "is required for file"})
+			}
+			if sink.Type == "http" && sink.URL == "" {
+				issues = append(issues, SettingIssue{Path: path + ".url", Message: "is required for http"})
+			}
+			atLeast(path+".timeout_sec", sink.TimeoutSec, 0)
+		}
+	}
+	if c := s.SpecPreflight; c != nil {
+		oneOf("spec_preflight.mode", c.Mode, "off", "warn", "block")
+	}
+	if c := s.ReviewTimeout; c != nil {
+		atLeast("review_timeout.timeout_sec", c.TimeoutSec, 0)
+		oneOf("review_timeout.policy", c.Policy, "retry", "force_implement")
+	}
+	if c := s.OwnershipContext; c != nil {
+		atLeast("ownership_context.max_files", c.MaxFiles, 1)
+		atLeast("ownership_context.max_commits", c.MaxCommits, 1)
+	}
+	if c := s.ReviewContext; c != nil {
+		atLeast("review_context.max_diff_kb", c.MaxDiffKB, 1)
+		atLeast("review_context.test_timeout_sec", c.TestTimeoutSec, 1)
+	}
+	if c := s.AgentAPI; c != nil {
+		if c.DefaultAgent != nil && *c.DefaultAgent != "" && !label.IsRoutableAgent(*c.DefaultAgent) {
+			issues = append(issues, SettingIssue{Path: "agent_api.default_agent", Message: fmt.Sprintf("unknown agent type %q (use %s)", *c.DefaultAgent, strings.Join(label.RoutableAgents, ", "))})
+		}
+		for _, name := range sortedKeys(c.Providers) {
+			provider := c.Providers[name]
+			path := "agent_api.providers." + name
+			if name != "anthropic" && name != "openai" && name != "gemini" {
+				issues = append(issues, SettingIssue{Path: path, Message: "unknown provider (use anthropic, openai or gemini)"})
+				continue
+			}
+			atLeast(path+".max_tokens", provider.MaxTokens, 1)
+			atLeast(path+".max_retries", provider.MaxRetries, 0)
+			atLeast(path+".timeout_sec", provider.TimeoutSec, 0)
+		}
+	}
+	if c := s.Ollama; c != nil {
+		atLeast("ollama.context_window", c.ContextWindow, 0)
+		atLeast("ollama.max_output_tokens", c.MaxOutputTokens, 1)
+		oneOf("ollama.overflow", c.Overflow, "truncate", "summarize")
+		atLeast("ollama.timeout_sec", c.TimeoutSec, 0)
+	}
+	if c := s.AgentCache; c != nil {
+		for i := range c.Steps {
+			oneOf(fmt.Sprintf("agent_cache.steps[%d]", i), &c.Steps[i], "review")
+		}
+		atLeast("agent_cache.ttl_hours", c.TTLHours, 0)
+	}
+	if c := s.FailurePolicy; c != nil {
+		oneOf("failure_policy.on_limit", c.OnLimit, "done", "failed")
+	}
+	if c := s.ArtifactRetry; c != nil {
+		atLeast("artifact_retry.attempts", c.Attempts, 0)
+	}
+	if c := s.Trash; c != nil {
+		atLeast("trash.retention_days", c.RetentionDays, 0)
+	}
+	if c := s.PromptArchive; c != nil {
+		atLeast("prompt_archive.retention_days", c.RetentionDays, 0)
+	}
+	if c := s.Backup; c != nil {
+		atLeast("backup.interval_hours", c.IntervalHours, 1)
+		atLeast("backup.keep", c.Keep, 1)
+	}
+	if c := s.TurnTimeBox; c != nil {
+		atLeast("turn_time_box.minutes", c.Minutes, 0)
+		atLeast("turn_time_box.checkpoint_minutes", c.CheckpointMinutes, 1)
+	}
+	if c := s.ModelPolicy; c != nil {
+		for i, rule := range c.Rules {
+			policyRule := domainservice.ModelPolicyRule{Step: rule.Step, Family: rule.Family, MinVersion: rule.MinVersion}
+			if err := domainservice.ValidateModelPolicyRule(policyRule); err != nil {
+				issues = append(issues, SettingIssue{Path: fmt.Sprintf("model_policy.rules[%d]", i), Message: err.Error()})
+			}
+		}
+	}
+	if c := s.AgentRouting; c != nil {
+		for i, rule := range c.Rules {
+			routingRule := domainservice.AgentRoutingRule{Steps: rule.Steps, MinHours: rule.MinHours, MaxHours: rule.MaxHours, FileTypes: rule.FileTypes, Agent: rule.Agent, Model: rule.Model}
+			if err := domainservice.ValidateAgentRoutingRule(routingRule); err != nil {
+				issues = append(issues, SettingIssue{Path: fmt.Sprintf("agent_routing.rules[%d]", i), Message: err.Error()})
+			}
+		}
+	}
+	if c := s.PromptExperiment; c != nil && c.Enabled {
+		experiment := domainservice.PromptExperiment{Name: c.Name, Template: c.Template, Variant: c.Variant}
+		if err := domainservice.ValidatePromptExperiment(experiment); err != nil {
+			issues = append(issues, SettingIssue{Path: "prompt_experiment", Message: err.Error()})
+		}
+	}
+	if c := s.MCP; c != nil {
+		for _, name := range sortedKeys(c.Servers) {
+			server := c.Servers[name]
+			path := "mcp.servers." + name
+			if !label.IsValidMCPServerName(name) {
+				issues = append(issues, SettingIssue{Path: path, Message: "invalid server name (letters, digits, '_', '.' and '-' only)"})
+				continue
+			}
+			if err := domainservice.ValidateMCPServer(server.Type, server.Command, server.URL); err != nil {
+				issues = append(issues, SettingIssue{Path: path, Message: err.Error()})
+			}
+		}
+	}
+	if c := s.ChangeBudget; c != nil {
+		atLeast("change_budget.max_lines", c.MaxLines, 0)
+		atLeast("change_budget.max_files", c.MaxFiles, 0)
+		oneOf("change_budget.on_exceed", c.OnExceed, "split", "flag")
+		atLeast("change_budget.split_attempts", c.SplitAttempts, 1)
+	}
+	if c := s.TemplateVars; c != nil {
+		for _, name := range sortedKeys(c.Vars) {
+			v := c.Vars[name]
+			if err := domainservice.ValidateTemplateVar(name, domainservice.TemplateVar{Value: v.Value, Command: v.Command}); err != nil {
+				issues = append(issues, SettingIssue{Path: "template_vars.vars." + name, Message: err.Error()})
+			}
+		}
+		atLeast("template_vars.command_timeout_sec", c.CommandTimeoutSec, 1)
+	}
+	if c := s.DuplicateCheck; c != nil {
+		oneOf("duplicate_check.mode", c.Mode, "off", "warn", "block")
+		if c.Threshold != nil && (*c.Threshold <= 0 || *c.Threshold > 1) {
+			issues = append(issues, SettingIssue{Path: "duplicate_check.threshold", Message: fmt.Sprintf("must be greater than 0 and at most 1, got %g", *c.Threshold)})
+		}
+	}
+	if c := s.ContextWindow; c != nil {
+		oneOf("context_window.mode", c.Mode, "off", "summarize", "fail")
+		for _, model := range sortedKeys(c.Models) {
+			window := c.Models[model]
+			atLeast("context_window.models."+model, &window, 1)
+		}
+	}
+	if c := s.IDScheme; c != nil {
+		oneOf("id_scheme.strategy", c.Strategy, "ulid", "date_sequence", "prefix")
+		for _, taskType := range sortedKeys(c.Prefixes) {
+			switch strings.ToLower(taskType) {
+			case "sbi", "pbi", "epic":
+				if err := model.ValidateIDPrefix(c.Prefixes[taskType]); err != nil {
+					issues = append(issues, SettingIssue{Path: "id_scheme.prefixes." + taskType, Message: err.Error()})
+				}
+			default:
+				issues = append(issues, SettingIssue{Path: "id_scheme.prefixes." + taskType, Message: "unknown task type (must be sbi, pbi or epic)"})
+			}
+		}
+		if c.Digits != nil && (*c.Digits < 1 || *c.Digits > 9) {
+			issues = append(issues, SettingIssue{Path: "id_scheme.digits", Message: fmt.Sprintf("must be between 1 and 9, got %d", *c.Digits)})
+		}
+	}
+	if c := s.DatabaseEncryption; c != nil && c.KeySource != nil {
+		kind, value, _ := strings.Cut(*c.KeySource, ":")
+		if (kind != "env" && kind != "file" && kind != "cmd") || strings.TrimSpace(value) == "" {
+			issues = append(issues, SettingIssue{Path: "database_encryption.key_source", Message: fmt.Sprintf("must be env:NAME, file:PATH or cmd:COMMAND, got %q", *c.KeySource)})
+		}
+	}
+	return issues
+}
+
+// jsonFields maps the JSON keys of a struct type to its fields
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// closestKey suggests the known key nearest to an unknown one, if it is close enough to be a typo
+func closestKey(key string, fields map[string]reflect.StructField) string {
+	maxDistance := 2
+	if len(key) > 9 {
+		maxDistance = 3
+	}
+	best, bestDistance := "", maxDistance+1
+	for candidate := range fields {
+		d := editDistance(key, candidate)
+		if d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func typeIssue(path, want string, value interface{}) SettingIssue {
+	return SettingIssue{Path: path, Message: fmt.Sprintf("must be %s, got %s", want, describeJSON(value))}
+}
+
+// describeJSON names the JSON type of a decoded value, with the value for scalars
+func describeJSON(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case json.Number:
+		return "number " + v.String()
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
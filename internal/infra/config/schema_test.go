@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
+)
+
+func TestValidateSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		json       string
+		wantIssues []string
+	}{
+		{name: "empty", json: ""},
+		{name: "valid", json: `{"max_turns": 12, "scheduling": {"pbi_wip_limit": 2}, "agent_pool_config": {"max_concurrent": {"codex": 3}}}`},
+		{name: "null uses default", json: `{"max_turns": null, "journal": null}`},
+		{
+			name:       "unknown key with suggestion",
+			json:       `{"max_turn": 12}`,
+			wantIssues: []string{`max_turn: unknown key (did you mean "max_turns"?)`},
+		},
+		{
+			name:       "unknown nested key",
+			json:       `{"agent_pool_config": {"max_concurent": {"codex": 3}}}`,
+			wantIssues: []string{`agent_pool_config.max_concurent: unknown key (did you mean "max_concurrent"?)`},
+		},
+		{
+			name:       "unknown key without suggestion",
+			json:       `{"colour_scheme": "dark"}`,
+			wantIssues: []string{"colour_scheme: unknown key"},
+		},
+		{
+			name: "wrong types",
+			json: `{"timeout_sec": "900", "auto_fb": "yes", "max_turns": 1.5, "aliases": ["r"]}`,
+			wantIssues: []string{
+				"aliases: must be an object, got an array",
+				`auto_fb: must be true or false, got string "yes"`,
+				"max_turns: must be an integer, got number 1.5",
+				`timeout_sec: must be an integer, got string "900"`,
+			},
+		},
+		{
+			name: "out of range",
+			json: `{"max_turns": 0, "agent_pool_config": {"max_concurrent": {"codex": 0}}, "notification_config": {"email": {"smtp_host": "smtp.example.com", "smtp_port": 0, "tls": "ssl", "from": "deespec", "to": ["team@example.com", "lead"]}}, "scheduling": {"pbi_wip_limit": -1, "review_pool_size": -2, "aging_interval_hours": -24, "windows": ["22:00-06:00", "22-6"]}, "spec_preflight": {"mode": "strict"}, "review_timeout": {"policy": "escalate"}, "status_labels": {"picked": "Queued", "BLOCKED": "Blocked", "DONE": ""}, "review_context": {"max_diff_kb": 0}, "agent_api": {"default_agent": "mistral-api", "providers": {"openai": {"max_retries": -1}, "azure": {}}}, "ollama": {"overflow": "drop"}, "agent_cache": {"steps": ["done", "implement"]}, "failure_policy": {"on_limit": "cancelled"}, "artifact_retry": {"attempts": -1}, "trash": {"retention_days": -1}, "prompt_archive": {"retention_days": -7}, "backup": {"interval_hours": 0, "keep": 0}, "turn_time_box": {"minutes": -5, "checkpoint_minutes": 0}, "model_policy": {"rules": [{"step": "review", "family": "claude-opus", "min_version": "4.1"}, {"step": "review", "family": "gpt", "min_version": "v5"}]}, "agent_routing": {"rules": [{"labels": ["docs"], "model": "claude-haiku"}, {"min_hours": 8, "max_hours": 4, "model": "claude-opus"}]}, "prompt_experiment": {"enabled": true, "name": "wip-v2", "template": "PLAN.md", "variant": "experiments/WIP.v2.md"}, "mcp": {"servers": {"db-schema": {"command": "db-mcp"}, "docs": {"type": "http"}, "bad name": {"command": "x"}}}, "change_budget": {"max_lines": -1, "on_exceed": "reject", "split_attempts": 0}, "template_vars": {"vars": {"Owner": {"value": "team"}, "on-call": {"command": "oncall"}, "Empty": {}}, "command_timeout_sec": 0}, "duplicate_check": {"mode": "strict", "threshold": 1.5}, "context_window": {"mode": "truncate", "models": {"gpt-5": 400000, "tiny": 0}}, "id_scheme": {"strategy": "uuid", "prefixes": {"sbi": "TASK", "pbi": "2024", "story": "ST"}, "digits": 12}, "database_encryption": {"enabled": true, "key_source": "vault:deespec"}, "journal": {"sinks": [{"type": "stdout"}, {"type": "kafka"}, {"type": "http"}]}}`,
+			wantIssues: []string{
+				"max_turns: must be at least 1, got 0",
+				"status_labels.BLOCKED: unknown SBI status (use PENDING, PICKED, IMPLEMENTING, REVIEWING, DONE, FAILED or CANCELLED)",
+				"status_labels.DONE: must not be empty",
+				"agent_pool_config.max_concurrent.codex: must be at least 1, got 0",
+				"notification_config.email.smtp_port: must be between 1 and 65535, got 0",
+				`notification_config.email.tls: must be one of starttls, tls, none, got "ssl"`,
+				`notification_config.email.from: invalid address "deespec"`,
+				`notification_config.email.to[1]: invalid address "lead"`,
+				"scheduling.pbi_wip_limit: must be at least 0, got -1",
+				"scheduling.review_pool_size: must be at least 0, got -2",
+				"scheduling.aging_interval_hours: must be at least 0, got -24",
+				`scheduling.windows[1]: invalid execution window "22-6" (expected HH:MM-HH:MM, e.g. 22:00-06:00)`,
+				`journal.sinks[1].type: must be one of stdout, file, sqlite, http, got "kafka"`,
+				"journal.sinks[2].url: is required for http",
+				`spec_preflight.mode: must be one of off, warn, block, got "strict"`,
+				`review_timeout.policy: must be one of retry, force_implement, got "escalate"`,
+				"review_context.max_diff_kb: must be at least 1, got 0",
+				`agent_api.default_agent: unknown agent type "mistral-api" (use claude-code, claude-code-cli, gemini-cli, codex, anthropic-api, openai-api, gemini-api, ollama)`,
+				"agent_api.providers.azure: unknown provider (use anthropic, openai or gemini)",
+				"agent_api.providers.openai.max_retries: must be at least 0, got -1",
+				`ollama.overflow: must be one of truncate, summarize, got "drop"`,
+				`agent_cache.steps[0]: must be one of review, got "done"`,
+				`agent_cache.steps[1]: must be one of review, got "implement"`,
+				`failure_policy.on_limit: must be one of done, failed, got "cancelled"`,
+				"artifact_retry.attempts: must be at least 0, got -1",
+				"trash.retention_days: must be at least 0, got -1",
+				"prompt_archive.retention_days: must be at least 0, got -7",
+				"backup.interval_hours: must be at least 1, got 0",
+				"backup.keep: must be at least 1, got 0",
+				"turn_time_box.minutes: must be at least 0, got -5",
+				"turn_time_box.checkpoint_minutes: must be at least 1, got 0",
+				`model_policy.rules[1]: invalid min_version "v5" (use numbers separated by dots, e.g. 4.1)`,
+				"agent_routing.rules[1]: min_hours 8 is greater than max_hours 4",
+				"prompt_experiment: unknown template \"PLAN.md\" (must be one of WIP.md, REVIEW.md, REVIEW_AND_WIP.md, DONE.md, POSTMORTEM.md)",
+				"mcp.servers.bad name: invalid server name (letters, digits, '_', '.' and '-' only)",
+				"mcp.servers.docs: invalid url \"\" (must be an http or https URL)",
+				"change_budget.max_lines: must be at least 0, got -1",
+				`change_budget.on_exceed: must be one of split, flag, got "reject"`,
+				"change_budget.split_attempts: must be at least 1, got 0",
+				"template_vars.vars.Empty: set either value or command",
+				`template_vars.vars.on-call: invalid variable name "on-call" (a letter followed by letters, digits and underscores)`,
+				"template_vars.command_timeout_sec: must be at least 1, got 0",
+				`duplicate_check.mode: must be one of off, warn, block, got "strict"`,
+				"duplicate_check.threshold: must be greater than 0 and at most 1, got 1.5",
+				`context_window.mode: must be one of off, summarize, fail, got "truncate"`,
+				"context_window.models.tiny: must be at least 1, got 0",
+				`id_scheme.strategy: must be one of ulid, date_sequence, prefix, got "uuid"`,
+				`id_scheme.prefixes.pbi: must start with a letter and contain only letters, digits and '_' (at most 16), got "2024"`,
+				"id_scheme.prefixes.story: unknown task type (must be sbi, pbi or epic)",
+				"id_scheme.digits: must be between 1 and 9, got 12",
+				`database_encryption.key_source: must be env:NAME, file:PATH or cmd:COMMAND, got "vault:deespec"`,
+			},
+		},
+		{
+			name:       "cross-field range against default",
+			json:       `{"decomposition": {"max_estimated_hours": 0.25}}`,
+			wantIssues: []string{"decomposition.max_estimated_hours: must not be less than min_estimated_hours (0.5), got 0.25"},
+		},
+		{
+			name:       "bucket required for remote store",
+			json:       `{"artifact_store": {"type": "s3"}}`,
+			wantIssues: []string{"artifact_store.bucket: is required for s3"},
+		},
+		{
+			name:       "invalid JSON",
+			json:       `{"max_turns": 3,}`,
+			wantIssues: []string{"not valid JSON: invalid character '}' looking for beginning of object key string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := ValidateSettings([]byte(tt.json))
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantIssues, "\n") {
+				t.Errorf("ValidateSettings() issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantIssues, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateSettings_DefaultSettingsAreValid(t *testing.T) {
+	for _, loc := range []locale.Locale{locale.Japanese, locale.English} {
+		if issues := ValidateSettings(CreateDefaultSettingsForLocale(loc)); len(issues) > 0 {
+			t.Errorf("default settings for %s have issues: %v", loc, issues)
+		}
+	}
+}
+
+func TestLoadSettings_RejectsInvalidSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	data := `{"max_turn": 12, "timeout_sec": "900"}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "setting.json"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadSettings(tmpDir)
+	var settingsErr *SettingsError
+	if !errors.As(err, &settingsErr) {
+		t.Fatalf("LoadSettings() error = %v, want *SettingsError", err)
+	}
+	if len(settingsErr.Issues) != 2 {
+		t.Errorf("Issues = %v, want 2", settingsErr.Issues)
+	}
+	if !strings.Contains(err.Error(), "(2 problems)") || !strings.Contains(err.Error(), `did you mean "max_turns"?`) {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
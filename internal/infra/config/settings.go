@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/YoshitsuguKoike/deespec/internal/app/config"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
 )
 
 // RawSettings represents the structure of setting.json file.
@@ -51,11 +52,122 @@ type RawSettings struct {
 	PolicyPath  *string `json:"policy_path"`
 	StderrLevel *string `json:"stderr_level"`
 
+	// Localization of built-in templates and SBI section names ("ja" or "en")
+	Locale *string `json:"locale"`
+
+	// User-defined command aliases (e.g. {"r": "run --parallel 2"})
+	Aliases *map[string]string `json:"aliases"`
+
+	// Display labels of SBI statuses in list/show commands (e.g. {"PICKED": "Queued"})
+	StatusLabels *map[string]string `json:"status_labels"`
+
 	// Label system configuration
 	LabelConfig *RawLabelConfig `json:"label_config"`
 
 	// Agent pool configuration
 	AgentPoolConfig *RawAgentPoolConfig `json:"agent_pool_config"`
+
+	// Notification configuration
+	NotificationConfig *RawNotificationConfig `json:"notification_config"`
+
+	// Artifact storage configuration
+	ArtifactStore *RawArtifactStoreConfig `json:"artifact_store"`
+
+	// PBI decomposition configuration
+	Decomposition *RawDecompositionConfig `json:"decomposition"`
+
+	// Review decision extraction configuration
+	DecisionExtraction *RawDecisionExtractionConfig `json:"decision_extraction"`
+
+	// SBI scheduling configuration
+	Scheduling *RawSchedulingConfig `json:"scheduling"`
+
+	// Agent conversation continuation across turns
+	AgentSession *RawAgentSessionConfig `json:"agent_session"`
+
+	// Values referenced by SBI/PBI env declarations
+	TaskEnv *RawTaskEnvConfig `json:"task_env"`
+
+	// Multiple reviewer agents per review step
+	ReviewQuorum *RawReviewQuorumConfig `json:"review_quorum"`
+
+	// Reviewer must differ from the implementer
+	SelfReviewGuard *RawSelfReviewGuardConfig `json:"self_review_guard"`
+
+	// Journal rotation and compaction
+	Journal *RawJournalConfig `json:"journal"`
+
+	// Spec quality check before the first turn
+	SpecPreflight *RawSpecPreflightConfig `json:"spec_preflight"`
+
+	// Review decision SLA
+	ReviewTimeout *RawReviewTimeoutConfig `json:"review_timeout"`
+
+	// Git ownership annotations in prompts
+	OwnershipContext *RawOwnershipContextConfig `json:"ownership_context"`
+
+	// Diff, test and report bundle for reviews
+	ReviewContext *RawReviewContextConfig `json:"review_context"`
+
+	// HTTP API agent backends
+	AgentAPI *RawAgentAPIConfig `json:"agent_api"`
+
+	// Local LLM agent served by Ollama
+	Ollama *RawOllamaConfig `json:"ollama"`
+
+	// Agent output reuse for identical prompts
+	AgentCache *RawAgentCacheConfig `json:"agent_cache"`
+
+	// Terminal status of SBIs that exhaust their turn limit
+	FailurePolicy *RawFailurePolicyConfig `json:"failure_policy"`
+
+	// Corrective prompts for missing or empty step reports
+	ArtifactRetry *RawArtifactRetryConfig `json:"artifact_retry"`
+
+	// Retention of deleted PBIs and SBIs
+	Trash *RawTrashConfig `json:"trash"`
+
+	// Archive of the prompts sent to agents
+	PromptArchive *RawPromptArchiveConfig `json:"prompt_archive"`
+
+	// Periodic snapshots of the task database
+	Backup *RawBackupConfig `json:"backup"`
+
+	// Wall-clock budget of implement turns
+	TurnTimeBox *RawTurnTimeBoxConfig `json:"turn_time_box"`
+
+	// Models workflow steps may be dispatched to
+	ModelPolicy *RawModelPolicyConfig `json:"model_policy"`
+
+	// Agent and model chosen by task characteristics
+	AgentRouting *RawAgentRoutingConfig `json:"agent_routing"`
+
+	// A/B test of a prompt template variant
+	PromptExperiment *RawPromptExperimentConfig `json:"prompt_experiment"`
+
+	// MCP servers labels can enable for agents
+	MCP *RawMCPConfig `json:"mcp"`
+
+	// Code change cap of implement turns
+	ChangeBudget *RawChangeBudgetConfig `json:"change_budget"`
+
+	// Custom prompt template variables
+	TemplateVars *RawTemplateVarsConfig `json:"template_vars"`
+
+	// Near-duplicate check when registering SBIs and PBIs
+	DuplicateCheck *RawDuplicateCheckConfig `json:"duplicate_check"`
+
+	// Pre-check that prompts fit the model's context window
+	ContextWindow *RawContextWindowConfig `json:"context_window"`
+
+	// How the IDs of new EPICs, PBIs and SBIs are generated
+	IDScheme *RawIDSchemeConfig `json:"id_scheme"`
+
+	// Encryption at rest of the task database
+	DatabaseEncryption *RawDatabaseEncryptionConfig `json:"database_encryption"`
+
+	// Refuse commands that mutate the database or filesystem (e.g. in CI)
+	ReadOnly *bool `json:"read_only"`
 }
 
 // RawLabelImportConfig represents import settings for labels
@@ -83,6 +195,312 @@ type RawAgentPoolConfig struct {
 	MaxConcurrent *map[string]int `json:"max_concurrent"`
 }
 
+// RawNotificationConfig represents notification configuration in setting.json
+type RawNotificationConfig struct {
+	WebhookURL               *string `json:"webhook_url"`
+	DeadlineCheckIntervalSec *int    `json:"deadline_check_interval_sec"`
+	EscalationHours          *int    `json:"escalation_hours"`
+
+	Email *RawEmailNotificationConfig `json:"email"`
+}
+
+// RawEmailNotificationConfig represents SMTP notification settings in setting.json
+type RawEmailNotificationConfig struct {
+	SMTPHost            string   `json:"smtp_host"`
+	SMTPPort            *int     `json:"smtp_port"`
+	TLS                 *string  `json:"tls"`
+	Username            string   `json:"username"`
+	PasswordEnv         *string  `json:"password_env"`
+	From                string   `json:"from"`
+	To                  []string `json:"to"`
+	DigestIntervalHours *int     `json:"digest_interval_hours"`
+	TemplateDir         string   `json:"template_dir"`
+}
+
+// RawArtifactStoreConfig represents artifact storage configuration in setting.json
+type RawArtifactStoreConfig struct {
+	Type     *string `json:"type"`
+	Bucket   *string `json:"bucket"`
+	Prefix   *string `json:"prefix"`
+	Region   *string `json:"region"`
+	Endpoint *string `json:"endpoint"`
+}
+
+// RawDecompositionConfig represents SBI sizing limits in setting.json
+type RawDecompositionConfig struct {
+	MinEstimatedHours     *float64 `json:"min_estimated_hours"`
+	MaxEstimatedHours     *float64 `json:"max_estimated_hours"`
+	MaxAcceptanceCriteria *int     `json:"max_acceptance_criteria"`
+	MaxTouchedAreas       *int     `json:"max_touched_areas"`
+	RejectOversized       *bool    `json:"reject_oversized"`
+}
+
+// RawDecisionExtractionConfig represents review decision extraction settings in setting.json
+type RawDecisionExtractionConfig struct {
+	Strategies *[]string `json:"strategies"`
+	Pattern    *string   `json:"pattern"`
+}
+
+// RawSchedulingConfig represents SBI scheduling settings in setting.json
+type RawSchedulingConfig struct {
+	PBIWIPLimit         *int     `json:"pbi_wip_limit"`
+	MaxTurnsPerPBI      *int     `json:"max_turns_per_pbi"`
+	MaxAgentCallsPerDay *int     `json:"max_agent_calls_per_day"`
+	ImplementPoolSize   *int     `json:"implement_pool_size"`
+	ReviewPoolSize      *int     `json:"review_pool_size"`
+	Windows             []string `json:"windows"`
+	AgingIntervalHours  *int     `json:"aging_interval_hours"`
+	AgingMaxPriority    *int     `json:"aging_max_priority"`
+}
+
+// RawAgentSessionConfig represents agent session continuation settings in setting.json
+type RawAgentSessionConfig struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// RawTaskEnvConfig represents task environment settings in setting.json
+type RawTaskEnvConfig struct {
+	Vars map[string]string `json:"vars"`
+}
+
+// RawReviewerConfig represents one reviewer agent in setting.json
+type RawReviewerConfig struct {
+	Name  string `json:"name"`
+	Agent string `json:"agent"`
+	Model string `json:"model"`
+}
+
+// RawReviewQuorumConfig represents review quorum settings in setting.json
+type RawReviewQuorumConfig struct {
+	Reviewers []RawReviewerConfig `json:"reviewers"`
+	Rule      *string             `json:"rule"`
+}
+
+// RawAgentRouteConfig represents an agent and model pair in setting.json
+type RawAgentRouteConfig struct {
+	Agent string `json:"agent"`
+	Model string `json:"model"`
+}
+
+// RawSelfReviewGuardConfig represents self-review guard settings in setting.json
+type RawSelfReviewGuardConfig struct {
+	Enabled    *bool                 `json:"enabled"`
+	Alternates []RawAgentRouteConfig `json:"alternates"`
+}
+
+// RawJournalConfig represents journal rotation settings in setting.json
+type RawJournalConfig struct {
+	RotateMaxMB       *int `json:"rotate_max_mb"`
+	RotateMaxAgeHours *int `json:"rotate_max_age_hours"`
+	KeepFullSegments  *int `json:"keep_full_segments"`
+
+	Sinks          []RawJournalSinkConfig `json:"sinks"`
+	SinkBufferSize *int                   `json:"sink_buffer_size"`
+}
+
+// RawJournalSinkConfig represents one additional journal destination in setting.json
+type RawJournalSinkConfig struct {
+	Type       string            `json:"type"`
+	Path       string            `json:"path"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"`
+	TimeoutSec *int              `json:"timeout_sec"`
+}
+
+// RawSpecPreflightConfig represents spec pre-flight settings in setting.json
+type RawSpecPreflightConfig struct {
+	Mode   *string  `json:"mode"`
+	Ignore []string `json:"ignore"`
+}
+
+// RawReviewTimeoutConfig represents review timeout settings in setting.json
+type RawReviewTimeoutConfig struct {
+	TimeoutSec *int    `json:"timeout_sec"`
+	Policy     *string `json:"policy"`
+}
+
+// RawOwnershipContextConfig represents git ownership annotation settings in setting.json
+type RawOwnershipContextConfig struct {
+	Enabled    *bool `json:"enabled"`
+	MaxFiles   *int  `json:"max_files"`
+	MaxCommits *int  `json:"max_commits"`
+}
+
+// RawReviewContextConfig represents review context bundle settings in setting.json
+type RawReviewContextConfig struct {
+	Enabled        *bool   `json:"enabled"`
+	MaxDiffKB      *int    `json:"max_diff_kb"`
+	TestCommand    *string `json:"test_command"`
+	TestTimeoutSec *int    `json:"test_timeout_sec"`
+}
+
+// RawAgentAPIProviderConfig represents one HTTP API agent provider in setting.json
+type RawAgentAPIProviderConfig struct {
+	BaseURL    string `json:"base_url"`
+	APIKeyEnv  string `json:"api_key_env"`
+	Model      string `json:"model"`
+	MaxTokens  *int   `json:"max_tokens"`
+	MaxRetries *int   `json:"max_retries"`
+	TimeoutSec *int   `json:"timeout_sec"`
+}
+
+// RawAgentAPIConfig represents HTTP API agent backend settings in setting.json
+type RawAgentAPIConfig struct {
+	DefaultAgent *string                              `json:"default_agent"`
+	Providers    map[string]RawAgentAPIProviderConfig `json:"providers"`
+}
+
+// RawOllamaConfig represents local LLM agent settings in setting.json
+type RawOllamaConfig struct {
+	BaseURL         *string `json:"base_url"`
+	Model           *string `json:"model"`
+	ContextWindow   *int    `json:"context_window"`
+	MaxOutputTokens *int    `json:"max_output_tokens"`
+	Overflow        *string `json:"overflow"`
+	TimeoutSec      *int    `json:"timeout_sec"`
+}
+
+// RawAgentCacheConfig represents agent output cache settings in setting.json
+type RawAgentCacheConfig struct {
+	Enabled  *bool    `json:"enabled"`
+	Steps    []string `json:"steps"`
+	TTLHours *int     `json:"ttl_hours"`
+}
+
+// RawFailurePolicyConfig represents turn limit failure policy settings in setting.json
+type RawFailurePolicyConfig struct {
+	OnLimit    *string `json:"on_limit"`
+	Postmortem *bool   `json:"postmortem"`
+}
+
+// RawArtifactRetryConfig represents missing report retry settings in setting.json
+type RawArtifactRetryConfig struct {
+	Attempts *int `json:"attempts"`
+}
+
+// RawTrashConfig represents trash settings in setting.json
+type RawTrashConfig struct {
+	RetentionDays *int `json:"retention_days"`
+}
+
+// RawPromptArchiveConfig represents prompt archive settings in setting.json
+type RawPromptArchiveConfig struct {
+	Enabled       *bool `json:"enabled"`
+	Compress      *bool `json:"compress"`
+	RetentionDays *int  `json:"retention_days"`
+}
+
+// RawBackupConfig represents database backup settings in setting.json
+type RawBackupConfig struct {
+	Enabled       *bool `json:"enabled"`
+	IntervalHours *int  `json:"interval_hours"`
+	Keep          *int  `json:"keep"`
+	Upload        *bool `json:"upload"`
+}
+
+// RawTurnTimeBoxConfig represents implement turn time box settings in setting.json
+type RawTurnTimeBoxConfig struct {
+	Minutes           *int `json:"minutes"`
+	CheckpointMinutes *int `json:"checkpoint_minutes"`
+}
+
+// RawModelPolicyConfig represents model policy settings in setting.json
+type RawModelPolicyConfig struct {
+	Rules []RawModelPolicyRuleConfig `json:"rules"`
+}
+
+// RawModelPolicyRuleConfig represents one model policy rule in setting.json
+type RawModelPolicyRuleConfig struct {
+	Step       string `json:"step"`
+	Family     string `json:"family"`
+	MinVersion string `json:"min_version"`
+}
+
+// RawAgentRoutingConfig represents agent routing settings in setting.json
+type RawAgentRoutingConfig struct {
+	Rules []RawAgentRoutingRuleConfig `json:"rules"`
+}
+
+// RawAgentRoutingRuleConfig represents one agent routing rule in setting.json
+type RawAgentRoutingRuleConfig struct {
+	Name      string   `json:"name"`
+	Steps     []string `json:"steps"`
+	Labels    []string `json:"labels"`
+	MinHours  float64  `json:"min_hours"`
+	MaxHours  float64  `json:"max_hours"`
+	FileTypes []string `json:"file_types"`
+	Agent     string   `json:"agent"`
+	Model     string   `json:"model"`
+}
+
+// RawPromptExperimentConfig represents prompt experiment settings in setting.json
+type RawPromptExperimentConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	Variant  string `json:"variant"`
+}
+
+// RawMCPConfig represents MCP server settings in setting.json
+type RawMCPConfig struct {
+	Servers map[string]RawMCPServerConfig `json:"servers"`
+}
+
+// RawMCPServerConfig represents one MCP server in setting.json
+type RawMCPServerConfig struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+}
+
+// RawChangeBudgetConfig represents per-turn change budget settings in setting.json
+type RawChangeBudgetConfig struct {
+	MaxLines      *int    `json:"max_lines"`
+	MaxFiles      *int    `json:"max_files"`
+	OnExceed      *string `json:"on_exceed"`
+	SplitAttempts *int    `json:"split_attempts"`
+}
+
+// RawTemplateVarsConfig represents custom prompt template variables in setting.json
+type RawTemplateVarsConfig struct {
+	Vars              map[string]RawTemplateVarConfig `json:"vars"`
+	CommandTimeoutSec *int                            `json:"command_timeout_sec"`
+}
+
+// RawTemplateVarConfig represents one custom prompt template variable in setting.json
+type RawTemplateVarConfig struct {
+	Value   string `json:"value"`
+	Command string `json:"command"`
+}
+
+// RawDuplicateCheckConfig represents near-duplicate check settings in setting.json
+type RawDuplicateCheckConfig struct {
+	Mode      *string  `json:"mode"`
+	Threshold *float64 `json:"threshold"`
+}
+
+// RawContextWindowConfig represents context window pre-check settings in setting.json
+type RawContextWindowConfig struct {
+	Mode   *string        `json:"mode"`
+	Models map[string]int `json:"models"`
+}
+
+// RawIDSchemeConfig represents task ID scheme settings in setting.json
+type RawIDSchemeConfig struct {
+	Strategy *string           `json:"strategy"`
+	Prefixes map[string]string `json:"prefixes"`
+	Digits   *int              `json:"digits"`
+}
+
+// RawDatabaseEncryptionConfig represents database encryption settings in setting.json
+type RawDatabaseEncryptionConfig struct {
+	Enabled   *bool   `json:"enabled"`
+	KeySource *string `json:"key_source"`
+}
+
 // LoadSettings loads configuration from setting.json only.
 // Priority: setting.json > defaults
 func LoadSettings(baseDir string) (*config.AppConfig, error) {
@@ -94,6 +512,10 @@ func LoadSettings(baseDir string) (*config.AppConfig, error) {
 	// Try to load setting.json
 	jsonPath := filepath.Join(baseDir, "setting.json")
 	if data, err := os.ReadFile(jsonPath); err == nil {
+		// Unknown keys, wrong types and out-of-range values are errors rather than silently defaulted
+		if issues := ValidateSettings(data); len(issues) > 0 {
+			return nil, &SettingsError{File: jsonPath, Issues: issues}
+		}
 		if err := json.Unmarshal(data, settings); err != nil {
 			return nil, fmt.Errorf("failed to parse %s: %w", jsonPath, err)
 		}
@@ -109,6 +531,13 @@ func LoadSettings(baseDir string) (*config.AppConfig, error) {
 	// Warn about deprecated settings
 	checkDeprecated(settings)
 
+	// Normalize locale (e.g. "en_US" -> "en")
+	loc, err := locale.Parse(*settings.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale in %s: %w", jsonPath, err)
+	}
+	*settings.Locale = loc.String()
+
 	// Build AppConfig
 	return buildAppConfig(settings, configSource, settingPath), nil
 }
@@ -229,6 +658,24 @@ func applyDefaults(settings *RawSettings) {
 		settings.StderrLevel = &v
 	}
 
+	// Localization
+	if settings.Locale == nil {
+		v := locale.Default.String()
+		settings.Locale = &v
+	}
+
+	// CLI aliases
+	if settings.Aliases == nil {
+		v := map[string]string{}
+		settings.Aliases = &v
+	}
+
+	// Status labels (workflow labels)
+	if settings.StatusLabels == nil {
+		v := map[string]string{}
+		settings.StatusLabels = &v
+	}
+
 	// Label system configuration
 	if settings.LabelConfig == nil {
 		settings.LabelConfig = &RawLabelConfig{}
@@ -276,6 +723,491 @@ func applyDefaults(settings *RawSettings) {
 		}
 		settings.AgentPoolConfig.MaxConcurrent = &v
 	}
+
+	// Notification configuration
+	if settings.NotificationConfig == nil {
+		settings.NotificationConfig = &RawNotificationConfig{}
+	}
+	if settings.NotificationConfig.WebhookURL == nil {
+		v := ""
+		settings.NotificationConfig.WebhookURL = &v
+	}
+	if settings.NotificationConfig.DeadlineCheckIntervalSec == nil {
+		v := 60
+		settings.NotificationConfig.DeadlineCheckIntervalSec = &v
+	}
+	if settings.NotificationConfig.EscalationHours == nil {
+		v := 24
+		settings.NotificationConfig.EscalationHours = &v
+	}
+	if settings.NotificationConfig.Email == nil {
+		settings.NotificationConfig.Email = &RawEmailNotificationConfig{}
+	}
+	if settings.NotificationConfig.Email.SMTPPort == nil {
+		v := 587
+		settings.NotificationConfig.Email.SMTPPort = &v
+	}
+	if settings.NotificationConfig.Email.TLS == nil {
+		v := "starttls"
+		settings.NotificationConfig.Email.TLS = &v
+	}
+	if settings.NotificationConfig.Email.PasswordEnv == nil {
+		v := "DEESPEC_SMTP_PASSWORD"
+		settings.NotificationConfig.Email.PasswordEnv = &v
+	}
+	if settings.NotificationConfig.Email.DigestIntervalHours == nil {
+		v := 24
+		settings.NotificationConfig.Email.DigestIntervalHours = &v
+	}
+
+	// Artifact storage configuration
+	if settings.ArtifactStore == nil {
+		settings.ArtifactStore = &RawArtifactStoreConfig{}
+	}
+	if settings.ArtifactStore.Type == nil {
+		v := "local"
+		settings.ArtifactStore.Type = &v
+	}
+	if settings.ArtifactStore.Bucket == nil {
+		v := ""
+		settings.ArtifactStore.Bucket = &v
+	}
+	if settings.ArtifactStore.Prefix == nil {
+		v := ""
+		settings.ArtifactStore.Prefix = &v
+	}
+	if settings.ArtifactStore.Region == nil {
+		v := ""
+		settings.ArtifactStore.Region = &v
+	}
+	if settings.ArtifactStore.Endpoint == nil {
+		v := ""
+		settings.ArtifactStore.Endpoint = &v
+	}
+
+	// PBI decomposition configuration
+	if settings.Decomposition == nil {
+		settings.Decomposition = &RawDecompositionConfig{}
+	}
+	if settings.Decomposition.MinEstimatedHours == nil {
+		v := 0.5
+		settings.Decomposition.MinEstimatedHours = &v
+	}
+	if settings.Decomposition.MaxEstimatedHours == nil {
+		v := 8.0
+		settings.Decomposition.MaxEstimatedHours = &v
+	}
+	if settings.Decomposition.MaxAcceptanceCriteria == nil {
+		v := 8
+		settings.Decomposition.MaxAcceptanceCriteria = &v
+	}
+	if settings.Decomposition.MaxTouchedAreas == nil {
+		v := 5
+		settings.Decomposition.MaxTouchedAreas = &v
+	}
+	if settings.Decomposition.RejectOversized == nil {
+		v := false
+		settings.Decomposition.RejectOversized = &v
+	}
+
+	// Review decision extraction configuration
+	if settings.DecisionExtraction == nil {
+		settings.DecisionExtraction = &RawDecisionExtractionConfig{}
+	}
+	if settings.DecisionExtraction.Strategies == nil {
+		v := []string{} // Empty uses the built-in order
+		settings.DecisionExtraction.Strategies = &v
+	}
+	if settings.DecisionExtraction.Pattern == nil {
+		v := ""
+		settings.DecisionExtraction.Pattern = &v
+	}
+
+	// SBI scheduling configuration
+	if settings.Scheduling == nil {
+		settings.Scheduling = &RawSchedulingConfig{}
+	}
+	if settings.Scheduling.PBIWIPLimit == nil {
+		v := 0 // Unlimited
+		settings.Scheduling.PBIWIPLimit = &v
+	}
+	if settings.Scheduling.MaxTurnsPerPBI == nil {
+		v := 0 // Unlimited
+		settings.Scheduling.MaxTurnsPerPBI = &v
+	}
+	if settings.Scheduling.MaxAgentCallsPerDay == nil {
+		v := 0 // Unlimited
+		settings.Scheduling.MaxAgentCallsPerDay = &v
+	}
+	if settings.Scheduling.ImplementPoolSize == nil {
+		v := 0 // Shared pool (--parallel)
+		settings.Scheduling.ImplementPoolSize = &v
+	}
+	if settings.Scheduling.ReviewPoolSize == nil {
+		v := 0 // Shared pool (--parallel)
+		settings.Scheduling.ReviewPoolSize = &v
+	}
+	if settings.Scheduling.AgingIntervalHours == nil {
+		v := 24 // A pending SBI gains one priority level per day of waiting
+		settings.Scheduling.AgingIntervalHours = &v
+	}
+	if settings.Scheduling.AgingMaxPriority == nil {
+		v := 2 // Urgent
+		settings.Scheduling.AgingMaxPriority = &v
+	}
+
+	// Agent session continuation (opt-in)
+	if settings.AgentSession == nil {
+		settings.AgentSession = &RawAgentSessionConfig{}
+	}
+	if settings.AgentSession.Enabled == nil {
+		v := false
+		settings.AgentSession.Enabled = &v
+	}
+
+	// Task environment values
+	if settings.TaskEnv == nil {
+		settings.TaskEnv = &RawTaskEnvConfig{}
+	}
+
+	// Review quorum (disabled without reviewers)
+	if settings.ReviewQuorum == nil {
+		settings.ReviewQuorum = &RawReviewQuorumConfig{}
+	}
+	if settings.ReviewQuorum.Rule == nil {
+		v := "majority"
+		settings.ReviewQuorum.Rule = &v
+	}
+
+	// Self-review guard (opt-in)
+	if settings.SelfReviewGuard == nil {
+		settings.SelfReviewGuard = &RawSelfReviewGuardConfig{}
+	}
+	if settings.SelfReviewGuard.Enabled == nil {
+		v := false
+		settings.SelfReviewGuard.Enabled = &v
+	}
+
+	// Journal rotation (opt-in)
+	if settings.Journal == nil {
+		settings.Journal = &RawJournalConfig{}
+	}
+	if settings.Journal.RotateMaxMB == nil {
+		v := 0 // Disabled
+		settings.Journal.RotateMaxMB = &v
+	}
+	if settings.Journal.RotateMaxAgeHours == nil {
+		v := 0 // Disabled
+		settings.Journal.RotateMaxAgeHours = &v
+	}
+	if settings.Journal.KeepFullSegments == nil {
+		v := 0 // Never compact
+		settings.Journal.KeepFullSegments = &v
+	}
+	if settings.Journal.SinkBufferSize == nil {
+		v := 1000
+		settings.Journal.SinkBufferSize = &v
+	}
+
+	// Spec pre-flight (warn only)
+	if settings.SpecPreflight == nil {
+		settings.SpecPreflight = &RawSpecPreflightConfig{}
+	}
+	if settings.SpecPreflight.Mode == nil {
+		v := "warn"
+		settings.SpecPreflight.Mode = &v
+	}
+
+	// Review timeout (disabled)
+	if settings.ReviewTimeout == nil {
+		settings.ReviewTimeout = &RawReviewTimeoutConfig{}
+	}
+	if settings.ReviewTimeout.TimeoutSec == nil {
+		v := 0 // Wait for the review decision indefinitely
+		settings.ReviewTimeout.TimeoutSec = &v
+	}
+	if settings.ReviewTimeout.Policy == nil {
+		v := "retry"
+		settings.ReviewTimeout.Policy = &v
+	}
+
+	// Ownership context (opt-in)
+	if settings.OwnershipContext == nil {
+		settings.OwnershipContext = &RawOwnershipContextConfig{}
+	}
+	if settings.OwnershipContext.Enabled == nil {
+		v := false
+		settings.OwnershipContext.Enabled = &v
+	}
+	if settings.OwnershipContext.MaxFiles == nil {
+		v := 5
+		settings.OwnershipContext.MaxFiles = &v
+	}
+	if settings.OwnershipContext.MaxCommits == nil {
+		v := 50
+		settings.OwnershipContext.MaxCommits = &v
+	}
+
+	// Review context (opt-in)
+	if settings.ReviewContext == nil {
+		settings.ReviewContext = &RawReviewContextConfig{}
+	}
+	if settings.ReviewContext.Enabled == nil {
+		v := false
+		settings.ReviewContext.Enabled = &v
+	}
+	if settings.ReviewContext.MaxDiffKB == nil {
+		v := 200
+		settings.ReviewContext.MaxDiffKB = &v
+	}
+	if settings.ReviewContext.TestCommand == nil {
+		v := ""
+		settings.ReviewContext.TestCommand = &v
+	}
+	if settings.ReviewContext.TestTimeoutSec == nil {
+		v := 600
+		settings.ReviewContext.TestTimeoutSec = &v
+	}
+
+	// HTTP API agents (the CLI agent stays the default)
+	if settings.AgentAPI == nil {
+		settings.AgentAPI = &RawAgentAPIConfig{}
+	}
+	if settings.AgentAPI.DefaultAgent == nil {
+		v := ""
+		settings.AgentAPI.DefaultAgent = &v
+	}
+
+	// Ollama (used only when an SBI or label routes to the "ollama" agent)
+	if settings.Ollama == nil {
+		settings.Ollama = &RawOllamaConfig{}
+	}
+	if settings.Ollama.BaseURL == nil {
+		v := ""
+		settings.Ollama.BaseURL = &v
+	}
+	if settings.Ollama.Model == nil {
+		v := "llama3.1"
+		settings.Ollama.Model = &v
+	}
+	if settings.Ollama.ContextWindow == nil {
+		v := 0
+		settings.Ollama.ContextWindow = &v
+	}
+	if settings.Ollama.MaxOutputTokens == nil {
+		v := 4096
+		settings.Ollama.MaxOutputTokens = &v
+	}
+	if settings.Ollama.Overflow == nil {
+		v := "truncate"
+		settings.Ollama.Overflow = &v
+	}
+	if settings.Ollama.TimeoutSec == nil {
+		v := 0
+		settings.Ollama.TimeoutSec = &v
+	}
+
+	// Agent output cache (opt-in, reviews only)
+	if settings.AgentCache == nil {
+		settings.AgentCache = &RawAgentCacheConfig{}
+	}
+	if settings.AgentCache.Enabled == nil {
+		v := false
+		settings.AgentCache.Enabled = &v
+	}
+	if settings.AgentCache.Steps == nil {
+		settings.AgentCache.Steps = []string{"review"}
+	}
+	if settings.AgentCache.TTLHours == nil {
+		v := 168
+		settings.AgentCache.TTLHours = &v
+	}
+
+	// Turn limit failure policy (force completion for backward compatibility)
+	if settings.FailurePolicy == nil {
+		settings.FailurePolicy = &RawFailurePolicyConfig{}
+	}
+	if settings.FailurePolicy.OnLimit == nil {
+		v := "done"
+		settings.FailurePolicy.OnLimit = &v
+	}
+	if settings.FailurePolicy.Postmortem == nil {
+		v := true
+		settings.FailurePolicy.Postmortem = &v
+	}
+
+	// Missing report retry (one corrective prompt before saving the agent output)
+	if settings.ArtifactRetry == nil {
+		settings.ArtifactRetry = &RawArtifactRetryConfig{}
+	}
+	if settings.ArtifactRetry.Attempts == nil {
+		v := 1
+		settings.ArtifactRetry.Attempts = &v
+	}
+
+	// Trash (deleted items can be restored for 30 days)
+	if settings.Trash == nil {
+		settings.Trash = &RawTrashConfig{}
+	}
+	if settings.Trash.RetentionDays == nil {
+		v := 30
+		settings.Trash.RetentionDays = &v
+	}
+
+	// Prompt archive (compressed, kept for 30 days)
+	if settings.PromptArchive == nil {
+		settings.PromptArchive = &RawPromptArchiveConfig{}
+	}
+	if settings.PromptArchive.Enabled == nil {
+		v := true
+		settings.PromptArchive.Enabled = &v
+	}
+	if settings.PromptArchive.Compress == nil {
+		v := true
+		settings.PromptArchive.Compress = &v
+	}
+	if settings.PromptArchive.RetentionDays == nil {
+		v := 30
+		settings.PromptArchive.RetentionDays = &v
+	}
+
+	// Database backups (daily, a week of snapshots kept locally)
+	if settings.Backup == nil {
+		settings.Backup = &RawBackupConfig{}
+	}
+	if settings.Backup.Enabled == nil {
+		v := true
+		settings.Backup.Enabled = &v
+	}
+	if settings.Backup.IntervalHours == nil {
+		v := 24
+		settings.Backup.IntervalHours = &v
+	}
+	if settings.Backup.Keep == nil {
+		v := 7
+		settings.Backup.Keep = &v
+	}
+	if settings.Backup.Upload == nil {
+		v := false
+		settings.Backup.Upload = &v
+	}
+
+	// Turn time box (off; turns run until the agent finishes or times out)
+	if settings.TurnTimeBox == nil {
+		settings.TurnTimeBox = &RawTurnTimeBoxConfig{}
+	}
+	if settings.TurnTimeBox.Minutes == nil {
+		v := 0
+		settings.TurnTimeBox.Minutes = &v
+	}
+	if settings.TurnTimeBox.CheckpointMinutes == nil {
+		v := 5
+		settings.TurnTimeBox.CheckpointMinutes = &v
+	}
+
+	// Model policy (no rules; steps run on any model)
+	if settings.ModelPolicy == nil {
+		settings.ModelPolicy = &RawModelPolicyConfig{}
+	}
+
+	// Agent routing (no rules; steps run on the default or label-routed agent)
+	if settings.AgentRouting == nil {
+		settings.AgentRouting = &RawAgentRoutingConfig{}
+	}
+
+	// Prompt experiment (disabled; every SBI uses the regular templates)
+	if settings.PromptExperiment == nil {
+		settings.PromptExperiment = &RawPromptExperimentConfig{}
+	}
+
+	// MCP servers (none; agents only get their built-in tools)
+	if settings.MCP == nil {
+		settings.MCP = &RawMCPConfig{}
+	}
+
+	// Change budget (off; implement turns may change any amount of code)
+	if settings.ChangeBudget == nil {
+		settings.ChangeBudget = &RawChangeBudgetConfig{}
+	}
+	if settings.ChangeBudget.MaxLines == nil {
+		v := 0
+		settings.ChangeBudget.MaxLines = &v
+	}
+	if settings.ChangeBudget.MaxFiles == nil {
+		v := 0
+		settings.ChangeBudget.MaxFiles = &v
+	}
+	if settings.ChangeBudget.OnExceed == nil {
+		v := "split"
+		settings.ChangeBudget.OnExceed = &v
+	}
+	if settings.ChangeBudget.SplitAttempts == nil {
+		v := 1
+		settings.ChangeBudget.SplitAttempts = &v
+	}
+
+	// Custom prompt template variables (none)
+	if settings.TemplateVars == nil {
+		settings.TemplateVars = &RawTemplateVarsConfig{}
+	}
+	if settings.TemplateVars.CommandTimeoutSec == nil {
+		v := 10
+		settings.TemplateVars.CommandTimeoutSec = &v
+	}
+
+	// Near-duplicate check (warn only)
+	if settings.DuplicateCheck == nil {
+		settings.DuplicateCheck = &RawDuplicateCheckConfig{}
+	}
+	if settings.DuplicateCheck.Mode == nil {
+		v := "warn"
+		settings.DuplicateCheck.Mode = &v
+	}
+	if settings.DuplicateCheck.Threshold == nil {
+		v := 0.8
+		settings.DuplicateCheck.Threshold = &v
+	}
+
+	// Context window pre-check (summarize prompts that do not fit)
+	if settings.ContextWindow == nil {
+		settings.ContextWindow = &RawContextWindowConfig{}
+	}
+	if settings.ContextWindow.Mode == nil {
+		v := "summarize"
+		settings.ContextWindow.Mode = &v
+	}
+
+	// Task ID scheme (ULIDs)
+	if settings.IDScheme == nil {
+		settings.IDScheme = &RawIDSchemeConfig{}
+	}
+	if settings.IDScheme.Strategy == nil {
+		v := "ulid"
+		settings.IDScheme.Strategy = &v
+	}
+	if settings.IDScheme.Digits == nil {
+		v := 4
+		settings.IDScheme.Digits = &v
+	}
+
+	// Database encryption (off; key from DEESPEC_DB_KEY)
+	if settings.DatabaseEncryption == nil {
+		settings.DatabaseEncryption = &RawDatabaseEncryptionConfig{}
+	}
+	if settings.DatabaseEncryption.Enabled == nil {
+		v := false
+		settings.DatabaseEncryption.Enabled = &v
+	}
+	if settings.DatabaseEncryption.KeySource == nil {
+		v := "env:DEESPEC_DB_KEY"
+		settings.DatabaseEncryption.KeySource = &v
+	}
+
+	// Read-only mode
+	if settings.ReadOnly == nil {
+		v := false
+		settings.ReadOnly = &v
+	}
 }
 
 // checkDeprecated warns about deprecated settings
@@ -304,6 +1236,312 @@ func buildAppConfig(settings *RawSettings, configSource, settingPath string) *co
 		MaxConcurrent: *settings.AgentPoolConfig.MaxConcurrent,
 	}
 
+	// Convert RawNotificationConfig to config.NotificationConfig
+	notificationConfig := config.NotificationConfig{
+		WebhookURL:               *settings.NotificationConfig.WebhookURL,
+		DeadlineCheckIntervalSec: *settings.NotificationConfig.DeadlineCheckIntervalSec,
+		EscalationHours:          *settings.NotificationConfig.EscalationHours,
+		Email: config.EmailNotificationConfig{
+			SMTPHost:            settings.NotificationConfig.Email.SMTPHost,
+			SMTPPort:            *settings.NotificationConfig.Email.SMTPPort,
+			TLS:                 *settings.NotificationConfig.Email.TLS,
+			Username:            settings.NotificationConfig.Email.Username,
+			PasswordEnv:         *settings.NotificationConfig.Email.PasswordEnv,
+			From:                settings.NotificationConfig.Email.From,
+			To:                  settings.NotificationConfig.Email.To,
+			DigestIntervalHours: *settings.NotificationConfig.Email.DigestIntervalHours,
+			TemplateDir:         settings.NotificationConfig.Email.TemplateDir,
+		},
+	}
+
+	// Convert RawArtifactStoreConfig to config.ArtifactStoreConfig
+	artifactStoreConfig := config.ArtifactStoreConfig{
+		Type:     *settings.ArtifactStore.Type,
+		Bucket:   *settings.ArtifactStore.Bucket,
+		Prefix:   *settings.ArtifactStore.Prefix,
+		Region:   *settings.ArtifactStore.Region,
+		Endpoint: *settings.ArtifactStore.Endpoint,
+	}
+
+	// Convert RawDecompositionConfig to config.DecompositionConfig
+	decompositionConfig := config.DecompositionConfig{
+		MinEstimatedHours:     *settings.Decomposition.MinEstimatedHours,
+		MaxEstimatedHours:     *settings.Decomposition.MaxEstimatedHours,
+		MaxAcceptanceCriteria: *settings.Decomposition.MaxAcceptanceCriteria,
+		MaxTouchedAreas:       *settings.Decomposition.MaxTouchedAreas,
+		RejectOversized:       *settings.Decomposition.RejectOversized,
+	}
+
+	// Convert RawDecisionExtractionConfig to config.DecisionExtractionConfig
+	decisionExtractionConfig := config.DecisionExtractionConfig{
+		Strategies: *settings.DecisionExtraction.Strategies,
+		Pattern:    *settings.DecisionExtraction.Pattern,
+	}
+
+	// Convert RawSchedulingConfig to config.SchedulingConfig
+	schedulingConfig := config.SchedulingConfig{
+		PBIWIPLimit:         *settings.Scheduling.PBIWIPLimit,
+		MaxTurnsPerPBI:      *settings.Scheduling.MaxTurnsPerPBI,
+		MaxAgentCallsPerDay: *settings.Scheduling.MaxAgentCallsPerDay,
+		ImplementPoolSize:   *settings.Scheduling.ImplementPoolSize,
+		ReviewPoolSize:      *settings.Scheduling.ReviewPoolSize,
+		Windows:             settings.Scheduling.Windows,
+		AgingIntervalHours:  *settings.Scheduling.AgingIntervalHours,
+		AgingMaxPriority:    *settings.Scheduling.AgingMaxPriority,
+	}
+
+	// Convert RawAgentSessionConfig to config.AgentSessionConfig
+	agentSessionConfig := config.AgentSessionConfig{
+		Enabled: *settings.AgentSession.Enabled,
+	}
+
+	// Convert RawTaskEnvConfig to config.TaskEnvConfig
+	taskEnvConfig := config.TaskEnvConfig{
+		Vars: settings.TaskEnv.Vars,
+	}
+
+	// Convert RawReviewQuorumConfig to config.ReviewQuorumConfig
+	reviewQuorumConfig := config.ReviewQuorumConfig{
+		Rule: *settings.ReviewQuorum.Rule,
+	}
+	for _, r := range settings.ReviewQuorum.Reviewers {
+		reviewQuorumConfig.Reviewers = append(reviewQuorumConfig.Reviewers, config.ReviewerConfig{
+			Name:  r.Name,
+			Agent: r.Agent,
+			Model: r.Model,
+		})
+	}
+
+	// Convert RawSelfReviewGuardConfig to config.SelfReviewGuardConfig
+	selfReviewGuardConfig := config.SelfReviewGuardConfig{
+		Enabled: *settings.SelfReviewGuard.Enabled,
+	}
+	for _, a := range settings.SelfReviewGuard.Alternates {
+		selfReviewGuardConfig.Alternates = append(selfReviewGuardConfig.Alternates, config.AgentRouteConfig{
+			Agent: a.Agent,
+			Model: a.Model,
+		})
+	}
+
+	// Convert RawJournalConfig to config.JournalConfig
+	journalConfig := config.JournalConfig{
+		RotateMaxMB:       *settings.Journal.RotateMaxMB,
+		RotateMaxAgeHours: *settings.Journal.RotateMaxAgeHours,
+		KeepFullSegments:  *settings.Journal.KeepFullSegments,
+		SinkBufferSize:    *settings.Journal.SinkBufferSize,
+	}
+	for _, sink := range settings.Journal.Sinks {
+		sinkConfig := config.JournalSinkConfig{
+			Type:    sink.Type,
+			Path:    sink.Path,
+			URL:     sink.URL,
+			Headers: sink.Headers,
+		}
+		if sink.TimeoutSec != nil {
+			sinkConfig.TimeoutSec = *sink.TimeoutSec
+		}
+		journalConfig.Sinks = append(journalConfig.Sinks, sinkConfig)
+	}
+
+	// Convert RawSpecPreflightConfig to config.SpecPreflightConfig
+	specPreflightConfig := config.SpecPreflightConfig{
+		Mode:   *settings.SpecPreflight.Mode,
+		Ignore: settings.SpecPreflight.Ignore,
+	}
+
+	// Convert RawReviewTimeoutConfig to config.ReviewTimeoutConfig
+	reviewTimeoutConfig := config.ReviewTimeoutConfig{
+		TimeoutSec: *settings.ReviewTimeout.TimeoutSec,
+		Policy:     *settings.ReviewTimeout.Policy,
+	}
+
+	// Convert RawOwnershipContextConfig to config.OwnershipContextConfig
+	ownershipContextConfig := config.OwnershipContextConfig{
+		Enabled:    *settings.OwnershipContext.Enabled,
+		MaxFiles:   *settings.OwnershipContext.MaxFiles,
+		MaxCommits: *settings.OwnershipContext.MaxCommits,
+	}
+
+	// Convert RawReviewContextConfig to config.ReviewContextConfig
+	reviewContextConfig := config.ReviewContextConfig{
+		Enabled:        *settings.ReviewContext.Enabled,
+		MaxDiffKB:      *settings.ReviewContext.MaxDiffKB,
+		TestCommand:    *settings.ReviewContext.TestCommand,
+		TestTimeoutSec: *settings.ReviewContext.TestTimeoutSec,
+	}
+
+	// Convert RawAgentAPIConfig to config.AgentAPIConfig
+	// Unset provider limits keep the provider defaults; retries default to 3
+	agentAPIConfig := config.AgentAPIConfig{
+		DefaultAgent: *settings.AgentAPI.DefaultAgent,
+		Providers:    make(map[string]config.AgentAPIProviderConfig),
+	}
+	for name, p := range settings.AgentAPI.Providers {
+		provider := config.AgentAPIProviderConfig{
+			BaseURL:    p.BaseURL,
+			APIKeyEnv:  p.APIKeyEnv,
+			Model:      p.Model,
+			MaxRetries: 3,
+		}
+		if p.MaxTokens != nil {
+			provider.MaxTokens = *p.MaxTokens
+		}
+		if p.MaxRetries != nil {
+			provider.MaxRetries = *p.MaxRetries
+		}
+		if p.TimeoutSec != nil {
+			provider.TimeoutSec = *p.TimeoutSec
+		}
+		agentAPIConfig.Providers[name] = provider
+	}
+
+	// Convert RawOllamaConfig to config.OllamaConfig
+	ollamaConfig := config.OllamaConfig{
+		BaseURL:         *settings.Ollama.BaseURL,
+		Model:           *settings.Ollama.Model,
+		ContextWindow:   *settings.Ollama.ContextWindow,
+		MaxOutputTokens: *settings.Ollama.MaxOutputTokens,
+		Overflow:        *settings.Ollama.Overflow,
+		TimeoutSec:      *settings.Ollama.TimeoutSec,
+	}
+
+	// Convert RawAgentCacheConfig to config.AgentCacheConfig
+	agentCacheConfig := config.AgentCacheConfig{
+		Enabled:  *settings.AgentCache.Enabled,
+		Steps:    settings.AgentCache.Steps,
+		TTLHours: *settings.AgentCache.TTLHours,
+	}
+
+	// Convert RawFailurePolicyConfig to config.FailurePolicyConfig
+	failurePolicyConfig := config.FailurePolicyConfig{
+		OnLimit:    *settings.FailurePolicy.OnLimit,
+		Postmortem: *settings.FailurePolicy.Postmortem,
+	}
+
+	// Convert RawArtifactRetryConfig to config.ArtifactRetryConfig
+	artifactRetryConfig := config.ArtifactRetryConfig{
+		Attempts: *settings.ArtifactRetry.Attempts,
+	}
+
+	// Convert RawTrashConfig to config.TrashConfig
+	trashConfig := config.TrashConfig{
+		RetentionDays: *settings.Trash.RetentionDays,
+	}
+
+	// Convert RawPromptArchiveConfig to config.PromptArchiveConfig
+	promptArchiveConfig := config.PromptArchiveConfig{
+		Enabled:       *settings.PromptArchive.Enabled,
+		Compress:      *settings.PromptArchive.Compress,
+		RetentionDays: *settings.PromptArchive.RetentionDays,
+	}
+
+	// Convert RawBackupConfig to config.BackupConfig
+	backupConfig := config.BackupConfig{
+		Enabled:       *settings.Backup.Enabled,
+		IntervalHours: *settings.Backup.IntervalHours,
+		Keep:          *settings.Backup.Keep,
+		Upload:        *settings.Backup.Upload,
+	}
+
+	// Convert RawTurnTimeBoxConfig to config.TurnTimeBoxConfig
+	turnTimeBoxConfig := config.TurnTimeBoxConfig{
+		Minutes:           *settings.TurnTimeBox.Minutes,
+		CheckpointMinutes: *settings.TurnTimeBox.CheckpointMinutes,
+	}
+
+	// Convert RawModelPolicyConfig to config.ModelPolicyConfig
+	modelPolicyConfig := config.ModelPolicyConfig{}
+	for _, rule := range settings.ModelPolicy.Rules {
+		modelPolicyConfig.Rules = append(modelPolicyConfig.Rules, config.ModelPolicyRuleConfig{
+			Step:       rule.Step,
+			Family:     rule.Family,
+			MinVersion: rule.MinVersion,
+		})
+	}
+
+	// Convert RawAgentRoutingConfig to config.AgentRoutingConfig
+	agentRoutingConfig := config.AgentRoutingConfig{}
+	for _, rule := range settings.AgentRouting.Rules {
+		agentRoutingConfig.Rules = append(agentRoutingConfig.Rules, config.AgentRoutingRuleConfig{
+			Name:      rule.Name,
+			Steps:     rule.Steps,
+			Labels:    rule.Labels,
+			MinHours:  rule.MinHours,
+			MaxHours:  rule.MaxHours,
+			FileTypes: rule.FileTypes,
+			Agent:     rule.Agent,
+			Model:     rule.Model,
+		})
+	}
+
+	// Convert RawPromptExperimentConfig to config.PromptExperimentConfig
+	promptExperimentConfig := config.PromptExperimentConfig{
+		Enabled:  settings.PromptExperiment.Enabled,
+		Name:     settings.PromptExperiment.Name,
+		Template: settings.PromptExperiment.Template,
+		Variant:  settings.PromptExperiment.Variant,
+	}
+
+	// Convert RawMCPConfig to config.MCPConfig
+	mcpConfig := config.MCPConfig{}
+	if len(settings.MCP.Servers) > 0 {
+		mcpConfig.Servers = make(map[string]config.MCPServerConfig, len(settings.MCP.Servers))
+		for name, server := range settings.MCP.Servers {
+			mcpConfig.Servers[name] = config.MCPServerConfig{
+				Type:    server.Type,
+				Command: server.Command,
+				Args:    server.Args,
+				Env:     server.Env,
+				URL:     server.URL,
+				Headers: server.Headers,
+			}
+		}
+	}
+
+	// Convert RawChangeBudgetConfig to config.ChangeBudgetConfig
+	changeBudgetConfig := config.ChangeBudgetConfig{
+		MaxLines:      *settings.ChangeBudget.MaxLines,
+		MaxFiles:      *settings.ChangeBudget.MaxFiles,
+		OnExceed:      *settings.ChangeBudget.OnExceed,
+		SplitAttempts: *settings.ChangeBudget.SplitAttempts,
+	}
+
+	// Convert RawTemplateVarsConfig to config.TemplateVarsConfig
+	templateVarsConfig := config.TemplateVarsConfig{
+		CommandTimeoutSec: *settings.TemplateVars.CommandTimeoutSec,
+	}
+	if len(settings.TemplateVars.Vars) > 0 {
+		templateVarsConfig.Vars = make(map[string]config.TemplateVarConfig, len(settings.TemplateVars.Vars))
+		for name, v := range settings.TemplateVars.Vars {
+			templateVarsConfig.Vars[name] = config.TemplateVarConfig{Value: v.Value, Command: v.Command}
+		}
+	}
+
+	// Convert RawDuplicateCheckConfig to config.DuplicateCheckConfig
+	duplicateCheckConfig := config.DuplicateCheckConfig{
+		Mode:      *settings.DuplicateCheck.Mode,
+		Threshold: *settings.DuplicateCheck.Threshold,
+	}
+
+	// Convert RawContextWindowConfig to config.ContextWindowConfig
+	contextWindowConfig := config.ContextWindowConfig{
+		Mode:   *settings.ContextWindow.Mode,
+		Models: settings.ContextWindow.Models,
+	}
+
+	// Convert RawIDSchemeConfig to config.IDSchemeConfig
+	idSchemeConfig := config.IDSchemeConfig{
+		Strategy: *settings.IDScheme.Strategy,
+		Prefixes: settings.IDScheme.Prefixes,
+		Digits:   *settings.IDScheme.Digits,
+	}
+
+	// Convert RawDatabaseEncryptionConfig to config.DatabaseEncryptionConfig
+	databaseEncryptionConfig := config.DatabaseEncryptionConfig{
+		Enabled:   *settings.DatabaseEncryption.Enabled,
+		KeySource: *settings.DatabaseEncryption.KeySource,
+	}
+
 	return config.NewAppConfig(
 		*settings.Home,
 		*settings.AgentBin,
@@ -327,8 +1565,45 @@ func buildAppConfig(settings *RawSettings, configSource, settingPath string) *co
 		*settings.Workflow,
 		*settings.PolicyPath,
 		*settings.StderrLevel,
+		*settings.Locale,
+		*settings.Aliases,
+		*settings.StatusLabels,
 		labelConfig,
 		agentPoolConfig,
+		notificationConfig,
+		artifactStoreConfig,
+		decompositionConfig,
+		decisionExtractionConfig,
+		schedulingConfig,
+		agentSessionConfig,
+		taskEnvConfig,
+		reviewQuorumConfig,
+		selfReviewGuardConfig,
+		journalConfig,
+		specPreflightConfig,
+		reviewTimeoutConfig,
+		ownershipContextConfig,
+		reviewContextConfig,
+		agentAPIConfig,
+		ollamaConfig,
+		agentCacheConfig,
+		failurePolicyConfig,
+		artifactRetryConfig,
+		trashConfig,
+		promptArchiveConfig,
+		backupConfig,
+		turnTimeBoxConfig,
+		modelPolicyConfig,
+		agentRoutingConfig,
+		promptExperimentConfig,
+		mcpConfig,
+		changeBudgetConfig,
+		templateVarsConfig,
+		duplicateCheckConfig,
+		contextWindowConfig,
+		idSchemeConfig,
+		databaseEncryptionConfig,
+		*settings.ReadOnly,
 		configSource,
 		settingPath,
 	)
@@ -342,7 +1617,13 @@ func toBool(s string) bool {
 
 // CreateDefaultSettings creates a default setting.json content
 func CreateDefaultSettings() []byte {
-	settings := &RawSettings{}
+	return CreateDefaultSettingsForLocale(locale.Default)
+}
+
+// CreateDefaultSettingsForLocale creates a default setting.json content with the given locale
+func CreateDefaultSettingsForLocale(loc locale.Locale) []byte {
+	v := loc.String()
+	settings := &RawSettings{Locale: &v}
 	applyDefaults(settings)
 
 	data, _ := json.MarshalIndent(settings, "", "  ")
@@ -362,3 +362,42 @@ func hasSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLoadSettings_Locale(t *testing.T) {
+	tests := []struct {
+		name       string
+		json       string
+		wantLocale string
+		wantErr    bool
+	}{
+		{name: "default", json: "", wantLocale: "ja"},
+		{name: "english", json: `{"locale": "en"}`, wantLocale: "en"},
+		{name: "region qualified", json: `{"locale": "en_US"}`, wantLocale: "en"},
+		{name: "unsupported", json: `{"locale": "fr"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if tt.json != "" {
+				if err := os.WriteFile(filepath.Join(tmpDir, "setting.json"), []byte(tt.json), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := LoadSettings(tmpDir)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadSettings() expected error for unsupported locale")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSettings() error = %v", err)
+			}
+			if got := cfg.Locale(); got != tt.wantLocale {
+				t.Errorf("Locale() = %v, want %v", got, tt.wantLocale)
+			}
+		})
+	}
+}
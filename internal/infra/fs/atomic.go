@@ -1,8 +1,11 @@
 package fs
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 )
@@ -10,47 +13,11 @@ import (
 // AtomicWriteJSON writes JSON atomically with fsync(file) and fsync(parent dir),
 // using a unique temporary file in the same directory to avoid collisions.
 func AtomicWriteJSON(path string, v any) error {
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return err
-	}
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
-
-	// Create unique temp file in same directory
-	tf, err := os.CreateTemp(dir, ".tmp.*")
-	if err != nil {
-		return fmt.Errorf("atomic json: create temp: %w", err)
-	}
-	tmp := tf.Name()
-	// Ensure cleanup in error cases (harmless if renamed)
-	defer os.Remove(tmp)
-
-	// Set desired permission (CreateTemp defaults 0600)
-	if err := os.Chmod(tmp, 0o644); err != nil {
-		tf.Close()
-		return fmt.Errorf("atomic json: chmod temp: %w", err)
-	}
-
-	if _, err := tf.Write(data); err != nil {
-		tf.Close()
-		return fmt.Errorf("atomic json: write temp: %w", err)
-	}
-	if err := FsyncFile(tf); err != nil {
-		tf.Close()
-		return fmt.Errorf("atomic json: fsync temp: %w", err)
-	}
-	if err := tf.Close(); err != nil {
-		return fmt.Errorf("atomic json: close temp: %w", err)
-	}
-
-	// Atomic rename to destination and fsync parent dir
-	if err := AtomicRename(tmp, path); err != nil {
-		return err
-	}
-	return nil
+	return WriteFileAtomic(path, data, 0o644)
 }
 
 // すでに存在したら失敗する簡易ロック（多重実行防止）
@@ -80,17 +47,31 @@ func AcquireLock(lockPath string) (release func() error, err error) {
 // - Returns error if file operations fail
 // - Lock is always released even if write fails
 func AppendNDJSONLine(path string, record interface{}) error {
+	_, err := AppendNDJSONLineIfAbsent(path, record, nil)
+	return err
+}
+
+// dedupScanWindow bounds how many trailing bytes AppendNDJSONLineIfAbsent scans
+// Replays happen right after the original append, so the tail is enough and appends
+// stay O(1) as the file grows
+var dedupScanWindow int64 = 1 << 20
+
+// AppendNDJSONLineIfAbsent appends record like AppendNDJSONLine unless exists reports
+// true for a line in the last dedupScanWindow bytes of the file. The lines are scanned
+// while holding the exclusive lock, so concurrent writers cannot both append the same record.
+// A nil exists appends unconditionally. Returns whether the record was appended.
+func AppendNDJSONLineIfAbsent(path string, record interface{}, exists func(line []byte) bool) (bool, error) {
 	// Ensure parent directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("append ndjson: failed to create directory: %w", err)
+		return false, fmt.Errorf("append ndjson: failed to create directory: %w", err)
 	}
 
 	// Open file in append mode (create if not exists)
 	// O_APPEND ensures atomic writes at end of file on POSIX systems
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
 	if err != nil {
-		return fmt.Errorf("append ndjson: failed to open file: %w", err)
+		return false, fmt.Errorf("append ndjson: failed to open file: %w", err)
 	}
 	defer f.Close()
 
@@ -98,15 +79,34 @@ func AppendNDJSONLine(path string, record interface{}) error {
 	// This prevents concurrent writes from corrupting the file
 	// LOCK_EX = exclusive lock (no other process can hold any lock)
 	if err := flockExclusive(f); err != nil {
-		return fmt.Errorf("append ndjson: failed to acquire file lock: %w", err)
+		return false, fmt.Errorf("append ndjson: failed to acquire file lock: %w", err)
 	}
 	// Release lock when function returns (defer ensures this happens)
 	defer flockUnlock(f)
 
+	if exists != nil {
+		reader, err := tailReader(f, dedupScanWindow)
+		if err != nil {
+			return false, fmt.Errorf("append ndjson: failed to read file: %w", err)
+		}
+		for {
+			line, readErr := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 && exists(line) {
+				return false, nil
+			}
+			if readErr == io.EOF {
+				break
+			}
+			if readErr != nil {
+				return false, fmt.Errorf("append ndjson: failed to read file: %w", readErr)
+			}
+		}
+	}
+
 	// Marshal record to JSON (single line, no indentation for NDJSON)
 	jsonBytes, err := json.Marshal(record)
 	if err != nil {
-		return fmt.Errorf("append ndjson: failed to marshal record: %w", err)
+		return false, fmt.Errorf("append ndjson: failed to marshal record: %w", err)
 	}
 
 	// Append newline to create NDJSON format (one JSON object per line)
@@ -115,14 +115,120 @@ func AppendNDJSONLine(path string, record interface{}) error {
 	// Write the complete line atomically
 	// O_APPEND flag ensures this write goes to end of file atomically
 	if _, err := f.Write(line); err != nil {
-		return fmt.Errorf("append ndjson: failed to write line: %w", err)
+		return false, fmt.Errorf("append ndjson: failed to write line: %w", err)
 	}
 
 	// fsync ensures data is written to persistent storage
 	// This guarantees durability even if system crashes after this call
 	if err := FsyncFile(f); err != nil {
-		return fmt.Errorf("append ndjson: failed to sync file: %w", err)
+		return false, fmt.Errorf("append ndjson: failed to sync file: %w", err)
+	}
+
+	return true, nil
+}
+
+// tailReader returns a reader positioned at the first complete line within the last
+// window bytes of f
+func tailReader(f *os.File, window int64) (*bufio.Reader, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() <= window {
+		return bufio.NewReader(f), nil
+	}
+
+	// Start one byte early so a window beginning exactly at a line start is not skipped
+	if _, err := f.Seek(info.Size()-window-1, io.SeekStart); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(f)
+	if _, err := reader.ReadBytes('\n'); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// RewriteNDJSONLines rewrites the lines of an NDJSON file in place while holding the
+// same exclusive lock as AppendNDJSONLine, so appends made during the rewrite wait for
+// it instead of being lost. rewrite returns the replacement for a line (without the
+// trailing newline) and whether it changed. The file is only written when at least one
+// line changed; a copy of the original is kept at backupPath first unless it is empty.
+// Returns the number of changed lines. A missing file has nothing to rewrite.
+func RewriteNDJSONLines(path, backupPath string, rewrite func(line []byte) ([]byte, bool)) (int, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0o644)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("rewrite ndjson: failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	if err := flockExclusive(f); err != nil {
+		return 0, fmt.Errorf("rewrite ndjson: failed to acquire file lock: %w", err)
+	}
+	defer flockUnlock(f)
+
+	original, err := io.ReadAll(f)
+	if err != nil {
+		return 0, fmt.Errorf("rewrite ndjson: failed to read file: %w", err)
+	}
+
+	var out bytes.Buffer
+	changed := 0
+	for _, line := range bytes.SplitAfter(original, []byte("\n")) {
+		content := bytes.TrimRight(line, "\n")
+		if len(bytes.TrimSpace(content)) > 0 {
+			if replaced, ok := rewrite(content); ok {
+				content = replaced
+				changed++
+			}
+		}
+		out.Write(content)
+		if bytes.HasSuffix(line, []byte("\n")) {
+			out.WriteByte('\n')
+		}
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+
+	if backupPath != "" {
+		if err := WriteFileSync(backupPath, original, 0o644); err != nil {
+			return 0, fmt.Errorf("rewrite ndjson: failed to write backup: %w", err)
+		}
+	}
+	if err := f.Truncate(0); err != nil {
+		return 0, fmt.Errorf("rewrite ndjson: failed to truncate file: %w", err)
+	}
+	if _, err := f.WriteAt(out.Bytes(), 0); err != nil {
+		return 0, fmt.Errorf("rewrite ndjson: failed to write file: %w", err)
+	}
+	if err := FsyncFile(f); err != nil {
+		return 0, fmt.Errorf("rewrite ndjson: failed to sync file: %w", err)
 	}
+	return changed, nil
+}
+
+// WithExclusiveLock runs fn while holding the exclusive lock AppendNDJSONLine takes on
+// path, so appends wait until fn returns. The file is created if missing and passed to
+// fn opened for reading and writing. fn must keep the file in place (truncate rather
+// than replace it): appenders blocked on the lock hold the same inode.
+func WithExclusiveLock(path string, fn func(f *os.File) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("lock %s: failed to create directory: %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("lock %s: failed to open file: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := flockExclusive(f); err != nil {
+		return fmt.Errorf("lock %s: failed to acquire file lock: %w", path, err)
+	}
+	defer flockUnlock(f)
 
-	return nil
+	return fn(f)
 }
@@ -0,0 +1,111 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// atomicTempPrefix starts the names of the temporary files AtomicFile writes
+const atomicTempPrefix = ".tmp."
+
+// AtomicFile is a file that is replaced in full or not at all
+// Writes go to a temporary file in the destination's directory, which Commit syncs and renames
+// over the destination. A crash, kill or signal before Commit leaves the destination as it was
+// (at worst a stray temporary file is left behind); readers never see a truncated file.
+type AtomicFile struct {
+	file *os.File
+	path string
+	done bool
+}
+
+// CreateAtomic starts writing the file at path with the permission perm (0 = 0644)
+// The parent directory is created if missing. Callers must Commit or Abort the file;
+// deferring Abort is safe, as it does nothing after Commit.
+func CreateAtomic(path string, perm os.FileMode) (*AtomicFile, error) {
+	if path == "" {
+		return nil, fmt.Errorf("atomic write: path is empty")
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("atomic write %s: failed to create parent dir: %w", path, err)
+	}
+
+	// Keep the temp file in the same directory so that the rename stays on one filesystem
+	tf, err := os.CreateTemp(dir, atomicTempPrefix+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("atomic write %s: failed to create temp file: %w", path, err)
+	}
+	if perm == 0 {
+		perm = 0644
+	}
+	// CreateTemp creates 0600 files
+	if err := tf.Chmod(perm); err != nil {
+		tf.Close()
+		os.Remove(tf.Name())
+		return nil, fmt.Errorf("atomic write %s: failed to set temp perm: %w", path, err)
+	}
+	return &AtomicFile{file: tf, path: path}, nil
+}
+
+// Write writes to the temporary file
+func (f *AtomicFile) Write(p []byte) (int, error) {
+	return f.file.Write(p)
+}
+
+// Path returns the destination path
+func (f *AtomicFile) Path() string {
+	return f.path
+}
+
+// Commit syncs the written data and renames it over the destination
+// On failure the temporary file is removed and the destination is left as it was.
+func (f *AtomicFile) Commit() error {
+	if f.done {
+		return fmt.Errorf("atomic write %s: already committed or aborted", f.path)
+	}
+	f.done = true
+	tmp := f.file.Name()
+
+	if err := FsyncFile(f.file); err != nil {
+		f.file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("atomic write %s: failed to sync file: %w", f.path, err)
+	}
+	if err := f.file.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("atomic write %s: failed to close file: %w", f.path, err)
+	}
+	if err := AtomicRename(tmp, f.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("atomic write %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Abort discards the written data, leaving the destination as it was
+func (f *AtomicFile) Abort() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+	f.file.Close()
+	if err := os.Remove(f.file.Name()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("atomic write %s: failed to remove temp file: %w", f.path, err)
+	}
+	return nil
+}
+
+// WriteFileAtomic writes data to the file at path through an AtomicFile
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := CreateAtomic(path, perm)
+	if err != nil {
+		return err
+	}
+	defer f.Abort()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("atomic write %s: failed to write data: %w", path, err)
+	}
+	return f.Commit()
+}
@@ -0,0 +1,244 @@
+package fs
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// atomicHelperEnv makes the test binary run TestAtomicFileHelperProcess as a writer to be killed
+const atomicHelperEnv = "DEESPEC_ATOMIC_HELPER"
+
+// atomicHelperSize is the size of every file the helper writes
+const atomicHelperSize = 256 * 1024
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "artifact.md")
+
+	if err := WriteFileAtomic(path, []byte("first"), 0640); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("second"), 0640); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Content = %q, want %q", data, "second")
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Failed to stat file: %v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Errorf("Mode = %v, want 0640", info.Mode().Perm())
+		}
+	}
+	assertNoTempFiles(t, filepath.Dir(path))
+}
+
+func TestAtomicFile_AbortKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "approval.yaml")
+	if err := os.WriteFile(path, []byte("original"), 0644); err != nil {
+		t.Fatalf("Failed to write original: %v", err)
+	}
+
+	f, err := CreateAtomic(path, 0644)
+	if err != nil {
+		t.Fatalf("CreateAtomic failed: %v", err)
+	}
+	if _, err := f.Write([]byte("half-writ")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// The destination is untouched while the write is in progress
+	assertContent(t, path, "original")
+
+	// Interrupted write: the caller gives up before committing
+	if err := f.Abort(); err != nil {
+		t.Fatalf("Abort failed: %v", err)
+	}
+	assertContent(t, path, "original")
+	assertNoTempFiles(t, dir)
+
+	if err := f.Commit(); err == nil {
+		t.Error("Commit after Abort should fail")
+	}
+}
+
+func TestAtomicFile_AbortAfterCommitIsNoop(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.json")
+
+	f, err := CreateAtomic(path, 0)
+	if err != nil {
+		t.Fatalf("CreateAtomic failed: %v", err)
+	}
+	if _, err := f.Write([]byte("{}")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := f.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := f.Abort(); err != nil {
+		t.Errorf("Abort after Commit failed: %v", err)
+	}
+	assertContent(t, path, "{}")
+	assertNoTempFiles(t, dir)
+}
+
+func TestAtomicFile_FailedCommitCleansUp(t *testing.T) {
+	dir := t.TempDir()
+	// A non-empty directory cannot be replaced by a file
+	path := filepath.Join(dir, "occupied")
+	if err := os.MkdirAll(filepath.Join(path, "child"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	err := WriteFileAtomic(path, []byte("data"), 0644)
+	if err == nil {
+		t.Fatal("WriteFileAtomic over a directory should fail")
+	}
+	if !strings.Contains(err.Error(), path) {
+		t.Errorf("Error should name the destination: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		t.Errorf("Destination should be left as it was")
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestAtomicFile_EmptyPath(t *testing.T) {
+	if _, err := CreateAtomic("", 0644); err == nil {
+		t.Error("CreateAtomic with an empty path should fail")
+	}
+}
+
+// TestAtomicFile_KilledWriter kills a process that keeps rewriting a file and checks that the file
+// always holds a complete version
+func TestAtomicFile_KilledWriter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Process kill simulation is not supported on Windows")
+	}
+	if testing.Short() {
+		t.Skip("Skipping process kill simulation in short mode")
+	}
+
+	for _, mode := range []string{"uncommitted", "rewrite"} {
+		t.Run(mode, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "artifact.md")
+			original := bytes.Repeat([]byte{'o'}, atomicHelperSize)
+			if err := os.WriteFile(path, original, 0644); err != nil {
+				t.Fatalf("Failed to write original: %v", err)
+			}
+
+			cmd := exec.Command(os.Args[0], "-test.run=^TestAtomicFileHelperProcess$")
+			cmd.Env = append(os.Environ(), atomicHelperEnv+"="+mode, "DEESPEC_ATOMIC_PATH="+path)
+			stdout, err := cmd.StdoutPipe()
+			if err != nil {
+				t.Fatalf("Failed to get helper stdout: %v", err)
+			}
+			if err := cmd.Start(); err != nil {
+				t.Fatalf("Failed to start helper: %v", err)
+			}
+
+			// Wait until the helper is in the middle of writing, then kill it without warning
+			if _, err := bufio.NewReader(stdout).ReadString('\n'); err != nil {
+				cmd.Process.Kill()
+				cmd.Wait()
+				t.Fatalf("Helper did not start writing: %v", err)
+			}
+			time.Sleep(50 * time.Millisecond)
+			if err := cmd.Process.Kill(); err != nil {
+				t.Fatalf("Failed to kill helper: %v", err)
+			}
+			cmd.Wait()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read file after kill: %v", err)
+			}
+			if len(data) != atomicHelperSize {
+				t.Fatalf("File is truncated after kill: %d bytes, want %d", len(data), atomicHelperSize)
+			}
+			if !bytes.Equal(data, bytes.Repeat(data[:1], atomicHelperSize)) {
+				t.Fatal("File mixes two versions after kill")
+			}
+			if mode == "uncommitted" && data[0] != 'o' {
+				t.Errorf("Uncommitted write replaced the file")
+			}
+		})
+	}
+}
+
+// TestAtomicFileHelperProcess is the writer killed by TestAtomicFile_KilledWriter
+func TestAtomicFileHelperProcess(t *testing.T) {
+	mode := os.Getenv(atomicHelperEnv)
+	if mode == "" {
+		t.Skip("Helper process only")
+	}
+	path := os.Getenv("DEESPEC_ATOMIC_PATH")
+
+	chunk := atomicHelperSize / 8
+	for i := 0; ; i++ {
+		f, err := CreateAtomic(path, 0644)
+		if err != nil {
+			os.Exit(2)
+		}
+		version := byte('a' + i%26)
+		for written := 0; written < atomicHelperSize; written += chunk {
+			if _, err := f.Write(bytes.Repeat([]byte{version}, chunk)); err != nil {
+				os.Exit(2)
+			}
+			if i == 0 && written == 0 {
+				os.Stdout.WriteString("writing\n")
+			}
+			if mode == "uncommitted" {
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+		if mode == "uncommitted" {
+			time.Sleep(time.Hour) // Never commits; waits to be killed
+		}
+		if err := f.Commit(); err != nil {
+			os.Exit(2)
+		}
+	}
+}
+
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if string(data) != want {
+		t.Errorf("Content = %q, want %q", data, want)
+	}
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), atomicTempPrefix) {
+			t.Errorf("Temp file left behind: %s", entry.Name())
+		}
+	}
+}
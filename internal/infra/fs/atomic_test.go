@@ -2,6 +2,7 @@ package fs
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -271,6 +272,106 @@ func TestAppendNDJSONLine_NestedStructures(t *testing.T) {
 	}
 }
 
+func TestAppendNDJSONLineIfAbsent_ScansTailOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "journal.ndjson")
+
+	defer func(window int64) { dedupScanWindow = window }(dedupScanWindow)
+	dedupScanWindow = 64
+
+	old := map[string]interface{}{"id": "old", "pad": "xxxxxxxxxxxxxxxxxxxx"}
+	recent := map[string]interface{}{"id": "recent"}
+	for _, record := range []map[string]interface{}{old, old, recent} {
+		if err := AppendNDJSONLine(path, record); err != nil {
+			t.Fatalf("AppendNDJSONLine failed: %v", err)
+		}
+	}
+
+	var scanned []string
+	existsID := func(id string) func(line []byte) bool {
+		return func(line []byte) bool {
+			var record map[string]interface{}
+			if err := json.Unmarshal(line, &record); err != nil {
+				t.Fatalf("Scanned a partial line: %q", line)
+			}
+			scanned = append(scanned, record["id"].(string))
+			return record["id"] == id
+		}
+	}
+
+	// A replay of the latest record is found in the tail
+	appended, err := AppendNDJSONLineIfAbsent(path, recent, existsID("recent"))
+	if err != nil {
+		t.Fatalf("AppendNDJSONLineIfAbsent failed: %v", err)
+	}
+	if appended {
+		t.Error("Expected replay of the latest record to be skipped")
+	}
+
+	// Lines before the window are not scanned
+	scanned = nil
+	if _, err := AppendNDJSONLineIfAbsent(path, map[string]interface{}{"id": "new"}, existsID("new")); err != nil {
+		t.Fatalf("AppendNDJSONLineIfAbsent failed: %v", err)
+	}
+	if len(scanned) != 2 || scanned[0] != "old" || scanned[1] != "recent" {
+		t.Errorf("Expected only the last two lines to be scanned, got %v", scanned)
+	}
+}
+
+func TestRewriteNDJSONLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "test.ndjson")
+	backupPath := jsonPath + ".bak"
+
+	original := "{\"path\":\"old/a.md\"}\n\n{\"path\":\"new/b.md\"}\n"
+	if err := os.WriteFile(jsonPath, []byte(original), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	rewrite := func(line []byte) ([]byte, bool) {
+		if !bytes.Contains(line, []byte("old/")) {
+			return line, false
+		}
+		return bytes.ReplaceAll(line, []byte("old/"), []byte("new/")), true
+	}
+
+	changed, err := RewriteNDJSONLines(jsonPath, backupPath, rewrite)
+	if err != nil {
+		t.Fatalf("RewriteNDJSONLines failed: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("Expected 1 changed line, got %d", changed)
+	}
+
+	data, _ := os.ReadFile(jsonPath)
+	if want := "{\"path\":\"new/a.md\"}\n\n{\"path\":\"new/b.md\"}\n"; string(data) != want {
+		t.Errorf("Unexpected content:\n%s", data)
+	}
+	backup, _ := os.ReadFile(backupPath)
+	if string(backup) != original {
+		t.Errorf("Backup does not hold the original content:\n%s", backup)
+	}
+
+	// Appends after the rewrite land at the end of the rewritten file
+	if err := AppendNDJSONLine(jsonPath, map[string]string{"path": "new/c.md"}); err != nil {
+		t.Fatalf("AppendNDJSONLine failed: %v", err)
+	}
+	data, _ = os.ReadFile(jsonPath)
+	if !bytes.HasSuffix(data, []byte("{\"path\":\"new/c.md\"}\n")) {
+		t.Errorf("Append after rewrite was not kept:\n%s", data)
+	}
+
+	// Nothing left to rewrite: the file and backup are left alone
+	os.Remove(backupPath)
+	changed, err = RewriteNDJSONLines(jsonPath, backupPath, rewrite)
+	if err != nil || changed != 0 {
+		t.Errorf("Expected no changes, got %d (err: %v)", changed, err)
+	}
+	if _, err := os.Stat(backupPath); !os.IsNotExist(err) {
+		t.Errorf("Backup should not be written when nothing changed")
+	}
+}
+
 func TestAtomicWriteJSON(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "atomic_test")
 	if err != nil {
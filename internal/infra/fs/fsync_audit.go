@@ -135,40 +135,8 @@ func WriteFileSync(path string, data []byte, perm os.FileMode) error {
 		GetLogger().Debug("AUDIT: File write with sync completed fsync.write.file.sync=true path=%s size=%d\n", path, len(data))
 	}
 
-	// Create temp file
-	dir := filepath.Dir(path)
-	tempFile, err := os.CreateTemp(dir, ".tmp-*")
-	if err != nil {
-		return err
-	}
-	tempPath := tempFile.Name()
-	defer os.Remove(tempPath)
-
-	// Write data
-	if _, err := tempFile.Write(data); err != nil {
-		tempFile.Close()
-		return err
-	}
-
-	// Sync file
-	if err := FsyncFile(tempFile); err != nil {
-		tempFile.Close()
-		return err
-	}
-	tempFile.Close()
-
-	// Set permissions
-	if err := os.Chmod(tempPath, perm); err != nil {
-		return err
-	}
-
-	// Atomic rename
-	if err := os.Rename(tempPath, path); err != nil {
-		return err
-	}
-
-	// Sync directory
-	return FsyncDir(dir)
+	// Temp file, fsync(file), rename and fsync(dir) through the audited functions
+	return WriteFileAtomic(path, data, perm)
 }
 
 // PrintFsyncReport prints a summary of fsync calls
@@ -96,65 +96,10 @@ func AtomicRename(src, dst string) error {
 }
 
 // WriteFileSync writes data to a file and ensures it is synced to disk.
-// This is a convenience function that combines write, fsync(file), and fsync(parent dir).
-// The temporary file is created in the same directory as the destination for same-FS guarantee.
+// This is a convenience function that combines write, fsync(file), and fsync(parent dir)
+// through an AtomicFile, whose temporary file is in the destination's directory for same-FS guarantee.
 // Default permission is 0644 if not specified (subject to umask).
 // According to ARCHITECTURE.md Section 3.3: both file and parent dir must be synced.
 func WriteFileSync(path string, data []byte, perm os.FileMode) error {
-	if path == "" {
-		return fmt.Errorf("write file sync: path is empty")
-	}
-
-	// Ensure parent directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("write file sync %s: failed to create parent dir: %w", path, err)
-	}
-
-	// Write to temporary file first for atomicity
-	// Keep temp file in same directory to ensure same filesystem
-	// Use unique name to avoid collisions under concurrency
-	pattern := fmt.Sprintf(".tmp.%s.*", filepath.Base(path))
-	tf, err := os.CreateTemp(dir, pattern)
-	if err != nil {
-		return fmt.Errorf("write file sync %s: failed to create temp file: %w", path, err)
-	}
-	tempFile := tf.Name()
-	// Ensure cleanup of temp file path on exit (harmless if already renamed)
-	defer os.Remove(tempFile)
-
-	// Create and write to temp file
-	// Use provided permission or default to 0644
-	if perm == 0 {
-		perm = 0644
-	}
-	// Ensure file mode as requested (CreateTemp sets 0600 by default)
-	if err := os.Chmod(tempFile, perm); err != nil {
-		tf.Close()
-		return fmt.Errorf("write file sync %s: failed to set temp perm: %w", path, err)
-	}
-
-	// Write data
-	if _, err := tf.Write(data); err != nil {
-		tf.Close()
-		return fmt.Errorf("write file sync %s: failed to write data: %w", path, err)
-	}
-
-	// Sync file contents
-	if err := FsyncFile(tf); err != nil {
-		tf.Close()
-		return fmt.Errorf("write file sync %s: failed to sync file: %w", path, err)
-	}
-
-	// Close before rename
-	if err := tf.Close(); err != nil {
-		return fmt.Errorf("write file sync %s: failed to close file: %w", path, err)
-	}
-
-	// Atomic rename to final destination
-	if err := AtomicRename(tempFile, path); err != nil {
-		return fmt.Errorf("write file sync %s: %w", path, err)
-	}
-
-	return nil
+	return WriteFileAtomic(path, data, perm)
 }
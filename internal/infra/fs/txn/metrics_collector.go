@@ -9,6 +9,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 )
 
 // Schema version for metrics.json compatibility
@@ -304,7 +306,7 @@ func (m *MetricsCollector) CreateSnapshot(metricsPath string) error {
 		return fmt.Errorf("marshal snapshot: %w", err)
 	}
 
-	if err := os.WriteFile(snapshotPath, data, 0644); err != nil {
+	if err := fs.WriteFileAtomic(snapshotPath, data, 0644); err != nil {
 		return fmt.Errorf("write snapshot: %w", err)
 	}
 
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 )
 
 // ThresholdConfig defines CI/CD threshold monitoring configuration
@@ -167,7 +169,7 @@ func SaveThresholdConfig(config *ThresholdConfig, configPath string) error {
 		return fmt.Errorf("marshal threshold config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := fs.WriteFileAtomic(configPath, data, 0644); err != nil {
 		return fmt.Errorf("write threshold config: %w", err)
 	}
 
@@ -0,0 +1,133 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// stubGateway answers every call
+type stubGateway struct {
+	output.AgentGateway
+	calls int
+}
+
+func (g *stubGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	g.calls++
+	return &output.AgentResponse{Output: "ok"}, nil
+}
+
+func (g *stubGateway) GetCapability() output.AgentCapability {
+	return output.AgentCapability{AgentType: "stub"}
+}
+
+// stubJournal accepts every append
+type stubJournal struct {
+	repository.JournalRepository
+	appended int
+}
+
+func (j *stubJournal) Append(ctx context.Context, record *repository.JournalRecord) error {
+	j.appended++
+	return nil
+}
+
+// stubSBIRepository accepts every save
+type stubSBIRepository struct {
+	repository.SBIRepository
+	saved int
+}
+
+func (r *stubSBIRepository) Save(ctx context.Context, s *sbi.SBI) error {
+	r.saved++
+	return nil
+}
+
+func TestNewInjector(t *testing.T) {
+	_, err := NewInjector(1.5, 1, nil)
+	assert.Error(t, err)
+
+	_, err = NewInjector(0.5, 1, []string{"disk_full"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown chaos fault")
+
+	injector, err := NewInjector(1, 1, []string{FaultDBBusy})
+	require.NoError(t, err)
+	assert.False(t, injector.Inject(FaultAgentTimeout, "agent"), "disabled faults are never injected")
+	assert.True(t, injector.Inject(FaultDBBusy, "SBI save"))
+}
+
+func TestInjector_SameSeedSameSequence(t *testing.T) {
+	sequence := func() []bool {
+		injector, err := NewInjector(0.5, 42, nil)
+		require.NoError(t, err)
+		var hits []bool
+		for i := 0; i < 50; i++ {
+			hits = append(hits, injector.Inject(FaultJournalWrite, "journal append"))
+		}
+		return hits
+	}
+	assert.Equal(t, sequence(), sequence())
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	injector, err := NewInjector(1, 1, nil)
+	require.NoError(t, err)
+	var logged []string
+	injector.OnInject(func(fault, operation string) { logged = append(logged, fault+" "+operation) })
+
+	gateway := &stubGateway{}
+	_, err = NewAgentGateway(gateway, injector).Execute(ctx, output.AgentRequest{})
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, service.ErrorClassAgentTimeout, service.ClassifyError(err))
+	assert.Zero(t, gateway.calls)
+
+	journal := &stubJournal{}
+	err = NewJournalRepository(journal, injector).Append(ctx, &repository.JournalRecord{})
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.Zero(t, journal.appended)
+
+	repo := &stubSBIRepository{}
+	chaosRepo := NewSBIRepository(repo, injector)
+
+	// Unfenced saves cannot lose a lock, but the database can be busy
+	err = chaosRepo.Save(ctx, nil)
+	var sqliteErr sqlite3.Error
+	require.True(t, errors.As(err, &sqliteErr))
+	assert.Equal(t, sqlite3.ErrBusy, sqliteErr.Code)
+
+	lockID, err := lock.NewLockID("sbi-1")
+	require.NoError(t, err)
+	fenced := lock.WithFence(ctx, lock.Fence{LockID: lockID, Token: 3})
+	err = chaosRepo.Save(fenced, nil)
+	assert.ErrorIs(t, err, lock.ErrStaleFencingToken)
+	assert.Equal(t, service.ErrorClassLockLost, service.ClassifyError(err))
+	assert.Zero(t, repo.saved)
+
+	assert.Equal(t, []string{
+		"agent_timeout agent stub", "journal_write journal append", "db_busy SBI save", "lock_expiry SBI save",
+	}, logged)
+	assert.Equal(t, "agent_timeout=1 db_busy=1 journal_write=1 lock_expiry=1", injector.Summary())
+
+	// Without faults calls pass through
+	off, err := NewInjector(0, 1, nil)
+	require.NoError(t, err)
+	_, err = NewAgentGateway(gateway, off).Execute(ctx, output.AgentRequest{})
+	require.NoError(t, err)
+	require.NoError(t, NewSBIRepository(repo, off).Save(fenced, nil))
+	assert.Equal(t, 1, gateway.calls)
+	assert.Equal(t, 1, repo.saved)
+	assert.Equal(t, "no faults injected", off.Summary())
+}
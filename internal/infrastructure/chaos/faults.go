@@ -0,0 +1,75 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// agentGateway fails agent calls with a timeout
+type agentGateway struct {
+	output.AgentGateway
+	injector *Injector
+}
+
+// NewAgentGateway wraps an agent gateway so that its calls time out at the injector's rate
+func NewAgentGateway(gateway output.AgentGateway, injector *Injector) output.AgentGateway {
+	return &agentGateway{AgentGateway: gateway, injector: injector}
+}
+
+// Execute runs the agent unless a timeout is injected
+func (g *agentGateway) Execute(ctx context.Context, req output.AgentRequest) (*output.AgentResponse, error) {
+	if g.injector.Inject(FaultAgentTimeout, "agent "+g.GetCapability().AgentType) {
+		return nil, fmt.Errorf("%w: agent execution timed out: %w", ErrInjected, context.DeadlineExceeded)
+	}
+	return g.AgentGateway.Execute(ctx, req)
+}
+
+// journalRepository fails journal appends with an I/O error
+type journalRepository struct {
+	repository.JournalRepository
+	injector *Injector
+}
+
+// NewJournalRepository wraps a journal so that its appends fail at the injector's rate
+func NewJournalRepository(journal repository.JournalRepository, injector *Injector) repository.JournalRepository {
+	return &journalRepository{JournalRepository: journal, injector: injector}
+}
+
+// Append appends a record unless a write error is injected
+func (r *journalRepository) Append(ctx context.Context, record *repository.JournalRecord) error {
+	if r.injector.Inject(FaultJournalWrite, "journal append") {
+		return fmt.Errorf("%w: failed to write journal: %w", ErrInjected, syscall.EIO)
+	}
+	return r.JournalRepository.Append(ctx, record)
+}
+
+// sbiRepository fails SBI saves with an expired lock or a busy database
+type sbiRepository struct {
+	repository.SBIRepository
+	injector *Injector
+}
+
+// NewSBIRepository wraps an SBI repository so that its saves fail at the injector's rate
+// Only saves made under a lock fence can lose their lock.
+func NewSBIRepository(repo repository.SBIRepository, injector *Injector) repository.SBIRepository {
+	return &sbiRepository{SBIRepository: repo, injector: injector}
+}
+
+// Save saves an SBI unless a lock expiry or a busy database is injected
+func (r *sbiRepository) Save(ctx context.Context, s *sbi.SBI) error {
+	if fence, fenced := lock.FenceFromContext(ctx); fenced && r.injector.Inject(FaultLockExpiry, "SBI save") {
+		return fmt.Errorf("%w: lock %s expired mid-turn: %w", ErrInjected, fence.LockID.String(), lock.ErrStaleFencingToken)
+	}
+	if r.injector.Inject(FaultDBBusy, "SBI save") {
+		return fmt.Errorf("%w: failed to save SBI: %w", ErrInjected, sqlite3.Error{Code: sqlite3.ErrBusy})
+	}
+	return r.SBIRepository.Save(ctx, s)
+}
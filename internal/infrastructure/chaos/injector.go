@@ -0,0 +1,124 @@
+// Package chaos injects infrastructure faults for reliability testing
+//
+// With `deespec run --chaos <rate>` the agent gateways, the SBI repository and the journal
+// are wrapped so that a share of their calls fail the way the real infrastructure fails:
+// agents time out, journal writes fail, locks expire in the middle of a turn and the database
+// is busy. The recovery subsystem must bring every SBI back to a consistent state; `deespec
+// doctor` verifies that afterwards. Never enable chaos mode on a real project.
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Injectable faults
+const (
+	FaultAgentTimeout = "agent_timeout" // The agent call times out
+	FaultJournalWrite = "journal_write" // Appending to the journal fails
+	FaultLockExpiry   = "lock_expiry"   // The lock expires mid-turn and the fenced SBI save is rejected
+	FaultDBBusy       = "db_busy"       // The database is locked when the SBI is saved
+)
+
+// Faults lists the injectable faults
+var Faults = []string{FaultAgentTimeout, FaultJournalWrite, FaultLockExpiry, FaultDBBusy}
+
+// ErrInjected marks the errors returned by injected faults
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Injector decides which calls fail
+// Every call that can fail with an enabled fault fails with probability rate. A fixed seed
+// reproduces the same sequence of decisions for the same sequence of calls.
+type Injector struct {
+	rate   float64
+	faults map[string]bool
+	rng    *rand.Rand
+	counts map[string]int
+	onHit  func(fault, operation string)
+	mu     sync.Mutex
+}
+
+// NewInjector creates an injector failing calls with probability rate (0-1)
+// An empty faults list enables every fault.
+func NewInjector(rate float64, seed int64, faults []string) (*Injector, error) {
+	if rate < 0 || rate > 1 {
+		return nil, fmt.Errorf("chaos rate must be between 0 and 1, got %g", rate)
+	}
+	if len(faults) == 0 {
+		faults = Faults
+	}
+	enabled := make(map[string]bool, len(faults))
+	for _, fault := range faults {
+		fault = strings.TrimSpace(fault)
+		known := false
+		for _, f := range Faults {
+			if f == fault {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown chaos fault %q (valid: %s)", fault, strings.Join(Faults, ", "))
+		}
+		enabled[fault] = true
+	}
+	return &Injector{
+		rate:   rate,
+		faults: enabled,
+		rng:    rand.New(rand.NewSource(seed)),
+		counts: make(map[string]int),
+	}, nil
+}
+
+// OnInject sets a function called for every injected fault, e.g. to log it
+func (i *Injector) OnInject(fn func(fault, operation string)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.onHit = fn
+}
+
+// Inject reports whether a call of operation fails with fault
+func (i *Injector) Inject(fault, operation string) bool {
+	i.mu.Lock()
+	if !i.faults[fault] || i.rng.Float64() >= i.rate {
+		i.mu.Unlock()
+		return false
+	}
+	i.counts[fault]++
+	onHit := i.onHit
+	i.mu.Unlock()
+
+	if onHit != nil {
+		onHit(fault, operation)
+	}
+	return true
+}
+
+// Counts returns the number of injected faults by fault
+func (i *Injector) Counts() map[string]int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	counts := make(map[string]int, len(i.counts))
+	for fault, n := range i.counts {
+		counts[fault] = n
+	}
+	return counts
+}
+
+// Summary describes the injected faults, e.g. "agent_timeout=2 db_busy=1"
+func (i *Injector) Summary() string {
+	counts := i.Counts()
+	if len(counts) == 0 {
+		return "no faults injected"
+	}
+	var parts []string
+	for fault, n := range counts {
+		parts = append(parts, fmt.Sprintf("%s=%d", fault, n))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
@@ -3,6 +3,7 @@ package di
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/YoshitsuguKoike/deespec/internal/adapter/controller/cli"
 	agentgateway "github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
+	notificationgateway "github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/notification"
 	storagegateway "github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/storage"
 	"github.com/YoshitsuguKoike/deespec/internal/adapter/presenter"
 	appconfig "github.com/YoshitsuguKoike/deespec/internal/app/config"
@@ -22,6 +24,7 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/domain/factory"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/service/strategy"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/chaos"
 	sqliterepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
 	_ "github.com/mattn/go-sqlite3"
@@ -32,7 +35,8 @@ import (
 // This implements manual dependency injection for Clean Architecture
 type Container struct {
 	// Infrastructure Layer - Database
-	db *sql.DB
+	db    *sql.DB
+	store *sqliterepo.Store // Write connection and read pool with prepared statement caches
 
 	// Infrastructure Layer - Repositories (SQLite implementations)
 	taskRepo       repository.TaskRepository
@@ -40,19 +44,47 @@ type Container struct {
 	pbiRepo        repository.PBIRepository
 	sbiRepo        repository.SBIRepository
 	sbiExecLogRepo repository.SBIExecLogRepository
+	feedbackRepo   repository.ReviewFeedbackRepository
+	criteriaRepo   repository.AcceptanceCriteriaRepository
+	issueRepo      repository.ReviewIssueRepository
+	snapshotRepo   repository.SBISnapshotRepository
+	tombstoneRepo  repository.SBITombstoneRepository
+	trashRepo      repository.TrashRepository
+	sessionRepo    repository.AgentSessionRepository
+	voteRepo       repository.ReviewVoteRepository
+	stepAgentRepo  repository.StepAgentRepository
+	provenanceRepo repository.ArtifactProvenanceRepository
+	noteRepo       repository.SBINoteRepository
+	referenceRepo  repository.SBIReferenceRepository
+	acceptanceRepo repository.PBIAcceptanceRepository
+	agentCallRepo  repository.AgentCallRepository
+	backupRepo     repository.DatabaseBackupRepository
+	responseCache  repository.AgentResponseCacheRepository
+	apiTokenRepo   repository.APITokenRepository
+	apiAuditRepo   repository.APIAuditLogRepository
+	idSequenceRepo repository.IDSequenceRepository
 	runLockRepo    repository.RunLockRepository
 	stateLockRepo  repository.StateLockRepository
+	turnClaimRepo  repository.TurnClaimRepository
 	labelRepo      repository.LabelRepository
 
 	// Infrastructure Layer - Gateways
-	agentGateway   output.AgentGateway
-	storageGateway output.StorageGateway
+	agentGateway        output.AgentGateway
+	storageGateway      output.StorageGateway
+	notificationGateway output.NotificationGateway
+
+	// Application Layer - Agent routing (initialized lazily with label repository)
+	agentRegistry *service.AgentRegistry
 
 	// Infrastructure Layer - Transaction Manager
 	txManager output.TransactionManager
 
 	// Application Layer - Services
 	lockService service.LockService
+	eventBroker *service.TurnEventBroker // Turn lifecycle events (initialized lazily)
+
+	// Infrastructure Layer - Fault injection for reliability testing (nil = disabled)
+	chaos *chaos.Injector
 
 	// Domain Layer - Factories
 	taskFactory *factory.Factory
@@ -79,7 +111,7 @@ type Container struct {
 
 // Config holds configuration for the container
 type Config struct {
-	AgentType    string // Agent type (claude-code, gemini-cli, codex)
+	AgentType    string // Agent type (claude-code, gemini-cli, codex, anthropic-api, openai-api, gemini-api, ollama)
 	OutputFormat string // Output format (cli, json)
 	OutputWriter io.Writer
 	Version      string
@@ -99,6 +131,22 @@ type Config struct {
 
 	// Label system configuration
 	LabelConfig appconfig.LabelConfig
+
+	// HTTP API agent backends (provider settings for anthropic-api, openai-api and gemini-api)
+	AgentAPI appconfig.AgentAPIConfig
+
+	// Local LLM agent (settings for ollama)
+	Ollama appconfig.OllamaConfig
+
+	// Notification configuration
+	NotificationWebhookURL string                            // Webhook URL for notifications (empty: log to stderr)
+	NotificationEmail      appconfig.EmailNotificationConfig // SMTP settings for email notifications (empty host: no email)
+
+	// ReadOnly opens the database read-only and skips migrations
+	ReadOnly bool
+
+	// ReadConnections is the size of the read connection pool (default: 4)
+	ReadConnections int
 }
 
 // NewContainer creates and initializes the DI container
@@ -154,35 +202,90 @@ func (c *Container) initializeInfrastructure() error {
 	// - Multiple readers can access the database while one writer is active
 	// - Reduces lock contention significantly
 	// - Enables `deespec run` and `deespec register` to work simultaneously
-	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on&_journal_mode=WAL")
+	// With database encryption enabled the DSN carries the SQLCipher key
+	dsn := sqliterepo.DSN(dbPath, "_foreign_keys=on", "_journal_mode=WAL", "_busy_timeout="+fmt.Sprint(sqliterepo.DefaultBusyTimeoutMs))
+	if c.config.ReadOnly {
+		// Read-only mode: any write fails at the SQLite level, and the
+		// database must already exist (it is never created)
+		if _, err := os.Stat(dbPath); err != nil {
+			return fmt.Errorf("database not found in read-only mode: %w", err)
+		}
+		dsn = sqliterepo.DSN(dbPath, "mode=ro", "_foreign_keys=on")
+	}
+	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
 	c.db = db
 
-	// 2a. Verify WAL mode is enabled
+	// 2a. Refuse a damaged database before anything reads or migrates it
+	if dbPath != ":memory:" {
+		if _, err := sqliterepo.CheckIntegrity(db); err != nil {
+			if errors.Is(err, sqliterepo.ErrDatabaseCorrupted) {
+				return fmt.Errorf("%s: %w (run `deespec db repair` to rebuild it)", dbPath, err)
+			}
+			return err
+		}
+	}
+
+	// 2b. Verify WAL mode is enabled
 	var journalMode string
 	if err := db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
 		return fmt.Errorf("failed to check journal mode: %w", err)
 	}
-	if journalMode != "wal" {
+	if journalMode != "wal" && !c.config.ReadOnly {
 		return fmt.Errorf("WAL mode not enabled, got: %s", journalMode)
 	}
 
-	// 3. Run database migrations
-	migrator := sqliterepo.NewMigrator(db)
-	if err := migrator.Migrate(); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+	// 3. Run database migrations (the schema is left untouched in read-only mode)
+	if !c.config.ReadOnly {
+		migrator := sqliterepo.NewMigrator(db)
+		if err := migrator.Migrate(); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+
+	// 3a. Open the store used on the hot paths: SBIs are saved and found under parallel load,
+	// so they go through a single write connection, a read pool and prepared statements.
+	// A read-only or in-memory database shares its pool.
+	if c.config.ReadOnly || dbPath == ":memory:" {
+		c.store = sqliterepo.NewStore(db)
+	} else {
+		store, err := sqliterepo.OpenStore(dbPath, sqliterepo.StoreOptions{ReadConnections: c.config.ReadConnections})
+		if err != nil {
+			return fmt.Errorf("failed to open database store: %w", err)
+		}
+		c.store = store
 	}
 
 	// 4. Initialize SQLite Repositories
 	c.taskRepo = sqliterepo.NewTaskRepository(db)
 	c.epicRepo = sqliterepo.NewEPICRepository(db)
 	c.pbiRepo = sqliterepo.NewPBIRepository(db)
-	c.sbiRepo = sqliterepo.NewSBIRepository(db)
+	c.sbiRepo = sqliterepo.NewSBIRepositoryWithStore(c.store)
 	c.sbiExecLogRepo = sqliterepo.NewSBIExecLogRepository(db)
+	c.feedbackRepo = sqliterepo.NewReviewFeedbackRepository(db)
+	c.criteriaRepo = sqliterepo.NewAcceptanceCriteriaRepository(db)
+	c.issueRepo = sqliterepo.NewReviewIssueRepository(db)
+	c.snapshotRepo = sqliterepo.NewSBISnapshotRepository(db)
+	c.tombstoneRepo = sqliterepo.NewSBITombstoneRepository(db)
+	c.trashRepo = sqliterepo.NewTrashRepository(db)
+	c.sessionRepo = sqliterepo.NewAgentSessionRepository(db)
+	c.voteRepo = sqliterepo.NewReviewVoteRepository(db)
+	c.stepAgentRepo = sqliterepo.NewStepAgentRepository(db)
+	c.provenanceRepo = sqliterepo.NewArtifactProvenanceRepository(db)
+	c.noteRepo = sqliterepo.NewSBINoteRepository(db)
+	c.referenceRepo = sqliterepo.NewSBIReferenceRepository(db)
+	c.acceptanceRepo = sqliterepo.NewPBIAcceptanceRepository(db)
+	c.agentCallRepo = sqliterepo.NewAgentCallRepository(db)
+	c.backupRepo = sqliterepo.NewDatabaseBackupRepository(db, dbPath)
+	c.responseCache = sqliterepo.NewAgentResponseCacheRepository(db)
+	c.apiTokenRepo = sqliterepo.NewAPITokenRepository(db)
+	c.apiAuditRepo = sqliterepo.NewAPIAuditLogRepository(db)
+	c.idSequenceRepo = sqliterepo.NewIDSequenceRepository(db)
 	c.runLockRepo = sqliterepo.NewRunLockRepository(db)
 	c.stateLockRepo = sqliterepo.NewStateLockRepository(db)
+	c.turnClaimRepo = sqliterepo.NewTurnClaimRepository(db)
 	// Note: labelRepo will be initialized when GetLabelRepository() is called
 	// This allows it to use the loaded config
 
@@ -200,7 +303,7 @@ func (c *Container) initializeInfrastructure() error {
 			agentType = agentgateway.GetDefaultAgent()
 		}
 
-		gateway, err := agentgateway.NewAgentGateway(agentType)
+		gateway, err := c.agentGatewayFactory()(agentType)
 		if err != nil {
 			// If agent gateway creation fails (e.g., missing API key) and no specific agent was requested,
 			// fall back to mock gateway to allow tests to run
@@ -386,16 +489,124 @@ func (c *Container) GetAgentGateway() output.AgentGateway {
 	return c.agentGateway
 }
 
+// SetAgentGateway replaces the agent gateway for every step and label route
+// Used by simulation mode to run the workflow against a scripted agent
+func (c *Container) SetAgentGateway(gateway output.AgentGateway) {
+	c.agentGateway = gateway
+	c.agentRegistry = service.NewAgentRegistry(
+		gateway,
+		func(string) (output.AgentGateway, error) { return gateway, nil },
+		c.GetLabelRepository(),
+	)
+}
+
+// EnableChaos wraps the agent gateways, the SBI repository and the journals handed out by
+// GetUnitOfWork with a fault injector
+// Used by `deespec run --chaos` to test recovery; call it after SetAgentGateway.
+func (c *Container) EnableChaos(injector *chaos.Injector) {
+	wrap := func(gateway output.AgentGateway) output.AgentGateway {
+		return chaos.NewAgentGateway(gateway, injector)
+	}
+	c.GetAgentRegistry().Decorate(wrap)
+	c.agentGateway = wrap(c.agentGateway)
+	c.sbiRepo = chaos.NewSBIRepository(c.sbiRepo, injector)
+	c.chaos = injector
+}
+
+// GetAgentRegistry returns the agent registry used for per-label agent/model routing
+// Initializes on first call so that it shares the lazily created label repository
+func (c *Container) GetAgentRegistry() *service.AgentRegistry {
+	if c.agentRegistry == nil {
+		c.agentRegistry = service.NewAgentRegistry(
+			c.agentGateway,
+			c.agentGatewayFactory(),
+			c.GetLabelRepository(),
+		)
+	}
+	return c.agentRegistry
+}
+
+// agentGatewayFactory creates agent gateways with the configured HTTP API providers and Ollama
+func (c *Container) agentGatewayFactory() service.AgentGatewayFactory {
+	options := agentgateway.AgentGatewayOptions{
+		APIProviders: make(map[string]agentgateway.APIProviderConfig, len(c.config.AgentAPI.Providers)),
+		Ollama: agentgateway.OllamaConfig{
+			BaseURL:         c.config.Ollama.BaseURL,
+			Model:           c.config.Ollama.Model,
+			ContextWindow:   c.config.Ollama.ContextWindow,
+			MaxOutputTokens: c.config.Ollama.MaxOutputTokens,
+			Overflow:        c.config.Ollama.Overflow,
+			Timeout:         time.Duration(c.config.Ollama.TimeoutSec) * time.Second,
+		},
+	}
+	for name, p := range c.config.AgentAPI.Providers {
+		options.APIProviders[name] = agentgateway.APIProviderConfig{
+			BaseURL:    p.BaseURL,
+			APIKeyEnv:  p.APIKeyEnv,
+			Model:      p.Model,
+			MaxTokens:  p.MaxTokens,
+			MaxRetries: p.MaxRetries,
+			Timeout:    time.Duration(p.TimeoutSec) * time.Second,
+		}
+	}
+	return agentgateway.NewAgentGatewayFactory(options)
+}
+
 // GetStorageGateway returns the storage gateway
 func (c *Container) GetStorageGateway() output.StorageGateway {
 	return c.storageGateway
 }
 
+// GetNotificationGateway returns the notification gateway
+// Uses the webhook and email gateways that are configured (both when both are), otherwise logs to stderr
+func (c *Container) GetNotificationGateway() output.NotificationGateway {
+	if c.notificationGateway == nil {
+		var gateways []output.NotificationGateway
+		if c.config.NotificationWebhookURL != "" {
+			gateways = append(gateways, notificationgateway.NewWebhookNotificationGateway(c.config.NotificationWebhookURL, 10*time.Second))
+		}
+		if email := c.config.NotificationEmail; email.SMTPHost != "" {
+			gateways = append(gateways, notificationgateway.NewEmailNotificationGateway(notificationgateway.EmailConfig{
+				Host:        email.SMTPHost,
+				Port:        email.SMTPPort,
+				TLS:         email.TLS,
+				Username:    email.Username,
+				Password:    os.Getenv(email.PasswordEnv),
+				From:        email.From,
+				To:          email.To,
+				TemplateDir: email.TemplateDir,
+			}))
+		}
+		switch len(gateways) {
+		case 0:
+			c.notificationGateway = notificationgateway.NewLogNotificationGateway(os.Stderr)
+		case 1:
+			c.notificationGateway = gateways[0]
+		default:
+			c.notificationGateway = notificationgateway.NewMultiNotificationGateway(gateways...)
+		}
+	}
+	return c.notificationGateway
+}
+
 // GetLockService returns the lock service
 func (c *Container) GetLockService() service.LockService {
 	return c.lockService
 }
 
+// GetTurnEventBroker returns the broker that fans turn lifecycle events out to live progress views
+func (c *Container) GetTurnEventBroker() *service.TurnEventBroker {
+	if c.eventBroker == nil {
+		c.eventBroker = service.NewTurnEventBroker()
+	}
+	return c.eventBroker
+}
+
+// GetEPICRepository returns the EPIC repository
+func (c *Container) GetEPICRepository() repository.EPICRepository {
+	return c.epicRepo
+}
+
 // GetSBIRepository returns the SBI repository
 func (c *Container) GetSBIRepository() repository.SBIRepository {
 	return c.sbiRepo
@@ -406,6 +617,111 @@ func (c *Container) GetSBIExecLogRepository() repository.SBIExecLogRepository {
 	return c.sbiExecLogRepo
 }
 
+// GetReviewFeedbackRepository returns the review feedback repository
+func (c *Container) GetReviewFeedbackRepository() repository.ReviewFeedbackRepository {
+	return c.feedbackRepo
+}
+
+// GetAcceptanceCriteriaRepository returns the acceptance criteria repository
+func (c *Container) GetAcceptanceCriteriaRepository() repository.AcceptanceCriteriaRepository {
+	return c.criteriaRepo
+}
+
+// GetReviewIssueRepository returns the review issue repository
+func (c *Container) GetReviewIssueRepository() repository.ReviewIssueRepository {
+	return c.issueRepo
+}
+
+// GetSBISnapshotRepository returns the SBI snapshot repository
+func (c *Container) GetSBISnapshotRepository() repository.SBISnapshotRepository {
+	return c.snapshotRepo
+}
+
+// GetSBITombstoneRepository returns the repository of archived SBI tombstones
+func (c *Container) GetSBITombstoneRepository() repository.SBITombstoneRepository {
+	return c.tombstoneRepo
+}
+
+// GetTurnClaimRepository returns the repository guarding turns against double execution
+func (c *Container) GetTurnClaimRepository() repository.TurnClaimRepository {
+	return c.turnClaimRepo
+}
+
+// GetTrashRepository returns the repository of deleted PBIs and SBIs
+func (c *Container) GetTrashRepository() repository.TrashRepository {
+	return c.trashRepo
+}
+
+// GetAgentSessionRepository returns the agent session repository
+func (c *Container) GetAgentSessionRepository() repository.AgentSessionRepository {
+	return c.sessionRepo
+}
+
+// GetReviewVoteRepository returns the review quorum vote repository
+func (c *Container) GetReviewVoteRepository() repository.ReviewVoteRepository {
+	return c.voteRepo
+}
+
+// GetStepAgentRepository returns the repository recording which agent executed each SBI step
+func (c *Container) GetStepAgentRepository() repository.StepAgentRepository {
+	return c.stepAgentRepo
+}
+
+// GetArtifactProvenanceRepository returns the repository recording which agent, model and version produced each artifact
+func (c *Container) GetArtifactProvenanceRepository() repository.ArtifactProvenanceRepository {
+	return c.provenanceRepo
+}
+
+// GetSBINoteRepository returns the repository of notes attached to SBIs
+func (c *Container) GetSBINoteRepository() repository.SBINoteRepository {
+	return c.noteRepo
+}
+
+// GetSBIReferenceRepository returns the repository of the SBIs whose artifacts an SBI references
+func (c *Container) GetSBIReferenceRepository() repository.SBIReferenceRepository {
+	return c.referenceRepo
+}
+
+// GetPBIAcceptanceRepository returns the repository of the acceptance tests run when a PBI's SBIs are done
+func (c *Container) GetPBIAcceptanceRepository() repository.PBIAcceptanceRepository {
+	return c.acceptanceRepo
+}
+
+// GetAgentResponseCacheRepository returns the repository caching agent outputs by prompt hash
+func (c *Container) GetAgentResponseCacheRepository() repository.AgentResponseCacheRepository {
+	return c.responseCache
+}
+
+// GetAgentCallRepository returns the repository recording agent calls for the daily budget
+func (c *Container) GetAgentCallRepository() repository.AgentCallRepository {
+	return c.agentCallRepo
+}
+
+// GetDatabaseBackupRepository returns the repository of the database backups
+func (c *Container) GetDatabaseBackupRepository() repository.DatabaseBackupRepository {
+	return c.backupRepo
+}
+
+// GetAPIAuthorizer returns the authorizer of API server requests
+func (c *Container) GetAPIAuthorizer() *service.APIAuthorizer {
+	return service.NewAPIAuthorizer(c.apiTokenRepo, c.apiAuditRepo)
+}
+
+// GetAPITokenRepository returns the API token repository
+func (c *Container) GetAPITokenRepository() repository.APITokenRepository {
+	return c.apiTokenRepo
+}
+
+// GetAPIAuditLogRepository returns the API audit log repository
+func (c *Container) GetAPIAuditLogRepository() repository.APIAuditLogRepository {
+	return c.apiAuditRepo
+}
+
+// GetIDSequenceRepository returns the repository handing out sequential task IDs
+func (c *Container) GetIDSequenceRepository() repository.IDSequenceRepository {
+	return c.idSequenceRepo
+}
+
 // GetLabelRepository returns the label repository
 // Initializes on first call with configured LabelConfig
 func (c *Container) GetLabelRepository() repository.LabelRepository {
@@ -415,6 +731,20 @@ func (c *Container) GetLabelRepository() repository.LabelRepository {
 	return c.labelRepo
 }
 
+// GetUnitOfWork returns a unit of work committing SBI saves together with journal entries,
+// and journal behind the outbox that makes its appends part of the unit
+// In read-only mode there is no unit of work and journal is returned unchanged.
+func (c *Container) GetUnitOfWork(journal repository.JournalRepository) (output.UnitOfWork, repository.JournalRepository) {
+	if c.chaos != nil {
+		journal = chaos.NewJournalRepository(journal, c.chaos)
+	}
+	if c.config.ReadOnly {
+		return nil, journal
+	}
+	outbox := sqliterepo.NewJournalOutboxRepository(c.store, journal)
+	return sqliterepo.NewUnitOfWork(c.store, outbox), outbox
+}
+
 // GetDB returns the database connection
 func (c *Container) GetDB() *sql.DB {
 	return c.db
@@ -422,6 +752,11 @@ func (c *Container) GetDB() *sql.DB {
 
 // Start starts background services (Lock Service, etc.)
 func (c *Container) Start(ctx context.Context) error {
+	// Expired lock cleanup writes to the database
+	if c.config.ReadOnly {
+		return nil
+	}
+
 	// Start Lock Service for heartbeat and cleanup
 	if err := c.lockService.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start lock service: %w", err)
@@ -439,6 +774,13 @@ func (c *Container) Close() error {
 		}
 	}
 
+	// Close the store before the connection it was migrated with
+	if c.store != nil {
+		if err := c.store.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close database store: %v\n", err)
+		}
+	}
+
 	// Close database connection
 	if c.db != nil {
 		return c.db.Close()
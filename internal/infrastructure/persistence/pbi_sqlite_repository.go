@@ -2,12 +2,16 @@ package persistence
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -32,6 +36,8 @@ func NewPBISQLiteRepository(db *sql.DB, rootPath string) *PBISQLiteRepository {
 }
 
 // Save saves a PBI with its Markdown body
+// The metadata is written only at the version the PBI was loaded at (repository.ErrStaleEntity
+// otherwise), and the version is incremented
 func (r *PBISQLiteRepository) Save(p *pbi.PBI, body string) error {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -40,26 +46,41 @@ func (r *PBISQLiteRepository) Save(p *pbi.PBI, body string) error {
 	defer tx.Rollback()
 
 	// 1. Save metadata to database
-	_, err = tx.Exec(`
+	result, err := tx.Exec(`
 		INSERT INTO pbis (
 			id, title, status, story_points, priority,
-			parent_epic_id, current_step, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			parent_epic_id, deadline, env, labels, assigned_agent, current_step, created_at, updated_at, version
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			title = excluded.title,
 			status = excluded.status,
 			story_points = excluded.story_points,
 			priority = excluded.priority,
 			parent_epic_id = excluded.parent_epic_id,
-			updated_at = excluded.updated_at
+			deadline = excluded.deadline,
+			env = excluded.env,
+			labels = excluded.labels,
+			assigned_agent = excluded.assigned_agent,
+			updated_at = excluded.updated_at,
+			version = pbis.version + 1
+		WHERE pbis.version = ?
 	`,
 		p.ID, p.Title, string(p.Status), p.EstimatedStoryPoints,
-		p.Priority, nullString(p.ParentEpicID), "planning",
-		p.CreatedAt.Format(time.RFC3339), p.UpdatedAt.Format(time.RFC3339),
+		p.Priority, nullString(p.ParentEpicID), nullDeadline(p.Deadline), nullEnv(p.Env),
+		nullLabels(p.Labels), nullString(p.AssignedAgent), "planning",
+		p.CreatedAt.Format(time.RFC3339), p.UpdatedAt.Format(time.RFC3339), p.Version+1,
+		p.Version,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save PBI metadata: %w", err)
 	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("failed to save PBI %s: %w", p.ID, repository.ErrStaleEntity)
+	}
 
 	// 2. Save Markdown file
 	pbiDir := filepath.Join(r.rootPath, ".deespec", "specs", "pbi", p.ID)
@@ -68,32 +89,37 @@ func (r *PBISQLiteRepository) Save(p *pbi.PBI, body string) error {
 	}
 
 	mdPath := filepath.Join(pbiDir, "pbi.md")
-	if err := os.WriteFile(mdPath, []byte(body), 0644); err != nil {
+	if err := fs.WriteFileAtomic(mdPath, []byte(body), 0644); err != nil {
 		return fmt.Errorf("failed to write Markdown file: %w", err)
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	p.Version++
+	return nil
 }
 
 // FindByID retrieves a PBI by ID (metadata only)
+// PBIs in the trash are not found
 func (r *PBISQLiteRepository) FindByID(id string) (*pbi.PBI, error) {
 	var p pbi.PBI
 	var status string
 	var priority int
-	var parentEpicID sql.NullString
+	var parentEpicID, deadline, env, labels, assignedAgent sql.NullString
 	var createdAt, updatedAt string
 
 	err := r.db.QueryRow(`
 		SELECT id, title, status, story_points, priority,
-		       parent_epic_id, created_at, updated_at
+		       parent_epic_id, deadline, env, labels, assigned_agent, created_at, updated_at, version
 		FROM pbis
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`, id).Scan(
 		&p.ID, &p.Title, &status, &p.EstimatedStoryPoints,
-		&priority, &parentEpicID, &createdAt, &updatedAt,
+		&priority, &parentEpicID, &deadline, &env, &labels, &assignedAgent, &createdAt, &updatedAt, &p.Version,
 	)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("PBI not found: %s", id)
+		return nil, fmt.Errorf("PBI %w: %s", model.ErrNotFound, id)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to find PBI: %w", err)
@@ -110,6 +136,22 @@ func (r *PBISQLiteRepository) FindByID(id string) (*pbi.PBI, error) {
 		p.ParentEpicID = parentEpicID.String
 	}
 
+	// Parse deadline
+	if p.Deadline, err = parseDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	// Parse env
+	if p.Env, err = parseEnv(env); err != nil {
+		return nil, err
+	}
+
+	// Parse labels and assigned agent
+	if p.Labels, err = parseLabels(labels); err != nil {
+		return nil, err
+	}
+	p.AssignedAgent = assignedAgent.String
+
 	// Parse timestamps
 	p.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
 	if err != nil {
@@ -138,8 +180,9 @@ func (r *PBISQLiteRepository) GetBody(id string) (string, error) {
 func (r *PBISQLiteRepository) FindAll() ([]*pbi.PBI, error) {
 	rows, err := r.db.Query(`
 		SELECT id, title, status, story_points, priority,
-		       parent_epic_id, created_at, updated_at
+		       parent_epic_id, deadline, env, labels, assigned_agent, created_at, updated_at, version
 		FROM pbis
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 	`)
 	if err != nil {
@@ -154,9 +197,9 @@ func (r *PBISQLiteRepository) FindAll() ([]*pbi.PBI, error) {
 func (r *PBISQLiteRepository) FindByStatus(status pbi.Status) ([]*pbi.PBI, error) {
 	rows, err := r.db.Query(`
 		SELECT id, title, status, story_points, priority,
-		       parent_epic_id, created_at, updated_at
+		       parent_epic_id, deadline, env, labels, assigned_agent, created_at, updated_at, version
 		FROM pbis
-		WHERE status = ?
+		WHERE status = ? AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`, string(status))
 	if err != nil {
@@ -171,12 +214,13 @@ func (r *PBISQLiteRepository) FindByStatus(status pbi.Status) ([]*pbi.PBI, error
 func (r *PBISQLiteRepository) FindAllWithSBICount() ([]*PBIWithSBICount, error) {
 	rows, err := r.db.Query(`
 		SELECT p.id, p.title, p.status, p.story_points, p.priority,
-		       p.parent_epic_id, p.created_at, p.updated_at,
+		       p.parent_epic_id, p.deadline, p.env, p.labels, p.assigned_agent, p.created_at, p.updated_at, p.version,
 		       COALESCE(COUNT(s.id), 0) as sbi_count
 		FROM pbis p
-		LEFT JOIN sbis s ON s.parent_pbi_id = p.id
+		LEFT JOIN sbis s ON s.parent_pbi_id = p.id AND s.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL
 		GROUP BY p.id, p.title, p.status, p.story_points, p.priority,
-		         p.parent_epic_id, p.created_at, p.updated_at
+		         p.parent_epic_id, p.deadline, p.env, p.labels, p.assigned_agent, p.created_at, p.updated_at, p.version
 		ORDER BY p.created_at DESC
 	`)
 	if err != nil {
@@ -191,13 +235,13 @@ func (r *PBISQLiteRepository) FindAllWithSBICount() ([]*PBIWithSBICount, error)
 func (r *PBISQLiteRepository) FindByStatusWithSBICount(status pbi.Status) ([]*PBIWithSBICount, error) {
 	rows, err := r.db.Query(`
 		SELECT p.id, p.title, p.status, p.story_points, p.priority,
-		       p.parent_epic_id, p.created_at, p.updated_at,
+		       p.parent_epic_id, p.deadline, p.env, p.labels, p.assigned_agent, p.created_at, p.updated_at, p.version,
 		       COALESCE(COUNT(s.id), 0) as sbi_count
 		FROM pbis p
-		LEFT JOIN sbis s ON s.parent_pbi_id = p.id
-		WHERE p.status = ?
+		LEFT JOIN sbis s ON s.parent_pbi_id = p.id AND s.deleted_at IS NULL
+		WHERE p.status = ? AND p.deleted_at IS NULL
 		GROUP BY p.id, p.title, p.status, p.story_points, p.priority,
-		         p.parent_epic_id, p.created_at, p.updated_at
+		         p.parent_epic_id, p.deadline, p.env, p.labels, p.assigned_agent, p.created_at, p.updated_at, p.version
 		ORDER BY p.created_at DESC
 	`, string(status))
 	if err != nil {
@@ -208,7 +252,8 @@ func (r *PBISQLiteRepository) FindByStatusWithSBICount(status pbi.Status) ([]*PB
 	return r.scanPBIsWithSBICount(rows)
 }
 
-// Delete deletes a PBI (both database and Markdown file)
+// Delete permanently deletes a PBI (both database and Markdown file)
+// Deleting from the command line moves the PBI to the trash instead (TrashUseCase).
 func (r *PBISQLiteRepository) Delete(id string) error {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -232,6 +277,7 @@ func (r *PBISQLiteRepository) Delete(id string) error {
 }
 
 // Exists checks if a PBI exists
+// PBIs in the trash count, as their IDs stay reserved until they are purged
 func (r *PBISQLiteRepository) Exists(id string) (bool, error) {
 	var count int
 	err := r.db.QueryRow(`SELECT COUNT(*) FROM pbis WHERE id = ?`, id).Scan(&count)
@@ -249,12 +295,12 @@ func (r *PBISQLiteRepository) scanPBIs(rows *sql.Rows) ([]*pbi.PBI, error) {
 		var p pbi.PBI
 		var status string
 		var priority int
-		var parentEpicID sql.NullString
+		var parentEpicID, deadline, env, labels, assignedAgent sql.NullString
 		var createdAt, updatedAt string
 
 		err := rows.Scan(
 			&p.ID, &p.Title, &status, &p.EstimatedStoryPoints,
-			&priority, &parentEpicID, &createdAt, &updatedAt,
+			&priority, &parentEpicID, &deadline, &env, &labels, &assignedAgent, &createdAt, &updatedAt, &p.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan PBI: %w", err)
@@ -271,6 +317,22 @@ func (r *PBISQLiteRepository) scanPBIs(rows *sql.Rows) ([]*pbi.PBI, error) {
 			p.ParentEpicID = parentEpicID.String
 		}
 
+		// Parse deadline
+		if p.Deadline, err = parseDeadline(deadline); err != nil {
+			return nil, err
+		}
+
+		// Parse env
+		if p.Env, err = parseEnv(env); err != nil {
+			return nil, err
+		}
+
+		// Parse labels and assigned agent
+		if p.Labels, err = parseLabels(labels); err != nil {
+			return nil, err
+		}
+		p.AssignedAgent = assignedAgent.String
+
 		// Parse timestamps
 		p.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
 		if err != nil {
@@ -296,13 +358,13 @@ func (r *PBISQLiteRepository) scanPBIsWithSBICount(rows *sql.Rows) ([]*PBIWithSB
 		var p pbi.PBI
 		var status string
 		var priority int
-		var parentEpicID sql.NullString
+		var parentEpicID, deadline, env, labels, assignedAgent sql.NullString
 		var createdAt, updatedAt string
 		var sbiCount int
 
 		err := rows.Scan(
 			&p.ID, &p.Title, &status, &p.EstimatedStoryPoints,
-			&priority, &parentEpicID, &createdAt, &updatedAt,
+			&priority, &parentEpicID, &deadline, &env, &labels, &assignedAgent, &createdAt, &updatedAt, &p.Version,
 			&sbiCount,
 		)
 		if err != nil {
@@ -320,6 +382,22 @@ func (r *PBISQLiteRepository) scanPBIsWithSBICount(rows *sql.Rows) ([]*PBIWithSB
 			p.ParentEpicID = parentEpicID.String
 		}
 
+		// Parse deadline
+		if p.Deadline, err = parseDeadline(deadline); err != nil {
+			return nil, err
+		}
+
+		// Parse env
+		if p.Env, err = parseEnv(env); err != nil {
+			return nil, err
+		}
+
+		// Parse labels and assigned agent
+		if p.Labels, err = parseLabels(labels); err != nil {
+			return nil, err
+		}
+		p.AssignedAgent = assignedAgent.String
+
 		// Parse timestamps
 		p.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
 		if err != nil {
@@ -347,3 +425,72 @@ func nullString(s string) sql.NullString {
 	}
 	return sql.NullString{String: s, Valid: true}
 }
+
+// nullDeadline converts an optional deadline to a nullable RFC3339 UTC string
+// UTC is used so that deadlines compare correctly as strings in SQL
+func nullDeadline(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.UTC().Format(time.RFC3339), Valid: true}
+}
+
+// parseDeadline parses a nullable deadline column
+func parseDeadline(s sql.NullString) (*time.Time, error) {
+	if !s.Valid || s.String == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deadline: %w", err)
+	}
+	return &t, nil
+}
+
+// nullEnv converts optional environment variables to a nullable JSON object
+func nullEnv(env map[string]string) sql.NullString {
+	if len(env) == 0 {
+		return sql.NullString{}
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// parseEnv parses a nullable env column
+func parseEnv(s sql.NullString) (map[string]string, error) {
+	if !s.Valid || s.String == "" {
+		return nil, nil
+	}
+	var env map[string]string
+	if err := json.Unmarshal([]byte(s.String), &env); err != nil {
+		return nil, fmt.Errorf("failed to parse env: %w", err)
+	}
+	return env, nil
+}
+
+// nullLabels converts optional labels to a nullable JSON array
+func nullLabels(labels []string) sql.NullString {
+	if len(labels) == 0 {
+		return sql.NullString{}
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// parseLabels parses a nullable labels column
+func parseLabels(s sql.NullString) ([]string, error) {
+	if !s.Valid || s.String == "" {
+		return nil, nil
+	}
+	var labels []string
+	if err := json.Unmarshal([]byte(s.String), &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse labels: %w", err)
+	}
+	return labels, nil
+}
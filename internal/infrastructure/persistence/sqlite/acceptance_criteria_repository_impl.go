@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// AcceptanceCriteriaRepositoryImpl implements AcceptanceCriteriaRepository using SQLite
+type AcceptanceCriteriaRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAcceptanceCriteriaRepository creates a new AcceptanceCriteriaRepository implementation
+func NewAcceptanceCriteriaRepository(db *sql.DB) repository.AcceptanceCriteriaRepository {
+	return &AcceptanceCriteriaRepositoryImpl{db: db}
+}
+
+// SaveAll replaces the checklist of an SBI
+func (r *AcceptanceCriteriaRepositoryImpl) SaveAll(ctx context.Context, sbiID string, criteria []*repository.AcceptanceCriterion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sbi_acceptance_criteria WHERE sbi_id = ?`, sbiID); err != nil {
+		return fmt.Errorf("failed to clear acceptance criteria: %w", err)
+	}
+
+	query := `
+		INSERT INTO sbi_acceptance_criteria (sbi_id, position, description, source, status, note, turn, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`
+	for _, c := range criteria {
+		status := c.Status
+		if status == "" {
+			status = repository.CriterionPending
+		}
+		source := c.Source
+		if source == "" {
+			source = "sbi"
+		}
+		if _, err := tx.ExecContext(ctx, query, sbiID, c.Position, c.Description, source, string(status), c.Note, c.Turn); err != nil {
+			return fmt.Errorf("failed to save acceptance criterion %d: %w", c.Position, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit acceptance criteria: %w", err)
+	}
+	return nil
+}
+
+// FindBySBIID retrieves the checklist of an SBI ordered by position
+func (r *AcceptanceCriteriaRepositoryImpl) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.AcceptanceCriterion, error) {
+	query := `
+		SELECT id, sbi_id, position, description, source, status, note, turn, updated_at
+		FROM sbi_acceptance_criteria
+		WHERE sbi_id = ?
+		ORDER BY position ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, sbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query acceptance criteria: %w", err)
+	}
+	defer rows.Close()
+
+	criteria := []*repository.AcceptanceCriterion{}
+	for rows.Next() {
+		c := &repository.AcceptanceCriterion{}
+		var status string
+		if err := rows.Scan(&c.ID, &c.SBIID, &c.Position, &c.Description, &c.Source, &status, &c.Note, &c.Turn, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan acceptance criterion: %w", err)
+		}
+		c.Status = repository.CriterionStatus(status)
+		criteria = append(criteria, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate acceptance criteria: %w", err)
+	}
+
+	return criteria, nil
+}
+
+// UpdateStatus sets the status and note of a single criterion
+func (r *AcceptanceCriteriaRepositoryImpl) UpdateStatus(ctx context.Context, sbiID string, position int, status repository.CriterionStatus, note string, turn int) error {
+	query := `
+		UPDATE sbi_acceptance_criteria
+		SET status = ?, note = ?, turn = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE sbi_id = ? AND position = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, string(status), note, turn, sbiID, position)
+	if err != nil {
+		return fmt.Errorf("failed to update acceptance criterion: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("acceptance criterion AC-%d %w for SBI %s", position, model.ErrNotFound, sbiID)
+	}
+
+	return nil
+}
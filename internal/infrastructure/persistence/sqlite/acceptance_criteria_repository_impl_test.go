@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForAcceptanceCriteria(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	migrator := NewMigrator(db)
+	require.NoError(t, migrator.Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-AC-001', 'Criteria test', 'IMPLEMENTING', 'IMPLEMENT', 0)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestAcceptanceCriteriaRepository_SaveAllAndFind(t *testing.T) {
+	db := setupTestDBForAcceptanceCriteria(t)
+	defer db.Close()
+
+	repo := NewAcceptanceCriteriaRepository(db)
+	ctx := context.Background()
+
+	// No criteria yet
+	found, err := repo.FindBySBIID(ctx, "SBI-AC-001")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	err = repo.SaveAll(ctx, "SBI-AC-001", []*repository.AcceptanceCriterion{
+		{Position: 2, Description: "Errors are logged", Source: "pbi"},
+		{Position: 1, Description: "Returns 200 on success"},
+	})
+	require.NoError(t, err)
+
+	found, err = repo.FindBySBIID(ctx, "SBI-AC-001")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, 1, found[0].Position)
+	assert.Equal(t, "Returns 200 on success", found[0].Description)
+	assert.Equal(t, "sbi", found[0].Source)
+	assert.Equal(t, repository.CriterionPending, found[0].Status)
+	assert.Equal(t, "pbi", found[1].Source)
+
+	// Saving again replaces the checklist
+	err = repo.SaveAll(ctx, "SBI-AC-001", []*repository.AcceptanceCriterion{
+		{Position: 1, Description: "Only criterion"},
+	})
+	require.NoError(t, err)
+
+	found, err = repo.FindBySBIID(ctx, "SBI-AC-001")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Only criterion", found[0].Description)
+}
+
+func TestAcceptanceCriteriaRepository_UpdateStatus(t *testing.T) {
+	db := setupTestDBForAcceptanceCriteria(t)
+	defer db.Close()
+
+	repo := NewAcceptanceCriteriaRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveAll(ctx, "SBI-AC-001", []*repository.AcceptanceCriterion{
+		{Position: 1, Description: "Returns 200 on success"},
+	}))
+
+	require.NoError(t, repo.UpdateStatus(ctx, "SBI-AC-001", 1, repository.CriterionFailed, "returns 500", 4))
+
+	found, err := repo.FindBySBIID(ctx, "SBI-AC-001")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, repository.CriterionFailed, found[0].Status)
+	assert.Equal(t, "returns 500", found[0].Note)
+	assert.Equal(t, 4, found[0].Turn)
+	assert.False(t, found[0].Status.IsSatisfied())
+
+	err = repo.UpdateStatus(ctx, "SBI-AC-001", 9, repository.CriterionPassed, "", 4)
+	assert.Error(t, err)
+}
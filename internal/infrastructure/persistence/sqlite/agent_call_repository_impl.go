@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// AgentCallRepositoryImpl implements AgentCallRepository using SQLite
+type AgentCallRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAgentCallRepository creates a new AgentCallRepository implementation
+func NewAgentCallRepository(db *sql.DB) repository.AgentCallRepository {
+	return &AgentCallRepositoryImpl{db: db}
+}
+
+// Save records an agent call
+func (r *AgentCallRepositoryImpl) Save(ctx context.Context, call *repository.AgentCall) error {
+	calledAt := call.CalledAt
+	if calledAt.IsZero() {
+		calledAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO agent_calls (sbi_id, turn, step, agent_type, called_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		call.SBIID,
+		call.Turn,
+		call.Step,
+		call.AgentType,
+		calledAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save agent call: %w", err)
+	}
+	return nil
+}
+
+// CountSince returns the number of agent calls made at or after since
+func (r *AgentCallRepositoryImpl) CountSince(ctx context.Context, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM agent_calls WHERE called_at >= ?`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, since.UTC().Format(time.RFC3339)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count agent calls: %w", err)
+	}
+	return count, nil
+}
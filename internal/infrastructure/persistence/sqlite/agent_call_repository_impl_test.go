@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestAgentCallRepository_CountSince(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewAgentCallRepository(db)
+	ctx := context.Background()
+	midnight := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+
+	for _, calledAt := range []time.Time{
+		midnight.Add(-time.Minute), // Previous day
+		midnight,
+		midnight.Add(9 * time.Hour),
+	} {
+		require.NoError(t, repo.Save(ctx, &repository.AgentCall{
+			SBIID: "SBI-CALL-001", Turn: 1, Step: "implement", AgentType: "claude-code-cli", CalledAt: calledAt,
+		}))
+	}
+
+	count, err := repo.CountSince(ctx, midnight)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = repo.CountSince(ctx, midnight.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
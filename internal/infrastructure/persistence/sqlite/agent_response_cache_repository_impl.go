@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// AgentResponseCacheRepositoryImpl implements AgentResponseCacheRepository using SQLite
+type AgentResponseCacheRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAgentResponseCacheRepository creates a new AgentResponseCacheRepository implementation
+func NewAgentResponseCacheRepository(db *sql.DB) repository.AgentResponseCacheRepository {
+	return &AgentResponseCacheRepositoryImpl{db: db}
+}
+
+// Find retrieves the cached response of a prompt hash, returning nil when none is cached
+func (r *AgentResponseCacheRepositoryImpl) Find(ctx context.Context, promptHash string) (*repository.CachedAgentResponse, error) {
+	query := `
+		SELECT prompt_hash, agent_type, model, step, output, hits, created_at
+		FROM agent_response_cache
+		WHERE prompt_hash = ?
+	`
+
+	response := &repository.CachedAgentResponse{}
+	var createdAt string
+	err := r.db.QueryRowContext(ctx, query, promptHash).Scan(
+		&response.PromptHash,
+		&response.AgentType,
+		&response.Model,
+		&response.Step,
+		&response.Output,
+		&response.Hits,
+		&createdAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cached agent response: %w", err)
+	}
+	if response.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, fmt.Errorf("failed to parse cached agent response created_at: %w", err)
+	}
+	return response, nil
+}
+
+// Save stores a response, replacing any previous one for the prompt hash
+func (r *AgentResponseCacheRepositoryImpl) Save(ctx context.Context, response *repository.CachedAgentResponse) error {
+	createdAt := response.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO agent_response_cache (prompt_hash, agent_type, model, step, output, hits, created_at)
+		VALUES (?, ?, ?, ?, ?, 0, ?)
+		ON CONFLICT(prompt_hash) DO UPDATE SET
+			agent_type = excluded.agent_type,
+			model = excluded.model,
+			step = excluded.step,
+			output = excluded.output,
+			hits = 0,
+			created_at = excluded.created_at,
+			last_hit_at = NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		response.PromptHash,
+		response.AgentType,
+		response.Model,
+		response.Step,
+		response.Output,
+		createdAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save cached agent response: %w", err)
+	}
+	return nil
+}
+
+// RecordHit counts a reuse of the cached response of a prompt hash
+func (r *AgentResponseCacheRepositoryImpl) RecordHit(ctx context.Context, promptHash string) error {
+	query := `UPDATE agent_response_cache SET hits = hits + 1, last_hit_at = ? WHERE prompt_hash = ?`
+	if _, err := r.db.ExecContext(ctx, query, time.Now().UTC().Format(time.RFC3339), promptHash); err != nil {
+		return fmt.Errorf("failed to record cache hit: %w", err)
+	}
+	return nil
+}
+
+// DeleteBefore removes the responses cached before the given time
+func (r *AgentResponseCacheRepositoryImpl) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM agent_response_cache WHERE created_at < ?`, before.UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete cached agent responses: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted cached agent responses: %w", err)
+	}
+	return int(deleted), nil
+}
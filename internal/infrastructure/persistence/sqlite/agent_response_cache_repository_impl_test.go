@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestAgentResponseCacheRepository(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewAgentResponseCacheRepository(db)
+	ctx := context.Background()
+
+	found, err := repo.Find(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, repo.Save(ctx, &repository.CachedAgentResponse{
+		PromptHash: "hash-1", AgentType: "claude-code-cli", Step: "done", Output: "first", CreatedAt: old,
+	}))
+	require.NoError(t, repo.RecordHit(ctx, "hash-1"))
+	require.NoError(t, repo.RecordHit(ctx, "hash-1"))
+
+	found, err = repo.Find(ctx, "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "first", found.Output)
+	assert.Equal(t, 2, found.Hits)
+	assert.Equal(t, old.UTC().Truncate(time.Second), found.CreatedAt)
+
+	// Saving again replaces the output and restarts the entry
+	require.NoError(t, repo.Save(ctx, &repository.CachedAgentResponse{
+		PromptHash: "hash-1", AgentType: "claude-code-cli", Model: "claude-opus", Step: "done", Output: "second",
+	}))
+	found, err = repo.Find(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, "second", found.Output)
+	assert.Equal(t, "claude-opus", found.Model)
+	assert.Zero(t, found.Hits)
+
+	require.NoError(t, repo.Save(ctx, &repository.CachedAgentResponse{
+		PromptHash: "hash-2", AgentType: "codex", Step: "done", Output: "stale", CreatedAt: old,
+	}))
+	deleted, err := repo.DeleteBefore(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	found, err = repo.Find(ctx, "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
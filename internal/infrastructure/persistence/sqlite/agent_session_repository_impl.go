@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// AgentSessionRepositoryImpl implements AgentSessionRepository using SQLite
+type AgentSessionRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAgentSessionRepository creates a new AgentSessionRepository implementation
+func NewAgentSessionRepository(db *sql.DB) repository.AgentSessionRepository {
+	return &AgentSessionRepositoryImpl{db: db}
+}
+
+// Find retrieves the session of an SBI step, returning nil when none exists
+func (r *AgentSessionRepositoryImpl) Find(ctx context.Context, sbiID, step string) (*repository.AgentSession, error) {
+	query := `
+		SELECT sbi_id, step, agent_type, session_id, turn, updated_at
+		FROM sbi_agent_sessions
+		WHERE sbi_id = ? AND step = ?
+	`
+
+	session := &repository.AgentSession{}
+	err := r.db.QueryRowContext(ctx, query, sbiID, step).Scan(
+		&session.SBIID,
+		&session.Step,
+		&session.AgentType,
+		&session.SessionID,
+		&session.Turn,
+		&session.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find agent session: %w", err)
+	}
+	return session, nil
+}
+
+// Save stores the session of an SBI step, replacing any previous one
+func (r *AgentSessionRepositoryImpl) Save(ctx context.Context, session *repository.AgentSession) error {
+	query := `
+		INSERT INTO sbi_agent_sessions (sbi_id, step, agent_type, session_id, turn, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(sbi_id, step) DO UPDATE SET
+			agent_type = excluded.agent_type,
+			session_id = excluded.session_id,
+			turn = excluded.turn,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.SBIID,
+		session.Step,
+		session.AgentType,
+		session.SessionID,
+		session.Turn,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save agent session: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the session of an SBI step
+func (r *AgentSessionRepositoryImpl) Delete(ctx context.Context, sbiID, step string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM sbi_agent_sessions WHERE sbi_id = ? AND step = ?`, sbiID, step); err != nil {
+		return fmt.Errorf("failed to delete agent session: %w", err)
+	}
+	return nil
+}
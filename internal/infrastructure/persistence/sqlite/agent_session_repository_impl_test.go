@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForAgentSessions(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	migrator := NewMigrator(db)
+	require.NoError(t, migrator.Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-SESS-001', 'Session test', 'IMPLEMENTING', 'IMPLEMENT', 0)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestAgentSessionRepository_SaveFindDelete(t *testing.T) {
+	db := setupTestDBForAgentSessions(t)
+	defer db.Close()
+
+	repo := NewAgentSessionRepository(db)
+	ctx := context.Background()
+
+	found, err := repo.Find(ctx, "SBI-SESS-001", "implement")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	require.NoError(t, repo.Save(ctx, &repository.AgentSession{
+		SBIID: "SBI-SESS-001", Step: "implement", AgentType: "claude-code-cli", SessionID: "session-1", Turn: 1,
+	}))
+
+	// Saving again replaces the session of the step
+	require.NoError(t, repo.Save(ctx, &repository.AgentSession{
+		SBIID: "SBI-SESS-001", Step: "implement", AgentType: "claude-code-cli", SessionID: "session-2", Turn: 3,
+	}))
+
+	found, err = repo.Find(ctx, "SBI-SESS-001", "implement")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "session-2", found.SessionID)
+	assert.Equal(t, 3, found.Turn)
+
+	// Sessions are kept per step
+	review, err := repo.Find(ctx, "SBI-SESS-001", "review")
+	require.NoError(t, err)
+	assert.Nil(t, review)
+
+	require.NoError(t, repo.Delete(ctx, "SBI-SESS-001", "implement"))
+	found, err = repo.Find(ctx, "SBI-SESS-001", "implement")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// APITokenRepositoryImpl implements APITokenRepository using SQLite
+type APITokenRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository creates a new APITokenRepository implementation
+func NewAPITokenRepository(db *sql.DB) repository.APITokenRepository {
+	return &APITokenRepositoryImpl{db: db}
+}
+
+// Save creates a token
+func (r *APITokenRepositoryImpl) Save(ctx context.Context, token *repository.APIToken) error {
+	createdAt := token.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO api_tokens (name, token_hash, scopes, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		token.Name,
+		token.TokenHash,
+		strings.Join(token.Scopes, ","),
+		createdAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save API token %s: %w", token.Name, err)
+	}
+	return nil
+}
+
+// FindActiveByHash returns the unrevoked token with the secret hash
+func (r *APITokenRepositoryImpl) FindActiveByHash(ctx context.Context, tokenHash string) (*repository.APIToken, error) {
+	query := `
+		SELECT name, token_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE token_hash = ? AND revoked_at IS NULL
+	`
+	rows, err := r.db.QueryContext(ctx, query, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API token: %w", err)
+	}
+	defer rows.Close()
+
+	tokens, err := scanAPITokens(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, repository.ErrAPITokenNotFound
+	}
+	return tokens[0], nil
+}
+
+// List returns all tokens by name
+func (r *APITokenRepositoryImpl) List(ctx context.Context) ([]*repository.APIToken, error) {
+	query := `
+		SELECT name, token_hash, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens
+		ORDER BY name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAPITokens(rows)
+}
+
+// CountActive returns the number of unrevoked tokens
+func (r *APITokenRepositoryImpl) CountActive(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_tokens WHERE revoked_at IS NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count API tokens: %w", err)
+	}
+	return count, nil
+}
+
+// Revoke revokes a token by name
+func (r *APITokenRepositoryImpl) Revoke(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE api_tokens SET revoked_at = ? WHERE name = ? AND revoked_at IS NULL`,
+		time.Now().UTC().Format(time.RFC3339), name)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token %s: %w", name, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected failed: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrAPITokenNotFound, name)
+	}
+	return nil
+}
+
+// TouchLastUsed records when a token was last used
+func (r *APITokenRepositoryImpl) TouchLastUsed(ctx context.Context, name string, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE api_tokens SET last_used_at = ? WHERE name = ?`,
+		at.UTC().Format(time.RFC3339), name); err != nil {
+		return fmt.Errorf("failed to update API token %s: %w", name, err)
+	}
+	return nil
+}
+
+// scanAPITokens reads the tokens of a query result
+func scanAPITokens(rows *sql.Rows) ([]*repository.APIToken, error) {
+	tokens := []*repository.APIToken{}
+	for rows.Next() {
+		var (
+			token      repository.APIToken
+			scopes     string
+			createdAt  string
+			lastUsedAt sql.NullString
+			revokedAt  sql.NullString
+		)
+		if err := rows.Scan(&token.Name, &token.TokenHash, &scopes, &createdAt, &lastUsedAt, &revokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		if scopes != "" {
+			token.Scopes = strings.Split(scopes, ",")
+		}
+		token.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		token.LastUsedAt = parseNullTime(lastUsedAt)
+		token.RevokedAt = parseNullTime(revokedAt)
+		tokens = append(tokens, &token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// parseNullTime parses a nullable RFC3339 column
+func parseNullTime(value sql.NullString) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// APIAuditLogRepositoryImpl implements APIAuditLogRepository using SQLite
+type APIAuditLogRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAPIAuditLogRepository creates a new APIAuditLogRepository implementation
+func NewAPIAuditLogRepository(db *sql.DB) repository.APIAuditLogRepository {
+	return &APIAuditLogRepositoryImpl{db: db}
+}
+
+// Save appends an audit entry
+func (r *APIAuditLogRepositoryImpl) Save(ctx context.Context, entry *repository.APIAuditEntry) error {
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO api_audit_log (token_name, route, target, outcome, detail, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		entry.TokenName,
+		entry.Route,
+		entry.Target,
+		entry.Outcome,
+		entry.Detail,
+		createdAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save API audit entry: %w", err)
+	}
+	if id, err := result.LastInsertId(); err == nil {
+		entry.ID = id
+	}
+	return nil
+}
+
+// ListRecent returns the newest entries first
+func (r *APIAuditLogRepositoryImpl) ListRecent(ctx context.Context, limit int) ([]*repository.APIAuditEntry, error) {
+	query := `
+		SELECT id, token_name, route, COALESCE(target, ''), outcome, COALESCE(detail, ''), created_at
+		FROM api_audit_log
+		ORDER BY id DESC
+	`
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*repository.APIAuditEntry{}
+	for rows.Next() {
+		var (
+			entry     repository.APIAuditEntry
+			createdAt string
+		)
+		if err := rows.Scan(&entry.ID, &entry.TokenName, &entry.Route, &entry.Target, &entry.Outcome, &entry.Detail, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API audit entry: %w", err)
+		}
+		entry.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API audit entries: %w", err)
+	}
+	return entries, nil
+}
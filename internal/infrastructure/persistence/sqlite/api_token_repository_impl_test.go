@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestAPITokenRepository_Lifecycle(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewAPITokenRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, &repository.APIToken{Name: "ci", TokenHash: "hash-ci", Scopes: []string{"read", "run-turn"}}))
+	require.NoError(t, repo.Save(ctx, &repository.APIToken{Name: "ops", TokenHash: "hash-ops", Scopes: []string{"admin"}}))
+	assert.Error(t, repo.Save(ctx, &repository.APIToken{Name: "ci", TokenHash: "hash-other", Scopes: []string{"read"}}))
+
+	token, err := repo.FindActiveByHash(ctx, "hash-ci")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", token.Name)
+	assert.Equal(t, []string{"read", "run-turn"}, token.Scopes)
+	assert.Nil(t, token.LastUsedAt)
+
+	usedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.TouchLastUsed(ctx, "ci", usedAt))
+	token, err = repo.FindActiveByHash(ctx, "hash-ci")
+	require.NoError(t, err)
+	require.NotNil(t, token.LastUsedAt)
+	assert.True(t, usedAt.Equal(*token.LastUsedAt))
+
+	count, err := repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, repo.Revoke(ctx, "ci"))
+	_, err = repo.FindActiveByHash(ctx, "hash-ci")
+	assert.True(t, errors.Is(err, repository.ErrAPITokenNotFound))
+	assert.True(t, errors.Is(repo.Revoke(ctx, "ci"), repository.ErrAPITokenNotFound))
+
+	count, err = repo.CountActive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Revoked tokens stay listed
+	tokens, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "ci", tokens[0].Name)
+	assert.NotNil(t, tokens[0].RevokedAt)
+}
+
+func TestAPIAuditLogRepository_ListRecent(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewAPIAuditLogRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, &repository.APIAuditEntry{TokenName: "ci", Route: "RunTurn", Target: "SBI-1", Outcome: "ok"}))
+	require.NoError(t, repo.Save(ctx, &repository.APIAuditEntry{Route: "StreamEvents", Outcome: "denied", Detail: "no token"}))
+	require.NoError(t, repo.Save(ctx, &repository.APIAuditEntry{TokenName: "ops", Route: "ReleaseLock", Target: "lock-1", Outcome: "ok"}))
+
+	entries, err := repo.ListRecent(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ReleaseLock", entries[0].Route)
+	assert.Equal(t, "no token", entries[1].Detail)
+	assert.Equal(t, "", entries[1].TokenName)
+
+	all, err := repo.ListRecent(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "SBI-1", all[2].Target)
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ArtifactProvenanceRepositoryImpl implements ArtifactProvenanceRepository using SQLite
+type ArtifactProvenanceRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewArtifactProvenanceRepository creates a new ArtifactProvenanceRepository implementation
+func NewArtifactProvenanceRepository(db *sql.DB) repository.ArtifactProvenanceRepository {
+	return &ArtifactProvenanceRepositoryImpl{db: db}
+}
+
+// Save records the provenance of an artifact
+func (r *ArtifactProvenanceRepositoryImpl) Save(ctx context.Context, provenance *repository.ArtifactProvenance) error {
+	query := `
+		INSERT INTO sbi_artifact_provenance
+			(sbi_id, step, turn, artifact_path, decision, agent_type, model, agent_version, reviewer, prompt_hash, artifact_hash, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		provenance.SBIID,
+		provenance.Step,
+		provenance.Turn,
+		provenance.ArtifactPath,
+		provenance.Decision,
+		provenance.AgentType,
+		provenance.Model,
+		provenance.AgentVersion,
+		provenance.Reviewer,
+		provenance.PromptHash,
+		provenance.ArtifactHash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save artifact provenance: %w", err)
+	}
+	return nil
+}
+
+// FindBySBIID retrieves the provenance records of an SBI, oldest first
+func (r *ArtifactProvenanceRepositoryImpl) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.ArtifactProvenance, error) {
+	query := `
+		SELECT sbi_id, step, turn, artifact_path, decision, agent_type, model, agent_version, reviewer, prompt_hash, artifact_hash, created_at
+		FROM sbi_artifact_provenance
+		WHERE sbi_id = ?
+		ORDER BY turn ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, sbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artifact provenance: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*repository.ArtifactProvenance
+	for rows.Next() {
+		provenance := &repository.ArtifactProvenance{}
+		if err := rows.Scan(
+			&provenance.SBIID,
+			&provenance.Step,
+			&provenance.Turn,
+			&provenance.ArtifactPath,
+			&provenance.Decision,
+			&provenance.AgentType,
+			&provenance.Model,
+			&provenance.AgentVersion,
+			&provenance.Reviewer,
+			&provenance.PromptHash,
+			&provenance.ArtifactHash,
+			&provenance.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan artifact provenance: %w", err)
+		}
+		records = append(records, provenance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate artifact provenance: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestArtifactProvenanceRepository_SaveAndFind(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-PROV-001', 'Provenance test', 'REVIEWING', 'REVIEW', 0)
+	`)
+	require.NoError(t, err)
+
+	repo := NewArtifactProvenanceRepository(db)
+	ctx := context.Background()
+
+	records, err := repo.FindBySBIID(ctx, "SBI-PROV-001")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, repo.Save(ctx, &repository.ArtifactProvenance{
+		SBIID: "SBI-PROV-001", Step: "review", Turn: 2, ArtifactPath: "review_2.md", Decision: "SUCCEEDED",
+		AgentType: "codex", Model: "gpt-5", Reviewer: "codex",
+	}))
+	require.NoError(t, repo.Save(ctx, &repository.ArtifactProvenance{
+		SBIID: "SBI-PROV-001", Step: "implement", Turn: 1, ArtifactPath: "implement_1.md",
+		AgentType: "claude-code-cli", Model: "claude-opus-4-1-20250805", AgentVersion: "1.0.98 (Claude Code)",
+		PromptHash: "3f2a", ArtifactHash: "9bc1",
+	}))
+
+	records, err = repo.FindBySBIID(ctx, "SBI-PROV-001")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "implement", records[0].Step)
+	assert.Equal(t, "claude-opus-4-1-20250805", records[0].Model)
+	assert.Equal(t, "1.0.98 (Claude Code)", records[0].AgentVersion)
+	assert.Equal(t, "3f2a", records[0].PromptHash)
+	assert.Equal(t, "9bc1", records[0].ArtifactHash)
+	assert.Equal(t, "review", records[1].Step)
+	assert.Equal(t, "SUCCEEDED", records[1].Decision)
+	assert.Equal(t, "codex", records[1].Reviewer)
+	assert.False(t, records[1].CreatedAt.IsZero())
+
+	// Records go with their SBI
+	_, err = db.Exec(`DELETE FROM sbis WHERE id = 'SBI-PROV-001'`)
+	require.NoError(t, err)
+	records, err = repo.FindBySBIID(ctx, "SBI-PROV-001")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,194 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// backupTimeLayout is the UTC time in the name of a backup file
+const backupTimeLayout = "20060102T150405Z"
+
+// migrationBackupKind matches the v<version> a pre-migration backup is named after
+var migrationBackupKind = regexp.MustCompile(`^v[0-9]+$`)
+
+// DatabaseBackupRepositoryImpl keeps the backups of a database file as
+// <backups>/<name>.<kind>-<UTC time>.bak next to it, alongside the pre-migration backups
+type DatabaseBackupRepositoryImpl struct {
+	db   *sql.DB // Only needed to create backups
+	path string  // Database file
+}
+
+// NewDatabaseBackupRepository creates a repository of the backups of the database file at path
+// db may be nil to list and find backups without opening the database.
+func NewDatabaseBackupRepository(db *sql.DB, path string) repository.DatabaseBackupRepository {
+	return &DatabaseBackupRepositoryImpl{db: db, path: path}
+}
+
+// dir returns the directory holding the backups
+func (r *DatabaseBackupRepositoryImpl) dir() string {
+	return filepath.Join(filepath.Dir(r.path), BackupDirName)
+}
+
+// Create snapshots the database into the backups directory
+func (r *DatabaseBackupRepositoryImpl) Create(ctx context.Context, kind repository.DatabaseBackupKind, at time.Time) (*repository.DatabaseBackup, error) {
+	if r.db == nil || r.path == "" || r.path == ":memory:" {
+		return nil, fmt.Errorf("cannot back up an in-memory database")
+	}
+	name := fmt.Sprintf("%s.%s-%s.bak", filepath.Base(r.path), kind, at.UTC().Format(backupTimeLayout))
+	dest := filepath.Join(r.dir(), name)
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		if err := BackupDatabase(r.db, dest); err != nil {
+			return nil, err
+		}
+	}
+	return r.Find(ctx, name)
+}
+
+// List returns the backups of the database, newest first
+func (r *DatabaseBackupRepositoryImpl) List(ctx context.Context) ([]*repository.DatabaseBackup, error) {
+	entries, err := os.ReadDir(r.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*repository.DatabaseBackup{}, nil
+		}
+		return nil, fmt.Errorf("failed to read backups: %w", err)
+	}
+	backups := []*repository.DatabaseBackup{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if backup := r.parse(entry.Name()); backup != nil {
+			if info, err := entry.Info(); err == nil {
+				backup.Size = info.Size()
+			}
+			backups = append(backups, backup)
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// Find returns the backup with the name
+func (r *DatabaseBackupRepositoryImpl) Find(ctx context.Context, name string) (*repository.DatabaseBackup, error) {
+	backup := r.parse(name)
+	if backup == nil || filepath.Base(name) != name {
+		return nil, fmt.Errorf("%w: %s", repository.ErrDatabaseBackupNotFound, name)
+	}
+	info, err := os.Stat(backup.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", repository.ErrDatabaseBackupNotFound, name)
+		}
+		return nil, err
+	}
+	backup.Size = info.Size()
+	return backup, nil
+}
+
+// Rotate removes all but the newest keep backups of the kind
+func (r *DatabaseBackupRepositoryImpl) Rotate(ctx context.Context, kind repository.DatabaseBackupKind, keep int) ([]*repository.DatabaseBackup, error) {
+	backups, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	removed := []*repository.DatabaseBackup{}
+	kept := 0
+	for _, backup := range backups {
+		if backup.Kind != kind {
+			continue
+		}
+		if kept < keep {
+			kept++
+			continue
+		}
+		if err := os.Remove(backup.Path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove old backup: %w", err)
+		}
+		removed = append(removed, backup)
+	}
+	return removed, nil
+}
+
+// parse reads the kind and time of a backup from its file name (nil for any other file)
+func (r *DatabaseBackupRepositoryImpl) parse(name string) *repository.DatabaseBackup {
+	rest, ok := strings.CutPrefix(name, filepath.Base(r.path)+".")
+	if !ok {
+		return nil
+	}
+	rest, ok = strings.CutSuffix(rest, ".bak")
+	if !ok {
+		return nil
+	}
+	idx := strings.LastIndex(rest, "-")
+	if idx < 0 {
+		return nil
+	}
+	createdAt, err := time.Parse(backupTimeLayout, rest[idx+1:])
+	if err != nil {
+		return nil
+	}
+
+	kind := repository.DatabaseBackupKind(rest[:idx])
+	switch {
+	case migrationBackupKind.MatchString(string(kind)):
+		kind = repository.DatabaseBackupMigration
+	case kind == repository.DatabaseBackupScheduled, kind == repository.DatabaseBackupManual, kind == repository.DatabaseBackupPreRestore:
+	default:
+		return nil
+	}
+	return &repository.DatabaseBackup{
+		Name:      name,
+		Kind:      kind,
+		Path:      filepath.Join(r.dir(), name),
+		CreatedAt: createdAt,
+	}
+}
+
+// RestoreDatabase replaces the database file at path with a copy of the backup file
+// Its WAL and shared-memory files are removed so that SQLite does not replay them onto the
+// restored copy. Nothing may have the database open.
+func RestoreDatabase(backup, path string) error {
+	src, err := os.Open(backup)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path+suffix, err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestDatabaseBackupRepository(t *testing.T) {
+	ctx := context.Background()
+	path := newTestDBFile(t)
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewDatabaseBackupRepository(db, path)
+
+	dir := filepath.Join(filepath.Dir(path), BackupDirName)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for _, other := range []string{"store.db.v30-20260101T000000Z.bak", "store.db.weekly-20260101T000000Z.bak", "other.db.manual-20260101T000000Z.bak"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, other), nil, 0644))
+	}
+
+	start := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	for day := 0; day < 3; day++ {
+		_, err := repo.Create(ctx, repository.DatabaseBackupScheduled, start.AddDate(0, 0, day))
+		require.NoError(t, err)
+	}
+	manual, err := repo.Create(ctx, repository.DatabaseBackupManual, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "store.db.manual-20260301T040000Z.bak", manual.Name)
+	assert.Positive(t, manual.Size)
+
+	backups, err := repo.List(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	assert.Equal(t, []string{
+		"store.db.scheduled-20260303T030000Z.bak",
+		"store.db.scheduled-20260302T030000Z.bak",
+		"store.db.manual-20260301T040000Z.bak",
+		"store.db.scheduled-20260301T030000Z.bak",
+		"store.db.v30-20260101T000000Z.bak",
+	}, names, "newest first; unknown kinds and other databases are ignored")
+	assert.Equal(t, repository.DatabaseBackupMigration, backups[4].Kind)
+
+	removed, err := repo.Rotate(ctx, repository.DatabaseBackupScheduled, 2)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "store.db.scheduled-20260301T030000Z.bak", removed[0].Name)
+	_, err = repo.Find(ctx, removed[0].Name)
+	assert.ErrorIs(t, err, repository.ErrDatabaseBackupNotFound)
+	_, err = repo.Find(ctx, manual.Name)
+	assert.NoError(t, err, "other kinds are not rotated")
+	_, err = repo.Find(ctx, "../store.db.manual-20260301T040000Z.bak")
+	assert.ErrorIs(t, err, repository.ErrDatabaseBackupNotFound)
+}
+
+func TestRestoreDatabase(t *testing.T) {
+	ctx := context.Background()
+	path := newTestDBFile(t)
+	db, err := sql.Open("sqlite3", path+"?_journal_mode=WAL")
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO sbis (id, title, status, current_step) VALUES ('SBI-1', 'Before', 'PENDING', 'PICK')`)
+	require.NoError(t, err)
+	backup, err := NewDatabaseBackupRepository(db, path).Create(ctx, repository.DatabaseBackupManual, time.Now())
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE sbis SET title = 'After' WHERE id = 'SBI-1'`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	require.NoError(t, RestoreDatabase(backup.Path, path))
+	_, err = os.Stat(path + "-wal")
+	assert.True(t, os.IsNotExist(err), "the WAL of the replaced database is not replayed")
+
+	restored, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer restored.Close()
+	var title string
+	require.NoError(t, restored.QueryRow("SELECT title FROM sbis WHERE id = 'SBI-1'").Scan(&title))
+	assert.Equal(t, "Before", title)
+}
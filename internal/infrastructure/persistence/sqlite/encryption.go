@@ -0,0 +1,209 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keySourceCommandTimeout bounds a cmd: key source (e.g. a keychain prompt)
+const keySourceCommandTimeout = 30 * time.Second
+
+// ErrSQLCipherUnavailable is returned when encryption is requested but SQLite is not SQLCipher
+var ErrSQLCipherUnavailable = errors.New("this build of deespec is not linked against SQLCipher")
+
+// encryptionKey is the SQLCipher key of the databases opened by the process ("" = plaintext)
+var encryptionKey struct {
+	sync.RWMutex
+	key string
+}
+
+// EnableEncryption makes every database opened through DSN use the SQLCipher key
+// SQLite must be SQLCipher (see `make build-sqlcipher`); otherwise the key would be ignored
+// and the database written in plain text, so ErrSQLCipherUnavailable is returned.
+func EnableEncryption(key string) error {
+	if key == "" {
+		return errors.New("database encryption key is empty")
+	}
+	version, err := CipherVersion()
+	if err != nil {
+		return err
+	}
+	if version == "" {
+		return fmt.Errorf("%w: rebuild it with `make build-sqlcipher` or disable database_encryption", ErrSQLCipherUnavailable)
+	}
+	encryptionKey.Lock()
+	encryptionKey.key = key
+	encryptionKey.Unlock()
+	return nil
+}
+
+// EncryptionEnabled reports whether databases are opened with an encryption key
+func EncryptionEnabled() bool {
+	encryptionKey.RLock()
+	defer encryptionKey.RUnlock()
+	return encryptionKey.key != ""
+}
+
+// CipherVersion returns the SQLCipher version of the linked SQLite, or "" for plain SQLite
+func CipherVersion() (string, error) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return "", fmt.Errorf("failed to open in-memory database: %w", err)
+	}
+	defer db.Close()
+
+	var version string
+	err = db.QueryRow("PRAGMA cipher_version").Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil // Plain SQLite ignores unknown pragmas
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query cipher version: %w", err)
+	}
+	return version, nil
+}
+
+// DSN returns the data source name of the database file at path with driver parameters
+// (e.g. "_foreign_keys=on"). With encryption enabled the key is passed as the SQLCipher "key"
+// URI parameter, so that it is applied before the driver reads the file.
+func DSN(path string, params ...string) string {
+	encryptionKey.RLock()
+	key := encryptionKey.key
+	encryptionKey.RUnlock()
+	if key != "" && path != ":memory:" {
+		params = append(params, "key="+escapeURIComponent(key))
+	}
+
+	dsn := fileURI(path)
+	if len(params) > 0 {
+		dsn += "?" + strings.Join(params, "&")
+	}
+	return dsn
+}
+
+// fileURI returns the SQLite URI of a database file
+func fileURI(path string) string {
+	return "file:" + strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(path)
+}
+
+// escapeURIComponent percent-encodes everything but unreserved characters
+// SQLite decodes %XX escapes in URI parameters, but not "+" as a space.
+func escapeURIComponent(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// ResolveEncryptionKey reads the database key from its source:
+//   - env:NAME     the environment variable NAME
+//   - file:PATH    the file at PATH (should be readable by the owner only)
+//   - cmd:COMMAND  the output of a shell command, e.g. a keychain or secret manager CLI
+//
+// Surrounding whitespace is trimmed; an empty key is an error.
+func ResolveEncryptionKey(source string) (string, error) {
+	kind, value, _ := strings.Cut(source, ":")
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("invalid key source %q (use env:NAME, file:PATH or cmd:COMMAND)", source)
+	}
+
+	var key string
+	switch kind {
+	case "env":
+		key = os.Getenv(value)
+		if key == "" {
+			return "", fmt.Errorf("database encryption key not set: environment variable %s is empty", value)
+		}
+	case "file":
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to read database encryption key: %w", err)
+		}
+		key = string(data)
+	case "cmd":
+		ctx, cancel := context.WithTimeout(context.Background(), keySourceCommandTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "sh", "-c", value)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("database encryption key command failed: %w", err)
+		}
+		key = string(out)
+	default:
+		return "", fmt.Errorf("invalid key source %q (use env:NAME, file:PATH or cmd:COMMAND)", source)
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", fmt.Errorf("database encryption key from %s is empty", kind)
+	}
+	return key, nil
+}
+
+// EncryptDatabase converts the plaintext database file at path to SQLCipher with the key
+// The database is exported into a new file that then replaces the original, so that no
+// plaintext page is left behind in it. Nothing else may use the database meanwhile.
+func EncryptDatabase(path, key string) error {
+	if version, err := CipherVersion(); err != nil {
+		return err
+	} else if version == "" {
+		return ErrSQLCipherUnavailable
+	}
+
+	// Fold the WAL into the database file so that the export sees every committed change
+	plain, err := sql.Open("sqlite3", fileURI(path)+"?_busy_timeout="+fmt.Sprint(DefaultBusyTimeoutMs))
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer plain.Close()
+	plain.SetMaxOpenConns(1) // ATTACH is per connection
+	var count int
+	if err := plain.QueryRow("SELECT count(*) FROM sqlite_master").Scan(&count); err != nil {
+		return fmt.Errorf("failed to read database (already encrypted?): %w", err)
+	}
+	if _, err := plain.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+
+	encrypted := path + ".encrypting"
+	_ = os.Remove(encrypted)
+	if _, err := plain.Exec("ATTACH DATABASE ? AS encrypted KEY ?", encrypted, key); err != nil {
+		return fmt.Errorf("failed to create encrypted database: %w", err)
+	}
+	if _, err := plain.Exec("SELECT sqlcipher_export('encrypted')"); err != nil {
+		_, _ = plain.Exec("DETACH DATABASE encrypted")
+		_ = os.Remove(encrypted)
+		return fmt.Errorf("failed to export database: %w", err)
+	}
+	if _, err := plain.Exec("DETACH DATABASE encrypted"); err != nil {
+		_ = os.Remove(encrypted)
+		return fmt.Errorf("failed to detach encrypted database: %w", err)
+	}
+	if err := plain.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	if err := os.Rename(encrypted, path); err != nil {
+		_ = os.Remove(encrypted)
+		return fmt.Errorf("failed to replace database: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		_ = os.Remove(path + suffix)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setTestEncryptionKey sets the key DSN adds for the rest of the test
+func setTestEncryptionKey(t *testing.T, key string) {
+	t.Helper()
+	encryptionKey.Lock()
+	previous := encryptionKey.key
+	encryptionKey.key = key
+	encryptionKey.Unlock()
+	t.Cleanup(func() {
+		encryptionKey.Lock()
+		encryptionKey.key = previous
+		encryptionKey.Unlock()
+	})
+}
+
+func TestDSN(t *testing.T) {
+	setTestEncryptionKey(t, "")
+	assert.Equal(t, "file:.deespec/deespec.db", DSN(".deespec/deespec.db"))
+	assert.Equal(t, "file:/tmp/a%3fb%23c.db?mode=ro&_foreign_keys=on", DSN("/tmp/a?b#c.db", "mode=ro", "_foreign_keys=on"))
+	assert.False(t, EncryptionEnabled())
+
+	setTestEncryptionKey(t, "p@ss word&key=1+")
+	assert.True(t, EncryptionEnabled())
+	assert.Equal(t, "file:/tmp/x.db?_foreign_keys=on&key=p%40ss%20word%26key%3D1%2B", DSN("/tmp/x.db", "_foreign_keys=on"))
+	assert.Equal(t, "file::memory:", DSN(":memory:"), "in-memory databases are not encrypted")
+
+	// The driver still understands its own parameters next to the key
+	path := filepath.Join(t.TempDir(), "dsn.db")
+	db, err := sql.Open("sqlite3", DSN(path, "_foreign_keys=on", "_journal_mode=WAL"))
+	require.NoError(t, err)
+	defer db.Close()
+	var journalMode string
+	require.NoError(t, db.QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+}
+
+func TestEnableEncryption_RequiresSQLCipher(t *testing.T) {
+	version, err := CipherVersion()
+	require.NoError(t, err)
+	if version != "" {
+		t.Skipf("linked against SQLCipher %s", version)
+	}
+	setTestEncryptionKey(t, "")
+
+	err = EnableEncryption("secret")
+	assert.ErrorIs(t, err, ErrSQLCipherUnavailable)
+	assert.False(t, EncryptionEnabled(), "a key SQLite would ignore must not be used")
+	assert.ErrorIs(t, EncryptDatabase(filepath.Join(t.TempDir(), "plain.db"), "secret"), ErrSQLCipherUnavailable)
+
+	assert.Error(t, EnableEncryption(""))
+}
+
+func TestResolveEncryptionKey(t *testing.T) {
+	t.Setenv("DEESPEC_TEST_DB_KEY", " from-env\n")
+	keyFile := filepath.Join(t.TempDir(), "db.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("from-file\n"), 0600))
+
+	tests := []struct {
+		source  string
+		want    string
+		wantErr string
+	}{
+		{source: "env:DEESPEC_TEST_DB_KEY", want: "from-env"},
+		{source: "file:" + keyFile, want: "from-file"},
+		{source: "cmd:echo from-cmd", want: "from-cmd"},
+		{source: "env:DEESPEC_TEST_DB_KEY_UNSET", wantErr: "environment variable DEESPEC_TEST_DB_KEY_UNSET is empty"},
+		{source: "file:" + keyFile + ".missing", wantErr: "failed to read database encryption key"},
+		{source: "cmd:exit 1", wantErr: "key command failed"},
+		{source: "cmd:true", wantErr: "key from cmd is empty"},
+		{source: "vault:deespec", wantErr: "invalid key source"},
+		{source: "env:", wantErr: "invalid key source"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, err := ResolveEncryptionKey(tt.source)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -46,7 +46,7 @@ func (r *EPICRepositoryImpl) Find(ctx context.Context, id repository.EPICID) (*e
 	query := `
 		SELECT id, title, description, status, current_step,
 		       estimated_story_points, priority, labels, assigned_agent,
-		       created_at, updated_at
+		       created_at, updated_at, version
 		FROM epics
 		WHERE id = ?
 	`
@@ -56,6 +56,8 @@ func (r *EPICRepositoryImpl) Find(ctx context.Context, id repository.EPICID) (*e
 }
 
 // Save persists an EPIC entity
+// The write succeeds only at the version the EPIC was loaded at (repository.ErrStaleEntity otherwise)
+// and increments it
 func (r *EPICRepositoryImpl) Save(ctx context.Context, e *epic.EPIC) error {
 	metadata := e.Metadata()
 
@@ -68,8 +70,8 @@ func (r *EPICRepositoryImpl) Save(ctx context.Context, e *epic.EPIC) error {
 	query := `
 		INSERT INTO epics (id, title, description, status, current_step,
 		                   estimated_story_points, priority, labels, assigned_agent,
-		                   created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                   created_at, updated_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			title = excluded.title,
 			description = excluded.description,
@@ -79,20 +81,32 @@ func (r *EPICRepositoryImpl) Save(ctx context.Context, e *epic.EPIC) error {
 			priority = excluded.priority,
 			labels = excluded.labels,
 			assigned_agent = excluded.assigned_agent,
-			updated_at = excluded.updated_at
+			updated_at = excluded.updated_at,
+			version = epics.version + 1
+		WHERE epics.version = ?
 	`
 
 	db := r.getDB(ctx)
-	_, err = db.ExecContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		e.ID().String(), e.Title(), e.Description(),
 		string(e.Status()), string(e.CurrentStep()),
 		metadata.EstimatedStoryPoints, metadata.Priority, string(labelsJSON), metadata.AssignedAgent,
-		e.CreatedAt().Value(), e.UpdatedAt().Value(),
+		e.CreatedAt().Value(), e.UpdatedAt().Value(), e.Version()+1,
+		e.Version(),
 	)
 	if err != nil {
 		return fmt.Errorf("save EPIC failed: %w", err)
 	}
 
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected failed: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("save EPIC %s: %w", e.ID().String(), repository.ErrStaleEntity)
+	}
+	e.SetVersion(e.Version() + 1)
+
 	// Update PBI relationships
 	if err := r.updatePBIRelationships(ctx, e); err != nil {
 		return fmt.Errorf("update PBI relationships failed: %w", err)
@@ -115,7 +129,7 @@ func (r *EPICRepositoryImpl) Delete(ctx context.Context, id repository.EPICID) e
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("EPIC not found: %s", id)
+		return fmt.Errorf("EPIC %w: %s", model.ErrNotFound, id)
 	}
 
 	return nil
@@ -126,7 +140,7 @@ func (r *EPICRepositoryImpl) List(ctx context.Context, filter repository.EPICFil
 	query := `
 		SELECT id, title, description, status, current_step,
 		       estimated_story_points, priority, labels, assigned_agent,
-		       created_at, updated_at
+		       created_at, updated_at, version
 		FROM epics
 		WHERE 1=1
 	`
@@ -184,7 +198,7 @@ func (r *EPICRepositoryImpl) FindByPBIID(ctx context.Context, pbiID repository.P
 	query := `
 		SELECT e.id, e.title, e.description, e.status, e.current_step,
 		       e.estimated_story_points, e.priority, e.labels, e.assigned_agent,
-		       e.created_at, e.updated_at
+		       e.created_at, e.updated_at, e.version
 		FROM epics e
 		INNER JOIN epic_pbis ep ON e.id = ep.epic_id
 		WHERE ep.pbi_id = ?
@@ -208,16 +222,17 @@ func (r *EPICRepositoryImpl) scanEPIC(row *sql.Row) (*epic.EPIC, error) {
 		assignedAgent        sql.NullString
 		createdAt            string
 		updatedAt            string
+		version              int
 	)
 
 	err := row.Scan(
 		&epicID, &title, &description, &status, &currentStep,
 		&estimatedStoryPoints, &priority, &labelsJSON, &assignedAgent,
-		&createdAt, &updatedAt,
+		&createdAt, &updatedAt, &version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("EPIC not found")
+			return nil, fmt.Errorf("EPIC %w", model.ErrNotFound)
 		}
 		return nil, fmt.Errorf("scan EPIC failed: %w", err)
 	}
@@ -234,7 +249,7 @@ func (r *EPICRepositoryImpl) scanEPIC(row *sql.Row) (*epic.EPIC, error) {
 
 	return r.reconstructEPIC(epicID, title, description, status, currentStep,
 		estimatedStoryPoints, priority, labelsJSON, assignedAgent,
-		createdAtTime, updatedAtTime, context.Background())
+		createdAtTime, updatedAtTime, version, context.Background())
 }
 
 // scanEPICFromRows scans a single EPIC from rows
@@ -251,12 +266,13 @@ func (r *EPICRepositoryImpl) scanEPICFromRows(rows *sql.Rows, ctx context.Contex
 		assignedAgent        sql.NullString
 		createdAt            string
 		updatedAt            string
+		version              int
 	)
 
 	err := rows.Scan(
 		&epicID, &title, &description, &status, &currentStep,
 		&estimatedStoryPoints, &priority, &labelsJSON, &assignedAgent,
-		&createdAt, &updatedAt,
+		&createdAt, &updatedAt, &version,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("scan EPIC failed: %w", err)
@@ -274,7 +290,7 @@ func (r *EPICRepositoryImpl) scanEPICFromRows(rows *sql.Rows, ctx context.Contex
 
 	return r.reconstructEPIC(epicID, title, description, status, currentStep,
 		estimatedStoryPoints, priority, labelsJSON, assignedAgent,
-		createdAtTime, updatedAtTime, ctx)
+		createdAtTime, updatedAtTime, version, ctx)
 }
 
 // reconstructEPIC reconstructs an EPIC entity from database values
@@ -285,6 +301,7 @@ func (r *EPICRepositoryImpl) reconstructEPIC(
 	estimatedStoryPoints, priority int,
 	labelsJSON, assignedAgent sql.NullString,
 	createdAt, updatedAt time.Time,
+	version int,
 	ctx context.Context,
 ) (*epic.EPIC, error) {
 	// Unmarshal JSON arrays
@@ -315,7 +332,7 @@ func (r *EPICRepositoryImpl) reconstructEPIC(
 		return nil, fmt.Errorf("query PBI IDs failed: %w", err)
 	}
 
-	return epic.ReconstructEPIC(
+	e := epic.ReconstructEPIC(
 		taskID,
 		title,
 		description.String,
@@ -325,7 +342,9 @@ func (r *EPICRepositoryImpl) reconstructEPIC(
 		metadata,
 		createdAt,
 		updatedAt,
-	), nil
+	)
+	e.SetVersion(version)
+	return e, nil
 }
 
 // parseTime parses a time string in RFC3339 format
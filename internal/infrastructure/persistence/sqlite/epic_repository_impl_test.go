@@ -775,3 +775,34 @@ func TestEPICRepositoryImpl_PaginationBoundaries(t *testing.T) {
 		})
 	}
 }
+
+func TestEPICRepositoryImpl_SaveChecksVersion(t *testing.T) {
+	db := setupTestDBForEPIC(t)
+	defer db.Close()
+
+	repo := NewEPICRepository(db)
+	ctx := context.Background()
+
+	e, err := epic.NewEPIC("Versioned", "Description", epic.EPICMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, e))
+	assert.Equal(t, 1, e.Version())
+
+	epicID := repository.EPICID(e.ID().String())
+	stale, err := repo.Find(ctx, epicID)
+	require.NoError(t, err)
+
+	// Saving the in-memory EPIC again moves the stored version past the loaded copy
+	require.NoError(t, e.UpdateTitle("Updated"))
+	require.NoError(t, repo.Save(ctx, e))
+	assert.Equal(t, 2, e.Version())
+
+	require.NoError(t, stale.UpdateTitle("Overwritten"))
+	err = repo.Save(ctx, stale)
+	assert.ErrorIs(t, err, repository.ErrStaleEntity)
+
+	found, err := repo.Find(ctx, epicID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", found.Title())
+	assert.Equal(t, 2, found.Version())
+}
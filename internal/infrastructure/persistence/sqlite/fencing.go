@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
+)
+
+// withinTx runs fn in the transaction carried by ctx, or in a new one if there is none
+func withinTx(ctx context.Context, db *sql.DB, fn func(txCtx context.Context) error) error {
+	if _, ok := transaction.GetTxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return transaction.NewSQLiteTransactionManager(db).InTransaction(ctx, fn)
+}
+
+// issueFencingToken increments and returns the fencing token of a lock ID
+// Tokens are kept in lock_fencing_tokens so they keep increasing across release and expiry
+func issueFencingToken(ctx context.Context, db dbExecutor, lockID lock.LockID) (int64, error) {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO lock_fencing_tokens (lock_id, token, updated_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT(lock_id) DO UPDATE SET
+			token = token + 1,
+			updated_at = excluded.updated_at
+	`, lockID.String(), time.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return 0, fmt.Errorf("issue fencing token: %w", err)
+	}
+
+	var token int64
+	if err := db.QueryRowContext(ctx,
+		`SELECT token FROM lock_fencing_tokens WHERE lock_id = ?`, lockID.String(),
+	).Scan(&token); err != nil {
+		return 0, fmt.Errorf("read fencing token: %w", err)
+	}
+	return token, nil
+}
+
+// checkFence rejects a write whose fence is older than the newest token issued for its lock
+// Writes without a fence in ctx are not guarded
+func checkFence(ctx context.Context, db dbExecutor) error {
+	fence, ok := lock.FenceFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var current int64
+	err := db.QueryRowContext(ctx,
+		`SELECT token FROM lock_fencing_tokens WHERE lock_id = ?`, fence.LockID.String(),
+	).Scan(&current)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read fencing token: %w", err)
+	}
+
+	if current > fence.Token {
+		return fmt.Errorf("%w: lock %s is now held with token %d, write carries token %d",
+			lock.ErrStaleFencingToken, fence.LockID.String(), current, fence.Token)
+	}
+	return nil
+}
+
+// fenceCondition narrows a lock row update to the holder of the fence in ctx
+// so that a stale holder cannot release or refresh a lock that was taken over
+func fenceCondition(ctx context.Context, lockID lock.LockID) (string, []interface{}) {
+	fence, ok := lock.FenceFromContext(ctx)
+	if !ok || !fence.LockID.Equals(lockID) {
+		return "", nil
+	}
+	return " AND fencing_token = ?", []interface{}{fence.Token}
+}
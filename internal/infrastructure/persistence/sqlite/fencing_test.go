@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestFencingToken_IncreasesAcrossReleaseAndTakeover(t *testing.T) {
+	db := setupTestDBForLock(t)
+	defer db.Close()
+
+	repo := NewStateLockRepository(db)
+	ctx := context.Background()
+	lockID, _ := lock.NewLockID("sbi/SBI-FENCE-001")
+
+	first, err := repo.Acquire(ctx, lockID, lock.LockTypeWrite, 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.Equal(t, int64(1), first.FencingToken())
+
+	// Token survives release
+	require.NoError(t, repo.Release(ctx, lockID))
+	second, err := repo.Acquire(ctx, lockID, lock.LockTypeWrite, -time.Second) // Already expired
+	require.NoError(t, err)
+	require.NotNil(t, second)
+	assert.Equal(t, int64(2), second.FencingToken())
+
+	// Takeover of the expired lease issues a newer token
+	third, err := repo.Acquire(ctx, lockID, lock.LockTypeWrite, 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, third)
+	assert.Equal(t, int64(3), third.FencingToken())
+
+	found, err := repo.Find(ctx, lockID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), found.FencingToken())
+
+	// A failed acquisition does not consume a token
+	held, err := repo.Acquire(ctx, lockID, lock.LockTypeWrite, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, held)
+	require.NoError(t, repo.Release(ctx, lockID))
+	next, err := repo.Acquire(ctx, lockID, lock.LockTypeWrite, 5*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), next.FencingToken())
+}
+
+func TestFencingToken_StaleHolderWritesRejected(t *testing.T) {
+	db := setupTestDBForLock(t)
+	defer db.Close()
+
+	lockRepo := NewStateLockRepository(db)
+	sbiRepo := NewSBIRepository(db)
+	ctx := context.Background()
+
+	s, err := sbi.NewSBI("Fenced task", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, s))
+	lockID, _ := lock.NewLockID("sbi/" + s.ID().String())
+
+	// Runner A acquires a lease that then expires (laptop goes to sleep)
+	stale, err := lockRepo.Acquire(ctx, lockID, lock.LockTypeWrite, -time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, stale)
+	staleCtx := lock.WithFence(ctx, stale.Fence())
+
+	// Runner B takes over
+	current, err := lockRepo.Acquire(ctx, lockID, lock.LockTypeWrite, 5*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, current)
+	currentCtx := lock.WithFence(ctx, current.Fence())
+
+	s.UpdateDescription("written by B")
+	require.NoError(t, sbiRepo.Save(currentCtx, s))
+
+	// Runner A wakes up: its write, heartbeat and release are all rejected
+	s.UpdateDescription("written by A")
+	err = sbiRepo.Save(staleCtx, s)
+	assert.ErrorIs(t, err, lock.ErrStaleFencingToken)
+	assert.Error(t, lockRepo.UpdateHeartbeat(staleCtx, lockID))
+	assert.Error(t, lockRepo.Release(staleCtx, lockID))
+
+	saved, err := sbiRepo.Find(ctx, repository.SBIID(s.ID().String()))
+	require.NoError(t, err)
+	assert.Equal(t, "written by B", saved.Description())
+
+	// Runner B still holds the lock
+	held, err := lockRepo.Find(ctx, lockID)
+	require.NoError(t, err)
+	assert.Equal(t, current.FencingToken(), held.FencingToken())
+	require.NoError(t, lockRepo.Release(currentCtx, lockID))
+}
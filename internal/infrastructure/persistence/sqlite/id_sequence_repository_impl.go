@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// IDSequenceRepositoryImpl implements IDSequenceRepository using SQLite
+type IDSequenceRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewIDSequenceRepository creates a new IDSequenceRepository implementation
+func NewIDSequenceRepository(db *sql.DB) repository.IDSequenceRepository {
+	return &IDSequenceRepositoryImpl{db: db}
+}
+
+// NextID returns the next unused ID of a prefix
+// The sequence is advanced before anything is read, so the transaction holds the write lock
+// throughout and concurrent callers never get the same number.
+func (r *IDSequenceRepositoryImpl) NextID(ctx context.Context, prefix string, digits int) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO id_sequences (prefix, last_value) VALUES (?, 1)
+		ON CONFLICT(prefix) DO UPDATE SET last_value = last_value + 1
+	`, prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to advance ID sequence: %w", err)
+	}
+	var n int
+	if err := tx.QueryRowContext(ctx, `SELECT last_value FROM id_sequences WHERE prefix = ?`, prefix).Scan(&n); err != nil {
+		return "", fmt.Errorf("failed to read ID sequence: %w", err)
+	}
+
+	// Skip IDs that exist already, e.g. restored from a snapshot or created before a scheme change
+	for {
+		id := fmt.Sprintf("%s%0*d", prefix, digits, n)
+		var taken int
+		err := tx.QueryRowContext(ctx, `
+			SELECT (SELECT COUNT(*) FROM sbis WHERE id = ?) + (SELECT COUNT(*) FROM pbis WHERE id = ?) +
+			       (SELECT COUNT(*) FROM epics WHERE id = ?) + (SELECT COUNT(*) FROM sbi_tombstones WHERE id = ?)
+		`, id, id, id, id).Scan(&taken)
+		if err != nil {
+			return "", fmt.Errorf("failed to check ID collision: %w", err)
+		}
+		if taken == 0 {
+			break
+		}
+		n++
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE id_sequences SET last_value = ? WHERE prefix = ?`, n, prefix); err != nil {
+		return "", fmt.Errorf("failed to update ID sequence: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return fmt.Sprintf("%s%0*d", prefix, digits, n), nil
+}
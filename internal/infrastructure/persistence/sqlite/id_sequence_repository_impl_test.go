@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDSequenceRepository_NextID(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	// SBI-2024-0002 was imported and TASK-01 archived
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-2024-0002', 'Imported', 'PENDING', 'PICK', 0)
+	`)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		INSERT INTO sbi_tombstones (id, title, status, archive_path, archived_at)
+		VALUES ('TASK-01', 'Archived', 'DONE', 'archive/TASK-01.tar.gz', '2024-05-01T00:00:00Z')
+	`)
+	require.NoError(t, err)
+
+	repo := NewIDSequenceRepository(db)
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, err := repo.NextID(ctx, "SBI-2024-", 4)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"SBI-2024-0001", "SBI-2024-0003", "SBI-2024-0004"}, ids, "existing IDs are skipped")
+
+	id, err := repo.NextID(ctx, "SBI-2025-", 4)
+	require.NoError(t, err)
+	assert.Equal(t, "SBI-2025-0001", id, "each prefix has its own sequence")
+
+	id, err = repo.NextID(ctx, "TASK-", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-02", id, "archived IDs are never reused")
+}
@@ -0,0 +1,362 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrDatabaseCorrupted is returned when a database fails its integrity check
+var ErrDatabaseCorrupted = errors.New("database is corrupted")
+
+// Pre-migration backups
+const (
+	// BackupDirName is the directory next to the database file holding its backups
+	BackupDirName = "backups"
+	// DefaultMigrationBackups is the number of pre-migration backups kept per database
+	DefaultMigrationBackups = 3
+)
+
+// CheckIntegrity runs PRAGMA integrity_check on the database and returns the problems it reports
+// A database with problems, or that SQLite cannot read at all (not a database, wrong key),
+// fails with ErrDatabaseCorrupted; other errors (e.g. a busy database) are returned as they are.
+func CheckIntegrity(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("PRAGMA integrity_check")
+	if err != nil {
+		return nil, integrityError(err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, integrityError(err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return problems, integrityError(err)
+	}
+	if len(problems) > 0 {
+		return problems, fmt.Errorf("%w: %s", ErrDatabaseCorrupted, problems[0])
+	}
+	return nil, nil
+}
+
+// integrityError marks the SQLite errors of a damaged or unreadable file as ErrDatabaseCorrupted
+func integrityError(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB) {
+		return fmt.Errorf("%w: %v", ErrDatabaseCorrupted, err)
+	}
+	return fmt.Errorf("integrity check failed: %w", err)
+}
+
+// DatabaseFile returns the path of the file holding the main database, or "" for an in-memory database
+func DatabaseFile(db *sql.DB) (string, error) {
+	rows, err := db.Query("PRAGMA database_list")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			seq        int
+			name, file string
+		)
+		if err := rows.Scan(&seq, &name, &file); err != nil {
+			return "", err
+		}
+		if name == "main" {
+			return file, nil
+		}
+	}
+	return "", rows.Err()
+}
+
+// BackupDatabase writes a consistent copy of the database to dest, which must not exist
+// An encrypted database is exported with its own key, so that the copy is encrypted as well.
+func BackupDatabase(db *sql.DB, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	encryptionKey.RLock()
+	key := encryptionKey.key
+	encryptionKey.RUnlock()
+	if key == "" {
+		if _, err := db.Exec("VACUUM INTO ?", dest); err != nil {
+			_ = os.Remove(dest)
+			return fmt.Errorf("failed to back up database: %w", err)
+		}
+		return nil
+	}
+
+	// ATTACH is per connection, so the export runs on a dedicated one
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(context.Background(), "ATTACH DATABASE ? AS backup KEY ?", dest, key); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	_, err = conn.ExecContext(context.Background(), "SELECT sqlcipher_export('backup')")
+	_, _ = conn.ExecContext(context.Background(), "DETACH DATABASE backup")
+	if err != nil {
+		_ = os.Remove(dest)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
+
+// BackupBeforeMigration backs up the database file at path into the backups directory next to it
+// as <name>.v<version>-<UTC time>.bak, keeping only the newest keep backups of the file
+func BackupBeforeMigration(db *sql.DB, path string, version, keep int) (string, error) {
+	dir := filepath.Join(filepath.Dir(path), BackupDirName)
+	name := filepath.Base(path)
+	dest := filepath.Join(dir, fmt.Sprintf("%s.v%d-%s.bak", name, version, time.Now().UTC().Format(backupTimeLayout)))
+	if _, err := os.Stat(dest); err == nil {
+		return dest, nil // Already backed up this second by a concurrent command
+	}
+	if err := BackupDatabase(db, dest); err != nil {
+		return "", err
+	}
+	return dest, rotateBackups(dir, name, keep)
+}
+
+// rotateBackups removes all but the newest keep backups of the database file name
+func rotateBackups(dir, name string, keep int) error {
+	backups, err := filepath.Glob(filepath.Join(dir, name+".v*.bak"))
+	if err != nil {
+		return err
+	}
+	if len(backups) <= keep {
+		return nil
+	}
+	// The UTC time in the name sorts chronologically within a version; versions only grow
+	sort.Slice(backups, func(i, j int) bool {
+		return backupTime(backups[i]).Before(backupTime(backups[j]))
+	})
+	for _, backup := range backups[:len(backups)-keep] {
+		if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// backupTime returns the time a backup was taken, from its name (zero when unparsable)
+func backupTime(path string) time.Time {
+	name := strings.TrimSuffix(filepath.Base(path), ".bak")
+	idx := strings.LastIndex(name, "-")
+	if idx < 0 {
+		return time.Time{}
+	}
+	t, _ := time.Parse(backupTimeLayout, name[idx+1:])
+	return t
+}
+
+// MoveDatabaseAside renames the database file at path and its WAL and shared-memory files to
+// <path>.<label>-<UTC time>, numbered when that name is taken, and returns the new path
+func MoveDatabaseAside(path, label string) (string, error) {
+	stamp := time.Now().UTC().Format(backupTimeLayout)
+	moved := fmt.Sprintf("%s.%s-%s", path, label, stamp)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(moved); os.IsNotExist(err) {
+			break
+		}
+		moved = fmt.Sprintf("%s.%s-%s-%d", path, label, stamp, n)
+	}
+	if err := os.Rename(path, moved); err != nil {
+		return "", fmt.Errorf("failed to move the database aside: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Rename(path+suffix, moved+suffix); err != nil && !os.IsNotExist(err) {
+			return moved, fmt.Errorf("failed to move %s aside: %w", path+suffix, err)
+		}
+	}
+	return moved, nil
+}
+
+// SalvagedTable reports what was copied out of one table of a damaged database
+type SalvagedTable struct {
+	Table  string `json:"table"`
+	Rows   int64  `json:"rows"`
+	Failed int64  `json:"failed,omitempty"` // Rows that could not be read
+	Error  string `json:"error,omitempty"`  // Why the table could not be read at all
+}
+
+// SalvageDatabase copies every readable row of the damaged database file at path into the tables
+// of db (already migrated) that exist in both, over the columns they share. Rows already present
+// are kept. A table whose bulk copy fails is copied row by row, skipping the unreadable rows.
+func SalvageDatabase(ctx context.Context, db *sql.DB, path string) ([]SalvagedTable, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Rows are copied table by table, so references are only valid once every table is copied
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return nil, err
+	}
+	defer conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	// Without a KEY the attached file is opened with the key of the main database
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS damaged", path); err != nil {
+		return nil, fmt.Errorf("failed to open damaged database: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "DETACH DATABASE damaged")
+
+	tables, err := tableNames(ctx, conn, "main")
+	if err != nil {
+		return nil, err
+	}
+	damagedTables, err := tableNames(ctx, conn, "damaged")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read damaged database schema: %w", err)
+	}
+	present := make(map[string]bool, len(damagedTables))
+	for _, table := range damagedTables {
+		present[table] = true
+	}
+
+	var salvaged []SalvagedTable
+	for _, table := range tables {
+		if table == "schema_migrations" || !present[table] {
+			continue
+		}
+		salvaged = append(salvaged, salvageTable(ctx, conn, table))
+	}
+	return salvaged, nil
+}
+
+// salvageTable copies the readable rows of one table of the attached damaged database
+func salvageTable(ctx context.Context, conn *sql.Conn, table string) SalvagedTable {
+	result := SalvagedTable{Table: table}
+	columns, err := sharedColumns(ctx, conn, table)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if len(columns) == 0 {
+		result.Error = "no column in common"
+		return result
+	}
+	list := strings.Join(columns, ", ")
+	insert := fmt.Sprintf("INSERT OR IGNORE INTO main.%s (%s) SELECT %s FROM damaged.%s", quoteIdent(table), list, list, quoteIdent(table))
+
+	if res, err := conn.ExecContext(ctx, insert); err == nil {
+		result.Rows, _ = res.RowsAffected()
+		return result
+	}
+
+	// The bulk copy stops at the first unreadable page: copy whatever rows can be reached one by one
+	rowids, scanErr := readableRowIDs(ctx, conn, table)
+	for _, rowid := range rowids {
+		res, err := conn.ExecContext(ctx, insert+" WHERE rowid = ?", rowid)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+		n, _ := res.RowsAffected()
+		result.Rows += n
+	}
+	if scanErr != nil {
+		result.Error = scanErr.Error()
+	}
+	return result
+}
+
+// readableRowIDs returns the row IDs of a damaged table up to the first unreadable page
+func readableRowIDs(ctx context.Context, conn *sql.Conn, table string) ([]int64, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT rowid FROM damaged.%s", quoteIdent(table)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var rowids []int64
+	for rows.Next() {
+		var rowid int64
+		if err := rows.Scan(&rowid); err != nil {
+			return rowids, err
+		}
+		rowids = append(rowids, rowid)
+	}
+	return rowids, rows.Err()
+}
+
+// tableNames returns the tables of an attached schema
+func tableNames(ctx context.Context, conn *sql.Conn, schema string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT name FROM %s.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%%' ORDER BY name", schema))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// sharedColumns returns the quoted columns a table has in both the main and the damaged database
+func sharedColumns(ctx context.Context, conn *sql.Conn, table string) ([]string, error) {
+	mainColumns, err := columnNames(ctx, conn, "main", table)
+	if err != nil {
+		return nil, err
+	}
+	damagedColumns, err := columnNames(ctx, conn, "damaged", table)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[string]bool, len(damagedColumns))
+	for _, column := range damagedColumns {
+		present[column] = true
+	}
+	var shared []string
+	for _, column := range mainColumns {
+		if present[column] {
+			shared = append(shared, quoteIdent(column))
+		}
+	}
+	return shared, nil
+}
+
+// columnNames returns the columns of a table in an attached schema
+func columnNames(ctx context.Context, conn *sql.Conn, schema, table string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT name FROM %s.pragma_table_info(?)", schema), table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return names, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// quoteIdent quotes an SQL identifier
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
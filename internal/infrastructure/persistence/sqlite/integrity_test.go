@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	db, err := sql.Open("sqlite3", newTestDBFile(t))
+	require.NoError(t, err)
+	defer db.Close()
+	problems, err := CheckIntegrity(db)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	garbage := filepath.Join(t.TempDir(), "garbage.db")
+	require.NoError(t, os.WriteFile(garbage, []byte("this is not an SQLite database, only some bytes long enough to be read as a header"), 0644))
+	damaged, err := sql.Open("sqlite3", garbage)
+	require.NoError(t, err)
+	defer damaged.Close()
+	_, err = CheckIntegrity(damaged)
+	assert.ErrorIs(t, err, ErrDatabaseCorrupted)
+}
+
+func TestBackupBeforeMigration(t *testing.T) {
+	path := newTestDBFile(t)
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	file, err := DatabaseFile(db)
+	require.NoError(t, err)
+	assert.Equal(t, path, file)
+
+	dir := filepath.Join(filepath.Dir(path), BackupDirName)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for _, old := range []string{"store.db.v9-20250101T000000Z.bak", "store.db.v12-20250201T000000Z.bak", "store.db.v30-20250301T000000Z.bak"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, old), nil, 0644))
+	}
+
+	backup, err := BackupBeforeMigration(db, path, 34, 3)
+	require.NoError(t, err)
+	copied, err := sql.Open("sqlite3", backup)
+	require.NoError(t, err)
+	defer copied.Close()
+	var version int
+	require.NoError(t, copied.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version))
+	assert.Equal(t, 34, version)
+
+	backups, err := filepath.Glob(filepath.Join(dir, "store.db.v*.bak"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "store.db.v12-20250201T000000Z.bak"),
+		filepath.Join(dir, "store.db.v30-20250301T000000Z.bak"),
+		backup,
+	}, backups, "the oldest backup is rotated out")
+}
+
+func TestSalvageDatabase(t *testing.T) {
+	ctx := context.Background()
+	damagedPath := newTestDBFile(t)
+	damaged, err := sql.Open("sqlite3", damagedPath)
+	require.NoError(t, err)
+	_, err = damaged.Exec(`INSERT INTO sbis (id, title, status, current_step) VALUES ('SBI-1', 'Kept', 'PENDING', 'PICK')`)
+	require.NoError(t, err)
+	_, err = damaged.Exec(`INSERT INTO sbi_notes (sbi_id, body, created_at) VALUES ('SBI-1', 'A note', CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+	_, err = damaged.Exec(`CREATE TABLE retired (id TEXT)`)
+	require.NoError(t, err)
+	require.NoError(t, damaged.Close())
+
+	db, err := sql.Open("sqlite3", newTestDBFile(t)+"?_foreign_keys=on")
+	require.NoError(t, err)
+	defer db.Close()
+	salvaged, err := SalvageDatabase(ctx, db, damagedPath)
+	require.NoError(t, err)
+
+	rows := make(map[string]int64)
+	for _, table := range salvaged {
+		assert.Empty(t, table.Error, table.Table)
+		rows[table.Table] = table.Rows
+	}
+	assert.Equal(t, int64(1), rows["sbis"])
+	assert.Equal(t, int64(1), rows["sbi_notes"])
+	assert.NotContains(t, rows, "retired", "tables the current schema does not have are left behind")
+	assert.NotContains(t, rows, "schema_migrations")
+
+	var title string
+	require.NoError(t, db.QueryRow("SELECT title FROM sbis WHERE id = 'SBI-1'").Scan(&title))
+	assert.Equal(t, "Kept", title)
+}
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
+)
+
+// JournalOutboxRepository makes journal appends part of SQLite transactions
+// Inside a transaction a record is written to journal_outbox, so that it commits or rolls back
+// together with the entity it describes; Flush then moves committed records to the journal.
+// Outside a transaction records are appended to the journal directly.
+type JournalOutboxRepository struct {
+	store   *Store
+	journal repository.JournalRepository
+}
+
+// NewJournalOutboxRepository creates a journal outbox in front of journal
+func NewJournalOutboxRepository(store *Store, journal repository.JournalRepository) *JournalOutboxRepository {
+	return &JournalOutboxRepository{store: store, journal: journal}
+}
+
+// Append adds a record to the outbox when ctx carries a transaction, and to the journal otherwise
+func (r *JournalOutboxRepository) Append(ctx context.Context, record *repository.JournalRecord) error {
+	tx, ok := transaction.GetTxFromContext(ctx)
+	if !ok {
+		return r.journal.Append(ctx, record)
+	}
+
+	// The record is stamped when it is written, not when it is flushed
+	keyed := *record
+	if keyed.Timestamp == "" {
+		keyed.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	data, err := json.Marshal(&keyed)
+	if err != nil {
+		return fmt.Errorf("encode journal record: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO journal_outbox (record, created_at) VALUES (?, ?)`,
+		string(data), time.Now().UTC().Format(time.RFC3339),
+	); err != nil {
+		return fmt.Errorf("insert journal outbox record: %w", err)
+	}
+	return nil
+}
+
+// Flush moves committed outbox records to the journal, oldest first, and returns how many were moved
+// A record is deleted only after it was appended; since appends are idempotent, a record
+// flushed twice (after a crash, or by two processes at once) is journaled once.
+func (r *JournalOutboxRepository) Flush(ctx context.Context) (int, error) {
+	if _, ok := transaction.GetTxFromContext(ctx); ok {
+		return 0, errors.New("journal outbox cannot be flushed inside a transaction")
+	}
+
+	type pending struct {
+		id     int64
+		record repository.JournalRecord
+	}
+	rows, err := r.store.readStmts.QueryContext(ctx, `SELECT id, record FROM journal_outbox ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("query journal outbox: %w", err)
+	}
+	var records []pending
+	for rows.Next() {
+		var (
+			p    pending
+			data string
+		)
+		if err := rows.Scan(&p.id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan journal outbox record: %w", err)
+		}
+		if err := json.Unmarshal([]byte(data), &p.record); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("decode journal outbox record %d: %w", p.id, err)
+		}
+		records = append(records, p)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("iterate journal outbox: %w", err)
+	}
+	rows.Close()
+
+	for i, p := range records {
+		if err := r.journal.Append(ctx, &p.record); err != nil {
+			return i, err
+		}
+		if _, err := r.store.writeStmts.ExecContext(ctx, `DELETE FROM journal_outbox WHERE id = ?`, p.id); err != nil {
+			return i, fmt.Errorf("delete journal outbox record %d: %w", p.id, err)
+		}
+	}
+	return len(records), nil
+}
+
+// Load retrieves all journal records, flushing committed outbox records first
+func (r *JournalOutboxRepository) Load(ctx context.Context) ([]*repository.JournalRecord, error) {
+	r.flushBeforeRead(ctx)
+	return r.journal.Load(ctx)
+}
+
+// FindByTurn retrieves records for a specific turn, flushing committed outbox records first
+func (r *JournalOutboxRepository) FindByTurn(ctx context.Context, turn int) ([]*repository.JournalRecord, error) {
+	r.flushBeforeRead(ctx)
+	return r.journal.FindByTurn(ctx, turn)
+}
+
+// FindBySBI retrieves records for a specific SBI, flushing committed outbox records first
+func (r *JournalOutboxRepository) FindBySBI(ctx context.Context, sbiID string) ([]*repository.JournalRecord, error) {
+	r.flushBeforeRead(ctx)
+	return r.journal.FindBySBI(ctx, sbiID)
+}
+
+// flushBeforeRead flushes the outbox so that reads see committed records
+// Inside a transaction the outbox is left alone; a failed flush is retried by the next one.
+func (r *JournalOutboxRepository) flushBeforeRead(ctx context.Context) {
+	if _, ok := transaction.GetTxFromContext(ctx); ok {
+		return
+	}
+	_, _ = r.Flush(ctx)
+}
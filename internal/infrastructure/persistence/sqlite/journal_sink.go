@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// JournalSink copies journal records to the journal_entries table
+// A record delivered twice is stored once (by its idempotency key).
+type JournalSink struct {
+	db *sql.DB
+}
+
+// NewJournalSink creates a journal sink writing to db
+func NewJournalSink(db *sql.DB) *JournalSink {
+	return &JournalSink{db: db}
+}
+
+// Name identifies the sink
+func (s *JournalSink) Name() string {
+	return "sqlite"
+}
+
+// Write inserts the records in one transaction
+func (s *JournalSink) Write(ctx context.Context, records []*repository.JournalRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, record := range records {
+		entry := infraRepo.JournalEntry(record)
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encode journal record: %w", err)
+		}
+		var key interface{}
+		if k := record.IdempotencyKey(); k != "" {
+			key = k
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO journal_entries
+				(idempotency_key, timestamp, sbi_id, turn, step, status, decision, error_class, record)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			key, entry["timestamp"], record.SBIID, record.Turn, record.Step, record.Status,
+			record.Decision, record.ErrorClass, string(data),
+		); err != nil {
+			return fmt.Errorf("insert journal entry: %w", err)
+		}
+	}
+	return tx.Commit()
+}
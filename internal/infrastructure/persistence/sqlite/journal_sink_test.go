@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestJournalSink(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	sink := NewJournalSink(db)
+	ctx := context.Background()
+	records := []*repository.JournalRecord{
+		{Timestamp: "2026-10-16T00:00:00Z", SBIID: "sbi-1", Turn: 1, Step: "implement", Status: "WIP"},
+		{Timestamp: "2026-10-16T00:05:00Z", SBIID: "sbi-1", Turn: 1, Step: "review", Status: "REVIEW", Decision: "SUCCEEDED"},
+	}
+	require.NoError(t, sink.Write(ctx, records))
+
+	// A batch retried after a partial failure is stored once
+	require.NoError(t, sink.Write(ctx, records))
+
+	rows, err := db.QueryContext(ctx, `SELECT step, decision, record FROM journal_entries WHERE sbi_id = ? ORDER BY id`, "sbi-1")
+	require.NoError(t, err)
+	defer rows.Close()
+	var steps []string
+	for rows.Next() {
+		var step, decision, data string
+		require.NoError(t, rows.Scan(&step, &decision, &data))
+		steps = append(steps, step+":"+decision)
+
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(data), &entry))
+		assert.Equal(t, step, entry["step"], "the record column holds the NDJSON entry")
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"implement:", "review:SUCCEEDED"}, steps)
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	appconfig "github.com/YoshitsuguKoike/deespec/internal/app/config"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
@@ -390,7 +391,7 @@ func (r *LabelRepositoryImpl) scanLabel(row *sql.Row) (*label.Label, error) {
 		&createdAt, &updatedAt,
 	)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("label not found")
+		return nil, fmt.Errorf("label %w", model.ErrNotFound)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("scan label failed: %w", err)
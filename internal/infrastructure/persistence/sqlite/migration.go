@@ -25,14 +25,95 @@ var migration007SQL string
 //go:embed migrations/008_add_only_implement_flag.sql
 var migration008SQL string
 
+//go:embed migrations/009_create_sbi_review_feedback.sql
+var migration009SQL string
+
+//go:embed migrations/010_add_task_deadlines.sql
+var migration010SQL string
+
+//go:embed migrations/011_create_sbi_acceptance_criteria.sql
+var migration011SQL string
+
+//go:embed migrations/012_add_lock_fencing_tokens.sql
+var migration012SQL string
+
+//go:embed migrations/013_create_sbi_agent_sessions.sql
+var migration013SQL string
+
+//go:embed migrations/014_add_task_env.sql
+var migration014SQL string
+
+//go:embed migrations/015_create_sbi_review_votes.sql
+var migration015SQL string
+
+//go:embed migrations/016_create_sbi_step_agents.sql
+var migration016SQL string
+
+//go:embed migrations/017_create_agent_calls.sql
+var migration017SQL string
+
+//go:embed migrations/018_create_sbi_tombstones.sql
+var migration018SQL string
+
+//go:embed migrations/019_create_api_tokens.sql
+var migration019SQL string
+
+//go:embed migrations/020_add_task_versions.sql
+var migration020SQL string
+
+//go:embed migrations/021_create_journal_outbox.sql
+var migration021SQL string
+
+//go:embed migrations/022_create_sbi_notes.sql
+var migration022SQL string
+
+//go:embed migrations/023_add_sbi_review_started_at.sql
+var migration023SQL string
+
+//go:embed migrations/024_create_agent_response_cache.sql
+var migration024SQL string
+
+//go:embed migrations/025_create_journal_entries.sql
+var migration025SQL string
+
+//go:embed migrations/026_add_soft_delete.sql
+var migration026SQL string
+
+//go:embed migrations/027_create_sbi_references.sql
+var migration027SQL string
+
+//go:embed migrations/028_create_pbi_acceptance_tests.sql
+var migration028SQL string
+
+//go:embed migrations/029_create_sbi_artifact_provenance.sql
+var migration029SQL string
+
+//go:embed migrations/030_add_provenance_hashes.sql
+var migration030SQL string
+
+//go:embed migrations/031_create_id_sequences.sql
+var migration031SQL string
+
+//go:embed migrations/032_create_sbi_review_issues.sql
+var migration032SQL string
+
+//go:embed migrations/033_add_sbi_estimate_suggestion.sql
+var migration033SQL string
+
+//go:embed migrations/034_create_sbi_turn_claims.sql
+var migration034SQL string
+
 // Migrator manages database schema migrations
 type Migrator struct {
-	db *sql.DB
+	db      *sql.DB
+	backups int // Pre-migration backups kept next to the database file (0 = no backup)
 }
 
 // NewMigrator creates a new database migrator
+// Before migrating an existing database file it backs the file up into the backups directory
+// next to it, keeping the newest DefaultMigrationBackups backups.
 func NewMigrator(db *sql.DB) *Migrator {
-	return &Migrator{db: db}
+	return &Migrator{db: db, backups: DefaultMigrationBackups}
 }
 
 // Migrate applies all pending database migrations
@@ -57,7 +138,7 @@ func (m *Migrator) Migrate() error {
 
 	// Always apply incremental migrations (for both new and existing databases)
 	// This ensures new migrations added after schema.sql are always applied
-	if err := m.applyIncrementalMigrations(); err != nil {
+	if err := m.applyIncrementalMigrations(applied); err != nil {
 		return fmt.Errorf("apply incremental migrations failed: %w", err)
 	}
 
@@ -158,7 +239,8 @@ func splitSQLStatements(sql string) []string {
 }
 
 // applyIncrementalMigrations applies incremental migrations for existing databases
-func (m *Migrator) applyIncrementalMigrations() error {
+// A database that existed before this run (existing) is backed up first when any migration is pending.
+func (m *Migrator) applyIncrementalMigrations(existing bool) error {
 	// Check current version
 	currentVersion, err := m.getCurrentVersion()
 	if err != nil {
@@ -176,6 +258,38 @@ func (m *Migrator) applyIncrementalMigrations() error {
 		{6, migration006SQL, "Add started_at and completed_at timestamps to sbis table"},
 		{7, migration007SQL, "Create SBI execution logs table"},
 		{8, migration008SQL, "Add only_implement flag to sbis table for workflow control"},
+		{9, migration009SQL, "Create SBI review feedback table"},
+		{10, migration010SQL, "Add deadline columns to sbis and pbis tables"},
+		{11, migration011SQL, "Create SBI acceptance criteria table"},
+		{12, migration012SQL, "Add fencing tokens to run_locks and state_locks"},
+		{13, migration013SQL, "Create SBI agent sessions table"},
+		{14, migration014SQL, "Add env columns to sbis and pbis tables"},
+		{15, migration015SQL, "Create SBI review votes table"},
+		{16, migration016SQL, "Create SBI step agents table"},
+		{17, migration017SQL, "Create agent calls table"},
+		{18, migration018SQL, "Create SBI tombstones table"},
+		{19, migration019SQL, "Create API tokens and audit log tables"},
+		{20, migration020SQL, "Add version columns for optimistic concurrency control"},
+		{21, migration021SQL, "Create journal outbox table"},
+		{22, migration022SQL, "Create SBI notes table"},
+		{23, migration023SQL, "Add review_started_at to sbis"},
+		{24, migration024SQL, "Create agent response cache table"},
+		{25, migration025SQL, "Create journal entries table"},
+		{26, migration026SQL, "Add deleted_at to pbis and sbis"},
+		{27, migration027SQL, "Create SBI references table"},
+		{28, migration028SQL, "Create PBI acceptance tests table"},
+		{29, migration029SQL, "Create SBI artifact provenance table"},
+		{30, migration030SQL, "Add prompt and artifact hashes to sbi_artifact_provenance"},
+		{31, migration031SQL, "Create id_sequences table"},
+		{32, migration032SQL, "Create SBI review issues table"},
+		{33, migration033SQL, "Add estimate_suggestion to sbis"},
+		{34, migration034SQL, "Create SBI turn claims table"},
+	}
+
+	if existing && currentVersion < migrations[len(migrations)-1].version {
+		if err := m.backup(currentVersion); err != nil {
+			return fmt.Errorf("backup before migration failed: %w", err)
+		}
 	}
 
 	// Apply each migration if not already applied
@@ -193,6 +307,19 @@ func (m *Migrator) applyIncrementalMigrations() error {
 	return nil
 }
 
+// backup backs up the database file before it is migrated from version (in-memory databases are skipped)
+func (m *Migrator) backup(version int) error {
+	if m.backups <= 0 {
+		return nil
+	}
+	path, err := DatabaseFile(m.db)
+	if err != nil || path == "" {
+		return err
+	}
+	_, err = BackupBeforeMigration(m.db, path, version, m.backups)
+	return err
+}
+
 // getCurrentVersion returns the current schema version
 func (m *Migrator) getCurrentVersion() (int, error) {
 	var version int
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"database/sql"
 	"os"
+	"path/filepath"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -32,8 +33,8 @@ func TestMigration_NewDatabase(t *testing.T) {
 		t.Fatalf("Failed to query schema_migrations: %v", err)
 	}
 
-	if count < 8 {
-		t.Errorf("Expected at least 8 migration records (004, 005, 006, 007, 008), got %d", count)
+	if count < 34 {
+		t.Errorf("Expected at least 34 migration records (004-034), got %d", count)
 	}
 
 	// Verify sbis table has new fields (from migrations 004, 005, 006)
@@ -145,6 +146,54 @@ func TestMigration_ExistingDatabase(t *testing.T) {
 		t.Fatalf("Failed to create sbis table: %v", err)
 	}
 
+	// Create pbis table (created by the initial schema)
+	_, err = db.Exec(`
+		CREATE TABLE pbis (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			status TEXT NOT NULL,
+			current_step TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create pbis table: %v", err)
+	}
+
+	// Create epics table (created by the initial schema)
+	_, err = db.Exec(`
+		CREATE TABLE epics (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			status TEXT NOT NULL,
+			current_step TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create epics table: %v", err)
+	}
+
+	// Create lock tables (created by migration 2)
+	for _, table := range []string{"run_locks", "state_locks"} {
+		_, err = db.Exec(`
+			CREATE TABLE ` + table + ` (
+				lock_id TEXT PRIMARY KEY,
+				pid INTEGER NOT NULL,
+				hostname TEXT NOT NULL,
+				acquired_at DATETIME NOT NULL,
+				expires_at DATETIME NOT NULL,
+				heartbeat_at DATETIME NOT NULL,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)
+		`)
+		if err != nil {
+			t.Fatalf("Failed to create %s table: %v", table, err)
+		}
+	}
+
 	// Insert migration records up to version 3
 	_, err = db.Exec("INSERT INTO schema_migrations (version, description) VALUES (1, 'Initial schema')")
 	if err != nil {
@@ -174,6 +223,15 @@ func TestMigration_ExistingDatabase(t *testing.T) {
 		t.Fatalf("Failed to migrate: %v", err)
 	}
 
+	// Verify the database was backed up at version 3 before it was migrated
+	backups, _ := filepath.Glob(filepath.Join(filepath.Dir(tmpDB), BackupDirName, filepath.Base(tmpDB)+".v3-*.bak"))
+	for _, backup := range backups {
+		defer os.Remove(backup)
+	}
+	if len(backups) != 1 {
+		t.Errorf("Expected 1 pre-migration backup, got %d", len(backups))
+	}
+
 	// Verify version 7 was applied (migration 004, 005, 006, 007)
 	var version int
 	err = db.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version)
@@ -181,8 +239,8 @@ func TestMigration_ExistingDatabase(t *testing.T) {
 		t.Fatalf("Failed to query version: %v", err)
 	}
 
-	if version != 8 {
-		t.Errorf("Expected version 8, got %d", version)
+	if version != 34 {
+		t.Errorf("Expected version 34, got %d", version)
 	}
 
 	// Verify new fields exist (from migrations 004, 005, 006)
@@ -0,0 +1,23 @@
+-- Migration 009: Create SBI review feedback table
+-- Stores the issue list extracted from NEEDS_CHANGES/FAILED review reports
+-- so that the next implement prompt can embed it directly
+
+CREATE TABLE IF NOT EXISTS sbi_review_feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sbi_id TEXT NOT NULL,
+    turn INTEGER NOT NULL,  -- Turn of the review that produced the feedback
+    decision TEXT NOT NULL,  -- 'NEEDS_CHANGES' or 'FAILED'
+    issues TEXT NOT NULL DEFAULT '[]',  -- JSON array of issue strings
+    report_path TEXT,  -- Path to review_N.md
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(sbi_id, turn),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Index for querying by SBI ID and turn
+CREATE INDEX IF NOT EXISTS idx_sbi_review_feedback_sbi_turn ON sbi_review_feedback(sbi_id, turn);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (9, 'Create SBI review feedback table');
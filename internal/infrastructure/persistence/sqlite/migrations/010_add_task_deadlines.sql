@@ -0,0 +1,14 @@
+-- Migration 010: Add deadline columns to SBIs and PBIs
+-- Deadlines are optional and stored as RFC3339 UTC strings so they compare
+-- correctly in SQL when filtering overdue tasks
+
+ALTER TABLE sbis ADD COLUMN deadline DATETIME;
+ALTER TABLE pbis ADD COLUMN deadline DATETIME;
+
+-- Index for efficient overdue queries
+CREATE INDEX IF NOT EXISTS idx_sbis_deadline ON sbis(deadline);
+CREATE INDEX IF NOT EXISTS idx_pbis_deadline ON pbis(deadline);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (10, 'Add deadline columns to sbis and pbis tables');
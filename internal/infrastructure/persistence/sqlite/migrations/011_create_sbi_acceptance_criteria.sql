@@ -0,0 +1,26 @@
+-- Migration 011: Create SBI acceptance criteria checklist table
+-- Acceptance criteria parsed from SBI/PBI descriptions are stored as individual
+-- checklist items; the review step marks each one PASSED/FAILED and DONE is
+-- blocked until every item is PASSED or WAIVED
+
+CREATE TABLE IF NOT EXISTS sbi_acceptance_criteria (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sbi_id TEXT NOT NULL,
+    position INTEGER NOT NULL,  -- 1-based, referenced as AC-<position> in reports
+    description TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT 'sbi',  -- 'sbi' or 'pbi'
+    status TEXT NOT NULL DEFAULT 'PENDING',  -- 'PENDING', 'PASSED', 'FAILED', 'WAIVED'
+    note TEXT NOT NULL DEFAULT '',  -- Reviewer note or waiver reason
+    turn INTEGER NOT NULL DEFAULT 0,  -- Turn of the last evaluation
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(sbi_id, position),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Index for loading an SBI's checklist
+CREATE INDEX IF NOT EXISTS idx_sbi_acceptance_criteria_sbi ON sbi_acceptance_criteria(sbi_id, position);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (11, 'Create SBI acceptance criteria table');
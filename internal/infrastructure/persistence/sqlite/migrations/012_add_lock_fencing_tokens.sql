@@ -0,0 +1,22 @@
+-- Migration 012: Add fencing tokens to run and state locks
+-- Every acquisition of a lock (including the takeover of an expired lease)
+-- receives a token that is strictly greater than any token previously issued
+-- for the same lock_id. Writes guarded by a lock carry its token and are
+-- rejected when a newer holder exists, so a runner that resumes after its
+-- lease expired (e.g. a laptop waking from sleep) cannot overwrite the work
+-- of the runner that took over.
+
+ALTER TABLE run_locks ADD COLUMN fencing_token INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE state_locks ADD COLUMN fencing_token INTEGER NOT NULL DEFAULT 0;
+
+-- Highest token issued per lock_id; survives lock release and expiry cleanup
+CREATE TABLE IF NOT EXISTS lock_fencing_tokens (
+    lock_id TEXT PRIMARY KEY,
+    token INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (12, 'Add fencing tokens to run_locks and state_locks');
@@ -0,0 +1,20 @@
+-- Migration 013: Create SBI agent sessions table
+-- Stores the provider conversation/session ID of the last agent call per SBI step
+-- so that consecutive turns can continue the same conversation instead of
+-- starting fresh (reduces latency and token cost)
+
+CREATE TABLE IF NOT EXISTS sbi_agent_sessions (
+    sbi_id TEXT NOT NULL,
+    step TEXT NOT NULL,        -- 'implement' or 'review'
+    agent_type TEXT NOT NULL,  -- Agent that owns the session (e.g. 'claude-code-cli')
+    session_id TEXT NOT NULL,  -- Provider session ID
+    turn INTEGER NOT NULL,     -- Turn that last used the session
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (sbi_id, step),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (13, 'Create SBI agent sessions table');
@@ -0,0 +1,10 @@
+-- Migration 014: Add env columns to SBIs and PBIs
+-- Non-secret environment variables (JSON object) exported to agents while
+-- they execute steps for the task. SBI values override PBI values.
+
+ALTER TABLE sbis ADD COLUMN env TEXT;
+ALTER TABLE pbis ADD COLUMN env TEXT;
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (14, 'Add env columns to sbis and pbis tables');
@@ -0,0 +1,20 @@
+-- Migration 015: Create SBI review votes table
+-- In review quorum mode several reviewer agents review the same turn; each
+-- reviewer's decision is recorded here and the workflow aggregates them into
+-- the turn's final review decision
+
+CREATE TABLE IF NOT EXISTS sbi_review_votes (
+    sbi_id TEXT NOT NULL,
+    turn INTEGER NOT NULL,
+    reviewer TEXT NOT NULL,    -- Reviewer name from the review_quorum setting
+    decision TEXT NOT NULL,    -- SUCCEEDED, NEEDS_CHANGES or FAILED
+    report_path TEXT,          -- The reviewer's own report (review_<turn>_<reviewer>.md)
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (sbi_id, turn, reviewer),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (15, 'Create SBI review votes table');
@@ -0,0 +1,19 @@
+-- Migration 016: Create SBI step agents table
+-- Records which agent and model last executed each SBI step so that the
+-- self-review guard can keep the implementer from reviewing its own work
+
+CREATE TABLE IF NOT EXISTS sbi_step_agents (
+    sbi_id TEXT NOT NULL,
+    step TEXT NOT NULL,        -- 'implement' or 'review'
+    agent_type TEXT NOT NULL,  -- Agent that executed the step (e.g. 'claude-code-cli')
+    model TEXT NOT NULL DEFAULT '', -- Model passed to the agent ('' = agent default)
+    turn INTEGER NOT NULL,     -- Turn the step was executed in
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (sbi_id, step),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (16, 'Create SBI step agents table');
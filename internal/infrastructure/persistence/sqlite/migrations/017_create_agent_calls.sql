@@ -0,0 +1,20 @@
+-- Migration 017: Create agent calls table
+-- Records every agent execution so that the scheduler can enforce the
+-- project-wide daily agent call budget. Rows outlive their SBI on purpose:
+-- calls already made still count against the day's budget
+
+CREATE TABLE IF NOT EXISTS agent_calls (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sbi_id TEXT NOT NULL,
+    turn INTEGER NOT NULL,
+    step TEXT NOT NULL,        -- 'implement' or 'review'
+    agent_type TEXT NOT NULL,  -- Agent that was called (e.g. 'claude-code-cli')
+    called_at TEXT NOT NULL    -- UTC RFC3339
+);
+
+-- Index for counting calls since a point in time
+CREATE INDEX IF NOT EXISTS idx_agent_calls_called_at ON agent_calls(called_at);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (17, 'Create agent calls table');
@@ -0,0 +1,23 @@
+-- Migration 018: Create SBI tombstones table
+-- An archived SBI is removed from sbis and its full state moved into an archive
+-- bundle. The tombstone keeps its ID and sequence reserved, answers lookups of
+-- the archived ID, and points to the bundle for `deespec archive restore`
+
+CREATE TABLE IF NOT EXISTS sbi_tombstones (
+    id TEXT PRIMARY KEY,       -- ID of the archived SBI
+    parent_pbi_id TEXT,
+    title TEXT NOT NULL,
+    status TEXT NOT NULL,      -- Status at archive time (DONE, FAILED or CANCELLED)
+    sequence INTEGER,          -- Registration sequence, never reused
+    completed_at TEXT,         -- UTC RFC3339 (NULL if the SBI never completed)
+    archive_path TEXT NOT NULL, -- Bundle path relative to the .deespec directory
+    archived_at TEXT NOT NULL  -- UTC RFC3339
+);
+
+-- Indexes for searching archives
+CREATE INDEX IF NOT EXISTS idx_sbi_tombstones_status ON sbi_tombstones(status);
+CREATE INDEX IF NOT EXISTS idx_sbi_tombstones_archived_at ON sbi_tombstones(archived_at);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (18, 'Create SBI tombstones table');
@@ -0,0 +1,30 @@
+-- Migration 019: Create API tokens and audit log tables
+-- Tokens authenticate clients of `deespec serve`; each grants scopes checked
+-- per route. The audit log records which token triggered which state change,
+-- and every refused request
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    name TEXT PRIMARY KEY,            -- Token name chosen at creation (e.g. 'ci')
+    token_hash TEXT NOT NULL UNIQUE,  -- SHA-256 (hex) of the secret, which is never stored
+    scopes TEXT NOT NULL,             -- Comma-separated: read, run-turn, admin
+    created_at TEXT NOT NULL,         -- UTC RFC3339
+    last_used_at TEXT,                -- UTC RFC3339
+    revoked_at TEXT                   -- UTC RFC3339 (NULL while active)
+);
+
+CREATE TABLE IF NOT EXISTS api_audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    token_name TEXT NOT NULL,  -- '' when no valid token was presented
+    route TEXT NOT NULL,       -- gRPC method or HTTP path
+    target TEXT,               -- SBI or lock the request acted on
+    outcome TEXT NOT NULL,     -- 'ok', 'error' or 'denied'
+    detail TEXT,
+    created_at TEXT NOT NULL   -- UTC RFC3339
+);
+
+-- Index for listing recent audit entries
+CREATE INDEX IF NOT EXISTS idx_api_audit_log_created_at ON api_audit_log(created_at);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (19, 'Create API tokens and audit log tables');
@@ -0,0 +1,12 @@
+-- Migration 020: Add version columns for optimistic concurrency control
+-- Every save of an SBI, PBI or EPIC increments its version and succeeds only
+-- when the stored version is still the one the entity was loaded at, so that
+-- two processes saving the same task no longer silently overwrite each other
+
+ALTER TABLE sbis ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE pbis ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE epics ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (20, 'Add version columns for optimistic concurrency control');
@@ -0,0 +1,14 @@
+-- Migration 021: Create journal outbox table
+-- Journal records appended inside a unit of work are written here in the same
+-- transaction as the SBI they describe, and moved to the NDJSON journal after
+-- the commit. Rows left behind by a crash are moved on the next flush
+
+CREATE TABLE IF NOT EXISTS journal_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    record TEXT NOT NULL,      -- JSON-encoded journal record
+    created_at TEXT NOT NULL   -- UTC RFC3339
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (21, 'Create journal outbox table');
@@ -0,0 +1,24 @@
+-- Migration 022: Create SBI notes table
+-- Free-form notes and links attached to an SBI by a person (e.g. "blocked on
+-- INFRA-42"). A note marked for the prompt is added to the prior context of
+-- the next agent step; injected_turn records the turn that received it
+
+CREATE TABLE IF NOT EXISTS sbi_notes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sbi_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    links TEXT NOT NULL DEFAULT '[]',          -- JSON array of URLs or ticket references
+    author TEXT NOT NULL DEFAULT '',
+    inject_prompt INTEGER NOT NULL DEFAULT 0,  -- 1 when the note goes into the next prompt
+    injected_turn INTEGER,                     -- Turn whose prompt received the note (NULL until then)
+    created_at TEXT NOT NULL,                  -- UTC RFC3339
+
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Index for listing the notes of an SBI
+CREATE INDEX IF NOT EXISTS idx_sbi_notes_sbi_id ON sbi_notes(sbi_id);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (22, 'Create SBI notes table');
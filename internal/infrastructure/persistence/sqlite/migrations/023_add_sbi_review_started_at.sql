@@ -0,0 +1,12 @@
+-- Migration 023: Add review_started_at to sbis
+-- Records when an SBI entered REVIEWING, so that a review whose decision never
+-- arrives (e.g. the agent crashed before `sbi review`) can be timed out.
+-- SBIs already reviewing start their clock at their last update
+
+ALTER TABLE sbis ADD COLUMN review_started_at DATETIME;
+
+UPDATE sbis SET review_started_at = updated_at WHERE status = 'REVIEWING';
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (23, 'Add review_started_at to sbis');
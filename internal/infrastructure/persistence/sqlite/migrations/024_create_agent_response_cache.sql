@@ -0,0 +1,22 @@
+-- Migration 024: Create agent response cache table
+-- Agent outputs of deterministic steps (e.g. the done report) keyed by the
+-- hash of the agent type, model and prompt, so that re-running a step with an
+-- identical prompt reuses the output instead of calling the agent again
+
+CREATE TABLE IF NOT EXISTS agent_response_cache (
+    prompt_hash TEXT PRIMARY KEY,          -- SHA-256 of agent type, model and prompt
+    agent_type TEXT NOT NULL,
+    model TEXT NOT NULL DEFAULT '',
+    step TEXT NOT NULL,
+    output TEXT NOT NULL,
+    hits INTEGER NOT NULL DEFAULT 0,       -- Times the output was reused
+    created_at TEXT NOT NULL,              -- UTC RFC3339
+    last_hit_at TEXT                       -- UTC RFC3339 (NULL until reused)
+);
+
+-- Index for expiring old entries
+CREATE INDEX IF NOT EXISTS idx_agent_response_cache_created_at ON agent_response_cache(created_at);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (24, 'Create agent response cache table');
@@ -0,0 +1,24 @@
+-- Migration 025: Create journal entries table
+-- Copy of the NDJSON journal kept by the "sqlite" journal sink, so that turn
+-- history can be queried with SQL. The NDJSON journal stays authoritative
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    idempotency_key TEXT,              -- sbi_id/turn/step@timestamp (NULL when incomplete)
+    timestamp TEXT NOT NULL,           -- UTC RFC3339Nano
+    sbi_id TEXT NOT NULL DEFAULT '',
+    turn INTEGER NOT NULL DEFAULT 0,
+    step TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT '',
+    decision TEXT NOT NULL DEFAULT '',
+    error_class TEXT NOT NULL DEFAULT '',
+    record TEXT NOT NULL               -- JSON entry as written to the NDJSON journal
+);
+
+-- A record delivered twice is stored once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_key ON journal_entries(idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_sbi ON journal_entries(sbi_id, turn);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (25, 'Create journal entries table');
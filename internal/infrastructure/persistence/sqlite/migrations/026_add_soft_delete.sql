@@ -0,0 +1,15 @@
+-- Migration 026: Add deleted_at to pbis and sbis
+-- A deleted PBI or SBI is kept with deleted_at set (moved to the trash) so that
+-- `deespec trash restore` can undo the deletion. Trashed rows are hidden from all
+-- lookups and still reserve their ID and sequence; `deespec trash purge` removes
+-- them once the retention window has passed
+
+ALTER TABLE pbis ADD COLUMN deleted_at DATETIME;
+ALTER TABLE sbis ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_pbis_deleted_at ON pbis(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_sbis_deleted_at ON sbis(deleted_at);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (26, 'Add deleted_at to pbis and sbis');
@@ -0,0 +1,23 @@
+-- Migration 027: Create SBI references table
+-- An SBI can reference other SBIs whose final artifacts (done and implement
+-- reports) are given to the agent as context, e.g. "builds upon the design doc
+-- of SBI-0042". Unlike sbi_dependencies, a reference does not hold the SBI back
+
+CREATE TABLE IF NOT EXISTS sbi_references (
+    sbi_id TEXT NOT NULL,
+    referenced_sbi_id TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',    -- Why the artifacts are relevant, shown to the agent
+    created_at TEXT NOT NULL,         -- UTC RFC3339
+
+    PRIMARY KEY (sbi_id, referenced_sbi_id),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE,
+    FOREIGN KEY (referenced_sbi_id) REFERENCES sbis(id) ON DELETE CASCADE,
+    CHECK (sbi_id != referenced_sbi_id)
+);
+
+-- Reverse lookup index for the SBIs referencing an SBI
+CREATE INDEX IF NOT EXISTS idx_sbi_references_referenced ON sbi_references(referenced_sbi_id);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (27, 'Create SBI references table');
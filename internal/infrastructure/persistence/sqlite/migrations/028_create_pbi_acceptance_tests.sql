@@ -0,0 +1,18 @@
+-- Migration 028: Create PBI acceptance tests table
+-- A PBI can define an end-to-end check that runs once all of its SBIs are DONE:
+-- a shell command and/or an agent-driven acceptance review. A failing check
+-- reopens the SBIs it names or registers a new fix SBI under the PBI
+
+CREATE TABLE IF NOT EXISTS pbi_acceptance_tests (
+    pbi_id TEXT PRIMARY KEY,
+    command TEXT NOT NULL DEFAULT '',           -- Shell command run in the project root, passes on exit code 0
+    agent_review INTEGER NOT NULL DEFAULT 0,    -- 1 when an agent reviews the PBI against its acceptance criteria
+    timeout_seconds INTEGER NOT NULL DEFAULT 0, -- 0 uses the default timeout
+    updated_at TEXT NOT NULL,                   -- UTC RFC3339
+
+    FOREIGN KEY (pbi_id) REFERENCES pbis(id) ON DELETE CASCADE
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (28, 'Create PBI acceptance tests table');
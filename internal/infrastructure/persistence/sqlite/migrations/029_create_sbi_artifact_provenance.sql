@@ -0,0 +1,27 @@
+-- Migration 029: Create SBI artifact provenance table
+-- Records which agent, model and agent version produced each step artifact and
+-- decision of an SBI, so that `deespec sbi show` can tell where every report came
+-- from. Unlike sbi_step_agents, every step run is kept, including each reviewer
+-- of a review quorum
+
+CREATE TABLE IF NOT EXISTS sbi_artifact_provenance (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sbi_id TEXT NOT NULL,
+    step TEXT NOT NULL,                     -- Step that produced the artifact (e.g. 'implement', 'review')
+    turn INTEGER NOT NULL,                  -- Turn the step was executed in
+    artifact_path TEXT NOT NULL,            -- Report written by the step
+    decision TEXT NOT NULL DEFAULT '',      -- Review decision ('' for other steps)
+    agent_type TEXT NOT NULL,               -- Agent that executed the step (e.g. 'claude-code-cli')
+    model TEXT NOT NULL DEFAULT '',         -- Model reported by the agent ('' = unknown)
+    agent_version TEXT NOT NULL DEFAULT '', -- Version of the agent CLI ('' = unknown)
+    reviewer TEXT NOT NULL DEFAULT '',      -- Reviewer name within a review quorum
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sbi_artifact_provenance_sbi ON sbi_artifact_provenance(sbi_id, turn);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (29, 'Create SBI artifact provenance table');
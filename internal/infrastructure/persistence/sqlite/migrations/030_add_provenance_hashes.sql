@@ -0,0 +1,12 @@
+-- Migration 030: Add prompt and artifact hashes to sbi_artifact_provenance
+-- SHA-256 digests of the prompt sent to the agent and of the artifact it produced,
+-- taken when the step ran, so that attestations can prove what was asked and what
+-- was delivered. Records from before this migration have no hashes
+
+ALTER TABLE sbi_artifact_provenance ADD COLUMN prompt_hash TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE sbi_artifact_provenance ADD COLUMN artifact_hash TEXT NOT NULL DEFAULT '';
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (30, 'Add prompt and artifact hashes to sbi_artifact_provenance');
@@ -0,0 +1,13 @@
+-- Migration 031: Create id_sequences table
+-- The last number handed out per ID prefix of the sequential ID schemes
+-- (e.g. "SBI-2024-" for SBI-2024-0153). IDs that already exist are skipped
+-- when a number is handed out, so the sequence never reuses an ID
+
+CREATE TABLE IF NOT EXISTS id_sequences (
+    prefix TEXT PRIMARY KEY,
+    last_value INTEGER NOT NULL
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (31, 'Create id_sequences table');
@@ -0,0 +1,28 @@
+-- Migration 032: Create SBI review issues table
+-- Issues raised by reviews are tracked as individual records across turns:
+-- implement prompts list the open ones, reviews mark them ADDRESSED or
+-- WONTFIX, and DONE is blocked while a blocking issue is still OPEN
+
+CREATE TABLE IF NOT EXISTS sbi_review_issues (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sbi_id TEXT NOT NULL,
+    number INTEGER NOT NULL,  -- 1-based per SBI, referenced as ISSUE-<number> in reports
+    description TEXT NOT NULL,
+    blocking INTEGER NOT NULL DEFAULT 1,
+    status TEXT NOT NULL DEFAULT 'OPEN',  -- 'OPEN', 'ADDRESSED', 'WONTFIX'
+    note TEXT NOT NULL DEFAULT '',  -- Note of the last status change
+    raised_turn INTEGER NOT NULL,
+    turn INTEGER NOT NULL,  -- Turn of the last status change
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(sbi_id, number),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Index for loading an SBI's issues
+CREATE INDEX IF NOT EXISTS idx_sbi_review_issues_sbi ON sbi_review_issues(sbi_id, number);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (32, 'Create SBI review issues table');
@@ -0,0 +1,9 @@
+-- Migration 033: Add estimate_suggestion to sbis
+-- Estimate suggested by `deespec sbi estimate` (JSON object with the hours and
+-- their uncertainty range), pending confirmation. NULL when none is pending.
+
+ALTER TABLE sbis ADD COLUMN estimate_suggestion TEXT;
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (33, 'Add estimate_suggestion to sbis');
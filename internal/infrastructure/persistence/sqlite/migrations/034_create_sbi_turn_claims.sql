@@ -0,0 +1,20 @@
+-- Migration 034: Create SBI turn claims table
+-- A runner records (sbi_id, turn) before calling the agent for a turn. When two
+-- runners get past locking (e.g. because of clock skew between hosts), the
+-- primary key lets only one of them execute the turn; the other aborts with a
+-- journaled conflict instead of calling the agent a second time
+
+CREATE TABLE IF NOT EXISTS sbi_turn_claims (
+    sbi_id TEXT NOT NULL,
+    turn INTEGER NOT NULL,
+    pid INTEGER NOT NULL,
+    hostname TEXT NOT NULL,
+    claimed_at TEXT NOT NULL,  -- RFC3339 (UTC)
+
+    PRIMARY KEY (sbi_id, turn),
+    FOREIGN KEY (sbi_id) REFERENCES sbis(id) ON DELETE CASCADE
+);
+
+-- Record migration
+INSERT INTO schema_migrations (version, description)
+VALUES (34, 'Create SBI turn claims table');
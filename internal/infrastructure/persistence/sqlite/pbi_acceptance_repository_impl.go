@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// PBIAcceptanceRepositoryImpl implements PBIAcceptanceRepository using SQLite
+type PBIAcceptanceRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewPBIAcceptanceRepository creates a new PBIAcceptanceRepository implementation
+func NewPBIAcceptanceRepository(db *sql.DB) repository.PBIAcceptanceRepository {
+	return &PBIAcceptanceRepositoryImpl{db: db}
+}
+
+// Save saves the acceptance test of a PBI, replacing an existing one
+func (r *PBIAcceptanceRepositoryImpl) Save(ctx context.Context, test *repository.PBIAcceptanceTest) error {
+	test.UpdatedAt = time.Now()
+
+	agentReview := 0
+	if test.AgentReview {
+		agentReview = 1
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO pbi_acceptance_tests (pbi_id, command, agent_review, timeout_seconds, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(pbi_id) DO UPDATE SET
+			command = excluded.command,
+			agent_review = excluded.agent_review,
+			timeout_seconds = excluded.timeout_seconds,
+			updated_at = excluded.updated_at
+	`, test.PBIID, test.Command, agentReview, int64(test.Timeout/time.Second), test.UpdatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to save PBI acceptance test: %w", err)
+	}
+	return nil
+}
+
+// FindByPBIID retrieves the acceptance test of a PBI
+func (r *PBIAcceptanceRepositoryImpl) FindByPBIID(ctx context.Context, pbiID string) (*repository.PBIAcceptanceTest, error) {
+	test := &repository.PBIAcceptanceTest{}
+	var agentReview int
+	var timeoutSeconds int64
+	var updatedAt string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT pbi_id, command, agent_review, timeout_seconds, updated_at
+		FROM pbi_acceptance_tests
+		WHERE pbi_id = ?
+	`, pbiID).Scan(&test.PBIID, &test.Command, &agentReview, &timeoutSeconds, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PBI acceptance test: %w", err)
+	}
+
+	test.AgentReview = agentReview != 0
+	test.Timeout = time.Duration(timeoutSeconds) * time.Second
+	if test.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse acceptance test updated_at: %w", err)
+	}
+	return test, nil
+}
+
+// Delete removes the acceptance test of a PBI
+func (r *PBIAcceptanceRepositoryImpl) Delete(ctx context.Context, pbiID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM pbi_acceptance_tests WHERE pbi_id = ?`, pbiID)
+	if err != nil {
+		return fmt.Errorf("failed to delete PBI acceptance test: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrPBIAcceptanceTestNotFound, pbiID)
+	}
+	return nil
+}
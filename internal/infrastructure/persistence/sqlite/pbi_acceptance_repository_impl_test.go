@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestPBIAcceptanceRepository_SaveFindDelete(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, NewMigrator(db).Migrate())
+	_, err = db.Exec(`INSERT INTO pbis (id, title, status, current_step) VALUES ('PBI-001', 'Checkout', 'in_progress', 'PICK')`)
+	require.NoError(t, err)
+
+	repo := NewPBIAcceptanceRepository(db)
+	ctx := context.Background()
+
+	test, err := repo.FindByPBIID(ctx, "PBI-001")
+	require.NoError(t, err)
+	assert.Nil(t, test, "a PBI defines no acceptance test by default")
+
+	require.NoError(t, repo.Save(ctx, &repository.PBIAcceptanceTest{PBIID: "PBI-001", Command: "make e2e", Timeout: 5 * time.Minute}))
+	// Saving again replaces the definition
+	require.NoError(t, repo.Save(ctx, &repository.PBIAcceptanceTest{PBIID: "PBI-001", Command: "make e2e-checkout", AgentReview: true}))
+
+	test, err = repo.FindByPBIID(ctx, "PBI-001")
+	require.NoError(t, err)
+	require.NotNil(t, test)
+	assert.Equal(t, "make e2e-checkout", test.Command)
+	assert.True(t, test.AgentReview)
+	assert.Zero(t, test.Timeout)
+	assert.False(t, test.UpdatedAt.IsZero())
+
+	require.NoError(t, repo.Delete(ctx, "PBI-001"))
+	err = repo.Delete(ctx, "PBI-001")
+	assert.True(t, errors.Is(err, repository.ErrPBIAcceptanceTestNotFound))
+
+	// Purging the PBI removes its acceptance test
+	require.NoError(t, repo.Save(ctx, &repository.PBIAcceptanceTest{PBIID: "PBI-001", Command: "make e2e"}))
+	_, err = db.Exec(`DELETE FROM pbis WHERE id = 'PBI-001'`)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM pbi_acceptance_tests`).Scan(&count))
+	assert.Zero(t, count)
+}
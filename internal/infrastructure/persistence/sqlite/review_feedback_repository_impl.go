@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ReviewFeedbackRepositoryImpl implements ReviewFeedbackRepository using SQLite
+type ReviewFeedbackRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewReviewFeedbackRepository creates a new ReviewFeedbackRepository implementation
+func NewReviewFeedbackRepository(db *sql.DB) repository.ReviewFeedbackRepository {
+	return &ReviewFeedbackRepositoryImpl{db: db}
+}
+
+// Save saves feedback for a review turn, replacing any existing feedback for the same turn
+func (r *ReviewFeedbackRepositoryImpl) Save(ctx context.Context, feedback *repository.ReviewFeedback) error {
+	issues := feedback.Issues
+	if issues == nil {
+		issues = []string{}
+	}
+	issuesJSON, err := json.Marshal(issues)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review issues: %w", err)
+	}
+
+	query := `
+		INSERT INTO sbi_review_feedback (sbi_id, turn, decision, issues, report_path, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(sbi_id, turn) DO UPDATE SET
+			decision = excluded.decision,
+			issues = excluded.issues,
+			report_path = excluded.report_path,
+			created_at = excluded.created_at
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		feedback.SBIID,
+		feedback.Turn,
+		feedback.Decision,
+		string(issuesJSON),
+		feedback.ReportPath,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save review feedback: %w", err)
+	}
+
+	return nil
+}
+
+// FindBySBIIDAndTurn retrieves feedback produced by the review at a specific turn
+func (r *ReviewFeedbackRepositoryImpl) FindBySBIIDAndTurn(ctx context.Context, sbiID string, turn int) (*repository.ReviewFeedback, error) {
+	query := `
+		SELECT id, sbi_id, turn, decision, issues, report_path, created_at
+		FROM sbi_review_feedback
+		WHERE sbi_id = ? AND turn = ?
+	`
+
+	return r.scanOne(r.db.QueryRowContext(ctx, query, sbiID, turn))
+}
+
+// FindLatestBySBIID retrieves the most recent feedback before the given turn
+func (r *ReviewFeedbackRepositoryImpl) FindLatestBySBIID(ctx context.Context, sbiID string, beforeTurn int) (*repository.ReviewFeedback, error) {
+	query := `
+		SELECT id, sbi_id, turn, decision, issues, report_path, created_at
+		FROM sbi_review_feedback
+		WHERE sbi_id = ? AND turn < ?
+		ORDER BY turn DESC
+		LIMIT 1
+	`
+
+	return r.scanOne(r.db.QueryRowContext(ctx, query, sbiID, beforeTurn))
+}
+
+// scanOne scans a single review feedback row, returning nil when no row matches
+func (r *ReviewFeedbackRepositoryImpl) scanOne(row *sql.Row) (*repository.ReviewFeedback, error) {
+	feedback := &repository.ReviewFeedback{}
+	var issuesJSON string
+	var reportPath sql.NullString
+
+	err := row.Scan(
+		&feedback.ID,
+		&feedback.SBIID,
+		&feedback.Turn,
+		&feedback.Decision,
+		&issuesJSON,
+		&reportPath,
+		&feedback.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan review feedback: %w", err)
+	}
+
+	if reportPath.Valid {
+		feedback.ReportPath = reportPath.String
+	}
+	if err := json.Unmarshal([]byte(issuesJSON), &feedback.Issues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal review issues: %w", err)
+	}
+
+	return feedback, nil
+}
@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForReviewFeedback(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	migrator := NewMigrator(db)
+	require.NoError(t, migrator.Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-FB-001', 'Feedback test', 'IMPLEMENTING', 'IMPLEMENT', 0)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestReviewFeedbackRepository_SaveAndFind(t *testing.T) {
+	db := setupTestDBForReviewFeedback(t)
+	defer db.Close()
+
+	repo := NewReviewFeedbackRepository(db)
+	ctx := context.Background()
+
+	err := repo.Save(ctx, &repository.ReviewFeedback{
+		SBIID:      "SBI-FB-001",
+		Turn:       2,
+		Decision:   "NEEDS_CHANGES",
+		Issues:     []string{"Add error handling", "Missing tests"},
+		ReportPath: ".deespec/reports/sbi/SBI-FB-001/review_2.md",
+	})
+	require.NoError(t, err)
+
+	found, err := repo.FindBySBIIDAndTurn(ctx, "SBI-FB-001", 2)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "NEEDS_CHANGES", found.Decision)
+	assert.Equal(t, []string{"Add error handling", "Missing tests"}, found.Issues)
+	assert.Equal(t, ".deespec/reports/sbi/SBI-FB-001/review_2.md", found.ReportPath)
+
+	// Saving the same turn again replaces the feedback
+	err = repo.Save(ctx, &repository.ReviewFeedback{
+		SBIID:    "SBI-FB-001",
+		Turn:     2,
+		Decision: "FAILED",
+		Issues:   []string{"Wrong approach"},
+	})
+	require.NoError(t, err)
+
+	found, err = repo.FindBySBIIDAndTurn(ctx, "SBI-FB-001", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "FAILED", found.Decision)
+	assert.Equal(t, []string{"Wrong approach"}, found.Issues)
+}
+
+func TestReviewFeedbackRepository_FindLatest(t *testing.T) {
+	db := setupTestDBForReviewFeedback(t)
+	defer db.Close()
+
+	repo := NewReviewFeedbackRepository(db)
+	ctx := context.Background()
+
+	// No feedback yet
+	found, err := repo.FindLatestBySBIID(ctx, "SBI-FB-001", 5)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	for _, turn := range []int{2, 4} {
+		require.NoError(t, repo.Save(ctx, &repository.ReviewFeedback{
+			SBIID:    "SBI-FB-001",
+			Turn:     turn,
+			Decision: "NEEDS_CHANGES",
+		}))
+	}
+
+	found, err = repo.FindLatestBySBIID(ctx, "SBI-FB-001", 5)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, 4, found.Turn)
+	assert.Empty(t, found.Issues)
+
+	found, err = repo.FindLatestBySBIID(ctx, "SBI-FB-001", 4)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, 2, found.Turn)
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ReviewIssueRepositoryImpl implements ReviewIssueRepository using SQLite
+type ReviewIssueRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewReviewIssueRepository creates a new ReviewIssueRepository implementation
+func NewReviewIssueRepository(db *sql.DB) repository.ReviewIssueRepository {
+	return &ReviewIssueRepositoryImpl{db: db}
+}
+
+// Add records new issues of an SBI, numbering them after the existing ones
+func (r *ReviewIssueRepositoryImpl) Add(ctx context.Context, sbiID string, issues []*repository.ReviewIssue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var last int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(number), 0) FROM sbi_review_issues WHERE sbi_id = ?`, sbiID).Scan(&last); err != nil {
+		return fmt.Errorf("failed to number review issues: %w", err)
+	}
+
+	query := `
+		INSERT INTO sbi_review_issues (sbi_id, number, description, blocking, status, note, raised_turn, turn, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`
+	for i, issue := range issues {
+		number := last + i + 1
+		status := issue.Status
+		if status == "" {
+			status = repository.ReviewIssueOpen
+		}
+		turn := issue.Turn
+		if turn == 0 {
+			turn = issue.RaisedTurn
+		}
+		result, err := tx.ExecContext(ctx, query, sbiID, number, issue.Description, issue.Blocking, string(status), issue.Note, issue.RaisedTurn, turn)
+		if err != nil {
+			return fmt.Errorf("failed to save review issue %d: %w", number, err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get review issue id: %w", err)
+		}
+		issue.ID = id
+		issue.SBIID = sbiID
+		issue.Number = number
+		issue.Status = status
+		issue.Turn = turn
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review issues: %w", err)
+	}
+	return nil
+}
+
+// FindBySBIID retrieves the issues of an SBI ordered by number
+func (r *ReviewIssueRepositoryImpl) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.ReviewIssue, error) {
+	query := `
+		SELECT id, sbi_id, number, description, blocking, status, note, raised_turn, turn, created_at, updated_at
+		FROM sbi_review_issues
+		WHERE sbi_id = ?
+		ORDER BY number ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, sbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review issues: %w", err)
+	}
+	defer rows.Close()
+
+	issues := []*repository.ReviewIssue{}
+	for rows.Next() {
+		issue := &repository.ReviewIssue{}
+		var status string
+		if err := rows.Scan(&issue.ID, &issue.SBIID, &issue.Number, &issue.Description, &issue.Blocking, &status, &issue.Note,
+			&issue.RaisedTurn, &issue.Turn, &issue.CreatedAt, &issue.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review issue: %w", err)
+		}
+		issue.Status = repository.ReviewIssueStatus(status)
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// UpdateStatus sets the status and note of a single issue
+func (r *ReviewIssueRepositoryImpl) UpdateStatus(ctx context.Context, sbiID string, number int, status repository.ReviewIssueStatus, note string, turn int) error {
+	query := `
+		UPDATE sbi_review_issues
+		SET status = ?, note = ?, turn = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE sbi_id = ? AND number = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, string(status), note, turn, sbiID, number)
+	if err != nil {
+		return fmt.Errorf("failed to update review issue: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("review issue ISSUE-%d %w for SBI %s", number, model.ErrNotFound, sbiID)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForReviewIssues(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	migrator := NewMigrator(db)
+	require.NoError(t, migrator.Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-RI-001', 'Issue test', 'REVIEWING', 'REVIEW', 0)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestReviewIssueRepository_AddAndFind(t *testing.T) {
+	db := setupTestDBForReviewIssues(t)
+	defer db.Close()
+
+	repo := NewReviewIssueRepository(db)
+	ctx := context.Background()
+
+	found, err := repo.FindBySBIID(ctx, "SBI-RI-001")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	first := []*repository.ReviewIssue{
+		{Description: "Handle the nil config", Blocking: true, RaisedTurn: 1},
+		{Description: "nit: rename helper", RaisedTurn: 1},
+	}
+	require.NoError(t, repo.Add(ctx, "SBI-RI-001", first))
+	assert.Equal(t, 1, first[0].Number)
+	assert.Equal(t, 2, first[1].Number)
+	assert.NotZero(t, first[0].ID)
+
+	// Issues raised by a later review are numbered after the existing ones
+	second := []*repository.ReviewIssue{{Description: "Add a regression test", Blocking: true, RaisedTurn: 2}}
+	require.NoError(t, repo.Add(ctx, "SBI-RI-001", second))
+	assert.Equal(t, 3, second[0].Number)
+
+	found, err = repo.FindBySBIID(ctx, "SBI-RI-001")
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	assert.Equal(t, "Handle the nil config", found[0].Description)
+	assert.True(t, found[0].Blocking)
+	assert.False(t, found[1].Blocking)
+	assert.Equal(t, repository.ReviewIssueOpen, found[0].Status)
+	assert.Equal(t, 1, found[0].Turn)
+	assert.Equal(t, 2, found[2].RaisedTurn)
+}
+
+func TestReviewIssueRepository_UpdateStatus(t *testing.T) {
+	db := setupTestDBForReviewIssues(t)
+	defer db.Close()
+
+	repo := NewReviewIssueRepository(db)
+	ctx := context.Background()
+	require.NoError(t, repo.Add(ctx, "SBI-RI-001", []*repository.ReviewIssue{{Description: "Handle the nil config", Blocking: true, RaisedTurn: 1}}))
+
+	require.NoError(t, repo.UpdateStatus(ctx, "SBI-RI-001", 1, repository.ReviewIssueAddressed, "guard added", 2))
+	found, err := repo.FindBySBIID(ctx, "SBI-RI-001")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, repository.ReviewIssueAddressed, found[0].Status)
+	assert.True(t, found[0].Status.IsClosed())
+	assert.Equal(t, "guard added", found[0].Note)
+	assert.Equal(t, 2, found[0].Turn)
+	assert.Equal(t, 1, found[0].RaisedTurn)
+
+	err = repo.UpdateStatus(ctx, "SBI-RI-001", 9, repository.ReviewIssueWontFix, "", 2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ISSUE-9 not found")
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ReviewVoteRepositoryImpl implements ReviewVoteRepository using SQLite
+type ReviewVoteRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewReviewVoteRepository creates a new ReviewVoteRepository implementation
+func NewReviewVoteRepository(db *sql.DB) repository.ReviewVoteRepository {
+	return &ReviewVoteRepositoryImpl{db: db}
+}
+
+// Save stores a reviewer's vote, replacing the reviewer's previous vote for the turn
+func (r *ReviewVoteRepositoryImpl) Save(ctx context.Context, vote *repository.ReviewVote) error {
+	query := `
+		INSERT INTO sbi_review_votes (sbi_id, turn, reviewer, decision, report_path, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(sbi_id, turn, reviewer) DO UPDATE SET
+			decision = excluded.decision,
+			report_path = excluded.report_path,
+			created_at = excluded.created_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		vote.SBIID,
+		vote.Turn,
+		vote.Reviewer,
+		vote.Decision,
+		vote.ReportPath,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save review vote: %w", err)
+	}
+	return nil
+}
+
+// FindByTurn retrieves all votes cast for an SBI turn, ordered by reviewer
+func (r *ReviewVoteRepositoryImpl) FindByTurn(ctx context.Context, sbiID string, turn int) ([]*repository.ReviewVote, error) {
+	query := `
+		SELECT sbi_id, turn, reviewer, decision, COALESCE(report_path, ''), created_at
+		FROM sbi_review_votes
+		WHERE sbi_id = ? AND turn = ?
+		ORDER BY reviewer
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, sbiID, turn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review votes: %w", err)
+	}
+	defer rows.Close()
+
+	var votes []*repository.ReviewVote
+	for rows.Next() {
+		vote := &repository.ReviewVote{}
+		if err := rows.Scan(
+			&vote.SBIID,
+			&vote.Turn,
+			&vote.Reviewer,
+			&vote.Decision,
+			&vote.ReportPath,
+			&vote.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan review vote: %w", err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForReviewVotes(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	migrator := NewMigrator(db)
+	require.NoError(t, migrator.Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-VOTE-001', 'Vote test', 'REVIEWING', 'REVIEW', 0)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestReviewVoteRepository_SaveAndFindByTurn(t *testing.T) {
+	db := setupTestDBForReviewVotes(t)
+	defer db.Close()
+
+	repo := NewReviewVoteRepository(db)
+	ctx := context.Background()
+
+	votes, err := repo.FindByTurn(ctx, "SBI-VOTE-001", 2)
+	require.NoError(t, err)
+	assert.Empty(t, votes)
+
+	require.NoError(t, repo.Save(ctx, &repository.ReviewVote{
+		SBIID: "SBI-VOTE-001", Turn: 2, Reviewer: "security", Decision: "NEEDS_CHANGES", ReportPath: "review_2_security.md",
+	}))
+	require.NoError(t, repo.Save(ctx, &repository.ReviewVote{
+		SBIID: "SBI-VOTE-001", Turn: 2, Reviewer: "design", Decision: "SUCCEEDED",
+	}))
+	// A reviewer reporting twice for the same turn replaces its vote
+	require.NoError(t, repo.Save(ctx, &repository.ReviewVote{
+		SBIID: "SBI-VOTE-001", Turn: 2, Reviewer: "security", Decision: "SUCCEEDED", ReportPath: "review_2_security.md",
+	}))
+	// Votes of other turns are kept apart
+	require.NoError(t, repo.Save(ctx, &repository.ReviewVote{
+		SBIID: "SBI-VOTE-001", Turn: 3, Reviewer: "design", Decision: "FAILED",
+	}))
+
+	votes, err = repo.FindByTurn(ctx, "SBI-VOTE-001", 2)
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+	assert.Equal(t, "design", votes[0].Reviewer)
+	assert.Equal(t, "SUCCEEDED", votes[0].Decision)
+	assert.Equal(t, "security", votes[1].Reviewer)
+	assert.Equal(t, "SUCCEEDED", votes[1].Decision)
+	assert.Equal(t, "review_2_security.md", votes[1].ReportPath)
+}
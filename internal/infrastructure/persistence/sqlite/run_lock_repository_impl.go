@@ -5,13 +5,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/process"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
 )
 
@@ -42,7 +41,7 @@ func (r *RunLockRepositoryImpl) Acquire(ctx context.Context, lockID lock.LockID,
 	existing, err := r.Find(ctx, lockID)
 	if err == nil {
 		// Lock exists - check if it's stale
-		isStale := existing.IsExpired() || !isProcessRunning(existing.PID())
+		isStale := existing.IsExpired() || !process.IsLockHolderRunning(existing.Hostname(), existing.PID())
 
 		if !isStale {
 			// Lock is held by an active process
@@ -84,22 +83,33 @@ func (r *RunLockRepositoryImpl) Acquire(ctx context.Context, lockID lock.LockID,
 		return nil, fmt.Errorf("marshal metadata: %w", err)
 	}
 
-	// Step 3: Insert new lock
-	// If UNIQUE constraint fails, another process acquired the lock
+	// Step 3: Insert new lock with the next fencing token
+	// If UNIQUE constraint fails, another process acquired the lock and the token is rolled back
 	insertQuery := `
-		INSERT INTO run_locks (lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, metadata)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO run_locks (lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, metadata, fencing_token)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err = db.ExecContext(ctx, insertQuery,
-		runLock.LockID().String(),
-		runLock.PID(),
-		runLock.Hostname(),
-		runLock.AcquiredAt().Format(time.RFC3339Nano),
-		runLock.ExpiresAt().Format(time.RFC3339Nano),
-		runLock.HeartbeatAt().Format(time.RFC3339Nano),
-		string(metadataJSON),
-	)
+	err = withinTx(ctx, r.db, func(txCtx context.Context) error {
+		txDB := r.getDB(txCtx)
+		token, err := issueFencingToken(txCtx, txDB, lockID)
+		if err != nil {
+			return err
+		}
+		runLock.SetFencingToken(token)
+
+		_, err = txDB.ExecContext(txCtx, insertQuery,
+			runLock.LockID().String(),
+			runLock.PID(),
+			runLock.Hostname(),
+			runLock.AcquiredAt().Format(time.RFC3339Nano),
+			runLock.ExpiresAt().Format(time.RFC3339Nano),
+			runLock.HeartbeatAt().Format(time.RFC3339Nano),
+			string(metadataJSON),
+			runLock.FencingToken(),
+		)
+		return err
+	})
 
 	if err != nil {
 		// Check if it's a UNIQUE constraint violation
@@ -115,10 +125,11 @@ func (r *RunLockRepositoryImpl) Acquire(ctx context.Context, lockID lock.LockID,
 
 // Release releases a run lock
 func (r *RunLockRepositoryImpl) Release(ctx context.Context, lockID lock.LockID) error {
-	query := `DELETE FROM run_locks WHERE lock_id = ?`
+	fenceSQL, fenceArgs := fenceCondition(ctx, lockID)
+	query := `DELETE FROM run_locks WHERE lock_id = ?` + fenceSQL
 
 	db := r.getDB(ctx)
-	result, err := db.ExecContext(ctx, query, lockID.String())
+	result, err := db.ExecContext(ctx, query, append([]interface{}{lockID.String()}, fenceArgs...)...)
 	if err != nil {
 		return fmt.Errorf("delete run lock: %w", err)
 	}
@@ -129,7 +140,7 @@ func (r *RunLockRepositoryImpl) Release(ctx context.Context, lockID lock.LockID)
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("lock not found: %s", lockID.String())
+		return fmt.Errorf("%w: %s", lock.ErrLockNotFound, lockID.String())
 	}
 
 	return nil
@@ -138,7 +149,7 @@ func (r *RunLockRepositoryImpl) Release(ctx context.Context, lockID lock.LockID)
 // Find retrieves a run lock by ID
 func (r *RunLockRepositoryImpl) Find(ctx context.Context, lockID lock.LockID) (*lock.RunLock, error) {
 	query := `
-		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, metadata
+		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, metadata, fencing_token
 		FROM run_locks
 		WHERE lock_id = ?
 	`
@@ -154,12 +165,13 @@ func (r *RunLockRepositoryImpl) Find(ctx context.Context, lockID lock.LockID) (*
 		expiresAt    string
 		heartbeatAt  string
 		metadataJSON sql.NullString
+		fencingToken int64
 	)
 
-	err := row.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &metadataJSON)
+	err := row.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &metadataJSON, &fencingToken)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("run lock not found: %s", lockID.String())
+			return nil, fmt.Errorf("run %w: %s", lock.ErrLockNotFound, lockID.String())
 		}
 		return nil, fmt.Errorf("scan run lock: %w", err)
 	}
@@ -201,15 +213,19 @@ func (r *RunLockRepositoryImpl) Find(ctx context.Context, lockID lock.LockID) (*
 		return nil, fmt.Errorf("invalid lock ID: %w", err)
 	}
 
-	return lock.ReconstructRunLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, metadata), nil
+	runLock := lock.ReconstructRunLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, metadata)
+	runLock.SetFencingToken(fencingToken)
+	return runLock, nil
 }
 
 // UpdateHeartbeat updates the heartbeat timestamp for a lock
 func (r *RunLockRepositoryImpl) UpdateHeartbeat(ctx context.Context, lockID lock.LockID) error {
-	query := `UPDATE run_locks SET heartbeat_at = ? WHERE lock_id = ?`
+	fenceSQL, fenceArgs := fenceCondition(ctx, lockID)
+	query := `UPDATE run_locks SET heartbeat_at = ? WHERE lock_id = ?` + fenceSQL
 
 	db := r.getDB(ctx)
-	result, err := db.ExecContext(ctx, query, time.Now().UTC().Format(time.RFC3339Nano), lockID.String())
+	args := append([]interface{}{time.Now().UTC().Format(time.RFC3339Nano), lockID.String()}, fenceArgs...)
+	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("update heartbeat: %w", err)
 	}
@@ -220,7 +236,7 @@ func (r *RunLockRepositoryImpl) UpdateHeartbeat(ctx context.Context, lockID lock
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("lock not found: %s", lockID.String())
+		return fmt.Errorf("%w: %s", lock.ErrLockNotFound, lockID.String())
 	}
 
 	return nil
@@ -269,7 +285,7 @@ func (r *RunLockRepositoryImpl) CleanupExpired(ctx context.Context) (int, error)
 // List lists all active run locks
 func (r *RunLockRepositoryImpl) List(ctx context.Context) ([]*lock.RunLock, error) {
 	query := `
-		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, metadata
+		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, metadata, fencing_token
 		FROM run_locks
 		ORDER BY acquired_at DESC
 	`
@@ -291,9 +307,10 @@ func (r *RunLockRepositoryImpl) List(ctx context.Context) ([]*lock.RunLock, erro
 			expiresAt    string
 			heartbeatAt  string
 			metadataJSON sql.NullString
+			fencingToken int64
 		)
 
-		if err := rows.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &metadataJSON); err != nil {
+		if err := rows.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &metadataJSON, &fencingToken); err != nil {
 			return nil, fmt.Errorf("scan run lock: %w", err)
 		}
 
@@ -320,7 +337,9 @@ func (r *RunLockRepositoryImpl) List(ctx context.Context) ([]*lock.RunLock, erro
 		// Reconstruct lock ID
 		lid, _ := lock.NewLockID(lockIDStr)
 
-		locks = append(locks, lock.ReconstructRunLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, metadata))
+		runLock := lock.ReconstructRunLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, metadata)
+		runLock.SetFencingToken(fencingToken)
+		locks = append(locks, runLock)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -330,16 +349,6 @@ func (r *RunLockRepositoryImpl) List(ctx context.Context) ([]*lock.RunLock, erro
 	return locks, nil
 }
 
-// isProcessRunning checks if a process with the given PID is running
-// Returns true if the process exists and is running, false otherwise
-func isProcessRunning(pid int) bool {
-	// Use ps command to check if process exists
-	// This works on Unix-like systems (Linux, macOS)
-	cmd := exec.Command("ps", "-p", strconv.Itoa(pid))
-	err := cmd.Run()
-	return err == nil
-}
-
 // isUniqueConstraintError checks if the error is a UNIQUE constraint violation
 func isUniqueConstraintError(err error) bool {
 	if err == nil {
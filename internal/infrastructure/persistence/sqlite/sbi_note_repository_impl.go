@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// SBINoteRepositoryImpl implements SBINoteRepository using SQLite
+type SBINoteRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewSBINoteRepository creates a new SBINoteRepository implementation
+func NewSBINoteRepository(db *sql.DB) repository.SBINoteRepository {
+	return &SBINoteRepositoryImpl{db: db}
+}
+
+const sbiNoteColumns = `id, sbi_id, body, links, author, inject_prompt, injected_turn, created_at`
+
+// Add saves a new note and sets its ID
+func (r *SBINoteRepositoryImpl) Add(ctx context.Context, note *repository.SBINote) error {
+	links := note.Links
+	if links == nil {
+		links = []string{}
+	}
+	linksJSON, err := json.Marshal(links)
+	if err != nil {
+		return fmt.Errorf("failed to marshal note links: %w", err)
+	}
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO sbi_notes (sbi_id, body, links, author, inject_prompt, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, note.SBIID, note.Body, string(linksJSON), note.Author, note.InjectPrompt, note.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to add SBI note: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get SBI note ID: %w", err)
+	}
+	note.ID = id
+	return nil
+}
+
+// FindBySBIID retrieves the notes of an SBI, oldest first
+func (r *SBINoteRepositoryImpl) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.SBINote, error) {
+	return r.query(ctx, `SELECT `+sbiNoteColumns+` FROM sbi_notes WHERE sbi_id = ? ORDER BY id`, sbiID)
+}
+
+// FindForPrompt retrieves the notes to inject into the prompt of a turn
+func (r *SBINoteRepositoryImpl) FindForPrompt(ctx context.Context, sbiID string, turn int) ([]*repository.SBINote, error) {
+	return r.query(ctx, `
+		SELECT `+sbiNoteColumns+` FROM sbi_notes
+		WHERE sbi_id = ? AND inject_prompt = 1 AND (injected_turn IS NULL OR injected_turn = ?)
+		ORDER BY id
+	`, sbiID, turn)
+}
+
+// MarkInjected records that the prompt of turn received the notes
+func (r *SBINoteRepositoryImpl) MarkInjected(ctx context.Context, ids []int64, turn int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, turn)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+
+	_, err := r.db.ExecContext(ctx, `UPDATE sbi_notes SET injected_turn = ? WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark SBI notes injected: %w", err)
+	}
+	return nil
+}
+
+// SetInjectPrompt marks a note of an SBI for the prompt of the next turn, or unmarks it
+func (r *SBINoteRepositoryImpl) SetInjectPrompt(ctx context.Context, sbiID string, id int64, inject bool) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE sbi_notes SET inject_prompt = ?, injected_turn = NULL WHERE sbi_id = ? AND id = ?`,
+		inject, sbiID, id)
+	if err != nil {
+		return fmt.Errorf("failed to update SBI note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %d", repository.ErrSBINoteNotFound, id)
+	}
+	return nil
+}
+
+// Delete removes a note of an SBI
+func (r *SBINoteRepositoryImpl) Delete(ctx context.Context, sbiID string, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM sbi_notes WHERE sbi_id = ? AND id = ?`, sbiID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete SBI note: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %d", repository.ErrSBINoteNotFound, id)
+	}
+	return nil
+}
+
+// query runs a note query and scans every row
+func (r *SBINoteRepositoryImpl) query(ctx context.Context, query string, args ...interface{}) ([]*repository.SBINote, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SBI notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*repository.SBINote
+	for rows.Next() {
+		note := &repository.SBINote{}
+		var linksJSON, createdAt string
+		var injectedTurn sql.NullInt64
+		if err := rows.Scan(&note.ID, &note.SBIID, &note.Body, &linksJSON, &note.Author, &note.InjectPrompt, &injectedTurn, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan SBI note: %w", err)
+		}
+		if err := json.Unmarshal([]byte(linksJSON), &note.Links); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal note links: %w", err)
+		}
+		note.InjectedTurn = int(injectedTurn.Int64)
+		if note.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			return nil, fmt.Errorf("failed to parse note created_at: %w", err)
+		}
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate SBI notes: %w", err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForSBINotes(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	migrator := NewMigrator(db)
+	require.NoError(t, migrator.Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-NOTE-001', 'Note test', 'PENDING', 'PICK', 0)
+	`)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestSBINoteRepository_AddFindDelete(t *testing.T) {
+	db := setupTestDBForSBINotes(t)
+	defer db.Close()
+
+	repo := NewSBINoteRepository(db)
+	ctx := context.Background()
+
+	first := &repository.SBINote{SBIID: "SBI-NOTE-001", Body: "blocked on infra ticket INFRA-42", Links: []string{"https://tracker.example/INFRA-42"}, Author: "alice"}
+	require.NoError(t, repo.Add(ctx, first))
+	assert.NotZero(t, first.ID)
+	second := &repository.SBINote{SBIID: "SBI-NOTE-001", Body: "unblocked"}
+	require.NoError(t, repo.Add(ctx, second))
+
+	notes, err := repo.FindBySBIID(ctx, "SBI-NOTE-001")
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, "blocked on infra ticket INFRA-42", notes[0].Body)
+	assert.Equal(t, []string{"https://tracker.example/INFRA-42"}, notes[0].Links)
+	assert.Equal(t, "alice", notes[0].Author)
+	assert.Empty(t, notes[1].Links)
+	assert.False(t, notes[0].CreatedAt.IsZero())
+
+	require.NoError(t, repo.Delete(ctx, "SBI-NOTE-001", first.ID))
+	err = repo.Delete(ctx, "SBI-NOTE-001", first.ID)
+	assert.True(t, errors.Is(err, repository.ErrSBINoteNotFound))
+	err = repo.Delete(ctx, "SBI-OTHER", second.ID)
+	assert.True(t, errors.Is(err, repository.ErrSBINoteNotFound), "a note is only deleted through its own SBI")
+
+	notes, err = repo.FindBySBIID(ctx, "SBI-NOTE-001")
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+}
+
+func TestSBINoteRepository_PromptInjection(t *testing.T) {
+	db := setupTestDBForSBINotes(t)
+	defer db.Close()
+
+	repo := NewSBINoteRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Add(ctx, &repository.SBINote{SBIID: "SBI-NOTE-001", Body: "for people only"}))
+	inject := &repository.SBINote{SBIID: "SBI-NOTE-001", Body: "use the v2 endpoint", InjectPrompt: true}
+	require.NoError(t, repo.Add(ctx, inject))
+
+	notes, err := repo.FindForPrompt(ctx, "SBI-NOTE-001", 3)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "use the v2 endpoint", notes[0].Body)
+
+	require.NoError(t, repo.MarkInjected(ctx, []int64{inject.ID}, 3))
+
+	// A retried turn receives the note again; later turns do not
+	notes, err = repo.FindForPrompt(ctx, "SBI-NOTE-001", 3)
+	require.NoError(t, err)
+	assert.Len(t, notes, 1)
+	notes, err = repo.FindForPrompt(ctx, "SBI-NOTE-001", 4)
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	all, err := repo.FindBySBIID(ctx, "SBI-NOTE-001")
+	require.NoError(t, err)
+	assert.Equal(t, 3, all[1].InjectedTurn)
+	assert.Zero(t, all[0].InjectedTurn)
+
+	// Marking a note again nudges the next turn; unmarking keeps it for people only
+	require.NoError(t, repo.SetInjectPrompt(ctx, "SBI-NOTE-001", inject.ID, true))
+	notes, err = repo.FindForPrompt(ctx, "SBI-NOTE-001", 4)
+	require.NoError(t, err)
+	assert.Len(t, notes, 1)
+	require.NoError(t, repo.SetInjectPrompt(ctx, "SBI-NOTE-001", inject.ID, false))
+	notes, err = repo.FindForPrompt(ctx, "SBI-NOTE-001", 4)
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	err = repo.SetInjectPrompt(ctx, "SBI-NOTE-002", inject.ID, true)
+	assert.ErrorIs(t, err, repository.ErrSBINoteNotFound, "notes of other SBIs are not touched")
+}
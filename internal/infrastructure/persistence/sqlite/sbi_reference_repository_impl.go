@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// SBIReferenceRepositoryImpl implements SBIReferenceRepository using SQLite
+type SBIReferenceRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewSBIReferenceRepository creates a new SBIReferenceRepository implementation
+func NewSBIReferenceRepository(db *sql.DB) repository.SBIReferenceRepository {
+	return &SBIReferenceRepositoryImpl{db: db}
+}
+
+// Add saves a reference, replacing the note of an existing one
+func (r *SBIReferenceRepositoryImpl) Add(ctx context.Context, ref *repository.SBIReference) error {
+	if ref.CreatedAt.IsZero() {
+		ref.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO sbi_references (sbi_id, referenced_sbi_id, note, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(sbi_id, referenced_sbi_id) DO UPDATE SET note = excluded.note
+	`, ref.SBIID, ref.ReferencedSBIID, ref.Note, ref.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to add SBI reference: %w", err)
+	}
+	return nil
+}
+
+// FindBySBIID retrieves the references of an SBI, oldest first
+// References to trashed SBIs are left out until the SBI is restored.
+func (r *SBIReferenceRepositoryImpl) FindBySBIID(ctx context.Context, sbiID string) ([]*repository.SBIReference, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT r.sbi_id, r.referenced_sbi_id, r.note, r.created_at
+		FROM sbi_references r
+		JOIN sbis s ON s.id = r.referenced_sbi_id
+		WHERE r.sbi_id = ? AND s.deleted_at IS NULL
+		ORDER BY r.created_at, r.referenced_sbi_id
+	`, sbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SBI references: %w", err)
+	}
+	defer rows.Close()
+
+	var refs []*repository.SBIReference
+	for rows.Next() {
+		ref := &repository.SBIReference{}
+		var createdAt string
+		if err := rows.Scan(&ref.SBIID, &ref.ReferencedSBIID, &ref.Note, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan SBI reference: %w", err)
+		}
+		if ref.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			return nil, fmt.Errorf("failed to parse reference created_at: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate SBI references: %w", err)
+	}
+	return refs, nil
+}
+
+// Delete removes a reference
+func (r *SBIReferenceRepositoryImpl) Delete(ctx context.Context, sbiID, referencedSBIID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM sbi_references WHERE sbi_id = ? AND referenced_sbi_id = ?`, sbiID, referencedSBIID)
+	if err != nil {
+		return fmt.Errorf("failed to delete SBI reference: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s -> %s", repository.ErrSBIReferenceNotFound, sbiID, referencedSBIID)
+	}
+	return nil
+}
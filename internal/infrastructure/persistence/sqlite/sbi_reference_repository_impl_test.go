@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForSBIReferences(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority) VALUES
+			('SBI-REF-001', 'Design doc', 'DONE', 'DONE', 0),
+			('SBI-REF-002', 'API schema', 'DONE', 'DONE', 0),
+			('SBI-REF-003', 'Implementation', 'PENDING', 'PICK', 0)
+	`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestSBIReferenceRepository_AddFindDelete(t *testing.T) {
+	db := setupTestDBForSBIReferences(t)
+	repo := NewSBIReferenceRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.Add(ctx, &repository.SBIReference{SBIID: "SBI-REF-003", ReferencedSBIID: "SBI-REF-001", Note: "design", CreatedAt: now.Add(-time.Hour)}))
+	require.NoError(t, repo.Add(ctx, &repository.SBIReference{SBIID: "SBI-REF-003", ReferencedSBIID: "SBI-REF-002", CreatedAt: now}))
+	// Adding a reference again replaces its note
+	require.NoError(t, repo.Add(ctx, &repository.SBIReference{SBIID: "SBI-REF-003", ReferencedSBIID: "SBI-REF-001", Note: "builds upon the design doc"}))
+	assert.Error(t, repo.Add(ctx, &repository.SBIReference{SBIID: "SBI-REF-003", ReferencedSBIID: "SBI-REF-003"}), "an SBI cannot reference itself")
+
+	refs, err := repo.FindBySBIID(ctx, "SBI-REF-003")
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	assert.Equal(t, "SBI-REF-001", refs[0].ReferencedSBIID)
+	assert.Equal(t, "builds upon the design doc", refs[0].Note)
+	assert.Equal(t, "SBI-REF-002", refs[1].ReferencedSBIID)
+
+	// References to trashed SBIs are hidden
+	_, err = db.Exec(`UPDATE sbis SET deleted_at = CURRENT_TIMESTAMP WHERE id = 'SBI-REF-002'`)
+	require.NoError(t, err)
+	refs, err = repo.FindBySBIID(ctx, "SBI-REF-003")
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+
+	require.NoError(t, repo.Delete(ctx, "SBI-REF-003", "SBI-REF-001"))
+	err = repo.Delete(ctx, "SBI-REF-003", "SBI-REF-001")
+	assert.True(t, errors.Is(err, repository.ErrSBIReferenceNotFound))
+
+	// Purging the referenced SBI removes the reference
+	_, err = db.Exec(`DELETE FROM sbis WHERE id = 'SBI-REF-002'`)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sbi_references`).Scan(&count))
+	assert.Zero(t, count)
+}
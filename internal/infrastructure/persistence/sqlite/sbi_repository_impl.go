@@ -5,51 +5,92 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
 )
 
+// sbiColumns are the columns of the sbis table scanned into an SBI entity, in scan order
+const sbiColumns = `id, title, description, status, current_step, parent_pbi_id,
+		       estimated_hours, priority, sequence, registered_at, started_at, completed_at, deadline, review_started_at,
+		       labels, assigned_agent, file_paths, env,
+		       current_turn, current_attempt, max_turns, max_attempts, last_error, artifact_paths,
+		       only_implement, estimate_suggestion,
+		       created_at, updated_at, version`
+
+// findManyBatchSize bounds the IDs bound in one FindMany query, below SQLite's variable limit
+const findManyBatchSize = 500
+
 // SBIRepositoryImpl implements repository.SBIRepository with SQLite
+// Statements run through the store's prepared statement caches: writes on its write
+// connection and reads on its read pool, unless ctx carries a transaction
 type SBIRepositoryImpl struct {
-	db *sql.DB
+	store *Store
 }
 
-// getDB returns the appropriate database executor from context
+// getDB returns the appropriate database executor for writes from context
 func (r *SBIRepositoryImpl) getDB(ctx context.Context) dbExecutor {
 	if tx, ok := transaction.GetTxFromContext(ctx); ok {
 		return tx
 	}
-	return r.db
+	return r.store.writeStmts
 }
 
-// NewSBIRepository creates a new SQLite-based SBI repository
+// getReadDB returns the appropriate database executor for reads from context
+// Reads inside a transaction use it, so that they see its uncommitted writes
+func (r *SBIRepositoryImpl) getReadDB(ctx context.Context) dbExecutor {
+	if tx, ok := transaction.GetTxFromContext(ctx); ok {
+		return tx
+	}
+	return r.store.readStmts
+}
+
+// NewSBIRepository creates a new SQLite-based SBI repository on a single connection pool
 func NewSBIRepository(db *sql.DB) repository.SBIRepository {
-	return &SBIRepositoryImpl{db: db}
+	return NewSBIRepositoryWithStore(NewStore(db))
+}
+
+// NewSBIRepositoryWithStore creates a new SQLite-based SBI repository on a store
+func NewSBIRepositoryWithStore(store *Store) repository.SBIRepository {
+	return &SBIRepositoryImpl{store: store}
 }
 
 // Find retrieves an SBI by its ID
 func (r *SBIRepositoryImpl) Find(ctx context.Context, id repository.SBIID) (*sbi.SBI, error) {
 	query := `
-		SELECT id, title, description, status, current_step, parent_pbi_id,
-		       estimated_hours, priority, sequence, registered_at, started_at, completed_at,
-		       labels, assigned_agent, file_paths,
-		       current_turn, current_attempt, max_turns, max_attempts, last_error, artifact_paths,
-		       only_implement,
-		       created_at, updated_at
+		SELECT ` + sbiColumns + `
 		FROM sbis
-		WHERE id = ?
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
-	db := r.getDB(ctx)
+	db := r.getReadDB(ctx)
 	return r.scanSBI(db.QueryRowContext(ctx, query, string(id)))
 }
 
 // Save persists an SBI entity
+// The write succeeds only at the version the SBI was loaded at and increments it. A stored
+// CANCELLED SBI fails with repository.ErrSBICancelled, any other concurrent save with
+// repository.ErrStaleEntity. When ctx carries a lock fence (lock.WithFence), the write is rejected with
+// lock.ErrStaleFencingToken if the lock has since been taken over by a newer holder
 func (r *SBIRepositoryImpl) Save(ctx context.Context, s *sbi.SBI) error {
+	if _, fenced := lock.FenceFromContext(ctx); fenced {
+		return withinTx(ctx, r.store.Writer(), func(txCtx context.Context) error {
+			if err := checkFence(txCtx, r.getDB(txCtx)); err != nil {
+				return err
+			}
+			return r.save(txCtx, s)
+		})
+	}
+	return r.save(ctx, s)
+}
+
+// save writes an SBI entity without fencing checks
+func (r *SBIRepositoryImpl) save(ctx context.Context, s *sbi.SBI) error {
 	metadata := s.Metadata()
 	execution := s.ExecutionState()
 
@@ -69,6 +110,26 @@ func (r *SBIRepositoryImpl) Save(ctx context.Context, s *sbi.SBI) error {
 		return fmt.Errorf("marshal artifact paths failed: %w", err)
 	}
 
+	// Handle env (NULL if not set)
+	var envJSON interface{}
+	if len(metadata.Env) > 0 {
+		data, err := json.Marshal(metadata.Env)
+		if err != nil {
+			return fmt.Errorf("marshal env failed: %w", err)
+		}
+		envJSON = string(data)
+	}
+
+	// Handle estimate_suggestion (NULL unless a suggestion is pending)
+	var estimateSuggestionJSON interface{}
+	if metadata.EstimateSuggestion != nil {
+		data, err := json.Marshal(metadata.EstimateSuggestion)
+		if err != nil {
+			return fmt.Errorf("marshal estimate suggestion failed: %w", err)
+		}
+		estimateSuggestionJSON = string(data)
+	}
+
 	// Handle optional parent PBI ID
 	var parentPBIID interface{}
 	if s.ParentTaskID() != nil {
@@ -99,14 +160,26 @@ func (r *SBIRepositoryImpl) Save(ctx context.Context, s *sbi.SBI) error {
 		completedAt = *metadata.CompletedAt
 	}
 
+	// Handle review_started_at (NULL unless reviewing)
+	var reviewStartedAt interface{}
+	if metadata.ReviewStartedAt != nil {
+		reviewStartedAt = *metadata.ReviewStartedAt
+	}
+
+	// Handle deadline (NULL if not set, UTC RFC3339 so that it compares correctly as a string)
+	var deadline interface{}
+	if metadata.Deadline != nil {
+		deadline = metadata.Deadline.UTC().Format(time.RFC3339)
+	}
+
 	query := `
 		INSERT INTO sbis (id, title, description, status, current_step, parent_pbi_id,
-		                  estimated_hours, priority, sequence, registered_at, started_at, completed_at,
-		                  labels, assigned_agent, file_paths,
+		                  estimated_hours, priority, sequence, registered_at, started_at, completed_at, deadline, review_started_at,
+		                  labels, assigned_agent, file_paths, env,
 		                  current_turn, current_attempt, max_turns, max_attempts, last_error, artifact_paths,
-		                  only_implement,
-		                  created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                  only_implement, estimate_suggestion,
+		                  created_at, updated_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			title = excluded.title,
 			description = excluded.description,
@@ -119,9 +192,12 @@ func (r *SBIRepositoryImpl) Save(ctx context.Context, s *sbi.SBI) error {
 			registered_at = excluded.registered_at,
 			started_at = excluded.started_at,
 			completed_at = excluded.completed_at,
+			deadline = excluded.deadline,
+			review_started_at = excluded.review_started_at,
 			labels = excluded.labels,
 			assigned_agent = excluded.assigned_agent,
 			file_paths = excluded.file_paths,
+			env = excluded.env,
 			current_turn = excluded.current_turn,
 			current_attempt = excluded.current_attempt,
 			max_turns = excluded.max_turns,
@@ -129,24 +205,46 @@ func (r *SBIRepositoryImpl) Save(ctx context.Context, s *sbi.SBI) error {
 			last_error = excluded.last_error,
 			artifact_paths = excluded.artifact_paths,
 			only_implement = excluded.only_implement,
-			updated_at = excluded.updated_at
+			estimate_suggestion = excluded.estimate_suggestion,
+			updated_at = excluded.updated_at,
+			version = sbis.version + 1
+		WHERE (sbis.status <> ? OR excluded.status = ?) AND sbis.version = ?
 	`
 
 	db := r.getDB(ctx)
-	_, err = db.ExecContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		s.ID().String(), s.Title(), s.Description(),
 		string(s.Status()), string(s.CurrentStep()), parentPBIID,
-		metadata.EstimatedHours, metadata.Priority, sequence, registeredAt, startedAt, completedAt,
-		string(labelsJSON), metadata.AssignedAgent, string(filePathsJSON),
+		metadata.EstimatedHours, metadata.Priority, sequence, registeredAt, startedAt, completedAt, deadline, reviewStartedAt,
+		string(labelsJSON), metadata.AssignedAgent, string(filePathsJSON), envJSON,
 		execution.CurrentTurn.Value(), execution.CurrentAttempt.Value(), execution.MaxTurns, execution.MaxAttempts,
 		execution.LastError, string(artifactPathsJSON),
-		metadata.OnlyImplement,
-		s.CreatedAt().Value(), s.UpdatedAt().Value(),
+		metadata.OnlyImplement, estimateSuggestionJSON,
+		s.CreatedAt().Value(), s.UpdatedAt().Value(), s.Version()+1,
+		string(model.StatusCancelled), string(model.StatusCancelled), s.Version(),
 	)
 	if err != nil {
 		return fmt.Errorf("save SBI failed: %w", err)
 	}
 
+	// The conditional upsert leaves a cancelled or concurrently saved row untouched,
+	// checked atomically with the write
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected failed: %w", err)
+	}
+	if rows == 0 {
+		var stored string
+		if err := db.QueryRowContext(ctx, `SELECT status FROM sbis WHERE id = ?`, s.ID().String()).Scan(&stored); err != nil {
+			return fmt.Errorf("check stored SBI failed: %w", err)
+		}
+		if stored == string(model.StatusCancelled) && s.Status() != model.StatusCancelled {
+			return fmt.Errorf("save SBI %s: %w", s.ID().String(), repository.ErrSBICancelled)
+		}
+		return fmt.Errorf("save SBI %s: %w", s.ID().String(), repository.ErrStaleEntity)
+	}
+	s.SetVersion(s.Version() + 1)
+
 	return nil
 }
 
@@ -164,7 +262,7 @@ func (r *SBIRepositoryImpl) Delete(ctx context.Context, id repository.SBIID) err
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("SBI not found: %s", id)
+		return fmt.Errorf("SBI %w: %s", model.ErrNotFound, id)
 	}
 
 	return nil
@@ -172,87 +270,282 @@ func (r *SBIRepositoryImpl) Delete(ctx context.Context, id repository.SBIID) err
 
 // List retrieves SBIs by filter
 func (r *SBIRepositoryImpl) List(ctx context.Context, filter repository.SBIFilter) ([]*sbi.SBI, error) {
+	clause, args, err := sbiFilterClause(filter)
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, title, description, status, current_step, parent_pbi_id,
-		       estimated_hours, priority, sequence, registered_at, started_at, completed_at,
-		       labels, assigned_agent, file_paths,
-		       current_turn, current_attempt, max_turns, max_attempts, last_error, artifact_paths,
-		       only_implement,
-		       created_at, updated_at
+		SELECT ` + sbiColumns + `
 		FROM sbis
-		WHERE 1=1
-	`
+		WHERE deleted_at IS NULL
+	` + clause
+
+	db := r.getReadDB(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list SBIs failed: %w", err)
+	}
+	defer rows.Close()
+
+	var sbis []*sbi.SBI
+	for rows.Next() {
+		s, err := r.scanSBIFromRows(rows, ctx)
+		if err != nil {
+			return nil, err
+		}
+		sbis = append(sbis, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate SBIs failed: %w", err)
+	}
+
+	return sbis, nil
+}
+
+// FindMany retrieves the SBIs with the given IDs, querying them in batches
+func (r *SBIRepositoryImpl) FindMany(ctx context.Context, ids []repository.SBIID) ([]*sbi.SBI, error) {
+	found := make(map[string]*sbi.SBI, len(ids))
+	db := r.getReadDB(ctx)
+	for start := 0; start < len(ids); start += findManyBatchSize {
+		batch := ids[start:min(start+findManyBatchSize, len(ids))]
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = string(id)
+		}
+		query := `
+			SELECT ` + sbiColumns + `
+			FROM sbis
+			WHERE id IN (` + placeholders(len(batch)) + `) AND deleted_at IS NULL
+		`
+
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("find SBIs failed: %w", err)
+		}
+		for rows.Next() {
+			s, err := r.scanSBIFromRows(rows, ctx)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			found[s.ID().String()] = s
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("iterate SBIs failed: %w", err)
+		}
+	}
+
+	sbis := make([]*sbi.SBI, 0, len(found))
+	for _, id := range ids {
+		if s, ok := found[string(id)]; ok {
+			sbis = append(sbis, s)
+			delete(found, string(id)) // Duplicate IDs are returned once
+		}
+	}
+	return sbis, nil
+}
+
+// ListWithExecutionState retrieves SBIs by filter with their dependency state in a single query
+// A dependency is unmet unless the SBI it names is stored and DONE
+func (r *SBIRepositoryImpl) ListWithExecutionState(ctx context.Context, filter repository.SBIFilter) ([]*repository.SBIExecutionState, error) {
+	clause, filterArgs, err := sbiFilterClause(filter)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT ` + sbiColumns + `,
+		       (SELECT GROUP_CONCAT(id) FROM (
+		           SELECT d.depends_on_sbi_id AS id
+		             FROM sbi_dependencies d
+		            WHERE d.sbi_id = sbis.id
+		            ORDER BY d.created_at ASC, d.rowid ASC)) AS depends_on,
+		       (SELECT GROUP_CONCAT(id) FROM (
+		           SELECT d.depends_on_sbi_id AS id
+		             FROM sbi_dependencies d
+		             LEFT JOIN sbis dep ON dep.id = d.depends_on_sbi_id
+		            WHERE d.sbi_id = sbis.id AND (dep.status IS NULL OR dep.status <> ?)
+		            ORDER BY d.created_at ASC, d.rowid ASC)) AS unmet_dependencies
+		FROM sbis
+		WHERE sbis.deleted_at IS NULL
+	` + clause
+	args := append([]interface{}{string(model.StatusDone)}, filterArgs...)
+
+	db := r.getReadDB(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list SBIs with execution state failed: %w", err)
+	}
+	defer rows.Close()
+
+	var states []*repository.SBIExecutionState
+	for rows.Next() {
+		var dependsOn, unmet sql.NullString
+		s, err := r.scanSBIColumns(rows, &dependsOn, &unmet)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, &repository.SBIExecutionState{
+			SBI:               s,
+			DependsOn:         splitIDList(dependsOn),
+			UnmetDependencies: splitIDList(unmet),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate SBIs failed: %w", err)
+	}
+
+	return states, nil
+}
+
+// sbiFilterClause builds the conditions, ordering and pagination of an SBI filter
+// The clause follows the WHERE condition on the sbis table that excludes trashed SBIs
+func sbiFilterClause(filter repository.SBIFilter) (string, []interface{}, error) {
+	clause := ""
 	args := []interface{}{}
 
 	// Add status filter
 	if len(filter.Statuses) > 0 {
-		query += " AND status IN ("
+		clause += " AND status IN ("
 		for i, status := range filter.Statuses {
 			if i > 0 {
-				query += ", "
+				clause += ", "
 			}
-			query += "?"
+			clause += "?"
 			args = append(args, string(status))
 		}
-		query += ")"
+		clause += ")"
 	}
 
 	// Add parent PBI filter
 	if filter.PBIID != nil {
-		query += " AND parent_pbi_id = ?"
+		clause += " AND parent_pbi_id = ?"
 		args = append(args, string(*filter.PBIID))
 	}
 
+	// Add label filter (all labels must be present)
+	for _, label := range filter.Labels {
+		clause += " AND EXISTS (SELECT 1 FROM json_each(sbis.labels) WHERE json_each.value = ?)"
+		args = append(args, label)
+	}
+
+	// Add assigned agent filter
+	if len(filter.AssignedAgents) > 0 {
+		clause += " AND assigned_agent IN (" + placeholders(len(filter.AssignedAgents)) + ")"
+		for _, agent := range filter.AssignedAgents {
+			args = append(args, agent)
+		}
+	}
+
+	// Add priority and turn ranges
+	if filter.MinPriority != nil {
+		clause += " AND priority >= ?"
+		args = append(args, *filter.MinPriority)
+	}
+	if filter.MaxPriority != nil {
+		clause += " AND priority <= ?"
+		args = append(args, *filter.MaxPriority)
+	}
+	if filter.MinTurns != nil {
+		clause += " AND current_turn >= ?"
+		args = append(args, *filter.MinTurns)
+	}
+	if filter.MaxTurns != nil {
+		clause += " AND current_turn <= ?"
+		args = append(args, *filter.MaxTurns)
+	}
+
+	// Add last-updated window (updated_at keeps its time zone offset, so compare as julian days)
+	if filter.UpdatedAfter != nil {
+		clause += " AND julianday(updated_at) >= julianday(?)"
+		args = append(args, filter.UpdatedAfter.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.UpdatedBefore != nil {
+		clause += " AND julianday(updated_at) <= julianday(?)"
+		args = append(args, filter.UpdatedBefore.UTC().Format(time.RFC3339Nano))
+	}
+
+	// Add overdue filter (deadline passed and not finished)
+	if filter.OverdueAt != nil {
+		clause += " AND deadline IS NOT NULL AND deadline < ? AND status NOT IN (?, ?, ?)"
+		args = append(args, filter.OverdueAt.UTC().Format(time.RFC3339), string(model.StatusDone), string(model.StatusFailed), string(model.StatusCancelled))
+	}
+
 	// Add ordering and pagination
-	// IMPORTANT: Order by priority DESC, registered_at ASC, sequence ASC for correct task execution order
-	query += " ORDER BY priority DESC, registered_at ASC, sequence ASC"
+	// IMPORTANT: Order by priority DESC, registered_at ASC, sequence ASC for correct task execution order;
+	// requested sort keys come first and ties keep the execution order
+	clause += " ORDER BY "
+	for _, key := range filter.Sort {
+		column, ok := sbiSortColumns[key.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown SBI sort key: %s", key.Field)
+		}
+		clause += column
+		if key.Desc {
+			clause += " DESC"
+		}
+		clause += ", "
+	}
+	if aging := filter.Aging; aging != nil && aging.Enabled() {
+		// Pending SBIs rank by their aged priority (sbi.PriorityAging.Effective); ties go to the
+		// longest waiting SBI rather than the higher stored priority, so an SBI that aged to the
+		// top cannot be overtaken by later arrivals
+		agedAt := filter.AgedAt
+		if agedAt.IsZero() {
+			agedAt = time.Now()
+		}
+		clause += `CASE WHEN status = ? AND registered_at IS NOT NULL AND priority < ?
+			THEN MIN(?, priority + CAST(MAX(0, julianday(?) - julianday(registered_at)) * 86400.0 / ? AS INTEGER))
+			ELSE priority END DESC, registered_at ASC, sequence ASC`
+		args = append(args, string(model.StatusPending), aging.MaxPriority, aging.MaxPriority,
+			agedAt.UTC().Format(time.RFC3339Nano), aging.Interval.Seconds())
+	} else {
+		clause += "priority DESC, registered_at ASC, sequence ASC"
+	}
 	if filter.Limit > 0 {
-		query += " LIMIT ?"
+		clause += " LIMIT ?"
 		args = append(args, filter.Limit)
 	}
 	if filter.Offset > 0 {
-		query += " OFFSET ?"
+		clause += " OFFSET ?"
 		args = append(args, filter.Offset)
 	}
 
-	db := r.getDB(ctx)
-	rows, err := db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("list SBIs failed: %w", err)
-	}
-	defer rows.Close()
+	return clause, args, nil
+}
 
-	var sbis []*sbi.SBI
-	for rows.Next() {
-		s, err := r.scanSBIFromRows(rows, ctx)
-		if err != nil {
-			return nil, err
-		}
-		sbis = append(sbis, s)
-	}
+// sbiSortColumns maps repository.SBISortFields to the expressions they sort by
+var sbiSortColumns = map[string]string{
+	"priority": "priority",
+	"turns":    "current_turn",
+	"updated":  "julianday(updated_at)",
+	"created":  "julianday(created_at)",
+	"title":    "title COLLATE NOCASE",
+	"status":   "status",
+	"agent":    "assigned_agent",
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate SBIs failed: %w", err)
+// splitIDList splits a GROUP_CONCAT list of IDs
+func splitIDList(list sql.NullString) []string {
+	if !list.Valid || list.String == "" {
+		return []string{}
 	}
-
-	return sbis, nil
+	return strings.Split(list.String, ",")
 }
 
 // FindByPBIID retrieves SBIs that belong to a PBI
 func (r *SBIRepositoryImpl) FindByPBIID(ctx context.Context, pbiID repository.PBIID) ([]*sbi.SBI, error) {
 	query := `
-		SELECT id, title, description, status, current_step, parent_pbi_id,
-		       estimated_hours, priority, sequence, registered_at, started_at, completed_at,
-		       labels, assigned_agent, file_paths,
-		       current_turn, current_attempt, max_turns, max_attempts, last_error, artifact_paths,
-		       only_implement,
-		       created_at, updated_at
+		SELECT ` + sbiColumns + `
 		FROM sbis
-		WHERE parent_pbi_id = ?
+		WHERE parent_pbi_id = ? AND deleted_at IS NULL
 		ORDER BY priority DESC, registered_at ASC, sequence ASC
 	`
 
-	db := r.getDB(ctx)
+	db := r.getReadDB(ctx)
 	rows, err := db.QueryContext(ctx, query, string(pbiID))
 	if err != nil {
 		return nil, fmt.Errorf("find SBIs by PBI ID failed: %w", err)
@@ -290,9 +583,12 @@ func (r *SBIRepositoryImpl) scanSBI(row *sql.Row) (*sbi.SBI, error) {
 		registeredAt      sql.NullString
 		startedAt         sql.NullString
 		completedAt       sql.NullString
+		deadline          sql.NullString
+		reviewStartedAt   sql.NullString
 		labelsJSON        sql.NullString
 		assignedAgent     sql.NullString
 		filePathsJSON     sql.NullString
+		envJSON           sql.NullString
 		currentTurn       int
 		currentAttempt    int
 		maxTurns          int
@@ -300,21 +596,23 @@ func (r *SBIRepositoryImpl) scanSBI(row *sql.Row) (*sbi.SBI, error) {
 		lastError         sql.NullString
 		artifactPathsJSON sql.NullString
 		onlyImplement     bool
+		estimateJSON      sql.NullString
 		createdAt         string
 		updatedAt         string
+		version           int
 	)
 
 	err := row.Scan(
 		&sbiID, &title, &description, &status, &currentStep, &parentPBIID,
-		&estimatedHours, &priority, &sequence, &registeredAt, &startedAt, &completedAt,
-		&labelsJSON, &assignedAgent, &filePathsJSON,
+		&estimatedHours, &priority, &sequence, &registeredAt, &startedAt, &completedAt, &deadline, &reviewStartedAt,
+		&labelsJSON, &assignedAgent, &filePathsJSON, &envJSON,
 		&currentTurn, &currentAttempt, &maxTurns, &maxAttempts, &lastError, &artifactPathsJSON,
-		&onlyImplement,
-		&createdAt, &updatedAt,
+		&onlyImplement, &estimateJSON,
+		&createdAt, &updatedAt, &version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("SBI not found")
+			return nil, fmt.Errorf("SBI %w", model.ErrNotFound)
 		}
 		return nil, fmt.Errorf("scan SBI failed: %w", err)
 	}
@@ -330,15 +628,20 @@ func (r *SBIRepositoryImpl) scanSBI(row *sql.Row) (*sbi.SBI, error) {
 	}
 
 	return r.reconstructSBI(sbiID, title, description, status, currentStep, parentPBIID,
-		estimatedHours, priority, sequence, registeredAt, startedAt, completedAt,
-		labelsJSON, assignedAgent, filePathsJSON,
+		estimatedHours, priority, sequence, registeredAt, startedAt, completedAt, deadline, reviewStartedAt,
+		labelsJSON, assignedAgent, filePathsJSON, envJSON,
 		currentTurn, currentAttempt, maxTurns, maxAttempts, lastError, artifactPathsJSON,
-		onlyImplement,
-		createdAtTime, updatedAtTime)
+		onlyImplement, estimateJSON,
+		createdAtTime, updatedAtTime, version)
 }
 
 // scanSBIFromRows scans a single SBI from rows
 func (r *SBIRepositoryImpl) scanSBIFromRows(rows *sql.Rows, ctx context.Context) (*sbi.SBI, error) {
+	return r.scanSBIColumns(rows)
+}
+
+// scanSBIColumns scans the sbiColumns of a row, followed by extra columns into extra
+func (r *SBIRepositoryImpl) scanSBIColumns(rows *sql.Rows, extra ...interface{}) (*sbi.SBI, error) {
 	var (
 		sbiID             string
 		title             string
@@ -352,9 +655,12 @@ func (r *SBIRepositoryImpl) scanSBIFromRows(rows *sql.Rows, ctx context.Context)
 		registeredAt      sql.NullString
 		startedAt         sql.NullString
 		completedAt       sql.NullString
+		deadline          sql.NullString
+		reviewStartedAt   sql.NullString
 		labelsJSON        sql.NullString
 		assignedAgent     sql.NullString
 		filePathsJSON     sql.NullString
+		envJSON           sql.NullString
 		currentTurn       int
 		currentAttempt    int
 		maxTurns          int
@@ -362,18 +668,20 @@ func (r *SBIRepositoryImpl) scanSBIFromRows(rows *sql.Rows, ctx context.Context)
 		lastError         sql.NullString
 		artifactPathsJSON sql.NullString
 		onlyImplement     bool
+		estimateJSON      sql.NullString
 		createdAt         string
 		updatedAt         string
+		version           int
 	)
 
-	err := rows.Scan(
+	err := rows.Scan(append([]interface{}{
 		&sbiID, &title, &description, &status, &currentStep, &parentPBIID,
-		&estimatedHours, &priority, &sequence, &registeredAt, &startedAt, &completedAt,
-		&labelsJSON, &assignedAgent, &filePathsJSON,
+		&estimatedHours, &priority, &sequence, &registeredAt, &startedAt, &completedAt, &deadline, &reviewStartedAt,
+		&labelsJSON, &assignedAgent, &filePathsJSON, &envJSON,
 		&currentTurn, &currentAttempt, &maxTurns, &maxAttempts, &lastError, &artifactPathsJSON,
-		&onlyImplement,
-		&createdAt, &updatedAt,
-	)
+		&onlyImplement, &estimateJSON,
+		&createdAt, &updatedAt, &version,
+	}, extra...)...)
 	if err != nil {
 		return nil, fmt.Errorf("scan SBI failed: %w", err)
 	}
@@ -389,11 +697,11 @@ func (r *SBIRepositoryImpl) scanSBIFromRows(rows *sql.Rows, ctx context.Context)
 	}
 
 	return r.reconstructSBI(sbiID, title, description, status, currentStep, parentPBIID,
-		estimatedHours, priority, sequence, registeredAt, startedAt, completedAt,
-		labelsJSON, assignedAgent, filePathsJSON,
+		estimatedHours, priority, sequence, registeredAt, startedAt, completedAt, deadline, reviewStartedAt,
+		labelsJSON, assignedAgent, filePathsJSON, envJSON,
 		currentTurn, currentAttempt, maxTurns, maxAttempts, lastError, artifactPathsJSON,
-		onlyImplement,
-		createdAtTime, updatedAtTime)
+		onlyImplement, estimateJSON,
+		createdAtTime, updatedAtTime, version)
 }
 
 // reconstructSBI reconstructs an SBI entity from database values
@@ -405,12 +713,14 @@ func (r *SBIRepositoryImpl) reconstructSBI(
 	estimatedHours float64,
 	priority int,
 	sequence sql.NullInt64,
-	registeredAt, startedAt, completedAt sql.NullString,
-	labelsJSON, assignedAgent, filePathsJSON sql.NullString,
+	registeredAt, startedAt, completedAt, deadline, reviewStartedAt sql.NullString,
+	labelsJSON, assignedAgent, filePathsJSON, envJSON sql.NullString,
 	currentTurn, currentAttempt, maxTurns, maxAttempts int,
 	lastError, artifactPathsJSON sql.NullString,
 	onlyImplement bool,
+	estimateJSON sql.NullString,
 	createdAt, updatedAt time.Time,
+	version int,
 ) (*sbi.SBI, error) {
 	// Unmarshal JSON arrays
 	var labels []string
@@ -427,6 +737,20 @@ func (r *SBIRepositoryImpl) reconstructSBI(
 		}
 	}
 
+	var env map[string]string
+	if envJSON.Valid && envJSON.String != "" {
+		if err := json.Unmarshal([]byte(envJSON.String), &env); err != nil {
+			return nil, fmt.Errorf("unmarshal env failed: %w", err)
+		}
+	}
+
+	var estimateSuggestion *sbi.EstimateSuggestion
+	if estimateJSON.Valid && estimateJSON.String != "" {
+		if err := json.Unmarshal([]byte(estimateJSON.String), &estimateSuggestion); err != nil {
+			return nil, fmt.Errorf("unmarshal estimate suggestion failed: %w", err)
+		}
+	}
+
 	var artifactPaths []string
 	if artifactPathsJSON.Valid && artifactPathsJSON.String != "" {
 		if err := json.Unmarshal([]byte(artifactPathsJSON.String), &artifactPaths); err != nil {
@@ -480,18 +804,42 @@ func (r *SBIRepositoryImpl) reconstructSBI(
 		completedAtTime = &t
 	}
 
+	// Parse deadline timestamp (nullable)
+	var deadlineTime *time.Time
+	if deadline.Valid && deadline.String != "" {
+		t, err := parseTime(deadline.String)
+		if err != nil {
+			return nil, fmt.Errorf("parse deadline failed: %w", err)
+		}
+		deadlineTime = &t
+	}
+
+	// Parse review_started_at timestamp (nullable)
+	var reviewStartedAtTime *time.Time
+	if reviewStartedAt.Valid && reviewStartedAt.String != "" {
+		t, err := parseTime(reviewStartedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("parse review_started_at failed: %w", err)
+		}
+		reviewStartedAtTime = &t
+	}
+
 	// Reconstruct SBI metadata
 	metadata := sbi.SBIMetadata{
-		EstimatedHours: estimatedHours,
-		Priority:       priority,
-		Sequence:       int(sequence.Int64),
-		RegisteredAt:   registeredAtTime,
-		StartedAt:      startedAtTime,
-		CompletedAt:    completedAtTime,
-		Labels:         labels,
-		AssignedAgent:  assignedAgent.String,
-		FilePaths:      filePaths,
-		OnlyImplement:  onlyImplement,
+		EstimatedHours:     estimatedHours,
+		Priority:           priority,
+		Sequence:           int(sequence.Int64),
+		RegisteredAt:       registeredAtTime,
+		StartedAt:          startedAtTime,
+		CompletedAt:        completedAtTime,
+		Deadline:           deadlineTime,
+		ReviewStartedAt:    reviewStartedAtTime,
+		Labels:             labels,
+		AssignedAgent:      assignedAgent.String,
+		FilePaths:          filePaths,
+		Env:                env,
+		OnlyImplement:      onlyImplement,
+		EstimateSuggestion: estimateSuggestion,
 	}
 
 	// Reconstruct execution state
@@ -514,7 +862,7 @@ func (r *SBIRepositoryImpl) reconstructSBI(
 		ArtifactPaths:  artifactPaths,
 	}
 
-	return sbi.ReconstructSBI(
+	s := sbi.ReconstructSBI(
 		taskID,
 		title,
 		description.String,
@@ -525,15 +873,24 @@ func (r *SBIRepositoryImpl) reconstructSBI(
 		execution,
 		createdAt,
 		updatedAt,
-	), nil
+	)
+	s.SetVersion(version)
+	return s, nil
 }
 
 // GetNextSequence returns the next sequence number for SBI registration
 // This method is used to guarantee registration order
+// Sequences of archived SBIs (sbi_tombstones) are never reused
 func (r *SBIRepositoryImpl) GetNextSequence(ctx context.Context) (int, error) {
-	query := `SELECT COALESCE(MAX(sequence), 0) + 1 FROM sbis`
+	query := `
+		SELECT COALESCE(MAX(sequence), 0) + 1 FROM (
+			SELECT sequence FROM sbis
+			UNION ALL
+			SELECT sequence FROM sbi_tombstones
+		)
+	`
 
-	db := r.getDB(ctx)
+	db := r.getReadDB(ctx)
 	var nextSeq int
 	err := db.QueryRowContext(ctx, query).Scan(&nextSeq)
 	if err != nil {
@@ -544,11 +901,15 @@ func (r *SBIRepositoryImpl) GetNextSequence(ctx context.Context) (int, error) {
 }
 
 // ResetSBIState resets an SBI to allow re-execution
+// The version is incremented, so that entities loaded before the reset can no longer be saved;
+// a reset into REVIEWING restarts the review clock
 func (r *SBIRepositoryImpl) ResetSBIState(ctx context.Context, id repository.SBIID, toStatus string) error {
-	query := `UPDATE sbis SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+	query := `UPDATE sbis SET status = ?,
+		review_started_at = CASE WHEN ? = ? THEN CURRENT_TIMESTAMP END,
+		updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL`
 
 	db := r.getDB(ctx)
-	result, err := db.ExecContext(ctx, query, toStatus, string(id))
+	result, err := db.ExecContext(ctx, query, toStatus, toStatus, string(model.StatusReviewing), string(id))
 	if err != nil {
 		return fmt.Errorf("reset SBI state failed: %w", err)
 	}
@@ -559,7 +920,7 @@ func (r *SBIRepositoryImpl) ResetSBIState(ctx context.Context, id repository.SBI
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("SBI not found: %s", id)
+		return fmt.Errorf("SBI %w: %s", model.ErrNotFound, id)
 	}
 
 	return nil
@@ -574,7 +935,7 @@ func (r *SBIRepositoryImpl) GetDependencies(ctx context.Context, sbiID repositor
 		ORDER BY created_at ASC
 	`
 
-	db := r.getDB(ctx)
+	db := r.getReadDB(ctx)
 	rows, err := db.QueryContext(ctx, query, string(sbiID))
 	if err != nil {
 		return nil, fmt.Errorf("get dependencies failed: %w", err)
@@ -606,7 +967,7 @@ func (r *SBIRepositoryImpl) GetDependents(ctx context.Context, sbiID repository.
 		ORDER BY created_at ASC
 	`
 
-	db := r.getDB(ctx)
+	db := r.getReadDB(ctx)
 	rows, err := db.QueryContext(ctx, query, string(sbiID))
 	if err != nil {
 		return nil, fmt.Errorf("get dependents failed: %w", err)
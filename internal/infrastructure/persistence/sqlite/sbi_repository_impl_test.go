@@ -0,0 +1,264 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func setupTestDBForSBI(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, NewMigrator(db).Migrate())
+	return db
+}
+
+// saveTestSBI saves an SBI in the given status
+func saveTestSBI(t *testing.T, repo repository.SBIRepository, title string, status model.Status) *sbi.SBI {
+	t.Helper()
+	s, err := sbi.NewSBI(title, "Description of "+title, nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), s))
+	if status != model.StatusPending {
+		require.NoError(t, repo.ResetSBIState(context.Background(), repository.SBIID(s.ID().String()), string(status)))
+	}
+	return s
+}
+
+func TestSBIRepository_FindMany(t *testing.T) {
+	repo := NewSBIRepository(setupTestDBForSBI(t))
+	ctx := context.Background()
+
+	var ids []repository.SBIID
+	for i := 0; i < findManyBatchSize+5; i++ {
+		s := saveTestSBI(t, repo, fmt.Sprintf("SBI %03d", i), model.StatusPending)
+		ids = append(ids, repository.SBIID(s.ID().String()))
+	}
+
+	t.Run("keeps the requested order and skips unknown IDs", func(t *testing.T) {
+		found, err := repo.FindMany(ctx, []repository.SBIID{ids[2], "unknown", ids[0], ids[2]})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "SBI 002", found[0].Title())
+		assert.Equal(t, "SBI 000", found[1].Title())
+	})
+
+	t.Run("spans batches", func(t *testing.T) {
+		found, err := repo.FindMany(ctx, ids)
+		require.NoError(t, err)
+		require.Len(t, found, len(ids))
+		assert.Equal(t, fmt.Sprintf("SBI %03d", findManyBatchSize+4), found[len(found)-1].Title())
+	})
+
+	t.Run("no IDs", func(t *testing.T) {
+		found, err := repo.FindMany(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func TestSBIRepository_ListWithExecutionState(t *testing.T) {
+	db := setupTestDBForSBI(t)
+	repo := NewSBIRepository(db)
+	ctx := context.Background()
+
+	done := saveTestSBI(t, repo, "Done", model.StatusDone)
+	picked := saveTestSBI(t, repo, "Picked", model.StatusPicked)
+	ready := saveTestSBI(t, repo, "Ready", model.StatusPending)
+	waiting := saveTestSBI(t, repo, "Waiting", model.StatusPending)
+	orphaned := saveTestSBI(t, repo, "Orphaned", model.StatusPending)
+
+	require.NoError(t, repo.SaveDependencies(ctx, repository.SBIID(ready.ID().String()), []string{done.ID().String()}))
+	require.NoError(t, repo.SaveDependencies(ctx, repository.SBIID(waiting.ID().String()),
+		[]string{done.ID().String(), picked.ID().String()}))
+	// A dependency on an SBI that is no longer stored is never met
+	require.NoError(t, repo.SaveDependencies(ctx, repository.SBIID(orphaned.ID().String()), []string{"01GONE"}))
+
+	states, err := repo.ListWithExecutionState(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusPending}})
+	require.NoError(t, err)
+
+	// Same SBIs and order as List
+	listed, err := repo.List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusPending}})
+	require.NoError(t, err)
+	require.Len(t, states, len(listed))
+	byTitle := make(map[string]*repository.SBIExecutionState)
+	for i, state := range states {
+		assert.Equal(t, listed[i].ID().String(), state.SBI.ID().String())
+		byTitle[state.SBI.Title()] = state
+	}
+
+	assert.Equal(t, []string{done.ID().String()}, byTitle["Ready"].DependsOn)
+	assert.True(t, byTitle["Ready"].DependenciesMet())
+
+	assert.Equal(t, []string{done.ID().String(), picked.ID().String()}, byTitle["Waiting"].DependsOn)
+	assert.Equal(t, []string{picked.ID().String()}, byTitle["Waiting"].UnmetDependencies)
+	assert.False(t, byTitle["Waiting"].DependenciesMet())
+
+	assert.Equal(t, []string{"01GONE"}, byTitle["Orphaned"].UnmetDependencies)
+
+	// SBIs without dependencies come with empty lists
+	states, err = repo.ListWithExecutionState(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusDone}, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Empty(t, states[0].DependsOn)
+	assert.True(t, states[0].DependenciesMet())
+}
+
+func TestSBIRepository_ListFilters(t *testing.T) {
+	repo := NewSBIRepository(setupTestDBForSBI(t))
+	ctx := context.Background()
+
+	save := func(title string, priority, turns int, agent string, labels ...string) {
+		s, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{Priority: priority, AssignedAgent: agent, Labels: labels})
+		require.NoError(t, err)
+		for i := 0; i < turns; i++ {
+			s.IncrementTurn()
+		}
+		require.NoError(t, repo.Save(ctx, s))
+	}
+	save("api", 2, 5, "codex", "backend", "api")
+	save("db", 1, 1, "claude-code", "backend")
+	save("ui", 3, 4, "codex", "frontend")
+
+	titles := func(filter repository.SBIFilter) []string {
+		t.Helper()
+		sbis, err := repo.List(ctx, filter)
+		require.NoError(t, err)
+		var titles []string
+		for _, s := range sbis {
+			titles = append(titles, s.Title())
+		}
+		return titles
+	}
+	intPtr := func(n int) *int { return &n }
+	hourAgo := time.Now().Add(-time.Hour)
+	inAnHour := time.Now().Add(time.Hour)
+
+	assert.Equal(t, []string{"ui", "api", "db"}, titles(repository.SBIFilter{}), "execution order by default")
+	assert.Equal(t, []string{"api", "db"}, titles(repository.SBIFilter{Labels: []string{"backend"}}))
+	assert.Equal(t, []string{"api"}, titles(repository.SBIFilter{Labels: []string{"backend", "api"}}), "all labels are required")
+	assert.Equal(t, []string{"ui", "api"}, titles(repository.SBIFilter{AssignedAgents: []string{"codex"}}))
+	assert.Equal(t, []string{"api", "db"}, titles(repository.SBIFilter{MaxPriority: intPtr(2)}))
+	assert.Equal(t, []string{"ui", "api"}, titles(repository.SBIFilter{MinTurns: intPtr(4)}))
+	assert.Equal(t, []string{"ui"}, titles(repository.SBIFilter{MinTurns: intPtr(4), MaxTurns: intPtr(4)}))
+	assert.Len(t, titles(repository.SBIFilter{UpdatedAfter: &hourAgo, UpdatedBefore: &inAnHour}), 3)
+	assert.Empty(t, titles(repository.SBIFilter{UpdatedAfter: &inAnHour}))
+
+	assert.Equal(t, []string{"api", "ui", "db"}, titles(repository.SBIFilter{Sort: []repository.SortKey{{Field: "turns", Desc: true}}}))
+	assert.Equal(t, []string{"db", "ui", "api"}, titles(repository.SBIFilter{Sort: []repository.SortKey{{Field: "agent"}}}),
+		"ties keep the execution order")
+
+	_, err := repo.List(ctx, repository.SBIFilter{Sort: []repository.SortKey{{Field: "rowid; DROP TABLE sbis"}}})
+	assert.ErrorContains(t, err, "unknown SBI sort key")
+}
+
+func TestSBIRepository_ListAgedPriority(t *testing.T) {
+	repo := NewSBIRepository(setupTestDBForSBI(t))
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	save := func(title string, priority int, waited time.Duration) {
+		s, err := sbi.NewSBI(title, "", nil, sbi.SBIMetadata{Priority: priority, RegisteredAt: now.Add(-waited)})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, s))
+	}
+	save("starving", 0, 72*time.Hour)
+	save("waiting", 0, 36*time.Hour)
+	save("urgent", 2, time.Hour)
+
+	titles := func(filter repository.SBIFilter) []string {
+		t.Helper()
+		states, err := repo.ListWithExecutionState(ctx, filter)
+		require.NoError(t, err)
+		var titles []string
+		for _, state := range states {
+			titles = append(titles, state.SBI.Title())
+		}
+		return titles
+	}
+	aging := &sbi.PriorityAging{Interval: 24 * time.Hour, MaxPriority: 2}
+
+	assert.Equal(t, []string{"urgent", "starving", "waiting"}, titles(repository.SBIFilter{}))
+	// starving aged to the top level and waited longest; waiting gained one level
+	assert.Equal(t, []string{"starving", "urgent", "waiting"}, titles(repository.SBIFilter{Aging: aging, AgedAt: now}))
+	assert.Equal(t, []string{"urgent", "starving", "waiting"},
+		titles(repository.SBIFilter{Aging: &sbi.PriorityAging{MaxPriority: 2}, AgedAt: now}), "an interval of 0 disables aging")
+	assert.Equal(t, []string{"starving"}, titles(repository.SBIFilter{Aging: aging, AgedAt: now, Limit: 1}))
+}
+
+func TestSBIRepository_SaveChecksVersion(t *testing.T) {
+	repo := NewSBIRepository(setupTestDBForSBI(t))
+	ctx := context.Background()
+
+	s := saveTestSBI(t, repo, "Versioned", model.StatusPending)
+	assert.Equal(t, 1, s.Version())
+	id := repository.SBIID(s.ID().String())
+
+	// Two copies loaded at the same version: the first save wins
+	first, err := repo.Find(ctx, id)
+	require.NoError(t, err)
+	second, err := repo.Find(ctx, id)
+	require.NoError(t, err)
+
+	require.NoError(t, first.UpdateStatus(model.StatusPicked))
+	require.NoError(t, repo.Save(ctx, first))
+	assert.Equal(t, 2, first.Version())
+
+	require.NoError(t, second.UpdateTitle("Overwritten"))
+	err = repo.Save(ctx, second)
+	assert.ErrorIs(t, err, repository.ErrStaleEntity)
+
+	stored, err := repo.Find(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Versioned", stored.Title())
+	assert.Equal(t, model.StatusPicked, stored.Status())
+	assert.Equal(t, 2, stored.Version())
+
+	require.NoError(t, repo.ResetSBIState(ctx, id, string(model.StatusPending)))
+	err = repo.Save(ctx, stored)
+	assert.ErrorIs(t, err, repository.ErrStaleEntity, "ResetSBIState increments the version")
+
+	// A stored CANCELLED SBI is reported as cancelled rather than stale
+	cancelled, err := repo.Find(ctx, id)
+	require.NoError(t, err)
+	require.NoError(t, cancelled.Cancel("stop"))
+	require.NoError(t, repo.Save(ctx, cancelled))
+	require.NoError(t, stored.UpdateTitle("After cancel"))
+	err = repo.Save(ctx, stored)
+	assert.ErrorIs(t, err, repository.ErrSBICancelled)
+}
+
+func TestSBIRepository_EstimateSuggestion(t *testing.T) {
+	repo := NewSBIRepository(setupTestDBForSBI(t))
+	ctx := context.Background()
+	s := saveTestSBI(t, repo, "Estimated", model.StatusPending)
+	id := repository.SBIID(s.ID().String())
+
+	suggestedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.SuggestEstimate(sbi.EstimateSuggestion{Hours: 4, LowHours: 3, HighHours: 7, Rationale: "Like the login SBIs", Samples: 5, SuggestedAt: suggestedAt})
+	require.NoError(t, repo.Save(ctx, s))
+
+	found, err := repo.Find(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, found.EstimateSuggestion())
+	assert.Equal(t, sbi.EstimateSuggestion{Hours: 4, LowHours: 3, HighHours: 7, Rationale: "Like the login SBIs", Samples: 5, SuggestedAt: suggestedAt},
+		*found.EstimateSuggestion())
+
+	require.NoError(t, found.AcceptEstimate())
+	require.NoError(t, repo.Save(ctx, found))
+	found, err = repo.Find(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, found.EstimateSuggestion())
+	assert.Equal(t, 4.0, found.Metadata().EstimatedHours)
+}
@@ -0,0 +1,245 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// sbiSnapshotTable is a table holding rows of a single SBI
+type sbiSnapshotTable struct {
+	name      string // Table name
+	keyColumn string // Column referencing the SBI
+	dropID    bool   // Drop the AUTOINCREMENT id so rows can be inserted into another database
+}
+
+// sbiSnapshotTables lists the tables exported for an SBI, parent table first
+var sbiSnapshotTables = []sbiSnapshotTable{
+	{name: "sbis", keyColumn: "id"},
+	{name: "sbi_dependencies", keyColumn: "sbi_id"},
+	{name: "sbi_exec_logs", keyColumn: "sbi_id", dropID: true},
+	{name: "sbi_review_feedback", keyColumn: "sbi_id", dropID: true},
+	{name: "sbi_acceptance_criteria", keyColumn: "sbi_id", dropID: true},
+	{name: "sbi_review_issues", keyColumn: "sbi_id", dropID: true},
+	{name: "sbi_review_votes", keyColumn: "sbi_id"},
+	{name: "sbi_step_agents", keyColumn: "sbi_id"},
+	{name: "sbi_notes", keyColumn: "sbi_id", dropID: true},
+	{name: "sbi_artifact_provenance", keyColumn: "sbi_id", dropID: true},
+}
+
+// SBISnapshotRepositoryImpl implements SBISnapshotRepository using SQLite
+type SBISnapshotRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewSBISnapshotRepository creates a new SBISnapshotRepository implementation
+func NewSBISnapshotRepository(db *sql.DB) repository.SBISnapshotRepository {
+	return &SBISnapshotRepositoryImpl{db: db}
+}
+
+// ExportRows returns the rows of the SBI from every snapshot table
+func (r *SBISnapshotRepositoryImpl) ExportRows(ctx context.Context, id repository.SBIID) (repository.SBIRows, error) {
+	rows := make(repository.SBIRows)
+	for _, table := range sbiSnapshotTables {
+		tableRows, err := r.queryRows(ctx, table, string(id))
+		if err != nil {
+			return nil, err
+		}
+		rows[table.name] = tableRows
+	}
+
+	if len(rows["sbis"]) == 0 {
+		return nil, fmt.Errorf("%w: %s", repository.ErrSBIRowsNotFound, id)
+	}
+	return rows, nil
+}
+
+// queryRows reads all rows of a table that belong to the SBI
+func (r *SBISnapshotRepositoryImpl) queryRows(ctx context.Context, table sbiSnapshotTable, sbiID string) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", table.name, table.keyColumn)
+	if table.dropID {
+		query += " ORDER BY id"
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, sbiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", table.name, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s columns: %w", table.name, err)
+	}
+
+	result := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s row: %w", table.name, err)
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if table.dropID && column == "id" {
+				continue
+			}
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate %s rows: %w", table.name, err)
+	}
+	return result, nil
+}
+
+// ImportRows upserts the SBI row and replaces its dependent rows in one transaction
+func (r *SBISnapshotRepositoryImpl) ImportRows(ctx context.Context, id repository.SBIID, rows repository.SBIRows) error {
+	if len(rows["sbis"]) != 1 {
+		return fmt.Errorf("snapshot must contain exactly one sbis row, got %d", len(rows["sbis"]))
+	}
+	if rowID, _ := rows["sbis"][0]["id"].(string); rowID != string(id) {
+		return fmt.Errorf("snapshot row id %q does not match SBI %s", rowID, id)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range sbiSnapshotTables {
+		columns, err := tableColumns(ctx, tx, table.name)
+		if err != nil {
+			return err
+		}
+
+		if table.name == "sbis" {
+			row := rows["sbis"][0]
+			// The parent PBI may not exist on this machine
+			if pbiID, ok := row["parent_pbi_id"].(string); ok && pbiID != "" && !rowExists(ctx, tx, "pbis", pbiID) {
+				row["parent_pbi_id"] = nil
+			}
+			if err := upsertSBIRow(ctx, tx, columns, row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table.name, table.keyColumn), string(id)); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table.name, err)
+		}
+		for _, row := range rows[table.name] {
+			// Dependencies on SBIs missing from this database cannot be satisfied
+			if dependsOn, ok := row["depends_on_sbi_id"].(string); ok && !rowExists(ctx, tx, "sbis", dependsOn) {
+				continue
+			}
+			if err := insertRow(ctx, tx, table.name, columns, row); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// tableColumns returns the column names of a table in this database
+func tableColumns(ctx context.Context, tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s schema: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			ctype      string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &defaultVal, &pk); err != nil {
+			return nil, fmt.Errorf("failed to scan %s schema: %w", table, err)
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+// knownColumns returns the row's columns that exist in the table, sorted for stable SQL
+// Columns added by newer schema versions are dropped so snapshots restore across versions
+func knownColumns(columns map[string]bool, row map[string]interface{}) ([]string, []interface{}) {
+	var names []string
+	for name := range row {
+		if columns[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		values[i] = row[name]
+	}
+	return names, values
+}
+
+// upsertSBIRow inserts or updates the sbis row in place
+// An update (rather than INSERT OR REPLACE) keeps rows of other SBIs that reference it
+func upsertSBIRow(ctx context.Context, tx *sql.Tx, columns map[string]bool, row map[string]interface{}) error {
+	names, values := knownColumns(columns, row)
+
+	var updates []string
+	for _, name := range names {
+		if name != "id" {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", name, name))
+		}
+	}
+
+	query := fmt.Sprintf("INSERT INTO sbis (%s) VALUES (%s) ON CONFLICT(id) DO UPDATE SET %s",
+		strings.Join(names, ", "), placeholders(len(names)), strings.Join(updates, ", "))
+	if _, err := tx.ExecContext(ctx, query, values...); err != nil {
+		return fmt.Errorf("failed to restore sbis row: %w", err)
+	}
+	return nil
+}
+
+// insertRow inserts a row into a table
+func insertRow(ctx context.Context, tx *sql.Tx, table string, columns map[string]bool, row map[string]interface{}) error {
+	names, values := knownColumns(columns, row)
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(names, ", "), placeholders(len(names)))
+	if _, err := tx.ExecContext(ctx, query, values...); err != nil {
+		return fmt.Errorf("failed to restore %s row: %w", table, err)
+	}
+	return nil
+}
+
+// rowExists reports whether a table has a row with the given id
+func rowExists(ctx context.Context, tx *sql.Tx, table, id string) bool {
+	var exists int
+	err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT 1 FROM %s WHERE id = ?", table), id).Scan(&exists)
+	return err == nil
+}
+
+// placeholders returns n comma-separated "?" placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
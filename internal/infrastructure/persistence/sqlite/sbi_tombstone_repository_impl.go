@@ -0,0 +1,169 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// SBITombstoneRepositoryImpl implements SBITombstoneRepository using SQLite
+type SBITombstoneRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewSBITombstoneRepository creates a new SBITombstoneRepository implementation
+func NewSBITombstoneRepository(db *sql.DB) repository.SBITombstoneRepository {
+	return &SBITombstoneRepositoryImpl{db: db}
+}
+
+// Save creates or replaces the tombstone of an SBI
+func (r *SBITombstoneRepositoryImpl) Save(ctx context.Context, t *repository.SBITombstone) error {
+	archivedAt := t.ArchivedAt
+	if archivedAt.IsZero() {
+		archivedAt = time.Now()
+	}
+	var completedAt interface{}
+	if t.CompletedAt != nil {
+		completedAt = t.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	var parentPBIID interface{}
+	if t.ParentPBIID != "" {
+		parentPBIID = t.ParentPBIID
+	}
+	var sequence interface{}
+	if t.Sequence > 0 {
+		sequence = t.Sequence
+	}
+
+	query := `
+		INSERT INTO sbi_tombstones (id, parent_pbi_id, title, status, sequence, completed_at, archive_path, archived_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			parent_pbi_id = excluded.parent_pbi_id,
+			title = excluded.title,
+			status = excluded.status,
+			sequence = excluded.sequence,
+			completed_at = excluded.completed_at,
+			archive_path = excluded.archive_path,
+			archived_at = excluded.archived_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		t.SBIID,
+		parentPBIID,
+		t.Title,
+		t.Status,
+		sequence,
+		completedAt,
+		t.ArchivePath,
+		archivedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save SBI tombstone: %w", err)
+	}
+	return nil
+}
+
+// Find returns the tombstone of an SBI
+func (r *SBITombstoneRepositoryImpl) Find(ctx context.Context, id repository.SBIID) (*repository.SBITombstone, error) {
+	query := `
+		SELECT id, parent_pbi_id, title, status, sequence, completed_at, archive_path, archived_at
+		FROM sbi_tombstones
+		WHERE id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, string(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SBI tombstone: %w", err)
+	}
+	defer rows.Close()
+
+	tombstones, err := scanTombstones(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tombstones) == 0 {
+		return nil, fmt.Errorf("%w: %s", repository.ErrSBITombstoneNotFound, id)
+	}
+	return tombstones[0], nil
+}
+
+// Search returns the tombstones matching the filter, most recently archived first
+func (r *SBITombstoneRepositoryImpl) Search(ctx context.Context, filter repository.SBITombstoneFilter) ([]*repository.SBITombstone, error) {
+	query := `
+		SELECT id, parent_pbi_id, title, status, sequence, completed_at, archive_path, archived_at
+		FROM sbi_tombstones
+		WHERE 1=1
+	`
+	var args []interface{}
+
+	if filter.Query != "" {
+		pattern := "%" + strings.ToLower(filter.Query) + "%"
+		query += " AND (LOWER(id) LIKE ? OR LOWER(title) LIKE ? OR LOWER(COALESCE(parent_pbi_id, '')) LIKE ?)"
+		args = append(args, pattern, pattern, pattern)
+	}
+	if len(filter.Statuses) > 0 {
+		query += " AND status IN (" + placeholders(len(filter.Statuses)) + ")"
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+
+	query += " ORDER BY archived_at DESC, id"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search SBI tombstones: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTombstones(rows)
+}
+
+// Delete removes the tombstone of a restored SBI
+func (r *SBITombstoneRepositoryImpl) Delete(ctx context.Context, id repository.SBIID) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM sbi_tombstones WHERE id = ?", string(id)); err != nil {
+		return fmt.Errorf("failed to delete SBI tombstone: %w", err)
+	}
+	return nil
+}
+
+// scanTombstones reads the tombstones of a query result
+func scanTombstones(rows *sql.Rows) ([]*repository.SBITombstone, error) {
+	tombstones := []*repository.SBITombstone{}
+	for rows.Next() {
+		var (
+			t           repository.SBITombstone
+			parentPBIID sql.NullString
+			sequence    sql.NullInt64
+			completedAt sql.NullString
+			archivedAt  string
+		)
+		if err := rows.Scan(&t.SBIID, &parentPBIID, &t.Title, &t.Status, &sequence, &completedAt, &t.ArchivePath, &archivedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan SBI tombstone: %w", err)
+		}
+		t.ParentPBIID = parentPBIID.String
+		t.Sequence = int(sequence.Int64)
+		if completedAt.Valid {
+			if ts, err := time.Parse(time.RFC3339, completedAt.String); err == nil {
+				t.CompletedAt = &ts
+			}
+		}
+		if ts, err := time.Parse(time.RFC3339, archivedAt); err == nil {
+			t.ArchivedAt = ts
+		}
+		tombstones = append(tombstones, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate SBI tombstones: %w", err)
+	}
+	return tombstones, nil
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestSBITombstoneRepository_SaveFindSearch(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewSBITombstoneRepository(db)
+	ctx := context.Background()
+	completedAt := time.Date(2023, 11, 2, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Save(ctx, &repository.SBITombstone{
+		SBIID: "SBI-A", ParentPBIID: "PBI-001", Title: "Login form", Status: "DONE", Sequence: 3,
+		CompletedAt: &completedAt, ArchivePath: "archive/sbi/SBI-A.tar.gz", ArchivedAt: completedAt.Add(time.Hour),
+	}))
+	require.NoError(t, repo.Save(ctx, &repository.SBITombstone{
+		SBIID: "SBI-B", Title: "Password reset", Status: "FAILED", Sequence: 4,
+		ArchivePath: "archive/sbi/SBI-B.tar.gz", ArchivedAt: completedAt.Add(2 * time.Hour),
+	}))
+
+	found, err := repo.Find(ctx, "SBI-A")
+	require.NoError(t, err)
+	assert.Equal(t, "PBI-001", found.ParentPBIID)
+	assert.Equal(t, 3, found.Sequence)
+	require.NotNil(t, found.CompletedAt)
+	assert.True(t, completedAt.Equal(*found.CompletedAt))
+
+	_, err = repo.Find(ctx, "SBI-X")
+	assert.True(t, errors.Is(err, repository.ErrSBITombstoneNotFound))
+
+	// Most recently archived first
+	all, err := repo.Search(ctx, repository.SBITombstoneFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "SBI-B", all[0].SBIID)
+
+	byTitle, err := repo.Search(ctx, repository.SBITombstoneFilter{Query: "login"})
+	require.NoError(t, err)
+	require.Len(t, byTitle, 1)
+	assert.Equal(t, "SBI-A", byTitle[0].SBIID)
+
+	byStatus, err := repo.Search(ctx, repository.SBITombstoneFilter{Statuses: []string{"FAILED"}})
+	require.NoError(t, err)
+	require.Len(t, byStatus, 1)
+	assert.Equal(t, "SBI-B", byStatus[0].SBIID)
+
+	require.NoError(t, repo.Delete(ctx, "SBI-A"))
+	_, err = repo.Find(ctx, "SBI-A")
+	assert.True(t, errors.Is(err, repository.ErrSBITombstoneNotFound))
+}
+
+func TestSBIRepository_GetNextSequenceSkipsTombstones(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	ctx := context.Background()
+	sbiRepo := NewSBIRepository(db)
+	task, err := sbi.NewSBI("Active", "", nil, sbi.SBIMetadata{RegisteredAt: time.Now(), Sequence: 2})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, task))
+
+	// The archived SBI registered last keeps its sequence reserved
+	require.NoError(t, NewSBITombstoneRepository(db).Save(ctx, &repository.SBITombstone{
+		SBIID: "SBI-ARCHIVED", Title: "Archived", Status: "DONE", Sequence: 5, ArchivePath: "archive/sbi/SBI-ARCHIVED.tar.gz",
+	}))
+
+	next, err := sbiRepo.GetNextSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 6, next)
+}
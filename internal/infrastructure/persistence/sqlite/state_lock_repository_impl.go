@@ -76,22 +76,33 @@ func (r *StateLockRepositoryImpl) Acquire(ctx context.Context, lockID lock.LockI
 		return nil, fmt.Errorf("create state lock: %w", err)
 	}
 
-	// Step 3: Insert new lock
-	// If UNIQUE constraint fails, another process acquired the lock
+	// Step 3: Insert new lock with the next fencing token
+	// If UNIQUE constraint fails, another process acquired the lock and the token is rolled back
 	insertQuery := `
-		INSERT INTO state_locks (lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, lock_type)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO state_locks (lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, lock_type, fencing_token)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err = db.ExecContext(ctx, insertQuery,
-		stateLock.LockID().String(),
-		stateLock.PID(),
-		stateLock.Hostname(),
-		stateLock.AcquiredAt().Format(time.RFC3339Nano),
-		stateLock.ExpiresAt().Format(time.RFC3339Nano),
-		stateLock.HeartbeatAt().Format(time.RFC3339Nano),
-		string(stateLock.LockType()),
-	)
+	err = withinTx(ctx, r.db, func(txCtx context.Context) error {
+		txDB := r.getDB(txCtx)
+		token, err := issueFencingToken(txCtx, txDB, lockID)
+		if err != nil {
+			return err
+		}
+		stateLock.SetFencingToken(token)
+
+		_, err = txDB.ExecContext(txCtx, insertQuery,
+			stateLock.LockID().String(),
+			stateLock.PID(),
+			stateLock.Hostname(),
+			stateLock.AcquiredAt().Format(time.RFC3339Nano),
+			stateLock.ExpiresAt().Format(time.RFC3339Nano),
+			stateLock.HeartbeatAt().Format(time.RFC3339Nano),
+			string(stateLock.LockType()),
+			stateLock.FencingToken(),
+		)
+		return err
+	})
 
 	if err != nil {
 		// Check if it's a UNIQUE constraint violation
@@ -107,10 +118,11 @@ func (r *StateLockRepositoryImpl) Acquire(ctx context.Context, lockID lock.LockI
 
 // Release releases a state lock
 func (r *StateLockRepositoryImpl) Release(ctx context.Context, lockID lock.LockID) error {
-	query := `DELETE FROM state_locks WHERE lock_id = ?`
+	fenceSQL, fenceArgs := fenceCondition(ctx, lockID)
+	query := `DELETE FROM state_locks WHERE lock_id = ?` + fenceSQL
 
 	db := r.getDB(ctx)
-	result, err := db.ExecContext(ctx, query, lockID.String())
+	result, err := db.ExecContext(ctx, query, append([]interface{}{lockID.String()}, fenceArgs...)...)
 	if err != nil {
 		return fmt.Errorf("delete state lock: %w", err)
 	}
@@ -121,7 +133,7 @@ func (r *StateLockRepositoryImpl) Release(ctx context.Context, lockID lock.LockI
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("lock not found: %s", lockID.String())
+		return fmt.Errorf("%w: %s", lock.ErrLockNotFound, lockID.String())
 	}
 
 	return nil
@@ -130,7 +142,7 @@ func (r *StateLockRepositoryImpl) Release(ctx context.Context, lockID lock.LockI
 // Find retrieves a state lock by ID
 func (r *StateLockRepositoryImpl) Find(ctx context.Context, lockID lock.LockID) (*lock.StateLock, error) {
 	query := `
-		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, lock_type
+		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, lock_type, fencing_token
 		FROM state_locks
 		WHERE lock_id = ?
 	`
@@ -139,19 +151,20 @@ func (r *StateLockRepositoryImpl) Find(ctx context.Context, lockID lock.LockID)
 	row := db.QueryRowContext(ctx, query, lockID.String())
 
 	var (
-		lockIDStr   string
-		pid         int
-		hostname    string
-		acquiredAt  string
-		expiresAt   string
-		heartbeatAt string
-		lockType    string
+		lockIDStr    string
+		pid          int
+		hostname     string
+		acquiredAt   string
+		expiresAt    string
+		heartbeatAt  string
+		lockType     string
+		fencingToken int64
 	)
 
-	err := row.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &lockType)
+	err := row.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &lockType, &fencingToken)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("state lock not found: %s", lockID.String())
+			return nil, fmt.Errorf("state %w: %s", lock.ErrLockNotFound, lockID.String())
 		}
 		return nil, fmt.Errorf("scan state lock: %w", err)
 	}
@@ -185,15 +198,19 @@ func (r *StateLockRepositoryImpl) Find(ctx context.Context, lockID lock.LockID)
 		return nil, fmt.Errorf("invalid lock ID: %w", err)
 	}
 
-	return lock.ReconstructStateLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, lock.LockType(lockType)), nil
+	stateLock := lock.ReconstructStateLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, lock.LockType(lockType))
+	stateLock.SetFencingToken(fencingToken)
+	return stateLock, nil
 }
 
 // UpdateHeartbeat updates the heartbeat timestamp for a lock
 func (r *StateLockRepositoryImpl) UpdateHeartbeat(ctx context.Context, lockID lock.LockID) error {
-	query := `UPDATE state_locks SET heartbeat_at = ? WHERE lock_id = ?`
+	fenceSQL, fenceArgs := fenceCondition(ctx, lockID)
+	query := `UPDATE state_locks SET heartbeat_at = ? WHERE lock_id = ?` + fenceSQL
 
 	db := r.getDB(ctx)
-	result, err := db.ExecContext(ctx, query, time.Now().UTC().Format(time.RFC3339Nano), lockID.String())
+	args := append([]interface{}{time.Now().UTC().Format(time.RFC3339Nano), lockID.String()}, fenceArgs...)
+	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("update heartbeat: %w", err)
 	}
@@ -204,7 +221,7 @@ func (r *StateLockRepositoryImpl) UpdateHeartbeat(ctx context.Context, lockID lo
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("lock not found: %s", lockID.String())
+		return fmt.Errorf("%w: %s", lock.ErrLockNotFound, lockID.String())
 	}
 
 	return nil
@@ -253,7 +270,7 @@ func (r *StateLockRepositoryImpl) CleanupExpired(ctx context.Context) (int, erro
 // List lists all active state locks
 func (r *StateLockRepositoryImpl) List(ctx context.Context) ([]*lock.StateLock, error) {
 	query := `
-		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, lock_type
+		SELECT lock_id, pid, hostname, acquired_at, expires_at, heartbeat_at, lock_type, fencing_token
 		FROM state_locks
 		ORDER BY acquired_at DESC
 	`
@@ -268,16 +285,17 @@ func (r *StateLockRepositoryImpl) List(ctx context.Context) ([]*lock.StateLock,
 	var locks []*lock.StateLock
 	for rows.Next() {
 		var (
-			lockIDStr   string
-			pid         int
-			hostname    string
-			acquiredAt  string
-			expiresAt   string
-			heartbeatAt string
-			lockType    string
+			lockIDStr    string
+			pid          int
+			hostname     string
+			acquiredAt   string
+			expiresAt    string
+			heartbeatAt  string
+			lockType     string
+			fencingToken int64
 		)
 
-		if err := rows.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &lockType); err != nil {
+		if err := rows.Scan(&lockIDStr, &pid, &hostname, &acquiredAt, &expiresAt, &heartbeatAt, &lockType, &fencingToken); err != nil {
 			return nil, fmt.Errorf("scan state lock: %w", err)
 		}
 
@@ -298,7 +316,9 @@ func (r *StateLockRepositoryImpl) List(ctx context.Context) ([]*lock.StateLock,
 		// Reconstruct lock ID
 		lid, _ := lock.NewLockID(lockIDStr)
 
-		locks = append(locks, lock.ReconstructStateLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, lock.LockType(lockType)))
+		stateLock := lock.ReconstructStateLock(lid, pid, hostname, acquiredAtTime, expiresAtTime, heartbeatAtTime, lock.LockType(lockType))
+		stateLock.SetFencingToken(fencingToken)
+		locks = append(locks, stateLock)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// StepAgentRepositoryImpl implements StepAgentRepository using SQLite
+type StepAgentRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewStepAgentRepository creates a new StepAgentRepository implementation
+func NewStepAgentRepository(db *sql.DB) repository.StepAgentRepository {
+	return &StepAgentRepositoryImpl{db: db}
+}
+
+// Find retrieves the agent that last executed an SBI step, returning nil when none did
+func (r *StepAgentRepositoryImpl) Find(ctx context.Context, sbiID, step string) (*repository.StepAgent, error) {
+	query := `
+		SELECT sbi_id, step, agent_type, model, turn, updated_at
+		FROM sbi_step_agents
+		WHERE sbi_id = ? AND step = ?
+	`
+
+	stepAgent := &repository.StepAgent{}
+	err := r.db.QueryRowContext(ctx, query, sbiID, step).Scan(
+		&stepAgent.SBIID,
+		&stepAgent.Step,
+		&stepAgent.AgentType,
+		&stepAgent.Model,
+		&stepAgent.Turn,
+		&stepAgent.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find step agent: %w", err)
+	}
+	return stepAgent, nil
+}
+
+// Save records the agent of an SBI step, replacing any previous one
+func (r *StepAgentRepositoryImpl) Save(ctx context.Context, stepAgent *repository.StepAgent) error {
+	query := `
+		INSERT INTO sbi_step_agents (sbi_id, step, agent_type, model, turn, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(sbi_id, step) DO UPDATE SET
+			agent_type = excluded.agent_type,
+			model = excluded.model,
+			turn = excluded.turn,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		stepAgent.SBIID,
+		stepAgent.Step,
+		stepAgent.AgentType,
+		stepAgent.Model,
+		stepAgent.Turn,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save step agent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestStepAgentRepository_SaveAndFind(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	_, err = db.Exec(`
+		INSERT INTO sbis (id, title, status, current_step, priority)
+		VALUES ('SBI-AGENT-001', 'Step agent test', 'IMPLEMENTING', 'IMPLEMENT', 0)
+	`)
+	require.NoError(t, err)
+
+	repo := NewStepAgentRepository(db)
+	ctx := context.Background()
+
+	found, err := repo.Find(ctx, "SBI-AGENT-001", "implement")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	require.NoError(t, repo.Save(ctx, &repository.StepAgent{
+		SBIID: "SBI-AGENT-001", Step: "implement", AgentType: "claude-code-cli", Turn: 2,
+	}))
+	// The next implement turn replaces the record
+	require.NoError(t, repo.Save(ctx, &repository.StepAgent{
+		SBIID: "SBI-AGENT-001", Step: "implement", AgentType: "codex", Model: "gpt-5", Turn: 4,
+	}))
+
+	found, err = repo.Find(ctx, "SBI-AGENT-001", "implement")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "codex", found.AgentType)
+	assert.Equal(t, "gpt-5", found.Model)
+	assert.Equal(t, 4, found.Turn)
+
+	review, err := repo.Find(ctx, "SBI-AGENT-001", "review")
+	require.NoError(t, err)
+	assert.Nil(t, review)
+}
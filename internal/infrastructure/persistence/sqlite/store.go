@@ -0,0 +1,242 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Store defaults
+const (
+	DefaultReadConnections = 4
+	DefaultBusyTimeoutMs   = 5000
+	DefaultStmtCacheSize   = 128
+)
+
+// StoreOptions configures the connections of a Store
+type StoreOptions struct {
+	ReadConnections int // Size of the read connection pool (default: DefaultReadConnections)
+	BusyTimeoutMs   int // How long a connection waits for a lock held by another one (default: DefaultBusyTimeoutMs)
+	StmtCacheSize   int // Prepared statements kept per pool (default: DefaultStmtCacheSize)
+}
+
+// Store is the connection layer of a SQLite database file: one write connection, so that writers
+// queue in-process instead of failing with SQLITE_BUSY, and a pool of read connections that WAL
+// mode lets run alongside it. Each pool keeps a cache of prepared statements.
+type Store struct {
+	writer     *sql.DB
+	reader     *sql.DB
+	writeStmts *StmtCache
+	readStmts  *StmtCache
+	owned      bool // The connections were opened by the store and are closed with it
+}
+
+// OpenStore opens the write connection and read pool of the database file at path
+// The database must already be migrated; the read connections are query-only.
+func OpenStore(path string, opts StoreOptions) (*Store, error) {
+	if opts.ReadConnections <= 0 {
+		opts.ReadConnections = DefaultReadConnections
+	}
+	if opts.BusyTimeoutMs <= 0 {
+		opts.BusyTimeoutMs = DefaultBusyTimeoutMs
+	}
+	if opts.StmtCacheSize <= 0 {
+		opts.StmtCacheSize = DefaultStmtCacheSize
+	}
+
+	// Transactions on the write connection take the write lock up front (_txlock=immediate),
+	// so that a transaction never fails upgrading from a read lock
+	busyTimeout := fmt.Sprintf("_busy_timeout=%d", opts.BusyTimeoutMs)
+	writer, err := sql.Open("sqlite3", DSN(path, "_foreign_keys=on", "_journal_mode=WAL", busyTimeout, "_txlock=immediate"))
+	if err != nil {
+		return nil, fmt.Errorf("open write connection failed: %w", err)
+	}
+	writer.SetMaxOpenConns(1)
+	writer.SetMaxIdleConns(1)
+	writer.SetConnMaxLifetime(0)
+
+	reader, err := sql.Open("sqlite3", DSN(path, "_foreign_keys=on", busyTimeout, "_query_only=true"))
+	if err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("open read connections failed: %w", err)
+	}
+	reader.SetMaxOpenConns(opts.ReadConnections)
+	reader.SetMaxIdleConns(opts.ReadConnections)
+	reader.SetConnMaxLifetime(0)
+
+	// Fail early instead of on the first query
+	for _, db := range []*sql.DB{writer, reader} {
+		if err := db.Ping(); err != nil {
+			writer.Close()
+			reader.Close()
+			return nil, fmt.Errorf("connect to database failed: %w", err)
+		}
+	}
+
+	return &Store{
+		writer:     writer,
+		reader:     reader,
+		writeStmts: NewStmtCache(writer, opts.StmtCacheSize),
+		readStmts:  NewStmtCache(reader, opts.StmtCacheSize),
+		owned:      true,
+	}, nil
+}
+
+// NewStore wraps an open database as a store whose reads and writes share its pool
+// Used for in-memory and read-only databases, where separate pools would not see the same data.
+// The database stays owned by the caller.
+func NewStore(db *sql.DB) *Store {
+	stmts := NewStmtCache(db, DefaultStmtCacheSize)
+	return &Store{
+		writer:     db,
+		reader:     db,
+		writeStmts: stmts,
+		readStmts:  stmts,
+	}
+}
+
+// Writer returns the write connection
+func (s *Store) Writer() *sql.DB {
+	return s.writer
+}
+
+// Reader returns the read connection pool
+func (s *Store) Reader() *sql.DB {
+	return s.reader
+}
+
+// Stats returns the statement cache statistics of the write and read pools
+func (s *Store) Stats() (write StmtCacheStats, read StmtCacheStats) {
+	return s.writeStmts.Stats(), s.readStmts.Stats()
+}
+
+// Close closes the cached statements and the connections opened by the store
+func (s *Store) Close() error {
+	var errs []error
+	errs = append(errs, s.writeStmts.Close())
+	if s.readStmts != s.writeStmts {
+		errs = append(errs, s.readStmts.Close())
+	}
+	if s.owned {
+		errs = append(errs, s.reader.Close(), s.writer.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// StmtCacheStats counts how statements were obtained from a StmtCache
+type StmtCacheStats struct {
+	Hits     int64 // Statement was already prepared
+	Misses   int64 // Statement was prepared and cached
+	Uncached int64 // Cache was full; the query ran without a prepared statement
+	Size     int   // Statements currently cached
+}
+
+// StmtCache executes queries through prepared statements, prepared once per query string
+// It implements the same executor methods as *sql.DB and *sql.Tx. Queries that fail to prepare
+// run directly on the database, so that the caller sees the database's error.
+type StmtCache struct {
+	db      *sql.DB
+	maxSize int
+
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+
+	hits     atomic.Int64
+	misses   atomic.Int64
+	uncached atomic.Int64
+}
+
+// NewStmtCache creates a statement cache holding up to maxSize statements of db
+func NewStmtCache(db *sql.DB, maxSize int) *StmtCache {
+	if maxSize <= 0 {
+		maxSize = DefaultStmtCacheSize
+	}
+	return &StmtCache{
+		db:      db,
+		maxSize: maxSize,
+		stmts:   make(map[string]*sql.Stmt),
+	}
+}
+
+// stmt returns the prepared statement of query, or nil when the query is to run unprepared
+func (c *StmtCache) stmt(query string) *sql.Stmt {
+	c.mu.RLock()
+	stmt, ok := c.stmts[query]
+	c.mu.RUnlock()
+	if ok {
+		c.hits.Add(1)
+		return stmt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stmt, ok := c.stmts[query]; ok {
+		c.hits.Add(1)
+		return stmt
+	}
+	if len(c.stmts) >= c.maxSize {
+		c.uncached.Add(1)
+		return nil
+	}
+	// The statement outlives this call, so it is not prepared with the caller's context
+	stmt, err := c.db.Prepare(query)
+	if err != nil {
+		c.uncached.Add(1)
+		return nil
+	}
+	c.stmts[query] = stmt
+	c.misses.Add(1)
+	return stmt
+}
+
+// ExecContext executes a statement that returns no rows
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if stmt := c.stmt(query); stmt != nil {
+		return stmt.ExecContext(ctx, args...)
+	}
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns rows
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if stmt := c.stmt(query); stmt != nil {
+		return stmt.QueryContext(ctx, args...)
+	}
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext executes a query that returns at most one row
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if stmt := c.stmt(query); stmt != nil {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return c.db.QueryRowContext(ctx, query, args...)
+}
+
+// Stats returns the cache statistics
+func (c *StmtCache) Stats() StmtCacheStats {
+	c.mu.RLock()
+	size := len(c.stmts)
+	c.mu.RUnlock()
+	return StmtCacheStats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Uncached: c.uncached.Load(),
+		Size:     size,
+	}
+}
+
+// Close closes every cached statement and empties the cache
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs []error
+	for query, stmt := range c.stmts {
+		errs = append(errs, stmt.Close())
+		delete(c.stmts, query)
+	}
+	return errors.Join(errs...)
+}
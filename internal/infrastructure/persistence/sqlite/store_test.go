@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// newTestDBFile creates a migrated database file
+func newTestDBFile(t testing.TB) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "store.db")
+
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_journal_mode=WAL")
+	require.NoError(t, err)
+	require.NoError(t, NewMigrator(db).Migrate())
+	require.NoError(t, db.Close())
+	return path
+}
+
+// openTestStore opens a store on a new migrated database file
+func openTestStore(t testing.TB, opts StoreOptions) *Store {
+	t.Helper()
+	store, err := OpenStore(newTestDBFile(t), opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func newStoreTestSBI(t testing.TB, title string) *sbi.SBI {
+	t.Helper()
+	s, err := sbi.NewSBI(title, "Description of "+title, nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	return s
+}
+
+func TestStmtCache_ReusesPreparedStatements(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)`)
+	require.NoError(t, err)
+
+	cache := NewStmtCache(db, 2)
+	defer cache.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.ExecContext(ctx, `INSERT INTO items (name) VALUES (?)`, fmt.Sprintf("item-%d", i))
+		require.NoError(t, err)
+	}
+	var count int
+	require.NoError(t, cache.QueryRowContext(ctx, `SELECT COUNT(*) FROM items`).Scan(&count))
+	assert.Equal(t, 3, count)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(2), stats.Misses)
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, 2, stats.Size)
+
+	// A full cache still runs new queries, unprepared
+	rows, err := cache.QueryContext(ctx, `SELECT name FROM items ORDER BY id`)
+	require.NoError(t, err)
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Close())
+	assert.Equal(t, []string{"item-0", "item-1", "item-2"}, names)
+	assert.Equal(t, int64(1), cache.Stats().Uncached)
+
+	// Invalid queries are not cached and report the database's error
+	_, err = cache.ExecContext(ctx, `INSERT INTO missing (name) VALUES (?)`, "x")
+	assert.ErrorContains(t, err, "no such table")
+
+	require.NoError(t, cache.Close())
+	assert.Equal(t, 0, cache.Stats().Size)
+}
+
+func TestStore_SBIRepository(t *testing.T) {
+	store := openTestStore(t, StoreOptions{ReadConnections: 2})
+	repo := NewSBIRepositoryWithStore(store)
+	ctx := context.Background()
+
+	// Reads on the read pool see writes committed on the write connection
+	s := newStoreTestSBI(t, "Stored")
+	require.NoError(t, repo.Save(ctx, s))
+	found, err := repo.Find(ctx, repository.SBIID(s.ID().String()))
+	require.NoError(t, err)
+	assert.Equal(t, "Stored", found.Title())
+
+	// Concurrent writers queue on the write connection instead of failing with SQLITE_BUSY
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := repo.Save(ctx, newStoreTestSBI(t, fmt.Sprintf("Parallel %d", i))); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := repo.List(ctx, repository.SBIFilter{Limit: 5}); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent access failed: %v", err)
+	}
+
+	all, err := repo.List(ctx, repository.SBIFilter{})
+	require.NoError(t, err)
+	assert.Len(t, all, 21)
+
+	write, read := store.Stats()
+	assert.Greater(t, write.Hits, int64(0), "saves reuse the prepared upsert")
+	assert.Greater(t, read.Hits, int64(0), "lists reuse the prepared query")
+
+	// The read pool is query-only
+	_, err = store.Reader().ExecContext(ctx, `DELETE FROM sbis`)
+	assert.Error(t, err)
+}
+
+// BenchmarkSBIRepository_Parallel compares parallel Save/Find throughput on the store with
+// a single shared pool running every statement unprepared, as the repository did before the store
+func BenchmarkSBIRepository_Parallel(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		repo func(b *testing.B) repository.SBIRepository
+	}{
+		{"shared_pool_unprepared", func(b *testing.B) repository.SBIRepository {
+			db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=%d",
+				newTestDBFile(b), DefaultBusyTimeoutMs))
+			require.NoError(b, err)
+			b.Cleanup(func() { db.Close() })
+			unprepared := &StmtCache{db: db, stmts: map[string]*sql.Stmt{}}
+			return &SBIRepositoryImpl{store: &Store{writer: db, reader: db, writeStmts: unprepared, readStmts: unprepared}}
+		}},
+		{"store", func(b *testing.B) repository.SBIRepository {
+			return NewSBIRepositoryWithStore(openTestStore(b, StoreOptions{}))
+		}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			repo := bc.repo(b)
+			ctx := context.Background()
+
+			ids := make([]repository.SBIID, 50)
+			for i := range ids {
+				s := newStoreTestSBI(b, fmt.Sprintf("Bench %d", i))
+				require.NoError(b, repo.Save(ctx, s))
+				ids[i] = repository.SBIID(s.ID().String())
+			}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					i++
+					// One save for every four finds, like a turn updating its SBI between lookups
+					if i%5 == 0 {
+						s, err := repo.Find(ctx, ids[i%len(ids)])
+						if err == nil {
+							err = repo.Save(ctx, s)
+						}
+						if err != nil {
+							b.Error(err)
+							return
+						}
+						continue
+					}
+					if _, err := repo.Find(ctx, ids[i%len(ids)]); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -26,7 +26,7 @@ func NewTaskRepository(db *sql.DB) repository.TaskRepository {
 		db:       db,
 		epicRepo: &EPICRepositoryImpl{db: db},
 		// pbiRepo removed: PBI system is being refactored to Markdown + SQLite hybrid
-		sbiRepo: &SBIRepositoryImpl{db: db},
+		sbiRepo: &SBIRepositoryImpl{store: NewStore(db)},
 	}
 }
 
@@ -47,7 +47,7 @@ func (r *TaskRepositoryImpl) FindByID(ctx context.Context, id repository.TaskID)
 	case repository.TaskTypeSBI:
 		return r.sbiRepo.Find(ctx, repository.SBIID(id))
 	default:
-		return nil, fmt.Errorf("task not found: %s", id)
+		return nil, fmt.Errorf("task %w: %s", model.ErrNotFound, id)
 	}
 }
 
@@ -94,7 +94,7 @@ func (r *TaskRepositoryImpl) Delete(ctx context.Context, id repository.TaskID) e
 		}
 	}
 
-	return fmt.Errorf("task not found: %s", id)
+	return fmt.Errorf("task %w: %s", model.ErrNotFound, id)
 }
 
 // List retrieves tasks by filter
@@ -123,9 +123,19 @@ func (r *TaskRepositoryImpl) List(ctx context.Context, filter repository.TaskFil
 			continue
 		case repository.TaskTypeSBI:
 			sbiFilter := repository.SBIFilter{
-				Statuses: convertStatusesToModel(filter.Statuses),
-				Limit:    filter.Limit,
-				Offset:   filter.Offset,
+				Statuses:       convertStatusesToModel(filter.Statuses),
+				Labels:         filter.Labels,
+				AssignedAgents: filter.AssignedAgents,
+				MinPriority:    filter.MinPriority,
+				MaxPriority:    filter.MaxPriority,
+				MinTurns:       filter.MinTurns,
+				MaxTurns:       filter.MaxTurns,
+				UpdatedAfter:   filter.UpdatedAfter,
+				UpdatedBefore:  filter.UpdatedBefore,
+				OverdueAt:      filter.OverdueAt,
+				Sort:           filter.Sort,
+				Limit:          filter.Limit,
+				Offset:         filter.Offset,
 			}
 			// Map ParentID to PBIID for SBI filtering
 			if filter.ParentID != nil {
@@ -164,7 +174,7 @@ func (r *TaskRepositoryImpl) determineTaskType(ctx context.Context, id string) (
 		}
 	}
 
-	return "", fmt.Errorf("task not found: %s", id)
+	return "", fmt.Errorf("task %w: %s", model.ErrNotFound, id)
 }
 
 // getTaskTypesToQuery returns task types to query based on filter
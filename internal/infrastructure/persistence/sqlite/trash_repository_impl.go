@@ -0,0 +1,149 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// trashTables maps each trash kind to its table
+var trashTables = map[repository.TrashKind]string{
+	repository.TrashKindPBI: "pbis",
+	repository.TrashKindSBI: "sbis",
+}
+
+// TrashRepositoryImpl implements TrashRepository with the deleted_at columns of pbis and sbis
+type TrashRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewTrashRepository creates a new TrashRepository implementation
+func NewTrashRepository(db *sql.DB) repository.TrashRepository {
+	return &TrashRepositoryImpl{db: db}
+}
+
+// Trash marks a PBI or SBI as deleted
+// The version is incremented, so that entities loaded before the deletion can no longer be saved
+func (r *TrashRepositoryImpl) Trash(ctx context.Context, kind repository.TrashKind, id string, deletedAt time.Time) error {
+	table, err := trashTable(kind)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE "+table+" SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL",
+		deletedAt.UTC().Format(time.RFC3339), id)
+	if err != nil {
+		return fmt.Errorf("failed to move %s %s to the trash: %w", kind, id, err)
+	}
+	return requireTrashRow(result, id)
+}
+
+// Find returns the trashed PBI or SBI with the ID
+func (r *TrashRepositoryImpl) Find(ctx context.Context, id string) (*repository.TrashItem, error) {
+	items, err := r.query(ctx, "WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: %s", repository.ErrTrashItemNotFound, id)
+	}
+	return items[0], nil
+}
+
+// List returns the trashed items deleted before the given time, most recently deleted first
+func (r *TrashRepositoryImpl) List(ctx context.Context, deletedBefore time.Time) ([]*repository.TrashItem, error) {
+	if deletedBefore.IsZero() {
+		return r.query(ctx, "")
+	}
+	return r.query(ctx, "WHERE deleted_at < ?", deletedBefore.UTC().Format(time.RFC3339))
+}
+
+// Restore clears the deletion mark of a trashed item
+func (r *TrashRepositoryImpl) Restore(ctx context.Context, kind repository.TrashKind, id string) error {
+	table, err := trashTable(kind)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE "+table+" SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s %s: %w", kind, id, err)
+	}
+	return requireTrashRow(result, id)
+}
+
+// Purge permanently removes the rows of a trashed item
+func (r *TrashRepositoryImpl) Purge(ctx context.Context, kind repository.TrashKind, id string) error {
+	table, err := trashTable(kind)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM "+table+" WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("failed to purge %s %s: %w", kind, id, err)
+	}
+	return requireTrashRow(result, id)
+}
+
+// query returns the trashed items matching a condition on the union of both tables
+func (r *TrashRepositoryImpl) query(ctx context.Context, where string, args ...interface{}) ([]*repository.TrashItem, error) {
+	query := `
+		SELECT kind, id, title, status, deleted_at FROM (
+			SELECT 'pbi' AS kind, id, title, status, deleted_at FROM pbis WHERE deleted_at IS NOT NULL
+			UNION ALL
+			SELECT 'sbi' AS kind, id, title, status, deleted_at FROM sbis WHERE deleted_at IS NOT NULL
+		)
+		` + where + `
+		ORDER BY deleted_at DESC, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*repository.TrashItem{}
+	for rows.Next() {
+		var (
+			item      repository.TrashItem
+			kind      string
+			deletedAt string
+		)
+		if err := rows.Scan(&kind, &item.ID, &item.Title, &item.Status, &deletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan trash item: %w", err)
+		}
+		item.Kind = repository.TrashKind(kind)
+		if ts, err := time.Parse(time.RFC3339, deletedAt); err == nil {
+			item.DeletedAt = ts
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate the trash: %w", err)
+	}
+	return items, nil
+}
+
+// trashTable returns the table holding items of the kind
+func trashTable(kind repository.TrashKind) (string, error) {
+	table, ok := trashTables[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown trash kind: %s", kind)
+	}
+	return table, nil
+}
+
+// requireTrashRow fails with ErrTrashItemNotFound when a statement changed no row
+func requireTrashRow(result sql.Result, id string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", repository.ErrTrashItemNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestTrashRepository_TrashHidesAndRestores(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	ctx := context.Background()
+	sbiRepo := NewSBIRepository(db)
+	trashRepo := NewTrashRepository(db)
+
+	kept, err := sbi.NewSBI("Kept", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, kept))
+	deleted, err := sbi.NewSBI("Deleted", "", nil, sbi.SBIMetadata{Sequence: 7})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, deleted))
+	deletedID := deleted.ID().String()
+
+	deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, trashRepo.Trash(ctx, repository.TrashKindSBI, deletedID, deletedAt))
+	err = trashRepo.Trash(ctx, repository.TrashKindSBI, deletedID, deletedAt)
+	assert.True(t, errors.Is(err, repository.ErrTrashItemNotFound), "an item is trashed once")
+
+	// Trashed SBIs are hidden from lookups but keep their sequence reserved
+	_, err = sbiRepo.Find(ctx, repository.SBIID(deletedID))
+	assert.Error(t, err)
+	live, err := sbiRepo.List(ctx, repository.SBIFilter{})
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, "Kept", live[0].Title())
+	next, err := sbiRepo.GetNextSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 8, next)
+
+	// A save of the SBI loaded before the deletion is rejected
+	err = sbiRepo.Save(ctx, deleted)
+	assert.True(t, errors.Is(err, repository.ErrStaleEntity))
+
+	item, err := trashRepo.Find(ctx, deletedID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.TrashKindSBI, item.Kind)
+	assert.Equal(t, "Deleted", item.Title)
+	assert.True(t, deletedAt.Equal(item.DeletedAt))
+
+	expired, err := trashRepo.List(ctx, deletedAt)
+	require.NoError(t, err)
+	assert.Empty(t, expired, "items deleted at the cutoff are not listed")
+	expired, err = trashRepo.List(ctx, deletedAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+
+	require.NoError(t, trashRepo.Restore(ctx, repository.TrashKindSBI, deletedID))
+	restored, err := sbiRepo.Find(ctx, repository.SBIID(deletedID))
+	require.NoError(t, err)
+	assert.Equal(t, "Deleted", restored.Title())
+	_, err = trashRepo.Find(ctx, deletedID)
+	assert.True(t, errors.Is(err, repository.ErrTrashItemNotFound))
+}
+
+func TestTrashRepository_PurgeOnlyTrashedItems(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	ctx := context.Background()
+	sbiRepo := NewSBIRepository(db)
+	trashRepo := NewTrashRepository(db)
+
+	s, err := sbi.NewSBI("Purged", "", nil, sbi.SBIMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, sbiRepo.Save(ctx, s))
+	id := s.ID().String()
+
+	err = trashRepo.Purge(ctx, repository.TrashKindSBI, id)
+	assert.True(t, errors.Is(err, repository.ErrTrashItemNotFound), "live items are never purged")
+
+	require.NoError(t, trashRepo.Trash(ctx, repository.TrashKindSBI, id, time.Now()))
+	require.NoError(t, trashRepo.Purge(ctx, repository.TrashKindSBI, id))
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sbis WHERE id = ?", id).Scan(&count))
+	assert.Equal(t, 0, count)
+}
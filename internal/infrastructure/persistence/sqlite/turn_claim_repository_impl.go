@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/process"
+)
+
+// TurnClaimRepositoryImpl implements TurnClaimRepository using SQLite
+// The primary key on (sbi_id, turn) makes the first insert win.
+type TurnClaimRepositoryImpl struct {
+	db       *sql.DB
+	pid      int
+	hostname string
+}
+
+// NewTurnClaimRepository creates a new TurnClaimRepository implementation
+// Claims are recorded with the PID and hostname of the current process.
+func NewTurnClaimRepository(db *sql.DB) repository.TurnClaimRepository {
+	hostname, _ := os.Hostname()
+	return &TurnClaimRepositoryImpl{db: db, pid: os.Getpid(), hostname: hostname}
+}
+
+// Claim records the current process as the runner of the turn
+func (r *TurnClaimRepositoryImpl) Claim(ctx context.Context, sbiID string, turn int, staleAfter time.Duration) (*repository.TurnClaim, error) {
+	now := time.Now().UTC()
+	claimedAt := now.Format(time.RFC3339Nano)
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO sbi_turn_claims (sbi_id, turn, pid, hostname, claimed_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(sbi_id, turn) DO NOTHING
+	`, sbiID, turn, r.pid, r.hostname, claimedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim turn %d of %s: %w", turn, sbiID, err)
+	}
+	claim := &repository.TurnClaim{SBIID: sbiID, Turn: turn, PID: r.pid, Hostname: r.hostname, ClaimedAt: now}
+	if affected, err := result.RowsAffected(); err == nil && affected == 1 {
+		return claim, nil
+	}
+
+	existing, existingAt, err := r.find(ctx, sbiID, turn)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		// Released in between; the next runner to claim it wins
+		return r.Claim(ctx, sbiID, turn, staleAfter)
+	}
+
+	mine := existing.PID == r.pid && existing.Hostname == r.hostname
+	stale := staleAfter > 0 && now.Sub(existing.ClaimedAt) > staleAfter
+	if !mine && !stale && process.IsLockHolderRunning(existing.Hostname, existing.PID) {
+		return existing, repository.ErrTurnClaimed
+	}
+
+	// Take the claim over unless another runner took it over first
+	result, err = r.db.ExecContext(ctx, `
+		UPDATE sbi_turn_claims SET pid = ?, hostname = ?, claimed_at = ?
+		WHERE sbi_id = ? AND turn = ? AND pid = ? AND hostname = ? AND claimed_at = ?
+	`, r.pid, r.hostname, claimedAt, sbiID, turn, existing.PID, existing.Hostname, existingAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take over turn %d of %s: %w", turn, sbiID, err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 1 {
+		return claim, nil
+	}
+
+	holder, _, err := r.find(ctx, sbiID, turn)
+	if err != nil {
+		return nil, err
+	}
+	return holder, repository.ErrTurnClaimed
+}
+
+// Release removes the claim of the current process
+func (r *TurnClaimRepositoryImpl) Release(ctx context.Context, sbiID string, turn int) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM sbi_turn_claims
+		WHERE sbi_id = ? AND turn = ? AND pid = ? AND hostname = ?
+	`, sbiID, turn, r.pid, r.hostname)
+	if err != nil {
+		return fmt.Errorf("failed to release turn %d of %s: %w", turn, sbiID, err)
+	}
+	return nil
+}
+
+// find returns the claim of a turn with its stored claimed_at, or nil when unclaimed
+func (r *TurnClaimRepositoryImpl) find(ctx context.Context, sbiID string, turn int) (*repository.TurnClaim, string, error) {
+	claim := &repository.TurnClaim{SBIID: sbiID, Turn: turn}
+	var claimedAt string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT pid, hostname, claimed_at FROM sbi_turn_claims WHERE sbi_id = ? AND turn = ?
+	`, sbiID, turn).Scan(&claim.PID, &claim.Hostname, &claimedAt)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find claim of turn %d of %s: %w", turn, sbiID, err)
+	}
+	claim.ClaimedAt, _ = time.Parse(time.RFC3339Nano, claimedAt)
+	return claim, claimedAt, nil
+}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestTurnClaimRepository_Claim(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewTurnClaimRepository(db)
+	ctx := context.Background()
+
+	// The first claim wins, and the current process may claim its turn again
+	claim, err := repo.Claim(ctx, "SBI-A", 3, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), claim.PID)
+	_, err = repo.Claim(ctx, "SBI-A", 3, time.Hour)
+	require.NoError(t, err)
+
+	// A runner on another host holds turn 4
+	claimedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	_, err = db.Exec(`INSERT INTO sbi_turn_claims (sbi_id, turn, pid, hostname, claimed_at) VALUES ('SBI-A', 4, 4242, 'other-host', ?)`, claimedAt)
+	require.NoError(t, err)
+
+	holder, err := repo.Claim(ctx, "SBI-A", 4, time.Hour)
+	assert.True(t, errors.Is(err, repository.ErrTurnClaimed))
+	assert.True(t, errors.Is(err, model.ErrLockHeld))
+	require.NotNil(t, holder)
+	assert.Equal(t, "other-host", holder.Hostname)
+	assert.Equal(t, 4242, holder.PID)
+
+	// Releasing only removes claims of the current process
+	require.NoError(t, repo.Release(ctx, "SBI-A", 4))
+	_, err = repo.Claim(ctx, "SBI-A", 4, time.Hour)
+	assert.True(t, errors.Is(err, repository.ErrTurnClaimed))
+
+	require.NoError(t, repo.Release(ctx, "SBI-A", 3))
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sbi_turn_claims WHERE turn = 3`).Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestTurnClaimRepository_ClaimStale(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, NewMigrator(db).Migrate())
+
+	repo := NewTurnClaimRepository(db)
+	ctx := context.Background()
+
+	claimedAt := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339Nano)
+	_, err = db.Exec(`INSERT INTO sbi_turn_claims (sbi_id, turn, pid, hostname, claimed_at) VALUES ('SBI-A', 5, 4242, 'other-host', ?)`, claimedAt)
+	require.NoError(t, err)
+
+	// Not stale without a staleness limit
+	_, err = repo.Claim(ctx, "SBI-A", 5, 0)
+	assert.True(t, errors.Is(err, repository.ErrTurnClaimed))
+
+	claim, err := repo.Claim(ctx, "SBI-A", 5, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), claim.PID)
+
+	var pid int
+	require.NoError(t, db.QueryRow(`SELECT pid FROM sbi_turn_claims WHERE sbi_id = 'SBI-A' AND turn = 5`).Scan(&pid))
+	assert.Equal(t, os.Getpid(), pid)
+}
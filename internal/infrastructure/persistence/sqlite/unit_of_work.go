@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
+)
+
+// UnitOfWork implements output.UnitOfWork with a transaction on the store's write connection
+// Repositories on the same store, and journal records appended through the outbox, commit
+// together. Repositories writing through another connection must not be used within it:
+// the write connection is held until the unit ends.
+type UnitOfWork struct {
+	store  *Store
+	outbox *JournalOutboxRepository
+}
+
+// NewUnitOfWork creates a unit of work on store that flushes outbox after each commit
+func NewUnitOfWork(store *Store, outbox *JournalOutboxRepository) *UnitOfWork {
+	return &UnitOfWork{store: store, outbox: outbox}
+}
+
+// Do executes fn in a transaction and moves its journal records to the journal once committed
+// A failed flush does not undo the commit: the records stay in the outbox until the next flush.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(txCtx context.Context) error) error {
+	if _, ok := transaction.GetTxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	if err := transaction.NewSQLiteTransactionManager(u.store.Writer()).InTransaction(ctx, fn); err != nil {
+		return err
+	}
+	if u.outbox != nil {
+		if _, err := u.outbox.Flush(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to flush journal outbox (records kept for the next flush): %v\n", err)
+		}
+	}
+	return nil
+}
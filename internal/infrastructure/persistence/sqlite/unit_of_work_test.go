@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/transaction"
+)
+
+// newTestUnitOfWork creates a unit of work on a new store with a file journal behind its outbox
+func newTestUnitOfWork(t *testing.T) (*Store, *UnitOfWork, *JournalOutboxRepository, *infraRepo.JournalRepositoryImpl) {
+	t.Helper()
+	store := openTestStore(t, StoreOptions{})
+	journal := infraRepo.NewJournalRepositoryImpl(filepath.Join(t.TempDir(), "journal.ndjson"))
+	outbox := NewJournalOutboxRepository(store, journal)
+	return store, NewUnitOfWork(store, outbox), outbox, journal
+}
+
+func countOutbox(t *testing.T, store *Store) int {
+	t.Helper()
+	var count int
+	require.NoError(t, store.Reader().QueryRow(`SELECT COUNT(*) FROM journal_outbox`).Scan(&count))
+	return count
+}
+
+func TestUnitOfWork_CommitsSBIAndJournalEntry(t *testing.T) {
+	store, uow, outbox, journal := newTestUnitOfWork(t)
+	repo := NewSBIRepositoryWithStore(store)
+	ctx := context.Background()
+	s := newStoreTestSBI(t, "Atomic")
+
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := repo.Save(txCtx, s); err != nil {
+			return err
+		}
+		return outbox.Append(txCtx, &repository.JournalRecord{SBIID: s.ID().String(), Turn: 1, Step: "pick", Status: "WIP"})
+	})
+	require.NoError(t, err)
+
+	found, err := repo.Find(ctx, repository.SBIID(s.ID().String()))
+	require.NoError(t, err)
+	assert.Equal(t, "Atomic", found.Title())
+
+	records, err := journal.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "pick", records[0].Step)
+	assert.NotEmpty(t, records[0].Timestamp)
+	assert.Equal(t, 0, countOutbox(t, store), "committed records are moved to the journal")
+}
+
+func TestUnitOfWork_RollsBackSBIAndJournalEntry(t *testing.T) {
+	store, uow, outbox, journal := newTestUnitOfWork(t)
+	repo := NewSBIRepositoryWithStore(store)
+	ctx := context.Background()
+	s := newStoreTestSBI(t, "Rolled back")
+	failure := errors.New("crash before commit")
+
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := repo.Save(txCtx, s); err != nil {
+			return err
+		}
+		if err := outbox.Append(txCtx, &repository.JournalRecord{SBIID: s.ID().String(), Turn: 1, Step: "pick"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	found, err := repo.Find(ctx, repository.SBIID(s.ID().String()))
+	assert.True(t, err != nil || found == nil, "SBI save must be rolled back")
+
+	records, err := journal.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 0, countOutbox(t, store))
+}
+
+func TestJournalOutbox_FlushesRecordsLeftByCrash(t *testing.T) {
+	store, _, outbox, journal := newTestUnitOfWork(t)
+	ctx := context.Background()
+
+	// Committed without the flush that follows a unit of work, as after a crash
+	record := &repository.JournalRecord{Timestamp: "2025-10-01T00:00:00Z", SBIID: "sbi-1", Turn: 2, Step: "implement"}
+	err := transaction.NewSQLiteTransactionManager(store.Writer()).InTransaction(ctx, func(txCtx context.Context) error {
+		return outbox.Append(txCtx, record)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, countOutbox(t, store))
+
+	// Reads through the outbox see the record
+	records, err := outbox.FindBySBI(ctx, "sbi-1")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "implement", records[0].Step)
+	assert.Equal(t, 0, countOutbox(t, store))
+
+	// A record flushed twice is journaled once
+	require.NoError(t, journal.Append(ctx, record))
+	records, err = journal.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestJournalOutbox_AppendsDirectlyOutsideTransaction(t *testing.T) {
+	store, _, outbox, journal := newTestUnitOfWork(t)
+	ctx := context.Background()
+
+	require.NoError(t, outbox.Append(ctx, &repository.JournalRecord{SBIID: "sbi-1", Turn: 1, Step: "pick"}))
+
+	assert.Equal(t, 0, countOutbox(t, store))
+	records, err := journal.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
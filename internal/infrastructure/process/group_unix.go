@@ -0,0 +1,41 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+// ConfigureGroup starts cmd in its own process group and makes cancelling it (timeout or
+// context cancellation) kill the whole group, so that the children an agent CLI spawns
+// (shells, test runners, language servers) are not left orphaned
+// cmd must have been created with exec.CommandContext.
+func ConfigureGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	cmd.Cancel = func() error {
+		return KillGroup(cmd.Process.Pid)
+	}
+	// Children holding the output pipes open must not keep Wait from returning
+	cmd.WaitDelay = groupWaitDelay
+}
+
+// KillGroup kills every process of a process group
+func KillGroup(pgid int) error {
+	err := syscall.Kill(-pgid, syscall.SIGKILL)
+	if errors.Is(err, syscall.ESRCH) {
+		return nil
+	}
+	return err
+}
+
+// groupAlive reports whether any process of a process group is still running
+func groupAlive(pgid int) bool {
+	err := syscall.Kill(-pgid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
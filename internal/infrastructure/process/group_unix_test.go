@@ -0,0 +1,213 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitGroupGone waits until every process of a process group has exited
+func waitGroupGone(t *testing.T, pgid int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for groupAlive(pgid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("process group %d is still alive", pgid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// deadPID returns a PID that is not in use on this host
+func deadPID() int {
+	pid := 1 << 22
+	for IsRunning(pid) {
+		pid++
+	}
+	return pid
+}
+
+func TestConfigureGroup_TimeoutKillsChildren(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	// The shell starts a child that would outlive it if only the shell were killed
+	cmd := exec.CommandContext(ctx, "sh", "-c", "sleep 30 & echo $!; wait")
+	ConfigureGroup(cmd)
+	start := time.Now()
+	out, err := cmd.Output()
+	if err == nil {
+		t.Fatal("expected the command to be killed")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("command took %s to return after the timeout", elapsed)
+	}
+	if _, err := strconv.Atoi(strings.TrimSpace(string(out))); err != nil {
+		t.Fatalf("unexpected output %q", out)
+	}
+	waitGroupGone(t, cmd.Process.Pid)
+}
+
+func TestRegistry_TrackAndList(t *testing.T) {
+	registry := NewRegistry(filepath.Join(t.TempDir(), "agents"))
+	registry.keepalive = 10 * time.Millisecond
+
+	cmd := exec.CommandContext(context.Background(), "sleep", "30")
+	ConfigureGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	defer func() { _ = KillGroup(cmd.Process.Pid); _ = cmd.Wait() }()
+
+	untrack := registry.Track(cmd, time.Minute)
+	records, err := registry.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("List() returned %d records, want 1", len(records))
+	}
+	record := records[0]
+	if record.PGID != cmd.Process.Pid || record.Command != "sleep" || record.OwnerPID != os.Getpid() {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if record.Deadline.Sub(record.StartedAt) != time.Minute {
+		t.Errorf("Deadline = %s, want a minute after the start", record.Deadline)
+	}
+
+	// The keepalive refreshes the heartbeat
+	time.Sleep(50 * time.Millisecond)
+	records, _ = registry.List()
+	if len(records) != 1 || !records[0].HeartbeatAt.After(record.HeartbeatAt) {
+		t.Errorf("heartbeat was not refreshed: %+v", records)
+	}
+
+	// A group whose owner is waiting for it is not reaped
+	reaped, err := registry.Reap()
+	if err != nil || len(reaped) != 0 {
+		t.Errorf("Reap() = %v, %v; want nothing reaped", reaped, err)
+	}
+
+	untrack()
+	untrack()
+	if records, _ := registry.List(); len(records) != 0 {
+		t.Errorf("record was not removed: %+v", records)
+	}
+}
+
+func TestRegistry_Reap(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	start := func(t *testing.T) *exec.Cmd {
+		cmd := exec.CommandContext(context.Background(), "sh", "-c", "sleep 30 & wait")
+		ConfigureGroup(cmd)
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("failed to start: %v", err)
+		}
+		t.Cleanup(func() { _ = KillGroup(cmd.Process.Pid); _ = cmd.Wait() })
+		return cmd
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		record func(pgid int) AgentRecord
+		reason string // "" when the group must survive
+	}{
+		{
+			name: "owner exited",
+			record: func(pgid int) AgentRecord {
+				return AgentRecord{PGID: pgid, OwnerPID: deadPID(), Hostname: hostname, StartedAt: now, HeartbeatAt: now}
+			},
+			reason: "owner process",
+		},
+		{
+			name: "keepalive stopped",
+			record: func(pgid int) AgentRecord {
+				return AgentRecord{PGID: pgid, OwnerPID: os.Getpid(), Hostname: hostname, StartedAt: now.Add(-time.Hour), HeartbeatAt: now.Add(-time.Hour)}
+			},
+			reason: "no keepalive",
+		},
+		{
+			name: "past deadline",
+			record: func(pgid int) AgentRecord {
+				return AgentRecord{PGID: pgid, OwnerPID: os.Getpid(), Hostname: hostname, StartedAt: now.Add(-time.Hour), HeartbeatAt: now, Deadline: now.Add(-time.Hour)}
+			},
+			reason: "past its timeout",
+		},
+		{
+			name: "running",
+			record: func(pgid int) AgentRecord {
+				return AgentRecord{PGID: pgid, OwnerPID: os.Getpid(), Hostname: hostname, StartedAt: now, HeartbeatAt: now, Deadline: now.Add(time.Hour)}
+			},
+		},
+		{
+			name: "other host",
+			record: func(pgid int) AgentRecord {
+				return AgentRecord{PGID: pgid, OwnerPID: deadPID(), Hostname: hostname + "-other", StartedAt: now, HeartbeatAt: now}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(t.TempDir())
+			cmd := start(t)
+			if err := registry.write(tt.record(cmd.Process.Pid)); err != nil {
+				t.Fatalf("failed to write record: %v", err)
+			}
+
+			reaped, err := registry.Reap()
+			if err != nil {
+				t.Fatalf("Reap() error = %v", err)
+			}
+			records, _ := registry.List()
+			if tt.reason == "" {
+				if len(reaped) != 0 || len(records) != 1 || !groupAlive(cmd.Process.Pid) {
+					t.Errorf("group was reaped: %+v", reaped)
+				}
+				return
+			}
+			if len(reaped) != 1 || reaped[0].PGID != cmd.Process.Pid || !strings.Contains(reaped[0].Reason, tt.reason) {
+				t.Fatalf("Reap() = %+v, want the group reaped because %q", reaped, tt.reason)
+			}
+			if len(records) != 0 {
+				t.Errorf("record was not removed: %+v", records)
+			}
+			_ = cmd.Wait()
+			waitGroupGone(t, cmd.Process.Pid)
+		})
+	}
+}
+
+func TestRegistry_ReapRemovesExitedGroups(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	registry := NewRegistry(t.TempDir())
+	if err := registry.write(AgentRecord{PGID: deadPID(), OwnerPID: deadPID(), Hostname: hostname}); err != nil {
+		t.Fatalf("failed to write record: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(registry.dir, "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reaped, err := registry.Reap()
+	if err != nil || len(reaped) != 0 {
+		t.Errorf("Reap() = %+v, %v; want nothing reaped", reaped, err)
+	}
+	entries, _ := os.ReadDir(registry.dir)
+	if len(entries) != 0 {
+		t.Errorf("%d records left, want none", len(entries))
+	}
+}
@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package process
+
+import (
+	"os"
+	"os/exec"
+)
+
+// ConfigureGroup makes cancelling cmd kill it without waiting for the children holding its
+// output pipes
+// Windows has no process groups to kill at once; children of the agent may survive it.
+// cmd must have been created with exec.CommandContext.
+func ConfigureGroup(cmd *exec.Cmd) {
+	cmd.WaitDelay = groupWaitDelay
+}
+
+// KillGroup kills the leader of a process group
+func KillGroup(pgid int) error {
+	p, err := os.FindProcess(pgid)
+	if err != nil {
+		return nil
+	}
+	return p.Kill()
+}
+
+// groupAlive reports whether the leader of a process group is still running
+func groupAlive(pgid int) bool {
+	p, err := os.FindProcess(pgid)
+	if err != nil {
+		return false
+	}
+	p.Release()
+	return true
+}
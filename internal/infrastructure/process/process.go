@@ -0,0 +1,28 @@
+// Package process checks whether the processes recorded by locks are still alive, and runs
+// agent CLIs in process groups that can be killed as a whole and reaped when abandoned
+package process
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// IsRunning checks if a process with the given PID is running on this host
+func IsRunning(pid int) bool {
+	// Use ps command to check if process exists
+	// This works on Unix-like systems (Linux, macOS)
+	cmd := exec.Command("ps", "-p", strconv.Itoa(pid))
+	return cmd.Run() == nil
+}
+
+// IsLockHolderRunning reports whether the holder of a lock may still be running
+// A PID is only meaningful on the host that recorded it, so a holder on another host
+// (or an unknown local hostname) is reported as running and left to lease expiry
+func IsLockHolderRunning(hostname string, pid int) bool {
+	local, err := os.Hostname()
+	if err != nil || hostname != local {
+		return true
+	}
+	return IsRunning(pid)
+}
@@ -0,0 +1,31 @@
+package process
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsLockHolderRunning(t *testing.T) {
+	local, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+
+	// Find a PID that is not in use on this host
+	deadPID := 1 << 22
+	for IsRunning(deadPID) {
+		deadPID++
+	}
+
+	if !IsLockHolderRunning(local, os.Getpid()) {
+		t.Error("IsLockHolderRunning() = false for the current process")
+	}
+	if IsLockHolderRunning(local, deadPID) {
+		t.Error("IsLockHolderRunning() = true for a dead local process")
+	}
+
+	// A PID recorded on another host cannot be checked here
+	if !IsLockHolderRunning(local+"-other", deadPID) {
+		t.Error("IsLockHolderRunning() = false for a holder on another host")
+	}
+}
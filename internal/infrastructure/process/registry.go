@@ -0,0 +1,204 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+// groupWaitDelay is how long Wait waits for the output pipes after the agent was killed
+const groupWaitDelay = 5 * time.Second
+
+// DefaultKeepaliveInterval is how often a running agent's record is refreshed
+const DefaultKeepaliveInterval = 30 * time.Second
+
+// AgentRecord is an agent process group tracked by the registry
+type AgentRecord struct {
+	PGID        int       `json:"pgid"`         // Process group (and PID of the agent CLI)
+	Command     string    `json:"command"`      // Agent binary
+	OwnerPID    int       `json:"owner_pid"`    // deespec process that started the agent
+	Hostname    string    `json:"hostname"`     // Host of the processes
+	StartedAt   time.Time `json:"started_at"`   // When the agent was started
+	HeartbeatAt time.Time `json:"heartbeat_at"` // Refreshed by the owner while it waits for the agent
+	Deadline    time.Time `json:"deadline"`     // When the agent call times out (zero = no timeout)
+}
+
+// ReapedAgent is an abandoned agent process group the reaper killed
+type ReapedAgent struct {
+	AgentRecord
+	Reason string // Why the group was considered abandoned
+}
+
+// Registry records the agent processes deespec starts, one JSON file per process group,
+// so that a reaper can kill the groups left behind by a crashed or hung runner
+// While an agent runs its owner refreshes the record (keepalive); a group whose owner is
+// gone, stopped refreshing, or is past the call's deadline is abandoned.
+type Registry struct {
+	dir       string
+	keepalive time.Duration
+	grace     time.Duration // Allowed overrun of the deadline and heartbeat before a group is reaped
+	now       func() time.Time
+}
+
+// NewRegistry creates a registry keeping its records in dir (e.g. .deespec/var/agents)
+func NewRegistry(dir string) *Registry {
+	return &Registry{
+		dir:       dir,
+		keepalive: DefaultKeepaliveInterval,
+		grace:     2 * time.Minute,
+		now:       time.Now,
+	}
+}
+
+// Track records a started agent process group and refreshes its record until the returned
+// function is called, which removes the record. cmd must have been started with ConfigureGroup.
+// Tracking is best effort: a record that cannot be written is skipped.
+func (r *Registry) Track(cmd *exec.Cmd, timeout time.Duration) func() {
+	if r == nil || cmd.Process == nil {
+		return func() {}
+	}
+	hostname, _ := os.Hostname()
+	now := r.now()
+	record := AgentRecord{
+		PGID:        cmd.Process.Pid,
+		Command:     filepath.Base(cmd.Path),
+		OwnerPID:    os.Getpid(),
+		Hostname:    hostname,
+		StartedAt:   now,
+		HeartbeatAt: now,
+	}
+	if timeout > 0 {
+		record.Deadline = now.Add(timeout)
+	}
+	if err := r.write(record); err != nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(r.keepalive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				record.HeartbeatAt = r.now()
+				_ = r.write(record)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+			_ = os.Remove(r.path(record.PGID))
+		})
+	}
+}
+
+// List returns the tracked agent records, oldest first
+func (r *Registry) List() ([]AgentRecord, error) {
+	entries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent registry: %w", err)
+	}
+	var records []AgentRecord
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var record AgentRecord
+		if err := json.Unmarshal(data, &record); err != nil || record.PGID <= 0 {
+			_ = os.Remove(filepath.Join(r.dir, entry.Name())) // Unreadable records cannot be reaped
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].StartedAt.Before(records[j].StartedAt) })
+	return records, nil
+}
+
+// Reap kills the abandoned agent process groups of this host and removes their records
+// Records of groups that already exited are removed silently; records of other hosts are kept.
+func (r *Registry) Reap() ([]ReapedAgent, error) {
+	records, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := os.Hostname()
+	var reaped []ReapedAgent
+	for _, record := range records {
+		if record.Hostname != hostname {
+			continue
+		}
+		// The kernel does not reuse a PID while its process group exists, so a live group is the agent's
+		if !groupAlive(record.PGID) {
+			_ = os.Remove(r.path(record.PGID))
+			continue
+		}
+		reason := r.abandoned(record)
+		if reason == "" {
+			continue
+		}
+		if err := KillGroup(record.PGID); err != nil {
+			return reaped, fmt.Errorf("failed to kill agent process group %d: %w", record.PGID, err)
+		}
+		_ = os.Remove(r.path(record.PGID))
+		reaped = append(reaped, ReapedAgent{AgentRecord: record, Reason: reason})
+	}
+	return reaped, nil
+}
+
+// abandoned returns why a running agent group is abandoned, or "" while its owner still waits for it
+func (r *Registry) abandoned(record AgentRecord) string {
+	now := r.now()
+	switch {
+	case record.OwnerPID != os.Getpid() && !IsRunning(record.OwnerPID):
+		return fmt.Sprintf("owner process %d exited", record.OwnerPID)
+	case now.Sub(record.HeartbeatAt) > 2*r.keepalive+r.grace:
+		return fmt.Sprintf("no keepalive since %s", record.HeartbeatAt.Local().Format("15:04:05"))
+	case !record.Deadline.IsZero() && now.After(record.Deadline.Add(r.grace)):
+		return fmt.Sprintf("running past its timeout (%s)", record.Deadline.Local().Format("15:04:05"))
+	}
+	return ""
+}
+
+// write stores a record atomically
+func (r *Registry) write(record AgentRecord) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	// Write and rename so that the reaper never reads a partial record
+	return fs.WriteFileAtomic(r.path(record.PGID), data, 0644)
+}
+
+// path returns the record file of a process group
+func (r *Registry) path(pgid int) string {
+	return filepath.Join(r.dir, strconv.Itoa(pgid)+".json")
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+	"gopkg.in/yaml.v3"
+)
+
+// backlogProposalManifest is the manifest file name in the proposal directory
+const backlogProposalManifest = "proposal.yaml"
+
+// BacklogProposalRepositoryImpl implements BacklogProposalRepository for file-based storage
+type BacklogProposalRepositoryImpl struct {
+	dir string // .deespec/specs/proposal
+}
+
+// NewBacklogProposalRepositoryImpl creates a backlog proposal repository storing its files in dir
+func NewBacklogProposalRepositoryImpl(dir string) repository.BacklogProposalRepository {
+	return &BacklogProposalRepositoryImpl{dir: dir}
+}
+
+// Load loads the proposal manifest, or nil when there is no proposal
+func (r *BacklogProposalRepositoryImpl) Load(ctx context.Context) (*pbi.BacklogProposal, error) {
+	data, err := os.ReadFile(filepath.Join(r.dir, backlogProposalManifest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backlog proposal: %w", err)
+	}
+	var proposal pbi.BacklogProposal
+	if err := yaml.Unmarshal(data, &proposal); err != nil {
+		return nil, fmt.Errorf("failed to parse backlog proposal: %w", err)
+	}
+	return &proposal, nil
+}
+
+// Save persists the proposal manifest atomically
+func (r *BacklogProposalRepositoryImpl) Save(ctx context.Context, proposal *pbi.BacklogProposal) error {
+	if proposal == nil {
+		return fmt.Errorf("proposal cannot be nil")
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", r.dir, err)
+	}
+	data, err := yaml.Marshal(proposal)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backlog proposal: %w", err)
+	}
+	if err := fs.WriteFileAtomic(filepath.Join(r.dir, backlogProposalManifest), data, 0644); err != nil {
+		return fmt.Errorf("failed to write backlog proposal: %w", err)
+	}
+	return nil
+}
+
+// Replace removes the current proposal directory and writes the new drafts and manifest
+func (r *BacklogProposalRepositoryImpl) Replace(ctx context.Context, proposal *pbi.BacklogProposal, drafts map[string]string) error {
+	if err := os.RemoveAll(r.dir); err != nil {
+		return fmt.Errorf("failed to remove the previous proposal: %w", err)
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", r.dir, err)
+	}
+	for file, content := range drafts {
+		path, err := r.draftPath(file)
+		if err != nil {
+			return err
+		}
+		if err := fs.WriteFileAtomic(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write draft %s: %w", file, err)
+		}
+	}
+	return r.Save(ctx, proposal)
+}
+
+// ReadDraft returns the Markdown content of a draft file
+func (r *BacklogProposalRepositoryImpl) ReadDraft(ctx context.Context, file string) (string, error) {
+	path, err := r.draftPath(file)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read draft %s: %w", file, err)
+	}
+	return string(content), nil
+}
+
+// draftPath resolves a draft file name inside the proposal directory
+func (r *BacklogProposalRepositoryImpl) draftPath(file string) (string, error) {
+	if file == "" || file == backlogProposalManifest || filepath.Base(file) != file {
+		return "", fmt.Errorf("invalid draft file name %q", file)
+	}
+	return filepath.Join(r.dir, file), nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+)
+
+func TestBacklogProposalRepositoryImpl(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "specs", "proposal")
+	repo := NewBacklogProposalRepositoryImpl(dir)
+
+	proposal, err := repo.Load(ctx)
+	if err != nil || proposal != nil {
+		t.Fatalf("Load() without a proposal = %v, %v; want nil, nil", proposal, err)
+	}
+
+	first := pbi.NewBacklogProposal([]pbi.BacklogDraft{{File: "pbi-01.md", Kind: pbi.DraftKindPBI, Title: "Old"}})
+	if err := repo.Replace(ctx, first, map[string]string{"pbi-01.md": "# Old\n"}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	second := pbi.NewBacklogProposal([]pbi.BacklogDraft{{File: "epic-01.md", Kind: pbi.DraftKindEPIC, Title: "New"}})
+	if err := repo.Replace(ctx, second, map[string]string{"epic-01.md": "# New\n"}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pbi-01.md")); !os.IsNotExist(err) {
+		t.Errorf("drafts of the replaced proposal should be removed, stat error = %v", err)
+	}
+
+	loaded, err := repo.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Drafts) != 1 || loaded.Drafts[0].Title != "New" || loaded.Drafts[0].Status != pbi.ApprovalStatusPending {
+		t.Errorf("Load() drafts = %+v", loaded.Drafts)
+	}
+
+	content, err := repo.ReadDraft(ctx, "epic-01.md")
+	if err != nil || content != "# New\n" {
+		t.Errorf("ReadDraft() = %q, %v", content, err)
+	}
+	for _, file := range []string{"", "proposal.yaml", filepath.Join("..", "epic-01.md")} {
+		if _, err := repo.ReadDraft(ctx, file); err == nil {
+			t.Errorf("ReadDraft(%q) should fail", file)
+		}
+	}
+}
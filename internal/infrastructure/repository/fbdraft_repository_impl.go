@@ -11,6 +11,7 @@ import (
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 	"gopkg.in/yaml.v3"
 )
 
@@ -61,7 +62,7 @@ func (r *FBDraftRepositoryImpl) PersistDraft(ctx context.Context, draft dto.FBDr
 `, draft.TargetTaskID, draft.ReasonCode, draft.Summary,
 		createdAt.Format(time.RFC3339Nano), draft.TargetTaskID, draft.ReasonCode)
 
-	if err := os.WriteFile(contextPath, []byte(contextContent), 0644); err != nil {
+	if err := fs.WriteFileAtomic(contextPath, []byte(contextContent), 0644); err != nil {
 		return "", fmt.Errorf("write context.md: %w", err)
 	}
 
@@ -73,7 +74,7 @@ Summary: %s
 Created At: %s
 `, draft.TargetTaskID, draft.ReasonCode, draft.Summary, createdAt.Format(time.RFC3339Nano))
 
-	if err := os.WriteFile(evidencePath, []byte(evidenceContent), 0644); err != nil {
+	if err := fs.WriteFileAtomic(evidencePath, []byte(evidenceContent), 0644); err != nil {
 		return "", fmt.Errorf("write evidence.txt: %w", err)
 	}
 
@@ -103,7 +104,7 @@ Created At: %s
 		return "", fmt.Errorf("marshal draft.yaml: %w", err)
 	}
 
-	if err := os.WriteFile(draftPath, yamlData, 0644); err != nil {
+	if err := fs.WriteFileAtomic(draftPath, yamlData, 0644); err != nil {
 		return "", fmt.Errorf("write draft.yaml: %w", err)
 	}
 
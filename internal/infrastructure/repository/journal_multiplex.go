@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// JournalSink receives a copy of every journal record appended through a
+// MultiplexJournalRepository (e.g. stdout, a second file, the database, a remote collector)
+type JournalSink interface {
+	// Name identifies the sink in warnings, e.g. "http https://collector.example.com/journal"
+	Name() string
+
+	// Write delivers records in journal order; on error the whole batch is retried later,
+	// so sinks that can receive a record twice should deduplicate by its idempotency key
+	Write(ctx context.Context, records []*repository.JournalRecord) error
+}
+
+// DefaultJournalSinkBuffer is the number of records a sink keeps while it is failing
+const DefaultJournalSinkBuffer = 1000
+
+// journalSinkRetryInterval is how often a failing sink is retried without new records
+const journalSinkRetryInterval = 5 * time.Second
+
+// MultiplexJournalRepository appends each record to the primary journal and copies it to
+// every sink. The primary journal decides whether an append succeeds and serves all reads;
+// each sink is written by its own goroutine from its own buffer, so a slow or failing sink
+// neither blocks appends nor affects the other sinks. While a sink fails, its records are
+// kept (up to the buffer size, dropping the oldest) and retried.
+type MultiplexJournalRepository struct {
+	repository.JournalRepository
+	sinks []*bufferedJournalSink
+}
+
+// NewMultiplexJournalRepository copies the records appended to primary to sinks, buffering up
+// to bufferSize records per sink (DefaultJournalSinkBuffer when <= 0)
+// Close must be called to deliver the records still buffered.
+func NewMultiplexJournalRepository(primary repository.JournalRepository, sinks []JournalSink, bufferSize int) *MultiplexJournalRepository {
+	if bufferSize <= 0 {
+		bufferSize = DefaultJournalSinkBuffer
+	}
+	r := &MultiplexJournalRepository{JournalRepository: primary}
+	for _, sink := range sinks {
+		buffered := newBufferedJournalSink(sink, bufferSize, journalSinkRetryInterval)
+		go buffered.run()
+		r.sinks = append(r.sinks, buffered)
+	}
+	return r
+}
+
+// Append appends a record to the primary journal, then queues it for every sink
+func (r *MultiplexJournalRepository) Append(ctx context.Context, record *repository.JournalRecord) error {
+	// Sinks receive the timestamp the primary journal records
+	stamped := *record
+	if stamped.Timestamp == "" {
+		stamped.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	if err := r.JournalRepository.Append(ctx, &stamped); err != nil {
+		return err
+	}
+	for _, sink := range r.sinks {
+		sink.enqueue(&stamped)
+	}
+	return nil
+}
+
+// Close delivers the buffered records, waiting at most timeout for the sinks, and stops them
+// Records a sink could not deliver are reported on stderr.
+func (r *MultiplexJournalRepository) Close(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, sink := range r.sinks {
+		wg.Add(1)
+		go func(sink *bufferedJournalSink) {
+			defer wg.Done()
+			sink.close(ctx)
+		}(sink)
+	}
+	wg.Wait()
+}
+
+// bufferedJournalSink feeds a sink from a bounded buffer on its own goroutine
+type bufferedJournalSink struct {
+	sink          JournalSink
+	size          int
+	retryInterval time.Duration
+	warn          func(format string, args ...interface{})
+
+	mu       sync.Mutex
+	pending  []*repository.JournalRecord
+	dropped  int
+	failing  bool
+	reported sync.Once
+
+	wake chan struct{}
+	stop chan context.Context
+	done chan struct{}
+}
+
+func newBufferedJournalSink(sink JournalSink, size int, retryInterval time.Duration) *bufferedJournalSink {
+	return &bufferedJournalSink{
+		sink:          sink,
+		size:          size,
+		retryInterval: retryInterval,
+		warn: func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: "+format+"\n", args...)
+		},
+		wake: make(chan struct{}, 1),
+		stop: make(chan context.Context),
+		done: make(chan struct{}),
+	}
+}
+
+// enqueue buffers a record, dropping the oldest when the buffer is full
+func (s *bufferedJournalSink) enqueue(record *repository.JournalRecord) {
+	s.mu.Lock()
+	s.pending = append(s.pending, record)
+	if over := len(s.pending) - s.size; over > 0 {
+		s.pending = s.pending[over:]
+		s.dropped += over
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers buffered records until close is called
+func (s *bufferedJournalSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.wake:
+			// A failing sink is only retried on the ticker
+			if !s.isFailing() {
+				s.flush(context.Background())
+			}
+		case <-ticker.C:
+			s.flush(context.Background())
+		case ctx := <-s.stop:
+			s.flush(ctx)
+			s.reportUndelivered()
+			return
+		}
+	}
+}
+
+// flush writes the buffered records as one batch, keeping them when the write fails
+func (s *bufferedJournalSink) flush(ctx context.Context) {
+	s.mu.Lock()
+	batch := append([]*repository.JournalRecord(nil), s.pending...)
+	droppedBefore := s.dropped
+	s.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+
+	err := s.sink.Write(ctx, batch)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		// Warn once per outage rather than on every retry
+		if !s.failing {
+			s.warn("journal sink %s failed, buffering records: %v", s.sink.Name(), err)
+		}
+		s.failing = true
+		return
+	}
+	if s.failing {
+		s.warn("journal sink %s recovered", s.sink.Name())
+		s.failing = false
+	}
+	// Records dropped from a full buffer while the batch was written were its oldest
+	// ones, and were delivered after all
+	droppedDuring := s.dropped - droppedBefore
+	if droppedDuring > len(batch) {
+		droppedDuring = len(batch)
+	}
+	s.dropped -= droppedDuring
+	s.pending = s.pending[len(batch)-droppedDuring:]
+}
+
+// isFailing reports whether the last write to the sink failed
+func (s *bufferedJournalSink) isFailing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failing
+}
+
+// reportUndelivered warns, once, about the records the sink never received
+func (s *bufferedJournalSink) reportUndelivered() {
+	s.reported.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if len(s.pending) > 0 || s.dropped > 0 {
+			s.warn("journal sink %s did not receive %d record(s) (%d dropped from a full buffer)",
+				s.sink.Name(), len(s.pending)+s.dropped, s.dropped)
+		}
+	})
+}
+
+// close makes a last delivery attempt and stops the goroutine, giving up when ctx is done
+func (s *bufferedJournalSink) close(ctx context.Context) {
+	select {
+	case s.stop <- ctx:
+		select {
+		case <-s.done:
+			return
+		case <-ctx.Done():
+		}
+	case <-s.done:
+		return
+	case <-ctx.Done():
+	}
+	s.reportUndelivered()
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// recordingSink records the SBI IDs it receives and fails while failing is set
+type recordingSink struct {
+	name string
+
+	mu       sync.Mutex
+	received []string
+	failing  bool
+	block    chan struct{} // When set, writes wait for it to close
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Write(ctx context.Context, records []*repository.JournalRecord) error {
+	if s.block != nil {
+		select {
+		case <-s.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing {
+		return errors.New("collector unavailable")
+	}
+	for _, record := range records {
+		s.received = append(s.received, record.SBIID)
+	}
+	return nil
+}
+
+func (s *recordingSink) setFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+func (s *recordingSink) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMultiplexJournalRepository_IsolatesSinks(t *testing.T) {
+	ctx := context.Background()
+	primary := NewJournalRepositoryImpl(filepath.Join(t.TempDir(), "journal.ndjson"))
+	healthy := &recordingSink{name: "healthy"}
+	failing := &recordingSink{name: "failing", failing: true}
+	stuck := &recordingSink{name: "stuck", block: make(chan struct{})}
+	defer close(stuck.block)
+
+	repo := NewMultiplexJournalRepository(primary, []JournalSink{healthy, failing, stuck}, 10)
+	for i := 1; i <= 3; i++ {
+		if err := repo.Append(ctx, &repository.JournalRecord{SBIID: fmt.Sprintf("sbi-%d", i), Turn: i, Step: "implement"}); err != nil {
+			t.Fatalf("Append() error = %v, want the primary journal to decide", err)
+		}
+	}
+
+	// The healthy sink is not held up by the failing and stuck ones
+	waitFor(t, "the healthy sink", func() bool { return len(healthy.ids()) == 3 })
+
+	records, err := repo.Load(ctx)
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Load() = %d records, %v; reads come from the primary journal", len(records), err)
+	}
+	if records[0].Timestamp == "" {
+		t.Error("records are stamped before they are copied")
+	}
+
+	// The failing sink buffers its records and receives them once it recovers
+	failing.setFailing(false)
+	repo.sinks[1].flush(ctx)
+	if got := failing.ids(); strings.Join(got, ",") != "sbi-1,sbi-2,sbi-3" {
+		t.Errorf("recovered sink received %v, want every buffered record in order", got)
+	}
+}
+
+func TestBufferedJournalSink_DropsOldestWhenFull(t *testing.T) {
+	sink := &recordingSink{name: "collector", failing: true}
+	buffered := newBufferedJournalSink(sink, 2, time.Hour)
+	var warnings []string
+	buffered.warn = func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	for i := 1; i <= 3; i++ {
+		buffered.enqueue(&repository.JournalRecord{SBIID: fmt.Sprintf("sbi-%d", i)})
+		buffered.flush(context.Background())
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one per outage", warnings)
+	}
+
+	sink.setFailing(false)
+	buffered.flush(context.Background())
+	if got := sink.ids(); strings.Join(got, ",") != "sbi-2,sbi-3" {
+		t.Errorf("received %v, want the newest records of a full buffer", got)
+	}
+
+	buffered.reportUndelivered()
+	if last := warnings[len(warnings)-1]; !strings.Contains(last, "did not receive 1 record(s) (1 dropped") {
+		t.Errorf("last warning = %q, want the dropped record reported", last)
+	}
+}
+
+func TestMultiplexJournalRepository_CloseDeliversBufferedRecords(t *testing.T) {
+	ctx := context.Background()
+	primary := NewJournalRepositoryImpl(filepath.Join(t.TempDir(), "journal.ndjson"))
+	sink := &recordingSink{name: "collector", failing: true}
+	repo := NewMultiplexJournalRepository(primary, []JournalSink{sink}, 10)
+
+	if err := repo.Append(ctx, &repository.JournalRecord{SBIID: "sbi-1", Turn: 1, Step: "review"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the first delivery attempt", repo.sinks[0].isFailing)
+
+	sink.setFailing(false)
+	repo.Close(time.Second)
+	if got := sink.ids(); len(got) != 1 {
+		t.Errorf("received %v after Close, want the buffered record", got)
+	}
+}
+
+func TestHTTPJournalSink(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []map[string]interface{}
+		auth   string
+		status = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		auth = r.Header.Get("Authorization")
+		if r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var entry map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				t.Errorf("invalid NDJSON line %q: %v", scanner.Text(), err)
+			}
+			bodies = append(bodies, entry)
+		}
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPJournalSink(server.URL, map[string]string{"Authorization": "Bearer token"}, time.Second)
+	records := []*repository.JournalRecord{
+		{Timestamp: "2026-10-16T00:00:00Z", SBIID: "sbi-1", Turn: 1, Step: "implement"},
+		{Timestamp: "2026-10-16T00:01:00Z", SBIID: "sbi-1", Turn: 1, Step: "review", Error: "timeout", ErrorClass: "agent_timeout"},
+	}
+	if err := sink.Write(context.Background(), records); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if auth != "Bearer token" || len(bodies) != 2 {
+		t.Fatalf("collector got auth %q and %d records", auth, len(bodies))
+	}
+	if bodies[1]["step"] != "review" || bodies[1]["error_class"] != "agent_timeout" {
+		t.Errorf("record = %v, want the journal's NDJSON form", bodies[1])
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := sink.Write(context.Background(), records); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Write() error = %v, want the collector's status", err)
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,8 +15,10 @@ import (
 )
 
 // JournalRepositoryImpl implements repository.JournalRepository using NDJSON file-based storage
+// Records rotated out of the active journal are read back from its segments (see journal_rotation.go)
 type JournalRepositoryImpl struct {
 	journalPath string
+	rotation    JournalRotationPolicy // Optional: rotate the active journal after appends
 }
 
 // NewJournalRepositoryImpl creates a new NDJSON-based journal repository
@@ -25,8 +28,9 @@ func NewJournalRepositoryImpl(journalPath string) *JournalRepositoryImpl {
 	}
 }
 
-// Append adds a new record to the journal using NDJSON format with file locking
-func (r *JournalRepositoryImpl) Append(ctx context.Context, record *repository.JournalRecord) error {
+// JournalEntry returns the NDJSON form of a record, as written to the journal and its sinks
+// A record without a timestamp is stamped with the current time.
+func JournalEntry(record *repository.JournalRecord) map[string]interface{} {
 	entry := map[string]interface{}{
 		"timestamp":  record.Timestamp,
 		"sbi_id":     record.SBIID,
@@ -40,6 +44,15 @@ func (r *JournalRepositoryImpl) Append(ctx context.Context, record *repository.J
 		"artifacts":  record.Artifacts,
 	}
 
+	// The error class is only journaled with an error, keeping error-free records unchanged
+	if record.ErrorClass != "" {
+		entry["error_class"] = record.ErrorClass
+	}
+	// Likewise the sequence of a step's write is only journaled after the first write
+	if record.Seq > 0 {
+		entry["seq"] = record.Seq
+	}
+
 	// Normalize timestamps
 	if entry["timestamp"] == "" {
 		entry["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
@@ -49,24 +62,62 @@ func (r *JournalRepositoryImpl) Append(ctx context.Context, record *repository.J
 	if entry["artifacts"] == nil {
 		entry["artifacts"] = []interface{}{}
 	}
+	return entry
+}
+
+// Append adds a new record to the journal using NDJSON format with file locking
+func (r *JournalRepositoryImpl) Append(ctx context.Context, record *repository.JournalRecord) error {
+	entry := JournalEntry(record)
 
 	// Use NDJSON append with file locking
-	if err := fs.AppendNDJSONLine(r.journalPath, entry); err != nil {
+	// Records with an idempotency key are skipped when the key is already journaled
+	var exists func(line []byte) bool
+	if key := record.IdempotencyKey(); key != "" {
+		exists = func(line []byte) bool {
+			// Cheap pre-filter before decoding the line
+			if !bytes.Contains(line, []byte(record.SBIID)) {
+				return false
+			}
+			var existing map[string]interface{}
+			if err := json.Unmarshal(line, &existing); err != nil {
+				return false
+			}
+			return r.mapToRecord(existing).IdempotencyKey() == key
+		}
+	}
+
+	if _, err := fs.AppendNDJSONLineIfAbsent(r.journalPath, entry, exists); err != nil {
 		return fmt.Errorf("failed to append journal entry: %w", err)
 	}
 
+	// The entry is already journaled; a failed rotation is retried on the next append
+	if r.rotation.Enabled() {
+		if _, err := r.Rotate(ctx, false); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to rotate journal: %v\n", err)
+		}
+	}
+
 	return nil
 }
 
-// Load retrieves all journal records from NDJSON file
+// Load retrieves all journal records, oldest rotated segment first, then the active journal
 func (r *JournalRepositoryImpl) Load(ctx context.Context) ([]*repository.JournalRecord, error) {
+	index, err := r.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+	return r.loadWithSegments(index.Segments)
+}
+
+// loadFile retrieves the journal records of one NDJSON file
+func (r *JournalRepositoryImpl) loadFile(path string) ([]*repository.JournalRecord, error) {
 	// Check if file exists
-	if _, err := os.Stat(r.journalPath); os.IsNotExist(err) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return []*repository.JournalRecord{}, nil
 	}
 
 	// Open file for reading
-	file, err := os.Open(r.journalPath)
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open journal file: %w", err)
 	}
@@ -124,8 +175,20 @@ func (r *JournalRepositoryImpl) FindByTurn(ctx context.Context, turn int) ([]*re
 }
 
 // FindBySBI retrieves records for a specific SBI
+// Only the rotated segments the index lists for the SBI are read
 func (r *JournalRepositoryImpl) FindBySBI(ctx context.Context, sbiID string) ([]*repository.JournalRecord, error) {
-	all, err := r.Load(ctx)
+	index, err := r.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+	var segments []JournalSegment
+	for _, segment := range index.Segments {
+		if segment.HasSBI(sbiID) {
+			segments = append(segments, segment)
+		}
+	}
+
+	all, err := r.loadWithSegments(segments)
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +252,16 @@ func (r *JournalRepositoryImpl) mapToRecord(entry map[string]interface{}) *repos
 		record.Error = errMsg
 	}
 
+	if errorClass, ok := entry["error_class"].(string); ok {
+		record.ErrorClass = errorClass
+	}
+
+	if seq, ok := entry["seq"].(float64); ok {
+		record.Seq = int(seq)
+	} else if seq, ok := entry["seq"].(int); ok {
+		record.Seq = seq
+	}
+
 	if artifacts, ok := entry["artifacts"].([]interface{}); ok {
 		record.Artifacts = artifacts
 	}
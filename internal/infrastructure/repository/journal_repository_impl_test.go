@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
@@ -790,3 +792,138 @@ func TestJournalRepositoryImpl_AppendToExistingFile(t *testing.T) {
 		t.Errorf("Expected second record Turn 2, got %d", records[1].Turn)
 	}
 }
+
+func TestJournalRepositoryImpl_AppendIdempotent(t *testing.T) {
+	tmpDir := t.TempDir()
+	journalPath := filepath.Join(tmpDir, "journal.ndjson")
+	repo := NewJournalRepositoryImpl(journalPath)
+	ctx := context.Background()
+
+	record := &repository.JournalRecord{
+		Timestamp: "2025-01-01T00:00:01Z",
+		SBIID:     "test-sbi-001",
+		Turn:      2,
+		Step:      "review",
+		Decision:  "SUCCEEDED",
+	}
+
+	// Replaying the same record (e.g. a retry after a partial failure) is a no-op,
+	// even when the retry was stamped again
+	for i := 0; i < 3; i++ {
+		replay := *record
+		replay.Timestamp = fmt.Sprintf("2025-01-01T00:00:0%dZ", i+1)
+		if err := repo.Append(ctx, &replay); err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+
+	// A different step, turn or SBI is a new entry
+	for _, other := range []repository.JournalRecord{
+		{Timestamp: "2025-01-01T00:00:01Z", SBIID: "test-sbi-001", Turn: 2, Step: "implement"},
+		{Timestamp: "2025-01-01T00:00:01Z", SBIID: "test-sbi-001", Turn: 3, Step: "review"},
+		{Timestamp: "2025-01-01T00:00:01Z", SBIID: "test-sbi-002", Turn: 2, Step: "review"},
+	} {
+		other := other
+		if err := repo.Append(ctx, &other); err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+
+	// Records without a key (no SBI ID) are never deduplicated
+	for i := 0; i < 2; i++ {
+		if err := repo.Append(ctx, &repository.JournalRecord{Step: "legacy"}); err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+
+	records, err := repo.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("Expected 6 records, got %d", len(records))
+	}
+	if duplicates := repository.FindJournalDuplicates(records); len(duplicates) != 0 {
+		t.Errorf("Expected no duplicates, got %v", duplicates)
+	}
+}
+
+func TestJournalRepositoryImpl_AppendKeepsNumberedRunInSameTurn(t *testing.T) {
+	tmpDir := t.TempDir()
+	journalPath := filepath.Join(tmpDir, "journal.ndjson")
+	repo := NewJournalRepositoryImpl(journalPath)
+	ctx := context.Background()
+
+	// The implement run of turn 1 moves the SBI to REVIEWING, so its record carries the review step
+	implement := &repository.JournalRecord{Timestamp: "2025-01-01T00:00:01Z", SBIID: "test-sbi-001", Turn: 1, Step: "review", Status: "REVIEW"}
+	if err := repo.Append(ctx, implement); err != nil {
+		t.Fatalf("Failed to append record: %v", err)
+	}
+
+	// The review agent never reports: the SBI stays REVIEWING and the review is re-run in turn 1
+	prior, err := repo.FindBySBI(ctx, "test-sbi-001")
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	review := &repository.JournalRecord{Timestamp: "2025-01-01T00:05:00Z", SBIID: "test-sbi-001", Turn: 1, Step: "review", Status: "REVIEW"}
+	review.Seq = repository.NextJournalSeq(prior, review.SBIID, review.Turn, review.Step)
+	if review.Seq != 1 {
+		t.Fatalf("Expected the re-run to be numbered 1, got %d", review.Seq)
+	}
+
+	// Retrying the re-run's write keeps its number and is skipped
+	retry := *review
+	retry.Timestamp = "2025-01-01T00:05:01Z"
+	for _, record := range []*repository.JournalRecord{review, &retry} {
+		if err := repo.Append(ctx, record); err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+
+	records, err := repo.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected every run to be journaled once, got %d records", len(records))
+	}
+	if records[1].Seq != 1 || records[1].IdempotencyKey() != "test-sbi-001/1/review#1" {
+		t.Errorf("Expected the re-run to be loaded with its number, got %+v", records[1])
+	}
+}
+
+func TestJournalRepositoryImpl_ErrorClass(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "journal.ndjson")
+	repo := NewJournalRepositoryImpl(journalPath)
+	ctx := context.Background()
+
+	records := []*repository.JournalRecord{
+		{Timestamp: "2025-01-01T00:00:00Z", SBIID: "test-sbi-001", Turn: 1, Step: "implement", Error: "exit status 1", ErrorClass: "agent_crash"},
+		{Timestamp: "2025-01-01T00:01:00Z", SBIID: "test-sbi-001", Turn: 1, Step: "review"},
+	}
+	for _, record := range records {
+		if err := repo.Append(ctx, record); err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+
+	// Records without an error are journaled without the field
+	content, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("Failed to read journal file: %v", err)
+	}
+	if n := strings.Count(string(content), `"error_class"`); n != 1 {
+		t.Errorf("Expected error_class in 1 line, found %d", n)
+	}
+
+	loaded, err := repo.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(loaded))
+	}
+	if loaded[0].ErrorClass != "agent_crash" || loaded[1].ErrorClass != "" {
+		t.Errorf("Expected error classes [agent_crash, \"\"], got [%q, %q]", loaded[0].ErrorClass, loaded[1].ErrorClass)
+	}
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+// JournalRotationPolicy controls when the active journal is rotated into a segment
+type JournalRotationPolicy struct {
+	MaxBytes     int64         // Rotate once the active journal reaches this size (0 = no size limit)
+	MaxAge       time.Duration // Rotate once the oldest active entry is this old (0 = no age limit)
+	KeepSegments int           // Newest segments kept in full; older ones are compacted (0 = never compact)
+}
+
+// Enabled reports whether the policy ever rotates the journal
+func (p JournalRotationPolicy) Enabled() bool {
+	return p.MaxBytes > 0 || p.MaxAge > 0
+}
+
+// JournalSegment describes one rotated part of the journal
+type JournalSegment struct {
+	File           string   `json:"file"` // File name in the segments directory
+	FirstTimestamp string   `json:"first_timestamp"`
+	LastTimestamp  string   `json:"last_timestamp"`
+	Entries        int      `json:"entries"`
+	Compacted      bool     `json:"compacted"` // Only the final record of each SBI is kept
+	SBIIDs         []string `json:"sbi_ids"`   // Sorted SBI IDs with records in the segment
+}
+
+// HasSBI reports whether the segment holds records of the SBI
+func (s JournalSegment) HasSBI(sbiID string) bool {
+	i := sort.SearchStrings(s.SBIIDs, sbiID)
+	return i < len(s.SBIIDs) && s.SBIIDs[i] == sbiID
+}
+
+// JournalIndex lists the rotated segments of a journal, oldest first
+type JournalIndex struct {
+	Segments []JournalSegment `json:"segments"`
+}
+
+// SetRotationPolicy enables rotating the active journal after appends
+func (r *JournalRepositoryImpl) SetRotationPolicy(policy JournalRotationPolicy) {
+	r.rotation = policy
+}
+
+// SegmentsDir returns the directory holding the rotated segments and their index
+// (journal.ndjson -> journal.segments)
+func (r *JournalRepositoryImpl) SegmentsDir() string {
+	return strings.TrimSuffix(r.journalPath, filepath.Ext(r.journalPath)) + ".segments"
+}
+
+// indexPath returns the path of the segment index
+func (r *JournalRepositoryImpl) indexPath() string {
+	return filepath.Join(r.SegmentsDir(), "index.json")
+}
+
+// LoadIndex loads the segment index; a journal that was never rotated has no segments
+func (r *JournalRepositoryImpl) LoadIndex() (*JournalIndex, error) {
+	data, err := os.ReadFile(r.indexPath())
+	if os.IsNotExist(err) {
+		return &JournalIndex{Segments: []JournalSegment{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal index: %w", err)
+	}
+
+	var index JournalIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse journal index: %w", err)
+	}
+	return &index, nil
+}
+
+// Rotate moves the active journal into a new segment when the policy calls for it, or always when force is set
+// The active journal is truncated in place under the append lock, so appends running
+// concurrently either land in the segment or in the emptied journal, never in between.
+// Returns the new segment, or nil when nothing was rotated.
+func (r *JournalRepositoryImpl) Rotate(ctx context.Context, force bool) (*JournalSegment, error) {
+	// Cheap check without the lock, since every append asks
+	if !force && !r.rotationDue() {
+		return nil, nil
+	}
+
+	var segment *JournalSegment
+	err := fs.WithExclusiveLock(r.journalPath, func(f *os.File) error {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return fmt.Errorf("failed to read journal: %w", err)
+		}
+		lines := splitLines(data)
+		if len(lines) == 0 || (!force && !r.shouldRotate(int64(len(data)), lines[0])) {
+			return nil
+		}
+
+		rotated := describeSegment(lines)
+		rotated.File = fmt.Sprintf("journal-%s.ndjson", time.Now().UTC().Format("20060102T150405.000000000Z"))
+
+		index, err := r.LoadIndex()
+		if err != nil {
+			return err
+		}
+		// The segment and index are written before truncating: an interruption leaves the
+		// records in both places, and loading skips the copies left in the active journal
+		if err := fs.WriteFileSync(filepath.Join(r.SegmentsDir(), rotated.File), data, 0644); err != nil {
+			return fmt.Errorf("failed to write journal segment: %w", err)
+		}
+		index.Segments = append(index.Segments, rotated)
+		if err := fs.AtomicWriteJSON(r.indexPath(), index); err != nil {
+			return fmt.Errorf("failed to write journal index: %w", err)
+		}
+
+		if err := f.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate journal: %w", err)
+		}
+		if err := fs.FsyncFile(f); err != nil {
+			return fmt.Errorf("failed to sync journal: %w", err)
+		}
+		segment = &rotated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if segment != nil && r.rotation.KeepSegments > 0 {
+		if _, err := r.Compact(ctx, r.rotation.KeepSegments); err != nil {
+			return segment, fmt.Errorf("rotated journal, but failed to compact: %w", err)
+		}
+	}
+	return segment, nil
+}
+
+// Compact reduces every segment except the newest keep to the final record of each SBI
+// A summary record keeps the final record's fields and adds compacted_entries (records it
+// replaces) and total_elapsed_ms. Returns the number of segments compacted.
+func (r *JournalRepositoryImpl) Compact(ctx context.Context, keep int) (int, error) {
+	if keep < 0 {
+		keep = 0
+	}
+
+	compacted := 0
+	err := fs.WithExclusiveLock(r.journalPath, func(_ *os.File) error {
+		index, err := r.LoadIndex()
+		if err != nil {
+			return err
+		}
+
+		var full []int
+		for i, segment := range index.Segments {
+			if !segment.Compacted {
+				full = append(full, i)
+			}
+		}
+		if len(full) <= keep {
+			return nil
+		}
+
+		for _, i := range full[:len(full)-keep] {
+			segment := &index.Segments[i]
+			path := filepath.Join(r.SegmentsDir(), segment.File)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read journal segment %s: %w", segment.File, err)
+			}
+
+			summaries := compactLines(splitLines(data))
+			var out bytes.Buffer
+			for _, line := range summaries {
+				out.Write(line)
+				out.WriteByte('\n')
+			}
+			if err := fs.WriteFileSync(path, out.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write journal segment %s: %w", segment.File, err)
+			}
+			segment.Entries = len(summaries)
+			segment.Compacted = true
+			compacted++
+
+			// Keep the index in step with each rewritten segment
+			if err := fs.AtomicWriteJSON(r.indexPath(), index); err != nil {
+				return fmt.Errorf("failed to write journal index: %w", err)
+			}
+		}
+		return nil
+	})
+	return compacted, err
+}
+
+// loadWithSegments loads the given segments followed by the active journal
+// Records of the active journal already present in a segment are left over from an
+// interrupted rotation and are skipped
+func (r *JournalRepositoryImpl) loadWithSegments(segments []JournalSegment) ([]*repository.JournalRecord, error) {
+	records := []*repository.JournalRecord{}
+	rotated := make(map[string]bool)
+	for _, segment := range segments {
+		segmentRecords, err := r.loadFile(filepath.Join(r.SegmentsDir(), segment.File))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range segmentRecords {
+			if key := record.IdempotencyKey(); key != "" {
+				rotated[key] = true
+			}
+		}
+		records = append(records, segmentRecords...)
+	}
+
+	active, err := r.loadFile(r.journalPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range active {
+		if key := record.IdempotencyKey(); key != "" && rotated[key] {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// rotationDue checks the rotation policy against the active journal's size and first line
+func (r *JournalRepositoryImpl) rotationDue() bool {
+	f, err := os.Open(r.journalPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	firstLine, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return false
+	}
+	return r.shouldRotate(info.Size(), firstLine)
+}
+
+// shouldRotate checks the rotation policy against the active journal's size and oldest entry
+func (r *JournalRepositoryImpl) shouldRotate(size int64, firstLine []byte) bool {
+	if r.rotation.MaxBytes > 0 && size >= r.rotation.MaxBytes {
+		return true
+	}
+	if r.rotation.MaxAge > 0 {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(firstLine, &entry); err == nil {
+			if ts, err := time.Parse(time.RFC3339Nano, r.mapToRecord(entry).Timestamp); err == nil {
+				return time.Since(ts) >= r.rotation.MaxAge
+			}
+		}
+	}
+	return false
+}
+
+// splitLines returns the non-empty lines of NDJSON data
+func splitLines(data []byte) [][]byte {
+	var lines [][]byte
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// describeSegment builds the index entry of a segment from its lines
+func describeSegment(lines [][]byte) JournalSegment {
+	segment := JournalSegment{Entries: len(lines), SBIIDs: []string{}}
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		if ts, ok := entry["timestamp"].(string); ok && ts != "" {
+			if segment.FirstTimestamp == "" {
+				segment.FirstTimestamp = ts
+			}
+			segment.LastTimestamp = ts
+		}
+		if sbiID, ok := entry["sbi_id"].(string); ok && sbiID != "" && !seen[sbiID] {
+			seen[sbiID] = true
+			segment.SBIIDs = append(segment.SBIIDs, sbiID)
+		}
+	}
+	sort.Strings(segment.SBIIDs)
+	return segment
+}
+
+// compactLines keeps the final record of each SBI, in the order those records were journaled
+// Records without an SBI ID and corrupted lines are dropped
+func compactLines(lines [][]byte) [][]byte {
+	type summary struct {
+		entry   map[string]interface{}
+		entries int
+		elapsed float64
+		last    int
+	}
+	summaries := make(map[string]*summary)
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		sbiID, _ := entry["sbi_id"].(string)
+		if sbiID == "" {
+			continue
+		}
+		s, ok := summaries[sbiID]
+		if !ok {
+			s = &summary{}
+			summaries[sbiID] = s
+		}
+		s.entries++
+		if elapsed, ok := entry["elapsed_ms"].(float64); ok {
+			s.elapsed += elapsed
+		}
+		s.entry = entry
+		s.last = i
+	}
+
+	ordered := make([]*summary, 0, len(summaries))
+	for _, s := range summaries {
+		ordered = append(ordered, s)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].last < ordered[j].last })
+
+	result := make([][]byte, 0, len(ordered))
+	for _, s := range ordered {
+		s.entry["compacted_entries"] = s.entries
+		s.entry["total_elapsed_ms"] = int64(s.elapsed)
+		line, err := json.Marshal(s.entry)
+		if err != nil {
+			continue
+		}
+		result = append(result, line)
+	}
+	return result
+}
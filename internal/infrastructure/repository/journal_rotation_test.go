@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func newRotationRecord(sbiID string, turn int) *repository.JournalRecord {
+	return &repository.JournalRecord{
+		Timestamp: time.Date(2025, 1, 1, 0, 0, turn, 0, time.UTC).Format(time.RFC3339Nano),
+		SBIID:     sbiID,
+		Turn:      turn,
+		Step:      "implement",
+		Status:    "WIP",
+		ElapsedMs: 100,
+		Artifacts: []interface{}{},
+	}
+}
+
+func TestJournalRotation_SizeBased(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "journal.ndjson")
+	repo := NewJournalRepositoryImpl(journalPath)
+	repo.SetRotationPolicy(JournalRotationPolicy{MaxBytes: 400})
+	ctx := context.Background()
+
+	for turn := 1; turn <= 10; turn++ {
+		sbiID := "sbi-a"
+		if turn > 5 {
+			sbiID = "sbi-b"
+		}
+		if err := repo.Append(ctx, newRotationRecord(sbiID, turn)); err != nil {
+			t.Fatalf("Failed to append record: %v", err)
+		}
+	}
+
+	index, err := repo.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+	if len(index.Segments) == 0 {
+		t.Fatal("Expected the journal to be rotated")
+	}
+	if info, err := os.Stat(journalPath); err != nil || info.Size() >= 400 {
+		t.Errorf("Active journal should be below the rotation size")
+	}
+
+	// Records are read back across segments in order
+	records, err := repo.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	if len(records) != 10 {
+		t.Fatalf("Expected 10 records, got %d", len(records))
+	}
+	for i, record := range records {
+		if record.Turn != i+1 {
+			t.Errorf("Record %d: expected turn %d, got %d", i, i+1, record.Turn)
+		}
+	}
+
+	sbiRecords, err := repo.FindBySBI(ctx, "sbi-a")
+	if err != nil {
+		t.Fatalf("Failed to find records: %v", err)
+	}
+	if len(sbiRecords) != 5 {
+		t.Errorf("Expected 5 records of sbi-a, got %d", len(sbiRecords))
+	}
+}
+
+func TestJournalRotation_Compact(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "journal.ndjson")
+	repo := NewJournalRepositoryImpl(journalPath)
+	ctx := context.Background()
+
+	turn := 0
+	for segment := 0; segment < 3; segment++ {
+		for i := 0; i < 3; i++ {
+			turn++
+			if err := repo.Append(ctx, newRotationRecord("sbi-a", turn)); err != nil {
+				t.Fatalf("Failed to append record: %v", err)
+			}
+		}
+		if _, err := repo.Rotate(ctx, true); err != nil {
+			t.Fatalf("Failed to rotate: %v", err)
+		}
+	}
+
+	compacted, err := repo.Compact(ctx, 1)
+	if err != nil {
+		t.Fatalf("Failed to compact: %v", err)
+	}
+	if compacted != 2 {
+		t.Errorf("Expected 2 compacted segments, got %d", compacted)
+	}
+
+	index, _ := repo.LoadIndex()
+	if !index.Segments[0].Compacted || !index.Segments[1].Compacted || index.Segments[2].Compacted {
+		t.Errorf("Only the newest segment should be kept in full: %+v", index.Segments)
+	}
+
+	// Each compacted segment keeps the final record of the SBI: turns 3 and 6, then 7-9 in full
+	records, err := repo.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	var turns []int
+	for _, record := range records {
+		turns = append(turns, record.Turn)
+	}
+	if fmt.Sprint(turns) != "[3 6 7 8 9]" {
+		t.Errorf("Unexpected turns after compaction: %v", turns)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(repo.SegmentsDir(), index.Segments[0].File))
+	if want := `"compacted_entries":3`; !strings.Contains(string(data), want) {
+		t.Errorf("Summary should record the compacted entries: %s", data)
+	}
+}
+
+func TestJournalRotation_ConcurrentAppends(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "journal.ndjson")
+	ctx := context.Background()
+
+	// Every append may rotate; no record may be lost or duplicated
+	numGoroutines := 20
+	perGoroutine := 10
+	var wg sync.WaitGroup
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			repo := NewJournalRepositoryImpl(journalPath)
+			repo.SetRotationPolicy(JournalRotationPolicy{MaxBytes: 1000})
+			for i := 0; i < perGoroutine; i++ {
+				if err := repo.Append(ctx, newRotationRecord(fmt.Sprintf("sbi-%d", g), i+1)); err != nil {
+					t.Errorf("Append failed: %v", err)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	records, err := NewJournalRepositoryImpl(journalPath).Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load records: %v", err)
+	}
+	if len(records) != numGoroutines*perGoroutine {
+		t.Errorf("Expected %d records, got %d", numGoroutines*perGoroutine, len(records))
+	}
+	seen := make(map[string]bool)
+	for _, record := range records {
+		key := record.IdempotencyKey()
+		if seen[key] {
+			t.Errorf("Duplicate record %s", key)
+		}
+		seen[key] = true
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// encodeJournalRecords encodes records as NDJSON in the journal's own format
+func encodeJournalRecords(records []*repository.JournalRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(JournalEntry(record)); err != nil {
+			return nil, fmt.Errorf("encode journal record: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// WriterJournalSink writes records as JSON lines to a stream (e.g. stdout for log collectors)
+type WriterJournalSink struct {
+	name string
+	w    io.Writer
+}
+
+// NewStdoutJournalSink creates a sink writing JSON lines to stdout
+func NewStdoutJournalSink() *WriterJournalSink {
+	return &WriterJournalSink{name: "stdout", w: os.Stdout}
+}
+
+// Name identifies the sink
+func (s *WriterJournalSink) Name() string {
+	return s.name
+}
+
+// Write writes the records as JSON lines
+func (s *WriterJournalSink) Write(ctx context.Context, records []*repository.JournalRecord) error {
+	data, err := encodeJournalRecords(records)
+	if err != nil {
+		return err
+	}
+	_, err = s.w.Write(data)
+	return err
+}
+
+// FileJournalSink appends records to an NDJSON file besides the journal (e.g. a shared log volume)
+// Unlike the journal it is never rotated or deduplicated.
+type FileJournalSink struct {
+	path string
+}
+
+// NewFileJournalSink creates a sink appending to the NDJSON file at path
+func NewFileJournalSink(path string) *FileJournalSink {
+	return &FileJournalSink{path: path}
+}
+
+// Name identifies the sink
+func (s *FileJournalSink) Name() string {
+	return "file " + s.path
+}
+
+// Write appends the records to the file
+func (s *FileJournalSink) Write(ctx context.Context, records []*repository.JournalRecord) error {
+	data, err := encodeJournalRecords(records)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", s.path, err)
+	}
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("write %s: %w", s.path, err)
+	}
+	return file.Close()
+}
+
+// HTTPJournalSink posts records to a remote collector as an NDJSON body
+// (Content-Type: application/x-ndjson); any non-2xx response is a failed delivery.
+type HTTPJournalSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewHTTPJournalSink creates a sink posting to url with extra headers (e.g. Authorization)
+// A timeout <= 0 defaults to 10 seconds.
+func NewHTTPJournalSink(url string, headers map[string]string, timeout time.Duration) *HTTPJournalSink {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &HTTPJournalSink{url: url, headers: headers, client: &http.Client{Timeout: timeout}}
+}
+
+// Name identifies the sink
+func (s *HTTPJournalSink) Name() string {
+	return "http " + s.url
+}
+
+// Write posts the records in one request
+func (s *HTTPJournalSink) Write(ctx context.Context, records []*repository.JournalRecord) error {
+	data, err := encodeJournalRecords(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+// PromptArchiveRepositoryImpl implements PromptArchiveRepository for file-based storage
+// Each entry is a JSON file, gzip-compressed when compress is set, named after its turn, step
+// and archive time: <dir>/<sbi id>/turn-003-implement-20261016T143000.123Z.json.gz
+type PromptArchiveRepositoryImpl struct {
+	dir      string // .deespec/var/prompts
+	compress bool
+}
+
+// NewPromptArchiveRepositoryImpl creates a prompt archive storing its files in dir
+func NewPromptArchiveRepositoryImpl(dir string, compress bool) repository.PromptArchiveRepository {
+	return &PromptArchiveRepositoryImpl{dir: dir, compress: compress}
+}
+
+// Save writes the entry to its own file
+func (r *PromptArchiveRepositoryImpl) Save(ctx context.Context, entry *repository.PromptArchiveEntry) error {
+	if entry == nil || entry.SBIID == "" {
+		return fmt.Errorf("prompt archive entry requires an SBI ID")
+	}
+	if strings.ContainsAny(entry.SBIID, `/\`) || strings.Contains(entry.SBIID, "..") {
+		return fmt.Errorf("invalid SBI ID for the prompt archive: %q", entry.SBIID)
+	}
+	dir := filepath.Join(r.dir, entry.SBIID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal prompt archive entry: %w", err)
+	}
+	name := fmt.Sprintf("turn-%03d-%s-%s.json", entry.Turn, entry.Step, entry.ArchivedAt.UTC().Format("20060102T150405.000Z"))
+	if r.compress {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(data); err != nil {
+			return fmt.Errorf("failed to compress prompt archive entry: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to compress prompt archive entry: %w", err)
+		}
+		data, name = buf.Bytes(), name+".gz"
+	}
+	if err := fs.WriteFileAtomic(filepath.Join(dir, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write prompt archive entry: %w", err)
+	}
+	return nil
+}
+
+// List reads the entries of an SBI, compressed or not, oldest first
+func (r *PromptArchiveRepositoryImpl) List(ctx context.Context, sbiID string) ([]*repository.PromptArchiveEntry, error) {
+	dir := filepath.Join(r.dir, sbiID)
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt archive: %w", err)
+	}
+
+	var entries []*repository.PromptArchiveEntry
+	for _, file := range files {
+		if file.IsDir() || !isPromptArchiveFile(file.Name()) {
+			continue
+		}
+		entry, err := readPromptArchiveEntry(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entry.File = filepath.Join(dir, file.Name())
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ArchivedAt.Before(entries[j].ArchivedAt)
+	})
+	return entries, nil
+}
+
+// Prune removes the entry files last modified before the cutoff, and the SBI directories left empty
+func (r *PromptArchiveRepositoryImpl) Prune(ctx context.Context, before time.Time) (int, error) {
+	sbiDirs, err := os.ReadDir(r.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read prompt archive: %w", err)
+	}
+
+	removed := 0
+	for _, sbiDir := range sbiDirs {
+		if !sbiDir.IsDir() {
+			continue
+		}
+		dir := filepath.Join(r.dir, sbiDir.Name())
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return removed, fmt.Errorf("failed to read prompt archive: %w", err)
+		}
+		kept := 0
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || !isPromptArchiveFile(file.Name()) || !info.ModTime().Before(before) {
+				kept++
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+				return removed, fmt.Errorf("failed to prune prompt archive: %w", err)
+			}
+			removed++
+		}
+		if kept == 0 {
+			_ = os.Remove(dir)
+		}
+	}
+	return removed, nil
+}
+
+// isPromptArchiveFile reports whether a file name is an archive entry
+func isPromptArchiveFile(name string) bool {
+	return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz")
+}
+
+// readPromptArchiveEntry reads an entry file, decompressing .gz files
+func readPromptArchiveEntry(path string) (*repository.PromptArchiveEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt archive entry: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %w", filepath.Base(path), err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	var entry repository.PromptArchiveEntry
+	if err := json.NewDecoder(reader).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return &entry, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestPromptArchiveRepositoryImpl(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "var", "prompts")
+	archivedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	entry := func(turn int, step string, at time.Time) *repository.PromptArchiveEntry {
+		return &repository.PromptArchiveEntry{
+			SBIID: "SBI-001", Turn: turn, Step: step, AgentType: "claude-code",
+			Prompt: strings.Repeat("Implement the login endpoint. ", 100), Response: "Done.", ArchivedAt: at,
+		}
+	}
+
+	compressed := NewPromptArchiveRepositoryImpl(dir, true)
+	if err := compressed.Save(ctx, entry(1, "implement", archivedAt)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	plain := NewPromptArchiveRepositoryImpl(dir, false)
+	if err := plain.Save(ctx, entry(2, "review", archivedAt.Add(time.Hour))); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := plain.Save(ctx, &repository.PromptArchiveEntry{SBIID: "../escape"}); err == nil {
+		t.Error("Save() should refuse an SBI ID that leaves the archive")
+	}
+
+	entries, err := plain.List(ctx, "SBI-001")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Step != "implement" || entries[1].Step != "review" {
+		t.Fatalf("List() = %v, want the implement then the review entry", entries)
+	}
+	if entries[0].Prompt != entry(1, "implement", archivedAt).Prompt || !strings.HasSuffix(entries[0].File, ".json.gz") {
+		t.Errorf("The compressed entry should read back as written, got file %s", entries[0].File)
+	}
+	if info, _ := os.Stat(entries[0].File); info.Size() >= int64(len(entries[0].Prompt)) {
+		t.Errorf("Compressed entry is %d bytes, want less than the %d byte prompt", info.Size(), len(entries[0].Prompt))
+	}
+
+	// Entries older than the cutoff are pruned along with the directories left empty
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(entries[0].File, old, old); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := plain.Prune(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil || removed != 1 {
+		t.Fatalf("Prune() = %d, %v; want 1 entry removed", removed, err)
+	}
+	if removed, _ = plain.Prune(ctx, time.Now().Add(time.Hour)); removed != 1 {
+		t.Fatalf("Prune() = %d, want the last entry removed", removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "SBI-001")); !os.IsNotExist(err) {
+		t.Errorf("The empty SBI directory should be removed, got %v", err)
+	}
+	if entries, err := plain.List(ctx, "SBI-001"); err != nil || len(entries) != 0 {
+		t.Errorf("List() after pruning = %v, %v; want nothing", entries, err)
+	}
+}
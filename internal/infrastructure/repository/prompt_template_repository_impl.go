@@ -67,6 +67,18 @@ func (r *PromptTemplateRepositoryImpl) LoadLabelContent(ctx context.Context, lab
 	return ""
 }
 
+// LoadLabelReviewContent loads the review-specific guidance of a label
+// The guidance sits next to the label content: .deespec/prompts/labels/<label>.review.md
+func (r *PromptTemplateRepositoryImpl) LoadLabelReviewContent(ctx context.Context, labelName string) string {
+	labelPath := filepath.Join(".deespec", "prompts", "labels", labelName+".review.md")
+
+	content, err := os.ReadFile(labelPath)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
 // LoadMetaLabels loads labels from a task's meta.yaml file
 func (r *PromptTemplateRepositoryImpl) LoadMetaLabels(ctx context.Context, sbiID string) ([]string, error) {
 	// Try meta.yml first, then meta.yaml as fallback
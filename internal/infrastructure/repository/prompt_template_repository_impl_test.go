@@ -812,3 +812,48 @@ func TestLoadLabelContent_MultipleDirectoryFallback(t *testing.T) {
 		t.Errorf("Expected label from fallback directory, got '%s'", content)
 	}
 }
+
+// TestLoadLabelReviewContent tests that review guidance is read from <label>.review.md
+func TestLoadLabelReviewContent(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "prompt_template_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	originalDir, _ := os.Getwd()
+	err = os.Chdir(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	labelsDir := filepath.Join(".deespec", "prompts", "labels", "security")
+	err = os.MkdirAll(labelsDir, 0755)
+	if err != nil {
+		t.Fatalf("Failed to create labels directory: %v", err)
+	}
+
+	reviewContent := "# Auth Review Checklist\n- Passwords are hashed"
+	err = os.WriteFile(filepath.Join(labelsDir, "auth.review.md"), []byte(reviewContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write review guidance file: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(labelsDir, "auth.md"), []byte("# Auth Guidelines"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write label file: %v", err)
+	}
+
+	repo := NewPromptTemplateRepositoryImpl()
+	ctx := context.Background()
+
+	if content := repo.LoadLabelReviewContent(ctx, "security/auth"); content != reviewContent {
+		t.Errorf("Expected review guidance, got '%s'", content)
+	}
+	if content := repo.LoadLabelContent(ctx, "security/auth"); content != "# Auth Guidelines" {
+		t.Errorf("Expected implement guidance to stay separate, got '%s'", content)
+	}
+	if content := repo.LoadLabelReviewContent(ctx, "backend"); content != "" {
+		t.Errorf("Expected empty string for label without review guidance, got '%s'", content)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 	"gopkg.in/yaml.v3"
 )
 
@@ -62,7 +63,7 @@ func (r *SBIApprovalRepositoryImpl) SaveManifest(ctx context.Context, manifest *
 	}
 
 	// Write file atomically
-	if err := r.atomicWrite(manifestPath, data); err != nil {
+	if err := fs.WriteFileAtomic(manifestPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write approval manifest for PBI %s: %w", manifest.PBIID, err)
 	}
 
@@ -107,47 +108,3 @@ func (r *SBIApprovalRepositoryImpl) DeleteManifest(ctx context.Context, pbiID re
 func (r *SBIApprovalRepositoryImpl) getManifestPath(pbiID repository.PBIID) string {
 	return filepath.Join(".deespec", "specs", "pbi", string(pbiID), "approval.yaml")
 }
-
-// atomicWrite writes data to a file atomically using a temp file and rename
-func (r *SBIApprovalRepositoryImpl) atomicWrite(path string, data []byte) error {
-	// Create temp file in the same directory as the target
-	dir := filepath.Dir(path)
-	tmpFile, err := os.CreateTemp(dir, ".tmp-approval-*.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tmpPath := tmpFile.Name()
-
-	// Ensure cleanup in case of error
-	defer func() {
-		_ = os.Remove(tmpPath)
-	}()
-
-	// Write data to temp file
-	if _, err := tmpFile.Write(data); err != nil {
-		_ = tmpFile.Close()
-		return fmt.Errorf("failed to write to temp file: %w", err)
-	}
-
-	// Sync to ensure data is written to disk
-	if err := tmpFile.Sync(); err != nil {
-		_ = tmpFile.Close()
-		return fmt.Errorf("failed to sync temp file: %w", err)
-	}
-
-	// Close the temp file
-	if err := tmpFile.Close(); err != nil {
-		return fmt.Errorf("failed to close temp file: %w", err)
-	}
-
-	// Atomic rename (file permissions are set to 0644 by default with CreateTemp)
-	if err := os.Chmod(tmpPath, 0644); err != nil {
-		return fmt.Errorf("failed to set file permissions: %w", err)
-	}
-
-	if err := os.Rename(tmpPath, path); err != nil {
-		return fmt.Errorf("failed to rename temp file to %s: %w", path, err)
-	}
-
-	return nil
-}
@@ -86,16 +86,21 @@ func (r *SBITaskRepositoryImpl) LoadAllTasks(ctx context.Context, specsDir strin
 	}
 
 	// Enrich tasks with database-based dependencies if sbiRepo is available
-	if r.sbiRepo != nil {
+	// (one query for all tasks instead of one per task)
+	if r.sbiRepo != nil && len(tasks) > 0 {
+		states, err := r.sbiRepo.ListWithExecutionState(ctx, repository.SBIFilter{})
+		if err != nil {
+			// Continue with file-based dependencies
+			r.warnLog("failed to load dependencies from database: %v", err)
+			return tasks, nil
+		}
+		dependsOn := make(map[string][]string, len(states))
+		for _, state := range states {
+			dependsOn[state.SBI.ID().String()] = state.DependsOn
+		}
 		for _, task := range tasks {
-			deps, err := r.sbiRepo.GetDependencies(ctx, repository.SBIID(task.ID))
-			if err != nil {
-				r.warnLog("failed to load dependencies for %s from database: %v", task.ID, err)
-				// Continue with file-based dependencies
-				continue
-			}
 			// Override with database dependencies if available
-			if len(deps) > 0 {
+			if deps := dependsOn[task.ID]; len(deps) > 0 {
 				task.DependsOn = deps
 				r.debugLog("loaded %d dependencies for %s from database", len(deps), task.ID)
 			}
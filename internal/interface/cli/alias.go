@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// registerAliases adds user-defined command aliases ("aliases" in setting.json) to the root command
+// e.g. {"r": "run --parallel 2"} makes `deespec r --once` run `deespec run --parallel 2 --once`.
+// Aliases that shadow a built-in command or expand to another alias are ignored with a warning.
+func registerAliases(root *cobra.Command, aliases map[string]string) {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Snapshot built-in commands before any alias is added
+	builtin := builtinCommandNames(root)
+
+	for _, name := range names {
+		expansion := strings.Fields(aliases[name])
+		if name == "" || strings.ContainsAny(name, " \t") || len(expansion) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring invalid alias %q\n", name)
+			continue
+		}
+		if builtin[name] {
+			fmt.Fprintf(os.Stderr, "Warning: alias %q shadows a built-in command and is ignored\n", name)
+			continue
+		}
+		if _, nested := aliases[expansion[0]]; nested && !builtin[expansion[0]] {
+			fmt.Fprintf(os.Stderr, "Warning: alias %q expands to another alias and is ignored\n", name)
+			continue
+		}
+
+		root.AddCommand(newAliasCommand(name, expansion))
+	}
+}
+
+// builtinCommandNames returns the names and aliases of all top-level commands
+func builtinCommandNames(root *cobra.Command) map[string]bool {
+	names := map[string]bool{"help": true, "completion": true}
+	for _, c := range root.Commands() {
+		names[c.Name()] = true
+		for _, alias := range c.Aliases {
+			names[alias] = true
+		}
+	}
+	return names
+}
+
+// newAliasCommand creates a command that re-runs the root command with the alias expanded
+func newAliasCommand(name string, expansion []string) *cobra.Command {
+	return &cobra.Command{
+		Use:                name,
+		Short:              fmt.Sprintf("Alias for '%s'", strings.Join(expansion, " ")),
+		DisableFlagParsing: true, // Flags belong to the expanded command
+		// Read-only mode is enforced on the expanded command
+		Annotations: map[string]string{common.ReadOnlyDeferAnnotation: "true"},
+		// The expanded command reports its own errors and usage
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(c *cobra.Command, args []string) error {
+			root := c.Root()
+			root.SetArgs(expandAlias(expansion, args))
+			return root.Execute()
+		},
+		ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			target, targetArgs, err := c.Root().Find(expandAlias(expansion, args))
+			if err != nil || target.ValidArgsFunction == nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return target.ValidArgsFunction(target, positionalArgs(targetArgs), toComplete)
+		},
+	}
+}
+
+// expandAlias returns the alias expansion followed by the user's arguments
+func expandAlias(expansion, args []string) []string {
+	expanded := make([]string, 0, len(expansion)+len(args))
+	expanded = append(expanded, expansion...)
+	return append(expanded, args...)
+}
+
+// positionalArgs drops flags from args (best effort: flag values are not known here)
+func positionalArgs(args []string) []string {
+	var positional []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+		}
+	}
+	return positional
+}
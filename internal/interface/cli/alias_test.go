@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAliasTestRoot builds a root with a "run" command that records its parsed flags and args
+func newAliasTestRoot(parallel *int, once *bool, gotArgs *[]string) *cobra.Command {
+	root := &cobra.Command{Use: "deespec"}
+	run := &cobra.Command{
+		Use: "run",
+		RunE: func(c *cobra.Command, args []string) error {
+			*gotArgs = args
+			return nil
+		},
+		ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"SBI-1"}, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	run.Flags().IntVar(parallel, "parallel", 1, "")
+	run.Flags().BoolVar(once, "once", false, "")
+	root.AddCommand(run)
+	return root
+}
+
+func TestRegisterAliases_ExpandsWithFlags(t *testing.T) {
+	var parallel int
+	var once bool
+	var gotArgs []string
+	root := newAliasTestRoot(&parallel, &once, &gotArgs)
+
+	registerAliases(root, map[string]string{"r": "run --parallel 2"})
+
+	root.SetArgs([]string{"r", "--once", "extra"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, 2, parallel)
+	assert.True(t, once)
+	assert.Equal(t, []string{"extra"}, gotArgs)
+}
+
+func TestRegisterAliases_IgnoresInvalidAliases(t *testing.T) {
+	var parallel int
+	var once bool
+	var gotArgs []string
+	root := newAliasTestRoot(&parallel, &once, &gotArgs)
+
+	registerAliases(root, map[string]string{
+		"run":   "run --once",       // shadows a built-in command
+		"help":  "run",              // shadows cobra's help command
+		"empty": "   ",              // no expansion
+		"rr":    "r --parallel 3",   // expands to another alias
+		"r":     "run --parallel 2", // valid
+	})
+
+	var names []string
+	for _, c := range root.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"run", "r"}, names)
+}
+
+func TestAliasCommand_DelegatesCompletion(t *testing.T) {
+	var parallel int
+	var once bool
+	var gotArgs []string
+	root := newAliasTestRoot(&parallel, &once, &gotArgs)
+	registerAliases(root, map[string]string{"r": "run --parallel 2"})
+
+	alias, _, err := root.Find([]string{"r"})
+	require.NoError(t, err)
+
+	completions, directive := alias.ValidArgsFunction(alias, nil, "")
+	assert.Equal(t, []string{"SBI-1"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
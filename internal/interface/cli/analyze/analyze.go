@@ -0,0 +1,132 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// analyzeFlags holds the flags for the analyze command
+type analyzeFlags struct {
+	maxEPICs       int
+	maxPBIsPerEPIC int
+	promptOnly     bool
+	force          bool
+}
+
+// NewCommand creates the analyze command
+func NewCommand() *cobra.Command {
+	flags := &analyzeFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Propose an initial EPIC/PBI backlog from the existing codebase",
+		Long: `Have an agent scan the repository and propose an initial backlog of EPICs and
+PBIs: architecture areas, missing tests and TODOs found in the code. This
+kick-starts adopting deespec on an existing project.
+
+The proposal is written as draft specs pending approval to
+.deespec/specs/proposal: one Markdown file per EPIC or PBI and a proposal.yaml
+manifest. Edit the drafts as needed, then:
+
+  deespec analyze list                 # Show the drafts and their status
+  deespec analyze approve [draft...]   # Approve drafts (all undecided ones without arguments)
+  deespec analyze reject <draft...>    # Reject drafts
+  deespec analyze register             # Create the EPICs and PBIs of approved drafts
+
+The existing backlog is listed in the prompt so that it is not proposed again.
+A proposal with undecided or unregistered drafts is only replaced with --force.`,
+		Example: `  # Analyze the repository
+  deespec analyze
+
+  # A smaller backlog
+  deespec analyze --max-epics 3 --max-pbis 4
+
+  # Print the prompt without running the agent
+  deespec analyze --prompt-only`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAnalyze(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.maxEPICs, "max-epics", 5, "Maximum number of EPICs to propose")
+	cmd.Flags().IntVar(&flags.maxPBIsPerEPIC, "max-pbis", 6, "Maximum number of PBIs to propose per EPIC")
+	cmd.Flags().BoolVar(&flags.promptOnly, "prompt-only", false, "Print the analysis prompt without running the agent")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Replace a proposal that still has undecided or unregistered drafts")
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newApproveCommand())
+	cmd.AddCommand(newRejectCommand())
+	cmd.AddCommand(newRegisterCommand())
+	return cmd
+}
+
+// runAnalyze runs the analysis and prints the proposed drafts
+func runAnalyze(ctx context.Context, flags *analyzeFlags) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	uc, dir, closeDB, err := newAnalyzeUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if !flags.promptOnly {
+		fmt.Println("🔍 Analyzing the repository (this may take several minutes)...")
+	}
+	result, err := uc.Execute(ctx, usecase.AnalyzeOptions{
+		MaxEPICs:       flags.maxEPICs,
+		MaxPBIsPerEPIC: flags.maxPBIsPerEPIC,
+		PromptOnly:     flags.promptOnly,
+		Force:          flags.force,
+	})
+	if err != nil {
+		return err
+	}
+	if flags.promptOnly {
+		fmt.Print(result.Prompt)
+		return nil
+	}
+
+	fmt.Printf("✅ Proposed %d draft(s) in %s\n\n", len(result.Proposal.Drafts), dir)
+	printDrafts(result.Proposal)
+	fmt.Println()
+	fmt.Println("Review and edit the drafts, then approve and register them:")
+	fmt.Println("  deespec analyze approve [draft...]")
+	fmt.Println("  deespec analyze register")
+	return nil
+}
+
+// newAnalyzeUseCase builds the analyze use case on the local database and the proposal directory
+// The returned function closes the database and must be called when done
+func newAnalyzeUseCase() (*usecase.AnalyzeRepositoryUseCase, string, func(), error) {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to initialize container: %w", err)
+	}
+	rootPath, err := os.Getwd()
+	if err != nil {
+		container.Close()
+		return nil, "", nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	dir := filepath.Join(app.GetPathsWithConfig(common.GetGlobalConfig()).Home, "specs", "proposal")
+	uc := usecase.NewAnalyzeRepositoryUseCase(
+		container.GetAgentGateway(),
+		infrarepo.NewBacklogProposalRepositoryImpl(dir),
+		container.GetEPICRepository(),
+		persistence.NewPBISQLiteRepository(container.GetDB(), rootPath),
+	)
+	return uc, dir, func() { container.Close() }, nil
+}
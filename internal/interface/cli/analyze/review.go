@@ -0,0 +1,154 @@
+package analyze
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+)
+
+// newListCommand creates the analyze list command
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the drafts of the backlog proposal",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uc, _, closeDB, err := newAnalyzeUseCase()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			proposal, err := uc.Proposal(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if proposal == nil {
+				fmt.Println("No backlog proposal. Run 'deespec analyze' to create one.")
+				return nil
+			}
+			fmt.Printf("Proposal of %s: %d draft(s), %d undecided\n\n",
+				proposal.GeneratedAt.Local().Format("2006-01-02 15:04"), len(proposal.Drafts), proposal.PendingCount())
+			printDrafts(proposal)
+			return nil
+		},
+	}
+}
+
+// newApproveCommand creates the analyze approve command
+func newApproveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "approve [draft...]",
+		Short: "Approve drafts of the backlog proposal (all undecided ones without arguments)",
+		Example: `  # Approve two drafts
+  deespec analyze approve epic-01.md epic-01-pbi-01.md
+
+  # Approve every undecided draft
+  deespec analyze approve`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uc, _, closeDB, err := newAnalyzeUseCase()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			files, err := uc.Review(cmd.Context(), args, pbi.ApprovalStatusApproved, "")
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ Approved %d draft(s). Register them with 'deespec analyze register'.\n", len(files))
+			return nil
+		},
+	}
+}
+
+// newRejectCommand creates the analyze reject command
+func newRejectCommand() *cobra.Command {
+	var reason string
+	cmd := &cobra.Command{
+		Use:   "reject <draft...>",
+		Short: "Reject drafts of the backlog proposal",
+		Long: `Reject drafts of the backlog proposal. Rejecting an EPIC draft keeps its PBI
+drafts; approved ones are registered without an EPIC.`,
+		Example: `  deespec analyze reject pbi-02.md --reason "already tracked elsewhere"`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uc, _, closeDB, err := newAnalyzeUseCase()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			files, err := uc.Review(cmd.Context(), args, pbi.ApprovalStatusRejected, reason)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("❌ Rejected %d draft(s).\n", len(files))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the drafts are rejected")
+	return cmd
+}
+
+// newRegisterCommand creates the analyze register command
+func newRegisterCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "register",
+		Short: "Create the EPICs and PBIs of the approved drafts",
+		Long: `Create the EPICs and PBIs of the approved drafts that are not registered yet.
+Titles and bodies are read from the draft files, so edits are kept. PBIs are
+attached to the EPIC of their parent draft when that was registered.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uc, _, closeDB, err := newAnalyzeUseCase()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			registered, err := uc.Register(cmd.Context())
+			for _, r := range registered {
+				suffix := ""
+				if r.EPICID != "" {
+					suffix = " in " + r.EPICID
+				}
+				fmt.Printf("✅ %s → %s %s: %s%s\n", r.File, r.Kind, r.ID, r.Title, suffix)
+			}
+			if err != nil {
+				return err
+			}
+			if len(registered) == 0 {
+				return errors.New("no approved drafts to register; approve drafts with 'deespec analyze approve'")
+			}
+			fmt.Println()
+			fmt.Println("Decompose the PBIs into SBIs with 'deespec pbi decompose <pbi-id>'.")
+			return nil
+		},
+	}
+}
+
+// printDrafts lists the drafts of a proposal, PBIs indented under their EPIC
+func printDrafts(proposal *pbi.BacklogProposal) {
+	for _, draft := range proposal.Drafts {
+		indent := ""
+		if draft.Parent != "" {
+			indent = "    "
+		}
+		status := string(draft.Status)
+		if draft.RegisteredID != "" {
+			status = "registered as " + draft.RegisteredID
+		}
+		category := ""
+		if draft.Category != "" {
+			category = " [" + draft.Category + "]"
+		}
+		fmt.Printf("  %s%-4s %-22s %s%s (%s)\n", indent, draft.Kind, draft.File, draft.Title, category, status)
+		if draft.RejectionReason != "" {
+			fmt.Printf("  %s     rejected: %s\n", indent, draft.RejectionReason)
+		}
+	}
+}
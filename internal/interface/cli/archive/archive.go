@@ -0,0 +1,247 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the archive command
+func NewCommand() *cobra.Command {
+	var (
+		before     string
+		statuses   []string
+		dryRun     bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Move old finished SBIs into the archive store",
+		Long: `Move finished SBIs completed before a date out of the working set.
+
+Each SBI is bundled with its database rows, journal entries, and spec and
+report directories into .deespec/archive/sbi/<id>.tar.gz, then removed from
+the database and the working tree. A tombstone keeps its ID and sequence
+reserved; find archived SBIs with 'deespec archive search' and bring them
+back with 'deespec archive restore'.
+
+SBIs that an unfinished SBI still depends on are skipped.`,
+		Example: `  # Archive SBIs finished before 2024
+  deespec archive --before 2024-01-01 --status DONE
+
+  # Preview what would be archived
+  deespec archive --before 2024-01-01 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if before == "" {
+				return fmt.Errorf("--before is required")
+			}
+			cutoff, err := parseBefore(before)
+			if err != nil {
+				return err
+			}
+			opts := usecase.ArchiveSBIOptions{Before: cutoff, DryRun: dryRun}
+			for _, status := range statuses {
+				opts.Statuses = append(opts.Statuses, model.Status(strings.ToUpper(strings.TrimSpace(status))))
+			}
+
+			return withArchiveUseCase(func(uc *usecase.ArchiveSBIUseCase) error {
+				result, err := uc.Archive(cmd.Context(), opts)
+				if result != nil {
+					if jsonOutput {
+						if encErr := printJSON(result); encErr != nil && err == nil {
+							err = encErr
+						}
+					} else {
+						printArchiveResult(result)
+					}
+				}
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&before, "before", "", "Archive SBIs completed before this date (YYYY-MM-DD, local time, or RFC3339)")
+	cmd.Flags().StringSliceVar(&statuses, "status", []string{"DONE", "FAILED"}, "Statuses to archive (DONE, FAILED, CANCELLED)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be archived without changing anything")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result in JSON format")
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newSearchCommand())
+	cmd.AddCommand(newRestoreCommand())
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	var (
+		statuses   []string
+		limit      int
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List archived SBIs, most recently archived first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := repository.SBITombstoneFilter{Statuses: upper(statuses), Limit: limit}
+			return runSearch(cmd.Context(), filter, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only list SBIs archived in these statuses")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of SBIs to list (0 = all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newSearchCommand() *cobra.Command {
+	var (
+		statuses   []string
+		limit      int
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search archived SBIs by ID, title or parent PBI",
+		Example: `  deespec archive search login
+  deespec archive search PBI-001 --status FAILED`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := repository.SBITombstoneFilter{Query: args[0], Statuses: upper(statuses), Limit: limit}
+			return runSearch(cmd.Context(), filter, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only match SBIs archived in these statuses")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of results (0 = all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <id>",
+		Short: "Move an archived SBI back into the working set",
+		Long: `Restore an archived SBI's database rows, journal entries, and spec and
+report files from its archive, then remove the archive and the tombstone.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withArchiveUseCase(func(uc *usecase.ArchiveSBIUseCase) error {
+				tombstone, err := uc.Restore(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Printf("✓ SBI %s restored (%s, archived %s)\n",
+					tombstone.SBIID, tombstone.Status, tombstone.ArchivedAt.Local().Format("2006-01-02 15:04"))
+				return nil
+			})
+		},
+	}
+}
+
+// withArchiveUseCase builds the archive use case from the container and configured paths
+func withArchiveUseCase(fn func(uc *usecase.ArchiveSBIUseCase) error) error {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	return fn(newArchiveUseCase(container))
+}
+
+// newArchiveUseCase creates the archive use case
+func newArchiveUseCase(container *di.Container) *usecase.ArchiveSBIUseCase {
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	return usecase.NewArchiveSBIUseCase(
+		container.GetSBIRepository(),
+		container.GetSBITombstoneRepository(),
+		container.GetSBISnapshotRepository(),
+		common.NewJournalRepository(paths.Journal),
+		paths.Home,
+	)
+}
+
+// runSearch prints the archived SBIs matching filter
+func runSearch(ctx context.Context, filter repository.SBITombstoneFilter, jsonOutput bool) error {
+	return withArchiveUseCase(func(uc *usecase.ArchiveSBIUseCase) error {
+		tombstones, err := uc.Search(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return printJSON(tombstones)
+		}
+		if len(tombstones) == 0 {
+			fmt.Println("No archived SBIs found")
+			return nil
+		}
+		fmt.Printf("%-26s  %-9s  %-16s  %s\n", "ID", "STATUS", "ARCHIVED", "TITLE")
+		for _, t := range tombstones {
+			fmt.Printf("%-26s  %-9s  %-16s  %s\n",
+				t.SBIID, t.Status, t.ArchivedAt.Local().Format("2006-01-02 15:04"), t.Title)
+		}
+		return nil
+	})
+}
+
+// printArchiveResult prints the result of an archive run in text form
+func printArchiveResult(result *usecase.ArchiveSBIResult) {
+	verb := "Archived"
+	if result.DryRun {
+		verb = "Would archive"
+	}
+	fmt.Printf("%s %d SBI(s)\n", verb, len(result.Archived))
+	for _, a := range result.Archived {
+		fmt.Printf("  %s  %-9s  %s\n", a.SBIID, a.Status, a.Title)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("\nSkipped %d SBI(s)\n", len(result.Skipped))
+		for _, s := range result.Skipped {
+			fmt.Printf("  %s  %s\n", s.SBIID, s.Reason)
+		}
+	}
+}
+
+// parseBefore parses a YYYY-MM-DD date (local midnight) or an RFC3339 time
+func parseBefore(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --before %q (expected YYYY-MM-DD or RFC3339)", value)
+}
+
+// upper normalizes status flag values
+func upper(values []string) []string {
+	var result []string
+	for _, v := range values {
+		result = append(result, strings.ToUpper(strings.TrimSpace(v)))
+	}
+	return result
+}
+
+// printJSON writes v as indented JSON
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal json: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
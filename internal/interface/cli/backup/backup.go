@@ -0,0 +1,313 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the backup command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Take, list and restore snapshots of the task database",
+		Long: `Snapshots of the SQLite task database are kept in .deespec/backups.
+
+While 'deespec run' is running, a snapshot is taken once a day and the newest 7
+scheduled snapshots are kept. Set "backup.interval_hours", "backup.keep" or
+"backup.enabled" in setting.json to change this. With "backup.upload" every
+snapshot is also uploaded to the s3 or gcs artifact_store under backups/.
+
+The backups taken before schema migrations are listed as well.`,
+		Example: `  deespec backup now
+  deespec backup list
+  deespec backup restore deespec.db.scheduled-20260101T030000Z.bak`,
+		RunE: func(c *cobra.Command, _ []string) error { return c.Help() },
+	}
+
+	cmd.AddCommand(newNowCommand())
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newRestoreCommand())
+	return cmd
+}
+
+func newNowCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "now",
+		Short: "Take a snapshot of the task database now",
+		Long: `Take a snapshot of the task database now. The snapshot is consistent even
+while 'deespec run' is writing to the database, and is uploaded to the
+artifact store when "backup.upload" is set. Manual snapshots are never rotated out.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			container, err := common.InitializeContainer()
+			if err != nil {
+				return fmt.Errorf("failed to initialize container: %w", err)
+			}
+			defer container.Close()
+
+			scheduler, err := common.NewDatabaseBackupScheduler(container.GetDatabaseBackupRepository())
+			if err != nil {
+				return err
+			}
+			result, err := scheduler.Backup(cmd.Context(), repository.DatabaseBackupManual, time.Now())
+			if result == nil {
+				return err
+			}
+			if jsonOutput {
+				if encErr := printJSON(result); encErr != nil {
+					return encErr
+				}
+			} else {
+				fmt.Printf("✓ Saved %s (%s)\n", result.Backup.Path, formatBytes(result.Backup.Size))
+				if result.URI != "" {
+					fmt.Printf("  Uploaded to %s\n", result.URI)
+				}
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the task database, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := common.ResolveDBPath()
+			if err != nil {
+				return err
+			}
+			backups, err := sqlite.NewDatabaseBackupRepository(nil, path).List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return printJSON(backups)
+			}
+			if len(backups) == 0 {
+				fmt.Println("No backups yet; take one with 'deespec backup now'")
+				return nil
+			}
+			fmt.Printf("%-16s  %-11s  %9s  %s\n", "TAKEN", "KIND", "SIZE", "NAME")
+			for _, backup := range backups {
+				fmt.Printf("%-16s  %-11s  %9s  %s\n", backup.CreatedAt.Local().Format("2006-01-02 15:04"), backup.Kind, formatBytes(backup.Size), backup.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// restoreReport is the JSON output of backup restore
+type restoreReport struct {
+	Path       string `json:"path"`
+	Backup     string `json:"backup"`                // Name of the restored backup
+	Downloaded string `json:"downloaded,omitempty"`  // URI the backup was downloaded from
+	PreRestore string `json:"pre_restore,omitempty"` // Snapshot of the replaced database
+	MovedTo    string `json:"moved_to,omitempty"`    // Where the replaced database was moved when it was damaged
+}
+
+func newRestoreCommand() *cobra.Command {
+	var (
+		force      bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Replace the task database with a snapshot",
+		Long: `Replace the task database with a snapshot listed by 'deespec backup list'.
+
+The snapshot is checked for integrity first. The database it replaces is saved
+as a pre-restore snapshot (or moved aside to deespec.db.damaged-<time> when it
+is damaged), so a restore can itself be undone. A snapshot missing locally is
+downloaded from the artifact store when "backup.upload" is set.
+
+Stop 'deespec run' and any other command using the database first. A snapshot
+taken by an older deespec version is migrated by the next command.`,
+		Example: `  deespec backup list
+  deespec backup restore deespec.db.scheduled-20260101T030000Z.bak`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !force && !jsonOutput {
+				fmt.Printf("⚠️  Replace the task database with %s\n", args[0])
+				fmt.Printf("    The current database is kept as a pre-restore snapshot.\n\n")
+				fmt.Print("Are you sure? (y/N): ")
+
+				response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("failed to read confirmation: %w", err)
+				}
+				response = strings.TrimSpace(strings.ToLower(response))
+				if response != "y" && response != "yes" {
+					fmt.Println("❌ Cancelled")
+					return nil
+				}
+			}
+
+			report, err := restoreBackup(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return printJSON(report)
+			}
+			if report.Downloaded != "" {
+				fmt.Printf("Downloaded %s\n", report.Downloaded)
+			}
+			if report.PreRestore != "" {
+				fmt.Printf("Saved the previous database as %s\n", report.PreRestore)
+			}
+			if report.MovedTo != "" {
+				fmt.Printf("Moved the damaged database to %s\n", report.MovedTo)
+			}
+			fmt.Printf("✓ Restored %s from %s\n", report.Path, report.Backup)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format (implies --force)")
+	return cmd
+}
+
+// restoreBackup replaces the database with the named backup after snapshotting it
+func restoreBackup(ctx context.Context, name string) (*restoreReport, error) {
+	path, err := common.ResolveDBPath()
+	if err != nil {
+		return nil, err
+	}
+	report := &restoreReport{Path: path, Backup: name}
+	backups := sqlite.NewDatabaseBackupRepository(nil, path)
+
+	backup, err := backups.Find(ctx, name)
+	if errors.Is(err, repository.ErrDatabaseBackupNotFound) {
+		if report.Downloaded, err = download(ctx, path, name); err != nil {
+			return nil, err
+		}
+		if report.Downloaded == "" {
+			return nil, fmt.Errorf("%w: %s (see 'deespec backup list')", repository.ErrDatabaseBackupNotFound, name)
+		}
+		backup, err = backups.Find(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkDatabase(backup.Path); err != nil {
+		return nil, fmt.Errorf("backup %s cannot be restored: %w", name, err)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		_, err := checkDatabase(path)
+		switch {
+		case err == nil:
+			if report.PreRestore, err = snapshot(ctx, path); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, sqlite.ErrDatabaseCorrupted):
+			if report.MovedTo, err = sqlite.MoveDatabaseAside(path, "damaged"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	if err := sqlite.RestoreDatabase(backup.Path, path); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// download fetches a backup missing locally from the artifact store into the backups directory
+// and returns its URI, or "" when backups are not uploaded
+func download(ctx context.Context, path, name string) (string, error) {
+	store, err := common.BackupArtifactStore()
+	if err != nil || store == nil {
+		return "", err
+	}
+	uri := store.URI(service.BackupArtifactKey(name))
+	content, err := store.Get(ctx, uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", uri, err)
+	}
+	dir := filepath.Join(filepath.Dir(path), sqlite.BackupDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.Base(name)), content, 0644); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", name, err)
+	}
+	return uri, nil
+}
+
+// snapshot saves the database at path as a pre-restore backup and returns its name
+func snapshot(ctx context.Context, path string) (string, error) {
+	db, err := sql.Open("sqlite3", sqlite.DSN(path, "_busy_timeout="+fmt.Sprint(sqlite.DefaultBusyTimeoutMs)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	backup, err := sqlite.NewDatabaseBackupRepository(db, path).Create(ctx, repository.DatabaseBackupPreRestore, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to save the current database: %w", err)
+	}
+	return backup.Name, nil
+}
+
+// checkDatabase runs the integrity check on the database file at path
+func checkDatabase(path string) ([]string, error) {
+	db, err := sql.Open("sqlite3", sqlite.DSN(path, "mode=ro"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	return sqlite.CheckIntegrity(db)
+}
+
+// formatBytes renders a size in B, KB or MB
+func formatBytes(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
+
+// printJSON writes v as indented JSON
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal json: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
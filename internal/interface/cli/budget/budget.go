@@ -0,0 +1,77 @@
+package budget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the budget command
+func NewCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "budget",
+		Short: "Show turn budget usage against the configured limits",
+		Long: `Show how much of the aggregate turn budgets has been used:
+  - Agent calls made today (local time) against scheduling.max_agent_calls_per_day
+  - Turns spent by the SBIs of each PBI against scheduling.max_turns_per_pbi
+
+When a budget is exhausted the scheduler skips the affected turns with the
+NoOp reason "daily_agent_call_budget_exhausted" or "pbi_turn_budget_exhausted".
+A limit of 0 means unlimited.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			container, err := common.InitializeContainer()
+			if err != nil {
+				return fmt.Errorf("failed to initialize container: %w", err)
+			}
+			defer container.Close()
+
+			usage, err := common.NewTurnBudget(container).Usage(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to load budget usage: %w", err)
+			}
+
+			if jsonOutput {
+				b, err := json.MarshalIndent(usage, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshal json: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+			printUsage(usage)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output budget usage in JSON format")
+	return cmd
+}
+
+// printUsage prints the budget usage in text form
+func printUsage(usage *service.TurnBudgetUsage) {
+	fmt.Printf("Agent calls today (%s): %s\n", usage.Day,
+		formatUsage(usage.AgentCalls, usage.MaxAgentCallsPerDay))
+
+	fmt.Printf("\nTurns per PBI (%d)\n", len(usage.PBIs))
+	for _, p := range usage.PBIs {
+		line := fmt.Sprintf("  %s  %s", p.PBIID, formatUsage(p.Turns, usage.MaxTurnsPerPBI))
+		if p.Exhausted {
+			line += "  EXHAUSTED"
+		}
+		fmt.Println(line)
+	}
+}
+
+// formatUsage renders used against limit, where a limit of 0 is unlimited
+func formatUsage(used, limit int) string {
+	if limit == 0 {
+		return fmt.Sprintf("%d (unlimited)", used)
+	}
+	return fmt.Sprintf("%d / %d", used, limit)
+}
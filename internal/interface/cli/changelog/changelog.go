@@ -0,0 +1,221 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// maxKeyFiles caps the key files listed per SBI
+const maxKeyFiles = 5
+
+// changelogFlags holds the flags for the changelog command
+type changelogFlags struct {
+	since      string
+	until      string
+	jsonOutput bool
+	output     string
+}
+
+// NewCommand creates the changelog command
+func NewCommand() *cobra.Command {
+	flags := &changelogFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "changelog",
+		Short: "Compose a change log from DONE SBIs for release notes",
+		Long: `Compose a human-readable change log from the SBIs completed in a time range,
+grouped by PBI, for inclusion in release notes.
+
+--since and --until take a git ref (e.g. a release tag: the time of its commit),
+a date (YYYY-MM-DD, local time) or an RFC 3339 time. The range includes --since
+and excludes --until.
+
+Each SBI lists the key files it changed, taken from git history:
+  - the files of commits whose message mentions the SBI ID, else
+  - the files committed while the SBI was being worked on, else
+  - the files the SBI declared it would modify ("declared")
+
+SBIs force-completed at the turn limit are marked as such.`,
+		Example: `  # Changes since the v0.3.0 tag, as Markdown
+  deespec changelog --since v0.3.0
+
+  # Changes between two releases, written to a file
+  deespec changelog --since v0.3.0 --until v0.4.0 -o CHANGES.md
+
+  # Changes of September as JSON
+  deespec changelog --since 2026-09-01 --until 2026-10-01 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChangelog(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.since, "since", "", "Only SBIs completed at or after this git ref, date or time")
+	cmd.Flags().StringVar(&flags.until, "until", "", "Only SBIs completed before this git ref, date or time")
+	cmd.Flags().BoolVar(&flags.jsonOutput, "json", false, "Output the change log in JSON format")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "Write the change log to this file instead of stdout")
+	return cmd
+}
+
+// runChangelog composes and prints the change log
+func runChangelog(ctx context.Context, flags *changelogFlags) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	history := service.NewChangeHistory(rootPath, maxKeyFiles)
+
+	var opts usecase.ChangelogOptions
+	if opts.Since, err = resolveTime(ctx, history, flags.since); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if opts.Until, err = resolveTime(ctx, history, flags.until); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && !opts.Since.Before(opts.Until) {
+		return fmt.Errorf("--since (%s) must be before --until (%s)", formatTime(opts.Since), formatTime(opts.Until))
+	}
+
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	uc := usecase.NewChangelogUseCase(
+		container.GetSBIRepository(),
+		persistence.NewPBISQLiteRepository(container.GetDB(), rootPath),
+		common.NewJournalRepository(paths.Journal),
+		history,
+	)
+	changelog, err := uc.Execute(ctx, opts)
+	if err != nil {
+		return err
+	}
+	for _, w := range changelog.Warnings {
+		common.Warn("could not read %s\n", w)
+	}
+
+	out := io.Writer(os.Stdout)
+	if flags.output != "" {
+		if err := common.CheckWritable("write " + flags.output); err != nil {
+			return err
+		}
+		file, err := os.Create(common.ResolveUserPath(flags.output))
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", flags.output, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if flags.jsonOutput {
+		b, err := json.MarshalIndent(changelog, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal json: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	}
+	_, err = io.WriteString(out, renderMarkdown(changelog, flags.since, flags.until))
+	return err
+}
+
+// resolveTime parses a date, an RFC 3339 time or, failing both, a git ref; "" is the zero time
+func resolveTime(ctx context.Context, history *service.ChangeHistory, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := history.RefTime(ctx, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a date (YYYY-MM-DD), an RFC 3339 time nor a known git ref: %w", value, err)
+	}
+	return t, nil
+}
+
+// renderMarkdown renders the change log as Markdown; since and until are the flag values,
+// so that release tags stay readable in the heading
+func renderMarkdown(changelog *usecase.Changelog, since, until string) string {
+	var sb strings.Builder
+	heading := "Changes"
+	if since != "" {
+		heading += " since " + since
+	}
+	if until != "" {
+		heading += " until " + until
+	}
+	fmt.Fprintf(&sb, "# %s\n\n", heading)
+
+	if changelog.Total == 0 {
+		sb.WriteString("No SBIs were completed in this range.\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%d SBI(s) completed", changelog.Total)
+	if changelog.Since != nil {
+		fmt.Fprintf(&sb, " from %s", formatTime(*changelog.Since))
+	}
+	if changelog.Until != nil {
+		fmt.Fprintf(&sb, " until %s", formatTime(*changelog.Until))
+	}
+	sb.WriteString(".\n")
+
+	for _, group := range changelog.Groups {
+		switch {
+		case group.PBIID == "":
+			sb.WriteString("\n## Other changes\n\n")
+		case group.PBITitle != "":
+			fmt.Fprintf(&sb, "\n## %s (%s)\n\n", group.PBITitle, group.PBIID)
+		default:
+			fmt.Fprintf(&sb, "\n## %s\n\n", group.PBIID)
+		}
+		for _, entry := range group.Entries {
+			fmt.Fprintf(&sb, "- %s (%s, %s)", entry.Title, entry.SBIID, entry.CompletedAt.Local().Format("2006-01-02"))
+			if len(entry.Labels) > 0 {
+				fmt.Fprintf(&sb, " [%s]", strings.Join(entry.Labels, ", "))
+			}
+			if entry.ForceCompleted {
+				sb.WriteString(" — force-completed at the turn limit")
+			}
+			sb.WriteString("\n")
+			if len(entry.Files) > 0 {
+				files := make([]string, len(entry.Files))
+				for i, file := range entry.Files {
+					files[i] = "`" + file + "`"
+				}
+				suffix := ""
+				if entry.FilesSource == service.ChangeSourceDeclared {
+					suffix = " (declared)"
+				}
+				fmt.Fprintf(&sb, "  - Files: %s%s\n", strings.Join(files, ", "), suffix)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// formatTime renders a range bound in local time
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+)
+
+// agentCacheBypass is set from the run --no-cache flag
+var agentCacheBypass bool
+
+// SetAgentCacheBypass makes agents run even when a cached output exists; the fresh
+// outputs replace the cached ones
+func SetAgentCacheBypass(bypass bool) {
+	agentCacheBypass = bypass
+}
+
+// NewAgentResponseCache builds the agent output cache from setting.json
+// ("agent_cache.enabled", "steps", "ttl_hours") and prunes its expired entries;
+// returns nil when disabled
+func NewAgentResponseCache(container *di.Container) *service.AgentResponseCache {
+	cfg := GetGlobalConfig()
+	if cfg == nil || !cfg.AgentCacheConfig().Enabled {
+		return nil
+	}
+	agentCache := cfg.AgentCacheConfig()
+	cache := service.NewAgentResponseCache(container.GetAgentResponseCacheRepository(), ProjectRoot(),
+		agentCache.Steps, time.Duration(agentCache.TTLHours)*time.Hour)
+	cache.SetBypass(agentCacheBypass)
+	if _, err := cache.Prune(context.Background()); err != nil {
+		Warn("%v\n", err)
+	}
+	return cache
+}
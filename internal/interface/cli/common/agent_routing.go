@@ -0,0 +1,36 @@
+package common
+
+import (
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewAgentRouter builds the agent router from setting.json ("agent_routing.rules", e.g.
+// [{"name": "docs", "labels": ["docs"], "file_types": [".md"], "model": "claude-haiku"}]);
+// invalid rules are skipped with a warning, and without rules no agent is chosen by task
+func NewAgentRouter() *domainservice.AgentRouter {
+	cfg := GetGlobalConfig()
+	if cfg == nil || len(cfg.AgentRoutingConfig().Rules) == 0 {
+		return nil
+	}
+
+	var rules []domainservice.AgentRoutingRule
+	for i, r := range cfg.AgentRoutingConfig().Rules {
+		rule := domainservice.AgentRoutingRule{
+			Name:      r.Name,
+			Steps:     r.Steps,
+			Labels:    r.Labels,
+			MinHours:  r.MinHours,
+			MaxHours:  r.MaxHours,
+			FileTypes: r.FileTypes,
+			Agent:     r.Agent,
+			Model:     r.Model,
+		}
+		if err := domainservice.ValidateAgentRoutingRule(rule); err != nil {
+			Warn("[AgentRouting] rule %d: %v (rule ignored)\n", i+1, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	router, _ := domainservice.NewAgentRouter(rules)
+	return router
+}
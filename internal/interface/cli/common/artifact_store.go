@@ -0,0 +1,56 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/storage"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+// ArtifactBaseDir is the local root for reports and other artifacts
+const ArtifactBaseDir = ".deespec"
+
+// NewArtifactStore creates the artifact store configured in setting.json (artifact_store)
+// Remote stores (s3, gcs) are write-through: a local working copy is kept for agents
+func NewArtifactStore() (output.ArtifactStore, error) {
+	local := storage.NewLocalArtifactStore(ArtifactBaseDir)
+
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return local, nil
+	}
+
+	storeConfig := cfg.ArtifactStoreConfig()
+	switch storeConfig.Type {
+	case "", "local":
+		return local, nil
+
+	case "s3":
+		if storeConfig.Bucket == "" {
+			return nil, fmt.Errorf("artifact_store.bucket is required for s3")
+		}
+		remote, err := storage.NewS3ArtifactStore(storage.S3Config{
+			BucketName: storeConfig.Bucket,
+			Prefix:     storeConfig.Prefix,
+			Region:     storeConfig.Region,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create S3 artifact store: %w", err)
+		}
+		return storage.NewWriteThroughArtifactStore(local, remote), nil
+
+	case "gcs":
+		if storeConfig.Bucket == "" {
+			return nil, fmt.Errorf("artifact_store.bucket is required for gcs")
+		}
+		remote := storage.NewGCSArtifactStore(storage.GCSConfig{
+			BucketName: storeConfig.Bucket,
+			Prefix:     storeConfig.Prefix,
+			Endpoint:   storeConfig.Endpoint,
+		})
+		return storage.NewWriteThroughArtifactStore(local, remote), nil
+
+	default:
+		return nil, fmt.Errorf("unknown artifact store type: %s (must be local, s3 or gcs)", storeConfig.Type)
+	}
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewChangeBudgetChecker builds the per-turn change budget from setting.json ("change_budget",
+// e.g. {"max_lines": 400, "max_files": 10, "on_exceed": "split"}); without limits no turn is
+// checked. notifier may be nil when flagged turns are only journaled.
+func NewChangeBudgetChecker(root string, notifier output.NotificationGateway) *service.ChangeBudgetChecker {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return nil
+	}
+
+	c := cfg.ChangeBudgetConfig()
+	budget := domainservice.ChangeBudget{MaxLines: c.MaxLines, MaxFiles: c.MaxFiles}
+	if !budget.Enabled() {
+		return nil
+	}
+	return service.NewChangeBudgetChecker(root, budget, c.OnExceed, c.SplitAttempts, notifier)
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+// completionLimit caps the number of IDs offered to the shell
+const completionLimit = 200
+
+// CompleteSBIIDs completes the first positional argument with SBI IDs from the local database
+// Each candidate carries "title [STATUS]" as its description
+func CompleteSBIIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return queryIDCompletions(
+		`SELECT id, title, status FROM sbis WHERE id LIKE ? AND deleted_at IS NULL ORDER BY updated_at DESC LIMIT ?`,
+		toComplete,
+	), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompletePBIIDs completes the first positional argument with PBI IDs from the local database
+// Each candidate carries "title [status]" as its description
+func CompletePBIIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return queryIDCompletions(
+		`SELECT id, title, status FROM pbis WHERE id LIKE ? AND deleted_at IS NULL ORDER BY updated_at DESC LIMIT ?`,
+		toComplete,
+	), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteEPICIDs completes the first positional argument with EPIC IDs from the local database
+// Each candidate carries "title [STATUS]" as its description
+func CompleteEPICIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return queryIDCompletions(
+		`SELECT id, title, status FROM epics WHERE id LIKE ? ORDER BY updated_at DESC LIMIT ?`,
+		toComplete,
+	), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteTrashedIDs completes the first positional argument with the IDs of trashed PBIs and SBIs
+// Each candidate carries "title [STATUS]" as its description
+func CompleteTrashedIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return queryIDCompletions(
+		`SELECT id, title, status FROM (
+		     SELECT id, title, status, deleted_at FROM pbis WHERE deleted_at IS NOT NULL
+		     UNION ALL
+		     SELECT id, title, status, deleted_at FROM sbis WHERE deleted_at IS NOT NULL
+		 ) WHERE id LIKE ? ORDER BY deleted_at DESC LIMIT ?`,
+		toComplete,
+	), cobra.ShellCompDirectiveNoFileComp
+}
+
+// queryIDCompletions runs an (id, title, status) query against the local database
+// Completion runs on every <TAB>, so the database is opened read-only and never
+// created or migrated; any failure simply yields no candidates
+func queryIDCompletions(query, prefix string) []string {
+	dbPath, err := ResolveDBPath()
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil
+	}
+
+	db, err := sql.Open("sqlite3", sqlite.DSN(dbPath, "mode=ro"))
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	rows, err := db.Query(query, prefix+"%", completionLimit)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var completions []string
+	for rows.Next() {
+		var id, title, status string
+		if err := rows.Scan(&id, &title, &status); err != nil {
+			continue
+		}
+		completions = append(completions, fmt.Sprintf("%s\t%s [%s]", id, title, status))
+	}
+	return completions
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompleteSBIIDs_UsesGlobalDatabase(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// A working directory without a local .deespec falls back to ~/.deespec/deespec.db
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	dbPath := filepath.Join(home, ".deespec", "deespec.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE sbis (id TEXT, title TEXT, status TEXT, updated_at DATETIME, deleted_at DATETIME)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO sbis (id, title, status, updated_at) VALUES ('sbi-001', 'Login form', 'PENDING', CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatal(err)
+	}
+
+	completions, _ := CompleteSBIIDs(nil, nil, "sbi-")
+	if len(completions) != 1 || completions[0] != "sbi-001\tLogin form [PENDING]" {
+		t.Errorf("CompleteSBIIDs() = %v, want [sbi-001\\tLogin form [PENDING]]", completions)
+	}
+}
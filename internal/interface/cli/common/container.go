@@ -6,26 +6,33 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/YoshitsuguKoike/deespec/internal/app"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
 )
 
-// InitializeContainer creates and returns a DI container with default configuration
-func InitializeContainer() (*di.Container, error) {
-	var dbPath string
-
+// ResolveDBPath returns the SQLite database path used by all commands
+func ResolveDBPath() (string, error) {
 	// Check if we're in a local .deespec directory (e.g., in tests)
 	// This allows tests to run in temporary directories
 	localDeespecDir := filepath.Join(".", ".deespec")
 	if stat, err := os.Stat(localDeespecDir); err == nil && stat.IsDir() {
 		// Use local .deespec directory if it exists
-		dbPath = filepath.Join(localDeespecDir, "deespec.db")
-	} else {
-		// Otherwise use global home directory
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
-		}
-		dbPath = filepath.Join(homeDir, ".deespec", "deespec.db")
+		return filepath.Join(localDeespecDir, "deespec.db"), nil
+	}
+
+	// Otherwise use global home directory
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".deespec", "deespec.db"), nil
+}
+
+// InitializeContainer creates and returns a DI container with default configuration
+func InitializeContainer() (*di.Container, error) {
+	dbPath, err := ResolveDBPath()
+	if err != nil {
+		return nil, err
 	}
 
 	// Create container config
@@ -35,6 +42,50 @@ func InitializeContainer() (*di.Container, error) {
 		LockHeartbeatInterval: 30 * time.Second,
 		LockCleanupInterval:   60 * time.Second,
 	}
+	if cfg := GetGlobalConfig(); cfg != nil {
+		config.NotificationWebhookURL = cfg.NotificationConfig().WebhookURL
+		config.NotificationEmail = cfg.NotificationConfig().Email
+		if dir := config.NotificationEmail.TemplateDir; dir != "" && !filepath.IsAbs(dir) {
+			config.NotificationEmail.TemplateDir = filepath.Join(ProjectRoot(), dir)
+		}
+		config.AgentType = cfg.AgentAPIConfig().DefaultAgent
+		config.AgentAPI = cfg.AgentAPIConfig()
+		config.Ollama = cfg.OllamaConfig()
+	}
+	config.ReadOnly = IsReadOnly()
+
+	_, statErr := os.Stat(dbPath)
+	container, err := di.NewContainer(config)
+	if err != nil {
+		return nil, err
+	}
+	if os.IsNotExist(statErr) {
+		warnMissingDatabase(dbPath)
+	}
+	ConfigureIDScheme(container.GetIDSequenceRepository())
+	return container, nil
+}
 
-	return di.NewContainer(config)
+// warnMissingDatabase points to `deespec db repair` when a new database was just created
+// although SBI spec directories exist, i.e. the database of an existing project was lost
+func warnMissingDatabase(dbPath string) {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return
+	}
+	specsSBI := app.GetPathsWithConfig(cfg).SpecsSBI
+	entries, err := os.ReadDir(specsSBI)
+	if err != nil {
+		return
+	}
+	specs := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			specs++
+		}
+	}
+	if specs > 0 {
+		Warn("No database was found at %s, but %s holds %d SBI specs; run `deespec db repair` to rebuild it from them and the journal\n",
+			dbPath, specsSBI, specs)
+	}
 }
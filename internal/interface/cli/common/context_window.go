@@ -0,0 +1,18 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewContextWindowCheck builds the context window pre-check for the project at root from
+// setting.json ("context_window.mode": off, summarize or fail; "context_window.models": context
+// window in tokens per model name or prefix); without a configuration prompts that do not fit
+// the window of their agent are summarized
+func NewContextWindowCheck(root string) *service.ContextWindowCheck {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return service.NewContextWindowCheck(root, service.ContextWindowSummarize, nil)
+	}
+	contextWindow := cfg.ContextWindowConfig()
+	return service.NewContextWindowCheck(root, contextWindow.Mode, contextWindow.Models)
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// NewDatabaseBackupScheduler builds the scheduler of database snapshots from setting.json
+// ("backup.interval_hours", "keep", "upload")
+func NewDatabaseBackupScheduler(backupRepo repository.DatabaseBackupRepository) (*service.DatabaseBackupScheduler, error) {
+	interval, keep := 24*time.Hour, 7
+	if cfg := GetGlobalConfig(); cfg != nil {
+		c := cfg.BackupConfig()
+		if c.IntervalHours > 0 {
+			interval = time.Duration(c.IntervalHours) * time.Hour
+		}
+		if c.Keep > 0 {
+			keep = c.Keep
+		}
+	}
+	store, err := BackupArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	return service.NewDatabaseBackupScheduler(backupRepo, store, interval, keep), nil
+}
+
+// BackupArtifactStore returns the remote artifact store database backups are uploaded to,
+// or nil when "backup.upload" is off or the artifact store is local (backups already are)
+func BackupArtifactStore() (output.ArtifactStore, error) {
+	cfg := GetGlobalConfig()
+	if cfg == nil || !cfg.BackupConfig().Upload {
+		return nil, nil
+	}
+	switch cfg.ArtifactStoreConfig().Type {
+	case "", "local":
+		return nil, nil
+	}
+	return NewArtifactStore()
+}
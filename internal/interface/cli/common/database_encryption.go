@@ -0,0 +1,20 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+)
+
+// EnableDatabaseEncryption resolves the database key from its source and makes every command
+// open the task database with it
+func EnableDatabaseEncryption(keySource string) error {
+	key, err := sqlite.ResolveEncryptionKey(keySource)
+	if err != nil {
+		return fmt.Errorf("database encryption: %w", err)
+	}
+	if err := sqlite.EnableEncryption(key); err != nil {
+		return fmt.Errorf("database encryption: %w", err)
+	}
+	return nil
+}
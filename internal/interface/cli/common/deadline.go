@@ -0,0 +1,31 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseDeadline parses a --deadline flag value
+// Accepts RFC3339 ("2025-10-20T18:00:00+09:00"), "2006-01-02 15:04" or a date ("2025-10-20").
+// A date without time means the end of that day in local time.
+// An empty value returns nil (no deadline).
+func ParseDeadline(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		endOfDay := time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+		return &endOfDay, nil
+	}
+
+	return nil, fmt.Errorf("invalid deadline: %q (use YYYY-MM-DD, \"YYYY-MM-DD HH:MM\" or RFC3339)", value)
+}
@@ -0,0 +1,17 @@
+package common
+
+import (
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewDecisionExtractorChain builds the review decision extractor chain from setting.json
+// ("decision_extraction"); without a configuration the built-in strategy order is used
+func NewDecisionExtractorChain() (*domainservice.DecisionExtractorChain, error) {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return domainservice.NewDefaultDecisionExtractorChain(), nil
+	}
+
+	extractionConfig := cfg.DecisionExtractionConfig()
+	return domainservice.NewDecisionExtractorChain(extractionConfig.Strategies, extractionConfig.Pattern)
+}
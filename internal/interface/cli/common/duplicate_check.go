@@ -0,0 +1,40 @@
+package common
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewDuplicateCheck builds the near-duplicate check of new SBIs and PBIs from setting.json
+// ("duplicate_check.mode": off, warn or block; "duplicate_check.threshold": similarity from
+// 0 to 1); without a configuration duplicates are reported but never refused
+func NewDuplicateCheck() *service.DuplicateCheck {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return service.NewDuplicateCheck(service.DuplicateCheckWarn, service.DefaultDuplicateThreshold)
+	}
+	duplicateCheck := cfg.DuplicateCheckConfig()
+	return service.NewDuplicateCheck(duplicateCheck.Mode, duplicateCheck.Threshold)
+}
+
+// ReportDuplicates writes the near-duplicates of a task being registered ("SBI" or "PBI") to w
+// In block mode an error is returned instead, unless force is set.
+func ReportDuplicates(w io.Writer, check *service.DuplicateCheck, kind string, matches []domainservice.DuplicateMatch, force bool) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	if check.Blocks() && !force {
+		best := matches[0]
+		return fmt.Errorf("%s looks like a duplicate of %s %q (%.0f%% similar; %d similar in total), use --force to register it anyway",
+			kind, best.ID, best.Title, best.Score*100, len(matches))
+	}
+
+	fmt.Fprintf(w, "⚠️  WARNING: Similar %ss already exist:\n", kind)
+	for _, m := range matches {
+		fmt.Fprintf(w, "   - %s %s (%.0f%% similar)\n", m.ID, m.Title, m.Score*100)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package common
+
+import (
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewExecutionSchedule builds the execution windows from setting.json ("scheduling.windows",
+// e.g. ["22:00-06:00"]); without windows, or when they cannot be parsed, turns may start at any time
+func NewExecutionSchedule() *domainservice.ExecutionSchedule {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return nil
+	}
+	schedule, err := domainservice.ParseExecutionSchedule(cfg.SchedulingConfig().Windows)
+	if err != nil {
+		Warn("[Scheduling] %v (scheduling.windows ignored)\n", err)
+		return nil
+	}
+	return schedule
+}
@@ -0,0 +1,17 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewFailurePolicy builds the turn limit failure policy from setting.json
+// ("failure_policy.on_limit": done or failed; "failure_policy.postmortem": analyse failed SBIs);
+// returns nil when SBIs that exhaust their turn limit are force-completed
+func NewFailurePolicy() *service.FailurePolicy {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return nil
+	}
+	failurePolicy := cfg.FailurePolicyConfig()
+	return service.NewFailurePolicy(failurePolicy.OnLimit, failurePolicy.Postmortem)
+}
@@ -0,0 +1,21 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// ConfigureIDScheme sets how the IDs of new tasks are generated from setting.json
+// ("id_scheme.strategy": ulid, date_sequence or prefix; "id_scheme.prefixes": prefix per task
+// type; "id_scheme.digits"), handing out sequential IDs from seqRepo; without a configuration
+// IDs are ULIDs. Commands that open the database themselves call it before creating tasks.
+func ConfigureIDScheme(seqRepo repository.IDSequenceRepository) {
+	cfg := GetGlobalConfig()
+	if cfg == nil || cfg.IDSchemeConfig().Strategy == "" || cfg.IDSchemeConfig().Strategy == model.IDSchemeULID {
+		model.SetIDGenerator(nil)
+		return
+	}
+	scheme := cfg.IDSchemeConfig()
+	model.SetIDGenerator(service.NewTaskIDGenerator(scheme.Strategy, scheme.Prefixes, scheme.Digits, seqRepo))
+}
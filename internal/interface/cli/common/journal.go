@@ -0,0 +1,78 @@
+package common
+
+import (
+	"database/sql"
+	"os"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// NewJournalRepository opens the journal at path with the rotation policy from setting.json
+// ("journal.rotate_max_mb", "journal.rotate_max_age_hours", "journal.keep_full_segments");
+// without a configuration the journal is never rotated
+func NewJournalRepository(path string) *infraRepo.JournalRepositoryImpl {
+	repo := infraRepo.NewJournalRepositoryImpl(path)
+	repo.SetRotationPolicy(JournalRotationPolicy())
+	return repo
+}
+
+// JournalRotationPolicy returns the journal rotation policy configured in setting.json
+func JournalRotationPolicy() infraRepo.JournalRotationPolicy {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return infraRepo.JournalRotationPolicy{}
+	}
+	journal := cfg.JournalConfig()
+	return infraRepo.JournalRotationPolicy{
+		MaxBytes:     int64(journal.RotateMaxMB) * 1024 * 1024,
+		MaxAge:       time.Duration(journal.RotateMaxAgeHours) * time.Hour,
+		KeepSegments: journal.KeepFullSegments,
+	}
+}
+
+// journalSinkCloseTimeout bounds how long a command waits for the journal sinks on exit
+const journalSinkCloseTimeout = 5 * time.Second
+
+// NewJournalSinks builds the additional journal destinations configured in setting.json
+// ("journal.sinks"); the sqlite sink writes to db
+func NewJournalSinks(db *sql.DB) []infraRepo.JournalSink {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return nil
+	}
+	var sinks []infraRepo.JournalSink
+	for _, sink := range cfg.JournalConfig().Sinks {
+		switch sink.Type {
+		case "stdout":
+			sinks = append(sinks, infraRepo.NewStdoutJournalSink())
+		case "file":
+			sinks = append(sinks, infraRepo.NewFileJournalSink(sink.Path))
+		case "sqlite":
+			if db != nil {
+				sinks = append(sinks, sqlite.NewJournalSink(db))
+			}
+		case "http":
+			headers := make(map[string]string, len(sink.Headers))
+			for key, value := range sink.Headers {
+				headers[key] = os.ExpandEnv(value)
+			}
+			sinks = append(sinks, infraRepo.NewHTTPJournalSink(sink.URL, headers, time.Duration(sink.TimeoutSec)*time.Second))
+		}
+	}
+	return sinks
+}
+
+// WithJournalSinks copies the records appended to journal to the configured sinks
+// The returned function delivers the records still buffered and must be called before the
+// command exits; without sinks journal is returned unchanged.
+func WithJournalSinks(journal repository.JournalRepository, db *sql.DB) (repository.JournalRepository, func()) {
+	sinks := NewJournalSinks(db)
+	if len(sinks) == 0 {
+		return journal, func() {}
+	}
+	multiplex := infraRepo.NewMultiplexJournalRepository(journal, sinks, GetGlobalConfig().JournalConfig().SinkBufferSize)
+	return multiplex, func() { multiplex.Close(journalSinkCloseTimeout) }
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// NewMCPResolver builds the MCP resolver from setting.json ("mcp.servers", e.g.
+// {"db-schema": {"command": "npx", "args": ["-y", "db-schema-mcp"]}}); servers are enabled per
+// task by labels (`deespec label mcp`), and without servers no agent gets any
+func NewMCPResolver(labelRepo repository.LabelRepository) *service.MCPResolver {
+	cfg := GetGlobalConfig()
+	if cfg == nil || len(cfg.MCPConfig().Servers) == 0 {
+		return nil
+	}
+
+	servers := make(map[string]output.MCPServer, len(cfg.MCPConfig().Servers))
+	for name, s := range cfg.MCPConfig().Servers {
+		servers[name] = output.MCPServer{
+			Type:    s.Type,
+			Command: s.Command,
+			Args:    s.Args,
+			Env:     s.Env,
+			URL:     s.URL,
+			Headers: s.Headers,
+		}
+	}
+	return service.NewMCPResolver(labelRepo, servers)
+}
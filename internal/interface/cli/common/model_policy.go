@@ -0,0 +1,27 @@
+package common
+
+import (
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewModelPolicy builds the model policy from setting.json ("model_policy.rules", e.g.
+// [{"step": "review", "family": "claude-opus", "min_version": "4.1"}]); invalid rules are
+// skipped with a warning, and without rules steps may run on any model
+func NewModelPolicy() *domainservice.ModelPolicy {
+	cfg := GetGlobalConfig()
+	if cfg == nil || len(cfg.ModelPolicyConfig().Rules) == 0 {
+		return nil
+	}
+
+	var rules []domainservice.ModelPolicyRule
+	for i, r := range cfg.ModelPolicyConfig().Rules {
+		rule := domainservice.ModelPolicyRule{Step: r.Step, Family: r.Family, MinVersion: r.MinVersion}
+		if err := domainservice.ValidateModelPolicyRule(rule); err != nil {
+			Warn("[ModelPolicy] rule %d: %v (rule ignored)\n", i+1, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	policy, _ := domainservice.NewModelPolicy(rules)
+	return policy
+}
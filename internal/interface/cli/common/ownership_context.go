@@ -0,0 +1,19 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewOwnershipProvider builds the git ownership provider for the repository at root from
+// setting.json ("ownership_context.enabled", "max_files", "max_commits"); returns nil when disabled
+func NewOwnershipProvider(root string) *service.OwnershipProvider {
+	cfg := GetGlobalConfig()
+	if cfg == nil || !cfg.OwnershipContextConfig().Enabled {
+		return nil
+	}
+	ownershipContext := cfg.OwnershipContextConfig()
+	provider := service.NewOwnershipProvider(root)
+	provider.SetMaxFiles(ownershipContext.MaxFiles)
+	provider.SetMaxCommits(ownershipContext.MaxCommits)
+	return provider
+}
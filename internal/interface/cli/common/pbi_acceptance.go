@@ -0,0 +1,22 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+)
+
+// NewPBIAcceptanceService builds the PBI acceptance service for the project root from the container
+// Acceptance reviews use the default agent; failures are fed back to reopened SBIs as review feedback.
+func NewPBIAcceptanceService(container *di.Container) *service.PBIAcceptanceService {
+	root := ProjectRoot()
+	svc := service.NewPBIAcceptanceService(
+		container.GetPBIAcceptanceRepository(),
+		persistence.NewPBISQLiteRepository(container.GetDB(), root),
+		container.GetSBIRepository(),
+		root,
+	)
+	svc.SetFeedbackRepository(container.GetReviewFeedbackRepository())
+	svc.SetAgentGateway(container.GetAgentGateway())
+	return svc
+}
@@ -0,0 +1,22 @@
+package common
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
+)
+
+// NewPriorityAging builds the aging of pending SBI priorities from setting.json
+// ("scheduling.aging_interval_hours", 0 disables it; "scheduling.aging_max_priority");
+// without a configuration an SBI gains one level per day of waiting, up to urgent
+func NewPriorityAging() sbi.PriorityAging {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return sbi.PriorityAging{Interval: 24 * time.Hour, MaxPriority: 2}
+	}
+	scheduling := cfg.SchedulingConfig()
+	return sbi.PriorityAging{
+		Interval:    time.Duration(scheduling.AgingIntervalHours) * time.Hour,
+		MaxPriority: scheduling.AgingMaxPriority,
+	}
+}
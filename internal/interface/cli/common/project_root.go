@@ -0,0 +1,56 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+)
+
+// projectRoot is the directory holding .deespec; empty until EnterProjectRoot runs
+// or when no project was found
+var projectRoot string
+
+// invocationDir is the working directory deespec was started in, when EnterProjectRoot
+// left it for the project root
+var invocationDir string
+
+// EnterProjectRoot changes the working directory to the project root (the nearest
+// directory holding .deespec), so that commands run from a subdirectory resolve
+// .deespec/... paths as they do from the root. Outside a project the working
+// directory is left unchanged.
+func EnterProjectRoot() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	root, found := app.FindProjectRoot(cwd)
+	if !found {
+		return nil
+	}
+	projectRoot = root
+	if root == cwd {
+		return nil
+	}
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("failed to enter project root %s: %w", root, err)
+	}
+	invocationDir = cwd
+	return nil
+}
+
+// ProjectRoot returns the absolute project root, or "" outside a project
+func ProjectRoot() string {
+	return projectRoot
+}
+
+// ResolveUserPath resolves a path given on the command line against the directory
+// deespec was started in, which differs from the working directory when it was
+// started in a subdirectory of the project
+func ResolveUserPath(path string) string {
+	if path == "" || path == "-" || filepath.IsAbs(path) || invocationDir == "" {
+		return path
+	}
+	return filepath.Join(invocationDir, path)
+}
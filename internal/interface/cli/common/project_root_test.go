@@ -0,0 +1,54 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnterProjectRoot(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		os.Chdir(cwd)
+		projectRoot, invocationDir = "", ""
+	}()
+
+	project, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	subdir := filepath.Join(project, "internal", "pkg")
+	if err := os.MkdirAll(filepath.Join(project, ".deespec"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(subdir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnterProjectRoot(); err != nil {
+		t.Fatalf("EnterProjectRoot() error = %v", err)
+	}
+	if wd, _ := os.Getwd(); wd != project {
+		t.Errorf("working directory = %s, want the project root %s", wd, project)
+	}
+	if ProjectRoot() != project {
+		t.Errorf("ProjectRoot() = %s, want %s", ProjectRoot(), project)
+	}
+
+	// Command line paths stay relative to where deespec was started
+	if got, want := ResolveUserPath("spec.md"), filepath.Join(subdir, "spec.md"); got != want {
+		t.Errorf("ResolveUserPath(spec.md) = %s, want %s", got, want)
+	}
+	if got := ResolveUserPath("/tmp/spec.md"); got != "/tmp/spec.md" {
+		t.Errorf("ResolveUserPath keeps absolute paths, got %s", got)
+	}
+	if got := ResolveUserPath("-"); got != "-" {
+		t.Errorf("ResolveUserPath keeps stdin, got %s", got)
+	}
+}
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// NewPromptArchive builds the archive of the prompts sent to agents from setting.json
+// ("prompt_archive.enabled", "compress", "retention_days") and prunes the entries past the
+// retention; returns nil when disabled
+func NewPromptArchive() repository.PromptArchiveRepository {
+	cfg := GetGlobalConfig()
+	if cfg == nil || !cfg.PromptArchiveConfig().Enabled {
+		return nil
+	}
+	c := cfg.PromptArchiveConfig()
+	archive := infraRepo.NewPromptArchiveRepositoryImpl(PromptArchiveDir(), c.Compress)
+	if c.RetentionDays > 0 {
+		before := time.Now().AddDate(0, 0, -c.RetentionDays)
+		if _, err := archive.Prune(context.Background(), before); err != nil {
+			Warn("%v\n", err)
+		}
+	}
+	return archive
+}
+
+// PromptArchiveDir returns the directory of the prompt archive (.deespec/var/prompts)
+func PromptArchiveDir() string {
+	return filepath.Join(app.GetPathsWithConfig(GetGlobalConfig()).Var, "prompts")
+}
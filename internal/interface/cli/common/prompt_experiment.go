@@ -0,0 +1,32 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewPromptExperiment builds the prompt experiment from setting.json ("prompt_experiment", e.g.
+// {"enabled": true, "name": "wip-v2", "template": "WIP.md", "variant": "experiments/WIP.v2.md"});
+// an invalid experiment or a missing variant template disables it with a warning, since the
+// treatment arm would silently fall back to the built-in prompt
+func NewPromptExperiment() *domainservice.PromptExperiment {
+	cfg := GetGlobalConfig()
+	if cfg == nil || !cfg.PromptExperimentConfig().Enabled {
+		return nil
+	}
+
+	c := cfg.PromptExperimentConfig()
+	experiment := &domainservice.PromptExperiment{Name: c.Name, Template: c.Template, Variant: c.Variant}
+	if err := domainservice.ValidatePromptExperiment(*experiment); err != nil {
+		Warn("[PromptExperiment] %v (experiment disabled)\n", err)
+		return nil
+	}
+	variantPath := filepath.Join(ProjectRoot(), ".deespec", "prompts", filepath.FromSlash(c.Variant))
+	if _, err := os.Stat(variantPath); err != nil {
+		Warn("[PromptExperiment] variant template not found: %s (experiment disabled)\n", variantPath)
+		return nil
+	}
+	return experiment
+}
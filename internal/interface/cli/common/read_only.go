@@ -0,0 +1,128 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ErrReadOnly is returned when a command would mutate the database or filesystem in read-only mode
+var ErrReadOnly = errors.New("read-only mode")
+
+// ReadOnlyDeferAnnotation marks commands that re-run the root command (aliases);
+// the read-only check is applied to the command they expand to instead
+const ReadOnlyDeferAnnotation = "deespec/read-only-defer"
+
+// readOnly is set from the --read-only flag or setting.json "read_only"
+var readOnly bool
+
+// readOnlySafeCommands lists the commands (path without the root name) that never
+// mutate the database or filesystem. Everything else is refused in read-only mode,
+// so new commands are safe by default until they are added here.
+var readOnlySafeCommands = map[string]bool{
+	"help":               true,
+	"completion":         true,
+	"__complete":         true,
+	"__completeNoDesc":   true,
+	"version":            true,
+	"stats":              true,
+	"stats errors":       true,
+	"stats experiment":   true,
+	"status":             true,
+	"budget":             true,
+	"analyze list":       true,
+	"changelog":          true, // --output is refused separately
+	"digest":             true, // --output is refused separately
+	"config":             true,
+	"config validate":    true,
+	"archive list":       true,
+	"archive search":     true,
+	"trash":              true,
+	"trash list":         true,
+	"db":                 true,
+	"db check":           true,
+	"backup":             true,
+	"backup list":        true,
+	"doctor":             true,
+	"health":             true,
+	"health verify":      true,
+	"import":             true,
+	"journal":            true,
+	"journal verify":     true,
+	"journal segments":   true,
+	"sbi":                true,
+	"sbi list":           true,
+	"sbi show":           true,
+	"sbi history":        true,
+	"sbi prompts":        true,
+	"sbi compare":        true,
+	"sbi criteria":       true, // --waive is refused separately
+	"sbi estimate":       true, // only --prompt-only is allowed
+	"sbi issues":         true, // --close is refused separately
+	"sbi note":           true,
+	"sbi note list":      true,
+	"sbi ref":            true,
+	"sbi ref list":       true,
+	"epic":               true,
+	"epic list":          true,
+	"epic show":          true,
+	"pbi":                true,
+	"pbi list":           true,
+	"pbi show":           true,
+	"pbi sbi":            true,
+	"pbi sbi list":       true,
+	"pbi acceptance":     true,
+	"priority":           true,
+	"priority propagate": true, // Refused separately unless --dry-run
+	"label":              true,
+	"label list":         true,
+	"label show":         true,
+	"label templates":    true,
+	"label validate":     true, // --sync is refused separately
+	"lock":               true,
+	"lock list":          true,
+	"lock info":          true,
+	"token":              true,
+	"token list":         true,
+	"token audit":        true,
+}
+
+// SetReadOnly enables or disables read-only mode
+func SetReadOnly(enabled bool) {
+	readOnly = enabled
+}
+
+// IsReadOnly reports whether read-only mode is enabled
+func IsReadOnly() bool {
+	return readOnly
+}
+
+// CheckWritable returns ErrReadOnly in read-only mode; action describes the refused mutation
+func CheckWritable(action string) error {
+	if readOnly {
+		return fmt.Errorf("%w: refusing to %s", ErrReadOnly, action)
+	}
+	return nil
+}
+
+// EnforceReadOnly refuses to run cmd in read-only mode unless it is known to be read-only safe
+func EnforceReadOnly(cmd *cobra.Command) error {
+	if !readOnly {
+		return nil
+	}
+
+	if _, deferred := cmd.Annotations[ReadOnlyDeferAnnotation]; deferred {
+		return nil
+	}
+
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != nil {
+		path = strings.TrimSpace(strings.TrimPrefix(path, root.Name()))
+	}
+	if path == "" || readOnlySafeCommands[path] || strings.HasPrefix(path, "completion ") {
+		return nil
+	}
+	return fmt.Errorf("%w: 'deespec %s' may modify the database or filesystem", ErrReadOnly, path)
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestEnforceReadOnly(t *testing.T) {
+	root := &cobra.Command{Use: "deespec"}
+	sbi := &cobra.Command{Use: "sbi"}
+	list := &cobra.Command{Use: "list"}
+	register := &cobra.Command{Use: "register"}
+	alias := &cobra.Command{Use: "ls", Annotations: map[string]string{ReadOnlyDeferAnnotation: "true"}}
+	run := &cobra.Command{Use: "run"}
+	sbi.AddCommand(list, register)
+	root.AddCommand(sbi, alias, run)
+
+	defer SetReadOnly(false)
+
+	// Everything runs when read-only mode is off
+	SetReadOnly(false)
+	if err := EnforceReadOnly(run); err != nil {
+		t.Fatalf("EnforceReadOnly() error = %v, want nil", err)
+	}
+
+	SetReadOnly(true)
+	tests := []struct {
+		cmd     *cobra.Command
+		allowed bool
+	}{
+		{root, true},
+		{list, true},
+		{alias, true},
+		{register, false},
+		{run, false},
+	}
+	for _, tt := range tests {
+		err := EnforceReadOnly(tt.cmd)
+		if tt.allowed && err != nil {
+			t.Errorf("%s: EnforceReadOnly() error = %v, want nil", tt.cmd.CommandPath(), err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: EnforceReadOnly() error = %v, want ErrReadOnly", tt.cmd.CommandPath(), err)
+		}
+	}
+
+	if err := CheckWritable("waive acceptance criteria"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("CheckWritable() error = %v, want ErrReadOnly", err)
+	}
+}
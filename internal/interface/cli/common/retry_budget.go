@@ -0,0 +1,20 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// defaultMaxTurns matches the run command's turn limit without a configuration
+const defaultMaxTurns = 8
+
+// NewRetryBudget builds the retry budget from the turn limit in setting.json ("max_turns")
+// and the workflow's attempt limit; notifier may be nil when no notifications are sent
+func NewRetryBudget(notifier output.NotificationGateway) *service.RetryBudget {
+	maxTurns := defaultMaxTurns
+	if cfg := GetGlobalConfig(); cfg != nil && cfg.MaxTurns() > 0 {
+		maxTurns = cfg.MaxTurns()
+	}
+	return service.NewRetryBudget(maxTurns, domainservice.DefaultMaxAttempts, notifier)
+}
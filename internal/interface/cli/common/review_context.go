@@ -0,0 +1,22 @@
+package common
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewReviewContextBuilder builds the review context bundle builder for the project at root from
+// setting.json ("review_context.enabled", "max_diff_kb", "test_command", "test_timeout_sec");
+// returns nil when disabled
+func NewReviewContextBuilder(root string) *service.ReviewContextBuilder {
+	cfg := GetGlobalConfig()
+	if cfg == nil || !cfg.ReviewContextConfig().Enabled {
+		return nil
+	}
+	reviewContext := cfg.ReviewContextConfig()
+	builder := service.NewReviewContextBuilder(root)
+	builder.SetMaxDiffBytes(reviewContext.MaxDiffKB * 1024)
+	builder.SetTestCommand(reviewContext.TestCommand, time.Duration(reviewContext.TestTimeoutSec)*time.Second)
+	return builder
+}
@@ -0,0 +1,19 @@
+package common
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewReviewTimeout builds the review decision SLA from setting.json
+// ("review_timeout.timeout_sec": seconds to wait, 0 to wait indefinitely;
+// "review_timeout.policy": retry or force_implement); returns nil without an SLA
+func NewReviewTimeout() *service.ReviewTimeout {
+	cfg := GetGlobalConfig()
+	if cfg == nil {
+		return nil
+	}
+	reviewTimeout := cfg.ReviewTimeoutConfig()
+	return service.NewReviewTimeout(time.Duration(reviewTimeout.TimeoutSec)*time.Second, reviewTimeout.Policy)
+}
@@ -0,0 +1,22 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewSpecPreflight builds the spec quality pre-flight check from setting.json
+// ("spec_preflight.mode": off, warn or block; "spec_preflight.ignore": checks to skip);
+// without a configuration warnings are reported but never block an SBI
+func NewSpecPreflight() *service.SpecPreflight {
+	mode := service.SpecPreflightWarn
+	var ignore []string
+	cfg := GetGlobalConfig()
+	if cfg != nil {
+		preflight := cfg.SpecPreflightConfig()
+		mode = preflight.Mode
+		ignore = preflight.Ignore
+	}
+	paths := app.GetPathsWithConfig(cfg)
+	return service.NewSpecPreflight(mode, ignore, paths.SpecsSBI, paths.SpecsPBI)
+}
@@ -0,0 +1,23 @@
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// NewStatusLabels builds the SBI status display labels from setting.json ("status_labels");
+// invalid labels are reported and the workflow labels are used instead
+func NewStatusLabels() *service.StatusLabels {
+	var overrides map[string]string
+	if cfg := GetGlobalConfig(); cfg != nil {
+		overrides = cfg.StatusLabels()
+	}
+	labels, err := service.NewStatusLabels(overrides)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  WARNING: Ignoring status_labels: %v\n", err)
+		labels, _ = service.NewStatusLabels(nil)
+	}
+	return labels
+}
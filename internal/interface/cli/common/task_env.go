@@ -0,0 +1,42 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// ParseEnvAssignments parses repeated --env KEY=VALUE flag values
+// Values may reference task_env.vars from setting.json as ${name}; they are expanded when a step runs.
+// No assignments returns nil.
+func ParseEnvAssignments(assignments []string) (map[string]string, error) {
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	env := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		key, value, ok := strings.Cut(assignment, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("invalid env: %q (use KEY=VALUE)", assignment)
+		}
+		if err := service.ValidateTaskEnvKey(key); err != nil {
+			return nil, err
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// FormatEnv renders env as sorted KEY=VALUE pairs for display
+func FormatEnv(env map[string]string) []string {
+	lines := make([]string, 0, len(env))
+	for key, value := range env {
+		lines = append(lines, key+"="+value)
+	}
+	sort.Strings(lines)
+	return lines
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+)
+
+// NewTemplateVarResolver builds the resolver of custom prompt template variables for the project
+// at root from setting.json ("template_vars.vars", "command_timeout_sec"); invalid variables are
+// skipped with a warning, and nil is returned when none are declared
+func NewTemplateVarResolver(root string) *service.TemplateVarResolver {
+	cfg := GetGlobalConfig()
+	if cfg == nil || len(cfg.TemplateVarsConfig().Vars) == 0 {
+		return nil
+	}
+	templateVars := cfg.TemplateVarsConfig()
+
+	vars := make(map[string]domainservice.TemplateVar, len(templateVars.Vars))
+	for name, v := range templateVars.Vars {
+		templateVar := domainservice.TemplateVar{Value: v.Value, Command: v.Command}
+		if err := domainservice.ValidateTemplateVar(name, templateVar); err != nil {
+			Warn("[TemplateVars] %s: %v (variable ignored)\n", name, err)
+			continue
+		}
+		vars[name] = templateVar
+	}
+	resolver := service.NewTemplateVarResolver(root, vars)
+	resolver.SetCommandTimeout(time.Duration(templateVars.CommandTimeoutSec) * time.Second)
+	return resolver
+}
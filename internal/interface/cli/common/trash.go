@@ -0,0 +1,24 @@
+package common
+
+import (
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+// defaultTrashRetentionDays is used when no configuration is loaded
+const defaultTrashRetentionDays = 30
+
+// NewTrashUseCase builds the trash use case with the .deespec directory and the retention
+// window of setting.json ("trash.retention_days")
+func NewTrashUseCase(trashRepo repository.TrashRepository) *usecase.TrashUseCase {
+	cfg := GetGlobalConfig()
+	retentionDays := defaultTrashRetentionDays
+	if cfg != nil {
+		retentionDays = cfg.TrashConfig().RetentionDays
+	}
+	paths := app.GetPathsWithConfig(cfg)
+	return usecase.NewTrashUseCase(trashRepo, paths.Home, time.Duration(retentionDays)*24*time.Hour)
+}
@@ -0,0 +1,20 @@
+package common
+
+import (
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+)
+
+// NewTurnBudget builds the per-PBI turn and daily agent call budgets from setting.json
+// ("scheduling.max_turns_per_pbi", "scheduling.max_agent_calls_per_day"); without a
+// configuration both budgets are unlimited, but agent calls are still recorded
+func NewTurnBudget(container *di.Container) *service.TurnBudget {
+	var maxTurnsPerPBI, maxAgentCallsPerDay int
+	if cfg := GetGlobalConfig(); cfg != nil {
+		scheduling := cfg.SchedulingConfig()
+		maxTurnsPerPBI = scheduling.MaxTurnsPerPBI
+		maxAgentCallsPerDay = scheduling.MaxAgentCallsPerDay
+	}
+	return service.NewTurnBudget(maxTurnsPerPBI, maxAgentCallsPerDay,
+		container.GetSBIRepository(), container.GetAgentCallRepository())
+}
@@ -0,0 +1,84 @@
+package config_cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	infraConfig "github.com/YoshitsuguKoike/deespec/internal/infra/config"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the config command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Configuration (setting.json) commands",
+		RunE:  func(c *cobra.Command, _ []string) error { return c.Help() },
+	}
+	cmd.AddCommand(newConfigValidateCmd())
+	return cmd
+}
+
+func newConfigValidateCmd() *cobra.Command {
+	var filePath string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate setting.json against the configuration schema",
+		Long: `Validate setting.json against the configuration schema.
+
+Reports every unknown key (with the closest known key, for typos), value of
+the wrong type and value out of range. Commands refuse to start with an
+invalid setting.json, so run this after editing it.
+
+Exits with status 1 when problems are found.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigValidate(filePath, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&filePath, "path", ".deespec/setting.json", "Path to setting.json file")
+	cmd.Flags().StringVar(&format, "format", "", "Output format (json for CI integration)")
+	return cmd
+}
+
+// validateResult is the JSON output of config validate
+type validateResult struct {
+	File   string                     `json:"file"`
+	Valid  bool                       `json:"valid"`
+	Issues []infraConfig.SettingIssue `json:"issues"`
+}
+
+func runConfigValidate(filePath, format string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	issues := infraConfig.ValidateSettings(data)
+	if format == "json" {
+		if issues == nil {
+			issues = []infraConfig.SettingIssue{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(validateResult{File: filePath, Valid: len(issues) == 0, Issues: issues}); err != nil {
+			return err
+		}
+	} else if len(issues) == 0 {
+		fmt.Printf("OK: %s is valid\n", filePath)
+	} else {
+		for _, issue := range issues {
+			fmt.Printf("ERROR: %s %s\n", filePath, issue)
+		}
+		fmt.Printf("SUMMARY: %d problem(s)\n", len(issues))
+	}
+
+	if len(issues) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
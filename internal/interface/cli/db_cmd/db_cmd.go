@@ -0,0 +1,261 @@
+package db_cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the db command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Check and repair the task database",
+		Long: `Check and repair the SQLite task database (.deespec/deespec.db).
+
+Every command runs an integrity check when it opens the database and refuses
+a damaged one. Before a new deespec version migrates the schema, the database
+is backed up into .deespec/backups (the newest 3 backups are kept).`,
+		RunE: func(c *cobra.Command, _ []string) error { return c.Help() },
+	}
+	cmd.AddCommand(newCheckCmd())
+	cmd.AddCommand(newRepairCmd())
+	return cmd
+}
+
+// checkReport is the JSON output of db check
+type checkReport struct {
+	Path     string   `json:"path"`
+	Healthy  bool     `json:"healthy"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+func newCheckCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Run the SQLite integrity check on the task database",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := common.ResolveDBPath()
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("database not found: %w", err)
+			}
+			problems, err := checkDatabase(path)
+			if err != nil && !errors.Is(err, sqlite.ErrDatabaseCorrupted) {
+				return err
+			}
+			report := checkReport{Path: path, Healthy: err == nil, Problems: problems}
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if encErr := encoder.Encode(report); encErr != nil {
+					return encErr
+				}
+			} else if report.Healthy {
+				fmt.Printf("✓ %s passed the integrity check\n", path)
+			} else {
+				fmt.Printf("✗ %s is damaged:\n", path)
+				for _, problem := range problems {
+					fmt.Printf("  - %s\n", problem)
+				}
+				if len(problems) == 0 {
+					fmt.Printf("  - %v\n", err)
+				}
+				fmt.Println("\nRun 'deespec db repair' to rebuild it.")
+			}
+			if !report.Healthy {
+				return fmt.Errorf("database integrity check failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// repairReport is the JSON output of db repair
+type repairReport struct {
+	Path     string                 `json:"path"`
+	Created  bool                   `json:"created"`            // A new database was created
+	MovedTo  string                 `json:"moved_to,omitempty"` // Where the previous database was moved
+	Problems []string               `json:"problems,omitempty"`
+	Salvaged []sqlite.SalvagedTable `json:"salvaged,omitempty"`
+	Rebuilt  []usecase.RebuiltTask  `json:"rebuilt"`
+}
+
+func newRepairCmd() *cobra.Command {
+	var (
+		force      bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "repair",
+		Short: "Rebuild a damaged or missing task database from its rows, the journal and the spec files",
+		Long: `Rebuild the task database when it is damaged or missing:
+
+1. A damaged database is moved aside to deespec.db.damaged-<time> (never deleted).
+2. A new database is created with the current schema.
+3. Every row that can still be read from the damaged database is copied into it,
+   table by table, skipping the unreadable pages.
+4. The PBIs and SBIs still missing are restored from .deespec/specs:
+   pbi.md and spec.md give their titles and bodies (spec.md also the parent PBI
+   and labels), and the journal gives each SBI its last turn and outcome.
+   DONE, FAILED and CANCELLED SBIs keep their status; the others come back
+   pending so that the next run picks them up again.
+
+A database that passes the integrity check is kept and only step 4 runs,
+unless --force rebuilds it all the same. Stop 'deespec run' and any other
+command using the database first.`,
+		Example: `  # Check the database first
+  deespec db check
+
+  # Rebuild it
+  deespec db repair`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := repairDatabase(cmd.Context(), force)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+			printRepairReport(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Rebuild the database even when it passes the integrity check")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// repairDatabase rebuilds a damaged or missing database (any database with force), then restores
+// the tasks missing from it
+func repairDatabase(ctx context.Context, force bool) (*repairReport, error) {
+	path, err := common.ResolveDBPath()
+	if err != nil {
+		return nil, err
+	}
+	report := &repairReport{Path: path, Created: true}
+
+	if _, err := os.Stat(path); err == nil {
+		problems, err := checkDatabase(path)
+		if err != nil && !errors.Is(err, sqlite.ErrDatabaseCorrupted) {
+			return nil, err
+		}
+		report.Problems = problems
+		if err == nil && !force {
+			report.Created = false
+		} else if report.MovedTo, err = sqlite.MoveDatabaseAside(path, "damaged"); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open("sqlite3", sqlite.DSN(path, "_foreign_keys=on", "_journal_mode=WAL", "_busy_timeout="+fmt.Sprint(sqlite.DefaultBusyTimeoutMs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+	defer db.Close()
+	if err := sqlite.NewMigrator(db).Migrate(); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	if report.MovedTo != "" {
+		if report.Salvaged, err = sqlite.SalvageDatabase(ctx, db, report.MovedTo); err != nil {
+			// Nothing could be read: the spec files and the journal are all that is left
+			common.Warn("Could not read the damaged database, rebuilding from the spec files only: %v\n", err)
+		}
+	}
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	uc := usecase.NewRebuildTasksUseCase(
+		sqlite.NewSBIRepository(db),
+		persistence.NewPBISQLiteRepository(db, common.ProjectRoot()),
+		common.NewJournalRepository(paths.Journal),
+		paths.SpecsSBI,
+		paths.SpecsPBI,
+	)
+	result, err := uc.Execute(ctx)
+	if result != nil {
+		report.Rebuilt = result.Tasks
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild tasks from the spec files: %w", err)
+	}
+	return report, nil
+}
+
+// checkDatabase runs the integrity check on the database file at path
+func checkDatabase(path string) ([]string, error) {
+	db, err := sql.Open("sqlite3", sqlite.DSN(path, "mode=ro"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	return sqlite.CheckIntegrity(db)
+}
+
+// printRepairReport prints what the repair did
+func printRepairReport(report *repairReport) {
+	switch {
+	case report.MovedTo != "":
+		fmt.Printf("Moved the previous database to %s\n", report.MovedTo)
+		for _, problem := range report.Problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+	case report.Created:
+		fmt.Printf("No database was found at %s; created a new one\n", report.Path)
+	default:
+		fmt.Printf("✓ %s passed the integrity check; restoring the tasks missing from it\n", report.Path)
+	}
+
+	var rows, failed int64
+	for _, table := range report.Salvaged {
+		rows += table.Rows
+		failed += table.Failed
+		if table.Failed > 0 || table.Error != "" {
+			fmt.Printf("  ⚠ %s: %d rows copied, %d unreadable", table.Table, table.Rows, table.Failed)
+			if table.Error != "" {
+				fmt.Printf(" (%s)", table.Error)
+			}
+			fmt.Println()
+		}
+	}
+	if len(report.Salvaged) > 0 {
+		fmt.Printf("Salvaged %d rows from %d tables (%d unreadable)\n", rows, len(report.Salvaged), failed)
+	}
+
+	rebuilt := usecase.RebuildTasksResult{Tasks: report.Rebuilt}
+	for _, task := range rebuilt.Tasks {
+		if task.Error != "" {
+			fmt.Printf("  ✗ %s %s: %s\n", task.Kind, task.TaskID, task.Error)
+			continue
+		}
+		fmt.Printf("  ✓ %s %s restored as %s: %s\n", task.Kind, task.TaskID, task.Status, task.Title)
+	}
+	fmt.Printf("Restored %d tasks from the spec files and the journal\n", rebuilt.Restored())
+	if report.Created {
+		fmt.Printf("✓ %s rebuilt\n", report.Path)
+	}
+}
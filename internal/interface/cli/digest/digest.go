@@ -0,0 +1,247 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// maxFindings caps the review findings listed in the Markdown digest
+const maxFindings = 10
+
+// digestFlags holds the flags for the digest command
+type digestFlags struct {
+	since      string
+	until      string
+	jsonOutput bool
+	output     string
+	post       bool
+}
+
+// NewCommand creates the digest command
+func NewCommand() *cobra.Command {
+	flags := &digestFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Summarize recent work for a daily standup",
+		Long: `Summarize the work of a period as Markdown for a daily standup:
+  - SBIs completed and failed in the period
+  - SBIs in progress now, flagging those close to their turn limit
+  - review findings raised in the period, blocking ones first
+  - the effort spent: agent calls, turns, steps and execution time
+
+--since and --until take "today", "yesterday" (midnight, local time), a duration
+back from now (e.g. 12h or 7d), a date (YYYY-MM-DD, local time) or an RFC 3339
+time. The period includes --since and excludes --until, which defaults to now.
+
+--post sends the digest through the configured notification channels
+(webhook and/or email, see notification_config in setting.json).`,
+		Example: `  # What happened since the start of yesterday
+  deespec digest
+
+  # The last week, posted to the team channel
+  deespec digest --since 7d --post
+
+  # Yesterday only, as JSON
+  deespec digest --since yesterday --until today --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDigest(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.since, "since", "yesterday", "Start of the period: today, yesterday, a duration, a date or a time")
+	cmd.Flags().StringVar(&flags.until, "until", "", "End of the period (default now)")
+	cmd.Flags().BoolVar(&flags.jsonOutput, "json", false, "Output the digest in JSON format")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "Write the digest to this file instead of stdout")
+	cmd.Flags().BoolVar(&flags.post, "post", false, "Also send the digest through the notification channels")
+	return cmd
+}
+
+// runDigest builds, prints and optionally posts the digest
+func runDigest(ctx context.Context, flags *digestFlags) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now()
+
+	var opts usecase.DigestOptions
+	var err error
+	if opts.Since, err = resolveTime(flags.since, now); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if flags.until != "" {
+		if opts.Until, err = resolveTime(flags.until, now); err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if flags.output != "" {
+		// Checked up front so that a refused write does not follow a posted digest
+		if err := common.CheckWritable("write " + flags.output); err != nil {
+			return err
+		}
+	}
+
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	uc := usecase.NewDigestUseCase(
+		container.GetSBIRepository(),
+		common.NewJournalRepository(paths.Journal),
+		container.GetReviewIssueRepository(),
+		container.GetAgentCallRepository(),
+		common.NewRetryBudget(nil),
+	)
+	digest, err := uc.Execute(ctx, opts)
+	if err != nil {
+		return err
+	}
+	for _, w := range digest.Warnings {
+		common.Warn("could not read %s\n", w)
+	}
+
+	markdown := renderMarkdown(digest)
+	if flags.post {
+		if err := container.GetNotificationGateway().Notify(ctx, digest.Notification(markdown)); err != nil {
+			return fmt.Errorf("failed to post digest: %w", err)
+		}
+	}
+
+	out := io.Writer(os.Stdout)
+	if flags.output != "" {
+		file, err := os.Create(common.ResolveUserPath(flags.output))
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", flags.output, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if flags.jsonOutput {
+		b, err := json.MarshalIndent(digest, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal json: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	}
+	_, err = io.WriteString(out, markdown)
+	return err
+}
+
+// resolveTime parses today, yesterday, a duration back from now (hours and minutes, or days
+// as "7d"), a date or an RFC 3339 time
+func resolveTime(value string, now time.Time) (time.Time, error) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value {
+	case "today":
+		return midnight, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither today, yesterday, a duration (e.g. 12h, 7d), a date (YYYY-MM-DD) nor an RFC 3339 time", value)
+}
+
+// renderMarkdown renders the digest as Markdown
+func renderMarkdown(digest *usecase.Digest) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Digest %s – %s\n\n", formatTime(digest.Since), formatTime(digest.Until))
+	fmt.Fprintf(&sb, "%d completed, %d in progress, %d failed, %d review finding(s).\n",
+		len(digest.Completed), len(digest.InProgress), len(digest.Failed), len(digest.ReviewFindings))
+
+	writeSBIs(&sb, "Completed", digest.Completed, false)
+	writeSBIs(&sb, "In progress", digest.InProgress, true)
+	writeSBIs(&sb, "Failed", digest.Failed, false)
+
+	if len(digest.ReviewFindings) > 0 {
+		sb.WriteString("\n## Review findings\n\n")
+		for i, finding := range digest.ReviewFindings {
+			if i == maxFindings {
+				fmt.Fprintf(&sb, "- … and %d more\n", len(digest.ReviewFindings)-maxFindings)
+				break
+			}
+			blocking := ""
+			if finding.Blocking {
+				blocking = " **blocking**"
+			}
+			fmt.Fprintf(&sb, "- ISSUE-%d%s on %s (%s): %s [%s]\n", finding.Number, blocking, finding.SBITitle, finding.SBIID,
+				firstLine(finding.Description), strings.ToLower(finding.Status))
+		}
+	}
+
+	cost := digest.Cost
+	sb.WriteString("\n## Cost\n\n")
+	if cost.AgentCalls >= 0 {
+		fmt.Fprintf(&sb, "- Agent calls: %d\n", cost.AgentCalls)
+	}
+	fmt.Fprintf(&sb, "- Turns: %d (%d steps, %d with errors)\n", cost.Turns, cost.Steps, cost.Errors)
+	fmt.Fprintf(&sb, "- Execution time: %s\n", (time.Duration(cost.ExecutionMs) * time.Millisecond).Round(time.Second))
+	return sb.String()
+}
+
+// writeSBIs lists SBIs under a heading, e.g. "## Completed (2)", with their status when withStatus is set
+func writeSBIs(sb *strings.Builder, heading string, sbis []usecase.DigestSBI, withStatus bool) {
+	if len(sbis) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s (%d)\n\n", heading, len(sbis))
+	for _, s := range sbis {
+		fmt.Fprintf(sb, "- %s (%s, ", s.Title, s.SBIID)
+		if s.MaxTurns > 0 {
+			fmt.Fprintf(sb, "turn %d of %d", s.Turn, s.MaxTurns)
+		} else {
+			fmt.Fprintf(sb, "turn %d", s.Turn)
+		}
+		if withStatus {
+			fmt.Fprintf(sb, ", %s", strings.ToLower(s.Status))
+		}
+		sb.WriteString(")")
+		if s.AtRisk {
+			sb.WriteString(" — at risk of hitting the turn limit")
+		}
+		if s.Error != "" {
+			fmt.Fprintf(sb, ": %s", firstLine(s.Error))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// firstLine returns the first line of a possibly multi-line text
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return line
+}
+
+// formatTime renders a period bound in local time
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
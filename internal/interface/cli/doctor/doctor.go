@@ -115,17 +115,21 @@ func newDoctorCmd() *cobra.Command {
 			} else {
 				fmt.Printf("OK: %s found\n", agentBin)
 			}
-			if err := os.MkdirAll(paths.SpecsSBI, 0o755); err != nil {
-				return fmt.Errorf("specs/sbi dir error: %w", err)
-			}
-			probeFile := filepath.Join(paths.Var, ".probe")
-			if f, err := os.Create(probeFile); err != nil {
-				return fmt.Errorf("write check failed: %w", err)
+			if common.IsReadOnly() {
+				fmt.Println("SKIP: write permission check (read-only mode)")
 			} else {
-				f.Close()
-				os.Remove(probeFile) // Clean up probe file
+				if err := os.MkdirAll(paths.SpecsSBI, 0o755); err != nil {
+					return fmt.Errorf("specs/sbi dir error: %w", err)
+				}
+				probeFile := filepath.Join(paths.Var, ".probe")
+				if f, err := os.Create(probeFile); err != nil {
+					return fmt.Errorf("write check failed: %w", err)
+				} else {
+					f.Close()
+					os.Remove(probeFile) // Clean up probe file
+				}
+				fmt.Println("OK: write permission in var dir")
 			}
-			fmt.Println("OK: write permission in var dir")
 
 			// Note: workflow.yaml validation removed - now using template-based prompts
 			// Prompt templates are in .deespec/prompts/WIP.md, REVIEW.md, REVIEW_AND_WIP.md
@@ -176,6 +180,7 @@ func newDoctorCmd() *cobra.Command {
 				} else {
 					fmt.Printf("OK: journal.ndjson found and valid at %s\n", paths.Journal)
 				}
+				printJournalDedupReport(paths.Journal)
 			}
 
 			// Check review_policy.yaml exists
@@ -315,6 +320,14 @@ func checkScheduler() {
 }
 
 func checkWritable(dir string) error {
+	if common.IsReadOnly() {
+		// Read-only mode: only check that the directory exists
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("cannot access directory: %w", err)
+		}
+		return nil
+	}
+
 	if _, err := os.Stat(dir); err != nil {
 		if os.IsNotExist(err) {
 			// Try to create the directory
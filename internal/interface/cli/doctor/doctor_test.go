@@ -637,3 +637,36 @@ steps:
 	// Note: We can't easily test os.Exit() behavior in unit tests
 	// That would require a separate integration test
 }
+
+func TestCheckJournalDuplicates(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "journal.ndjson")
+	lines := []string{
+		`{"timestamp":"2025-01-01T00:00:00Z","sbi_id":"SBI-1","turn":1,"step":"implement"}`,
+		`{"timestamp":"2025-01-01T00:00:00Z","sbi_id":"SBI-1","turn":1,"step":"implement"}`,
+		`{"timestamp":"2025-01-01T00:00:01Z","sbi_id":"SBI-1","turn":1,"step":"implement"}`,
+		`{"timestamp":"2025-01-01T00:00:02Z","sbi_id":"SBI-1","turn":1,"step":"review"}`,
+		`{"timestamp":"2025-01-01T00:00:03Z","sbi_id":"SBI-1","turn":1,"step":"review","seq":1}`,
+		`{"timestamp":"2025-01-01T00:00:03Z","sbi_id":"SBI-2","turn":4,"step":"done"}`,
+		`{"timestamp":"2025-01-01T00:00:03Z","sbi_id":"SBI-2","turn":4,"step":"done"}`,
+		`{"timestamp":"2025-01-01T00:00:03Z","sbi_id":"SBI-2","turn":4,"step":"done"}`,
+		`{"timestamp":"2025-01-01T00:00:06Z","turn":1,"step":"legacy"}`,
+		`{"timestamp":"2025-01-01T00:00:06Z","turn":1,"step":"legacy"}`,
+	}
+	if err := os.WriteFile(journalPath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write journal: %v", err)
+	}
+
+	duplicates, err := checkJournalDuplicates(journalPath)
+	if err != nil {
+		t.Fatalf("checkJournalDuplicates failed: %v", err)
+	}
+	if len(duplicates) != 2 {
+		t.Fatalf("Expected 2 duplicate keys, got %v", duplicates)
+	}
+	if duplicates[0].Key != "SBI-1/1/implement" || duplicates[0].Count != 3 {
+		t.Errorf("Unexpected first duplicate: %+v", duplicates[0])
+	}
+	if duplicates[1].Key != "SBI-2/4/done" || duplicates[1].Count != 3 {
+		t.Errorf("Unexpected second duplicate: %+v", duplicates[1])
+	}
+}
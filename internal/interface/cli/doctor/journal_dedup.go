@@ -0,0 +1,48 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+)
+
+// maxReportedJournalDuplicates caps the duplicate keys listed by doctor
+const maxReportedJournalDuplicates = 10
+
+// checkJournalDuplicates returns the journal idempotency keys (sbi_id/turn/step) that occur more than once
+// New appends are deduplicated by the journal repository; duplicates found here predate that or were written by hand
+func checkJournalDuplicates(path string) ([]repository.JournalDuplicate, error) {
+	records, err := infraRepo.NewJournalRepositoryImpl(path).Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return repository.FindJournalDuplicates(records), nil
+}
+
+// printJournalDedupReport prints the journal deduplication report
+func printJournalDedupReport(path string) {
+	duplicates, err := checkJournalDuplicates(path)
+	if err != nil {
+		fmt.Printf("WARN: journal.ndjson dedup check failed: %v\n", err)
+		return
+	}
+	if len(duplicates) == 0 {
+		fmt.Println("OK: journal.ndjson has no duplicate entries (sbi_id/turn/step)")
+		return
+	}
+
+	extra := 0
+	for _, d := range duplicates {
+		extra += d.Count - 1
+	}
+	fmt.Printf("WARN: journal.ndjson has %d duplicate entries across %d keys (sbi_id/turn/step)\n", extra, len(duplicates))
+	for i, d := range duplicates {
+		if i == maxReportedJournalDuplicates {
+			fmt.Printf("  ... and %d more\n", len(duplicates)-maxReportedJournalDuplicates)
+			break
+		}
+		fmt.Printf("  %s x%d\n", d.Key, d.Count)
+	}
+}
@@ -0,0 +1,65 @@
+package epic
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAttachCommand creates a new attach command
+func NewAttachCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach <epic-id> <pbi-id>...",
+		Short: "Attach PBIs to an EPIC",
+		Long:  "Make an EPIC the parent of one or more PBIs. A PBI belongs to at most one EPIC.",
+		Example: `  # Attach PBIs
+  deespec epic attach 01JB6X8Y2K9FQR4T3VWHGP5M2C PBI-001 PBI-002`,
+		ValidArgsFunction: completeEPICPBI,
+		Args:              cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useCase, closeDB, err := newEPICUseCase()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			epicID := args[0]
+			for _, pbiID := range args[1:] {
+				if err := useCase.AttachPBI(cmd.Context(), epicID, pbiID); err != nil {
+					return err
+				}
+				fmt.Printf("✅ PBI %s attached to EPIC %s\n", pbiID, epicID)
+			}
+			return nil
+		},
+	}
+}
+
+// NewDetachCommand creates a new detach command
+func NewDetachCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "detach <epic-id> <pbi-id>...",
+		Short: "Detach PBIs from an EPIC",
+		Long:  "Remove one or more PBIs from an EPIC. The PBIs themselves are kept.",
+		Example: `  # Detach a PBI
+  deespec epic detach 01JB6X8Y2K9FQR4T3VWHGP5M2C PBI-001`,
+		ValidArgsFunction: completeEPICPBI,
+		Args:              cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useCase, closeDB, err := newEPICUseCase()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			epicID := args[0]
+			for _, pbiID := range args[1:] {
+				if err := useCase.DetachPBI(cmd.Context(), epicID, pbiID); err != nil {
+					return err
+				}
+				fmt.Printf("✅ PBI %s detached from EPIC %s\n", pbiID, epicID)
+			}
+			return nil
+		},
+	}
+}
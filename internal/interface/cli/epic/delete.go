@@ -0,0 +1,85 @@
+package epic
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewDeleteCommand creates a new delete command
+func NewDeleteCommand() *cobra.Command {
+	var (
+		force  bool
+		detach bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete an EPIC",
+		Long: `Delete an EPIC. Its PBIs are never deleted.
+An EPIC with attached PBIs is only deleted with --detach, which detaches them first.
+By default, asks for confirmation before deleting.`,
+		Example: `  # Delete with confirmation
+  deespec epic delete 01JB6X8Y2K9FQR4T3VWHGP5M2C
+
+  # Detach its PBIs and delete without confirmation
+  deespec epic delete 01JB6X8Y2K9FQR4T3VWHGP5M2C --detach --force`,
+		ValidArgsFunction: common.CompleteEPICIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(cmd, args[0], force, detach)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&detach, "detach", false, "Detach the attached PBIs before deleting")
+
+	return cmd
+}
+
+func runDelete(cmd *cobra.Command, epicID string, force, detach bool) error {
+	useCase, closeDB, err := newEPICUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	detail, err := useCase.Get(cmd.Context(), epicID)
+	if err != nil {
+		return err
+	}
+
+	// Confirmation prompt (unless --force)
+	if !force {
+		fmt.Printf("⚠️  Delete EPIC: %s\n", epicID)
+		fmt.Printf("    Title: %s\n", detail.EPIC.Title())
+		if count := detail.EPIC.PBICount(); count > 0 && detach {
+			fmt.Printf("    %d attached PBIs will be detached (not deleted).\n", count)
+		}
+		fmt.Println()
+		fmt.Print("Are you sure? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("❌ Cancelled")
+			return nil
+		}
+	}
+
+	if err := useCase.Delete(cmd.Context(), epicID, detach); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ EPIC deleted: %s\n", epicID)
+	return nil
+}
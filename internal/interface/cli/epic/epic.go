@@ -0,0 +1,25 @@
+package epic
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewEPICCommand creates a new epic command
+func NewEPICCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epic",
+		Short: "Manage EPICs (large feature groups)",
+		Long:  "Commands for managing EPICs and the PBIs attached to them",
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewNewCommand())
+	cmd.AddCommand(NewListCommand())
+	cmd.AddCommand(NewShowCommand())
+	cmd.AddCommand(NewUpdateCommand())
+	cmd.AddCommand(NewDeleteCommand())
+	cmd.AddCommand(NewAttachCommand())
+	cmd.AddCommand(NewDetachCommand())
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package epic
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	epicusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// progressBarWidth is the number of cells in a progress bar
+const progressBarWidth = 20
+
+// newEPICUseCase builds the EPIC use case on the local database
+// The returned function closes the database and must be called when done
+func newEPICUseCase() (*epicusecase.EPICUseCase, func(), error) {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize container: %w", err)
+	}
+
+	rootPath, err := os.Getwd()
+	if err != nil {
+		container.Close()
+		return nil, nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	pbiRepo := persistence.NewPBISQLiteRepository(container.GetDB(), rootPath)
+
+	useCase := epicusecase.NewEPICUseCase(container.GetEPICRepository(), pbiRepo, container.GetSBIRepository())
+	return useCase, func() { container.Close() }, nil
+}
+
+// completeEPICPBI completes "<epic-id> <pbi-id>..." arguments: an EPIC ID first, then PBI IDs
+func completeEPICPBI(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return common.CompleteEPICIDs(cmd, args, toComplete)
+	}
+	return common.CompletePBIIDs(cmd, nil, toComplete)
+}
+
+// progressBar renders percent as a fixed-width bar, e.g. "[██████░░░░░░░░░░░░░░] 30%"
+func progressBar(percent int) string {
+	filled := percent * progressBarWidth / 100
+	return fmt.Sprintf("[%s%s] %d%%", strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled), percent)
+}
+
+func truncateString(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
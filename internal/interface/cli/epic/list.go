@@ -0,0 +1,109 @@
+package epic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// epicListFlags holds the flags for epic list command
+type epicListFlags struct {
+	status  []string // Filter by status
+	jsonOut bool     // Output in JSON format
+}
+
+// NewListCommand creates a new list command
+func NewListCommand() *cobra.Command {
+	flags := &epicListFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List EPICs with their progress",
+		Long:  "Display every EPIC with its attached PBIs and how far their SBIs are done",
+		Example: `  # List all EPICs
+  deespec epic list
+
+  # List EPICs by status
+  deespec epic list --status pending
+
+  # Output as JSON
+  deespec epic list --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&flags.status, "status", []string{}, "Filter by status (pending, picked, implementing, reviewing, done, failed, cancelled)")
+	cmd.Flags().BoolVar(&flags.jsonOut, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, flags *epicListFlags) error {
+	useCase, closeDB, err := newEPICUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	summaries, err := useCase.List(cmd.Context(), flags.status)
+	if err != nil {
+		return err
+	}
+
+	if flags.jsonOut {
+		views := make([]epicView, 0, len(summaries))
+		for _, summary := range summaries {
+			views = append(views, newEPICView(summary.EPIC, summary.Progress))
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(views)
+	}
+
+	if len(flags.status) > 0 {
+		fmt.Printf("EPIC一覧（status=%s, %d件）\n", strings.Join(flags.status, ","), len(summaries))
+	} else {
+		fmt.Printf("EPIC一覧（全%d件）\n", len(summaries))
+	}
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	if len(summaries) == 0 {
+		fmt.Println("No EPICs found.")
+		fmt.Println("Create one with 'deespec epic new <title>'.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tSTATUS\tSP\tPRIORITY\tPBIs\tSBIs\tPROGRESS\tTITLE\n")
+	fmt.Fprintf(w, "---\t------\t--\t--------\t----\t----\t--------\t-----\n")
+	for _, summary := range summaries {
+		e := summary.EPIC
+		progress := summary.Progress
+		sp := "-"
+		if points := e.Metadata().EstimatedStoryPoints; points > 0 {
+			sp = fmt.Sprintf("%d", points)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d/%d\t%d/%d\t%d%%\t%s\n",
+			e.ID().String(),
+			e.Status(),
+			sp,
+			e.Metadata().Priority,
+			progress.PBIDone, progress.PBITotal,
+			progress.SBIDone, progress.SBITotal,
+			progress.CompletedPercent,
+			truncateString(e.Title(), 40),
+		)
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("Use 'deespec epic show <id>' for details.")
+
+	return nil
+}
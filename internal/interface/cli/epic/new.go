@@ -0,0 +1,59 @@
+package epic
+
+import (
+	"fmt"
+
+	epicusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/epic"
+	"github.com/spf13/cobra"
+)
+
+// NewNewCommand creates a new epic new command
+func NewNewCommand() *cobra.Command {
+	opts := epicusecase.CreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "new <title>",
+		Aliases: []string{"create"},
+		Short:   "Create a new EPIC",
+		Long:    "Create an EPIC to group related PBIs. Attach PBIs with 'deespec epic attach'.",
+		Example: `  # Create an EPIC
+  deespec epic new "Checkout redesign"
+
+  # Create an EPIC with metadata
+  deespec epic new "Checkout redesign" --story-points 40 --priority 2 \
+    --label payments --label ux --agent claude-code`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Title = args[0]
+			return runNew(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Description, "description", "d", "", "EPIC description")
+	cmd.Flags().IntVarP(&opts.EstimatedStoryPoints, "story-points", "s", 0, "Estimated total story points")
+	cmd.Flags().IntVarP(&opts.Priority, "priority", "p", 0, "Priority (higher is more important)")
+	cmd.Flags().StringSliceVarP(&opts.Labels, "label", "l", []string{}, "Label (can be specified multiple times)")
+	cmd.Flags().StringVar(&opts.AssignedAgent, "agent", "", "Assigned agent (e.g. claude-code, gemini-cli)")
+
+	return cmd
+}
+
+func runNew(cmd *cobra.Command, opts epicusecase.CreateOptions) error {
+	useCase, closeDB, err := newEPICUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	e, err := useCase.Create(cmd.Context(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to create EPIC: %w", err)
+	}
+
+	fmt.Printf("✅ EPIC created: %s\n", e.ID().String())
+	fmt.Printf("   Title: %s\n", e.Title())
+	fmt.Println()
+	fmt.Printf("Attach PBIs with 'deespec epic attach %s <pbi-id>'.\n", e.ID().String())
+
+	return nil
+}
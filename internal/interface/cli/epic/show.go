@@ -0,0 +1,142 @@
+package epic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	epicusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// epicShowFlags holds the flags for epic show command
+type epicShowFlags struct {
+	jsonOut bool // Output in JSON format
+}
+
+// NewShowCommand creates a new show command
+func NewShowCommand() *cobra.Command {
+	flags := &epicShowFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show EPIC details, attached PBIs and progress",
+		Example: `  # Show an EPIC
+  deespec epic show 01JB6X8Y2K9FQR4T3VWHGP5M2C
+
+  # Output as JSON
+  deespec epic show 01JB6X8Y2K9FQR4T3VWHGP5M2C --json`,
+		ValidArgsFunction: common.CompleteEPICIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(cmd, args[0], flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.jsonOut, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runShow(cmd *cobra.Command, epicID string, flags *epicShowFlags) error {
+	useCase, closeDB, err := newEPICUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	detail, err := useCase.Get(cmd.Context(), epicID)
+	if err != nil {
+		return err
+	}
+
+	if flags.jsonOut {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newEPICDetailView(detail))
+	}
+
+	outputDetail(detail)
+	return nil
+}
+
+// outputDetail prints an EPIC with its PBIs and progress
+func outputDetail(detail *epicusecase.EPICDetail) {
+	e := detail.EPIC
+	metadata := e.Metadata()
+	progress := detail.Progress
+
+	fmt.Printf("🗂  %s: %s\n", e.ID().String(), e.Title())
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+	fmt.Printf("📊 Status: %s\n", e.Status())
+	fmt.Printf("🔢 Story Points: %d (PBIs: %d, done: %d)\n", metadata.EstimatedStoryPoints, progress.StoryPoints, progress.DoneStoryPoints)
+	fmt.Printf("⭐ Priority: %d\n", metadata.Priority)
+	if len(metadata.Labels) > 0 {
+		fmt.Printf("🏷  Labels: %s\n", strings.Join(metadata.Labels, ", "))
+	}
+	if metadata.AssignedAgent != "" {
+		fmt.Printf("🤖 Agent: %s\n", metadata.AssignedAgent)
+	}
+	fmt.Println()
+	fmt.Printf("📈 Progress: %s\n", progressBar(progress.CompletedPercent))
+	fmt.Printf("   PBIs: %d/%d done", progress.PBIDone, progress.PBITotal)
+	if len(progress.PBIStatusCounts) > 0 {
+		fmt.Printf(" (%s)", formatStatusCounts(progress.PBIStatusCounts))
+	}
+	fmt.Println()
+	fmt.Printf("   SBIs: %d/%d done\n", progress.SBIDone, progress.SBITotal)
+	fmt.Println()
+	fmt.Printf("🕐 Created: %s\n", e.CreatedAt().Value().Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("🕐 Updated: %s\n", e.UpdatedAt().Value().Local().Format("2006-01-02 15:04:05"))
+
+	if e.Description() != "" {
+		fmt.Println()
+		fmt.Println("📋 Description:")
+		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Println(e.Description())
+	}
+
+	fmt.Println()
+	fmt.Println("📦 PBIs:")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if len(detail.PBIs) == 0 && len(progress.MissingPBIs) == 0 {
+		fmt.Printf("No PBIs attached. Attach one with 'deespec epic attach %s <pbi-id>'.\n", e.ID().String())
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tSTATUS\tSP\tSBIs\tTITLE\n")
+	fmt.Fprintf(w, "---\t------\t--\t----\t-----\n")
+	for _, p := range detail.PBIs {
+		sp := "-"
+		if p.EstimatedStoryPoints > 0 {
+			sp = fmt.Sprintf("%d", p.EstimatedStoryPoints)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\n", p.ID, p.Status, sp, detail.SBIDone[p.ID], detail.SBICount[p.ID], truncateString(p.Title, 40))
+	}
+	w.Flush()
+
+	for _, id := range progress.MissingPBIs {
+		fmt.Printf("⚠️  %s is attached but no longer exists; run 'deespec epic detach %s %s'\n", id, e.ID().String(), id)
+	}
+}
+
+// formatStatusCounts formats status counts as "done: 1, pending: 2", sorted by status
+func formatStatusCounts(counts map[string]int) string {
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%s: %d", status, counts[status]))
+	}
+	return strings.Join(parts, ", ")
+}
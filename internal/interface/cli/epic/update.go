@@ -0,0 +1,121 @@
+package epic
+
+import (
+	"errors"
+	"fmt"
+
+	epicusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/priority"
+	"github.com/spf13/cobra"
+)
+
+// epicUpdateFlags holds the flags for epic update command
+type epicUpdateFlags struct {
+	title         string
+	description   string
+	storyPoints   int
+	priority      int
+	labels        []string
+	clearLabels   bool
+	assignedAgent string
+	propagate     bool
+}
+
+// NewUpdateCommand creates a new update command
+func NewUpdateCommand() *cobra.Command {
+	flags := &epicUpdateFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "update <id>",
+		Aliases: []string{"edit"},
+		Short:   "Update EPIC title, description or metadata",
+		Long: `Update an EPIC. Only the given flags are changed.
+--label replaces every label; use --clear-labels to remove them all.`,
+		Example: `  # Update story points and priority
+  deespec epic update 01JB6X8Y2K9FQR4T3VWHGP5M2C --story-points 55 --priority 1
+
+  # Raise the priority together with the PBIs and SBIs that follow it
+  deespec epic update 01JB6X8Y2K9FQR4T3VWHGP5M2C --priority 2 --propagate
+
+  # Replace labels and assign an agent
+  deespec epic update 01JB6X8Y2K9FQR4T3VWHGP5M2C --label payments --label q4 --agent gemini-cli`,
+		ValidArgsFunction: common.CompleteEPICIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpdate(cmd, args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.title, "title", "t", "", "New title")
+	cmd.Flags().StringVarP(&flags.description, "description", "d", "", "New description")
+	cmd.Flags().IntVarP(&flags.storyPoints, "story-points", "s", 0, "Estimated total story points")
+	cmd.Flags().IntVarP(&flags.priority, "priority", "p", 0, "Priority (higher is more important)")
+	cmd.Flags().StringSliceVarP(&flags.labels, "label", "l", []string{}, "Label (can be specified multiple times; replaces the current labels)")
+	cmd.Flags().BoolVar(&flags.clearLabels, "clear-labels", false, "Remove every label")
+	cmd.Flags().StringVar(&flags.assignedAgent, "agent", "", "Assigned agent (empty string to unassign)")
+	cmd.Flags().BoolVar(&flags.propagate, "propagate", false, "Pass a priority change down to the PBIs and SBIs that did not override it")
+
+	return cmd
+}
+
+func runUpdate(cmd *cobra.Command, epicID string, flags *epicUpdateFlags) error {
+	changed := cmd.Flags().Changed
+	if changed("label") && flags.clearLabels {
+		return errors.New("--label and --clear-labels cannot be used together")
+	}
+	if flags.propagate && !changed("priority") {
+		return errors.New("--propagate requires --priority")
+	}
+
+	opts := epicusecase.UpdateOptions{}
+	if changed("title") {
+		opts.Title = &flags.title
+	}
+	if changed("description") {
+		opts.Description = &flags.description
+	}
+	if changed("story-points") {
+		opts.EstimatedStoryPoints = &flags.storyPoints
+	}
+	if changed("priority") {
+		opts.Priority = &flags.priority
+	}
+	if changed("label") {
+		opts.Labels = &flags.labels
+	}
+	if flags.clearLabels {
+		opts.Labels = &[]string{}
+	}
+	if changed("agent") {
+		opts.AssignedAgent = &flags.assignedAgent
+	}
+	if opts == (epicusecase.UpdateOptions{}) {
+		return errors.New("nothing to update; specify at least one flag (see --help)")
+	}
+
+	useCase, closeDB, err := newEPICUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	var previousPriority int
+	if flags.propagate {
+		detail, err := useCase.Get(cmd.Context(), epicID)
+		if err != nil {
+			return err
+		}
+		previousPriority = detail.EPIC.Metadata().Priority
+	}
+
+	if _, err := useCase.Update(cmd.Context(), epicID, opts); err != nil {
+		return fmt.Errorf("failed to update EPIC: %w", err)
+	}
+
+	fmt.Printf("✅ EPIC updated: %s\n", epicID)
+	if flags.propagate {
+		return priority.Propagate(cmd.Context(), epicID, previousPriority)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package epic
+
+import (
+	"time"
+
+	epicusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/epic"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/epic"
+)
+
+// epicView is the JSON representation of an EPIC
+type epicView struct {
+	ID                   string               `json:"id"`
+	Title                string               `json:"title"`
+	Description          string               `json:"description"`
+	Status               string               `json:"status"`
+	EstimatedStoryPoints int                  `json:"estimated_story_points"`
+	Priority             int                  `json:"priority"`
+	Labels               []string             `json:"labels"`
+	AssignedAgent        string               `json:"assigned_agent"`
+	PBIIDs               []string             `json:"pbi_ids"`
+	PBIs                 []pbiView            `json:"pbis,omitempty"`
+	Progress             epicusecase.Progress `json:"progress"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+}
+
+// pbiView is the JSON representation of a PBI attached to an EPIC
+type pbiView struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	StoryPoints int    `json:"story_points"`
+	SBITotal    int    `json:"sbi_total"`
+	SBIDone     int    `json:"sbi_done"`
+}
+
+func newEPICView(e *epic.EPIC, progress epicusecase.Progress) epicView {
+	metadata := e.Metadata()
+	labels := metadata.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	pbiIDs := []string{}
+	for _, id := range e.PBIIDs() {
+		pbiIDs = append(pbiIDs, id.String())
+	}
+
+	return epicView{
+		ID:                   e.ID().String(),
+		Title:                e.Title(),
+		Description:          e.Description(),
+		Status:               string(e.Status()),
+		EstimatedStoryPoints: metadata.EstimatedStoryPoints,
+		Priority:             metadata.Priority,
+		Labels:               labels,
+		AssignedAgent:        metadata.AssignedAgent,
+		PBIIDs:               pbiIDs,
+		Progress:             progress,
+		CreatedAt:            e.CreatedAt().Value(),
+		UpdatedAt:            e.UpdatedAt().Value(),
+	}
+}
+
+func newEPICDetailView(detail *epicusecase.EPICDetail) epicView {
+	view := newEPICView(detail.EPIC, detail.Progress)
+	view.PBIs = []pbiView{}
+	for _, p := range detail.PBIs {
+		view.PBIs = append(view.PBIs, pbiView{
+			ID:          p.ID,
+			Title:       p.Title,
+			Status:      string(p.Status),
+			StoryPoints: p.EstimatedStoryPoints,
+			SBITotal:    detail.SBICount[p.ID],
+			SBIDone:     detail.SBIDone[p.ID],
+		})
+	}
+	return view
+}
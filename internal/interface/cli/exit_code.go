@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// Exit statuses of deespec
+// Scripts can tell user errors (a missing task, a task in the wrong status, a busy lock,
+// an unreachable agent) from system errors without parsing messages. 2 is left to the
+// warnings of 'deespec doctor'.
+const (
+	ExitError            = 1 // System errors and errors of no other kind
+	ExitNotFound         = 3 // The task, lock or other record does not exist
+	ExitInvalidState     = 4 // The status of the task does not allow the operation
+	ExitLockHeld         = 5 // Another process holds the lock
+	ExitAgentUnavailable = 6 // The agent cannot be reached
+)
+
+// ExitCode returns the exit status for an error returned by a command, or 0 for nil
+// Plugins exit with their own status.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var pluginErr *PluginExitError
+	if errors.As(err, &pluginErr) && pluginErr.ExitCode() > 0 {
+		return pluginErr.ExitCode()
+	}
+	switch model.ErrorCode(err) {
+	case model.ErrorCodeNotFound:
+		return ExitNotFound
+	case model.ErrorCodeInvalidState:
+		return ExitInvalidState
+	case model.ErrorCodeLockHeld:
+		return ExitLockHeld
+	case model.ErrorCodeAgentUnavailable:
+		return ExitAgentUnavailable
+	default:
+		return ExitError
+	}
+}
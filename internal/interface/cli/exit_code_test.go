@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, 0},
+		{"system error", errors.New("disk I/O error"), ExitError},
+		{"not found", fmt.Errorf("failed to find SBI: %w", fmt.Errorf("SBI %w: SBI-1", model.ErrNotFound)), ExitNotFound},
+		{"repository sentinel", fmt.Errorf("restore: %w", repository.ErrDatabaseBackupNotFound), ExitNotFound},
+		{"invalid state", fmt.Errorf("%w: SBI SBI-1 is already DONE and cannot be cancelled", model.ErrInvalidState), ExitInvalidState},
+		{"lock held", fmt.Errorf("%w: another instance is already running", model.ErrLockHeld), ExitLockHeld},
+		{"agent unavailable", fmt.Errorf("turn: %w", model.ErrAgentUnavailable), ExitAgentUnavailable},
+		{"plugin", fmt.Errorf("run: %w", &PluginExitError{Plugin: "lint", Code: 7}), 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package import_cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// NewCommand creates the import command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import an existing backlog",
+		RunE:  func(c *cobra.Command, _ []string) error { return c.Help() },
+	}
+	cmd.AddCommand(newImportCSVCmd())
+	return cmd
+}
+
+// csvFlags holds flags for the import csv command
+type csvFlags struct {
+	mapping    string
+	kind       string
+	dryRun     bool
+	jsonOutput bool
+}
+
+func newImportCSVCmd() *cobra.Command {
+	flags := &csvFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "csv <file>",
+		Short: "Create PBIs and SBIs from a backlog CSV",
+		Long: `Create PBIs and SBIs from a backlog exported as CSV (Jira, spreadsheets, ...).
+
+The first row must be a header. --map maps task fields to header names
+(matched case-insensitively):
+
+  title     Task title (required)
+  desc      PBI body / SBI description
+  type      Row kind, "pbi" or "sbi" (rows without one use --kind)
+  points    PBI story points (0-13)
+  hours     SBI estimated hours
+  priority  0-2, or normal/high/urgent
+  labels    SBI labels, separated by commas or semicolons
+  parent    SBI parent PBI: a PBI ID, or the title of a PBI row in the same file
+
+PBI rows are created first, so SBI rows can name them as parent. Rows that
+fail validation are skipped and listed in the report; the other rows are
+still imported. Use --dry-run to preview the import without creating tasks.`,
+		Example: `  # Preview a Jira export as PBIs
+  deespec import csv backlog.csv --map title=Summary,desc=Description,points=SP --dry-run
+
+  # Import stories and their sub-tasks
+  deespec import csv backlog.csv --map title=Summary,desc=Description,type=Kind,parent=Epic
+
+  # Import SBIs and print the report as JSON
+  deespec import csv tasks.csv --kind sbi --map title=Task,hours=Estimate,labels=Tags --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImportCSV(cmd.Context(), common.ResolveUserPath(args[0]), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.mapping, "map", "", "Field to column mapping (e.g. title=Summary,desc=Description,points=SP)")
+	cmd.Flags().StringVar(&flags.kind, "kind", "pbi", "Kind of rows without a type column value (pbi or sbi)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Validate and preview without creating tasks")
+	cmd.Flags().BoolVar(&flags.jsonOutput, "json", false, "Print the import report as JSON")
+	_ = cmd.MarkFlagRequired("map")
+
+	return cmd
+}
+
+func runImportCSV(ctx context.Context, path string, flags *csvFlags) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	mapping, err := usecase.ParseImportMapping(flags.mapping)
+	if err != nil {
+		return fmt.Errorf("invalid --map: %w", err)
+	}
+	if flags.kind != "pbi" && flags.kind != "sbi" {
+		return fmt.Errorf("--kind must be pbi or sbi, got %q", flags.kind)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	uc := usecase.NewImportBacklogUseCase(
+		container.GetSBIRepository(),
+		persistence.NewPBISQLiteRepository(container.GetDB(), rootPath),
+		filepath.Join(".deespec", "specs", "sbi"),
+	)
+
+	result, err := uc.Import(ctx, file, usecase.ImportBacklogOptions{
+		Mapping:     mapping,
+		DefaultKind: flags.kind,
+		DryRun:      flags.dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", path, err)
+	}
+
+	if flags.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	printImportReport(path, result)
+	return nil
+}
+
+// printImportReport prints one line per row and a summary
+func printImportReport(path string, result *usecase.ImportBacklogResult) {
+	if result.DryRun {
+		fmt.Printf("[DRY RUN] Nothing was created\n\n")
+	}
+	for _, row := range result.Rows {
+		kind := "PBI"
+		if row.Kind == "sbi" {
+			kind = "SBI"
+		}
+		switch {
+		case row.Action == usecase.ImportActionSkipped:
+			fmt.Printf("❌ Row %d skipped: %s (%s)\n", row.Row, row.Error, row.Title)
+		case result.DryRun:
+			fmt.Printf("✅ Row %d: would create %s %s\n", row.Row, kind, row.Title)
+		default:
+			fmt.Printf("✅ Row %d: created %s %s: %s\n", row.Row, kind, row.TaskID, row.Title)
+			if row.Error != "" {
+				fmt.Printf("   ⚠️  %s\n", row.Error)
+			}
+		}
+	}
+
+	created := result.Count(usecase.ImportActionCreated)
+	skipped := result.Count(usecase.ImportActionSkipped)
+	verb := "Created"
+	if result.DryRun {
+		verb = "Would create"
+	}
+	fmt.Printf("\n%s %d tasks from %s; %d rows skipped\n", verb, created, path, skipped)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
 	"github.com/YoshitsuguKoike/deespec/internal/embed"
 	"github.com/YoshitsuguKoike/deespec/internal/infra/config"
 	"github.com/spf13/cobra"
@@ -16,9 +17,10 @@ import (
 // NewCommand creates the init command
 func NewCommand() *cobra.Command {
 	var (
-		dir   string
-		force bool
-		home  string
+		dir        string
+		force      bool
+		home       string
+		localeFlag string
 	)
 
 	cmd := &cobra.Command{
@@ -38,8 +40,13 @@ All files will be created under the .deespec/ directory.`,
 			}
 			deespecDir := filepath.Join(dir, deespecHome)
 
-			// Get all templates
-			templates, err := embed.GetTemplates()
+			loc, err := locale.Parse(localeFlag)
+			if err != nil {
+				return err
+			}
+
+			// Get all templates in the selected locale
+			templates, err := embed.GetTemplatesForLocale(loc.String())
 			if err != nil {
 				return fmt.Errorf("failed to load templates: %w", err)
 			}
@@ -79,7 +86,7 @@ All files will be created under the .deespec/ directory.`,
 			settingPath := filepath.Join(deespecDir, "setting.json")
 			settingExists := fileExists(settingPath)
 			if force || !settingExists {
-				settingContent := config.CreateDefaultSettings()
+				settingContent := config.CreateDefaultSettingsForLocale(loc)
 				if err := writeFileAtomic(settingPath, settingContent, 0644); err != nil {
 					return fmt.Errorf("failed to write setting.json: %w", err)
 				}
@@ -130,7 +137,8 @@ All files will be created under the .deespec/ directory.`,
 			fmt.Println("  │   ├── WIP.md            # Implementation prompt")
 			fmt.Println("  │   ├── REVIEW.md         # Code review prompt")
 			fmt.Println("  │   ├── REVIEW_AND_WIP.md # Combined prompt")
-			fmt.Println("  │   └── DONE.md           # Completion report prompt")
+			fmt.Println("  │   ├── DONE.md           # Completion report prompt")
+			fmt.Println("  │   └── POSTMORTEM.md     # Failure analysis prompt")
 			fmt.Println("  ├── specs/")
 			fmt.Println("  │   ├── sbi/")
 			fmt.Println("  │   └── pbi/")
@@ -149,6 +157,7 @@ All files will be created under the .deespec/ directory.`,
 	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Target directory")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing files")
 	cmd.Flags().StringVar(&home, "home", "", "Custom deespec home directory (default: .deespec)")
+	cmd.Flags().StringVar(&localeFlag, "locale", "ja", "Locale of prompt templates and SBI section names (ja, en)")
 
 	return cmd
 }
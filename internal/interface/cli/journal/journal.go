@@ -17,10 +17,14 @@ import (
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "journal",
-		Short: "Journal validation commands",
+		Short: "Journal validation, rotation and export commands",
 		RunE:  func(c *cobra.Command, _ []string) error { return c.Help() },
 	}
 	cmd.AddCommand(newJournalVerifyCmd())
+	cmd.AddCommand(newJournalRotateCmd())
+	cmd.AddCommand(newJournalCompactCmd())
+	cmd.AddCommand(newJournalSegmentsCmd())
+	cmd.AddCommand(newJournalExportCmd())
 	return cmd
 }
 
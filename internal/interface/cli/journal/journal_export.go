@@ -0,0 +1,86 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+func newJournalExportCmd() *cobra.Command {
+	var (
+		outputPath string
+		sbiID      string
+		redact     bool
+		salt       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Bundle the journal into an archive for a bug report",
+		Long: `Bundle the journal (including rotated segments) into a tar.gz archive that
+can be attached to a bug report to reproduce a workflow bug.
+
+With --redact the archive can be shared without leaking proprietary
+information: file contents, agent output and other free text are dropped,
+error messages are cut to their first line, and paths, task IDs and other
+identifiers are replaced with hashes. Hashes are stable within an export,
+so the workflow of each task can still be followed; they are salted at
+random unless --salt is given, so separate exports cannot be linked.
+deespec's own layout (.deespec/reports/sbi/..., step report names), steps,
+statuses, decisions, error classes, timings, agents and models are kept.`,
+		Example: `  # Share the whole journal
+  deespec journal export --redact
+
+  # Only one SBI, with hashes that match an earlier export
+  deespec journal export --redact --sbi 01K7P4N123EQAB57FA5E5ZG6A3 --salt bug-1234 -o bug-1234.tar.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if salt != "" && !redact {
+				return fmt.Errorf("--salt requires --redact")
+			}
+			if !redact {
+				common.Warn("Exporting the journal without --redact: the archive contains paths, task IDs and error output of this project\n")
+			}
+			if outputPath == "" {
+				outputPath = fmt.Sprintf("deespec-journal-%s.tar.gz", time.Now().Format("20060102-150405"))
+			}
+			outputPath = common.ResolveUserPath(outputPath)
+
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create archive: %w", err)
+			}
+			uc := usecase.NewExportJournalUseCase(common.NewJournalRepository(journalPath()))
+			manifest, err := uc.Export(cmd.Context(), usecase.JournalExportOptions{
+				SBIID:   sbiID,
+				Redact:  redact,
+				Salt:    salt,
+				Version: buildinfo.GetVersion(),
+			}, file)
+			if closeErr := file.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("failed to write archive: %w", closeErr)
+			}
+			if err != nil {
+				os.Remove(outputPath)
+				return err
+			}
+
+			fmt.Printf("✓ Journal exported to %s\n", outputPath)
+			fmt.Printf("  Journal entries: %d\n", manifest.JournalRecords)
+			fmt.Printf("  SBIs:            %d\n", manifest.SBIs)
+			fmt.Printf("  Redacted:        %t\n", manifest.Redacted)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Archive path (default: deespec-journal-<time>.tar.gz)")
+	cmd.Flags().StringVar(&sbiID, "sbi", "", "Export only the records of this SBI")
+	cmd.Flags().BoolVar(&redact, "redact", false, "Strip file contents and hash paths and identifiers")
+	cmd.Flags().StringVar(&salt, "salt", "", "Salt of the hashes (default: random per export)")
+	_ = cmd.RegisterFlagCompletionFunc("sbi", common.CompleteSBIIDs)
+	return cmd
+}
@@ -0,0 +1,112 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+func newJournalRotateCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate the journal into a segment",
+		Long: `Move the active journal into a new segment under journal.segments/ when
+the rotation policy in setting.json ("journal.rotate_max_mb",
+"journal.rotate_max_age_hours") calls for it. Safe while a run is appending.`,
+		Example: `  deespec journal rotate
+  deespec journal rotate --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo := common.NewJournalRepository(journalPath())
+			segment, err := repo.Rotate(context.Background(), force)
+			if err != nil {
+				return fmt.Errorf("failed to rotate journal: %w", err)
+			}
+			if segment == nil {
+				fmt.Println("Journal not rotated (rotation policy not reached; use --force to rotate anyway)")
+				return nil
+			}
+			fmt.Printf("✓ Rotated %d entries into %s\n", segment.Entries, segment.File)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Rotate regardless of the rotation policy")
+	return cmd
+}
+
+func newJournalCompactCmd() *cobra.Command {
+	var keep int
+
+	cmd := &cobra.Command{
+		Use:   "compact",
+		Short: "Compact old journal segments into per-SBI summaries",
+		Long: `Reduce every rotated segment except the newest --keep to the final record
+of each SBI. Summaries keep the final record and add compacted_entries and
+total_elapsed_ms. The active journal is never compacted.`,
+		Example: `  deespec journal compact
+  deespec journal compact --keep 0`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("keep") {
+				keep = common.JournalRotationPolicy().KeepSegments
+			}
+			repo := common.NewJournalRepository(journalPath())
+			compacted, err := repo.Compact(context.Background(), keep)
+			if err != nil {
+				return fmt.Errorf("failed to compact journal: %w", err)
+			}
+			fmt.Printf("✓ Compacted %d segment(s)\n", compacted)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&keep, "keep", 0, "Newest segments kept in full (default: journal.keep_full_segments)")
+	return cmd
+}
+
+func newJournalSegmentsCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "segments",
+		Short: "List rotated journal segments",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo := common.NewJournalRepository(journalPath())
+			index, err := repo.LoadIndex()
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(index)
+			}
+			if len(index.Segments) == 0 {
+				fmt.Println("No rotated segments")
+				return nil
+			}
+			fmt.Printf("%-40s  %-7s  %-9s  %-5s  %s\n", "FILE", "ENTRIES", "COMPACTED", "SBIS", "RANGE")
+			for _, segment := range index.Segments {
+				fmt.Printf("%-40s  %-7d  %-9t  %-5d  %s .. %s\n",
+					segment.File, segment.Entries, segment.Compacted, len(segment.SBIIDs),
+					segment.FirstTimestamp, segment.LastTimestamp)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the segment index as JSON")
+	return cmd
+}
+
+// journalPath returns the configured journal path
+func journalPath() string {
+	return app.GetPathsWithConfig(common.GetGlobalConfig()).Journal
+}
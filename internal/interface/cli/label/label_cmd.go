@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
@@ -51,6 +52,8 @@ Templates provide additional context to AI prompts, enabling more specialized im
 	cmd.AddCommand(newLabelTemplatesCmd())
 	cmd.AddCommand(newLabelImportCmd())
 	cmd.AddCommand(newLabelValidateCmd())
+	cmd.AddCommand(newLabelRouteCmd())
+	cmd.AddCommand(newLabelMCPCmd())
 
 	return cmd
 }
@@ -298,6 +301,19 @@ func newLabelShowCmd() *cobra.Command {
 				fmt.Printf("\nParent Label ID: %d\n", *lbl.ParentLabelID())
 			}
 
+			if routes := lbl.AgentRoutes(); len(routes) > 0 {
+				fmt.Printf("\nAgent Routes:\n")
+				for _, step := range []string{label.RouteStepImplement, label.RouteStepReview} {
+					if route, ok := routes[step]; ok {
+						fmt.Printf("  - %s: %s\n", step, formatAgentRoute(route))
+					}
+				}
+			}
+
+			if servers := lbl.MCPServers(); len(servers) > 0 {
+				fmt.Printf("\nMCP Servers: %s\n", strings.Join(servers, ", "))
+			}
+
 			return nil
 		},
 	}
@@ -0,0 +1,116 @@
+package label
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// newLabelMCPCmd creates the label mcp command
+func newLabelMCPCmd() *cobra.Command {
+	var servers []string
+	var clearServers bool
+
+	cmd := &cobra.Command{
+		Use:   "mcp <name-or-id>",
+		Short: "Enable MCP servers for agents working on tasks with this label",
+		Long: `Enable MCP servers, which give agents extra tools such as database schema
+lookups, for every task carrying this label.
+
+Servers are configured once in setting.json and referred to by name:
+
+  "mcp": {
+    "servers": {
+      "db-schema": {"command": "npx", "args": ["-y", "db-schema-mcp"], "env": {"DB_URL": "..."}},
+      "context7":  {"type": "http", "url": "https://mcp.context7.com/mcp"}
+    }
+  }
+
+A task gets the servers of all of its active labels. Only agents that
+support MCP (claude-code-cli) use them; other agents run without.
+
+Without --server/--clear, the enabled servers are displayed.`,
+		Example: `  # Give backend tasks the database schema server
+  deespec label mcp backend --server db-schema
+
+  # Enable several servers (replaces the current list)
+  deespec label mcp backend --server db-schema --server context7
+
+  # Disable all servers
+  deespec label mcp backend --clear
+
+  # Show the enabled servers
+  deespec label mcp backend`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nameOrID := args[0]
+
+			container, err := common.InitializeContainer()
+			if err != nil {
+				return fmt.Errorf("failed to initialize container: %w", err)
+			}
+			defer container.Close()
+
+			labelRepo := container.GetLabelRepository()
+			ctx := context.Background()
+
+			// Find label
+			var lbl *label.Label
+			if id, err := strconv.Atoi(nameOrID); err == nil {
+				lbl, err = labelRepo.FindByID(ctx, id)
+				if err != nil {
+					return fmt.Errorf("label not found: %s", nameOrID)
+				}
+			} else {
+				lbl, err = labelRepo.FindByName(ctx, nameOrID)
+				if err != nil {
+					return fmt.Errorf("label not found: %s", nameOrID)
+				}
+			}
+
+			// Display mode
+			if len(servers) == 0 && !clearServers {
+				if enabled := lbl.MCPServers(); len(enabled) > 0 {
+					fmt.Printf("MCP servers for label %s: %s\n", lbl.Name(), strings.Join(enabled, ", "))
+				} else {
+					fmt.Printf("No MCP servers for label: %s\n", lbl.Name())
+				}
+				return nil
+			}
+			if clearServers && len(servers) > 0 {
+				return fmt.Errorf("--clear cannot be combined with --server")
+			}
+
+			if err := lbl.SetMCPServers(servers); err != nil {
+				return err
+			}
+			if err := labelRepo.Update(ctx, lbl); err != nil {
+				return fmt.Errorf("failed to update label: %w", err)
+			}
+
+			if clearServers {
+				fmt.Printf("✓ MCP servers cleared: %s\n", lbl.Name())
+				return nil
+			}
+			fmt.Printf("✓ MCP servers updated: %s → %s\n", lbl.Name(), strings.Join(lbl.MCPServers(), ", "))
+			if cfg := common.GetGlobalConfig(); cfg != nil {
+				for _, name := range lbl.MCPServers() {
+					if _, ok := cfg.MCPConfig().Servers[name]; !ok {
+						common.Warn("MCP server %q is not configured in setting.json (\"mcp.servers\")\n", name)
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&servers, "server", nil, "Name of an MCP server configured in setting.json (repeatable)")
+	cmd.Flags().BoolVar(&clearServers, "clear", false, "Disable all MCP servers of the label")
+
+	return cmd
+}
@@ -0,0 +1,138 @@
+package label
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// newLabelRouteCmd creates the label route command
+func newLabelRouteCmd() *cobra.Command {
+	var step string
+	var agent string
+	var model string
+	var clearRoute bool
+
+	cmd := &cobra.Command{
+		Use:   "route <name-or-id>",
+		Short: "Pin the agent/model used for implement or review steps",
+		Long: `Pin which agent and model handle a workflow step for tasks carrying this label.
+
+When a task has several routed labels, the label with the highest priority wins.
+Tasks without a routed label use the default agent.
+
+Without --agent/--model/--clear, the current routes are displayed.`,
+		Example: `  # Use claude-sonnet for implementation of frontend tasks
+  deespec label route frontend --step implement --agent claude-code-cli --model claude-sonnet
+
+  # Use a different agent for review
+  deespec label route frontend --step review --agent codex
+
+  # Remove the review route
+  deespec label route frontend --step review --clear
+
+  # Show current routes
+  deespec label route frontend`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nameOrID := args[0]
+
+			container, err := common.InitializeContainer()
+			if err != nil {
+				return fmt.Errorf("failed to initialize container: %w", err)
+			}
+			defer container.Close()
+
+			labelRepo := container.GetLabelRepository()
+			ctx := context.Background()
+
+			// Find label
+			var lbl *label.Label
+			if id, err := strconv.Atoi(nameOrID); err == nil {
+				lbl, err = labelRepo.FindByID(ctx, id)
+				if err != nil {
+					return fmt.Errorf("label not found: %s", nameOrID)
+				}
+			} else {
+				lbl, err = labelRepo.FindByName(ctx, nameOrID)
+				if err != nil {
+					return fmt.Errorf("label not found: %s", nameOrID)
+				}
+			}
+
+			// Display mode
+			if agent == "" && model == "" && !clearRoute {
+				printAgentRoutes(lbl)
+				return nil
+			}
+
+			if step == "" {
+				return fmt.Errorf("--step is required (implement or review)")
+			}
+
+			route := label.AgentRoute{Agent: agent, Model: model}
+			if clearRoute {
+				route = label.AgentRoute{}
+			}
+
+			if err := lbl.SetAgentRoute(step, route); err != nil {
+				return err
+			}
+
+			if err := labelRepo.Update(ctx, lbl); err != nil {
+				return fmt.Errorf("failed to update label: %w", err)
+			}
+
+			if clearRoute {
+				fmt.Printf("✓ Route cleared: %s (%s)\n", lbl.Name(), step)
+			} else {
+				fmt.Printf("✓ Route updated: %s (%s) → %s\n", lbl.Name(), step, formatAgentRoute(route))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&step, "step", "s", "", "Workflow step to route (implement or review)")
+	cmd.Flags().StringVarP(&agent, "agent", "a", "", "Agent type (claude-code, claude-code-cli, gemini-cli, codex, anthropic-api, openai-api, gemini-api, ollama)")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model passed to the agent")
+	cmd.Flags().BoolVar(&clearRoute, "clear", false, "Remove the route for the step")
+
+	return cmd
+}
+
+// printAgentRoutes prints the agent routes pinned by a label
+func printAgentRoutes(lbl *label.Label) {
+	routes := lbl.AgentRoutes()
+	if len(routes) == 0 {
+		fmt.Printf("No agent routes for label: %s\n", lbl.Name())
+		return
+	}
+
+	steps := make([]string, 0, len(routes))
+	for step := range routes {
+		steps = append(steps, step)
+	}
+	sort.Strings(steps)
+
+	fmt.Printf("Agent routes for label: %s\n", lbl.Name())
+	for _, step := range steps {
+		fmt.Printf("  %-10s → %s\n", step, formatAgentRoute(routes[step]))
+	}
+}
+
+// formatAgentRoute formats a route as "agent (model)"
+func formatAgentRoute(route label.AgentRoute) string {
+	agent := route.Agent
+	if agent == "" {
+		agent = "(default agent)"
+	}
+	if route.Model == "" {
+		return agent
+	}
+	return fmt.Sprintf("%s (%s)", agent, route.Model)
+}
@@ -39,6 +39,12 @@ Use --sync to automatically update hashes for modified files.`,
   deespec label validate --details`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if sync {
+				if err := common.CheckWritable("sync labels"); err != nil {
+					return err
+				}
+			}
+
 			// Initialize container
 			container, err := common.InitializeContainer()
 			if err != nil {
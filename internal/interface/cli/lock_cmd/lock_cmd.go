@@ -5,15 +5,18 @@ import (
 )
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/process"
 )
 
 // newLockCmd creates the lock command
@@ -24,13 +27,14 @@ func NewCommand() *cobra.Command {
 		Short: "Manage locks (run locks and state locks)",
 		Long: `Manage locks using the SQLite-based lock system.
 
-This command provides operations to list, inspect, and cleanup locks.
+This command provides operations to list, inspect, release, and cleanup locks.
 It uses the new SQLite-based lock system (Phase 7 implementation).`,
 	}
 
 	cmd.AddCommand(newLockListCmd())
 	cmd.AddCommand(newLockCleanupCmd())
 	cmd.AddCommand(newLockInfoCmd())
+	cmd.AddCommand(newLockReleaseCmd())
 
 	return cmd
 }
@@ -50,9 +54,10 @@ func newLockListCmd() *cobra.Command {
 // newLockCleanupCmd creates the lock cleanup command
 func newLockCleanupCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "cleanup",
-		Short: "Clean up expired locks",
-		Long:  `Remove all expired locks from the system.`,
+		Use:     "cleanup",
+		Aliases: []string{"cleanup-expired"},
+		Short:   "Clean up expired locks",
+		Long:    `Remove all expired locks from the system.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLockCleanup()
 		},
@@ -75,6 +80,33 @@ func newLockInfoCmd() *cobra.Command {
 	}
 }
 
+// newLockReleaseCmd creates the lock release command
+func newLockReleaseCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "release <lockID>",
+		Short: "Force-release a stuck lock",
+		Long: `Force-release a run lock or state lock by its ID.
+
+Use this when a process died without releasing its lock and you do not want
+to wait for the TTL to expire. Releasing a lock held by a live process can
+cause concurrent executions, so the holder is shown and confirmation is required.
+
+Examples:
+  deespec lock release runlock-01H...
+  deespec lock release sbi-01H... --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLockRelease(args[0], yes)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
 // runLockList lists all active locks
 func runLockList() error {
 	// Initialize DI container
@@ -115,18 +147,19 @@ func runLockList() error {
 	if len(runLocks) > 0 {
 		common.Info("Run Locks (%d):\n", len(runLocks))
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "LOCK ID\tPID\tHOSTNAME\tACQUIRED\tEXPIRES\tSTATUS")
+		fmt.Fprintln(w, "LOCK ID\tPID\tHOSTNAME\tACQUIRED\tEXPIRES\tTTL\tSTATUS")
 		for _, l := range runLocks {
 			status := "active"
 			if l.IsExpired() {
 				status = "expired"
 			}
-			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
 				l.LockID().String(),
 				l.PID(),
 				l.Hostname(),
 				l.AcquiredAt().Format("15:04:05"),
 				l.ExpiresAt().Format("15:04:05"),
+				remainingTTL(l.ExpiresAt()),
 				status,
 			)
 		}
@@ -137,19 +170,20 @@ func runLockList() error {
 	if len(stateLocks) > 0 {
 		common.Info("State Locks (%d):\n", len(stateLocks))
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "LOCK ID\tTYPE\tPID\tHOSTNAME\tACQUIRED\tEXPIRES\tSTATUS")
+		fmt.Fprintln(w, "LOCK ID\tTYPE\tPID\tHOSTNAME\tACQUIRED\tEXPIRES\tTTL\tSTATUS")
 		for _, l := range stateLocks {
 			status := "active"
 			if l.IsExpired() {
 				status = "expired"
 			}
-			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
 				l.LockID().String(),
 				l.LockType(),
 				l.PID(),
 				l.Hostname(),
 				l.AcquiredAt().Format("15:04:05"),
 				l.ExpiresAt().Format("15:04:05"),
+				remainingTTL(l.ExpiresAt()),
 				status,
 			)
 		}
@@ -178,48 +212,25 @@ func runLockCleanup() error {
 
 	common.Info("Cleaning up expired locks...\n")
 
-	// Get lock counts before cleanup
-	runLocksBefore, _ := lockService.ListRunLocks(ctx)
-	stateLocksBefore, _ := lockService.ListStateLocks(ctx)
-
-	// Cleanup via repositories (Lock Service doesn't expose direct cleanup)
-	// We'll trigger cleanup by calling the cleanup method directly
-	// Note: In production, the cleanup scheduler runs automatically
-
-	// For now, we'll just report what the automatic cleanup would do
-	expiredRunCount := 0
-	for _, l := range runLocksBefore {
-		if l.IsExpired() {
-			expiredRunCount++
-		}
-	}
-
-	expiredStateCount := 0
-	for _, l := range stateLocksBefore {
-		if l.IsExpired() {
-			expiredStateCount++
-		}
+	runCount, stateCount, err := lockService.CleanupExpiredLocks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup expired locks: %w", err)
 	}
 
-	totalExpired := expiredRunCount + expiredStateCount
-
+	totalExpired := runCount + stateCount
 	if totalExpired == 0 {
 		common.Info("No expired locks found\n")
 		return nil
 	}
 
-	common.Info("Found %d expired lock(s):\n", totalExpired)
-	if expiredRunCount > 0 {
-		common.Info("  - Run locks: %d\n", expiredRunCount)
+	common.Info("Removed %d expired lock(s):\n", totalExpired)
+	if runCount > 0 {
+		common.Info("  - Run locks: %d\n", runCount)
 	}
-	if expiredStateCount > 0 {
-		common.Info("  - State locks: %d\n", expiredStateCount)
+	if stateCount > 0 {
+		common.Info("  - State locks: %d\n", stateCount)
 	}
 
-	common.Info("\nNote: Lock Service automatically cleans up expired locks every 60 seconds.\n")
-	common.Info("Manual cleanup via repositories is not exposed in the public API.\n")
-	common.Info("The expired locks will be cleaned up on the next automatic cleanup cycle.\n")
-
 	return nil
 }
 
@@ -260,7 +271,69 @@ func runLockInfo(lockIDStr string) error {
 		return nil
 	}
 
-	return fmt.Errorf("lock not found: %s", lockIDStr)
+	return fmt.Errorf("%w: %s", lock.ErrLockNotFound, lockIDStr)
+}
+
+// runLockRelease force-releases a run lock or state lock after confirmation
+func runLockRelease(lockIDStr string, yes bool) error {
+	// Initialize DI container
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	// Start Lock Service
+	ctx := context.Background()
+	if err := container.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start lock service: %w", err)
+	}
+
+	lockService := container.GetLockService()
+
+	lockID, err := lockIDFromString(lockIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid lock ID: %w", err)
+	}
+
+	// Resolve the lock kind and show its holder before releasing
+	var release func(context.Context, lock.LockID) error
+	var holderHost string
+	var holderPID int
+	var expired bool
+	if runLock, err := lockService.FindRunLock(ctx, lockID); err == nil && runLock != nil {
+		displayRunLockInfo(runLock)
+		release = lockService.ReleaseRunLock
+		holderHost = runLock.Hostname()
+		holderPID = runLock.PID()
+		expired = runLock.IsExpired()
+	} else if stateLock, err := lockService.FindStateLock(ctx, lockID); err == nil && stateLock != nil {
+		displayStateLockInfo(stateLock)
+		release = lockService.ReleaseStateLock
+		holderHost = stateLock.Hostname()
+		holderPID = stateLock.PID()
+		expired = stateLock.IsExpired()
+	} else {
+		return fmt.Errorf("%w: %s", lock.ErrLockNotFound, lockIDStr)
+	}
+
+	if !yes {
+		message := "Release this lock?"
+		if !expired && process.IsLockHolderRunning(holderHost, holderPID) {
+			message = fmt.Sprintf("⚠️  Holder process %d on %s may still be running. Release this lock anyway?", holderPID, holderHost)
+		}
+		if !promptConfirmation(message) {
+			common.Info("Release cancelled\n")
+			return nil
+		}
+	}
+
+	if err := release(ctx, lockID); err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	common.Info("✅ Released lock: %s\n", lockIDStr)
+	return nil
 }
 
 // Helper functions
@@ -293,6 +366,28 @@ func displayStateLockInfo(stateLock *lock.StateLock) {
 	common.Info("  Status:     %s\n", lockStatus(stateLock.IsExpired()))
 }
 
+// remainingTTL formats the time left until a lock expires
+func remainingTTL(expiresAt time.Time) string {
+	remaining := time.Until(expiresAt)
+	if remaining <= 0 {
+		return "-"
+	}
+	return remaining.Truncate(time.Second).String()
+}
+
+// promptConfirmation asks the user for yes/no confirmation
+func promptConfirmation(message string) bool {
+	fmt.Printf("\n%s (y/N): ", message)
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
+
 func lockStatus(isExpired bool) string {
 	if isExpired {
 		return "EXPIRED"
@@ -0,0 +1,142 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the migrate command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "One-shot migrations of the .deespec layout",
+		RunE:  func(c *cobra.Command, _ []string) error { return c.Help() },
+	}
+	cmd.AddCommand(newReportsCmd())
+	cmd.AddCommand(newEncryptCmd())
+	return cmd
+}
+
+func newReportsCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "reports",
+		Short: "Move pre-v0.2.13 reports from .deespec/specs/sbi into .deespec/reports/sbi",
+		Long: `Move implement_N.md and review_N.md reports written before v0.2.13 from
+.deespec/specs/sbi/<id>/ into .deespec/reports/sbi/<id>/.
+
+- A report that already exists in the new layout with the same content is
+  removed from the old location; one with different content is left in place
+  and listed as a conflict.
+- Journal artifact references to moved reports are rewritten. The original
+  journal is backed up first.
+- A manifest of every move is written to .deespec/var/migrations/.
+
+Running the command again only picks up what is left.
+
+Examples:
+  # Show what would be moved
+  deespec migrate reports --dry-run
+
+  # Move the reports
+  deespec migrate reports`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+
+			manifest, err := MigrateReports(paths, MigrateReportsOptions{DryRun: dryRun})
+			if err != nil {
+				return err
+			}
+			printManifest(manifest, dryRun)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be moved without changing anything")
+	return cmd
+}
+
+func newEncryptCmd() *cobra.Command {
+	var keySource string
+
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the plaintext task database with SQLCipher",
+		Long: `Encrypt the task database at rest with SQLCipher. The database is exported
+into an encrypted copy that replaces the original file.
+
+The key is read from the database_encryption.key_source of setting.json
+(default env:DEESPEC_DB_KEY) unless --key-source is given:
+  env:NAME     the environment variable NAME
+  file:PATH    a key file readable by the owner only
+  cmd:COMMAND  the output of a keychain or secret manager CLI
+
+deespec must be built against SQLCipher (make build-sqlcipher). Stop
+'deespec run' and other commands using the database first, back it up, and
+set "database_encryption": {"enabled": true} in setting.json afterwards.
+
+Examples:
+  DEESPEC_DB_KEY=... deespec migrate encrypt
+  deespec migrate encrypt --key-source "cmd:security find-generic-password -s deespec -w"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keySource == "" {
+				keySource = "env:DEESPEC_DB_KEY"
+				if cfg := common.GetGlobalConfig(); cfg != nil && cfg.DatabaseEncryptionConfig().KeySource != "" {
+					keySource = cfg.DatabaseEncryptionConfig().KeySource
+				}
+			}
+			key, err := sqlite.ResolveEncryptionKey(keySource)
+			if err != nil {
+				return err
+			}
+			dbPath, err := common.ResolveDBPath()
+			if err != nil {
+				return err
+			}
+			if err := sqlite.EncryptDatabase(dbPath, key); err != nil {
+				return fmt.Errorf("failed to encrypt %s: %w", dbPath, err)
+			}
+
+			fmt.Printf("Encrypted %s\n", dbPath)
+			if cfg := common.GetGlobalConfig(); cfg == nil || !cfg.DatabaseEncryptionConfig().Enabled {
+				fmt.Println(`Set "database_encryption": {"enabled": true} in setting.json to open it`)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keySource, "key-source", "", "Where to read the key (env:NAME, file:PATH or cmd:COMMAND; default: setting.json)")
+	return cmd
+}
+
+// printManifest prints the migration result
+func printManifest(m *ReportsManifest, dryRun bool) {
+	verb := "Moved"
+	if dryRun {
+		verb = "Would move"
+	}
+	for _, r := range m.Moved {
+		fmt.Printf("%s %s -> %s\n", verb, r.From, r.To)
+	}
+	for _, r := range m.Duplicates {
+		fmt.Printf("Duplicate %s (already at %s)\n", r.From, r.To)
+	}
+	for _, r := range m.Conflicts {
+		fmt.Printf("CONFLICT %s differs from %s; left in place\n", r.From, r.To)
+	}
+
+	if len(m.Moved)+len(m.Duplicates)+len(m.Conflicts) == 0 {
+		fmt.Println("No legacy reports to migrate")
+		return
+	}
+	fmt.Printf("SUMMARY: moved=%d duplicates=%d conflicts=%d journal_entries=%d\n",
+		len(m.Moved), len(m.Duplicates), len(m.Conflicts), m.JournalEntries)
+	if m.ManifestPath != "" {
+		fmt.Printf("Manifest: %s\n", m.ManifestPath)
+	}
+}
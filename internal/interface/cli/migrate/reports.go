@@ -0,0 +1,261 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
+)
+
+var (
+	// legacyReportPattern matches the report names the run loop still looks up in .deespec/specs/sbi
+	legacyReportPattern = regexp.MustCompile(`^(implement|review)_\d+\.md$`)
+
+	// legacyReportRefPattern matches legacy report references inside journal artifacts
+	legacyReportRefPattern = regexp.MustCompile(`specs/sbi/([^/"]+)/((?:implement|review)_\d+\.md)`)
+)
+
+// MigrateReportsOptions represents options for the reports migration
+type MigrateReportsOptions struct {
+	DryRun bool // If true, only report what would be moved
+}
+
+// MovedReport is one legacy report and its location in the new layout
+type MovedReport struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ReportsManifest records what the reports migration did
+type ReportsManifest struct {
+	MigratedAt     time.Time     `json:"migrated_at"`
+	Moved          []MovedReport `json:"moved"`
+	Duplicates     []MovedReport `json:"duplicates"` // Identical report already in the new layout; legacy copy removed
+	Conflicts      []MovedReport `json:"conflicts"`  // Different report already in the new layout; legacy copy kept
+	JournalEntries int           `json:"journal_entries"`
+	JournalBackup  string        `json:"journal_backup,omitempty"`
+	ManifestPath   string        `json:"-"`
+}
+
+// MigrateReports moves legacy reports from .deespec/specs/sbi into .deespec/reports/sbi,
+// rewrites the journal artifact references to them and writes a manifest
+func MigrateReports(paths app.Paths, opts MigrateReportsOptions) (*ReportsManifest, error) {
+	root := filepath.Dir(paths.Home)
+	reportsDir := filepath.Join(paths.Home, "reports", "sbi")
+	manifest := &ReportsManifest{
+		MigratedAt: time.Now().UTC(),
+		Moved:      []MovedReport{},
+		Duplicates: []MovedReport{},
+		Conflicts:  []MovedReport{},
+	}
+
+	legacy, err := findLegacyReports(paths.SpecsSBI)
+	if err != nil {
+		return nil, err
+	}
+
+	// sbiID/name of every report whose journal references move to the new layout
+	migrated := make(map[string]bool)
+	for _, src := range legacy {
+		sbiID := filepath.Base(filepath.Dir(src))
+		name := filepath.Base(src)
+		dst := filepath.Join(reportsDir, sbiID, name)
+		entry := MovedReport{From: relPath(root, src), To: relPath(root, dst)}
+
+		if existing, err := os.ReadFile(dst); err == nil {
+			content, err := os.ReadFile(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", src, err)
+			}
+			if !bytes.Equal(existing, content) {
+				manifest.Conflicts = append(manifest.Conflicts, entry)
+				continue
+			}
+			if !opts.DryRun {
+				if err := os.Remove(src); err != nil {
+					return nil, fmt.Errorf("failed to remove duplicate %s: %w", src, err)
+				}
+			}
+			manifest.Duplicates = append(manifest.Duplicates, entry)
+			migrated[sbiID+"/"+name] = true
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+			}
+			if err := os.Rename(src, dst); err != nil {
+				return nil, fmt.Errorf("failed to move %s: %w", src, err)
+			}
+		}
+		manifest.Moved = append(manifest.Moved, entry)
+		migrated[sbiID+"/"+name] = true
+	}
+
+	// References left behind by an earlier run are rewritten as well
+	isMigrated := func(sbiID, name string) bool {
+		if migrated[sbiID+"/"+name] {
+			return true
+		}
+		_, legacyErr := os.Stat(filepath.Join(paths.SpecsSBI, sbiID, name))
+		_, newErr := os.Stat(filepath.Join(reportsDir, sbiID, name))
+		return os.IsNotExist(legacyErr) && newErr == nil
+	}
+	rewrite := func(line []byte) ([]byte, bool) {
+		return rewriteJournalLine(line, isMigrated)
+	}
+
+	stamp := manifest.MigratedAt.Format("20060102T150405Z")
+	migrationsDir := filepath.Join(paths.Var, "migrations")
+	if opts.DryRun {
+		manifest.JournalEntries, err = countJournalRewrites(paths.Journal, rewrite)
+		if err != nil {
+			return nil, err
+		}
+		return manifest, nil
+	}
+
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", migrationsDir, err)
+	}
+	backupPath := filepath.Join(migrationsDir, fmt.Sprintf("journal_%s.ndjson", stamp))
+	manifest.JournalEntries, err = fs.RewriteNDJSONLines(paths.Journal, backupPath, rewrite)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update journal: %w", err)
+	}
+	if manifest.JournalEntries > 0 {
+		manifest.JournalBackup = relPath(root, backupPath)
+	}
+
+	if len(manifest.Moved)+len(manifest.Duplicates)+len(manifest.Conflicts)+manifest.JournalEntries == 0 {
+		return manifest, nil
+	}
+	manifestPath := filepath.Join(migrationsDir, fmt.Sprintf("reports_%s.json", stamp))
+	if err := fs.AtomicWriteJSON(manifestPath, manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	manifest.ManifestPath = relPath(root, manifestPath)
+	return manifest, nil
+}
+
+// findLegacyReports returns the legacy report files under specsSBI, sorted by path
+func findLegacyReports(specsSBI string) ([]string, error) {
+	entries, err := os.ReadDir(specsSBI)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", specsSBI, err)
+	}
+
+	var reports []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(specsSBI, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		for _, file := range files {
+			if !file.IsDir() && legacyReportPattern.MatchString(file.Name()) {
+				reports = append(reports, filepath.Join(specsSBI, entry.Name(), file.Name()))
+			}
+		}
+	}
+	sort.Strings(reports)
+	return reports, nil
+}
+
+// rewriteJournalLine points the legacy report references in a journal entry's artifacts to the new layout
+// Lines that are not valid JSON or have nothing to rewrite are left unchanged
+func rewriteJournalLine(line []byte, isMigrated func(sbiID, name string) bool) ([]byte, bool) {
+	if !bytes.Contains(line, []byte("specs/sbi/")) {
+		return line, false
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return line, false
+	}
+	artifacts, ok := entry["artifacts"]
+	if !ok {
+		return line, false
+	}
+
+	changed := false
+	entry["artifacts"] = rewriteArtifactRefs(artifacts, func(ref string) string {
+		return legacyReportRefPattern.ReplaceAllStringFunc(ref, func(match string) string {
+			parts := legacyReportRefPattern.FindStringSubmatch(match)
+			if !isMigrated(parts[1], parts[2]) {
+				return match
+			}
+			changed = true
+			return "reports/sbi/" + parts[1] + "/" + parts[2]
+		})
+	})
+	if !changed {
+		return line, false
+	}
+
+	rewritten, err := json.Marshal(entry)
+	if err != nil {
+		return line, false
+	}
+	return rewritten, true
+}
+
+// rewriteArtifactRefs applies rewrite to every string in a journal artifacts value
+// Artifacts are either plain paths or objects holding paths
+func rewriteArtifactRefs(value interface{}, rewrite func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return rewrite(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = rewriteArtifactRefs(v[i], rewrite)
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = rewriteArtifactRefs(v[k], rewrite)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// countJournalRewrites returns how many journal entries rewrite would change
+func countJournalRewrites(journalPath string, rewrite func(line []byte) ([]byte, bool)) (int, error) {
+	data, err := os.ReadFile(journalPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	count := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if _, ok := rewrite(line); ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// relPath returns path relative to the project root, or path itself when that fails
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return rel
+	}
+	return path
+}
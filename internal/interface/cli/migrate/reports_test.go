@@ -0,0 +1,103 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestMigrateReports(t *testing.T) {
+	paths := app.ResolvePathsWithHome(filepath.Join(t.TempDir(), ".deespec"))
+	reportsDir := filepath.Join(paths.Home, "reports", "sbi")
+
+	writeFile(t, filepath.Join(paths.SpecsSBI, "SBI-1", "spec.md"), "spec")
+	writeFile(t, filepath.Join(paths.SpecsSBI, "SBI-1", "implement_1.md"), "impl 1")
+	writeFile(t, filepath.Join(paths.SpecsSBI, "SBI-1", "review_1.md"), "review 1")
+	writeFile(t, filepath.Join(reportsDir, "SBI-1", "review_1.md"), "review 1")
+	writeFile(t, filepath.Join(paths.SpecsSBI, "SBI-2", "implement_1.md"), "old impl")
+	writeFile(t, filepath.Join(reportsDir, "SBI-2", "implement_1.md"), "new impl")
+
+	journal := `{"sbi_id":"SBI-1","turn":1,"step":"implement","artifacts":[".deespec/specs/sbi/SBI-1/implement_1.md"]}
+{"sbi_id":"SBI-2","turn":1,"step":"implement","artifacts":[{"path":".deespec/specs/sbi/SBI-2/implement_1.md"}]}
+{"sbi_id":"SBI-1","turn":1,"step":"review","artifacts":[]}
+`
+	writeFile(t, paths.Journal, journal)
+
+	// A dry run changes nothing
+	manifest, err := MigrateReports(paths, MigrateReportsOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("MigrateReports (dry run) failed: %v", err)
+	}
+	if len(manifest.Moved) != 1 || manifest.JournalEntries != 1 {
+		t.Errorf("Dry run: expected 1 move and 1 journal entry, got %d and %d", len(manifest.Moved), manifest.JournalEntries)
+	}
+	if _, err := os.Stat(filepath.Join(paths.SpecsSBI, "SBI-1", "implement_1.md")); err != nil {
+		t.Errorf("Dry run moved a report: %v", err)
+	}
+
+	manifest, err = MigrateReports(paths, MigrateReportsOptions{})
+	if err != nil {
+		t.Fatalf("MigrateReports failed: %v", err)
+	}
+	if len(manifest.Moved) != 1 || len(manifest.Duplicates) != 1 || len(manifest.Conflicts) != 1 {
+		t.Errorf("Expected 1 moved, 1 duplicate and 1 conflict, got %+v", manifest)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(reportsDir, "SBI-1", "implement_1.md")); err != nil || string(data) != "impl 1" {
+		t.Errorf("Report was not moved: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(paths.SpecsSBI, "SBI-1", "review_1.md")); !os.IsNotExist(err) {
+		t.Error("Duplicate legacy report should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(paths.SpecsSBI, "SBI-2", "implement_1.md")); err != nil {
+		t.Error("Conflicting legacy report should be kept")
+	}
+	if _, err := os.Stat(filepath.Join(paths.SpecsSBI, "SBI-1", "spec.md")); err != nil {
+		t.Error("spec.md is not a report and must stay")
+	}
+
+	// Only the reference to the moved report is rewritten
+	data, _ := os.ReadFile(paths.Journal)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 journal lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `.deespec/reports/sbi/SBI-1/implement_1.md`) {
+		t.Errorf("Journal reference was not rewritten: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `.deespec/specs/sbi/SBI-2/implement_1.md`) {
+		t.Errorf("Conflicting reference should be kept: %s", lines[1])
+	}
+
+	if manifest.ManifestPath == "" || manifest.JournalBackup == "" {
+		t.Fatalf("Expected manifest and journal backup paths, got %q and %q", manifest.ManifestPath, manifest.JournalBackup)
+	}
+	root := filepath.Dir(paths.Home)
+	for _, p := range []string{manifest.ManifestPath, manifest.JournalBackup} {
+		if _, err := os.Stat(filepath.Join(root, p)); err != nil {
+			t.Errorf("Expected %s to exist: %v", p, err)
+		}
+	}
+
+	// Running again only finds the conflict that is left
+	manifest, err = MigrateReports(paths, MigrateReportsOptions{})
+	if err != nil {
+		t.Fatalf("Second MigrateReports failed: %v", err)
+	}
+	if len(manifest.Moved) != 0 || len(manifest.Conflicts) != 1 || manifest.JournalEntries != 0 {
+		t.Errorf("Second run: expected only the conflict, got %+v", manifest)
+	}
+}
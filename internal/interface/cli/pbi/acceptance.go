@@ -0,0 +1,196 @@
+package pbi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// NewAcceptanceCommand creates the pbi acceptance command with its subcommands
+func NewAcceptanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acceptance",
+		Short: "Define the end-to-end check run when all SBIs of a PBI are done",
+		Long: `Define a PBI-level acceptance test: a shell command, an agent-driven acceptance
+review against the PBI's acceptance criteria, or both.
+
+The test runs automatically when 'deespec run' completes the last SBI of the PBI
+(cancelled SBIs are left out). When it passes, the PBI is done. When it fails:
+  - the SBIs the reviewer names are reopened, with the issues as review feedback
+    for their next implement turn, or
+  - a fix SBI is registered under the PBI when no single SBI is responsible.
+After 3 failed automatic runs the PBI is marked failed.
+
+Each run writes its report to .deespec/reports/pbi/<id>/acceptance_<n>.md.
+Given a PBI ID, shows the PBI's acceptance test.`,
+		Example:           `  deespec pbi acceptance PBI-001`,
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return c.Help()
+			}
+			return withPBIAcceptance(args[0], func(container *di.Container) error {
+				test, err := container.GetPBIAcceptanceRepository().FindByPBIID(c.Context(), args[0])
+				if err != nil {
+					return err
+				}
+				if test == nil {
+					fmt.Printf("%s defines no acceptance test\n", args[0])
+					return nil
+				}
+				fmt.Printf("Acceptance test of %s:\n", args[0])
+				printAcceptanceTest(test)
+				return nil
+			})
+		},
+	}
+
+	cmd.AddCommand(newAcceptanceSetCommand())
+	cmd.AddCommand(newAcceptanceClearCommand())
+	cmd.AddCommand(newAcceptanceRunCommand())
+
+	return cmd
+}
+
+func newAcceptanceSetCommand() *cobra.Command {
+	var (
+		command     string
+		agentReview bool
+		timeout     time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set <pbi-id>",
+		Short: "Set the acceptance test of a PBI",
+		Example: `  # Run the end-to-end suite once all SBIs are done
+  deespec pbi acceptance set PBI-001 --command "make e2e"
+
+  # Also have an agent review the PBI against its acceptance criteria
+  deespec pbi acceptance set PBI-001 --command "make e2e" --agent-review --timeout 20m`,
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command = strings.TrimSpace(command)
+			if command == "" && !agentReview {
+				return errors.New("specify --command, --agent-review or both")
+			}
+			if timeout < 0 {
+				return errors.New("--timeout must not be negative")
+			}
+			test := &repository.PBIAcceptanceTest{PBIID: args[0], Command: command, AgentReview: agentReview, Timeout: timeout}
+			return withPBIAcceptance(args[0], func(container *di.Container) error {
+				if err := container.GetPBIAcceptanceRepository().Save(cmd.Context(), test); err != nil {
+					return err
+				}
+				fmt.Printf("✅ Acceptance test of %s set\n", args[0])
+				printAcceptanceTest(test)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&command, "command", "", "Shell command run in the project root; exit code 0 passes")
+	cmd.Flags().BoolVar(&agentReview, "agent-review", false, "Have an agent review the PBI against its acceptance criteria")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout of the command and the review each (default 10m)")
+
+	return cmd
+}
+
+func newAcceptanceClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "clear <pbi-id>",
+		Short:             "Remove the acceptance test of a PBI",
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withPBIAcceptance(args[0], func(container *di.Container) error {
+				if err := container.GetPBIAcceptanceRepository().Delete(cmd.Context(), args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("✅ Acceptance test of %s removed\n", args[0])
+				return nil
+			})
+		},
+	}
+}
+
+func newAcceptanceRunCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run <pbi-id>",
+		Short: "Run the acceptance test of a PBI now",
+		Long: `Run the acceptance test of a PBI now, whatever the state of its SBIs.
+
+The outcome is applied like an automatic run, except that the PBI is only
+completed when all of its SBIs are done and is never marked failed.`,
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withPBIAcceptance(args[0], func(container *di.Container) error {
+				result, err := common.NewPBIAcceptanceService(container).Run(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+
+				if result.Passed {
+					fmt.Printf("✅ %s passed its acceptance test (attempt %d)\n", args[0], result.Attempt)
+				} else {
+					fmt.Printf("❌ %s failed its acceptance test (attempt %d)\n", args[0], result.Attempt)
+					for _, issue := range result.Issues {
+						fmt.Printf("   - %s\n", issue)
+					}
+					if len(result.ReopenedSBIIDs) > 0 {
+						fmt.Printf("   Reopened: %s\n", strings.Join(result.ReopenedSBIIDs, ", "))
+					}
+					if result.FixSBIID != "" {
+						fmt.Printf("   Registered fix SBI: %s\n", result.FixSBIID)
+					}
+				}
+				fmt.Printf("   Report: %s\n", result.ReportPath)
+				return nil
+			})
+		},
+	}
+}
+
+// withPBIAcceptance initializes the container and checks that the PBI exists before running fn
+func withPBIAcceptance(pbiID string, fn func(container *di.Container) error) error {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	exists, err := persistence.NewPBISQLiteRepository(container.GetDB(), common.ProjectRoot()).Exists(pbiID)
+	if err != nil {
+		return fmt.Errorf("failed to check PBI existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("PBI %w: %s", model.ErrNotFound, pbiID)
+	}
+	return fn(container)
+}
+
+// printAcceptanceTest prints the definition of an acceptance test
+func printAcceptanceTest(test *repository.PBIAcceptanceTest) {
+	command := test.Command
+	if command == "" {
+		command = "(none)"
+	}
+	timeout := "default (10m)"
+	if test.Timeout > 0 {
+		timeout = test.Timeout.String()
+	}
+	fmt.Printf("   Command:      %s\n", command)
+	fmt.Printf("   Agent review: %t\n", test.AgentReview)
+	fmt.Printf("   Timeout:      %s\n", timeout)
+}
@@ -0,0 +1,33 @@
+package pbi
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// completePBISBIFiles completes "<pbi-id> <sbi-file>" arguments:
+// PBI IDs first, then the SBI files (sbi_*.md) in that PBI's spec directory
+func completePBISBIFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return common.CompletePBIIDs(cmd, args, toComplete)
+	case 1:
+		files, err := filepath.Glob(filepath.Join(".deespec", "specs", "pbi", args[0], "sbi_*.md"))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []string
+		for _, file := range files {
+			name := filepath.Base(file)
+			if strings.HasPrefix(name, toComplete) {
+				completions = append(completions, name)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
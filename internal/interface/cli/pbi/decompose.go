@@ -9,10 +9,12 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
 	appconfig "github.com/YoshitsuguKoike/deespec/internal/app/config"
 	pbiusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/locale"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
 	sqliterepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
 	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +23,9 @@ type decomposeFlags struct {
 	promptOnly bool // Generate prompt file only, without AI execution
 	minSBIs    int
 	maxSBIs    int
+
+	rejectOversized bool // Reject SBIs exceeding the size limits instead of flagging them
+	noSizeCheck     bool // Disable SBI sizing validation
 }
 
 // NewDecomposeCommand creates a new decompose command
@@ -39,6 +44,11 @@ By default, this command:
 
 Use --prompt-only to generate the prompt file without AI execution (for manual review).
 
+Each generated SBI is checked against the size limits in setting.json
+("decomposition": estimated hours, acceptance criteria count, touched areas).
+Oversized SBIs are flagged in approval.yaml, or rejected with --reject-oversized
+(or "reject_oversized": true) so that the PBI can be decomposed again.
+
 Only PBIs in "pending" or "planning" status can be decomposed.`,
 		Example: `  # Decompose a PBI with AI agent execution (default)
   deespec pbi decompose PBI-001
@@ -47,8 +57,12 @@ Only PBIs in "pending" or "planning" status can be decomposed.`,
   deespec pbi decompose PBI-001 --prompt-only
 
   # Specify min/max SBI count
-  deespec pbi decompose PBI-001 --min-sbis 3 --max-sbis 7`,
-		Args: cobra.ExactArgs(1),
+  deespec pbi decompose PBI-001 --min-sbis 3 --max-sbis 7
+
+  # Reject SBIs that exceed the configured size limits
+  deespec pbi decompose PBI-001 --reject-oversized`,
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			return runDecompose(pbiID, flags)
@@ -59,6 +73,8 @@ Only PBIs in "pending" or "planning" status can be decomposed.`,
 	cmd.Flags().BoolVar(&flags.promptOnly, "prompt-only", false, "Generate prompt file only without AI execution")
 	cmd.Flags().IntVar(&flags.minSBIs, "min-sbis", 2, "最小SBI数（デフォルト: 2）")
 	cmd.Flags().IntVar(&flags.maxSBIs, "max-sbis", 10, "最大SBI数（デフォルト: 10）")
+	cmd.Flags().BoolVar(&flags.rejectOversized, "reject-oversized", false, "サイズ上限を超えるSBIを却下して再分解を促す")
+	cmd.Flags().BoolVar(&flags.noSizeCheck, "no-size-check", false, "SBIのサイズ検証を無効にする")
 
 	return cmd
 }
@@ -79,7 +95,7 @@ func runDecompose(pbiID string, flags *decomposeFlags) error {
 	}
 
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqliterepo.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -120,6 +136,7 @@ func runDecompose(pbiID string, flags *decomposeFlags) error {
 
 	// Create use case
 	useCase := pbiusecase.NewDecomposePBIUseCase(pbiRepo, promptRepo, approvalRepo, labelRepo, agentGateway)
+	useCase.SetLocale(configuredLocale())
 
 	// Display progress: retrieving PBI
 	fmt.Println("🔄 PBIを取得中...")
@@ -130,6 +147,7 @@ func runDecompose(pbiID string, flags *decomposeFlags) error {
 		MaxSBIs:    flags.maxSBIs,
 		DryRun:     flags.promptOnly, // PromptOnly mode = DryRun (no AI execution)
 		OutputOnly: false,
+		SizeLimits: buildSizeLimits(flags),
 	}
 
 	// Display progress: building prompt
@@ -166,6 +184,21 @@ func runDecompose(pbiID string, flags *decomposeFlags) error {
 		fmt.Println()
 		fmt.Println("   2. 手動でClaude Code CLIを実行してください:")
 		fmt.Printf("      $ claude -p --dangerously-skip-permissions \"$(cat %s)\"\n", result.PromptFilePath)
+	} else if result.NeedsRedecomposition {
+		// Oversized SBIs were rejected: the PBI must be decomposed again
+		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Println("⚠️  サイズ上限を超えるSBIが却下されました")
+		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Println()
+		printSizeReports(result.SizeReports)
+		fmt.Printf("ℹ️  %s\n", result.Message)
+		fmt.Println()
+		fmt.Println("💡 次のステップ:")
+		fmt.Println("   1. 却下理由を確認してください")
+		fmt.Printf("      $ deespec pbi sbi list %s\n", pbiID)
+		fmt.Println()
+		fmt.Println("   2. PBIを再分解してください（必要に応じて --max-sbis を増やしてください）")
+		fmt.Printf("      $ deespec pbi decompose %s --max-sbis %d\n", pbiID, flags.maxSBIs+2)
 	} else if result.SBICount > 0 {
 		// Success: SBI files were generated
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -177,6 +210,7 @@ func runDecompose(pbiID string, flags *decomposeFlags) error {
 			fmt.Printf("   - %s\n", sbiFile)
 		}
 		fmt.Println()
+		printSizeReports(result.SizeReports)
 		fmt.Println("📋 approval.yaml作成済み")
 		fmt.Println()
 		fmt.Println("💡 次のステップ:")
@@ -211,3 +245,58 @@ func runDecompose(pbiID string, flags *decomposeFlags) error {
 
 	return nil
 }
+
+// configuredLocale returns the locale from setting.json, falling back to the default
+func configuredLocale() locale.Locale {
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		if loc, err := locale.Parse(cfg.Locale()); err == nil {
+			return loc
+		}
+	}
+	return locale.Default
+}
+
+// buildSizeLimits resolves SBI size limits from setting.json and command flags
+func buildSizeLimits(flags *decomposeFlags) pbiusecase.SBISizeLimits {
+	if flags.noSizeCheck {
+		return pbiusecase.SBISizeLimits{}
+	}
+
+	limits := pbiusecase.DefaultSBISizeLimits()
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		dc := cfg.DecompositionConfig()
+		limits = pbiusecase.SBISizeLimits{
+			MinEstimatedHours:     dc.MinEstimatedHours,
+			MaxEstimatedHours:     dc.MaxEstimatedHours,
+			MaxAcceptanceCriteria: dc.MaxAcceptanceCriteria,
+			MaxTouchedAreas:       dc.MaxTouchedAreas,
+			RejectOversized:       dc.RejectOversized,
+		}
+	}
+	if flags.rejectOversized {
+		limits.RejectOversized = true
+	}
+	return limits
+}
+
+// printSizeReports prints the size violations of oversized SBIs
+func printSizeReports(reports []*pbiusecase.SBISizeReport) {
+	oversized := 0
+	for _, report := range reports {
+		if !report.Oversized() {
+			continue
+		}
+		if oversized == 0 {
+			fmt.Println("📏 サイズ上限を超えるSBI:")
+		}
+		oversized++
+		fmt.Printf("   - %s (推定 %.1fh, 受け入れ基準 %d個, 領域 %d箇所)\n",
+			report.File, report.EstimatedHours, report.CriteriaCount, len(report.TouchedAreas))
+		for _, violation := range report.Violations {
+			fmt.Printf("       ⚠️  %s\n", violation)
+		}
+	}
+	if oversized > 0 {
+		fmt.Println()
+	}
+}
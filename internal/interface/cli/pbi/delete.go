@@ -2,13 +2,17 @@ package pbi
 
 import (
 	"bufio"
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
@@ -18,16 +22,19 @@ func NewDeleteCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "delete PBI_ID",
-		Short: "Delete a PBI",
+		Short: "Delete a PBI (moves it to the trash)",
 		Long: `Delete a Product Backlog Item (PBI).
-This removes both the database record and the Markdown directory.
+The PBI is moved to the trash: its database record is hidden and its Markdown
+directory moves to .deespec/trash. Undo with 'deespec trash restore'; it is
+permanently removed by 'deespec trash purge' after the retention window.
 By default, asks for confirmation before deleting.`,
 		Example: `  # Delete with confirmation
   deespec pbi delete PBI-001
 
   # Delete without confirmation
   deespec pbi delete PBI-001 --force`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			return runDelete(pbiID, force)
@@ -41,7 +48,7 @@ By default, asks for confirmation before deleting.`,
 
 func runDelete(pbiID string, force bool) error {
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -66,7 +73,7 @@ func runDelete(pbiID string, force bool) error {
 		return fmt.Errorf("failed to check PBI existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("PBI not found: %s", pbiID)
+		return fmt.Errorf("PBI %w: %s", model.ErrNotFound, pbiID)
 	}
 
 	// Load PBI details for confirmation message
@@ -79,7 +86,7 @@ func runDelete(pbiID string, force bool) error {
 	if !force {
 		fmt.Printf("⚠️  Delete PBI: %s\n", pbiID)
 		fmt.Printf("    Title: %s\n", p.Title)
-		fmt.Printf("    This will remove both database record and Markdown files.\n\n")
+		fmt.Printf("    It can be restored with 'deespec trash restore %s'.\n\n", pbiID)
 		fmt.Print("Are you sure? (y/N): ")
 
 		reader := bufio.NewReader(os.Stdin)
@@ -95,12 +102,13 @@ func runDelete(pbiID string, force bool) error {
 		}
 	}
 
-	// Delete PBI
-	if err := repo.Delete(pbiID); err != nil {
+	// Move PBI to the trash
+	trash := common.NewTrashUseCase(sqlite.NewTrashRepository(db))
+	if err := trash.Delete(context.Background(), repository.TrashKindPBI, pbiID); err != nil {
 		return fmt.Errorf("failed to delete PBI: %w", err)
 	}
 
-	fmt.Printf("✅ PBI deleted: %s\n", pbiID)
+	fmt.Printf("✅ PBI moved to the trash: %s\n", pbiID)
 
 	return nil
 }
@@ -7,8 +7,10 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
@@ -33,7 +35,8 @@ and the Markdown file before opening the editor.`,
 
   # Use specific editor
   EDITOR=nano deespec pbi edit PBI-001`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			return runEdit(pbiID, title)
@@ -47,7 +50,7 @@ and the Markdown file before opening the editor.`,
 
 func runEdit(pbiID string, newTitle string) error {
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -72,7 +75,7 @@ func runEdit(pbiID string, newTitle string) error {
 		return fmt.Errorf("failed to check PBI existence: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("PBI not found: %s", pbiID)
+		return fmt.Errorf("PBI %w: %s", model.ErrNotFound, pbiID)
 	}
 
 	// Get Markdown file path
@@ -86,7 +89,7 @@ func runEdit(pbiID string, newTitle string) error {
 	// Update title if specified
 	if newTitle != "" {
 		// Update database
-		_, err := db.Exec("UPDATE pbis SET title = ? WHERE id = ?", newTitle, pbiID)
+		_, err := db.Exec("UPDATE pbis SET title = ?, version = version + 1 WHERE id = ?", newTitle, pbiID)
 		if err != nil {
 			return fmt.Errorf("failed to update title in database: %w", err)
 		}
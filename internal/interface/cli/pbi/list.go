@@ -1,10 +1,12 @@
 package pbi
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 
+	pbiusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/pbi"
 	pbidomain "github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
@@ -13,32 +15,43 @@ import (
 
 // NewListCommand creates a new list command
 func NewListCommand() *cobra.Command {
-	var status string
+	var (
+		status string
+		tree   bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all PBIs",
-		Long:  "Display a list of all Product Backlog Items",
+		Long: `Display a list of all Product Backlog Items.
+
+With --tree, the backlog is shown as an EPIC → PBI → SBI tree with status
+glyphs, story points (done/total) and SBI progress per node. PBIs outside any
+EPIC and SBIs outside any PBI are listed after the EPICs.`,
 		Example: `  # List all PBIs
   deespec pbi list
 
+  # Show the EPIC → PBI → SBI hierarchy
+  deespec pbi list --tree
+
   # List PBIs by status
   deespec pbi list --status pending
   deespec pbi list --status in_progress
   deespec pbi list --status done`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(status)
+			return runList(status, tree)
 		},
 	}
 
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status (pending|planning|planed|in_progress|done)")
+	cmd.Flags().BoolVar(&tree, "tree", false, "Show the EPIC → PBI → SBI hierarchy")
 
 	return cmd
 }
 
-func runList(statusFilter string) error {
+func runList(statusFilter string, tree bool) error {
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -57,6 +70,16 @@ func runList(statusFilter string) error {
 	}
 	repo := persistence.NewPBISQLiteRepository(db, rootPath)
 
+	if tree {
+		useCase := pbiusecase.NewBacklogTreeUseCase(sqlite.NewTaskRepository(db), repo)
+		backlog, err := useCase.Execute(context.Background(), pbidomain.Status(statusFilter))
+		if err != nil {
+			return fmt.Errorf("failed to build backlog tree: %w", err)
+		}
+		printBacklogTree(backlog)
+		return nil
+	}
+
 	// Find PBIs with SBI counts
 	var pbiWithCounts []*persistence.PBIWithSBICount
 	if statusFilter != "" {
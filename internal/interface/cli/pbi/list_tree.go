@@ -0,0 +1,107 @@
+package pbi
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	pbiusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/pbi"
+)
+
+// statusGlyph returns the glyph of an EPIC, PBI or SBI status
+func statusGlyph(status string) string {
+	switch strings.ToLower(status) {
+	case "pending":
+		return "○"
+	case "planning", "planed", "picked":
+		return "◔"
+	case "in_progress", "implementing", "reviewing":
+		return "◐"
+	case "done", "completed":
+		return "●"
+	case "failed":
+		return "✗"
+	case "cancelled":
+		return "⊘"
+	default:
+		return "?"
+	}
+}
+
+// printBacklogTree prints the backlog tree to stdout
+func printBacklogTree(tree *pbiusecase.BacklogTree) {
+	writeBacklogTree(os.Stdout, tree)
+}
+
+// writeBacklogTree renders the backlog tree, PBIs without an EPIC and SBIs without a PBI last
+func writeBacklogTree(w io.Writer, tree *pbiusecase.BacklogTree) {
+	pbiCount, sbiCount := len(tree.PBIs), len(tree.SBIs)
+	for _, e := range tree.EPICs {
+		pbiCount += len(e.Children)
+		sbiCount += e.SBITotal
+	}
+	for _, p := range tree.PBIs {
+		sbiCount += p.SBITotal
+	}
+
+	fmt.Fprintf(w, "Backlog tree (EPICs: %d, PBIs: %d, SBIs: %d, story points: %d/%d done)\n",
+		len(tree.EPICs), pbiCount, sbiCount, tree.DoneStoryPoints, tree.StoryPoints)
+	fmt.Fprintln(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(tree.EPICs) == 0 && len(tree.PBIs) == 0 && len(tree.SBIs) == 0 {
+		fmt.Fprintln(w, "No PBIs found.")
+		return
+	}
+
+	for _, e := range tree.EPICs {
+		fmt.Fprintf(w, "%s EPIC %s %s\n", statusGlyph(e.Status), e.ID, nodeSummary(e))
+		writeChildren(w, e.Children, "")
+		fmt.Fprintln(w)
+	}
+	if len(tree.PBIs) > 0 {
+		fmt.Fprintln(w, "(no EPIC)")
+		writeChildren(w, tree.PBIs, "")
+		fmt.Fprintln(w)
+	}
+	if len(tree.SBIs) > 0 {
+		fmt.Fprintln(w, "(no PBI)")
+		writeChildren(w, tree.SBIs, "")
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "○ pending  ◔ planned/picked  ◐ in progress  ● done  ✗ failed  ⊘ cancelled")
+}
+
+// writeChildren renders nodes below a parent, with indent carrying the guides of the ancestors
+func writeChildren(w io.Writer, nodes []*pbiusecase.BacklogTreeNode, indent string) {
+	for i, node := range nodes {
+		branch, guide := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, guide = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%s%s%s %s\n", indent, branch, nodeLabel(node), nodeSummary(node))
+		writeChildren(w, node.Children, indent+guide)
+	}
+}
+
+// nodeLabel returns the status glyph and ID of a node
+func nodeLabel(node *pbiusecase.BacklogTreeNode) string {
+	return statusGlyph(node.Status) + " " + node.ID
+}
+
+// nodeSummary returns the title of a node with its story points and SBI progress, if any
+func nodeSummary(node *pbiusecase.BacklogTreeNode) string {
+	var stats []string
+	if node.StoryPoints > 0 {
+		stats = append(stats, fmt.Sprintf("%d/%d SP", node.DoneStoryPoints, node.StoryPoints))
+	}
+	if len(node.Children) > 0 && node.SBITotal > 0 {
+		stats = append(stats, fmt.Sprintf("SBIs %d/%d", node.SBIDone, node.SBITotal))
+	}
+	summary := truncateString(node.Title, 50)
+	if len(stats) > 0 {
+		summary += "  [" + strings.Join(stats, " · ") + "]"
+	}
+	return summary
+}
@@ -21,7 +21,9 @@ func NewPBICommand() *cobra.Command {
 	cmd.AddCommand(NewEditCommand())
 	cmd.AddCommand(NewDeleteCommand())
 	cmd.AddCommand(NewDecomposeCommand())
+	cmd.AddCommand(NewRedecomposeCommand())
 	cmd.AddCommand(NewSBICommand())
+	cmd.AddCommand(NewAcceptanceCommand())
 
 	return cmd
 }
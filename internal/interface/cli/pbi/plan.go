@@ -13,6 +13,7 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/parser"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,7 @@ func NewPlanCommand() *cobra.Command {
 		storyPoints int
 		priority    int
 		status      string
+		force       bool
 	)
 
 	cmd := &cobra.Command{
@@ -37,6 +39,10 @@ func NewPlanCommand() *cobra.Command {
 The PBI ID is automatically generated by the system.
 Markdown file is stored at .deespec/specs/pbi/{id}/pbi.md
 
+The title and body are compared with existing PBIs. Near-duplicates are listed
+as a warning, or refuse the plan when duplicate_check.mode is "block" in
+setting.json; --force plans the PBI anyway.
+
 After planning, use 'deespec pbi decompose' to break down the PBI into SBIs.`,
 		Example: `  # Interactive mode
   deespec pbi plan
@@ -49,9 +55,12 @@ After planning, use 'deespec pbi decompose' to break down the PBI into SBIs.`,
     -t "Custom Title" \
     --story-points 8 \
     --priority 1 \
-    --status planning`,
+    --status planning
+
+  # Plan although a similar PBI exists
+  deespec pbi plan -f docs/plan.md --force`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPlan(filePath, title, storyPoints, priority, status)
+			return runPlan(common.ResolveUserPath(filePath), title, storyPoints, priority, status, force)
 		},
 	}
 
@@ -60,11 +69,12 @@ After planning, use 'deespec pbi decompose' to break down the PBI into SBIs.`,
 	cmd.Flags().IntVarP(&storyPoints, "story-points", "s", -1, "Override story points (0-13)")
 	cmd.Flags().IntVarP(&priority, "priority", "p", -1, "Override priority (0=通常, 1=高, 2=緊急)")
 	cmd.Flags().StringVar(&status, "status", "", "Override status (pending|planning|planed|in_progress|done)")
+	cmd.Flags().BoolVar(&force, "force", false, "Plan even when a similar PBI already exists")
 
 	return cmd
 }
 
-func runPlan(filePath, titleOverride string, storyPoints, priority int, status string) error {
+func runPlan(filePath, titleOverride string, storyPoints, priority int, status string, force bool) error {
 	// Load settings
 	rootPath, err := os.Getwd()
 	if err != nil {
@@ -76,7 +86,7 @@ func runPlan(filePath, titleOverride string, storyPoints, priority int, status s
 	}
 
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -87,6 +97,7 @@ func runPlan(filePath, titleOverride string, storyPoints, priority int, status s
 	if err := migrator.Migrate(); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
+	common.ConfigureIDScheme(sqlite.NewIDSequenceRepository(db))
 
 	// Create repository
 	repo := persistence.NewPBISQLiteRepository(db, rootPath)
@@ -141,6 +152,16 @@ func runPlan(filePath, titleOverride string, storyPoints, priority int, status s
 		p.Status = pbidomain.Status(status)
 	}
 
+	// Look for near-duplicates of the PBI
+	duplicateCheck := common.NewDuplicateCheck()
+	duplicates, err := duplicateCheck.CheckPBI(repo, "", p.Title, body)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate PBIs: %w", err)
+	}
+	if err := common.ReportDuplicates(os.Stderr, duplicateCheck, "PBI", duplicates, force); err != nil {
+		return err
+	}
+
 	// Execute use case
 	pbiID, err := useCase.Execute(p, body)
 	if err != nil {
@@ -0,0 +1,147 @@
+package pbi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
+	appconfig "github.com/YoshitsuguKoike/deespec/internal/app/config"
+	pbiusecase "github.com/YoshitsuguKoike/deespec/internal/application/usecase/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewRedecomposeCommand creates a new redecompose command
+func NewRedecomposeCommand() *cobra.Command {
+	flags := &decomposeFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "redecompose <pbi-id>",
+		Short: "Re-decompose a partially completed PBI, adding only new SBIs",
+		Long: `Re-run decomposition for a PBI that already has SBIs.
+
+Existing SBIs (including DONE ones) are preserved and listed in the prompt.
+Proposed SBIs that duplicate existing work are dropped; only genuinely new
+SBIs are added to the PBI directory and appended to approval.yaml in pending
+status. Approve them and run 'deespec pbi register' to register only the new SBIs.`,
+		Example: `  # Re-decompose a PBI after its requirements changed
+  deespec pbi redecompose PBI-001
+
+  # Generate prompt file only (for manual review)
+  deespec pbi redecompose PBI-001 --prompt-only`,
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRedecompose(args[0], flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.promptOnly, "prompt-only", false, "Generate prompt file only without AI execution")
+	cmd.Flags().IntVar(&flags.minSBIs, "min-sbis", 1, "追加する最小SBI数（デフォルト: 1）")
+	cmd.Flags().IntVar(&flags.maxSBIs, "max-sbis", 5, "追加する最大SBI数（デフォルト: 5）")
+	cmd.Flags().BoolVar(&flags.rejectOversized, "reject-oversized", false, "サイズ上限を超えるSBIを却下する")
+	cmd.Flags().BoolVar(&flags.noSizeCheck, "no-size-check", false, "SBIのサイズ検証を無効にする")
+
+	return cmd
+}
+
+func runRedecompose(pbiID string, flags *decomposeFlags) error {
+	ctx := context.Background()
+
+	if flags.minSBIs < 1 {
+		return fmt.Errorf("--min-sbis must be at least 1, got %d", flags.minSBIs)
+	}
+	if flags.maxSBIs < flags.minSBIs {
+		return fmt.Errorf("--max-sbis (%d) must be greater than or equal to --min-sbis (%d)",
+			flags.maxSBIs, flags.minSBIs)
+	}
+
+	// Open database
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	migrator := sqlite.NewMigrator(db)
+	if err := migrator.Migrate(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	pbiRepo := persistence.NewPBISQLiteRepository(db, rootPath)
+	sbiRepo := sqlite.NewSBIRepository(db)
+	promptRepo := infrarepo.NewPromptTemplateRepositoryImpl()
+	approvalRepo := infrarepo.NewSBIApprovalRepositoryImpl()
+	labelRepo := sqlite.NewLabelRepository(db, appconfig.LabelConfig{
+		TemplateDirs: []string{".claude", ".deespec/prompts/labels"},
+	})
+
+	useCase := pbiusecase.NewRedecomposePBIUseCase(
+		pbiRepo, sbiRepo, promptRepo, approvalRepo, labelRepo, agent.NewClaudeCodeCLIGateway(),
+	)
+	useCase.SetLocale(configuredLocale())
+
+	fmt.Println("🔄 既存SBIを確認し、再分解中...")
+	result, err := useCase.Execute(ctx, pbiID, pbiusecase.DecomposeOptions{
+		MinSBIs:    flags.minSBIs,
+		MaxSBIs:    flags.maxSBIs,
+		DryRun:     flags.promptOnly,
+		SizeLimits: buildSizeLimits(flags),
+	})
+	if err != nil {
+		return fmt.Errorf("PBIの再分解に失敗しました: %w", err)
+	}
+
+	fmt.Println("✅ 完了")
+	fmt.Println()
+	fmt.Printf("📦 既存SBI: %d個 (うちDONE: %d個) は保持されます\n", result.PreservedCount, result.DoneCount)
+	fmt.Printf("📁 プロンプト: %s\n", result.PromptFilePath)
+	fmt.Println()
+
+	if flags.promptOnly {
+		fmt.Println("💡 手動でClaude Code CLIを実行してください:")
+		fmt.Printf("   $ claude -p --dangerously-skip-permissions \"$(cat %s)\"\n", result.PromptFilePath)
+		return nil
+	}
+
+	if len(result.Duplicates) > 0 {
+		fmt.Println("♻️  既存SBIと重複するため除外された提案:")
+		for _, dup := range result.Duplicates {
+			fmt.Printf("   - %s (≈ [%s] %s)\n", dup.Title, dup.MatchedStatus, dup.MatchedTitle)
+		}
+		fmt.Println()
+	}
+	for _, errMsg := range result.Errors {
+		fmt.Printf("⚠️  %s\n", errMsg)
+	}
+
+	if len(result.AddedFiles) == 0 {
+		fmt.Printf("ℹ️  %s\n", result.Message)
+		return nil
+	}
+
+	fmt.Println("🆕 追加されたSBIファイル:")
+	for _, file := range result.AddedFiles {
+		fmt.Printf("   - %s\n", file)
+	}
+	fmt.Println()
+	printSizeReports(result.SizeReports)
+	fmt.Println("💡 次のステップ:")
+	fmt.Println("   1. 追加されたSBIを承認してください")
+	fmt.Printf("      $ deespec pbi sbi approve %s <sbi-file>\n", pbiID)
+	fmt.Println()
+	fmt.Println("   2. 新しいSBIのみを登録してください")
+	fmt.Printf("      $ deespec pbi register %s\n", pbiID)
+	fmt.Println()
+
+	return nil
+}
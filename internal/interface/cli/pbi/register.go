@@ -11,6 +11,7 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
 	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
@@ -43,7 +44,8 @@ can be executed using 'deespec run' or 'deespec sbi list'.`,
 
   # Force re-registration of already registered SBIs
   deespec pbi register PBI-001 --force`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			return runRegister(pbiID, flags)
@@ -116,7 +118,7 @@ func runRegister(pbiID string, flags *registerFlags) error {
 	}
 
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -127,6 +129,7 @@ func runRegister(pbiID string, flags *registerFlags) error {
 	if err := migrator.Migrate(); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
+	common.ConfigureIDScheme(sqlite.NewIDSequenceRepository(db))
 
 	// Create repositories
 	rootPath, err := os.Getwd()
@@ -42,6 +42,7 @@ func NewSBIApproveCommand() *cobra.Command {
 
   # Approve with specific reviewer
   deespec pbi sbi approve PBI-001 sbi_1.md --user "alice"`,
+		ValidArgsFunction: completePBISBIFiles,
 		Args: func(cmd *cobra.Command, args []string) error {
 			// If --all is specified, require exactly 1 arg (pbi-id)
 			// Otherwise, require exactly 2 args (pbi-id and sbi-file)
@@ -276,7 +277,7 @@ func runSBIApproveAll(pbiID string, flags *sbiApproveFlags) error {
 // updatePBIStatusToPlaned updates PBI status to planed when all SBIs are approved
 func updatePBIStatusToPlaned(ctx context.Context, pbiID string) error {
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -37,7 +37,8 @@ func NewSBIEditCommand() *cobra.Command {
 
   # Edit with notes
   deespec pbi sbi edit PBI-001 sbi_1.md --notes "推定工数を3時間→4時間に修正"`,
-		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: completePBISBIFiles,
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			sbiFile := args[1]
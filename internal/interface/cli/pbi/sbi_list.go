@@ -9,6 +9,7 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
@@ -37,7 +38,8 @@ func NewSBIListCommand() *cobra.Command {
 		Long:  "Display a list of all generated SBIs with their approval status for a specific PBI",
 		Example: `  # List SBIs for a PBI
   deespec pbi sbi list PBI-001`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			return runSBIList(pbiID)
@@ -31,7 +31,8 @@ func NewSBIRejectCommand() *cobra.Command {
 
   # Reject with specific reviewer
   deespec pbi sbi reject PBI-001 sbi_2.md --reason "粒度が大きすぎる" --user "alice"`,
-		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: completePBISBIFiles,
+		Args:              cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			sbiFile := args[1]
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -37,7 +38,8 @@ Use --detail flag to see the PBI file content.`,
   # Show PBI file details
   deespec pbi show PBI-001 --detail
   deespec pbi show PBI-001 -d`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
 			if flags.detail {
@@ -84,7 +86,7 @@ func runShowSBIList(ctx context.Context, pbiID string) error {
 
 // outputSBITable outputs the SBI list in table format
 func outputSBITable(tasks []dto.TaskDTO, pbiID string, taskUseCase interface {
-	GetSBI(context.Context, string) (*dto.SBIDTO, error)
+	GetSBIs(context.Context, []string) ([]*dto.SBIDTO, error)
 }, ctx context.Context) error {
 	if len(tasks) == 0 {
 		fmt.Printf("No SBIs found for PBI: %s\n", pbiID)
@@ -104,6 +106,18 @@ func outputSBITable(tasks []dto.TaskDTO, pbiID string, taskUseCase interface {
 	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tSTEP\tTURN\tSTARTED\tCOMPLETED\tCREATED\n")
 	fmt.Fprintf(w, "---\t-----\t------\t----\t----\t-------\t---------\t-------\n")
 
+	// Fetch detailed SBI info to get turn, started_at, completed_at for all rows at once
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	details := make(map[string]*dto.SBIDTO, len(tasks))
+	if sbiDTOs, err := taskUseCase.GetSBIs(ctx, ids); err == nil {
+		for _, sbiDTO := range sbiDTOs {
+			details[sbiDTO.ID] = sbiDTO
+		}
+	}
+
 	// Print rows
 	for _, task := range tasks {
 		id := task.ID
@@ -112,12 +126,11 @@ func outputSBITable(tasks []dto.TaskDTO, pbiID string, taskUseCase interface {
 		step := task.CurrentStep
 		created := formatTime(task.CreatedAt)
 
-		// Fetch detailed SBI info to get turn, started_at, completed_at
-		sbiDTO, err := taskUseCase.GetSBI(ctx, task.ID)
+		sbiDTO, ok := details[task.ID]
 		turn := "-"
 		started := "-"
 		completed := "-"
-		if err == nil {
+		if ok {
 			turn = fmt.Sprintf("%d", sbiDTO.CurrentTurn)
 			started = formatTimePtr(sbiDTO.StartedAt)
 			completed = formatTimePtr(sbiDTO.CompletedAt)
@@ -136,7 +149,7 @@ func outputSBITable(tasks []dto.TaskDTO, pbiID string, taskUseCase interface {
 // runShowDetail displays PBI file details
 func runShowDetail(pbiID string) error {
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -177,6 +190,22 @@ func runShowDetail(pbiID string) error {
 	if p.ParentEpicID != "" {
 		fmt.Printf("📂 Parent EPIC: %s\n", p.ParentEpicID)
 	}
+	if p.Deadline != nil {
+		overdue := ""
+		if p.IsOverdue(time.Now()) {
+			overdue = " ⚠️ OVERDUE"
+		}
+		fmt.Printf("⏰ Deadline: %s%s\n", p.Deadline.Local().Format("2006-01-02 15:04:05"), overdue)
+	}
+	if len(p.Env) > 0 {
+		fmt.Printf("🔧 Env: %v\n", common.FormatEnv(p.Env))
+	}
+	if len(p.Labels) > 0 {
+		fmt.Printf("🏷️  Labels: %s\n", strings.Join(p.Labels, ", "))
+	}
+	if p.AssignedAgent != "" {
+		fmt.Printf("🤖 Agent: %s\n", p.AssignedAgent)
+	}
 	fmt.Println()
 	fmt.Printf("📄 Markdown File: %s\n", p.GetMarkdownPath())
 	fmt.Println()
@@ -1,6 +1,7 @@
 package pbi
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -9,22 +10,34 @@ import (
 	pbidomain "github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	prioritycmd "github.com/YoshitsuguKoike/deespec/internal/interface/cli/priority"
 	"github.com/spf13/cobra"
 )
 
 // NewUpdateCommand creates a new update command
 func NewUpdateCommand() *cobra.Command {
 	var (
-		status      string
-		storyPoints int
-		priority    int
+		status        string
+		storyPoints   int
+		priority      int
+		deadline      string
+		clearDeadline bool
+		env           []string
+		unsetEnv      []string
+		labels        []string
+		agent         string
+		propagate     bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "update PBI_ID",
 		Short: "Update a PBI's metadata",
 		Long: `Update Product Backlog Item (PBI) metadata.
-You can update status, story points, and priority.
+You can update status, story points, priority, deadline, env, labels and agent.
+Env variables are exported to agents working on the PBI's SBIs
+(an SBI's own env overrides them). Labels, agent and priority are
+inherited by the SBIs decomposed from the PBI.
 The Markdown body is preserved unchanged.`,
 		Example: `  # Update status
   deespec pbi update PBI-001 --status in_progress
@@ -36,24 +49,57 @@ The Markdown body is preserved unchanged.`,
     --priority 1
 
   # Update priority only
-  deespec pbi update PBI-002 --priority 2`,
-		Args: cobra.ExactArgs(1),
+  deespec pbi update PBI-002 --priority 2
+
+  # Raise the priority of the PBI and of the SBIs that did not override it
+  deespec pbi update PBI-002 --priority 2 --propagate
+
+  # Set or clear a deadline
+  deespec pbi update PBI-002 --deadline 2025-10-31
+  deespec pbi update PBI-002 --clear-deadline
+
+  # Set or remove non-secret env for the agents (${name} expands task_env.vars)
+  deespec pbi update PBI-002 --env TARGET_SERVICE=checkout --env FEATURE_FLAG=new_cart
+  deespec pbi update PBI-002 --unset-env FEATURE_FLAG
+
+  # Set the labels and agent inherited by decomposed SBIs (--labels "" clears them)
+  deespec pbi update PBI-002 --labels security,backend --agent codex`,
+		ValidArgsFunction: common.CompletePBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pbiID := args[0]
-			return runUpdate(pbiID, status, storyPoints, priority)
+			var labelsUpdate *[]string
+			if cmd.Flags().Changed("labels") {
+				labelsUpdate = &labels
+			}
+			var agentUpdate *string
+			if cmd.Flags().Changed("agent") {
+				agentUpdate = &agent
+			}
+			if propagate && priority < 0 {
+				return fmt.Errorf("--propagate requires --priority")
+			}
+			return runUpdate(cmd.Context(), pbiID, status, storyPoints, priority, deadline, clearDeadline, env, unsetEnv, labelsUpdate, agentUpdate, propagate)
 		},
 	}
 
 	cmd.Flags().StringVar(&status, "status", "", "Update status (pending|planning|planed|in_progress|done)")
 	cmd.Flags().IntVarP(&storyPoints, "story-points", "s", -1, "Update story points (0-13)")
 	cmd.Flags().IntVarP(&priority, "priority", "p", -1, "Update priority (0=通常, 1=高, 2=緊急)")
+	cmd.Flags().StringVar(&deadline, "deadline", "", "Update deadline (YYYY-MM-DD, \"YYYY-MM-DD HH:MM\" or RFC3339)")
+	cmd.Flags().BoolVar(&clearDeadline, "clear-deadline", false, "Remove the deadline")
+	cmd.Flags().StringArrayVar(&env, "env", []string{}, "Set a non-secret env variable for agent steps, KEY=VALUE (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&unsetEnv, "unset-env", []string{}, "Remove an env variable (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&labels, "labels", []string{}, "Replace the labels inherited by decomposed SBIs (comma-separated)")
+	cmd.Flags().StringVar(&agent, "agent", "", "Set the agent assigned to decomposed SBIs (empty = default agent)")
+	cmd.Flags().BoolVar(&propagate, "propagate", false, "Pass a priority change down to the SBIs that did not override it")
 
 	return cmd
 }
 
-func runUpdate(pbiID, status string, storyPoints, priority int) error {
+func runUpdate(ctx context.Context, pbiID, status string, storyPoints, priority int, deadline string, clearDeadline bool, env, unsetEnv []string, labels *[]string, agent *string, propagate bool) error {
 	// Open database
-	db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+	db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -112,9 +158,49 @@ func runUpdate(pbiID, status string, storyPoints, priority int) error {
 		opts.Priority = &p
 	}
 
+	// Validate and set deadline
+	if deadline != "" && clearDeadline {
+		return fmt.Errorf("--deadline and --clear-deadline cannot be used together")
+	}
+	if deadline != "" {
+		d, err := common.ParseDeadline(deadline)
+		if err != nil {
+			return err
+		}
+		opts.Deadline = d
+	}
+	opts.ClearDeadline = clearDeadline
+
+	// Validate and set env
+	setEnv, err := common.ParseEnvAssignments(env)
+	if err != nil {
+		return err
+	}
+	opts.SetEnv = setEnv
+	opts.UnsetEnv = unsetEnv
+
+	// Set labels and agent
+	if labels != nil {
+		cleaned := pbi.NormalizeLabels(*labels)
+		opts.Labels = &cleaned
+	}
+	opts.AssignedAgent = agent
+
 	// Check if any updates were provided
-	if opts.Status == nil && opts.EstimatedStoryPoints == nil && opts.Priority == nil {
-		return fmt.Errorf("no updates specified (use --status, --story-points, or --priority)")
+	if opts.Status == nil && opts.EstimatedStoryPoints == nil && opts.Priority == nil &&
+		opts.Deadline == nil && !opts.ClearDeadline && len(opts.SetEnv) == 0 && len(opts.UnsetEnv) == 0 &&
+		opts.Labels == nil && opts.AssignedAgent == nil {
+		return fmt.Errorf("no updates specified (use --status, --story-points, --priority, --deadline, --env, --labels, or --agent)")
+	}
+
+	// Remember the priority the SBIs inherited
+	var previousPriority int
+	if propagate {
+		current, err := repo.FindByID(pbiID)
+		if err != nil {
+			return fmt.Errorf("failed to find PBI: %w", err)
+		}
+		previousPriority = int(current.Priority)
 	}
 
 	// Execute use case
@@ -123,6 +209,11 @@ func runUpdate(pbiID, status string, storyPoints, priority int) error {
 	}
 
 	fmt.Printf("✅ PBI updated: %s\n", pbiID)
+	if propagate {
+		if err := prioritycmd.Propagate(ctx, pbiID, previousPriority); err != nil {
+			return err
+		}
+	}
 	fmt.Printf("\nView details: deespec pbi show %s\n", pbiID)
 
 	return nil
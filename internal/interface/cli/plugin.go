@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the executable name prefix of exec-based extensions
+// e.g. `deespec-jira` on PATH is available as `deespec jira`.
+const pluginPrefix = "deespec-"
+
+// PluginProtocolVersion is the version of the context document sent to plugins on stdin
+const PluginProtocolVersion = 1
+
+// PluginContext is the workspace context a plugin receives as JSON on stdin
+type PluginContext struct {
+	ProtocolVersion int      `json:"protocol_version"`
+	Plugin          string   `json:"plugin"`
+	Args            []string `json:"args"`
+	Workspace       string   `json:"workspace"`    // Working directory deespec was invoked in
+	Home            string   `json:"home"`         // .deespec directory of the workspace
+	DBPath          string   `json:"db_path"`      // SQLite database used by deespec
+	SettingPath     string   `json:"setting_path"` // setting.json path (empty if defaults are used)
+	Version         string   `json:"version"`      // deespec version
+}
+
+// PluginExitError reports a non-zero exit status of a plugin
+// main exits with the same status so scripts can rely on the plugin's exit code.
+type PluginExitError struct {
+	Plugin string
+	Code   int
+}
+
+func (e *PluginExitError) Error() string {
+	return fmt.Sprintf("plugin %q exited with status %d", e.Plugin, e.Code)
+}
+
+// ExitCode returns the plugin's exit status
+func (e *PluginExitError) ExitCode() int {
+	return e.Code
+}
+
+// registerPlugins adds `deespec-<name>` executables found on PATH as subcommands (git-style)
+// Built-in commands always win; the first match on PATH wins among plugins.
+func registerPlugins(root *cobra.Command, pathEnv string) {
+	builtin := builtinCommandNames(root)
+	plugins := discoverPlugins(pathEnv)
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if builtin[name] {
+			continue
+		}
+		root.AddCommand(newPluginCommand(name, plugins[name]))
+	}
+}
+
+// discoverPlugins returns plugin name -> executable path for deespec-* executables on pathEnv
+func discoverPlugins(pathEnv string) map[string]string {
+	plugins := make(map[string]string)
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			if _, exists := plugins[name]; exists {
+				continue // Earlier PATH entries take precedence
+			}
+			path := filepath.Join(dir, entry.Name())
+			if isExecutable(path) {
+				plugins[name] = path
+			}
+		}
+	}
+	return plugins
+}
+
+// pluginName extracts the subcommand name from a deespec-<name> file name
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, pluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if name == "" || strings.ContainsAny(name, " \t.") {
+		return "", false
+	}
+	return name, true
+}
+
+// isExecutable reports whether path is a regular file the user may execute
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// newPluginCommand creates a command that runs an external plugin executable
+func newPluginCommand(name, path string) *cobra.Command {
+	return &cobra.Command{
+		Use:                name,
+		Short:              fmt.Sprintf("Plugin (%s)", path),
+		DisableFlagParsing: true, // Flags belong to the plugin
+		// The plugin reports its own errors
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runPlugin(c, name, path, args)
+		},
+	}
+}
+
+// runPlugin executes the plugin with the workspace context as JSON on stdin
+// The plugin's stdout and stderr are passed through unchanged, and the workspace
+// context is also exported as DEESPEC_* environment variables.
+func runPlugin(c *cobra.Command, name, path string, args []string) error {
+	pluginCtx, err := buildPluginContext(name, args)
+	if err != nil {
+		return err
+	}
+	input, err := json.Marshal(pluginCtx)
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin context: %w", err)
+	}
+
+	cmd := exec.CommandContext(c.Context(), path, args...)
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+	cmd.Stdout = c.OutOrStdout()
+	cmd.Stderr = c.ErrOrStderr()
+	cmd.Env = append(os.Environ(),
+		"DEESPEC_PLUGIN="+name,
+		"DEESPEC_WORKSPACE="+pluginCtx.Workspace,
+		"DEESPEC_HOME="+pluginCtx.Home,
+		"DEESPEC_DB_PATH="+pluginCtx.DBPath,
+		"DEESPEC_VERSION="+pluginCtx.Version,
+	)
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &PluginExitError{Plugin: name, Code: exitErr.ExitCode()}
+		}
+		return fmt.Errorf("failed to run plugin %q: %w", name, err)
+	}
+	return nil
+}
+
+// buildPluginContext collects the workspace context passed to plugins
+func buildPluginContext(name string, args []string) (*PluginContext, error) {
+	workspace, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	dbPath, err := common.ResolveDBPath()
+	if err != nil {
+		return nil, err
+	}
+	if abs, err := filepath.Abs(dbPath); err == nil {
+		dbPath = abs
+	}
+
+	pluginCtx := &PluginContext{
+		ProtocolVersion: PluginProtocolVersion,
+		Plugin:          name,
+		Args:            args,
+		Workspace:       workspace,
+		Home:            filepath.Join(workspace, ".deespec"),
+		DBPath:          dbPath,
+		Version:         buildinfo.GetVersion(),
+	}
+	if pluginCtx.Args == nil {
+		pluginCtx.Args = []string{}
+	}
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		pluginCtx.SettingPath = cfg.SettingPath()
+		if home := cfg.Home(); home != "" {
+			if !filepath.IsAbs(home) {
+				home = filepath.Join(workspace, home)
+			}
+			pluginCtx.Home = home
+		}
+	}
+	return pluginCtx, nil
+}
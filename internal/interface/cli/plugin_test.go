@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin creates an executable shell script named deespec-<name> in dir
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, pluginPrefix+name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestRegisterPlugins_DiscoversOnPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not executable on Windows")
+	}
+
+	first := t.TempDir()
+	second := t.TempDir()
+	hello := writePlugin(t, first, "hello", "exit 0\n")
+	writePlugin(t, second, "hello", "exit 1\n") // Shadowed by the first PATH entry
+	writePlugin(t, second, "run", "exit 1\n")   // Built-in commands win
+	require.NoError(t, os.WriteFile(filepath.Join(second, pluginPrefix+"notexec"), []byte("x"), 0o644))
+
+	root := &cobra.Command{Use: "deespec"}
+	root.AddCommand(&cobra.Command{Use: "run", Run: func(*cobra.Command, []string) {}})
+
+	registerPlugins(root, first+string(os.PathListSeparator)+second)
+
+	cmd, _, err := root.Find([]string{"hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", cmd.Name())
+	assert.Contains(t, cmd.Short, hello)
+
+	cmd, _, err = root.Find([]string{"run"})
+	require.NoError(t, err)
+	assert.NotContains(t, cmd.Short, "Plugin")
+
+	_, _, err = root.Find([]string{"notexec"})
+	assert.Error(t, err)
+}
+
+func TestRunPlugin_PassesContextAndExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not executable on Windows")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "echo", `cat
+echo "env=$DEESPEC_PLUGIN"
+[ "$1" = "--fail" ] && exit 3
+exit 0
+`)
+
+	root := &cobra.Command{Use: "deespec"}
+	registerPlugins(root, dir)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"echo", "--flag", "value"})
+	require.NoError(t, root.Execute())
+
+	// First line is the JSON context from stdin
+	lines := bytes.SplitN(out.Bytes(), []byte("\n"), 2)
+	var pluginCtx PluginContext
+	require.NoError(t, json.Unmarshal(lines[0], &pluginCtx))
+	assert.Equal(t, PluginProtocolVersion, pluginCtx.ProtocolVersion)
+	assert.Equal(t, "echo", pluginCtx.Plugin)
+	assert.Equal(t, []string{"--flag", "value"}, pluginCtx.Args)
+	assert.NotEmpty(t, pluginCtx.Workspace)
+	assert.NotEmpty(t, pluginCtx.DBPath)
+	assert.Contains(t, string(lines[1]), "env=echo")
+
+	root.SetArgs([]string{"echo", "--fail"})
+	err := root.Execute()
+	var exitErr *PluginExitError
+	require.True(t, errors.As(err, &exitErr), "expected PluginExitError, got %v", err)
+	assert.Equal(t, 3, exitErr.ExitCode())
+}
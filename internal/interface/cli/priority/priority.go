@@ -0,0 +1,142 @@
+package priority
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// NewCommand creates the priority command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "priority",
+		Short: "Manage task priorities across EPICs, PBIs and SBIs",
+	}
+
+	cmd.AddCommand(newPropagateCommand())
+	return cmd
+}
+
+// propagateFlags holds the flags for priority propagate command
+type propagateFlags struct {
+	from       []int
+	dryRun     bool
+	jsonOutput bool
+}
+
+func newPropagateCommand() *cobra.Command {
+	flags := &propagateFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "propagate <epic-id|pbi-id>",
+		Short: "Pass the priority of an EPIC or PBI down to its PBIs and SBIs",
+		Long: `Pass the current priority of an EPIC down to its PBIs and their SBIs, or of a
+PBI down to its SBIs.
+
+A child follows its parent unless it overrode the priority it inherited: only
+children at the default (normal) priority, or at the parent's previous priority
+given with --from, are changed. Children that follow pass the change on to their
+own SBIs. Done and failed tasks are left alone. EPIC priorities above 2 are
+capped at 2 (urgent), the highest PBI and SBI priority.
+
+'deespec epic update' and 'deespec pbi update' propagate a priority change right
+away with --propagate, using the previous priority automatically.`,
+		Example: `  # Show which tasks would change
+  deespec priority propagate 01JB6X8Y2K9FQR4T3VWHGP5M2C --dry-run
+
+  # The EPIC was high (1) before; children still at high follow as well
+  deespec priority propagate 01JB6X8Y2K9FQR4T3VWHGP5M2C --from 1`,
+		ValidArgsFunction: common.CompleteEPICIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !flags.dryRun {
+				if err := common.CheckWritable("propagate priorities"); err != nil {
+					return err
+				}
+			}
+			return withPriorityUseCase(func(uc *usecase.PriorityPropagationUseCase) error {
+				result, err := uc.Propagate(cmd.Context(), args[0], flags.from, flags.dryRun)
+				if err != nil {
+					return err
+				}
+				if flags.jsonOutput {
+					encoder := json.NewEncoder(os.Stdout)
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(result)
+				}
+				PrintResult(result)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().IntSliceVar(&flags.from, "from", nil, "Previous priority of the EPIC or PBI; children still at it follow (can be specified multiple times)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Show the affected tasks without changing them")
+	cmd.Flags().BoolVar(&flags.jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// Propagate passes the new priority of an EPIC or PBI down to its children and prints the result
+// previous is the priority the EPIC or PBI had before the change.
+func Propagate(ctx context.Context, id string, previous int) error {
+	return withPriorityUseCase(func(uc *usecase.PriorityPropagationUseCase) error {
+		result, err := uc.Propagate(ctx, id, []int{previous}, false)
+		if err != nil {
+			return fmt.Errorf("failed to propagate priority: %w", err)
+		}
+		fmt.Println()
+		PrintResult(result)
+		return nil
+	})
+}
+
+// withPriorityUseCase runs fn with a priority propagation use case on the local database
+func withPriorityUseCase(fn func(uc *usecase.PriorityPropagationUseCase) error) error {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	pbiRepo := persistence.NewPBISQLiteRepository(container.GetDB(), rootPath)
+
+	return fn(usecase.NewPriorityPropagationUseCase(container.GetEPICRepository(), pbiRepo, container.GetSBIRepository()))
+}
+
+// PrintResult prints the tasks a propagation changed (or would change) and those it kept
+func PrintResult(result *usecase.PriorityPropagationResult) {
+	verb := "Changed"
+	if result.DryRun {
+		verb = "Would change"
+	}
+	fmt.Printf("Priority of %s %s: %d\n\n", result.Type, result.ID, result.Priority)
+
+	if len(result.Changes) == 0 {
+		fmt.Println("No task needs a change")
+	} else {
+		fmt.Printf("%s %d tasks:\n", verb, len(result.Changes))
+		for _, c := range result.Changes {
+			fmt.Printf("  %-3s %-26s %d → %d  %s\n", c.Type, c.ID, c.From, c.To, c.Title)
+		}
+	}
+	if len(result.Overridden) > 0 {
+		fmt.Printf("\nKept %d tasks with their own priority:\n", len(result.Overridden))
+		for _, c := range result.Overridden {
+			fmt.Printf("  %-3s %-26s %d      %s\n", c.Type, c.ID, c.From, c.Title)
+		}
+	}
+	if result.Completed > 0 {
+		fmt.Printf("\nSkipped %d done or failed tasks\n", result.Completed)
+	}
+}
@@ -1,33 +1,91 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
 	"github.com/YoshitsuguKoike/deespec/internal/app/config"
 	infraConfig "github.com/YoshitsuguKoike/deespec/internal/infra/config"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/analyze"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/archive"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/backup"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/budget"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/changelog"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/clear"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/config_cmd"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/db_cmd"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/digest"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/doctor"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/epic"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/health"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/import_cmd"
 	initcmd "github.com/YoshitsuguKoike/deespec/internal/interface/cli/init"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/journal"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/label"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/lock_cmd"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/migrate"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/priority"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/run"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/sbi"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/serve"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/stats"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/status"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/token"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/trash"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/upgrade"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/version"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/watch_specs"
 	"github.com/spf13/cobra"
 )
 
 // globalLogLevel is the CLI flag override for log level
 var globalLogLevel string
 
+// globalReadOnly is the CLI flag enabling read-only mode
+var globalReadOnly bool
+
+// settingsTolerantCommands lists the commands (path without the root name) that still run,
+// with default settings, when setting.json is invalid: those used to inspect or repair it
+var settingsTolerantCommands = map[string]bool{
+	"":                 true,
+	"help":             true,
+	"completion":       true,
+	"__complete":       true,
+	"__completeNoDesc": true,
+	"version":          true,
+	"init":             true,
+	"doctor":           true,
+	"config":           true,
+	"config validate":  true,
+}
+
+// commandPath returns the path of cmd without the root command name (e.g. "config validate")
+func commandPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != nil {
+		path = strings.TrimSpace(strings.TrimPrefix(path, root.Name()))
+	}
+	return path
+}
+
 func NewRoot() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "deespec",
 		Short: "DeeSpec CLI - Parallel workflow orchestration for specification processing",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Run from the project root so that .deespec/... paths resolve from any
+			// subdirectory; init creates a project where it is run instead
+			if commandPath(cmd) != "init" {
+				if err := common.EnterProjectRoot(); err != nil {
+					return err
+				}
+			}
+
 			// Load configuration before any command runs
 			// Priority: CLI flag > setting.json > defaults
 			// Always use .deespec as the base directory
@@ -35,7 +93,17 @@ func NewRoot() *cobra.Command {
 
 			cfg, err := infraConfig.LoadSettings(baseDir)
 			if err != nil {
-				// Continue with defaults if loading fails
+				// An invalid setting.json must not silently fall back to defaults (a typo could
+				// e.g. disable parallelism); only commands that help fix it still run
+				path := commandPath(cmd)
+				if !settingsTolerantCommands[path] && !strings.HasPrefix(path, "completion ") {
+					cmd.SilenceUsage = true
+					return fmt.Errorf("%w\nRun 'deespec config validate' after fixing it", err)
+				}
+				// config validate reports the problems itself
+				if !strings.HasPrefix(path, "config") {
+					fmt.Fprintf(os.Stderr, "Warning: %v\nContinuing with default settings\n", err)
+				}
 				defaultLabelConfig := config.LabelConfig{
 					TemplateDirs: []string{".claude", ".deespec/prompts/labels"},
 					Import: config.LabelImportConfig{
@@ -55,6 +123,11 @@ func NewRoot() *cobra.Command {
 						"codex":       1,
 					},
 				}
+				defaultNotificationConfig := config.NotificationConfig{
+					DeadlineCheckIntervalSec: 60,
+					EscalationHours:          24,
+					Email:                    config.EmailNotificationConfig{SMTPPort: 587, TLS: "starttls", PasswordEnv: "DEESPEC_SMTP_PASSWORD", DigestIntervalHours: 24},
+				}
 				cfg = config.NewAppConfig(
 					".deespec", "claude", 60, "vim", // Add default editor
 					"", "", "", "",
@@ -64,13 +137,63 @@ func NewRoot() *cobra.Command {
 					false, false,
 					false, false,
 					"", "", "warn", // Default log level
+					"ja",
+					nil, nil,
 					defaultLabelConfig,
 					defaultAgentPoolConfig,
+					defaultNotificationConfig,
+					config.ArtifactStoreConfig{Type: "local"},
+					config.DecompositionConfig{MinEstimatedHours: 0.5, MaxEstimatedHours: 8, MaxAcceptanceCriteria: 8, MaxTouchedAreas: 5},
+					config.DecisionExtractionConfig{},
+					config.SchedulingConfig{AgingIntervalHours: 24, AgingMaxPriority: 2},
+					config.AgentSessionConfig{},
+					config.TaskEnvConfig{},
+					config.ReviewQuorumConfig{Rule: "majority"},
+					config.SelfReviewGuardConfig{},
+					config.JournalConfig{},
+					config.SpecPreflightConfig{Mode: "warn"},
+					config.ReviewTimeoutConfig{Policy: "retry"},
+					config.OwnershipContextConfig{MaxFiles: 5, MaxCommits: 50},
+					config.ReviewContextConfig{MaxDiffKB: 200, TestTimeoutSec: 600},
+					config.AgentAPIConfig{},
+					config.OllamaConfig{Model: "llama3.1", MaxOutputTokens: 4096, Overflow: "truncate"},
+					config.AgentCacheConfig{Steps: []string{"review"}, TTLHours: 168},
+					config.FailurePolicyConfig{OnLimit: "done", Postmortem: true},
+					config.ArtifactRetryConfig{Attempts: 1},
+					config.TrashConfig{RetentionDays: 30},
+					config.PromptArchiveConfig{Enabled: true, Compress: true, RetentionDays: 30},
+					config.BackupConfig{Enabled: true, IntervalHours: 24, Keep: 7},
+					config.TurnTimeBoxConfig{CheckpointMinutes: 5},
+					config.ModelPolicyConfig{},
+					config.AgentRoutingConfig{},
+					config.PromptExperimentConfig{},
+					config.MCPConfig{},
+					config.ChangeBudgetConfig{OnExceed: "split", SplitAttempts: 1},
+					config.TemplateVarsConfig{CommandTimeoutSec: 10},
+					config.DuplicateCheckConfig{Mode: "warn", Threshold: 0.8},
+					config.ContextWindowConfig{Mode: "summarize"},
+					config.IDSchemeConfig{Strategy: "ulid", Digits: 4},
+					config.DatabaseEncryptionConfig{KeySource: "env:DEESPEC_DB_KEY"},
+					false,
 					"default", "",
 				)
 			}
 			common.SetGlobalConfig(cfg)
 
+			// Open the task database with its SQLCipher key when encryption at rest is enabled
+			if enc := cfg.DatabaseEncryptionConfig(); enc.Enabled {
+				if err := common.EnableDatabaseEncryption(enc.KeySource); err != nil {
+					path := commandPath(cmd)
+					if !settingsTolerantCommands[path] && !strings.HasPrefix(path, "completion ") {
+						cmd.SilenceUsage = true
+						return err
+					}
+					if !strings.HasPrefix(path, "__complete") {
+						fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+					}
+				}
+			}
+
 			// Determine log level: CLI flag takes precedence
 			logLevel := cfg.StderrLevel()
 			if globalLogLevel != "" {
@@ -83,27 +206,71 @@ func NewRoot() *cobra.Command {
 			// Initialize loggers for all layers
 			common.InitializeLoggers(common.GetLogger())
 
-			return nil
+			// Read-only mode: CLI flag or setting.json
+			common.SetReadOnly(globalReadOnly || cfg.ReadOnly())
+			return common.EnforceReadOnly(cmd)
 		},
 		RunE: func(c *cobra.Command, _ []string) error { return c.Help() },
 	}
 	cmd.AddCommand(initcmd.NewCommand())
 	cmd.AddCommand(status.NewCommand())
+	cmd.AddCommand(budget.NewCommand())
+	cmd.AddCommand(stats.NewCommand())     // Journal statistics
+	cmd.AddCommand(changelog.NewCommand()) // Release notes from DONE SBIs
+	cmd.AddCommand(digest.NewCommand())    // Daily standup summary
 	cmd.AddCommand(run.NewCommand())
 	cmd.AddCommand(doctor.NewCommand())
 	cmd.AddCommand(journal.NewCommand())
 	cmd.AddCommand(health.NewCommand())
-	cmd.AddCommand(pbi.NewPBICommand()) // PBI management
+	cmd.AddCommand(epic.NewEPICCommand()) // EPIC management
+	cmd.AddCommand(pbi.NewPBICommand())   // PBI management
 	cmd.AddCommand(sbi.NewSBICommand())
+	cmd.AddCommand(watch_specs.NewCommand()) // Register tasks from dropped-in spec files
+	cmd.AddCommand(import_cmd.NewCommand())  // Seed the backlog from a CSV export
+	cmd.AddCommand(analyze.NewCommand())     // Propose a backlog from the existing codebase
 	cmd.AddCommand(clear.NewCommand())
+	cmd.AddCommand(migrate.NewCommand())
+	cmd.AddCommand(db_cmd.NewCommand()) // Check and repair the task database
+	cmd.AddCommand(backup.NewCommand()) // Snapshots of the task database
+	cmd.AddCommand(archive.NewCommand())
+	cmd.AddCommand(trash.NewCommand())    // Restore or purge deleted PBIs and SBIs
 	cmd.AddCommand(lock_cmd.NewCommand()) // SQLite-based lock management
 	cmd.AddCommand(label.NewCommand())
+	cmd.AddCommand(priority.NewCommand()) // Propagate priorities from EPICs and PBIs
 	cmd.AddCommand(version.NewCommand())
 	cmd.AddCommand(upgrade.NewCommand())
+	cmd.AddCommand(serve.NewCommand()) // gRPC API
+	cmd.AddCommand(token.NewCommand()) // API tokens for serve
+	cmd.AddCommand(config_cmd.NewCommand())
 
 	// Add global log level flag
 	cmd.PersistentFlags().StringVar(&globalLogLevel, "log-level", "",
 		"Set log level (debug, info, warn, error). Overrides setting.json")
 
+	// Add global read-only flag (for CI validation)
+	cmd.PersistentFlags().BoolVar(&globalReadOnly, "read-only", false,
+		"Refuse to run commands that modify the database or filesystem")
+
+	// Exec-based extensions (deespec-<name> on PATH) are registered before
+	// aliases so that aliases may expand to plugin commands
+	registerPlugins(cmd, os.Getenv("PATH"))
+
+	// User-defined aliases must exist before argument parsing, so they are read
+	// from setting.json here rather than in PersistentPreRunE
+	if cfg, err := infraConfig.LoadSettings(projectDeespecDir()); err == nil {
+		registerAliases(cmd, cfg.Aliases())
+	}
+
 	return cmd
 }
+
+// projectDeespecDir returns the .deespec directory of the project containing the working
+// directory, for the settings read before PersistentPreRunE enters the project root
+func projectDeespecDir() string {
+	if cwd, err := os.Getwd(); err == nil {
+		if root, found := app.FindProjectRoot(cwd); found {
+			return filepath.Join(root, ".deespec")
+		}
+	}
+	return ".deespec"
+}
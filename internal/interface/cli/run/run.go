@@ -19,12 +19,22 @@ import (
 
 	"github.com/spf13/cobra"
 
+	agentgateway "github.com/YoshitsuguKoike/deespec/internal/adapter/gateway/agent"
 	"github.com/YoshitsuguKoike/deespec/internal/app"
 	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
 	"github.com/YoshitsuguKoike/deespec/internal/application/usecase/execution"
 	"github.com/YoshitsuguKoike/deespec/internal/application/workflow"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/label"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/chaos"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/process"
 	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/workflow_sbi"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/external/claudecli"
@@ -184,14 +194,6 @@ func cleanupStaleLocks() {
 	}
 }
 
-// isProcessRunning checks if a process with the given PID is running
-func isProcessRunning(pid int) bool {
-	// Use ps command to check if process exists
-	cmd := exec.Command("ps", "-p", strconv.Itoa(pid))
-	err := cmd.Run()
-	return err == nil
-}
-
 // promptUserConfirmation asks the user for yes/no confirmation
 func promptUserConfirmation(message string) bool {
 	fmt.Printf("\n%s (y/N): ", message)
@@ -218,7 +220,7 @@ func killProcessAndCleanup(pid int, container *di.Container) error {
 	time.Sleep(500 * time.Millisecond)
 
 	// Check if process is still running
-	if isProcessRunning(pid) {
+	if process.IsRunning(pid) {
 		// Process didn't terminate, try force kill (SIGKILL)
 		common.Warn("Process %d did not terminate gracefully, forcing termination...\n", pid)
 		cmd = exec.Command("kill", "-9", strconv.Itoa(pid))
@@ -228,7 +230,7 @@ func killProcessAndCleanup(pid int, container *di.Container) error {
 
 		// Wait a bit and verify again
 		time.Sleep(500 * time.Millisecond)
-		if isProcessRunning(pid) {
+		if process.IsRunning(pid) {
 			return fmt.Errorf("process %d is still running after force kill signal", pid)
 		}
 	}
@@ -270,8 +272,8 @@ func handleLockConflict(ctx context.Context, container *di.Container) (bool, err
 	hostname := existingLock.Hostname()
 	expiresAt := existingLock.ExpiresAt().Format("15:04:05")
 
-	// Check if process is actually running
-	if !isProcessRunning(pid) {
+	// Check if process is actually running (only possible on the host that holds the lock)
+	if !process.IsLockHolderRunning(hostname, pid) {
 		common.Warn("Lock held by PID %d, but process is not running (stale lock)\n", pid)
 		common.Info("Cleaning up stale lock...\n")
 
@@ -299,6 +301,12 @@ func handleLockConflict(ctx context.Context, container *di.Container) (bool, err
 	common.Warn("    Lock expires: %s\n", expiresAt)
 	common.Warn("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 
+	// A process on another host cannot be stopped from here
+	if localHost, _ := os.Hostname(); hostname != localHost {
+		common.Info("The lock is held from another host; wait for it to expire or run `deespec lock release %s`\n", lockID.String())
+		return false, nil
+	}
+
 	if !promptUserConfirmation("Do you want to stop the other process and continue?") {
 		common.Info("Aborted by user\n")
 		return false, nil
@@ -319,6 +327,13 @@ func NewCommand() *cobra.Command {
 	var intervalStr string
 	var enabledWorkflows []string
 	var maxParallel int // Maximum number of concurrent SBI executions
+	var simulateFixture string
+	var chaosRate float64
+	var chaosSeed int64
+	var chaosFaults []string
+	var noCache bool
+	var quiet bool
+	var verbose bool
 
 	cmd := &cobra.Command{
 		Use:   "run",
@@ -332,6 +347,74 @@ Parallel Execution:
   Use --parallel flag to enable concurrent SBI processing (1-10 tasks).
   Default is 1 (sequential execution). Higher values increase throughput
   but require more system resources.
+  Set "scheduling.implement_pool_size" and "review_pool_size" in setting.json
+  to run implement and review turns in separate pools, so that reviews are
+  not starved behind long implementations (an unset size uses --parallel).
+
+Execution Windows:
+  Set "scheduling.windows" in setting.json (e.g. ["22:00-06:00"], local time)
+  to start turns only inside those windows, e.g. to use cheaper off-peak rate
+  limits. Outside them tasks stay queued and the next start is reported.
+
+Turn Limit:
+  SBIs that exceed max_turns are force-completed as DONE by default. Set
+  "failure_policy.on_limit" to "failed" to terminate them as FAILED instead;
+  a postmortem report (.deespec/reports/sbi/<id>/postmortem.md) then analyses
+  why they failed unless "failure_policy.postmortem" is false.
+
+Live Status:
+  While an agent works, a status line shows the running SBI, its step, the
+  elapsed time and the size of the agent output so far. It is redrawn in place
+  on a terminal and printed every 30s otherwise (e.g. in CI logs). Use --quiet
+  to hide it and --verbose to also stream the agent output.
+
+Turn Time Box:
+  Set "turn_time_box.minutes" in setting.json to bound implement turns. An
+  agent still working when the time is up is stopped and asked to write a
+  progress checkpoint (.deespec/reports/sbi/<id>/checkpoint_<turn>.md); the
+  turn ends as IMPLEMENTING instead of failing and the next turn resumes from
+  the checkpoint.
+
+Change Budget:
+  Set "change_budget.max_lines" and/or "change_budget.max_files" in
+  setting.json to cap how much code one implement turn may change (git
+  projects only; .deespec is not counted). An agent that exceeds the budget is
+  asked to keep a reviewable part and defer the rest to the next turn; if the
+  change stays too large, or "change_budget.on_exceed" is "flag", the turn is
+  flagged for human review with a notification and a change_budget journal
+  record.
+
+Prompt Archive:
+  Every prompt sent to an agent and its answer are kept under
+  .deespec/var/prompts/<sbi id>, gzip-compressed, for 30 days; list and read
+  them with 'deespec sbi prompts <id>'. Set "prompt_archive.retention_days"
+  (0 keeps them forever), "prompt_archive.compress" or
+  "prompt_archive.enabled" in setting.json to change this.
+
+Database Backups:
+  While running, a snapshot of the task database is saved to
+  .deespec/backups once a day and the newest 7 are kept; take one by hand
+  with 'deespec backup now' and go back to one with 'deespec backup restore'.
+  Set "backup.interval_hours", "backup.keep" or "backup.enabled" in
+  setting.json to change this, and "backup.upload" to also upload every
+  snapshot to the s3 or gcs artifact_store.
+
+Model Policy:
+  Rules in "model_policy.rules" of setting.json require a step to run on a
+  model family at or above a version, e.g. {"step": "review", "family":
+  "claude-opus", "min_version": "4.1"}. They are checked before the agent is
+  started: a step routed to another model, or to no pinned model, fails with
+  a policy_violation error. The agent, model and agent version of every report
+  are shown by 'deespec sbi show'.
+
+Prompt Variables:
+  Prompt templates can reference custom variables as {{.Custom.Name}}, declared
+  in "template_vars.vars" of setting.json as a static value or a shell command,
+  e.g. {"ServiceOwner": {"value": "team-payments"}, "OnCall": {"command":
+  "./scripts/oncall.sh"}}. Commands run in the project root for every prompt
+  with DEESPEC_SBI_ID, DEESPEC_STEP and DEESPEC_TURN set. Values are inserted
+  as plain text: template syntax in them is not evaluated, control characters
+  are removed and values are cut at 4KB.
 
 Configuration:
   Workflows can be configured via .deespec/workflow.yaml file.
@@ -347,7 +430,10 @@ Examples:
   deespec run --workflows sbi           # Run only SBI workflow
   deespec run --interval 10s            # Run with 10-second intervals
   deespec run --auto-fb                 # Enable automatic FB-SBI registration
-  deespec run --parallel 5 --interval 30s  # 5 concurrent tasks, 30s intervals`,
+  deespec run --parallel 5 --interval 30s  # 5 concurrent tasks, 30s intervals
+  deespec run --simulate fixture.yaml   # Replay scripted agent responses (no AI calls)
+  deespec run --no-cache                # Ignore cached reviews (agent_cache)
+  deespec run --verbose                 # Stream agent output as it is produced`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if deespec is initialized
 			deespecDir := ".deespec"
@@ -395,6 +481,8 @@ Examples:
 				common.Info("[Sequential Mode] Running one SBI at a time\n")
 			}
 
+			common.SetAgentCacheBypass(noCache)
+
 			// Cleanup stale locks before starting
 			cleanupStaleLocks()
 
@@ -416,6 +504,37 @@ Examples:
 				return fmt.Errorf("failed to start container services: %w", err)
 			}
 
+			// Replace the agent with a scripted simulation agent if requested
+			if simulateFixture != "" {
+				if err := enableSimulation(container, simulateFixture); err != nil {
+					return err
+				}
+				common.Info("[Simulation] Replaying agent responses from %s\n", simulateFixture)
+			}
+
+			// Inject infrastructure faults to test recovery if requested (test-only)
+			if chaosRate > 0 {
+				injector, err := enableChaos(container, chaosRate, chaosSeed, chaosFaults)
+				if err != nil {
+					return err
+				}
+				defer func() { common.Warn("[Chaos] Injected faults: %s\n", injector.Summary()) }()
+			}
+
+			// Start deadline monitor to escalate overdue tasks via the notification gateway
+			monitorCtx, cancelMonitor := context.WithCancel(ctx)
+			defer cancelMonitor()
+			startDeadlineMonitor(monitorCtx, container)
+			startNotificationDigest(monitorCtx, container)
+			startDatabaseBackup(monitorCtx, container)
+
+			// Kill agent process groups left behind by crashed or hung runs
+			startAgentReaper(monitorCtx)
+
+			// Show the running step instead of silence while agents work
+			stopStatus := startTurnStatus(container.GetTurnEventBroker(), quiet, verbose)
+			defer stopStatus()
+
 			// Create workflow manager with logging functions
 			manager := workflow.NewWorkflowManager(common.Info, common.Warn, common.Debug)
 
@@ -437,7 +556,21 @@ Examples:
 					return ExecuteSingleSBI(ctx, container, sbiID, autoFB)
 				}
 
-				sbiRunner = workflow_sbi.NewParallelSBIWorkflowRunner(container, maxParallel, executeTurnFunc)
+				parallelRunner := workflow_sbi.NewParallelSBIWorkflowRunner(container, maxParallel, executeTurnFunc)
+				if cfg := common.GetGlobalConfig(); cfg != nil {
+					scheduling := cfg.SchedulingConfig()
+					parallelRunner.SetPBIWIPLimit(scheduling.PBIWIPLimit)
+					if scheduling.ImplementPoolSize > 0 || scheduling.ReviewPoolSize > 0 {
+						parallelRunner.SetStepPool(service.NewStepPool(scheduling.ImplementPoolSize, scheduling.ReviewPoolSize, maxParallel))
+						// Turns outlive the cycle that started them; let them finish before the container closes
+						defer parallelRunner.Wait()
+					}
+				}
+				parallelRunner.SetTurnBudget(common.NewTurnBudget(container))
+				parallelRunner.SetExecutionSchedule(common.NewExecutionSchedule())
+				parallelRunner.SetSpecPreflight(common.NewSpecPreflight())
+				parallelRunner.SetPriorityAging(common.NewPriorityAging())
+				sbiRunner = parallelRunner
 			} else {
 				// Use sequential SBIWorkflowRunner
 				runTurnFunc := func(autoFB bool) error {
@@ -502,7 +635,7 @@ Examples:
 					return fmt.Errorf("failed to handle lock conflict: %w", err)
 				}
 				if !shouldContinue {
-					return fmt.Errorf("all workflows stopped - another instance may be running")
+					return fmt.Errorf("%w: all workflows stopped - another instance may be running", model.ErrLockHeld)
 				}
 
 				// User confirmed cleanup - retry starting workflows
@@ -543,6 +676,17 @@ Examples:
 	cmd.Flags().StringVar(&intervalStr, "interval", "", "Execution interval for all workflows (default: 5s, min: 1s, max: 10m)")
 	cmd.Flags().StringSliceVar(&enabledWorkflows, "workflows", nil, "Comma-separated list of workflows to enable (default: all available)")
 	cmd.Flags().IntVar(&maxParallel, "parallel", 1, "Maximum concurrent SBI executions (1-10, default: 1)")
+	cmd.Flags().StringVar(&simulateFixture, "simulate", "", "Run with a scripted simulation agent loaded from a YAML fixture")
+	cmd.Flags().Float64Var(&chaosRate, "chaos", 0, "Test only: fail this share (0-1) of agent calls, journal writes and SBI saves")
+	cmd.Flags().Int64Var(&chaosSeed, "chaos-seed", 0, "Test only: seed of the --chaos fault sequence (default: random)")
+	cmd.Flags().StringSliceVar(&chaosFaults, "chaos-faults", nil, "Test only: faults to inject with --chaos (agent_timeout, journal_write, lock_expiry, db_busy; default: all)")
+	_ = cmd.Flags().MarkHidden("chaos")
+	_ = cmd.Flags().MarkHidden("chaos-seed")
+	_ = cmd.Flags().MarkHidden("chaos-faults")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Always call agents, refreshing cached reviews")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Hide the live status line of running turns")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Stream agent output as it is produced")
+	cmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
 
 	return cmd
 }
@@ -742,6 +886,12 @@ func runAgent(agent claudecli.Runner, prompt string, sbiDir string, stepName str
 // This function is designed for parallel execution where RunLock is managed externally
 // StateLock for the specific SBI should be acquired by the caller before calling this
 func ExecuteSingleSBI(ctx context.Context, container *di.Container, sbiID string, autoFB bool) error {
+	_, err := ExecuteSingleSBITurn(ctx, container, sbiID, autoFB)
+	return err
+}
+
+// ExecuteSingleSBITurn is ExecuteSingleSBI returning the turn result
+func ExecuteSingleSBITurn(ctx context.Context, container *di.Container, sbiID string, autoFB bool) (*dto.RunTurnOutput, error) {
 	startTime := time.Now()
 
 	// Get paths and services
@@ -750,7 +900,10 @@ func ExecuteSingleSBI(ctx context.Context, container *di.Container, sbiID string
 	sbiRepo := container.GetSBIRepository()
 
 	// Create repository implementations
-	journalRepo := infraRepo.NewJournalRepositoryImpl(paths.Journal)
+	// Journal entries go through the database, so that they commit with the SBI saves they record
+	journal, closeJournalSinks := common.WithJournalSinks(common.NewJournalRepository(paths.Journal), container.GetDB())
+	defer closeJournalSinks()
+	unitOfWork, journalRepo := container.GetUnitOfWork(journal)
 
 	// Get AgentGateway from container
 	agentGateway := container.GetAgentGateway()
@@ -771,6 +924,59 @@ func ExecuteSingleSBI(ctx context.Context, container *di.Container, sbiID string
 		maxTurns,
 		leaseTTL,
 	)
+	useCase.SetUnitOfWork(unitOfWork)
+	useCase.SetAgentRegistry(configureAgentRegistry(container))
+	useCase.SetStepAgentRepository(container.GetStepAgentRepository())
+	useCase.SetArtifactProvenanceRepository(container.GetArtifactProvenanceRepository())
+	useCase.SetModelPolicy(common.NewModelPolicy())
+	useCase.SetReviewFeedbackRepository(container.GetReviewFeedbackRepository())
+	useCase.SetAcceptanceCriteriaRepository(container.GetAcceptanceCriteriaRepository())
+	useCase.SetReviewIssueRepository(container.GetReviewIssueRepository())
+	useCase.SetNoteRepository(container.GetSBINoteRepository())
+	if cfg := common.GetGlobalConfig(); cfg != nil && cfg.AgentSessionConfig().Enabled {
+		useCase.SetAgentSessionRepository(container.GetAgentSessionRepository())
+	}
+	if workDir, err := os.Getwd(); err == nil {
+		useCase.SetContextSelector(service.NewContextSelector(workDir))
+		useCase.SetOwnershipProvider(common.NewOwnershipProvider(workDir))
+		useCase.SetReviewContextBuilder(common.NewReviewContextBuilder(workDir))
+		useCase.SetTemplateVarResolver(common.NewTemplateVarResolver(workDir))
+		useCase.SetContextWindowCheck(common.NewContextWindowCheck(workDir))
+	}
+	useCase.SetTaskEnvResolver(newTaskEnvResolver(container))
+	useCase.SetReferenceContextBuilder(service.NewReferenceContextBuilder(container.GetSBIReferenceRepository(), sbiRepo, common.ProjectRoot()))
+	useCase.SetTurnBudget(common.NewTurnBudget(container))
+	useCase.SetSpecPreflight(common.NewSpecPreflight())
+	useCase.SetPriorityAging(common.NewPriorityAging())
+	useCase.SetPBIAcceptance(common.NewPBIAcceptanceService(container))
+	useCase.SetReviewTimeout(common.NewReviewTimeout())
+	useCase.SetFailurePolicy(common.NewFailurePolicy())
+	useCase.SetRetryBudget(common.NewRetryBudget(container.GetNotificationGateway()))
+	useCase.SetPromptTemplateRepository(infraRepo.NewPromptTemplateRepositoryImpl())
+	useCase.SetPromptExperiment(common.NewPromptExperiment())
+	useCase.SetMCPResolver(common.NewMCPResolver(container.GetLabelRepository()))
+	useCase.SetChangeBudget(common.NewChangeBudgetChecker(common.ProjectRoot(), container.GetNotificationGateway()))
+	enableAgentResponseCache(container, useCase)
+	useCase.SetPromptArchive(common.NewPromptArchive())
+	useCase.SetTurnClaimRepository(container.GetTurnClaimRepository())
+	useCase.SetProjectRoot(common.ProjectRoot())
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		useCase.SetArtifactRetries(cfg.ArtifactRetryConfig().Attempts)
+		timeBox := cfg.TurnTimeBoxConfig()
+		useCase.SetTurnTimeBox(time.Duration(timeBox.Minutes)*time.Minute, time.Duration(timeBox.CheckpointMinutes)*time.Minute)
+	}
+	enableReviewQuorum(container, useCase)
+	useCase.SetEventPublisher(container.GetTurnEventBroker())
+	if artifactStore, err := common.NewArtifactStore(); err == nil {
+		useCase.SetArtifactStore(artifactStore)
+	} else {
+		common.Warn("[Artifact] %v (artifacts stay local)\n", err)
+	}
+	if decisionExtractor, err := common.NewDecisionExtractorChain(); err == nil {
+		useCase.SetDecisionExtractor(decisionExtractor)
+	} else {
+		common.Warn("[Decision] %v (using built-in strategies)\n", err)
+	}
 
 	// Execute turn for the specific SBI
 	// Note: ExecuteForSBI skips SBI picking and uses the provided SBI ID
@@ -781,11 +987,13 @@ func ExecuteSingleSBI(ctx context.Context, container *di.Container, sbiID string
 	output, err := useCase.ExecuteForSBI(ctx, sbiID, input)
 	if err != nil {
 		common.Error("failed to execute turn for SBI %s: %v", sbiID, err)
-		return fmt.Errorf("execute turn for SBI %s: %w", sbiID, err)
+		return nil, fmt.Errorf("execute turn for SBI %s: %w", sbiID, err)
 	}
 
 	// Log execution results (simplified for parallel execution)
-	if output.NoOp {
+	if output.NoOp && output.NoOpReason == execution.NoOpReasonTurnConflict {
+		common.Warn("SBI %s: Turn %d skipped: %s\n", sbiID, output.Turn, output.ErrorMsg)
+	} else if output.NoOp {
 		common.Debug("SBI %s: No-op (%s)", sbiID, output.NoOpReason)
 	} else {
 		common.Info("SBI %s: Turn %d completed (%s -> %s)",
@@ -799,7 +1007,7 @@ func ExecuteSingleSBI(ctx context.Context, container *di.Container, sbiID string
 	}
 
 	common.Debug("SBI %s execution took %v", sbiID[:8], time.Since(startTime))
-	return nil
+	return output, nil
 }
 
 // RunTurnWithContainer executes a single workflow turn using a shared DI container
@@ -831,10 +1039,10 @@ func RunTurnWithContainer(container *di.Container, autoFB bool) error {
 	if runLock == nil {
 		// Another instance is running - return error immediately
 		if existingLock, err := lockService.FindRunLock(ctx, lockID); err == nil && existingLock != nil {
-			return fmt.Errorf("another instance is already running (PID %d on %s, expires: %s)",
-				existingLock.PID(), existingLock.Hostname(), existingLock.ExpiresAt().Format("15:04:05"))
+			return fmt.Errorf("%w: another instance is already running (PID %d on %s, expires: %s)",
+				model.ErrLockHeld, existingLock.PID(), existingLock.Hostname(), existingLock.ExpiresAt().Format("15:04:05"))
 		}
-		return fmt.Errorf("another instance is already running")
+		return fmt.Errorf("%w: another instance is already running", model.ErrLockHeld)
 	}
 
 	defer func() {
@@ -844,7 +1052,10 @@ func RunTurnWithContainer(container *di.Container, autoFB bool) error {
 	}()
 
 	// Create repository implementations
-	journalRepo := infraRepo.NewJournalRepositoryImpl(paths.Journal)
+	// Journal entries go through the database, so that they commit with the SBI saves they record
+	journal, closeJournalSinks := common.WithJournalSinks(common.NewJournalRepository(paths.Journal), container.GetDB())
+	defer closeJournalSinks()
+	unitOfWork, journalRepo := container.GetUnitOfWork(journal)
 
 	// Get AgentGateway from container
 	agentGateway := container.GetAgentGateway()
@@ -864,6 +1075,60 @@ func RunTurnWithContainer(container *di.Container, autoFB bool) error {
 		maxTurns,
 		leaseTTL,
 	)
+	useCase.SetUnitOfWork(unitOfWork)
+	useCase.SetAgentRegistry(configureAgentRegistry(container))
+	useCase.SetStepAgentRepository(container.GetStepAgentRepository())
+	useCase.SetArtifactProvenanceRepository(container.GetArtifactProvenanceRepository())
+	useCase.SetModelPolicy(common.NewModelPolicy())
+	useCase.SetReviewFeedbackRepository(container.GetReviewFeedbackRepository())
+	useCase.SetAcceptanceCriteriaRepository(container.GetAcceptanceCriteriaRepository())
+	useCase.SetReviewIssueRepository(container.GetReviewIssueRepository())
+	useCase.SetNoteRepository(container.GetSBINoteRepository())
+	if cfg := common.GetGlobalConfig(); cfg != nil && cfg.AgentSessionConfig().Enabled {
+		useCase.SetAgentSessionRepository(container.GetAgentSessionRepository())
+	}
+	if workDir, err := os.Getwd(); err == nil {
+		useCase.SetContextSelector(service.NewContextSelector(workDir))
+		useCase.SetOwnershipProvider(common.NewOwnershipProvider(workDir))
+		useCase.SetReviewContextBuilder(common.NewReviewContextBuilder(workDir))
+		useCase.SetTemplateVarResolver(common.NewTemplateVarResolver(workDir))
+		useCase.SetContextWindowCheck(common.NewContextWindowCheck(workDir))
+	}
+	useCase.SetTaskEnvResolver(newTaskEnvResolver(container))
+	useCase.SetReferenceContextBuilder(service.NewReferenceContextBuilder(container.GetSBIReferenceRepository(), sbiRepo, common.ProjectRoot()))
+	useCase.SetTurnBudget(common.NewTurnBudget(container))
+	useCase.SetExecutionSchedule(common.NewExecutionSchedule())
+	useCase.SetSpecPreflight(common.NewSpecPreflight())
+	useCase.SetPriorityAging(common.NewPriorityAging())
+	useCase.SetPBIAcceptance(common.NewPBIAcceptanceService(container))
+	useCase.SetReviewTimeout(common.NewReviewTimeout())
+	useCase.SetFailurePolicy(common.NewFailurePolicy())
+	useCase.SetRetryBudget(common.NewRetryBudget(container.GetNotificationGateway()))
+	useCase.SetPromptTemplateRepository(infraRepo.NewPromptTemplateRepositoryImpl())
+	useCase.SetPromptExperiment(common.NewPromptExperiment())
+	useCase.SetMCPResolver(common.NewMCPResolver(container.GetLabelRepository()))
+	useCase.SetChangeBudget(common.NewChangeBudgetChecker(common.ProjectRoot(), container.GetNotificationGateway()))
+	enableAgentResponseCache(container, useCase)
+	useCase.SetPromptArchive(common.NewPromptArchive())
+	useCase.SetTurnClaimRepository(container.GetTurnClaimRepository())
+	useCase.SetProjectRoot(common.ProjectRoot())
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		useCase.SetArtifactRetries(cfg.ArtifactRetryConfig().Attempts)
+		timeBox := cfg.TurnTimeBoxConfig()
+		useCase.SetTurnTimeBox(time.Duration(timeBox.Minutes)*time.Minute, time.Duration(timeBox.CheckpointMinutes)*time.Minute)
+	}
+	enableReviewQuorum(container, useCase)
+	useCase.SetEventPublisher(container.GetTurnEventBroker())
+	if artifactStore, err := common.NewArtifactStore(); err == nil {
+		useCase.SetArtifactStore(artifactStore)
+	} else {
+		common.Warn("[Artifact] %v (artifacts stay local)\n", err)
+	}
+	if decisionExtractor, err := common.NewDecisionExtractorChain(); err == nil {
+		useCase.SetDecisionExtractor(decisionExtractor)
+	} else {
+		common.Warn("[Decision] %v (using built-in strategies)\n", err)
+	}
 
 	// Execute turn
 	input := dto.RunTurnInput{
@@ -885,12 +1150,22 @@ func RunTurnWithContainer(container *di.Container, autoFB bool) error {
 			lockService := container.GetLockService()
 			lockID, _ := lock.NewLockID("system-runlock")
 			if existingLock, err := lockService.FindRunLock(ctx, lockID); err == nil && existingLock != nil {
-				return fmt.Errorf("another instance is already running (PID %d on %s, expires: %s)",
-					existingLock.PID(), existingLock.Hostname(), existingLock.ExpiresAt().Format("15:04:05"))
+				return fmt.Errorf("%w: another instance is already running (PID %d on %s, expires: %s)",
+					model.ErrLockHeld, existingLock.PID(), existingLock.Hostname(), existingLock.ExpiresAt().Format("15:04:05"))
 			}
-			return fmt.Errorf("another instance is already running")
+			return fmt.Errorf("%w: another instance is already running", model.ErrLockHeld)
 		case "no_tasks":
 			common.Info("💤 No tasks available to process")
+		case service.NoOpReasonPBITurnBudgetExhausted:
+			common.Info("⏸️  Turn budget of the parent PBI is exhausted; SBI %s is held (scheduling.max_turns_per_pbi)", output.SBIID)
+		case service.NoOpReasonDailyAgentCallBudgetExhausted:
+			common.Info("⏸️  Daily agent call budget is exhausted; waiting until tomorrow (scheduling.max_agent_calls_per_day)")
+		case service.NoOpReasonOutsideExecutionWindow:
+			common.Info("⏸️  Outside the execution windows; tasks stay queued until %s (scheduling.windows)", output.ResumeAt.Format("2006-01-02 15:04"))
+		case service.NoOpReasonSpecQualityWarnings:
+			common.Info("⏸️  Spec of SBI %s has unresolved quality warnings; see 'deespec sbi show %s' (spec_preflight.mode: block)", output.SBIID, output.SBIID)
+		case execution.NoOpReasonTurnConflict:
+			common.Warn("Turn %d of SBI %s skipped: %s\n", output.Turn, output.SBIID, output.ErrorMsg)
 		default:
 			if output.Turn == 0 {
 				common.Info("⏳ Waiting...")
@@ -978,3 +1253,264 @@ func RunTurn(autoFB bool) error {
 
 	return RunTurnWithContainer(container, autoFB)
 }
+
+// startDeadlineMonitor runs the overdue task check in the background until ctx is cancelled
+func startDeadlineMonitor(ctx context.Context, container *di.Container) {
+	interval := 60 * time.Second
+	escalateAfter := service.DefaultEscalationAfter
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		notificationConfig := cfg.NotificationConfig()
+		if notificationConfig.DeadlineCheckIntervalSec > 0 {
+			interval = time.Duration(notificationConfig.DeadlineCheckIntervalSec) * time.Second
+		}
+		if notificationConfig.EscalationHours > 0 {
+			escalateAfter = time.Duration(notificationConfig.EscalationHours) * time.Hour
+		}
+	}
+
+	monitor := service.NewDeadlineMonitor(container.GetSBIRepository(), container.GetNotificationGateway(), escalateAfter)
+	if rootPath, err := os.Getwd(); err == nil {
+		monitor.SetPBIRepository(persistence.NewPBISQLiteRepository(container.GetDB(), rootPath))
+	}
+	go monitor.Run(ctx, interval, func(err error) {
+		common.Warn("[Deadline] %v\n", err)
+	})
+}
+
+// digestCheckInterval is how often the run daemon checks whether a notification digest is due
+const digestCheckInterval = 5 * time.Minute
+
+// startNotificationDigest sends the digest of finished and at-risk SBIs on its schedule until ctx
+// is cancelled; digests are sent only when email notifications are configured
+func startNotificationDigest(ctx context.Context, container *di.Container) {
+	cfg := common.GetGlobalConfig()
+	if cfg == nil {
+		return
+	}
+	email := cfg.NotificationConfig().Email
+	if email.SMTPHost == "" || email.DigestIntervalHours <= 0 {
+		return
+	}
+
+	paths := app.GetPathsWithConfig(cfg)
+	digest := service.NewNotificationDigest(container.GetSBIRepository(), common.NewRetryBudget(nil), container.GetNotificationGateway(),
+		time.Duration(email.DigestIntervalHours)*time.Hour, filepath.Join(paths.Var, "notification_digest"))
+	go digest.Run(ctx, digestCheckInterval, func(err error) {
+		common.Warn("[Digest] %v\n", err)
+	})
+}
+
+// backupCheckInterval is how often the run daemon checks whether a database snapshot is due
+const backupCheckInterval = 15 * time.Minute
+
+// startDatabaseBackup takes the scheduled snapshots of the task database until ctx is cancelled
+func startDatabaseBackup(ctx context.Context, container *di.Container) {
+	cfg := common.GetGlobalConfig()
+	if cfg == nil || !cfg.BackupConfig().Enabled {
+		return
+	}
+	scheduler, err := common.NewDatabaseBackupScheduler(container.GetDatabaseBackupRepository())
+	if err != nil {
+		common.Warn("[Backup] %v\n", err)
+		return
+	}
+	go scheduler.Run(ctx, backupCheckInterval, func(err error) {
+		common.Warn("[Backup] %v\n", err)
+	})
+}
+
+// agentReapInterval is how often the run daemon looks for abandoned agent processes
+const agentReapInterval = time.Minute
+
+// startAgentReaper reaps abandoned agent process groups now and then periodically until ctx is cancelled
+func startAgentReaper(ctx context.Context) {
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	registry := process.NewRegistry(agentgateway.AgentRegistryDir(rootPath))
+	reap := func() {
+		reaped, err := registry.Reap()
+		for _, r := range reaped {
+			common.Warn("[Reaper] Killed abandoned agent process group %d (%s, started %s): %s\n",
+				r.PGID, r.Command, r.StartedAt.Local().Format("2006-01-02 15:04:05"), r.Reason)
+		}
+		if err != nil {
+			common.Warn("[Reaper] %v\n", err)
+		}
+	}
+	reap()
+	go func() {
+		ticker := time.NewTicker(agentReapInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reap()
+			}
+		}
+	}()
+}
+
+// newTaskEnvResolver builds the resolver for env declared on SBIs and their parent PBIs
+func newTaskEnvResolver(container *di.Container) *service.TaskEnvResolver {
+	var vars map[string]string
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		vars = cfg.TaskEnvConfig().Vars
+	}
+
+	var pbiRepo pbi.Repository
+	if rootPath, err := os.Getwd(); err == nil {
+		pbiRepo = persistence.NewPBISQLiteRepository(container.GetDB(), rootPath)
+	}
+	return service.NewTaskEnvResolver(pbiRepo, vars)
+}
+
+// configureAgentRegistry applies the agent_routing and self_review_guard settings to the agent registry
+func configureAgentRegistry(container *di.Container) *service.AgentRegistry {
+	registry := container.GetAgentRegistry()
+	registry.SetRouter(common.NewAgentRouter())
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		guardConfig := cfg.SelfReviewGuardConfig()
+		var alternates []label.AgentRoute
+		for _, a := range guardConfig.Alternates {
+			alternates = append(alternates, label.AgentRoute{Agent: a.Agent, Model: a.Model})
+		}
+		registry.SetSelfReviewGuard(guardConfig.Enabled, alternates)
+	}
+	return registry
+}
+
+// enableReviewQuorum fans the review step out to the reviewers configured in review_quorum
+// The aggregated decision is submitted through ReportSBIUseCase, like a single reviewer's report
+func enableReviewQuorum(container *di.Container, useCase *execution.RunTurnUseCase) {
+	cfg := common.GetGlobalConfig()
+	if cfg == nil || !cfg.ReviewQuorumConfig().Enabled() {
+		return
+	}
+	quorumConfig := cfg.ReviewQuorumConfig()
+
+	rule, err := domainservice.ParseReviewQuorumRule(quorumConfig.Rule)
+	if err != nil {
+		common.Warn("[ReviewQuorum] %v (using a single reviewer)\n", err)
+		return
+	}
+
+	registry := container.GetAgentRegistry()
+	seen := make(map[string]bool)
+	var reviewers []execution.ReviewQuorumReviewer
+	for _, r := range quorumConfig.Reviewers {
+		if err := domainservice.ValidateReviewerName(r.Name); err != nil || seen[r.Name] {
+			if err == nil {
+				err = fmt.Errorf("duplicate reviewer name %q", r.Name)
+			}
+			common.Warn("[ReviewQuorum] %v (using a single reviewer)\n", err)
+			return
+		}
+		seen[r.Name] = true
+
+		gateway, err := registry.Gateway(r.Agent)
+		if err != nil {
+			common.Warn("[ReviewQuorum] reviewer %s: %v (using a single reviewer)\n", r.Name, err)
+			return
+		}
+		reviewers = append(reviewers, execution.ReviewQuorumReviewer{Name: r.Name, Gateway: gateway, Model: r.Model})
+	}
+
+	reportUseCase, err := newRunnerReportUseCase(container)
+	if err != nil {
+		common.Warn("[ReviewQuorum] %v (using a single reviewer)\n", err)
+		return
+	}
+	useCase.SetReviewQuorum(reviewers, rule, container.GetReviewVoteRepository(), reportUseCase.Execute)
+}
+
+// enableAgentResponseCache reuses reviews of unchanged code (agent_cache)
+// Cached reviews are submitted through ReportSBIUseCase, like a live reviewer's report.
+func enableAgentResponseCache(container *di.Container, useCase *execution.RunTurnUseCase) {
+	cache := common.NewAgentResponseCache(container)
+	if cache == nil {
+		return
+	}
+	reportUseCase, err := newRunnerReportUseCase(container)
+	if err != nil {
+		common.Warn("[AgentCache] %v (cache disabled)\n", err)
+		return
+	}
+	useCase.SetAgentResponseCache(cache, reportUseCase.Execute)
+}
+
+// newRunnerReportUseCase creates the ReportSBIUseCase through which the runner submits
+// reviews itself
+func newRunnerReportUseCase(container *di.Container) (*usecase.ReportSBIUseCase, error) {
+	artifactStore, err := common.NewArtifactStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifact store: %w", err)
+	}
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	reportUseCase := usecase.NewReportSBIUseCase(
+		container.GetSBIRepository(),
+		common.NewJournalRepository(paths.Journal),
+		container.GetSBIExecLogRepository(),
+		container.GetReviewFeedbackRepository(),
+		container.GetAcceptanceCriteriaRepository(),
+		artifactStore,
+	)
+	reportUseCase.SetReviewIssueRepository(container.GetReviewIssueRepository())
+	// The runner submits reviews from inside the running review step
+	reportUseCase.SetAllowInProgressTurn(true)
+	return reportUseCase, nil
+}
+
+// enableChaos wraps the container's agent gateways, SBI repository and journal with a fault injector
+// Every injected fault is logged; the seed is logged so that a run can be reproduced.
+func enableChaos(container *di.Container, rate float64, seed int64, faults []string) (*chaos.Injector, error) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	injector, err := chaos.NewInjector(rate, seed, faults)
+	if err != nil {
+		return nil, err
+	}
+	injector.OnInject(func(fault, operation string) {
+		common.Warn("[Chaos] Injected %s into %s\n", fault, operation)
+	})
+	container.EnableChaos(injector)
+	common.Warn("[Chaos] Fault injection enabled: rate %g, seed %d (never use on a real project)\n", rate, seed)
+	return injector, nil
+}
+
+// enableSimulation swaps the agent gateway for a simulation gateway that replays a YAML fixture
+// Reports are submitted through ReportSBIUseCase, as real agents do via `deespec sbi report`
+func enableSimulation(container *di.Container, fixturePath string) error {
+	sim, err := agentgateway.LoadSimulationGateway(fixturePath)
+	if err != nil {
+		return err
+	}
+
+	artifactStore, err := common.NewArtifactStore()
+	if err != nil {
+		return fmt.Errorf("failed to create artifact store: %w", err)
+	}
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	reportUseCase := usecase.NewReportSBIUseCase(
+		container.GetSBIRepository(),
+		common.NewJournalRepository(paths.Journal),
+		container.GetSBIExecLogRepository(),
+		container.GetReviewFeedbackRepository(),
+		container.GetAcceptanceCriteriaRepository(),
+		artifactStore,
+	)
+	reportUseCase.SetAllowInProgressTurn(true)
+	reportUseCase.SetReviewIssueRepository(container.GetReviewIssueRepository())
+	reportUseCase.SetReviewVoteRepository(container.GetReviewVoteRepository())
+	sim.SetReporter(reportUseCase.Execute)
+	sim.SetVoteReporter(reportUseCase.SubmitReviewVote)
+
+	container.SetAgentGateway(sim)
+	return nil
+}
@@ -0,0 +1,254 @@
+package run
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// Off-terminal status lines are printed this often, so that CI logs show progress without
+// being flooded
+const defaultStatusLogInterval = 30 * time.Second
+
+// runningStep is a step in progress as seen through turn events
+type runningStep struct {
+	sbiID   string
+	step    string
+	turn    int
+	started time.Time
+	bytes   int
+}
+
+// turnStatusView renders live turn progress from turn events: a status line with the running
+// SBI, step, elapsed time and agent output size. On a terminal the line is redrawn in place
+// every second and log lines written through the view are printed above it; otherwise it is
+// printed as a regular line every interval. In verbose mode agent output is streamed as well.
+type turnStatusView struct {
+	mu       sync.Mutex
+	out      io.Writer
+	tty      bool
+	verbose  bool
+	interval time.Duration
+	now      func() time.Time
+
+	steps     map[string]*runningStep
+	partial   map[string]string // Verbose: output after the last newline, per SBI
+	shown     bool              // A status line is drawn on the terminal
+	lastPrint time.Time
+}
+
+// newTurnStatusView creates a status view writing to out
+func newTurnStatusView(out io.Writer, tty, verbose bool) *turnStatusView {
+	return &turnStatusView{
+		out:      out,
+		tty:      tty,
+		verbose:  verbose,
+		interval: defaultStatusLogInterval,
+		now:      time.Now,
+		steps:    make(map[string]*runningStep),
+		partial:  make(map[string]string),
+	}
+}
+
+// startTurnStatus shows live turn progress on stderr until the returned function is called
+// Log output goes through the view meanwhile, so that it does not garble the status line.
+func startTurnStatus(broker *service.TurnEventBroker, quiet, verbose bool) func() {
+	if quiet || broker == nil {
+		return func() {}
+	}
+
+	view := newTurnStatusView(os.Stderr, isTerminal(os.Stderr), verbose)
+	events, unsubscribe := broker.Subscribe(256)
+	common.GetLogger().SetOutput(view)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		view.run(events, ticker.C)
+	}()
+
+	return func() {
+		unsubscribe()
+		<-done
+		view.clear()
+		common.GetLogger().SetOutput(os.Stderr)
+	}
+}
+
+// run applies events and refreshes the status on every tick until events is closed
+func (v *turnStatusView) run(events <-chan output.TurnEvent, ticks <-chan time.Time) {
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			v.handle(event)
+		case <-ticks:
+			v.refresh()
+		}
+	}
+}
+
+// handle updates the running steps with a turn event
+func (v *turnStatusView) handle(event output.TurnEvent) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	switch event.Type {
+	case output.TurnEventPromptBuilt, output.TurnEventAgentStarted:
+		if current, ok := v.steps[event.SBIID]; ok && current.step == event.Step && current.turn == event.Turn {
+			break // The agent of a step whose prompt was built
+		}
+		started := event.Timestamp
+		if started.IsZero() {
+			started = v.now()
+		}
+		v.flushPartial(event.SBIID)
+		v.steps[event.SBIID] = &runningStep{sbiID: event.SBIID, step: event.Step, turn: event.Turn, started: started}
+		if v.verbose {
+			v.writeAbove(fmt.Sprintf("── %s %s (turn %d) ──\n", shortSBIID(event.SBIID), event.Step, event.Turn))
+		}
+	case output.TurnEventAgentOutputChunk:
+		chunk := event.Data["chunk"]
+		if step, ok := v.steps[event.SBIID]; ok {
+			step.bytes += len(chunk)
+		}
+		if v.verbose {
+			// Only whole lines are written, so that the status line can be redrawn below them
+			text := v.partial[event.SBIID] + chunk
+			if i := strings.LastIndex(text, "\n"); i >= 0 {
+				v.writeAbove(text[:i+1])
+				text = text[i+1:]
+			}
+			v.partial[event.SBIID] = text
+		}
+	case output.TurnEventSaved:
+		v.flushPartial(event.SBIID)
+		delete(v.steps, event.SBIID)
+		if v.shown && len(v.steps) == 0 {
+			v.clearLocked()
+		}
+	}
+}
+
+// refresh redraws the status line on a terminal, or prints it when the interval has passed
+func (v *turnStatusView) refresh() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	line := v.statusLine()
+	if line == "" {
+		return
+	}
+	if v.tty {
+		fmt.Fprintf(v.out, "\r\033[K%s", line)
+		v.shown = true
+		return
+	}
+	if now := v.now(); now.Sub(v.lastPrint) >= v.interval {
+		fmt.Fprintln(v.out, line)
+		v.lastPrint = now
+	}
+}
+
+// statusLine describes the running steps, ordered by SBI ID (empty when none is running)
+func (v *turnStatusView) statusLine() string {
+	if len(v.steps) == 0 {
+		return ""
+	}
+	ids := make([]string, 0, len(v.steps))
+	for id := range v.steps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		step := v.steps[id]
+		elapsed := v.now().Sub(step.started).Truncate(time.Second)
+		parts = append(parts, fmt.Sprintf("%s %s (turn %d) %s · %s output",
+			shortSBIID(step.sbiID), step.step, step.turn, elapsed, formatOutputBytes(step.bytes)))
+	}
+	return "⏳ " + strings.Join(parts, " | ")
+}
+
+// Write prints log output above the status line
+func (v *turnStatusView) Write(p []byte) (int, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.writeAbove(string(p))
+	return len(p), nil
+}
+
+// writeAbove writes text, clearing the status line first and redrawing it afterwards
+func (v *turnStatusView) writeAbove(text string) {
+	if v.shown {
+		fmt.Fprint(v.out, "\r\033[K")
+	}
+	fmt.Fprint(v.out, text)
+	v.shown = false
+	if v.tty {
+		if line := v.statusLine(); line != "" {
+			fmt.Fprint(v.out, line)
+			v.shown = true
+		}
+	}
+}
+
+// flushPartial writes the incomplete output line of an SBI in verbose mode
+func (v *turnStatusView) flushPartial(sbiID string) {
+	if text := v.partial[sbiID]; text != "" {
+		v.writeAbove(text + "\n")
+	}
+	delete(v.partial, sbiID)
+}
+
+// clear removes the status line from the terminal
+func (v *turnStatusView) clear() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.clearLocked()
+}
+
+func (v *turnStatusView) clearLocked() {
+	if v.shown {
+		fmt.Fprint(v.out, "\r\033[K")
+		v.shown = false
+	}
+}
+
+// shortSBIID shortens an SBI ID (a ULID) for the status line
+func shortSBIID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// formatOutputBytes formats an output size as B or KB
+func formatOutputBytes(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,75 @@
+package run
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/output"
+)
+
+func newTestStatusView(tty, verbose bool) (*turnStatusView, *bytes.Buffer, *time.Time) {
+	var out bytes.Buffer
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	view := newTurnStatusView(&out, tty, verbose)
+	view.now = func() time.Time { return now }
+	return view, &out, &now
+}
+
+func TestTurnStatusView_StatusLine(t *testing.T) {
+	view, out, now := newTestStatusView(false, false)
+	started := *now
+	view.handle(output.TurnEvent{Type: output.TurnEventPromptBuilt, SBIID: "01HZX4K9ABCDEF", Turn: 3, Step: "implement", Timestamp: started})
+	view.handle(output.TurnEvent{Type: output.TurnEventAgentStarted, SBIID: "01HZX4K9ABCDEF", Turn: 3, Step: "implement", Timestamp: started.Add(time.Second)})
+	view.handle(output.TurnEvent{Type: output.TurnEventAgentOutputChunk, SBIID: "01HZX4K9ABCDEF", Data: map[string]string{"chunk": strings.Repeat("x", 2048)}})
+
+	*now = started.Add(2*time.Minute + 14*time.Second + 300*time.Millisecond)
+	view.refresh()
+	assert.Equal(t, "⏳ 01HZX4K9 implement (turn 3) 2m14s · 2.0 KB output\n", out.String())
+
+	// Off a terminal the line is only repeated once the interval has passed
+	out.Reset()
+	*now = now.Add(time.Second)
+	view.refresh()
+	assert.Empty(t, out.String())
+	*now = now.Add(defaultStatusLogInterval)
+	view.refresh()
+	assert.Contains(t, out.String(), "2m45s")
+
+	// Nothing is shown once the turn is saved
+	out.Reset()
+	view.handle(output.TurnEvent{Type: output.TurnEventSaved, SBIID: "01HZX4K9ABCDEF"})
+	*now = now.Add(time.Hour)
+	view.refresh()
+	assert.Empty(t, out.String())
+}
+
+func TestTurnStatusView_TerminalKeepsLogsAboveTheStatusLine(t *testing.T) {
+	view, out, now := newTestStatusView(true, false)
+	view.handle(output.TurnEvent{Type: output.TurnEventPromptBuilt, SBIID: "01HZX4K9ABCDEF", Turn: 1, Step: "review", Timestamp: *now})
+	view.refresh()
+	assert.Equal(t, "\r\033[K⏳ 01HZX4K9 review (turn 1) 0s · 0 B output", out.String())
+
+	out.Reset()
+	_, err := view.Write([]byte("[09:00:01] INFO: heartbeat\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "\r\033[K[09:00:01] INFO: heartbeat\n⏳ 01HZX4K9 review (turn 1) 0s · 0 B output", out.String())
+
+	out.Reset()
+	view.handle(output.TurnEvent{Type: output.TurnEventSaved, SBIID: "01HZX4K9ABCDEF"})
+	assert.Equal(t, "\r\033[K", out.String(), "the status line is cleared when no step is running")
+}
+
+func TestTurnStatusView_VerboseStreamsWholeLines(t *testing.T) {
+	view, out, _ := newTestStatusView(false, true)
+	view.handle(output.TurnEvent{Type: output.TurnEventPromptBuilt, SBIID: "01HZX4K9ABCDEF", Turn: 2, Step: "implement"})
+	view.handle(output.TurnEvent{Type: output.TurnEventAgentOutputChunk, SBIID: "01HZX4K9ABCDEF", Data: map[string]string{"chunk": "Reading the han"}})
+	view.handle(output.TurnEvent{Type: output.TurnEventAgentOutputChunk, SBIID: "01HZX4K9ABCDEF", Data: map[string]string{"chunk": "dler\nWriting tes"}})
+	assert.Equal(t, "── 01HZX4K9 implement (turn 2) ──\nReading the handler\n", out.String())
+
+	view.handle(output.TurnEvent{Type: output.TurnEventSaved, SBIID: "01HZX4K9ABCDEF"})
+	assert.True(t, strings.HasSuffix(out.String(), "Writing tes\n"), "the last partial line is written when the turn ends")
+}
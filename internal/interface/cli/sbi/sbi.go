@@ -20,8 +20,20 @@ func NewSBICommand() *cobra.Command {
 	cmd.AddCommand(NewSBIListCommand())
 	cmd.AddCommand(NewSBIShowCommand())
 	cmd.AddCommand(NewSBIResetCommand())
+	cmd.AddCommand(NewSBICancelCommand())
+	cmd.AddCommand(NewSBIDeleteCommand())
+	cmd.AddCommand(NewSBICriteriaCommand())
+	cmd.AddCommand(NewSBIEstimateCommand())
+	cmd.AddCommand(NewSBIIssuesCommand())
+	cmd.AddCommand(NewSBINoteCommand())
+	cmd.AddCommand(NewSBIRefCommand())
 	cmd.AddCommand(NewSBIHistoryCommand())
+	cmd.AddCommand(NewSBIPromptsCommand())
 	cmd.AddCommand(NewSBIReportCommand())
+	cmd.AddCommand(NewSBICompareCommand())
+	cmd.AddCommand(NewSBISnapshotCommand())
+	cmd.AddCommand(NewSBIRestoreCommand())
+	cmd.AddCommand(NewSBIAttestCommand())
 
 	return cmd
 }
@@ -0,0 +1,132 @@
+package sbi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// sbiAttestFlags holds the flags for sbi attest command
+type sbiAttestFlags struct {
+	output string // File to write the attestation of one SBI to (default: stdout)
+	all    bool   // Attest every done SBI
+	dir    string // Directory for the attestations of --all
+}
+
+// NewSBIAttestCommand creates the sbi attest command
+func NewSBIAttestCommand() *cobra.Command {
+	flags := &sbiAttestFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "attest [id]",
+		Short: "Export the execution attestation of a done SBI",
+		Long: `Export a machine-readable attestation of a done SBI in a CycloneDX-like JSON format.
+
+The attestation records, for every implement and review artifact, the agent,
+model and agent version that produced it, the SHA-256 of its prompt and of
+the artifact itself, the review decisions, and the test runs bundled for the
+reviews. Compliance pipelines can use it as provenance of generated code.
+
+Examples:
+  # Print the attestation of an SBI
+  deespec sbi attest 01K7P4N123EQAB57FA5E5ZG6A3
+
+  # Write it to a file
+  deespec sbi attest 01K7P4N123EQAB57FA5E5ZG6A3 -o login.cdx.json
+
+  # Write <id>.cdx.json for every done SBI
+  deespec sbi attest --all --dir attestations`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.all == (len(args) == 1) {
+				return fmt.Errorf("specify either an SBI ID or --all")
+			}
+			sbiID := ""
+			if len(args) == 1 {
+				sbiID = args[0]
+			}
+			return runSBIAttest(cmd.Context(), sbiID, flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "File to write the attestation to (default: stdout)")
+	cmd.Flags().BoolVar(&flags.all, "all", false, "Attest every done SBI")
+	cmd.Flags().StringVar(&flags.dir, "dir", ".", "Directory to write <id>.cdx.json to with --all")
+
+	return cmd
+}
+
+// runSBIAttest executes the sbi attest command
+func runSBIAttest(ctx context.Context, sbiID string, flags *sbiAttestFlags) error {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	builder := service.NewAttestationBuilder(root, container.GetSBIRepository(), container.GetArtifactProvenanceRepository(), buildinfo.GetVersion())
+
+	if !flags.all {
+		attestation, err := builder.Build(ctx, sbiID)
+		if err != nil {
+			return err
+		}
+		if flags.output == "" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(attestation)
+		}
+		outputPath := common.ResolveUserPath(flags.output)
+		if err := writeAttestation(outputPath, attestation); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Attestation of SBI %s written to %s\n", sbiID, outputPath)
+		return nil
+	}
+
+	done, err := container.GetSBIRepository().List(ctx, repository.SBIFilter{Statuses: []model.Status{model.StatusDone}})
+	if err != nil {
+		return fmt.Errorf("failed to list done SBIs: %w", err)
+	}
+	dir := common.ResolveUserPath(flags.dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	for _, s := range done {
+		attestation, err := builder.Build(ctx, s.ID().String())
+		if err != nil {
+			return err
+		}
+		if err := writeAttestation(filepath.Join(dir, s.ID().String()+".cdx.json"), attestation); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("✓ Attestations of %d done SBIs written to %s\n", len(done), dir)
+	return nil
+}
+
+// writeAttestation writes an attestation as indented JSON
+func writeAttestation(path string, attestation *service.Attestation) error {
+	data, err := json.MarshalIndent(attestation, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode attestation: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write attestation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiCancelFlags holds the flags for sbi cancel command
+type sbiCancelFlags struct {
+	reason string // Reason recorded in the journal
+	force  bool   // Cancel without confirmation
+}
+
+// NewSBICancelCommand creates the sbi cancel command
+func NewSBICancelCommand() *cobra.Command {
+	flags := &sbiCancelFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel an SBI, aborting it if it is currently running",
+		Long: `Cancel an SBI task and move it to the terminal CANCELLED status.
+
+If the SBI is being executed by 'deespec run', the running turn detects the
+cancellation within a few seconds and aborts the agent call. The SBI's locks
+are released and the reason is recorded in the journal.
+
+A cancelled SBI is never picked again. Use 'deespec sbi reset' to re-run it.
+
+Examples:
+  # Cancel an SBI
+  deespec sbi cancel 010b1f9c --reason "requirements changed"
+
+  # Cancel without confirmation
+  deespec sbi cancel 010b1f9c --force`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBICancel(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.reason, "reason", "", "Reason for cancellation (recorded in the journal)")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Cancel without confirmation")
+
+	return cmd
+}
+
+// runSBICancel executes the sbi cancel command
+func runSBICancel(ctx context.Context, sbiID string, flags *sbiCancelFlags) error {
+	// Initialize DI container
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	sbiRepo := container.GetSBIRepository()
+
+	// Find SBI to confirm it exists
+	sbiEntity, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+
+	// Show current state and ask for confirmation (unless --force)
+	if !flags.force {
+		fmt.Printf("Current SBI State:\n")
+		fmt.Printf("  ID:     %s\n", sbiEntity.ID().String())
+		fmt.Printf("  Title:  %s\n", sbiEntity.Title())
+		fmt.Printf("  Status: %s\n", sbiEntity.Status())
+		fmt.Printf("  Step:   %s\n", sbiEntity.CurrentStep())
+		fmt.Printf("\nCancel this SBI? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Cancel aborted.")
+			return nil
+		}
+	}
+
+	journalRepo, closeJournalSinks := common.WithJournalSinks(infrarepo.NewJournalRepositoryImpl(".deespec/journal.ndjson"), container.GetDB())
+	defer closeJournalSinks()
+	cancelUseCase := usecase.NewCancelSBIUseCase(sbiRepo, journalRepo, container.GetLockService())
+	if err := cancelUseCase.Execute(ctx, sbiEntity.ID().String(), flags.reason); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ SBI %s has been cancelled\n", sbiID)
+	return nil
+}
@@ -0,0 +1,170 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/YoshitsuguKoike/deespec/internal/pkg/textdiff"
+	"github.com/spf13/cobra"
+)
+
+// sbiCompareFlags holds the flags for sbi compare command
+type sbiCompareFlags struct {
+	turns      string // Comma-separated pair of turns (e.g., "2,4")
+	step       string // Report step to compare: implement or review
+	sideBySide bool   // Render side-by-side instead of unified diff
+	context    int    // Context lines for unified diff
+	width      int    // Column width for side-by-side output
+}
+
+// NewSBICompareCommand creates the sbi compare command
+func NewSBICompareCommand() *cobra.Command {
+	flags := &sbiCompareFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "compare <id>",
+		Short: "Diff implement or review reports between turns",
+		Long: `Compare implementation or review reports of an SBI between two turns.
+
+Helps to check whether the agent is actually addressing reviewer feedback
+attempt over attempt. Reports are read from .deespec/reports/sbi/<id>/
+(falling back to the pre-v0.2.13 location under .deespec/specs/sbi/<id>/).
+
+Examples:
+  # Diff implement reports of turn 2 and turn 4
+  deespec sbi compare 01K7P4N123EQAB57FA5E5ZG6A3 --turns 2,4
+
+  # Diff review reports side by side
+  deespec sbi compare 01K7P4N123EQAB57FA5E5ZG6A3 --turns 3,5 --step review --side-by-side`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBICompare(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.turns, "turns", "", "Two turns to compare, comma-separated (e.g., 2,4)")
+	cmd.Flags().StringVar(&flags.step, "step", "implement", "Report step to compare (implement or review)")
+	cmd.Flags().BoolVar(&flags.sideBySide, "side-by-side", false, "Render side-by-side instead of unified diff")
+	cmd.Flags().IntVarP(&flags.context, "context", "U", 3, "Number of context lines for unified diff")
+	cmd.Flags().IntVar(&flags.width, "width", 60, "Column width for side-by-side output")
+	_ = cmd.MarkFlagRequired("turns")
+
+	return cmd
+}
+
+// runSBICompare executes the sbi compare command
+func runSBICompare(ctx context.Context, sbiID string, flags *sbiCompareFlags) error {
+	step := strings.ToLower(strings.TrimSpace(flags.step))
+	if step != "implement" && step != "review" {
+		return fmt.Errorf("invalid step: %s (must be implement or review)", flags.step)
+	}
+
+	fromTurn, toTurn, err := parseTurnPair(flags.turns)
+	if err != nil {
+		return err
+	}
+
+	// Prefer report paths recorded in execution logs; fall back to conventional paths
+	var execLogRepo repository.SBIExecLogRepository
+	if container, err := common.InitializeContainer(); err == nil {
+		defer container.Close()
+		execLogRepo = container.GetSBIExecLogRepository()
+	}
+
+	fromPath, err := resolveReportPath(ctx, execLogRepo, sbiID, step, fromTurn)
+	if err != nil {
+		return err
+	}
+	toPath, err := resolveReportPath(ctx, execLogRepo, sbiID, step, toTurn)
+	if err != nil {
+		return err
+	}
+
+	fromContent, err := os.ReadFile(fromPath)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+	toContent, err := os.ReadFile(toPath)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	if flags.sideBySide {
+		fmt.Printf("%s%s\n", padRight(fromPath, flags.width+3), toPath)
+		fmt.Printf("%s\n", strings.Repeat("=", flags.width*2+3))
+		fmt.Print(textdiff.SideBySide(string(fromContent), string(toContent), flags.width))
+		return nil
+	}
+
+	diff := textdiff.Unified(fromPath, toPath, string(fromContent), string(toContent), flags.context)
+	if diff == "" {
+		fmt.Printf("No differences between %s reports of turn %d and turn %d\n", step, fromTurn, toTurn)
+		return nil
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+// parseTurnPair parses "2,4" into two positive turn numbers
+func parseTurnPair(value string) (int, int, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("--turns must contain exactly two turns (e.g., 2,4), got: %q", value)
+	}
+
+	turns := make([]int, 2)
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid turn number: %q", part)
+		}
+		turns[i] = n
+	}
+
+	if turns[0] == turns[1] {
+		return 0, 0, fmt.Errorf("--turns must reference two different turns")
+	}
+	return turns[0], turns[1], nil
+}
+
+// resolveReportPath finds the report file for a step at a specific turn
+func resolveReportPath(ctx context.Context, execLogRepo repository.SBIExecLogRepository, sbiID, step string, turn int) (string, error) {
+	if execLogRepo != nil {
+		log, err := execLogRepo.FindBySBIIDAndTurn(ctx, sbiID, turn, strings.ToUpper(step))
+		if err == nil && log != nil && log.ReportPath != "" {
+			if _, statErr := os.Stat(log.ReportPath); statErr == nil {
+				return log.ReportPath, nil
+			}
+		}
+	}
+
+	// Since v0.2.13, reports are in .deespec/reports/sbi/ but old reports may be in .deespec/specs/sbi/
+	// until `deespec migrate reports` moves them
+	filename := fmt.Sprintf("%s_%d.md", step, turn)
+	candidates := []string{
+		filepath.Join(".deespec", "reports", "sbi", sbiID, filename),
+		filepath.Join(".deespec", "specs", "sbi", sbiID, filename),
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("no %s report found for turn %d (checked: %s)", step, turn, strings.Join(candidates, ", "))
+}
+
+// padRight pads s with spaces up to width
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s + " "
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
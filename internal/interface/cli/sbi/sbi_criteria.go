@@ -0,0 +1,110 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiCriteriaFlags holds the flags for sbi criteria command
+type sbiCriteriaFlags struct {
+	waive  int    // Position of the criterion to waive (0 = list only)
+	reason string // Reason recorded with the waiver
+}
+
+// NewSBICriteriaCommand creates the sbi criteria command
+func NewSBICriteriaCommand() *cobra.Command {
+	flags := &sbiCriteriaFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "criteria <id>",
+		Short: "Show or waive the acceptance criteria checklist of an SBI",
+		Long: `Show the acceptance criteria checklist of an SBI.
+
+Criteria are parsed from the "Acceptance Criteria" (受け入れ基準) sections of the
+SBI description and its parent PBI when the SBI is first executed. The review
+step marks each criterion PASSED or FAILED, and the SBI cannot reach DONE until
+every criterion has passed or been waived.
+
+Examples:
+  # Show the checklist
+  deespec sbi criteria 010b1f9c
+
+  # Waive criterion AC-2
+  deespec sbi criteria 010b1f9c --waive 2 --reason "covered by PBI-level e2e test"`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBICriteria(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.waive, "waive", 0, "Waive the criterion at this position (AC-<n>)")
+	cmd.Flags().StringVar(&flags.reason, "reason", "", "Reason for the waiver (required with --waive)")
+
+	return cmd
+}
+
+// runSBICriteria executes the sbi criteria command
+func runSBICriteria(ctx context.Context, sbiID string, flags *sbiCriteriaFlags) error {
+	if flags.waive > 0 && flags.reason == "" {
+		return fmt.Errorf("--reason is required when waiving a criterion")
+	}
+	if flags.waive > 0 {
+		if err := common.CheckWritable("waive acceptance criteria"); err != nil {
+			return err
+		}
+	}
+
+	// Initialize DI container
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	sbiEntity, err := container.GetSBIRepository().Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+	id := sbiEntity.ID().String()
+	criteriaRepo := container.GetAcceptanceCriteriaRepository()
+
+	if flags.waive > 0 {
+		turn := sbiEntity.ExecutionState().CurrentTurn.Value()
+		if err := criteriaRepo.UpdateStatus(ctx, id, flags.waive, repository.CriterionWaived, flags.reason, turn); err != nil {
+			return err
+		}
+		fmt.Printf("✓ AC-%d waived for SBI %s\n\n", flags.waive, id)
+	}
+
+	criteria, err := criteriaRepo.FindBySBIID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(criteria) == 0 {
+		fmt.Printf("No acceptance criteria recorded for SBI %s (they are parsed on the first run)\n", id)
+		return nil
+	}
+
+	satisfied := 0
+	fmt.Printf("Acceptance criteria for %s: %s\n\n", id, sbiEntity.Title())
+	for _, c := range criteria {
+		mark := " "
+		if c.Status.IsSatisfied() {
+			mark = "x"
+			satisfied++
+		}
+		fmt.Printf("  [%s] AC-%d %-8s %s (%s)\n", mark, c.Position, c.Status, c.Description, c.Source)
+		if c.Note != "" {
+			fmt.Printf("           └ %s\n", c.Note)
+		}
+	}
+	fmt.Printf("\n%d/%d satisfied\n", satisfied, len(criteria))
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiDeleteFlags holds the flags for sbi delete command
+type sbiDeleteFlags struct {
+	force bool // Delete without confirmation
+}
+
+// NewSBIDeleteCommand creates the sbi delete command
+func NewSBIDeleteCommand() *cobra.Command {
+	flags := &sbiDeleteFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete an SBI (moves it to the trash)",
+		Long: `Delete an SBI task by moving it to the trash.
+
+Its database rows are hidden from every other command and its spec and report
+directories move to .deespec/trash. Undo with 'deespec trash restore'; it is
+permanently removed by 'deespec trash purge' after the retention window.
+
+Use 'deespec sbi cancel' to stop an SBI while keeping it in the backlog.
+
+Examples:
+  # Delete an SBI
+  deespec sbi delete 010b1f9c
+
+  # Delete without confirmation
+  deespec sbi delete 010b1f9c --force`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIDelete(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Delete without confirmation")
+
+	return cmd
+}
+
+// runSBIDelete executes the sbi delete command
+func runSBIDelete(ctx context.Context, sbiID string, flags *sbiDeleteFlags) error {
+	// Initialize DI container
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	// Find SBI to confirm it exists
+	sbiEntity, err := container.GetSBIRepository().Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+
+	// Show current state and ask for confirmation (unless --force)
+	if !flags.force {
+		fmt.Printf("Current SBI State:\n")
+		fmt.Printf("  ID:     %s\n", sbiEntity.ID().String())
+		fmt.Printf("  Title:  %s\n", sbiEntity.Title())
+		fmt.Printf("  Status: %s\n", sbiEntity.Status())
+		fmt.Printf("\nMove this SBI to the trash? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Delete aborted.")
+			return nil
+		}
+	}
+
+	trash := common.NewTrashUseCase(container.GetTrashRepository())
+	if err := trash.Delete(ctx, repository.TrashKindSBI, sbiEntity.ID().String()); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ SBI %s moved to the trash (restore with 'deespec trash restore %s')\n", sbiID, sbiID)
+	return nil
+}
@@ -0,0 +1,124 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiEstimateFlags holds the flags for sbi estimate command
+type sbiEstimateFlags struct {
+	accept     bool // Confirm the pending suggestion
+	reject     bool // Discard the pending suggestion
+	samples    int  // Completed SBIs shown to the agent
+	promptOnly bool // Print the prompt without running the agent
+}
+
+// NewSBIEstimateCommand creates the sbi estimate command
+func NewSBIEstimateCommand() *cobra.Command {
+	flags := &sbiEstimateFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "estimate <id>",
+		Short: "Suggest the estimated hours of an SBI from similar completed SBIs",
+		Long: `Ask an agent to estimate the effort of an SBI.
+
+The agent reads the spec along with the estimated and actual hours (from start
+to completion) of completed SBIs, those sharing the most labels first, and
+suggests estimated hours with an uncertainty range. The suggestion is recorded
+in the SBI pending confirmation: --accept replaces the estimated hours with it,
+--reject discards it.
+
+Examples:
+  # Suggest an estimate
+  deespec sbi estimate 010b1f9c
+
+  # Confirm the suggestion
+  deespec sbi estimate 010b1f9c --accept
+
+  # Print the prompt without running the agent
+  deespec sbi estimate 010b1f9c --prompt-only`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIEstimate(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.accept, "accept", false, "Replace the estimated hours with the pending suggestion")
+	cmd.Flags().BoolVar(&flags.reject, "reject", false, "Discard the pending suggestion")
+	cmd.Flags().IntVar(&flags.samples, "samples", usecase.DefaultEstimateSamples, "Maximum number of completed SBIs shown to the agent")
+	cmd.Flags().BoolVar(&flags.promptOnly, "prompt-only", false, "Print the estimation prompt without running the agent")
+	cmd.MarkFlagsMutuallyExclusive("accept", "reject", "prompt-only")
+
+	return cmd
+}
+
+// runSBIEstimate executes the sbi estimate command
+func runSBIEstimate(ctx context.Context, sbiID string, flags *sbiEstimateFlags) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !flags.promptOnly {
+		if err := common.CheckWritable("estimate SBI"); err != nil {
+			return err
+		}
+	}
+
+	// Initialize DI container
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	uc := usecase.NewEstimateSBIUseCase(container.GetAgentGateway(), container.GetSBIRepository())
+
+	if flags.accept || flags.reject {
+		confirmed, err := uc.Confirm(ctx, sbiID, flags.accept)
+		if err != nil {
+			return err
+		}
+		if flags.accept {
+			fmt.Printf("✓ Estimated hours of SBI %s set to %gh\n", confirmed.ID().String(), confirmed.Metadata().EstimatedHours)
+		} else {
+			fmt.Printf("✓ Estimate suggestion of SBI %s discarded (estimated hours: %gh)\n", confirmed.ID().String(), confirmed.Metadata().EstimatedHours)
+		}
+		return nil
+	}
+
+	if !flags.promptOnly {
+		fmt.Println("🔍 Estimating the SBI...")
+	}
+	result, err := uc.Execute(ctx, sbiID, usecase.EstimateOptions{MaxSamples: flags.samples, PromptOnly: flags.promptOnly})
+	if err != nil {
+		return err
+	}
+	if flags.promptOnly {
+		fmt.Print(result.Prompt)
+		return nil
+	}
+
+	id := result.SBI.ID().String()
+	suggestion := result.Suggestion
+	fmt.Printf("\nEstimate for %s: %s\n\n", id, result.SBI.Title())
+	fmt.Printf("  Suggested: %gh (range %gh – %gh)\n", suggestion.Hours, suggestion.LowHours, suggestion.HighHours)
+	if current := result.SBI.Metadata().EstimatedHours; current > 0 {
+		fmt.Printf("  Current:   %gh\n", current)
+	}
+	fmt.Printf("  Based on:  %d completed SBI(s)", suggestion.Samples)
+	if result.Ratio > 0 {
+		fmt.Printf(", which took a median %.2f× their estimates", result.Ratio)
+	}
+	fmt.Println()
+	if suggestion.Rationale != "" {
+		fmt.Printf("\n  %s\n", suggestion.Rationale)
+	}
+	fmt.Printf("\nThe suggestion is pending: confirm it with --accept or discard it with --reject\n")
+	fmt.Printf("  deespec sbi estimate %s --accept\n", id)
+	return nil
+}
@@ -44,7 +44,8 @@ Examples:
 
   # Dry run to see what would be extracted
   deespec sbi extract-improvements SBI-01K6P86GWPD8A78X1DEHQ7FAWH --dry-run`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			sbiID := args[0]
 			return extractImprovements(sbiID, dryRun, autoRegister)
@@ -52,7 +52,8 @@ Examples:
 
   # Show in JSON format
   deespec sbi history 010b1f9c --json`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSBIHistory(cmd.Context(), args[0], flags)
 		},
@@ -0,0 +1,130 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiIssuesFlags holds the flags for sbi issues command
+type sbiIssuesFlags struct {
+	close  int    // Number of the issue to close (0 = list only)
+	status string // Status the issue is closed with (addressed or wontfix)
+	reason string // Note recorded with the status change
+	all    bool   // Also list closed issues
+}
+
+// NewSBIIssuesCommand creates the sbi issues command
+func NewSBIIssuesCommand() *cobra.Command {
+	flags := &sbiIssuesFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "issues <id>",
+		Short: "Show or close the review issues tracked for an SBI",
+		Long: `Show the review issues tracked for an SBI.
+
+Issues listed by a review are recorded as ISSUE-<n> and stay open until a later
+review reports them as ADDRESSED or WONTFIX. Implement prompts list the open
+issues, and the SBI cannot reach DONE while a blocking issue is open. Issues
+tagged as nits, minor or optional, and those raised by a passing review, are
+not blocking.
+
+Examples:
+  # Show the open issues
+  deespec sbi issues 010b1f9c
+
+  # Show every issue, including closed ones
+  deespec sbi issues 010b1f9c --all
+
+  # Close ISSUE-2 without fixing it
+  deespec sbi issues 010b1f9c --close 2 --status wontfix --reason "out of scope, tracked in PBI-012"`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIIssues(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.close, "close", 0, "Close the issue with this number (ISSUE-<n>)")
+	cmd.Flags().StringVar(&flags.status, "status", "wontfix", "Status to close the issue with: addressed or wontfix")
+	cmd.Flags().StringVar(&flags.reason, "reason", "", "Reason for closing the issue (required with --close)")
+	cmd.Flags().BoolVar(&flags.all, "all", false, "Also show closed issues")
+
+	return cmd
+}
+
+// runSBIIssues executes the sbi issues command
+func runSBIIssues(ctx context.Context, sbiID string, flags *sbiIssuesFlags) error {
+	status := repository.ReviewIssueStatus(strings.ToUpper(flags.status))
+	if flags.close > 0 {
+		if !status.IsClosed() {
+			return fmt.Errorf("invalid status: %s (must be addressed or wontfix)", flags.status)
+		}
+		if flags.reason == "" {
+			return fmt.Errorf("--reason is required when closing an issue")
+		}
+		if err := common.CheckWritable("close review issues"); err != nil {
+			return err
+		}
+	}
+
+	// Initialize DI container
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	sbiEntity, err := container.GetSBIRepository().Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		return fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+	id := sbiEntity.ID().String()
+	issueRepo := container.GetReviewIssueRepository()
+
+	if flags.close > 0 {
+		turn := sbiEntity.ExecutionState().CurrentTurn.Value()
+		if err := issueRepo.UpdateStatus(ctx, id, flags.close, status, flags.reason, turn); err != nil {
+			return err
+		}
+		fmt.Printf("✓ ISSUE-%d closed as %s for SBI %s\n\n", flags.close, status, id)
+	}
+
+	issues, err := issueRepo.FindBySBIID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Printf("No review issues recorded for SBI %s\n", id)
+		return nil
+	}
+
+	openBlocking, closed := 0, 0
+	fmt.Printf("Review issues for %s: %s\n\n", id, sbiEntity.Title())
+	for _, issue := range issues {
+		if issue.Status.IsClosed() {
+			closed++
+			if !flags.all {
+				continue
+			}
+		} else if issue.Blocking {
+			openBlocking++
+		}
+		kind := "blocking"
+		if !issue.Blocking {
+			kind = "non-blocking"
+		}
+		fmt.Printf("  ISSUE-%d %-9s %-12s %s (turn %d)\n", issue.Number, issue.Status, kind, issue.Description, issue.RaisedTurn)
+		if issue.Note != "" {
+			fmt.Printf("           └ %s\n", issue.Note)
+		}
+	}
+	fmt.Printf("\n%d open blocking, %d/%d closed\n", openBlocking, closed, len(issues))
+
+	return nil
+}
@@ -4,21 +4,36 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/port/input"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
 // sbiListFlags holds the flags for sbi list command
 type sbiListFlags struct {
-	status  []string // Filter by status
-	labels  []string // Filter by labels
-	limit   int      // Limit number of results
-	offset  int      // Offset for pagination
-	jsonOut bool     // Output in JSON format
+	status        []string // Filter by status
+	labels        []string // Filter by labels
+	agents        []string // Filter by assigned agent
+	minPriority   int      // Priority range (applied when the flag is set)
+	maxPriority   int
+	minTurns      int // Turn count range (applied when the flag is set)
+	maxTurns      int
+	updatedWithin string // Only SBIs updated within this duration (e.g. 24h, 7d)
+	filter        string // Filter expression, e.g. "status=WIP,label=backend,turns>3"
+	sort          string // Sort keys, e.g. "priority,-updated"
+	limit         int    // Limit number of results
+	offset        int    // Offset for pagination
+	overdue       bool   // Only unfinished SBIs past their deadline
+	jsonOut       bool   // Output in JSON format
 }
 
 // NewSBIListCommand creates the sbi list command
@@ -31,6 +46,31 @@ func NewSBIListCommand() *cobra.Command {
 		Long: `List all SBI tasks with optional filtering.
 
 Displays SBIs in order: priority DESC → registered_at ASC → sequence ASC
+(--sort keys come first; "-" sorts descending).
+
+Filters are combined with AND. --filter takes comma-separated terms
+"key<op>value" with the operators =, !=, <, <=, >, >=:
+  status    =, != (domain status or label, e.g. WIP; "a|b" for either)
+  label     =     (repeat the term to require several labels)
+  agent     =     ("a|b" for either)
+  priority  number comparisons
+  turns     number comparisons
+  updated   duration since the last update (updated<24h, updated>7d)
+            or a date (updated>=2026-10-01)
+Sort keys: ` + strings.Join(repository.SBISortFields, ", ") + `
+
+The QUEUE column shows the position of pending SBIs in their PBI's execution
+queue. When setting.json "scheduling.pbi_wip_limit" is set, SBIs marked
+"(wip)" wait until an SBI of the same PBI finishes.
+
+The PRIORITY column shows the effective priority pending SBIs are picked by:
+they gain one level per "scheduling.aging_interval_hours" of waiting (default:
+24) up to "scheduling.aging_max_priority" (default: 2), so that a stream of
+higher-priority SBIs cannot starve them, e.g. "0→1 (aged)".
+
+The TURN column marks unfinished SBIs "(at-risk)" once they have used 80% of
+the turn limit ("max_turns") or of their review attempts; "deespec run" also
+sends a "task.at_risk" notification when an SBI crosses that mark.
 
 Examples:
   # List all SBIs
@@ -42,25 +82,105 @@ Examples:
   # List SBIs with specific label
   deespec sbi list --label bug
 
+  # List in-progress backend SBIs that needed more than 3 turns
+  deespec sbi list --filter "status=WIP,label=backend,turns>3"
+
+  # List SBIs of an agent updated in the last day, most recently updated first
+  deespec sbi list --agent codex --updated-within 24h --sort -updated
+
+  # List unfinished SBIs past their deadline
+  deespec sbi list --overdue
+
   # List with pagination
   deespec sbi list --limit 10 --offset 0`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSBIList(cmd.Context(), flags)
+			return runSBIList(cmd.Context(), cmd, flags)
 		},
 	}
 
 	// Define flags
-	cmd.Flags().StringSliceVar(&flags.status, "status", []string{}, "Filter by status (pending, implementing, done, failed)")
+	cmd.Flags().StringSliceVar(&flags.status, "status", []string{}, "Filter by status (pending, implementing, done, failed, cancelled)")
 	cmd.Flags().StringSliceVar(&flags.labels, "label", []string{}, "Filter by labels (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&flags.agents, "agent", []string{}, "Filter by assigned agent (can be specified multiple times)")
+	cmd.Flags().IntVar(&flags.minPriority, "min-priority", 0, "Show only SBIs with at least this priority")
+	cmd.Flags().IntVar(&flags.maxPriority, "max-priority", 0, "Show only SBIs with at most this priority")
+	cmd.Flags().IntVar(&flags.minTurns, "min-turns", 0, "Show only SBIs that used at least this many turns")
+	cmd.Flags().IntVar(&flags.maxTurns, "max-turns", 0, "Show only SBIs that used at most this many turns")
+	cmd.Flags().StringVar(&flags.updatedWithin, "updated-within", "", "Show only SBIs updated within this duration (e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&flags.filter, "filter", "", "Filter expression, e.g. \"status=WIP,label=backend,turns>3\"")
+	cmd.Flags().StringVar(&flags.sort, "sort", "", "Sort keys, e.g. \"priority,-updated\" (- for descending)")
 	cmd.Flags().IntVar(&flags.limit, "limit", 50, "Maximum number of results to return")
 	cmd.Flags().IntVar(&flags.offset, "offset", 0, "Number of results to skip")
+	cmd.Flags().BoolVar(&flags.overdue, "overdue", false, "Show only unfinished SBIs past their deadline")
 	cmd.Flags().BoolVar(&flags.jsonOut, "json", false, "Output in JSON format")
 
 	return cmd
 }
 
+// buildSBIListRequest builds the list request from the flags and the --filter expression
+func buildSBIListRequest(cmd *cobra.Command, flags *sbiListFlags, statusLabels *service.StatusLabels, now time.Time) (dto.ListTasksRequest, error) {
+	req := dto.ListTasksRequest{
+		Types:          []string{"SBI"},
+		Labels:         flags.labels,
+		AssignedAgents: flags.agents,
+		Sort:           flags.sort,
+		Limit:          flags.limit,
+		Offset:         flags.offset,
+		Overdue:        flags.overdue,
+	}
+	filter := newSBIListFilter(&req, statusLabels, now)
+
+	if len(flags.status) > 0 {
+		if err := filter.addStatuses(flags.status, false); err != nil {
+			return req, err
+		}
+	}
+	ranges := []struct {
+		flag  string
+		op    string
+		value int
+	}{
+		{"min-priority", ">=", flags.minPriority},
+		{"max-priority", "<=", flags.maxPriority},
+		{"min-turns", ">=", flags.minTurns},
+		{"max-turns", "<=", flags.maxTurns},
+	}
+	for _, r := range ranges {
+		if cmd.Flags().Changed(r.flag) {
+			key := strings.TrimPrefix(strings.TrimPrefix(r.flag, "min-"), "max-")
+			if err := filter.addTerm(key, r.op, strconv.Itoa(r.value)); err != nil {
+				return req, fmt.Errorf("--%s: %w", r.flag, err)
+			}
+		}
+	}
+	if flags.updatedWithin != "" {
+		if _, err := parseAge(flags.updatedWithin); err != nil {
+			return req, fmt.Errorf("--updated-within: %w", err)
+		}
+		if err := filter.addUpdated("<=", flags.updatedWithin); err != nil {
+			return req, err
+		}
+	}
+	if flags.filter != "" {
+		if err := filter.parseExpression(flags.filter); err != nil {
+			return req, err
+		}
+	}
+	if flags.sort != "" {
+		if _, err := repository.ParseSortKeys(flags.sort, repository.SBISortFields); err != nil {
+			return req, err
+		}
+	}
+	return req, filter.finish()
+}
+
 // runSBIList executes the sbi list command
-func runSBIList(ctx context.Context, flags *sbiListFlags) error {
+func runSBIList(ctx context.Context, cmd *cobra.Command, flags *sbiListFlags) error {
+	req, err := buildSBIListRequest(cmd, flags, common.NewStatusLabels(), time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Initialize DI container
 	container, err := common.InitializeContainer()
 	if err != nil {
@@ -71,37 +191,79 @@ func runSBIList(ctx context.Context, flags *sbiListFlags) error {
 	// Get Task UseCase
 	taskUseCase := container.GetTaskUseCase()
 
-	// Build filter from flags
-	req := dto.ListTasksRequest{
-		Types:    []string{"SBI"},
-		Statuses: flags.status,
-		Limit:    flags.limit,
-		Offset:   flags.offset,
-	}
-
 	// Execute list operation
 	response, err := taskUseCase.ListTasks(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to list SBIs: %w", err)
 	}
 
-	// Filter by labels if specified (client-side filtering for now)
-	var filteredTasks []dto.TaskDTO
-	if len(flags.labels) > 0 {
-		for _, task := range response.Tasks {
-			// This is a simplified filter - in production, you'd want server-side filtering
-			filteredTasks = append(filteredTasks, task)
-		}
-	} else {
-		filteredTasks = response.Tasks
-	}
-
 	// Output results
 	if flags.jsonOut {
-		return outputJSONList(filteredTasks, response.TotalCount)
+		return outputJSONList(response.Tasks, response.TotalCount)
+	}
+
+	return outputTableList(response.Tasks, response.TotalCount, flags.offset)
+}
+
+// loadQueuePositions computes per-PBI queue positions of pending SBIs
+// Errors are non-fatal: the list is still shown without queue positions
+func loadQueuePositions(ctx context.Context) map[string]service.PBIQueuePosition {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return nil
+	}
+	defer container.Close()
+
+	limit := 0
+	if cfg := common.GetGlobalConfig(); cfg != nil {
+		limit = cfg.SchedulingConfig().PBIWIPLimit
+	}
+
+	execService := service.NewSBIExecutionService(container.GetSBIRepository(), container.GetLockService())
+	execService.SetPriorityAging(common.NewPriorityAging())
+	positions, err := execService.PBIQueuePositions(ctx, limit)
+	if err != nil {
+		return nil
+	}
+	return positions
+}
+
+// loadSBIDetails loads the SBI details of the listed tasks keyed by SBI ID
+// Errors are non-fatal: the list is still shown without the details
+func loadSBIDetails(ctx context.Context, taskUseCase input.TaskUseCase, tasks []dto.TaskDTO) map[string]*dto.SBIDTO {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	details := make(map[string]*dto.SBIDTO, len(tasks))
+	sbiDTOs, err := taskUseCase.GetSBIs(ctx, ids)
+	if err != nil {
+		return details
+	}
+	for _, sbiDTO := range sbiDTOs {
+		details[sbiDTO.ID] = sbiDTO
 	}
+	return details
+}
 
-	return outputTableList(filteredTasks, response.TotalCount, flags.offset)
+// formatQueuePosition formats a queue position for display
+func formatQueuePosition(pos service.PBIQueuePosition, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	if pos.Waiting {
+		return fmt.Sprintf("#%d (wip)", pos.Position)
+	}
+	return fmt.Sprintf("#%d", pos.Position)
+}
+
+// formatPriority formats the stored priority, with the effective one when aging raised it
+func formatPriority(priority, effective int) string {
+	if effective == priority {
+		return fmt.Sprintf("%d", priority)
+	}
+	return fmt.Sprintf("%d→%d (aged)", priority, effective)
 }
 
 // outputTableList outputs the SBI list in table format
@@ -116,37 +278,54 @@ func outputTableList(tasks []dto.TaskDTO, total, offset int) error {
 	defer w.Flush()
 
 	// Print header
-	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tSTEP\tTURN\tSTARTED\tCOMPLETED\tCREATED\n")
-	fmt.Fprintf(w, "---\t-----\t------\t----\t----\t-------\t---------\t-------\n")
+	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tSTEP\tPRIORITY\tTURN\tQUEUE\tSTARTED\tCOMPLETED\tDEADLINE\tCREATED\n")
+	fmt.Fprintf(w, "---\t-----\t------\t----\t--------\t----\t-----\t-------\t---------\t--------\t-------\n")
 
 	// Print rows - need to fetch detailed SBI info for each task
 	ctx := context.Background()
+	queuePositions := loadQueuePositions(ctx)
 	container, err := common.InitializeContainer()
 	if err != nil {
 		return fmt.Errorf("failed to initialize container: %w", err)
 	}
 	defer container.Close()
 	taskUseCase := container.GetTaskUseCase()
+	statusLabels := common.NewStatusLabels()
+	retryBudget := common.NewRetryBudget(nil)
+	aging := common.NewPriorityAging()
+	now := time.Now()
+
+	// Fetch detailed SBI info to get turn, started_at, completed_at for all rows at once
+	details := loadSBIDetails(ctx, taskUseCase, tasks)
 
 	for _, task := range tasks {
 		id := task.ID // Show full ULID for sbi show command compatibility
 		title := truncateString(task.Title, 40)
-		status := task.Status
+		status := statusLabels.Display(model.Status(task.Status))
 		step := task.CurrentStep
 		created := formatTime(task.CreatedAt)
 
-		// Fetch detailed SBI info to get turn, started_at, completed_at
-		sbiDTO, err := taskUseCase.GetSBI(ctx, task.ID)
+		sbiDTO, ok := details[task.ID]
+		priority := "-"
 		turn := "-"
 		started := "-"
 		completed := "-"
-		if err == nil {
+		deadline := "-"
+		if ok {
+			priority = formatPriority(sbiDTO.Priority, aging.Effective(sbiDTO.Priority, model.Status(task.Status), sbiDTO.RegisteredAt, now))
 			turn = fmt.Sprintf("%d", sbiDTO.CurrentTurn)
+			if retryBudget.Usage(model.Status(task.Status), sbiDTO.CurrentTurn, sbiDTO.CurrentAttempt).AtRisk {
+				turn += " (at-risk)"
+			}
 			started = formatTimePtr(sbiDTO.StartedAt)
 			completed = formatTimePtr(sbiDTO.CompletedAt)
+			deadline = formatTimePtr(sbiDTO.Deadline)
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", id, title, status, step, turn, started, completed, created)
+		pos, queued := queuePositions[task.ID]
+		queue := formatQueuePosition(pos, queued)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", id, title, status, step, priority, turn, queue, started, completed, deadline, created)
 	}
 
 	// Print summary
@@ -162,6 +341,18 @@ func outputTableList(tasks []dto.TaskDTO, total, offset int) error {
 
 // outputJSONList outputs the SBI list in JSON format
 func outputJSONList(tasks []dto.TaskDTO, total int) error {
+	statusLabels := common.NewStatusLabels()
+	retryBudget := common.NewRetryBudget(nil)
+	aging := common.NewPriorityAging()
+	now := time.Now()
+
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+	details := loadSBIDetails(context.Background(), container.GetTaskUseCase(), tasks)
+
 	// For now, just pretty-print the task list
 	// In production, you'd use json.Marshal with proper formatting
 	fmt.Printf(`{
@@ -172,14 +363,25 @@ func outputJSONList(tasks []dto.TaskDTO, total int) error {
 		if i == len(tasks)-1 {
 			comma = ""
 		}
+		atRisk := false
+		priority, effectivePriority := 0, 0
+		if sbiDTO, ok := details[task.ID]; ok {
+			atRisk = retryBudget.Usage(model.Status(task.Status), sbiDTO.CurrentTurn, sbiDTO.CurrentAttempt).AtRisk
+			priority = sbiDTO.Priority
+			effectivePriority = aging.Effective(sbiDTO.Priority, model.Status(task.Status), sbiDTO.RegisteredAt, now)
+		}
 		fmt.Printf(`    {
       "id": "%s",
       "title": "%s",
       "status": "%s",
+      "status_label": "%s",
       "current_step": "%s",
+      "priority": %d,
+      "effective_priority": %d,
+      "at_risk": %t,
       "created_at": "%s"
     }%s
-`, task.ID, task.Title, task.Status, task.CurrentStep, task.CreatedAt.Format(time.RFC3339), comma)
+`, task.ID, task.Title, task.Status, statusLabels.Label(model.Status(task.Status)), task.CurrentStep, priority, effectivePriority, atRisk, task.CreatedAt.Format(time.RFC3339), comma)
 	}
 	fmt.Printf(`  ],
   "total": %d
@@ -0,0 +1,229 @@
+package sbi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+)
+
+// filterTermPattern matches one "key<op>value" term of a --filter expression
+var filterTermPattern = regexp.MustCompile(`^\s*([a-zA-Z_]+)\s*(>=|<=|!=|=|>|<)\s*(.*?)\s*$`)
+
+// listStatuses are the statuses status criteria choose from, in workflow order
+var listStatuses = []model.Status{
+	model.StatusPending, model.StatusPicked, model.StatusImplementing, model.StatusReviewing,
+	model.StatusDone, model.StatusFailed, model.StatusCancelled,
+}
+
+// sbiListFilter collects the criteria of the sbi list flags and --filter terms
+// Terms are ANDed: a second status term narrows the first, ranges take the tighter bound.
+type sbiListFilter struct {
+	req          *dto.ListTasksRequest
+	statusLabels *service.StatusLabels
+	now          time.Time
+	statuses     map[model.Status]bool // nil until a status criterion is given
+}
+
+// newSBIListFilter creates a filter that adds its criteria to req
+func newSBIListFilter(req *dto.ListTasksRequest, statusLabels *service.StatusLabels, now time.Time) *sbiListFilter {
+	return &sbiListFilter{req: req, statusLabels: statusLabels, now: now}
+}
+
+// parseExpression adds the terms of a --filter expression such as
+// "status=WIP,label=backend,turns>3"
+func (f *sbiListFilter) parseExpression(expr string) error {
+	for _, term := range strings.Split(expr, ",") {
+		if strings.TrimSpace(term) == "" {
+			continue
+		}
+		m := filterTermPattern.FindStringSubmatch(term)
+		if m == nil || m[3] == "" {
+			return fmt.Errorf("invalid filter term %q (expected key<op>value, e.g. turns>3)", strings.TrimSpace(term))
+		}
+		if err := f.addTerm(strings.ToLower(m[1]), m[2], m[3]); err != nil {
+			return fmt.Errorf("invalid filter term %q: %w", strings.TrimSpace(term), err)
+		}
+	}
+	return nil
+}
+
+// addTerm adds one filter term
+func (f *sbiListFilter) addTerm(key, op, value string) error {
+	switch key {
+	case "status":
+		if op != "=" && op != "!=" {
+			return fmt.Errorf("status supports = and !=")
+		}
+		return f.addStatuses(strings.Split(value, "|"), op == "!=")
+	case "label":
+		if op != "=" {
+			return fmt.Errorf("label supports =")
+		}
+		f.req.Labels = append(f.req.Labels, value)
+	case "agent":
+		if op != "=" {
+			return fmt.Errorf("agent supports =")
+		}
+		f.req.AssignedAgents = append(f.req.AssignedAgents, strings.Split(value, "|")...)
+	case "priority":
+		return addIntRange(&f.req.MinPriority, &f.req.MaxPriority, op, value)
+	case "turns":
+		return addIntRange(&f.req.MinTurns, &f.req.MaxTurns, op, value)
+	case "updated":
+		return f.addUpdated(op, value)
+	default:
+		return fmt.Errorf("unknown key %q (keys: status, label, agent, priority, turns, updated)", key)
+	}
+	return nil
+}
+
+// addStatuses keeps the statuses named (or, when exclude is set, all but those); names are
+// domain statuses or their labels, e.g. "WIP" or "implementing"
+func (f *sbiListFilter) addStatuses(names []string, exclude bool) error {
+	named := make(map[model.Status]bool)
+	for _, name := range names {
+		statuses := f.statusLabels.Statuses(name)
+		if len(statuses) == 0 {
+			return fmt.Errorf("unknown status %q", name)
+		}
+		for _, status := range statuses {
+			named[status] = true
+		}
+	}
+
+	kept := make(map[model.Status]bool)
+	for _, status := range listStatuses {
+		if named[status] != exclude && (f.statuses == nil || f.statuses[status]) {
+			kept[status] = true
+		}
+	}
+	f.statuses = kept
+	return nil
+}
+
+// addUpdated narrows the last-updated window: a duration compares the time since the
+// last update ("updated<24h" is within the last day), a date or RFC 3339 time compares
+// the update time itself ("updated>=2026-10-01")
+func (f *sbiListFilter) addUpdated(op, value string) error {
+	if op == "=" || op == "!=" {
+		return fmt.Errorf("updated supports <, <=, > and >=")
+	}
+	if age, err := parseAge(value); err == nil {
+		since := f.now.Add(-age)
+		if op == "<" || op == "<=" {
+			f.setUpdatedAfter(since)
+		} else {
+			f.setUpdatedBefore(since)
+		}
+		return nil
+	}
+	at, err := parseDateTime(value)
+	if err != nil {
+		return fmt.Errorf("expected a duration (e.g. 24h, 7d) or a date (YYYY-MM-DD)")
+	}
+	if op == ">" || op == ">=" {
+		f.setUpdatedAfter(at)
+	} else {
+		f.setUpdatedBefore(at)
+	}
+	return nil
+}
+
+// setUpdatedAfter narrows the window to updates at or after t
+func (f *sbiListFilter) setUpdatedAfter(t time.Time) {
+	if f.req.UpdatedAfter == nil || t.After(*f.req.UpdatedAfter) {
+		f.req.UpdatedAfter = &t
+	}
+}
+
+// setUpdatedBefore narrows the window to updates at or before t
+func (f *sbiListFilter) setUpdatedBefore(t time.Time) {
+	if f.req.UpdatedBefore == nil || t.Before(*f.req.UpdatedBefore) {
+		f.req.UpdatedBefore = &t
+	}
+}
+
+// finish writes the collected statuses to the request
+func (f *sbiListFilter) finish() error {
+	if f.statuses == nil {
+		return nil
+	}
+	if len(f.statuses) == 0 {
+		return fmt.Errorf("the status criteria exclude every status")
+	}
+	f.req.Statuses = nil
+	for _, status := range listStatuses {
+		if f.statuses[status] {
+			f.req.Statuses = append(f.req.Statuses, string(status))
+		}
+	}
+	return nil
+}
+
+// addIntRange narrows an inclusive integer range by one comparison
+func addIntRange(lower, upper **int, op, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("expected an integer, got %q", value)
+	}
+	switch op {
+	case "=":
+		setMin(lower, n)
+		setMax(upper, n)
+	case ">":
+		setMin(lower, n+1)
+	case ">=":
+		setMin(lower, n)
+	case "<":
+		setMax(upper, n-1)
+	case "<=":
+		setMax(upper, n)
+	default:
+		return fmt.Errorf("%s is not supported for numbers", op)
+	}
+	return nil
+}
+
+// setMin raises the lower bound to n
+func setMin(lower **int, n int) {
+	if *lower == nil || n > **lower {
+		*lower = &n
+	}
+}
+
+// setMax lowers the upper bound to n
+func setMax(upper **int, n int) {
+	if *upper == nil || n < **upper {
+		*upper = &n
+	}
+}
+
+// parseAge parses a duration, also accepting days ("7d")
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return age, nil
+}
+
+// parseDateTime parses a local date (YYYY-MM-DD) or an RFC 3339 time
+func parseDateTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
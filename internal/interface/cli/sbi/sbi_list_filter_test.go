@@ -0,0 +1,90 @@
+package sbi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+)
+
+// parseListFlags parses sbi list arguments into the command and its flag values
+func parseListFlags(t *testing.T, args ...string) (*cobra.Command, *sbiListFlags) {
+	t.Helper()
+	cmd := NewSBIListCommand()
+	require.NoError(t, cmd.ParseFlags(args))
+	flags := &sbiListFlags{}
+	flags.status, _ = cmd.Flags().GetStringSlice("status")
+	flags.labels, _ = cmd.Flags().GetStringSlice("label")
+	flags.agents, _ = cmd.Flags().GetStringSlice("agent")
+	flags.minPriority, _ = cmd.Flags().GetInt("min-priority")
+	flags.maxPriority, _ = cmd.Flags().GetInt("max-priority")
+	flags.minTurns, _ = cmd.Flags().GetInt("min-turns")
+	flags.maxTurns, _ = cmd.Flags().GetInt("max-turns")
+	flags.updatedWithin, _ = cmd.Flags().GetString("updated-within")
+	flags.filter, _ = cmd.Flags().GetString("filter")
+	flags.sort, _ = cmd.Flags().GetString("sort")
+	return cmd, flags
+}
+
+func TestBuildSBIListRequest(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	labels, err := service.NewStatusLabels(nil)
+	require.NoError(t, err)
+
+	t.Run("filter expression", func(t *testing.T) {
+		cmd, flags := parseListFlags(t, "--filter", "status=WIP,label=backend,turns>3,priority<=2,updated<7d,agent=codex|gemini-cli", "--sort", "-updated")
+		req, err := buildSBIListRequest(cmd, flags, labels, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"PICKED", "IMPLEMENTING"}, req.Statuses)
+		assert.Equal(t, []string{"backend"}, req.Labels)
+		assert.Equal(t, []string{"codex", "gemini-cli"}, req.AssignedAgents)
+		assert.Equal(t, 4, *req.MinTurns)
+		assert.Nil(t, req.MaxTurns)
+		assert.Equal(t, 2, *req.MaxPriority)
+		assert.Equal(t, now.Add(-7*24*time.Hour), *req.UpdatedAfter)
+		assert.Equal(t, "-updated", req.Sort)
+	})
+
+	t.Run("flags and terms are combined", func(t *testing.T) {
+		cmd, flags := parseListFlags(t, "--status", "pending,picked,done", "--min-turns", "0", "--max-turns", "5",
+			"--updated-within", "24h", "--filter", "status!=DONE,turns<=3,updated<2h")
+		req, err := buildSBIListRequest(cmd, flags, labels, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"PENDING", "PICKED"}, req.Statuses, "status terms narrow --status")
+		assert.Equal(t, 0, *req.MinTurns, "a zero flag value is applied when set")
+		assert.Equal(t, 3, *req.MaxTurns, "the tighter bound wins")
+		assert.Equal(t, now.Add(-2*time.Hour), *req.UpdatedAfter)
+		assert.Nil(t, req.MinPriority, "unset flags add nothing")
+	})
+
+	t.Run("dates", func(t *testing.T) {
+		cmd, flags := parseListFlags(t, "--filter", "updated>=2026-10-01T00:00:00Z,updated<2026-10-08T00:00:00Z")
+		req, err := buildSBIListRequest(cmd, flags, labels, now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), req.UpdatedAfter.UTC())
+		assert.Equal(t, time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC), req.UpdatedBefore.UTC())
+	})
+
+	for _, tt := range []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--filter", "turns"}, `invalid filter term "turns"`},
+		{[]string{"--filter", "size>3"}, `unknown key "size"`},
+		{[]string{"--filter", "status=BLOCKED"}, `unknown status "BLOCKED"`},
+		{[]string{"--filter", "status>DONE"}, "status supports = and !="},
+		{[]string{"--filter", "turns>many"}, "expected an integer"},
+		{[]string{"--filter", "updated<soon"}, "expected a duration"},
+		{[]string{"--filter", "status=DONE,status=FAILED"}, "exclude every status"},
+		{[]string{"--updated-within", "1w"}, "--updated-within"},
+		{[]string{"--sort", "size"}, `unknown sort key "size"`},
+	} {
+		cmd, flags := parseListFlags(t, tt.args...)
+		_, err := buildSBIListRequest(cmd, flags, labels, now)
+		assert.ErrorContains(t, err, tt.wantErr, "%v", tt.args)
+	}
+}
@@ -0,0 +1,293 @@
+package sbi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiNoteView is the JSON representation of an SBI note
+type sbiNoteView struct {
+	ID           int64     `json:"id"`
+	Body         string    `json:"body"`
+	Links        []string  `json:"links"`
+	Author       string    `json:"author"`
+	InjectPrompt bool      `json:"inject_prompt"`
+	InjectedTurn int       `json:"injected_turn,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// sbiNoteAddFlags holds the flags for sbi note add command
+type sbiNoteAddFlags struct {
+	links  []string // URLs or ticket references
+	author string   // Author recorded with the note
+	prompt bool     // Add the note to the next prompt's prior context
+}
+
+// NewSBINoteCommand creates the sbi note command with its subcommands
+func NewSBINoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "note",
+		Short: "Attach notes and links to an SBI",
+		Long: `Attach timestamped notes and links to an SBI.
+
+Notes are shown by 'deespec sbi show'. A note added with --prompt, or marked
+later with 'deespec sbi note prompt', is also given to the agent in the prior
+context of the next turn. The journal entry of that turn records the notes.`,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+	}
+
+	cmd.AddCommand(newSBINoteAddCommand())
+	cmd.AddCommand(newSBINoteListCommand())
+	cmd.AddCommand(newSBINoteRemoveCommand())
+	cmd.AddCommand(newSBINotePromptCommand())
+
+	return cmd
+}
+
+func newSBINoteAddCommand() *cobra.Command {
+	flags := &sbiNoteAddFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add <id> <text>",
+		Short: "Add a note to an SBI",
+		Example: `  # Add a note
+  deespec sbi note add 010b1f9c "blocked on infra ticket INFRA-42" --link https://tracker.example/INFRA-42
+
+  # Add a note the agent sees in its next turn
+  deespec sbi note add 010b1f9c "use the v2 payments endpoint, v1 is deprecated" --prompt`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBINoteAdd(cmd.Context(), args[0], args[1], flags)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.links, "link", []string{}, "Link or ticket reference (can be specified multiple times)")
+	cmd.Flags().StringVar(&flags.author, "author", os.Getenv("USER"), "Author of the note")
+	cmd.Flags().BoolVar(&flags.prompt, "prompt", false, "Add the note to the prior context of the next agent turn")
+
+	return cmd
+}
+
+func newSBINoteListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "list <id>",
+		Short:             "List the notes of an SBI",
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBINoteList(cmd.Context(), args[0])
+		},
+	}
+}
+
+func newSBINoteRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm <id> <note-id>",
+		Aliases:           []string{"remove"},
+		Short:             "Remove a note from an SBI",
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noteID, err := parseNoteID(args[1])
+			if err != nil {
+				return err
+			}
+			return runSBINoteRemove(cmd.Context(), args[0], noteID)
+		},
+	}
+}
+
+func newSBINotePromptCommand() *cobra.Command {
+	var off bool
+
+	cmd := &cobra.Command{
+		Use:   "prompt <id> <note-id>",
+		Short: "Give an existing note to the agent in the next turn",
+		Example: `  # Nudge the agent with a note added earlier
+  deespec sbi note prompt 010b1f9c 3
+
+  # Keep the note for people only
+  deespec sbi note prompt 010b1f9c 3 --off`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noteID, err := parseNoteID(args[1])
+			if err != nil {
+				return err
+			}
+			return runSBINotePrompt(cmd.Context(), args[0], noteID, !off)
+		},
+	}
+
+	cmd.Flags().BoolVar(&off, "off", false, "Stop giving the note to the agent")
+
+	return cmd
+}
+
+// parseNoteID parses a note ID as shown by sbi note list, with or without the "#"
+func parseNoteID(arg string) (int64, error) {
+	noteID, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid note ID %q: use the number shown by 'deespec sbi note list'", arg)
+	}
+	return noteID, nil
+}
+
+// runSBINoteAdd executes the sbi note add command
+func runSBINoteAdd(ctx context.Context, sbiID, text string, flags *sbiNoteAddFlags) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("note text must not be empty")
+	}
+
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	note := &repository.SBINote{
+		SBIID:        id,
+		Body:         text,
+		Links:        flags.links,
+		Author:       flags.author,
+		InjectPrompt: flags.prompt,
+	}
+	if err := container.GetSBINoteRepository().Add(ctx, note); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Note #%d added to SBI %s\n", note.ID, id)
+	if note.InjectPrompt {
+		fmt.Println("  It will be given to the agent in the next turn.")
+	}
+	return nil
+}
+
+// runSBINoteList executes the sbi note list command
+func runSBINoteList(ctx context.Context, sbiID string) error {
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	notes, err := container.GetSBINoteRepository().FindBySBIID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		fmt.Printf("No notes for SBI %s\n", id)
+		return nil
+	}
+
+	fmt.Printf("Notes for %s:\n\n", id)
+	printSBINotes(notes)
+	return nil
+}
+
+// runSBINoteRemove executes the sbi note rm command
+func runSBINoteRemove(ctx context.Context, sbiID string, noteID int64) error {
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	if err := container.GetSBINoteRepository().Delete(ctx, id, noteID); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Note #%d removed from SBI %s\n", noteID, id)
+	return nil
+}
+
+// runSBINotePrompt executes the sbi note prompt command
+func runSBINotePrompt(ctx context.Context, sbiID string, noteID int64, inject bool) error {
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	if err := container.GetSBINoteRepository().SetInjectPrompt(ctx, id, noteID, inject); err != nil {
+		return err
+	}
+	if inject {
+		fmt.Printf("✓ Note #%d of SBI %s will be given to the agent in the next turn\n", noteID, id)
+	} else {
+		fmt.Printf("✓ Note #%d of SBI %s is no longer given to the agent\n", noteID, id)
+	}
+	return nil
+}
+
+// openSBIForNotes initializes the container and resolves the full ID of the SBI
+// The caller must close the returned container
+func openSBIForNotes(ctx context.Context, sbiID string) (*di.Container, string, error) {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to initialize container: %w", err)
+	}
+
+	sbiEntity, err := container.GetSBIRepository().Find(ctx, repository.SBIID(sbiID))
+	if err != nil {
+		container.Close()
+		return nil, "", fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
+	}
+	return container, sbiEntity.ID().String(), nil
+}
+
+// printSBINotes prints notes oldest first with their links
+func printSBINotes(notes []*repository.SBINote) {
+	for _, note := range notes {
+		header := fmt.Sprintf("  #%d  %s", note.ID, note.CreatedAt.Local().Format("2006-01-02 15:04"))
+		if note.Author != "" {
+			header += "  " + note.Author
+		}
+		switch {
+		case note.InjectedTurn > 0:
+			header += fmt.Sprintf("  [prompt: turn %d]", note.InjectedTurn)
+		case note.InjectPrompt:
+			header += "  [prompt: next turn]"
+		}
+		fmt.Println(header)
+		fmt.Printf("      %s\n", note.Body)
+		for _, link := range note.Links {
+			fmt.Printf("      🔗 %s\n", link)
+		}
+	}
+}
+
+// newSBINoteViews converts notes to their JSON representation
+func newSBINoteViews(notes []*repository.SBINote) []sbiNoteView {
+	views := make([]sbiNoteView, 0, len(notes))
+	for _, note := range notes {
+		links := note.Links
+		if links == nil {
+			links = []string{}
+		}
+		views = append(views, sbiNoteView{
+			ID:           note.ID,
+			Body:         note.Body,
+			Links:        links,
+			Author:       note.Author,
+			InjectPrompt: note.InjectPrompt,
+			InjectedTurn: note.InjectedTurn,
+			CreatedAt:    note.CreatedAt,
+		})
+	}
+	return views
+}
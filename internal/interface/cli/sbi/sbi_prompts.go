@@ -0,0 +1,155 @@
+package sbi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// sbiPromptsFlags holds the flags for sbi prompts command
+type sbiPromptsFlags struct {
+	turn    int    // Only the prompts of this turn (0 = all)
+	step    string // Only the prompts of this step
+	show    int    // Print the prompt and answer of this entry (0 = list only)
+	jsonOut bool   // Output in JSON format
+}
+
+// NewSBIPromptsCommand creates the sbi prompts command
+func NewSBIPromptsCommand() *cobra.Command {
+	flags := &sbiPromptsFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "prompts <id>",
+		Short: "List or show the archived prompts sent to agents for an SBI",
+		Long: `List the prompts sent to agents for an SBI, from the prompt archive in
+.deespec/var/prompts, and show the exact prompt and answer of one of them.
+
+Every agent call of a turn is archived: the step prompt, corrective prompts
+for missing reports, change budget split requests and time box checkpoints.
+Entries are kept for prompt_archive.retention_days (default: 30) in setting.json.
+
+Examples:
+  # List the archived prompts
+  deespec sbi prompts 010b1f9c-2cbf-40e6-90d8-ecba5b62d335
+
+  # Only the review prompts of turn 3
+  deespec sbi prompts 010b1f9c-2cbf-40e6-90d8-ecba5b62d335 --turn 3 --step review
+
+  # Show the prompt and answer of the second entry listed
+  deespec sbi prompts 010b1f9c-2cbf-40e6-90d8-ecba5b62d335 --show 2`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIPrompts(cmd.Context(), args[0], flags)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.turn, "turn", 0, "Only list the prompts of this turn")
+	cmd.Flags().StringVar(&flags.step, "step", "", "Only list the prompts of this step (e.g. implement, review)")
+	cmd.Flags().IntVar(&flags.show, "show", 0, "Print the prompt and answer of the entry at this position in the list")
+	cmd.Flags().BoolVar(&flags.jsonOut, "json", false, "Output in JSON format, with the prompts and answers")
+
+	return cmd
+}
+
+// runSBIPrompts executes the sbi prompts command
+func runSBIPrompts(ctx context.Context, sbiID string, flags *sbiPromptsFlags) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	archive := infraRepo.NewPromptArchiveRepositoryImpl(common.PromptArchiveDir(), true)
+	all, err := archive.List(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	var entries []*repository.PromptArchiveEntry
+	for _, entry := range all {
+		if (flags.turn == 0 || entry.Turn == flags.turn) && (flags.step == "" || entry.Step == flags.step) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if flags.show > 0 {
+		if flags.show > len(entries) {
+			return fmt.Errorf("no archived prompt #%d for SBI %s (%d listed)", flags.show, sbiID, len(entries))
+		}
+		entries = entries[flags.show-1 : flags.show]
+	}
+	if flags.jsonOut {
+		if entries == nil {
+			entries = []*repository.PromptArchiveEntry{}
+		}
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal json: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	if flags.show > 0 {
+		printArchivedPrompt(entries[0])
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No archived prompts for SBI %s\n", sbiID)
+		return nil
+	}
+	fmt.Printf("Archived prompts for %s:\n\n", sbiID)
+	for i, entry := range entries {
+		fmt.Printf("  #%-3d %s  turn %-3d %-10s %s  prompt %s, answer %s%s\n",
+			i+1, entry.ArchivedAt.Local().Format("2006-01-02 15:04:05"), entry.Turn, entry.Step,
+			describeArchivedAgent(entry), formatBytes(len(entry.Prompt)), formatBytes(len(entry.Response)), archivedNotes(entry))
+	}
+	fmt.Printf("\nShow one with: deespec sbi prompts %s --show <n>\n", sbiID)
+	return nil
+}
+
+// printArchivedPrompt prints the exact prompt and answer of an archived entry
+func printArchivedPrompt(entry *repository.PromptArchiveEntry) {
+	fmt.Printf("SBI %s, turn %d, %s step, %s%s\n", entry.SBIID, entry.Turn, entry.Step, describeArchivedAgent(entry), archivedNotes(entry))
+	fmt.Printf("Archived at %s\n", entry.ArchivedAt.Local().Format("2006-01-02 15:04:05"))
+	if entry.Error != "" {
+		fmt.Printf("Error: %s\n", entry.Error)
+	}
+	fmt.Printf("\n===== Prompt =====\n\n%s\n", entry.Prompt)
+	fmt.Printf("\n===== Answer =====\n\n%s\n", entry.Response)
+}
+
+// describeArchivedAgent renders the agent and model of an entry, e.g. "claude-code (claude-opus-4)"
+func describeArchivedAgent(entry *repository.PromptArchiveEntry) string {
+	agent := entry.AgentType
+	if entry.Model != "" {
+		agent += " (" + entry.Model + ")"
+	}
+	if reviewer := entry.Context["reviewer"]; reviewer != "" {
+		agent += " as reviewer " + reviewer
+	}
+	return agent
+}
+
+// archivedNotes flags cached and failed entries
+func archivedNotes(entry *repository.PromptArchiveEntry) string {
+	notes := ""
+	if entry.Cached {
+		notes += " [cached]"
+	}
+	if entry.Error != "" {
+		notes += " [failed]"
+	}
+	return notes
+}
+
+// formatBytes renders a size in B or KB
+func formatBytes(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
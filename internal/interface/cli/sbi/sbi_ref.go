@@ -0,0 +1,169 @@
+package sbi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// NewSBIRefCommand creates the sbi ref command with its subcommands
+func NewSBIRefCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ref",
+		Short: "Give an SBI the artifacts of other SBIs as context",
+		Long: `Declare that an SBI builds upon other SBIs, e.g. the design doc written by an
+earlier SBI.
+
+The final artifacts of each referenced SBI (its done report and newest implement
+report) are added to the implement and review prompts of the referencing SBI.
+Artifacts larger than 16 KB are summarized to their headings and the first
+paragraph of each section, with their path for the agent to read the rest.
+
+Unlike --depends-on, a reference does not hold the SBI back: artifacts that do
+not exist yet are simply left out.`,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+	}
+
+	cmd.AddCommand(newSBIRefAddCommand())
+	cmd.AddCommand(newSBIRefListCommand())
+	cmd.AddCommand(newSBIRefRemoveCommand())
+
+	return cmd
+}
+
+func newSBIRefAddCommand() *cobra.Command {
+	var note string
+
+	cmd := &cobra.Command{
+		Use:   "add <id> <referenced-id>...",
+		Short: "Reference other SBIs from an SBI",
+		Example: `  # The implementation builds upon the design doc of an earlier SBI
+  deespec sbi ref add 010b1f9c 01a2c3d4 --note "builds upon the payment API design doc"`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIRefAdd(cmd.Context(), args[0], args[1:], note)
+		},
+	}
+
+	cmd.Flags().StringVar(&note, "note", "", "Why the artifacts are relevant (shown to the agent)")
+
+	return cmd
+}
+
+func newSBIRefListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "list <id>",
+		Short:             "List the SBIs an SBI references and their artifacts",
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIRefList(cmd.Context(), args[0])
+		},
+	}
+}
+
+func newSBIRefRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm <id> <referenced-id>",
+		Aliases:           []string{"remove"},
+		Short:             "Remove a reference from an SBI",
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIRefRemove(cmd.Context(), args[0], args[1])
+		},
+	}
+}
+
+// runSBIRefAdd executes the sbi ref add command
+func runSBIRefAdd(ctx context.Context, sbiID string, referencedIDs []string, note string) error {
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	for _, referencedID := range referencedIDs {
+		referenced, err := container.GetSBIRepository().Find(ctx, repository.SBIID(referencedID))
+		if err != nil {
+			return fmt.Errorf("referenced SBI not found: %s (error: %w)", referencedID, err)
+		}
+		if referenced.ID().String() == id {
+			return errors.New("an SBI cannot reference itself")
+		}
+
+		ref := &repository.SBIReference{SBIID: id, ReferencedSBIID: referenced.ID().String(), Note: note}
+		if err := container.GetSBIReferenceRepository().Add(ctx, ref); err != nil {
+			return err
+		}
+		fmt.Printf("✓ SBI %s now references %s (%s)\n", id, ref.ReferencedSBIID, referenced.Title())
+	}
+	return nil
+}
+
+// runSBIRefList executes the sbi ref list command
+func runSBIRefList(ctx context.Context, sbiID string) error {
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	refs, err := container.GetSBIReferenceRepository().FindBySBIID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		fmt.Printf("SBI %s references no other SBIs\n", id)
+		return nil
+	}
+
+	builder := service.NewReferenceContextBuilder(container.GetSBIReferenceRepository(), container.GetSBIRepository(), common.ProjectRoot())
+	fmt.Printf("SBIs referenced by %s:\n\n", id)
+	for _, ref := range refs {
+		title, status := "(not found)", ""
+		if referenced, err := container.GetSBIRepository().Find(ctx, repository.SBIID(ref.ReferencedSBIID)); err == nil {
+			title, status = referenced.Title(), string(referenced.Status())
+		}
+		fmt.Printf("  %s  %-12s  %s\n", ref.ReferencedSBIID, status, title)
+		if ref.Note != "" {
+			fmt.Printf("      %s\n", ref.Note)
+		}
+		artifacts := builder.Artifacts(ref.ReferencedSBIID)
+		if len(artifacts) == 0 {
+			fmt.Printf("      (no artifacts yet)\n")
+		}
+		for _, artifact := range artifacts {
+			fmt.Printf("      📄 %s (%d bytes)\n", artifact.Path, len(artifact.Content))
+		}
+	}
+	return nil
+}
+
+// runSBIRefRemove executes the sbi ref rm command
+func runSBIRefRemove(ctx context.Context, sbiID, referencedID string) error {
+	container, id, err := openSBIForNotes(ctx, sbiID)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	// The referenced SBI may be given by a prefix like the referencing one
+	if referenced, err := container.GetSBIRepository().Find(ctx, repository.SBIID(referencedID)); err == nil {
+		referencedID = referenced.ID().String()
+	}
+	if err := container.GetSBIReferenceRepository().Delete(ctx, id, referencedID); err != nil {
+		return err
+	}
+	fmt.Printf("✓ SBI %s no longer references %s\n", id, referencedID)
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +28,9 @@ type sbiRegisterFlags struct {
 	labelArray    []string // Multiple --label flags
 	dependsOn     []string // SBI IDs that this SBI depends on
 	onlyImplement bool     // If true, skip review cycle (implementation-only)
+	deadline      string   // Optional deadline (YYYY-MM-DD, "YYYY-MM-DD HH:MM" or RFC3339)
+	env           []string // Environment variables for agent steps (KEY=VALUE)
+	force         bool     // Register even when a near-duplicate SBI exists
 	jsonOut       bool
 	dryRun        bool
 	quiet         bool
@@ -44,6 +48,22 @@ func NewSBIRegisterCommand() *cobra.Command {
 The command generates a unique SBI-ID using ULID and creates a spec.md file
 with guidelines and the provided content.
 
+The title and body are compared with existing SBIs (cancelled ones aside).
+Near-duplicates are listed as a warning, or refuse the registration when
+duplicate_check.mode is "block" in setting.json; --force registers anyway.
+
+The body may start with a YAML front matter setting the SBI's metadata
+(parent_pbi, labels, priority, estimated_hours, depends_on, agent); flags win
+over its values and labels and dependencies are merged:
+
+  ---
+  parent_pbi: PBI-001
+  labels: [backend, api]
+  priority: 1
+  estimated_hours: 3
+  depends_on: [SBI-01HX0000000000000000000000]
+  ---
+
 Examples:
   # Register with title and body from command line
   deespec sbi register --title "User Authentication" --body "Implementation details..."
@@ -57,9 +77,18 @@ Examples:
   # Short form with -f flag
   deespec sbi register --title "New Feature" -f spec.md --parent-pbi PBI-001 --only-implement
 
+  # Register with a deadline (end of day in local time)
+  deespec sbi register --title "Release Notes" --body "Details..." --deadline 2025-10-31
+
+  # Export non-secret environment variables to the agent (${name} expands task_env.vars)
+  deespec sbi register --title "Checkout API" --body "Details..." --env TARGET_SERVICE=checkout --env API_BASE='${staging_url}'
+
   # Register with title and body from stdin
   echo "Implementation details..." | deespec sbi register --title "User Authentication"
 
+  # Register although a similar SBI exists
+  deespec sbi register --title "Fix flaky payment test" --body "Details..." --force
+
   # Dry run to see what would be created
   deespec sbi register --title "Test Spec" --body "Content" --dry-run --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,6 +105,9 @@ Examples:
 	cmd.Flags().StringSliceVar(&flags.labelArray, "label", []string{}, "Label for the specification (can be specified multiple times)")
 	cmd.Flags().StringSliceVar(&flags.dependsOn, "depends-on", []string{}, "SBI IDs that must be completed before this SBI (can be specified multiple times)")
 	cmd.Flags().BoolVar(&flags.onlyImplement, "only-implement", false, "Skip review cycle and go directly to DONE after implementation")
+	cmd.Flags().StringVar(&flags.deadline, "deadline", "", "Deadline (YYYY-MM-DD, \"YYYY-MM-DD HH:MM\" or RFC3339)")
+	cmd.Flags().StringArrayVar(&flags.env, "env", []string{}, "Non-secret environment variable for agent steps, KEY=VALUE (can be specified multiple times)")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Register even when a similar SBI already exists")
 	cmd.Flags().BoolVar(&flags.jsonOut, "json", false, "Output result in JSON format")
 	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Simulate registration without creating files")
 	cmd.Flags().BoolVar(&flags.quiet, "quiet", false, "Suppress non-error output")
@@ -140,7 +172,7 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 
 	// If --from-file is specified, read from file
 	if body == "" && flags.fromFile != "" {
-		data, err := os.ReadFile(flags.fromFile)
+		data, err := os.ReadFile(common.ResolveUserPath(flags.fromFile))
 		if err != nil {
 			return fmt.Errorf("failed to read from file '%s': %w", flags.fromFile, err)
 		}
@@ -156,8 +188,46 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 		body = string(data)
 	}
 
+	// Split off the YAML front matter; flags win over its values
+	front, body, err := domainservice.ParseSpecFrontMatter(body)
+	if err != nil {
+		return err
+	}
+
 	// Process labels
 	labels := processLabels(flags.labelArray, flags.labels)
+	parentPBI, dependsOn := flags.parentPBI, flags.dependsOn
+	var (
+		priority       int
+		estimatedHours float64
+		agent          string
+	)
+	if front != nil {
+		labels = processLabels(append(labels, front.Labels...), "")
+		dependsOn = processLabels(append(append([]string{}, dependsOn...), front.DependsOn...), "")
+		if parentPBI == "" {
+			parentPBI = front.ParentPBI
+		}
+		if front.Priority != nil {
+			priority = *front.Priority
+		}
+		if front.EstimatedHours != nil {
+			estimatedHours = *front.EstimatedHours
+		}
+		agent = front.Agent
+	}
+
+	// Parse deadline
+	deadline, err := common.ParseDeadline(flags.deadline)
+	if err != nil {
+		return err
+	}
+
+	// Parse env
+	env, err := common.ParseEnvAssignments(flags.env)
+	if err != nil {
+		return err
+	}
 
 	// For dry-run, simulate without creating actual SBI
 	if flags.dryRun {
@@ -166,12 +236,14 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 				ID:    "SBI-DRYRUN-EXAMPLE",
 				Title: flags.title,
 			},
-			Labels: labels,
+			Labels:   labels,
+			Deadline: deadline,
+			Env:      env,
 		}
 		specPath := filepath.Join(".deespec", "specs", "sbi", sbiDTO.ID, "spec.md")
 
 		if flags.jsonOut {
-			return outputJSONNew(sbiDTO, specPath, false)
+			return outputJSONNew(sbiDTO, specPath, false, nil)
 		}
 
 		if !flags.quiet {
@@ -192,23 +264,38 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 	}
 	defer container.Close()
 
+	// Look for near-duplicates of the SBI
+	duplicateCheck := common.NewDuplicateCheck()
+	duplicates, err := duplicateCheck.CheckSBI(ctx, container.GetSBIRepository(), flags.title, body)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate SBIs: %w", err)
+	}
+	if err := common.ReportDuplicates(os.Stderr, duplicateCheck, "SBI", duplicates, flags.force); err != nil {
+		return err
+	}
+
 	// Get Task UseCase
 	taskUseCase := container.GetTaskUseCase()
 
 	// Prepare parent PBI ID if provided
 	var parentPBIID *string
-	if flags.parentPBI != "" {
-		parentPBIID = &flags.parentPBI
+	if parentPBI != "" {
+		parentPBIID = &parentPBI
 	}
 
 	// Create SBI request
 	req := dto.CreateSBIRequest{
-		Title:         flags.title,
-		Description:   body,
-		ParentPBIID:   parentPBIID,
-		Labels:        labels,
-		DependsOn:     flags.dependsOn,
-		OnlyImplement: flags.onlyImplement,
+		Title:          flags.title,
+		Description:    body,
+		ParentPBIID:    parentPBIID,
+		EstimatedHours: estimatedHours,
+		Priority:       priority,
+		Labels:         labels,
+		AssignedAgent:  agent,
+		DependsOn:      dependsOn,
+		OnlyImplement:  flags.onlyImplement,
+		Deadline:       deadline,
+		Env:            env,
 	}
 
 	// Execute the use case
@@ -217,8 +304,17 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 		return fmt.Errorf("failed to register SBI: %w", err)
 	}
 
-	// Build spec markdown content
+	// Build spec markdown content, keeping the registered metadata in its front matter
 	specContent := buildSpecMarkdown(flags.title, body)
+	if front != nil {
+		front.ParentPBI, front.Labels, front.DependsOn = parentPBI, labels, dependsOn
+		front.Sequence = sbiDTO.Sequence
+		rendered, err := front.Render()
+		if err != nil {
+			return err
+		}
+		specContent = rendered + specContent
+	}
 
 	// Save spec.md to .deespec/specs/sbi/<ID>/spec.md (for backward compatibility)
 	specDir := filepath.Join(".deespec", "specs", "sbi", sbiDTO.ID)
@@ -236,7 +332,7 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 
 	// Output the result
 	if flags.jsonOut {
-		return outputJSONNew(sbiDTO, specPath, true)
+		return outputJSONNew(sbiDTO, specPath, true, duplicates)
 	}
 
 	if !flags.quiet {
@@ -249,13 +345,19 @@ func runSBIRegister(ctx context.Context, flags *sbiRegisterFlags) error {
 		if len(labels) > 0 {
 			fmt.Printf("Labels: %v\n", labels)
 		}
+		if deadline != nil {
+			fmt.Printf("Deadline: %s\n", deadline.Format("2006-01-02 15:04"))
+		}
+		if len(env) > 0 {
+			fmt.Printf("Env: %v\n", common.FormatEnv(env))
+		}
 	}
 
 	return nil
 }
 
 // outputJSONNew outputs the result in JSON format using new implementation
-func outputJSONNew(sbiDTO *dto.SBIDTO, specPath string, created bool) error {
+func outputJSONNew(sbiDTO *dto.SBIDTO, specPath string, created bool, duplicates []domainservice.DuplicateMatch) error {
 	result := map[string]interface{}{
 		"ok":        true,
 		"id":        sbiDTO.ID,
@@ -268,6 +370,15 @@ func outputJSONNew(sbiDTO *dto.SBIDTO, specPath string, created bool) error {
 		result["labels"] = sbiDTO.Labels
 	}
 
+	// Add near-duplicates registered anyway
+	if len(duplicates) > 0 {
+		similar := make([]map[string]interface{}, 0, len(duplicates))
+		for _, m := range duplicates {
+			similar = append(similar, map[string]interface{}{"id": m.ID, "title": m.Title, "score": m.Score})
+		}
+		result["duplicates"] = similar
+	}
+
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(result); err != nil {
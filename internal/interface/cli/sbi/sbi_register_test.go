@@ -26,7 +26,7 @@ func TestNewSBIRegisterCommand(t *testing.T) {
 	}
 
 	// Test optional flags
-	flags := []string{"body", "json", "dry-run", "quiet", "label", "labels"}
+	flags := []string{"body", "json", "dry-run", "quiet", "label", "labels", "force"}
 	for _, flagName := range flags {
 		flag := cmd.Flag(flagName)
 		if flag == nil {
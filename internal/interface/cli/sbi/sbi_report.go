@@ -9,8 +9,11 @@ import (
 	"strings"
 
 	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence/sqlite"
 	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -52,8 +55,13 @@ Example for review:
 
   - All tests passing
   - Code quality: Good
-  EOF`,
-		Args: cobra.ExactArgs(1),
+  EOF
+
+In review quorum mode each reviewer agent runs with DEESPEC_REVIEWER set; its review is
+then recorded as a vote (review_<turn>_<reviewer>.md) and the workflow aggregates the
+votes of all reviewers into the turn's decision.`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			sbiID := args[0]
 
@@ -106,7 +114,7 @@ Example for review:
 			}
 
 			// Initialize repository
-			db, err := sql.Open("sqlite3", ".deespec/deespec.db")
+			db, err := sql.Open("sqlite3", sqlite.DSN(".deespec/deespec.db"))
 			if err != nil {
 				return fmt.Errorf("failed to open database: %w", err)
 			}
@@ -119,14 +127,40 @@ Example for review:
 			}
 
 			sbiRepo := sqlite.NewSBIRepository(db)
-			journalRepo := infrarepo.NewJournalRepositoryImpl(".deespec/journal.ndjson")
+			journalRepo, closeJournalSinks := common.WithJournalSinks(infrarepo.NewJournalRepositoryImpl(".deespec/journal.ndjson"), db)
+			defer closeJournalSinks()
 			execLogRepo := sqlite.NewSBIExecLogRepository(db)
+			feedbackRepo := sqlite.NewReviewFeedbackRepository(db)
+			criteriaRepo := sqlite.NewAcceptanceCriteriaRepository(db)
+
+			// Create artifact store (local by default, s3/gcs via setting.json)
+			artifactStore, err := common.NewArtifactStore()
+			if err != nil {
+				return fmt.Errorf("failed to create artifact store: %w", err)
+			}
 
 			// Create use case
-			reportUseCase := usecase.NewReportSBIUseCase(sbiRepo, journalRepo, execLogRepo)
+			reportUseCase := usecase.NewReportSBIUseCase(sbiRepo, journalRepo, execLogRepo, feedbackRepo, criteriaRepo, artifactStore)
+			reportUseCase.SetReviewIssueRepository(sqlite.NewReviewIssueRepository(db))
 
 			// Execute report submission
+			// When run by an agent inside a step, the runner's lock fence is inherited via env;
+			// writes are then rejected once another runner has taken over the SBI
 			ctx := context.Background()
+			fence, fenced, err := lock.FenceFromEnv(os.Getenv)
+			if err != nil {
+				return err
+			}
+			if fenced {
+				ctx = lock.WithFence(ctx, fence)
+			}
+			if reviewer := os.Getenv(domainservice.ReviewerEnvKey); reviewer != "" && step == "review" {
+				reportUseCase.SetReviewVoteRepository(sqlite.NewReviewVoteRepository(db))
+				if err := reportUseCase.SubmitReviewVote(ctx, sbiID, turn, reviewer, decision, content); err != nil {
+					return fmt.Errorf("failed to submit review vote: %w", err)
+				}
+				return nil
+			}
 			if err := reportUseCase.Execute(ctx, sbiID, turn, step, decision, content); err != nil {
 				return fmt.Errorf("failed to submit report: %w", err)
 			}
@@ -40,7 +40,8 @@ Examples:
 
   # Force reset without confirmation
   deespec sbi reset 010b1f9c --to-status pending --force`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSBIReset(cmd.Context(), args[0], flags)
 		},
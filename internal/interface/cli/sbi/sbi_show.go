@@ -2,11 +2,16 @@ package sbi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +32,7 @@ func NewSBIShowCommand() *cobra.Command {
 		Long: `Display detailed information about a specific SBI task.
 
 Shows all metadata including status, priority, sequence, timestamps, labels, execution state, and work history.
+The provenance section lists which agent, model and agent version produced each report and decision.
 
 Examples:
   # Show SBI details with work history
@@ -38,7 +44,8 @@ Examples:
 
   # Show in JSON format
   deespec sbi show 010b1f9c --json`,
-		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSBIShow(cmd.Context(), args[0], flags)
 		},
@@ -67,6 +74,11 @@ func runSBIShow(ctx context.Context, sbiID string, flags *sbiShowFlags) error {
 	// Find SBI by ID
 	sbiEntity, err := sbiRepo.Find(ctx, repository.SBIID(sbiID))
 	if err != nil {
+		// Archived SBIs are only reachable through their tombstone
+		if tombstone, findErr := container.GetSBITombstoneRepository().Find(ctx, repository.SBIID(sbiID)); findErr == nil {
+			return fmt.Errorf("SBI %s was archived on %s (%s); restore it with 'deespec archive restore %s'",
+				sbiID, tombstone.ArchivedAt.Local().Format("2006-01-02"), tombstone.ArchivePath, sbiID)
+		}
 		return fmt.Errorf("SBI not found: %s (error: %w)", sbiID, err)
 	}
 
@@ -81,16 +93,32 @@ func runSBIShow(ctx context.Context, sbiID string, flags *sbiShowFlags) error {
 		return outputTurnReport(sbiID, flags.turn, execLogs)
 	}
 
+	notes, err := container.GetSBINoteRepository().FindBySBIID(ctx, sbiEntity.ID().String())
+	if err != nil {
+		return fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	provenance, err := container.GetArtifactProvenanceRepository().FindBySBIID(ctx, sbiEntity.ID().String())
+	if err != nil {
+		return fmt.Errorf("failed to get artifact provenance: %w", err)
+	}
+
+	// Spec warnings are reported until the SBI starts its first turn
+	var specWarnings []domainservice.SpecQualityWarning
+	if sbiEntity.Status() == model.StatusPending {
+		specWarnings = common.NewSpecPreflight().Check(sbiEntity)
+	}
+
 	// Output results
 	if flags.jsonOut {
-		return outputJSONShow(sbiEntity)
+		return outputJSONShow(sbiEntity, specWarnings, notes, provenance)
 	}
 
-	return outputDetailShow(sbiEntity, execLogs)
+	return outputDetailShow(sbiEntity, execLogs, specWarnings, notes, provenance)
 }
 
 // outputDetailShow outputs SBI details in human-readable format
-func outputDetailShow(s *sbi.SBI, execLogs []*repository.SBIExecLog) error {
+func outputDetailShow(s *sbi.SBI, execLogs []*repository.SBIExecLog, specWarnings []domainservice.SpecQualityWarning, notes []*repository.SBINote, provenance []*repository.ArtifactProvenance) error {
 	metadata := s.Metadata()
 	execState := s.ExecutionState()
 
@@ -98,7 +126,7 @@ func outputDetailShow(s *sbi.SBI, execLogs []*repository.SBIExecLog) error {
 	fmt.Printf("=============\n\n")
 	fmt.Printf("ID:              %s\n", s.ID().String())
 	fmt.Printf("Title:           %s\n", s.Title())
-	fmt.Printf("Status:          %s\n", s.Status())
+	fmt.Printf("Status:          %s\n", common.NewStatusLabels().Display(s.Status()))
 	fmt.Printf("Current Step:    %s\n", s.CurrentStep())
 	fmt.Printf("Priority:        %d\n", metadata.Priority)
 	fmt.Printf("Sequence:        %d\n", metadata.Sequence)
@@ -112,6 +140,16 @@ func outputDetailShow(s *sbi.SBI, execLogs []*repository.SBIExecLog) error {
 	if metadata.AssignedAgent != "" {
 		fmt.Printf("Assigned Agent:  %s\n", metadata.AssignedAgent)
 	}
+	if metadata.Deadline != nil {
+		overdue := ""
+		if s.IsOverdue(time.Now()) {
+			overdue = " (OVERDUE)"
+		}
+		fmt.Printf("Deadline:        %s%s\n", metadata.Deadline.Local().Format("2006-01-02 15:04:05"), overdue)
+	}
+	if len(metadata.Env) > 0 {
+		fmt.Printf("Env:             %v\n", common.FormatEnv(metadata.Env))
+	}
 
 	fmt.Printf("\nExecution State:\n")
 	fmt.Printf("  Current Turn:    %d\n", execState.CurrentTurn.Value())
@@ -122,6 +160,34 @@ func outputDetailShow(s *sbi.SBI, execLogs []*repository.SBIExecLog) error {
 	if execState.LastError != "" {
 		fmt.Printf("  Last Error:      %s\n", execState.LastError)
 	}
+	if s.Status() == model.StatusFailed {
+		postmortemPath := fmt.Sprintf(".deespec/reports/sbi/%s/postmortem.md", s.ID().String())
+		if _, err := os.Stat(postmortemPath); err == nil {
+			fmt.Printf("  Postmortem:      %s\n", postmortemPath)
+		}
+	}
+
+	if len(specWarnings) > 0 {
+		fmt.Printf("\nSpec Warnings:\n")
+		for _, warning := range specWarnings {
+			location := ""
+			if warning.Line > 0 {
+				location = fmt.Sprintf(" (line %d)", warning.Line)
+			}
+			fmt.Printf("  ⚠️  [%s]%s %s\n", warning.Check, location, warning.Message)
+		}
+		fmt.Printf("  Resolve them in .deespec/specs/sbi/%s/spec.md before the first turn\n", s.ID().String())
+	}
+
+	if len(notes) > 0 {
+		fmt.Printf("\nNotes:\n")
+		printSBINotes(notes)
+	}
+
+	if len(provenance) > 0 {
+		fmt.Printf("\nProvenance:\n")
+		printArtifactProvenance(provenance)
+	}
 
 	// Display work history if available
 	if len(execLogs) > 0 {
@@ -166,6 +232,60 @@ func outputDetailShow(s *sbi.SBI, execLogs []*repository.SBIExecLog) error {
 	return nil
 }
 
+// printArtifactProvenance prints which agent, model and version produced each artifact
+func printArtifactProvenance(provenance []*repository.ArtifactProvenance) {
+	for _, record := range provenance {
+		step := strings.ToUpper(record.Step)
+		if record.Reviewer != "" {
+			step += "/" + record.Reviewer
+		}
+		model := record.Model
+		if model == "" {
+			model = "(agent default)"
+		}
+		line := fmt.Sprintf("  Turn %d %-10s %s · %s", record.Turn, step+":", record.AgentType, model)
+		if record.AgentVersion != "" {
+			line += " · " + record.AgentVersion
+		}
+		if record.Decision != "" {
+			line += fmt.Sprintf(" (%s)", record.Decision)
+		}
+		fmt.Printf("%s\n      → %s\n", line, record.ArtifactPath)
+	}
+}
+
+// artifactProvenanceView is the JSON form of an artifact's provenance
+type artifactProvenanceView struct {
+	Turn         int       `json:"turn"`
+	Step         string    `json:"step"`
+	ArtifactPath string    `json:"artifact_path"`
+	Decision     string    `json:"decision,omitempty"`
+	AgentType    string    `json:"agent_type"`
+	Model        string    `json:"model"`
+	AgentVersion string    `json:"agent_version"`
+	Reviewer     string    `json:"reviewer,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// newArtifactProvenanceViews converts provenance records to their JSON form
+func newArtifactProvenanceViews(provenance []*repository.ArtifactProvenance) []artifactProvenanceView {
+	views := make([]artifactProvenanceView, 0, len(provenance))
+	for _, record := range provenance {
+		views = append(views, artifactProvenanceView{
+			Turn:         record.Turn,
+			Step:         record.Step,
+			ArtifactPath: record.ArtifactPath,
+			Decision:     record.Decision,
+			AgentType:    record.AgentType,
+			Model:        record.Model,
+			AgentVersion: record.AgentVersion,
+			Reviewer:     record.Reviewer,
+			CreatedAt:    record.CreatedAt,
+		})
+	}
+	return views
+}
+
 // outputTurnReport outputs a specific turn's report
 func outputTurnReport(sbiID string, turn int, execLogs []*repository.SBIExecLog) error {
 	// Find logs for the specified turn
@@ -180,6 +300,12 @@ func outputTurnReport(sbiID string, turn int, execLogs []*repository.SBIExecLog)
 		return fmt.Errorf("no reports found for turn %d", turn)
 	}
 
+	// Reports may be stored as local paths or object storage URIs
+	artifactStore, err := common.NewArtifactStore()
+	if err != nil {
+		return fmt.Errorf("failed to create artifact store: %w", err)
+	}
+
 	fmt.Printf("SBI Turn %d Report\n", turn)
 	fmt.Printf("==================\n\n")
 	fmt.Printf("SBI ID: %s\n\n", sbiID)
@@ -193,7 +319,7 @@ func outputTurnReport(sbiID string, turn int, execLogs []*repository.SBIExecLog)
 		fmt.Printf("\n")
 
 		// Read and display report content
-		content, err := os.ReadFile(log.ReportPath)
+		content, err := artifactStore.Get(context.Background(), log.ReportPath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "⚠️  WARNING: Failed to read report file: %s\n", log.ReportPath)
 			fmt.Fprintf(os.Stderr, "   Error: %v\n\n", err)
@@ -212,14 +338,31 @@ func outputTurnReport(sbiID string, turn int, execLogs []*repository.SBIExecLog)
 }
 
 // outputJSONShow outputs SBI details in JSON format
-func outputJSONShow(s *sbi.SBI) error {
+func outputJSONShow(s *sbi.SBI, specWarnings []domainservice.SpecQualityWarning, notes []*repository.SBINote, provenance []*repository.ArtifactProvenance) error {
 	metadata := s.Metadata()
 	execState := s.ExecutionState()
 
+	if specWarnings == nil {
+		specWarnings = []domainservice.SpecQualityWarning{}
+	}
+	warningsJSON, err := json.Marshal(specWarnings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec warnings: %w", err)
+	}
+	notesJSON, err := json.Marshal(newSBINoteViews(notes))
+	if err != nil {
+		return fmt.Errorf("failed to marshal notes: %w", err)
+	}
+	provenanceJSON, err := json.Marshal(newArtifactProvenanceViews(provenance))
+	if err != nil {
+		return fmt.Errorf("failed to marshal artifact provenance: %w", err)
+	}
+
 	fmt.Printf(`{
   "id": "%s",
   "title": "%s",
   "status": "%s",
+  "status_label": "%s",
   "current_step": "%s",
   "priority": %d,
   "sequence": %d,
@@ -234,12 +377,16 @@ func outputJSONShow(s *sbi.SBI) error {
     "max_turns": %d,
     "max_attempts": %d,
     "last_error": "%s"
-  }
+  },
+  "spec_warnings": %s,
+  "notes": %s,
+  "provenance": %s
 }
 `,
 		s.ID().String(),
 		s.Title(),
 		s.Status(),
+		common.NewStatusLabels().Label(s.Status()),
 		s.CurrentStep(),
 		metadata.Priority,
 		metadata.Sequence,
@@ -253,6 +400,9 @@ func outputJSONShow(s *sbi.SBI) error {
 		execState.MaxTurns,
 		execState.MaxAttempts,
 		execState.LastError,
+		warningsJSON,
+		notesJSON,
+		provenanceJSON,
 	)
 	return nil
 }
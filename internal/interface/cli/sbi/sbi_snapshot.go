@@ -0,0 +1,148 @@
+package sbi
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewSBISnapshotCommand creates the sbi snapshot command
+func NewSBISnapshotCommand() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot <id>",
+		Short: "Bundle the full state of an SBI into an archive",
+		Long: `Bundle the full state of an SBI into a tar.gz archive.
+
+The archive contains the SBI's database rows (including execution state,
+dependencies, execution logs, review feedback, acceptance criteria and notes),
+its journal entries, and its spec and report directories. Restore it on
+another machine with 'deespec sbi restore' to reproduce agent failures.
+
+Examples:
+  # Write sbi-<id>.tar.gz in the current directory
+  deespec sbi snapshot 01K7P4N123EQAB57FA5E5ZG6A3
+
+  # Choose the archive path
+  deespec sbi snapshot 01K7P4N123EQAB57FA5E5ZG6A3 -o /tmp/flaky-login.tar.gz`,
+		ValidArgsFunction: common.CompleteSBIIDs,
+		Args:              cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBISnapshot(cmd.Context(), args[0], outputPath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Archive path (default: sbi-<id>.tar.gz)")
+
+	return cmd
+}
+
+// NewSBIRestoreCommand creates the sbi restore command
+func NewSBIRestoreCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore an SBI from a snapshot archive",
+		Long: `Restore an SBI from an archive written by 'deespec sbi snapshot'.
+
+Database rows, journal entries, and spec and report files are restored.
+A parent PBI or dependency that does not exist in this database is dropped.
+An existing SBI with the same ID is only replaced with --force.
+
+Examples:
+  deespec sbi restore sbi-01K7P4N123EQAB57FA5E5ZG6A3.tar.gz
+
+  # Replace the SBI if it already exists
+  deespec sbi restore sbi-01K7P4N123EQAB57FA5E5ZG6A3.tar.gz --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSBIRestore(cmd.Context(), common.ResolveUserPath(args[0]), force)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace the SBI if it already exists")
+
+	return cmd
+}
+
+// newSnapshotUseCase builds the snapshot use case from the container and configured paths
+func newSnapshotUseCase() (*usecase.SnapshotSBIUseCase, func(), error) {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize container: %w", err)
+	}
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	uc := usecase.NewSnapshotSBIUseCase(
+		container.GetSBISnapshotRepository(),
+		infrarepo.NewJournalRepositoryImpl(paths.Journal),
+		paths.Home,
+	)
+	return uc, func() { container.Close() }, nil
+}
+
+// runSBISnapshot executes the sbi snapshot command
+func runSBISnapshot(ctx context.Context, sbiID string, outputPath string) error {
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("sbi-%s.tar.gz", sbiID)
+	}
+	outputPath = common.ResolveUserPath(outputPath)
+
+	uc, closeContainer, err := newSnapshotUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeContainer()
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	manifest, err := uc.Snapshot(ctx, sbiID, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write archive: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(outputPath)
+		return err
+	}
+
+	fmt.Printf("✓ Snapshot of SBI %s written to %s\n", sbiID, outputPath)
+	fmt.Printf("  Journal entries: %d\n", manifest.JournalRecords)
+	fmt.Printf("  Files:           %d\n", len(manifest.Files))
+	return nil
+}
+
+// runSBIRestore executes the sbi restore command
+func runSBIRestore(ctx context.Context, archivePath string, force bool) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	uc, closeContainer, err := newSnapshotUseCase()
+	if err != nil {
+		return err
+	}
+	defer closeContainer()
+
+	manifest, err := uc.Restore(ctx, file, force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ SBI %s restored from %s (snapshot taken %s)\n", manifest.SBIID, archivePath, manifest.CreatedAt)
+	fmt.Printf("  Journal entries: %d\n", manifest.JournalRecords)
+	fmt.Printf("  Files:           %d\n", len(manifest.Files))
+	return nil
+}
@@ -0,0 +1,245 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/controller/grpcapi"
+	"github.com/YoshitsuguKoike/deespec/internal/adapter/controller/httpapi"
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/dto"
+	"github.com/YoshitsuguKoike/deespec/internal/application/service"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	infraRepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/run"
+)
+
+// defaultGRPCAddr is the default listen address of the gRPC API (loopback only)
+const defaultGRPCAddr = "127.0.0.1:7410"
+
+// defaultHTTPAddr is the default listen address of the HTTP event stream (loopback only)
+const defaultHTTPAddr = "127.0.0.1:7411"
+
+// NewCommand creates the serve command
+func NewCommand() *cobra.Command {
+	var grpcAddr string
+	var httpAddr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the deespec API for programmatic orchestration",
+		Long: `Serve the deespec gRPC API (package deespec.v1).
+
+Services:
+  TaskService     List and inspect EPIC/PBI/SBI tasks
+  TurnService     Run SBI turns with streaming progress updates
+  JournalService  Read the execution journal
+  LockService     List and release run/state locks
+
+Protocol definitions and Go client stubs are in
+github.com/YoshitsuguKoike/deespec/api/deespec/v1.
+
+Authentication:
+  Once an API token exists ('deespec token create'), every request must
+  carry one as "authorization: Bearer <token>" (gRPC metadata or HTTP
+  header; ?access_token=<token> for the event stream). Scopes:
+    read      List tasks, journal entries and locks; follow events
+    run-turn  Run turns
+    admin     Everything, including releasing locks
+  Turn runs, lock releases and refused requests are recorded in the audit
+  log ('deespec token audit'). Without tokens the server only listens on
+  loopback addresses.
+
+HTTP:
+  GET /events     Server-Sent Events stream of turn progress (picked,
+                  prompt-built, agent-started, agent-output-chunk,
+                  decision, saved); ?sbi_id=<id> follows a single SBI
+
+Errors:
+  gRPC errors carry an ErrorInfo detail (domain "deespec") whose reason is
+  the error code; HTTP errors are JSON: {"error": {"code": ..., "message": ...}}.
+    not_found          NOT_FOUND            404
+    invalid_state      FAILED_PRECONDITION  409
+    lock_held          FAILED_PRECONDITION  409
+    agent_unavailable  UNAVAILABLE          503
+    internal           INTERNAL             500
+
+Examples:
+  # Serve on the default loopback address
+  deespec serve
+
+  # Serve on a custom address
+  deespec serve --grpc-addr 0.0.0.0:7410
+
+  # Follow turn progress of one SBI
+  curl -N "http://127.0.0.1:7411/events?sbi_id=<id>"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(cmd.Context(), grpcAddr, httpAddr)
+		},
+	}
+
+	cmd.Flags().StringVar(&grpcAddr, "grpc-addr", defaultGRPCAddr, "Listen address of the gRPC API")
+	cmd.Flags().StringVar(&httpAddr, "http-addr", defaultHTTPAddr, "Listen address of the HTTP event stream (empty to disable)")
+
+	return cmd
+}
+
+// runServe serves the gRPC API and the HTTP event stream until SIGINT/SIGTERM
+func runServe(ctx context.Context, grpcAddr, httpAddr string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	if err := container.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	// Tokens are required as soon as one exists; without them only loopback is served
+	authorizer := container.GetAPIAuthorizer()
+	authEnabled, err := authorizer.Enabled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check API tokens: %w", err)
+	}
+	var serverOptions []grpc.ServerOption
+	if authEnabled {
+		serverOptions = grpcapi.AuthServerOptions(authorizer)
+	} else {
+		authorizer = nil
+		for _, addr := range []string{grpcAddr, httpAddr} {
+			if addr != "" && !isLoopbackAddr(addr) {
+				return fmt.Errorf("refusing to serve on %s without authentication; create an API token with 'deespec token create' first", addr)
+			}
+		}
+	}
+
+	paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+	server := grpc.NewServer(serverOptions...)
+	grpcapi.Register(server, grpcapi.Dependencies{
+		TaskUseCase: container.GetTaskUseCase(),
+		SBIRepo:     container.GetSBIRepository(),
+		JournalRepo: infraRepo.NewJournalRepositoryImpl(paths.Journal),
+		LockService: container.GetLockService(),
+		RunTurn:     newTurnRunner(container),
+	})
+
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", grpcAddr, err)
+	}
+
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- fmt.Errorf("gRPC server stopped: %w", server.Serve(listener))
+	}()
+	common.Info("gRPC API listening on %s\n", listener.Addr())
+	if authEnabled {
+		common.Info("API token authentication enabled\n")
+	}
+
+	var httpServer *http.Server
+	if httpAddr != "" {
+		httpListener, err := net.Listen("tcp", httpAddr)
+		if err != nil {
+			server.Stop()
+			return fmt.Errorf("failed to listen on %s: %w", httpAddr, err)
+		}
+		httpServer = &http.Server{Handler: httpapi.NewMux(container.GetTurnEventBroker(), authorizer)}
+		go func() {
+			serveErr <- fmt.Errorf("HTTP server stopped: %w", httpServer.Serve(httpListener))
+		}()
+		common.Info("HTTP event stream listening on http://%s/events\n", httpListener.Addr())
+	}
+
+	select {
+	case <-ctx.Done():
+		common.Info("Shutting down API servers...\n")
+		if httpServer != nil {
+			// Event streams never finish on their own, so close them instead of draining
+			_ = httpServer.Close()
+		}
+		server.GracefulStop()
+		return nil
+	case err := <-serveErr:
+		server.Stop()
+		if httpServer != nil {
+			_ = httpServer.Close()
+		}
+		return err
+	}
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local connections
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// newTurnRunner executes turns the same way as parallel workers: the SBI-specific
+// state lock guards against concurrent execution and fences the turn's writes
+func newTurnRunner(container *di.Container) grpcapi.TurnRunner {
+	return func(ctx context.Context, sbiID string, autoFB bool) (string, *dto.RunTurnOutput, error) {
+		lockService := container.GetLockService()
+
+		if sbiID == "" {
+			picker := service.NewSBIExecutionService(container.GetSBIRepository(), lockService)
+			picker.SetPriorityAging(common.NewPriorityAging())
+			next, err := picker.PickNextSBI(ctx)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to pick SBI: %w", err)
+			}
+			if next == nil {
+				return "", nil, fmt.Errorf("executable SBI %w", model.ErrNotFound)
+			}
+			sbiID = next.ID().String()
+		}
+
+		lockID, err := lock.NewLockID(fmt.Sprintf("sbi-%s", sbiID))
+		if err != nil {
+			return sbiID, nil, fmt.Errorf("failed to create lock ID: %w", err)
+		}
+		sbiLock, err := lockService.AcquireStateLock(ctx, lockID, lock.LockTypeWrite, 10*time.Minute)
+		if err != nil {
+			return sbiID, nil, fmt.Errorf("failed to acquire lock: %w", err)
+		}
+		if sbiLock == nil {
+			return sbiID, nil, fmt.Errorf("%w: SBI %s is already being executed by another worker", model.ErrLockHeld, sbiID)
+		}
+
+		ctx = lock.WithFence(ctx, sbiLock.Fence())
+		defer func() {
+			if err := lockService.ReleaseStateLock(ctx, lockID); err != nil {
+				common.Warn("failed to release lock %s: %v\n", lockID, err)
+			}
+		}()
+
+		output, err := run.ExecuteSingleSBITurn(ctx, container, sbiID, autoFB)
+		return sbiID, output, err
+	}
+}
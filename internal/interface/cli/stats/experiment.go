@@ -0,0 +1,132 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// minComparableDone is the number of done SBIs per arm below which differences are flagged as noise
+const minComparableDone = 10
+
+// newExperimentCommand creates the stats experiment command
+func newExperimentCommand() *cobra.Command {
+	var name string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "experiment",
+		Short: "Compare the arms of prompt experiments",
+		Long: `Compare the SBIs prompted with the regular template (control) against the
+SBIs prompted with the variant template (treatment) of a prompt experiment.
+
+Experiments are configured in setting.json:
+
+  "prompt_experiment": {
+    "enabled": true,
+    "name": "wip-v2",
+    "template": "WIP.md",
+    "variant": "experiments/WIP.v2.md"
+  }
+
+Each SBI is assigned to one arm by a hash of the experiment name and its ID,
+and keeps that arm across turns. For each arm the report shows:
+
+  SBIS              SBIs assigned to the arm
+  DONE / FAILED     SBIs that reached DONE / failed or were force-terminated
+  TURNS TO DONE     Mean turn at which the done SBIs reached DONE
+  REVIEW PASS RATE  Share of review decisions that were SUCCEEDED
+
+SBIs that had started before the experiment are excluded.`,
+		Example: `  # Compare the arms of every experiment in the journal
+  deespec stats experiment
+
+  # One experiment as JSON
+  deespec stats experiment --name wip-v2 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+			uc := usecase.NewPromptExperimentReportUseCase(common.NewJournalRepository(paths.Journal))
+			report, err := uc.Execute(context.Background(), usecase.PromptExperimentReportOptions{Experiment: name})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				b, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshal json: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+			printExperimentReport(report, name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Only report this experiment")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the comparison in JSON format")
+	return cmd
+}
+
+// printExperimentReport prints the arms of each experiment as a table
+func printExperimentReport(report *usecase.PromptExperimentReport, name string) {
+	if len(report.Experiments) == 0 {
+		if name != "" {
+			fmt.Printf("No journal records of prompt experiment %q\n", name)
+		} else {
+			fmt.Println("No prompt experiments in the journal")
+		}
+		return
+	}
+
+	for i, experiment := range report.Experiments {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Experiment %s (%s)\n\n", experiment.Name, experiment.Template)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ARM\tSBIS\tDONE\tFAILED\tTURNS TO DONE\tREVIEW PASS RATE")
+		fmt.Fprintln(w, "---\t----\t----\t------\t-------------\t----------------")
+		var control, treatment *usecase.PromptExperimentArm
+		for j := range experiment.Arms {
+			arm := &experiment.Arms[j]
+			switch arm.Variant {
+			case domainservice.PromptVariantControl:
+				control = arm
+			case domainservice.PromptVariantTreatment:
+				treatment = arm
+			}
+			turns, passRate := "-", "-"
+			if arm.Done > 0 {
+				turns = fmt.Sprintf("%.1f", arm.TurnsToDone)
+			}
+			if arm.Reviews > 0 {
+				passRate = fmt.Sprintf("%.0f%% (%d/%d)", 100*arm.ReviewPassRate, arm.ReviewsPassed, arm.Reviews)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", arm.Variant, arm.SBIs, arm.Done, arm.Failed, turns, passRate)
+		}
+		w.Flush()
+
+		if control != nil && treatment != nil && control.Done > 0 && treatment.Done > 0 {
+			fmt.Printf("\nTreatment vs control: turns to DONE %+.1f, review pass rate %+.0f pts\n",
+				treatment.TurnsToDone-control.TurnsToDone, 100*(treatment.ReviewPassRate-control.ReviewPassRate))
+			if control.Done < minComparableDone || treatment.Done < minComparableDone {
+				fmt.Printf("Fewer than %d done SBIs in an arm; the difference may be noise\n", minComparableDone)
+			}
+		}
+		if experiment.Excluded > 0 {
+			fmt.Printf("%d SBIs that had started before the experiment are excluded\n", experiment.Excluded)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/app"
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// maxErrorMessageLength truncates the newest error message shown per class
+const maxErrorMessageLength = 60
+
+// NewCommand creates the stats command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show statistics collected from the journal",
+	}
+	cmd.AddCommand(newErrorsCommand())
+	cmd.AddCommand(newExperimentCommand())
+	return cmd
+}
+
+// newErrorsCommand creates the stats errors command
+func newErrorsCommand() *cobra.Command {
+	var sbiID string
+	var since time.Duration
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "errors",
+		Short: "Break journaled errors down by error class",
+		Long: `Count the errors recorded in the journal by error class:
+
+  agent_timeout     The agent did not answer in time (including review timeouts)
+  agent_crash       The agent process or API call failed
+  template_error    A prompt template could not be read, parsed or executed
+  lock_lost         The runner lost its lock to another runner
+  policy_violation  A configured limit stopped the work (max turns, budgets)
+  test_failure      The implementation failed its tests
+  unknown           Errors matching no other class
+
+New journal records carry their class in the "error_class" field; older
+records are classified from their error message.`,
+		Example: `  # Error breakdown of the whole journal
+  deespec stats errors
+
+  # Errors of the last 24 hours
+  deespec stats errors --since 24h
+
+  # Errors of one SBI as JSON
+  deespec stats errors --sbi <id> --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since < 0 {
+				return fmt.Errorf("--since must not be negative, got %s", since)
+			}
+			opts := usecase.ErrorStatsOptions{SBIID: sbiID}
+			if since > 0 {
+				opts.Since = time.Now().Add(-since)
+			}
+
+			paths := app.GetPathsWithConfig(common.GetGlobalConfig())
+			uc := usecase.NewErrorStatsUseCase(common.NewJournalRepository(paths.Journal))
+			stats, err := uc.Execute(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				b, err := json.MarshalIndent(stats, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshal json: %w", err)
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+			printErrorStats(stats)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&sbiID, "sbi", "", "Only count errors of this SBI")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only count errors of this recent period (e.g. 24h)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the breakdown in JSON format")
+	return cmd
+}
+
+// printErrorStats prints the error breakdown as a table
+func printErrorStats(stats *usecase.ErrorStats) {
+	if stats.Errors == 0 {
+		fmt.Printf("No errors in %d journal records\n", stats.Records)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLASS\tCOUNT\tSHARE\tSBIS\tLAST SEEN\tLAST ERROR")
+	fmt.Fprintln(w, "-----\t-----\t-----\t----\t---------\t----------")
+	for _, class := range stats.Classes {
+		lastSeen := "-"
+		if !class.LastSeen.IsZero() {
+			lastSeen = class.LastSeen.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%d\t%s\t%s\n",
+			class.Class, class.Count, 100*float64(class.Count)/float64(stats.Errors),
+			class.SBIs, lastSeen, truncate(class.LastError))
+	}
+	w.Flush()
+	fmt.Printf("\nTotal: %d errors in %d journal records\n", stats.Errors, stats.Records)
+}
+
+// truncate shortens an error message to a single table cell
+func truncate(msg string) string {
+	msg = strings.Join(strings.Fields(msg), " ")
+	if len([]rune(msg)) <= maxErrorMessageLength {
+		return msg
+	}
+	return string([]rune(msg)[:maxErrorMessageLength-3]) + "..."
+}
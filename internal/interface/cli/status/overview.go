@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
+	"github.com/YoshitsuguKoike/deespec/internal/domain/model/pbi"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	infrarepo "github.com/YoshitsuguKoike/deespec/internal/infrastructure/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// Lifecycle order used when printing status counts; unknown statuses follow alphabetically
+var (
+	taskStatusOrder = []string{
+		string(model.StatusPending), string(model.StatusPicked), string(model.StatusImplementing),
+		string(model.StatusReviewing), string(model.StatusDone), string(model.StatusFailed), string(model.StatusCancelled),
+	}
+	pbiStatusOrder = []string{
+		string(pbi.StatusPending), string(pbi.StatusPlanning), string(pbi.StatusPlaned),
+		string(pbi.StatusInProgress), string(pbi.StatusDone), string(pbi.StatusFailed),
+	}
+)
+
+// buildOverview collects the project overview from the container's repositories
+func buildOverview(ctx context.Context, container *di.Container) (*usecase.ProjectStatus, error) {
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	uc := usecase.NewProjectStatusUseCase(
+		container.GetEPICRepository(),
+		persistence.NewPBISQLiteRepository(container.GetDB(), rootPath),
+		container.GetSBIRepository(),
+		infrarepo.NewJournalRepositoryImpl(".deespec/journal.ndjson"),
+		infrarepo.NewSBIApprovalRepositoryImpl(),
+		container.GetLockService(),
+	)
+	return uc.Execute(ctx)
+}
+
+// printOverview prints the project overview in text form
+func printOverview(o *usecase.ProjectStatus) {
+	fmt.Println("Overview")
+	fmt.Printf("  EPIC : %s\n", formatCounts(o.EPICCounts, taskStatusOrder))
+	fmt.Printf("  PBI  : %s\n", formatCounts(o.PBICounts, pbiStatusOrder))
+	fmt.Printf("  SBI  : %s\n", formatCounts(o.SBICounts, taskStatusOrder))
+	if len(o.Failed) > 0 {
+		fmt.Printf("  ⚠️  %d SBI(s) FAILED; see 'deespec sbi list --status failed'\n", len(o.Failed))
+	}
+
+	statusLabels := common.NewStatusLabels()
+	fmt.Printf("\nIn flight (%d)\n", len(o.InFlight))
+	for _, s := range o.InFlight {
+		fmt.Printf("  %s [%s] %s (turn %d, updated %s ago)\n",
+			s.ID, statusLabels.Display(model.Status(s.Status)), s.Title, s.Turn, formatAge(o.GeneratedAt.Sub(s.UpdatedAt)))
+	}
+
+	if len(o.Failed) > 0 {
+		fmt.Printf("\nFailed (%d)\n", len(o.Failed))
+		for _, s := range o.Failed {
+			fmt.Printf("  %s %s (turn %d, %s ago)", s.ID, s.Title, s.Turn, formatAge(o.GeneratedAt.Sub(s.UpdatedAt)))
+			if s.Error != "" {
+				fmt.Printf(": %s", s.Error)
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("\nLocks (%d)\n", len(o.Locks))
+	for _, l := range o.Locks {
+		expiry := "expires " + l.ExpiresAt.Local().Format(time.RFC3339)
+		if l.Expired {
+			expiry = "EXPIRED"
+		}
+		fmt.Printf("  %-5s %s  pid %d@%s  %s\n", l.Kind, l.ID, l.PID, l.Hostname, expiry)
+	}
+
+	fmt.Println()
+	if o.LastTurn != nil {
+		fmt.Printf("Last turn : %s (%s ago) %s turn %d %s",
+			o.LastTurn.Timestamp.Local().Format(time.RFC3339), formatAge(o.GeneratedAt.Sub(o.LastTurn.Timestamp)),
+			o.LastTurn.SBIID, o.LastTurn.Turn, o.LastTurn.Step)
+		if o.LastTurn.Decision != "" {
+			fmt.Printf(" (%s)", o.LastTurn.Decision)
+		}
+		fmt.Println()
+	} else {
+		fmt.Println("Last turn : none")
+	}
+
+	fmt.Printf("Journal errors (24h) : %d\n", o.JournalIssues)
+	for _, issue := range o.RecentIssues {
+		fmt.Printf("  %s %s turn %d %s: %s\n",
+			issue.Timestamp.Local().Format(time.RFC3339), issue.SBIID, issue.Turn, issue.Step, issue.Error)
+	}
+
+	fmt.Printf("\nPending approvals (%d)\n", len(o.PendingApprovals))
+	for _, a := range o.PendingApprovals {
+		fmt.Printf("  %s: %d pending, %d approved but not registered\n", a.PBIID, a.Pending, a.Unregistered)
+	}
+
+	for _, w := range o.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: could not collect %s\n", w)
+	}
+}
+
+// formatCounts renders status counts like "2 PENDING, 1 DONE" in lifecycle order
+func formatCounts(counts map[string]int, order []string) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+
+	var extra []string
+	known := make(map[string]bool, len(order))
+	for _, status := range order {
+		known[status] = true
+	}
+	for status := range counts {
+		if !known[status] {
+			extra = append(extra, status)
+		}
+	}
+	sort.Strings(extra)
+
+	var parts []string
+	for _, status := range append(append([]string{}, order...), extra...) {
+		if n := counts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatAge renders a duration as a short human readable age (e.g. "5m", "3h", "2d")
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
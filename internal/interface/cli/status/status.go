@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
 	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
@@ -15,11 +16,12 @@ import (
 )
 
 type StatusOutput struct {
-	Ts    string `json:"ts"`
-	Turn  int    `json:"turn"`
-	Step  string `json:"step"`
-	Ok    bool   `json:"ok"`
-	Error string `json:"error"`
+	Ts       string                 `json:"ts"`
+	Turn     int                    `json:"turn"`
+	Step     string                 `json:"step"`
+	Ok       bool                   `json:"ok"`
+	Error    string                 `json:"error"`
+	Overview *usecase.ProjectStatus `json:"overview,omitempty"`
 }
 
 // getLastJournalError reads the last line of journal.ndjson and returns the error field
@@ -69,7 +71,12 @@ func NewCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "status",
-		Short: "Show current workflow status",
+		Short: "Show current workflow status and a project overview",
+		Long: `Show the currently executing SBI followed by a one-shot project overview:
+  - EPIC/PBI/SBI counts by status
+  - In-flight SBIs and active locks
+  - The last journaled turn and journal errors in the last 24h
+  - PBIs with generated SBIs awaiting approval or registration`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Initialize container to access DB
 			container, err := common.InitializeContainer()
@@ -150,14 +157,20 @@ func NewCommand() *cobra.Command {
 				lastError = fmt.Sprintf("journal read error: %v", err)
 			}
 
+			overview, err := buildOverview(ctx, container)
+			if err != nil {
+				return fmt.Errorf("failed to build project overview: %w", err)
+			}
+
 			if jsonOutput {
 				// JSON output mode
 				output := StatusOutput{
-					Ts:    time.Now().UTC().Format(time.RFC3339Nano),
-					Turn:  turn,
-					Step:  step,
-					Ok:    lastError == "",
-					Error: lastError,
+					Ts:       time.Now().UTC().Format(time.RFC3339Nano),
+					Turn:     turn,
+					Step:     step,
+					Ok:       lastError == "",
+					Error:    lastError,
+					Overview: overview,
 				}
 
 				b, err := json.Marshal(output)
@@ -173,6 +186,8 @@ func NewCommand() *cobra.Command {
 				fmt.Printf("Current : %s\n", step)
 				fmt.Printf("Turn    : %d\n", turn)
 				fmt.Printf("Updated : %s\n", updatedAt.Format(time.RFC3339))
+				fmt.Println()
+				printOverview(overview)
 			}
 
 			return nil
@@ -0,0 +1,235 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// tokenView is the JSON form of a token; the secret hash stays in the database
+type tokenView struct {
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// auditView is the JSON form of an audit entry
+type auditView struct {
+	ID        int64     `json:"id"`
+	TokenName string    `json:"token_name"`
+	Route     string    `json:"route"`
+	Target    string    `json:"target,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewCommand creates the token command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage API tokens for deespec serve",
+		Long: `Manage the API tokens that authenticate clients of 'deespec serve'.
+
+Once a token exists, every API request must carry one. Scopes:
+  read      List tasks, journal entries and locks; follow events
+  run-turn  Run turns
+  admin     Everything, including releasing locks`,
+		RunE: func(c *cobra.Command, _ []string) error { return c.Help() },
+	}
+
+	cmd.AddCommand(newCreateCommand())
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newRevokeCommand())
+	cmd.AddCommand(newAuditCommand())
+	return cmd
+}
+
+func newCreateCommand() *cobra.Command {
+	var scopes []string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an API token and print its secret",
+		Example: `  deespec token create dashboard --scope read
+  deespec token create ci --scope read,run-turn`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(func(container *di.Container) error {
+				var normalized []string
+				for _, scope := range scopes {
+					normalized = append(normalized, strings.ToLower(strings.TrimSpace(scope)))
+				}
+				secret, token, err := container.GetAPIAuthorizer().CreateToken(context.Background(), args[0], normalized)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("✓ Created API token %s (scopes: %s)\n", token.Name, strings.Join(token.Scopes, ", "))
+				fmt.Println()
+				fmt.Println(secret)
+				fmt.Println()
+				fmt.Println("Store the token now; it cannot be shown again.")
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&scopes, "scope", []string{repository.APIScopeRead}, "Scopes granted to the token (read, run-turn, admin)")
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(func(container *di.Container) error {
+				tokens, err := container.GetAPITokenRepository().List(context.Background())
+				if err != nil {
+					return err
+				}
+				if jsonOutput {
+					views := make([]tokenView, 0, len(tokens))
+					for _, token := range tokens {
+						views = append(views, tokenView{
+							Name:       token.Name,
+							Scopes:     token.Scopes,
+							CreatedAt:  token.CreatedAt,
+							LastUsedAt: token.LastUsedAt,
+							RevokedAt:  token.RevokedAt,
+						})
+					}
+					return printJSON(views)
+				}
+				if len(tokens) == 0 {
+					fmt.Println("No API tokens (deespec serve only listens on loopback)")
+					return nil
+				}
+				fmt.Printf("%-20s  %-22s  %-16s  %-16s  %s\n", "NAME", "SCOPES", "CREATED", "LAST USED", "STATE")
+				for _, token := range tokens {
+					state := "active"
+					if token.RevokedAt != nil {
+						state = "revoked " + formatTime(token.RevokedAt)
+					}
+					fmt.Printf("%-20s  %-22s  %-16s  %-16s  %s\n",
+						token.Name, strings.Join(token.Scopes, ","), formatTime(&token.CreatedAt), formatTime(token.LastUsedAt), state)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <name>",
+		Short: "Revoke an API token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(func(container *di.Container) error {
+				if err := container.GetAPITokenRepository().Revoke(context.Background(), args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("✓ Revoked API token %s\n", args[0])
+				return nil
+			})
+		},
+	}
+}
+
+func newAuditCommand() *cobra.Command {
+	var (
+		limit      int
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show which token triggered which state change",
+		Long: `Show the API audit log, newest first: turn runs and lock releases made
+through 'deespec serve' with the token that triggered them, and requests
+refused for a missing, invalid or insufficiently scoped token.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(func(container *di.Container) error {
+				entries, err := container.GetAPIAuditLogRepository().ListRecent(context.Background(), limit)
+				if err != nil {
+					return err
+				}
+				if jsonOutput {
+					views := make([]auditView, 0, len(entries))
+					for _, e := range entries {
+						views = append(views, auditView(*e))
+					}
+					return printJSON(views)
+				}
+				if len(entries) == 0 {
+					fmt.Println("No audit entries")
+					return nil
+				}
+				for _, e := range entries {
+					tokenName := e.TokenName
+					if tokenName == "" {
+						tokenName = "-"
+					}
+					line := fmt.Sprintf("%s  %-7s  %-16s  %s", e.CreatedAt.Local().Format("2006-01-02 15:04:05"), e.Outcome, tokenName, e.Route)
+					if e.Target != "" {
+						line += "  " + e.Target
+					}
+					if e.Detail != "" {
+						line += "  (" + e.Detail + ")"
+					}
+					fmt.Println(line)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of entries (0 = all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// withContainer runs fn with an initialized container
+func withContainer(fn func(container *di.Container) error) error {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+	return fn(container)
+}
+
+// formatTime renders an optional time in local time
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// printJSON writes v as indented JSON
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal json: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
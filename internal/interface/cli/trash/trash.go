@@ -0,0 +1,175 @@
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the trash command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List, restore and purge deleted PBIs and SBIs",
+		Long: `Deleted PBIs and SBIs are moved to the trash rather than removed.
+
+Their database rows are kept (hidden from every other command) and their spec
+and report directories are moved below .deespec/trash. A trashed item can be
+restored until it is purged; 'deespec trash purge' permanently removes the
+items deleted longer ago than trash.retention_days (default: 30) in setting.json.`,
+		Example: `  deespec trash list
+  deespec trash restore PBI-001
+  deespec trash purge`,
+	}
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newRestoreCommand())
+	cmd.AddCommand(newPurgeCommand())
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List trashed PBIs and SBIs, most recently deleted first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withTrashUseCase(func(uc *usecase.TrashUseCase) error {
+				items, err := uc.List(cmd.Context())
+				if err != nil {
+					return err
+				}
+				if jsonOutput {
+					return printJSON(items)
+				}
+				if len(items) == 0 {
+					fmt.Println("The trash is empty")
+					return nil
+				}
+				printItems(items)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <id>...",
+		Short: "Move trashed PBIs or SBIs back into the working set",
+		Long: `Restore trashed PBIs or SBIs: their database rows become visible again and
+their spec and report directories move back out of .deespec/trash.
+
+A restore never overwrites files created in the working tree since the deletion.`,
+		ValidArgsFunction: common.CompleteTrashedIDs,
+		Args:              cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withTrashUseCase(func(uc *usecase.TrashUseCase) error {
+				for _, id := range args {
+					item, err := uc.Restore(cmd.Context(), id)
+					if err != nil {
+						return fmt.Errorf("failed to restore %s: %w", id, err)
+					}
+					fmt.Printf("✓ %s %s restored (%s, deleted %s)\n",
+						strings.ToUpper(string(item.Kind)), item.ID, item.Title, item.DeletedAt.Local().Format("2006-01-02 15:04"))
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newPurgeCommand() *cobra.Command {
+	var (
+		all        bool
+		dryRun     bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge [id]...",
+		Short: "Permanently remove trashed PBIs and SBIs",
+		Long: `Permanently remove trashed items from the database and .deespec/trash.
+
+Without arguments, only the items past the retention window (trash.retention_days)
+are purged. Name items to purge them now, or use --all to empty the trash.`,
+		Example: `  # Purge items deleted longer ago than the retention window
+  deespec trash purge
+
+  # Purge one item now
+  deespec trash purge PBI-001
+
+  # Empty the trash
+  deespec trash purge --all`,
+		ValidArgsFunction: common.CompleteTrashedIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all && len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with item IDs")
+			}
+			opts := usecase.TrashPurgeOptions{IDs: args, All: all, DryRun: dryRun}
+			return withTrashUseCase(func(uc *usecase.TrashUseCase) error {
+				purged, err := uc.Purge(cmd.Context(), opts)
+				if jsonOutput {
+					if encErr := printJSON(purged); encErr != nil && err == nil {
+						err = encErr
+					}
+					return err
+				}
+				verb := "Purged"
+				if dryRun {
+					verb = "Would purge"
+				}
+				fmt.Printf("%s %d item(s)\n", verb, len(purged))
+				for _, item := range purged {
+					fmt.Printf("  %s  %-3s  %s\n", item.ID, strings.ToUpper(string(item.Kind)), item.Title)
+				}
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Purge every item in the trash")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be purged without changing anything")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result in JSON format")
+	return cmd
+}
+
+// withTrashUseCase builds the trash use case from the container and configured paths
+func withTrashUseCase(fn func(uc *usecase.TrashUseCase) error) error {
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	return fn(common.NewTrashUseCase(container.GetTrashRepository()))
+}
+
+// printItems prints trashed items in a table
+func printItems(items []*usecase.TrashedItem) {
+	fmt.Printf("%-26s  %-4s  %-11s  %-16s  %-16s  %s\n", "ID", "KIND", "STATUS", "DELETED", "PURGED AFTER", "TITLE")
+	for _, item := range items {
+		fmt.Printf("%-26s  %-4s  %-11s  %-16s  %-16s  %s\n",
+			item.ID, strings.ToUpper(string(item.Kind)), item.Status,
+			item.DeletedAt.Local().Format("2006-01-02 15:04"), item.ExpiresAt.Local().Format("2006-01-02 15:04"), item.Title)
+	}
+}
+
+// printJSON writes v as indented JSON
+func printJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal json: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
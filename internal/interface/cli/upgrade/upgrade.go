@@ -12,6 +12,7 @@ import (
 
 	"github.com/YoshitsuguKoike/deespec/internal/buildinfo"
 	"github.com/YoshitsuguKoike/deespec/internal/embed"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
 	"github.com/spf13/cobra"
 )
 
@@ -235,9 +236,15 @@ func upgradePromptsOnly() error {
 }
 
 // updatePromptTemplates copies prompt templates from embedded files to .deespec/prompts/
+// Templates are localized according to the "locale" setting in setting.json
 func updatePromptTemplates() error {
-	// Get all templates
-	templates, err := embed.GetTemplates()
+	locale := "ja"
+	if cfg := common.GetGlobalConfig(); cfg != nil && cfg.Locale() != "" {
+		locale = cfg.Locale()
+	}
+
+	// Get all templates in the configured locale
+	templates, err := embed.GetTemplatesForLocale(locale)
 	if err != nil {
 		return fmt.Errorf("failed to get templates: %w", err)
 	}
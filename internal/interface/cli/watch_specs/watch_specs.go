@@ -0,0 +1,173 @@
+package watch_specs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/YoshitsuguKoike/deespec/internal/application/usecase"
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/persistence"
+	"github.com/YoshitsuguKoike/deespec/internal/interface/cli/common"
+)
+
+// defaultInterval is how often the specs directory is scanned
+const defaultInterval = 2 * time.Second
+
+// NewCommand creates the watch-specs command
+func NewCommand() *cobra.Command {
+	var interval time.Duration
+	var once bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "watch-specs",
+		Short: "Register and update tasks from spec files dropped into .deespec/specs",
+		Long: `Watch .deespec/specs for new and edited markdown files and register or
+update tasks from them, so specs can be added through pull requests without
+running CLI commands.
+
+Drop-in specs:
+  .deespec/specs/sbi/<name>.md  Registered as a new SBI and moved to
+                                .deespec/specs/sbi/<id>/spec.md
+  .deespec/specs/pbi/<name>.md  Registered as a new PBI and moved to
+                                .deespec/specs/pbi/<id>/pbi.md
+
+  A spec needs an H1 title ("# Title"); an SBI spec also needs a body below
+  it. An SBI spec may end with a metadata section:
+
+    ---
+    Parent PBI: PBI-001
+    Labels: frontend, auth
+
+Edited specs:
+  Editing .deespec/specs/sbi/<id>/spec.md updates the SBI's title and
+  description, unless the SBI is finished. PBI bodies are always read from
+  pbi.md, so PBI edits take effect without syncing.
+
+Invalid specs are reported once and left in place until they are fixed.
+The directory is polled every --interval; stop watching with Ctrl+C.`,
+		Example: `  # Watch for spec changes
+  deespec watch-specs
+
+  # Sync once and exit (e.g. in CI after merging spec PRs)
+  deespec watch-specs --once
+
+  # Stream synced specs as JSON lines
+  deespec watch-specs --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive, got %s", interval)
+			}
+			return runWatchSpecs(cmd.Context(), interval, once, jsonOutput)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", defaultInterval, "How often to scan the specs directory")
+	cmd.Flags().BoolVar(&once, "once", false, "Sync once and exit")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print each synced spec as a JSON line")
+
+	return cmd
+}
+
+// runWatchSpecs syncs the specs directory every interval until SIGINT/SIGTERM, or once
+func runWatchSpecs(ctx context.Context, interval time.Duration, once, jsonOutput bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	container, err := common.InitializeContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Close()
+
+	rootPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	specsDir := filepath.Join(rootPath, ".deespec", "specs")
+	uc := usecase.NewSyncSpecsUseCase(
+		container.GetSBIRepository(),
+		persistence.NewPBISQLiteRepository(container.GetDB(), rootPath),
+		filepath.Join(specsDir, "sbi"),
+		filepath.Join(specsDir, "pbi"),
+	)
+
+	if !once && !jsonOutput {
+		fmt.Printf("👀 Watching %s every %s (Ctrl+C to stop)\n", relativePath(rootPath, specsDir), interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := uc.Sync(ctx)
+		if result != nil {
+			if err := printSyncedSpecs(rootPath, result, jsonOutput); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if once {
+			if !jsonOutput && len(result.Specs) == 0 {
+				fmt.Println("No new or edited specs")
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printSyncedSpecs prints one line per synced spec
+func printSyncedSpecs(rootPath string, result *usecase.SyncSpecsResult, jsonOutput bool) error {
+	for _, spec := range result.Specs {
+		spec.Path = relativePath(rootPath, spec.Path)
+		if jsonOutput {
+			if err := json.NewEncoder(os.Stdout).Encode(spec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch spec.Action {
+		case usecase.SpecActionRegistered:
+			fmt.Printf("✅ Registered %s %s: %s (%s)\n", kindLabel(spec.Kind), spec.TaskID, spec.Title, spec.Path)
+		case usecase.SpecActionUpdated:
+			fmt.Printf("📝 Updated %s %s: %s (%s)\n", kindLabel(spec.Kind), spec.TaskID, spec.Title, spec.Path)
+		case usecase.SpecActionInvalid:
+			fmt.Printf("❌ Invalid spec %s: %s\n", spec.Path, spec.Error)
+		}
+	}
+	return nil
+}
+
+// kindLabel returns the display name of a spec kind ("sbi" -> "SBI")
+func kindLabel(kind string) string {
+	if kind == "pbi" {
+		return "PBI"
+	}
+	return "SBI"
+}
+
+// relativePath shortens path to be relative to rootPath when possible
+func relativePath(rootPath, path string) string {
+	if rel, err := filepath.Rel(rootPath, path); err == nil {
+		return rel
+	}
+	return path
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/lock"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/model/sbi"
 	"github.com/YoshitsuguKoike/deespec/internal/domain/repository"
+	domainservice "github.com/YoshitsuguKoike/deespec/internal/domain/service"
 	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/di"
 )
 
@@ -24,11 +25,23 @@ type ExecuteTurnFunc func(ctx context.Context, container *di.Container, sbiID st
 // It implements the WorkflowRunner interface for parallel SBI processing
 type ParallelSBIWorkflowRunner struct {
 	enabled     bool
-	maxParallel int                // Maximum number of concurrent SBI executions
-	container   *di.Container      // Shared DI container
-	executeTurn ExecuteTurnFunc    // Function to execute a single SBI turn
-	agentPool   *service.AgentPool // Optional agent pool for per-agent concurrency control
-	mu          sync.RWMutex       // Protects enabled flag
+	maxParallel int                              // Maximum number of concurrent SBI executions
+	container   *di.Container                    // Shared DI container
+	executeTurn ExecuteTurnFunc                  // Function to execute a single SBI turn
+	agentPool   *service.AgentPool               // Optional agent pool for per-agent concurrency control
+	pbiWIPLimit int                              // Maximum concurrent SBIs per PBI (0 = unlimited)
+	turnBudget  *service.TurnBudget              // Optional per-PBI turn and daily agent call budgets
+	schedule    *domainservice.ExecutionSchedule // Optional windows in which turns may start
+	preflight   *service.SpecPreflight           // Optional spec quality check before the first turn
+	stepPool    *service.StepPool                // Optional separate implement and review pools
+	aging       *sbi.PriorityAging               // Optional aging of pending SBI priorities
+	mu          sync.RWMutex                     // Protects enabled flag
+
+	// With step pools, turns outlive the Run that started them
+	conflicts *service.ConflictDetector // File conflicts of the running turns
+	inFlight  map[string]bool           // IDs of the SBIs with a running turn
+	inFlightM sync.Mutex                // Protects inFlight
+	tasks     sync.WaitGroup            // Running turns
 }
 
 // truncateID safely truncates an ID string to the specified length
@@ -70,6 +83,45 @@ func NewParallelSBIWorkflowRunnerWithAgentPool(
 	return runner
 }
 
+// SetPBIWIPLimit limits how many SBIs of the same PBI run concurrently (0 = unlimited)
+func (r *ParallelSBIWorkflowRunner) SetPBIWIPLimit(limit int) {
+	r.pbiWIPLimit = limit
+}
+
+// SetTurnBudget holds back SBIs whose PBI turn budget or the daily agent call budget is exhausted
+func (r *ParallelSBIWorkflowRunner) SetTurnBudget(budget *service.TurnBudget) {
+	r.turnBudget = budget
+}
+
+// SetExecutionSchedule starts no turns outside the execution windows
+func (r *ParallelSBIWorkflowRunner) SetExecutionSchedule(schedule *domainservice.ExecutionSchedule) {
+	r.schedule = schedule
+}
+
+// SetSpecPreflight holds back pending SBIs whose spec has unresolved warnings in block mode
+func (r *ParallelSBIWorkflowRunner) SetSpecPreflight(preflight *service.SpecPreflight) {
+	r.preflight = preflight
+}
+
+// SetPriorityAging orders pending SBIs by their priority raised with the time they have waited
+func (r *ParallelSBIWorkflowRunner) SetPriorityAging(aging sbi.PriorityAging) {
+	r.aging = &aging
+}
+
+// SetStepPool runs implement and review turns in separate pools; Run then starts turns
+// in the free slots without waiting for the running ones, so reviews are not held up
+// behind long implementations
+func (r *ParallelSBIWorkflowRunner) SetStepPool(pool *service.StepPool) {
+	r.stepPool = pool
+	r.conflicts = service.NewConflictDetector()
+	r.inFlight = make(map[string]bool)
+}
+
+// Wait blocks until the turns started in the step pools have finished
+func (r *ParallelSBIWorkflowRunner) Wait() {
+	r.tasks.Wait()
+}
+
 // Name returns the workflow name
 func (r *ParallelSBIWorkflowRunner) Name() string {
 	return "sbi-parallel"
@@ -77,6 +129,10 @@ func (r *ParallelSBIWorkflowRunner) Name() string {
 
 // Description returns a human-readable description
 func (r *ParallelSBIWorkflowRunner) Description() string {
+	if r.stepPool != nil {
+		return fmt.Sprintf("Parallel SBI workflow (implement: %d, review: %d concurrent tasks)",
+			r.stepPool.Size(service.StepKindImplement), r.stepPool.Size(service.StepKindReview))
+	}
 	return fmt.Sprintf("Parallel SBI workflow (max: %d concurrent tasks)", r.maxParallel)
 }
 
@@ -103,9 +159,12 @@ func (r *ParallelSBIWorkflowRunner) Run(ctx context.Context, config workflow.Wor
 	default:
 	}
 
+	if r.stepPool != nil {
+		return r.runWithStepPool(ctx, config.AutoFB)
+	}
+
 	// Get services from container
 	sbiRepo := r.container.GetSBIRepository()
-	lockService := r.container.GetLockService()
 
 	// Extract AutoFB from config
 	autoFB := config.AutoFB
@@ -154,9 +213,9 @@ func (r *ParallelSBIWorkflowRunner) Run(ctx context.Context, config workflow.Wor
 		}
 
 		// Skip if file conflict detected
-		if conflictDetector.HasConflict(currentSBI) {
+		if conflict, ok := conflictDetector.Conflict(currentSBI); ok {
 			// Skip this SBI to avoid concurrent file modifications
-			log.Printf("⏭️  [Parallel] Skipped SBI %s (file conflict)", truncateID(currentSBI.ID().String(), 8))
+			log.Printf("⏭️  [Parallel] Skipped SBI %s (file conflict: %s)", truncateID(currentSBI.ID().String(), 8), conflict)
 			skippedCount++
 			continue
 		}
@@ -191,43 +250,8 @@ func (r *ParallelSBIWorkflowRunner) Run(ctx context.Context, config workflow.Wor
 				defer r.agentPool.Release(agentName)
 			}
 
-			// Acquire SBI-specific lock
-			lockID, err := lock.NewLockID(fmt.Sprintf("sbi-%s", s.ID()))
-			if err != nil {
-				errChan <- fmt.Errorf("SBI %s: failed to create lock ID: %w", s.ID(), err)
-				return
-			}
-
-			log.Printf("🚀 [Parallel #%d] Starting SBI %s - %s", taskNum, truncateID(s.ID().String(), 8), s.Title())
-
-			sbiLock, err := lockService.AcquireStateLock(ctx, lockID, lock.LockTypeWrite, 10*time.Minute)
-			if err != nil {
-				log.Printf("⚠️  [Parallel #%d] SBI %s failed to acquire lock: %v", taskNum, truncateID(s.ID().String(), 8), err)
-				errChan <- fmt.Errorf("SBI %s: failed to acquire lock: %w", s.ID(), err)
-				return
-			}
-
-			if sbiLock == nil {
-				// Another worker is processing this SBI, skip
-				log.Printf("⏭️  [Parallel #%d] SBI %s already locked by another worker", taskNum, truncateID(s.ID().String(), 8))
-				return
-			}
-
-			defer func() {
-				if err := lockService.ReleaseStateLock(ctx, lockID); err != nil {
-					log.Printf("⚠️  [Parallel #%d] SBI %s failed to release lock: %v", taskNum, truncateID(s.ID().String(), 8), err)
-				}
-			}()
-
-			// Execute turn for this SBI
-			startTime := time.Now()
-			if err := r.executeTurn(ctx, r.container, s.ID().String(), autoFB); err != nil {
-				duration := time.Since(startTime)
-				log.Printf("❌ [Parallel #%d] SBI %s failed after %v: %v", taskNum, truncateID(s.ID().String(), 8), duration, err)
-				errChan <- fmt.Errorf("SBI %s: %w", s.ID(), err)
-			} else {
-				duration := time.Since(startTime)
-				log.Printf("✅ [Parallel #%d] SBI %s completed in %v", taskNum, truncateID(s.ID().String(), 8), duration)
+			if err := r.runTask(ctx, s, taskNum, autoFB); err != nil {
+				errChan <- err
 			}
 		}(currentSBI, agent, startedCount)
 	}
@@ -257,6 +281,131 @@ func (r *ParallelSBIWorkflowRunner) Run(ctx context.Context, config workflow.Wor
 	return nil
 }
 
+// runTask runs one turn of an SBI under its SBI lock
+// Returns nil when another worker holds the lock
+func (r *ParallelSBIWorkflowRunner) runTask(ctx context.Context, s *sbi.SBI, taskNum int, autoFB bool) error {
+	lockService := r.container.GetLockService()
+
+	// Acquire SBI-specific lock
+	lockID, err := lock.NewLockID(fmt.Sprintf("sbi-%s", s.ID()))
+	if err != nil {
+		return fmt.Errorf("SBI %s: failed to create lock ID: %w", s.ID(), err)
+	}
+
+	log.Printf("🚀 [Parallel #%d] Starting SBI %s - %s", taskNum, truncateID(s.ID().String(), 8), s.Title())
+
+	sbiLock, err := lockService.AcquireStateLock(ctx, lockID, lock.LockTypeWrite, 10*time.Minute)
+	if err != nil {
+		log.Printf("⚠️  [Parallel #%d] SBI %s failed to acquire lock: %v", taskNum, truncateID(s.ID().String(), 8), err)
+		return fmt.Errorf("SBI %s: failed to acquire lock: %w", s.ID(), err)
+	}
+
+	if sbiLock == nil {
+		// Another worker is processing this SBI, skip
+		log.Printf("⏭️  [Parallel #%d] SBI %s already locked by another worker", taskNum, truncateID(s.ID().String(), 8))
+		return nil
+	}
+
+	// Writes of this turn are rejected once another runner takes over the lease
+	ctx = lock.WithFence(ctx, sbiLock.Fence())
+
+	defer func() {
+		if err := lockService.ReleaseStateLock(ctx, lockID); err != nil {
+			log.Printf("⚠️  [Parallel #%d] SBI %s failed to release lock: %v", taskNum, truncateID(s.ID().String(), 8), err)
+		}
+	}()
+
+	// Execute turn for this SBI
+	startTime := time.Now()
+	if err := r.executeTurn(ctx, r.container, s.ID().String(), autoFB); err != nil {
+		duration := time.Since(startTime)
+		log.Printf("❌ [Parallel #%d] SBI %s failed after %v: %v", taskNum, truncateID(s.ID().String(), 8), duration, err)
+		return fmt.Errorf("SBI %s: %w", s.ID(), err)
+	}
+	duration := time.Since(startTime)
+	log.Printf("✅ [Parallel #%d] SBI %s completed in %v", taskNum, truncateID(s.ID().String(), 8), duration)
+	return nil
+}
+
+// runWithStepPool starts SBIs in the free slots of their step's pool and returns without
+// waiting for them; the next Run fills the slots that have been freed in the meantime
+func (r *ParallelSBIWorkflowRunner) runWithStepPool(ctx context.Context, autoFB bool) error {
+	r.inFlightM.Lock()
+	running := len(r.inFlight)
+	r.inFlightM.Unlock()
+
+	// Running SBIs are fetched again (they are in progress); leave room for the free slots
+	sbis, err := r.fetchExecutableSBIsAlt(ctx, r.container.GetSBIRepository(), r.stepPool.Capacity()+running)
+	if err != nil {
+		return fmt.Errorf("failed to fetch executable SBIs: %w", err)
+	}
+
+	var startedCount, skippedCount int
+	for _, currentSBI := range sbis {
+		if ctx.Err() != nil {
+			break
+		}
+		id := currentSBI.ID().String()
+
+		r.inFlightM.Lock()
+		busy := r.inFlight[id]
+		r.inFlightM.Unlock()
+		if busy {
+			continue
+		}
+
+		if conflict, ok := r.conflicts.Conflict(currentSBI); ok {
+			log.Printf("⏭️  [Parallel] Skipped SBI %s (file conflict: %s)", truncateID(id, 8), conflict)
+			skippedCount++
+			continue
+		}
+
+		kind := service.StepKindOf(currentSBI.Status())
+		if !r.stepPool.TryAcquire(kind) {
+			skippedCount++
+			continue
+		}
+
+		agent := currentSBI.Metadata().AssignedAgent
+		if r.agentPool != nil && !r.agentPool.TryAcquire(agent) {
+			r.stepPool.Release(kind)
+			log.Printf("⏭️  [Parallel] Skipped SBI %s (agent %s busy)", truncateID(id, 8), agent)
+			skippedCount++
+			continue
+		}
+
+		r.conflicts.Register(currentSBI)
+		r.inFlightM.Lock()
+		r.inFlight[id] = true
+		r.inFlightM.Unlock()
+		r.tasks.Add(1)
+		startedCount++
+
+		log.Printf("🧵 [Parallel] SBI %s takes a %s slot", truncateID(id, 8), kind)
+		go func(s *sbi.SBI, kind service.StepKind, agentName string, taskNum int) {
+			defer r.tasks.Done()
+			defer r.stepPool.Release(kind)
+			defer r.conflicts.Unregister(s)
+			if r.agentPool != nil {
+				defer r.agentPool.Release(agentName)
+			}
+			defer func() {
+				r.inFlightM.Lock()
+				delete(r.inFlight, s.ID().String())
+				r.inFlightM.Unlock()
+			}()
+
+			// Failures are logged by runTask; the turn records them in the journal
+			_ = r.runTask(ctx, s, taskNum, autoFB)
+		}(currentSBI, kind, agent, startedCount)
+	}
+
+	if startedCount > 0 || skippedCount > 0 {
+		log.Printf("⏳ [Parallel] Started %d tasks (running: %d, waiting for a slot: %d)", startedCount, running+startedCount, skippedCount)
+	}
+	return nil
+}
+
 // Validate checks if the workflow can be executed
 func (r *ParallelSBIWorkflowRunner) Validate() error {
 	if r.maxParallel < 1 {
@@ -283,6 +432,9 @@ func (r *ParallelSBIWorkflowRunner) fetchExecutableSBIs(
 	var sbis []*sbi.SBI
 
 	sbiExecService := service.NewSBIExecutionService(sbiRepo, r.container.GetLockService())
+	if r.aging != nil {
+		sbiExecService.SetPriorityAging(*r.aging)
+	}
 
 	for i := 0; i < limit; i++ {
 		nextSBI, err := sbiExecService.PickNextSBI(ctx)
@@ -307,13 +459,25 @@ func (r *ParallelSBIWorkflowRunner) fetchExecutableSBIsAlt(
 	sbiRepo repository.SBIRepository,
 	limit int,
 ) ([]*sbi.SBI, error) {
-	// Get completed SBI IDs first to check dependencies
-	completedSet, err := r.getCompletedSBIIDs(ctx, sbiRepo)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get completed SBIs: %w", err)
+	// Nothing is started outside the execution windows
+	if now := time.Now(); !r.schedule.IsOpen(now) {
+		log.Printf("⏸️  [Parallel] Outside the execution windows (%s); next start at %s",
+			r.schedule, r.schedule.NextStart(now).Format("2006-01-02 15:04"))
+		return nil, nil
 	}
 
-	// Fetch all executable SBIs (more than limit to account for dependency filtering)
+	// Nothing is started once today's agent call budget is spent
+	if r.turnBudget != nil {
+		reason, err := r.turnBudget.CheckDaily(ctx)
+		if err != nil {
+			log.Printf("⚠️  [Parallel] Failed to check the daily agent call budget: %v", err)
+		} else if reason != "" {
+			log.Printf("⏸️  [Parallel] Daily agent call budget exhausted (%s)", reason)
+			return nil, nil
+		}
+	}
+
+	// Fetch all executable SBIs with their dependency state (more than limit to account for dependency filtering)
 	filter := repository.SBIFilter{
 		Statuses: []model.Status{
 			model.StatusPending,
@@ -323,82 +487,97 @@ func (r *ParallelSBIWorkflowRunner) fetchExecutableSBIsAlt(
 		},
 		Limit:  limit * 3, // Fetch more to account for dependency filtering
 		Offset: 0,
+		Aging:  r.aging,
+		AgedAt: time.Now(),
+	}
+	if r.pbiWIPLimit > 0 {
+		// Blocked PBIs may hold back many pending SBIs; scan all of them
+		filter.Limit = 0
 	}
 
-	allSBIs, err := sbiRepo.List(ctx, filter)
+	allSBIs, err := sbiRepo.ListWithExecutionState(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter SBIs by dependencies
+	// SBIs declaring overlapping file paths never run concurrently: a candidate sharing
+	// files with an SBI selected before it is deferred and its slot goes to the next one
+	candidates := make([]*sbi.SBI, 0, len(allSBIs))
+	for _, state := range allSBIs {
+		candidates = append(candidates, state.SBI)
+	}
+	conflicts := service.NewConflictMatrix(candidates)
+
+	// In-progress SBIs (PICKED, IMPLEMENTING, REVIEWING) are always included first
+	// They already passed dependency checks when they were picked and hold
+	// the WIP slots of their PBIs
+	wipLimiter := service.NewPBIWIPLimiter(r.pbiWIPLimit)
 	var result []*sbi.SBI
-	for _, candidate := range allSBIs {
-		// In-progress SBIs (PICKED, IMPLEMENTING, REVIEWING) are always included
-		// They already passed dependency checks when they were picked
-		if candidate.Status() != model.StatusPending {
-			result = append(result, candidate)
-			if len(result) >= limit {
-				break
-			}
+	for _, state := range allSBIs {
+		candidate := state.SBI
+		if candidate.Status() == model.StatusPending || len(result) >= limit {
 			continue
 		}
-
-		// For PENDING SBIs, check dependencies
-		if r.areDependenciesMet(ctx, candidate, completedSet, sbiRepo) {
-			result = append(result, candidate)
-			if len(result) >= limit {
-				break
-			}
+		wipLimiter.Register(candidate)
+		if !r.withinPBITurnBudget(ctx, candidate) {
+			continue
 		}
+		if conflict, ok := conflicts.ConflictWith(candidate.ID().String(), result); ok {
+			log.Printf("⏸️  [Parallel] SBI %s deferred (%s)", truncateID(candidate.ID().String(), 8), conflict)
+			continue
+		}
+		result = append(result, candidate)
 	}
 
-	return result, nil
-}
-
-// getCompletedSBIIDs returns a set of completed SBI IDs
-func (r *ParallelSBIWorkflowRunner) getCompletedSBIIDs(ctx context.Context, sbiRepo repository.SBIRepository) (map[string]bool, error) {
-	completedFilter := repository.SBIFilter{
-		Statuses: []model.Status{model.StatusDone},
-		Limit:    1000, // Get all completed SBIs
-	}
-
-	completedSBIs, err := sbiRepo.List(ctx, completedFilter)
-	if err != nil {
-		return nil, err
-	}
-
-	completedSet := make(map[string]bool)
-	for _, sbiItem := range completedSBIs {
-		completedSet[sbiItem.ID().String()] = true
+	// For PENDING SBIs, check dependencies and the per-PBI WIP limit
+	for _, state := range allSBIs {
+		candidate := state.SBI
+		if len(result) >= limit {
+			break
+		}
+		if candidate.Status() != model.StatusPending {
+			continue
+		}
+		if !state.DependenciesMet() {
+			// Dependency not completed - log for debugging
+			log.Printf("⏸️  [Parallel] SBI %s waiting on dependency: %s", truncateID(candidate.ID().String(), 8), truncateID(state.UnmetDependencies[0], 8))
+			continue
+		}
+		if !r.withinPBITurnBudget(ctx, candidate) {
+			continue
+		}
+		if r.preflight != nil && r.preflight.Holds(candidate) {
+			log.Printf("⏸️  [Parallel] SBI %s held back (%s)", truncateID(candidate.ID().String(), 8), service.NoOpReasonSpecQualityWarnings)
+			continue
+		}
+		if conflict, ok := conflicts.ConflictWith(candidate.ID().String(), result); ok {
+			log.Printf("⏸️  [Parallel] SBI %s deferred (%s)", truncateID(candidate.ID().String(), 8), conflict)
+			continue
+		}
+		if !wipLimiter.TryAcquire(candidate) {
+			log.Printf("⏸️  [Parallel] SBI %s waiting for a WIP slot of its PBI (limit: %d)", truncateID(candidate.ID().String(), 8), wipLimiter.Limit())
+			continue
+		}
+		result = append(result, candidate)
 	}
 
-	return completedSet, nil
+	return result, nil
 }
 
-// areDependenciesMet checks if all dependencies of an SBI are completed
-func (r *ParallelSBIWorkflowRunner) areDependenciesMet(ctx context.Context, candidate *sbi.SBI, completedSet map[string]bool, sbiRepo repository.SBIRepository) bool {
-	// Get dependencies from database
-	deps, err := sbiRepo.GetDependencies(ctx, repository.SBIID(candidate.ID().String()))
-	if err != nil {
-		// Log error but continue (assume no dependencies if we can't load them)
-		log.Printf("⚠️  [Parallel] Failed to load dependencies for SBI %s: %v", truncateID(candidate.ID().String(), 8), err)
+// withinPBITurnBudget checks if the SBI's parent PBI has turns left in its budget
+// A failed check does not hold the SBI back; the turn re-checks the budget before running
+func (r *ParallelSBIWorkflowRunner) withinPBITurnBudget(ctx context.Context, candidate *sbi.SBI) bool {
+	if r.turnBudget == nil {
 		return true
 	}
-
-	// No dependencies - ready to execute
-	if len(deps) == 0 {
+	reason, err := r.turnBudget.CheckPBI(ctx, candidate)
+	if err != nil {
+		log.Printf("⚠️  [Parallel] Failed to check the turn budget of SBI %s: %v", truncateID(candidate.ID().String(), 8), err)
 		return true
 	}
-
-	// Check if all dependencies are in completed set
-	for _, depID := range deps {
-		if !completedSet[depID] {
-			// Dependency not completed - log for debugging
-			log.Printf("⏸️  [Parallel] SBI %s waiting on dependency: %s", truncateID(candidate.ID().String(), 8), truncateID(depID, 8))
-			return false
-		}
+	if reason != "" {
+		log.Printf("⏸️  [Parallel] SBI %s held back (%s)", truncateID(candidate.ID().String(), 8), reason)
+		return false
 	}
-
-	// All dependencies met
 	return true
 }
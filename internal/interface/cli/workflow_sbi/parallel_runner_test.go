@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -588,6 +589,42 @@ func TestParallelSBIWorkflowRunner_FileConflictDetection(t *testing.T) {
 	t.Logf("Execution order: %v", executionOrder)
 }
 
+func TestParallelSBIWorkflowRunner_DefersFileConflictsAtPick(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	container := createTestContainer(t)
+	defer container.Close()
+
+	ctx := context.Background()
+	sbiRepo := container.GetSBIRepository()
+
+	// SBI-001 and SBI-002 overlap through a directory; SBI-003 touches other files
+	require.NoError(t, sbiRepo.Save(ctx, createTestSBIWithFiles("SBI-001", []string{"internal/auth"})))
+	require.NoError(t, sbiRepo.Save(ctx, createTestSBIWithFiles("SBI-002", []string{"./internal/auth/login.go"})))
+	require.NoError(t, sbiRepo.Save(ctx, createTestSBIWithFiles("SBI-003", []string{"docs/README.md"})))
+
+	var mu sync.Mutex
+	var executed []string
+	executeTurn := func(ctx context.Context, container *di.Container, sbiID string, autoFB bool) error {
+		mu.Lock()
+		executed = append(executed, sbiID)
+		mu.Unlock()
+		return nil
+	}
+
+	// With 2 slots, the conflicting SBI is deferred at pick time so that SBI-003 fills its slot
+	runner := NewParallelSBIWorkflowRunner(container, 2, executeTurn)
+	err := runner.Run(ctx, workflow.WorkflowConfig{Name: "sbi", Enabled: true, Interval: time.Second})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, executed, 2)
+	assert.Contains(t, executed, "SBI-003")
+	assert.False(t, slices.Contains(executed, "SBI-001") && slices.Contains(executed, "SBI-002"),
+		"SBIs sharing files should not run in the same batch")
+}
+
 // createTestSBIWithFiles creates a test SBI with specific file paths
 func createTestSBIWithFiles(id string, filePaths []string) *sbi.SBI {
 	title := fmt.Sprintf("Test SBI %s", id)
@@ -745,3 +782,61 @@ func TestParallelSBIWorkflowRunner_AgentPoolControl(t *testing.T) {
 	t.Logf("Executed SBIs: %v", executedSBIs)
 	t.Logf("Agent counts: %v", agentCounts)
 }
+
+func TestParallelSBIWorkflowRunner_StepPool(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	container := createTestContainer(t)
+	defer container.Close()
+
+	ctx := context.Background()
+	sbiRepo := container.GetSBIRepository()
+	require.NoError(t, sbiRepo.Save(ctx, createTestSBI("SBI-001", model.StatusImplementing)))
+	require.NoError(t, sbiRepo.Save(ctx, createTestSBI("SBI-002", model.StatusPending)))
+	require.NoError(t, sbiRepo.Save(ctx, createTestSBI("SBI-003", model.StatusReviewing)))
+
+	// Implementations block until released; reviews finish immediately
+	release := make(chan struct{})
+	reviewed := make(chan string, 2)
+	implementing := make(chan string, 2)
+	var mu sync.Mutex
+	started := map[string]int{}
+	executeTurn := func(ctx context.Context, container *di.Container, sbiID string, autoFB bool) error {
+		mu.Lock()
+		started[sbiID]++
+		mu.Unlock()
+		if sbiID == "SBI-003" {
+			reviewed <- sbiID
+			return nil
+		}
+		implementing <- sbiID
+		<-release
+		return nil
+	}
+
+	runner := NewParallelSBIWorkflowRunner(container, 3, executeTurn)
+	runner.SetStepPool(service.NewStepPool(1, 1, 3))
+	assert.Contains(t, runner.Description(), "implement: 1, review: 1")
+
+	config := workflow.WorkflowConfig{Name: "sbi", Enabled: true, Interval: time.Second}
+	require.NoError(t, runner.Run(ctx, config), "Run does not wait for the running turns")
+
+	select {
+	case id := <-reviewed:
+		assert.Equal(t, "SBI-003", id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the review was starved behind the implementation")
+	}
+
+	assert.Equal(t, "SBI-001", <-implementing, "in-progress SBIs take the implement slot first")
+
+	// The implement pool is still full, and the running SBI is not started again
+	require.NoError(t, runner.Run(ctx, config))
+	mu.Lock()
+	assert.Equal(t, 1, started["SBI-001"])
+	assert.Zero(t, started["SBI-002"])
+	mu.Unlock()
+
+	close(release)
+	runner.Wait()
+}
@@ -2,6 +2,7 @@ package claudecli
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,11 +11,16 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infrastructure/process"
 )
 
 type Runner struct {
 	Bin     string
 	Timeout time.Duration
+
+	// Registry tracks the running agent process groups for the reaper (nil = untracked)
+	Registry *process.Registry
 }
 
 // ClaudeResponse represents the JSON response from claude
@@ -31,8 +37,11 @@ type ClaudeResponse struct {
 
 // RunOptions contains options for Claude Code execution
 type RunOptions struct {
-	AllowedTools    []string // Tools to allow (e.g., "Read", "Edit", "Bash")
-	DisallowedTools []string // Tools to disallow
+	AllowedTools    []string        // Tools to allow (e.g., "Read", "Edit", "Bash")
+	DisallowedTools []string        // Tools to disallow
+	Env             []string        // Extra environment variables (KEY=VALUE) added to the inherited environment
+	ResumeSessionID string          // Continue this conversation instead of starting a new one (--resume)
+	OnSessionID     func(id string) // Optional: receives the session ID of the conversation
 }
 
 // StreamEvent represents a single streaming event from Claude
@@ -53,6 +62,48 @@ type StreamContext struct {
 	StepName  string                                   // implement, review, etc.
 	Turn      int                                      // Turn number
 	LogWriter func(format string, args ...interface{}) // Optional logger
+	OnContent func(content string)                     // Optional: receives content chunks as they arrive
+}
+
+// applyEnv adds the extra environment variables of opts to cmd
+func applyEnv(cmd *exec.Cmd, opts *RunOptions) {
+	if opts == nil || len(opts.Env) == 0 {
+		return
+	}
+	cmd.Env = append(os.Environ(), opts.Env...)
+}
+
+// appendOptionArgs adds the CLI flags for tool permissions and session continuation
+func appendOptionArgs(args []string, opts *RunOptions) []string {
+	if opts == nil {
+		return args
+	}
+	if len(opts.AllowedTools) > 0 {
+		args = append(args, "--allowed-tools", strings.Join(opts.AllowedTools, ","))
+	}
+	if len(opts.DisallowedTools) > 0 {
+		args = append(args, "--disallowed-tools", strings.Join(opts.DisallowedTools, ","))
+	}
+	if opts.ResumeSessionID != "" {
+		args = append(args, "--resume", opts.ResumeSessionID)
+	}
+	return args
+}
+
+// reportSessionID passes the conversation's session ID to opts.OnSessionID
+func reportSessionID(opts *RunOptions, sessionID string) {
+	if opts != nil && opts.OnSessionID != nil && sessionID != "" {
+		opts.OnSessionID(sessionID)
+	}
+}
+
+// Version returns the output of `claude --version` (e.g. "1.0.98 (Claude Code)")
+func (r Runner) Version(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, r.Bin, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get claude version: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
 }
 
 func (r Runner) Run(ctx context.Context, prompt string, extraArgs ...string) (string, error) {
@@ -64,15 +115,8 @@ func (r Runner) RunWithOptions(ctx context.Context, prompt string, opts *RunOpti
 	// 権限確認をスキップして動作確認を優先
 	args := []string{"-p", "--dangerously-skip-permissions", "--output-format", "json"}
 
-	// Add tool permissions if specified
-	if opts != nil {
-		if len(opts.AllowedTools) > 0 {
-			args = append(args, "--allowed-tools", strings.Join(opts.AllowedTools, ","))
-		}
-		if len(opts.DisallowedTools) > 0 {
-			args = append(args, "--disallowed-tools", strings.Join(opts.DisallowedTools, ","))
-		}
-	}
+	// Add tool permissions and session continuation if specified
+	args = appendOptionArgs(args, opts)
 
 	args = append(args, extraArgs...) // 将来拡張用
 	args = append(args, prompt)
@@ -80,8 +124,20 @@ func (r Runner) RunWithOptions(ctx context.Context, prompt string, opts *RunOpti
 	cctx, cancel := context.WithTimeout(ctx, r.Timeout)
 	defer cancel()
 
+	// Run the agent in its own process group so that a timeout kills its children too
 	cmd := exec.CommandContext(cctx, r.Bin, args...)
-	out, err := cmd.CombinedOutput()
+	applyEnv(cmd, opts)
+	process.ConfigureGroup(cmd)
+	var combined bytes.Buffer
+	cmd.Stdout = &combined
+	cmd.Stderr = &combined
+	err := cmd.Start()
+	if err == nil {
+		untrack := r.Registry.Track(cmd, r.Timeout)
+		err = cmd.Wait()
+		untrack()
+	}
+	out := combined.Bytes()
 
 	// コマンド実行エラーの場合
 	if err != nil {
@@ -101,6 +157,7 @@ func (r Runner) RunWithOptions(ctx context.Context, prompt string, opts *RunOpti
 	}
 
 	// 正常レスポンスの場合、resultフィールドのみを返す
+	reportSessionID(opts, response.SessionID)
 	return response.Result, nil
 }
 
@@ -132,15 +189,8 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 		streamCtx.LogWriter("[debug] Running command: %s %v", r.Bin, args)
 	}
 
-	// Add tool permissions if specified
-	if opts != nil {
-		if len(opts.AllowedTools) > 0 {
-			args = append(args, "--allowed-tools", strings.Join(opts.AllowedTools, ","))
-		}
-		if len(opts.DisallowedTools) > 0 {
-			args = append(args, "--disallowed-tools", strings.Join(opts.DisallowedTools, ","))
-		}
-	}
+	// Add tool permissions and session continuation if specified
+	args = appendOptionArgs(args, opts)
 
 	args = append(args, extraArgs...)
 	args = append(args, prompt)
@@ -149,6 +199,8 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 	defer cancel()
 
 	cmd := exec.CommandContext(cctx, r.Bin, args...)
+	applyEnv(cmd, opts)
+	process.ConfigureGroup(cmd)
 
 	// Set up pipes for stdout and stderr
 	stdout, err := cmd.StdoutPipe()
@@ -165,6 +217,8 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 	if err := cmd.Start(); err != nil {
 		return "", fmt.Errorf("failed to start claude: %w", err)
 	}
+	untrack := r.Registry.Track(cmd, r.Timeout)
+	defer untrack()
 
 	// Process stderr (errors/warnings) in background
 	go func() {
@@ -183,6 +237,7 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 
 	// Process stdout (streaming JSON events)
 	var finalResult string
+	var sessionID string               // Reported by the init and result events
 	var contentBuilder strings.Builder // Accumulate content chunks
 	scanner := bufio.NewScanner(stdout)
 	// Increase buffer size for large JSON lines (10MB)
@@ -206,6 +261,10 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 			continue
 		}
 
+		if id, ok := event["session_id"].(string); ok && id != "" {
+			sessionID = id
+		}
+
 		// Create StreamEvent with timestamp
 		streamEvent := StreamEvent{
 			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
@@ -221,6 +280,9 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 					streamEvent.Content = content
 					// Accumulate content for final result
 					contentBuilder.WriteString(content)
+					if streamCtx.OnContent != nil {
+						streamCtx.OnContent(content)
+					}
 					if streamCtx.LogWriter != nil {
 						if len(content) > 100 {
 							streamCtx.LogWriter("[stream:content] %d chars received", len(content))
@@ -233,6 +295,9 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 				if text, ok := event["text"].(string); ok {
 					streamEvent.Content = text
 					contentBuilder.WriteString(text)
+					if streamCtx.OnContent != nil {
+						streamCtx.OnContent(text)
+					}
 					if streamCtx.LogWriter != nil {
 						streamCtx.LogWriter("[stream:text] %s", text)
 					}
@@ -323,6 +388,7 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 			if streamCtx.LogWriter != nil {
 				streamCtx.LogWriter("[warning] Command exited with error but got result: %v", err)
 			}
+			reportSessionID(opts, sessionID)
 			return finalResult, nil
 		}
 		return "", fmt.Errorf("claude execution failed: %w", err)
@@ -359,5 +425,6 @@ func (r Runner) RunWithStream(ctx context.Context, prompt string, streamCtx *Str
 		streamCtx.LogWriter("[stream:complete] History saved to: %s", historyFile)
 	}
 
+	reportSessionID(opts, sessionID)
 	return finalResult, nil
 }
@@ -0,0 +1,254 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// OpKind identifies the kind of a line-level edit
+type OpKind int
+
+const (
+	OpEqual  OpKind = iota // Line present in both texts
+	OpDelete               // Line only present in the old text
+	OpInsert               // Line only present in the new text
+)
+
+// Line is a single line of a diff with its edit kind
+type Line struct {
+	Kind OpKind
+	Text string
+}
+
+// Lines computes a line-level diff between old and new using LCS
+// Reports are typically a few hundred lines, so the O(n*m) table is acceptable
+func Lines(oldText, newText string) []Line {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// lcs[i][j] = length of LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Kind: OpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Kind: OpDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Kind: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Kind: OpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Kind: OpInsert, Text: b[j]})
+	}
+
+	return lines
+}
+
+// HasChanges reports whether a diff contains any insert or delete
+func HasChanges(lines []Line) bool {
+	for _, l := range lines {
+		if l.Kind != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// Unified renders a unified diff with the given number of context lines
+// Returns an empty string when the texts are identical
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	lines := Lines(oldText, newText)
+	if !HasChanges(lines) {
+		return ""
+	}
+	if context < 0 {
+		context = 0
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n", oldName)
+	fmt.Fprintf(&sb, "+++ %s\n", newName)
+
+	// Track line numbers in old/new text for each diff line
+	oldNo := make([]int, len(lines))
+	newNo := make([]int, len(lines))
+	o, n := 1, 1
+	for idx, l := range lines {
+		oldNo[idx], newNo[idx] = o, n
+		switch l.Kind {
+		case OpEqual:
+			o++
+			n++
+		case OpDelete:
+			o++
+		case OpInsert:
+			n++
+		}
+	}
+
+	// Group changes into hunks with surrounding context
+	idx := 0
+	for idx < len(lines) {
+		if lines[idx].Kind == OpEqual {
+			idx++
+			continue
+		}
+
+		start := idx - context
+		if start < 0 {
+			start = 0
+		}
+		end := idx
+		for end < len(lines) {
+			if lines[end].Kind != OpEqual {
+				end++
+				continue
+			}
+			// Look ahead: merge hunks separated by <= 2*context equal lines
+			run := end
+			for run < len(lines) && lines[run].Kind == OpEqual {
+				run++
+			}
+			if run < len(lines) && run-end <= 2*context {
+				end = run
+				continue
+			}
+			end += context
+			if end > len(lines) {
+				end = len(lines)
+			}
+			break
+		}
+
+		oldCount, newCount := 0, 0
+		for _, l := range lines[start:end] {
+			if l.Kind != OpInsert {
+				oldCount++
+			}
+			if l.Kind != OpDelete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldNo[start], oldCount, newNo[start], newCount)
+		for _, l := range lines[start:end] {
+			sb.WriteString(prefix(l.Kind))
+			sb.WriteString(l.Text)
+			sb.WriteString("\n")
+		}
+
+		idx = end
+	}
+
+	return sb.String()
+}
+
+// SideBySide renders the diff as two columns of the given width each
+// Changed lines are marked with '|', deletions with '<' and insertions with '>'
+func SideBySide(oldText, newText string, width int) string {
+	if width < 10 {
+		width = 10
+	}
+	lines := Lines(oldText, newText)
+
+	var sb strings.Builder
+	for idx := 0; idx < len(lines); idx++ {
+		l := lines[idx]
+		switch l.Kind {
+		case OpEqual:
+			writeRow(&sb, l.Text, " ", l.Text, width)
+		case OpDelete:
+			// Pair a run of deletions with the following run of insertions
+			var dels, ins []string
+			for idx < len(lines) && lines[idx].Kind == OpDelete {
+				dels = append(dels, lines[idx].Text)
+				idx++
+			}
+			for idx < len(lines) && lines[idx].Kind == OpInsert {
+				ins = append(ins, lines[idx].Text)
+				idx++
+			}
+			idx--
+			for k := 0; k < len(dels) || k < len(ins); k++ {
+				switch {
+				case k < len(dels) && k < len(ins):
+					writeRow(&sb, dels[k], "|", ins[k], width)
+				case k < len(dels):
+					writeRow(&sb, dels[k], "<", "", width)
+				default:
+					writeRow(&sb, "", ">", ins[k], width)
+				}
+			}
+		case OpInsert:
+			writeRow(&sb, "", ">", l.Text, width)
+		}
+	}
+
+	return sb.String()
+}
+
+func writeRow(sb *strings.Builder, left, marker, right string, width int) {
+	row := pad(truncate(left, width), width) + " " + marker + " " + truncate(right, width)
+	sb.WriteString(strings.TrimRight(row, " "))
+	sb.WriteString("\n")
+}
+
+func prefix(kind OpKind) string {
+	switch kind {
+	case OpDelete:
+		return "-"
+	case OpInsert:
+		return "+"
+	default:
+		return " "
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	return strings.Split(s, "\n")
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func pad(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-n)
+}
@@ -0,0 +1,86 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	lines := Lines("a\nb\nc\n", "a\nx\nc\n")
+
+	want := []Line{
+		{OpEqual, "a"},
+		{OpDelete, "b"},
+		{OpInsert, "x"},
+		{OpEqual, "c"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Lines() = %v, want %v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Lines()[%d] = %v, want %v", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestUnified_Identical(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same\n", 3); got != "" {
+		t.Errorf("Unified() = %q, want empty", got)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	oldText := "line1\nline2\nline3\nline4\n"
+	newText := "line1\nline2 changed\nline3\nline4\nline5\n"
+
+	got := Unified("implement_2.md", "implement_4.md", oldText, newText, 1)
+
+	wantParts := []string{
+		"--- implement_2.md\n",
+		"+++ implement_4.md\n",
+		"@@ -1,4 +1,5 @@\n",
+		"-line2\n",
+		"+line2 changed\n",
+		"+line5\n",
+	}
+	for _, part := range wantParts {
+		if !strings.Contains(got, part) {
+			t.Errorf("Unified() missing %q in:\n%s", part, got)
+		}
+	}
+}
+
+func TestUnified_SeparateHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 20; i++ {
+		oldLines = append(oldLines, "same")
+		newLines = append(newLines, "same")
+	}
+	oldLines[1], newLines[1] = "old-a", "new-a"
+	oldLines[18], newLines[18] = "old-b", "new-b"
+
+	got := Unified("old", "new", strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"), 2)
+
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("expected 2 hunks, got %d:\n%s", n, got)
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	got := SideBySide("keep\nold\ngone\n", "keep\nnew\n", 10)
+	rows := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	if len(rows) != 3 {
+		t.Fatalf("SideBySide() rows = %d, want 3:\n%s", len(rows), got)
+	}
+	if !strings.Contains(rows[0], "  keep") {
+		t.Errorf("row 0 = %q", rows[0])
+	}
+	if !strings.Contains(rows[1], "| new") {
+		t.Errorf("row 1 = %q", rows[1])
+	}
+	if !strings.HasSuffix(rows[2], "<") {
+		t.Errorf("row 2 = %q", rows[2])
+	}
+}
@@ -2,9 +2,9 @@ package util
 
 import (
 	"bytes"
-	"fmt"
 	"os"
-	"path/filepath"
+
+	"github.com/YoshitsuguKoike/deespec/internal/infra/fs"
 )
 
 // WriteFileAtomic writes data to a file atomically using temp file + rename
@@ -12,30 +12,13 @@ func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
 	// Normalize line endings: CRLF -> LF
 	data = NormalizeCRLFToLF(data)
 
-	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
 	// Add newline if missing (for proper POSIX text file)
 	if len(data) > 0 && data[len(data)-1] != '\n' {
 		data = append(data, '\n')
 	}
 
-	// Write to temp file first
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, perm); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, path); err != nil {
-		os.Remove(tmpPath) // Clean up on failure
-		return fmt.Errorf("failed to rename temp file: %w", err)
-	}
-
-	return nil
+	// Temp file + fsync + rename, so that a crash never leaves a truncated file
+	return fs.WriteFileAtomic(path, data, perm)
 }
 
 // NormalizeCRLFToLF converts CRLF line endings to LF